@@ -0,0 +1,134 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/whento/pkg/database"
+
+	"github.com/whento/whento/internal/backup"
+	"github.com/whento/whento/internal/migrate"
+)
+
+// runExport implements "whento export": writes the whole instance to a portable archive
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := fs.String("o", "whento-export-"+time.Now().Format("20060102-150405")+".zip", "Archive file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour)
+	defer cancel()
+
+	pool, migrator, err := openMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close(pool)
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("%w at version %d, fix it before exporting", migrate.ErrDirty, status.Version)
+	}
+	if status.Version == migrate.NilVersion {
+		return errors.New("the database has no schema, nothing to export")
+	}
+
+	file, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	manifest, err := backup.Export(ctx, pool, file, buildType, status.Version)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(*output)
+		return err
+	}
+
+	fmt.Printf("✓ Instance exported to %s (%s build, schema version %d)\n", *output, manifest.BuildType, manifest.SchemaVersion)
+	printTables(manifest)
+	return nil
+}
+
+// runImport implements "whento import": loads an archive into a fresh install
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: whento import ARCHIVE")
+		fmt.Fprintln(fs.Output(), "\nLoads an archive created by 'whento export' into an empty database.")
+		fmt.Fprintln(fs.Output(), "Missing migrations are applied first.")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("missing archive file")
+	}
+
+	archive, err := zip.OpenReader(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer archive.Close()
+
+	manifest, err := backup.ReadManifest(&archive.Reader)
+	if err != nil {
+		return err
+	}
+	if manifest.BuildType != buildType {
+		fmt.Fprintf(os.Stderr, "Warning: archive was created by a %s build, this is a %s build\n", manifest.BuildType, buildType)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour)
+	defer cancel()
+
+	pool, migrator, err := openMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close(pool)
+
+	err = migrator.Run(ctx, migrate.DirectionUp, 0, func(step migrate.Step) {
+		fmt.Printf("→ Applying migration %03d_%s\n", step.Migration.Version, step.Migration.Name)
+	})
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := backup.Import(ctx, pool, &archive.Reader, status.Version); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Instance imported from %s (exported %s)\n", fs.Arg(0), manifest.CreatedAt.Format(time.RFC3339))
+	printTables(manifest)
+	fmt.Println("\nUsers need to sign in again: sessions are not exported.")
+	return nil
+}
+
+func printTables(manifest *backup.Manifest) {
+	for _, table := range manifest.Tables {
+		fmt.Printf("  %-24s %d\n", table.Name, table.Rows)
+	}
+}
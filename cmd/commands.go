@@ -0,0 +1,78 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command is a subcommand of the whento binary, run instead of the server
+type command struct {
+	Usage string
+	Run   func(args []string) error
+}
+
+// commands lists the available subcommands (whento <name> [flags])
+var commands = map[string]command{
+	"export":          {Usage: "Export the whole instance to a portable archive", Run: runExport},
+	"healthcheck":     {Usage: "Probe the local server health endpoint (for container health checks)", Run: runHealthcheck},
+	"import":          {Usage: "Import an archive created by export into a fresh install", Run: runImport},
+	"keys":            {Usage: "Generate or rotate the JWT signing key pair", Run: runKeys},
+	"maintenance":     {Usage: "Turn the maintenance mode on or off (503 for everyone but admins)", Run: runMaintenance},
+	"migrate":         {Usage: "Show, apply or roll back database migrations", Run: runMigrate},
+	"retention":       {Usage: "Report, or purge, data older than the retention periods", Run: runRetention},
+	"seed":            {Usage: "Generate demo calendars, availabilities and recurrences", Run: runSeed},
+	"support-bundle":  {Usage: "Collect version, redacted config and anonymized stats into a zip for bug reports", Run: runSupportBundle},
+	"validate-config": {Usage: "Check configuration and connectivity, then exit (also --validate-config)", Run: runValidateConfig},
+}
+
+// runCommand runs a subcommand and returns the process exit code
+func runCommand(name string, args []string) int {
+	if name == "help" || name == "-h" || name == "--help" {
+		printCommands()
+		return 0
+	}
+	if name == "--validate-config" {
+		name = "validate-config"
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+		printCommands()
+		return 2
+	}
+
+	if err := cmd.Run(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// printCommands prints the available subcommands
+func printCommands() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage: whento [command] [flags]")
+	fmt.Fprintln(os.Stderr, "\nWithout a command, the server is started.")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, commands[name].Usage)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun 'whento [command] -h' for command flags.")
+}
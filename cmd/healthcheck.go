@@ -0,0 +1,52 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/whento/whento/internal/config"
+)
+
+// runHealthcheck implements "whento healthcheck": probes the local server for container health checks
+// Exits with 0 when /api/health answers 2xx within the timeout, 1 otherwise
+func runHealthcheck(args []string) error {
+	// The port may be set in the configuration file
+	port := config.Load().Port
+
+	fs := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	url := fs.String("url", "http://127.0.0.1:"+port+"/api/health", "Health endpoint to probe")
+	timeout := fs.Duration("timeout", 3*time.Second, "Request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid health URL: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unhealthy: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unhealthy: %s returned %s", *url, resp.Status)
+	}
+
+	fmt.Println("healthy")
+	return nil
+}
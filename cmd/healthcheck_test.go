@@ -0,0 +1,55 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunHealthcheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/health":
+			w.Write([]byte(`{"status":"ok"}`))
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"healthy", []string{"--url", server.URL + "/api/health"}, ""},
+		{"unavailable", []string{"--url", server.URL + "/unavailable"}, "returned 503 Service Unavailable"},
+		{"not found", []string{"--url", server.URL + "/missing"}, "returned 404 Not Found"},
+		{"timeout", []string{"--url", server.URL + "/slow", "--timeout", "50ms"}, "unhealthy"},
+		{"connection refused", []string{"--url", closed.URL + "/api/health"}, "unhealthy"},
+		{"invalid URL", []string{"--url", "://nowhere"}, "invalid health URL"},
+		{"unknown flag", []string{"--verbose"}, "flag provided but not defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runHealthcheck(tt.args)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("runHealthcheck(%q) error = %v, want %q", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -9,17 +9,24 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/whento/pkg/jwt"
+	"github.com/whento/pkg/license"
 	"github.com/whento/pkg/logger"
 	"github.com/whento/pkg/middleware"
 	"github.com/whento/whento/internal/config"
 	"github.com/whento/whento/internal/quota"
 
+	// Auth module (single sign-on)
+	authHandlers "github.com/whento/whento/internal/auth/handlers"
+	authService "github.com/whento/whento/internal/auth/service"
+
 	// Subscription module (Cloud only)
 	subscriptionHandlers "github.com/whento/whento/internal/subscription/handlers"
 	subscriptionRepo "github.com/whento/whento/internal/subscription/repository"
@@ -304,6 +311,19 @@ func RegisterBillingRoutes(r chi.Router, services *Services, cfg *config.Config,
 	}
 }
 
+// InitSAML returns no SAML provider: SAML single sign-on is a feature of self-hosted Enterprise licenses
+func InitSAML(cfg *config.Config, services *Services, ssoSvc *authService.SSOService, jwtManager *jwt.Manager, log *slog.Logger) authHandlers.SAMLProvider {
+	if cfg.SAML.Enabled() {
+		log.Warn("SAML single sign-on is only available in self-hosted builds, ignoring SAML_IDP_METADATA")
+	}
+	return nil
+}
+
+// RegisterSAMLRoutes is a no-op in cloud mode (SAML is self-hosted only)
+func RegisterSAMLRoutes(r chi.Router, provider authHandlers.SAMLProvider, cfg *config.Config, rateLimiter *middleware.RateLimiter) {
+	// No-op: SAML routes only exist in self-hosted mode
+}
+
 // StartVATRefreshTask starts a background task that refreshes VAT rates daily (Cloud only)
 func StartVATRefreshTask(ctx context.Context, services *Services) {
 	log := logger.Default()
@@ -365,3 +385,29 @@ func StartVATRefreshTask(ctx context.Context, services *Services) {
 		}
 	}()
 }
+
+// buildConfigChecks validates the Stripe and license signing configuration for "whento --validate-config"
+func buildConfigChecks(cfg *config.Config) []checkResult {
+	var results []checkResult
+
+	switch {
+	case cfg.Stripe.SecretKey == "":
+		results = append(results, checkWarned("Stripe", "STRIPE_SECRET_KEY not set (subscriptions disabled)"))
+	case !strings.HasPrefix(cfg.Stripe.SecretKey, "sk_") && !strings.HasPrefix(cfg.Stripe.SecretKey, "rk_"):
+		results = append(results, checkFailed("Stripe", "STRIPE_SECRET_KEY is not a Stripe secret key"))
+	case strings.HasPrefix(cfg.Stripe.SecretKey, "sk_test_") || strings.HasPrefix(cfg.Stripe.SecretKey, "rk_test_"):
+		results = append(results, checkWarned("Stripe", "test mode key"))
+	default:
+		results = append(results, checkPassed("Stripe", "live mode key"))
+	}
+
+	if cfg.Shop.LicensePrivateKeyBase64 == "" {
+		results = append(results, checkWarned("License signing key", "LICENSE_PRIVATE_KEY_BASE64 not set (license sales disabled)"))
+	} else if _, err := license.DecodePrivateKey(cfg.Shop.LicensePrivateKeyBase64); err != nil {
+		results = append(results, checkFailed("License signing key", err.Error()))
+	} else {
+		results = append(results, checkPassed("License signing key", "valid Ed25519 key"))
+	}
+
+	return results
+}
@@ -8,8 +8,13 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/whento/pkg/jwt"
@@ -18,11 +23,19 @@ import (
 	"github.com/whento/whento/internal/config"
 	"github.com/whento/whento/internal/quota"
 
+	// Auth module (single sign-on)
+	authHandlers "github.com/whento/whento/internal/auth/handlers"
+	authService "github.com/whento/whento/internal/auth/service"
+
 	// Licensing module (Self-hosted only)
 	licensingHandlers "github.com/whento/whento/internal/licensing/handlers"
 	licensingRepo "github.com/whento/whento/internal/licensing/repository"
 	licensingService "github.com/whento/whento/internal/licensing/service"
 
+	// SAML module (Self-hosted only, Enterprise licenses)
+	samlHandlers "github.com/whento/whento/internal/saml/handlers"
+	samlService "github.com/whento/whento/internal/saml/service"
+
 	// Calendar repo for quota checks
 	calendarRepo "github.com/whento/whento/internal/calendar/repository"
 )
@@ -37,7 +50,9 @@ func InitServices(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool) (
 	licRepo := licensingRepo.New(pool)
 
 	// Initialize licensing service with Ed25519 public key
-	licService, err := licensingService.New(licRepo, licensingService.Config{}, log)
+	licService, err := licensingService.New(licRepo, licensingService.Config{
+		RevocationListPath: cfg.License.RevocationList,
+	}, log)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +94,15 @@ func InitServices(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool) (
 
 	log.Info("Quota service initialized (Self-hosted mode - server-wide limits)")
 
+	// Custom branding is a feature of Pro and Enterprise licenses
+	// Applied at startup: restart after activating a license to use the configured branding
+	if cfg.Branding != config.DefaultBranding {
+		if ok, _ := quotaService.HasCapability(ctx, uuid.Nil, quota.CapabilityCustomBranding); !ok {
+			log.Warn("Custom branding requires a Pro or Enterprise license, using the default branding", "tier", activeLicense.Tier)
+			cfg.Branding = config.DefaultBranding
+		}
+	}
+
 	return &Services{
 		QuotaService:     quotaService,
 		LicensingService: licService,
@@ -133,7 +157,74 @@ func RegisterBillingRoutes(r chi.Router, services *Services, cfg *config.Config,
 	log.Info("Self-hosted licensing routes registered successfully")
 }
 
+// InitSAML initializes SAML single sign-on when an identity provider is configured
+// The routes only answer while the license includes SAML (Enterprise tier)
+func InitSAML(cfg *config.Config, services *Services, ssoSvc *authService.SSOService, jwtManager *jwt.Manager, log *slog.Logger) authHandlers.SAMLProvider {
+	if !cfg.SAML.Enabled() {
+		return nil
+	}
+
+	svc := samlService.New(ssoSvc, services.QuotaService, jwtManager, cfg, log)
+	if !svc.Available(context.Background()) {
+		log.Warn("SAML single sign-on requires an Enterprise license, the SAML login is hidden until one is activated")
+	} else {
+		log.Info("SAML single sign-on initialized", "provider", cfg.SAML.ProviderName)
+	}
+	return svc
+}
+
+// RegisterSAMLRoutes registers the SAML service provider routes under /api/v1/auth
+func RegisterSAMLRoutes(r chi.Router, provider authHandlers.SAMLProvider, cfg *config.Config, rateLimiter *middleware.RateLimiter) {
+	svc, ok := provider.(*samlService.Service)
+	if !ok || svc == nil {
+		return
+	}
+
+	handler := samlHandlers.New(svc, cfg.AppURL, logger.Default())
+
+	r.Route("/saml", func(r chi.Router) {
+		r.Get("/metadata", handler.HandleMetadata)
+
+		if cfg.RateLimitEnabled {
+			// SAML sign-in: 10 requests/minute/IP
+			r.Group(func(r chi.Router) {
+				r.Use(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests: 10,
+					Window:   time.Minute,
+					KeyFunc:  middleware.IPKeyFunc,
+				}))
+				r.Get("/login", handler.HandleLogin)
+				r.Post("/acs", handler.HandleACS)
+			})
+		} else {
+			r.Get("/login", handler.HandleLogin)
+			r.Post("/acs", handler.HandleACS)
+		}
+	})
+}
+
 // StartVATRefreshTask is a no-op in self-hosted mode (VAT management is cloud-only)
 func StartVATRefreshTask(ctx context.Context, services *Services) {
 	// No-op: VAT refresh only runs in cloud mode
 }
+
+// buildConfigChecks validates the license configuration for "whento --validate-config"
+func buildConfigChecks(cfg *config.Config) []checkResult {
+	// The service is only used to verify keys, it never touches the database here
+	licService, err := licensingService.New(nil, licensingService.Config{
+		RevocationListPath: cfg.License.RevocationList,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		return []checkResult{checkFailed("License", err.Error())}
+	}
+
+	if cfg.License.Key == "" {
+		return []checkResult{checkPassed("License", "no LICENSE_KEY (Community tier, or license activated from the UI)")}
+	}
+
+	payload, err := licService.ValidateLicenseKey(cfg.License.Key)
+	if err != nil {
+		return []checkResult{checkFailed("License", "LICENSE_KEY: "+err.Error())}
+	}
+	return []checkResult{checkPassed("License", fmt.Sprintf("%s tier, issued to %s", payload.Tier, payload.IssuedTo))}
+}
@@ -0,0 +1,132 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/whento/pkg/jwt"
+
+	"github.com/whento/whento/internal/config"
+	pushService "github.com/whento/whento/internal/push/service"
+)
+
+const keysUsage = `Usage: whento keys <generate|rotate|vapid> [flags]
+
+  generate            Generate the JWT key pair at JWT_PRIVATE_KEY_PATH and JWT_PUBLIC_KEY_PATH
+  rotate              Generate a new key pair and keep the current public key at
+                      JWT_PREVIOUS_PUBLIC_KEY_PATH, so issued tokens stay valid
+  vapid               Print a new VAPID key pair for Web Push notifications
+
+Flags:`
+
+// runKeys implements "whento keys": generates and rotates the RS256 key pair used to sign JWTs,
+// and generates the VAPID key pair of Web Push
+func runKeys(args []string) error {
+	fs := flag.NewFlagSet("keys", flag.ContinueOnError)
+	bits := fs.Int("bits", 4096, "RSA key size")
+	force := fs.Bool("force", false, "With generate: overwrite existing keys")
+	keepPrevious := fs.Bool("keep-previous", true, "With rotate: keep accepting tokens signed with the current key")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), keysUsage)
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return errors.New("missing keys action")
+	}
+
+	// VAPID keys are printed for the environment, they don't depend on the configuration
+	if positional[0] == "vapid" {
+		return keysVAPID()
+	}
+
+	cfg := config.Load()
+
+	switch positional[0] {
+	case "generate":
+		return keysGenerate(cfg, *bits, *force)
+	case "rotate":
+		return keysRotate(cfg, *bits, *keepPrevious)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown keys action %q", positional[0])
+	}
+}
+
+func keysVAPID() error {
+	publicKey, privateKey, err := pushService.GenerateVAPIDKeys()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("WEBPUSH_VAPID_PUBLIC_KEY=%s\nWEBPUSH_VAPID_PRIVATE_KEY=%s\n", publicKey, privateKey)
+	fmt.Println("\nKeep the private key secret. Changing the keys invalidates existing browser subscriptions.")
+	return nil
+}
+
+func keysGenerate(cfg *config.Config, bits int, force bool) error {
+	if !force {
+		for _, path := range []string{cfg.JWTPrivateKeyPath, cfg.JWTPublicKeyPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists (use -force to overwrite, or 'whento keys rotate')", path)
+			}
+		}
+	}
+
+	if err := writeNewKeyPair(cfg, bits); err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated RSA %d key pair\n  private key: %s (0600)\n  public key:  %s (0644)\n", bits, cfg.JWTPrivateKeyPath, cfg.JWTPublicKeyPath)
+	if force {
+		fmt.Println("\nTokens signed with the old key are no longer valid: all users must sign in again.")
+	}
+	return nil
+}
+
+func keysRotate(cfg *config.Config, bits int, keepPrevious bool) error {
+	if _, err := os.Stat(cfg.JWTPrivateKeyPath); err != nil {
+		return fmt.Errorf("no current key to rotate (run 'whento keys generate' first): %w", err)
+	}
+
+	if keepPrevious {
+		if err := jwt.CopyPublicKey(cfg.JWTPublicKeyPath, cfg.JWTPreviousPublicKeyPath); err != nil {
+			return fmt.Errorf("failed to keep the current public key: %w", err)
+		}
+	} else if err := os.Remove(cfg.JWTPreviousPublicKeyPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove the previous public key: %w", err)
+	}
+
+	if err := writeNewKeyPair(cfg, bits); err != nil {
+		return err
+	}
+
+	fmt.Printf("Rotated JWT keys (RSA %d)\n  private key: %s\n  public key:  %s\n", bits, cfg.JWTPrivateKeyPath, cfg.JWTPublicKeyPath)
+	fmt.Println("\nRestart WhenTo to sign new tokens with the new key.")
+	if keepPrevious {
+		fmt.Printf("Tokens signed with the old key are still accepted through %s.\n", cfg.JWTPreviousPublicKeyPath)
+		fmt.Printf("Delete it after %s (JWT_REFRESH_EXPIRY) and restart to complete the rotation.\n", cfg.JWTRefreshExpiry)
+	} else {
+		fmt.Println("Tokens signed with the old key are no longer valid: all users must sign in again.")
+	}
+	return nil
+}
+
+func writeNewKeyPair(cfg *config.Config, bits int) error {
+	key, err := jwt.GenerateKeyPair(bits)
+	if err != nil {
+		return err
+	}
+	return jwt.WriteKeyPair(cfg.JWTPrivateKeyPath, cfg.JWTPublicKeyPath, key)
+}
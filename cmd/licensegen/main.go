@@ -5,9 +5,12 @@
 package main
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
@@ -30,13 +33,15 @@ It uses Ed25519 signatures to ensure license authenticity.
 
 Usage:
   1. Generate a key pair: licensegen keygen
-  2. Generate a license: licensegen generate --tier pro --to "Company Name"`,
+  2. Generate a license: licensegen generate --tier pro --to "Company Name"
+  3. Revoke a license:   licensegen revoke --license license.json --reason refunded`,
 		Version: Version,
 	}
 
 	rootCmd.AddCommand(keygenCmd())
 	rootCmd.AddCommand(generateCmd())
 	rootCmd.AddCommand(renewSupportCmd())
+	rootCmd.AddCommand(revokeCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -147,6 +152,43 @@ Cost: 60€/year for support renewal (all tiers)`,
 	return cmd
 }
 
+func revokeCmd() *cobra.Command {
+	var (
+		privateKeyPath string
+		licenseFile    string
+		reason         string
+		crlFile        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Add a license to the signed revocation list",
+		Long: `Add a license to the signed revocation list.
+
+The revocation list is a JSON file signed with the same private key as licenses.
+It is created if it doesn't exist; otherwise its signature is checked, the license
+is appended and the list is signed again with an incremented version.
+
+Publish the updated file to instances (LICENSE_REVOCATION_LIST): a revoked license
+is refused on activation and dropped back to the Community tier at startup.
+
+Reasons: refunded, chargeback, compromised, superseded, other`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return revokeLicense(privateKeyPath, licenseFile, reason, crlFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&privateKeyPath, "key", "k", "license_private.key", "Path to private key file")
+	cmd.Flags().StringVarP(&licenseFile, "license", "l", "", "Path to the license JSON file to revoke")
+	cmd.Flags().StringVarP(&reason, "reason", "r", "", "Revocation reason")
+	cmd.Flags().StringVarP(&crlFile, "crl", "c", "revocations.json", "Revocation list file (created if missing)")
+
+	cmd.MarkFlagRequired("license")
+	cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
 func generateKeyPair(outputDir string) error {
 	// Create output directory if it doesn't exist
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -349,3 +391,77 @@ func renewSupport(privateKeyPath, licenseFile string, supportYears int, outputFi
 
 	return nil
 }
+
+func revokeLicense(privateKeyPath, licenseFile, reason, crlFile string) error {
+	// Read license to revoke
+	licenseJSON, err := os.ReadFile(licenseFile)
+	if err != nil {
+		return fmt.Errorf("failed to read license file: %w", err)
+	}
+
+	var lic license.License
+	if err := json.Unmarshal(licenseJSON, &lic); err != nil {
+		return fmt.Errorf("failed to parse license JSON: %w", err)
+	}
+
+	// Read and decode private key
+	privateKeyB64, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	privateKey, err := license.DecodePrivateKey(string(privateKeyB64))
+	if err != nil {
+		return err
+	}
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	// Only licenses signed with this key can be revoked
+	if err := license.Validate(&lic, publicKey); err != nil {
+		return fmt.Errorf("license was not signed with this key: %w", err)
+	}
+
+	// Load existing revocation list, verifying it wasn't modified since it was signed
+	var list license.RevocationList
+	crlJSON, err := os.ReadFile(crlFile)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(crlJSON, &list); err != nil {
+			return fmt.Errorf("failed to parse revocation list: %w", err)
+		}
+		if err := license.ValidateRevocationList(&list, publicKey); err != nil {
+			return fmt.Errorf("existing revocation list is not valid: %w", err)
+		}
+	case errors.Is(err, os.ErrNotExist):
+		// New revocation list
+	default:
+		return fmt.Errorf("failed to read revocation list: %w", err)
+	}
+
+	entry, err := list.Revoke(&lic, reason, privateKey)
+	if err != nil {
+		return err
+	}
+
+	updatedJSON, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal revocation list: %w", err)
+	}
+	if err := os.WriteFile(crlFile, updatedJSON, 0644); err != nil {
+		return fmt.Errorf("failed to write revocation list: %w", err)
+	}
+
+	fmt.Printf("✓ License revoked successfully!\n")
+	fmt.Printf("Revocation list: %s (version %d, %d revoked)\n\n", crlFile, list.Version, len(list.Entries))
+
+	fmt.Printf("Revocation Summary:\n")
+	fmt.Printf("  Issued To:    %s\n", entry.IssuedTo)
+	fmt.Printf("  Support Key:  %s\n", entry.SupportKey)
+	fmt.Printf("  Reason:       %s\n", entry.Reason)
+	fmt.Printf("  Fingerprint:  %s\n", entry.Fingerprint)
+
+	fmt.Printf("\nDistribute the updated revocation list to instances:\n")
+	fmt.Printf("   LICENSE_REVOCATION_LIST=/path/to/%s\n", filepath.Base(crlFile))
+
+	return nil
+}
@@ -43,6 +43,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -52,6 +53,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
+	"google.golang.org/grpc"
 
 	"github.com/whento/pkg/cache"
 	"github.com/whento/pkg/database"
@@ -59,7 +61,10 @@ import (
 	"github.com/whento/pkg/jwt"
 	"github.com/whento/pkg/logger"
 	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/mqtt"
+	"github.com/whento/pkg/tracing"
 	"github.com/whento/whento/internal/config"
+	configHandlers "github.com/whento/whento/internal/config/handlers"
 
 	// Auth module
 	authHandlers "github.com/whento/whento/internal/auth/handlers"
@@ -94,11 +99,95 @@ import (
 	// SEO module
 	"github.com/whento/whento/internal/seo"
 
+	// Branding module
+	"github.com/whento/whento/internal/branding"
+
 	// Notification module
 	notifyHandlers "github.com/whento/whento/internal/notify/handlers"
 	notifyRepo "github.com/whento/whento/internal/notify/repository"
 	notifyService "github.com/whento/whento/internal/notify/service"
 
+	// REST hooks module (Zapier, Make)
+	hooksHandlers "github.com/whento/whento/internal/hooks/handlers"
+	hooksRepo "github.com/whento/whento/internal/hooks/repository"
+	hooksService "github.com/whento/whento/internal/hooks/service"
+
+	// Outbound webhooks module (signed calendar events)
+	webhooksHandlers "github.com/whento/whento/internal/webhooks/handlers"
+	webhooksRepo "github.com/whento/whento/internal/webhooks/repository"
+	webhooksService "github.com/whento/whento/internal/webhooks/service"
+
+	// CalDAV module (organizer busy time)
+	caldavHandlers "github.com/whento/whento/internal/caldav/handlers"
+	caldavRepo "github.com/whento/whento/internal/caldav/repository"
+	caldavService "github.com/whento/whento/internal/caldav/service"
+
+	// Integration module (Nextcloud app: app passwords, login flow)
+	integrationHandlers "github.com/whento/whento/internal/integration/handlers"
+	integrationRepo "github.com/whento/whento/internal/integration/repository"
+	integrationService "github.com/whento/whento/internal/integration/service"
+
+	// Directory module (Google Workspace, Microsoft 365 participant import)
+	directoryHandlers "github.com/whento/whento/internal/directory/handlers"
+	directoryRepo "github.com/whento/whento/internal/directory/repository"
+	directoryService "github.com/whento/whento/internal/directory/service"
+
+	// Outlook module (confirmed events pushed to Microsoft 365 calendars)
+	outlookHandlers "github.com/whento/whento/internal/outlook/handlers"
+	outlookRepo "github.com/whento/whento/internal/outlook/repository"
+	outlookService "github.com/whento/whento/internal/outlook/service"
+
+	// Push module (Web Push notifications to browsers)
+	pushHandlers "github.com/whento/whento/internal/push/handlers"
+	pushRepo "github.com/whento/whento/internal/push/repository"
+	pushService "github.com/whento/whento/internal/push/service"
+
+	// Organization module (calendars owned by clubs and companies)
+	orgHandlers "github.com/whento/whento/internal/organization/handlers"
+	orgRepo "github.com/whento/whento/internal/organization/repository"
+	orgService "github.com/whento/whento/internal/organization/service"
+
+	// Search module (calendars, participants and notes)
+	searchHandlers "github.com/whento/whento/internal/search/handlers"
+	searchRepo "github.com/whento/whento/internal/search/repository"
+	searchService "github.com/whento/whento/internal/search/service"
+
+	// Stats module (instance statistics for administrators)
+	statsHandlers "github.com/whento/whento/internal/stats/handlers"
+	statsRepo "github.com/whento/whento/internal/stats/repository"
+	statsService "github.com/whento/whento/internal/stats/service"
+
+	// GraphQL module (read-only API over calendars and availabilities)
+	graphqlHandlers "github.com/whento/whento/internal/graphql/handlers"
+	graphqlService "github.com/whento/whento/internal/graphql/service"
+
+	// gRPC API (server-to-server integrations)
+	"github.com/whento/whento/internal/grpcapi"
+
+	// Quota (plan and license feature gating)
+	"github.com/whento/whento/internal/quota"
+
+	// Retention janitor (purges expired data)
+	"github.com/whento/whento/internal/retention"
+
+	// Maintenance mode (503 for everyone but admins)
+	maintenanceHandlers "github.com/whento/whento/internal/maintenance/handlers"
+	maintenanceRepo "github.com/whento/whento/internal/maintenance/repository"
+	maintenanceService "github.com/whento/whento/internal/maintenance/service"
+
+	// Admin announcements (banners and emails)
+	announcementHandlers "github.com/whento/whento/internal/announcement/handlers"
+	announcementRepo "github.com/whento/whento/internal/announcement/repository"
+	announcementService "github.com/whento/whento/internal/announcement/service"
+
+	// Mail queue (retries and undelivered emails)
+	mailQueueHandlers "github.com/whento/whento/internal/mailqueue/handlers"
+	mailQueueRepo "github.com/whento/whento/internal/mailqueue/repository"
+	mailQueueService "github.com/whento/whento/internal/mailqueue/service"
+
+	// Home Assistant module (MQTT sensors)
+	homeAssistantService "github.com/whento/whento/internal/homeassistant/service"
+
 	// Frontend embedding
 	"github.com/whento/whento/web"
 
@@ -107,6 +196,11 @@ import (
 )
 
 func main() {
+	// Subcommands (e.g. "whento seed") run instead of the server
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+
 	// Load configuration
 	cfg := config.Load()
 
@@ -116,10 +210,34 @@ func main() {
 
 	log.Info("Starting WhenTo Application", "port", cfg.Port, "env", cfg.AppEnv)
 
+	// Fail fast on invalid settings rather than at runtime
+	if problems := cfg.Problems(); len(problems) > 0 {
+		for _, problem := range problems {
+			log.Error("Invalid configuration", "error", problem)
+		}
+		log.Error("Fix the configuration (see 'whento --validate-config') and restart")
+		os.Exit(1)
+	}
+
 	// Context for initialization
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// OpenTelemetry tracing (no-op unless an OTLP endpoint is configured)
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Enabled:        cfg.Tracing.Enabled,
+		Protocol:       cfg.Tracing.Protocol,
+		ServiceName:    cfg.Tracing.ServiceName,
+		ServiceVersion: Version,
+	})
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	if cfg.Tracing.Enabled {
+		log.Info("OpenTelemetry tracing enabled", "protocol", cfg.Tracing.Protocol, "service", cfg.Tracing.ServiceName)
+	}
+
 	// Connect to PostgreSQL (shared by all modules)
 	dbConfig := &database.Config{
 		URL: cfg.DatabaseURL,
@@ -132,6 +250,11 @@ func main() {
 	defer database.Close(pool)
 	log.Info("Connected to PostgreSQL")
 
+	if err := migrateOnStart(ctx, pool, cfg.MigrateOnStart, log); err != nil {
+		log.Error("Database schema is not up to date", "error", err)
+		os.Exit(1)
+	}
+
 	// Connect to Redis (shared by all modules) - Optional
 	redisConfig := &database.RedisConfig{
 		URL: cfg.RedisURL,
@@ -147,11 +270,12 @@ func main() {
 
 	// Initialize JWT manager
 	jwtConfig := &jwt.Config{
-		PrivateKeyPath: cfg.JWTPrivateKeyPath,
-		PublicKeyPath:  cfg.JWTPublicKeyPath,
-		AccessExpiry:   cfg.JWTAccessExpiry,
-		RefreshExpiry:  cfg.JWTRefreshExpiry,
-		Issuer:         cfg.JWTIssuer,
+		PrivateKeyPath:        cfg.JWTPrivateKeyPath,
+		PublicKeyPath:         cfg.JWTPublicKeyPath,
+		PreviousPublicKeyPath: cfg.JWTPreviousPublicKeyPath,
+		AccessExpiry:          cfg.JWTAccessExpiry,
+		RefreshExpiry:         cfg.JWTRefreshExpiry,
+		Issuer:                cfg.JWTIssuer,
 	}
 	jwtManager, err := jwt.NewManager(jwtConfig)
 	if err != nil {
@@ -169,21 +293,20 @@ func main() {
 	}
 
 	// Initialize email service
-	emailService := email.NewService(email.Config{
-		Host:        cfg.Email.SMTPHost,
-		Port:        cfg.Email.SMTPPort,
-		Username:    cfg.Email.SMTPUsername,
-		Password:    cfg.Email.SMTPPassword,
-		FromAddress: cfg.Email.FromAddress,
-		FromName:    cfg.Email.FromName,
-	}, log)
+	emailService := email.NewService(cfg.Email.SMTP(), log)
 	if emailService.IsConfigured() {
-		log.Info("Email service configured", "smtp_host", cfg.Email.SMTPHost)
+		log.Info("Email service configured", "provider", cfg.Email.Provider, "smtp_host", cfg.Email.SMTPHost)
 		log.Info("Email verification", "enable", cfg.Email.VerificationEnabled)
 	} else {
 		log.Info("Email service not configured (email features disabled)")
 	}
 
+	// Emails are queued in the database and sent by a worker, with retries
+	mailQueueSvc := mailQueueService.NewMailQueueService(mailQueueRepo.NewMailRepository(pool), emailService, log)
+	emailService.SetQueue(mailQueueSvc)
+	mailQueueSvc.StartTask(context.Background())
+	mailQueueHandler := mailQueueHandlers.NewMailQueueHandler(mailQueueSvc, log)
+
 	// ========== LICENSING/SUBSCRIPTION MODULE ==========
 	// Initialize build-specific services (Cloud: Stripe subscriptions, Self-hosted: License management)
 	services, err := InitServices(ctx, cfg, pool)
@@ -210,6 +333,18 @@ func main() {
 	// Initialize magic link service
 	magicLinkSvc := authService.NewMagicLinkService(userRepo, tokenRepo, emailService, jwtManager, cfg, log)
 
+	// Initialize single sign-on services (OIDC, and SAML on self-hosted Enterprise licenses)
+	identityRepo := authRepo.NewIdentityRepository(pool)
+	ssoSvc := authService.NewSSOService(authSvc, userRepo, identityRepo, log)
+	oidcSvc := authService.NewOIDCService(ssoSvc, jwtManager, cfg)
+	samlProvider := InitSAML(cfg, services, ssoSvc, jwtManager, log)
+
+	// Initialize personal access tokens (scoped Bearer tokens accepted by the REST API alongside JWTs)
+	personalTokenSvc := authService.NewPersonalTokenService(authRepo.NewPersonalTokenRepository(pool), log)
+
+	// Initialize personal data exports (GDPR archives built in the background)
+	dataExportSvc := authService.NewDataExportService(authRepo.NewDataExportRepository(pool), userRepo, emailService, cfg, log)
+
 	// ========== PASSKEY MODULE ==========
 	// Initialize passkey repository
 	passkeyRepository := passkeyRepo.NewPasskeyRepository(pool)
@@ -222,39 +357,74 @@ func main() {
 	}
 	log.Info("Passkey service initialized")
 
-	// Initialize passkey handler (with auth service for completing login)
-	passkeyHandler := passkeyHandlers.NewPasskeyHandler(passkeySvc, authSvc, log)
-
 	// ========== AUTH HANDLERS (requires passkey and MFA repositories) ==========
 	// Initialize auth handlers (with MFA and passkey repos for status checking)
-	authHandler := authHandlers.NewAuthHandler(authSvc, userRepo, emailService, cfg, log, mfaRepository, passkeyRepository)
+	authHandler := authHandlers.NewAuthHandler(authSvc, userRepo, emailService, cfg, log, mfaRepository, passkeyRepository, services.QuotaService)
 	emailVerificationHandler := authHandlers.NewEmailVerificationHandler(authSvc, userRepo, emailService, cfg, log)
 	passwordResetHandler := authHandlers.NewPasswordResetHandler(passwordResetSvc)
 	magicLinkHandler := authHandlers.NewMagicLinkHandler(magicLinkSvc, emailService, log)
+	ssoHandler := authHandlers.NewSSOHandler(ssoSvc, oidcSvc, samlProvider, cfg.DisableLocalLogin, log)
+	oidcHandler := authHandlers.NewOIDCHandler(oidcSvc, log)
 	authHealthHandler := authHandlers.NewHealthHandler()
+	personalTokenHandler := authHandlers.NewPersonalTokenHandler(personalTokenSvc, log)
+	dataExportHandler := authHandlers.NewDataExportHandler(dataExportSvc, log)
 
 	// ========== MFA MODULE ==========
 	// Initialize MFA service (repository already created for auth service)
-	mfaSvc := mfaService.NewMFAService(mfaRepository, userRepo, cfg, log)
+	mfaSvc := mfaService.NewMFAService(mfaRepository, userRepo, emailService, cfg, log)
 	log.Info("MFA service initialized")
 
-	// Initialize MFA handler (with auth service for completing login)
-	mfaHandler := mfaHandlers.NewMFAHandler(mfaSvc, authSvc, jwtManager, log)
+	// Initialize MFA handler (with auth service for completing login and passkey service for security keys)
+	mfaHandler := mfaHandlers.NewMFAHandler(mfaSvc, authSvc, passkeySvc, jwtManager, log)
+
+	// Initialize passkey handler (with auth service for completing login and MFA service for security keys)
+	passkeyHandler := passkeyHandlers.NewPasskeyHandler(passkeySvc, authSvc, mfaSvc, log)
 
 	// Initialize admin MFA handler for admin operations (disable 2FA)
 	adminMFAHandler := authHandlers.NewAdminMFAHandler(mfaSvc, log)
 
+	// ========== ORGANIZATION MODULE ==========
+	organizationRepository := orgRepo.NewOrganizationRepository(pool)
+	organizationSvc := orgService.NewOrganizationService(organizationRepository, userRepo)
+	organizationHandler := orgHandlers.NewOrganizationHandler(organizationSvc, log)
+
+	// ========== SEARCH MODULE ==========
+	searchSvc := searchService.NewSearchService(searchRepo.NewSearchRepository(pool))
+	searchHandler := searchHandlers.NewSearchHandler(searchSvc, log)
+
+	statsSvc := statsService.NewStatsService(statsRepo.NewStatsRepository(pool), cfg.WeekStart)
+	statsHandler := statsHandlers.NewStatsHandler(statsSvc, log)
+
 	// ========== CALENDAR MODULE ==========
 	// Initialize calendar repositories
 	calendarRepository := calendarRepo.NewCalendarRepository(pool)
 	participantRepository := calendarRepo.NewParticipantRepository(pool)
+	calendarChangeRepository := calendarRepo.NewChangeRepository(pool)
+
+	// Outbound webhooks of calendars, fed by the calendar, availability and notification services
+	webhookSvc := webhooksService.NewWebhookService(webhooksRepo.NewWebhookRepository(pool), calendarRepository, cfg, log)
+	webhookHandler := webhooksHandlers.NewWebhookHandler(webhookSvc, log)
+	webhookSvc.StartTask(context.Background())
 
-	// Initialize calendar service with cache and user repo (for owner participant email)
-	calendarSvc := calendarService.NewCalendarService(calendarRepository, participantRepository, userRepo, cacheInstance)
+	// Initialize calendar service with cache, user repo (for owner participant email) and organization roles
+	calendarSvc := calendarService.NewCalendarService(calendarRepository, participantRepository, userRepo, organizationSvc, calendarChangeRepository, calendarRepo.NewExportRepository(pool), webhookSvc, cacheInstance, cfg)
 
 	// Initialize calendar handlers (with quota service for limit checking)
 	calendarHandler := calendarHandlers.NewCalendarHandler(calendarSvc, services.QuotaService, userRepo, cfg)
 	participantHandler := calendarHandlers.NewParticipantHandler(calendarSvc)
+	calendarTokenHandler := calendarHandlers.NewAPITokenHandler(
+		calendarService.NewAPITokenService(calendarSvc, calendarRepo.NewAPITokenRepository(pool), log),
+		log,
+	)
+	confirmationRepository := calendarRepo.NewConfirmationRepository(pool)
+	confirmationHandler := calendarHandlers.NewConfirmationHandler(
+		calendarService.NewConfirmationService(calendarSvc, confirmationRepository, log),
+		log,
+	)
+
+	// Authentication of the REST API: JWTs, personal access tokens or calendar API tokens
+	// Account security routes (passkeys, MFA, tokens, app passwords, license) keep requiring a JWT
+	apiAuth := middleware.AuthWithTokens(jwtManager, personalTokenHandler, calendarTokenHandler)
 
 	// ========== AVAILABILITY MODULE ==========
 	// Initialize availability repositories
@@ -270,20 +440,88 @@ func main() {
 	// Initialize ICS repositories
 	icsCalendarRepo := icsRepo.NewCalendarRepository(pool)
 	icsAvailabilityRepo := icsRepo.NewAvailabilityRepository(pool)
+	icsBusyRepo := icsRepo.NewBusyRepository(pool)
 
 	// Initialize ICS service (with quota checker to block feeds for over-quota users)
-	icsSvc := icsService.NewICSService(icsCalendarRepo, icsAvailabilityRepo, services.QuotaService, cfg.AppURL)
+	icsSvc := icsService.NewICSService(icsCalendarRepo, icsAvailabilityRepo, icsBusyRepo, services.QuotaService, cfg.AppURL)
 
 	// Initialize ICS handlers
 	icsHandler := icsHandlers.NewICSHandler(icsSvc)
+	davHandler := icsHandlers.NewDAVHandler(icsSvc)
+
+	// ========== REST HOOKS MODULE ==========
+	hookRepository := hooksRepo.NewHookRepository(pool)
+	hookSvc := hooksService.NewHookService(hookRepository, calendarRepository, cfg, log)
+	hookHandler := hooksHandlers.NewHookHandler(hookSvc, log)
+
+	// ========== CALDAV MODULE ==========
+	caldavRepository := caldavRepo.NewCalDAVRepository(pool)
+	caldavSvc := caldavService.NewCalDAVService(caldavRepository, userRepo, cfg, log)
+	caldavHandler := caldavHandlers.NewCalDAVHandler(caldavSvc, log)
+	caldavSvc.StartSyncTask(context.Background())
+
+	// ========== INTEGRATION MODULE ==========
+	appPasswordRepository := integrationRepo.NewAppPasswordRepository(pool)
+	loginFlowRepository := integrationRepo.NewLoginFlowRepository(pool)
+	integrationSvc := integrationService.NewIntegrationService(appPasswordRepository, loginFlowRepository, calendarRepository, cfg, log)
+	integrationHandler := integrationHandlers.NewIntegrationHandler(integrationSvc, Version, buildType, log)
+
+	// ========== DIRECTORY MODULE ==========
+	directoryRepository := directoryRepo.NewConnectionRepository(pool)
+	directorySvc := directoryService.NewDirectoryService(directoryRepository, calendarRepository, participantRepository, userRepo, jwtManager, cacheInstance, cfg, log)
+	directoryHandler := directoryHandlers.NewDirectoryHandler(directorySvc, log)
+
+	// ========== OUTLOOK MODULE ==========
+	outlookRepository := outlookRepo.NewOutlookRepository(pool)
+	outlookSvc := outlookService.NewOutlookService(outlookRepository, calendarRepository, icsSvc, jwtManager, cfg, log)
+	outlookHandler := outlookHandlers.NewOutlookHandler(outlookSvc, log)
+	outlookSvc.StartSyncTask(context.Background())
+
+	// ========== RETENTION JANITOR ==========
+	retention.NewJanitor(pool, cfg, log).StartTask(context.Background())
+
+	// ========== MAINTENANCE MODE ==========
+	maintenanceSvc := maintenanceService.NewMaintenanceService(maintenanceRepo.NewMaintenanceRepository(pool), log)
+	maintenanceSvc.StartTask(context.Background())
+	maintenanceHandler := maintenanceHandlers.NewMaintenanceHandler(maintenanceSvc, jwtManager, cfg.Branding.ProductName, log)
+
+	announcementSvc := announcementService.NewAnnouncementService(announcementRepo.NewAnnouncementRepository(pool), emailService, cfg, log)
+	announcementSvc.StartTask(context.Background())
+	announcementHandler := announcementHandlers.NewAnnouncementHandler(announcementSvc, log)
+
+	// ========== HOME ASSISTANT MODULE ==========
+	eventPublishers := notifyService.EventPublishers{hookSvc, webhookSvc}
+	if cfg.HomeAssistant.MQTTURL != "" {
+		hostname, _ := os.Hostname()
+		mqttClient, err := mqtt.NewClient(mqtt.Options{BrokerURL: cfg.HomeAssistant.MQTTURL, ClientID: "whento-" + hostname})
+		if err != nil {
+			log.Error("Invalid Home Assistant MQTT broker URL, MQTT publishing disabled", "error", err)
+		} else {
+			if err := mqttClient.Connect(); err != nil {
+				log.Warn("Failed to connect to Home Assistant MQTT broker, retrying in the background", "error", err)
+			}
+			defer mqttClient.Disconnect()
+
+			haPublisher := homeAssistantService.NewMQTTPublisher(mqttClient, calendarRepository, icsSvc, cfg, log)
+			haPublisher.StartRefreshTask(context.Background())
+			eventPublishers = append(eventPublishers, haPublisher)
+		}
+	}
+
+	// ========== PUSH MODULE ==========
+	// Web Push subscriptions of users and participants (enabled by the VAPID keys)
+	pushSvc := pushService.NewPushService(pushRepo.NewPushRepository(pool), cfg, log)
+	pushHandler := pushHandlers.NewPushHandler(pushSvc, log)
 
 	// ========== NOTIFICATION MODULE ==========
 	// Initialize notification repositories
 	notificationLogRepo := notifyRepo.NewNotificationLogRepository(pool)
+	digestRepo := notifyRepo.NewDigestRepository(pool)
+	outboxRepo := notifyRepo.NewOutboxRepository(pool)
 
 	// Initialize notification services
 	thresholdDetector := notifyService.NewThresholdDetector(availabilityRepository, log)
-	externalNotifier := notifyService.NewExternalNotifier(log)
+	externalNotifier := notifyService.NewExternalNotifier(cfg.Branding.ProductName, cfg.MQTTAllowPrivateBrokers, cfg.HooksAllowPrivateTargets, log)
 
 	notifySvc := notifyService.NewNotifyService(
 		calendarRepository,
@@ -291,19 +529,39 @@ func main() {
 		availabilityRepository,
 		userRepo,
 		notificationLogRepo,
+		confirmationRepository,
+		digestRepo,
+		outboxRepo,
 		emailService,
 		externalNotifier,
 		thresholdDetector,
-		cfg.AppURL,
+		eventPublishers,
+		pushSvc,
+		cfg,
 		log,
 	)
 
+	// Outbox of threshold notifications, retried with an increasing delay
+	notifySvc.StartOutboxTask(context.Background())
+
+	// Daily and weekly digests of threshold emails
+	notifyService.NewDigestScheduler(notifySvc, digestRepo, log).StartTask(context.Background())
+
+	// Event reminders pushed to subscribed browsers
+	notifyService.NewReminderScheduler(
+		notifySvc,
+		notifyRepo.NewReminderRepository(pool),
+		icsAvailabilityRepo,
+		log,
+	).StartTask(context.Background())
+
 	participantEmailSvc := notifyService.NewParticipantEmailService(
 		participantRepository,
 		emailService,
 		cfg,
 		log,
 	)
+	calendarSvc.SetParticipantEmails(participantEmailSvc)
 
 	// Initialize notification handlers
 	participantEmailHandler := notifyHandlers.NewParticipantEmailHandler(
@@ -315,6 +573,8 @@ func main() {
 
 	notifyConfigHandler := notifyHandlers.NewNotifyConfigHandler(
 		calendarRepository,
+		calendarChangeRepository,
+		notifySvc,
 		log,
 	)
 
@@ -326,27 +586,65 @@ func main() {
 		availParticipantRepo,
 		recurrenceRepository,
 		notifySvc,
+		webhookSvc,
+		caldavSvc,
 		cacheInstance,
+		cfg,
 	)
 
+	// Weekly summary emails of opted-in owners, from the range summaries of their calendars
+	notifyService.NewSummaryScheduler(
+		notifySvc,
+		notifyRepo.NewSummaryRepository(pool),
+		availabilitySvc,
+		cfg,
+		log,
+	).StartTask(context.Background())
+
 	// Initialize availability handlers
 	availabilityHandler := availabilityHandlers.NewAvailabilityHandler(availabilitySvc)
 	recurrenceHandler := availabilityHandlers.NewRecurrenceHandler(availabilitySvc)
+	embedHandler := availabilityHandlers.NewEmbedHandler(availabilitySvc, cfg.AppURL, cfg.Branding.ProductName, cfg.Branding.PrimaryColor)
+	badgeHandler := availabilityHandlers.NewBadgeHandler(availabilitySvc, cfg.Branding.ProductName)
+
+	// Initialize GraphQL handler
+	graphqlHandler := graphqlHandlers.NewGraphQLHandler(graphqlService.NewGraphQLService(calendarSvc, availabilitySvc, log), log)
+
+	// Configuration reload (SIGHUP or admin API): SMTP, rate limits, allowed emails and notification formats
+	reloader := config.NewReloader(cfg, log)
+	reloader.OnReload(func(next *config.Config) {
+		emailService.Reconfigure(next.Email.SMTP())
+		authSvc.SetAllowedEmails(next.AllowedEmails)
+		notifySvc.SetDefaultFormats(next.TimeFormat, next.DateFormat)
+	})
+	reloadHandler := configHandlers.NewReloadHandler(reloader, log)
 
 	// Initialize rate limiter
 	rateLimiter := middleware.NewRateLimiter(redisClient)
+	// Paid plans (cloud) and licenses (self-hosted) get higher limits on the authenticated API
+	apiRateLimit := quota.RateLimit(services.QuotaService, func() int { return reloader.Current().RateLimitAPIRequests }, log)
+	publicRateLimit := func(*http.Request) int { return reloader.Current().RateLimitPublicRequests }
 
-	// Setup router
+	// Setup router (CalDAV methods must be registered before any route)
+	chi.RegisterMethod("PROPFIND")
+	chi.RegisterMethod("REPORT")
 	r := chi.NewRouter()
 
 	// Global middleware
 	r.Use(chiMiddleware.RealIP)
+	r.Use(middleware.ClientInfo)
 	r.Use(middleware.RequestID)
+	r.Use(tracing.Middleware)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.SecurityHeaders)
 	r.Use(middleware.LimitRequestSize(1 * 1024 * 1024)) // 1MB max payload
-	r.Use(middleware.CORS([]string{"*"}))               // Configure for production
+	r.Use(middleware.CORS(middleware.CORSConfig{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+	}))
+	r.Use(maintenanceHandler.Middleware)
 
 	// Health routes (use auth health handler as primary)
 	r.Get("/api/health", authHealthHandler.Health)
@@ -356,66 +654,85 @@ func main() {
 	r.Route("/api/v1/auth", func(r chi.Router) {
 		// Public routes with rate limiting
 		r.Group(func(r chi.Router) {
-			if cfg.RateLimitEnabled {
-				// Login: 5 requests/minute/IP
-				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
-					Requests: 5,
-					Window:   time.Minute,
-					KeyFunc:  middleware.CombinedKeyFunc,
-				})).Post("/login", authHandler.Login)
-
-				// Register: 3 requests/minute/IP
-				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
-					Requests: 3,
-					Window:   time.Minute,
-					KeyFunc:  middleware.CombinedKeyFunc,
-				})).Post("/register", authHandler.Register)
-			} else {
-				r.Post("/login", authHandler.Login)
-				r.Post("/register", authHandler.Register)
-			}
+			// Local login (disabled with DISABLE_LOCAL_LOGIN when single sign-on is configured)
+			r.Group(func(r chi.Router) {
+				r.Use(ssoHandler.RequireLocalLogin)
+
+				if cfg.RateLimitEnabled {
+					// Login: 5 requests/minute/IP
+					r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+						Requests: 5,
+						Window:   time.Minute,
+						KeyFunc:  middleware.CombinedKeyFunc,
+					})).Post("/login", authHandler.Login)
+
+					// Register: 3 requests/minute/IP
+					r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+						Requests: 3,
+						Window:   time.Minute,
+						KeyFunc:  middleware.CombinedKeyFunc,
+					})).Post("/register", authHandler.Register)
+				} else {
+					r.Post("/login", authHandler.Login)
+					r.Post("/register", authHandler.Register)
+				}
+
+				// Password reset (public - no auth required)
+				if cfg.RateLimitEnabled {
+					// Forgot password: 3 requests/15 minutes/IP
+					r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+						Requests: 3,
+						Window:   15 * time.Minute,
+						KeyFunc:  middleware.IPKeyFunc,
+					})).Post("/forgot-password", passwordResetHandler.ForgotPassword)
+				} else {
+					r.Post("/forgot-password", passwordResetHandler.ForgotPassword)
+				}
+				r.Post("/reset-password", passwordResetHandler.ResetPassword)
+
+				// Magic link authentication (public)
+				if cfg.RateLimitEnabled {
+					r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+						Requests: 3,
+						Window:   15 * time.Minute,
+						KeyFunc:  middleware.IPKeyFunc,
+					})).Post("/magic-link/request", magicLinkHandler.RequestMagicLink)
+				} else {
+					r.Post("/magic-link/request", magicLinkHandler.RequestMagicLink)
+				}
+			})
 
 			r.Post("/refresh", authHandler.Refresh)
 			r.Post("/logout", authHandler.Logout)
 
-			// Password reset (public - no auth required)
-			if cfg.RateLimitEnabled {
-				// Forgot password: 3 requests/15 minutes/IP
-				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
-					Requests: 3,
-					Window:   15 * time.Minute,
-					KeyFunc:  middleware.IPKeyFunc,
-				})).Post("/forgot-password", passwordResetHandler.ForgotPassword)
-			} else {
-				r.Post("/forgot-password", passwordResetHandler.ForgotPassword)
-			}
-			r.Post("/reset-password", passwordResetHandler.ResetPassword)
-
-			// Magic link authentication (public)
-			if cfg.RateLimitEnabled {
-				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
-					Requests: 3,
-					Window:   15 * time.Minute,
-					KeyFunc:  middleware.IPKeyFunc,
-				})).Post("/magic-link/request", magicLinkHandler.RequestMagicLink)
-			} else {
-				r.Post("/magic-link/request", magicLinkHandler.RequestMagicLink)
-			}
 			r.Get("/magic-link/verify/{token}", magicLinkHandler.VerifyMagicLink)
 			r.Get("/magic-link/available", magicLinkHandler.CheckAvailable)
 
 			// Email verification (public - no auth required)
 			r.Get("/verify-email/{token}", emailVerificationHandler.VerifyEmail)
+
+			// Personal data export download (public - token from the emailed link)
+			r.Get("/exports/{token}", dataExportHandler.Download)
 		})
 
 		// Authenticated routes
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.Auth(jwtManager))
+			r.Use(apiAuth)
 
 			r.Get("/me", authHandler.GetMe)
 			r.Patch("/me", authHandler.UpdateMe)
 			r.Patch("/me/password", authHandler.ChangePassword)
 
+			// Sessions (signed-in devices)
+			r.Get("/sessions", authHandler.ListSessions)
+			r.Delete("/sessions", authHandler.RevokeAllSessions)
+			r.Delete("/sessions/{id}", authHandler.RevokeSession)
+
+			// Personal data export
+			r.Post("/me/export", dataExportHandler.Request)
+			r.Get("/me/export", dataExportHandler.Get)
+			r.Get("/me/export/download", dataExportHandler.DownloadLatest)
+
 			// Email verification (authenticated - requires login)
 			r.Post("/send-verification", emailVerificationHandler.SendVerificationEmail)
 
@@ -423,13 +740,68 @@ func main() {
 			r.Group(func(r chi.Router) {
 				r.Use(middleware.RequireRole("admin"))
 
+				r.Get("/admin/stats", statsHandler.GetStats)
+				r.Post("/admin/config/reload", reloadHandler.Reload)
+				r.Get("/admin/maintenance", maintenanceHandler.GetStatus)
+				r.Put("/admin/maintenance", maintenanceHandler.Update)
+				r.Get("/admin/announcements", announcementHandler.List)
+				r.Post("/admin/announcements", announcementHandler.Create)
+				r.Delete("/admin/announcements/{id}", announcementHandler.Delete)
+				r.Get("/admin/mail-queue", mailQueueHandler.List)
+				r.Post("/admin/mail-queue/{id}/resend", mailQueueHandler.Resend)
 				r.Get("/admin/users", authHandler.ListUsers)
 				r.Patch("/admin/users/{id}/role", authHandler.UpdateUserRole)
 				r.Delete("/admin/users/{id}", authHandler.DeleteUser)
+				r.Post("/admin/users/{id}/restore", authHandler.RestoreUser)
 				r.Post("/admin/users/{id}/disable-2fa", adminMFAHandler.AdminDisable2FA)
 			})
 		})
 
+		// Personal access tokens (JWT only: a token can't create others)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Auth(jwtManager))
+
+			// Tokens can still be listed and revoked after a downgrade
+			requireAPIKeys := quota.RequireCapability(services.QuotaService, quota.CapabilityAPIKeys, log)
+			r.With(requireAPIKeys).Post("/tokens", personalTokenHandler.Create)
+			r.Get("/tokens", personalTokenHandler.List)
+			r.Delete("/tokens/{id}", personalTokenHandler.Delete)
+		})
+
+		// Single sign-on (public)
+		r.Group(func(r chi.Router) {
+			r.Get("/sso", ssoHandler.Config)
+
+			if cfg.RateLimitEnabled {
+				// Login tickets of SAML sign-ins: 10 requests/minute/IP
+				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests: 10,
+					Window:   time.Minute,
+					KeyFunc:  middleware.IPKeyFunc,
+				})).Post("/sso/ticket", ssoHandler.RedeemTicket)
+
+				// OIDC sign-in: 10 requests/minute/IP
+				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests: 10,
+					Window:   time.Minute,
+					KeyFunc:  middleware.IPKeyFunc,
+				})).Post("/oidc/authorize", oidcHandler.Authorize)
+
+				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests: 10,
+					Window:   time.Minute,
+					KeyFunc:  middleware.IPKeyFunc,
+				})).Post("/oidc/callback", oidcHandler.Callback)
+			} else {
+				r.Post("/sso/ticket", ssoHandler.RedeemTicket)
+				r.Post("/oidc/authorize", oidcHandler.Authorize)
+				r.Post("/oidc/callback", oidcHandler.Callback)
+			}
+
+			// SAML sign-in (self-hosted Enterprise licenses)
+			RegisterSAMLRoutes(r, samlProvider, cfg, rateLimiter)
+		})
+
 		// Passkey authentication (public)
 		r.Group(func(r chi.Router) {
 			if cfg.RateLimitEnabled {
@@ -454,14 +826,19 @@ func main() {
 		// MFA verification (public - during login)
 		r.Group(func(r chi.Router) {
 			if cfg.RateLimitEnabled {
-				// MFA verification: 5 requests/5 minutes/IP
-				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+				// MFA verification and email codes: 5 requests/5 minutes/IP
+				mfaLimit := rateLimiter.Limit(middleware.RateLimitConfig{
 					Requests: 5,
 					Window:   5 * time.Minute,
 					KeyFunc:  middleware.CombinedKeyFunc,
-				})).Post("/mfa/verify", mfaHandler.VerifyLogin)
+				})
+				r.With(mfaLimit).Post("/mfa/verify", mfaHandler.VerifyLogin)
+				r.With(mfaLimit).Post("/mfa/email/send", mfaHandler.SendEmailCode)
+				r.With(mfaLimit).Post("/mfa/webauthn/begin", mfaHandler.BeginSecurityKey)
 			} else {
 				r.Post("/mfa/verify", mfaHandler.VerifyLogin)
+				r.Post("/mfa/email/send", mfaHandler.SendEmailCode)
+				r.Post("/mfa/webauthn/begin", mfaHandler.BeginSecurityKey)
 			}
 		})
 	})
@@ -483,9 +860,24 @@ func main() {
 				Window:   time.Minute,
 				KeyFunc:  middleware.UserKeyFunc,
 			})).Post("/register/finish", passkeyHandler.FinishRegistration)
+
+			// Security keys (second factor): same limits
+			r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+				Requests: 5,
+				Window:   time.Minute,
+				KeyFunc:  middleware.UserKeyFunc,
+			})).Post("/security-key/begin", passkeyHandler.BeginSecurityKeyRegistration)
+
+			r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+				Requests: 5,
+				Window:   time.Minute,
+				KeyFunc:  middleware.UserKeyFunc,
+			})).Post("/security-key/finish", passkeyHandler.FinishSecurityKeyRegistration)
 		} else {
 			r.Post("/register/begin", passkeyHandler.BeginRegistration)
 			r.Post("/register/finish", passkeyHandler.FinishRegistration)
+			r.Post("/security-key/begin", passkeyHandler.BeginSecurityKeyRegistration)
+			r.Post("/security-key/finish", passkeyHandler.FinishSecurityKeyRegistration)
 		}
 
 		r.Get("/list", passkeyHandler.List)
@@ -533,41 +925,94 @@ func main() {
 			if cfg.RateLimitEnabled {
 				// Public calendar access: 60 requests/minute/IP
 				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
-					Requests: 60,
-					Window:   time.Minute,
-					KeyFunc:  middleware.IPKeyFunc,
+					Requests:  60,
+					Window:    time.Minute,
+					KeyFunc:   middleware.IPKeyFunc,
+					LimitFunc: publicRateLimit,
 				})).Get("/public/{token}", calendarHandler.GetPublicCalendar)
 			} else {
 				r.Get("/public/{token}", calendarHandler.GetPublicCalendar)
 			}
 
+			// Live status badge (README, forum signatures)
+			if cfg.RateLimitEnabled {
+				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests:  60,
+					Window:    time.Minute,
+					KeyFunc:   middleware.IPKeyFunc,
+					LimitFunc: publicRateLimit,
+				})).Get("/public/{token}/badge.svg", badgeHandler.GetBadge)
+			} else {
+				r.Get("/public/{token}/badge.svg", badgeHandler.GetBadge)
+			}
+
+			// Claim of a participant link never handed out, on calendars not locking their participants
+			if cfg.RateLimitEnabled {
+				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests: 10,
+					Window:   time.Minute,
+					KeyFunc:  middleware.IPKeyFunc,
+				})).Post("/public/{token}/participants/{pid}/claim", calendarHandler.ClaimParticipantLink)
+			} else {
+				r.Post("/public/{token}/participants/{pid}/claim", calendarHandler.ClaimParticipantLink)
+			}
+
+			// Free/busy time for external schedulers
+			if cfg.RateLimitEnabled {
+				r.With(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests:  60,
+					Window:    time.Minute,
+					KeyFunc:   middleware.IPKeyFunc,
+					LimitFunc: publicRateLimit,
+				})).Get("/public/{token}/freebusy", icsHandler.GetFreeBusy)
+			} else {
+				r.Get("/public/{token}/freebusy", icsHandler.GetFreeBusy)
+			}
+
 			// Public participant email verification
 			r.Get("/participants/verify-email/{token}", participantEmailHandler.VerifyEmail)
 
-			// Public participant email management (requires calendar token validation)
-			r.Post("/{token}/participants/{pid}/email", participantEmailHandler.AddEmail)
-			r.Post("/{token}/participants/{pid}/resend-verification", participantEmailHandler.ResendVerification)
+			// Public owner decision on a proposed event (link sent by email)
+			r.Get("/confirmations/{token}", confirmationHandler.GetByToken)
+			r.Post("/confirmations/{token}", confirmationHandler.DecideByToken)
+
+			// Public participant email management (requires calendar token validation and the participant's link)
+			r.With(availabilityHandler.WithParticipantToken).Post("/{token}/participants/{pid}/email", participantEmailHandler.AddEmail)
+			r.With(availabilityHandler.WithParticipantToken).Post("/{token}/participants/{pid}/resend-verification", participantEmailHandler.ResendVerification)
+
+			// Public participant push subscriptions (through the participant's link)
+			r.With(availabilityHandler.WithParticipantToken).Post("/{token}/participants/{pid}/push", pushHandler.SubscribeParticipant)
+			r.With(availabilityHandler.WithParticipantToken).Delete("/{token}/participants/{pid}/push", pushHandler.UnsubscribeParticipant)
 		})
 
 		// Authenticated routes
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.Auth(jwtManager))
+			r.Use(apiAuth)
 
 			if cfg.RateLimitEnabled {
-				// Authenticated routes: 100 requests/minute/user
+				// Authenticated routes: 100 requests/minute/user, scaled with the plan or license
 				r.Use(rateLimiter.Limit(middleware.RateLimitConfig{
-					Requests: 100,
-					Window:   time.Minute,
-					KeyFunc:  middleware.UserKeyFunc,
+					Requests:  100,
+					Window:    time.Minute,
+					KeyFunc:   middleware.UserKeyFunc,
+					LimitFunc: apiRateLimit,
 				}))
 			}
 
+			// Organization switcher (X-Organization-ID header)
+			r.Use(organizationHandler.Switch)
+
 			// Calendar CRUD
 			r.Post("/", calendarHandler.CreateCalendar)
 			r.Get("/", calendarHandler.ListMyCalendars)
 			r.Get("/{id}", calendarHandler.GetCalendar)
 			r.Patch("/{id}", calendarHandler.UpdateCalendar)
 			r.Delete("/{id}", calendarHandler.DeleteCalendar)
+			r.Get("/{id}/changes", calendarHandler.ListChanges)
+
+			// Backup
+			r.Get("/{id}/export", calendarHandler.ExportCalendar)
+			r.Post("/import", calendarHandler.ImportCalendar)
 
 			// Token regeneration
 			r.Post("/{id}/regenerate-token", calendarHandler.RegenerateToken)
@@ -576,16 +1021,55 @@ func main() {
 			r.Post("/{id}/participants", participantHandler.AddParticipant)
 			r.Patch("/{id}/participants/{pid}", participantHandler.UpdateParticipant)
 			r.Delete("/{id}/participants/{pid}", participantHandler.RemoveParticipant)
+			r.Post("/{id}/participants/{pid}/regenerate-token", participantHandler.RegenerateParticipantToken)
+			r.Post("/{id}/participants/import", directoryHandler.ImportParticipants)
+			r.Post("/{id}/participants/import/csv", participantHandler.ImportParticipantsCSV)
 
 			// Notification config (owner only)
 			r.Get("/{id}/notify-config", notifyConfigHandler.GetConfig)
 			r.Patch("/{id}/notify-config", notifyConfigHandler.UpdateConfig)
+			r.Post("/{id}/notify-config/test", notifyConfigHandler.TestConfig)
+			r.Get("/{id}/notify-config/deliveries", notifyConfigHandler.ListDeliveries)
+
+			// Outbound webhooks (owner only); existing webhooks can still be managed after a downgrade
+			requireWebhooks := quota.RequireCapability(services.QuotaService, quota.CapabilityWebhooks, log)
+			r.Get("/{id}/webhooks", webhookHandler.List)
+			r.With(requireWebhooks).Post("/{id}/webhooks", webhookHandler.Create)
+			r.Patch("/{id}/webhooks/{wid}", webhookHandler.Update)
+			r.Delete("/{id}/webhooks/{wid}", webhookHandler.Delete)
+			r.Post("/{id}/webhooks/{wid}/rotate-secret", webhookHandler.RotateSecret)
+			r.With(requireWebhooks).Post("/{id}/webhooks/{wid}/ping", webhookHandler.Ping)
+			r.Get("/{id}/webhooks/{wid}/deliveries", webhookHandler.ListDeliveries)
+
+			// API tokens of the calendar (owner only); existing tokens can still be managed after a downgrade
+			requireAPIKeys := quota.RequireCapability(services.QuotaService, quota.CapabilityAPIKeys, log)
+			r.Get("/{id}/tokens", calendarTokenHandler.List)
+			r.With(requireAPIKeys).Post("/{id}/tokens", calendarTokenHandler.Create)
+			r.Delete("/{id}/tokens/{tid}", calendarTokenHandler.Revoke)
+
+			// Event confirmations of calendars requiring them (owner only)
+			r.Get("/{id}/confirmations", confirmationHandler.List)
+			r.Put("/{id}/confirmations/{date}", confirmationHandler.Decide)
+
+			// Summaries and availabilities by calendar ID (same as the public link routes), for API tokens
+			r.Group(func(r chi.Router) {
+				r.Use(calendarHandler.WithPublicToken)
+
+				r.Get("/{id}/dates/{date}", availabilityHandler.GetDateSummary)
+				r.Get("/{id}/range", availabilityHandler.GetRangeSummary)
+				r.Get("/{id}/participants/{pid}/availabilities", availabilityHandler.GetParticipantAvailabilities)
+				r.Post("/{id}/participants/{pid}/availabilities", availabilityHandler.CreateAvailability)
+				r.Post("/{id}/participants/{pid}/availabilities/bulk", availabilityHandler.BulkAvailability)
+				r.Patch("/{id}/participants/{pid}/availabilities/{date}", availabilityHandler.UpdateAvailability)
+				r.Delete("/{id}/participants/{pid}/availabilities/{date}", availabilityHandler.DeleteAvailability)
+			})
 
 			// Admin routes
 			r.Group(func(r chi.Router) {
 				r.Use(middleware.RequireRole("admin"))
 
 				r.Get("/admin/users/{id}/calendars", calendarHandler.ListUserCalendars)
+				r.Post("/admin/{id}/transfer", calendarHandler.TransferCalendar)
 			})
 		})
 	})
@@ -597,34 +1081,75 @@ func main() {
 			if cfg.RateLimitEnabled {
 				// Rate limiting: 60 requests/minute/IP for public availability access
 				r.Use(rateLimiter.Limit(middleware.RateLimitConfig{
-					Requests: 60,
-					Window:   time.Minute,
-					KeyFunc:  middleware.IPKeyFunc,
+					Requests:  60,
+					Window:    time.Minute,
+					KeyFunc:   middleware.IPKeyFunc,
+					LimitFunc: publicRateLimit,
 				}))
 			}
 
-			// Participant availability management
-			r.Get("/calendar/{token}/participant/{pid}", availabilityHandler.GetParticipantAvailabilities)
-			r.Post("/calendar/{token}/participant/{pid}", availabilityHandler.CreateAvailability)
-			r.Patch("/calendar/{token}/participant/{pid}/{date}", availabilityHandler.UpdateAvailability)
-			r.Delete("/calendar/{token}/participant/{pid}/{date}", availabilityHandler.DeleteAvailability)
-
-			// Recurrence management
-			r.Post("/calendar/{token}/participant/{pid}/recurrence", recurrenceHandler.CreateRecurrence)
-			r.Get("/calendar/{token}/participant/{pid}/recurrences", recurrenceHandler.GetParticipantRecurrences)
-			r.Patch("/calendar/{token}/participant/{pid}/recurrence/{rid}", recurrenceHandler.UpdateRecurrence)
-			r.Delete("/calendar/{token}/participant/{pid}/recurrence/{rid}", recurrenceHandler.DeleteRecurrence)
-
-			// Recurrence exceptions
-			r.Post("/calendar/{token}/participant/{pid}/recurrence/{rid}/exception", recurrenceHandler.CreateException)
-			r.Delete("/calendar/{token}/participant/{pid}/recurrence/{rid}/exception/{date}", recurrenceHandler.DeleteException)
+			// Participant routes: {pid} is the secret token of the participant's link
+			r.Group(func(r chi.Router) {
+				r.Use(availabilityHandler.WithParticipantToken)
+
+				// Participant availability management
+				r.Get("/calendar/{token}/participant/{pid}", availabilityHandler.GetParticipantAvailabilities)
+				r.Post("/calendar/{token}/participant/{pid}", availabilityHandler.CreateAvailability)
+				r.Post("/calendar/{token}/participant/{pid}/bulk", availabilityHandler.BulkAvailability)
+				r.Patch("/calendar/{token}/participant/{pid}/{date}", availabilityHandler.UpdateAvailability)
+				r.Delete("/calendar/{token}/participant/{pid}/{date}", availabilityHandler.DeleteAvailability)
+
+				// Recurrence management
+				r.Post("/calendar/{token}/participant/{pid}/recurrence", recurrenceHandler.CreateRecurrence)
+				r.Get("/calendar/{token}/participant/{pid}/recurrences", recurrenceHandler.GetParticipantRecurrences)
+				r.Patch("/calendar/{token}/participant/{pid}/recurrence/{rid}", recurrenceHandler.UpdateRecurrence)
+				r.Delete("/calendar/{token}/participant/{pid}/recurrence/{rid}", recurrenceHandler.DeleteRecurrence)
+
+				// Recurrence exceptions
+				r.Post("/calendar/{token}/participant/{pid}/recurrence/{rid}/exception", recurrenceHandler.CreateException)
+				r.Delete("/calendar/{token}/participant/{pid}/recurrence/{rid}/exception/{date}", recurrenceHandler.DeleteException)
+				r.Post("/calendar/{token}/participant/{pid}/exceptions", recurrenceHandler.CreateExceptionRange)
+
+				// Comment deletion by their author
+				r.Delete("/calendar/{token}/participant/{pid}/comments/{cid}", availabilityHandler.DeleteComment)
+
+				// Answers to the events, apart from availability
+				r.Put("/calendar/{token}/participant/{pid}/rsvp/{date}", availabilityHandler.SetRSVP)
+				r.Delete("/calendar/{token}/participant/{pid}/rsvp/{date}", availabilityHandler.DeleteRSVP)
+
+				// External busy feeds, flagging conflicting availabilities
+				r.Get("/calendar/{token}/participant/{pid}/busy-feeds", availabilityHandler.ListBusyFeeds)
+				r.Post("/calendar/{token}/participant/{pid}/busy-feeds", availabilityHandler.AddBusyFeed)
+				r.Delete("/calendar/{token}/participant/{pid}/busy-feeds/{fid}", availabilityHandler.DeleteBusyFeed)
+			})
 
 			// Date summaries
 			r.Get("/calendar/{token}/dates/{date}", availabilityHandler.GetDateSummary)
 			r.Get("/calendar/{token}/range", availabilityHandler.GetRangeSummary)
+
+			// Date comments
+			r.Post("/calendar/{token}/dates/{date}/comments", availabilityHandler.CreateComment)
 		})
 	})
 
+	// ========== EMBEDDABLE WIDGET (public, framed by third-party websites) ==========
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Embeddable(cfg.EmbedFrameAncestors))
+
+		if cfg.RateLimitEnabled {
+			// Same limit as the public calendar access: 60 requests/minute/IP
+			r.Use(rateLimiter.Limit(middleware.RateLimitConfig{
+				Requests:  60,
+				Window:    time.Minute,
+				KeyFunc:   middleware.IPKeyFunc,
+				LimitFunc: publicRateLimit,
+			}))
+		}
+
+		// HTML grid (accepts /embed/{token}.json for the compact JSON payload)
+		r.Get("/embed/{token}", embedHandler.GetEmbed)
+	})
+
 	// ========== BILLING/LICENSING ROUTES ==========
 	// Register build-specific routes (Cloud: Stripe billing, Self-hosted: License management)
 	RegisterBillingRoutes(r, services, cfg, pool, jwtManager)
@@ -644,20 +1169,220 @@ func main() {
 
 			// ICS feed endpoint (accepts both /feed/{token} and /feed/{token}.ics)
 			r.Get("/feed/{token}", icsHandler.GetFeed)
+
+			// Next confirmed event, for Home Assistant REST sensors
+			r.Get("/sensor/{token}", icsHandler.GetSensor)
+		})
+	})
+
+	// ========== CALDAV ROUTES ==========
+	// Read-only CalDAV collection of the confirmed events, for native subscriptions (Thunderbird, DAVx5)
+	r.Group(func(r chi.Router) {
+		if cfg.RateLimitEnabled {
+			// Same budget as the ICS feed: 30 requests/minute/IP
+			r.Use(rateLimiter.Limit(middleware.RateLimitConfig{
+				Requests: 30,
+				Window:   time.Minute,
+				KeyFunc:  middleware.IPKeyFunc,
+			}))
+		}
+
+		r.HandleFunc("/dav/calendars/{token}", davHandler.ServeHTTP)
+		r.HandleFunc("/dav/calendars/{token}/", davHandler.ServeHTTP)
+		r.HandleFunc("/dav/calendars/{token}/{object}", davHandler.ServeHTTP)
+	})
+
+	// ========== REST HOOKS ROUTES ==========
+	r.Route("/api/v1/hooks", func(r chi.Router) {
+		r.Use(apiAuth)
+
+		// Existing subscriptions can still be listed and removed after a downgrade
+		requireWebhooks := quota.RequireCapability(services.QuotaService, quota.CapabilityWebhooks, log)
+		r.With(requireWebhooks).Post("/", hookHandler.Subscribe)
+		r.Get("/", hookHandler.List)
+		r.With(requireWebhooks).Get("/poll", hookHandler.Poll)
+		r.Delete("/{id}", hookHandler.Unsubscribe)
+	})
+
+	// ========== CALDAV ROUTES ==========
+	r.Route("/api/v1/caldav", func(r chi.Router) {
+		r.Use(apiAuth)
+
+		// The account can still be viewed and disconnected after a downgrade
+		requireCalDAV := quota.RequireCapability(services.QuotaService, quota.CapabilityCalDAV, log)
+		r.Get("/account", caldavHandler.GetAccount)
+		r.With(requireCalDAV).Put("/account", caldavHandler.Connect)
+		r.Delete("/account", caldavHandler.Disconnect)
+		r.With(requireCalDAV).Post("/account/sync", caldavHandler.Sync)
+		r.Get("/busy", caldavHandler.GetBusy)
+	})
+
+	// ========== ORGANIZATION ROUTES ==========
+	r.Route("/api/v1/organizations", func(r chi.Router) {
+		r.Use(apiAuth)
+
+		r.Get("/", organizationHandler.List)
+		r.Post("/", organizationHandler.Create)
+		r.Get("/{id}", organizationHandler.Get)
+		r.Patch("/{id}", organizationHandler.Update)
+		r.Delete("/{id}", organizationHandler.Delete)
+		r.Post("/{id}/members", organizationHandler.AddMember)
+		r.Patch("/{id}/members/{userId}", organizationHandler.UpdateMember)
+		r.Delete("/{id}/members/{userId}", organizationHandler.RemoveMember)
+	})
+
+	// ========== SEARCH ROUTES ==========
+	r.Route("/api/v1/search", func(r chi.Router) {
+		r.Use(apiAuth)
+
+		if cfg.RateLimitEnabled {
+			// Same limit as the authenticated calendar routes: 100 requests/minute/user, scaled with the plan or license
+			r.Use(rateLimiter.Limit(middleware.RateLimitConfig{
+				Requests:  100,
+				Window:    time.Minute,
+				KeyFunc:   middleware.UserKeyFunc,
+				LimitFunc: apiRateLimit,
+			}))
+		}
+
+		r.Get("/", searchHandler.Search)
+	})
+
+	// ========== ANNOUNCEMENT ROUTES ==========
+	r.Route("/api/v1/announcements", func(r chi.Router) {
+		r.Use(apiAuth)
+
+		r.Get("/", announcementHandler.ListBanners)
+		r.Post("/{id}/dismiss", announcementHandler.Dismiss)
+	})
+
+	// ========== GRAPHQL ROUTES ==========
+	r.Route("/api/graphql", func(r chi.Router) {
+		r.Get("/schema", graphqlHandler.Schema)
+
+		r.Group(func(r chi.Router) {
+			r.Use(apiAuth)
+			r.Use(organizationHandler.Switch)
+
+			if cfg.RateLimitEnabled {
+				// Same limit as the authenticated calendar routes: 100 requests/minute/user, scaled with the plan or license
+				r.Use(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests:  100,
+					Window:    time.Minute,
+					KeyFunc:   middleware.UserKeyFunc,
+					LimitFunc: apiRateLimit,
+				}))
+			}
+
+			r.Post("/", graphqlHandler.Query)
+		})
+	})
+
+	// ========== DIRECTORY ROUTES ==========
+	r.Route("/api/v1/directory", func(r chi.Router) {
+		// OAuth callback, reached by the browser redirect of the provider (the state identifies the user)
+		r.Get("/{provider}/callback", directoryHandler.Callback)
+
+		r.Group(func(r chi.Router) {
+			r.Use(apiAuth)
+
+			r.Get("/providers", directoryHandler.ListProviders)
+			r.Post("/{provider}/connect", directoryHandler.Connect)
+			r.Delete("/{provider}", directoryHandler.Disconnect)
+			r.Get("/{provider}/people", directoryHandler.SearchPeople)
+		})
+	})
+
+	// ========== OUTLOOK ROUTES ==========
+	r.Route("/api/v1/outlook", func(r chi.Router) {
+		// OAuth callback, reached by the browser redirect of Microsoft (the state identifies the user)
+		r.Get("/callback", outlookHandler.Callback)
+
+		r.Group(func(r chi.Router) {
+			r.Use(apiAuth)
+
+			r.Get("/", outlookHandler.GetStatus)
+			r.Post("/connect", outlookHandler.Connect)
+			r.Post("/sync", outlookHandler.Sync)
+			r.Delete("/", outlookHandler.Disconnect)
+		})
+	})
+
+	// ========== PUSH ROUTES ==========
+	r.Route("/api/v1/push", func(r chi.Router) {
+		// VAPID public key, also needed by participants without account
+		r.Get("/public-key", pushHandler.GetPublicKey)
+
+		r.Group(func(r chi.Router) {
+			r.Use(apiAuth)
+
+			r.Post("/subscriptions", pushHandler.SubscribeUser)
+			r.Delete("/subscriptions", pushHandler.UnsubscribeUser)
+		})
+	})
+
+	// ========== INTEGRATION ROUTES ==========
+	r.Get("/api/v1/capabilities", integrationHandler.Capabilities)
+
+	r.Route("/api/v1/integrations", func(r chi.Router) {
+		// Login flow started and polled by the integration (public)
+		r.Group(func(r chi.Router) {
+			if cfg.RateLimitEnabled {
+				// 60 requests/minute/IP: integrations poll every few seconds
+				r.Use(rateLimiter.Limit(middleware.RateLimitConfig{
+					Requests:  60,
+					Window:    time.Minute,
+					KeyFunc:   middleware.IPKeyFunc,
+					LimitFunc: publicRateLimit,
+				}))
+			}
+
+			r.Post("/login-flow", integrationHandler.StartLoginFlow)
+			r.Post("/login-flow/poll", integrationHandler.PollLoginFlow)
+		})
+
+		// Account management by the user (JWT only: an app password can't create others)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Auth(jwtManager))
+
+			// App passwords can still be listed and revoked after a downgrade
+			requireAPIKeys := quota.RequireCapability(services.QuotaService, quota.CapabilityAPIKeys, log)
+			r.Get("/login-flow/{token}", integrationHandler.GetLoginFlow)
+			r.With(requireAPIKeys).Post("/login-flow/{token}/grant", integrationHandler.GrantLoginFlow)
+
+			r.With(requireAPIKeys).Post("/app-passwords", integrationHandler.CreateAppPassword)
+			r.Get("/app-passwords", integrationHandler.ListAppPasswords)
+			r.Delete("/app-passwords/{id}", integrationHandler.DeleteAppPassword)
+		})
+
+		// Routes used by integrations (app password or JWT)
+		r.Group(func(r chi.Router) {
+			r.Use(integrationHandlers.Auth(integrationSvc, jwtManager, log))
+
+			r.Get("/calendars", integrationHandler.ListCalendars)
 		})
 	})
 
 	// ========== SEO ROUTES (robots.txt, sitemap.xml) ==========
-	seoHandler := seo.NewHandler(cfg.AppURL, cfg.DisableRobots, buildType)
+	seoHandler := seo.NewHandler(cfg.AppURL, cfg.DisableRobots, buildType, cfg.Branding.ProductName)
 	r.Get("/robots.txt", seoHandler.HandleRobotsTxt)
 	r.Get("/sitemap.xml", seoHandler.HandleSitemapXML)
 
+	// ========== BRANDING (public, read by the SPA) ==========
+	brandingHandler := branding.NewHandler(cfg.Branding)
+	r.Get("/api/v1/branding", brandingHandler.GetBranding)
+
+	// ========== MAINTENANCE STATUS (public, polled by the SPA for its banner) ==========
+	r.Get("/api/v1/maintenance", maintenanceHandler.GetPublicStatus)
+
 	// ========== SWAGGER DOCUMENTATION ==========
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 
 	// ========== FRONTEND (SPA) ==========
 	// Serve embedded frontend for all non-API routes
-	spaHandler, err := web.NewSPAHandler(cfg.AppURL, buildType)
+	// Shared calendar links (/c/{token}) unfurl with the calendar name and next confirmed event
+	previewService := seo.NewPreviewService(icsSvc)
+	spaHandler, err := web.NewSPAHandler(cfg.AppURL, buildType, cfg.Branding.ProductName, cfg.Branding.LogoURL, previewService)
 	if err != nil {
 		log.Error("Failed to initialize SPA handler", "error", err)
 		os.Exit(1)
@@ -685,6 +1410,34 @@ func main() {
 		}
 	}()
 
+	// Start the gRPC API on its own port when enabled
+	var grpcServer *grpc.Server
+	if cfg.GRPCPort != "" {
+		grpcServer = grpcapi.NewServer(calendarSvc, availabilitySvc, organizationSvc, userRepo, services.QuotaService, cfg, log).
+			GRPCServer(jwtManager, personalTokenHandler, calendarTokenHandler)
+		listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Error("Failed to listen for the gRPC API", "error", err, "port", cfg.GRPCPort)
+			os.Exit(1)
+		}
+		go func() {
+			log.Info("gRPC API listening", "port", cfg.GRPCPort)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Error("gRPC server error", "error", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	// Reload the configuration on SIGHUP, without dropping requests in flight
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			_, _ = reloader.Reload()
+		}
+	}()
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -698,6 +1451,12 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.Error("Server forced to shutdown", "error", err)
 	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Error("Failed to flush traces", "error", err)
+	}
 
 	fmt.Println("Server exited")
 }
@@ -0,0 +1,75 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/whento/pkg/database"
+
+	"github.com/whento/whento/internal/config"
+	maintenanceRepo "github.com/whento/whento/internal/maintenance/repository"
+	maintenanceService "github.com/whento/whento/internal/maintenance/service"
+)
+
+// runMaintenance implements "whento maintenance [on|off|status]": turns the maintenance mode on or off
+// around migrations and backups, running servers pick the change up within maintenanceService.RefreshInterval
+func runMaintenance(args []string) error {
+	fs := flag.NewFlagSet("maintenance", flag.ContinueOnError)
+	message := fs.String("message", "", "Message shown to users while the maintenance mode is on")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: whento maintenance [on|off|status] [-message text]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	action := "status"
+	if fs.NArg() > 0 {
+		action = fs.Arg(0)
+	}
+	if action != "on" && action != "off" && action != "status" {
+		fs.Usage()
+		return errors.New("unknown action " + action)
+	}
+
+	cfg := config.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := database.NewPool(ctx, &database.Config{URL: cfg.DatabaseURL})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close(pool)
+
+	svc := maintenanceService.NewMaintenanceService(maintenanceRepo.NewMaintenanceRepository(pool), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if action != "status" {
+		if _, err := svc.Set(ctx, action == "on", *message, nil); err != nil {
+			return err
+		}
+	} else if err := svc.Load(ctx); err != nil {
+		return err
+	}
+
+	status := svc.Status()
+	if !status.Enabled {
+		fmt.Println("Maintenance mode is off")
+		return nil
+	}
+	fmt.Println("Maintenance mode is on since", status.UpdatedAt.Format(time.RFC3339))
+	if status.Message != "" {
+		fmt.Println("Message:", status.Message)
+	}
+	return nil
+}
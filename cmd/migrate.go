@@ -0,0 +1,295 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/pkg/database"
+
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/migrate"
+	"github.com/whento/whento/migrations"
+)
+
+const migrateUsage = `Usage: whento migrate <status|up|down|force> [flags]
+
+  status              Show the current version and pending migrations
+  up [N]              Apply all (or the next N) pending migrations
+  down [N]            Roll back the last N migrations (default 1)
+  force VERSION       Set the version without running migrations and clear the dirty flag
+                      (-1 = no migration applied)
+
+Flags:`
+
+// migrateCommand is a parsed "whento migrate" command line
+type migrateCommand struct {
+	action  string
+	n       int  // up/down: number of migrations, 0 = all
+	version int  // force: version to set
+	dryRun  bool // up/down: print the SQL instead of applying it
+}
+
+// runMigrate implements "whento migrate": manages the migrations embedded in the binary
+func runMigrate(args []string) error {
+	cmd, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	pool, migrator, err := openMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close(pool)
+
+	switch cmd.action {
+	case "status":
+		return migrateStatus(ctx, migrator)
+
+	case "up", "down":
+		return migrateRun(ctx, migrator, migrate.Direction(cmd.action), cmd.n, cmd.dryRun)
+
+	default: // force
+		if err := migrator.Force(ctx, cmd.version); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Version forced to %s\n", formatVersion(cmd.version))
+		return nil
+	}
+}
+
+// parseMigrateArgs checks the arguments of "whento migrate" before connecting to the database
+func parseMigrateArgs(args []string) (*migrateCommand, error) {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Print the SQL of up/down without applying it")
+	all := fs.Bool("all", false, "With down: roll back all migrations")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), migrateUsage)
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return nil, errors.New("missing migrate action")
+	}
+
+	cmd := &migrateCommand{action: positional[0], dryRun: *dryRun}
+	actionArgs := positional[1:]
+
+	switch cmd.action {
+	case "status":
+		return cmd, nil
+
+	case "up", "down":
+		if cmd.action == "down" && !*all {
+			cmd.n = 1
+		}
+		if len(actionArgs) > 0 {
+			if cmd.n, err = strconv.Atoi(actionArgs[0]); err != nil || cmd.n < 1 {
+				return nil, fmt.Errorf("invalid number of migrations %q", actionArgs[0])
+			}
+		}
+		return cmd, nil
+
+	case "force":
+		if len(actionArgs) != 1 {
+			return nil, errors.New("usage: whento migrate force VERSION")
+		}
+		if cmd.version, err = strconv.Atoi(actionArgs[0]); err != nil {
+			return nil, fmt.Errorf("invalid version %q", actionArgs[0])
+		}
+		return cmd, nil
+
+	default:
+		fs.Usage()
+		return nil, fmt.Errorf("unknown migrate action %q", cmd.action)
+	}
+}
+
+// migrateStatus prints the current version and pending migrations
+func migrateStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Build:            %s\n", buildType)
+	fmt.Printf("Database version: %s", formatVersion(status.Version))
+	if status.Dirty {
+		fmt.Print(" (dirty)")
+	}
+	fmt.Println()
+	fmt.Printf("Latest version:   %s\n", formatVersion(status.Latest))
+
+	if status.Version > status.Latest {
+		fmt.Println("\nThe database is newer than this binary.")
+	}
+	if status.Dirty {
+		fmt.Printf("\nA migration failed at version %d. Fix the schema manually, then run 'whento migrate force %d'.\n",
+			status.Version, status.Version)
+	}
+
+	if len(status.Pending) == 0 {
+		fmt.Println("\nThe database is up to date.")
+		return nil
+	}
+
+	fmt.Printf("\n%d pending migration(s):\n", len(status.Pending))
+	for _, migration := range status.Pending {
+		fmt.Printf("  %03d  %s\n", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+// migrateRun applies (or prints, with dryRun) up/down migrations
+func migrateRun(ctx context.Context, migrator *migrate.Migrator, direction migrate.Direction, n int, dryRun bool) error {
+	if dryRun {
+		steps, err := migrator.Plan(ctx, direction, n)
+		if errors.Is(err, migrate.ErrNoChange) {
+			fmt.Fprintln(os.Stderr, "No change: the database is already at the requested version.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, step := range steps {
+			fmt.Printf("-- %03d_%s.%s.sql (version %s)\n", step.Migration.Version, step.Migration.Name,
+				step.Direction, formatVersion(step.TargetVersion))
+			fmt.Println(step.SQL())
+		}
+		return nil
+	}
+
+	count := 0
+	err := migrator.Run(ctx, direction, n, func(step migrate.Step) {
+		fmt.Printf("→ %03d_%s (%s)\n", step.Migration.Version, step.Migration.Name, step.Direction)
+		count++
+	})
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("No change: the database is already at the requested version.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ %d migration(s) applied\n", count)
+	return nil
+}
+
+// migrateOnStart brings the schema up to date before the server starts (MIGRATE_ON_START), or refuses to
+// start on pending migrations when they are applied explicitly with "whento migrate up"
+// Replicas starting together are serialized by the migration lock, only the first one applies them
+func migrateOnStart(ctx context.Context, pool *pgxpool.Pool, apply bool, log *slog.Logger) error {
+	loaded, err := migrate.Load(migrations.Sources()...)
+	if err != nil {
+		return err
+	}
+	migrator := migrate.New(pool, loaded)
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Version > status.Latest {
+		log.Warn("The database is newer than this binary", "version", status.Version, "latest", status.Latest)
+	}
+	run, err := startupMigrations(status, apply)
+	if err != nil || !run {
+		return err
+	}
+
+	err = migrator.Run(ctx, migrate.DirectionUp, 0, func(step migrate.Step) {
+		log.Info("Applying migration", "version", step.Migration.Version, "name", step.Migration.Name)
+	})
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// startupMigrations reports whether the server applies the pending migrations when it starts
+// An empty database is always initialized; upgrades need MIGRATE_ON_START or "whento migrate up"
+func startupMigrations(status *migrate.Status, apply bool) (bool, error) {
+	switch {
+	case status.Dirty:
+		return false, fmt.Errorf("migration %d failed: fix the schema manually, then run 'whento migrate force %d'",
+			status.Version, status.Version)
+	case status.Version > status.Latest, len(status.Pending) == 0:
+		return false, nil
+	case !apply && status.Version != migrate.NilVersion:
+		return false, fmt.Errorf("%d pending migration(s) (database version %s, latest %d): run 'whento migrate up'",
+			len(status.Pending), formatVersion(status.Version), status.Latest)
+	}
+	return true, nil
+}
+
+// openMigrator connects to the configured database and loads the embedded migrations
+func openMigrator(ctx context.Context) (*pgxpool.Pool, *migrate.Migrator, error) {
+	loaded, err := migrate.Load(migrations.Sources()...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := config.Load()
+	pool, err := database.NewPool(ctx, &database.Config{URL: cfg.DatabaseURL})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return pool, migrate.New(pool, loaded), nil
+}
+
+// parseInterspersed parses flags placed before or after positional arguments
+// Negative numbers, such as "force -1", are positional arguments rather than flags
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if len(args) > 0 && isNegativeNumber(args[0]) {
+			positional = append(positional, args[0])
+			args = args[1:]
+			continue
+		}
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func isNegativeNumber(arg string) bool {
+	n, err := strconv.Atoi(arg)
+	return err == nil && n < 0
+}
+
+func formatVersion(version int) string {
+	if version == migrate.NilVersion {
+		return "none"
+	}
+	return strconv.Itoa(version)
+}
@@ -0,0 +1,88 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/whento/whento/internal/migrate"
+)
+
+func TestParseMigrateArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    migrateCommand
+		wantErr string
+	}{
+		{"status", []string{"status"}, migrateCommand{action: "status"}, ""},
+		{"up all", []string{"up"}, migrateCommand{action: "up"}, ""},
+		{"up N", []string{"up", "2"}, migrateCommand{action: "up", n: 2}, ""},
+		{"up dry run after action", []string{"up", "--dry-run"}, migrateCommand{action: "up", dryRun: true}, ""},
+		{"up dry run before action", []string{"--dry-run", "up", "3"}, migrateCommand{action: "up", n: 3, dryRun: true}, ""},
+		{"down defaults to one", []string{"down"}, migrateCommand{action: "down", n: 1}, ""},
+		{"down all", []string{"down", "--all"}, migrateCommand{action: "down"}, ""},
+		{"down N", []string{"down", "4"}, migrateCommand{action: "down", n: 4}, ""},
+		{"force", []string{"force", "13"}, migrateCommand{action: "force", version: 13}, ""},
+		{"force none", []string{"force", "-1"}, migrateCommand{action: "force", version: -1}, ""},
+		{"missing action", nil, migrateCommand{}, "missing migrate action"},
+		{"unknown action", []string{"sideways"}, migrateCommand{}, `unknown migrate action "sideways"`},
+		{"invalid count", []string{"up", "many"}, migrateCommand{}, `invalid number of migrations "many"`},
+		{"zero count", []string{"down", "0"}, migrateCommand{}, `invalid number of migrations "0"`},
+		{"force without version", []string{"force"}, migrateCommand{}, "usage: whento migrate force VERSION"},
+		{"invalid version", []string{"force", "latest"}, migrateCommand{}, `invalid version "latest"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := parseMigrateArgs(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseMigrateArgs(%q) error = %v, want %q", tt.args, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMigrateArgs(%q) error = %v", tt.args, err)
+			}
+			if *cmd != tt.want {
+				t.Errorf("parseMigrateArgs(%q) = %+v, want %+v", tt.args, *cmd, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartupMigrations(t *testing.T) {
+	pending := []migrate.Migration{{Version: 66}, {Version: 67}}
+
+	tests := []struct {
+		name    string
+		status  migrate.Status
+		apply   bool
+		wantRun bool
+		wantErr string
+	}{
+		{"up to date", migrate.Status{Version: 67, Latest: 67}, false, false, ""},
+		{"up to date with migrate on start", migrate.Status{Version: 67, Latest: 67}, true, false, ""},
+		{"empty database", migrate.Status{Version: migrate.NilVersion, Latest: 67, Pending: pending}, false, true, ""},
+		{"pending migrations", migrate.Status{Version: 65, Latest: 67, Pending: pending}, false, false, "2 pending migration(s) (database version 65, latest 67): run 'whento migrate up'"},
+		{"pending migrations with migrate on start", migrate.Status{Version: 65, Latest: 67, Pending: pending}, true, true, ""},
+		{"dirty", migrate.Status{Version: 66, Dirty: true, Latest: 67, Pending: pending[1:]}, true, false, "migration 66 failed"},
+		{"database newer than binary", migrate.Status{Version: 68, Latest: 67}, true, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run, err := startupMigrations(&tt.status, tt.apply)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("startupMigrations() error = %v, want %q", err, tt.wantErr)
+			}
+			if run != tt.wantRun {
+				t.Errorf("startupMigrations() = %v, want %v", run, tt.wantRun)
+			}
+		})
+	}
+}
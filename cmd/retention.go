@@ -0,0 +1,79 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/whento/pkg/database"
+
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/retention"
+)
+
+// runRetention implements "whento retention": reports the data older than its retention period,
+// and purges it with -purge (the server does it periodically, see RETENTION_INTERVAL)
+func runRetention(args []string) error {
+	fs := flag.NewFlagSet("retention", flag.ContinueOnError)
+	purge := fs.Bool("purge", false, "Delete the reported data (default: dry run)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	pool, err := database.NewPool(ctx, &database.Config{URL: cfg.DatabaseURL})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close(pool)
+
+	janitor := retention.NewJanitor(pool, cfg, slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	apply := janitor.Report
+	if *purge {
+		apply = janitor.Purge
+	}
+
+	reports, err := apply(ctx, time.Now())
+	printRetentionReports(os.Stdout, reports, *purge)
+	if err != nil {
+		return err
+	}
+
+	if !*purge {
+		fmt.Println("\nDry run, nothing was deleted. Run with -purge to delete these rows.")
+	}
+	return nil
+}
+
+// printRetentionReports prints the outcome of the retention policy of each table
+func printRetentionReports(out io.Writer, reports []retention.TableReport, purged bool) {
+	column := "TO PURGE"
+	if purged {
+		column = "PURGED"
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "TABLE\tCATEGORY\tRETENTION\tOLDER THAN\t%s\n", column)
+	for _, report := range reports {
+		if report.Cutoff == nil {
+			fmt.Fprintf(w, "%s\t%s\tforever\t-\t-\n", report.Table, report.Category)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d days\t%s\t%d\n",
+			report.Table, report.Category, report.RetentionDays, report.Cutoff.Format("2006-01-02"), report.Rows)
+	}
+	w.Flush()
+}
@@ -0,0 +1,64 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/whento/pkg/database"
+
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/seed"
+)
+
+// runSeed implements "whento seed": generates demo data for evaluation installs, screenshots and load testing
+func runSeed(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	calendars := fs.Int("calendars", 5, "Number of calendars to create")
+	participants := fs.Int("participants", 10, "Participants per calendar")
+	weeks := fs.Int("weeks", 8, "Weeks of availabilities to generate, starting today")
+	owner := fs.String("owner", seed.DefaultOwnerEmail, "Email of the calendar owner (created if missing)")
+	randomSeed := fs.Uint64("seed", 0, "Random seed for reproducible data (0 = random)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	pool, err := database.NewPool(ctx, &database.Config{URL: cfg.DatabaseURL})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close(pool)
+
+	result, err := seed.New(pool, cfg.BcryptCost).Run(ctx, seed.Options{
+		Calendars:    *calendars,
+		Participants: *participants,
+		Weeks:        *weeks,
+		OwnerEmail:   *owner,
+		Seed:         *randomSeed,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Demo data generated for %s\n", result.OwnerEmail)
+	if result.OwnerPassword != "" {
+		fmt.Printf("  Password: %s\n", result.OwnerPassword)
+	}
+	fmt.Printf("  %d calendars, %d participants, %d availabilities, %d recurrences\n\n",
+		len(result.Calendars), result.Participants, result.Availabilities, result.Recurrences)
+	for _, calendar := range result.Calendars {
+		fmt.Printf("  %-28s %s/c/%s\n", calendar.Name, cfg.AppURL, calendar.PublicToken)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/whento/pkg/database"
+
+	"github.com/whento/whento/internal/backup"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/supportbundle"
+)
+
+// runSupportBundle implements "whento support-bundle": collects the environment information needed
+// to reproduce an issue into a zip file, without secrets nor user data
+func runSupportBundle(args []string) error {
+	fs := flag.NewFlagSet("support-bundle", flag.ContinueOnError)
+	output := fs.String("o", "whento-support-"+time.Now().Format("20060102-150405")+".zip", "Output file")
+	logFile := fs.String("logs", "", "Log file to include (e.g. the output of 'docker compose logs app')")
+	logLines := fs.Int("log-lines", 1000, "Number of log lines to include")
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout of each connectivity check")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.Load()
+
+	bundle := &supportbundle.Bundle{
+		Version: supportbundle.NewVersionInfo(Version, buildType),
+		Config:  supportbundle.RedactConfig(cfg),
+	}
+
+	fmt.Println("Running configuration checks...")
+	var report strings.Builder
+	printConfigChecks(&report, cfg, runConfigChecks(cfg, *timeout))
+	bundle.Checks = supportbundle.AnonymizeLine(report.String())
+
+	fmt.Println("Collecting database statistics...")
+	stats, err := collectDatabaseStats(*timeout)
+	if err != nil {
+		bundle.Errors = append(bundle.Errors, "database: "+supportbundle.AnonymizeLine(err.Error()))
+	}
+	bundle.Database = stats
+
+	if *logFile != "" {
+		fmt.Printf("Reading the last %d lines of %s...\n", *logLines, *logFile)
+		if bundle.Logs, err = supportbundle.ReadLogTail(*logFile, *logLines); err != nil {
+			bundle.Errors = append(bundle.Errors, "logs: "+err.Error())
+		}
+	}
+
+	f, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Write(f); err != nil {
+		f.Close()
+		os.Remove(*output)
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✓ Support bundle written to %s\n", *output)
+	if len(bundle.Errors) > 0 {
+		fmt.Printf("  %d part(s) couldn't be collected, see errors.txt\n", len(bundle.Errors))
+	}
+	fmt.Println("  Secrets are redacted and emails, IP addresses and tokens masked, but please review it before sharing.")
+	return nil
+}
+
+// collectDatabaseStats reads the migration state and row counts of the exported tables
+func collectDatabaseStats(timeout time.Duration) (*supportbundle.DatabaseStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	pool, migrator, err := openMigrator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer database.Close(pool)
+
+	stats, err := supportbundle.CollectDatabaseStats(ctx, pool, backup.Tables())
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats.SchemaVersion = status.Version
+	stats.Dirty = status.Dirty
+
+	return stats, nil
+}
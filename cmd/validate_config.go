@@ -0,0 +1,277 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/whento/pkg/database"
+	"github.com/whento/pkg/email"
+	"github.com/whento/pkg/jwt"
+
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/migrate"
+	notifyService "github.com/whento/whento/internal/notify/service"
+	"github.com/whento/whento/internal/retention"
+	"github.com/whento/whento/migrations"
+)
+
+// checkStatus is the outcome of a configuration check
+type checkStatus int
+
+const (
+	checkOK checkStatus = iota
+	checkWarn
+	checkFail
+)
+
+// checkResult is a line of the configuration report
+type checkResult struct {
+	Name   string
+	Status checkStatus
+	Detail string
+}
+
+func checkPassed(name, detail string) checkResult { return checkResult{name, checkOK, detail} }
+func checkWarned(name, detail string) checkResult { return checkResult{name, checkWarn, detail} }
+func checkFailed(name, detail string) checkResult { return checkResult{name, checkFail, detail} }
+
+// errInvalidConfig makes "whento --validate-config" exit with a non-zero code
+var errInvalidConfig = errors.New("configuration is not valid")
+
+// runValidateConfig implements "whento --validate-config": checks the configuration and the
+// reachability of external services, prints a report and exits without starting the server
+func runValidateConfig(args []string) error {
+	fs := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout of each connectivity check")
+	file := fs.String("config", "", "Configuration file to check (default: "+config.FileEnv+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file != "" {
+		_ = os.Setenv(config.FileEnv, *file)
+	}
+
+	cfg := config.Load()
+
+	if failures := printConfigChecks(os.Stdout, cfg, runConfigChecks(cfg, *timeout)); failures > 0 {
+		return errInvalidConfig
+	}
+	return nil
+}
+
+// runConfigChecks runs all the configuration checks
+func runConfigChecks(cfg *config.Config, timeout time.Duration) []checkResult {
+	results := checkSettings(cfg)
+	results = append(results, checkAppURL(cfg))
+	results = append(results, checkDatabase(cfg, timeout)...)
+	results = append(results,
+		checkRedis(cfg, timeout),
+		checkSMTP(cfg, timeout),
+		checkJWT(cfg),
+		checkCORS(cfg),
+		checkRetention(cfg),
+		checkWeeklySummary(cfg),
+	)
+	return append(results, buildConfigChecks(cfg)...)
+}
+
+// printConfigChecks writes the configuration report and returns the number of failed checks
+func printConfigChecks(w io.Writer, cfg *config.Config, results []checkResult) int {
+	fmt.Fprintf(w, "WhenTo configuration check (%s build, %s)\n\n", buildType, cfg.AppEnv)
+
+	warnings, failures := 0, 0
+	for _, result := range results {
+		symbol := "✓"
+		switch result.Status {
+		case checkWarn:
+			symbol = "!"
+			warnings++
+		case checkFail:
+			symbol = "✗"
+			failures++
+		}
+		fmt.Fprintf(w, "  %s %-20s %s\n", symbol, result.Name, result.Detail)
+	}
+
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", failures, warnings)
+	return failures
+}
+
+func checkAppURL(cfg *config.Config) checkResult {
+	u, err := url.Parse(cfg.AppURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return checkFailed("App URL", fmt.Sprintf("APP_URL %q is not an absolute http(s) URL", cfg.AppURL))
+	}
+	if u.Scheme == "http" && cfg.AppEnv == "production" {
+		return checkWarned("App URL", cfg.AppURL+" (not HTTPS: passkeys and secure cookies require HTTPS)")
+	}
+	return checkPassed("App URL", cfg.AppURL)
+}
+
+// checkDatabase checks the connection and the migration state
+func checkDatabase(cfg *config.Config, timeout time.Duration) []checkResult {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	pool, err := database.NewPool(ctx, &database.Config{URL: cfg.DatabaseURL, MinConns: 1})
+	if err != nil {
+		return []checkResult{checkFailed("Database", err.Error())}
+	}
+	defer database.Close(pool)
+
+	results := []checkResult{checkPassed("Database", "connected")}
+
+	loaded, err := migrate.Load(migrations.Sources()...)
+	if err != nil {
+		return append(results, checkFailed("Migrations", err.Error()))
+	}
+	status, err := migrate.New(pool, loaded).Status(ctx)
+	switch {
+	case err != nil:
+		results = append(results, checkFailed("Migrations", err.Error()))
+	case status.Dirty:
+		results = append(results, checkFailed("Migrations", fmt.Sprintf("dirty at version %d (see 'whento migrate status')", status.Version)))
+	case status.Version > status.Latest:
+		results = append(results, checkWarned("Migrations", fmt.Sprintf("database version %d is newer than this binary (%d)", status.Version, status.Latest)))
+	case len(status.Pending) > 0:
+		results = append(results, checkWarned("Migrations", fmt.Sprintf("%d pending migration(s), run 'whento migrate up'", len(status.Pending))))
+	default:
+		results = append(results, checkPassed("Migrations", fmt.Sprintf("up to date (version %d)", status.Version)))
+	}
+
+	return results
+}
+
+// checkRedis checks the connection. Redis is optional, so failures are warnings
+func checkRedis(cfg *config.Config, timeout time.Duration) checkResult {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := database.NewRedisClient(ctx, &database.RedisConfig{URL: cfg.RedisURL})
+	if err != nil {
+		return checkWarned("Redis", err.Error()+" (cache and rate limiting disabled)")
+	}
+	defer database.CloseRedis(client)
+
+	return checkPassed("Redis", "connected")
+}
+
+// checkSMTP connects and authenticates to the SMTP server, or checks the credentials of the email provider
+// Email is optional when not configured
+func checkSMTP(cfg *config.Config, timeout time.Duration) checkResult {
+	service := email.NewService(cfg.Email.SMTP(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if provider := cfg.Email.Provider; provider != "" && provider != email.ProviderSMTP {
+		if !service.IsConfigured() {
+			return checkFailed("Email", fmt.Sprintf("%s: incomplete settings", provider))
+		}
+		if err := service.Verify(timeout); err != nil {
+			return checkFailed("Email", err.Error())
+		}
+		return checkPassed("Email", provider+" API")
+	}
+
+	if !service.IsConfigured() {
+		return checkWarned("SMTP", "not configured (email features disabled)")
+	}
+	if err := service.Verify(timeout); err != nil {
+		return checkFailed("SMTP", fmt.Sprintf("%s:%d: %v", cfg.Email.SMTPHost, cfg.Email.SMTPPort, err))
+	}
+	return checkPassed("SMTP", fmt.Sprintf("%s:%d", cfg.Email.SMTPHost, cfg.Email.SMTPPort))
+}
+
+// checkRetention checks the retention overrides, which only apply to tables purged by the janitor
+func checkRetention(cfg *config.Config) checkResult {
+	if unknown := retention.UnknownOverrides(cfg.Retention.Overrides); len(unknown) > 0 {
+		sort.Strings(unknown)
+		return checkWarned("Retention", fmt.Sprintf("RETENTION_OVERRIDES ignored for %s (purged tables: %s)",
+			strings.Join(unknown, ", "), strings.Join(retention.Tables(), ", ")))
+	}
+	if cfg.Retention.Interval <= 0 {
+		return checkPassed("Retention", "janitor disabled (RETENTION_INTERVAL=0)")
+	}
+	mode := ""
+	if cfg.Retention.DryRun {
+		mode = ", dry run"
+	}
+	return checkPassed("Retention", fmt.Sprintf("janitor every %s%s", cfg.Retention.Interval, mode))
+}
+
+// checkCORS reports the origins allowed in cross-origin requests (validated with the other settings)
+func checkCORS(cfg *config.Config) checkResult {
+	if slices.Contains(cfg.CORS.AllowedOrigins, "*") && cfg.AppEnv == "production" {
+		return checkWarned("CORS", "any origin allowed (set CORS_ALLOWED_ORIGINS to the hosts of the frontend)")
+	}
+	return checkPassed("CORS", strings.Join(cfg.CORS.AllowedOrigins, ", "))
+}
+
+// checkSettings reports the errors of the configuration file and the invalid settings, one line each
+func checkSettings(cfg *config.Config) []checkResult {
+	problems := cfg.Problems()
+	if len(problems) == 0 {
+		source := "environment"
+		if path := os.Getenv(config.FileEnv); path != "" {
+			source = "environment and " + path
+		}
+		return []checkResult{checkPassed("Settings", "valid ("+source+")")}
+	}
+
+	results := make([]checkResult, 0, len(problems))
+	for _, problem := range problems {
+		results = append(results, checkFailed("Settings", problem.Error()))
+	}
+	return results
+}
+
+// checkWeeklySummary checks the schedule of the weekly summary emails
+func checkWeeklySummary(cfg *config.Config) checkResult {
+	if cfg.WeeklySummary.Day == "" {
+		return checkPassed("Weekly summary", "disabled (WEEKLY_SUMMARY_DAY empty)")
+	}
+	if _, ok := notifyService.ParseWeekday(cfg.WeeklySummary.Day); !ok {
+		return checkFailed("Weekly summary", fmt.Sprintf("WEEKLY_SUMMARY_DAY %q is not a weekday", cfg.WeeklySummary.Day))
+	}
+	if cfg.WeeklySummary.Hour < 0 || cfg.WeeklySummary.Hour > 23 {
+		return checkFailed("Weekly summary", fmt.Sprintf("WEEKLY_SUMMARY_HOUR %d is not between 0 and 23", cfg.WeeklySummary.Hour))
+	}
+	return checkPassed("Weekly summary", fmt.Sprintf("sent on %s at %d:00 (owner timezone)", cfg.WeeklySummary.Day, cfg.WeeklySummary.Hour))
+}
+
+// checkJWT loads the JWT keys and checks that they form a pair
+func checkJWT(cfg *config.Config) checkResult {
+	manager, err := jwt.NewManager(&jwt.Config{
+		PrivateKeyPath:        cfg.JWTPrivateKeyPath,
+		PublicKeyPath:         cfg.JWTPublicKeyPath,
+		PreviousPublicKeyPath: cfg.JWTPreviousPublicKeyPath,
+		AccessExpiry:          cfg.JWTAccessExpiry,
+		RefreshExpiry:         cfg.JWTRefreshExpiry,
+		Issuer:                cfg.JWTIssuer,
+	})
+	if err != nil {
+		return checkFailed("JWT keys", err.Error())
+	}
+
+	token, err := manager.GenerateAccessToken("00000000-0000-0000-0000-000000000000", "check@whento.local", "user")
+	if err != nil {
+		return checkFailed("JWT keys", err.Error())
+	}
+	if _, err := manager.ValidateAccessToken(token); err != nil {
+		return checkFailed("JWT keys", "private and public keys don't match: "+err.Error())
+	}
+
+	return checkPassed("JWT keys", cfg.JWTPrivateKeyPath)
+}
@@ -0,0 +1,8 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+// Version is set at build time with -ldflags "-X main.Version=x.y.z"
+var Version = "dev"
@@ -0,0 +1,483 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/whento/pkg/database"
+	"github.com/whento/pkg/i18n"
+	"github.com/whento/pkg/validator"
+
+	authModels "github.com/whento/whento/internal/auth/models"
+	authRepo "github.com/whento/whento/internal/auth/repository"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	mfaRepo "github.com/whento/whento/internal/mfa/repository"
+)
+
+var (
+	// Version is set during build
+	Version = "dev"
+)
+
+// databaseURL overrides DATABASE_URL from the environment when set
+var databaseURL string
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// newRootCmd builds the whento-admin command tree
+func newRootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:   "whento-admin",
+		Short: "WhenTo Admin - Manage a WhenTo instance directly from the database",
+		Long: `WhenTo Admin
+
+This tool performs common administration tasks directly against the WhenTo database,
+without going through the web UI. It is meant for recovery situations such as an
+admin locked out of their account.
+
+The database connection is read from the same environment variables (or .env file)
+as the server, or from the --database-url flag.
+
+Usage:
+  whento-admin create-user --email admin@example.com --name "Admin" --admin
+  whento-admin reset-password admin@example.com
+  whento-admin disable-2fa admin@example.com`,
+		Version:       Version,
+		SilenceUsage:  true, // Errors are database or lookup failures, not usage mistakes
+		SilenceErrors: true, // Printed once by main
+	}
+
+	rootCmd.PersistentFlags().StringVar(&databaseURL, "database-url", "", "PostgreSQL connection URL (default: DATABASE_URL)")
+
+	rootCmd.AddCommand(createUserCmd())
+	rootCmd.AddCommand(promoteCmd())
+	rootCmd.AddCommand(resetPasswordCmd())
+	rootCmd.AddCommand(disable2FACmd())
+	rootCmd.AddCommand(listCalendarsCmd())
+
+	return rootCmd
+}
+
+func createUserCmd() *cobra.Command {
+	var (
+		email       string
+		displayName string
+		password    string
+		locale      string
+		admin       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-user",
+		Short: "Create a user account",
+		Long: `Create a user account with a verified email address.
+
+Registration restrictions (ALLOWED_REGISTER, ALLOWED_EMAILS) do not apply.
+If no password is given, a random one is generated and printed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return createUser(email, displayName, password, locale, admin)
+		},
+	}
+
+	cmd.Flags().StringVarP(&email, "email", "e", "", "Email address")
+	cmd.Flags().StringVarP(&displayName, "name", "n", "", "Display name")
+	cmd.Flags().StringVarP(&password, "password", "p", "", "Password (default: generated)")
+	cmd.Flags().StringVarP(&locale, "locale", "l", authModels.LocaleEN, "Locale ("+strings.Join(i18n.Locales, ", ")+")")
+	cmd.Flags().BoolVar(&admin, "admin", false, "Create the user as an admin")
+
+	cmd.MarkFlagRequired("email")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func promoteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "promote <email>",
+		Short: "Promote a user to admin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return promoteUser(args[0])
+		},
+	}
+}
+
+func resetPasswordCmd() *cobra.Command {
+	var password string
+
+	cmd := &cobra.Command{
+		Use:   "reset-password <email>",
+		Short: "Reset a user's password",
+		Long: `Reset a user's password and sign them out of all sessions.
+
+If no password is given, a random one is generated and printed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return resetPassword(args[0], password)
+		},
+	}
+
+	cmd.Flags().StringVarP(&password, "password", "p", "", "New password (default: generated)")
+
+	return cmd
+}
+
+func disable2FACmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable-2fa <email>",
+		Short: "Disable two-factor authentication (TOTP) for a user",
+		Long: `Disable two-factor authentication (TOTP) for a user.
+
+The TOTP secret and all backup codes are removed. Passkeys are left untouched.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return disable2FA(args[0])
+		},
+	}
+}
+
+func listCalendarsCmd() *cobra.Command {
+	var owner string
+
+	cmd := &cobra.Command{
+		Use:   "list-calendars",
+		Short: "List calendars",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listCalendars(owner)
+		},
+	}
+
+	cmd.Flags().StringVarP(&owner, "owner", "o", "", "Only list calendars owned by this email")
+
+	return cmd
+}
+
+// connect opens a database connection using --database-url or the server configuration
+func connect(ctx context.Context) (*pgxpool.Pool, *config.Config, error) {
+	cfg := config.Load()
+	if databaseURL != "" {
+		cfg.DatabaseURL = databaseURL
+	}
+
+	pool, err := database.NewPool(ctx, &database.Config{
+		URL:      cfg.DatabaseURL,
+		MaxConns: 2,
+		MinConns: 1,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return pool, cfg, nil
+}
+
+// getUserByEmail looks up a user, with a readable error when missing
+func getUserByEmail(ctx context.Context, users *authRepo.UserRepository, email string) (*authModels.User, error) {
+	user, err := users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, authRepo.ErrUserNotFound) {
+			return nil, fmt.Errorf("no user with email %s", email)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// resolvePassword validates the given password, or generates one when empty
+func resolvePassword(password string) (string, bool, error) {
+	if password == "" {
+		generated, err := generatePassword(20)
+		return generated, true, err
+	}
+
+	if err := validator.ValidateVar(password, "required,strongpassword,max=72"); err != nil {
+		return "", false, fmt.Errorf("password must be 12-72 characters with uppercase, lowercase, number, and special character")
+	}
+
+	return password, false, nil
+}
+
+func createUser(email, displayName, password, locale string, admin bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := validator.ValidateVar(email, "required,email"); err != nil {
+		return fmt.Errorf("invalid email address: %s", email)
+	}
+	if !i18n.IsSupported(locale) {
+		return fmt.Errorf("invalid locale: %s (must be one of %s)", locale, strings.Join(i18n.Locales, ", "))
+	}
+
+	password, generated, err := resolvePassword(password)
+	if err != nil {
+		return err
+	}
+
+	pool, cfg, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close(pool)
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), cfg.BcryptCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	role := authModels.RoleUser
+	if admin {
+		role = authModels.RoleAdmin
+	}
+
+	user := &authModels.User{
+		Email:         email,
+		PasswordHash:  string(passwordHash),
+		DisplayName:   displayName,
+		Role:          role,
+		Locale:        locale,
+		Timezone:      "Europe/Paris",
+		EmailVerified: true,
+	}
+	user.ID = uuid.New()
+
+	if err := authRepo.NewUserRepository(pool).Create(ctx, user); err != nil {
+		if errors.Is(err, authRepo.ErrUserAlreadyExists) {
+			return fmt.Errorf("a user with email %s already exists", email)
+		}
+		return err
+	}
+
+	fmt.Printf("✓ User created: %s (%s, role: %s)\n", user.Email, user.ID, user.Role)
+	if generated {
+		fmt.Printf("  Password: %s\n", password)
+	}
+
+	return nil
+}
+
+func promoteUser(email string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, _, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close(pool)
+
+	users := authRepo.NewUserRepository(pool)
+	user, err := getUserByEmail(ctx, users, email)
+	if err != nil {
+		return err
+	}
+
+	if user.IsAdmin() {
+		fmt.Printf("%s is already an admin\n", user.Email)
+		return nil
+	}
+
+	if err := users.UpdateRole(ctx, user.ID, authModels.RoleAdmin); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ %s promoted to admin\n", user.Email)
+	return nil
+}
+
+func resetPassword(email, password string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	password, generated, err := resolvePassword(password)
+	if err != nil {
+		return err
+	}
+
+	pool, cfg, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close(pool)
+
+	users := authRepo.NewUserRepository(pool)
+	user, err := getUserByEmail(ctx, users, email)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), cfg.BcryptCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := users.UpdatePassword(ctx, user.ID, string(passwordHash)); err != nil {
+		return err
+	}
+
+	// Revoke all sessions, like a regular password reset
+	if err := authRepo.NewTokenRepository(pool).DeleteByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("password updated but failed to revoke sessions: %w", err)
+	}
+
+	fmt.Printf("✓ Password reset for %s\n", user.Email)
+	if generated {
+		fmt.Printf("  Password: %s\n", password)
+	}
+
+	return nil
+}
+
+func disable2FA(email string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, _, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close(pool)
+
+	user, err := getUserByEmail(ctx, authRepo.NewUserRepository(pool), email)
+	if err != nil {
+		return err
+	}
+
+	mfa := mfaRepo.NewMFARepository(pool)
+	userMFA, err := mfa.GetByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, mfaRepo.ErrMFANotFound) {
+			fmt.Printf("2FA is not enabled for %s\n", user.Email)
+			return nil
+		}
+		return err
+	}
+
+	if err := mfa.Delete(ctx, user.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ 2FA disabled for %s (%d backup codes removed)\n", user.Email, len(userMFA.BackupCodes))
+	return nil
+}
+
+func listCalendars(ownerEmail string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, _, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close(pool)
+
+	users := authRepo.NewUserRepository(pool)
+	calendars := calendarRepo.NewCalendarRepository(pool)
+
+	var owners []*authModels.User
+	if ownerEmail != "" {
+		user, err := getUserByEmail(ctx, users, ownerEmail)
+		if err != nil {
+			return err
+		}
+		owners = []*authModels.User{user}
+	} else {
+		owners, err = users.List(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tOWNER\tTHRESHOLD\tPUBLIC TOKEN\tCREATED")
+
+	count := 0
+	for _, owner := range owners {
+		owned, err := calendars.GetByOwnerID(ctx, owner.ID)
+		if err != nil {
+			return err
+		}
+		for _, calendar := range owned {
+			printCalendar(w, calendar, owner.Email)
+			count++
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d calendar(s)\n", count)
+	return nil
+}
+
+func printCalendar(w *tabwriter.Writer, calendar *calendarModels.Calendar, ownerEmail string) {
+	fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+		calendar.ID,
+		calendar.Name,
+		ownerEmail,
+		calendar.Threshold,
+		calendar.PublicToken,
+		calendar.CreatedAt.Format("2006-01-02"),
+	)
+}
+
+// generatePassword returns a random password satisfying the strong password policy
+func generatePassword(length int) (string, error) {
+	const (
+		upper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+		lower   = "abcdefghijkmnpqrstuvwxyz"
+		digits  = "23456789"
+		special = "!@#$%&*-_=+?"
+	)
+	all := upper + lower + digits + special
+
+	// One character from each class, the rest from the full set
+	classes := []string{upper, lower, digits, special}
+	password := make([]byte, length)
+	for i := range password {
+		charset := all
+		if i < len(classes) {
+			charset = classes[i]
+		}
+		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random number: %w", err)
+		}
+		password[i] = charset[num.Int64()]
+	}
+
+	// Shuffle so the class characters are not always first
+	for i := len(password) - 1; i > 0; i-- {
+		num, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random number: %w", err)
+		}
+		j := num.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
@@ -0,0 +1,85 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/whento/pkg/validator"
+)
+
+func TestCommandValidation(t *testing.T) {
+	// Every case fails before the database is opened
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"unknown command", []string{"delete-everything"}, `unknown command "delete-everything"`},
+		{"create-user without email", []string{"create-user", "--name", "Admin"}, `required flag(s) "email" not set`},
+		{"create-user without name", []string{"create-user", "--email", "admin@example.com"}, `required flag(s) "name" not set`},
+		{"create-user invalid email", []string{"create-user", "--email", "admin", "--name", "Admin"}, "invalid email address: admin"},
+		{"create-user invalid locale", []string{"create-user", "--email", "admin@example.com", "--name", "Admin", "--locale", "xx"}, "invalid locale: xx"},
+		{"create-user weak password", []string{"create-user", "--email", "admin@example.com", "--name", "Admin", "--password", "secret"}, "password must be 12-72 characters"},
+		{"promote without email", []string{"promote"}, "accepts 1 arg(s), received 0"},
+		{"reset-password weak password", []string{"reset-password", "admin@example.com", "--password", "password123"}, "password must be 12-72 characters"},
+		{"disable-2fa with two emails", []string{"disable-2fa", "a@example.com", "b@example.com"}, "accepts 1 arg(s), received 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newRootCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("whento-admin %s error = %v, want %q", strings.Join(tt.args, " "), err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolvePassword(t *testing.T) {
+	password, generated, err := resolvePassword("Correct-Horse-42")
+	if err != nil || generated || password != "Correct-Horse-42" {
+		t.Errorf("resolvePassword(valid) = %q, %v, %v", password, generated, err)
+	}
+
+	password, generated, err = resolvePassword("")
+	if err != nil || !generated || len(password) != 20 {
+		t.Errorf("resolvePassword(empty) = %q, %v, %v", password, generated, err)
+	}
+
+	if _, _, err := resolvePassword("short"); err == nil {
+		t.Error("resolvePassword(weak) error = nil")
+	}
+	if _, _, err := resolvePassword(strings.Repeat("Aa1!", 19)); err == nil {
+		t.Error("resolvePassword(longer than bcrypt limit) error = nil")
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 50 {
+		password, err := generatePassword(20)
+		if err != nil {
+			t.Fatalf("generatePassword() error = %v", err)
+		}
+		if len(password) != 20 {
+			t.Errorf("generatePassword() length = %d, want 20", len(password))
+		}
+		if err := validator.ValidateVar(password, "required,strongpassword,max=72"); err != nil {
+			t.Errorf("generatePassword() = %q does not satisfy the password policy", password)
+		}
+		if seen[password] {
+			t.Errorf("generatePassword() returned %q twice", password)
+		}
+		seen[password] = true
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * SPDX-License-Identifier: BSL-1.1
+ */
+
+// Service worker showing the Web Push notifications of the server
+// Payload: { title, body, url, tag }
+
+self.addEventListener('push', (event) => {
+  let message = {}
+  try {
+    message = event.data ? event.data.json() : {}
+  } catch {
+    message = { body: event.data ? event.data.text() : '' }
+  }
+
+  event.waitUntil(
+    self.registration.showNotification(message.title || 'WhenTo', {
+      body: message.body || '',
+      icon: '/logo.png',
+      tag: message.tag,
+      data: { url: message.url || '/' },
+    })
+  )
+})
+
+self.addEventListener('notificationclick', (event) => {
+  event.notification.close()
+  const url = event.notification.data && event.notification.data.url
+
+  event.waitUntil(
+    self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((windows) => {
+      // Reuse a tab already showing the page
+      for (const client of windows) {
+        if (client.url === url && 'focus' in client) {
+          return client.focus()
+        }
+      }
+      return self.clients.openWindow(url || '/')
+    })
+  )
+})
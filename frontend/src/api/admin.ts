@@ -10,6 +10,21 @@ import type { User, CalendarWithParticipants } from '@/types'
 export interface UsersListResponse {
   users: User[]
   total: number
+  limit: number
+  offset: number
+}
+
+export interface ListUsersParams {
+  q?: string
+  role?: 'user' | 'admin'
+  verified?: boolean
+  has_2fa?: boolean
+  plan?: string
+  created_after?: string
+  created_before?: string
+  deleted?: boolean
+  limit?: number
+  offset?: number
 }
 
 export interface UpdateRoleRequest {
@@ -23,10 +38,18 @@ export interface AdminDisable2FAResponse {
 
 export const adminApi = {
   /**
-   * List all users (admin only)
+   * List users matching the given filters, one page at a time (admin only)
    */
-  async listUsers(): Promise<UsersListResponse> {
-    return apiClient.get<UsersListResponse>('/auth/admin/users')
+  async listUsers(filters: ListUsersParams = {}): Promise<UsersListResponse> {
+    const params = new URLSearchParams()
+    for (const [key, value] of Object.entries(filters)) {
+      if (value !== undefined && value !== '') params.append(key, String(value))
+    }
+
+    const queryString = params.toString()
+    return apiClient.get<UsersListResponse>(
+      `/auth/admin/users${queryString ? `?${queryString}` : ''}`
+    )
   },
 
   /**
@@ -43,6 +66,13 @@ export const adminApi = {
     return apiClient.delete<void>(`/auth/admin/users/${userId}`)
   },
 
+  /**
+   * Restore a deleted user that wasn't purged yet (admin only)
+   */
+  async restoreUser(userId: string): Promise<void> {
+    return apiClient.post<void>(`/auth/admin/users/${userId}/restore`)
+  },
+
   /**
    * Get all calendars for a specific user (admin only)
    */
@@ -50,6 +80,15 @@ export const adminApi = {
     return apiClient.get<CalendarWithParticipants[]>(`/calendars/admin/users/${userId}/calendars`)
   },
 
+  /**
+   * Transfer a calendar to another user, keeping its tokens and availabilities (admin only)
+   */
+  async transferCalendar(calendarId: string, userId: string): Promise<CalendarWithParticipants> {
+    return apiClient.post<CalendarWithParticipants>(`/calendars/admin/${calendarId}/transfer`, {
+      user_id: userId,
+    })
+  },
+
   /**
    * Disable TOTP 2FA authentication for a user (admin only)
    */
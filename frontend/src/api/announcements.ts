@@ -0,0 +1,84 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * SPDX-License-Identifier: BSL-1.1
+ */
+
+import { apiClient as client } from './client'
+
+export type AnnouncementLevel = 'info' | 'warning'
+
+export interface AnnouncementBanner {
+  id: string
+  title: string
+  body: string
+  level: AnnouncementLevel
+  created_at: string
+}
+
+export interface AnnouncementContent {
+  title: string
+  body: string
+}
+
+export interface Announcement {
+  id: string
+  title: string
+  body: string
+  translations: Record<string, AnnouncementContent>
+  level: AnnouncementLevel
+  audience: string
+  banner: boolean
+  ends_at?: string
+  email_status: 'none' | 'pending' | 'sending' | 'sent'
+  emails_sent: number
+  emails_failed: number
+  created_by?: string
+  created_at: string
+}
+
+export interface CreateAnnouncementRequest {
+  title: string
+  body: string
+  translations?: Record<string, AnnouncementContent>
+  level?: AnnouncementLevel
+  audience?: string
+  banner: boolean
+  email: boolean
+  ends_at?: string
+}
+
+/**
+ * Get the announcement banners of the current user, in their language
+ */
+export async function getAnnouncementBanners(): Promise<AnnouncementBanner[]> {
+  return await client.get<AnnouncementBanner[]>('/announcements')
+}
+
+/**
+ * Hide the banner of an announcement for the current user
+ */
+export async function dismissAnnouncement(id: string): Promise<void> {
+  await client.post(`/announcements/${id}/dismiss`)
+}
+
+/**
+ * List all announcements with the progress of their emails (admin only)
+ */
+export async function listAnnouncements(): Promise<Announcement[]> {
+  return await client.get<Announcement[]>('/auth/admin/announcements')
+}
+
+/**
+ * Publish an announcement as a banner and/or an email (admin only)
+ */
+export async function createAnnouncement(data: CreateAnnouncementRequest): Promise<Announcement> {
+  return await client.post<Announcement>('/auth/admin/announcements', data)
+}
+
+/**
+ * Delete an announcement, stopping the emails not sent yet (admin only)
+ */
+export async function deleteAnnouncement(id: string): Promise<void> {
+  await client.delete(`/auth/admin/announcements/${id}`)
+}
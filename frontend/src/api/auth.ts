@@ -5,7 +5,7 @@
  */
 
 import { apiClient } from './client'
-import type { User, LoginRequest, RegisterRequest, AuthResponse } from '@/types'
+import type { User, LoginRequest, RegisterRequest, AuthResponse, SSOConfig } from '@/types'
 
 export const authApi = {
   async register(data: RegisterRequest): Promise<AuthResponse> {
@@ -58,4 +58,20 @@ export const authApi = {
   async checkMagicLinkAvailable(): Promise<{ available: boolean }> {
     return apiClient.get<{ available: boolean }>('/auth/magic-link/available')
   },
+
+  async getSSOConfig(): Promise<SSOConfig> {
+    return apiClient.get<SSOConfig>('/auth/sso')
+  },
+
+  async authorizeOIDC(): Promise<{ auth_url: string }> {
+    return apiClient.post<{ auth_url: string }>('/auth/oidc/authorize')
+  },
+
+  async completeOIDC(code: string, state: string): Promise<AuthResponse> {
+    return apiClient.post<AuthResponse>('/auth/oidc/callback', { code, state })
+  },
+
+  async redeemSSOTicket(ticket: string): Promise<AuthResponse> {
+    return apiClient.post<AuthResponse>('/auth/sso/ticket', { ticket })
+  },
 }
@@ -8,8 +8,17 @@ import { apiClient } from './client'
 import type {
   Availability,
   CreateAvailabilityRequest,
+  CreateDateCommentRequest,
+  DateComment,
+  RSVP,
+  RSVPStatus,
+  BusyFeed,
+  BulkAvailabilityRequest,
+  BulkAvailabilityResponse,
   RecurrenceWithExceptions,
   CreateRecurrenceRequest,
+  CreateExceptionRangeRequest,
+  RecurrenceException,
   DateAvailabilitySummary,
   ParticipantAvailabilitiesResponse,
 } from '@/types'
@@ -45,6 +54,17 @@ export const availabilitiesApi = {
     )
   },
 
+  async bulk(
+    token: string,
+    participantId: string,
+    data: BulkAvailabilityRequest
+  ): Promise<BulkAvailabilityResponse> {
+    return apiClient.post<BulkAvailabilityResponse>(
+      `/availabilities/calendar/${token}/participant/${participantId}/bulk`,
+      data
+    )
+  },
+
   async update(
     token: string,
     participantId: string,
@@ -117,6 +137,18 @@ export const availabilitiesApi = {
     )
   },
 
+  // Excludes a whole date range (e.g. a vacation) from the participant's recurrences
+  async createExceptionRange(
+    token: string,
+    participantId: string,
+    data: CreateExceptionRangeRequest
+  ): Promise<RecurrenceException[]> {
+    return apiClient.post<RecurrenceException[]>(
+      `/availabilities/calendar/${token}/participant/${participantId}/exceptions`,
+      data
+    )
+  },
+
   async deleteException(
     token: string,
     participantId: string,
@@ -133,6 +165,49 @@ export const availabilitiesApi = {
     return apiClient.get<DateAvailabilitySummary>(`/availabilities/calendar/${token}/dates/${date}`)
   },
 
+  // Date comments
+  async createComment(
+    token: string,
+    date: string,
+    data: CreateDateCommentRequest
+  ): Promise<DateComment> {
+    return apiClient.post<DateComment>(`/availabilities/calendar/${token}/dates/${date}/comments`, data)
+  },
+
+  async deleteComment(token: string, participantId: string, commentId: string): Promise<void> {
+    return apiClient.delete<void>(
+      `/availabilities/calendar/${token}/participant/${participantId}/comments/${commentId}`
+    )
+  },
+
+  // Answers to the events, apart from availability
+  async setRSVP(token: string, participantId: string, date: string, status: RSVPStatus): Promise<RSVP> {
+    return apiClient.put<RSVP>(`/availabilities/calendar/${token}/participant/${participantId}/rsvp/${date}`, {
+      status,
+    })
+  },
+
+  async deleteRSVP(token: string, participantId: string, date: string): Promise<void> {
+    return apiClient.delete<void>(`/availabilities/calendar/${token}/participant/${participantId}/rsvp/${date}`)
+  },
+
+  // External busy feeds, flagging conflicting availabilities
+  async getBusyFeeds(token: string, participantId: string): Promise<BusyFeed[]> {
+    return apiClient.get<BusyFeed[]>(`/availabilities/calendar/${token}/participant/${participantId}/busy-feeds`)
+  },
+
+  async addBusyFeed(token: string, participantId: string, url: string): Promise<BusyFeed> {
+    return apiClient.post<BusyFeed>(`/availabilities/calendar/${token}/participant/${participantId}/busy-feeds`, {
+      url,
+    })
+  },
+
+  async deleteBusyFeed(token: string, participantId: string, feedId: string): Promise<void> {
+    return apiClient.delete<void>(
+      `/availabilities/calendar/${token}/participant/${participantId}/busy-feeds/${feedId}`
+    )
+  },
+
   async getRangeSummary(
     token: string,
     startDate: string,
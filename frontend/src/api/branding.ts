@@ -0,0 +1,21 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * SPDX-License-Identifier: BSL-1.1
+ */
+
+import { apiClient as client } from './client'
+
+export interface Branding {
+  product_name: string
+  logo_url?: string
+  primary_color: string
+  footer_text: string
+}
+
+/**
+ * Get the instance branding (public endpoint)
+ */
+export async function getBranding(): Promise<Branding> {
+  return await client.get<Branding>('/branding')
+}
@@ -8,11 +8,16 @@ import { apiClient } from './client'
 import type {
   Calendar,
   CalendarWithParticipants,
+  CalendarsPage,
+  ListCalendarsParams,
   CreateCalendarRequest,
   UpdateCalendarRequest,
   Participant,
   CreateParticipantRequest,
   UpdateParticipantRequest,
+  CSVImportResponse,
+  ConfirmationStatus,
+  EventConfirmation,
 } from '@/types'
 
 export const calendarsApi = {
@@ -21,6 +26,16 @@ export const calendarsApi = {
     return apiClient.get<CalendarWithParticipants[]>('/calendars')
   },
 
+  async list(params: ListCalendarsParams = {}): Promise<CalendarsPage> {
+    const query = new URLSearchParams()
+    if (params.q) query.set('q', params.q)
+    if (params.sort) query.set('sort', params.sort)
+    query.set('page', String(params.page ?? 1))
+    if (params.per_page) query.set('per_page', String(params.per_page))
+    if (params.participants === false) query.set('participants', 'false')
+    return apiClient.get<CalendarsPage>(`/calendars?${query.toString()}`)
+  },
+
   async getById(id: string): Promise<CalendarWithParticipants> {
     return apiClient.get<CalendarWithParticipants>(`/calendars/${id}`)
   },
@@ -69,12 +84,63 @@ export const calendarsApi = {
     return apiClient.delete<void>(`/calendars/${calendarId}/participants/${participantId}`)
   },
 
+  // CSV columns: name, email, locale (or any order after a header line)
+  async importParticipantsCSV(
+    calendarId: string,
+    csv: Blob | string,
+    sendInvites: boolean
+  ): Promise<CSVImportResponse> {
+    return apiClient.post<CSVImportResponse>(`/calendars/${calendarId}/participants/import/csv`, csv, {
+      headers: { 'Content-Type': 'text/csv' },
+      params: { send_invites: sendInvites },
+    })
+  },
+
+  async regenerateParticipantToken(calendarId: string, participantId: string): Promise<Participant> {
+    return apiClient.post<Participant>(
+      `/calendars/${calendarId}/participants/${participantId}/regenerate-token`
+    )
+  },
+
+  // Event confirmations of calendars requiring them (owner only)
+  async listConfirmations(calendarId: string, status?: ConfirmationStatus): Promise<EventConfirmation[]> {
+    const params = status ? { status } : {}
+    return apiClient.get<EventConfirmation[]>(`/calendars/${calendarId}/confirmations`, { params })
+  },
+
+  async decideConfirmation(
+    calendarId: string,
+    date: string,
+    status: Exclude<ConfirmationStatus, 'pending'>
+  ): Promise<EventConfirmation> {
+    return apiClient.put<EventConfirmation>(`/calendars/${calendarId}/confirmations/${date}`, { status })
+  },
+
+  // Confirmation link emailed to the owner (no auth required)
+  async getConfirmationByToken(token: string): Promise<EventConfirmation> {
+    return apiClient.get<EventConfirmation>(`/calendars/confirmations/${token}`)
+  },
+
+  async decideConfirmationByToken(
+    token: string,
+    status: Exclude<ConfirmationStatus, 'pending'>
+  ): Promise<EventConfirmation> {
+    return apiClient.post<EventConfirmation>(`/calendars/confirmations/${token}`, { status })
+  },
+
   // Public calendar view (no auth required)
   async getPublic(token: string, participantId?: string): Promise<CalendarWithParticipants> {
     const params = participantId ? { participant_id: participantId } : {}
     return apiClient.get<CalendarWithParticipants>(`/calendars/public/${token}`, { params })
   },
 
+  // Personal link of a participant never claimed, on calendars not locking their participants
+  async claimParticipantLink(token: string, participantId: string): Promise<{ access_token: string }> {
+    return apiClient.post<{ access_token: string }>(
+      `/calendars/public/${token}/participants/${participantId}/claim`
+    )
+  },
+
   async getSummary(token: string): Promise<any> {
     return apiClient.get<any>(`/calendars/public/${token}/summary`)
   },
@@ -136,6 +136,11 @@ class ApiClient {
     return response.data.data as T
   }
 
+  async put<T>(url: string, data?: any, config?: any): Promise<T> {
+    const response = await this.client.put<ApiResponse<T>>(url, data, config)
+    return response.data.data as T
+  }
+
   async patch<T>(url: string, data?: any, config?: any): Promise<T> {
     const response = await this.client.patch<ApiResponse<T>>(url, data, config)
     return response.data.data as T
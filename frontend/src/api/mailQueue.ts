@@ -0,0 +1,37 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * SPDX-License-Identifier: BSL-1.1
+ */
+
+import { apiClient as client } from './client'
+
+export type UndeliveredStatus = 'failed' | 'bounced'
+
+export interface QueuedEmail {
+  id: string
+  recipients: string[]
+  subject: string
+  html: boolean
+  status: 'pending' | 'sent' | UndeliveredStatus
+  attempts: number
+  error?: string
+  next_attempt_at?: string
+  created_at: string
+  sent_at?: string
+}
+
+/**
+ * List the latest failed and bounced emails, or those of a status (admin only)
+ */
+export async function listUndeliveredEmails(status?: UndeliveredStatus): Promise<QueuedEmail[]> {
+  const query = status ? `?status=${status}` : ''
+  return await client.get<QueuedEmail[]>(`/auth/admin/mail-queue${query}`)
+}
+
+/**
+ * Queue a failed or bounced email again (admin only)
+ */
+export async function resendEmail(id: string): Promise<void> {
+  await client.post(`/auth/admin/mail-queue/${id}/resend`)
+}
@@ -0,0 +1,38 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * SPDX-License-Identifier: BSL-1.1
+ */
+
+import { apiClient as client } from './client'
+
+export interface MaintenanceStatus {
+  enabled: boolean
+  message?: string
+}
+
+export interface AdminMaintenanceStatus extends MaintenanceStatus {
+  updated_by?: string
+  updated_at: string
+}
+
+/**
+ * Get the maintenance mode of the instance (public endpoint)
+ */
+export async function getMaintenanceStatus(): Promise<MaintenanceStatus> {
+  return await client.get<MaintenanceStatus>('/maintenance')
+}
+
+/**
+ * Get the maintenance mode with the admin who last changed it (admin only)
+ */
+export async function getAdminMaintenanceStatus(): Promise<AdminMaintenanceStatus> {
+  return await client.get<AdminMaintenanceStatus>('/auth/admin/maintenance')
+}
+
+/**
+ * Turn the maintenance mode on or off (admin only)
+ */
+export async function setMaintenance(enabled: boolean, message: string): Promise<AdminMaintenanceStatus> {
+  return await client.put<AdminMaintenanceStatus>('/auth/admin/maintenance', { enabled, message })
+}
@@ -7,13 +7,17 @@
 
 import { apiClient } from './client'
 
+export type MFAMethod = 'totp' | 'email' | 'webauthn'
+
 export interface MFAStatus {
   enabled: boolean
+  method?: MFAMethod
 }
 
 export interface TOTPSetup {
-  secret: string
-  qr_code_url: string
+  method: MFAMethod
+  secret?: string
+  qr_code_url?: string
   backup_codes: string[]
 }
 
@@ -26,25 +30,26 @@ export const mfaApi = {
   },
 
   /**
-   * Begin MFA setup - generate TOTP secret and QR code
+   * Begin MFA setup - generate TOTP secret and QR code, or send a code by email
    */
-  async beginSetup(): Promise<TOTPSetup> {
-    return apiClient.post('/mfa/setup/begin')
+  async beginSetup(method: MFAMethod = 'totp'): Promise<TOTPSetup> {
+    return apiClient.post('/mfa/setup/begin', { method })
   },
 
   /**
-   * Finish MFA setup - verify TOTP code and enable MFA
+   * Finish MFA setup - verify TOTP or email code and enable MFA
    */
   async finishSetup(code: string): Promise<void> {
     await apiClient.post('/mfa/setup/finish', { code })
   },
 
   /**
-   * Verify MFA code during login (2FA flow)
+   * Verify MFA code, or security key assertion, during login (2FA flow)
    */
   async verify(
     tempToken: string,
-    code: string
+    code: string,
+    credential?: object
   ): Promise<{
     access_token: string
     refresh_token: string
@@ -53,10 +58,18 @@ export const mfaApi = {
   }> {
     return apiClient.post('/auth/mfa/verify', {
       temp_token: tempToken,
-      code,
+      code: credential ? undefined : code,
+      credential,
     })
   },
 
+  /**
+   * Send a verification code by email during login (email MFA method)
+   */
+  async sendEmailCode(tempToken: string): Promise<void> {
+    await apiClient.post('/auth/mfa/email/send', { temp_token: tempToken })
+  },
+
   /**
    * Disable MFA - user is already authenticated via JWT
    */
@@ -7,6 +7,7 @@
 
 import { apiClient } from './client'
 import type {
+  NotificationDelivery,
   NotifyConfig,
   NotifyConfigResponse,
   ParticipantEmailResponse,
@@ -39,6 +40,15 @@ export const updateNotifyConfig = async (
   return response.config;
 };
 
+/**
+ * Get the delivery status of the latest notifications of a calendar
+ */
+export const getNotificationDeliveries = async (calendarId: string): Promise<NotificationDelivery[]> => {
+  return apiClient.get<NotificationDelivery[]>(
+    `/calendars/${calendarId}/notify-config/deliveries`
+  );
+};
+
 /**
  * Add email address to a participant for notifications
  */
@@ -86,11 +96,28 @@ export const getDefaultNotifyConfig = (): NotifyConfig => {
       email: { enabled: true },
       discord: { enabled: false },
       slack: { enabled: false },
+      rocketchat: { enabled: false },
+      mattermost: { enabled: false },
       telegram: { enabled: false },
+      webhook: { enabled: false },
+      push: { enabled: false },
+      apprise: { enabled: false },
     },
     reminders: {
       enabled: false,
       hours_before: 24,
     },
+    digest: {
+      mode: 'immediate',
+      hour: 18,
+      weekday: 1,
+    },
+    activity: {
+      enabled: false,
+    },
+    templates: {
+      message: {},
+      text: {},
+    },
   };
 };
@@ -49,10 +49,15 @@ export interface Passkey {
   id: string
   user_id: string
   name: string
+  second_factor: boolean
   created_at: string
   last_used_at: string | null
 }
 
+export interface SecurityKey extends Passkey {
+  backup_codes?: string[] // Returned when the key enables 2FA
+}
+
 interface BeginRegistrationResponse {
   publicKey: {
     challenge: string
@@ -86,12 +91,44 @@ interface BeginAuthenticationResponse {
   challengeId: string
 }
 
+// Convert assertion options from the server to the WebAuthn API format
+function toRequestOptions(options: BeginAuthenticationResponse['publicKey']): PublicKeyCredentialRequestOptions {
+  const publicKey: any = { ...options }
+  publicKey.challenge = base64ToArrayBuffer(options.challenge)
+  if (options.allowCredentials) {
+    publicKey.allowCredentials = options.allowCredentials.map((cred) => ({
+      ...cred,
+      id: base64ToArrayBuffer(cred.id),
+    }))
+  }
+  return publicKey
+}
+
+// Serialize a WebAuthn assertion for the server
+export function serializeAssertion(credential: PublicKeyCredential) {
+  const response = credential.response as AuthenticatorAssertionResponse
+  return {
+    id: credential.id,
+    rawId: arrayBufferToBase64url(credential.rawId),
+    type: credential.type,
+    response: {
+      clientDataJSON: arrayBufferToBase64url(response.clientDataJSON),
+      authenticatorData: arrayBufferToBase64url(response.authenticatorData),
+      signature: arrayBufferToBase64url(response.signature),
+      userHandle: response.userHandle ? arrayBufferToBase64url(response.userHandle) : null,
+    },
+  }
+}
+
 export const passkeyApi = {
   /**
    * Begin passkey registration - get WebAuthn creation options
+   * With secondFactor, registers a security key verifying password logins instead
    */
-  async beginRegistration(): Promise<PublicKeyCredentialCreationOptions> {
-    const response = await apiClient.post<BeginRegistrationResponse>('/passkey/register/begin')
+  async beginRegistration(secondFactor = false): Promise<PublicKeyCredentialCreationOptions> {
+    const response = await apiClient.post<BeginRegistrationResponse>(
+      secondFactor ? '/passkey/security-key/begin' : '/passkey/register/begin'
+    )
 
     // Convert base64 strings to ArrayBuffers for WebAuthn API
     const publicKey: any = { ...response.publicKey }
@@ -115,7 +152,7 @@ export const passkeyApi = {
   /**
    * Finish passkey registration - send WebAuthn credential to server
    */
-  async finishRegistration(credential: PublicKeyCredential): Promise<Passkey> {
+  async finishRegistration(credential: PublicKeyCredential, secondFactor = false): Promise<SecurityKey> {
     const response = credential.response as AuthenticatorAttestationResponse
 
     const body = {
@@ -128,7 +165,7 @@ export const passkeyApi = {
       },
     }
 
-    return apiClient.post('/passkey/register/finish', body)
+    return apiClient.post(secondFactor ? '/passkey/security-key/finish' : '/passkey/register/finish', body)
   },
 
   /**
@@ -165,23 +202,23 @@ export const passkeyApi = {
     )
 
     // Convert base64 strings to ArrayBuffers for WebAuthn API
-    const publicKey: any = { ...response.publicKey }
-    publicKey.challenge = base64ToArrayBuffer(response.publicKey.challenge)
-
-    // Convert allowed credentials if present (should be empty for discoverable)
-    if (response.publicKey.allowCredentials) {
-      publicKey.allowCredentials = response.publicKey.allowCredentials.map((cred) => ({
-        ...cred,
-        id: base64ToArrayBuffer(cred.id),
-      }))
-    }
-
     return {
-      options: publicKey,
+      options: toRequestOptions(response.publicKey),
       challengeId: response.challengeId,
     }
   },
 
+  /**
+   * Begin security key verification of a password login (2FA flow)
+   */
+  async beginSecondFactor(tempToken: string): Promise<PublicKeyCredentialRequestOptions> {
+    const response = await apiClient.post<Omit<BeginAuthenticationResponse, 'challengeId'>>(
+      '/auth/mfa/webauthn/begin',
+      { temp_token: tempToken }
+    )
+    return toRequestOptions(response.publicKey)
+  },
+
   /**
    * Finish passkey authentication - send WebAuthn assertion to server
    * Requires challengeId from beginAuthentication
@@ -196,20 +233,9 @@ export const passkeyApi = {
     user: any
     require_mfa?: boolean
     temp_token?: string
+    mfa_method?: 'totp' | 'email' | 'webauthn'
   }> {
-    const response = credential.response as AuthenticatorAssertionResponse
-
-    const body = {
-      id: credential.id,
-      rawId: arrayBufferToBase64url(credential.rawId),
-      type: credential.type,
-      response: {
-        clientDataJSON: arrayBufferToBase64url(response.clientDataJSON),
-        authenticatorData: arrayBufferToBase64url(response.authenticatorData),
-        signature: arrayBufferToBase64url(response.signature),
-        userHandle: response.userHandle ? arrayBufferToBase64url(response.userHandle) : null,
-      },
-    }
+    const body = serializeAssertion(credential)
 
     // Pass challengeId in header to avoid polluting WebAuthn body format
     return apiClient.post('/auth/passkey/login/finish', body, {
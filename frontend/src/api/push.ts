@@ -0,0 +1,54 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * Licensed under the Business Source License 1.1
+ * See LICENSE file for details
+ */
+
+import { apiClient } from './client'
+import type { PushPublicKey, PushSubscriptionPayload } from '@/types'
+
+/**
+ * Get the VAPID public key browsers subscribe with (enabled is false when Web Push is not configured)
+ */
+export const getPushPublicKey = async (): Promise<PushPublicKey> => {
+  return await apiClient.get<PushPublicKey>('/push/public-key')
+}
+
+/**
+ * Subscribe this browser to the notifications of the current user
+ */
+export const subscribeUserPush = async (subscription: PushSubscriptionPayload): Promise<void> => {
+  await apiClient.post('/push/subscriptions', subscription)
+}
+
+/**
+ * Unsubscribe this browser from the notifications of the current user
+ */
+export const unsubscribeUserPush = async (endpoint: string): Promise<void> => {
+  await apiClient.delete('/push/subscriptions', { data: { endpoint } })
+}
+
+/**
+ * Subscribe this browser to the notifications of a participant
+ */
+export const subscribeParticipantPush = async (
+  token: string,
+  participantId: string,
+  subscription: PushSubscriptionPayload
+): Promise<void> => {
+  await apiClient.post(`/calendars/${token}/participants/${participantId}/push`, subscription)
+}
+
+/**
+ * Unsubscribe this browser from the notifications of a participant
+ */
+export const unsubscribeParticipantPush = async (
+  token: string,
+  participantId: string,
+  endpoint: string
+): Promise<void> => {
+  await apiClient.delete(`/calendars/${token}/participants/${participantId}/push`, {
+    data: { endpoint },
+  })
+}
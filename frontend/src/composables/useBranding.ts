@@ -0,0 +1,56 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * SPDX-License-Identifier: BSL-1.1
+ */
+
+/**
+ * Composable exposing the instance branding (white-label for self-hosted instances)
+ *
+ * Usage:
+ * ```ts
+ * const { productName, logoUrl, footerText } = useBranding()
+ * ```
+ *
+ * Branding is fetched once at startup with `loadBranding()` and falls back to
+ * the WhenTo defaults when the endpoint is unavailable.
+ */
+
+import { computed, readonly, ref } from 'vue'
+import { getBranding, type Branding } from '@/api/branding'
+
+const DEFAULT_PRIMARY_COLOR = '#4F46E5'
+
+const branding = ref<Branding>({
+  product_name: 'WhenTo',
+  primary_color: DEFAULT_PRIMARY_COLOR,
+  footer_text: '',
+})
+
+export async function loadBranding() {
+  try {
+    branding.value = await getBranding()
+  } catch {
+    return
+  }
+
+  // Only override the theme palette when a custom color is configured
+  if (branding.value.primary_color.toLowerCase() !== DEFAULT_PRIMARY_COLOR.toLowerCase()) {
+    const root = document.documentElement.style
+    root.setProperty('--color-primary-500', branding.value.primary_color)
+    root.setProperty('--color-primary-600', branding.value.primary_color)
+  }
+}
+
+export function useBranding() {
+  const productName = computed(() => branding.value.product_name)
+  const logoUrl = computed(() => branding.value.logo_url || '/logo.png')
+  const footerText = computed(() => branding.value.footer_text)
+
+  return {
+    branding: readonly(branding),
+    productName,
+    logoUrl,
+    footerText,
+  }
+}
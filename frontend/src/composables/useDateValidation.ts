@@ -5,6 +5,7 @@
  */
 
 import Holidays from 'date-holidays'
+import type { BlackoutPeriod, CustomHoliday } from '@/types'
 
 // Type for holidays returned by date-holidays
 interface Holiday {
@@ -16,7 +17,16 @@ interface Holiday {
   rule?: string
 }
 
-// Cache for Holidays instances by country
+// Where the public holidays come from: the explicit holiday country (and region) when set,
+// otherwise the country of the timezone
+export interface HolidayLocation {
+  timezone: string
+  country?: string // ISO 3166-1 code
+  region?: string // ISO 3166-2 subdivision of the country, e.g. "BY"
+  countries?: string[] // Other countries whose public holidays also count, e.g. "BE" or "DE-BY"
+}
+
+// Cache for Holidays instances by holiday code ("DE" or "DE-BY")
 const holidaysCache = new Map<string, Holidays>()
 
 // Cache for timezone → country code mapping (generated from date-holidays)
@@ -78,11 +88,47 @@ function getCountryFromTimezone(timezone: string): string | null {
 }
 
 /**
- * Gets or creates a Holidays instance for a given country
+ * Gets the holiday code of a location (a timezone alone or an explicit country and region)
+ * Similar logic to backend datevalidation.HolidayCode: "DE-BY", "DE" or the country of the timezone
+ */
+function resolveHolidayCode(location: HolidayLocation | string): string | null {
+  if (typeof location === 'string') {
+    return getCountryFromTimezone(location)
+  }
+  if (!location.country) {
+    return getCountryFromTimezone(location.timezone)
+  }
+  const country = location.country.toUpperCase()
+  return location.region ? `${country}-${location.region.toUpperCase()}` : country
+}
+
+/**
+ * Gets the holiday codes of a location: its own country first, then its other countries
+ * A date is a holiday when it is one in any of them
+ */
+function resolveHolidayCodes(location: HolidayLocation | string): string[] {
+  const code = resolveHolidayCode(location)
+  const codes = code ? [code] : []
+  if (typeof location !== 'string') {
+    codes.push(...(location.countries || []).map(country => country.toUpperCase()))
+  }
+  return codes
+}
+
+/**
+ * Creates a Holidays instance for a holiday code ("DE" or "DE-BY")
+ */
+function createHolidays(code: string, options?: { languages?: string[] }): Holidays {
+  const [country, region] = code.split('-')
+  return region ? new Holidays(country, region, options) : new Holidays(country, options)
+}
+
+/**
+ * Gets or creates a Holidays instance for a given holiday code
  */
 function getHolidaysInstance(countryCode: string): Holidays {
   if (!holidaysCache.has(countryCode)) {
-    holidaysCache.set(countryCode, new Holidays(countryCode))
+    holidaysCache.set(countryCode, createHolidays(countryCode))
   }
   return holidaysCache.get(countryCode)!
 }
@@ -111,12 +157,89 @@ function isHoliday(date: Date, countryCode: string): boolean {
 }
 
 /**
- * Checks if a date is the day before a holiday
+ * Formats a date as YYYY-MM-DD in local time
  */
-function isHolidayEve(date: Date, countryCode: string): boolean {
+function toDateString(date: Date): string {
+  const year = date.getFullYear()
+  const month = String(date.getMonth() + 1).padStart(2, '0')
+  const day = String(date.getDate()).padStart(2, '0')
+  return `${year}-${month}-${day}`
+}
+
+/**
+ * Gets the custom holiday falling on a date, if any
+ * Yearly holidays repeat on the same day every year from their date on
+ */
+function findCustomHoliday(
+  date: Date,
+  customHolidays: CustomHoliday[] | undefined
+): CustomHoliday | null {
+  if (!customHolidays?.length) return null
+  const day = toDateString(date)
+  return (
+    customHolidays.find(holiday =>
+      holiday.yearly
+        ? day >= holiday.date && day.slice(4) === holiday.date.slice(4)
+        : day === holiday.date
+    ) || null
+  )
+}
+
+/**
+ * Checks if a date is a holiday in one of the countries or a custom holiday
+ */
+function isAnyHoliday(
+  date: Date,
+  countryCodes: string[],
+  customHolidays: CustomHoliday[] | undefined
+): boolean {
+  return (
+    countryCodes.some(countryCode => isHoliday(date, countryCode)) ||
+    findCustomHoliday(date, customHolidays) !== null
+  )
+}
+
+/**
+ * Checks if a date is the day before a holiday in one of the countries or a custom holiday
+ */
+function isAnyHolidayEve(
+  date: Date,
+  countryCodes: string[],
+  customHolidays: CustomHoliday[] | undefined
+): boolean {
   const nextDay = new Date(date)
   nextDay.setDate(nextDay.getDate() + 1)
-  return isHoliday(nextDay, countryCode)
+  return isAnyHoliday(nextDay, countryCodes, customHolidays)
+}
+
+/**
+ * Gets the name of an official holiday (type "public" only) in a country
+ */
+function getPublicHolidayName(date: Date, countryCode: string, locale: string): string | null {
+  try {
+    // We can't use cache because we need the locale
+    // Create a temporary instance with the locale
+    const hd = createHolidays(countryCode, { languages: [locale] })
+    const holidays = hd.isHoliday(date) as Holiday[] | Holiday | false
+    if (holidays && Array.isArray(holidays) && holidays.length > 0) {
+      // Return only public holidays
+      const publicHoliday = holidays.find(h => h.type === 'public')
+      return publicHoliday ? publicHoliday.name : null
+    }
+    // If it's a single object, check its type
+    if (
+      holidays &&
+      typeof holidays === 'object' &&
+      !Array.isArray(holidays) &&
+      holidays.type === 'public'
+    ) {
+      return holidays.name
+    }
+  } catch (_error) {
+    return null
+  }
+
+  return null
 }
 
 /**
@@ -129,16 +252,17 @@ export function useDateValidation() {
    */
   const isDateAllowed = (
     date: Date,
-    timezone: string,
+    location: HolidayLocation | string,
     allowedWeekdays: number[],
     holidaysPolicy: 'ignore' | 'allow' | 'block',
-    allowHolidayEves: boolean
+    allowHolidayEves: boolean,
+    customHolidays?: CustomHoliday[]
   ): boolean => {
-    // Get country code to check holidays
-    const countryCode = getCountryFromTimezone(timezone)
+    // Get country (and region) codes to check holidays
+    const countryCodes = resolveHolidayCodes(location)
 
-    // Check if it's a holiday (if we have the country code)
-    const isHolidayDate = countryCode ? isHoliday(date, countryCode) : false
+    // Check if it's a holiday (in one of the countries we know of, or a custom holiday)
+    const isHolidayDate = isAnyHoliday(date, countryCodes, customHolidays)
 
     // Apply holiday policy
     if (holidaysPolicy === 'block' && isHolidayDate) {
@@ -158,7 +282,7 @@ export function useDateValidation() {
     }
 
     // If day of week is not allowed, check holiday eve exception
-    if (countryCode && allowHolidayEves && isHolidayEve(date, countryCode)) {
+    if (allowHolidayEves && isAnyHolidayEve(date, countryCodes, customHolidays)) {
       return true
     }
 
@@ -166,60 +290,89 @@ export function useDateValidation() {
   }
 
   /**
-   * Checks if a date is a holiday
+   * Checks if a date is a holiday (country or custom)
    * Useful for visual display
    */
-  const checkIsHoliday = (date: Date, timezone: string): boolean => {
-    const countryCode = getCountryFromTimezone(timezone)
-    if (!countryCode) return false
-    return isHoliday(date, countryCode)
+  const checkIsHoliday = (
+    date: Date,
+    location: HolidayLocation | string,
+    customHolidays?: CustomHoliday[]
+  ): boolean => {
+    return isAnyHoliday(date, resolveHolidayCodes(location), customHolidays)
   }
 
   /**
-   * Checks if a date is a holiday eve
+   * Checks if a date is a holiday eve (country or custom)
    * Useful for visual display
    */
-  const checkIsHolidayEve = (date: Date, timezone: string): boolean => {
-    const countryCode = getCountryFromTimezone(timezone)
-    if (!countryCode) return false
-    return isHolidayEve(date, countryCode)
+  const checkIsHolidayEve = (
+    date: Date,
+    location: HolidayLocation | string,
+    customHolidays?: CustomHoliday[]
+  ): boolean => {
+    return isAnyHolidayEve(date, resolveHolidayCodes(location), customHolidays)
   }
 
   /**
-   * Gets the name of an official holiday (type "public" only)
+   * Gets the name of a custom holiday or an official holiday (type "public" only),
+   * from the first country of the location where it is one
    */
-  const getHolidayName = (date: Date, timezone: string, locale: string = 'fr'): string | null => {
-    const countryCode = getCountryFromTimezone(timezone)
-    if (!countryCode) return null
+  const getHolidayName = (
+    date: Date,
+    location: HolidayLocation | string,
+    locale: string = 'fr',
+    customHolidays?: CustomHoliday[]
+  ): string | null => {
+    const customHoliday = findCustomHoliday(date, customHolidays)
+    if (customHoliday) return customHoliday.name
 
-    try {
-      // For getHolidayName, we can't use cache because we need the locale
-      // Create a temporary instance with the locale
-      const hd = new Holidays(countryCode, { languages: [locale] })
-      const holidays = hd.isHoliday(date) as Holiday[] | Holiday | false
-      if (holidays && Array.isArray(holidays) && holidays.length > 0) {
-        // Return only public holidays
-        const publicHoliday = holidays.find(h => h.type === 'public')
-        return publicHoliday ? publicHoliday.name : null
-      }
-      // If it's a single object, check its type
-      if (
-        holidays &&
-        typeof holidays === 'object' &&
-        !Array.isArray(holidays) &&
-        holidays.type === 'public'
-      ) {
-        return holidays.name
-      }
-    } catch (_error) {
-      return null
+    for (const countryCode of resolveHolidayCodes(location)) {
+      const name = getPublicHolidayName(date, countryCode, locale)
+      if (name) return name
     }
 
     return null
   }
 
+  /**
+   * Gets the blackout period containing a date (YYYY-MM-DD), if any
+   * Blackout dates are blocked whatever the weekday and holiday rules
+   */
+  const getBlackout = (
+    dateString: string,
+    blackoutDates: BlackoutPeriod[] | undefined
+  ): BlackoutPeriod | null => {
+    return (
+      blackoutDates?.find(
+        period => dateString >= period.start_date && dateString <= (period.end_date || period.start_date)
+      ) || null
+    )
+  }
+
+  /**
+   * Lists the countries with known public holidays, by ISO 3166-1 code
+   */
+  const getHolidayCountries = (locale: string = 'fr'): Record<string, string> => {
+    return new Holidays().getCountries(locale) || {}
+  }
+
+  /**
+   * Lists the regions of a country with their own public holidays, by ISO 3166-2 subdivision code
+   */
+  const getHolidayRegions = (country: string, locale: string = 'fr'): Record<string, string> => {
+    if (!country) return {}
+    try {
+      return new Holidays().getStates(country, locale) || {}
+    } catch (_error) {
+      return {}
+    }
+  }
+
   return {
     isDateAllowed,
+    getHolidayCountries,
+    getHolidayRegions,
+    getBlackout,
     checkIsHoliday,
     checkIsHolidayEve,
     getHolidayName,
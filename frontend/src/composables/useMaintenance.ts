@@ -0,0 +1,43 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * SPDX-License-Identifier: BSL-1.1
+ */
+
+/**
+ * Composable exposing the maintenance mode of the instance, shown as a banner
+ *
+ * Usage:
+ * ```ts
+ * const { maintenance } = useMaintenance()
+ * ```
+ *
+ * The status is fetched at startup with `watchMaintenance()` and polled every minute.
+ */
+
+import { readonly, ref } from 'vue'
+import { getMaintenanceStatus, type MaintenanceStatus } from '@/api/maintenance'
+
+const POLL_INTERVAL_MS = 60_000
+
+const maintenance = ref<MaintenanceStatus>({ enabled: false })
+
+export async function loadMaintenance() {
+  try {
+    maintenance.value = await getMaintenanceStatus()
+  } catch {
+    // Keep the last known status
+  }
+}
+
+export function watchMaintenance() {
+  loadMaintenance()
+  setInterval(loadMaintenance, POLL_INTERVAL_MS)
+}
+
+export function useMaintenance() {
+  return {
+    maintenance: readonly(maintenance),
+    loadMaintenance,
+  }
+}
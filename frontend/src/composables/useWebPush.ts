@@ -0,0 +1,110 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * SPDX-License-Identifier: BSL-1.1
+ */
+
+/**
+ * Composable managing the Web Push subscription of this browser
+ *
+ * Usage:
+ * ```ts
+ * const { supported, subscribed, enable, disable } = useWebPush({
+ *   subscribe: (sub) => subscribeUserPush(sub),
+ *   unsubscribe: (endpoint) => unsubscribeUserPush(endpoint),
+ * })
+ * ```
+ *
+ * `supported` is false when the browser lacks the Push API or the server has no VAPID keys.
+ */
+
+import { onMounted, ref } from 'vue'
+import { getPushPublicKey } from '@/api/push'
+import type { PushSubscriptionPayload } from '@/types'
+
+interface WebPushTarget {
+  subscribe: (subscription: PushSubscriptionPayload) => Promise<void>
+  unsubscribe: (endpoint: string) => Promise<void>
+}
+
+// applicationServerKey expects the raw key bytes
+function decodeKey(base64url: string): Uint8Array {
+  const base64 = (base64url + '='.repeat((4 - (base64url.length % 4)) % 4))
+    .replace(/-/g, '+')
+    .replace(/_/g, '/')
+  return Uint8Array.from(atob(base64), (c) => c.charCodeAt(0))
+}
+
+async function registration(): Promise<ServiceWorkerRegistration> {
+  await navigator.serviceWorker.register('/sw.js')
+  return navigator.serviceWorker.ready
+}
+
+export function useWebPush(target: WebPushTarget) {
+  const supported = ref(false)
+  const subscribed = ref(false)
+  const denied = ref(false)
+  const loading = ref(false)
+  let publicKey = ''
+
+  onMounted(async () => {
+    if (!('serviceWorker' in navigator) || !('PushManager' in window) || !('Notification' in window)) {
+      return
+    }
+    try {
+      const key = await getPushPublicKey()
+      if (!key.enabled || !key.public_key) {
+        return
+      }
+      publicKey = key.public_key
+      supported.value = true
+      denied.value = Notification.permission === 'denied'
+
+      const existing = await (await registration()).pushManager.getSubscription()
+      subscribed.value = existing !== null
+    } catch {
+      supported.value = false
+    }
+  })
+
+  const enable = async () => {
+    loading.value = true
+    try {
+      const permission = await Notification.requestPermission()
+      if (permission !== 'granted') {
+        denied.value = permission === 'denied'
+        return false
+      }
+
+      const reg = await registration()
+      const subscription =
+        (await reg.pushManager.getSubscription()) ??
+        (await reg.pushManager.subscribe({
+          userVisibleOnly: true,
+          applicationServerKey: decodeKey(publicKey),
+        }))
+
+      await target.subscribe(subscription.toJSON() as PushSubscriptionPayload)
+      subscribed.value = true
+      return true
+    } finally {
+      loading.value = false
+    }
+  }
+
+  const disable = async () => {
+    loading.value = true
+    try {
+      const subscription = await (await registration()).pushManager.getSubscription()
+      if (subscription) {
+        await target.unsubscribe(subscription.endpoint)
+        await subscription.unsubscribe()
+      }
+      subscribed.value = false
+    } finally {
+      loading.value = false
+    }
+  }
+
+  return { supported, subscribed, denied, loading, enable, disable }
+}
@@ -11,6 +11,8 @@ import App from './App.vue'
 import router from './router'
 import { i18n } from './i18n'
 import { useAuthStore } from './stores/auth'
+import { loadBranding } from './composables/useBranding'
+import { watchMaintenance } from './composables/useMaintenance'
 import './style.css'
 
 const app = createApp(App)
@@ -24,4 +26,10 @@ app.use(i18n)
 const authStore = useAuthStore()
 authStore.initializeAuth()
 
+// Load instance branding (self-hosted white-label), don't wait for it either
+loadBranding()
+
+// Show a banner while the instance is under maintenance
+watchMaintenance()
+
 app.mount('#app')
@@ -73,6 +73,12 @@ export const routes: RouteRecordRaw[] = [
     component: () => import('@/views/VerifyParticipantEmail.vue'),
     meta: { public: true },
   },
+  {
+    path: '/confirm-event/:token',
+    name: 'confirm-event',
+    component: () => import('@/views/ConfirmEvent.vue'),
+    meta: { public: true },
+  },
   {
     path: '/settings',
     name: 'settings',
@@ -97,6 +103,18 @@ export const routes: RouteRecordRaw[] = [
     component: () => import('@/views/MagicLinkVerify.vue'),
     meta: { public: true },
   },
+  {
+    path: '/auth/oidc/callback',
+    name: 'oidc-callback',
+    component: () => import('@/views/SSOCallback.vue'),
+    meta: { public: true },
+  },
+  {
+    path: '/auth/saml/callback',
+    name: 'saml-callback',
+    component: () => import('@/views/SSOCallback.vue'),
+    meta: { public: true },
+  },
   // Cloud only: Stripe billing
   ...(isCloud
     ? [
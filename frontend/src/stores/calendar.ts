@@ -196,6 +196,27 @@ export const useCalendarStore = defineStore('calendar', () => {
     }
   }
 
+  async function regenerateParticipantToken(calendarId: string, participantId: string) {
+    loading.value = true
+    error.value = null
+
+    try {
+      const participant = await calendarsApi.regenerateParticipantToken(calendarId, participantId)
+      if (currentCalendar.value?.id === calendarId) {
+        const index = currentCalendar.value.participants.findIndex(p => p.id === participantId)
+        if (index !== -1) {
+          currentCalendar.value.participants[index] = participant
+        }
+      }
+      return participant
+    } catch (err: any) {
+      error.value = err.message || 'Failed to regenerate participant link'
+      throw err
+    } finally {
+      loading.value = false
+    }
+  }
+
   async function regeneratePublicToken(id: string) {
     loading.value = true
     error.value = null
@@ -261,6 +282,7 @@ export const useCalendarStore = defineStore('calendar', () => {
     addParticipant,
     updateParticipant,
     deleteParticipant,
+    regenerateParticipantToken,
     regeneratePublicToken,
     regenerateICSToken,
     clearCurrentCalendar,
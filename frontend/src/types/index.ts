@@ -18,9 +18,12 @@ export interface User {
   role: 'user' | 'admin'
   locale: 'fr' | 'en'
   timezone: string
+  weekly_summary?: boolean
+  capabilities?: Record<'webhooks' | 'caldav' | 'custom_branding' | 'api_keys' | 'saml', boolean>
   email_verified: boolean
   created_at: string
   updated_at: string
+  deleted_at?: string // Admin panel only: deleted, restorable until purged
   subscription?: SubscriptionInfo // Cloud builds only
   mfa_status?: MFAStatus // Admin panel only
 }
@@ -54,11 +57,37 @@ export interface AuthResponse {
   user: User
   require_mfa?: boolean
   temp_token?: string
+  mfa_method?: 'totp' | 'email' | 'webauthn'
+}
+
+export interface SSOConfig {
+  oidc_enabled: boolean
+  oidc_provider_name?: string
+  saml_enabled: boolean
+  saml_provider_name?: string
+  local_login: boolean
 }
 
 // Calendar Types
 export type HolidaysPolicy = 'ignore' | 'allow' | 'block'
 
+// What happens to new availabilities once a date reached max_participants
+export type CapacityPolicy = 'reject' | 'waitlist'
+
+// Dates blocked by the owner, whatever the weekday and holiday rules
+export interface BlackoutPeriod {
+  start_date: string // YYYY-MM-DD
+  end_date?: string // YYYY-MM-DD, inclusive; a single date when unset
+  reason?: string
+}
+
+// Holidays added by the owner, on top of the country holidays
+export interface CustomHoliday {
+  date: string // YYYY-MM-DD
+  name: string
+  yearly?: boolean // Same day every year from date on
+}
+
 export interface Calendar {
   id: string
   owner_id: string
@@ -67,11 +96,18 @@ export interface Calendar {
   public_token: string
   ics_token: string
   threshold: number
+  threshold_percent?: number // Threshold as a percentage of participants, overrides threshold
+  soft_threshold?: number // Lower level only notified ("looking good"), never creates events
   min_duration_hours: number
   allowed_weekdays: number[]
   timezone: string
   holidays_policy: HolidaysPolicy
   allow_holiday_eves: boolean
+  blackout_dates?: BlackoutPeriod[]
+  custom_holidays?: CustomHoliday[]
+  holiday_country?: string // ISO 3166-1 code, derived from the timezone when unset
+  holiday_region?: string // ISO 3166-2 subdivision of the country, e.g. "BY"
+  holiday_countries?: string[] // Other countries whose public holidays also count, e.g. "BE" or "DE-BY"
   weekday_times?: Record<string, TimeRange>
   holiday_min_time?: string
   holiday_max_time?: string
@@ -80,6 +116,10 @@ export interface Calendar {
   notify_on_threshold: boolean
   notify_config?: Record<string, unknown>
   lock_participants: boolean
+  count_maybe: boolean
+  require_confirmation?: boolean // Dates reaching the threshold wait for the owner's confirmation (owner only)
+  max_participants?: number // Participants counted per date, unset for no limit
+  capacity_policy: CapacityPolicy
   notify_participants: boolean
   start_date?: string
   end_date?: string
@@ -89,17 +129,41 @@ export interface Calendar {
 
 export interface CalendarWithParticipants extends Calendar {
   participants: Participant[]
+  participant_count: number
+}
+
+export interface ListCalendarsParams {
+  q?: string
+  sort?: string
+  page?: number
+  per_page?: number
+  participants?: boolean
+}
+
+export interface CalendarsPage {
+  items: CalendarWithParticipants[]
+  total: number
+  page: number
+  page_size: number
+  total_pages: number
 }
 
 export interface CreateCalendarRequest {
   name: string
   description?: string
   threshold: number
+  threshold_percent?: number // Threshold as a percentage of participants, overrides threshold
+  soft_threshold?: number // Lower level only notified ("looking good"), never creates events
   min_duration_hours?: number
   allowed_weekdays?: number[]
   timezone?: string
   holidays_policy?: HolidaysPolicy
   allow_holiday_eves?: boolean
+  blackout_dates?: BlackoutPeriod[]
+  custom_holidays?: CustomHoliday[]
+  holiday_country?: string // ISO 3166-1 code, derived from the timezone when unset
+  holiday_region?: string // ISO 3166-2 subdivision of the country, e.g. "BY"
+  holiday_countries?: string[] // Other countries whose public holidays also count, e.g. "BE" or "DE-BY"
   weekday_times?: Record<string, TimeRange>
   holiday_min_time?: string
   holiday_max_time?: string
@@ -108,6 +172,10 @@ export interface CreateCalendarRequest {
   notify_on_threshold?: boolean
   notify_config?: string
   lock_participants?: boolean
+  count_maybe?: boolean
+  require_confirmation?: boolean
+  max_participants?: number
+  capacity_policy?: CapacityPolicy
   start_date?: string
   end_date?: string
   participant_locale?: Locale
@@ -118,11 +186,18 @@ export interface UpdateCalendarRequest {
   name?: string
   description?: string
   threshold?: number
+  threshold_percent?: number // 0 switches back to the absolute threshold
+  soft_threshold?: number // 0 removes the soft threshold
   min_duration_hours?: number
   allowed_weekdays?: number[]
   timezone?: string
   holidays_policy?: HolidaysPolicy
   allow_holiday_eves?: boolean
+  blackout_dates?: BlackoutPeriod[]
+  custom_holidays?: CustomHoliday[]
+  holiday_country?: string // ISO 3166-1 code, derived from the timezone when unset
+  holiday_region?: string // ISO 3166-2 subdivision of the country, e.g. "BY"
+  holiday_countries?: string[] // Other countries whose public holidays also count, e.g. "BE" or "DE-BY"
   weekday_times?: Record<string, TimeRange>
   holiday_min_time?: string
   holiday_max_time?: string
@@ -131,17 +206,37 @@ export interface UpdateCalendarRequest {
   notify_on_threshold?: boolean
   notify_config?: string
   lock_participants?: boolean
+  count_maybe?: boolean
+  require_confirmation?: boolean
+  max_participants?: number // -1 removes the limit
+  capacity_policy?: CapacityPolicy
   start_date?: string
   end_date?: string
 }
 
 // Participant Types
+// Proposed event of a calendar requiring the owner's confirmation
+export type ConfirmationStatus = 'pending' | 'confirmed' | 'declined'
+
+export interface EventConfirmation {
+  calendar_id: string
+  calendar_name: string
+  date: string // YYYY-MM-DD
+  status: ConfirmationStatus
+  created_at: string
+  decided_at?: string
+}
+
 export interface Participant {
   id?: string // Optional in public views when lock_participants is enabled
   calendar_id: string
   name: string
   email?: string
   email_verified?: boolean
+  access_token?: string // Secret of the participant link, for the owner and the participant of the link
+  claimable?: boolean // Link never claimed on an open calendar, in public views
+  current?: boolean // Participant of the link, in public views
+  required?: boolean // Dates only reach the threshold when all required participants are available
   created_at: string
 }
 
@@ -150,10 +245,30 @@ export interface CreateParticipantRequest {
 }
 
 export interface UpdateParticipantRequest {
+  name?: string
+  required?: boolean
+}
+
+export interface CSVImportResult {
+  line: number
   name: string
+  email?: string
+  status: 'added' | 'duplicate' | 'invalid'
+  error?: string
+  participant_id?: string
+  invite_sent?: boolean
+}
+
+export interface CSVImportResponse {
+  added: number
+  duplicates: number
+  invalid: number
+  results: CSVImportResult[]
 }
 
 // Availability Types
+export type AvailabilityStatus = 'yes' | 'maybe'
+
 export interface Availability {
   id: string
   participant_id: string
@@ -164,6 +279,10 @@ export interface Availability {
   start_time?: string
   end_time?: string
   note?: string
+  status: AvailabilityStatus
+  preferred: boolean
+  waitlisted?: boolean // Queued on a full date, not counted until a spot frees up
+  conflict?: boolean // Overlaps the busy time of the participant's feeds
   created_at: string
   updated_at: string
 }
@@ -174,6 +293,10 @@ export interface AvailabilityItem {
   start_time?: string
   end_time?: string
   note?: string
+  status: AvailabilityStatus
+  preferred: boolean
+  waitlisted?: boolean // Queued on a full date, not counted until a spot frees up
+  conflict?: boolean // Overlaps the busy time of the participant's feeds
   created_at: string
   updated_at: string
 }
@@ -188,6 +311,22 @@ export interface ParticipantInfo {
 export interface ParticipantAvailabilitiesResponse {
   participant: ParticipantInfo
   availabilities: AvailabilityItem[]
+  busy: BusyPeriod[] // Busy time of the participant's feeds
+}
+
+export interface BusyPeriod {
+  start: string
+  end: string
+}
+
+// External ICS feed of a participant (e.g. their Google or Outlook busy feed)
+export interface BusyFeed {
+  id: string
+  participant_id: string
+  url: string
+  last_sync_at?: string
+  last_sync_error?: string
+  created_at: string
 }
 
 export interface CreateAvailabilityRequest {
@@ -195,31 +334,55 @@ export interface CreateAvailabilityRequest {
   start_time?: string
   end_time?: string
   note?: string
+  status?: AvailabilityStatus
+  preferred?: boolean
+}
+
+export interface BulkAvailabilityRequest {
+  availabilities?: CreateAvailabilityRequest[]
+  delete?: string[]
+}
+
+export interface BulkAvailabilityResponse {
+  availabilities: AvailabilityItem[]
+  deleted: string[]
 }
 
 // Recurrence Types
+export type RecurrenceFrequency = 'weekly' | 'monthly_day' | 'monthly_weekday'
+
 export interface Recurrence {
   id: string
   participant_id: string
-  day_of_week: number // 0=Sunday, 6=Saturday
+  frequency: RecurrenceFrequency
+  interval_weeks: number // Weekly only, 2 for every other week
+  day_of_week: number | null // 0=Sunday, 6=Saturday; null for monthly_day
+  day_of_month?: number // 1-31, monthly_day only
+  week_of_month?: number // 1-5 or -1 for the last one, monthly_weekday only
   start_time?: string
   end_time?: string
   note?: string
   start_date: string
   end_date?: string
   created_at: string
+  full_dates?: string[] // Upcoming dates left out on create and update, as the calendar is full
 }
 
 export interface RecurrenceWithExceptions {
   id: string
   participant_id: string
-  day_of_week: number
+  frequency: RecurrenceFrequency
+  interval_weeks: number
+  day_of_week: number | null
+  day_of_month?: number
+  week_of_month?: number
   start_time?: string
   end_time?: string
   note?: string
   start_date: string
   end_date?: string
   created_at: string
+  full_dates?: string[]
   exceptions: RecurrenceException[]
 }
 
@@ -230,8 +393,18 @@ export interface RecurrenceException {
   created_at: string
 }
 
+export interface CreateExceptionRangeRequest {
+  start_date: string
+  end_date: string // Inclusive, at most one year after start_date
+  recurrence_id?: string // Only this recurrence, all of them by default
+}
+
 export interface CreateRecurrenceRequest {
-  day_of_week: number
+  frequency?: RecurrenceFrequency // Default: weekly
+  interval_weeks?: number // Weekly only, default: 1
+  day_of_week: number | null // Required unless monthly_day
+  day_of_month?: number // Required for monthly_day
+  week_of_month?: number // Required for monthly_weekday, -1 for the last one
   start_time?: string
   end_time?: string
   note?: string
@@ -246,12 +419,53 @@ export interface ParticipantAvailabilitySummary {
   start_time?: string
   end_time?: string
   note?: string
+  status: AvailabilityStatus
+  preferred?: boolean
+  required?: boolean
 }
 
 export interface DateAvailabilitySummary {
   date: string
   total_count: number
+  maybe_count: number
+  preferred_count: number
+  score: number // 2 points per preferred answer, 1 per other counted answer
+  required_missing: number // Required participants not counted on the date
+  threshold_reached: boolean // Enough participants and no required one missing
+  capacity?: number // Maximum participants of the date, unset without limit
+  remaining_capacity?: number // Spots left, unset without limit
+  waitlist_count?: number // Participants waiting for a spot, only in single date summaries
+  blackout?: boolean // Date blocked by the owner, nobody counts
+  going_count?: number // Participants who answered they are going to the event
+  not_going_count?: number // Participants who answered they are not going
   participants: ParticipantAvailabilitySummary[]
+  comments?: DateComment[] // Only in single date summaries
+  rsvps?: RSVP[] // Only in single date summaries
+}
+
+// Answer of a participant to an event, apart from their availability
+export type RSVPStatus = 'going' | 'not_going'
+
+export interface RSVP {
+  participant_id: string
+  participant_name: string
+  date: string
+  status: RSVPStatus
+  updated_at: string
+}
+
+export interface CreateDateCommentRequest {
+  participant_id: string
+  body: string
+}
+
+export interface DateComment {
+  id: string
+  participant_id: string
+  participant_name: string
+  date: string
+  body: string
+  created_at: string
 }
 
 // API Response Types
@@ -287,17 +501,66 @@ export interface SlackChannelConfig {
   webhook_url?: string
 }
 
+export interface RocketChatChannelConfig {
+  enabled: boolean
+  webhook_url?: string
+}
+
+export interface MattermostChannelConfig {
+  enabled: boolean
+  webhook_url?: string
+  channel?: string
+}
+
 export interface TelegramChannelConfig {
   enabled: boolean
   bot_token?: string
   chat_id?: string
 }
 
+export interface WebhookChannelConfig {
+  enabled: boolean
+  url?: string
+  secret?: string
+}
+
+export interface PushChannelConfig {
+  enabled: boolean
+}
+
+export interface AppriseChannelConfig {
+  enabled: boolean
+  urls?: string[]
+}
+
 export interface ChannelConfig {
   email: EmailChannelConfig
   discord: DiscordChannelConfig
   slack: SlackChannelConfig
+  rocketchat: RocketChatChannelConfig
+  mattermost: MattermostChannelConfig
   telegram: TelegramChannelConfig
+  webhook: WebhookChannelConfig
+  push: PushChannelConfig
+  apprise: AppriseChannelConfig
+}
+
+export type DigestMode = 'immediate' | 'daily' | 'weekly'
+
+export interface DigestConfig {
+  mode?: DigestMode
+  hour: number // In the calendar timezone
+  weekday: number // 0 = Sunday, for weekly digests
+}
+
+export interface ActivityConfig {
+  enabled: boolean // Owner only, at most once an hour
+}
+
+// Custom messages by locale ("default" applies to the others), as Go text/template
+export interface MessageTemplates {
+  message?: Record<string, string> // Chat channels and push
+  text?: Record<string, string> // Emails
 }
 
 export interface ReminderConfig {
@@ -311,6 +574,26 @@ export interface NotifyConfig {
   notify_participants: boolean
   channels: ChannelConfig
   reminders: ReminderConfig
+  include_comments?: boolean
+  digest?: DigestConfig
+  activity?: ActivityConfig
+  templates?: MessageTemplates
+}
+
+export type NotificationDeliveryStatus = 'pending' | 'sent' | 'failed'
+
+export interface NotificationDelivery {
+  id: string
+  channel: string
+  event_type: string
+  date: string // YYYY-MM-DD
+  recipient_type: 'owner' | 'participant'
+  status: NotificationDeliveryStatus
+  attempts: number
+  error?: string
+  next_attempt_at?: string
+  created_at: string
+  sent_at?: string
 }
 
 export interface NotifyConfigResponse {
@@ -328,6 +611,20 @@ export interface ParticipantEmailResponse {
   message: string
 }
 
+// Web Push Types
+export interface PushPublicKey {
+  enabled: boolean
+  public_key?: string
+}
+
+export interface PushSubscriptionPayload {
+  endpoint: string
+  keys: {
+    p256dh: string
+    auth: string
+  }
+}
+
 // UI Types
 export type Theme = 'light' | 'dark' | 'system'
 
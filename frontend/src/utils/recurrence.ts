@@ -0,0 +1,56 @@
+/*
+ * WhenTo - Collaborative event calendar for self-hosted environments
+ * Copyright (C) 2025 WhenTo Contributors
+ * Licensed under the Business Source License 1.1
+ * See LICENSE file for details
+ */
+
+import type { Recurrence } from '@/types'
+
+type RecurrencePattern = Pick<
+  Recurrence,
+  | 'frequency'
+  | 'interval_weeks'
+  | 'day_of_week'
+  | 'day_of_month'
+  | 'week_of_month'
+  | 'start_date'
+  | 'end_date'
+>
+
+/**
+ * Whether a recurrence applies to a date, exceptions aside (same rules as the server)
+ * @param recurrence - Recurrence pattern with its start and end dates
+ * @param dateString - Date in YYYY-MM-DD format
+ */
+export function recurrenceOccursOn(recurrence: RecurrencePattern, dateString: string): boolean {
+  // Compare dates as strings to avoid timezone issues
+  if (dateString < recurrence.start_date) return false
+  if (recurrence.end_date && dateString > recurrence.end_date) return false
+
+  const date = new Date(`${dateString}T00:00:00Z`)
+  const dayOfWeek = date.getUTCDay()
+  const dayOfMonth = date.getUTCDate()
+
+  switch (recurrence.frequency) {
+    case 'monthly_day':
+      return dayOfMonth === recurrence.day_of_month
+    case 'monthly_weekday': {
+      if (dayOfWeek !== recurrence.day_of_week) return false
+      if (recurrence.week_of_month === -1) {
+        const weekLater = new Date(date.getTime() + 7 * 86400000)
+        return weekLater.getUTCMonth() !== date.getUTCMonth()
+      }
+      return Math.floor((dayOfMonth - 1) / 7) + 1 === recurrence.week_of_month
+    }
+    default: {
+      if (dayOfWeek !== recurrence.day_of_week) return false
+      const interval = recurrence.interval_weeks || 1
+      if (interval <= 1) return true
+      // Weeks elapsed since the first occurrence, on or after the start date
+      const start = new Date(`${recurrence.start_date}T00:00:00Z`)
+      const days = Math.round((date.getTime() - start.getTime()) / 86400000)
+      return Math.floor(days / 7) % interval === 0
+    }
+  }
+}
@@ -0,0 +1,205 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/announcement/models"
+	"github.com/whento/whento/internal/announcement/repository"
+	"github.com/whento/whento/internal/announcement/service"
+)
+
+// AnnouncementHandler handles the announcements of the admins
+type AnnouncementHandler struct {
+	service *service.AnnouncementService
+	logger  *slog.Logger
+}
+
+// NewAnnouncementHandler creates a new announcement handler
+func NewAnnouncementHandler(service *service.AnnouncementService, logger *slog.Logger) *AnnouncementHandler {
+	return &AnnouncementHandler{service: service, logger: logger}
+}
+
+// @Summary		Create an announcement
+// @Description	Publishes an announcement to all users, or to the users of a plan (cloud), as an in-app banner and/or an email. Title and body may use the {{.DisplayName}} and {{.ProductName}} placeholders, and be translated per locale. Emails are sent in the background to the users with a verified email, throttled by ANNOUNCEMENT_EMAILS_PER_MINUTE. Admin only.
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			request	body		models.CreateAnnouncementRequest	true	"Announcement"
+// @Success		201		{object}	models.Announcement
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid request, or email not configured"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403		{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Router			/api/v1/auth/admin/announcements [post]
+func (h *AnnouncementHandler) Create(w http.ResponseWriter, r *http.Request) {
+	adminID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid user ID")
+		return
+	}
+
+	var req models.CreateAnnouncementRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+	req.Title = strings.TrimSpace(req.Title)
+	req.Body = strings.TrimSpace(req.Body)
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	announcement, err := h.service.Create(r.Context(), adminID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNothingToDeliver):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, "Choose a banner, an email or both")
+		case errors.Is(err, service.ErrEmailNotConfigured):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Email is not configured on this instance")
+		case errors.Is(err, service.ErrAudienceNotAvailable):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, "Plan audiences are not available on this instance")
+		default:
+			h.logger.Error("Failed to create announcement", "error", err)
+			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to create announcement")
+		}
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, announcement)
+}
+
+// @Summary		List announcements
+// @Description	Returns all announcements, newest first, with the progress of their emails. Admin only.
+// @Tags			Admin
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		models.Announcement
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Router			/api/v1/auth/admin/announcements [get]
+func (h *AnnouncementHandler) List(w http.ResponseWriter, r *http.Request) {
+	announcements, err := h.service.List(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list announcements", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list announcements")
+		return
+	}
+	if announcements == nil {
+		announcements = []*models.Announcement{}
+	}
+
+	httputil.JSON(w, http.StatusOK, announcements)
+}
+
+// @Summary		Delete an announcement
+// @Description	Hides the banner of an announcement and stops the emails not sent yet. Admin only.
+// @Tags			Admin
+// @Security		BearerAuth
+// @Param			id	path	string	true	"Announcement ID"
+// @Success		204
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Failure		404	{object}	httputil.ErrorResponse	"Announcement not found"
+// @Router			/api/v1/auth/admin/announcements/{id} [delete]
+func (h *AnnouncementHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid announcement ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrAnnouncementNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Announcement not found")
+			return
+		}
+		h.logger.Error("Failed to delete announcement", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to delete announcement")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		List my announcements
+// @Description	Returns the announcement banners shown to the current user, in their language, that they haven't dismissed
+// @Tags			Announcements
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		models.BannerResponse
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Router			/api/v1/announcements [get]
+func (h *AnnouncementHandler) ListBanners(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid user ID")
+		return
+	}
+
+	banners, err := h.service.ListBanners(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAnnouncementNotFound) {
+			httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "User not found")
+			return
+		}
+		h.logger.Error("Failed to list announcement banners", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list announcements")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, banners)
+}
+
+// @Summary		Dismiss an announcement
+// @Description	Hides the banner of an announcement for the current user
+// @Tags			Announcements
+// @Security		BearerAuth
+// @Param			id	path	string	true	"Announcement ID"
+// @Success		204
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"Announcement not found"
+// @Router			/api/v1/announcements/{id}/dismiss [post]
+func (h *AnnouncementHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid user ID")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid announcement ID")
+		return
+	}
+
+	if err := h.service.Dismiss(r.Context(), id, userID); err != nil {
+		if errors.Is(err, repository.ErrAnnouncementNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Announcement not found")
+			return
+		}
+		h.logger.Error("Failed to dismiss announcement", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to dismiss announcement")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,89 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audience of an announcement
+const AudienceAll = "all"
+
+// Levels of an announcement banner
+const (
+	LevelInfo    = "info"
+	LevelWarning = "warning"
+)
+
+// Email delivery statuses of an announcement
+const (
+	EmailNone    = "none"
+	EmailPending = "pending"
+	EmailSending = "sending"
+	EmailSent    = "sent"
+)
+
+// Content is the title and body of an announcement in a language
+type Content struct {
+	Title string `json:"title" validate:"required,max=200"`
+	Body  string `json:"body" validate:"required,max=5000"`
+}
+
+// Announcement is a message of the admins to all users, or the users of a plan
+// Title and body may use the {{.DisplayName}} and {{.ProductName}} placeholders
+type Announcement struct {
+	ID           uuid.UUID          `json:"id"`
+	Title        string             `json:"title"`
+	Body         string             `json:"body"`
+	Translations map[string]Content `json:"translations"` // By locale, falling back to title and body
+	Level        string             `json:"level"`
+	Audience     string             `json:"audience"` // "all" or a subscription plan (cloud)
+	Banner       bool               `json:"banner"`
+	EndsAt       *time.Time         `json:"ends_at,omitempty"`
+	EmailStatus  string             `json:"email_status"`
+	EmailsSent   int                `json:"emails_sent"`
+	EmailsFailed int                `json:"emails_failed"`
+	CreatedBy    *uuid.UUID         `json:"created_by,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+// Localized returns the content of the announcement in a language
+func (a *Announcement) Localized(locale string) Content {
+	if content, ok := a.Translations[locale]; ok {
+		return content
+	}
+	return Content{Title: a.Title, Body: a.Body}
+}
+
+// Recipient is a user an announcement is emailed to
+type Recipient struct {
+	ID          uuid.UUID
+	Email       string
+	DisplayName string
+	Locale      string
+}
+
+// CreateAnnouncementRequest creates an announcement (admin only)
+type CreateAnnouncementRequest struct {
+	Title        string             `json:"title" validate:"required,max=200"`
+	Body         string             `json:"body" validate:"required,max=5000"`
+	Translations map[string]Content `json:"translations" validate:"omitempty,dive,keys,locale,endkeys"`
+	Level        string             `json:"level" validate:"omitempty,oneof=info warning"`
+	Audience     string             `json:"audience" validate:"omitempty,oneof=all free pro power"`
+	Banner       bool               `json:"banner"`
+	Email        bool               `json:"email"`
+	EndsAt       *time.Time         `json:"ends_at"`
+}
+
+// BannerResponse is an announcement shown to a user, in their language
+type BannerResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Level     string    `json:"level"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,230 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/announcement/models"
+)
+
+var ErrAnnouncementNotFound = errors.New("announcement not found")
+
+const announcementColumns = `id, title, body, translations, level, audience, banner, ends_at,
+	email_status, emails_sent, emails_failed, created_by, created_at`
+
+// AnnouncementRepository stores the announcements of the admins and their delivery
+type AnnouncementRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAnnouncementRepository creates a new announcement repository
+func NewAnnouncementRepository(pool *pgxpool.Pool) *AnnouncementRepository {
+	return &AnnouncementRepository{pool: pool}
+}
+
+// Create stores a new announcement
+func (r *AnnouncementRepository) Create(ctx context.Context, a *models.Announcement) error {
+	query := `
+		INSERT INTO announcements (id, title, body, translations, level, audience, banner, ends_at, email_status, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at`
+
+	err := r.pool.QueryRow(ctx, query,
+		a.ID, a.Title, a.Body, a.Translations, a.Level, a.Audience, a.Banner, a.EndsAt, a.EmailStatus, a.CreatedBy,
+	).Scan(&a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create announcement: %w", err)
+	}
+	return nil
+}
+
+// List returns all announcements, newest first
+func (r *AnnouncementRepository) List(ctx context.Context) ([]*models.Announcement, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+announcementColumns+` FROM announcements ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	return collectAnnouncements(rows)
+}
+
+// Delete removes an announcement, which stops its email delivery
+func (r *AnnouncementRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM announcements WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// GetRecipient returns a user as an announcement recipient
+func (r *AnnouncementRepository) GetRecipient(ctx context.Context, userID uuid.UUID) (*models.Recipient, error) {
+	recipient := &models.Recipient{}
+	err := r.pool.QueryRow(ctx, `SELECT id, email, display_name, COALESCE(locale, '') FROM users WHERE id = $1 AND deleted_at IS NULL`, userID).
+		Scan(&recipient.ID, &recipient.Email, &recipient.DisplayName, &recipient.Locale)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAnnouncementNotFound
+		}
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+	return recipient, nil
+}
+
+// ListBanners returns the banners of the announcements targeting a user that are still shown and not dismissed
+func (r *AnnouncementRepository) ListBanners(ctx context.Context, userID uuid.UUID, now time.Time) ([]*models.Announcement, error) {
+	query := `
+		SELECT ` + announcementColumns + `
+		FROM announcements a
+		WHERE a.banner
+		  AND (a.ends_at IS NULL OR a.ends_at > $2)
+		  AND NOT EXISTS (SELECT 1 FROM announcement_dismissals d WHERE d.announcement_id = a.id AND d.user_id = $1)
+		  AND EXISTS (SELECT 1 FROM users u WHERE u.id = $1 AND ` + fmt.Sprintf(audienceCondition, "a.audience") + `)
+		ORDER BY a.created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcement banners: %w", err)
+	}
+	return collectAnnouncements(rows)
+}
+
+// Dismiss hides the banner of an announcement for a user
+func (r *AnnouncementRepository) Dismiss(ctx context.Context, id, userID uuid.UUID) error {
+	query := `
+		INSERT INTO announcement_dismissals (announcement_id, user_id)
+		SELECT id, $2 FROM announcements WHERE id = $1
+		ON CONFLICT DO NOTHING`
+
+	result, err := r.pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to dismiss announcement: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		var exists bool
+		if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM announcements WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to dismiss announcement: %w", err)
+		}
+		if !exists {
+			return ErrAnnouncementNotFound
+		}
+	}
+	return nil
+}
+
+// ClaimEmail claims the oldest announcement waiting for its emails, or one whose delivery stalled
+// (its instance stopped before staleBefore), and returns it with the last user emailed
+// Returns nil when there is nothing to send
+func (r *AnnouncementRepository) ClaimEmail(ctx context.Context, staleBefore time.Time) (*models.Announcement, *uuid.UUID, error) {
+	query := `
+		UPDATE announcements
+		SET email_status = 'sending', email_claimed_at = NOW()
+		WHERE id = (
+			SELECT id FROM announcements
+			WHERE email_status = 'pending' OR (email_status = 'sending' AND email_claimed_at < $1)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + announcementColumns + `, email_cursor`
+
+	a := &models.Announcement{}
+	var cursor *uuid.UUID
+	err := r.pool.QueryRow(ctx, query, staleBefore).Scan(append(announcementFields(a), &cursor)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to claim announcement emails: %w", err)
+	}
+	return a, cursor, nil
+}
+
+// ListRecipients returns the next users with a verified email targeted by an announcement, after a user
+func (r *AnnouncementRepository) ListRecipients(ctx context.Context, audience string, after *uuid.UUID, limit int) ([]*models.Recipient, error) {
+	query := `
+		SELECT u.id, u.email, u.display_name, COALESCE(u.locale, '')
+		FROM users u
+		WHERE u.email_verified AND u.deleted_at IS NULL
+		  AND ($2::uuid IS NULL OR u.id > $2)
+		  AND ` + fmt.Sprintf(audienceCondition, "$1") + `
+		ORDER BY u.id
+		LIMIT $3`
+
+	rows, err := r.pool.Query(ctx, query, audience, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcement recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []*models.Recipient
+	for rows.Next() {
+		recipient := &models.Recipient{}
+		if err := rows.Scan(&recipient.ID, &recipient.Email, &recipient.DisplayName, &recipient.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement recipient: %w", err)
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, rows.Err()
+}
+
+// RecordProgress records an email sent (or failed) to a user and renews the claim of the delivery
+// Returns false when the announcement was deleted in the meantime, which stops the delivery
+func (r *AnnouncementRepository) RecordProgress(ctx context.Context, id, cursor uuid.UUID, sent bool) (bool, error) {
+	query := `
+		UPDATE announcements
+		SET email_cursor = $2, email_claimed_at = NOW(),
+		    emails_sent = emails_sent + CASE WHEN $3 THEN 1 ELSE 0 END,
+		    emails_failed = emails_failed + CASE WHEN $3 THEN 0 ELSE 1 END
+		WHERE id = $1 AND email_status = 'sending'`
+
+	result, err := r.pool.Exec(ctx, query, id, cursor, sent)
+	if err != nil {
+		return false, fmt.Errorf("failed to record announcement progress: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// FinishEmail marks the email delivery of an announcement as complete
+func (r *AnnouncementRepository) FinishEmail(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `UPDATE announcements SET email_status = 'sent' WHERE id = $1 AND email_status = 'sending'`, id)
+	if err != nil {
+		return fmt.Errorf("failed to finish announcement emails: %w", err)
+	}
+	return nil
+}
+
+// announcementFields returns the scan destinations of announcementColumns
+func announcementFields(a *models.Announcement) []any {
+	return []any{
+		&a.ID, &a.Title, &a.Body, &a.Translations, &a.Level, &a.Audience, &a.Banner, &a.EndsAt,
+		&a.EmailStatus, &a.EmailsSent, &a.EmailsFailed, &a.CreatedBy, &a.CreatedAt,
+	}
+}
+
+// collectAnnouncements scans announcement rows
+func collectAnnouncements(rows pgx.Rows) ([]*models.Announcement, error) {
+	defer rows.Close()
+
+	var announcements []*models.Announcement
+	for rows.Next() {
+		a := &models.Announcement{}
+		if err := rows.Scan(announcementFields(a)...); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, rows.Err()
+}
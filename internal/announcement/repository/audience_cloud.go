@@ -0,0 +1,14 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build cloud
+
+package repository
+
+// PlanAudiences reports whether announcements can target the users of a subscription plan
+const PlanAudiences = true
+
+// audienceCondition matches the user row u targeted by an audience expression
+// Users without subscription are on the free plan
+const audienceCondition = `(%[1]s = 'all' OR COALESCE((SELECT s.plan FROM subscriptions s WHERE s.user_id = u.id LIMIT 1), 'free') = %[1]s)`
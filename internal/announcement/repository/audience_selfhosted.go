@@ -0,0 +1,14 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build selfhosted
+
+package repository
+
+// PlanAudiences reports whether announcements can target the users of a subscription plan
+// Self-hosted instances have no plans: announcements go to all users
+const PlanAudiences = false
+
+// audienceCondition matches the user row u targeted by an audience expression
+const audienceCondition = `%[1]s = 'all'`
@@ -0,0 +1,381 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/email"
+	"github.com/whento/pkg/i18n"
+	"github.com/whento/whento/internal/announcement/models"
+	"github.com/whento/whento/internal/announcement/repository"
+	"github.com/whento/whento/internal/config"
+)
+
+//go:embed templates/announcement.html
+var announcementTemplate string
+
+//go:embed templates/locales/announcement.json
+var announcementTranslations string
+
+const (
+	// deliveryCheckInterval is how often the sender looks for announcements to email
+	deliveryCheckInterval = 30 * time.Second
+	// deliveryStaleAfter is how long a delivery can go without progress before another instance takes it over
+	deliveryStaleAfter = 10 * time.Minute
+	// recipientBatchSize is the number of recipients read at once
+	recipientBatchSize = 100
+)
+
+var (
+	ErrNothingToDeliver     = errors.New("announcement must be shown as a banner or emailed")
+	ErrEmailNotConfigured   = errors.New("email service is not configured")
+	ErrAudienceNotAvailable = errors.New("plan audiences are not available on this instance")
+)
+
+// AnnouncementRepository defines the interface for announcement repository operations
+type AnnouncementRepository interface {
+	Create(ctx context.Context, a *models.Announcement) error
+	List(ctx context.Context) ([]*models.Announcement, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetRecipient(ctx context.Context, userID uuid.UUID) (*models.Recipient, error)
+	ListBanners(ctx context.Context, userID uuid.UUID, now time.Time) ([]*models.Announcement, error)
+	Dismiss(ctx context.Context, id, userID uuid.UUID) error
+	ClaimEmail(ctx context.Context, staleBefore time.Time) (*models.Announcement, *uuid.UUID, error)
+	ListRecipients(ctx context.Context, audience string, after *uuid.UUID, limit int) ([]*models.Recipient, error)
+	RecordProgress(ctx context.Context, id, cursor uuid.UUID, sent bool) (bool, error)
+	FinishEmail(ctx context.Context, id uuid.UUID) error
+}
+
+// AnnouncementService publishes the announcements of the admins as banners and throttled emails
+type AnnouncementService struct {
+	repo         AnnouncementRepository
+	emailService *email.Service
+	template     *template.Template
+	translations i18n.Translations
+	appURL       string
+	branding     config.BrandingConfig
+	emailDelay   time.Duration
+	logger       *slog.Logger
+}
+
+// NewAnnouncementService creates a new announcement service
+func NewAnnouncementService(repo AnnouncementRepository, emailService *email.Service, cfg *config.Config, logger *slog.Logger) *AnnouncementService {
+	source, err := email.LoadTemplate(announcementTemplate, cfg.EmailTemplateDir, "announcement")
+	if err != nil {
+		logger.Error("Failed to load announcement template override", "error", err)
+	}
+	tmpl, err := template.New("announcement").Parse(source)
+	if err != nil {
+		logger.Error("Failed to parse announcement template", "error", err)
+	}
+
+	translations, err := i18n.Load(announcementTranslations, cfg.TranslationsDir, "announcement")
+	if err != nil {
+		logger.Error("Failed to load announcement translations", "error", err)
+	}
+
+	return &AnnouncementService{
+		repo:         repo,
+		emailService: emailService,
+		template:     tmpl,
+		translations: translations.WithVar("ProductName", cfg.Branding.ProductName),
+		appURL:       cfg.AppURL,
+		branding:     cfg.Branding,
+		emailDelay:   time.Minute / time.Duration(cfg.AnnouncementEmailsPerMinute),
+		logger:       logger,
+	}
+}
+
+// Create publishes a new announcement; its emails are sent in the background
+func (s *AnnouncementService) Create(ctx context.Context, adminID uuid.UUID, req *models.CreateAnnouncementRequest) (*models.Announcement, error) {
+	if !req.Banner && !req.Email {
+		return nil, ErrNothingToDeliver
+	}
+	if req.Email && !s.emailService.IsConfigured() {
+		return nil, ErrEmailNotConfigured
+	}
+
+	audience := req.Audience
+	if audience == "" {
+		audience = models.AudienceAll
+	}
+	if audience != models.AudienceAll && !repository.PlanAudiences {
+		return nil, ErrAudienceNotAvailable
+	}
+
+	level := req.Level
+	if level == "" {
+		level = models.LevelInfo
+	}
+
+	translations := req.Translations
+	if translations == nil {
+		translations = map[string]models.Content{}
+	}
+
+	emailStatus := models.EmailNone
+	if req.Email {
+		emailStatus = models.EmailPending
+	}
+
+	a := &models.Announcement{
+		ID:           uuid.New(),
+		Title:        req.Title,
+		Body:         req.Body,
+		Translations: translations,
+		Level:        level,
+		Audience:     audience,
+		Banner:       req.Banner,
+		EndsAt:       req.EndsAt,
+		EmailStatus:  emailStatus,
+		CreatedBy:    &adminID,
+	}
+	if err := s.repo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Announcement created", "announcement_id", a.ID, "admin_id", adminID, "audience", audience, "email", req.Email)
+	return a, nil
+}
+
+// List returns all announcements with their delivery progress
+func (s *AnnouncementService) List(ctx context.Context) ([]*models.Announcement, error) {
+	return s.repo.List(ctx)
+}
+
+// Delete removes an announcement, hiding its banner and stopping the emails not sent yet
+func (s *AnnouncementService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// ListBanners returns the banners shown to a user, in their language
+func (s *AnnouncementService) ListBanners(ctx context.Context, userID uuid.UUID) ([]*models.BannerResponse, error) {
+	recipient, err := s.repo.GetRecipient(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	announcements, err := s.repo.ListBanners(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	banners := make([]*models.BannerResponse, 0, len(announcements))
+	for _, a := range announcements {
+		content := s.personalize(a.Localized(recipient.Locale), recipient)
+		banners = append(banners, &models.BannerResponse{
+			ID:        a.ID,
+			Title:     content.Title,
+			Body:      content.Body,
+			Level:     a.Level,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+	return banners, nil
+}
+
+// Dismiss hides the banner of an announcement for a user
+func (s *AnnouncementService) Dismiss(ctx context.Context, id, userID uuid.UUID) error {
+	return s.repo.Dismiss(ctx, id, userID)
+}
+
+// StartTask checks periodically for announcements to email until the context is cancelled
+func (s *AnnouncementService) StartTask(ctx context.Context) {
+	s.logger.Info("Starting announcement email sender", "interval", s.emailDelay)
+
+	go func() {
+		ticker := time.NewTicker(deliveryCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Announcement email sender stopped (context cancelled)")
+				return
+			case <-ticker.C:
+				s.deliverPending(ctx)
+			}
+		}
+	}()
+}
+
+// deliverPending emails the announcements waiting for delivery, one at a time
+func (s *AnnouncementService) deliverPending(ctx context.Context) {
+	if !s.emailService.IsConfigured() {
+		return
+	}
+
+	for ctx.Err() == nil {
+		a, cursor, err := s.repo.ClaimEmail(ctx, time.Now().Add(-deliveryStaleAfter))
+		if err != nil {
+			s.logger.Error("Failed to claim announcement emails", "error", err)
+			return
+		}
+		if a == nil {
+			return
+		}
+
+		s.deliver(ctx, a, cursor)
+	}
+}
+
+// deliver emails an announcement to its recipients after cursor, at most one email every emailDelay
+// The progress is recorded after each email, so that an interrupted delivery resumes where it stopped
+func (s *AnnouncementService) deliver(ctx context.Context, a *models.Announcement, cursor *uuid.UUID) {
+	s.logger.Info("Sending announcement emails", "announcement_id", a.ID, "resumed", cursor != nil)
+
+	throttle := time.NewTicker(s.emailDelay)
+	defer throttle.Stop()
+
+	for {
+		recipients, err := s.repo.ListRecipients(ctx, a.Audience, cursor, recipientBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to list announcement recipients", "announcement_id", a.ID, "error", err)
+			return
+		}
+
+		for _, recipient := range recipients {
+			err := s.send(a, recipient)
+			if err != nil {
+				s.logger.Warn("Failed to send announcement email", "announcement_id", a.ID, "user_id", recipient.ID, "error", err)
+			}
+
+			active, err := s.repo.RecordProgress(ctx, a.ID, recipient.ID, err == nil)
+			if err != nil {
+				s.logger.Error("Failed to record announcement progress", "announcement_id", a.ID, "error", err)
+				return
+			}
+			if !active {
+				s.logger.Info("Announcement deleted, emails stopped", "announcement_id", a.ID)
+				return
+			}
+			cursor = &recipient.ID
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-throttle.C:
+			}
+		}
+
+		if len(recipients) < recipientBatchSize {
+			break
+		}
+	}
+
+	if err := s.repo.FinishEmail(ctx, a.ID); err != nil {
+		s.logger.Error("Failed to finish announcement emails", "announcement_id", a.ID, "error", err)
+		return
+	}
+	s.logger.Info("Announcement emails sent", "announcement_id", a.ID)
+}
+
+// send emails an announcement to a recipient, in their language
+func (s *AnnouncementService) send(a *models.Announcement, recipient *models.Recipient) error {
+	content := s.personalize(a.Localized(recipient.Locale), recipient)
+
+	body, err := s.render(content, recipient)
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.Send(email.Email{
+		To:      []string{recipient.Email},
+		Subject: content.Title,
+		Body:    body,
+		HTML:    true,
+	})
+}
+
+// announcementEmail is the data of the announcement email template
+type announcementEmail struct {
+	Locale       string
+	Subject      string
+	Greeting     string
+	Paragraphs   [][]string
+	OpenApp      string
+	AppURL       string
+	Reason       string
+	ProductName  string
+	LogoURL      string
+	PrimaryColor template.CSS
+	FooterText   string
+}
+
+// render renders the announcement email of a recipient
+func (s *AnnouncementService) render(content models.Content, recipient *models.Recipient) (string, error) {
+	if s.template == nil {
+		return "", fmt.Errorf("announcement template not loaded")
+	}
+
+	locale := recipient.Locale
+	data := announcementEmail{
+		Locale:       locale,
+		Subject:      content.Title,
+		Greeting:     s.translate(locale, "greeting", map[string]string{"Name": recipient.DisplayName}),
+		Paragraphs:   paragraphs(content.Body),
+		OpenApp:      s.translate(locale, "open_app", nil),
+		AppURL:       s.appURL,
+		Reason:       s.translate(locale, "reason", nil),
+		ProductName:  s.branding.ProductName,
+		LogoURL:      s.branding.LogoURL,
+		PrimaryColor: template.CSS(s.branding.PrimaryColor), // Validated as a hex color by the config
+		FooterText:   s.branding.Footer(),
+	}
+
+	var body bytes.Buffer
+	if err := s.template.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to render announcement: %w", err)
+	}
+	return body.String(), nil
+}
+
+// personalize replaces the placeholders of an announcement for a recipient
+func (s *AnnouncementService) personalize(content models.Content, recipient *models.Recipient) models.Content {
+	replacer := strings.NewReplacer(
+		"{{.DisplayName}}", recipient.DisplayName,
+		"{{.ProductName}}", s.branding.ProductName,
+	)
+	return models.Content{
+		Title: replacer.Replace(content.Title),
+		Body:  replacer.Replace(content.Body),
+	}
+}
+
+// translate returns a message in a language, falling back to English
+func (s *AnnouncementService) translate(locale, key string, vars map[string]string) string {
+	text, ok := s.translations.Lookup(locale)[key]
+	if !ok {
+		text = s.translations[i18n.DefaultLocale][key]
+	}
+	for name, value := range vars {
+		text = strings.ReplaceAll(text, "{{."+name+"}}", value)
+	}
+	return text
+}
+
+// paragraphs splits a plain text body into paragraphs (separated by blank lines) of lines
+func paragraphs(body string) [][]string {
+	var result [][]string
+	for _, block := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		result = append(result, strings.Split(block, "\n"))
+	}
+	return result
+}
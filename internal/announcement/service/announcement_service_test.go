@@ -0,0 +1,71 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/whento/whento/internal/announcement/models"
+	"github.com/whento/whento/internal/config"
+)
+
+func newTestService() *AnnouncementService {
+	cfg := &config.Config{
+		AppURL:                      "https://whento.example",
+		Branding:                    config.DefaultBranding,
+		AnnouncementEmailsPerMinute: 30,
+	}
+	return NewAnnouncementService(nil, nil, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestParagraphs(t *testing.T) {
+	got := paragraphs("First line\r\nsecond line\n\n\n  Next paragraph  \n")
+	want := [][]string{{"First line", "second line"}, {"Next paragraph"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paragraphs() = %q, want %q", got, want)
+	}
+}
+
+func TestLocalized(t *testing.T) {
+	a := &models.Announcement{
+		Title:        "Title",
+		Body:         "Body",
+		Translations: map[string]models.Content{"fr": {Title: "Titre", Body: "Corps"}},
+	}
+
+	if got := a.Localized("fr"); got.Title != "Titre" {
+		t.Errorf("Localized(fr) = %+v", got)
+	}
+	if got := a.Localized("en"); got.Title != "Title" || got.Body != "Body" {
+		t.Errorf("Localized(en) = %+v", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	s := newTestService()
+	recipient := &models.Recipient{DisplayName: "Alice", Locale: "fr"}
+	content := s.personalize(models.Content{
+		Title: "News from {{.ProductName}}",
+		Body:  "Hi {{.DisplayName}}\n<b>not bold</b>",
+	}, recipient)
+
+	if content.Title != "News from WhenTo" {
+		t.Errorf("personalized title = %q", content.Title)
+	}
+
+	body, err := s.render(content, recipient)
+	if err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	for _, want := range []string{"Bonjour Alice,", "Hi Alice<br>&lt;b&gt;not bold&lt;/b&gt;", "Ouvrir WhenTo", `href="https://whento.example"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("rendered email should contain %q", want)
+		}
+	}
+}
@@ -10,10 +10,10 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"strings"
 	"text/template"
@@ -24,6 +24,7 @@ import (
 
 	"github.com/whento/pkg/email"
 	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/i18n"
 	"github.com/whento/pkg/logger"
 	"github.com/whento/pkg/middleware"
 	"github.com/whento/pkg/validator"
@@ -31,6 +32,7 @@ import (
 	"github.com/whento/whento/internal/auth/repository"
 	"github.com/whento/whento/internal/auth/service"
 	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/quota"
 )
 
 //go:embed templates/email_verification.html
@@ -47,9 +49,10 @@ type AuthHandler struct {
 	cfg                      *config.Config
 	logger                   *slog.Logger
 	verificationTemplate     *template.Template
-	verificationTranslations map[string]map[string]string
+	verificationTranslations i18n.Translations
 	mfaRepo                  MFARepository
 	passkeyRepo              PasskeyRepository
+	capabilities             CapabilityChecker
 }
 
 // MFARepository interface for MFA status checking
@@ -62,6 +65,11 @@ type PasskeyRepository interface {
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
 }
 
+// CapabilityChecker interface for the features of the plan or license
+type CapabilityChecker interface {
+	GetCapabilities(ctx context.Context, userID uuid.UUID) (quota.Capabilities, error)
+}
+
 // NewAuthHandler creates a new auth handler
 func NewAuthHandler(
 	authService *service.AuthService,
@@ -71,18 +79,24 @@ func NewAuthHandler(
 	logger *slog.Logger,
 	mfaRepo MFARepository,
 	passkeyRepo PasskeyRepository,
+	capabilities CapabilityChecker,
 ) *AuthHandler {
-	// Parse email verification template
-	verificationTmpl, err := template.New("email_verification").Parse(emailVerificationTemplate)
+	// Parse email verification template (with an optional self-hosted override)
+	source, err := email.LoadTemplate(emailVerificationTemplate, cfg.EmailTemplateDir, "email_verification")
+	if err != nil {
+		logger.Error("Failed to load email verification template override", "error", err)
+	}
+	verificationTmpl, err := template.New("email_verification").Parse(source)
 	if err != nil {
 		logger.Error("Failed to parse email verification template", "error", err)
 	}
 
 	// Load email verification translations
-	var verificationTrans map[string]map[string]string
-	if err := json.Unmarshal([]byte(emailVerificationTranslationsJSON), &verificationTrans); err != nil {
+	verificationTrans, err := i18n.Load(emailVerificationTranslationsJSON, cfg.TranslationsDir, "email_verification")
+	if err != nil {
 		logger.Error("Failed to load email verification translations", "error", err)
 	}
+	verificationTrans = verificationTrans.WithVar("ProductName", cfg.Branding.ProductName)
 
 	return &AuthHandler{
 		authService:              authService,
@@ -94,6 +108,7 @@ func NewAuthHandler(
 		verificationTranslations: verificationTrans,
 		mfaRepo:                  mfaRepo,
 		passkeyRepo:              passkeyRepo,
+		capabilities:             capabilities,
 	}
 }
 
@@ -177,11 +192,7 @@ func (h *AuthHandler) sendVerificationEmail(to, displayName, locale, token strin
 
 	verificationURL := fmt.Sprintf("%s/verify-email/%s", h.cfg.AppURL, token)
 
-	// Get translations for locale (fallback to english)
-	trans, ok := h.verificationTranslations[locale]
-	if !ok {
-		trans = h.verificationTranslations["en"]
-	}
+	trans := h.verificationTranslations.Lookup(locale)
 
 	// Prepare template data
 	expiryDuration := h.cfg.Email.VerificationExpiry.String()
@@ -197,6 +208,7 @@ func (h *AuthHandler) sendVerificationEmail(to, displayName, locale, token strin
 		"Signature":       trans["signature"],
 		"VerificationURL": verificationURL,
 	}
+	maps.Copy(data, h.cfg.Branding.TemplateData())
 
 	// Execute template
 	var htmlBody bytes.Buffer
@@ -332,7 +344,7 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 // GetMe returns the current user's profile
 //
 //	@Summary		Get current user
-//	@Description	Returns the authenticated user's profile information
+//	@Description	Returns the authenticated user's profile information, with the features available with their plan or the server license in capabilities
 //	@Tags			Authentication
 //	@Produce		json
 //	@Security		BearerAuth
@@ -357,7 +369,19 @@ func (h *AuthHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httputil.JSON(w, http.StatusOK, user.ToResponse())
+	response := user.ToResponse()
+	if h.capabilities != nil {
+		capabilities, err := h.capabilities.GetCapabilities(r.Context(), user.ID)
+		if err != nil {
+			h.logger.Warn("Failed to get capabilities", "error", err, "user_id", userID)
+		}
+		response.Capabilities = make(map[string]bool, len(capabilities))
+		for capability, enabled := range capabilities {
+			response.Capabilities[string(capability)] = enabled
+		}
+	}
+
+	httputil.JSON(w, http.StatusOK, response)
 }
 
 // UpdateMe updates the current user's profile
@@ -515,7 +539,7 @@ func (h *AuthHandler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
 // DeleteUser deletes a user (admin only)
 //
 //	@Summary		Delete user
-//	@Description	Deletes a user account. Admin only. Cannot delete own account.
+//	@Description	Deletes a user account: the user is signed out and can no longer sign in, but is kept for RETENTION_DELETED_USER_DAYS days (30 by default) before being purged, and can be restored meanwhile. Admin only. Cannot delete own account.
 //	@Tags			Admin
 //	@Produce		json
 //	@Security		BearerAuth
@@ -546,3 +570,31 @@ func (h *AuthHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 	httputil.JSON(w, http.StatusOK, map[string]string{"message": "User deleted successfully"})
 }
+
+// RestoreUser restores a deleted user (admin only)
+//
+//	@Summary		Restore user
+//	@Description	Restores a user deleted by an admin and not purged yet. The user signs in again as before. Admin only.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+//	@Failure		404	{object}	httputil.ErrorResponse	"Deleted user not found"
+//	@Router			/api/v1/auth/admin/users/{id}/restore [post]
+func (h *AuthHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	targetUserID := chi.URLParam(r, "id")
+
+	if err := h.authService.RestoreUser(r.Context(), targetUserID); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Deleted user not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to restore user")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, map[string]string{"message": "User restored successfully"})
+}
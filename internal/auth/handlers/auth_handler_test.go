@@ -56,7 +56,11 @@ func (m *mockUserRepository) Update(ctx context.Context, user *models.User) erro
 	return m.err
 }
 
-func (m *mockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *mockUserRepository) SoftDelete(ctx context.Context, id, deletedBy uuid.UUID) error {
+	return m.err
+}
+
+func (m *mockUserRepository) Restore(ctx context.Context, id uuid.UUID) error {
 	return m.err
 }
 
@@ -64,23 +68,23 @@ func (m *mockUserRepository) Count(ctx context.Context) (int, error) {
 	return m.count, m.err
 }
 
-func (m *mockUserRepository) List(ctx context.Context) ([]*models.User, error) {
+func (m *mockUserRepository) ListFiltered(ctx context.Context, filter models.UserFilter) ([]*models.User, int, error) {
 	if m.err != nil {
-		return nil, m.err
+		return nil, 0, m.err
 	}
-	return m.users, nil
+	return m.users, len(m.users), nil
 }
 
-func (m *mockUserRepository) ListWithSubscriptions(ctx context.Context) ([]*models.UserWithSubscription, error) {
+func (m *mockUserRepository) ListWithSubscriptions(ctx context.Context, filter models.UserFilter) ([]*models.UserWithSubscription, int, error) {
 	if m.err != nil {
-		return nil, m.err
+		return nil, 0, m.err
 	}
 	// Convert users to UserWithSubscription format
 	var result []*models.UserWithSubscription
 	for _, u := range m.users {
 		result = append(result, &models.UserWithSubscription{User: *u})
 	}
-	return result, nil
+	return result, len(result), nil
 }
 
 func (m *mockUserRepository) UpdateRole(ctx context.Context, userID uuid.UUID, role string) error {
@@ -110,6 +114,18 @@ func (m *mockTokenRepository) GetByHash(ctx context.Context, tokenHash string) (
 	return token, nil
 }
 
+func (m *mockTokenRepository) Rotate(ctx context.Context, token *models.RefreshToken) error {
+	return m.err
+}
+
+func (m *mockTokenRepository) ListActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*models.RefreshToken, error) {
+	return nil, m.err
+}
+
+func (m *mockTokenRepository) DeleteByID(ctx context.Context, id, userID uuid.UUID) error {
+	return m.err
+}
+
 func (m *mockTokenRepository) DeleteByHash(ctx context.Context, tokenHash string) error {
 	return m.err
 }
@@ -0,0 +1,166 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/service"
+)
+
+// DataExportHandler handles exports of the personal data of users
+type DataExportHandler struct {
+	exportService *service.DataExportService
+	logger        *slog.Logger
+}
+
+// NewDataExportHandler creates a new data export handler
+func NewDataExportHandler(exportService *service.DataExportService, logger *slog.Logger) *DataExportHandler {
+	return &DataExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// Request starts an export of the personal data of the current user
+//
+//	@Summary		Request a personal data export
+//	@Description	Assembles an archive of the personal data of the current user (profile, calendars, participations, notification logs) in the background. An email with a download link valid 7 days is sent when it is ready.
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		202	{object}	models.DataExportResponse
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		409	{object}	httputil.ErrorResponse	"An export is already being prepared"
+//	@Router			/api/v1/auth/me/export [post]
+func (h *DataExportHandler) Request(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	export, err := h.exportService.Request(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err, userID, "Failed to request data export")
+		return
+	}
+
+	httputil.JSON(w, http.StatusAccepted, export.ToResponse())
+}
+
+// Get returns the status of the last personal data export of the current user
+//
+//	@Summary		Get the personal data export
+//	@Description	Returns the status of the last personal data export of the current user
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	models.DataExportResponse
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	httputil.ErrorResponse	"No export requested"
+//	@Router			/api/v1/auth/me/export [get]
+func (h *DataExportHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	export, err := h.exportService.Latest(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err, userID, "Failed to get data export")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, export.ToResponse())
+}
+
+// DownloadLatest downloads the archive of the last personal data export of the current user
+//
+//	@Summary		Download the personal data export
+//	@Description	Downloads the zip archive of the last personal data export of the current user, once ready
+//	@Tags			Authentication
+//	@Produce		application/zip
+//	@Security		BearerAuth
+//	@Success		200	{file}		binary
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	httputil.ErrorResponse	"No ready export"
+//	@Router			/api/v1/auth/me/export/download [get]
+func (h *DataExportHandler) DownloadLatest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	export, archive, err := h.exportService.DownloadLatest(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err, userID, "Failed to download data export")
+		return
+	}
+
+	writeArchive(w, export, archive)
+}
+
+// Download downloads the archive of a personal data export from the emailed link
+//
+//	@Summary		Download a personal data export
+//	@Description	Downloads the zip archive of a personal data export with the token of the emailed link
+//	@Tags			Authentication
+//	@Produce		application/zip
+//	@Param			token	path		string	true	"Download token"
+//	@Success		200		{file}		binary
+//	@Failure		404		{object}	httputil.ErrorResponse	"Unknown or expired link"
+//	@Router			/api/v1/auth/exports/{token} [get]
+func (h *DataExportHandler) Download(w http.ResponseWriter, r *http.Request) {
+	export, archive, err := h.exportService.Download(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.handleError(w, err, uuid.Nil, "Failed to download data export")
+		return
+	}
+
+	writeArchive(w, export, archive)
+}
+
+// writeArchive writes the zip archive of an export as an attachment
+func writeArchive(w http.ResponseWriter, export *models.DataExport, archive []byte) {
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"whento-data-%s.zip\"", export.CreatedAt.Format("2006-01-02")))
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(archive)
+}
+
+// userID returns the ID of the authenticated user, or writes an error
+func (h *DataExportHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// handleError writes the response of a data export error
+func (h *DataExportHandler) handleError(w http.ResponseWriter, err error, userID uuid.UUID, defaultMsg string) {
+	switch {
+	case errors.Is(err, service.ErrDataExportNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Data export not found")
+	case errors.Is(err, service.ErrDataExportInProgress):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, err.Error())
+	default:
+		h.logger.Error(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
+	}
+}
@@ -9,9 +9,9 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"strings"
 	"text/template"
@@ -22,6 +22,7 @@ import (
 
 	"github.com/whento/pkg/email"
 	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/i18n"
 	"github.com/whento/pkg/middleware"
 	"github.com/whento/whento/internal/auth/repository"
 	"github.com/whento/whento/internal/auth/service"
@@ -42,7 +43,7 @@ type EmailVerificationHandler struct {
 	cfg                      *config.Config
 	logger                   *slog.Logger
 	verificationTemplate     *template.Template
-	verificationTranslations map[string]map[string]string
+	verificationTranslations i18n.Translations
 }
 
 // NewEmailVerificationHandler creates a new email verification handler
@@ -53,17 +54,22 @@ func NewEmailVerificationHandler(
 	cfg *config.Config,
 	logger *slog.Logger,
 ) *EmailVerificationHandler {
-	// Parse email verification template
-	verificationTmpl, err := template.New("email_verification").Parse(emailVerificationTemplateEV)
+	// Parse email verification template (with an optional self-hosted override)
+	source, err := email.LoadTemplate(emailVerificationTemplateEV, cfg.EmailTemplateDir, "email_verification")
+	if err != nil {
+		logger.Error("Failed to load email verification template override", "error", err)
+	}
+	verificationTmpl, err := template.New("email_verification").Parse(source)
 	if err != nil {
 		logger.Error("Failed to parse email verification template", "error", err)
 	}
 
 	// Load email verification translations
-	var verificationTrans map[string]map[string]string
-	if err := json.Unmarshal([]byte(emailVerificationTranslationsEV), &verificationTrans); err != nil {
+	verificationTrans, err := i18n.Load(emailVerificationTranslationsEV, cfg.TranslationsDir, "email_verification")
+	if err != nil {
 		logger.Error("Failed to load email verification translations", "error", err)
 	}
+	verificationTrans = verificationTrans.WithVar("ProductName", cfg.Branding.ProductName)
 
 	return &EmailVerificationHandler{
 		authService:              authService,
@@ -208,11 +214,7 @@ func (h *EmailVerificationHandler) sendVerificationEmail(to, displayName, locale
 
 	verificationURL := fmt.Sprintf("%s/verify-email/%s", h.cfg.AppURL, token)
 
-	// Get translations for locale (fallback to english)
-	trans, ok := h.verificationTranslations[locale]
-	if !ok {
-		trans = h.verificationTranslations["en"]
-	}
+	trans := h.verificationTranslations.Lookup(locale)
 
 	// Prepare template data
 	expiryDuration := h.cfg.Email.VerificationExpiry.String()
@@ -228,6 +230,7 @@ func (h *EmailVerificationHandler) sendVerificationEmail(to, displayName, locale
 		"Signature":       trans["signature"],
 		"VerificationURL": verificationURL,
 	}
+	maps.Copy(data, h.cfg.Branding.TemplateData())
 
 	// Execute template
 	var htmlBody bytes.Buffer
@@ -0,0 +1,102 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/whento/whento/internal/auth/models"
+)
+
+const (
+	defaultUserPageSize = 50
+	maxUserPageSize     = 200
+)
+
+// parseUserFilter reads the search, filter and pagination parameters of the admin user list
+func parseUserFilter(r *http.Request) (models.UserFilter, error) {
+	q := r.URL.Query()
+	filter := models.UserFilter{
+		Search: strings.TrimSpace(q.Get("q")),
+		Role:   q.Get("role"),
+		Plan:   q.Get("plan"),
+		Limit:  defaultUserPageSize,
+	}
+
+	if filter.Role != "" && filter.Role != models.RoleUser && filter.Role != models.RoleAdmin {
+		return filter, fmt.Errorf("invalid role %q", filter.Role)
+	}
+
+	var err error
+	if filter.EmailVerified, err = parseBoolParam(q.Get("verified"), "verified"); err != nil {
+		return filter, err
+	}
+	if filter.HasMFA, err = parseBoolParam(q.Get("has_2fa"), "has_2fa"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedAfter, err = parseDateParam(q.Get("created_after"), "created_after", false); err != nil {
+		return filter, err
+	}
+	if filter.CreatedBefore, err = parseDateParam(q.Get("created_before"), "created_before", true); err != nil {
+		return filter, err
+	}
+	if deleted, err := parseBoolParam(q.Get("deleted"), "deleted"); err != nil {
+		return filter, err
+	} else if deleted != nil {
+		filter.Deleted = *deleted
+	}
+
+	if l := q.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("invalid limit %q", l)
+		}
+		filter.Limit = min(limit, maxUserPageSize)
+	}
+	if o := q.Get("offset"); o != "" {
+		offset, err := strconv.Atoi(o)
+		if err != nil || offset < 0 {
+			return filter, fmt.Errorf("invalid offset %q", o)
+		}
+		filter.Offset = offset
+	}
+
+	return filter, nil
+}
+
+// parseBoolParam parses an optional boolean query parameter
+func parseBoolParam(value, name string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return &b, nil
+}
+
+// parseDateParam parses an optional RFC 3339 timestamp or YYYY-MM-DD date query parameter.
+// A date used as an upper bound includes the whole day.
+func parseDateParam(value, name string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q (expected YYYY-MM-DD or RFC 3339)", name, value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
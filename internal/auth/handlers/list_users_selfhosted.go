@@ -13,25 +13,42 @@ import (
 	"github.com/whento/whento/internal/auth/models"
 )
 
-// ListUsers returns all users without subscription info (admin only, selfhosted build)
+// ListUsers returns a page of users without subscription info (admin only, selfhosted build)
 //
-//	@Summary		List all users
-//	@Description	Returns all users with MFA status. Admin only. Self-hosted version (no subscription info).
+//	@Summary		List users
+//	@Description	Returns a page of users matching the search and filters, with MFA status and the total count. Admin only. Self-hosted version (no subscription info, plan filter ignored).
 //	@Tags			Admin
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200	{object}	models.UsersListResponse
-//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
-//	@Failure		403	{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+//	@Param			q				query		string	false	"Search in email and display name"
+//	@Param			role			query		string	false	"Role (user or admin)"
+//	@Param			verified		query		bool	false	"Email verified"
+//	@Param			has_2fa			query		bool	false	"2FA enabled"
+//	@Param			plan			query		string	false	"Subscription plan (cloud only)"
+//	@Param			created_after	query		string	false	"Created on or after (YYYY-MM-DD or RFC 3339)"
+//	@Param			created_before	query		string	false	"Created on or before (YYYY-MM-DD or RFC 3339)"
+//	@Param			deleted			query		bool	false	"Deleted users, restorable until purged, instead of the active users"
+//	@Param			limit			query		int		false	"Page size (default 50, max 200)"
+//	@Param			offset			query		int		false	"Number of users to skip"
+//	@Success		200				{object}	models.UsersListResponse
+//	@Failure		400				{object}	httputil.ErrorResponse	"Invalid filter"
+//	@Failure		401				{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
 //	@Router			/api/v1/auth/admin/users [get]
 func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
-	users, err := h.authService.ListUsers(r.Context())
+	filter, err := parseUserFilter(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	users, total, err := h.authService.ListUsers(r.Context(), filter)
 	if err != nil {
 		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list users")
 		return
 	}
 
-	var responses []*models.UserResponse
+	responses := []*models.UserResponse{}
 	for _, user := range users {
 		resp := user.ToResponse()
 
@@ -47,7 +64,9 @@ func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	httputil.JSON(w, http.StatusOK, models.UsersListResponse{
-		Users: responses,
-		Total: len(responses),
+		Users:  responses,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
 	})
 }
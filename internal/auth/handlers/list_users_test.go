@@ -0,0 +1,60 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseUserFilter(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/v1/auth/admin/users?q=+ada+&role=admin&verified=true&has_2fa=false&plan=pro&created_after=2025-01-01&created_before=2025-01-31&deleted=true&limit=500&offset=40", nil)
+
+	filter, err := parseUserFilter(r)
+	if err != nil {
+		t.Fatalf("parseUserFilter: %v", err)
+	}
+	if filter.Search != "ada" || filter.Role != "admin" || filter.Plan != "pro" {
+		t.Errorf("search/role/plan = %q/%q/%q", filter.Search, filter.Role, filter.Plan)
+	}
+	if filter.EmailVerified == nil || !*filter.EmailVerified {
+		t.Error("verified not parsed")
+	}
+	if filter.HasMFA == nil || *filter.HasMFA {
+		t.Error("has_2fa not parsed")
+	}
+	if !filter.CreatedAfter.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("created_after = %v", filter.CreatedAfter)
+	}
+	// The upper bound includes the whole day
+	if !filter.CreatedBefore.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("created_before = %v", filter.CreatedBefore)
+	}
+	if !filter.Deleted {
+		t.Error("deleted not parsed")
+	}
+	if filter.Limit != maxUserPageSize || filter.Offset != 40 {
+		t.Errorf("limit/offset = %d/%d", filter.Limit, filter.Offset)
+	}
+}
+
+func TestParseUserFilter_Defaults(t *testing.T) {
+	filter, err := parseUserFilter(httptest.NewRequest("GET", "/api/v1/auth/admin/users", nil))
+	if err != nil {
+		t.Fatalf("parseUserFilter: %v", err)
+	}
+	if filter.Limit != defaultUserPageSize || filter.Offset != 0 || filter.EmailVerified != nil || filter.HasMFA != nil || filter.Deleted {
+		t.Errorf("unexpected defaults: %+v", filter)
+	}
+}
+
+func TestParseUserFilter_Invalid(t *testing.T) {
+	for _, query := range []string{"role=owner", "verified=maybe", "has_2fa=x", "created_after=01/02/2025", "deleted=soon", "limit=0", "offset=-1"} {
+		if _, err := parseUserFilter(httptest.NewRequest("GET", "/api/v1/auth/admin/users?"+query, nil)); err == nil {
+			t.Errorf("%s: expected an error", query)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/service"
+)
+
+// oidcStateCookie ties a sign-in to the browser that started it
+const oidcStateCookie = "whento_oidc_state"
+
+// OIDCHandler handles single sign-on with an OpenID Connect provider
+type OIDCHandler struct {
+	oidcService *service.OIDCService
+	logger      *slog.Logger
+}
+
+// NewOIDCHandler creates a new OpenID Connect handler
+func NewOIDCHandler(oidcService *service.OIDCService, logger *slog.Logger) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+		logger:      logger,
+	}
+}
+
+// Authorize starts an OpenID Connect sign-in
+//
+//	@Summary		Start single sign-on
+//	@Description	Returns the identity provider login page to redirect the user to. The provider redirects back to APP_URL/auth/oidc/callback.
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{object}	models.OIDCAuthorizeResponse
+//	@Failure		404	{object}	httputil.ErrorResponse	"Single sign-on not configured"
+//	@Failure		502	{object}	httputil.ErrorResponse	"Identity provider unavailable"
+//	@Router			/api/v1/auth/oidc/authorize [post]
+func (h *OIDCHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	state, authURL, err := h.oidcService.AuthURL(r.Context())
+	if err != nil {
+		writeSSOError(w, h.logger, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/api/v1/auth/oidc",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   10 * 60, // Same lifetime as the state
+	})
+
+	httputil.JSON(w, http.StatusOK, models.OIDCAuthorizeResponse{AuthURL: authURL})
+}
+
+// Callback completes an OpenID Connect sign-in
+//
+//	@Summary		Complete single sign-on
+//	@Description	Exchanges the code of the identity provider redirect for JWT tokens. Unknown users are linked by verified email or provisioned when OIDC_AUTO_PROVISION is enabled. If 2FA is enabled, returns require_mfa=true with a temporary token.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.OIDCCallbackRequest	true	"Code and state of the provider redirect"
+//	@Success		200		{object}	models.AuthResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request body, expired sign-in or invalid ID token"
+//	@Failure		403		{object}	httputil.ErrorResponse	"No linked account or email not allowed"
+//	@Failure		409		{object}	httputil.ErrorResponse	"An account with the unverified email already exists"
+//	@Failure		502		{object}	httputil.ErrorResponse	"Identity provider unavailable"
+//	@Router			/api/v1/auth/oidc/callback [post]
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	var req models.OIDCCallbackRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	// The state must come back to the browser that started the sign-in (login CSRF)
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(req.State)) != 1 {
+		writeSSOError(w, h.logger, service.ErrInvalidOIDCState)
+		return
+	}
+
+	// The state is single-use
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Path:     "/api/v1/auth/oidc",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+
+	resp, err := h.oidcService.Callback(r.Context(), req.Code, req.State)
+	if err != nil {
+		writeSSOError(w, h.logger, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,196 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/service"
+)
+
+// PersonalTokenHandler handles personal access tokens of the REST API
+type PersonalTokenHandler struct {
+	tokenService *service.PersonalTokenService
+	logger       *slog.Logger
+}
+
+// NewPersonalTokenHandler creates a new personal access token handler
+func NewPersonalTokenHandler(tokenService *service.PersonalTokenService, logger *slog.Logger) *PersonalTokenHandler {
+	return &PersonalTokenHandler{
+		tokenService: tokenService,
+		logger:       logger,
+	}
+}
+
+// Create creates a personal access token
+//
+//	@Summary		Create a personal access token
+//	@Description	Creates a long-lived Bearer token for scripts and automations. Scopes: read-only (GET requests), calendars:write (reads, calendars and REST hooks) or admin (everything, administrators only). The token is returned once and can't be retrieved later.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		models.CreatePersonalTokenRequest	true	"Token"
+//	@Success		201		{object}	models.CreatedPersonalTokenResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Admin scope requires an administrator"
+//	@Failure		409		{object}	httputil.ErrorResponse	"Too many tokens"
+//	@Router			/api/v1/auth/tokens [post]
+func (h *PersonalTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreatePersonalTokenRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	token, value, err := h.tokenService.Create(r.Context(), userID, middleware.GetUserRole(r.Context()), &req)
+	if err != nil {
+		h.handleError(w, err, userID, "Failed to create personal access token")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, models.CreatedPersonalTokenResponse{
+		PersonalTokenResponse: token.ToResponse(),
+		Token:                 value,
+	})
+}
+
+// List lists the personal access tokens of the current user
+//
+//	@Summary		List personal access tokens
+//	@Description	Lists the personal access tokens of the current user, without their values
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		models.PersonalTokenResponse
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Router			/api/v1/auth/tokens [get]
+func (h *PersonalTokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.tokenService.List(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err, userID, "Failed to list personal access tokens")
+		return
+	}
+
+	responses := make([]models.PersonalTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		responses = append(responses, token.ToResponse())
+	}
+
+	httputil.JSON(w, http.StatusOK, responses)
+}
+
+// Delete revokes a personal access token
+//
+//	@Summary		Revoke a personal access token
+//	@Description	Deletes a personal access token of the current user; requests using it are rejected immediately
+//	@Tags			Authentication
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Token ID"
+//	@Success		204	"Token revoked"
+//	@Failure		400	{object}	httputil.ErrorResponse	"Invalid token ID"
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	httputil.ErrorResponse	"Token not found"
+//	@Router			/api/v1/auth/tokens/{id} [delete]
+func (h *PersonalTokenHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid token ID")
+		return
+	}
+
+	if err := h.tokenService.Delete(r.Context(), userID, id); err != nil {
+		h.handleError(w, err, userID, "Failed to revoke personal access token")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AuthenticateToken implements middleware.TokenAuthenticator, so the Auth middleware accepts personal access tokens
+// Tokens get the role of their user only with the admin scope, and requests outside their scope are rejected
+func (h *PersonalTokenHandler) AuthenticateToken(r *http.Request, value string) (*middleware.TokenIdentity, error) {
+	owner, err := h.tokenService.Authenticate(r.Context(), value)
+	if err != nil {
+		if !errors.Is(err, service.ErrInvalidToken) {
+			h.logger.Error("Failed to authenticate personal access token", "error", err)
+		}
+		return nil, err
+	}
+
+	if !models.TokenScopeAllows(owner.Scope, r.Method, r.URL.Path) {
+		return nil, middleware.ErrTokenScope
+	}
+
+	role := models.RoleUser
+	if owner.Scope == models.TokenScopeAdmin {
+		role = owner.Role
+	}
+
+	return &middleware.TokenIdentity{
+		UserID: owner.UserID.String(),
+		Email:  owner.Email,
+		Role:   role,
+	}, nil
+}
+
+// IsToken implements middleware.TokenAuthenticator
+func (h *PersonalTokenHandler) IsToken(value string) bool {
+	return service.IsPersonalToken(value)
+}
+
+// userID returns the ID of the authenticated user, or writes an error
+func (h *PersonalTokenHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// handleError writes the response of a personal access token error
+func (h *PersonalTokenHandler) handleError(w http.ResponseWriter, err error, userID uuid.UUID, defaultMsg string) {
+	switch {
+	case errors.Is(err, service.ErrPersonalTokenNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Token not found")
+	case errors.Is(err, service.ErrPersonalTokenScopeAdmin):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, err.Error())
+	case errors.Is(err, service.ErrTooManyPersonalTokens):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, err.Error())
+	default:
+		h.logger.Error(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
+	}
+}
@@ -0,0 +1,113 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/service"
+)
+
+// ListSessions lists the active sessions of the current user
+//
+//	@Summary		List sessions
+//	@Description	Lists the devices signed in to the current account, most recently used first: device, user agent, IP address, sign-in and last refresh dates
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		models.SessionResponse
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Router			/api/v1/auth/sessions [get]
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to list sessions", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list sessions")
+		return
+	}
+
+	responses := make([]models.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, session.ToSession())
+	}
+
+	httputil.JSON(w, http.StatusOK, responses)
+}
+
+// RevokeSession signs a device out of the current account
+//
+//	@Summary		Revoke a session
+//	@Description	Signs a device out: its refresh token is revoked at once, and its current access token expires within 15 minutes
+//	@Tags			Authentication
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Session ID"
+//	@Success		204	"Session revoked"
+//	@Failure		400	{object}	httputil.ErrorResponse	"Invalid session ID"
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	httputil.ErrorResponse	"Session not found"
+//	@Router			/api/v1/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid session ID")
+		return
+	}
+
+	if err := h.authService.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Session not found")
+			return
+		}
+		h.logger.Error("Failed to revoke session", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to revoke session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAllSessions signs the current user out of all devices
+//
+//	@Summary		Log out everywhere
+//	@Description	Revokes all the sessions of the current account, this device included. Access tokens already issued expire within 15 minutes.
+//	@Tags			Authentication
+//	@Security		BearerAuth
+//	@Success		204	"All sessions revoked"
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Router			/api/v1/auth/sessions [delete]
+func (h *AuthHandler) RevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.authService.RevokeAllSessions(r.Context(), userID); err != nil {
+		h.logger.Error("Failed to revoke sessions", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to revoke sessions")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
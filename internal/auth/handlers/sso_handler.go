@@ -0,0 +1,159 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/service"
+)
+
+// SAMLProvider is the SAML service provider of self-hosted builds
+type SAMLProvider interface {
+	// Available reports whether SAML is configured and included in the license
+	Available(ctx context.Context) bool
+	// ProviderName returns the label of the login button
+	ProviderName() string
+}
+
+// SSOHandler handles the single sign-on options shared by providers
+type SSOHandler struct {
+	ssoService        *service.SSOService
+	oidcService       *service.OIDCService
+	samlProvider      SAMLProvider // nil = no SAML (cloud builds)
+	disableLocalLogin bool
+	logger            *slog.Logger
+}
+
+// NewSSOHandler creates a new single sign-on handler
+func NewSSOHandler(
+	ssoService *service.SSOService,
+	oidcService *service.OIDCService,
+	samlProvider SAMLProvider,
+	disableLocalLogin bool,
+	logger *slog.Logger,
+) *SSOHandler {
+	return &SSOHandler{
+		ssoService:        ssoService,
+		oidcService:       oidcService,
+		samlProvider:      samlProvider,
+		disableLocalLogin: disableLocalLogin,
+		logger:            logger,
+	}
+}
+
+// Config returns the single sign-on options of the login page
+//
+//	@Summary		Get single sign-on options
+//	@Description	Returns the available single sign-on providers (OpenID Connect, SAML on self-hosted Enterprise licenses) and whether local login (password, registration, magic links) is enabled
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{object}	models.SSOConfigResponse
+//	@Router			/api/v1/auth/sso [get]
+func (h *SSOHandler) Config(w http.ResponseWriter, r *http.Request) {
+	resp := models.SSOConfigResponse{
+		OIDCEnabled: h.oidcService.Enabled(),
+		SAMLEnabled: h.samlAvailable(r.Context()),
+		LocalLogin:  h.localLoginEnabled(r.Context()),
+	}
+	if resp.OIDCEnabled {
+		resp.OIDCProviderName = h.oidcService.ProviderName()
+	}
+	if resp.SAMLEnabled {
+		resp.SAMLProviderName = h.samlProvider.ProviderName()
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// RedeemTicket completes a sign-in with a login ticket
+//
+//	@Summary		Complete single sign-on with a login ticket
+//	@Description	Exchanges the single-use login ticket of a SAML sign-in for JWT tokens. Tickets expire after one minute. If 2FA is enabled, returns require_mfa=true with a temporary token.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.SSOTicketRequest	true	"Login ticket"
+//	@Success		200		{object}	models.AuthResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request body or expired ticket"
+//	@Router			/api/v1/auth/sso/ticket [post]
+func (h *SSOHandler) RedeemTicket(w http.ResponseWriter, r *http.Request) {
+	var req models.SSOTicketRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	resp, err := h.ssoService.RedeemTicket(r.Context(), req.Ticket)
+	if err != nil {
+		writeSSOError(w, h.logger, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// RequireLocalLogin rejects password, registration and magic link requests when local login is disabled
+func (h *SSOHandler) RequireLocalLogin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.localLoginEnabled(r.Context()) {
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "Local login is disabled, sign in with single sign-on")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// localLoginEnabled reports whether local login is available
+// DISABLE_LOCAL_LOGIN only applies while a provider is available (e.g. not after the license of SAML expired)
+func (h *SSOHandler) localLoginEnabled(ctx context.Context) bool {
+	return !h.disableLocalLogin || !(h.oidcService.Enabled() || h.samlAvailable(ctx))
+}
+
+func (h *SSOHandler) samlAvailable(ctx context.Context) bool {
+	return h.samlProvider != nil && h.samlProvider.Available(ctx)
+}
+
+// writeSSOError writes the response of a single sign-on error
+func writeSSOError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, service.ErrOIDCDisabled):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Single sign-on is not configured")
+	case errors.Is(err, service.ErrInvalidOIDCState), errors.Is(err, service.ErrInvalidToken):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Sign-in expired, please try again")
+	case errors.Is(err, service.ErrInvalidIDToken):
+		// Not 401, which the SPA handles as an expired session
+		logger.Warn("Rejected OIDC ID token", "error", err)
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid identity provider response")
+	case errors.Is(err, service.ErrSSOEmailMissing):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "The identity provider did not share your email address")
+	case errors.Is(err, service.ErrSSOAccountNotFound):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "No account is linked to this identity, ask an administrator")
+	case errors.Is(err, service.ErrEmailNotAllowed):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "This email address is not allowed to register")
+	case errors.Is(err, service.ErrUserAlreadyExists):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "An account with this email already exists, sign in with your password")
+	case errors.Is(err, service.ErrOIDCProvider):
+		logger.Error("OIDC provider request failed", "error", err)
+		httputil.Error(w, http.StatusBadGateway, httputil.ErrCodeInternal, "Identity provider unavailable")
+	default:
+		logger.Error("Single sign-on failed", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to sign in")
+	}
+}
@@ -0,0 +1,58 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of personal data exports
+const (
+	DataExportPending = "pending" // Being assembled in the background
+	DataExportReady   = "ready"   // Archive available until it expires
+	DataExportFailed  = "failed"
+)
+
+// DataExport is an archive of the personal data of a user (the archive itself is loaded on download only)
+type DataExport struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Status      string
+	SizeBytes   int64
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   time.Time
+}
+
+// DataExportResponse is the API response for a personal data export
+type DataExportResponse struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status" example:"pending"` // pending, ready or failed
+	SizeBytes   int64      `json:"size_bytes,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+}
+
+// ToResponse converts a DataExport to DataExportResponse
+func (e *DataExport) ToResponse() DataExportResponse {
+	return DataExportResponse{
+		ID:          e.ID.String(),
+		Status:      e.Status,
+		SizeBytes:   e.SizeBytes,
+		CreatedAt:   e.CreatedAt,
+		CompletedAt: e.CompletedAt,
+		ExpiresAt:   e.ExpiresAt,
+	}
+}
+
+// DataExportSection is a part of a personal data export: the rows of one kind of data as a JSON array
+type DataExportSection struct {
+	Name string
+	Rows int
+	Data []byte
+}
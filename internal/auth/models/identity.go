@@ -0,0 +1,54 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Single sign-on providers of identities
+const (
+	IdentityProviderOIDC = "oidc"
+	IdentityProviderSAML = "saml"
+)
+
+// Identity is an account of a single sign-on provider linked to a user
+type Identity struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Provider    string
+	Issuer      string
+	Subject     string // Stable ID of the account at the provider ("sub" claim)
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// SSOConfigResponse describes the single sign-on options of the login page
+type SSOConfigResponse struct {
+	OIDCEnabled      bool   `json:"oidc_enabled"`
+	OIDCProviderName string `json:"oidc_provider_name,omitempty"` // Label of the login button
+	SAMLEnabled      bool   `json:"saml_enabled"`                 // Self-hosted Enterprise licenses only
+	SAMLProviderName string `json:"saml_provider_name,omitempty"`
+	LocalLogin       bool   `json:"local_login"` // Password login, registration and magic links are available
+}
+
+// SSOTicketRequest completes a sign-in with the login ticket of a provider redirect (SAML)
+type SSOTicketRequest struct {
+	Ticket string `json:"ticket" validate:"required,len=64,hexadecimal"`
+}
+
+// OIDCAuthorizeResponse holds the provider login page to redirect the user to
+type OIDCAuthorizeResponse struct {
+	AuthURL string `json:"auth_url"`
+}
+
+// OIDCCallbackRequest completes an OpenID Connect login with the parameters of the provider redirect
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required,max=2048"`
+	State string `json:"state" validate:"required,max=4096"`
+}
@@ -0,0 +1,105 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scopes of personal access tokens
+const (
+	TokenScopeReadOnly       = "read-only"       // GET requests and GraphQL queries only
+	TokenScopeCalendarsWrite = "calendars:write" // Read everything, manage calendars and REST hooks
+	TokenScopeAdmin          = "admin"           // Everything the user can do, admin routes included (admins only)
+)
+
+// Routes writable with the calendars:write scope (REST hooks subscribe to calendar events)
+var calendarsWritePrefixes = []string{"/api/v1/calendars", "/api/v1/hooks"}
+
+// GraphQLPath is the GraphQL endpoint, which only serves queries although they are POSTed
+const GraphQLPath = "/api/graphql"
+
+// PersonalToken is a long-lived token of the REST API (only its hash is stored)
+type PersonalToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Scope      string
+	ExpiresAt  *time.Time // nil = never expires
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// PersonalTokenOwner is the user authenticated by a personal access token
+type PersonalTokenOwner struct {
+	TokenID uuid.UUID
+	Scope   string
+	UserID  uuid.UUID
+	Email   string
+	Role    string
+}
+
+// TokenScopeAllows reports whether a token scope allows a request
+func TokenScopeAllows(scope, method, path string) bool {
+	readOnly := method == http.MethodGet || method == http.MethodHead || (method == http.MethodPost && path == GraphQLPath)
+
+	switch scope {
+	case TokenScopeAdmin:
+		return true
+	case TokenScopeCalendarsWrite:
+		if readOnly {
+			return true
+		}
+		for _, prefix := range calendarsWritePrefixes {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return true
+			}
+		}
+		return false
+	case TokenScopeReadOnly:
+		return readOnly
+	default:
+		return false
+	}
+}
+
+// CreatePersonalTokenRequest represents a request to create a personal access token
+type CreatePersonalTokenRequest struct {
+	Name          string `json:"name" validate:"required,min=1,max=100"`
+	Scope         string `json:"scope" validate:"required,oneof=read-only calendars:write admin"`
+	ExpiresInDays *int   `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=3650"` // Never expires when omitted
+}
+
+// PersonalTokenResponse is the API response for a personal access token (the token itself is never returned)
+type PersonalTokenResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scope      string     `json:"scope"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedPersonalTokenResponse is returned once, when a personal access token is created
+type CreatedPersonalTokenResponse struct {
+	PersonalTokenResponse
+	Token string `json:"token"` // Shown only once
+}
+
+// ToResponse converts a PersonalToken to PersonalTokenResponse
+func (t *PersonalToken) ToResponse() PersonalTokenResponse {
+	return PersonalTokenResponse{
+		ID:         t.ID.String(),
+		Name:       t.Name,
+		Scope:      t.Scope,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
@@ -9,7 +9,7 @@ type RegisterRequest struct {
 	Email       string `json:"email" validate:"required,email,max=255"`
 	Password    string `json:"password" validate:"required,strongpassword,max=72"`
 	DisplayName string `json:"display_name" validate:"required,min=2,max=100"`
-	Locale      string `json:"locale" validate:"omitempty,oneof=fr en"`
+	Locale      string `json:"locale" validate:"omitempty,locale"`
 }
 
 // LoginRequest represents a login request
@@ -25,9 +25,11 @@ type RefreshRequest struct {
 
 // UpdateProfileRequest represents a profile update request
 type UpdateProfileRequest struct {
-	DisplayName *string `json:"display_name,omitempty" validate:"omitempty,min=2,max=100"`
-	Locale      *string `json:"locale,omitempty" validate:"omitempty,oneof=fr en"`
-	Timezone    *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	DisplayName   *string `json:"display_name,omitempty" validate:"omitempty,min=2,max=100"`
+	Locale        *string `json:"locale,omitempty" validate:"omitempty,locale"`
+	Timezone      *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	TimeFormat    *string `json:"time_format,omitempty" validate:"omitempty,oneof='' 24h 12h"` // Empty string resets to the default
+	WeeklySummary *bool   `json:"weekly_summary,omitempty"`
 }
 
 // ChangePasswordRequest represents a password change request
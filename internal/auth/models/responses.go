@@ -4,6 +4,8 @@
 
 package models
 
+import "time"
+
 // AuthResponse represents an authentication response
 type AuthResponse struct {
 	AccessToken  string `json:"access_token,omitempty"`
@@ -12,6 +14,7 @@ type AuthResponse struct {
 	User         *User  `json:"user"`
 	RequireMFA   bool   `json:"require_mfa,omitempty"` // True if 2FA verification is required
 	TempToken    string `json:"temp_token,omitempty"`  // Temporary token for 2FA flow (5min expiry)
+	MFAMethod    string `json:"mfa_method,omitempty"`  // 2FA method to verify ("totp" or "email")
 }
 
 // UserResponse represents a user response (public data)
@@ -22,10 +25,14 @@ type UserResponse struct {
 	Role          string            `json:"role"`
 	Locale        string            `json:"locale"`
 	Timezone      string            `json:"timezone"`
+	TimeFormat    *string           `json:"time_format,omitempty"`
+	WeeklySummary bool              `json:"weekly_summary"`
 	EmailVerified bool              `json:"email_verified"`
 	CreatedAt     string            `json:"created_at"`
+	DeletedAt     *time.Time        `json:"deleted_at,omitempty"`   // Deleted by an admin, restorable until purged
 	Subscription  *SubscriptionInfo `json:"subscription,omitempty"` // Cloud only
 	MFAStatus     *MFAStatus        `json:"mfa_status,omitempty"`   // MFA/auth status
+	Capabilities  map[string]bool   `json:"capabilities,omitempty"` // Features of the plan or license (webhooks, caldav, custom_branding, api_keys)
 }
 
 // ToResponse converts a User to UserResponse
@@ -37,8 +44,11 @@ func (u *User) ToResponse() *UserResponse {
 		Role:          u.Role,
 		Locale:        u.Locale,
 		Timezone:      u.Timezone,
+		TimeFormat:    u.TimeFormat,
+		WeeklySummary: u.WeeklySummary,
 		EmailVerified: u.EmailVerified,
 		CreatedAt:     u.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		DeletedAt:     u.DeletedAt,
 		Subscription:  nil, // Not included by default
 		MFAStatus:     nil, // Not included by default
 	}
@@ -54,8 +64,10 @@ func (u *UserWithSubscription) ToResponseWithSubscription() *UserResponse {
 // UsersListResponse represents a list of users
 // Note: Uses custom field name "users" instead of generic "items" for frontend compatibility
 type UsersListResponse struct {
-	Users []*UserResponse `json:"users"`
-	Total int             `json:"total"`
+	Users  []*UserResponse `json:"users"`
+	Total  int             `json:"total"` // Users matching the filters, across all pages
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
 }
 
 // MagicLinkResponse represents a magic link request response
@@ -0,0 +1,89 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SessionResponse is an active session of a user, as listed by the sessions API
+type SessionResponse struct {
+	ID         string     `json:"id"`
+	Device     string     `json:"device" example:"Firefox on Linux"` // Derived from the user agent
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address" example:"203.0.113.7"`
+	CreatedAt  time.Time  `json:"created_at"`             // Sign-in date
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"` // Last token refresh
+	ExpiresAt  time.Time  `json:"expires_at"`
+}
+
+// ToSession converts a RefreshToken to SessionResponse
+func (t *RefreshToken) ToSession() SessionResponse {
+	return SessionResponse{
+		ID:         t.ID.String(),
+		Device:     DeviceName(t.UserAgent),
+		UserAgent:  t.UserAgent,
+		IPAddress:  t.IPAddress,
+		CreatedAt:  t.CreatedAt,
+		LastUsedAt: t.LastUsedAt,
+		ExpiresAt:  t.ExpiresAt,
+	}
+}
+
+// Browsers and systems recognized in user agents, most specific first
+// (Edge and Opera user agents also mention Chrome, Chrome ones mention Safari, Android ones Linux)
+var (
+	browserNames = []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	}
+	systemNames = []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// DeviceName returns a short description of the device of a user agent, like "Firefox on Linux"
+func DeviceName(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := ""
+	for _, b := range browserNames {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	system := ""
+	for _, s := range systemNames {
+		if strings.Contains(userAgent, s.token) {
+			system = s.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		return "Unknown device"
+	}
+}
@@ -32,6 +32,8 @@ type User struct {
 	Role                        string     `json:"role"`
 	Locale                      string     `json:"locale"`
 	Timezone                    string     `json:"timezone"`
+	TimeFormat                  *string    `json:"time_format,omitempty"` // Nullable, inherits the calendar or instance default when unset
+	WeeklySummary               bool       `json:"weekly_summary"`        // Opt-in weekly summary email of the owned calendars
 	EmailVerified               bool       `json:"email_verified"`
 	VerificationToken           *string    `json:"-"`
 	VerificationTokenExpiresAt  *time.Time `json:"-"`
@@ -39,15 +41,20 @@ type User struct {
 	PasswordResetTokenExpiresAt *time.Time `json:"-"`
 	MagicLinkToken              *string    `json:"-"`
 	MagicLinkTokenExpiresAt     *time.Time `json:"-"`
+	DeletedAt                   *time.Time `json:"deleted_at,omitempty"` // Set when deleted by an admin, until purged (only read by the admin list)
 }
 
-// RefreshToken represents a refresh token
+// RefreshToken represents a refresh token, the session of a device
+// Refreshing rotates the token but keeps the session (ID, creation date)
 type RefreshToken struct {
 	models.Entity
-	UserID    uuid.UUID `json:"user_id"`
-	TokenHash string    `json:"-"`
-	ExpiresAt time.Time `json:"expires_at"`
-	CreatedAt time.Time `json:"created_at"`
+	UserID     uuid.UUID  `json:"user_id"`
+	TokenHash  string     `json:"-"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
 }
 
 // IsAdmin checks if user has admin role
@@ -0,0 +1,21 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "time"
+
+// UserFilter selects a page of the admin user list
+type UserFilter struct {
+	Search        string // Case-insensitive match on email or display name
+	Role          string
+	EmailVerified *bool
+	HasMFA        *bool  // 2FA enabled (TOTP, email or security key)
+	Plan          string // Subscription plan, cloud only ("free" includes users without subscription)
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Deleted       bool // Users deleted by an admin and not purged yet, instead of the active users
+	Limit         int
+	Offset        int
+}
@@ -0,0 +1,197 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/auth/models"
+)
+
+var ErrDataExportNotFound = errors.New("data export not found")
+
+// participations selects the participants of the calendars of the user ($1), and those registered with their email elsewhere
+const participations = `
+	SELECT p.id FROM participants p
+	JOIN calendars c ON c.id = p.calendar_id
+	WHERE c.owner_id = $1
+	   OR (p.email IS NOT NULL AND LOWER(p.email) = (SELECT LOWER(email) FROM users WHERE id = $1))`
+
+// dataExportSections lists the personal data of a user ($1), one JSON object per row
+// Credentials (password hashes, tokens, notification channel settings) are left out
+var dataExportSections = []struct {
+	name  string
+	query string
+}{
+	{"profile", `SELECT ` + withoutSecrets("u") + ` FROM users u WHERE u.id = $1`},
+	{"identities", `SELECT to_jsonb(i) FROM user_identities i WHERE i.user_id = $1 ORDER BY i.created_at`},
+	{"passkeys", `
+		SELECT jsonb_build_object('id', k.id, 'name', k.name, 'second_factor', k.second_factor, 'created_at', k.created_at, 'last_used_at', k.last_used_at)
+		FROM passkeys k WHERE k.user_id = $1 ORDER BY k.created_at`},
+	{"organizations", `
+		SELECT jsonb_build_object('organization_id', o.id, 'name', o.name, 'role', m.role, 'joined_at', m.created_at)
+		FROM organization_members m JOIN organizations o ON o.id = m.organization_id
+		WHERE m.user_id = $1 ORDER BY m.created_at`},
+	{"calendars", `
+		SELECT ` + withoutSecrets("c") + ` - 'notify_config'
+		FROM calendars c WHERE c.owner_id = $1 ORDER BY c.created_at`},
+	{"participations", `
+		SELECT ` + withoutSecrets("p") + ` || jsonb_build_object('calendar_name', c.name)
+		FROM participants p JOIN calendars c ON c.id = p.calendar_id
+		WHERE p.id IN (` + participations + `) ORDER BY c.name, p.name`},
+	{"availabilities", `
+		SELECT to_jsonb(a) FROM availabilities a
+		WHERE a.participant_id IN (` + participations + `) ORDER BY a.date`},
+	{"recurrences", `
+		SELECT to_jsonb(r) || jsonb_build_object('exceptions', ARRAY(
+			SELECT e.excluded_date FROM recurrence_exceptions e WHERE e.recurrence_id = r.id ORDER BY e.excluded_date))
+		FROM recurrences r
+		WHERE r.participant_id IN (` + participations + `) ORDER BY r.day_of_week, r.start_date`},
+	{"notification_logs", `
+		SELECT to_jsonb(n) FROM notification_log n
+		WHERE n.recipient_id = $1 OR n.recipient_id IN (` + participations + `) ORDER BY n.sent_at`},
+	{"calendar_changes", `SELECT to_jsonb(h) FROM calendar_changes h WHERE h.user_id = $1 ORDER BY h.created_at`},
+}
+
+// withoutSecrets returns the columns of a row as a JSON object, without tokens, hashes and passwords
+func withoutSecrets(alias string) string {
+	return fmt.Sprintf(`(SELECT jsonb_object_agg(key, value) FROM jsonb_each(to_jsonb(%s))
+		WHERE key NOT LIKE '%%token%%' AND key NOT LIKE '%%hash%%' AND key NOT LIKE '%%password%%')`, alias)
+}
+
+// DataExportRepository handles personal data exports
+type DataExportRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDataExportRepository creates a new data export repository
+func NewDataExportRepository(pool *pgxpool.Pool) *DataExportRepository {
+	return &DataExportRepository{pool: pool}
+}
+
+// Create records a pending export, downloaded later with the token matching tokenHash
+func (r *DataExportRepository) Create(ctx context.Context, export *models.DataExport, tokenHash string) error {
+	query := `
+		INSERT INTO data_exports (id, user_id, status, token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.pool.Exec(ctx, query, export.ID, export.UserID, export.Status, tokenHash, export.CreatedAt, export.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create data export: %w", err)
+	}
+	return nil
+}
+
+// GetLatestByUser returns the last export requested by a user
+func (r *DataExportRepository) GetLatestByUser(ctx context.Context, userID uuid.UUID) (*models.DataExport, error) {
+	query := `
+		SELECT id, user_id, status, size_bytes, created_at, completed_at, expires_at
+		FROM data_exports
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	var export models.DataExport
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&export.ID, &export.UserID, &export.Status, &export.SizeBytes,
+		&export.CreatedAt, &export.CompletedAt, &export.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrDataExportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+	return &export, nil
+}
+
+// GetArchive returns the archive of a ready, unexpired export from the hash of its download token
+func (r *DataExportRepository) GetArchive(ctx context.Context, tokenHash string, now time.Time) (*models.DataExport, []byte, error) {
+	return r.getArchive(ctx, `token_hash = $1`, tokenHash, now)
+}
+
+// GetLatestArchive returns the archive of the last export of a user, if it is ready and unexpired
+func (r *DataExportRepository) GetLatestArchive(ctx context.Context, userID uuid.UUID, now time.Time) (*models.DataExport, []byte, error) {
+	return r.getArchive(ctx, `id = (SELECT id FROM data_exports WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1)`, userID, now)
+}
+
+// getArchive returns a ready, unexpired export matching a condition on $1
+func (r *DataExportRepository) getArchive(ctx context.Context, condition string, arg any, now time.Time) (*models.DataExport, []byte, error) {
+	query := `
+		SELECT id, user_id, status, size_bytes, created_at, completed_at, expires_at, archive
+		FROM data_exports
+		WHERE ` + condition + ` AND status = 'ready' AND expires_at > $2`
+
+	var export models.DataExport
+	var archive []byte
+	err := r.pool.QueryRow(ctx, query, arg, now).Scan(&export.ID, &export.UserID, &export.Status, &export.SizeBytes,
+		&export.CreatedAt, &export.CompletedAt, &export.ExpiresAt, &archive)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, ErrDataExportNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+	return &export, archive, nil
+}
+
+// Complete stores the archive of an export and marks it ready
+func (r *DataExportRepository) Complete(ctx context.Context, id uuid.UUID, archive []byte, completedAt time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = 'ready', archive = $2, size_bytes = $3, completed_at = $4
+		WHERE id = $1`
+
+	if _, err := r.pool.Exec(ctx, query, id, archive, len(archive), completedAt); err != nil {
+		return fmt.Errorf("failed to complete data export: %w", err)
+	}
+	return nil
+}
+
+// Fail marks an export as failed
+func (r *DataExportRepository) Fail(ctx context.Context, id uuid.UUID, completedAt time.Time) error {
+	query := `UPDATE data_exports SET status = 'failed', completed_at = $2 WHERE id = $1`
+
+	if _, err := r.pool.Exec(ctx, query, id, completedAt); err != nil {
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+	return nil
+}
+
+// DeleteByUser deletes the previous exports of a user, except the one being assembled
+func (r *DataExportRepository) DeleteByUser(ctx context.Context, userID, keepID uuid.UUID) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM data_exports WHERE user_id = $1 AND id <> $2`, userID, keepID); err != nil {
+		return fmt.Errorf("failed to delete data exports: %w", err)
+	}
+	return nil
+}
+
+// Collect reads the personal data of a user, in a single repeatable read transaction
+// so that all sections describe the same moment
+func (r *DataExportRepository) Collect(ctx context.Context, userID uuid.UUID) ([]models.DataExportSection, error) {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	sections := make([]models.DataExportSection, 0, len(dataExportSections))
+	for _, s := range dataExportSections {
+		section := models.DataExportSection{Name: s.name}
+		query := `SELECT COUNT(*), COALESCE(jsonb_agg(data), '[]'::jsonb)::text FROM (` + strings.TrimSpace(s.query) + `) AS rows(data)`
+		if err := tx.QueryRow(ctx, query, userID).Scan(&section.Rows, &section.Data); err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", s.name, err)
+		}
+		sections = append(sections, section)
+	}
+
+	return sections, nil
+}
@@ -0,0 +1,111 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/auth/models"
+)
+
+var ErrIdentityNotFound = errors.New("identity not found")
+
+// IdentityRepository handles the single sign-on identities of users
+type IdentityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewIdentityRepository creates a new identity repository
+func NewIdentityRepository(pool *pgxpool.Pool) *IdentityRepository {
+	return &IdentityRepository{pool: pool}
+}
+
+// Get returns the identity of a provider account
+func (r *IdentityRepository) Get(ctx context.Context, provider, issuer, subject string) (*models.Identity, error) {
+	query := `
+		SELECT id, user_id, provider, issuer, subject, COALESCE(email, ''), created_at, last_login_at
+		FROM user_identities
+		WHERE provider = $1 AND issuer = $2 AND subject = $3`
+
+	var identity models.Identity
+	err := r.pool.QueryRow(ctx, query, provider, issuer, subject).Scan(
+		&identity.ID, &identity.UserID, &identity.Provider, &identity.Issuer,
+		&identity.Subject, &identity.Email, &identity.CreatedAt, &identity.LastLoginAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+	return &identity, nil
+}
+
+// Create links a provider account to a user
+func (r *IdentityRepository) Create(ctx context.Context, identity *models.Identity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, issuer, subject, email)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		RETURNING created_at, last_login_at`
+
+	err := r.pool.QueryRow(ctx, query,
+		identity.ID, identity.UserID, identity.Provider, identity.Issuer, identity.Subject, identity.Email,
+	).Scan(&identity.CreatedAt, &identity.LastLoginAt)
+	if err != nil {
+		return fmt.Errorf("failed to create identity: %w", err)
+	}
+	return nil
+}
+
+// RecordLogin updates the email and last login time of an identity
+func (r *IdentityRepository) RecordLogin(ctx context.Context, identity *models.Identity) error {
+	query := `
+		UPDATE user_identities
+		SET email = NULLIF($2, ''), last_login_at = NOW()
+		WHERE id = $1`
+
+	if _, err := r.pool.Exec(ctx, query, identity.ID, identity.Email); err != nil {
+		return fmt.Errorf("failed to record identity login: %w", err)
+	}
+	return nil
+}
+
+// SetLoginTicket stores the hash of a single-use login ticket of an identity, replacing the previous one
+func (r *IdentityRepository) SetLoginTicket(ctx context.Context, identityID uuid.UUID, ticketHash string, expiresAt time.Time) error {
+	query := `
+		UPDATE user_identities
+		SET login_ticket_hash = $2, login_ticket_expires_at = $3
+		WHERE id = $1`
+
+	if _, err := r.pool.Exec(ctx, query, identityID, ticketHash, expiresAt); err != nil {
+		return fmt.Errorf("failed to set login ticket: %w", err)
+	}
+	return nil
+}
+
+// RedeemLoginTicket consumes a login ticket and returns the user of its identity
+func (r *IdentityRepository) RedeemLoginTicket(ctx context.Context, ticketHash string) (uuid.UUID, error) {
+	query := `
+		UPDATE user_identities
+		SET login_ticket_hash = NULL, login_ticket_expires_at = NULL
+		WHERE login_ticket_hash = $1 AND login_ticket_expires_at > NOW()
+		RETURNING user_id`
+
+	var userID uuid.UUID
+	if err := r.pool.QueryRow(ctx, query, ticketHash).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, ErrIdentityNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to redeem login ticket: %w", err)
+	}
+	return userID, nil
+}
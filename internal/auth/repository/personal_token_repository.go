@@ -0,0 +1,108 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/auth/models"
+)
+
+var ErrPersonalTokenNotFound = errors.New("personal access token not found")
+
+// PersonalTokenRepository handles personal access tokens
+type PersonalTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPersonalTokenRepository creates a new personal access token repository
+func NewPersonalTokenRepository(pool *pgxpool.Pool) *PersonalTokenRepository {
+	return &PersonalTokenRepository{pool: pool}
+}
+
+// Create creates a personal access token from the hash of its value
+func (r *PersonalTokenRepository) Create(ctx context.Context, token *models.PersonalToken, tokenHash string) error {
+	query := `
+		INSERT INTO personal_access_tokens (id, user_id, name, scope, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.pool.Exec(ctx, query, token.ID, token.UserID, token.Name, token.Scope, tokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create personal access token: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns the personal access tokens of a user, newest first
+func (r *PersonalTokenRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.PersonalToken, error) {
+	query := `
+		SELECT id, user_id, name, scope, expires_at, last_used_at, created_at
+		FROM personal_access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.PersonalToken
+	for rows.Next() {
+		var token models.PersonalToken
+		if err := rows.Scan(&token.ID, &token.UserID, &token.Name, &token.Scope, &token.ExpiresAt, &token.LastUsedAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan personal access token: %w", err)
+		}
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// CountByUser returns the number of personal access tokens of a user
+func (r *PersonalTokenRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM personal_access_tokens WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+// Delete deletes a personal access token of a user
+func (r *PersonalTokenRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete personal access token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrPersonalTokenNotFound
+	}
+	return nil
+}
+
+// Authenticate returns the owner of an unexpired personal access token and records its use
+func (r *PersonalTokenRepository) Authenticate(ctx context.Context, tokenHash string) (*models.PersonalTokenOwner, error) {
+	query := `
+		UPDATE personal_access_tokens t
+		SET last_used_at = NOW()
+		FROM users u
+		WHERE t.token_hash = $1 AND u.id = t.user_id AND u.deleted_at IS NULL
+			AND (t.expires_at IS NULL OR t.expires_at > NOW())
+		RETURNING t.id, t.scope, u.id, u.email, u.role`
+
+	var owner models.PersonalTokenOwner
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&owner.TokenID, &owner.Scope, &owner.UserID, &owner.Email, &owner.Role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPersonalTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate personal access token: %w", err)
+	}
+	return &owner, nil
+}
@@ -34,19 +34,21 @@ func NewTokenRepository(pool *pgxpool.Pool) *TokenRepository {
 	return &TokenRepository{pool: pool}
 }
 
-// Create stores a new refresh token
+// Create stores a new refresh token, starting a session
 func (r *TokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
 	query := `
-		INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at)
-		VALUES ($1, $2, $3, $4)
-		RETURNING created_at`
+		INSERT INTO refresh_tokens (id, user_id, token_hash, user_agent, ip_address, expires_at, last_used_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING created_at, last_used_at`
 
 	err := r.pool.QueryRow(ctx, query,
 		token.ID,
 		token.UserID,
 		token.TokenHash,
+		nullIfEmpty(token.UserAgent),
+		nullIfEmpty(token.IPAddress),
 		token.ExpiresAt,
-	).Scan(&token.CreatedAt)
+	).Scan(&token.CreatedAt, &token.LastUsedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create refresh token: %w", err)
@@ -58,19 +60,11 @@ func (r *TokenRepository) Create(ctx context.Context, token *models.RefreshToken
 // GetByHash retrieves a refresh token by its hash
 func (r *TokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token_hash, expires_at, created_at
+		SELECT ` + sessionColumns + `
 		FROM refresh_tokens
 		WHERE token_hash = $1`
 
-	token := &models.RefreshToken{}
-	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
-		&token.ID,
-		&token.UserID,
-		&token.TokenHash,
-		&token.ExpiresAt,
-		&token.CreatedAt,
-	)
-
+	token, err := scanSession(r.pool.QueryRow(ctx, query, tokenHash))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrTokenNotFound
@@ -85,6 +79,72 @@ func (r *TokenRepository) GetByHash(ctx context.Context, tokenHash string) (*mod
 	return token, nil
 }
 
+// Rotate replaces the token of a session on refresh, recording the device that used it
+func (r *TokenRepository) Rotate(ctx context.Context, token *models.RefreshToken) error {
+	query := `
+		UPDATE refresh_tokens
+		SET token_hash = $2, expires_at = $3, user_agent = COALESCE($4, user_agent),
+		    ip_address = COALESCE($5, ip_address), last_used_at = NOW()
+		WHERE id = $1
+		RETURNING last_used_at`
+
+	err := r.pool.QueryRow(ctx, query,
+		token.ID,
+		token.TokenHash,
+		token.ExpiresAt,
+		nullIfEmpty(token.UserAgent),
+		nullIfEmpty(token.IPAddress),
+	).Scan(&token.LastUsedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTokenNotFound
+		}
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveByUserID returns the unexpired sessions of a user, most recently used first
+func (r *TokenRepository) ListActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*models.RefreshToken, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM refresh_tokens
+		WHERE user_id = $1 AND expires_at > NOW()
+		ORDER BY COALESCE(last_used_at, created_at) DESC`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.RefreshToken
+	for rows.Next() {
+		token, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// DeleteByID deletes a session of a user
+func (r *TokenRepository) DeleteByID(ctx context.Context, id, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM refresh_tokens WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
 // DeleteByHash deletes a refresh token by its hash
 func (r *TokenRepository) DeleteByHash(ctx context.Context, tokenHash string) error {
 	query := `DELETE FROM refresh_tokens WHERE token_hash = $1`
@@ -125,6 +185,33 @@ func (r *TokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
 	return result.RowsAffected(), nil
 }
 
+// sessionColumns are the columns read by scanSession
+const sessionColumns = `id, user_id, token_hash, COALESCE(user_agent, ''), COALESCE(ip_address, ''), expires_at, last_used_at, created_at`
+
+// scanSession scans a refresh token selected with sessionColumns
+func scanSession(row pgx.Row) (*models.RefreshToken, error) {
+	token := &models.RefreshToken{}
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.UserAgent,
+		&token.IPAddress,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&token.CreatedAt,
+	)
+	return token, err
+}
+
+// nullIfEmpty stores empty strings as NULL
+func nullIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
 // HashToken creates a SHA-256 hash of the token
 func HashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
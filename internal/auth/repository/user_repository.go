@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -65,13 +66,13 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, display_name, role, locale, timezone,
+		SELECT id, email, password_hash, display_name, role, locale, timezone, time_format, weekly_summary,
 		       email_verified, verification_token, verification_token_expires_at,
 		       password_reset_token, password_reset_token_expires_at,
 		       magic_link_token, magic_link_token_expires_at,
 		       created_at, updated_at
 		FROM users
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	user := &models.User{}
 	err := r.pool.QueryRow(ctx, query, id).Scan(
@@ -82,6 +83,8 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 		&user.Role,
 		&user.Locale,
 		&user.Timezone,
+		&user.TimeFormat,
+		&user.WeeklySummary,
 		&user.EmailVerified,
 		&user.VerificationToken,
 		&user.VerificationTokenExpiresAt,
@@ -106,13 +109,13 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 // GetByEmail retrieves a user by email
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, display_name, role, locale, timezone,
+		SELECT id, email, password_hash, display_name, role, locale, timezone, time_format, weekly_summary,
 		       email_verified, verification_token, verification_token_expires_at,
 		       password_reset_token, password_reset_token_expires_at,
 		       magic_link_token, magic_link_token_expires_at,
 		       created_at, updated_at
 		FROM users
-		WHERE email = $1`
+		WHERE email = $1 AND deleted_at IS NULL`
 
 	user := &models.User{}
 	err := r.pool.QueryRow(ctx, query, email).Scan(
@@ -123,6 +126,8 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 		&user.Role,
 		&user.Locale,
 		&user.Timezone,
+		&user.TimeFormat,
+		&user.WeeklySummary,
 		&user.EmailVerified,
 		&user.VerificationToken,
 		&user.VerificationTokenExpiresAt,
@@ -148,7 +153,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	query := `
 		UPDATE users
-		SET display_name = $2, locale = $3, timezone = $4, updated_at = NOW()
+		SET display_name = $2, locale = $3, timezone = $4, time_format = $5, weekly_summary = $6, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at`
 
@@ -157,6 +162,8 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 		user.DisplayName,
 		user.Locale,
 		user.Timezone,
+		user.TimeFormat,
+		user.WeeklySummary,
 	).Scan(&user.UpdatedAt)
 
 	if err != nil {
@@ -207,7 +214,7 @@ func (r *UserRepository) UpdateRole(ctx context.Context, userID uuid.UUID, role
 	return nil
 }
 
-// Delete deletes a user
+// Delete deletes a user permanently
 func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM users WHERE id = $1`
 
@@ -223,18 +230,119 @@ func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// SoftDelete marks a user as deleted: the user can no longer sign in and is purged by the retention janitor
+func (r *UserRepository) SoftDelete(ctx context.Context, id, deletedBy uuid.UUID) error {
+	query := `UPDATE users SET deleted_at = NOW(), deleted_by = $2 WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, id, deletedBy)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Restore restores a user deleted by an admin and not purged yet
+func (r *UserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE users SET deleted_at = NULL, deleted_by = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
 // List lists all users
 func (r *UserRepository) List(ctx context.Context) ([]*models.User, error) {
-	query := `
-		SELECT id, email, password_hash, display_name, role, locale, timezone,
-		       email_verified, verification_token, verification_token_expires_at,
-		       password_reset_token, password_reset_token_expires_at,
-		       magic_link_token, magic_link_token_expires_at,
-		       created_at, updated_at
-		FROM users
-		ORDER BY created_at DESC`
+	return r.list(ctx, "", nil, "")
+}
+
+// ListFiltered lists a page of the users matching a filter, along with the number of matching users
+func (r *UserRepository) ListFiltered(ctx context.Context, filter models.UserFilter) ([]*models.User, int, error) {
+	where, args := userFilterWhere(filter, "")
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM users u`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	page := fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	users, err := r.list(ctx, where, append(args, filter.Limit, filter.Offset), page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// userFilterWhere builds the WHERE clause of a user filter on the users table aliased "u".
+// planColumn is the subscription plan expression, or empty when subscriptions aren't joined.
+func userFilterWhere(filter models.UserFilter, planColumn string) (string, []any) {
+	var conditions []string
+	var args []any
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if filter.Search != "" {
+		add("(u.email ILIKE ? OR u.display_name ILIKE ?)", "%"+escapeLike(filter.Search)+"%")
+	}
+	if filter.Role != "" {
+		add("u.role = ?", filter.Role)
+	}
+	if filter.EmailVerified != nil {
+		add("u.email_verified = ?", *filter.EmailVerified)
+	}
+	if filter.HasMFA != nil {
+		add("EXISTS (SELECT 1 FROM user_mfa m WHERE m.user_id = u.id AND m.enabled) = ?", *filter.HasMFA)
+	}
+	if filter.Plan != "" && planColumn != "" {
+		add(planColumn+" = ?", filter.Plan)
+	}
+	if filter.CreatedAfter != nil {
+		add("u.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		add("u.created_at < ?", *filter.CreatedBefore)
+	}
 
-	rows, err := r.pool.Query(ctx, query)
+	if filter.Deleted {
+		conditions = append(conditions, "u.deleted_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "u.deleted_at IS NULL")
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// list lists the users matching a WHERE clause, newest first
+func (r *UserRepository) list(ctx context.Context, where string, args []any, page string) ([]*models.User, error) {
+	query := `
+		SELECT u.id, u.email, u.password_hash, u.display_name, u.role, u.locale, u.timezone, u.time_format, u.weekly_summary,
+		       u.email_verified, u.verification_token, u.verification_token_expires_at,
+		       u.password_reset_token, u.password_reset_token_expires_at,
+		       u.magic_link_token, u.magic_link_token_expires_at,
+		       u.created_at, u.updated_at, u.deleted_at
+		FROM users u` + where + `
+		ORDER BY u.created_at DESC` + page
+
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
@@ -251,6 +359,8 @@ func (r *UserRepository) List(ctx context.Context) ([]*models.User, error) {
 			&user.Role,
 			&user.Locale,
 			&user.Timezone,
+			&user.TimeFormat,
+			&user.WeeklySummary,
 			&user.EmailVerified,
 			&user.VerificationToken,
 			&user.VerificationTokenExpiresAt,
@@ -260,6 +370,7 @@ func (r *UserRepository) List(ctx context.Context) ([]*models.User, error) {
 			&user.MagicLinkTokenExpiresAt,
 			&user.CreatedAt,
 			&user.UpdatedAt,
+			&user.DeletedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -341,13 +452,13 @@ func (r *UserRepository) SetVerificationToken(ctx context.Context, userID uuid.U
 // GetByVerificationToken retrieves a user by verification token
 func (r *UserRepository) GetByVerificationToken(ctx context.Context, token string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, display_name, role, locale, timezone,
+		SELECT id, email, password_hash, display_name, role, locale, timezone, time_format, weekly_summary,
 		       email_verified, verification_token, verification_token_expires_at,
 		       password_reset_token, password_reset_token_expires_at,
 		       magic_link_token, magic_link_token_expires_at,
 		       created_at, updated_at
 		FROM users
-		WHERE verification_token = $1`
+		WHERE verification_token = $1 AND deleted_at IS NULL`
 
 	user := &models.User{}
 	err := r.pool.QueryRow(ctx, query, token).Scan(
@@ -358,6 +469,8 @@ func (r *UserRepository) GetByVerificationToken(ctx context.Context, token strin
 		&user.Role,
 		&user.Locale,
 		&user.Timezone,
+		&user.TimeFormat,
+		&user.WeeklySummary,
 		&user.EmailVerified,
 		&user.VerificationToken,
 		&user.VerificationTokenExpiresAt,
@@ -426,12 +539,12 @@ func (r *UserRepository) SetPasswordResetToken(ctx context.Context, userID uuid.
 // Only returns the user if the token is valid and not expired
 func (r *UserRepository) GetByPasswordResetToken(ctx context.Context, token string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, display_name, role, locale, timezone,
+		SELECT id, email, password_hash, display_name, role, locale, timezone, time_format, weekly_summary,
 		       email_verified, verification_token, verification_token_expires_at,
 		       password_reset_token, password_reset_token_expires_at,
 		       created_at, updated_at
 		FROM users
-		WHERE password_reset_token = $1
+		WHERE password_reset_token = $1 AND deleted_at IS NULL
 		  AND password_reset_token_expires_at > NOW()`
 
 	user := &models.User{}
@@ -443,6 +556,8 @@ func (r *UserRepository) GetByPasswordResetToken(ctx context.Context, token stri
 		&user.Role,
 		&user.Locale,
 		&user.Timezone,
+		&user.TimeFormat,
+		&user.WeeklySummary,
 		&user.EmailVerified,
 		&user.VerificationToken,
 		&user.VerificationTokenExpiresAt,
@@ -487,13 +602,13 @@ func (r *UserRepository) ClearPasswordResetToken(ctx context.Context, userID uui
 // Returns ErrUserNotFound if user doesn't exist or email not verified
 func (r *UserRepository) GetByEmailVerified(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, display_name, role, locale, timezone,
+		SELECT id, email, password_hash, display_name, role, locale, timezone, time_format, weekly_summary,
 		       email_verified, verification_token, verification_token_expires_at,
 		       password_reset_token, password_reset_token_expires_at,
 		       magic_link_token, magic_link_token_expires_at,
 		       created_at, updated_at
 		FROM users
-		WHERE email = $1 AND email_verified = true`
+		WHERE email = $1 AND email_verified = true AND deleted_at IS NULL`
 
 	user := &models.User{}
 	err := r.pool.QueryRow(ctx, query, email).Scan(
@@ -504,6 +619,8 @@ func (r *UserRepository) GetByEmailVerified(ctx context.Context, email string) (
 		&user.Role,
 		&user.Locale,
 		&user.Timezone,
+		&user.TimeFormat,
+		&user.WeeklySummary,
 		&user.EmailVerified,
 		&user.VerificationToken,
 		&user.VerificationTokenExpiresAt,
@@ -550,13 +667,13 @@ func (r *UserRepository) SetMagicLinkToken(ctx context.Context, userID uuid.UUID
 // Only returns the user if the token is valid and not expired
 func (r *UserRepository) GetByMagicLinkToken(ctx context.Context, token string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, display_name, role, locale, timezone,
+		SELECT id, email, password_hash, display_name, role, locale, timezone, time_format, weekly_summary,
 		       email_verified, verification_token, verification_token_expires_at,
 		       password_reset_token, password_reset_token_expires_at,
 		       magic_link_token, magic_link_token_expires_at,
 		       created_at, updated_at
 		FROM users
-		WHERE magic_link_token = $1
+		WHERE magic_link_token = $1 AND deleted_at IS NULL
 		  AND magic_link_token_expires_at > NOW()`
 
 	user := &models.User{}
@@ -568,6 +685,8 @@ func (r *UserRepository) GetByMagicLinkToken(ctx context.Context, token string)
 		&user.Role,
 		&user.Locale,
 		&user.Timezone,
+		&user.TimeFormat,
+		&user.WeeklySummary,
 		&user.EmailVerified,
 		&user.VerificationToken,
 		&user.VerificationTokenExpiresAt,
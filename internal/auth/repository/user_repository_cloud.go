@@ -13,23 +13,36 @@ import (
 	"github.com/whento/whento/internal/auth/models"
 )
 
-// ListWithSubscriptions returns all users with their subscription info (cloud only)
-func (r *UserRepository) ListWithSubscriptions(ctx context.Context) ([]*models.UserWithSubscription, error) {
+// planColumn is the subscription plan of a user, users without subscription being on the free plan
+const planColumn = "COALESCE(s.plan, 'free')"
+
+// ListWithSubscriptions returns a page of the users matching a filter with their subscription info,
+// along with the number of matching users (cloud only)
+func (r *UserRepository) ListWithSubscriptions(ctx context.Context, filter models.UserFilter) ([]*models.UserWithSubscription, int, error) {
+	where, args := userFilterWhere(filter, planColumn)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users u LEFT JOIN subscriptions s ON u.id = s.user_id` + where
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
 	query := `
 		SELECT
-			u.id, u.email, u.password_hash, u.display_name, u.role, u.locale, u.timezone,
+			u.id, u.email, u.password_hash, u.display_name, u.role, u.locale, u.timezone, u.time_format,
 			u.email_verified, u.verification_token, u.verification_token_expires_at,
 			u.password_reset_token, u.password_reset_token_expires_at,
 			u.magic_link_token, u.magic_link_token_expires_at,
-			u.created_at, u.updated_at,
+			u.created_at, u.updated_at, u.deleted_at,
 			s.plan, s.status, s.calendar_limit
 		FROM users u
-		LEFT JOIN subscriptions s ON u.id = s.user_id
-		ORDER BY u.created_at DESC`
+		LEFT JOIN subscriptions s ON u.id = s.user_id` + where + `
+		ORDER BY u.created_at DESC` +
+		fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := r.pool.Query(ctx, query, append(args, filter.Limit, filter.Offset)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list users with subscriptions: %w", err)
+		return nil, 0, fmt.Errorf("failed to list users with subscriptions: %w", err)
 	}
 	defer rows.Close()
 
@@ -49,6 +62,7 @@ func (r *UserRepository) ListWithSubscriptions(ctx context.Context) ([]*models.U
 			&user.Role,
 			&user.Locale,
 			&user.Timezone,
+			&user.TimeFormat,
 			&user.EmailVerified,
 			&user.VerificationToken,
 			&user.VerificationTokenExpiresAt,
@@ -58,12 +72,13 @@ func (r *UserRepository) ListWithSubscriptions(ctx context.Context) ([]*models.U
 			&user.MagicLinkTokenExpiresAt,
 			&user.CreatedAt,
 			&user.UpdatedAt,
+			&user.DeletedAt,
 			&plan,
 			&status,
 			&calendarLimit,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan user with subscription: %w", err)
+			return nil, 0, fmt.Errorf("failed to scan user with subscription: %w", err)
 		}
 
 		// If no subscription, default to Free plan
@@ -85,8 +100,8 @@ func (r *UserRepository) ListWithSubscriptions(ctx context.Context) ([]*models.U
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating user rows: %w", err)
+		return nil, 0, fmt.Errorf("error iterating user rows: %w", err)
 	}
 
-	return users, nil
+	return users, total, nil
 }
@@ -14,6 +14,6 @@ import (
 )
 
 // ListWithSubscriptions is not available in self-hosted mode
-func (r *UserRepository) ListWithSubscriptions(ctx context.Context) ([]*models.UserWithSubscription, error) {
-	return nil, errors.New("subscriptions are not available in self-hosted mode")
+func (r *UserRepository) ListWithSubscriptions(ctx context.Context, filter models.UserFilter) ([]*models.UserWithSubscription, int, error) {
+	return nil, 0, errors.New("subscriptions are not available in self-hosted mode")
 }
@@ -8,12 +8,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/whento/pkg/i18n"
 	"github.com/whento/pkg/jwt"
+	"github.com/whento/pkg/middleware"
 	"github.com/whento/pkg/validator"
 	"github.com/whento/whento/internal/auth/models"
 	"github.com/whento/whento/internal/auth/repository"
@@ -31,6 +35,7 @@ var (
 	ErrCannotDemoteSelf     = errors.New("cannot change your own role")
 	ErrRegistrationDisabled = errors.New("new user registration is disabled")
 	ErrEmailNotAllowed      = errors.New("email address is not allowed to register")
+	ErrSessionNotFound      = errors.New("session not found")
 )
 
 // UserRepository defines the interface for user repository operations
@@ -39,10 +44,11 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	SoftDelete(ctx context.Context, id, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
 	Count(ctx context.Context) (int, error)
-	List(ctx context.Context) ([]*models.User, error)
-	ListWithSubscriptions(ctx context.Context) ([]*models.UserWithSubscription, error)
+	ListFiltered(ctx context.Context, filter models.UserFilter) ([]*models.User, int, error)
+	ListWithSubscriptions(ctx context.Context, filter models.UserFilter) ([]*models.UserWithSubscription, int, error)
 	UpdateRole(ctx context.Context, userID uuid.UUID, role string) error
 	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
 }
@@ -51,6 +57,9 @@ type UserRepository interface {
 type TokenRepository interface {
 	Create(ctx context.Context, token *models.RefreshToken) error
 	GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	Rotate(ctx context.Context, token *models.RefreshToken) error
+	ListActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*models.RefreshToken, error)
+	DeleteByID(ctx context.Context, id, userID uuid.UUID) error
 	DeleteByHash(ctx context.Context, tokenHash string) error
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 }
@@ -68,6 +77,8 @@ type AuthService struct {
 	jwtManager      *jwt.Manager
 	bcryptCost      int
 	allowedRegister bool
+
+	allowedEmailsMu sync.RWMutex
 	allowedEmails   []string
 }
 
@@ -92,6 +103,20 @@ func NewAuthService(
 	}
 }
 
+// SetAllowedEmails replaces the email patterns allowed to register (ALLOWED_EMAILS)
+func (s *AuthService) SetAllowedEmails(patterns []string) {
+	s.allowedEmailsMu.Lock()
+	defer s.allowedEmailsMu.Unlock()
+	s.allowedEmails = patterns
+}
+
+// emailAllowed reports whether an email matches the allowed registration patterns
+func (s *AuthService) emailAllowed(email string) bool {
+	s.allowedEmailsMu.RLock()
+	defer s.allowedEmailsMu.RUnlock()
+	return validator.EmailMatches(email, s.allowedEmails)
+}
+
 // Register creates a new user account
 func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest) (*models.AuthResponse, error) {
 	// Check if this is the first user (will be admin)
@@ -108,7 +133,7 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 		}
 
 		// Check if email is in allowed list
-		if !validator.EmailMatches(req.Email, s.allowedEmails) {
+		if !s.emailAllowed(req.Email) {
 			return nil, ErrEmailNotAllowed
 		}
 	}
@@ -126,7 +151,7 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 
 	// Determine locale (default to English if not provided)
 	locale := models.LocaleEN
-	if req.Locale == models.LocaleFR || req.Locale == models.LocaleEN {
+	if i18n.IsSupported(req.Locale) {
 		locale = req.Locale
 	}
 
@@ -149,7 +174,7 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 	}
 
 	// Generate tokens
-	return s.generateAuthResponse(user)
+	return s.generateAuthResponse(ctx, user)
 }
 
 // Login authenticates a user
@@ -168,6 +193,11 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 		return nil, ErrInvalidCredentials
 	}
 
+	return s.CompleteLogin(ctx, user)
+}
+
+// CompleteLogin issues the tokens of an authenticated user, or a temporary token if 2FA is enabled
+func (s *AuthService) CompleteLogin(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
 	// Check if user has 2FA enabled
 	mfa, err := s.mfaRepo.GetByUserID(ctx, user.ID)
 	if err != nil && !errors.Is(err, mfaRepo.ErrMFANotFound) {
@@ -184,12 +214,13 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 		return &models.AuthResponse{
 			RequireMFA: true,
 			TempToken:  tempToken,
+			MFAMethod:  mfa.Method,
 			User:       user,
 		}, nil
 	}
 
 	// No MFA - generate full tokens
-	return s.generateAuthResponse(user)
+	return s.generateAuthResponse(ctx, user)
 }
 
 // RefreshToken refreshes the access token using a refresh token
@@ -214,16 +245,14 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*m
 		return nil, ErrUserNotFound
 	}
 
-	// Delete old refresh token
-	_ = s.tokenRepo.DeleteByHash(ctx, tokenHash)
-
 	// Verify stored token matches user
 	if storedToken.UserID != user.ID {
+		_ = s.tokenRepo.DeleteByHash(ctx, tokenHash)
 		return nil, ErrInvalidToken
 	}
 
-	// Generate new tokens
-	return s.generateAuthResponse(user)
+	// Generate new tokens, rotating the refresh token of the session
+	return s.issueTokens(ctx, user, storedToken)
 }
 
 // Logout invalidates the refresh token
@@ -232,6 +261,27 @@ func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
 	return s.tokenRepo.DeleteByHash(ctx, tokenHash)
 }
 
+// ListSessions returns the active sessions of a user (one per signed-in device)
+func (s *AuthService) ListSessions(ctx context.Context, userID uuid.UUID) ([]*models.RefreshToken, error) {
+	return s.tokenRepo.ListActiveByUserID(ctx, userID)
+}
+
+// RevokeSession signs a device out: its refresh token is deleted, and its access token expires within 15 minutes
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := s.tokenRepo.DeleteByID(ctx, sessionID, userID); err != nil {
+		if errors.Is(err, repository.ErrTokenNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// RevokeAllSessions signs a user out of all their devices
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	return s.tokenRepo.DeleteByUserID(ctx, userID)
+}
+
 // GetCurrentUser returns the current user
 func (s *AuthService) GetCurrentUser(ctx context.Context, userID string) (*models.User, error) {
 	uid, err := uuid.Parse(userID)
@@ -272,6 +322,16 @@ func (s *AuthService) UpdateProfile(ctx context.Context, userID string, req *mod
 	if req.Timezone != nil {
 		user.Timezone = *req.Timezone
 	}
+	if req.TimeFormat != nil {
+		if *req.TimeFormat == "" {
+			user.TimeFormat = nil
+		} else {
+			user.TimeFormat = req.TimeFormat
+		}
+	}
+	if req.WeeklySummary != nil {
+		user.WeeklySummary = *req.WeeklySummary
+	}
 
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
@@ -314,9 +374,9 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID string, req *mo
 	return nil
 }
 
-// ListUsers returns all users (admin only)
-func (s *AuthService) ListUsers(ctx context.Context) ([]*models.User, error) {
-	return s.userRepo.List(ctx)
+// ListUsers returns a page of the users matching a filter and their total count (admin only)
+func (s *AuthService) ListUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int, error) {
+	return s.userRepo.ListFiltered(ctx, filter)
 }
 
 // UpdateUserRole updates a user's role (admin only)
@@ -340,20 +400,61 @@ func (s *AuthService) UpdateUserRole(ctx context.Context, currentUserID, targetU
 }
 
 // DeleteUser deletes a user (admin only)
+// The user is signed out and kept, restorable, until the retention janitor purges it
 func (s *AuthService) DeleteUser(ctx context.Context, currentUserID, targetUserID string) error {
 	if currentUserID == targetUserID {
 		return ErrCannotDeleteSelf
 	}
 
+	adminID, err := uuid.Parse(currentUserID)
+	if err != nil {
+		return ErrUserNotFound
+	}
 	uid, err := uuid.Parse(targetUserID)
 	if err != nil {
 		return ErrUserNotFound
 	}
 
-	return s.userRepo.Delete(ctx, uid)
+	if err := s.userRepo.SoftDelete(ctx, uid, adminID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	// Sign the user out of all their devices
+	if err := s.tokenRepo.DeleteByUserID(ctx, uid); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (s *AuthService) generateAuthResponse(user *models.User) (*models.AuthResponse, error) {
+// RestoreUser restores a user deleted by an admin and not purged yet (admin only)
+func (s *AuthService) RestoreUser(ctx context.Context, targetUserID string) error {
+	uid, err := uuid.Parse(targetUserID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if err := s.userRepo.Restore(ctx, uid); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// generateAuthResponse issues the tokens of a new session
+func (s *AuthService) generateAuthResponse(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
+	return s.issueTokens(ctx, user, nil)
+}
+
+// issueTokens generates an access token and a refresh token, stored as a new session,
+// or replacing the refresh token of an existing session
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User, session *models.RefreshToken) (*models.AuthResponse, error) {
 	// Generate access token
 	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID.String(), user.Email, user.Role)
 	if err != nil {
@@ -367,15 +468,21 @@ func (s *AuthService) generateAuthResponse(user *models.User) (*models.AuthRespo
 	}
 
 	// Store refresh token hash
-	storedToken := &models.RefreshToken{
-		UserID:    user.ID,
-		TokenHash: repository.HashToken(refreshToken),
-		ExpiresAt: expiresAt,
-	}
-	storedToken.ID = uuid.New()
-
-	if err := s.tokenRepo.Create(context.Background(), storedToken); err != nil {
-		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	if session == nil {
+		storedToken := newSession(ctx, user.ID, refreshToken, expiresAt)
+		if err := s.tokenRepo.Create(ctx, storedToken); err != nil {
+			return nil, fmt.Errorf("failed to store refresh token: %w", err)
+		}
+	} else {
+		rotated := newSession(ctx, user.ID, refreshToken, expiresAt)
+		rotated.ID = session.ID
+		if err := s.tokenRepo.Rotate(ctx, rotated); err != nil {
+			if errors.Is(err, repository.ErrTokenNotFound) {
+				// Revoked meanwhile
+				return nil, ErrInvalidToken
+			}
+			return nil, fmt.Errorf("failed to store refresh token: %w", err)
+		}
 	}
 
 	return &models.AuthResponse{
@@ -386,6 +493,36 @@ func (s *AuthService) generateAuthResponse(user *models.User) (*models.AuthRespo
 	}, nil
 }
 
+// Limits of the device recorded with sessions
+const (
+	maxSessionUserAgent = 512
+	maxSessionIPAddress = 45
+)
+
+// newSession returns the refresh token of a new session, with the device of the request in ctx
+func newSession(ctx context.Context, userID uuid.UUID, refreshToken string, expiresAt time.Time) *models.RefreshToken {
+	session := &models.RefreshToken{
+		UserID:    userID,
+		TokenHash: repository.HashToken(refreshToken),
+		UserAgent: truncate(middleware.GetUserAgent(ctx), maxSessionUserAgent),
+		IPAddress: truncate(middleware.GetClientIP(ctx), maxSessionIPAddress),
+		ExpiresAt: expiresAt,
+	}
+	session.ID = uuid.New()
+	return session
+}
+
+// truncate cuts a string to at most limit bytes, on a rune boundary
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	for limit > 0 && !utf8.RuneStart(value[limit]) {
+		limit--
+	}
+	return value[:limit]
+}
+
 // generateTempToken generates a temporary token for 2FA verification (5-minute expiry)
 func (s *AuthService) generateTempToken(userID uuid.UUID) (string, error) {
 	// Generate a short-lived JWT with 5-minute expiry
@@ -409,7 +546,7 @@ func (s *AuthService) generateTempToken(userID uuid.UUID) (string, error) {
 func (s *AuthService) PasskeyLogin(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
 	// Passkey authentication is considered strong enough - no TOTP required
 	// Generate full tokens directly
-	return s.generateAuthResponse(user)
+	return s.generateAuthResponse(ctx, user)
 }
 
 // VerifyMFAAndLogin verifies the MFA code and completes login
@@ -448,5 +585,5 @@ func (s *AuthService) VerifyMFAAndLogin(ctx context.Context, tempToken string, m
 	// The handler will call this method only after successful verification
 
 	// Generate full tokens
-	return s.generateAuthResponse(user)
+	return s.generateAuthResponse(ctx, user)
 }
@@ -12,7 +12,7 @@ import (
 	"github.com/whento/whento/internal/auth/models"
 )
 
-// ListUsersWithSubscriptions returns all users with subscription info (cloud only)
-func (s *AuthService) ListUsersWithSubscriptions(ctx context.Context) ([]*models.UserWithSubscription, error) {
-	return s.userRepo.ListWithSubscriptions(ctx)
+// ListUsersWithSubscriptions returns a page of the users matching a filter with subscription info, and their total count (cloud only)
+func (s *AuthService) ListUsersWithSubscriptions(ctx context.Context, filter models.UserFilter) ([]*models.UserWithSubscription, int, error) {
+	return s.userRepo.ListWithSubscriptions(ctx, filter)
 }
@@ -238,6 +238,27 @@ func TestUpdateProfileRequest_Validation(t *testing.T) {
 	}
 }
 
+func TestUpdateProfileRequest_TimeFormat(t *testing.T) {
+	tests := []struct {
+		timeFormat string
+		wantErr    bool
+	}{
+		{"", false}, // Resets to the default
+		{"24h", false},
+		{"12h", false},
+		{"36h", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeFormat, func(t *testing.T) {
+			err := validator.Validate(&models.UpdateProfileRequest{TimeFormat: &tt.timeFormat})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(time_format=%q) error = %v, wantErr %v", tt.timeFormat, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestUser_IsAdmin(t *testing.T) {
 	tests := []struct {
 		name     string
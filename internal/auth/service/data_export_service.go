@@ -0,0 +1,299 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/email"
+	"github.com/whento/pkg/i18n"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/repository"
+	"github.com/whento/whento/internal/config"
+)
+
+//go:embed templates/data_export.html
+var dataExportTemplate string
+
+//go:embed templates/locales/data_export.json
+var dataExportTranslationsJSON string
+
+const (
+	// DataExportFormat is the version of the personal data archive layout
+	DataExportFormat = 1
+
+	dataExportExpiry  = 7 * 24 * time.Hour
+	dataExportTimeout = 5 * time.Minute
+)
+
+var (
+	ErrDataExportNotFound   = repository.ErrDataExportNotFound
+	ErrDataExportInProgress = errors.New("a data export is already being prepared")
+)
+
+// DataExportRepository defines the interface for personal data export operations
+type DataExportRepository interface {
+	Create(ctx context.Context, export *models.DataExport, tokenHash string) error
+	GetLatestByUser(ctx context.Context, userID uuid.UUID) (*models.DataExport, error)
+	GetArchive(ctx context.Context, tokenHash string, now time.Time) (*models.DataExport, []byte, error)
+	GetLatestArchive(ctx context.Context, userID uuid.UUID, now time.Time) (*models.DataExport, []byte, error)
+	Complete(ctx context.Context, id uuid.UUID, archive []byte, completedAt time.Time) error
+	Fail(ctx context.Context, id uuid.UUID, completedAt time.Time) error
+	DeleteByUser(ctx context.Context, userID, keepID uuid.UUID) error
+	Collect(ctx context.Context, userID uuid.UUID) ([]models.DataExportSection, error)
+}
+
+// DataExportService assembles archives of the personal data of users (GDPR right of access)
+// Archives are built in the background and the user gets an email with a download link when they are ready
+type DataExportService struct {
+	exportRepo   DataExportRepository
+	userRepo     *repository.UserRepository
+	emailService *email.Service
+	cfg          *config.Config
+	logger       *slog.Logger
+	template     *template.Template
+	translations i18n.Translations
+}
+
+// NewDataExportService creates a new data export service
+func NewDataExportService(
+	exportRepo DataExportRepository,
+	userRepo *repository.UserRepository,
+	emailService *email.Service,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *DataExportService {
+	source, err := email.LoadTemplate(dataExportTemplate, cfg.EmailTemplateDir, "data_export")
+	if err != nil {
+		logger.Error("Failed to load data export template override", "error", err)
+	}
+	tmpl, err := template.New("data_export").Parse(source)
+	if err != nil {
+		logger.Error("Failed to parse data export template", "error", err)
+	}
+
+	trans, err := i18n.Load(dataExportTranslationsJSON, cfg.TranslationsDir, "data_export")
+	if err != nil {
+		logger.Error("Failed to load data export translations", "error", err)
+	}
+	trans = trans.WithVar("ProductName", cfg.Branding.ProductName)
+
+	return &DataExportService{
+		exportRepo:   exportRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		cfg:          cfg,
+		logger:       logger,
+		template:     tmpl,
+		translations: trans,
+	}
+}
+
+// Request starts assembling an archive of the personal data of a user
+// Only one export is prepared at a time; a new one replaces the previous archives once ready
+func (s *DataExportService) Request(ctx context.Context, userID uuid.UUID) (*models.DataExport, error) {
+	now := time.Now()
+
+	latest, err := s.exportRepo.GetLatestByUser(ctx, userID)
+	if err != nil && !errors.Is(err, ErrDataExportNotFound) {
+		return nil, err
+	}
+	if latest != nil && latest.Status == models.DataExportPending && now.Sub(latest.CreatedAt) < dataExportTimeout {
+		return nil, ErrDataExportInProgress
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate download token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	export := &models.DataExport{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    models.DataExportPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(dataExportExpiry),
+	}
+	if err := s.exportRepo.Create(ctx, export, repository.HashToken(token)); err != nil {
+		return nil, err
+	}
+
+	go s.build(export, token)
+
+	return export, nil
+}
+
+// Latest returns the last export requested by a user
+func (s *DataExportService) Latest(ctx context.Context, userID uuid.UUID) (*models.DataExport, error) {
+	return s.exportRepo.GetLatestByUser(ctx, userID)
+}
+
+// Download returns the archive of a ready export from the token of its emailed link
+func (s *DataExportService) Download(ctx context.Context, token string) (*models.DataExport, []byte, error) {
+	return s.exportRepo.GetArchive(ctx, repository.HashToken(token), time.Now())
+}
+
+// DownloadLatest returns the archive of the last export of a user, if it is ready
+func (s *DataExportService) DownloadLatest(ctx context.Context, userID uuid.UUID) (*models.DataExport, []byte, error) {
+	return s.exportRepo.GetLatestArchive(ctx, userID, time.Now())
+}
+
+// build assembles the archive of an export in the background, then emails the download link
+func (s *DataExportService) build(export *models.DataExport, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), dataExportTimeout)
+	defer cancel()
+
+	log := s.logger.With("export_id", export.ID, "user_id", export.UserID)
+
+	fail := func(msg string, err error) {
+		log.Error(msg, "error", err)
+		if err := s.exportRepo.Fail(ctx, export.ID, time.Now()); err != nil {
+			log.Error("Failed to mark data export as failed", "error", err)
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, export.UserID)
+	if err != nil {
+		fail("Failed to get user of data export", err)
+		return
+	}
+
+	sections, err := s.exportRepo.Collect(ctx, export.UserID)
+	if err != nil {
+		fail("Failed to collect personal data", err)
+		return
+	}
+
+	var archive bytes.Buffer
+	if err := writeDataExportArchive(&archive, user, sections, time.Now()); err != nil {
+		fail("Failed to write data export archive", err)
+		return
+	}
+
+	if err := s.exportRepo.Complete(ctx, export.ID, archive.Bytes(), time.Now()); err != nil {
+		fail("Failed to store data export archive", err)
+		return
+	}
+	if err := s.exportRepo.DeleteByUser(ctx, export.UserID, export.ID); err != nil {
+		log.Error("Failed to delete previous data exports", "error", err)
+	}
+
+	log.Info("Data export ready", "size_bytes", archive.Len())
+
+	downloadURL := fmt.Sprintf("%s/api/v1/auth/exports/%s", s.cfg.AppURL, token)
+	if !s.emailService.IsConfigured() {
+		log.Warn("SMTP not configured - data export can be downloaded from the account settings")
+		return
+	}
+	if err := s.sendReadyEmail(user, downloadURL); err != nil {
+		log.Error("Failed to send data export email", "error", err)
+	}
+}
+
+// dataExportManifest describes the content of a personal data archive
+type dataExportManifest struct {
+	Format      int                         `json:"format"`
+	UserID      string                      `json:"user_id"`
+	Email       string                      `json:"email"`
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Files       []dataExportManifestSection `json:"files"`
+}
+
+type dataExportManifestSection struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+// writeDataExportArchive writes a zip archive with a manifest.json and one JSON file per section
+func writeDataExportArchive(w io.Writer, user *models.User, sections []models.DataExportSection, generatedAt time.Time) error {
+	archive := zip.NewWriter(w)
+
+	manifest := dataExportManifest{
+		Format:      DataExportFormat,
+		UserID:      user.ID.String(),
+		Email:       user.Email,
+		GeneratedAt: generatedAt.UTC(),
+	}
+
+	for _, section := range sections {
+		name := section.Name + ".json"
+		file, err := archive.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, section.Data, "", "  "); err != nil {
+			return fmt.Errorf("failed to format %s: %w", name, err)
+		}
+		indented.WriteByte('\n')
+		if _, err := indented.WriteTo(file); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+
+		manifest.Files = append(manifest.Files, dataExportManifestSection{Name: name, Rows: section.Rows})
+	}
+
+	file, err := archive.Create("manifest.json")
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return archive.Close()
+}
+
+// sendReadyEmail sends the download link of an archive
+func (s *DataExportService) sendReadyEmail(user *models.User, downloadURL string) error {
+	trans := s.translations.Lookup(user.Locale)
+
+	expiryDays := strconv.Itoa(int(dataExportExpiry / (24 * time.Hour)))
+	data := map[string]string{
+		"Subject":        trans["subject"],
+		"Greeting":       replaceVarPR(trans["greeting"], "DisplayName", user.DisplayName),
+		"Intro":          trans["intro"],
+		"CTAInstruction": trans["cta_instruction"],
+		"CTAButton":      trans["cta_button"],
+		"OrCopy":         trans["or_copy"],
+		"ExpiryNotice":   replaceVarPR(trans["expiry_notice"], "ExpiryDays", expiryDays),
+		"SecurityNotice": trans["security_notice"],
+		"Signature":      trans["signature"],
+		"DownloadURL":    downloadURL,
+	}
+	maps.Copy(data, s.cfg.Branding.TemplateData())
+
+	var htmlBody bytes.Buffer
+	if err := s.template.Execute(&htmlBody, data); err != nil {
+		return fmt.Errorf("failed to execute data export template: %w", err)
+	}
+
+	return s.emailService.Send(email.Email{
+		To:      []string{user.Email},
+		Subject: trans["subject"],
+		Body:    htmlBody.String(),
+		HTML:    true,
+	})
+}
@@ -0,0 +1,66 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/auth/models"
+)
+
+func TestWriteDataExportArchive(t *testing.T) {
+	user := &models.User{Email: "alice@example.com"}
+	user.ID = uuid.New()
+	sections := []models.DataExportSection{
+		{Name: "profile", Rows: 1, Data: []byte(`[{"email":"alice@example.com"}]`)},
+		{Name: "calendars", Rows: 0, Data: []byte(`[]`)},
+	}
+
+	var buf bytes.Buffer
+	if err := writeDataExportArchive(&buf, user, sections, time.Date(2025, 7, 4, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("writeDataExportArchive() error = %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		files[f.Name], _ = io.ReadAll(r)
+		r.Close()
+	}
+
+	var profile []map[string]string
+	if err := json.Unmarshal(files["profile.json"], &profile); err != nil || len(profile) != 1 || profile[0]["email"] != user.Email {
+		t.Errorf("profile.json = %s, error = %v", files["profile.json"], err)
+	}
+	if _, ok := files["calendars.json"]; !ok {
+		t.Error("calendars.json missing")
+	}
+
+	var manifest dataExportManifest
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("manifest.json: %v", err)
+	}
+	if manifest.Format != DataExportFormat || manifest.UserID != user.ID.String() || len(manifest.Files) != 2 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	if manifest.Files[0].Name != "profile.json" || manifest.Files[0].Rows != 1 {
+		t.Errorf("manifest.Files[0] = %+v, want profile.json with 1 row", manifest.Files[0])
+	}
+}
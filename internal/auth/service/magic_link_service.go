@@ -11,16 +11,15 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"strings"
 	"text/template"
 	"time"
 
-	"github.com/google/uuid"
-
 	"github.com/whento/pkg/email"
+	"github.com/whento/pkg/i18n"
 	"github.com/whento/pkg/jwt"
 	"github.com/whento/whento/internal/auth/models"
 	"github.com/whento/whento/internal/auth/repository"
@@ -41,7 +40,7 @@ type MagicLinkService struct {
 	cfg          *config.Config
 	logger       *slog.Logger
 	template     *template.Template
-	translations map[string]map[string]string
+	translations i18n.Translations
 }
 
 func NewMagicLinkService(
@@ -52,17 +51,22 @@ func NewMagicLinkService(
 	cfg *config.Config,
 	logger *slog.Logger,
 ) *MagicLinkService {
-	// Parse template
-	tmpl, err := template.New("magic_link").Parse(magicLinkTemplate)
+	// Parse template (with an optional self-hosted override)
+	source, err := email.LoadTemplate(magicLinkTemplate, cfg.EmailTemplateDir, "magic_link")
+	if err != nil {
+		logger.Error("Failed to load magic link template override", "error", err)
+	}
+	tmpl, err := template.New("magic_link").Parse(source)
 	if err != nil {
 		logger.Error("Failed to parse magic link template", "error", err)
 	}
 
 	// Load translations
-	var translations map[string]map[string]string
-	if err := json.Unmarshal([]byte(magicLinkTranslationsJSON), &translations); err != nil {
+	translations, err := i18n.Load(magicLinkTranslationsJSON, cfg.TranslationsDir, "email_magic_link")
+	if err != nil {
 		logger.Error("Failed to load magic link translations", "error", err)
 	}
+	translations = translations.WithVar("ProductName", cfg.Branding.ProductName)
 
 	return &MagicLinkService{
 		userRepo:     userRepo,
@@ -135,12 +139,7 @@ func (s *MagicLinkService) VerifyMagicLink(ctx context.Context, token string) (*
 	}
 
 	// Store refresh token in database
-	storedToken := &models.RefreshToken{
-		UserID:    user.ID,
-		TokenHash: repository.HashToken(refreshToken),
-		ExpiresAt: refreshExpiresAt,
-	}
-	storedToken.ID = uuid.New()
+	storedToken := newSession(ctx, user.ID, refreshToken, refreshExpiresAt)
 
 	if err := s.tokenRepo.Create(ctx, storedToken); err != nil {
 		return nil, fmt.Errorf("failed to store refresh token: %w", err)
@@ -165,11 +164,7 @@ func (s *MagicLinkService) sendMagicLinkEmail(to, displayName, locale, token str
 	// Build magic link URL
 	magicLinkURL := fmt.Sprintf("%s/auth/magic-link/verify/%s", s.cfg.AppURL, token)
 
-	// Get translations for locale (fallback to english)
-	trans, ok := s.translations[locale]
-	if !ok {
-		trans = s.translations["en"]
-	}
+	trans := s.translations.Lookup(locale)
 
 	// Prepare template data
 	data := map[string]string{
@@ -184,6 +179,7 @@ func (s *MagicLinkService) sendMagicLinkEmail(to, displayName, locale, token str
 		"Signature":      trans["signature"],
 		"MagicLinkURL":   magicLinkURL,
 	}
+	maps.Copy(data, s.cfg.Branding.TemplateData())
 
 	// Execute template
 	var htmlBody bytes.Buffer
@@ -0,0 +1,355 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/whento/pkg/jwt"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/config"
+)
+
+const (
+	// oidcStateTTL is the time left to the user to sign in on the provider's page
+	oidcStateTTL = 10 * time.Minute
+
+	// oidcNonceClaim binds the state token to the nonce of the ID token
+	oidcNonceClaim = "oidc_nonce"
+
+	// oidcClockSkew is the tolerated clock difference with the provider
+	oidcClockSkew = time.Minute
+
+	maxOIDCResponseSize = 1 << 20
+)
+
+var (
+	ErrOIDCDisabled     = errors.New("single sign-on is not configured")
+	ErrOIDCProvider     = errors.New("identity provider request failed") // Unreachable provider or unexpected response
+	ErrInvalidOIDCState = errors.New("invalid or expired sign-in state") // The user must start the sign-in again
+	ErrInvalidIDToken   = errors.New("invalid ID token")                 // The provider returned an ID token for another client or login
+)
+
+// OIDCService signs users in with an OpenID Connect provider (authorization code flow)
+type OIDCService struct {
+	ssoService   *SSOService
+	jwtManager   *jwt.Manager
+	cfg          config.OIDCConfig
+	redirectURI  string
+	requireHTTPS bool
+	httpClient   *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery // Fetched on first use
+}
+
+// oidcDiscovery is the part of the provider metadata used by the login flow
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// idTokenClaims are the claims read from the ID token
+type idTokenClaims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp"`
+	ExpiresAt         int64    `json:"exp"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     *bool    `json:"email_verified"`
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+	Locale            string   `json:"locale"`
+}
+
+// audience is the "aud" claim, a string or an array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// NewOIDCService creates a new OpenID Connect service
+func NewOIDCService(
+	ssoService *SSOService,
+	jwtManager *jwt.Manager,
+	cfg *config.Config,
+) *OIDCService {
+	return &OIDCService{
+		ssoService:   ssoService,
+		jwtManager:   jwtManager,
+		cfg:          cfg.OIDC,
+		redirectURI:  strings.TrimRight(cfg.AppURL, "/") + "/auth/oidc/callback",
+		requireHTTPS: cfg.AppEnv == "production",
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Enabled reports whether single sign-on is configured
+func (s *OIDCService) Enabled() bool {
+	return s.cfg.Enabled()
+}
+
+// ProviderName returns the label of the login button
+func (s *OIDCService) ProviderName() string {
+	return s.cfg.ProviderName
+}
+
+// AuthURL starts a sign-in and returns its state with the provider login page URL
+// The state must be sent back with the code of the provider redirect
+func (s *OIDCService) AuthURL(ctx context.Context) (state, authURL string, err error) {
+	if !s.Enabled() {
+		return "", "", ErrOIDCDisabled
+	}
+
+	discovery, err := s.getDiscovery(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return "", "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	nonce := hex.EncodeToString(nonceBytes)
+
+	// The state is signed so that no server-side storage is needed until the callback
+	state, err = s.jwtManager.GenerateCustomToken(map[string]interface{}{
+		oidcNonceClaim: nonce,
+		"exp":          time.Now().Add(oidcStateTTL).Unix(),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	params := url.Values{
+		"client_id":     {s.cfg.ClientID},
+		"redirect_uri":  {s.redirectURI},
+		"response_type": {"code"},
+		"scope":         {strings.Join(s.cfg.Scopes, " ")},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return state, discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Callback completes a sign-in with the code of the provider redirect
+// The user is found by identity, then linked by verified email, then provisioned if allowed
+func (s *OIDCService) Callback(ctx context.Context, code, state string) (*models.AuthResponse, error) {
+	if !s.Enabled() {
+		return nil, ErrOIDCDisabled
+	}
+
+	stateClaims, err := s.jwtManager.ValidateCustomToken(state)
+	if err != nil {
+		return nil, ErrInvalidOIDCState
+	}
+	nonce, ok := stateClaims[oidcNonceClaim].(string)
+	if !ok || nonce == "" {
+		return nil, ErrInvalidOIDCState
+	}
+
+	discovery, err := s.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := s.exchange(ctx, discovery, code)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := parseIDToken(idToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateClaims(claims, discovery.Issuer, nonce, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.ssoService.Login(ctx, &ExternalIdentity{
+		Provider: models.IdentityProviderOIDC,
+		Issuer:   claims.Issuer,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+		// Providers without the claim only return verified emails
+		EmailVerified: claims.EmailVerified == nil || *claims.EmailVerified,
+		DisplayName:   oidcDisplayName(claims),
+		Locale:        claims.Locale,
+	}, s.cfg.AutoProvision)
+}
+
+// oidcDisplayName picks the display name of the account at the provider
+func oidcDisplayName(claims *idTokenClaims) string {
+	if name := strings.TrimSpace(claims.Name); name != "" {
+		return name
+	}
+	return strings.TrimSpace(claims.PreferredUsername)
+}
+
+// getDiscovery returns the provider metadata, fetched once from the discovery document
+func (s *OIDCService) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.discovery != nil {
+		return s.discovery, nil
+	}
+
+	if s.requireHTTPS && !strings.HasPrefix(s.cfg.Issuer, "https://") {
+		return nil, fmt.Errorf("%w: issuer must use https", ErrOIDCProvider)
+	}
+
+	var discovery oidcDiscovery
+	discoveryURL := strings.TrimRight(s.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := s.getJSON(ctx, discoveryURL, &discovery); err != nil {
+		return nil, err
+	}
+
+	// The issuer of the metadata must be the configured one (OpenID Connect Discovery 4.3)
+	if discovery.Issuer != s.cfg.Issuer {
+		return nil, fmt.Errorf("%w: discovery issuer %q does not match %q", ErrOIDCProvider, discovery.Issuer, s.cfg.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" {
+		return nil, fmt.Errorf("%w: incomplete discovery document", ErrOIDCProvider)
+	}
+
+	s.discovery = &discovery
+	return s.discovery, nil
+}
+
+// exchange exchanges an authorization code for an ID token
+func (s *OIDCService) exchange(ctx context.Context, discovery *oidcDiscovery, code string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {s.redirectURI},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(url.QueryEscape(s.cfg.ClientID), url.QueryEscape(s.cfg.ClientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrOIDCProvider, err)
+	}
+	defer resp.Body.Close()
+
+	// invalid_grant: the code expired or was already used
+	if resp.StatusCode == http.StatusBadRequest {
+		return "", ErrInvalidOIDCState
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: token endpoint returned %d", ErrOIDCProvider, resp.StatusCode)
+	}
+
+	var body struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOIDCResponseSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: invalid token response: %v", ErrOIDCProvider, err)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("%w: no ID token, is the openid scope requested?", ErrOIDCProvider)
+	}
+	return body.IDToken, nil
+}
+
+// getJSON sends a GET request to the provider and decodes its response
+func (s *OIDCService) getJSON(ctx context.Context, rawURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOIDCProvider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned %d", ErrOIDCProvider, rawURL, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOIDCResponseSize)).Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid response: %v", ErrOIDCProvider, err)
+	}
+	return nil
+}
+
+// parseIDToken decodes the claims of an ID token
+// The signature isn't checked: the token comes straight from the token endpoint over TLS (OpenID Connect Core 3.1.3.7)
+func parseIDToken(idToken string) (*idTokenClaims, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidIDToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	var claims idTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	return &claims, nil
+}
+
+// validateClaims checks that an ID token was issued for this client and sign-in
+func (s *OIDCService) validateClaims(claims *idTokenClaims, issuer, nonce string, now time.Time) error {
+	switch {
+	case claims.Issuer != issuer:
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	case claims.Subject == "":
+		return fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	case !slices.Contains(claims.Audience, s.cfg.ClientID):
+		return fmt.Errorf("%w: issued for another client", ErrInvalidIDToken)
+	case claims.AuthorizedParty != "" && claims.AuthorizedParty != s.cfg.ClientID:
+		return fmt.Errorf("%w: authorized party %q", ErrInvalidIDToken, claims.AuthorizedParty)
+	case now.After(time.Unix(claims.ExpiresAt, 0).Add(oidcClockSkew)):
+		return fmt.Errorf("%w: expired", ErrInvalidIDToken)
+	case claims.Nonce != nonce:
+		return fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/config"
+)
+
+// fakeIDToken builds an unsigned ID token with the given claims
+func fakeIDToken(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2ln"
+}
+
+func TestParseIDToken(t *testing.T) {
+	claims, err := parseIDToken(fakeIDToken(t, map[string]any{
+		"iss": "https://idp.example.com", "sub": "42", "aud": "whento", "email_verified": false,
+	}))
+	if err != nil {
+		t.Fatalf("parseIDToken() error = %v", err)
+	}
+	if claims.Subject != "42" || len(claims.Audience) != 1 || claims.Audience[0] != "whento" {
+		t.Errorf("parseIDToken() = %+v", claims)
+	}
+	if claims.EmailVerified == nil || *claims.EmailVerified {
+		t.Errorf("email_verified = %v, want false", claims.EmailVerified)
+	}
+
+	claims, err = parseIDToken(fakeIDToken(t, map[string]any{"aud": []string{"other", "whento"}}))
+	if err != nil || len(claims.Audience) != 2 {
+		t.Errorf("parseIDToken() with audience array = %+v, %v", claims, err)
+	}
+
+	for _, token := range []string{"", "a.b", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("[]")) + ".c"} {
+		if _, err := parseIDToken(token); !errors.Is(err, ErrInvalidIDToken) {
+			t.Errorf("parseIDToken(%q) error = %v, want ErrInvalidIDToken", token, err)
+		}
+	}
+}
+
+func TestValidateClaims(t *testing.T) {
+	s := &OIDCService{cfg: config.OIDCConfig{ClientID: "whento"}}
+	now := time.Unix(1700000000, 0)
+	valid := func() *idTokenClaims {
+		return &idTokenClaims{
+			Issuer:    "https://idp.example.com",
+			Subject:   "42",
+			Audience:  audience{"whento"},
+			ExpiresAt: now.Add(5 * time.Minute).Unix(),
+			Nonce:     "n0nce",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*idTokenClaims)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*idTokenClaims) {}},
+		{name: "multiple audiences", modify: func(c *idTokenClaims) { c.Audience = audience{"other", "whento"}; c.AuthorizedParty = "whento" }},
+		{name: "expired within clock skew", modify: func(c *idTokenClaims) { c.ExpiresAt = now.Add(-30 * time.Second).Unix() }},
+		{name: "expired", modify: func(c *idTokenClaims) { c.ExpiresAt = now.Add(-2 * time.Minute).Unix() }, wantErr: true},
+		{name: "other issuer", modify: func(c *idTokenClaims) { c.Issuer = "https://evil.example.com" }, wantErr: true},
+		{name: "other audience", modify: func(c *idTokenClaims) { c.Audience = audience{"other"} }, wantErr: true},
+		{name: "other authorized party", modify: func(c *idTokenClaims) { c.AuthorizedParty = "other" }, wantErr: true},
+		{name: "missing subject", modify: func(c *idTokenClaims) { c.Subject = "" }, wantErr: true},
+		{name: "nonce mismatch", modify: func(c *idTokenClaims) { c.Nonce = "replayed" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.modify(claims)
+			err := s.validateClaims(claims, "https://idp.example.com", "n0nce", now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateClaims() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("validateClaims() error = %v, want ErrInvalidIDToken", err)
+			}
+		})
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	tests := []struct {
+		claims idTokenClaims
+		want   string
+	}{
+		{idTokenClaims{Name: " Jane Doe ", PreferredUsername: "jdoe", Email: "jane@example.com"}, "Jane Doe"},
+		{idTokenClaims{PreferredUsername: "jdoe", Email: "jane@example.com"}, "jdoe"},
+		{idTokenClaims{Email: "jane@example.com"}, "jane"},
+	}
+	for _, tt := range tests {
+		ext := &ExternalIdentity{Email: tt.claims.Email, DisplayName: oidcDisplayName(&tt.claims)}
+		if got := ssoDisplayName(ext); got != tt.want {
+			t.Errorf("display name of %+v = %q, want %q", tt.claims, got, tt.want)
+		}
+	}
+}
+
+func TestOIDCDiscoveryAndExchange(t *testing.T) {
+	var server *httptest.Server
+	discoveryRequests := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realms/whento/.well-known/openid-configuration":
+			discoveryRequests++
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 server.URL + "/realms/whento/",
+				"authorization_endpoint": server.URL + "/auth?kc_idp_hint=corp",
+				"token_endpoint":         server.URL + "/token",
+			})
+		case "/token":
+			clientID, secret, ok := r.BasicAuth()
+			if !ok || clientID != "whento" || secret != "s3cret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.PostFormValue("code") != "good" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if r.PostFormValue("redirect_uri") != "https://whento.example.com/auth/oidc/callback" {
+				t.Errorf("redirect_uri = %q", r.PostFormValue("redirect_uri"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "at", "id_token": "a.b.c"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	s := NewOIDCService(nil, nil, &config.Config{
+		AppURL: "https://whento.example.com/",
+		OIDC: config.OIDCConfig{
+			Issuer:       server.URL + "/realms/whento/", // Trailing slash kept, as issued by some providers
+			ClientID:     "whento",
+			ClientSecret: "s3cret",
+		},
+	})
+
+	ctx := context.Background()
+	discovery, err := s.getDiscovery(ctx)
+	if err != nil {
+		t.Fatalf("getDiscovery() error = %v", err)
+	}
+	if _, err := s.getDiscovery(ctx); err != nil || discoveryRequests != 1 {
+		t.Errorf("discovery fetched %d times, want 1 (cached)", discoveryRequests)
+	}
+
+	idToken, err := s.exchange(ctx, discovery, "good")
+	if err != nil || idToken != "a.b.c" {
+		t.Errorf("exchange() = %q, %v, want the ID token", idToken, err)
+	}
+	if _, err := s.exchange(ctx, discovery, "used"); !errors.Is(err, ErrInvalidOIDCState) {
+		t.Errorf("exchange() with a used code error = %v, want ErrInvalidOIDCState", err)
+	}
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		s := NewOIDCService(nil, nil, &config.Config{
+			OIDC: config.OIDCConfig{Issuer: server.URL + "/realms/whento", ClientID: "whento", ClientSecret: "s3cret"},
+		})
+		if _, err := s.getDiscovery(ctx); !errors.Is(err, ErrOIDCProvider) {
+			t.Errorf("getDiscovery() error = %v, want ErrOIDCProvider", err)
+		}
+	})
+
+	t.Run("http issuer in production", func(t *testing.T) {
+		s := NewOIDCService(nil, nil, &config.Config{
+			AppEnv: "production",
+			OIDC:   config.OIDCConfig{Issuer: server.URL + "/realms/whento/", ClientID: "whento", ClientSecret: "s3cret"},
+		})
+		if _, err := s.getDiscovery(ctx); !errors.Is(err, ErrOIDCProvider) {
+			t.Errorf("getDiscovery() error = %v, want ErrOIDCProvider", err)
+		}
+	})
+}
@@ -10,17 +10,17 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"strings"
 	"text/template"
 	"time"
 
-	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/whento/pkg/email"
+	"github.com/whento/pkg/i18n"
 	"github.com/whento/pkg/jwt"
 	"github.com/whento/whento/internal/auth/models"
 	"github.com/whento/whento/internal/auth/repository"
@@ -48,7 +48,7 @@ type PasswordResetService struct {
 	logger            *slog.Logger
 	bcryptCost        int
 	resetTemplate     *template.Template
-	resetTranslations map[string]map[string]string
+	resetTranslations i18n.Translations
 }
 
 // NewPasswordResetService creates a new password reset service
@@ -61,17 +61,22 @@ func NewPasswordResetService(
 	logger *slog.Logger,
 	bcryptCost int,
 ) *PasswordResetService {
-	// Parse password reset template
-	resetTmpl, err := template.New("password_reset").Parse(passwordResetTemplate)
+	// Parse password reset template (with an optional self-hosted override)
+	source, err := email.LoadTemplate(passwordResetTemplate, cfg.EmailTemplateDir, "password_reset")
+	if err != nil {
+		logger.Error("Failed to load password reset template override", "error", err)
+	}
+	resetTmpl, err := template.New("password_reset").Parse(source)
 	if err != nil {
 		logger.Error("Failed to parse password reset template", "error", err)
 	}
 
 	// Load password reset translations
-	var resetTrans map[string]map[string]string
-	if err := json.Unmarshal([]byte(passwordResetTranslationsJSON), &resetTrans); err != nil {
+	resetTrans, err := i18n.Load(passwordResetTranslationsJSON, cfg.TranslationsDir, "password_reset")
+	if err != nil {
 		logger.Error("Failed to load password reset translations", "error", err)
 	}
+	resetTrans = resetTrans.WithVar("ProductName", cfg.Branding.ProductName)
 
 	return &PasswordResetService{
 		userRepo:          userRepo,
@@ -201,12 +206,7 @@ func (s *PasswordResetService) ResetPassword(ctx context.Context, req *models.Re
 	}
 
 	// Store refresh token hash
-	storedToken := &models.RefreshToken{
-		UserID:    user.ID,
-		TokenHash: repository.HashToken(refreshToken),
-		ExpiresAt: expiresAt,
-	}
-	storedToken.ID = uuid.New()
+	storedToken := newSession(ctx, user.ID, refreshToken, expiresAt)
 
 	if err := s.tokenRepo.Create(ctx, storedToken); err != nil {
 		return nil, fmt.Errorf("failed to store refresh token: %w", err)
@@ -235,11 +235,7 @@ func (s *PasswordResetService) generateResetToken() (string, error) {
 
 // sendPasswordResetEmail sends the reset email
 func (s *PasswordResetService) sendPasswordResetEmail(user *models.User, resetURL string) error {
-	// Get translations for locale (fallback to english)
-	trans, ok := s.resetTranslations[user.Locale]
-	if !ok {
-		trans = s.resetTranslations["en"]
-	}
+	trans := s.resetTranslations.Lookup(user.Locale)
 
 	// Prepare template data
 	expiryDuration := passwordResetTokenExpiry.String()
@@ -255,6 +251,7 @@ func (s *PasswordResetService) sendPasswordResetEmail(user *models.User, resetUR
 		"Signature":      trans["signature"],
 		"ResetURL":       resetURL,
 	}
+	maps.Copy(data, s.cfg.Branding.TemplateData())
 
 	// Execute template
 	var htmlBody bytes.Buffer
@@ -292,6 +289,8 @@ func toUserResponse(user *models.User) *models.UserResponse {
 		Role:          user.Role,
 		Locale:        user.Locale,
 		Timezone:      user.Timezone,
+		TimeFormat:    user.TimeFormat,
+		WeeklySummary: user.WeeklySummary,
 		EmailVerified: user.EmailVerified,
 		CreatedAt:     user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -0,0 +1,132 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/repository"
+)
+
+const (
+	// PersonalTokenPrefix identifies personal access tokens in Authorization headers
+	PersonalTokenPrefix = "wtpat_"
+
+	// MaxPersonalTokensPerUser limits the number of personal access tokens of a user
+	MaxPersonalTokensPerUser = 25
+)
+
+var (
+	ErrPersonalTokenNotFound   = repository.ErrPersonalTokenNotFound
+	ErrTooManyPersonalTokens   = fmt.Errorf("too many personal access tokens (maximum %d)", MaxPersonalTokensPerUser)
+	ErrPersonalTokenScopeAdmin = errors.New("only administrators can create admin tokens")
+)
+
+// PersonalTokenRepository defines the interface for personal access token operations
+type PersonalTokenRepository interface {
+	Create(ctx context.Context, token *models.PersonalToken, tokenHash string) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.PersonalToken, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+	Authenticate(ctx context.Context, tokenHash string) (*models.PersonalTokenOwner, error)
+}
+
+// PersonalTokenService manages personal access tokens: long-lived scoped Bearer tokens of the REST API
+type PersonalTokenService struct {
+	tokenRepo PersonalTokenRepository
+	logger    *slog.Logger
+}
+
+// NewPersonalTokenService creates a new personal access token service
+func NewPersonalTokenService(tokenRepo PersonalTokenRepository, logger *slog.Logger) *PersonalTokenService {
+	return &PersonalTokenService{
+		tokenRepo: tokenRepo,
+		logger:    logger,
+	}
+}
+
+// Create creates a personal access token and returns its value, which is not stored
+func (s *PersonalTokenService) Create(ctx context.Context, userID uuid.UUID, role string, req *models.CreatePersonalTokenRequest) (*models.PersonalToken, string, error) {
+	if req.Scope == models.TokenScopeAdmin && role != models.RoleAdmin {
+		return nil, "", ErrPersonalTokenScopeAdmin
+	}
+
+	count, err := s.tokenRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if count >= MaxPersonalTokensPerUser {
+		return nil, "", ErrTooManyPersonalTokens
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate personal access token: %w", err)
+	}
+	value := PersonalTokenPrefix + base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	now := time.Now()
+	token := &models.PersonalToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      req.Name,
+		Scope:     req.Scope,
+		CreatedAt: now,
+	}
+	if req.ExpiresInDays != nil {
+		expiresAt := now.AddDate(0, 0, *req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.tokenRepo.Create(ctx, token, repository.HashToken(value)); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.Info("Personal access token created", "token_id", token.ID, "user_id", userID, "scope", token.Scope)
+	return token, value, nil
+}
+
+// List returns the personal access tokens of a user
+func (s *PersonalTokenService) List(ctx context.Context, userID uuid.UUID) ([]*models.PersonalToken, error) {
+	return s.tokenRepo.ListByUser(ctx, userID)
+}
+
+// Delete revokes a personal access token of a user
+func (s *PersonalTokenService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.tokenRepo.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Personal access token revoked", "token_id", id, "user_id", userID)
+	return nil
+}
+
+// Authenticate returns the user owning an unexpired personal access token
+func (s *PersonalTokenService) Authenticate(ctx context.Context, value string) (*models.PersonalTokenOwner, error) {
+	if !IsPersonalToken(value) {
+		return nil, ErrInvalidToken
+	}
+
+	owner, err := s.tokenRepo.Authenticate(ctx, repository.HashToken(value))
+	if errors.Is(err, ErrPersonalTokenNotFound) {
+		return nil, ErrInvalidToken
+	}
+	return owner, err
+}
+
+// IsPersonalToken reports whether a Bearer token looks like a personal access token
+func IsPersonalToken(value string) bool {
+	return strings.HasPrefix(value, PersonalTokenPrefix)
+}
@@ -0,0 +1,200 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/repository"
+)
+
+type mockPersonalTokenRepository struct {
+	tokens map[string]*models.PersonalToken // By hash
+	owners map[uuid.UUID]*models.PersonalTokenOwner
+}
+
+func newMockPersonalTokenRepository() *mockPersonalTokenRepository {
+	return &mockPersonalTokenRepository{
+		tokens: make(map[string]*models.PersonalToken),
+		owners: make(map[uuid.UUID]*models.PersonalTokenOwner),
+	}
+}
+
+func (m *mockPersonalTokenRepository) Create(ctx context.Context, token *models.PersonalToken, tokenHash string) error {
+	m.tokens[tokenHash] = token
+	return nil
+}
+
+func (m *mockPersonalTokenRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.PersonalToken, error) {
+	var tokens []*models.PersonalToken
+	for _, token := range m.tokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *mockPersonalTokenRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	tokens, _ := m.ListByUser(ctx, userID)
+	return len(tokens), nil
+}
+
+func (m *mockPersonalTokenRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	for hash, token := range m.tokens {
+		if token.ID == id && token.UserID == userID {
+			delete(m.tokens, hash)
+			return nil
+		}
+	}
+	return repository.ErrPersonalTokenNotFound
+}
+
+func (m *mockPersonalTokenRepository) Authenticate(ctx context.Context, tokenHash string) (*models.PersonalTokenOwner, error) {
+	token, ok := m.tokens[tokenHash]
+	if !ok || (token.ExpiresAt != nil && token.ExpiresAt.Before(time.Now())) {
+		return nil, repository.ErrPersonalTokenNotFound
+	}
+	owner := m.owners[token.UserID]
+	return &models.PersonalTokenOwner{TokenID: token.ID, Scope: token.Scope, UserID: owner.UserID, Email: owner.Email, Role: owner.Role}, nil
+}
+
+func TestPersonalTokenService_CreateAndAuthenticate(t *testing.T) {
+	repo := newMockPersonalTokenRepository()
+	svc := NewPersonalTokenService(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	userID := uuid.New()
+	repo.owners[userID] = &models.PersonalTokenOwner{UserID: userID, Email: "user@example.com", Role: models.RoleUser}
+
+	days := 30
+	token, value, err := svc.Create(ctx, userID, models.RoleUser, &models.CreatePersonalTokenRequest{
+		Name:          "CI",
+		Scope:         models.TokenScopeCalendarsWrite,
+		ExpiresInDays: &days,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !strings.HasPrefix(value, PersonalTokenPrefix) || !IsPersonalToken(value) {
+		t.Errorf("Create() value = %q, want %q prefix", value, PersonalTokenPrefix)
+	}
+	if token.ExpiresAt == nil || token.ExpiresAt.Sub(token.CreatedAt) != 30*24*time.Hour {
+		t.Errorf("Create() ExpiresAt = %v, want 30 days after creation", token.ExpiresAt)
+	}
+	if _, ok := repo.tokens[value]; ok {
+		t.Error("Create() stored the token value instead of its hash")
+	}
+
+	owner, err := svc.Authenticate(ctx, value)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if owner.UserID != userID || owner.Scope != models.TokenScopeCalendarsWrite {
+		t.Errorf("Authenticate() = %+v, want user %s with calendars:write", owner, userID)
+	}
+
+	if _, err := svc.Authenticate(ctx, value+"x"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Authenticate(unknown) error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := svc.Authenticate(ctx, "eyJhbGciOiJSUzI1NiJ9.payload.signature"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Authenticate(JWT) error = %v, want ErrInvalidToken", err)
+	}
+
+	if err := svc.Delete(ctx, userID, token.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, value); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Authenticate(revoked) error = %v, want ErrInvalidToken", err)
+	}
+	if err := svc.Delete(ctx, userID, token.ID); !errors.Is(err, ErrPersonalTokenNotFound) {
+		t.Errorf("Delete(revoked) error = %v, want ErrPersonalTokenNotFound", err)
+	}
+}
+
+func TestPersonalTokenService_CreateLimits(t *testing.T) {
+	repo := newMockPersonalTokenRepository()
+	svc := NewPersonalTokenService(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	adminReq := &models.CreatePersonalTokenRequest{Name: "Admin", Scope: models.TokenScopeAdmin}
+	if _, _, err := svc.Create(ctx, userID, models.RoleUser, adminReq); !errors.Is(err, ErrPersonalTokenScopeAdmin) {
+		t.Errorf("Create(admin scope by user) error = %v, want ErrPersonalTokenScopeAdmin", err)
+	}
+	if _, _, err := svc.Create(ctx, userID, models.RoleAdmin, adminReq); err != nil {
+		t.Errorf("Create(admin scope by admin) error = %v", err)
+	}
+
+	for i := 1; i < MaxPersonalTokensPerUser; i++ {
+		if _, _, err := svc.Create(ctx, userID, models.RoleAdmin, &models.CreatePersonalTokenRequest{Name: "Token", Scope: models.TokenScopeReadOnly}); err != nil {
+			t.Fatalf("Create() #%d error = %v", i, err)
+		}
+	}
+	if _, _, err := svc.Create(ctx, userID, models.RoleAdmin, &models.CreatePersonalTokenRequest{Name: "Token", Scope: models.TokenScopeReadOnly}); !errors.Is(err, ErrTooManyPersonalTokens) {
+		t.Errorf("Create() over the limit error = %v, want ErrTooManyPersonalTokens", err)
+	}
+}
+
+func TestTokenScopeAllows(t *testing.T) {
+	tests := []struct {
+		scope  string
+		method string
+		path   string
+		want   bool
+	}{
+		{models.TokenScopeReadOnly, "GET", "/api/v1/calendars", true},
+		{models.TokenScopeReadOnly, "HEAD", "/api/v1/auth/me", true},
+		{models.TokenScopeReadOnly, "POST", "/api/v1/calendars", false},
+		{models.TokenScopeReadOnly, "POST", "/api/graphql", true},
+		{models.TokenScopeCalendarsWrite, "GET", "/api/v1/organizations", true},
+		{models.TokenScopeCalendarsWrite, "POST", "/api/v1/calendars", true},
+		{models.TokenScopeCalendarsWrite, "DELETE", "/api/v1/calendars/abc/participants/def", true},
+		{models.TokenScopeCalendarsWrite, "POST", "/api/v1/hooks", true},
+		{models.TokenScopeCalendarsWrite, "POST", "/api/v1/calendarsx", false},
+		{models.TokenScopeCalendarsWrite, "PATCH", "/api/v1/auth/me", false},
+		{models.TokenScopeCalendarsWrite, "DELETE", "/api/v1/organizations/abc", false},
+		{models.TokenScopeAdmin, "DELETE", "/api/v1/auth/admin/users/abc", true},
+		{"unknown", "GET", "/api/v1/calendars", false},
+	}
+
+	for _, tt := range tests {
+		if got := models.TokenScopeAllows(tt.scope, tt.method, tt.path); got != tt.want {
+			t.Errorf("TokenScopeAllows(%q, %s %s) = %v, want %v", tt.scope, tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCreatePersonalTokenRequest_Validation(t *testing.T) {
+	days := 0
+	tests := []struct {
+		name    string
+		req     models.CreatePersonalTokenRequest
+		wantErr bool
+	}{
+		{"valid", models.CreatePersonalTokenRequest{Name: "CI", Scope: models.TokenScopeReadOnly}, false},
+		{"missing name", models.CreatePersonalTokenRequest{Scope: models.TokenScopeReadOnly}, true},
+		{"unknown scope", models.CreatePersonalTokenRequest{Name: "CI", Scope: "write"}, true},
+		{"zero expiry", models.CreatePersonalTokenRequest{Name: "CI", Scope: models.TokenScopeAdmin, ExpiresInDays: &days}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(&tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
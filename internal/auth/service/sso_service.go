@@ -0,0 +1,229 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/i18n"
+	"github.com/whento/whento/internal/auth/models"
+	"github.com/whento/whento/internal/auth/repository"
+)
+
+// loginTicketTTL is the time left to the web app to redeem a login ticket after the provider redirect
+const loginTicketTTL = time.Minute
+
+var (
+	ErrSSOEmailMissing    = errors.New("identity provider did not return email") // The email is required to provision or link accounts
+	ErrSSOAccountNotFound = errors.New("no account is linked to this identity")  // Automatic provisioning is disabled
+)
+
+// IdentityRepository defines the interface for single sign-on identity operations
+type IdentityRepository interface {
+	Get(ctx context.Context, provider, issuer, subject string) (*models.Identity, error)
+	Create(ctx context.Context, identity *models.Identity) error
+	RecordLogin(ctx context.Context, identity *models.Identity) error
+	SetLoginTicket(ctx context.Context, identityID uuid.UUID, ticketHash string, expiresAt time.Time) error
+	RedeemLoginTicket(ctx context.Context, ticketHash string) (uuid.UUID, error)
+}
+
+// ExternalIdentity is an account authenticated by a single sign-on provider
+type ExternalIdentity struct {
+	Provider      string // models.IdentityProvider*
+	Issuer        string
+	Subject       string // Stable ID of the account at the provider
+	Email         string
+	EmailVerified bool // Unverified emails never link existing accounts
+	DisplayName   string
+	Locale        string
+}
+
+// SSOService links single sign-on identities to users, provisioning accounts on first sign-in
+type SSOService struct {
+	authService  *AuthService
+	userRepo     UserRepository
+	identityRepo IdentityRepository
+	logger       *slog.Logger
+}
+
+// NewSSOService creates a new single sign-on service
+func NewSSOService(
+	authService *AuthService,
+	userRepo UserRepository,
+	identityRepo IdentityRepository,
+	logger *slog.Logger,
+) *SSOService {
+	return &SSOService{
+		authService:  authService,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		logger:       logger,
+	}
+}
+
+// Login signs in the user of an identity, or returns a temporary token if 2FA is enabled
+func (s *SSOService) Login(ctx context.Context, ext *ExternalIdentity, autoProvision bool) (*models.AuthResponse, error) {
+	user, _, err := s.Resolve(ctx, ext, autoProvision)
+	if err != nil {
+		return nil, err
+	}
+	return s.authService.CompleteLogin(ctx, user)
+}
+
+// IssueTicket returns a single-use login ticket for the user of an identity
+// Used by providers that post back to the API (SAML), which then redirects to the web app with the ticket
+func (s *SSOService) IssueTicket(ctx context.Context, ext *ExternalIdentity, autoProvision bool) (string, error) {
+	_, identity, err := s.Resolve(ctx, ext, autoProvision)
+	if err != nil {
+		return "", err
+	}
+
+	ticketBytes := make([]byte, 32)
+	if _, err := rand.Read(ticketBytes); err != nil {
+		return "", fmt.Errorf("failed to generate login ticket: %w", err)
+	}
+	ticket := hex.EncodeToString(ticketBytes)
+
+	if err := s.identityRepo.SetLoginTicket(ctx, identity.ID, repository.HashToken(ticket), time.Now().Add(loginTicketTTL)); err != nil {
+		return "", err
+	}
+	return ticket, nil
+}
+
+// RedeemTicket signs in with a login ticket, or returns a temporary token if 2FA is enabled
+func (s *SSOService) RedeemTicket(ctx context.Context, ticket string) (*models.AuthResponse, error) {
+	userID, err := s.identityRepo.RedeemLoginTicket(ctx, repository.HashToken(ticket))
+	if err != nil {
+		if errors.Is(err, repository.ErrIdentityNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return s.authService.CompleteLogin(ctx, user)
+}
+
+// Resolve returns the user of an identity, linking or provisioning the account on first sign-in
+func (s *SSOService) Resolve(ctx context.Context, ext *ExternalIdentity, autoProvision bool) (*models.User, *models.Identity, error) {
+	identity, err := s.identityRepo.Get(ctx, ext.Provider, ext.Issuer, ext.Subject)
+	if err == nil {
+		identity.Email = ext.Email
+		if err := s.identityRepo.RecordLogin(ctx, identity); err != nil {
+			s.logger.Warn("Failed to record identity login", "identity_id", identity.ID, "error", err)
+		}
+
+		user, err := s.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		return user, identity, nil
+	}
+	if !errors.Is(err, repository.ErrIdentityNotFound) {
+		return nil, nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	// First sign-in with this identity: link it to the account of the same email
+	// Unverified emails could be set by anyone at the provider, so they never take over an account
+	if ext.Email == "" {
+		return nil, nil, ErrSSOEmailMissing
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, ext.Email)
+	switch {
+	case err == nil:
+		if !ext.EmailVerified {
+			return nil, nil, ErrUserAlreadyExists
+		}
+	case errors.Is(err, repository.ErrUserNotFound):
+		if !autoProvision {
+			return nil, nil, ErrSSOAccountNotFound
+		}
+		if user, err = s.provision(ctx, ext); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	identity = &models.Identity{
+		ID:       uuid.New(),
+		UserID:   user.ID,
+		Provider: ext.Provider,
+		Issuer:   ext.Issuer,
+		Subject:  ext.Subject,
+		Email:    ext.Email,
+	}
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		return nil, nil, err
+	}
+
+	s.logger.Info("Linked single sign-on identity", "user_id", user.ID, "provider", ext.Provider, "issuer", ext.Issuer)
+	return user, identity, nil
+}
+
+// provision creates the account of a new single sign-on user
+func (s *SSOService) provision(ctx context.Context, ext *ExternalIdentity) (*models.User, error) {
+	// The first user is admin, like with registration
+	count, err := s.userRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	role := models.RoleUser
+	if count == 0 {
+		role = models.RoleAdmin
+	} else if !s.authService.emailAllowed(ext.Email) {
+		return nil, ErrEmailNotAllowed
+	}
+
+	locale := i18n.Match(ext.Locale)
+
+	// No password: the user signs in through the provider, or sets one with a password reset
+	user := &models.User{
+		Email:         ext.Email,
+		DisplayName:   ssoDisplayName(ext),
+		Role:          role,
+		Locale:        locale,
+		Timezone:      "Europe/Paris",
+		EmailVerified: ext.EmailVerified,
+	}
+	user.ID = uuid.New()
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrUserAlreadyExists) {
+			return nil, ErrUserAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	s.logger.Info("Provisioned single sign-on user", "user_id", user.ID, "provider", ext.Provider, "role", role)
+	return user, nil
+}
+
+// ssoDisplayName picks the display name of a provisioned user, the local part of the email by default
+func ssoDisplayName(ext *ExternalIdentity) string {
+	name := strings.TrimSpace(ext.DisplayName)
+	if name == "" {
+		name, _, _ = strings.Cut(ext.Email, "@")
+	}
+	// display_name is limited to 100 characters
+	if runes := []rune(name); len(runes) > 100 {
+		name = string(runes[:100])
+	}
+	return name
+}
@@ -30,16 +30,17 @@ func NewAvailabilityHandler(availabilityService *service.AvailabilityService) *A
 // CreateAvailability handles creating a new availability
 //
 //	@Summary		Create availability
-//	@Description	Creates a new availability slot for a participant. Public endpoint (uses calendar token).
+//	@Description	Creates a new availability slot for a participant. On a date that reached the calendar's max_participants, the availability is rejected, or queued with waitlisted set when the calendar uses the waitlist policy. Public endpoint (uses calendar token).
 //	@Tags			Availabilities
 //	@Accept			json
 //	@Produce		json
 //	@Param			token	path		string								true	"Calendar public token"
-//	@Param			pid		path		string								true	"Participant ID"
+//	@Param			pid		path		string								true	"Participant access token"
 //	@Param			request	body		models.CreateAvailabilityRequest	true	"Availability details"
 //	@Success		201		{object}	models.AvailabilityResponse
 //	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
 //	@Failure		404		{object}	httputil.ErrorResponse	"Calendar or participant not found"
+//	@Failure		409		{object}	httputil.ErrorResponse	"Availability already exists or date is full"
 //	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid} [post]
 func (h *AvailabilityHandler) CreateAvailability(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
@@ -76,7 +77,7 @@ func (h *AvailabilityHandler) CreateAvailability(w http.ResponseWriter, r *http.
 //	@Tags			Availabilities
 //	@Produce		json
 //	@Param			token	path		string	true	"Calendar public token"
-//	@Param			pid		path		string	true	"Participant ID"
+//	@Param			pid		path		string	true	"Participant access token"
 //	@Param			start	query		string	false	"Start date (YYYY-MM-DD)"
 //	@Param			end		query		string	false	"End date (YYYY-MM-DD)"
 //	@Success		200		{object}	models.ParticipantAvailabilitiesResponse
@@ -108,12 +109,13 @@ func (h *AvailabilityHandler) GetParticipantAvailabilities(w http.ResponseWriter
 //	@Accept			json
 //	@Produce		json
 //	@Param			token	path		string								true	"Calendar public token"
-//	@Param			pid		path		string								true	"Participant ID"
+//	@Param			pid		path		string								true	"Participant access token"
 //	@Param			date	path		string								true	"Date (YYYY-MM-DD)"
 //	@Param			request	body		models.UpdateAvailabilityRequest	true	"Updated availability"
 //	@Success		200		{object}	models.AvailabilityResponse
 //	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
 //	@Failure		404		{object}	httputil.ErrorResponse	"Availability not found"
+//	@Failure		409		{object}	httputil.ErrorResponse	"Date is full"
 //	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid}/{date} [patch]
 func (h *AvailabilityHandler) UpdateAvailability(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
@@ -147,11 +149,11 @@ func (h *AvailabilityHandler) UpdateAvailability(w http.ResponseWriter, r *http.
 // DeleteAvailability deletes an availability
 //
 //	@Summary		Delete availability
-//	@Description	Deletes an availability slot for a specific date. Public endpoint.
+//	@Description	Deletes an availability slot for a specific date, or its entry on the waitlist of a full date. Public endpoint.
 //	@Tags			Availabilities
 //	@Produce		json
 //	@Param			token	path		string	true	"Calendar public token"
-//	@Param			pid		path		string	true	"Participant ID"
+//	@Param			pid		path		string	true	"Participant access token"
 //	@Param			date	path		string	true	"Date (YYYY-MM-DD)"
 //	@Success		200		{object}	map[string]string
 //	@Failure		404		{object}	httputil.ErrorResponse	"Availability not found"
@@ -170,22 +172,67 @@ func (h *AvailabilityHandler) DeleteAvailability(w http.ResponseWriter, r *http.
 	httputil.JSON(w, http.StatusOK, map[string]string{"message": "Availability deleted successfully"})
 }
 
+// BulkAvailability handles setting and deleting availabilities of several dates at once
+//
+//	@Summary		Bulk availability submission
+//	@Description	Creates or replaces the availabilities of the given dates and deletes the availabilities of the dates in the delete list, atomically: if any entry is invalid, nothing is changed. Public endpoint (uses calendar token).
+//	@Tags			Availabilities
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string							true	"Calendar public token"
+//	@Param			pid		path		string							true	"Participant access token"
+//	@Param			request	body		models.BulkAvailabilityRequest	true	"Availabilities to set and dates to delete"
+//	@Success		200		{object}	models.BulkAvailabilityResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar or participant not found"
+//	@Failure		409		{object}	httputil.ErrorResponse	"Date is full"
+//	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid}/bulk [post]
+func (h *AvailabilityHandler) BulkAvailability(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	participantID := chi.URLParam(r, "pid")
+
+	var req models.BulkAvailabilityRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	result, err := h.availabilityService.BulkAvailability(r.Context(), token, participantID, &req)
+	if err != nil {
+		handleAvailabilityError(w, r, err, "Failed to apply availabilities")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, result)
+}
+
 // GetDateSummary gets all participants available on a specific date
 //
 //	@Summary		Get date summary
-//	@Description	Returns all participants available on a specific date with their time slots. Public endpoint.
+//	@Description	Returns all participants available on a specific date with their time slots, and the comments posted on the date. Public endpoint.
 //	@Tags			Availabilities
 //	@Produce		json
 //	@Param			token	path		string	true	"Calendar public token"
 //	@Param			date	path		string	true	"Date (YYYY-MM-DD)"
+//	@Param			tz		query		string	false	"IANA timezone to convert times into (defaults to the calendar timezone)"
 //	@Success		200		{object}	models.DateAvailabilitySummary
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid timezone"
 //	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
 //	@Router			/api/v1/availabilities/calendar/{token}/dates/{date} [get]
 func (h *AvailabilityHandler) GetDateSummary(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
 	date := chi.URLParam(r, "date")
 
-	summary, err := h.availabilityService.GetDateSummary(r.Context(), token, date)
+	summary, err := h.availabilityService.GetDateSummary(r.Context(), token, date, r.URL.Query().Get("tz"))
 	if err != nil {
 		handleAvailabilityError(w, r, err, "Failed to get date summary")
 		return
@@ -197,14 +244,15 @@ func (h *AvailabilityHandler) GetDateSummary(w http.ResponseWriter, r *http.Requ
 // GetRangeSummary gets availability summary over a date range
 //
 //	@Summary		Get range summary
-//	@Description	Returns availability summary for all dates in a range. Public endpoint.
+//	@Description	Returns availability summary for all dates in a range, sorted by date and tagged with the start of their week (per the calendar week_start setting). Public endpoint.
 //	@Tags			Availabilities
 //	@Produce		json
 //	@Param			token	path		string	true	"Calendar public token"
 //	@Param			start	query		string	true	"Start date (YYYY-MM-DD)"
 //	@Param			end		query		string	true	"End date (YYYY-MM-DD)"
+//	@Param			tz		query		string	false	"IANA timezone to convert times into (defaults to the calendar timezone)"
 //	@Success		200		{array}		models.DateAvailabilitySummary
-//	@Failure		400		{object}	httputil.ErrorResponse	"Missing start/end parameters"
+//	@Failure		400		{object}	httputil.ErrorResponse	"Missing start/end parameters or invalid timezone"
 //	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
 //	@Router			/api/v1/availabilities/calendar/{token}/range [get]
 func (h *AvailabilityHandler) GetRangeSummary(w http.ResponseWriter, r *http.Request) {
@@ -217,7 +265,7 @@ func (h *AvailabilityHandler) GetRangeSummary(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	summaries, err := h.availabilityService.GetRangeSummary(r.Context(), token, startDate, endDate, r.URL.Query().Get("participant_id"))
+	summaries, err := h.availabilityService.GetRangeSummary(r.Context(), token, startDate, endDate, r.URL.Query().Get("participant_id"), r.URL.Query().Get("tz"))
 	if err != nil {
 		handleAvailabilityError(w, r, err, "Failed to get range summary")
 		return
@@ -226,6 +274,259 @@ func (h *AvailabilityHandler) GetRangeSummary(w http.ResponseWriter, r *http.Req
 	httputil.JSON(w, http.StatusOK, summaries)
 }
 
+// CreateComment handles posting a comment on a date
+//
+//	@Summary		Comment on a date
+//	@Description	Posts a short comment of a participant on a date, shown in the date summary. Public endpoint (uses calendar token).
+//	@Tags			Availabilities
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string							true	"Calendar public token"
+//	@Param			date	path		string							true	"Date (YYYY-MM-DD)"
+//	@Param			request	body		models.CreateDateCommentRequest	true	"Comment"
+//	@Success		201		{object}	models.DateComment
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar or participant not found"
+//	@Router			/api/v1/availabilities/calendar/{token}/dates/{date}/comments [post]
+func (h *AvailabilityHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	date := chi.URLParam(r, "date")
+
+	var req models.CreateDateCommentRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	comment, err := h.availabilityService.CreateComment(r.Context(), token, date, &req)
+	if err != nil {
+		handleAvailabilityError(w, r, err, "Failed to post comment")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, comment)
+}
+
+// DeleteComment handles deleting a comment
+//
+//	@Summary		Delete a comment
+//	@Description	Deletes a date comment. Only the participant who posted it can delete it. Public endpoint (uses calendar token).
+//	@Tags			Availabilities
+//	@Produce		json
+//	@Param			token	path		string	true	"Calendar public token"
+//	@Param			pid		path		string	true	"Participant access token"
+//	@Param			cid		path		string	true	"Comment ID"
+//	@Success		200		{object}	map[string]string
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar, participant or comment not found"
+//	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid}/comments/{cid} [delete]
+func (h *AvailabilityHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	participantID := chi.URLParam(r, "pid")
+	commentID := chi.URLParam(r, "cid")
+
+	if err := h.availabilityService.DeleteComment(r.Context(), token, participantID, commentID); err != nil {
+		handleAvailabilityError(w, r, err, "Failed to delete comment")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, map[string]string{"message": "Comment deleted successfully"})
+}
+
+// SetRSVP handles answering an event
+//
+//	@Summary		Answer an event
+//	@Description	Records whether a participant is going to an event, apart from their availability. Only dates reaching the threshold (and confirmed by the owner on calendars requiring it) can be answered. Public endpoint (uses calendar token).
+//	@Tags			Availabilities
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string					true	"Calendar public token"
+//	@Param			pid		path		string					true	"Participant access token"
+//	@Param			date	path		string					true	"Date (YYYY-MM-DD)"
+//	@Param			request	body		models.SetRSVPRequest	true	"Answer"
+//	@Success		200		{object}	models.RSVP
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar or participant not found"
+//	@Failure		409		{object}	httputil.ErrorResponse	"The date is not an event"
+//	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid}/rsvp/{date} [put]
+func (h *AvailabilityHandler) SetRSVP(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	participantID := chi.URLParam(r, "pid")
+	date := chi.URLParam(r, "date")
+
+	var req models.SetRSVPRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	rsvp, err := h.availabilityService.SetRSVP(r.Context(), token, participantID, date, &req)
+	if err != nil {
+		handleAvailabilityError(w, r, err, "Failed to answer event")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, rsvp)
+}
+
+// DeleteRSVP handles withdrawing an answer to an event
+//
+//	@Summary		Withdraw an answer
+//	@Description	Withdraws the answer of a participant to an event. Public endpoint (uses calendar token).
+//	@Tags			Availabilities
+//	@Produce		json
+//	@Param			token	path		string	true	"Calendar public token"
+//	@Param			pid		path		string	true	"Participant access token"
+//	@Param			date	path		string	true	"Date (YYYY-MM-DD)"
+//	@Success		200		{object}	map[string]string
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar, participant or answer not found"
+//	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid}/rsvp/{date} [delete]
+func (h *AvailabilityHandler) DeleteRSVP(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	participantID := chi.URLParam(r, "pid")
+	date := chi.URLParam(r, "date")
+
+	if err := h.availabilityService.DeleteRSVP(r.Context(), token, participantID, date); err != nil {
+		handleAvailabilityError(w, r, err, "Failed to withdraw answer")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, map[string]string{"message": "RSVP withdrawn successfully"})
+}
+
+// ListBusyFeeds handles listing the ICS feeds of a participant
+//
+//	@Summary		List busy feeds
+//	@Description	Lists the external ICS feeds (e.g. Google or Outlook busy feeds) attached to a participant, with their sync status. Public endpoint (uses calendar token).
+//	@Tags			Availabilities
+//	@Produce		json
+//	@Param			token	path		string	true	"Calendar public token"
+//	@Param			pid		path		string	true	"Participant access token"
+//	@Success		200		{array}		models.BusyFeed
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar or participant not found"
+//	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid}/busy-feeds [get]
+func (h *AvailabilityHandler) ListBusyFeeds(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	participantID := chi.URLParam(r, "pid")
+
+	feeds, err := h.availabilityService.ListBusyFeeds(r.Context(), token, participantID)
+	if err != nil {
+		handleAvailabilityError(w, r, err, "Failed to list busy feeds")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, feeds)
+}
+
+// AddBusyFeed handles attaching an ICS feed to a participant
+//
+//	@Summary		Attach a busy feed
+//	@Description	Attaches an external ICS feed to a participant and runs a first sync. Its events are synced periodically, and the availabilities overlapping them are flagged with conflict. Public endpoint (uses calendar token).
+//	@Tags			Availabilities
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string						true	"Calendar public token"
+//	@Param			pid		path		string						true	"Participant access token"
+//	@Param			request	body		models.AddBusyFeedRequest	true	"Feed URL"
+//	@Success		201		{object}	models.BusyFeed
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request or too many feeds"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar or participant not found"
+//	@Failure		409		{object}	httputil.ErrorResponse	"Feed already attached"
+//	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid}/busy-feeds [post]
+func (h *AvailabilityHandler) AddBusyFeed(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	participantID := chi.URLParam(r, "pid")
+
+	var req models.AddBusyFeedRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	feed, err := h.availabilityService.AddBusyFeed(r.Context(), token, participantID, &req)
+	if err != nil {
+		handleAvailabilityError(w, r, err, "Failed to attach busy feed")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, feed)
+}
+
+// DeleteBusyFeed handles detaching an ICS feed from a participant
+//
+//	@Summary		Detach a busy feed
+//	@Description	Detaches an ICS feed from a participant and forgets its busy time. Public endpoint (uses calendar token).
+//	@Tags			Availabilities
+//	@Produce		json
+//	@Param			token	path		string	true	"Calendar public token"
+//	@Param			pid		path		string	true	"Participant access token"
+//	@Param			fid		path		string	true	"Feed ID"
+//	@Success		200		{object}	map[string]string
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar, participant or feed not found"
+//	@Router			/api/v1/availabilities/calendar/{token}/participant/{pid}/busy-feeds/{fid} [delete]
+func (h *AvailabilityHandler) DeleteBusyFeed(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	participantID := chi.URLParam(r, "pid")
+	feedID := chi.URLParam(r, "fid")
+
+	if err := h.availabilityService.DeleteBusyFeed(r.Context(), token, participantID, feedID); err != nil {
+		handleAvailabilityError(w, r, err, "Failed to detach busy feed")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, map[string]string{"message": "Busy feed detached successfully"})
+}
+
+// WithParticipantToken resolves the "pid" URL parameter of public routes, which holds the secret token of a
+// participant's link, and replaces it with the participant ID read by the handlers
+func (h *AvailabilityHandler) WithParticipantToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		participantID, err := h.availabilityService.ResolveParticipant(r.Context(), chi.URLParam(r, "token"), chi.URLParam(r, "pid"))
+		if err != nil {
+			handleAvailabilityError(w, r, err, "Failed to resolve participant")
+			return
+		}
+
+		setURLParam(chi.RouteContext(r.Context()), "pid", participantID.String())
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setURLParam replaces the value of a URL parameter already matched by the router
+func setURLParam(rctx *chi.Context, key, value string) {
+	for i, k := range rctx.URLParams.Keys {
+		if k == key {
+			rctx.URLParams.Values[i] = value
+		}
+	}
+}
+
 // handleAvailabilityError handles common error cases
 func handleAvailabilityError(w http.ResponseWriter, r *http.Request, err error, defaultMsg string) {
 	log := logger.FromContext(r.Context())
@@ -235,10 +536,28 @@ func handleAvailabilityError(w http.ResponseWriter, r *http.Request, err error,
 		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
 	case errors.Is(err, service.ErrParticipantNotFound):
 		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Participant not found")
+	case errors.Is(err, service.ErrParticipantTokenRequired):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "Participant routes require the participant's personal link")
 	case errors.Is(err, service.ErrAvailabilityNotFound):
 		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Availability not found")
+	case errors.Is(err, service.ErrCommentNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Comment not found")
+	case errors.Is(err, service.ErrEmptyComment), errors.Is(err, service.ErrCommentTooLong):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
 	case errors.Is(err, service.ErrAvailabilityExists):
 		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "Availability already exists for this date")
+	case errors.Is(err, service.ErrRSVPNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "RSVP not found")
+	case errors.Is(err, service.ErrBusyFeedNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Busy feed not found")
+	case errors.Is(err, service.ErrBusyFeedExists):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "This feed is already attached")
+	case errors.Is(err, service.ErrInvalidBusyFeed), errors.Is(err, service.ErrTooManyBusyFeeds):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+	case errors.Is(err, service.ErrNotAnEvent):
+		httputil.Error(w, http.StatusConflict, "not_an_event", "Only the events of the calendar can be answered")
+	case errors.Is(err, service.ErrDateFull):
+		httputil.Error(w, http.StatusConflict, "date_full", "This date has reached its maximum number of participants")
 	case errors.Is(err, service.ErrInvalidDate):
 		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid date format, expected YYYY-MM-DD")
 	case errors.Is(err, service.ErrInvalidTime):
@@ -249,8 +568,16 @@ func handleAvailabilityError(w http.ResponseWriter, r *http.Request, err error,
 		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Availability duration is less than the minimum required for this calendar")
 	case errors.Is(err, service.ErrWeekdayNotAllowed):
 		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "This day of the week is not allowed for this calendar")
+	case errors.Is(err, service.ErrDateBlackedOut):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "This date is blocked by the calendar owner")
 	case errors.Is(err, service.ErrDateInPast):
 		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Cannot modify availability for past dates")
+	case errors.Is(err, service.ErrPreferredMaybe):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+	case errors.Is(err, service.ErrDuplicateBulkDate):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+	case errors.Is(err, service.ErrInvalidTimezone):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid timezone, expected an IANA timezone name")
 	default:
 		log.Error(defaultMsg, "error", err)
 		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
@@ -0,0 +1,129 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/whento/pkg/logger"
+	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/service"
+)
+
+// Badge colors (shields.io palette)
+const (
+	badgeColorReached = "#4c1"    // A date reaches the threshold
+	badgeColorPartial = "#dfb317" // Some availability, no date reaching the threshold
+	badgeColorNone    = "#9f9f9f" // No availability, or calendar not found
+)
+
+// maxBadgeLabel is the maximum length of a custom badge label
+const maxBadgeLabel = 40
+
+// BadgeHandler serves the live status badges of public calendars
+type BadgeHandler struct {
+	availabilityService *service.AvailabilityService
+	productName         string
+}
+
+// NewBadgeHandler creates a new badge handler
+func NewBadgeHandler(availabilityService *service.AvailabilityService, productName string) *BadgeHandler {
+	return &BadgeHandler{
+		availabilityService: availabilityService,
+		productName:         productName,
+	}
+}
+
+// GetBadge serves a shields.io-style SVG badge of a public calendar
+//
+//	@Summary		Get calendar status badge
+//	@Description	Returns a shields.io-style SVG badge showing the next date reaching the threshold (within 90 days), or the best count of upcoming dates. Meant for READMEs and forum signatures. Public endpoint.
+//	@Tags			Calendars
+//	@Produce		image/svg+xml
+//	@Param			token	path		string	true	"Calendar public token"
+//	@Param			label	query		string	false	"Left-hand text (defaults to the product name, at most 40 characters)"
+//	@Success		200		{string}	string	"SVG badge"
+//	@Failure		404		{string}	string	"SVG badge reading \"calendar not found\""
+//	@Router			/api/v1/calendars/public/{token}/badge.svg [get]
+func (h *BadgeHandler) GetBadge(w http.ResponseWriter, r *http.Request) {
+	label := r.URL.Query().Get("label")
+	if label == "" {
+		label = h.productName
+	}
+	if utf8.RuneCountInString(label) > maxBadgeLabel {
+		label = string([]rune(label)[:maxBadgeLabel])
+	}
+
+	status := http.StatusOK
+	message, color := "calendar not found", badgeColorNone
+
+	badge, err := h.availabilityService.GetBadgeStatus(r.Context(), chi.URLParam(r, "token"), time.Now())
+	switch {
+	case err == nil:
+		message, color = badgeMessage(badge)
+	case errors.Is(err, service.ErrCalendarNotFound):
+		status = http.StatusNotFound
+	default:
+		logger.FromContext(r.Context()).Error("Failed to get calendar badge", "error", err)
+		status = http.StatusInternalServerError
+		message = "unavailable"
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	// Short cache so that image proxies (e.g. GitHub camo) refresh the badge
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(status)
+	_, _ = w.Write(renderBadge(label, message, color))
+}
+
+// badgeMessage returns the right-hand text and color of a badge
+func badgeMessage(badge *models.BadgeStatus) (string, string) {
+	switch {
+	case badge.NextDate != nil && badge.NextDate.Equal(badge.Today):
+		return "today", badgeColorReached
+	case badge.NextDate != nil && badge.NextDate.Equal(badge.Today.AddDate(0, 0, 1)):
+		return "tomorrow", badgeColorReached
+	case badge.NextDate != nil:
+		return "next " + badge.NextDate.Format("Mon Jan 2"), badgeColorReached
+	case badge.BestCount > 0:
+		return fmt.Sprintf("best %d/%d", badge.BestCount, badge.Threshold), badgeColorPartial
+	default:
+		return fmt.Sprintf("0/%d", badge.Threshold), badgeColorNone
+	}
+}
+
+// renderBadge draws a flat badge, sizing each half from an estimate of the Verdana 11px text width
+func renderBadge(label, message, color string) []byte {
+	labelWidth := badgeTextWidth(label)
+	messageWidth := badgeTextWidth(message)
+	width := labelWidth + messageWidth
+
+	label = html.EscapeString(label)
+	message = html.EscapeString(message)
+
+	return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">
+<title>%[4]s: %[5]s</title>
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[4]s</text><text x="%[7]d" y="14">%[4]s</text>
+<text x="%[8]d" y="15" fill="#010101" fill-opacity=".3">%[5]s</text><text x="%[8]d" y="14">%[5]s</text>
+</g>
+</svg>
+`, width, labelWidth, messageWidth, label, message, color, labelWidth/2, labelWidth+messageWidth/2))
+}
+
+// badgeTextWidth estimates the width of a badge half: about 7px per character plus 10px of padding
+func badgeTextWidth(text string) int {
+	return utf8.RuneCountInString(text)*7 + 10
+}
@@ -0,0 +1,58 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/availability/models"
+)
+
+func TestBadgeMessage(t *testing.T) {
+	today := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	date := func(days int) *time.Time {
+		d := today.AddDate(0, 0, days)
+		return &d
+	}
+
+	tests := []struct {
+		name    string
+		badge   models.BadgeStatus
+		message string
+		color   string
+	}{
+		{"today", models.BadgeStatus{NextDate: date(0), Threshold: 3}, "today", badgeColorReached},
+		{"tomorrow", models.BadgeStatus{NextDate: date(1), Threshold: 3}, "tomorrow", badgeColorReached},
+		{"later", models.BadgeStatus{NextDate: date(4), Threshold: 3}, "next Sat Jun 14", badgeColorReached},
+		{"best count", models.BadgeStatus{BestCount: 2, Threshold: 3}, "best 2/3", badgeColorPartial},
+		{"no availability", models.BadgeStatus{Threshold: 3}, "0/3", badgeColorNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.badge.Today = today
+			message, color := badgeMessage(&tt.badge)
+			if message != tt.message || color != tt.color {
+				t.Errorf("badgeMessage() = %q %s, want %q %s", message, color, tt.message, tt.color)
+			}
+		})
+	}
+}
+
+func TestRenderBadge(t *testing.T) {
+	svg := string(renderBadge("<script>", "best 2/3", badgeColorPartial))
+
+	if strings.Contains(svg, "<script>") || !strings.Contains(svg, "&lt;script&gt;") {
+		t.Error("label is not escaped")
+	}
+	if !strings.Contains(svg, `width="132"`) {
+		t.Errorf("unexpected badge width in %s", svg)
+	}
+	if !strings.Contains(svg, `fill="`+badgeColorPartial+`"`) {
+		t.Error("message color is not applied")
+	}
+}
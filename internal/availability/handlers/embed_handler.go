@@ -0,0 +1,161 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	_ "embed"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/logger"
+	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/service"
+)
+
+//go:embed templates/embed.html
+var embedTemplateHTML string
+
+var embedTemplate = template.Must(template.New("embed").Parse(embedTemplateHTML))
+
+// EmbedHandler serves the public calendar widget embedded on third-party websites
+type EmbedHandler struct {
+	availabilityService *service.AvailabilityService
+	appURL              string
+	productName         string
+	primaryColor        string
+}
+
+// NewEmbedHandler creates a new embed handler
+func NewEmbedHandler(availabilityService *service.AvailabilityService, appURL, productName, primaryColor string) *EmbedHandler {
+	return &EmbedHandler{
+		availabilityService: availabilityService,
+		appURL:              appURL,
+		productName:         productName,
+		primaryColor:        primaryColor,
+	}
+}
+
+// embedView is the data of the widget template
+type embedView struct {
+	Calendar     *models.EmbedCalendar
+	Weekdays     []string
+	Weeks        [][]embedDay
+	Link         string
+	ProductName  string
+	PrimaryColor template.CSS
+}
+
+// embedDay is a cell of the widget grid
+type embedDay struct {
+	Date    string
+	Day     int
+	Count   int
+	Reached bool
+	Today   bool
+}
+
+// GetEmbed serves the availability grid of a calendar for embedding
+//
+//	@Summary		Get embeddable calendar widget
+//	@Description	Returns a self-contained HTML availability grid (counts only, no participant names) meant to be embedded in an iframe. Append .json to the token for the compact JSON payload. Framing is restricted to EMBED_FRAME_ANCESTORS. Public endpoint.
+//	@Tags			Availabilities
+//	@Produce		html
+//	@Produce		json
+//	@Param			token	path		string	true	"Calendar public token, with an optional .json suffix"
+//	@Param			weeks	query		int		false	"Number of weeks shown, starting with the current week (default 4, max 12)"
+//	@Success		200		{object}	models.EmbedCalendar
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/embed/{token} [get]
+func (h *EmbedHandler) GetEmbed(w http.ResponseWriter, r *http.Request) {
+	token, asJSON := strings.CutSuffix(chi.URLParam(r, "token"), ".json")
+	weeks, _ := strconv.Atoi(r.URL.Query().Get("weeks"))
+
+	calendar, err := h.availabilityService.GetEmbedCalendar(r.Context(), token, weeks, time.Now())
+	if err != nil {
+		handleAvailabilityError(w, r, err, "Failed to get embedded calendar")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=60")
+
+	if asJSON {
+		httputil.JSON(w, http.StatusOK, calendar)
+		return
+	}
+
+	view := embedView{
+		Calendar:     calendar,
+		Weekdays:     embedWeekdays(calendar.WeekStart),
+		Weeks:        embedWeeks(calendar, time.Now()),
+		Link:         h.appURL + "/c/" + token,
+		ProductName:  h.productName,
+		PrimaryColor: template.CSS(h.primaryColor), // Validated as a hex color by the config
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := embedTemplate.Execute(w, view); err != nil {
+		logger.FromContext(r.Context()).Error("Failed to render embedded calendar", "error", err)
+	}
+}
+
+// embedWeekdays returns the short weekday names in the order of the grid columns
+func embedWeekdays(weekStart string) []string {
+	first := time.Monday
+	if weekStart == "sunday" {
+		first = time.Sunday
+	}
+
+	names := make([]string, 7)
+	for i := range names {
+		names[i] = time.Weekday((int(first) + i) % 7).String()[:3]
+	}
+	return names
+}
+
+// embedWeeks lays out the days of the widget grid week by week
+func embedWeeks(calendar *models.EmbedCalendar, now time.Time) [][]embedDay {
+	start, err := time.Parse("2006-01-02", calendar.Start)
+	if err != nil {
+		return nil
+	}
+	end, err := time.Parse("2006-01-02", calendar.End)
+	if err != nil {
+		return nil
+	}
+
+	counts := make(map[string]models.EmbedDate, len(calendar.Dates))
+	for _, date := range calendar.Dates {
+		counts[date.Date] = date
+	}
+
+	today := ""
+	if loc, err := time.LoadLocation(calendar.Timezone); err == nil {
+		today = now.In(loc).Format("2006-01-02")
+	}
+
+	var weeks [][]embedDay
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 7) {
+		week := make([]embedDay, 7)
+		for i := range week {
+			date := day.AddDate(0, 0, i)
+			key := date.Format("2006-01-02")
+			week[i] = embedDay{
+				Date:    key,
+				Day:     date.Day(),
+				Count:   counts[key].Count,
+				Reached: counts[key].Reached,
+				Today:   key == today,
+			}
+		}
+		weeks = append(weeks, week)
+	}
+	return weeks
+}
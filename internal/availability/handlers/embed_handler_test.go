@@ -0,0 +1,71 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/availability/models"
+)
+
+func TestEmbedWeeks(t *testing.T) {
+	calendar := &models.EmbedCalendar{
+		Name:      "<b>Five-a-side</b>",
+		Threshold: 3,
+		Timezone:  "UTC",
+		WeekStart: "monday",
+		Start:     "2025-06-09",
+		End:       "2025-06-22",
+		Dates: []models.EmbedDate{
+			{Date: "2025-06-10", Count: 1},
+			{Date: "2025-06-14", Count: 3, Reached: true},
+		},
+	}
+
+	weeks := embedWeeks(calendar, time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC))
+	if len(weeks) != 2 || len(weeks[0]) != 7 {
+		t.Fatalf("got %d weeks, want 2 weeks of 7 days", len(weeks))
+	}
+	if day := weeks[0][1]; day.Date != "2025-06-10" || day.Count != 1 || !day.Today {
+		t.Errorf("weeks[0][1] = %+v, want 2025-06-10 with 1 participant, today", day)
+	}
+	if day := weeks[0][5]; day.Day != 14 || !day.Reached {
+		t.Errorf("weeks[0][5] = %+v, want 14 reached", day)
+	}
+	if day := weeks[1][6]; day.Date != "2025-06-22" || day.Count != 0 {
+		t.Errorf("weeks[1][6] = %+v, want empty 2025-06-22", day)
+	}
+
+	var html strings.Builder
+	err := embedTemplate.Execute(&html, embedView{
+		Calendar:     calendar,
+		Weekdays:     embedWeekdays(calendar.WeekStart),
+		Weeks:        weeks,
+		Link:         "https://whento.example/c/token",
+		ProductName:  "WhenTo",
+		PrimaryColor: template.CSS("#4F46E5"),
+	})
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	if strings.Contains(html.String(), "<b>") {
+		t.Error("calendar name is not escaped")
+	}
+	if !strings.Contains(html.String(), "background: #4F46E5") {
+		t.Error("primary color is not applied")
+	}
+}
+
+func TestEmbedWeekdays(t *testing.T) {
+	if got := strings.Join(embedWeekdays("sunday"), ","); got != "Sun,Mon,Tue,Wed,Thu,Fri,Sat" {
+		t.Errorf("sunday week = %s", got)
+	}
+	if got := embedWeekdays("monday"); got[0] != "Mon" || got[6] != "Sun" {
+		t.Errorf("monday week = %v", got)
+	}
+}
@@ -29,12 +29,12 @@ func NewRecurrenceHandler(service *service.AvailabilityService) *RecurrenceHandl
 
 // CreateRecurrence handles POST /calendar/{token}/participant/{pid}/recurrence
 // @Summary Create a new recurrence pattern
-// @Description Creates a recurring availability pattern for a participant (e.g., every Monday 9:00-17:00)
+// @Description Creates a recurring availability pattern for a participant: weekly or every N weeks (e.g., every other Monday 9:00-17:00), on a day of the month (e.g., the 15th), or on the nth weekday of the month (e.g., the first Friday, week_of_month -1 for the last one)
 // @Tags Recurrences
 // @Accept json
 // @Produce json
 // @Param token path string true "Calendar public token"
-// @Param pid path string true "Participant ID (UUID)"
+// @Param pid path string true "Participant access token"
 // @Param body body models.CreateRecurrenceRequest true "Recurrence details"
 // @Success 201 {object} models.Recurrence "Recurrence created successfully"
 // @Failure 400 {object} httputil.ErrorResponse "Invalid request body or validation error"
@@ -76,7 +76,7 @@ func (h *RecurrenceHandler) CreateRecurrence(w http.ResponseWriter, r *http.Requ
 // @Tags Recurrences
 // @Produce json
 // @Param token path string true "Calendar public token"
-// @Param pid path string true "Participant ID (UUID)"
+// @Param pid path string true "Participant access token"
 // @Success 200 {array} models.RecurrenceWithExceptions "List of recurrence patterns with their exceptions"
 // @Failure 400 {object} httputil.ErrorResponse "Invalid participant ID"
 // @Failure 404 {object} httputil.ErrorResponse "Calendar or participant not found"
@@ -102,7 +102,7 @@ func (h *RecurrenceHandler) GetParticipantRecurrences(w http.ResponseWriter, r *
 // @Accept json
 // @Produce json
 // @Param token path string true "Calendar public token"
-// @Param pid path string true "Participant ID (UUID)"
+// @Param pid path string true "Participant access token"
 // @Param rid path string true "Recurrence ID (UUID)"
 // @Param body body models.UpdateRecurrenceRequest true "Updated recurrence details"
 // @Success 200 {object} models.Recurrence "Recurrence updated successfully"
@@ -146,7 +146,7 @@ func (h *RecurrenceHandler) UpdateRecurrence(w http.ResponseWriter, r *http.Requ
 // @Tags Recurrences
 // @Produce json
 // @Param token path string true "Calendar public token"
-// @Param pid path string true "Participant ID (UUID)"
+// @Param pid path string true "Participant access token"
 // @Param rid path string true "Recurrence ID (UUID)"
 // @Success 200 {object} map[string]string "Recurrence deleted successfully"
 // @Failure 400 {object} httputil.ErrorResponse "Invalid participant or recurrence ID"
@@ -175,7 +175,7 @@ func (h *RecurrenceHandler) DeleteRecurrence(w http.ResponseWriter, r *http.Requ
 // @Accept json
 // @Produce json
 // @Param token path string true "Calendar public token"
-// @Param pid path string true "Participant ID (UUID)"
+// @Param pid path string true "Participant access token"
 // @Param rid path string true "Recurrence ID (UUID)"
 // @Param body body models.CreateExceptionRequest true "Exception date to exclude (YYYY-MM-DD)"
 // @Success 201 {object} models.RecurrenceException "Exception created successfully"
@@ -212,13 +212,55 @@ func (h *RecurrenceHandler) CreateException(w http.ResponseWriter, r *http.Reque
 	httputil.JSON(w, http.StatusCreated, exception)
 }
 
+// CreateExceptionRange handles POST /calendar/{token}/participant/{pid}/exceptions
+// @Summary Exclude a date range from recurrence patterns
+// @Description Excludes every date of a range (e.g., a vacation from 2025-07-01 to 2025-07-21) from all recurrence patterns of the participant, or from the one given by recurrence_id. Only the dates a pattern falls on get an exception; dates already excluded are skipped. A range covers at most one year.
+// @Tags Recurrences
+// @Accept json
+// @Produce json
+// @Param token path string true "Calendar public token"
+// @Param pid path string true "Participant access token"
+// @Param body body models.CreateExceptionRangeRequest true "Date range to exclude (YYYY-MM-DD, inclusive)"
+// @Success 201 {array} models.RecurrenceException "Exceptions created"
+// @Failure 400 {object} httputil.ErrorResponse "Invalid request body, date format, or date range"
+// @Failure 404 {object} httputil.ErrorResponse "Calendar, participant, or recurrence not found"
+// @Failure 500 {object} httputil.ErrorResponse "Internal server error"
+// @Router /api/v1/availabilities/calendar/{token}/participant/{pid}/exceptions [post]
+func (h *RecurrenceHandler) CreateExceptionRange(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	participantID := chi.URLParam(r, "pid")
+
+	var req models.CreateExceptionRangeRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	exceptions, err := h.service.CreateExceptionRange(r.Context(), token, participantID, &req)
+	if err != nil {
+		handleRecurrenceError(w, r, err, "Failed to create exceptions")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, exceptions)
+}
+
 // DeleteException handles DELETE /calendar/{token}/participant/{pid}/recurrence/{rid}/exception/{date}
 // @Summary Remove an exception date from a recurrence pattern
 // @Description Removes a previously excluded date from a recurrence pattern, re-enabling it
 // @Tags Recurrences
 // @Produce json
 // @Param token path string true "Calendar public token"
-// @Param pid path string true "Participant ID (UUID)"
+// @Param pid path string true "Participant access token"
 // @Param rid path string true "Recurrence ID (UUID)"
 // @Param date path string true "Exception date to remove (YYYY-MM-DD)"
 // @Success 200 {object} map[string]string "Exception deleted successfully"
@@ -265,10 +307,16 @@ func handleRecurrenceError(w http.ResponseWriter, r *http.Request, err error, de
 		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Time range does not fit within allowed hours for this day")
 	case errors.Is(err, service.ErrDurationTooShort):
 		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Availability duration is less than the minimum required for this calendar")
-	case errors.Is(err, service.ErrInvalidDayOfWeek):
-		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "day_of_week must be between 0 (Sunday) and 6 (Saturday)")
+	case errors.Is(err, service.ErrInvalidDayOfWeek),
+		errors.Is(err, service.ErrInvalidFrequency),
+		errors.Is(err, service.ErrInvalidIntervalWeeks),
+		errors.Is(err, service.ErrInvalidDayOfMonth),
+		errors.Is(err, service.ErrInvalidWeekOfMonth),
+		errors.Is(err, service.ErrInvalidDateRange),
+		errors.Is(err, service.ErrExceptionRangeTooLong):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
 	case errors.Is(err, service.ErrRecurrenceOverlap):
-		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "A recurrence already exists for this day with overlapping dates")
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "A recurrence already exists for the same days with overlapping dates")
 	default:
 		log.Error(defaultMsg, "error", err)
 		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
@@ -12,6 +12,57 @@ import (
 	"github.com/whento/pkg/models"
 )
 
+// Availability statuses
+const (
+	StatusYes   = "yes"
+	StatusMaybe = "maybe" // Tentative, counts toward the threshold only when the calendar enables count_maybe
+)
+
+// Capacity policies, applied to the availabilities of a date that reached max_participants
+const (
+	CapacityReject   = "reject"
+	CapacityWaitlist = "waitlist" // Queued and promoted in arrival order when a spot frees up
+)
+
+// CountsTowardCapacity reports whether an answer takes a spot on its date
+// Maybe answers only do when they count toward the threshold
+func CountsTowardCapacity(status string, countMaybe bool) bool {
+	return status != StatusMaybe || countMaybe
+}
+
+// DateCount is the number of participants counted as available on a date
+// RequiredMissing is the number of required participants who are not counted
+type DateCount struct {
+	Count           int
+	RequiredMissing int
+}
+
+// Reaches reports whether the date meets the threshold: enough participants
+// are available and none of the required ones is missing
+func (c DateCount) Reaches(threshold int) bool {
+	return c.Count >= threshold && c.RequiredMissing == 0
+}
+
+// Threshold levels of a date
+const (
+	LevelNone = "none"
+	LevelSoft = "soft" // Soft threshold met: "looking good", notified only
+	LevelHard = "hard" // Threshold met: event in the ICS feed
+)
+
+// Level returns the highest threshold level the date meets
+// A soft threshold at or above the threshold is never met on its own
+func (c DateCount) Level(threshold int, softThreshold *int) string {
+	switch {
+	case c.Reaches(threshold):
+		return LevelHard
+	case softThreshold != nil && c.Reaches(*softThreshold):
+		return LevelSoft
+	default:
+		return LevelNone
+	}
+}
+
 // Availability represents a participant's availability for a specific date
 type Availability struct {
 	models.TimestampedEntity
@@ -22,6 +73,8 @@ type Availability struct {
 	Note          string     `json:"note,omitempty"`
 	Source        string     `json:"source"` // 'manual' or 'recurrence'
 	RecurrenceID  *uuid.UUID `json:"recurrence_id,omitempty"`
+	Status        string     `json:"status"` // 'yes' or 'maybe'
+	Preferred     bool       `json:"preferred"`
 }
 
 // CreateAvailabilityRequest represents a request to create availability
@@ -30,6 +83,8 @@ type CreateAvailabilityRequest struct {
 	StartTime *string `json:"start_time,omitempty" validate:"omitempty"` // Format: "15:04"
 	EndTime   *string `json:"end_time,omitempty" validate:"omitempty"`   // Format: "15:04"
 	Note      string  `json:"note,omitempty" validate:"max=1000"`
+	Status    string  `json:"status,omitempty" validate:"omitempty,oneof=yes maybe" enums:"yes,maybe"` // Default: yes
+	Preferred bool    `json:"preferred,omitempty"`                                                     // Preferred rather than merely possible date
 }
 
 // UpdateAvailabilityRequest represents a request to update availability
@@ -37,6 +92,21 @@ type UpdateAvailabilityRequest struct {
 	StartTime *string `json:"start_time,omitempty" validate:"omitempty"` // Format: "15:04" or null
 	EndTime   *string `json:"end_time,omitempty" validate:"omitempty"`   // Format: "15:04" or null
 	Note      *string `json:"note,omitempty" validate:"omitempty,max=1000"`
+	Status    *string `json:"status,omitempty" validate:"omitempty,oneof=yes maybe" enums:"yes,maybe"`
+	Preferred *bool   `json:"preferred,omitempty"`
+}
+
+// BulkAvailabilityRequest sets and deletes availabilities of several dates at once
+// All changes are applied atomically: an invalid entry rejects the whole request
+type BulkAvailabilityRequest struct {
+	Availabilities []CreateAvailabilityRequest `json:"availabilities" validate:"max=366,dive"` // Created, or replaced when the date already has one
+	Delete         []string                    `json:"delete" validate:"max=366"`              // Dates to clear (YYYY-MM-DD); dates without availability are ignored
+}
+
+// BulkAvailabilityResponse is the result of a bulk availability submission
+type BulkAvailabilityResponse struct {
+	Availabilities []AvailabilityItem `json:"availabilities"` // Including the ones waitlisted on full dates
+	Deleted        []string           `json:"deleted"`        // Dates that had an availability
 }
 
 // AvailabilityResponse represents the response for availability (single operation)
@@ -50,19 +120,27 @@ type AvailabilityResponse struct {
 	StartTime                *string   `json:"start_time,omitempty"` // Format: "15:04"
 	EndTime                  *string   `json:"end_time,omitempty"`   // Format: "15:04"
 	Note                     string    `json:"note,omitempty"`
+	Status                   string    `json:"status" enums:"yes,maybe"`
+	Preferred                bool      `json:"preferred"`
+	Waitlisted               bool      `json:"waitlisted,omitempty"` // Queued on a full date, not counted until a spot frees up
+	Conflict                 bool      `json:"conflict,omitempty"`   // Overlaps the busy time of the participant's feeds
 	CreatedAt                time.Time `json:"created_at"`
 	UpdatedAt                time.Time `json:"updated_at"`
 }
 
 // AvailabilityItem represents a single availability without participant info
 type AvailabilityItem struct {
-	ID        uuid.UUID `json:"id"`
-	Date      string    `json:"date"`                 // Format: "2006-01-02"
-	StartTime *string   `json:"start_time,omitempty"` // Format: "15:04"
-	EndTime   *string   `json:"end_time,omitempty"`   // Format: "15:04"
-	Note      string    `json:"note,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID `json:"id"`
+	Date       string    `json:"date"`                 // Format: "2006-01-02"
+	StartTime  *string   `json:"start_time,omitempty"` // Format: "15:04"
+	EndTime    *string   `json:"end_time,omitempty"`   // Format: "15:04"
+	Note       string    `json:"note,omitempty"`
+	Status     string    `json:"status" enums:"yes,maybe"`
+	Preferred  bool      `json:"preferred"`
+	Waitlisted bool      `json:"waitlisted,omitempty"` // Queued on a full date, not counted until a spot frees up
+	Conflict   bool      `json:"conflict,omitempty"`   // Overlaps the busy time of the participant's feeds
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 // ParticipantInfo represents participant information for availabilities response
@@ -77,15 +155,21 @@ type ParticipantInfo struct {
 type ParticipantAvailabilitiesResponse struct {
 	Participant    ParticipantInfo    `json:"participant"`
 	Availabilities []AvailabilityItem `json:"availabilities"`
+	Busy           []BusyPeriod       `json:"busy"` // Busy time of the participant's feeds over the requested range
 }
 
 // ParticipantAvailabilitySummary represents availability summary for a participant
 type ParticipantAvailabilitySummary struct {
-	ParticipantID   uuid.UUID `json:"participant_id"`
-	ParticipantName string    `json:"participant_name"`
-	StartTime       *string   `json:"start_time,omitempty"`
-	EndTime         *string   `json:"end_time,omitempty"`
-	Note            string    `json:"note,omitempty"`
+	ParticipantID   uuid.UUID  `json:"participant_id"`
+	ParticipantName string     `json:"participant_name"`
+	StartTime       *string    `json:"start_time,omitempty"`
+	EndTime         *string    `json:"end_time,omitempty"`
+	StartAt         *time.Time `json:"start_at,omitempty"` // Set when times are converted to a requested timezone
+	EndAt           *time.Time `json:"end_at,omitempty"`   // Set when times are converted to a requested timezone
+	Note            string     `json:"note,omitempty"`
+	Status          string     `json:"status" enums:"yes,maybe"`
+	Preferred       bool       `json:"preferred,omitempty"`
+	Required        bool       `json:"required,omitempty"` // Required participant of the calendar
 }
 
 // PublicParticipantAvailabilitySummary represents availability summary for a participant in public views
@@ -95,19 +179,77 @@ type PublicParticipantAvailabilitySummary struct {
 	ParticipantName string     `json:"participant_name"`
 	StartTime       *string    `json:"start_time,omitempty"`
 	EndTime         *string    `json:"end_time,omitempty"`
+	StartAt         *time.Time `json:"start_at,omitempty"` // Set when times are converted to a requested timezone
+	EndAt           *time.Time `json:"end_at,omitempty"`   // Set when times are converted to a requested timezone
 	Note            string     `json:"note,omitempty"`
+	Status          string     `json:"status" enums:"yes,maybe"`
+	Preferred       bool       `json:"preferred,omitempty"`
+	Required        bool       `json:"required,omitempty"` // Required participant of the calendar
 }
 
 // DateAvailabilitySummary represents all participants available on a specific date
 type DateAvailabilitySummary struct {
-	Date         string                           `json:"date"`
-	TotalCount   int                              `json:"total_count"`
-	Participants []ParticipantAvailabilitySummary `json:"participants"`
+	Date             string                           `json:"date"`
+	Timezone         string                           `json:"timezone,omitempty"`           // Timezone of the times, set when converted
+	TotalCount       int                              `json:"total_count"`                  // Participants counting toward the threshold
+	MaybeCount       int                              `json:"maybe_count"`                  // Participants who answered maybe
+	PreferredCount   int                              `json:"preferred_count"`              // Participants who marked the date as preferred
+	Score            int                              `json:"score"`                        // 2 points per preferred answer, 1 per other counted answer
+	RequiredMissing  int                              `json:"required_missing"`             // Required participants not counted on the date
+	ThresholdReached bool                             `json:"threshold_reached"`            // Enough participants and no required one missing
+	Capacity         *int                             `json:"capacity,omitempty"`           // Maximum participants of the date, unset without limit
+	Remaining        *int                             `json:"remaining_capacity,omitempty"` // Spots left, unset without limit
+	WaitlistCount    int                              `json:"waitlist_count,omitempty"`     // Participants waiting for a spot
+	Blackout         bool                             `json:"blackout,omitempty"`           // Date blocked by the owner, nobody counts
+	GoingCount       int                              `json:"going_count,omitempty"`        // Participants who answered they are going to the event
+	NotGoingCount    int                              `json:"not_going_count,omitempty"`    // Participants who answered they are not going
+	Participants     []ParticipantAvailabilitySummary `json:"participants"`
+	Comments         []DateComment                    `json:"comments"`        // Oldest first
+	RSVPs            []RSVP                           `json:"rsvps,omitempty"` // Answers to the event, by participant name
 }
 
 // PublicDateAvailabilitySummary represents all participants available on a specific date (public view)
 type PublicDateAvailabilitySummary struct {
-	Date         string                                 `json:"date"`
-	TotalCount   int                                    `json:"total_count"`
-	Participants []PublicParticipantAvailabilitySummary `json:"participants"`
+	Date             string                                 `json:"date"`
+	Week             string                                 `json:"week,omitempty"`               // First day of the week containing Date (YYYY-MM-DD)
+	Timezone         string                                 `json:"timezone,omitempty"`           // Timezone of the times, set when converted
+	TotalCount       int                                    `json:"total_count"`                  // Participants counting toward the threshold
+	MaybeCount       int                                    `json:"maybe_count"`                  // Participants who answered maybe
+	PreferredCount   int                                    `json:"preferred_count"`              // Participants who marked the date as preferred
+	Score            int                                    `json:"score"`                        // 2 points per preferred answer, 1 per other counted answer
+	RequiredMissing  int                                    `json:"required_missing"`             // Required participants not counted on the date
+	ThresholdReached bool                                   `json:"threshold_reached"`            // Enough participants and no required one missing
+	Capacity         *int                                   `json:"capacity,omitempty"`           // Maximum participants of the date, unset without limit
+	Remaining        *int                                   `json:"remaining_capacity,omitempty"` // Spots left, unset without limit
+	GoingCount       int                                    `json:"going_count,omitempty"`        // Participants who answered they are going to the event
+	NotGoingCount    int                                    `json:"not_going_count,omitempty"`    // Participants who answered they are not going
+	Participants     []PublicParticipantAvailabilitySummary `json:"participants"`
+}
+
+// EmbedCalendar is the compact public view of a calendar, embedded on third-party websites
+// Only counts are exposed, never participant names
+type EmbedCalendar struct {
+	Name      string      `json:"name"`
+	Threshold int         `json:"threshold"`
+	Timezone  string      `json:"timezone"`
+	WeekStart string      `json:"week_start" example:"monday"`
+	Start     string      `json:"start" example:"2025-06-09"` // First day of the grid (YYYY-MM-DD)
+	End       string      `json:"end" example:"2025-07-06"`   // Last day of the grid (YYYY-MM-DD)
+	Dates     []EmbedDate `json:"dates"`                      // Dates with at least one available participant
+}
+
+// EmbedDate is the availability count of a date in the embeddable widget
+type EmbedDate struct {
+	Date    string `json:"date" example:"2025-06-14"`
+	Count   int    `json:"count"`
+	Reached bool   `json:"reached,omitempty"` // Count reaches the calendar threshold
+}
+
+// BadgeStatus is the live status of a calendar shown by its public badge
+type BadgeStatus struct {
+	Name      string
+	Threshold int
+	NextDate  *time.Time // Next date reaching the threshold, nil if none in the horizon
+	BestCount int        // Highest count of an upcoming date when none reaches the threshold
+	Today     time.Time  // Today in the calendar timezone
 }
@@ -0,0 +1,28 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "testing"
+
+func TestCountsTowardCapacity(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		countMaybe bool
+		want       bool
+	}{
+		{"yes", StatusYes, false, true},
+		{"maybe not counted", StatusMaybe, false, false},
+		{"maybe counted", StatusMaybe, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountsTowardCapacity(tt.status, tt.countMaybe); got != tt.want {
+				t.Errorf("CountsTowardCapacity(%q, %v) = %v, want %v", tt.status, tt.countMaybe, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BusyFeed is an external ICS feed of a participant (e.g. their Google or Outlook busy feed),
+// synced periodically to flag the availabilities conflicting with their existing commitments
+type BusyFeed struct {
+	ID            uuid.UUID  `json:"id"`
+	ParticipantID uuid.UUID  `json:"participant_id"`
+	URL           string     `json:"url"`
+	LastSyncAt    *time.Time `json:"last_sync_at,omitempty"`
+	LastSyncError *string    `json:"last_sync_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// AddBusyFeedRequest represents a request to attach an ICS feed to a participant
+type AddBusyFeedRequest struct {
+	URL string `json:"url" validate:"required,max=2048"` // http(s) or webcal URL
+}
+
+// BusyPeriod is a time range during which a participant is busy according to their feeds
+type BusyPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
@@ -0,0 +1,30 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCommentLength is the maximum length of a date comment, in characters
+const MaxCommentLength = 500
+
+// DateComment is a short comment posted by a participant on a date
+type DateComment struct {
+	ID              uuid.UUID `json:"id"`
+	ParticipantID   uuid.UUID `json:"participant_id"`
+	ParticipantName string    `json:"participant_name"`
+	Date            string    `json:"date"` // Format: "2006-01-02"
+	Body            string    `json:"body"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// CreateDateCommentRequest represents a request to comment on a date
+type CreateDateCommentRequest struct {
+	ParticipantID string `json:"participant_id" validate:"required,max=64"` // Access token of the participant
+	Body          string `json:"body" validate:"required,max=500"`
+}
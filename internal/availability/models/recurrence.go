@@ -12,17 +12,69 @@ import (
 	"github.com/whento/pkg/models"
 )
 
+// Recurrence frequencies
+const (
+	FrequencyWeekly         = "weekly"          // Every interval_weeks weeks on day_of_week
+	FrequencyMonthlyDay     = "monthly_day"     // Each month on day_of_month, skipped by shorter months
+	FrequencyMonthlyWeekday = "monthly_weekday" // Each month on the week_of_month-th day_of_week, -1 for the last one
+)
+
+// LastWeekOfMonth is the week_of_month of the last given weekday of a month
+const LastWeekOfMonth = -1
+
 // Recurrence represents a recurring availability pattern
 type Recurrence struct {
 	models.Entity
 	ParticipantID uuid.UUID `json:"participant_id"`
-	DayOfWeek     int       `json:"day_of_week"`          // 0=Sunday, 1=Monday, ..., 6=Saturday
-	StartTime     *string   `json:"start_time,omitempty"` // Optional, format "HH:MM"
-	EndTime       *string   `json:"end_time,omitempty"`   // Optional, format "HH:MM"
+	Frequency     string    `json:"frequency" enums:"weekly,monthly_day,monthly_weekday"`
+	IntervalWeeks int       `json:"interval_weeks"`          // Weekly only, 2 for every other week
+	DayOfWeek     *int      `json:"day_of_week"`             // 0=Sunday, 1=Monday, ..., 6=Saturday; null for monthly_day
+	DayOfMonth    *int      `json:"day_of_month,omitempty"`  // 1-31, monthly_day only
+	WeekOfMonth   *int      `json:"week_of_month,omitempty"` // 1-5 or -1 for the last one, monthly_weekday only
+	StartTime     *string   `json:"start_time,omitempty"`    // Optional, format "HH:MM"
+	EndTime       *string   `json:"end_time,omitempty"`      // Optional, format "HH:MM"
 	Note          string    `json:"note,omitempty"`
 	StartDate     string    `json:"start_date"`         // Format: "YYYY-MM-DD"
 	EndDate       *string   `json:"end_date,omitempty"` // Optional, format: "YYYY-MM-DD"
 	CreatedAt     time.Time `json:"created_at"`
+	FullDates     []string  `json:"full_dates,omitempty"` // Set on create and update: upcoming dates left out as the calendar is full
+}
+
+// OccursOn reports whether the recurrence applies to a date (at midnight UTC), exceptions aside
+// Must match the recurrence_occurs_on SQL function
+func (r *Recurrence) OccursOn(date time.Time) bool {
+	// Compare dates as strings to avoid timezone issues
+	dateStr := date.Format("2006-01-02")
+	if dateStr < r.StartDate || (r.EndDate != nil && dateStr > *r.EndDate) {
+		return false
+	}
+
+	switch r.Frequency {
+	case FrequencyMonthlyDay:
+		return r.DayOfMonth != nil && date.Day() == *r.DayOfMonth
+	case FrequencyMonthlyWeekday:
+		if r.DayOfWeek == nil || r.WeekOfMonth == nil || int(date.Weekday()) != *r.DayOfWeek {
+			return false
+		}
+		if *r.WeekOfMonth == LastWeekOfMonth {
+			return date.AddDate(0, 0, 7).Month() != date.Month()
+		}
+		return (date.Day()-1)/7+1 == *r.WeekOfMonth
+	default:
+		if r.DayOfWeek == nil || int(date.Weekday()) != *r.DayOfWeek {
+			return false
+		}
+		if r.IntervalWeeks <= 1 {
+			return true
+		}
+		start, err := time.Parse("2006-01-02", r.StartDate)
+		if err != nil {
+			return false
+		}
+		// Weeks elapsed since the first occurrence, on or after the start date
+		days := int(date.Sub(start).Hours() / 24)
+		return (days/7)%r.IntervalWeeks == 0
+	}
 }
 
 // RecurrenceException represents a date excluded from a recurrence
@@ -35,22 +87,30 @@ type RecurrenceException struct {
 
 // CreateRecurrenceRequest represents the request to create a recurrence
 type CreateRecurrenceRequest struct {
-	DayOfWeek *int    `json:"day_of_week" validate:"required,min=0,max=6"`
-	StartTime *string `json:"start_time,omitempty" validate:"omitempty,len=5"` // Format: "HH:MM"
-	EndTime   *string `json:"end_time,omitempty" validate:"omitempty,len=5"`
-	Note      string  `json:"note,omitempty" validate:"omitempty,max=500"`
-	StartDate string  `json:"start_date" validate:"required"` // Format: "YYYY-MM-DD"
-	EndDate   *string `json:"end_date,omitempty"`             // Format: "YYYY-MM-DD"
+	Frequency     string  `json:"frequency,omitempty" validate:"omitempty,oneof=weekly monthly_day monthly_weekday" enums:"weekly,monthly_day,monthly_weekday"` // Default: weekly
+	IntervalWeeks int     `json:"interval_weeks,omitempty" validate:"omitempty,min=1,max=52"`                                                                   // Weekly only, default: 1
+	DayOfWeek     *int    `json:"day_of_week,omitempty" validate:"omitempty,min=0,max=6"`                                                                       // Required unless monthly_day
+	DayOfMonth    *int    `json:"day_of_month,omitempty" validate:"omitempty,min=1,max=31"`                                                                     // Required for monthly_day
+	WeekOfMonth   *int    `json:"week_of_month,omitempty" validate:"omitempty,oneof=-1 1 2 3 4 5"`                                                              // Required for monthly_weekday, -1 for the last one
+	StartTime     *string `json:"start_time,omitempty" validate:"omitempty,len=5"`                                                                              // Format: "HH:MM"
+	EndTime       *string `json:"end_time,omitempty" validate:"omitempty,len=5"`
+	Note          string  `json:"note,omitempty" validate:"omitempty,max=500"`
+	StartDate     string  `json:"start_date" validate:"required"` // Format: "YYYY-MM-DD"
+	EndDate       *string `json:"end_date,omitempty"`             // Format: "YYYY-MM-DD"
 }
 
 // UpdateRecurrenceRequest represents the request to update a recurrence
 type UpdateRecurrenceRequest struct {
-	DayOfWeek *int    `json:"day_of_week" validate:"required,min=0,max=6"`
-	StartTime *string `json:"start_time,omitempty" validate:"omitempty,len=5"` // Format: "HH:MM"
-	EndTime   *string `json:"end_time,omitempty" validate:"omitempty,len=5"`
-	Note      string  `json:"note,omitempty" validate:"omitempty,max=500"`
-	StartDate string  `json:"start_date" validate:"required"` // Format: "YYYY-MM-DD"
-	EndDate   *string `json:"end_date,omitempty"`             // Format: "YYYY-MM-DD"
+	Frequency     string  `json:"frequency,omitempty" validate:"omitempty,oneof=weekly monthly_day monthly_weekday" enums:"weekly,monthly_day,monthly_weekday"` // Default: weekly
+	IntervalWeeks int     `json:"interval_weeks,omitempty" validate:"omitempty,min=1,max=52"`                                                                   // Weekly only, default: 1
+	DayOfWeek     *int    `json:"day_of_week,omitempty" validate:"omitempty,min=0,max=6"`                                                                       // Required unless monthly_day
+	DayOfMonth    *int    `json:"day_of_month,omitempty" validate:"omitempty,min=1,max=31"`                                                                     // Required for monthly_day
+	WeekOfMonth   *int    `json:"week_of_month,omitempty" validate:"omitempty,oneof=-1 1 2 3 4 5"`                                                              // Required for monthly_weekday, -1 for the last one
+	StartTime     *string `json:"start_time,omitempty" validate:"omitempty,len=5"`                                                                              // Format: "HH:MM"
+	EndTime       *string `json:"end_time,omitempty" validate:"omitempty,len=5"`
+	Note          string  `json:"note,omitempty" validate:"omitempty,max=500"`
+	StartDate     string  `json:"start_date" validate:"required"` // Format: "YYYY-MM-DD"
+	EndDate       *string `json:"end_date,omitempty"`             // Format: "YYYY-MM-DD"
 }
 
 // CreateExceptionRequest represents the request to create an exception
@@ -58,6 +118,14 @@ type CreateExceptionRequest struct {
 	ExcludedDate string `json:"excluded_date" validate:"required"` // Format: "YYYY-MM-DD"
 }
 
+// CreateExceptionRangeRequest excludes every date of a range from a participant's recurrences,
+// e.g. a vacation from July 1 to 21
+type CreateExceptionRangeRequest struct {
+	StartDate    string  `json:"start_date" validate:"required"`                    // Format: "YYYY-MM-DD"
+	EndDate      string  `json:"end_date" validate:"required"`                      // Format: "YYYY-MM-DD", inclusive
+	RecurrenceID *string `json:"recurrence_id,omitempty" validate:"omitempty,uuid"` // Only this recurrence, all of them by default
+}
+
 // RecurrenceWithExceptions includes a recurrence and its exceptions
 type RecurrenceWithExceptions struct {
 	Recurrence
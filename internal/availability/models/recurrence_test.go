@@ -0,0 +1,49 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestRecurrence_OccursOn(t *testing.T) {
+	endDate := "2025-08-31"
+	tests := []struct {
+		name       string
+		recurrence Recurrence
+		date       string
+		want       bool
+	}{
+		{"weekly", Recurrence{Frequency: FrequencyWeekly, IntervalWeeks: 1, DayOfWeek: intPtr(5), StartDate: "2025-06-01"}, "2025-06-13", true},
+		{"weekly other day", Recurrence{Frequency: FrequencyWeekly, IntervalWeeks: 1, DayOfWeek: intPtr(5), StartDate: "2025-06-01"}, "2025-06-12", false},
+		{"before start", Recurrence{Frequency: FrequencyWeekly, IntervalWeeks: 1, DayOfWeek: intPtr(5), StartDate: "2025-06-01"}, "2025-05-30", false},
+		{"after end", Recurrence{Frequency: FrequencyWeekly, IntervalWeeks: 1, DayOfWeek: intPtr(1), StartDate: "2025-06-01", EndDate: &endDate}, "2025-09-01", false},
+		// Starts on a Wednesday: Fridays 6, 20 and July 4 are on, 13 and 27 are off
+		{"biweekly first week", Recurrence{Frequency: FrequencyWeekly, IntervalWeeks: 2, DayOfWeek: intPtr(5), StartDate: "2025-06-04"}, "2025-06-06", true},
+		{"biweekly off week", Recurrence{Frequency: FrequencyWeekly, IntervalWeeks: 2, DayOfWeek: intPtr(5), StartDate: "2025-06-04"}, "2025-06-13", false},
+		{"biweekly later week", Recurrence{Frequency: FrequencyWeekly, IntervalWeeks: 2, DayOfWeek: intPtr(5), StartDate: "2025-06-04"}, "2025-07-04", true},
+		{"day of month", Recurrence{Frequency: FrequencyMonthlyDay, DayOfMonth: intPtr(15), StartDate: "2025-01-01"}, "2025-03-15", true},
+		{"other day of month", Recurrence{Frequency: FrequencyMonthlyDay, DayOfMonth: intPtr(15), StartDate: "2025-01-01"}, "2025-03-16", false},
+		{"first friday", Recurrence{Frequency: FrequencyMonthlyWeekday, DayOfWeek: intPtr(5), WeekOfMonth: intPtr(1), StartDate: "2025-01-01"}, "2025-08-01", true},
+		{"second friday", Recurrence{Frequency: FrequencyMonthlyWeekday, DayOfWeek: intPtr(5), WeekOfMonth: intPtr(1), StartDate: "2025-01-01"}, "2025-08-08", false},
+		{"last friday", Recurrence{Frequency: FrequencyMonthlyWeekday, DayOfWeek: intPtr(5), WeekOfMonth: intPtr(LastWeekOfMonth), StartDate: "2025-01-01"}, "2025-08-29", true},
+		{"not the last friday", Recurrence{Frequency: FrequencyMonthlyWeekday, DayOfWeek: intPtr(5), WeekOfMonth: intPtr(LastWeekOfMonth), StartDate: "2025-01-01"}, "2025-08-22", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.recurrence.OccursOn(date); got != tt.want {
+				t.Errorf("OccursOn(%s) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
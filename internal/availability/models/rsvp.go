@@ -0,0 +1,37 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RSVP answers
+const (
+	RSVPGoing    = "going"
+	RSVPNotGoing = "not_going"
+)
+
+// RSVP is the answer of a participant to an event, given apart from their availability
+type RSVP struct {
+	ParticipantID   uuid.UUID `json:"participant_id"`
+	ParticipantName string    `json:"participant_name"`
+	Date            string    `json:"date"` // Format: "2006-01-02"
+	Status          string    `json:"status" enums:"going,not_going"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// SetRSVPRequest represents a request to answer an event
+type SetRSVPRequest struct {
+	Status string `json:"status" validate:"required,oneof=going not_going"`
+}
+
+// RSVPCount is the number of answers of each kind on a date
+type RSVPCount struct {
+	Going    int
+	NotGoing int
+}
@@ -19,6 +19,10 @@ import (
 
 var (
 	ErrAvailabilityNotFound = errors.New("availability not found")
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrRSVPNotFound         = errors.New("rsvp not found")
+	ErrBusyFeedNotFound     = errors.New("busy feed not found")
+	ErrBusyFeedExists       = errors.New("busy feed already attached")
 )
 
 // AvailabilityRepository handles availability database operations
@@ -33,12 +37,16 @@ func NewAvailabilityRepository(pool *pgxpool.Pool) *AvailabilityRepository {
 
 // Create creates a new availability
 func (r *AvailabilityRepository) Create(ctx context.Context, availability *models.Availability) error {
+	return createAvailability(ctx, r.pool, availability)
+}
+
+func createAvailability(ctx context.Context, db queryRower, availability *models.Availability) error {
 	query := `
-		INSERT INTO availabilities (id, participant_id, date, start_time, end_time, note, source, recurrence_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO availabilities (id, participant_id, date, start_time, end_time, note, source, recurrence_id, status, preferred)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING created_at, updated_at`
 
-	err := r.pool.QueryRow(ctx, query,
+	err := db.QueryRow(ctx, query,
 		availability.ID,
 		availability.ParticipantID,
 		availability.Date,
@@ -47,6 +55,8 @@ func (r *AvailabilityRepository) Create(ctx context.Context, availability *model
 		availability.Note,
 		availability.Source,
 		availability.RecurrenceID,
+		availability.Status,
+		availability.Preferred,
 	).Scan(&availability.CreatedAt, &availability.UpdatedAt)
 
 	if err != nil {
@@ -65,7 +75,7 @@ func (r *AvailabilityRepository) GetByParticipantAndDate(ctx context.Context, pa
 		SELECT id, participant_id, date,
 		       TO_CHAR(start_time, 'HH24:MI') as start_time,
 		       TO_CHAR(end_time, 'HH24:MI') as end_time,
-		       note, source, recurrence_id, created_at, updated_at
+		       note, source, recurrence_id, status, preferred, created_at, updated_at
 		FROM availabilities
 		WHERE participant_id = $1 AND date = $2`
 
@@ -79,6 +89,8 @@ func (r *AvailabilityRepository) GetByParticipantAndDate(ctx context.Context, pa
 		&availability.Note,
 		&availability.Source,
 		&availability.RecurrenceID,
+		&availability.Status,
+		&availability.Preferred,
 		&availability.CreatedAt,
 		&availability.UpdatedAt,
 	)
@@ -104,7 +116,7 @@ func (r *AvailabilityRepository) GetByParticipantIDWithDateRange(ctx context.Con
 		SELECT id, participant_id, date,
 		       TO_CHAR(start_time, 'HH24:MI') as start_time,
 		       TO_CHAR(end_time, 'HH24:MI') as end_time,
-		       note, source, recurrence_id, created_at, updated_at
+		       note, source, recurrence_id, status, preferred, created_at, updated_at
 		FROM availabilities
 		WHERE participant_id = $1`
 
@@ -143,6 +155,8 @@ func (r *AvailabilityRepository) GetByParticipantIDWithDateRange(ctx context.Con
 			&availability.Note,
 			&availability.Source,
 			&availability.RecurrenceID,
+			&availability.Status,
+			&availability.Preferred,
 			&availability.CreatedAt,
 			&availability.UpdatedAt,
 		)
@@ -161,7 +175,7 @@ func (r *AvailabilityRepository) GetByDateRange(ctx context.Context, participant
 		SELECT id, participant_id, date,
 		       TO_CHAR(start_time, 'HH24:MI') as start_time,
 		       TO_CHAR(end_time, 'HH24:MI') as end_time,
-		       note, source, recurrence_id, created_at, updated_at
+		       note, source, recurrence_id, status, preferred, created_at, updated_at
 		FROM availabilities
 		WHERE participant_id = $1 AND date >= $2 AND date <= $3
 		ORDER BY date ASC`
@@ -184,6 +198,8 @@ func (r *AvailabilityRepository) GetByDateRange(ctx context.Context, participant
 			&availability.Note,
 			&availability.Source,
 			&availability.RecurrenceID,
+			&availability.Status,
+			&availability.Preferred,
 			&availability.CreatedAt,
 			&availability.UpdatedAt,
 		)
@@ -202,7 +218,7 @@ func (r *AvailabilityRepository) GetByDate(ctx context.Context, calendarID uuid.
 		SELECT a.id, a.participant_id, a.date,
 		       TO_CHAR(a.start_time, 'HH24:MI') as start_time,
 		       TO_CHAR(a.end_time, 'HH24:MI') as end_time,
-		       a.note, a.source, a.recurrence_id, a.created_at, a.updated_at
+		       a.note, a.source, a.recurrence_id, a.status, a.preferred, a.created_at, a.updated_at
 		FROM availabilities a
 		JOIN participants p ON a.participant_id = p.id
 		WHERE p.calendar_id = $1 AND a.date = $2
@@ -226,6 +242,8 @@ func (r *AvailabilityRepository) GetByDate(ctx context.Context, calendarID uuid.
 			&availability.Note,
 			&availability.Source,
 			&availability.RecurrenceID,
+			&availability.Status,
+			&availability.Preferred,
 			&availability.CreatedAt,
 			&availability.UpdatedAt,
 		)
@@ -244,7 +262,7 @@ func (r *AvailabilityRepository) GetByCalendarDateRange(ctx context.Context, cal
 		SELECT a.id, a.participant_id, a.date,
 		       TO_CHAR(a.start_time, 'HH24:MI') as start_time,
 		       TO_CHAR(a.end_time, 'HH24:MI') as end_time,
-		       a.note, a.source, a.recurrence_id, a.created_at, a.updated_at
+		       a.note, a.source, a.recurrence_id, a.status, a.preferred, a.created_at, a.updated_at
 		FROM availabilities a
 		JOIN participants p ON a.participant_id = p.id
 		WHERE p.calendar_id = $1 AND a.date >= $2 AND a.date <= $3
@@ -268,6 +286,8 @@ func (r *AvailabilityRepository) GetByCalendarDateRange(ctx context.Context, cal
 			&availability.Note,
 			&availability.Source,
 			&availability.RecurrenceID,
+			&availability.Status,
+			&availability.Preferred,
 			&availability.CreatedAt,
 			&availability.UpdatedAt,
 		)
@@ -282,17 +302,23 @@ func (r *AvailabilityRepository) GetByCalendarDateRange(ctx context.Context, cal
 
 // Update updates an availability
 func (r *AvailabilityRepository) Update(ctx context.Context, availability *models.Availability) error {
+	return updateAvailability(ctx, r.pool, availability)
+}
+
+func updateAvailability(ctx context.Context, db queryRower, availability *models.Availability) error {
 	query := `
 		UPDATE availabilities
-		SET start_time = $2, end_time = $3, note = $4, updated_at = NOW()
+		SET start_time = $2, end_time = $3, note = $4, status = $5, preferred = $6, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at`
 
-	err := r.pool.QueryRow(ctx, query,
+	err := db.QueryRow(ctx, query,
 		availability.ID,
 		availability.StartTime,
 		availability.EndTime,
 		availability.Note,
+		availability.Status,
+		availability.Preferred,
 	).Scan(&availability.UpdatedAt)
 
 	if err != nil {
@@ -321,20 +347,133 @@ func (r *AvailabilityRepository) Delete(ctx context.Context, participantID uuid.
 	return nil
 }
 
-// GetParticipantCountForDate counts unique participants with availability for a specific date
+// BulkResult is the outcome of ApplyBulk
+type BulkResult struct {
+	Upserted   []*models.Availability // Availabilities saved, replacing the existing one of their date
+	Created    []bool                 // Whether Upserted[i] was new
+	Waitlisted []*models.Availability // Availabilities queued on their full date
+	Deleted    []time.Time            // Dates that actually had an availability
+}
+
+// ApplyBulk saves and deletes availabilities of a participant in a single transaction
+// Availabilities exceeding the capacity of a full date are waitlisted, or fail the whole
+// transaction with ErrDateFull, depending on the capacity policy of the calendar.
+// Every changed date leaves the waitlist.
+func (r *AvailabilityRepository) ApplyBulk(ctx context.Context, calendarID, participantID uuid.UUID, availabilities []*models.Availability, deletes []time.Time) (*BulkResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	c, err := lockCapacity(ctx, tx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := append([]time.Time{}, deletes...)
+	for _, availability := range availabilities {
+		changed = append(changed, availability.Date)
+	}
+	if len(changed) > 0 {
+		_, err := tx.Exec(ctx, `DELETE FROM availability_waitlist WHERE participant_id = $1 AND date = ANY($2)`, participantID, changed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to clear waitlist: %w", err)
+		}
+	}
+
+	result := &BulkResult{}
+	if len(deletes) > 0 {
+		rows, err := tx.Query(ctx, `
+			DELETE FROM availabilities
+			WHERE participant_id = $1 AND date = ANY($2)
+			RETURNING date`, participantID, deletes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete availabilities: %w", err)
+		}
+		result.Deleted, err = pgx.CollectRows(rows, pgx.RowTo[time.Time])
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete availabilities: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO availabilities (id, participant_id, date, start_time, end_time, note, source, recurrence_id, status, preferred)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (participant_id, date) DO UPDATE
+		SET start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time, note = EXCLUDED.note,
+		    source = EXCLUDED.source, recurrence_id = EXCLUDED.recurrence_id, status = EXCLUDED.status,
+		    preferred = EXCLUDED.preferred, updated_at = NOW()
+		RETURNING id, created_at, updated_at, (xmax = 0)`
+
+	for _, availability := range availabilities {
+		availability.ParticipantID = participantID
+		full, err := c.full(ctx, tx, calendarID, participantID, availability.Date, availability.Status)
+		if err != nil {
+			return nil, err
+		}
+		if full {
+			if c.policy != models.CapacityWaitlist {
+				return nil, fmt.Errorf("%s: %w", availability.Date.Format("2006-01-02"), ErrDateFull)
+			}
+			if err := addToWaitlist(ctx, tx, availability); err != nil {
+				return nil, err
+			}
+			result.Waitlisted = append(result.Waitlisted, availability)
+			continue
+		}
+
+		var created bool
+		err = tx.QueryRow(ctx, query,
+			availability.ID,
+			participantID,
+			availability.Date,
+			availability.StartTime,
+			availability.EndTime,
+			availability.Note,
+			availability.Source,
+			availability.RecurrenceID,
+			availability.Status,
+			availability.Preferred,
+		).Scan(&availability.ID, &availability.CreatedAt, &availability.UpdatedAt, &created)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upsert availability: %w", err)
+		}
+		result.Upserted = append(result.Upserted, availability)
+		result.Created = append(result.Created, created)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit availabilities: %w", err)
+	}
+	return result, nil
+}
+
+// GetParticipantCountForDate counts unique participants with availability for a specific date,
+// along with the required participants who are not available
 func (r *AvailabilityRepository) GetParticipantCountForDate(
 	ctx context.Context,
 	calendarID uuid.UUID,
 	date time.Time,
-) (int, error) {
+) (models.DateCount, error) {
 	// Query counts distinct participants who have availability on this date
 	// This includes both manual availabilities and recurrence-generated ones
+	// A "maybe" answer only counts when the calendar enables count_maybe, and overrides the recurrence of its date
 	query := `
 		WITH calendar_participants AS (
-			SELECT id as participant_id
+			SELECT id as participant_id, required
 			FROM participants
 			WHERE calendar_id = $1
 		),
+		ignored_maybes AS (
+			SELECT a.participant_id
+			FROM availabilities a
+			JOIN calendar_participants cp ON a.participant_id = cp.participant_id
+			JOIN calendars c ON c.id = $1
+			WHERE a.date = $2
+			  AND a.status = 'maybe'
+			  AND NOT c.count_maybe
+		),
 		date_availabilities AS (
 			-- Manual availabilities for this date
 			SELECT DISTINCT a.participant_id
@@ -342,6 +481,7 @@ func (r *AvailabilityRepository) GetParticipantCountForDate(
 			JOIN calendar_participants cp ON a.participant_id = cp.participant_id
 			WHERE a.date = $2
 			  AND a.source = 'manual'
+			  AND a.participant_id NOT IN (SELECT participant_id FROM ignored_maybes)
 
 			UNION
 
@@ -349,9 +489,8 @@ func (r *AvailabilityRepository) GetParticipantCountForDate(
 			SELECT DISTINCT r.participant_id
 			FROM recurrences r
 			JOIN calendar_participants cp ON r.participant_id = cp.participant_id
-			WHERE EXTRACT(DOW FROM $2::DATE) = r.day_of_week
-			  AND (r.start_date IS NULL OR $2::DATE >= r.start_date)
-			  AND (r.end_date IS NULL OR $2::DATE <= r.end_date)
+			WHERE recurrence_occurs_on(r, $2::DATE)
+			  AND r.participant_id NOT IN (SELECT participant_id FROM ignored_maybes)
 			  -- Exclude if there's an exception for this date
 			  AND NOT EXISTS (
 				SELECT 1 FROM recurrence_exceptions re
@@ -359,17 +498,263 @@ func (r *AvailabilityRepository) GetParticipantCountForDate(
 				  AND re.excluded_date = $2::DATE
 			  )
 		)
-		SELECT COUNT(*) FROM date_availabilities`
+		SELECT
+			(SELECT COUNT(*) FROM date_availabilities),
+			(SELECT COUNT(*) FROM calendar_participants cp
+			 WHERE cp.required
+			   AND cp.participant_id NOT IN (SELECT participant_id FROM date_availabilities))`
+
+	var count models.DateCount
+	err := r.pool.QueryRow(ctx, query, calendarID, date).Scan(&count.Count, &count.RequiredMissing)
+	if err != nil {
+		return models.DateCount{}, fmt.Errorf("failed to get participant count for date: %w", err)
+	}
 
-	var count int
-	err := r.pool.QueryRow(ctx, query, calendarID, date).Scan(&count)
+	return count, nil
+}
+
+// CreateComment adds a comment of a participant on a date of their calendar
+func (r *AvailabilityRepository) CreateComment(ctx context.Context, calendarID, participantID uuid.UUID, date time.Time, body string) (*models.DateComment, error) {
+	comment := &models.DateComment{ParticipantID: participantID, Date: date.Format("2006-01-02"), Body: body}
+	err := r.pool.QueryRow(ctx, `
+		WITH inserted AS (
+			INSERT INTO date_comments (calendar_id, participant_id, date, body)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, participant_id, created_at
+		)
+		SELECT i.id, p.name, i.created_at
+		FROM inserted i
+		JOIN participants p ON p.id = i.participant_id`,
+		calendarID, participantID, date, body,
+	).Scan(&comment.ID, &comment.ParticipantName, &comment.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+	return comment, nil
+}
+
+// GetCommentsByDate returns the comments of a date of a calendar, oldest first
+func (r *AvailabilityRepository) GetCommentsByDate(ctx context.Context, calendarID uuid.UUID, date time.Time) ([]models.DateComment, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT c.id, c.participant_id, p.name, c.date, c.body, c.created_at
+		FROM date_comments c
+		JOIN participants p ON p.id = c.participant_id
+		WHERE c.calendar_id = $1 AND c.date = $2
+		ORDER BY c.created_at, c.id`, calendarID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []models.DateComment{}
+	for rows.Next() {
+		var comment models.DateComment
+		var commentDate time.Time
+		if err := rows.Scan(&comment.ID, &comment.ParticipantID, &comment.ParticipantName, &commentDate, &comment.Body, &comment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comment.Date = commentDate.Format("2006-01-02")
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// DeleteComment deletes a comment posted by a participant
+func (r *AvailabilityRepository) DeleteComment(ctx context.Context, participantID, commentID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM date_comments WHERE id = $1 AND participant_id = $2`, commentID, participantID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get participant count for date: %w", err)
+		return fmt.Errorf("failed to delete comment: %w", err)
 	}
+	if result.RowsAffected() == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
 
+// AddToWaitlist queues an availability on a full date
+// A participant already waiting on the date keeps their place, with the new answer
+func (r *AvailabilityRepository) AddToWaitlist(ctx context.Context, availability *models.Availability) error {
+	return addToWaitlist(ctx, r.pool, availability)
+}
+
+// queryRower is implemented by both the pool and transactions
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func addToWaitlist(ctx context.Context, db queryRower, availability *models.Availability) error {
+	query := `
+		INSERT INTO availability_waitlist (id, participant_id, date, start_time, end_time, note, status, preferred)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (participant_id, date) DO UPDATE
+		SET start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time, note = EXCLUDED.note,
+		    status = EXCLUDED.status, preferred = EXCLUDED.preferred
+		RETURNING id, created_at`
+
+	err := db.QueryRow(ctx, query,
+		availability.ID,
+		availability.ParticipantID,
+		availability.Date,
+		availability.StartTime,
+		availability.EndTime,
+		availability.Note,
+		availability.Status,
+		availability.Preferred,
+	).Scan(&availability.ID, &availability.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add availability to waitlist: %w", err)
+	}
+	availability.UpdatedAt = availability.CreatedAt
+	return nil
+}
+
+// RemoveFromWaitlist removes the waitlisted availability of a participant on a date
+func (r *AvailabilityRepository) RemoveFromWaitlist(ctx context.Context, participantID uuid.UUID, date time.Time) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM availability_waitlist WHERE participant_id = $1 AND date = $2`, participantID, date)
+	if err != nil {
+		return fmt.Errorf("failed to remove availability from waitlist: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAvailabilityNotFound
+	}
+	return nil
+}
+
+// GetWaitlistByParticipant retrieves the waitlisted availabilities of a participant, optionally filtered by date range
+func (r *AvailabilityRepository) GetWaitlistByParticipant(ctx context.Context, participantID uuid.UUID, startDate, endDate *time.Time) ([]*models.Availability, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, participant_id, date,
+		       TO_CHAR(start_time, 'HH24:MI') as start_time,
+		       TO_CHAR(end_time, 'HH24:MI') as end_time,
+		       note, status, preferred, created_at
+		FROM availability_waitlist
+		WHERE participant_id = $1
+		  AND ($2::DATE IS NULL OR date >= $2)
+		  AND ($3::DATE IS NULL OR date <= $3)
+		ORDER BY date ASC`, participantID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist: %w", err)
+	}
+	defer rows.Close()
+
+	var availabilities []*models.Availability
+	for rows.Next() {
+		availability := &models.Availability{Source: "manual"}
+		err := rows.Scan(
+			&availability.ID,
+			&availability.ParticipantID,
+			&availability.Date,
+			&availability.StartTime,
+			&availability.EndTime,
+			&availability.Note,
+			&availability.Status,
+			&availability.Preferred,
+			&availability.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan waitlisted availability: %w", err)
+		}
+		availability.UpdatedAt = availability.CreatedAt
+		availabilities = append(availabilities, availability)
+	}
+	return availabilities, rows.Err()
+}
+
+// CountWaitlist counts the participants waiting for a spot on a date of a calendar
+func (r *AvailabilityRepository) CountWaitlist(ctx context.Context, calendarID uuid.UUID, date time.Time) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM availability_waitlist w
+		JOIN participants p ON p.id = w.participant_id
+		WHERE p.calendar_id = $1 AND w.date = $2`, calendarID, date).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count waitlist: %w", err)
+	}
 	return count, nil
 }
 
+// PromoteFromWaitlist moves the oldest waitlisted availability of a date into the availabilities
+// Entries of participants who answered the date meanwhile are dropped.
+// Returns nil when the waitlist is empty or the date has no spot left.
+func (r *AvailabilityRepository) PromoteFromWaitlist(ctx context.Context, calendarID uuid.UUID, date time.Time) (*models.Availability, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	c, err := lockCapacity(ctx, tx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		availability := &models.Availability{Source: "manual"}
+		err := tx.QueryRow(ctx, `
+			DELETE FROM availability_waitlist
+			WHERE id = (
+				SELECT w.id
+				FROM availability_waitlist w
+				JOIN participants p ON p.id = w.participant_id
+				WHERE p.calendar_id = $1 AND w.date = $2
+				ORDER BY w.created_at, w.id
+				LIMIT 1
+				FOR UPDATE OF w SKIP LOCKED
+			)
+			RETURNING participant_id, date, TO_CHAR(start_time, 'HH24:MI'), TO_CHAR(end_time, 'HH24:MI'), note, status, preferred`,
+			calendarID, date,
+		).Scan(
+			&availability.ParticipantID,
+			&availability.Date,
+			&availability.StartTime,
+			&availability.EndTime,
+			&availability.Note,
+			&availability.Status,
+			&availability.Preferred,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, tx.Commit(ctx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to pop waitlist: %w", err)
+		}
+		full, err := c.full(ctx, tx, calendarID, availability.ParticipantID, availability.Date, availability.Status)
+		if err != nil || full {
+			// The entry keeps its place with the rollback
+			return nil, err
+		}
+
+		availability.ID = uuid.New()
+		err = tx.QueryRow(ctx, `
+			INSERT INTO availabilities (id, participant_id, date, start_time, end_time, note, source, status, preferred)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (participant_id, date) DO NOTHING
+			RETURNING created_at, updated_at`,
+			availability.ID,
+			availability.ParticipantID,
+			availability.Date,
+			availability.StartTime,
+			availability.EndTime,
+			availability.Note,
+			availability.Source,
+			availability.Status,
+			availability.Preferred,
+		).Scan(&availability.CreatedAt, &availability.UpdatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to promote waitlisted availability: %w", err)
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to commit promotion: %w", err)
+		}
+		return availability, nil
+	}
+}
+
 func isDuplicateKeyError(err error) bool {
 	return err != nil && (
 	// PostgreSQL unique constraint violation
@@ -392,3 +777,186 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+// SetRSVP records the answer of a participant to an event of their calendar, replacing their previous one
+func (r *AvailabilityRepository) SetRSVP(ctx context.Context, calendarID, participantID uuid.UUID, date time.Time, status string) (*models.RSVP, error) {
+	rsvp := &models.RSVP{ParticipantID: participantID, Date: date.Format("2006-01-02"), Status: status}
+	err := r.pool.QueryRow(ctx, `
+		WITH upserted AS (
+			INSERT INTO rsvps (participant_id, calendar_id, date, status)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (participant_id, date) DO UPDATE
+			SET status = EXCLUDED.status, updated_at = NOW()
+			RETURNING participant_id, updated_at
+		)
+		SELECT p.name, u.updated_at
+		FROM upserted u
+		JOIN participants p ON p.id = u.participant_id`,
+		participantID, calendarID, date, status,
+	).Scan(&rsvp.ParticipantName, &rsvp.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set rsvp: %w", err)
+	}
+	return rsvp, nil
+}
+
+// DeleteRSVP withdraws the answer of a participant to an event
+func (r *AvailabilityRepository) DeleteRSVP(ctx context.Context, participantID uuid.UUID, date time.Time) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM rsvps WHERE participant_id = $1 AND date = $2`, participantID, date)
+	if err != nil {
+		return fmt.Errorf("failed to delete rsvp: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrRSVPNotFound
+	}
+	return nil
+}
+
+// GetRSVPsByDate returns the answers to an event of a calendar, by participant name
+func (r *AvailabilityRepository) GetRSVPsByDate(ctx context.Context, calendarID uuid.UUID, date time.Time) ([]models.RSVP, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT v.participant_id, p.name, v.date, v.status, v.updated_at
+		FROM rsvps v
+		JOIN participants p ON p.id = v.participant_id
+		WHERE v.calendar_id = $1 AND v.date = $2
+		ORDER BY p.name, v.participant_id`, calendarID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rsvps: %w", err)
+	}
+	defer rows.Close()
+
+	rsvps := []models.RSVP{}
+	for rows.Next() {
+		var rsvp models.RSVP
+		var rsvpDate time.Time
+		if err := rows.Scan(&rsvp.ParticipantID, &rsvp.ParticipantName, &rsvpDate, &rsvp.Status, &rsvp.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rsvp: %w", err)
+		}
+		rsvp.Date = rsvpDate.Format("2006-01-02")
+		rsvps = append(rsvps, rsvp)
+	}
+	return rsvps, rows.Err()
+}
+
+// CountRSVPsByDateRange counts the answers to the events of a calendar over a date range, by date (YYYY-MM-DD)
+func (r *AvailabilityRepository) CountRSVPsByDateRange(ctx context.Context, calendarID uuid.UUID, startDate, endDate time.Time) (map[string]models.RSVPCount, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT date,
+			COUNT(*) FILTER (WHERE status = 'going'),
+			COUNT(*) FILTER (WHERE status = 'not_going')
+		FROM rsvps
+		WHERE calendar_id = $1 AND date BETWEEN $2 AND $3
+		GROUP BY date`, calendarID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rsvps: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]models.RSVPCount)
+	for rows.Next() {
+		var date time.Time
+		var count models.RSVPCount
+		if err := rows.Scan(&date, &count.Going, &count.NotGoing); err != nil {
+			return nil, fmt.Errorf("failed to scan rsvp count: %w", err)
+		}
+		counts[date.Format("2006-01-02")] = count
+	}
+	return counts, rows.Err()
+}
+
+// CreateBusyFeed attaches an ICS feed to a participant
+func (r *AvailabilityRepository) CreateBusyFeed(ctx context.Context, participantID uuid.UUID, url string) (*models.BusyFeed, error) {
+	feed := &models.BusyFeed{ParticipantID: participantID, URL: url}
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO participant_busy_feeds (participant_id, url)
+		VALUES ($1, $2)
+		ON CONFLICT (participant_id, url) DO NOTHING
+		RETURNING id, created_at`,
+		participantID, url,
+	).Scan(&feed.ID, &feed.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBusyFeedExists
+		}
+		return nil, fmt.Errorf("failed to create busy feed: %w", err)
+	}
+	return feed, nil
+}
+
+// GetBusyFeed returns an ICS feed of a participant
+func (r *AvailabilityRepository) GetBusyFeed(ctx context.Context, participantID, feedID uuid.UUID) (*models.BusyFeed, error) {
+	feed := &models.BusyFeed{}
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, participant_id, url, last_sync_at, last_sync_error, created_at
+		FROM participant_busy_feeds
+		WHERE id = $1 AND participant_id = $2`, feedID, participantID,
+	).Scan(&feed.ID, &feed.ParticipantID, &feed.URL, &feed.LastSyncAt, &feed.LastSyncError, &feed.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBusyFeedNotFound
+		}
+		return nil, fmt.Errorf("failed to get busy feed: %w", err)
+	}
+	return feed, nil
+}
+
+// ListBusyFeeds returns the ICS feeds of a participant, oldest first
+func (r *AvailabilityRepository) ListBusyFeeds(ctx context.Context, participantID uuid.UUID) ([]models.BusyFeed, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, participant_id, url, last_sync_at, last_sync_error, created_at
+		FROM participant_busy_feeds
+		WHERE participant_id = $1
+		ORDER BY created_at, id`, participantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list busy feeds: %w", err)
+	}
+	defer rows.Close()
+
+	feeds := []models.BusyFeed{}
+	for rows.Next() {
+		var feed models.BusyFeed
+		if err := rows.Scan(&feed.ID, &feed.ParticipantID, &feed.URL, &feed.LastSyncAt, &feed.LastSyncError, &feed.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan busy feed: %w", err)
+		}
+		feeds = append(feeds, feed)
+	}
+	return feeds, rows.Err()
+}
+
+// DeleteBusyFeed detaches an ICS feed from a participant, along with its busy blocks
+func (r *AvailabilityRepository) DeleteBusyFeed(ctx context.Context, participantID, feedID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM participant_busy_feeds WHERE id = $1 AND participant_id = $2`, feedID, participantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete busy feed: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrBusyFeedNotFound
+	}
+	return nil
+}
+
+// GetBusyPeriods returns the busy blocks of the feeds of a participant overlapping [from, to), ordered by start
+// A nil bound leaves the range open on that side
+func (r *AvailabilityRepository) GetBusyPeriods(ctx context.Context, participantID uuid.UUID, from, to *time.Time) ([]models.BusyPeriod, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT start_at, end_at
+		FROM participant_busy_blocks
+		WHERE participant_id = $1
+		  AND ($2::timestamptz IS NULL OR end_at > $2)
+		  AND ($3::timestamptz IS NULL OR start_at < $3)
+		ORDER BY start_at`, participantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get busy periods: %w", err)
+	}
+	defer rows.Close()
+
+	periods := []models.BusyPeriod{}
+	for rows.Next() {
+		var period models.BusyPeriod
+		if err := rows.Scan(&period.Start, &period.End); err != nil {
+			return nil, fmt.Errorf("failed to scan busy period: %w", err)
+		}
+		periods = append(periods, period)
+	}
+	return periods, rows.Err()
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/pkg/datevalidation"
 )
 
 var (
@@ -46,16 +48,28 @@ type TimeRange struct {
 // Calendar represents calendar information needed for availability filtering
 type Calendar struct {
 	ID               uuid.UUID
+	Name             string
 	Threshold        int
 	AllowedWeekdays  []int
 	MinDurationHours int
 	Timezone         string
 	HolidaysPolicy   string
 	AllowHolidayEves bool
+	HolidaySets      []string
+	BlackoutDates    []datevalidation.BlackoutPeriod
+	CustomHolidays   datevalidation.CustomHolidays
+	HolidayCountry   string // Empty to derive the country from the timezone
+	HolidayRegion    string
+	HolidayCountries []string // Other countries whose public holidays also count
 	AllowedHours     AllowedHours
 	LockParticipants bool
 	StartDate        *time.Time
 	EndDate          *time.Time
+	WeekStart        *string
+	CountMaybe       bool
+	MaxParticipants  *int   // Participants counted per date, nil for no limit
+	CapacityPolicy   string // Availabilities on a full date: "reject" or "waitlist"
+	RequireConfirm   bool   // Dates reaching the threshold only become events once confirmed by the owner
 }
 
 // GetByPublicToken retrieves a calendar ID by public token (for validation)
@@ -77,22 +91,34 @@ func (r *CalendarRepository) GetByPublicToken(ctx context.Context, token string)
 
 // GetCalendarInfoByPublicToken retrieves calendar information by public token
 func (r *CalendarRepository) GetCalendarInfoByPublicToken(ctx context.Context, token string) (*Calendar, error) {
-	query := `SELECT id, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, lock_participants, start_date, end_date FROM calendars WHERE public_token = $1`
+	query := `SELECT id, name, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, holiday_sets, blackout_dates, custom_holidays, COALESCE(holiday_country, ''), COALESCE(holiday_region, ''), holiday_countries, allowed_hours, lock_participants, start_date, end_date, week_start, count_maybe, max_participants, capacity_policy, require_confirmation FROM calendars WHERE public_token = $1`
 
 	var cal Calendar
 	var allowedHoursJSON []byte
 	err := r.pool.QueryRow(ctx, query, token).Scan(
 		&cal.ID,
+		&cal.Name,
 		&cal.Threshold,
 		&cal.AllowedWeekdays,
 		&cal.MinDurationHours,
 		&cal.Timezone,
 		&cal.HolidaysPolicy,
 		&cal.AllowHolidayEves,
+		&cal.HolidaySets,
+		&cal.BlackoutDates,
+		&cal.CustomHolidays,
+		&cal.HolidayCountry,
+		&cal.HolidayRegion,
+		&cal.HolidayCountries,
 		&allowedHoursJSON,
 		&cal.LockParticipants,
 		&cal.StartDate,
 		&cal.EndDate,
+		&cal.WeekStart,
+		&cal.CountMaybe,
+		&cal.MaxParticipants,
+		&cal.CapacityPolicy,
+		&cal.RequireConfirm,
 	)
 
 	if err != nil {
@@ -110,6 +136,22 @@ func (r *CalendarRepository) GetCalendarInfoByPublicToken(ctx context.Context, t
 	return &cal, nil
 }
 
+// GetConfirmationStatus returns the owner's decision on a date of a calendar requiring confirmation,
+// empty if the date was never proposed or decided on
+func (r *CalendarRepository) GetConfirmationStatus(ctx context.Context, calendarID uuid.UUID, date time.Time) (string, error) {
+	query := `SELECT status FROM event_confirmations WHERE calendar_id = $1 AND date = $2`
+
+	var status string
+	err := r.pool.QueryRow(ctx, query, calendarID, date).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get confirmation status: %w", err)
+	}
+	return status, nil
+}
+
 // parseAllowedHours parses the allowed_hours JSONB field
 func parseAllowedHours(data []byte, allowedHours *AllowedHours) error {
 	if len(data) == 0 {
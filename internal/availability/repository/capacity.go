@@ -0,0 +1,263 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/whento/whento/internal/availability/models"
+)
+
+// ErrDateFull is returned when an answer doesn't fit on a full date and the calendar rejects the extra ones
+var ErrDateFull = errors.New("this date has reached its maximum number of participants")
+
+// capacityHorizon bounds the dates of a recurrence checked against the capacity of a calendar without end date
+const capacityHorizon = 366 * 24 * time.Hour
+
+// capacity is the participant limit of the dates of a calendar, read with the calendar row locked
+// Every write that may take a spot locks the row first, so that counting the spots and writing the
+// answer are atomic: concurrent answers on a calendar are serialized and can't exceed the limit.
+type capacity struct {
+	max        *int
+	policy     string
+	countMaybe bool
+	startDate  *time.Time
+	endDate    *time.Time
+}
+
+// lockCapacity locks the calendar for the rest of the transaction and returns its limit
+func lockCapacity(ctx context.Context, tx pgx.Tx, calendarID uuid.UUID) (*capacity, error) {
+	c := &capacity{}
+	err := tx.QueryRow(ctx, `
+		SELECT max_participants, capacity_policy, count_maybe, start_date, end_date
+		FROM calendars
+		WHERE id = $1
+		FOR UPDATE`, calendarID,
+	).Scan(&c.max, &c.policy, &c.countMaybe, &c.startDate, &c.endDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, fmt.Errorf("failed to lock calendar: %w", err)
+	}
+	return c, nil
+}
+
+// full reports whether an answer of a participant doesn't fit on a date, given the spots taken by the others
+// A participant replacing an answer that already takes a spot keeps it, as only the others are counted
+func (c *capacity) full(ctx context.Context, tx pgx.Tx, calendarID, participantID uuid.UUID, date time.Time, status string) (bool, error) {
+	if c.max == nil || !models.CountsTowardCapacity(status, c.countMaybe) {
+		return false, nil
+	}
+	taken, err := countTakenSpots(ctx, tx, calendarID, participantID, date)
+	if err != nil {
+		return false, err
+	}
+	return taken >= *c.max, nil
+}
+
+// countTakenSpots counts the participants other than participantID taking a spot on a date,
+// through a manual availability or a recurrence, like GetParticipantCountForDate
+func countTakenSpots(ctx context.Context, tx pgx.Tx, calendarID, participantID uuid.UUID, date time.Time) (int, error) {
+	var count int
+	err := tx.QueryRow(ctx, `
+		WITH calendar_participants AS (
+			SELECT id AS participant_id
+			FROM participants
+			WHERE calendar_id = $1 AND id <> $3
+		),
+		ignored_maybes AS (
+			SELECT a.participant_id
+			FROM availabilities a
+			JOIN calendar_participants cp ON a.participant_id = cp.participant_id
+			JOIN calendars c ON c.id = $1
+			WHERE a.date = $2
+			  AND a.status = 'maybe'
+			  AND NOT c.count_maybe
+		)
+		SELECT COUNT(*) FROM (
+			SELECT a.participant_id
+			FROM availabilities a
+			JOIN calendar_participants cp ON a.participant_id = cp.participant_id
+			WHERE a.date = $2
+			  AND a.source = 'manual'
+			  AND a.participant_id NOT IN (SELECT participant_id FROM ignored_maybes)
+
+			UNION
+
+			SELECT r.participant_id
+			FROM recurrences r
+			JOIN calendar_participants cp ON r.participant_id = cp.participant_id
+			WHERE recurrence_occurs_on(r, $2::DATE)
+			  AND r.participant_id NOT IN (SELECT participant_id FROM ignored_maybes)
+			  AND NOT EXISTS (
+				SELECT 1 FROM recurrence_exceptions re
+				WHERE re.recurrence_id = r.id
+				  AND re.excluded_date = $2::DATE
+			  )
+		) spots`, calendarID, date, participantID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count taken spots: %w", err)
+	}
+	return count, nil
+}
+
+// CreateWithinCapacity creates an availability if its date has a spot left
+// On a full date, the availability is waitlisted (waitlisted = true) or ErrDateFull is returned,
+// depending on the capacity policy of the calendar
+func (r *AvailabilityRepository) CreateWithinCapacity(ctx context.Context, calendarID uuid.UUID, availability *models.Availability) (waitlisted bool, err error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	c, err := lockCapacity(ctx, tx, calendarID)
+	if err != nil {
+		return false, err
+	}
+	full, err := c.full(ctx, tx, calendarID, availability.ParticipantID, availability.Date, availability.Status)
+	if err != nil {
+		return false, err
+	}
+
+	if full {
+		if c.policy != models.CapacityWaitlist {
+			return false, ErrDateFull
+		}
+		if err := addToWaitlist(ctx, tx, availability); err != nil {
+			return false, err
+		}
+	} else if err := createAvailability(ctx, tx, availability); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit availability: %w", err)
+	}
+	return full, nil
+}
+
+// UpdateWithinCapacity updates an availability, unless it now needs a spot on a full date (ErrDateFull)
+// An update is never waitlisted, as the current answer would be lost
+func (r *AvailabilityRepository) UpdateWithinCapacity(ctx context.Context, calendarID uuid.UUID, availability *models.Availability) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	c, err := lockCapacity(ctx, tx, calendarID)
+	if err != nil {
+		return err
+	}
+	full, err := c.full(ctx, tx, calendarID, availability.ParticipantID, availability.Date, availability.Status)
+	if err != nil {
+		return err
+	}
+	if full {
+		return ErrDateFull
+	}
+
+	if err := updateAvailability(ctx, tx, availability); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit availability: %w", err)
+	}
+	return nil
+}
+
+// recurrenceFullDates returns the upcoming dates of a recurrence on which it doesn't fit, once written in tx
+// Dates on which the participant already has an availability are skipped: that answer holds the spot.
+// Without calendar end date, only the dates of the next year are checked.
+func recurrenceFullDates(ctx context.Context, tx pgx.Tx, c *capacity, calendarID uuid.UUID, recurrence *models.Recurrence) ([]time.Time, error) {
+	if c.max == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if c.startDate != nil && c.startDate.After(from) {
+		from = *c.startDate
+	}
+	to := from.Add(capacityHorizon)
+	if c.endDate != nil && c.endDate.Before(to) {
+		to = *c.endDate
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT d::DATE
+		FROM recurrences r, generate_series($2::DATE, $3::DATE, INTERVAL '1 day') d
+		WHERE r.id = $1
+		  AND recurrence_occurs_on(r, d::DATE)
+		  AND NOT EXISTS (
+			SELECT 1 FROM recurrence_exceptions re
+			WHERE re.recurrence_id = r.id AND re.excluded_date = d::DATE
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM availabilities a
+			WHERE a.participant_id = r.participant_id AND a.date = d::DATE
+		  )
+		ORDER BY d`, recurrence.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurrence dates: %w", err)
+	}
+	dates, err := pgx.CollectRows(rows, pgx.RowTo[time.Time])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurrence dates: %w", err)
+	}
+
+	var full []time.Time
+	for _, date := range dates {
+		taken, err := countTakenSpots(ctx, tx, calendarID, recurrence.ParticipantID, date)
+		if err != nil {
+			return nil, err
+		}
+		if taken >= *c.max {
+			full = append(full, date)
+		}
+	}
+	return full, nil
+}
+
+// skipFullDates excludes the full dates from a recurrence, and waitlists them when the calendar queues extra answers
+func skipFullDates(ctx context.Context, tx pgx.Tx, c *capacity, recurrence *models.Recurrence, dates []time.Time) error {
+	for _, date := range dates {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO recurrence_exceptions (id, recurrence_id, excluded_date, created_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (recurrence_id, excluded_date) DO NOTHING`,
+			uuid.New(), recurrence.ID, date)
+		if err != nil {
+			return fmt.Errorf("failed to exclude full date: %w", err)
+		}
+
+		if c.policy != models.CapacityWaitlist {
+			continue
+		}
+		availability := &models.Availability{
+			ParticipantID: recurrence.ParticipantID,
+			Date:          date,
+			StartTime:     recurrence.StartTime,
+			EndTime:       recurrence.EndTime,
+			Note:          recurrence.Note,
+			Status:        models.StatusYes,
+		}
+		availability.ID = uuid.New()
+		if err := addToWaitlist(ctx, tx, availability); err != nil {
+			return err
+		}
+	}
+	return nil
+}
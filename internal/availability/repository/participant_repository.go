@@ -25,6 +25,7 @@ type Participant struct {
 	Name          string
 	Email         *string
 	EmailVerified bool
+	Required      bool // Dates only reach the threshold when all required participants are available
 }
 
 // ParticipantRepository handles participant database operations
@@ -40,7 +41,7 @@ func NewParticipantRepository(pool *pgxpool.Pool) *ParticipantRepository {
 // GetByID retrieves a participant by ID
 func (r *ParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*Participant, error) {
 	query := `
-		SELECT id, calendar_id, name, email, email_verified
+		SELECT id, calendar_id, name, email, email_verified, required
 		FROM participants
 		WHERE id = $1`
 
@@ -51,6 +52,7 @@ func (r *ParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*Par
 		&participant.Name,
 		&participant.Email,
 		&participant.EmailVerified,
+		&participant.Required,
 	)
 
 	if err != nil {
@@ -63,10 +65,45 @@ func (r *ParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*Par
 	return participant, nil
 }
 
+// GetByAccessToken retrieves a participant of a calendar by the secret token of their link
+// The first use of a link marks it as claimed, so it can no longer be claimed from the public page
+func (r *ParticipantRepository) GetByAccessToken(ctx context.Context, calendarID uuid.UUID, token string) (*Participant, error) {
+	query := `
+		WITH participant AS (
+			SELECT id, calendar_id, name, email, email_verified, required, access_token_claimed_at
+			FROM participants
+			WHERE calendar_id = $1 AND access_token = $2
+		), claim AS (
+			UPDATE participants SET access_token_claimed_at = NOW()
+			WHERE id = (SELECT id FROM participant WHERE access_token_claimed_at IS NULL)
+		)
+		SELECT id, calendar_id, name, email, email_verified, required
+		FROM participant`
+
+	participant := &Participant{}
+	err := r.pool.QueryRow(ctx, query, calendarID, token).Scan(
+		&participant.ID,
+		&participant.CalendarID,
+		&participant.Name,
+		&participant.Email,
+		&participant.EmailVerified,
+		&participant.Required,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrParticipantNotFound
+		}
+		return nil, fmt.Errorf("failed to get participant by access token: %w", err)
+	}
+
+	return participant, nil
+}
+
 // GetByCalendarID retrieves all participants for a calendar
 func (r *ParticipantRepository) GetByCalendarID(ctx context.Context, calendarID uuid.UUID) ([]*Participant, error) {
 	query := `
-		SELECT id, calendar_id, name, email, email_verified
+		SELECT id, calendar_id, name, email, email_verified, required
 		FROM participants
 		WHERE calendar_id = $1
 		ORDER BY created_at ASC`
@@ -86,6 +123,7 @@ func (r *ParticipantRepository) GetByCalendarID(ctx context.Context, calendarID
 			&participant.Name,
 			&participant.Email,
 			&participant.EmailVerified,
+			&participant.Required,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan participant: %w", err)
@@ -7,8 +7,10 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/whento/whento/internal/availability/models"
@@ -23,16 +25,34 @@ func NewRecurrenceRepository(db *pgxpool.Pool) *RecurrenceRepository {
 }
 
 // CreateRecurrence creates a new recurrence
-func (r *RecurrenceRepository) CreateRecurrence(ctx context.Context, recurrence *models.Recurrence) error {
+// Upcoming dates on which the calendar is full are excluded from it, and waitlisted when the
+// calendar queues extra answers; they are returned, in order.
+func (r *RecurrenceRepository) CreateRecurrence(ctx context.Context, calendarID uuid.UUID, recurrence *models.Recurrence) ([]time.Time, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	c, err := lockCapacity(ctx, tx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		INSERT INTO recurrences (id, participant_id, day_of_week, start_time, end_time, note, start_date, end_date, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO recurrences (id, participant_id, frequency, interval_weeks, day_of_week, day_of_month, week_of_month,
+		                         start_time, end_time, note, start_date, end_date, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		recurrence.ID,
 		recurrence.ParticipantID,
+		recurrence.Frequency,
+		recurrence.IntervalWeeks,
 		recurrence.DayOfWeek,
+		recurrence.DayOfMonth,
+		recurrence.WeekOfMonth,
 		recurrence.StartTime,
 		recurrence.EndTime,
 		recurrence.Note,
@@ -42,16 +62,16 @@ func (r *RecurrenceRepository) CreateRecurrence(ctx context.Context, recurrence
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to create recurrence: %w", err)
+		return nil, fmt.Errorf("failed to create recurrence: %w", err)
 	}
 
-	return nil
+	return commitWithinCapacity(ctx, tx, c, calendarID, recurrence)
 }
 
 // GetRecurrenceByID retrieves a recurrence by ID
 func (r *RecurrenceRepository) GetRecurrenceByID(ctx context.Context, id uuid.UUID) (*models.Recurrence, error) {
 	query := `
-		SELECT id, participant_id, day_of_week,
+		SELECT id, participant_id, frequency, interval_weeks, day_of_week, day_of_month, week_of_month,
 		       TO_CHAR(start_time, 'HH24:MI') as start_time,
 		       TO_CHAR(end_time, 'HH24:MI') as end_time,
 		       note,
@@ -68,7 +88,11 @@ func (r *RecurrenceRepository) GetRecurrenceByID(ctx context.Context, id uuid.UU
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&recurrence.ID,
 		&recurrence.ParticipantID,
+		&recurrence.Frequency,
+		&recurrence.IntervalWeeks,
 		&recurrence.DayOfWeek,
+		&recurrence.DayOfMonth,
+		&recurrence.WeekOfMonth,
 		&recurrence.StartTime,
 		&recurrence.EndTime,
 		&recurrence.Note,
@@ -89,7 +113,7 @@ func (r *RecurrenceRepository) GetRecurrenceByID(ctx context.Context, id uuid.UU
 // GetRecurrencesByParticipant retrieves all recurrences for a participant
 func (r *RecurrenceRepository) GetRecurrencesByParticipant(ctx context.Context, participantID uuid.UUID) ([]models.Recurrence, error) {
 	query := `
-		SELECT id, participant_id, day_of_week,
+		SELECT id, participant_id, frequency, interval_weeks, day_of_week, day_of_month, week_of_month,
 		       TO_CHAR(start_time, 'HH24:MI') as start_time,
 		       TO_CHAR(end_time, 'HH24:MI') as end_time,
 		       note,
@@ -114,7 +138,11 @@ func (r *RecurrenceRepository) GetRecurrencesByParticipant(ctx context.Context,
 		err := rows.Scan(
 			&rec.ID,
 			&rec.ParticipantID,
+			&rec.Frequency,
+			&rec.IntervalWeeks,
 			&rec.DayOfWeek,
+			&rec.DayOfMonth,
+			&rec.WeekOfMonth,
 			&rec.StartTime,
 			&rec.EndTime,
 			&rec.Note,
@@ -139,7 +167,7 @@ func (r *RecurrenceRepository) GetRecurrencesByParticipant(ctx context.Context,
 // GetRecurrencesByCalendar retrieves all recurrences for all participants in a calendar
 func (r *RecurrenceRepository) GetRecurrencesByCalendar(ctx context.Context, calendarID uuid.UUID) ([]models.Recurrence, error) {
 	query := `
-		SELECT r.id, r.participant_id, r.day_of_week,
+		SELECT r.id, r.participant_id, r.frequency, r.interval_weeks, r.day_of_week, r.day_of_month, r.week_of_month,
 		       TO_CHAR(r.start_time, 'HH24:MI') as start_time,
 		       TO_CHAR(r.end_time, 'HH24:MI') as end_time,
 		       r.note,
@@ -165,7 +193,11 @@ func (r *RecurrenceRepository) GetRecurrencesByCalendar(ctx context.Context, cal
 		err := rows.Scan(
 			&rec.ID,
 			&rec.ParticipantID,
+			&rec.Frequency,
+			&rec.IntervalWeeks,
 			&rec.DayOfWeek,
+			&rec.DayOfMonth,
+			&rec.WeekOfMonth,
 			&rec.StartTime,
 			&rec.EndTime,
 			&rec.Note,
@@ -188,20 +220,40 @@ func (r *RecurrenceRepository) GetRecurrencesByCalendar(ctx context.Context, cal
 }
 
 // UpdateRecurrence updates an existing recurrence
-func (r *RecurrenceRepository) UpdateRecurrence(ctx context.Context, recurrence *models.Recurrence) error {
+// Like on creation, its upcoming full dates are excluded, waitlisted if need be, and returned
+func (r *RecurrenceRepository) UpdateRecurrence(ctx context.Context, calendarID uuid.UUID, recurrence *models.Recurrence) ([]time.Time, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	c, err := lockCapacity(ctx, tx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		UPDATE recurrences
-		SET day_of_week = $1,
-		    start_time = $2,
-		    end_time = $3,
-		    note = $4,
-		    start_date = $5,
-		    end_date = $6
-		WHERE id = $7
+		SET frequency = $1,
+		    interval_weeks = $2,
+		    day_of_week = $3,
+		    day_of_month = $4,
+		    week_of_month = $5,
+		    start_time = $6,
+		    end_time = $7,
+		    note = $8,
+		    start_date = $9,
+		    end_date = $10
+		WHERE id = $11
 	`
 
-	result, err := r.db.Exec(ctx, query,
+	result, err := tx.Exec(ctx, query,
+		recurrence.Frequency,
+		recurrence.IntervalWeeks,
 		recurrence.DayOfWeek,
+		recurrence.DayOfMonth,
+		recurrence.WeekOfMonth,
 		recurrence.StartTime,
 		recurrence.EndTime,
 		recurrence.Note,
@@ -211,14 +263,30 @@ func (r *RecurrenceRepository) UpdateRecurrence(ctx context.Context, recurrence
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update recurrence: %w", err)
+		return nil, fmt.Errorf("failed to update recurrence: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("recurrence not found")
+		return nil, fmt.Errorf("recurrence not found")
 	}
 
-	return nil
+	return commitWithinCapacity(ctx, tx, c, calendarID, recurrence)
+}
+
+// commitWithinCapacity excludes the full dates of a written recurrence and commits the transaction
+func commitWithinCapacity(ctx context.Context, tx pgx.Tx, c *capacity, calendarID uuid.UUID, recurrence *models.Recurrence) ([]time.Time, error) {
+	full, err := recurrenceFullDates(ctx, tx, c, calendarID, recurrence)
+	if err != nil {
+		return nil, err
+	}
+	if err := skipFullDates(ctx, tx, c, recurrence, full); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit recurrence: %w", err)
+	}
+	return full, nil
 }
 
 // DeleteRecurrence deletes a recurrence
@@ -258,6 +326,44 @@ func (r *RecurrenceRepository) CreateException(ctx context.Context, exception *m
 	return nil
 }
 
+// CreateExceptions creates several exceptions at once, skipping the dates already excluded.
+// It returns the exceptions actually created.
+func (r *RecurrenceRepository) CreateExceptions(ctx context.Context, exceptions []*models.RecurrenceException) ([]models.RecurrenceException, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		INSERT INTO recurrence_exceptions (id, recurrence_id, excluded_date, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (recurrence_id, excluded_date) DO NOTHING
+	`
+
+	created := make([]models.RecurrenceException, 0, len(exceptions))
+	for _, exception := range exceptions {
+		result, err := tx.Exec(ctx, query,
+			exception.ID,
+			exception.RecurrenceID,
+			exception.ExcludedDate,
+			exception.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exception: %w", err)
+		}
+		if result.RowsAffected() > 0 {
+			created = append(created, *exception)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit exceptions: %w", err)
+	}
+
+	return created, nil
+}
+
 // GetExceptionsByRecurrence retrieves all exceptions for a recurrence
 func (r *RecurrenceRepository) GetExceptionsByRecurrence(ctx context.Context, recurrenceID uuid.UUID) ([]models.RecurrenceException, error) {
 	query := `
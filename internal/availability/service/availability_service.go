@@ -8,32 +8,63 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/whento/pkg/cache"
 	"github.com/whento/pkg/datevalidation"
+	pkgModels "github.com/whento/pkg/models"
+	"github.com/whento/pkg/tracing"
 	"github.com/whento/whento/internal/availability/models"
 	"github.com/whento/whento/internal/availability/repository"
+	"github.com/whento/whento/internal/config"
+	notifyModels "github.com/whento/whento/internal/notify/models"
+	webhookModels "github.com/whento/whento/internal/webhooks/models"
 )
 
+// tracerName is the tracer of the spans of the availability module
+const tracerName = "github.com/whento/whento/internal/availability"
+
 var (
-	ErrCalendarNotFound        = errors.New("calendar not found")
-	ErrParticipantNotFound     = errors.New("participant not found")
-	ErrInvalidParticipantID    = errors.New("invalid participant ID")
-	ErrInvalidDate             = errors.New("invalid date format, expected YYYY-MM-DD")
-	ErrInvalidTime             = errors.New("invalid time format, expected HH:MM")
-	ErrInvalidTimeRange        = errors.New("end time must be after start time")
-	ErrTimeOutsideAllowedHours = errors.New("time range does not fit within allowed hours for this day")
-	ErrDurationTooShort        = errors.New("availability duration is less than the minimum required")
-	ErrAvailabilityExists      = errors.New("availability already exists for this date")
-	ErrAvailabilityNotFound    = errors.New("availability not found")
-	ErrRecurrenceNotFound      = errors.New("recurrence not found")
-	ErrRecurrenceOverlap       = errors.New("recurrence overlaps with an existing recurrence on the same day")
-	ErrInvalidDayOfWeek        = errors.New("day_of_week must be between 0 (Sunday) and 6 (Saturday)")
-	ErrWeekdayNotAllowed       = errors.New("this day of the week is not allowed for this calendar")
-	ErrDateInPast              = errors.New("cannot modify availability for past dates")
+	ErrCalendarNotFound         = errors.New("calendar not found")
+	ErrParticipantNotFound      = errors.New("participant not found")
+	ErrInvalidParticipantID     = errors.New("invalid participant ID")
+	ErrInvalidDate              = errors.New("invalid date format, expected YYYY-MM-DD")
+	ErrInvalidTime              = errors.New("invalid time format, expected HH:MM")
+	ErrInvalidTimeRange         = errors.New("end time must be after start time")
+	ErrTimeOutsideAllowedHours  = errors.New("time range does not fit within allowed hours for this day")
+	ErrDurationTooShort         = errors.New("availability duration is less than the minimum required")
+	ErrAvailabilityExists       = errors.New("availability already exists for this date")
+	ErrAvailabilityNotFound     = errors.New("availability not found")
+	ErrRecurrenceNotFound       = errors.New("recurrence not found")
+	ErrRecurrenceOverlap        = errors.New("recurrence overlaps with an existing recurrence on the same day")
+	ErrInvalidDayOfWeek         = errors.New("day_of_week must be between 0 (Sunday) and 6 (Saturday)")
+	ErrInvalidFrequency         = errors.New("frequency must be weekly, monthly_day or monthly_weekday")
+	ErrInvalidIntervalWeeks     = errors.New("interval_weeks must be between 1 and 52")
+	ErrInvalidDayOfMonth        = errors.New("day_of_month must be between 1 and 31")
+	ErrInvalidWeekOfMonth       = errors.New("week_of_month must be between 1 and 5, or -1 for the last week")
+	ErrWeekdayNotAllowed        = errors.New("this day of the week is not allowed for this calendar")
+	ErrDateBlackedOut           = errors.New("this date is blocked by the calendar owner")
+	ErrDateInPast               = errors.New("cannot modify availability for past dates")
+	ErrInvalidTimezone          = errors.New("invalid timezone, expected an IANA timezone name")
+	ErrDuplicateBulkDate        = errors.New("a date appears more than once in the request")
+	ErrPreferredMaybe           = errors.New("a maybe answer cannot be marked as preferred")
+	ErrEmptyComment             = errors.New("comment cannot be empty")
+	ErrCommentTooLong           = errors.New("comment exceeds 500 characters")
+	ErrCommentNotFound          = errors.New("comment not found")
+	ErrParticipantTokenRequired = errors.New("participant routes require the participant's personal link")
+	ErrInvalidDateRange         = errors.New("end_date must be on or after start_date")
+	ErrExceptionRangeTooLong    = errors.New("an exception range cannot exceed one year")
+	ErrDateFull                 = repository.ErrDateFull
+	ErrNotAnEvent               = errors.New("only the events of the calendar can be answered")
+	ErrRSVPNotFound             = errors.New("rsvp not found")
+	ErrInvalidBusyFeed          = errors.New("invalid feed URL, expected an http(s) or webcal URL")
+	ErrBusyFeedExists           = errors.New("this feed is already attached")
+	ErrBusyFeedNotFound         = errors.New("busy feed not found")
+	ErrTooManyBusyFeeds         = errors.New("too many busy feeds for this participant")
 )
 
 // AvailabilityRepository defines the interface for availability repository operations
@@ -44,39 +75,73 @@ type AvailabilityRepository interface {
 	GetByParticipantAndDate(ctx context.Context, participantID uuid.UUID, date time.Time) (*models.Availability, error)
 	GetByDate(ctx context.Context, calendarID uuid.UUID, date time.Time) ([]*models.Availability, error)
 	GetByCalendarDateRange(ctx context.Context, calendarID uuid.UUID, startDate, endDate time.Time) ([]*models.Availability, error)
-	GetParticipantCountForDate(ctx context.Context, calendarID uuid.UUID, date time.Time) (int, error)
+	GetParticipantCountForDate(ctx context.Context, calendarID uuid.UUID, date time.Time) (models.DateCount, error)
 	Update(ctx context.Context, availability *models.Availability) error
 	Delete(ctx context.Context, participantID uuid.UUID, date time.Time) error
+	CreateWithinCapacity(ctx context.Context, calendarID uuid.UUID, availability *models.Availability) (bool, error)
+	UpdateWithinCapacity(ctx context.Context, calendarID uuid.UUID, availability *models.Availability) error
+	ApplyBulk(ctx context.Context, calendarID, participantID uuid.UUID, availabilities []*models.Availability, deletes []time.Time) (*repository.BulkResult, error)
+	AddToWaitlist(ctx context.Context, availability *models.Availability) error
+	RemoveFromWaitlist(ctx context.Context, participantID uuid.UUID, date time.Time) error
+	GetWaitlistByParticipant(ctx context.Context, participantID uuid.UUID, startDate, endDate *time.Time) ([]*models.Availability, error)
+	CountWaitlist(ctx context.Context, calendarID uuid.UUID, date time.Time) (int, error)
+	PromoteFromWaitlist(ctx context.Context, calendarID uuid.UUID, date time.Time) (*models.Availability, error)
+	CreateComment(ctx context.Context, calendarID, participantID uuid.UUID, date time.Time, body string) (*models.DateComment, error)
+	GetCommentsByDate(ctx context.Context, calendarID uuid.UUID, date time.Time) ([]models.DateComment, error)
+	DeleteComment(ctx context.Context, participantID, commentID uuid.UUID) error
+	SetRSVP(ctx context.Context, calendarID, participantID uuid.UUID, date time.Time, status string) (*models.RSVP, error)
+	DeleteRSVP(ctx context.Context, participantID uuid.UUID, date time.Time) error
+	GetRSVPsByDate(ctx context.Context, calendarID uuid.UUID, date time.Time) ([]models.RSVP, error)
+	CountRSVPsByDateRange(ctx context.Context, calendarID uuid.UUID, startDate, endDate time.Time) (map[string]models.RSVPCount, error)
+	CreateBusyFeed(ctx context.Context, participantID uuid.UUID, url string) (*models.BusyFeed, error)
+	GetBusyFeed(ctx context.Context, participantID, feedID uuid.UUID) (*models.BusyFeed, error)
+	ListBusyFeeds(ctx context.Context, participantID uuid.UUID) ([]models.BusyFeed, error)
+	DeleteBusyFeed(ctx context.Context, participantID, feedID uuid.UUID) error
+	GetBusyPeriods(ctx context.Context, participantID uuid.UUID, from, to *time.Time) ([]models.BusyPeriod, error)
 }
 
 // CalendarRepository defines the interface for calendar repository operations
 type CalendarRepository interface {
 	GetByPublicToken(ctx context.Context, token string) (uuid.UUID, error)
 	GetCalendarInfoByPublicToken(ctx context.Context, token string) (*repository.Calendar, error)
+	GetConfirmationStatus(ctx context.Context, calendarID uuid.UUID, date time.Time) (string, error)
 }
 
 // ParticipantRepository defines the interface for participant repository operations
 type ParticipantRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*repository.Participant, error)
 	GetByCalendarID(ctx context.Context, calendarID uuid.UUID) ([]*repository.Participant, error)
+	GetByAccessToken(ctx context.Context, calendarID uuid.UUID, token string) (*repository.Participant, error)
 }
 
 // RecurrenceRepository defines the interface for recurrence repository operations
 type RecurrenceRepository interface {
-	CreateRecurrence(ctx context.Context, recurrence *models.Recurrence) error
+	CreateRecurrence(ctx context.Context, calendarID uuid.UUID, recurrence *models.Recurrence) ([]time.Time, error)
 	GetRecurrencesByParticipant(ctx context.Context, participantID uuid.UUID) ([]models.Recurrence, error)
 	GetRecurrencesByCalendar(ctx context.Context, calendarID uuid.UUID) ([]models.Recurrence, error)
 	GetRecurrenceByID(ctx context.Context, id uuid.UUID) (*models.Recurrence, error)
-	UpdateRecurrence(ctx context.Context, recurrence *models.Recurrence) error
+	UpdateRecurrence(ctx context.Context, calendarID uuid.UUID, recurrence *models.Recurrence) ([]time.Time, error)
 	DeleteRecurrence(ctx context.Context, id uuid.UUID) error
 	CreateException(ctx context.Context, exception *models.RecurrenceException) error
+	CreateExceptions(ctx context.Context, exceptions []*models.RecurrenceException) ([]models.RecurrenceException, error)
 	GetExceptionsByRecurrence(ctx context.Context, recurrenceID uuid.UUID) ([]models.RecurrenceException, error)
 	DeleteException(ctx context.Context, recurrenceID uuid.UUID, excludedDate string) error
 }
 
 // NotifyService defines the interface for notification service operations
 type NotifyService interface {
-	CheckThresholdAndNotify(ctx context.Context, calendarID uuid.UUID, date time.Time, previousCount int) error
+	CheckThresholdAndNotify(ctx context.Context, calendarID uuid.UUID, date time.Time, previousCount models.DateCount) error
+	NotifyActivity(ctx context.Context, calendarID uuid.UUID, date time.Time, activity notifyModels.Activity) error
+}
+
+// WebhookDispatcher queues calendar events for the outbound webhooks of a calendar
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, calendarID uuid.UUID, event string, data any)
+}
+
+// BusyFeedSyncer pulls the busy time of the ICS feed of a participant, recording failures on the feed
+type BusyFeedSyncer interface {
+	SyncFeed(ctx context.Context, feedID uuid.UUID) error
 }
 
 // AvailabilityService handles availability business logic
@@ -86,7 +151,10 @@ type AvailabilityService struct {
 	participantRepo  ParticipantRepository
 	recurrenceRepo   RecurrenceRepository
 	notifyService    NotifyService
+	webhooks         WebhookDispatcher // nil = no webhooks
+	busyFeeds        BusyFeedSyncer    // nil = feeds only synced periodically
 	cache            cache.Cache
+	cfg              *config.Config
 }
 
 // NewAvailabilityService creates a new availability service
@@ -96,7 +164,10 @@ func NewAvailabilityService(
 	participantRepo ParticipantRepository,
 	recurrenceRepo RecurrenceRepository,
 	notifyService NotifyService,
+	webhooks WebhookDispatcher,
+	busyFeeds BusyFeedSyncer,
 	c cache.Cache,
+	cfg *config.Config,
 ) *AvailabilityService {
 	return &AvailabilityService{
 		availabilityRepo: availabilityRepo,
@@ -104,7 +175,10 @@ func NewAvailabilityService(
 		participantRepo:  participantRepo,
 		recurrenceRepo:   recurrenceRepo,
 		notifyService:    notifyService,
+		webhooks:         webhooks,
+		busyFeeds:        busyFeeds,
 		cache:            c,
+		cfg:              cfg,
 	}
 }
 
@@ -139,44 +213,112 @@ func (s *AvailabilityService) CreateAvailability(ctx context.Context, token, par
 		return nil, ErrParticipantNotFound
 	}
 
+	date, startTime, endTime, err := validateAvailability(calendarInfo, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get participant count BEFORE creating availability (for threshold detection)
+	previousCount, err := s.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
+	if err != nil {
+		// Log error but continue - notification just won't have accurate previous count
+		previousCount = models.DateCount{Count: -1}
+	}
+
+	// Create availability
+	availability := &models.Availability{
+		ParticipantID: partID,
+		Date:          date,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		Note:          req.Note,
+		Source:        "manual",
+		RecurrenceID:  nil,
+		Status:        availabilityStatus(req.Status),
+		Preferred:     req.Preferred,
+	}
+	availability.ID = uuid.New()
+
+	// A full date rejects the availability, or queues it until a spot frees up
+	waitlisted, err := s.availabilityRepo.CreateWithinCapacity(ctx, calendarID, availability)
+	if err != nil {
+		if isDuplicateError(err) {
+			return nil, ErrAvailabilityExists
+		}
+		return nil, err
+	}
+	if waitlisted {
+		response := toAvailabilityResponse(availability, participant.Name, participant.Email, participant.EmailVerified)
+		response.Waitlisted = true
+		response.Conflict = s.availabilityConflict(ctx, calendarInfo, availability)
+		return response, nil
+	}
+
+	// Trigger notification check (fire-and-forget, don't block availability operation)
+	go func() {
+		notifyCtx := context.Background()
+		s.dispatchAvailability(notifyCtx, calendarID, webhookModels.EventAvailabilityCreated, participant, availability)
+		if err := s.notifyService.CheckThresholdAndNotify(notifyCtx, calendarID, date, previousCount); err != nil {
+			// Log only, don't fail the availability operation
+		}
+		s.notifyActivity(notifyCtx, calendarID, date, participant, notifyModels.ActivityAdded)
+	}()
+
+	response := toAvailabilityResponse(availability, participant.Name, participant.Email, participant.EmailVerified)
+	response.Conflict = s.availabilityConflict(ctx, calendarInfo, availability)
+	return response, nil
+}
+
+// validateAvailability checks the date and time range of an availability against the calendar settings
+// Times are normalized and adjusted to the allowed hours of the date
+func validateAvailability(calendarInfo *repository.Calendar, req *models.CreateAvailabilityRequest) (time.Time, *string, *string, error) {
 	// Parse date
 	date, err := parseDate(req.Date)
 	if err != nil {
-		return nil, ErrInvalidDate
+		return time.Time{}, nil, nil, ErrInvalidDate
+	}
+
+	if req.Preferred && req.Status == models.StatusMaybe {
+		return time.Time{}, nil, nil, ErrPreferredMaybe
 	}
 
 	// Check if date is in the past
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	if date.Before(today) {
-		return nil, ErrDateInPast
+		return time.Time{}, nil, nil, ErrDateInPast
 	}
 
 	// Validate that the date is within calendar's date range if set
 	if calendarInfo.StartDate != nil && date.Before(*calendarInfo.StartDate) {
-		return nil, fmt.Errorf("date is before calendar start date (%s)", calendarInfo.StartDate.Format("2006-01-02"))
+		return time.Time{}, nil, nil, fmt.Errorf("date is before calendar start date (%s)", calendarInfo.StartDate.Format("2006-01-02"))
 	}
 	if calendarInfo.EndDate != nil && date.After(*calendarInfo.EndDate) {
-		return nil, fmt.Errorf("date is after calendar end date (%s)", calendarInfo.EndDate.Format("2006-01-02"))
+		return time.Time{}, nil, nil, fmt.Errorf("date is after calendar end date (%s)", calendarInfo.EndDate.Format("2006-01-02"))
 	}
 
 	// Validate that the date is allowed for this calendar
 	// This checks weekday, holidays policy, and holiday eves
-	if !datevalidation.IsDateAllowed(date, calendarInfo.Timezone, calendarInfo.AllowedWeekdays, calendarInfo.HolidaysPolicy, calendarInfo.AllowHolidayEves) {
-		return nil, ErrWeekdayNotAllowed
+	if !datevalidation.IsDateAllowed(date, holidayCode(calendarInfo), calendarInfo.AllowedWeekdays, calendarInfo.HolidaysPolicy, calendarInfo.AllowHolidayEves, calendarInfo.HolidaySets, holidayProviders(calendarInfo)...) {
+		return time.Time{}, nil, nil, ErrWeekdayNotAllowed
+	}
+
+	// Blackout dates are blocked whatever the weekday and holiday rules
+	if datevalidation.IsBlackedOut(date, calendarInfo.BlackoutDates) {
+		return time.Time{}, nil, nil, ErrDateBlackedOut
 	}
 
 	// Parse and validate times if provided
 	var startTime, endTime *string
 	if req.StartTime != nil && *req.StartTime != "" {
 		if !isValidTime(*req.StartTime) {
-			return nil, ErrInvalidTime
+			return time.Time{}, nil, nil, ErrInvalidTime
 		}
 		startTime = req.StartTime
 	}
 	if req.EndTime != nil && *req.EndTime != "" {
 		if !isValidTime(*req.EndTime) {
-			return nil, ErrInvalidTime
+			return time.Time{}, nil, nil, ErrInvalidTime
 		}
 		endTime = req.EndTime
 	}
@@ -190,65 +332,32 @@ func (s *AvailabilityService) CreateAvailability(ctx context.Context, token, par
 	// Validate time range if both provided
 	if startTime != nil && endTime != nil {
 		if !isValidTimeRange(*startTime, *endTime) {
-			return nil, ErrInvalidTimeRange
+			return time.Time{}, nil, nil, ErrInvalidTimeRange
 		}
 
 		// Validate duration against calendar's min_duration_hours
 		if calendarInfo.MinDurationHours > 0 {
 			duration := calculateDuration(*startTime, *endTime)
 			if duration < float64(calendarInfo.MinDurationHours) {
-				return nil, ErrDurationTooShort
+				return time.Time{}, nil, nil, ErrDurationTooShort
 			}
 		}
 	}
 
-	// Get participant count BEFORE creating availability (for threshold detection)
-	previousCount, err := s.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
-	if err != nil {
-		// Log error but continue - notification just won't have accurate previous count
-		previousCount = -1
-	}
-
-	// Create availability
-	availability := &models.Availability{
-		ParticipantID: partID,
-		Date:          date,
-		StartTime:     startTime,
-		EndTime:       endTime,
-		Note:          req.Note,
-		Source:        "manual",
-		RecurrenceID:  nil,
-	}
-	availability.ID = uuid.New()
-
-	if err := s.availabilityRepo.Create(ctx, availability); err != nil {
-		if isDuplicateError(err) {
-			return nil, ErrAvailabilityExists
-		}
-		return nil, err
-	}
-
-	// Trigger notification check (fire-and-forget, don't block availability operation)
-	go func() {
-		notifyCtx := context.Background()
-		if err := s.notifyService.CheckThresholdAndNotify(notifyCtx, calendarID, date, previousCount); err != nil {
-			// Log only, don't fail the availability operation
-		}
-	}()
-
-	return toAvailabilityResponse(availability, participant.Name, participant.Email, participant.EmailVerified), nil
+	return date, startTime, endTime, nil
 }
 
 // GetParticipantAvailabilities retrieves all availabilities for a participant
 func (s *AvailabilityService) GetParticipantAvailabilities(ctx context.Context, token, participantID, startDateStr, endDateStr string) (*models.ParticipantAvailabilitiesResponse, error) {
 	// Validate calendar token
-	calendarID, err := s.calendarRepo.GetByPublicToken(ctx, token)
+	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
 	if err != nil {
 		if errors.Is(err, repository.ErrCalendarNotFound) {
 			return nil, ErrCalendarNotFound
 		}
 		return nil, err
 	}
+	calendarID := calendarInfo.ID
 
 	// Parse participant ID
 	partID, err := uuid.Parse(participantID)
@@ -301,11 +410,47 @@ func (s *AvailabilityService) GetParticipantAvailabilities(ctx context.Context,
 			StartTime: avail.StartTime,
 			EndTime:   avail.EndTime,
 			Note:      avail.Note,
+			Status:    avail.Status,
+			Preferred: avail.Preferred,
 			CreatedAt: avail.CreatedAt,
 			UpdatedAt: avail.UpdatedAt,
 		}
 	}
 
+	// Availabilities waiting for a spot on full dates
+	waitlisted, err := s.availabilityRepo.GetWaitlistByParticipant(ctx, partID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, avail := range waitlisted {
+		items = append(items, models.AvailabilityItem{
+			ID:         avail.ID,
+			Date:       formatDate(avail.Date),
+			StartTime:  avail.StartTime,
+			EndTime:    avail.EndTime,
+			Note:       avail.Note,
+			Status:     avail.Status,
+			Preferred:  avail.Preferred,
+			Waitlisted: true,
+			CreatedAt:  avail.CreatedAt,
+			UpdatedAt:  avail.UpdatedAt,
+		})
+	}
+
+	// Busy time of the participant's feeds over the requested range, flagging conflicting availabilities
+	loc := calendarLocation(calendarInfo)
+	var busyFrom, busyTo *time.Time
+	if startDate != nil {
+		from, _ := availabilityWindow(*startDate, nil, nil, loc)
+		busyFrom = &from
+	}
+	if endDate != nil {
+		_, to := availabilityWindow(*endDate, nil, nil, loc)
+		busyTo = &to
+	}
+	busy := s.busyPeriods(ctx, partID, busyFrom, busyTo)
+	markConflicts(items, busy, loc)
+
 	return &models.ParticipantAvailabilitiesResponse{
 		Participant: models.ParticipantInfo{
 			ID:            participant.ID,
@@ -314,6 +459,7 @@ func (s *AvailabilityService) GetParticipantAvailabilities(ctx context.Context,
 			EmailVerified: participant.EmailVerified,
 		},
 		Availabilities: items,
+		Busy:           busy,
 	}, nil
 }
 
@@ -418,40 +564,61 @@ func (s *AvailabilityService) UpdateAvailability(ctx context.Context, token, par
 	if req.Note != nil {
 		availability.Note = *req.Note
 	}
+	if req.Status != nil {
+		availability.Status = availabilityStatus(*req.Status)
+	}
+	if req.Preferred != nil {
+		availability.Preferred = *req.Preferred
+	}
+	if availability.Status == models.StatusMaybe && availability.Preferred {
+		// Switching to maybe drops the preference, unless explicitly asked for both
+		if req.Preferred != nil {
+			return nil, ErrPreferredMaybe
+		}
+		availability.Preferred = false
+	}
 
 	// Get participant count (for threshold detection - count doesn't change on update)
 	currentCount, err := s.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
 	if err != nil {
-		currentCount = -1
+		currentCount = models.DateCount{Count: -1}
 	}
 
-	// Update in database
-	if err := s.availabilityRepo.Update(ctx, availability); err != nil {
+	// A maybe turning into a counted answer needs a free spot; it cannot be queued without losing the answer
+	if err := s.availabilityRepo.UpdateWithinCapacity(ctx, calendarID, availability); err != nil {
 		return nil, err
 	}
+	promoted := s.promoteWaitlist(ctx, calendarInfo, date)
 
 	// Trigger notification check (fire-and-forget)
 	// Note: Update doesn't change participant count, but we still check in case threshold config changed
 	go func() {
 		notifyCtx := context.Background()
+		s.dispatchAvailability(notifyCtx, calendarID, webhookModels.EventAvailabilityUpdated, participant, availability)
+		s.dispatchPromoted(notifyCtx, calendarID, promoted)
 		if err := s.notifyService.CheckThresholdAndNotify(notifyCtx, calendarID, date, currentCount); err != nil {
 			// Log only, don't fail the availability operation
 		}
+		s.notifyActivity(notifyCtx, calendarID, date, participant, notifyModels.ActivityChanged)
 	}()
 
-	return toAvailabilityResponse(availability, participant.Name, participant.Email, participant.EmailVerified), nil
+	response := toAvailabilityResponse(availability, participant.Name, participant.Email, participant.EmailVerified)
+	response.Conflict = s.availabilityConflict(ctx, calendarInfo, availability)
+	return response, nil
 }
 
-// DeleteAvailability deletes an availability
+// DeleteAvailability deletes an availability, or leaves the waitlist of a full date
+// The spot freed on a full date goes to the oldest waitlisted availability
 func (s *AvailabilityService) DeleteAvailability(ctx context.Context, token, participantID, dateStr string) error {
 	// Validate calendar token
-	calendarID, err := s.calendarRepo.GetByPublicToken(ctx, token)
+	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
 	if err != nil {
 		if errors.Is(err, repository.ErrCalendarNotFound) {
 			return ErrCalendarNotFound
 		}
 		return err
 	}
+	calendarID := calendarInfo.ID
 
 	// Parse participant ID
 	partID, err := uuid.Parse(participantID)
@@ -489,30 +656,246 @@ func (s *AvailabilityService) DeleteAvailability(ctx context.Context, token, par
 	previousCount, err := s.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
 	if err != nil {
 		// Log error but continue - notification just won't have accurate previous count
-		previousCount = -1
+		previousCount = models.DateCount{Count: -1}
 	}
 
 	// Delete availability
 	if err := s.availabilityRepo.Delete(ctx, partID, date); err != nil {
-		if errors.Is(err, repository.ErrAvailabilityNotFound) {
-			return ErrAvailabilityNotFound
+		if !errors.Is(err, repository.ErrAvailabilityNotFound) {
+			return err
 		}
-		return err
+		// Not counted yet, the participant may be waiting for a spot
+		if err := s.availabilityRepo.RemoveFromWaitlist(ctx, partID, date); err != nil {
+			if errors.Is(err, repository.ErrAvailabilityNotFound) {
+				return ErrAvailabilityNotFound
+			}
+			return err
+		}
+		return nil
 	}
+	promoted := s.promoteWaitlist(ctx, calendarInfo, date)
 
 	// Trigger notification check (fire-and-forget)
 	go func() {
 		notifyCtx := context.Background()
+		s.dispatchAvailability(notifyCtx, calendarID, webhookModels.EventAvailabilityDeleted, participant, &models.Availability{Date: date})
+		s.dispatchPromoted(notifyCtx, calendarID, promoted)
 		if err := s.notifyService.CheckThresholdAndNotify(notifyCtx, calendarID, date, previousCount); err != nil {
 			// Log only, don't fail the availability operation
 		}
+		s.notifyActivity(notifyCtx, calendarID, date, participant, notifyModels.ActivityRemoved)
 	}()
 
 	return nil
 }
 
+// BulkAvailability creates, replaces and deletes availabilities of a participant in a single transaction
+// Every entry is validated first, so an invalid one leaves the availabilities untouched
+func (s *AvailabilityService) BulkAvailability(ctx context.Context, token, participantID string, req *models.BulkAvailabilityRequest) (*models.BulkAvailabilityResponse, error) {
+	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+	calendarID := calendarInfo.ID
+
+	partID, err := uuid.Parse(participantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid participant id: %w", err)
+	}
+
+	participant, err := s.participantRepo.GetByID(ctx, partID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, ErrParticipantNotFound
+		}
+		return nil, err
+	}
+	if participant.CalendarID != calendarID {
+		return nil, ErrParticipantNotFound
+	}
+
+	// Fetch the regional holidays of the dates once, not per entry
+	prefetchHolidays(calendarInfo, req.Availabilities)
+
+	// Validate all entries before touching the database
+	seen := make(map[string]bool, len(req.Availabilities)+len(req.Delete))
+	upserts := make([]*models.Availability, 0, len(req.Availabilities))
+	for i := range req.Availabilities {
+		date, startTime, endTime, err := validateAvailability(calendarInfo, &req.Availabilities[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", req.Availabilities[i].Date, err)
+		}
+		if seen[formatDate(date)] {
+			return nil, fmt.Errorf("%s: %w", formatDate(date), ErrDuplicateBulkDate)
+		}
+		seen[formatDate(date)] = true
+
+		availability := &models.Availability{
+			ParticipantID: partID,
+			Date:          date,
+			StartTime:     startTime,
+			EndTime:       endTime,
+			Note:          req.Availabilities[i].Note,
+			Source:        "manual",
+			Status:        availabilityStatus(req.Availabilities[i].Status),
+			Preferred:     req.Availabilities[i].Preferred,
+		}
+		availability.ID = uuid.New()
+		upserts = append(upserts, availability)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	deletes := make([]time.Time, 0, len(req.Delete))
+	for _, dateStr := range req.Delete {
+		date, err := parseDate(dateStr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", dateStr, ErrInvalidDate)
+		}
+		if date.Before(today) {
+			return nil, fmt.Errorf("%s: %w", dateStr, ErrDateInPast)
+		}
+		if seen[formatDate(date)] {
+			return nil, fmt.Errorf("%s: %w", formatDate(date), ErrDuplicateBulkDate)
+		}
+		seen[formatDate(date)] = true
+		deletes = append(deletes, date)
+	}
+
+	// Participant counts BEFORE the changes (for threshold detection)
+	previousCounts := make(map[time.Time]models.DateCount, len(seen))
+	for _, date := range append(availabilityDates(upserts), deletes...) {
+		count, err := s.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
+		if err != nil {
+			count = models.DateCount{Count: -1}
+		}
+		previousCounts[date] = count
+	}
+
+	// Availabilities exceeding the capacity of a full date are rejected, or waitlisted
+	result, err := s.availabilityRepo.ApplyBulk(ctx, calendarID, partID, upserts, deletes)
+	if err != nil {
+		return nil, err
+	}
+	upserts, created, waitlisted, deleted := result.Upserted, result.Created, result.Waitlisted, result.Deleted
+
+	// Spots freed by deletions or maybe answers go to the waitlists
+	var promoted []*models.Availability
+	for date := range previousCounts {
+		promoted = append(promoted, s.promoteWaitlist(ctx, calendarInfo, date)...)
+	}
+
+	// Trigger notification checks (fire-and-forget), once per changed date
+	go func() {
+		notifyCtx := context.Background()
+		for i, availability := range upserts {
+			event := webhookModels.EventAvailabilityUpdated
+			if created[i] {
+				event = webhookModels.EventAvailabilityCreated
+			}
+			s.dispatchAvailability(notifyCtx, calendarID, event, participant, availability)
+		}
+		for _, date := range deleted {
+			s.dispatchAvailability(notifyCtx, calendarID, webhookModels.EventAvailabilityDeleted, participant, &models.Availability{Date: date})
+		}
+		s.dispatchPromoted(notifyCtx, calendarID, promoted)
+		for date, previousCount := range previousCounts {
+			if err := s.notifyService.CheckThresholdAndNotify(notifyCtx, calendarID, date, previousCount); err != nil {
+				// Log only, don't fail the availability operation
+			}
+		}
+		// Activity notifications are debounced per calendar: the first change stands for the whole update
+		switch {
+		case len(upserts) > 0:
+			action := notifyModels.ActivityChanged
+			if created[0] {
+				action = notifyModels.ActivityAdded
+			}
+			s.notifyActivity(notifyCtx, calendarID, upserts[0].Date, participant, action)
+		case len(deleted) > 0:
+			s.notifyActivity(notifyCtx, calendarID, deleted[0], participant, notifyModels.ActivityRemoved)
+		}
+	}()
+
+	response := &models.BulkAvailabilityResponse{
+		Availabilities: make([]models.AvailabilityItem, 0, len(upserts)+len(waitlisted)),
+		Deleted:        make([]string, 0, len(deleted)),
+	}
+	for _, availability := range upserts {
+		response.Availabilities = append(response.Availabilities, models.AvailabilityItem{
+			ID:        availability.ID,
+			Date:      formatDate(availability.Date),
+			StartTime: availability.StartTime,
+			EndTime:   availability.EndTime,
+			Note:      availability.Note,
+			Status:    availability.Status,
+			Preferred: availability.Preferred,
+			CreatedAt: availability.CreatedAt,
+			UpdatedAt: availability.UpdatedAt,
+		})
+	}
+	for _, availability := range waitlisted {
+		response.Availabilities = append(response.Availabilities, models.AvailabilityItem{
+			ID:         availability.ID,
+			Date:       formatDate(availability.Date),
+			StartTime:  availability.StartTime,
+			EndTime:    availability.EndTime,
+			Note:       availability.Note,
+			Status:     availability.Status,
+			Preferred:  availability.Preferred,
+			Waitlisted: true,
+			CreatedAt:  availability.CreatedAt,
+			UpdatedAt:  availability.UpdatedAt,
+		})
+	}
+	for _, date := range deleted {
+		response.Deleted = append(response.Deleted, formatDate(date))
+	}
+	markConflicts(response.Availabilities, s.busyPeriods(ctx, participant.ID, nil, nil), calendarLocation(calendarInfo))
+	return response, nil
+}
+
+// availabilityDates returns the dates of the availabilities
+func availabilityDates(availabilities []*models.Availability) []time.Time {
+	dates := make([]time.Time, len(availabilities))
+	for i, availability := range availabilities {
+		dates[i] = availability.Date
+	}
+	return dates
+}
+
+// notifyActivity notifies the owner of a change of a participant, when they enabled activity notifications
+func (s *AvailabilityService) notifyActivity(ctx context.Context, calendarID uuid.UUID, date time.Time, participant *repository.Participant, action string) {
+	// Errors are logged by the notify service, they don't fail the availability operation
+	_ = s.notifyService.NotifyActivity(ctx, calendarID, date, notifyModels.Activity{ParticipantName: participant.Name, Action: action})
+}
+
+// dispatchAvailability queues an availability event for the webhooks of the calendar
+func (s *AvailabilityService) dispatchAvailability(ctx context.Context, calendarID uuid.UUID, event string, participant *repository.Participant, availability *models.Availability) {
+	if s.webhooks == nil {
+		return
+	}
+	s.webhooks.Dispatch(ctx, calendarID, event, webhookModels.AvailabilityEventData{
+		ParticipantID:   participant.ID,
+		ParticipantName: participant.Name,
+		Date:            formatDate(availability.Date),
+		StartTime:       availability.StartTime,
+		EndTime:         availability.EndTime,
+		Note:            availability.Note,
+		Status:          availability.Status,
+		Preferred:       availability.Preferred,
+	})
+}
+
 // GetDateSummary gets all participants available on a specific date
-func (s *AvailabilityService) GetDateSummary(ctx context.Context, token, dateStr string) (*models.DateAvailabilitySummary, error) {
+// If timezone is set, times are converted from the calendar timezone into it
+func (s *AvailabilityService) GetDateSummary(ctx context.Context, token, dateStr, timezone string) (_ *models.DateAvailabilitySummary, err error) {
+	ctx, span := tracing.Start(ctx, tracerName, "AvailabilityService.GetDateSummary", attribute.String("whento.date", dateStr))
+	defer func() { tracing.End(span, err) }()
+
 	// Validate calendar token and get calendar info (including min_duration_hours)
 	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
 	if err != nil {
@@ -522,6 +905,13 @@ func (s *AvailabilityService) GetDateSummary(ctx context.Context, token, dateStr
 		return nil, err
 	}
 	calendarID := calendarInfo.ID
+	span.SetAttributes(attribute.String("whento.calendar_id", calendarID.String()))
+
+	// Resolve timezone conversion (nil locations when not requested)
+	fromLoc, toLoc, err := resolveConversion(calendarInfo.Timezone, timezone)
+	if err != nil {
+		return nil, err
+	}
 
 	// Parse date
 	date, err := parseDate(dateStr)
@@ -529,6 +919,30 @@ func (s *AvailabilityService) GetDateSummary(ctx context.Context, token, dateStr
 		return nil, ErrInvalidDate
 	}
 
+	// Nobody counts on a blackout date, whatever was answered before it was blocked
+	if datevalidation.IsBlackedOut(date, calendarInfo.BlackoutDates) {
+		participants, err := s.participantRepo.GetByCalendarID(ctx, calendarID)
+		if err != nil {
+			return nil, err
+		}
+		comments, err := s.availabilityRepo.GetCommentsByDate(ctx, calendarID, date)
+		if err != nil {
+			return nil, err
+		}
+		return &models.DateAvailabilitySummary{
+			Date:            dateStr,
+			RequiredMissing: countRequired(participants),
+			Blackout:        true,
+			Participants:    []models.ParticipantAvailabilitySummary{},
+			Comments:        comments,
+		}, nil
+	}
+
+	// Spots added since the waitlist was last processed, by a raised or removed limit, go to the waiting participants
+	if promoted := s.promoteWaitlist(ctx, calendarInfo, date); len(promoted) > 0 {
+		go s.dispatchPromoted(context.Background(), calendarID, promoted)
+	}
+
 	// Get all availabilities for this date
 	availabilities, err := s.availabilityRepo.GetByDate(ctx, calendarID, date)
 	if err != nil {
@@ -579,26 +993,17 @@ func (s *AvailabilityService) GetDateSummary(ctx context.Context, token, dateStr
 				StartTime:       avail.StartTime,
 				EndTime:         avail.EndTime,
 				Note:            avail.Note,
+				Status:          avail.Status,
+				Preferred:       avail.Preferred,
+				Required:        participant.Required,
 			})
 		}
 	}
 
 	// Add recurrence-based availabilities
-	dayOfWeek := int(date.Weekday())
 	for _, rec := range recurrences {
-		// Skip if day of week doesn't match
-		if rec.DayOfWeek != dayOfWeek {
-			continue
-		}
-
-		// Compare dates as strings to avoid timezone issues
-		// Skip if date is before recurrence start
-		if dateStr < rec.StartDate {
-			continue
-		}
-
-		// Skip if date is after recurrence end (if end date is set)
-		if rec.EndDate != nil && dateStr > *rec.EndDate {
+		// Skip if the recurrence doesn't apply to this date (frequency, start and end dates)
+		if !rec.OccursOn(date) {
 			continue
 		}
 
@@ -614,10 +1019,11 @@ func (s *AvailabilityService) GetDateSummary(ctx context.Context, token, dateStr
 			continue
 		}
 
-		// Skip if there's already an explicit availability for this participant
+		// Skip if there's already an explicit availability for this participant, or one of another recurrence
 		if explicitParticipants[rec.ParticipantID] {
 			continue
 		}
+		explicitParticipants[rec.ParticipantID] = true
 
 		// Add this participant to the summary
 		if participant, ok := participantMap[rec.ParticipantID]; ok {
@@ -627,27 +1033,73 @@ func (s *AvailabilityService) GetDateSummary(ctx context.Context, token, dateStr
 				StartTime:       rec.StartTime,
 				EndTime:         rec.EndTime,
 				Note:            rec.Note,
+				Status:          models.StatusYes,
+				Required:        participant.Required,
 			})
 		}
 	}
 
+	comments, err := s.availabilityRepo.GetCommentsByDate(ctx, calendarID, date)
+	if err != nil {
+		return nil, err
+	}
+	rsvps, err := s.availabilityRepo.GetRSVPsByDate(ctx, calendarID, date)
+	if err != nil {
+		return nil, err
+	}
+	rsvpCount := countRSVPs(rsvps)
+
 	// Apply min_duration_hours filter if configured
 	if calendarInfo.MinDurationHours > 0 && len(participantSummaries) > 0 {
 		duration := calculateDurationForDate(participantSummaries)
 		if duration < float64(calendarInfo.MinDurationHours) {
 			// Return empty summary if duration is less than minimum
 			return &models.DateAvailabilitySummary{
-				Date:         dateStr,
-				TotalCount:   0,
-				Participants: []models.ParticipantAvailabilitySummary{},
+				Date:            dateStr,
+				TotalCount:      0,
+				RequiredMissing: countRequired(participants),
+				GoingCount:      rsvpCount.Going,
+				NotGoingCount:   rsvpCount.NotGoing,
+				Participants:    []models.ParticipantAvailabilitySummary{},
+				Comments:        comments,
+				RSVPs:           rsvps,
 			}, nil
 		}
 	}
 
+	// Count on calendar-local times, then convert for display
+	totalCount, maybeCount := countParticipants(participantSummaries, calendarInfo.CountMaybe)
+	preferredCount, score := scoreParticipants(participantSummaries, calendarInfo.CountMaybe)
+	count := models.DateCount{
+		Count:           totalCount,
+		RequiredMissing: countRequired(participants) - countRequiredAvailable(participantSummaries, calendarInfo.CountMaybe),
+	}
+	convertSummaryTimes(dateStr, participantSummaries, fromLoc, toLoc)
+
+	waitlistCount := 0
+	if calendarInfo.MaxParticipants != nil {
+		if waitlistCount, err = s.availabilityRepo.CountWaitlist(ctx, calendarID, date); err != nil {
+			return nil, err
+		}
+	}
+
 	return &models.DateAvailabilitySummary{
-		Date:         dateStr,
-		TotalCount:   calculateMaxSimultaneousParticipants(participantSummaries),
-		Participants: participantSummaries,
+		Date:             dateStr,
+		Timezone:         locationName(toLoc),
+		TotalCount:       totalCount,
+		MaybeCount:       maybeCount,
+		PreferredCount:   preferredCount,
+		Score:            score,
+		RequiredMissing:  count.RequiredMissing,
+		ThresholdReached: count.Reaches(calendarInfo.Threshold),
+		Capacity:         calendarInfo.MaxParticipants,
+		Remaining:        remainingCapacity(calendarInfo.MaxParticipants, totalCount),
+		WaitlistCount:    waitlistCount,
+		GoingCount:       rsvpCount.Going,
+		NotGoingCount:    rsvpCount.NotGoing,
+		Participants:     participantSummaries,
+		Comments:         comments,
+		RSVPs:            rsvps,
 	}, nil
 }
 
@@ -672,7 +1124,12 @@ func filterParticipantSummaries(lockParticipants bool, participantID string, sum
 				ParticipantName: summary.ParticipantName,
 				StartTime:       summary.StartTime,
 				EndTime:         summary.EndTime,
+				StartAt:         summary.StartAt,
+				EndAt:           summary.EndAt,
 				Note:            summary.Note,
+				Status:          summary.Status,
+				Preferred:       summary.Preferred,
+				Required:        summary.Required,
 			}
 		} else if participantID != "" && summary.ParticipantID == parsedID {
 			// Keep this participant with their ID
@@ -681,7 +1138,12 @@ func filterParticipantSummaries(lockParticipants bool, participantID string, sum
 				ParticipantName: summary.ParticipantName,
 				StartTime:       summary.StartTime,
 				EndTime:         summary.EndTime,
+				StartAt:         summary.StartAt,
+				EndAt:           summary.EndAt,
 				Note:            summary.Note,
+				Status:          summary.Status,
+				Preferred:       summary.Preferred,
+				Required:        summary.Required,
 			}
 		} else {
 			// Mask the ID
@@ -690,7 +1152,12 @@ func filterParticipantSummaries(lockParticipants bool, participantID string, sum
 				ParticipantName: summary.ParticipantName,
 				StartTime:       summary.StartTime,
 				EndTime:         summary.EndTime,
+				StartAt:         summary.StartAt,
+				EndAt:           summary.EndAt,
 				Note:            summary.Note,
+				Status:          summary.Status,
+				Preferred:       summary.Preferred,
+				Required:        summary.Required,
 			}
 		}
 	}
@@ -699,7 +1166,14 @@ func filterParticipantSummaries(lockParticipants bool, participantID string, sum
 }
 
 // GetRangeSummary gets availability summary over a date range
-func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startDateStr, endDateStr, participantID string) ([]models.PublicDateAvailabilitySummary, error) {
+// If timezone is set, times are converted from the calendar timezone into it
+func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startDateStr, endDateStr, participantID, timezone string) (_ []models.PublicDateAvailabilitySummary, err error) {
+	ctx, span := tracing.Start(ctx, tracerName, "AvailabilityService.GetRangeSummary",
+		attribute.String("whento.range.start", startDateStr),
+		attribute.String("whento.range.end", endDateStr),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Validate calendar token and get calendar info (including min_duration_hours)
 	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
 	if err != nil {
@@ -709,6 +1183,13 @@ func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startD
 		return nil, err
 	}
 	calendarID := calendarInfo.ID
+	span.SetAttributes(attribute.String("whento.calendar_id", calendarID.String()))
+
+	// Resolve timezone conversion (nil locations when not requested)
+	fromLoc, toLoc, err := resolveConversion(calendarInfo.Timezone, timezone)
+	if err != nil {
+		return nil, err
+	}
 
 	// Parse dates
 	startDate, err := parseDate(startDateStr)
@@ -782,6 +1263,9 @@ func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startD
 				StartTime:       avail.StartTime,
 				EndTime:         avail.EndTime,
 				Note:            avail.Note,
+				Status:          avail.Status,
+				Preferred:       avail.Preferred,
+				Required:        participant.Required,
 			})
 		}
 	}
@@ -790,23 +1274,11 @@ func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startD
 	currentDate := startDate
 	for !currentDate.After(endDate) {
 		dateKey := formatDate(currentDate)
-		dayOfWeek := int(currentDate.Weekday())
 
 		// Check each recurrence
 		for _, rec := range recurrences {
-			// Skip if day of week doesn't match
-			if rec.DayOfWeek != dayOfWeek {
-				continue
-			}
-
-			// Compare dates as strings to avoid timezone issues
-			// Skip if date is before recurrence start
-			if dateKey < rec.StartDate {
-				continue
-			}
-
-			// Skip if date is after recurrence end (if end date is set)
-			if rec.EndDate != nil && dateKey > *rec.EndDate {
+			// Skip if the recurrence doesn't apply to this date (frequency, start and end dates)
+			if !rec.OccursOn(currentDate) {
 				continue
 			}
 
@@ -822,10 +1294,15 @@ func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startD
 				continue
 			}
 
-			// Skip if there's already an explicit availability for this participant on this date
-			if explicitAvailabilities[dateKey] != nil && explicitAvailabilities[dateKey][rec.ParticipantID] {
+			// Skip if there's already an explicit availability for this participant on this date,
+			// or one of another recurrence
+			if explicitAvailabilities[dateKey][rec.ParticipantID] {
 				continue
 			}
+			if explicitAvailabilities[dateKey] == nil {
+				explicitAvailabilities[dateKey] = make(map[uuid.UUID]bool)
+			}
+			explicitAvailabilities[dateKey][rec.ParticipantID] = true
 
 			// Add this participant to the date
 			if participant, ok := participantMap[rec.ParticipantID]; ok {
@@ -835,6 +1312,8 @@ func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startD
 					StartTime:       rec.StartTime,
 					EndTime:         rec.EndTime,
 					Note:            rec.Note,
+					Status:          models.StatusYes,
+					Required:        participant.Required,
 				})
 			}
 		}
@@ -843,9 +1322,29 @@ func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startD
 		currentDate = currentDate.AddDate(0, 0, 1)
 	}
 
+	// Dates are grouped by week using the calendar's week start, falling back to the instance default
+	weekStart := pkgModels.ResolveWeekStart(s.cfg.WeekStart, calendarInfo.WeekStart)
+
+	requiredCount := countRequired(participants)
+
+	rsvpCounts, err := s.availabilityRepo.CountRSVPsByDateRange(ctx, calendarID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build response (with min_duration_hours filter if configured)
 	var summaries []models.PublicDateAvailabilitySummary
 	for date, participants := range dateMap {
+		day, err := parseDate(date)
+		if err != nil {
+			return nil, err
+		}
+
+		// Blackout dates are left out of the summaries
+		if datevalidation.IsBlackedOut(day, calendarInfo.BlackoutDates) {
+			continue
+		}
+
 		// Apply min_duration_hours filter if configured
 		if calendarInfo.MinDurationHours > 0 {
 			duration := calculateDurationForDate(participants)
@@ -855,18 +1354,98 @@ func (s *AvailabilityService) GetRangeSummary(ctx context.Context, token, startD
 			}
 		}
 
+		// Count on calendar-local times, then convert for display
+		totalCount, maybeCount := countParticipants(participants, calendarInfo.CountMaybe)
+		preferredCount, score := scoreParticipants(participants, calendarInfo.CountMaybe)
+		count := models.DateCount{
+			Count:           totalCount,
+			RequiredMissing: requiredCount - countRequiredAvailable(participants, calendarInfo.CountMaybe),
+		}
+		convertSummaryTimes(date, participants, fromLoc, toLoc)
+
 		summaries = append(summaries, models.PublicDateAvailabilitySummary{
-			Date:         date,
-			TotalCount:   calculateMaxSimultaneousParticipants(participants),
-			Participants: filterParticipantSummaries(calendarInfo.LockParticipants, participantID, participants),
+			Date:             date,
+			Week:             formatDate(weekStart.StartOfWeek(day)),
+			Timezone:         locationName(toLoc),
+			TotalCount:       totalCount,
+			MaybeCount:       maybeCount,
+			PreferredCount:   preferredCount,
+			Score:            score,
+			RequiredMissing:  count.RequiredMissing,
+			ThresholdReached: count.Reaches(calendarInfo.Threshold),
+			Capacity:         calendarInfo.MaxParticipants,
+			Remaining:        remainingCapacity(calendarInfo.MaxParticipants, totalCount),
+			GoingCount:       rsvpCounts[date].Going,
+			NotGoingCount:    rsvpCounts[date].NotGoing,
+			Participants:     filterParticipantSummaries(calendarInfo.LockParticipants, participantID, participants),
 		})
 	}
 
+	// Sort by date so that weeks are contiguous
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Date < summaries[j].Date
+	})
+
 	return summaries, nil
 }
 
 // Helper functions
 
+// resolveConversion loads the calendar and requested timezones for summary conversion
+// Returns nil locations when no conversion is requested or both timezones are the same
+func resolveConversion(calendarTimezone, targetTimezone string) (*time.Location, *time.Location, error) {
+	if targetTimezone == "" || targetTimezone == calendarTimezone {
+		return nil, nil, nil
+	}
+
+	to, err := time.LoadLocation(targetTimezone)
+	if err != nil {
+		return nil, nil, ErrInvalidTimezone
+	}
+
+	from, err := time.LoadLocation(calendarTimezone)
+	if err != nil {
+		from = time.UTC
+	}
+
+	return from, to, nil
+}
+
+// locationName returns the name of a location, or an empty string for nil
+func locationName(loc *time.Location) string {
+	if loc == nil {
+		return ""
+	}
+	return loc.String()
+}
+
+// convertSummaryTimes converts participant time slots on a calendar-local date into another timezone
+// StartTime/EndTime become "HH:MM" in the target timezone and StartAt/EndAt carry the full timestamps,
+// since the converted slot may fall on a different date. All-day availabilities are left unchanged.
+func convertSummaryTimes(dateStr string, summaries []models.ParticipantAvailabilitySummary, from, to *time.Location) {
+	if from == nil || to == nil {
+		return
+	}
+
+	convert := func(hhmm *string) (*string, *time.Time) {
+		if hhmm == nil || *hhmm == "" {
+			return hhmm, nil
+		}
+		local, err := time.ParseInLocation("2006-01-02 15:04", dateStr+" "+*hhmm, from)
+		if err != nil {
+			return hhmm, nil
+		}
+		converted := local.In(to)
+		clock := converted.Format("15:04")
+		return &clock, &converted
+	}
+
+	for i := range summaries {
+		summaries[i].StartTime, summaries[i].StartAt = convert(summaries[i].StartTime)
+		summaries[i].EndTime, summaries[i].EndAt = convert(summaries[i].EndTime)
+	}
+}
+
 func parseDate(dateStr string) (time.Time, error) {
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
@@ -879,6 +1458,18 @@ func formatDate(date time.Time) string {
 	return date.Format("2006-01-02")
 }
 
+// formatDates formats the dates, nil when there are none
+func formatDates(dates []time.Time) []string {
+	if len(dates) == 0 {
+		return nil
+	}
+	formatted := make([]string, len(dates))
+	for i, date := range dates {
+		formatted[i] = formatDate(date)
+	}
+	return formatted
+}
+
 func isValidTime(timeStr string) bool {
 	_, err := time.Parse("15:04", timeStr)
 	return err == nil
@@ -935,6 +1526,14 @@ func calculateDuration(startTime, endTime string) float64 {
 	return duration
 }
 
+// availabilityStatus returns the status of a request, defaulting to yes
+func availabilityStatus(status string) string {
+	if status == models.StatusMaybe {
+		return models.StatusMaybe
+	}
+	return models.StatusYes
+}
+
 func isDuplicateError(err error) bool {
 	return err != nil && (err.Error() == "availability already exists for this date")
 }
@@ -950,6 +1549,8 @@ func toAvailabilityResponse(availability *models.Availability, participantName s
 		StartTime:                availability.StartTime,
 		EndTime:                  availability.EndTime,
 		Note:                     availability.Note,
+		Status:                   availability.Status,
+		Preferred:                availability.Preferred,
 		CreatedAt:                availability.CreatedAt,
 		UpdatedAt:                availability.UpdatedAt,
 	}
@@ -1044,13 +1645,14 @@ func (s *AvailabilityService) CreateRecurrence(ctx context.Context, token, parti
 		return nil, ErrParticipantNotFound
 	}
 
-	// Validate day of week (already validated by struct tags, but double-check)
-	if req.DayOfWeek == nil || *req.DayOfWeek < 0 || *req.DayOfWeek > 6 {
-		return nil, ErrInvalidDayOfWeek
+	// Validate the frequency and its days (already validated by struct tags, but double-check)
+	recurrence, err := recurrencePattern(req.Frequency, req.IntervalWeeks, req.DayOfWeek, req.DayOfMonth, req.WeekOfMonth)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate that this weekday is allowed for this calendar
-	if !datevalidation.IsWeekdayAllowed(*req.DayOfWeek, calendarInfo.AllowedWeekdays) {
+	// Validate that this weekday is allowed for this calendar (days of the month fall on any weekday)
+	if recurrence.DayOfWeek != nil && !datevalidation.IsWeekdayAllowed(*recurrence.DayOfWeek, calendarInfo.AllowedWeekdays) {
 		return nil, ErrWeekdayNotAllowed
 	}
 
@@ -1083,7 +1685,10 @@ func (s *AvailabilityService) CreateRecurrence(ctx context.Context, token, parti
 	}
 
 	// Adjust times based on allowed hours for this day of week
-	adjustedStartTime, adjustedEndTime := adjustTimesByAllowedHoursForWeekday(*req.DayOfWeek, normalizedStart, normalizedEnd, calendarInfo)
+	adjustedStartTime, adjustedEndTime := normalizedStart, normalizedEnd
+	if recurrence.DayOfWeek != nil {
+		adjustedStartTime, adjustedEndTime = adjustTimesByAllowedHoursForWeekday(*recurrence.DayOfWeek, normalizedStart, normalizedEnd, calendarInfo)
+	}
 
 	// Validate time range if both times are provided (end must be after start)
 	// After normalization and adjustment, an invalid range means the time doesn't fit within allowed hours
@@ -1101,27 +1706,27 @@ func (s *AvailabilityService) CreateRecurrence(ctx context.Context, token, parti
 		}
 	}
 
-	// Check for overlapping recurrences on the same day of week
-	if err := s.checkRecurrenceOverlap(ctx, partID, *req.DayOfWeek, req.StartDate, req.EndDate, nil); err != nil {
+	// Check for overlapping recurrences on the same days
+	recurrence.StartDate = req.StartDate
+	recurrence.EndDate = req.EndDate
+	if err := s.checkRecurrenceOverlap(ctx, partID, recurrence, nil); err != nil {
 		return nil, err
 	}
 
 	// Create recurrence (use string dates from request)
-	recurrence := &models.Recurrence{
-		ParticipantID: partID,
-		DayOfWeek:     *req.DayOfWeek,
-		StartTime:     adjustedStartTime,
-		EndTime:       adjustedEndTime,
-		Note:          req.Note,
-		StartDate:     req.StartDate,
-		EndDate:       req.EndDate,
-		CreatedAt:     time.Now(),
-	}
 	recurrence.ID = uuid.New()
+	recurrence.ParticipantID = partID
+	recurrence.StartTime = adjustedStartTime
+	recurrence.EndTime = adjustedEndTime
+	recurrence.Note = req.Note
+	recurrence.CreatedAt = time.Now()
 
-	if err := s.recurrenceRepo.CreateRecurrence(ctx, recurrence); err != nil {
+	// Occurrences on full dates are left out of the recurrence
+	fullDates, err := s.recurrenceRepo.CreateRecurrence(ctx, calendarID, recurrence)
+	if err != nil {
 		return nil, err
 	}
+	recurrence.FullDates = formatDates(fullDates)
 
 	return recurrence, nil
 }
@@ -1223,13 +1828,14 @@ func (s *AvailabilityService) UpdateRecurrence(ctx context.Context, token, parti
 		return nil, fmt.Errorf("recurrence does not belong to participant")
 	}
 
-	// Validate day of week
-	if req.DayOfWeek == nil || *req.DayOfWeek < 0 || *req.DayOfWeek > 6 {
-		return nil, ErrInvalidDayOfWeek
+	// Validate the frequency and its days
+	recurrence, err := recurrencePattern(req.Frequency, req.IntervalWeeks, req.DayOfWeek, req.DayOfMonth, req.WeekOfMonth)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate that this weekday is allowed for this calendar
-	if !datevalidation.IsWeekdayAllowed(*req.DayOfWeek, calendarInfo.AllowedWeekdays) {
+	// Validate that this weekday is allowed for this calendar (days of the month fall on any weekday)
+	if recurrence.DayOfWeek != nil && !datevalidation.IsWeekdayAllowed(*recurrence.DayOfWeek, calendarInfo.AllowedWeekdays) {
 		return nil, ErrWeekdayNotAllowed
 	}
 
@@ -1262,7 +1868,10 @@ func (s *AvailabilityService) UpdateRecurrence(ctx context.Context, token, parti
 	}
 
 	// Adjust times based on allowed hours for this day of week
-	adjustedStartTime, adjustedEndTime := adjustTimesByAllowedHoursForWeekday(*req.DayOfWeek, normalizedStart, normalizedEnd, calendarInfo)
+	adjustedStartTime, adjustedEndTime := normalizedStart, normalizedEnd
+	if recurrence.DayOfWeek != nil {
+		adjustedStartTime, adjustedEndTime = adjustTimesByAllowedHoursForWeekday(*recurrence.DayOfWeek, normalizedStart, normalizedEnd, calendarInfo)
+	}
 
 	// Validate time range if both times are provided (end must be after start)
 	// After normalization and adjustment, an invalid range means the time doesn't fit within allowed hours
@@ -1280,27 +1889,26 @@ func (s *AvailabilityService) UpdateRecurrence(ctx context.Context, token, parti
 		}
 	}
 
-	// Check for overlapping recurrences on the same day of week (excluding the current one)
-	if err := s.checkRecurrenceOverlap(ctx, partID, *req.DayOfWeek, req.StartDate, req.EndDate, &recID); err != nil {
+	// Check for overlapping recurrences on the same days (excluding the current one)
+	recurrence.StartDate = req.StartDate
+	recurrence.EndDate = req.EndDate
+	if err := s.checkRecurrenceOverlap(ctx, partID, recurrence, &recID); err != nil {
 		return nil, err
 	}
 
 	// Update recurrence
-	recurrence := &models.Recurrence{
-		ParticipantID: partID,
-		DayOfWeek:     *req.DayOfWeek,
-		StartTime:     adjustedStartTime,
-		EndTime:       adjustedEndTime,
-		Note:          req.Note,
-		StartDate:     req.StartDate,
-		EndDate:       req.EndDate,
-		CreatedAt:     existingRec.CreatedAt,
-	}
 	recurrence.ID = recID
+	recurrence.ParticipantID = partID
+	recurrence.StartTime = adjustedStartTime
+	recurrence.EndTime = adjustedEndTime
+	recurrence.Note = req.Note
+	recurrence.CreatedAt = existingRec.CreatedAt
 
-	if err := s.recurrenceRepo.UpdateRecurrence(ctx, recurrence); err != nil {
+	fullDates, err := s.recurrenceRepo.UpdateRecurrence(ctx, calendarID, recurrence)
+	if err != nil {
 		return nil, err
 	}
+	recurrence.FullDates = formatDates(fullDates)
 
 	return recurrence, nil
 }
@@ -1426,6 +2034,106 @@ func (s *AvailabilityService) CreateException(ctx context.Context, token, partic
 	return exception, nil
 }
 
+// maxExceptionRangeDays bounds the ranges excluded at once, to keep the generated rows reasonable
+const maxExceptionRangeDays = 366
+
+// CreateExceptionRange excludes every date from start to end (inclusive) from the participant's recurrences,
+// or from a single one when the request names it. Only the dates a recurrence actually falls on get an
+// exception, and dates already excluded are skipped.
+func (s *AvailabilityService) CreateExceptionRange(ctx context.Context, token, participantID string, req *models.CreateExceptionRangeRequest) ([]models.RecurrenceException, error) {
+	// Validate calendar token
+	calendarID, err := s.calendarRepo.GetByPublicToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	// Parse participant ID
+	partID, err := uuid.Parse(participantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid participant id: %w", err)
+	}
+
+	// Verify participant belongs to this calendar
+	participant, err := s.participantRepo.GetByID(ctx, partID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, ErrParticipantNotFound
+		}
+		return nil, err
+	}
+
+	if participant.CalendarID != calendarID {
+		return nil, ErrParticipantNotFound
+	}
+
+	startDate, err := parseDate(req.StartDate)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+	endDate, err := parseDate(req.EndDate)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+	if endDate.Sub(startDate).Hours()/24 >= maxExceptionRangeDays {
+		return nil, ErrExceptionRangeTooLong
+	}
+
+	var recurrences []models.Recurrence
+	if req.RecurrenceID != nil {
+		recID, err := uuid.Parse(*req.RecurrenceID)
+		if err != nil {
+			return nil, ErrRecurrenceNotFound
+		}
+		recurrence, err := s.recurrenceRepo.GetRecurrenceByID(ctx, recID)
+		if err != nil || recurrence.ParticipantID != partID {
+			return nil, ErrRecurrenceNotFound
+		}
+		recurrences = []models.Recurrence{*recurrence}
+	} else {
+		recurrences, err = s.recurrenceRepo.GetRecurrencesByParticipant(ctx, partID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	var exceptions []*models.RecurrenceException
+	for i := range recurrences {
+		for _, date := range recurrenceDates(&recurrences[i], startDate, endDate) {
+			exception := &models.RecurrenceException{
+				RecurrenceID: recurrences[i].ID,
+				ExcludedDate: date,
+				CreatedAt:    now,
+			}
+			exception.ID = uuid.New()
+			exceptions = append(exceptions, exception)
+		}
+	}
+
+	if len(exceptions) == 0 {
+		return []models.RecurrenceException{}, nil
+	}
+
+	return s.recurrenceRepo.CreateExceptions(ctx, exceptions)
+}
+
+// recurrenceDates lists the dates from start to end (inclusive) a recurrence falls on, as YYYY-MM-DD
+func recurrenceDates(recurrence *models.Recurrence, start, end time.Time) []string {
+	var dates []string
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if recurrence.OccursOn(date) {
+			dates = append(dates, formatDate(date))
+		}
+	}
+	return dates
+}
+
 // DeleteException deletes an exception from a recurrence
 func (s *AvailabilityService) DeleteException(ctx context.Context, token, participantID, recurrenceID, dateStr string) error {
 	// Validate calendar token
@@ -1577,11 +2285,11 @@ func getAllowedTimeRangeForDate(date time.Time, calendarInfo *repository.Calenda
 		}
 	}
 
-	// Determine the country code for holiday checking
-	countryCode := datevalidation.GetCountryFromTimezone(calendarInfo.Timezone)
+	// Determine the country (and region) code for holiday checking
+	countryCode := holidayCode(calendarInfo)
 
 	// Check if it's a holiday and policy is "allow"
-	if calendarInfo.HolidaysPolicy == "allow" && countryCode != "" && datevalidation.IsHoliday(date, countryCode) {
+	if calendarInfo.HolidaysPolicy == "allow" && datevalidation.IsHolidayInSets(date, countryCode, calendarInfo.HolidaySets, holidayProviders(calendarInfo)...) {
 		// If weekday is also allowed, combine the ranges
 		if weekdayAllowed {
 			return combineTimeRanges(calendarInfo.AllowedHours.Holidays, weekdayRange)
@@ -1591,7 +2299,7 @@ func getAllowedTimeRangeForDate(date time.Time, calendarInfo *repository.Calenda
 	}
 
 	// Check if it's a holiday eve
-	if calendarInfo.AllowHolidayEves && countryCode != "" && datevalidation.IsHolidayEve(date, countryCode) {
+	if calendarInfo.AllowHolidayEves && datevalidation.IsHolidayEveInSets(date, countryCode, calendarInfo.HolidaySets, holidayProviders(calendarInfo)...) {
 		// If weekday is also allowed, combine the ranges
 		if weekdayAllowed {
 			return combineTimeRanges(calendarInfo.AllowedHours.HolidayEves, weekdayRange)
@@ -1653,6 +2361,65 @@ func compareTime(t1, t2 string) int {
 	return 0
 }
 
+// countParticipants returns the participant count of a date toward the threshold, and the number of "maybe" answers
+// Maybe answers only count toward the threshold when the calendar enables count_maybe
+func countParticipants(participants []models.ParticipantAvailabilitySummary, countMaybe bool) (total, maybe int) {
+	counted := participants
+	if !countMaybe {
+		counted = make([]models.ParticipantAvailabilitySummary, 0, len(participants))
+	}
+	for _, p := range participants {
+		if p.Status == models.StatusMaybe {
+			maybe++
+		} else if !countMaybe {
+			counted = append(counted, p)
+		}
+	}
+	return calculateMaxSimultaneousParticipants(counted), maybe
+}
+
+// countRequired returns the number of required participants of a calendar
+func countRequired(participants []*repository.Participant) int {
+	required := 0
+	for _, p := range participants {
+		if p.Required {
+			required++
+		}
+	}
+	return required
+}
+
+// countRequiredAvailable returns the number of required participants counted on a date
+// Maybe answers only count when the calendar enables count_maybe
+func countRequiredAvailable(participants []models.ParticipantAvailabilitySummary, countMaybe bool) int {
+	available := 0
+	for _, p := range participants {
+		if p.Required && (countMaybe || p.Status != models.StatusMaybe) {
+			available++
+		}
+	}
+	return available
+}
+
+// scoreParticipants returns the number of preferred answers of a date and its preference score
+// Preferred answers weigh 2 points and other answers 1, maybe answers only when they count toward the threshold
+func scoreParticipants(participants []models.ParticipantAvailabilitySummary, countMaybe bool) (preferred, score int) {
+	for _, p := range participants {
+		switch {
+		case p.Status == models.StatusMaybe:
+			if countMaybe {
+				score++
+			}
+		case p.Preferred:
+			preferred++
+			score += 2
+		default:
+			score++
+		}
+	}
+	return preferred, score
+}
+
 // calculateMaxSimultaneousParticipants calculates the maximum number of participants
 // that are available at the same time on a given date.
 // This uses the same logic as the ICS feed generation (time slot segmentation).
@@ -1786,6 +2553,95 @@ func recurrencesOverlap(startDateA, endDateA, startDateB, endDateB string) bool
 	return startDateA <= endDateB && startDateB <= endDateA
 }
 
+// recurrencePattern validates the frequency of a recurrence request and returns it on a new recurrence,
+// leaving out the days its frequency doesn't use
+func recurrencePattern(frequency string, intervalWeeks int, dayOfWeek, dayOfMonth, weekOfMonth *int) (*models.Recurrence, error) {
+	recurrence := &models.Recurrence{Frequency: frequency, IntervalWeeks: 1}
+	if recurrence.Frequency == "" {
+		recurrence.Frequency = models.FrequencyWeekly
+	}
+
+	switch recurrence.Frequency {
+	case models.FrequencyWeekly, models.FrequencyMonthlyWeekday:
+		if dayOfWeek == nil || *dayOfWeek < 0 || *dayOfWeek > 6 {
+			return nil, ErrInvalidDayOfWeek
+		}
+		recurrence.DayOfWeek = dayOfWeek
+	case models.FrequencyMonthlyDay:
+		if dayOfMonth == nil || *dayOfMonth < 1 || *dayOfMonth > 31 {
+			return nil, ErrInvalidDayOfMonth
+		}
+		recurrence.DayOfMonth = dayOfMonth
+	default:
+		return nil, ErrInvalidFrequency
+	}
+
+	if recurrence.Frequency == models.FrequencyWeekly && intervalWeeks != 0 {
+		if intervalWeeks < 1 || intervalWeeks > 52 {
+			return nil, ErrInvalidIntervalWeeks
+		}
+		recurrence.IntervalWeeks = intervalWeeks
+	}
+
+	if recurrence.Frequency == models.FrequencyMonthlyWeekday {
+		if weekOfMonth == nil || *weekOfMonth < models.LastWeekOfMonth || *weekOfMonth == 0 || *weekOfMonth > 5 {
+			return nil, ErrInvalidWeekOfMonth
+		}
+		recurrence.WeekOfMonth = weekOfMonth
+	}
+
+	return recurrence, nil
+}
+
+// recurrencesShareDays checks if two recurrences can fall on the same day, regardless of their date ranges.
+// Recurrences of different frequencies only coincide now and then, summaries then count the participant once.
+func recurrencesShareDays(a, b *models.Recurrence) bool {
+	if a.Frequency != b.Frequency {
+		return false
+	}
+
+	switch a.Frequency {
+	case models.FrequencyMonthlyDay:
+		return *a.DayOfMonth == *b.DayOfMonth
+	case models.FrequencyMonthlyWeekday:
+		return *a.DayOfWeek == *b.DayOfWeek && *a.WeekOfMonth == *b.WeekOfMonth
+	}
+
+	if *a.DayOfWeek != *b.DayOfWeek {
+		return false
+	}
+
+	// Every N and M weeks: the recurrences meet when their first weeks differ by a multiple of gcd(N, M),
+	// e.g. two "every other week" recurrences can alternate
+	step := gcd(max(a.IntervalWeeks, 1), max(b.IntervalWeeks, 1))
+	if step == 1 {
+		return true
+	}
+	firstA, errA := firstOccurrence(a.StartDate, *a.DayOfWeek)
+	firstB, errB := firstOccurrence(b.StartDate, *b.DayOfWeek)
+	if errA != nil || errB != nil {
+		return true
+	}
+	weeks := int(firstA.Sub(firstB).Hours()/24) / 7
+	return weeks%step == 0
+}
+
+// firstOccurrence returns the first day of the week on or after a date (YYYY-MM-DD)
+func firstOccurrence(startDate string, dayOfWeek int) (time.Time, error) {
+	start, err := parseDate(startDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return start.AddDate(0, 0, (dayOfWeek-int(start.Weekday())+7)%7), nil
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
 // getAllowedTimeRangeForWeekday gets the allowed time range for a specific weekday.
 // This is used for recurrences where we know the day of week directly.
 // Unlike getAllowedTimeRangeForDate, this doesn't check for holidays since recurrences
@@ -1850,9 +2706,9 @@ func adjustTimesByAllowedHoursForWeekday(dayOfWeek int, requestedStart, requeste
 }
 
 // checkRecurrenceOverlap checks if a new/updated recurrence overlaps with existing recurrences
-// for the same participant on the same day of week.
+// for the same participant on the same days.
 // excludeID is used during updates to exclude the recurrence being updated from the check.
-func (s *AvailabilityService) checkRecurrenceOverlap(ctx context.Context, participantID uuid.UUID, dayOfWeek int, startDate string, endDate *string, excludeID *uuid.UUID) error {
+func (s *AvailabilityService) checkRecurrenceOverlap(ctx context.Context, participantID uuid.UUID, recurrence *models.Recurrence, excludeID *uuid.UUID) error {
 	// Get all existing recurrences for this participant
 	existingRecurrences, err := s.recurrenceRepo.GetRecurrencesByParticipant(ctx, participantID)
 	if err != nil {
@@ -1861,14 +2717,14 @@ func (s *AvailabilityService) checkRecurrenceOverlap(ctx context.Context, partic
 
 	// Convert endDate pointer to string for comparison
 	endDateStr := ""
-	if endDate != nil {
-		endDateStr = *endDate
+	if recurrence.EndDate != nil {
+		endDateStr = *recurrence.EndDate
 	}
 
-	// Check for overlaps with existing recurrences on the same day
+	// Check for overlaps with existing recurrences on the same days
 	for _, existing := range existingRecurrences {
-		// Skip if different day of week
-		if existing.DayOfWeek != dayOfWeek {
+		// Skip if the recurrences never fall on the same day
+		if !recurrencesShareDays(recurrence, &existing) {
 			continue
 		}
 
@@ -1884,10 +2740,47 @@ func (s *AvailabilityService) checkRecurrenceOverlap(ctx context.Context, partic
 		}
 
 		// Check if date ranges overlap
-		if recurrencesOverlap(startDate, endDateStr, existing.StartDate, existingEndDate) {
+		if recurrencesOverlap(recurrence.StartDate, endDateStr, existing.StartDate, existingEndDate) {
 			return ErrRecurrenceOverlap
 		}
 	}
 
 	return nil
 }
+
+// holidayCode returns the code of the public holidays of a calendar: its explicit holiday country and region,
+// or the country of its timezone
+func holidayCode(calendarInfo *repository.Calendar) string {
+	return datevalidation.HolidayCode(calendarInfo.Timezone, calendarInfo.HolidayCountry, calendarInfo.HolidayRegion)
+}
+
+// prefetchHolidays loads the regional holidays of a calendar for the dates of availabilities about to be validated
+func prefetchHolidays(calendarInfo *repository.Calendar, availabilities []models.CreateAvailabilityRequest) {
+	var first, last time.Time
+	for _, availability := range availabilities {
+		date, err := parseDate(availability.Date)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+	if first.IsZero() {
+		return
+	}
+	codes := append([]string{holidayCode(calendarInfo)}, calendarInfo.HolidayCountries...)
+	datevalidation.PrefetchHolidays(first, last.AddDate(0, 0, 1), codes...)
+}
+
+// holidayProviders returns the holidays of a calendar on top of its country and holiday sets:
+// its custom holidays and the public holidays of its other countries
+func holidayProviders(calendarInfo *repository.Calendar) []datevalidation.HolidayProvider {
+	return []datevalidation.HolidayProvider{
+		calendarInfo.CustomHolidays,
+		datevalidation.CountryHolidays(calendarInfo.HolidayCountries),
+	}
+}
@@ -5,11 +5,16 @@
 package service
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/whento/pkg/datevalidation"
 	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/repository"
 )
 
 func TestCalculateMaxSimultaneousParticipants(t *testing.T) {
@@ -296,3 +301,347 @@ func TestRecurrencesOverlap(t *testing.T) {
 		})
 	}
 }
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestRecurrencePattern(t *testing.T) {
+	weekly, err := recurrencePattern("", 0, intPtr(3), intPtr(15), nil)
+	if err != nil {
+		t.Fatalf("recurrencePattern() error = %v", err)
+	}
+	if weekly.Frequency != models.FrequencyWeekly || weekly.IntervalWeeks != 1 || weekly.DayOfMonth != nil {
+		t.Errorf("Expected a weekly recurrence every week without day of month, got %+v", weekly)
+	}
+
+	monthly, err := recurrencePattern(models.FrequencyMonthlyDay, 2, intPtr(3), intPtr(15), nil)
+	if err != nil {
+		t.Fatalf("recurrencePattern() error = %v", err)
+	}
+	if monthly.DayOfWeek != nil || monthly.IntervalWeeks != 1 || *monthly.DayOfMonth != 15 {
+		t.Errorf("Expected a monthly recurrence on the 15th only, got %+v", monthly)
+	}
+
+	tests := []struct {
+		name        string
+		frequency   string
+		interval    int
+		dayOfWeek   *int
+		dayOfMonth  *int
+		weekOfMonth *int
+		want        error
+	}{
+		{"missing day of week", models.FrequencyWeekly, 0, nil, nil, nil, ErrInvalidDayOfWeek},
+		{"interval too long", models.FrequencyWeekly, 53, intPtr(1), nil, nil, ErrInvalidIntervalWeeks},
+		{"missing day of month", models.FrequencyMonthlyDay, 0, intPtr(1), nil, nil, ErrInvalidDayOfMonth},
+		{"missing week of month", models.FrequencyMonthlyWeekday, 0, intPtr(5), nil, nil, ErrInvalidWeekOfMonth},
+		{"week zero", models.FrequencyMonthlyWeekday, 0, intPtr(5), nil, intPtr(0), ErrInvalidWeekOfMonth},
+		{"unknown frequency", "yearly", 0, intPtr(5), nil, nil, ErrInvalidFrequency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := recurrencePattern(tt.frequency, tt.interval, tt.dayOfWeek, tt.dayOfMonth, tt.weekOfMonth); err != tt.want {
+				t.Errorf("recurrencePattern() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrencesShareDays(t *testing.T) {
+	weekly := func(interval int, startDate string) *models.Recurrence {
+		return &models.Recurrence{Frequency: models.FrequencyWeekly, IntervalWeeks: interval, DayOfWeek: intPtr(5), StartDate: startDate}
+	}
+
+	tests := []struct {
+		name string
+		a, b *models.Recurrence
+		want bool
+	}{
+		{"same weekday", weekly(1, "2025-06-02"), weekly(2, "2025-06-09"), true},
+		{"other weekday", weekly(1, "2025-06-02"), &models.Recurrence{Frequency: models.FrequencyWeekly, IntervalWeeks: 1, DayOfWeek: intPtr(4), StartDate: "2025-06-02"}, false},
+		{"alternating weeks", weekly(2, "2025-06-02"), weekly(2, "2025-06-09"), false},
+		{"same weeks", weekly(2, "2025-06-02"), weekly(2, "2025-06-16"), true},
+		{"other frequency", weekly(1, "2025-06-02"), &models.Recurrence{Frequency: models.FrequencyMonthlyWeekday, DayOfWeek: intPtr(5), WeekOfMonth: intPtr(1)}, false},
+		{"same day of month", &models.Recurrence{Frequency: models.FrequencyMonthlyDay, DayOfMonth: intPtr(1)}, &models.Recurrence{Frequency: models.FrequencyMonthlyDay, DayOfMonth: intPtr(1)}, true},
+		{"other week of month", &models.Recurrence{Frequency: models.FrequencyMonthlyWeekday, DayOfWeek: intPtr(5), WeekOfMonth: intPtr(1)}, &models.Recurrence{Frequency: models.FrequencyMonthlyWeekday, DayOfWeek: intPtr(5), WeekOfMonth: intPtr(-1)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recurrencesShareDays(tt.a, tt.b); got != tt.want {
+				t.Errorf("recurrencesShareDays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceDates(t *testing.T) {
+	// Every other Friday from 2025-06-06, with a vacation from July 1 to 21
+	recurrence := &models.Recurrence{Frequency: models.FrequencyWeekly, IntervalWeeks: 2, DayOfWeek: intPtr(5), StartDate: "2025-06-06"}
+	start, _ := parseDate("2025-07-01")
+	end, _ := parseDate("2025-07-21")
+
+	dates := recurrenceDates(recurrence, start, end)
+	if len(dates) != 2 || dates[0] != "2025-07-04" || dates[1] != "2025-07-18" {
+		t.Errorf("Expected 2025-07-04 and 2025-07-18, got %v", dates)
+	}
+
+	// A single-day range on the boundary is inclusive
+	if dates := recurrenceDates(recurrence, end.AddDate(0, 0, -3), end.AddDate(0, 0, -3)); len(dates) != 1 {
+		t.Errorf("Expected the range end to be included, got %v", dates)
+	}
+}
+
+func TestCountParticipants(t *testing.T) {
+	participants := []models.ParticipantAvailabilitySummary{
+		{ParticipantName: "Alice", Status: models.StatusYes},
+		{ParticipantName: "Bob", Status: models.StatusMaybe},
+		{ParticipantName: "Carol", Status: models.StatusYes, StartTime: stringPtr("09:00"), EndTime: stringPtr("12:00")},
+	}
+
+	total, maybe := countParticipants(participants, false)
+	if total != 2 || maybe != 1 {
+		t.Errorf("Expected 2 counted and 1 maybe, got %d and %d", total, maybe)
+	}
+
+	total, maybe = countParticipants(participants, true)
+	if total != 3 || maybe != 1 {
+		t.Errorf("Expected 3 counted and 1 maybe, got %d and %d", total, maybe)
+	}
+}
+
+func TestScoreParticipants(t *testing.T) {
+	participants := []models.ParticipantAvailabilitySummary{
+		{ParticipantName: "Alice", Status: models.StatusYes, Preferred: true},
+		{ParticipantName: "Bob", Status: models.StatusYes},
+		{ParticipantName: "Carol", Status: models.StatusMaybe},
+	}
+
+	preferred, score := scoreParticipants(participants, false)
+	if preferred != 1 || score != 3 {
+		t.Errorf("Expected 1 preferred and a score of 3, got %d and %d", preferred, score)
+	}
+
+	_, score = scoreParticipants(participants, true)
+	if score != 4 {
+		t.Errorf("Expected a score of 4 when maybes count, got %d", score)
+	}
+}
+
+func TestCountRequiredAvailable(t *testing.T) {
+	participants := []models.ParticipantAvailabilitySummary{
+		{ParticipantName: "Alice", Status: models.StatusYes, Required: true},
+		{ParticipantName: "Bob", Status: models.StatusMaybe, Required: true},
+		{ParticipantName: "Carol", Status: models.StatusYes},
+	}
+
+	if available := countRequiredAvailable(participants, false); available != 1 {
+		t.Errorf("Expected 1 required participant counted, got %d", available)
+	}
+	if available := countRequiredAvailable(participants, true); available != 2 {
+		t.Errorf("Expected 2 required participants counted when maybes count, got %d", available)
+	}
+
+	count := models.DateCount{Count: 3, RequiredMissing: 1}
+	if count.Reaches(2) {
+		t.Error("Expected a date with a missing required participant not to reach the threshold")
+	}
+	count.RequiredMissing = 0
+	if !count.Reaches(3) || count.Reaches(4) {
+		t.Errorf("Expected %+v to reach 3 but not 4", count)
+	}
+}
+
+func TestNormalizeComment(t *testing.T) {
+	body, err := normalizeComment("  See you there!\n")
+	if err != nil || body != "See you there!" {
+		t.Errorf("Expected trimmed comment, got %q (%v)", body, err)
+	}
+
+	if _, err := normalizeComment(" \t "); !errors.Is(err, ErrEmptyComment) {
+		t.Errorf("Expected ErrEmptyComment, got %v", err)
+	}
+
+	if _, err := normalizeComment(strings.Repeat("é", models.MaxCommentLength)); err != nil {
+		t.Errorf("Expected a comment of the maximum length to be accepted, got %v", err)
+	}
+	if _, err := normalizeComment(strings.Repeat("a", models.MaxCommentLength+1)); !errors.Is(err, ErrCommentTooLong) {
+		t.Errorf("Expected ErrCommentTooLong, got %v", err)
+	}
+}
+
+func TestCountRSVPs(t *testing.T) {
+	count := countRSVPs([]models.RSVP{
+		{Status: models.RSVPGoing},
+		{Status: models.RSVPNotGoing},
+		{Status: models.RSVPGoing},
+	})
+	if count.Going != 2 || count.NotGoing != 1 {
+		t.Errorf("Expected 2 going and 1 not going, got %+v", count)
+	}
+
+	if count := countRSVPs(nil); count != (models.RSVPCount{}) {
+		t.Errorf("Expected no answers, got %+v", count)
+	}
+}
+
+func TestConvertSummaryTimes(t *testing.T) {
+	from, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	to, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	summaries := []models.ParticipantAvailabilitySummary{
+		{ParticipantName: "Alice", StartTime: stringPtr("03:00"), EndTime: stringPtr("10:30")},
+		{ParticipantName: "Bob"}, // All day
+	}
+
+	convertSummaryTimes("2025-07-01", summaries, from, to)
+
+	alice := summaries[0]
+	if *alice.StartTime != "21:00" || *alice.EndTime != "04:30" {
+		t.Errorf("Expected 21:00-04:30, got %s-%s", *alice.StartTime, *alice.EndTime)
+	}
+	if alice.StartAt == nil || alice.StartAt.Format("2006-01-02") != "2025-06-30" {
+		t.Errorf("Expected start on the previous day in New York, got %v", alice.StartAt)
+	}
+
+	bob := summaries[1]
+	if bob.StartTime != nil || bob.StartAt != nil || bob.EndAt != nil {
+		t.Error("Expected all-day availability to be left unchanged")
+	}
+}
+
+func TestResolveConversion(t *testing.T) {
+	if from, to, err := resolveConversion("Europe/Paris", ""); err != nil || from != nil || to != nil {
+		t.Error("Expected no conversion without a target timezone")
+	}
+	if from, to, err := resolveConversion("Europe/Paris", "Europe/Paris"); err != nil || from != nil || to != nil {
+		t.Error("Expected no conversion when the target equals the calendar timezone")
+	}
+	if _, _, err := resolveConversion("Europe/Paris", "Not/AZone"); !errors.Is(err, ErrInvalidTimezone) {
+		t.Errorf("Expected ErrInvalidTimezone, got %v", err)
+	}
+}
+
+func TestValidateAvailability(t *testing.T) {
+	calendarInfo := &repository.Calendar{
+		AllowedWeekdays:  []int{0, 1, 2, 3, 4, 5, 6},
+		Timezone:         "UTC",
+		HolidaysPolicy:   "ignore",
+		MinDurationHours: 2,
+	}
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	nextWeek := time.Now().UTC().AddDate(0, 0, 7).Format("2006-01-02")
+	calendarInfo.BlackoutDates = []datevalidation.BlackoutPeriod{{StartDate: nextWeek, Reason: "Venue closed"}}
+
+	date, startTime, endTime, err := validateAvailability(calendarInfo, &models.CreateAvailabilityRequest{
+		Date: tomorrow, StartTime: stringPtr("22:00"), EndTime: stringPtr("18:00"),
+	})
+	if err != nil {
+		t.Fatalf("validateAvailability: %v", err)
+	}
+	if formatDate(date) != tomorrow || *startTime != "18:00" || *endTime != "22:00" {
+		t.Errorf("got %s %s-%s, want %s 18:00-22:00 (swapped)", formatDate(date), *startTime, *endTime, tomorrow)
+	}
+
+	tests := []struct {
+		name string
+		req  models.CreateAvailabilityRequest
+		want error
+	}{
+		{"invalid date", models.CreateAvailabilityRequest{Date: "14/06/2025"}, ErrInvalidDate},
+		{"past date", models.CreateAvailabilityRequest{Date: yesterday}, ErrDateInPast},
+		{"invalid time", models.CreateAvailabilityRequest{Date: tomorrow, StartTime: stringPtr("25:00")}, ErrInvalidTime},
+		{"too short", models.CreateAvailabilityRequest{Date: tomorrow, StartTime: stringPtr("18:00"), EndTime: stringPtr("19:00")}, ErrDurationTooShort},
+		{"blackout date", models.CreateAvailabilityRequest{Date: nextWeek}, ErrDateBlackedOut},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := validateAvailability(calendarInfo, &tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemainingCapacity(t *testing.T) {
+	if remaining := remainingCapacity(nil, 3); remaining != nil {
+		t.Errorf("Expected no capacity without limit, got %d", *remaining)
+	}
+
+	tests := []struct {
+		max, count, expected int
+	}{
+		{max: 5, count: 0, expected: 5},
+		{max: 5, count: 3, expected: 2},
+		{max: 5, count: 5, expected: 0},
+		{max: 5, count: 7, expected: 0}, // Limit lowered below the existing answers
+	}
+	for _, tt := range tests {
+		remaining := remainingCapacity(intPtr(tt.max), tt.count)
+		if remaining == nil || *remaining != tt.expected {
+			t.Errorf("remainingCapacity(%d, %d) = %v, expected %d", tt.max, tt.count, remaining, tt.expected)
+		}
+	}
+}
+
+func TestMarkConflicts(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Paris")
+	periods := []models.BusyPeriod{
+		// 10:00 - 12:00 in Paris
+		{Start: time.Date(2025, 6, 10, 8, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 10, 10, 0, 0, 0, time.UTC)},
+	}
+	morning, noon, evening, night := "09:00", "10:00", "18:00", "20:00"
+
+	items := []models.AvailabilityItem{
+		{Date: "2025-06-10"}, // Whole day
+		{Date: "2025-06-10", StartTime: &morning, EndTime: &noon},
+		{Date: "2025-06-10", StartTime: &evening, EndTime: &night},
+		{Date: "2025-06-11"},
+	}
+	markConflicts(items, periods, loc)
+
+	expected := []bool{true, false, false, false}
+	for i, item := range items {
+		if item.Conflict != expected[i] {
+			t.Errorf("items[%d].Conflict = %v, expected %v", i, item.Conflict, expected[i])
+		}
+	}
+
+	// Without an end time, the availability runs to the end of the day
+	start, end := availabilityWindow(time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC), &morning, nil, loc)
+	if !overlapsBusy(periods, start, end) {
+		t.Error("Expected an availability from 09:00 to the end of the day to conflict")
+	}
+}
+
+func TestNormalizeFeedURL(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+		wantErr  bool
+	}{
+		{raw: "https://calendar.google.com/calendar/ical/x/basic.ics", expected: "https://calendar.google.com/calendar/ical/x/basic.ics"},
+		{raw: " webcal://outlook.office365.com/owa/calendar/x/calendar.ics ", expected: "https://outlook.office365.com/owa/calendar/x/calendar.ics"},
+		{raw: "ftp://example.com/busy.ics", wantErr: true},
+		{raw: "not a url", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeFeedURL(tt.raw)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidBusyFeed) {
+				t.Errorf("normalizeFeedURL(%q) error = %v, expected ErrInvalidBusyFeed", tt.raw, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("normalizeFeedURL(%q) = %q, %v, expected %q", tt.raw, got, err, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/repository"
+)
+
+// badgeHorizonDays is how far ahead the badge looks for a date reaching the threshold
+const badgeHorizonDays = 90
+
+// GetBadgeStatus returns the next date of a calendar reaching its threshold, or the best count of upcoming dates
+func (s *AvailabilityService) GetBadgeStatus(ctx context.Context, token string, now time.Time) (*models.BadgeStatus, error) {
+	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(calendarInfo.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	summaries, err := s.GetRangeSummary(ctx, token, formatDate(today), formatDate(today.AddDate(0, 0, badgeHorizonDays)), "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.BadgeStatus{
+		Name:      calendarInfo.Name,
+		Threshold: calendarInfo.Threshold,
+		Today:     today,
+	}
+	summarizeBadge(status, summaries)
+	return status, nil
+}
+
+// summarizeBadge sets the next date reaching the threshold, or the best count, from summaries sorted by date
+func summarizeBadge(status *models.BadgeStatus, summaries []models.PublicDateAvailabilitySummary) {
+	for _, summary := range summaries {
+		if (models.DateCount{Count: summary.TotalCount, RequiredMissing: summary.RequiredMissing}).Reaches(status.Threshold) {
+			date, err := parseDate(summary.Date)
+			if err != nil {
+				continue
+			}
+			status.NextDate = &date
+			status.BestCount = summary.TotalCount
+			return
+		}
+		status.BestCount = max(status.BestCount, summary.TotalCount)
+	}
+}
@@ -0,0 +1,45 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"testing"
+
+	"github.com/whento/whento/internal/availability/models"
+)
+
+func TestSummarizeBadge(t *testing.T) {
+	summaries := []models.PublicDateAvailabilitySummary{
+		{Date: "2025-06-10", TotalCount: 1},
+		{Date: "2025-06-12", TotalCount: 2},
+		{Date: "2025-06-13", TotalCount: 3, RequiredMissing: 1},
+		{Date: "2025-06-14", TotalCount: 3},
+		{Date: "2025-06-15", TotalCount: 4},
+	}
+
+	t.Run("date reaching the threshold", func(t *testing.T) {
+		status := &models.BadgeStatus{Threshold: 3}
+		summarizeBadge(status, summaries)
+		if status.NextDate == nil || formatDate(*status.NextDate) != "2025-06-14" || status.BestCount != 3 {
+			t.Errorf("status = %+v, want next date 2025-06-14 with 3", status)
+		}
+	})
+
+	t.Run("required participant missing", func(t *testing.T) {
+		status := &models.BadgeStatus{Threshold: 2}
+		summarizeBadge(status, summaries[2:3])
+		if status.NextDate != nil || status.BestCount != 3 {
+			t.Errorf("status = %+v, want no date and best count 3", status)
+		}
+	})
+
+	t.Run("best count", func(t *testing.T) {
+		status := &models.BadgeStatus{Threshold: 5}
+		summarizeBadge(status, summaries)
+		if status.NextDate != nil || status.BestCount != 4 {
+			t.Errorf("status = %+v, want no date and best count 4", status)
+		}
+	})
+}
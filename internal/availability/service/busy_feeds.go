@@ -0,0 +1,180 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/repository"
+)
+
+// maxBusyFeeds limits the number of ICS feeds attached to a participant
+const maxBusyFeeds = 5
+
+// ListBusyFeeds returns the ICS feeds attached to a participant
+func (s *AvailabilityService) ListBusyFeeds(ctx context.Context, token, participantID string) ([]models.BusyFeed, error) {
+	participant, err := s.calendarParticipant(ctx, token, participantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.availabilityRepo.ListBusyFeeds(ctx, participant.ID)
+}
+
+// AddBusyFeed attaches an ICS feed to a participant and runs a first sync
+// The feed is kept even if the first sync fails; the error is reported in its sync status
+func (s *AvailabilityService) AddBusyFeed(ctx context.Context, token, participantID string, req *models.AddBusyFeedRequest) (*models.BusyFeed, error) {
+	feedURL, err := normalizeFeedURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.calendarParticipant(ctx, token, participantID)
+	if err != nil {
+		return nil, err
+	}
+
+	feeds, err := s.availabilityRepo.ListBusyFeeds(ctx, participant.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(feeds) >= maxBusyFeeds {
+		return nil, ErrTooManyBusyFeeds
+	}
+
+	feed, err := s.availabilityRepo.CreateBusyFeed(ctx, participant.ID, feedURL)
+	if err != nil {
+		if errors.Is(err, repository.ErrBusyFeedExists) {
+			return nil, ErrBusyFeedExists
+		}
+		return nil, err
+	}
+
+	if s.busyFeeds == nil {
+		return feed, nil
+	}
+	// Failures are recorded on the feed by the syncer
+	_ = s.busyFeeds.SyncFeed(ctx, feed.ID)
+
+	return s.availabilityRepo.GetBusyFeed(ctx, participant.ID, feed.ID)
+}
+
+// DeleteBusyFeed detaches an ICS feed from a participant, forgetting its busy time
+func (s *AvailabilityService) DeleteBusyFeed(ctx context.Context, token, participantID, feedID string) error {
+	id, err := uuid.Parse(feedID)
+	if err != nil {
+		return ErrBusyFeedNotFound
+	}
+
+	participant, err := s.calendarParticipant(ctx, token, participantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.availabilityRepo.DeleteBusyFeed(ctx, participant.ID, id); err != nil {
+		if errors.Is(err, repository.ErrBusyFeedNotFound) {
+			return ErrBusyFeedNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// busyPeriods returns the busy time of the feeds of a participant overlapping [from, to)
+// Conflicts are only hints: a failed lookup reports no busy time rather than failing the request
+func (s *AvailabilityService) busyPeriods(ctx context.Context, participantID uuid.UUID, from, to *time.Time) []models.BusyPeriod {
+	periods, err := s.availabilityRepo.GetBusyPeriods(ctx, participantID, from, to)
+	if err != nil {
+		return []models.BusyPeriod{}
+	}
+	return periods
+}
+
+// availabilityConflict reports whether an availability overlaps the busy time of its participant
+func (s *AvailabilityService) availabilityConflict(ctx context.Context, calendarInfo *repository.Calendar, availability *models.Availability) bool {
+	loc := calendarLocation(calendarInfo)
+	start, end := availabilityWindow(availability.Date, availability.StartTime, availability.EndTime, loc)
+	return overlapsBusy(s.busyPeriods(ctx, availability.ParticipantID, &start, &end), start, end)
+}
+
+// markConflicts flags the availability items overlapping the given busy periods
+func markConflicts(items []models.AvailabilityItem, periods []models.BusyPeriod, loc *time.Location) {
+	if len(periods) == 0 {
+		return
+	}
+	for i := range items {
+		date, err := parseDate(items[i].Date)
+		if err != nil {
+			continue
+		}
+		start, end := availabilityWindow(date, items[i].StartTime, items[i].EndTime, loc)
+		items[i].Conflict = overlapsBusy(periods, start, end)
+	}
+}
+
+// availabilityWindow returns the time range of an availability in the calendar timezone
+// A missing start or end time extends the range to the beginning or end of the day
+func availabilityWindow(date time.Time, startTime, endTime *string, loc *time.Location) (time.Time, time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	start, end := day, day.AddDate(0, 0, 1)
+
+	if startTime != nil {
+		if t, err := time.Parse("15:04", *startTime); err == nil {
+			start = day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+		}
+	}
+	if endTime != nil {
+		if t, err := time.Parse("15:04", *endTime); err == nil && (t.Hour() != 0 || t.Minute() != 0) {
+			end = day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+		}
+	}
+	return start, end
+}
+
+// overlapsBusy reports whether [start, end) overlaps one of the busy periods
+func overlapsBusy(periods []models.BusyPeriod, start, end time.Time) bool {
+	for _, period := range periods {
+		if period.Start.Before(end) && period.End.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// calendarLocation returns the timezone of a calendar, UTC if unknown
+func calendarLocation(calendarInfo *repository.Calendar) *time.Location {
+	if calendarInfo.Timezone != "" {
+		if loc, err := time.LoadLocation(calendarInfo.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// normalizeFeedURL checks the URL of an ICS feed, turning webcal links into their HTTPS equivalent
+// Private addresses are rejected when the feed is fetched
+func normalizeFeedURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return "", ErrInvalidBusyFeed
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "webcal", "webcals":
+		u.Scheme = "https"
+	case "http", "https":
+		u.Scheme = strings.ToLower(u.Scheme)
+	default:
+		return "", ErrInvalidBusyFeed
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,50 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/repository"
+	webhookModels "github.com/whento/whento/internal/webhooks/models"
+)
+
+// promoteWaitlist fills the free spots of a date with its waitlisted availabilities, oldest first
+// Without limit, as after the owner removed it, the whole waitlist is promoted.
+// Promotion is best effort: a failure leaves the remaining entries on the waitlist
+func (s *AvailabilityService) promoteWaitlist(ctx context.Context, calendarInfo *repository.Calendar, date time.Time) []*models.Availability {
+	var promoted []*models.Availability
+	for {
+		availability, err := s.availabilityRepo.PromoteFromWaitlist(ctx, calendarInfo.ID, date)
+		if err != nil || availability == nil {
+			return promoted
+		}
+		promoted = append(promoted, availability)
+	}
+}
+
+// dispatchPromoted queues the webhook events of the availabilities promoted from the waitlist
+func (s *AvailabilityService) dispatchPromoted(ctx context.Context, calendarID uuid.UUID, promoted []*models.Availability) {
+	for _, availability := range promoted {
+		participant, err := s.participantRepo.GetByID(ctx, availability.ParticipantID)
+		if err != nil {
+			continue
+		}
+		s.dispatchAvailability(ctx, calendarID, webhookModels.EventAvailabilityCreated, participant, availability)
+	}
+}
+
+// remainingCapacity returns the spots left on a date, nil when the calendar has no limit
+func remainingCapacity(maxParticipants *int, count int) *int {
+	if maxParticipants == nil {
+		return nil
+	}
+	remaining := max(*maxParticipants-count, 0)
+	return &remaining
+}
@@ -0,0 +1,105 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/repository"
+)
+
+// CreateComment posts a comment of a participant on a date of their calendar
+func (s *AvailabilityService) CreateComment(ctx context.Context, token, dateStr string, req *models.CreateDateCommentRequest) (*models.DateComment, error) {
+	body, err := normalizeComment(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := parseDate(dateStr)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+
+	// The participant is referenced like on the availability routes, by their secret token or ID
+	participantID, err := s.ResolveParticipant(ctx, token, req.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.calendarParticipant(ctx, token, participantID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.availabilityRepo.CreateComment(ctx, participant.CalendarID, participant.ID, date, body)
+}
+
+// DeleteComment deletes a comment, only by the participant who posted it
+func (s *AvailabilityService) DeleteComment(ctx context.Context, token, participantID, commentID string) error {
+	id, err := uuid.Parse(commentID)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+
+	participant, err := s.calendarParticipant(ctx, token, participantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.availabilityRepo.DeleteComment(ctx, participant.ID, id); err != nil {
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// calendarParticipant returns a participant of the calendar of a public token
+func (s *AvailabilityService) calendarParticipant(ctx context.Context, token, participantID string) (*repository.Participant, error) {
+	calendarID, err := s.calendarRepo.GetByPublicToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	partID, err := uuid.Parse(participantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid participant id: %w", err)
+	}
+
+	participant, err := s.participantRepo.GetByID(ctx, partID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, ErrParticipantNotFound
+		}
+		return nil, err
+	}
+	if participant.CalendarID != calendarID {
+		return nil, ErrParticipantNotFound
+	}
+	return participant, nil
+}
+
+// normalizeComment trims a comment and checks its length
+func normalizeComment(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrEmptyComment
+	}
+	if utf8.RuneCountInString(body) > models.MaxCommentLength {
+		return "", ErrCommentTooLong
+	}
+	return body, nil
+}
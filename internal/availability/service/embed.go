@@ -0,0 +1,83 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pkgModels "github.com/whento/pkg/models"
+	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/repository"
+)
+
+// Number of weeks shown by the embeddable widget
+const (
+	DefaultEmbedWeeks = 4
+	MaxEmbedWeeks     = 12
+)
+
+// GetEmbedCalendar returns the compact availability grid of a calendar, starting with the current week
+func (s *AvailabilityService) GetEmbedCalendar(ctx context.Context, token string, weeks int, now time.Time) (*models.EmbedCalendar, error) {
+	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	weekStart := pkgModels.ResolveWeekStart(s.cfg.WeekStart, calendarInfo.WeekStart)
+	start, end := embedRange(now, calendarInfo.Timezone, weekStart, weeks)
+
+	summaries, err := s.GetRangeSummary(ctx, token, formatDate(start), formatDate(end), "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	embed := &models.EmbedCalendar{
+		Name:      calendarInfo.Name,
+		Threshold: calendarInfo.Threshold,
+		Timezone:  calendarInfo.Timezone,
+		WeekStart: weekStart.String(),
+		Start:     formatDate(start),
+		End:       formatDate(end),
+		Dates:     make([]models.EmbedDate, 0, len(summaries)),
+	}
+	for _, summary := range summaries {
+		if summary.TotalCount == 0 {
+			continue
+		}
+		embed.Dates = append(embed.Dates, models.EmbedDate{
+			Date:    summary.Date,
+			Count:   summary.TotalCount,
+			Reached: summary.ThresholdReached,
+		})
+	}
+
+	return embed, nil
+}
+
+// embedRange returns the first and last day of a grid of whole weeks starting with the current week
+// of the calendar timezone. The number of weeks is clamped to [1, MaxEmbedWeeks], 0 meaning the default.
+func embedRange(now time.Time, timezone string, weekStart pkgModels.WeekStart, weeks int) (time.Time, time.Time) {
+	switch {
+	case weeks <= 0:
+		weeks = DefaultEmbedWeeks
+	case weeks > MaxEmbedWeeks:
+		weeks = MaxEmbedWeeks
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	start := weekStart.StartOfWeek(today)
+	return start, start.AddDate(0, 0, weeks*7-1)
+}
@@ -0,0 +1,41 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	pkgModels "github.com/whento/pkg/models"
+)
+
+func TestEmbedRange(t *testing.T) {
+	// Sunday 15 June 2025, 23:30 UTC is already Monday 16 June in Paris
+	now := time.Date(2025, 6, 15, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		timezone  string
+		weekStart pkgModels.WeekStart
+		weeks     int
+		start     string
+		end       string
+	}{
+		{"default weeks", "UTC", pkgModels.WeekStartMonday, 0, "2025-06-09", "2025-07-06"},
+		{"sunday week start", "UTC", pkgModels.WeekStartSunday, 1, "2025-06-15", "2025-06-21"},
+		{"calendar timezone", "Europe/Paris", pkgModels.WeekStartMonday, 1, "2025-06-16", "2025-06-22"},
+		{"clamped weeks", "UTC", pkgModels.WeekStartMonday, 52, "2025-06-09", "2025-08-31"},
+		{"invalid timezone", "Nowhere/City", pkgModels.WeekStartMonday, 1, "2025-06-09", "2025-06-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := embedRange(now, tt.timezone, tt.weekStart, tt.weeks)
+			if formatDate(start) != tt.start || formatDate(end) != tt.end {
+				t.Errorf("embedRange() = %s - %s, want %s - %s", formatDate(start), formatDate(end), tt.start, tt.end)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/availability/repository"
+)
+
+// ResolveParticipant returns the ID of the participant referenced on a public link by the secret token
+// of their personal link. Participant IDs are refused on every calendar, so that a participant can't
+// change the availabilities of another one by knowing their ID.
+func (s *AvailabilityService) ResolveParticipant(ctx context.Context, token, ref string) (uuid.UUID, error) {
+	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return uuid.Nil, ErrCalendarNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	if isParticipantID(ref) {
+		return uuid.Nil, ErrParticipantTokenRequired
+	}
+
+	participant, err := s.participantRepo.GetByAccessToken(ctx, calendarInfo.ID, ref)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return uuid.Nil, ErrParticipantNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	return participant.ID, nil
+}
+
+// isParticipantID reports whether a participant reference is an ID rather than an access token
+func isParticipantID(ref string) bool {
+	_, err := uuid.Parse(ref)
+	return err == nil
+}
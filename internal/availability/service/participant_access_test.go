@@ -0,0 +1,99 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/availability/repository"
+)
+
+type fakeCalendarRepository struct {
+	calendar *repository.Calendar
+}
+
+func (f *fakeCalendarRepository) GetByPublicToken(ctx context.Context, token string) (uuid.UUID, error) {
+	return f.calendar.ID, nil
+}
+
+func (f *fakeCalendarRepository) GetCalendarInfoByPublicToken(ctx context.Context, token string) (*repository.Calendar, error) {
+	if token != "public" {
+		return nil, repository.ErrCalendarNotFound
+	}
+	return f.calendar, nil
+}
+
+func (f *fakeCalendarRepository) GetConfirmationStatus(ctx context.Context, calendarID uuid.UUID, date time.Time) (string, error) {
+	return "", nil
+}
+
+type fakeParticipantRepository struct {
+	participants map[string]*repository.Participant // by access token
+}
+
+func (f *fakeParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*repository.Participant, error) {
+	for _, p := range f.participants {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, repository.ErrParticipantNotFound
+}
+
+func (f *fakeParticipantRepository) GetByCalendarID(ctx context.Context, calendarID uuid.UUID) ([]*repository.Participant, error) {
+	var participants []*repository.Participant
+	for _, p := range f.participants {
+		participants = append(participants, p)
+	}
+	return participants, nil
+}
+
+func (f *fakeParticipantRepository) GetByAccessToken(ctx context.Context, calendarID uuid.UUID, token string) (*repository.Participant, error) {
+	if p, ok := f.participants[token]; ok && p.CalendarID == calendarID {
+		return p, nil
+	}
+	return nil, repository.ErrParticipantNotFound
+}
+
+func TestResolveParticipant(t *testing.T) {
+	calendarID := uuid.New()
+	participant := &repository.Participant{ID: uuid.New(), CalendarID: calendarID, Name: "Alice"}
+	accessToken := strings.ReplaceAll(uuid.NewString()+uuid.NewString(), "-", "")
+
+	tests := []struct {
+		name    string
+		token   string
+		ref     string
+		locked  bool
+		wantID  uuid.UUID
+		wantErr error
+	}{
+		{"token on open calendar", "public", accessToken, false, participant.ID, nil},
+		{"token on locked calendar", "public", accessToken, true, participant.ID, nil},
+		{"ID on open calendar", "public", participant.ID.String(), false, uuid.Nil, ErrParticipantTokenRequired},
+		{"ID on locked calendar", "public", participant.ID.String(), true, uuid.Nil, ErrParticipantTokenRequired},
+		{"ID without dashes", "public", strings.ReplaceAll(participant.ID.String(), "-", ""), false, uuid.Nil, ErrParticipantTokenRequired},
+		{"unknown token", "public", strings.Repeat("0", 64), false, uuid.Nil, ErrParticipantNotFound},
+		{"unknown calendar", "other", accessToken, false, uuid.Nil, ErrCalendarNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AvailabilityService{
+				calendarRepo:    &fakeCalendarRepository{calendar: &repository.Calendar{ID: calendarID, LockParticipants: tt.locked}},
+				participantRepo: &fakeParticipantRepository{participants: map[string]*repository.Participant{accessToken: participant}},
+			}
+			id, err := s.ResolveParticipant(context.Background(), tt.token, tt.ref)
+			if !errors.Is(err, tt.wantErr) || id != tt.wantID {
+				t.Errorf("ResolveParticipant(%q) = %v, %v, want %v, %v", tt.ref, id, err, tt.wantID, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/whento/pkg/datevalidation"
+	"github.com/whento/whento/internal/availability/models"
+	"github.com/whento/whento/internal/availability/repository"
+)
+
+// SetRSVP records the answer of a participant to an event of their calendar
+func (s *AvailabilityService) SetRSVP(ctx context.Context, token, participantID, dateStr string, req *models.SetRSVPRequest) (*models.RSVP, error) {
+	date, err := parseDate(dateStr)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+
+	participant, err := s.calendarParticipant(ctx, token, participantID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarInfo, err := s.calendarRepo.GetCalendarInfoByPublicToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+	if err := s.checkEventDate(ctx, calendarInfo, date); err != nil {
+		return nil, err
+	}
+
+	return s.availabilityRepo.SetRSVP(ctx, calendarInfo.ID, participant.ID, date, req.Status)
+}
+
+// DeleteRSVP withdraws the answer of a participant to an event, whatever the date became since
+func (s *AvailabilityService) DeleteRSVP(ctx context.Context, token, participantID, dateStr string) error {
+	date, err := parseDate(dateStr)
+	if err != nil {
+		return ErrInvalidDate
+	}
+
+	participant, err := s.calendarParticipant(ctx, token, participantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.availabilityRepo.DeleteRSVP(ctx, participant.ID, date); err != nil {
+		if errors.Is(err, repository.ErrRSVPNotFound) {
+			return ErrRSVPNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkEventDate checks that a date is an event participants can answer: it reaches the threshold
+// and, on calendars requiring confirmation, was confirmed by the owner
+func (s *AvailabilityService) checkEventDate(ctx context.Context, calendarInfo *repository.Calendar, date time.Time) error {
+	if datevalidation.IsBlackedOut(date, calendarInfo.BlackoutDates) {
+		return ErrNotAnEvent
+	}
+
+	count, err := s.availabilityRepo.GetParticipantCountForDate(ctx, calendarInfo.ID, date)
+	if err != nil {
+		return err
+	}
+	if !count.Reaches(calendarInfo.Threshold) {
+		return ErrNotAnEvent
+	}
+
+	if calendarInfo.RequireConfirm {
+		status, err := s.calendarRepo.GetConfirmationStatus(ctx, calendarInfo.ID, date)
+		if err != nil {
+			return err
+		}
+		if status != "confirmed" {
+			return ErrNotAnEvent
+		}
+	}
+	return nil
+}
+
+// countRSVPs counts the answers of each kind
+func countRSVPs(rsvps []models.RSVP) models.RSVPCount {
+	var count models.RSVPCount
+	for _, rsvp := range rsvps {
+		switch rsvp.Status {
+		case models.RSVPGoing:
+			count.Going++
+		case models.RSVPNotGoing:
+			count.NotGoing++
+		}
+	}
+	return count
+}
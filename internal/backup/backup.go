@@ -0,0 +1,352 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+// Package backup exports a whole instance to a portable archive and imports it into a fresh install.
+//
+// The archive is a zip file holding a manifest.json and one JSON Lines file per table
+// (data/<table>.jsonl, one JSON object per row keyed by column name). It doesn't depend on
+// PostgreSQL types or dumps, so it can be imported into any backend supporting the same schema.
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FormatVersion is the version of the archive layout
+const FormatVersion = 1
+
+const (
+	manifestName = "manifest.json"
+	dataDir      = "data/"
+	batchSize    = 500
+)
+
+var (
+	ErrInvalidArchive = errors.New("invalid archive")
+	ErrSchemaMismatch = errors.New("schema version mismatch")
+	ErrNotEmpty       = errors.New("database is not empty")
+)
+
+// tables lists the exported tables in dependency order (parents before children)
+// Sessions (refresh_tokens) are not exported: users sign in again on the new instance
+// Neither are recent hook events, webhook deliveries and CalDAV busy blocks, which are rebuilt as events happen and accounts sync,
+// nor pending integration login flows and personal data exports
+var tables = []string{
+	"users",
+	"passkeys",
+	"user_mfa",
+	"user_identities",
+	"caldav_accounts",
+	"app_passwords",
+	"personal_access_tokens",
+	"directory_connections",
+	"organizations",
+	"organization_members",
+	"calendars",
+	"rest_hooks",
+	"webhooks",
+	"calendar_api_tokens",
+	"participants",
+	"recurrences",
+	"recurrence_exceptions",
+	"availabilities",
+	"notification_log",
+	"calendar_changes",
+}
+
+// Tables returns the tables exported by this build, in import order
+func Tables() []string {
+	return tables
+}
+
+// Manifest describes the content of an archive
+type Manifest struct {
+	Format        int             `json:"format"`
+	BuildType     string          `json:"build_type"`
+	SchemaVersion int             `json:"schema_version"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Tables        []TableManifest `json:"tables"`
+}
+
+// TableManifest describes an exported table
+type TableManifest struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    int      `json:"rows"`
+}
+
+// Export writes all the exported tables to w as a zip archive
+// Tables are read in a single repeatable read transaction, so the archive is a consistent snapshot
+func Export(ctx context.Context, pool *pgxpool.Pool, w io.Writer, buildType string, schemaVersion int) (*Manifest, error) {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	manifest := &Manifest{
+		Format:        FormatVersion,
+		BuildType:     buildType,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	archive := zip.NewWriter(w)
+
+	for _, table := range tables {
+		columns, err := tableColumns(ctx, tx, table)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := archive.Create(dataDir + table + ".jsonl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to write archive: %w", err)
+		}
+
+		count, err := exportTable(ctx, tx, table, file)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Tables = append(manifest.Tables, TableManifest{Name: table, Columns: columns, Rows: count})
+	}
+
+	file, err := archive.Create(manifestName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// exportTable writes one JSON object per row and returns the number of rows
+func exportTable(ctx context.Context, tx pgx.Tx, table string, w io.Writer) (int, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf(`SELECT row_to_json(t)::text FROM %s t`, pgx.Identifier{table}.Sanitize()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to export %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	buf := bufio.NewWriter(w)
+	count := 0
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return 0, fmt.Errorf("failed to export %s: %w", table, err)
+		}
+		if _, err := buf.WriteString(row + "\n"); err != nil {
+			return 0, fmt.Errorf("failed to write archive: %w", err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to export %s: %w", table, err)
+	}
+
+	if err := buf.Flush(); err != nil {
+		return 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return count, nil
+}
+
+// ReadManifest reads and validates the manifest of an archive
+func ReadManifest(archive *zip.Reader) (*Manifest, error) {
+	file, err := archive.Open(manifestName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidArchive, manifestName)
+	}
+	defer file.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(file).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if manifest.Format != FormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidArchive, manifest.Format)
+	}
+
+	return &manifest, nil
+}
+
+// validateManifest checks that the archive can be imported into a database at schemaVersion
+func validateManifest(manifest *Manifest, schemaVersion int) error {
+	if schemaVersion < manifest.SchemaVersion {
+		return fmt.Errorf("%w: archive is at version %d, database at version %d (run 'whento migrate up' first)",
+			ErrSchemaMismatch, manifest.SchemaVersion, schemaVersion)
+	}
+
+	for _, table := range manifest.Tables {
+		if !slices.Contains(tables, table.Name) {
+			return fmt.Errorf("%w: table %s is not supported by this build", ErrInvalidArchive, table.Name)
+		}
+		if len(table.Columns) == 0 {
+			return fmt.Errorf("%w: table %s has no columns", ErrInvalidArchive, table.Name)
+		}
+	}
+
+	return nil
+}
+
+// Import loads an archive into an empty database, in a single transaction
+// The database must have been migrated to the archive schema version (or a later one)
+func Import(ctx context.Context, pool *pgxpool.Pool, archive *zip.Reader, schemaVersion int) (*Manifest, error) {
+	manifest, err := ReadManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateManifest(manifest, schemaVersion); err != nil {
+		return nil, err
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, table := range tables {
+		var exists bool
+		query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s)`, pgx.Identifier{table}.Sanitize())
+		if err := tx.QueryRow(ctx, query).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", table, err)
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: table %s already has rows, import requires a fresh install", ErrNotEmpty, table)
+		}
+	}
+
+	// Import in this build's dependency order rather than the archive order
+	for _, table := range tables {
+		index := slices.IndexFunc(manifest.Tables, func(t TableManifest) bool { return t.Name == table })
+		if index == -1 {
+			continue
+		}
+		if err := importTable(ctx, tx, archive, manifest.Tables[index]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// importTable inserts the rows of a table by batches
+// Only the archived columns are inserted, so columns added by later migrations get their default value
+func importTable(ctx context.Context, tx pgx.Tx, archive *zip.Reader, table TableManifest) error {
+	columns, err := tableColumns(ctx, tx, table.Name)
+	if err != nil {
+		return err
+	}
+	for _, column := range table.Columns {
+		if !slices.Contains(columns, column) {
+			return fmt.Errorf("%w: unknown column %s.%s", ErrSchemaMismatch, table.Name, column)
+		}
+	}
+
+	file, err := archive.Open(dataDir + table.Name + ".jsonl")
+	if err != nil {
+		return fmt.Errorf("%w: missing data for %s", ErrInvalidArchive, table.Name)
+	}
+	defer file.Close()
+
+	query := insertQuery(table)
+	batch := make([]string, 0, batchSize)
+	count := 0
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := tx.Exec(ctx, query, "["+strings.Join(batch, ",")+"]"); err != nil {
+			return fmt.Errorf("failed to import %s: %w", table.Name, err)
+		}
+		count += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		batch = append(batch, line)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: failed to read %s: %v", ErrInvalidArchive, table.Name, err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if count != table.Rows {
+		return fmt.Errorf("%w: %s has %d rows, manifest says %d", ErrInvalidArchive, table.Name, count, table.Rows)
+	}
+	return nil
+}
+
+// insertQuery builds the statement inserting a JSON array of rows into a table
+func insertQuery(table TableManifest) string {
+	columns := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		columns[i] = pgx.Identifier{column}.Sanitize()
+	}
+	name := pgx.Identifier{table.Name}.Sanitize()
+	list := strings.Join(columns, ", ")
+
+	return fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM json_populate_recordset(NULL::%s, $1::json)`, name, list, list, name)
+}
+
+// tableColumns returns the columns of a table, in definition order
+func tableColumns(ctx context.Context, tx pgx.Tx, table string) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+		ORDER BY ordinal_position
+	`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+
+	columns, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist", table)
+	}
+	return columns, nil
+}
@@ -0,0 +1,12 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build cloud
+
+package backup
+
+func init() {
+	// Shop sessions and the VAT rates cache are transient and not exported
+	tables = append(tables, "subscriptions", "clients", "orders", "sold_licenses")
+}
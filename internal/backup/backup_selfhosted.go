@@ -0,0 +1,11 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build selfhosted
+
+package backup
+
+func init() {
+	tables = append(tables, "licenses")
+}
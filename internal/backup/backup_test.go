@@ -0,0 +1,149 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func buildArchive(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestReadManifest(t *testing.T) {
+	archive := buildArchive(t, map[string]string{
+		"manifest.json":    `{"format": 1, "build_type": "selfhosted", "schema_version": 13, "tables": [{"name": "users", "columns": ["id", "email"], "rows": 2}]}`,
+		"data/users.jsonl": "{}\n{}\n",
+	})
+
+	manifest, err := ReadManifest(archive)
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+	if manifest.SchemaVersion != 13 || manifest.BuildType != "selfhosted" {
+		t.Errorf("ReadManifest() = %+v", manifest)
+	}
+	if len(manifest.Tables) != 1 || manifest.Tables[0].Rows != 2 {
+		t.Errorf("ReadManifest() tables = %+v", manifest.Tables)
+	}
+}
+
+func TestReadManifest_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{"missing manifest", map[string]string{"data/users.jsonl": "{}\n"}},
+		{"malformed manifest", map[string]string{"manifest.json": "not json"}},
+		{"unsupported format", map[string]string{"manifest.json": `{"format": 99}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadManifest(buildArchive(t, tt.files))
+			if !errors.Is(err, ErrInvalidArchive) {
+				t.Errorf("ReadManifest() error = %v, want ErrInvalidArchive", err)
+			}
+		})
+	}
+}
+
+func TestValidateManifest(t *testing.T) {
+	users := TableManifest{Name: "users", Columns: []string{"id", "email"}}
+
+	tests := []struct {
+		name          string
+		manifest      Manifest
+		schemaVersion int
+		wantErr       error
+	}{
+		{"same schema", Manifest{SchemaVersion: 13, Tables: []TableManifest{users}}, 13, nil},
+		{"newer database", Manifest{SchemaVersion: 12, Tables: []TableManifest{users}}, 13, nil},
+		{"older database", Manifest{SchemaVersion: 14, Tables: []TableManifest{users}}, 13, ErrSchemaMismatch},
+		{"unknown table", Manifest{SchemaVersion: 13, Tables: []TableManifest{{Name: "pg_authid", Columns: []string{"rolname"}}}}, 13, ErrInvalidArchive},
+		{"no columns", Manifest{SchemaVersion: 13, Tables: []TableManifest{{Name: "users"}}}, 13, ErrInvalidArchive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateManifest(&tt.manifest, tt.schemaVersion)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("validateManifest() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateManifest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInsertQuery(t *testing.T) {
+	got := insertQuery(TableManifest{Name: "users", Columns: []string{"id", "email"}})
+	want := `INSERT INTO "users" ("id", "email") SELECT "id", "email" FROM json_populate_recordset(NULL::"users", $1::json)`
+	if got != want {
+		t.Errorf("insertQuery() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTables_ParentsFirst(t *testing.T) {
+	// Each child table must come after the tables it references
+	parents := map[string][]string{
+		"passkeys":               {"users"},
+		"user_mfa":               {"users"},
+		"user_identities":        {"users"},
+		"caldav_accounts":        {"users"},
+		"app_passwords":          {"users"},
+		"personal_access_tokens": {"users"},
+		"directory_connections":  {"users"},
+		"organization_members":   {"organizations", "users"},
+		"calendars":              {"users", "organizations"},
+		"rest_hooks":             {"users", "calendars"},
+		"webhooks":               {"users", "calendars"},
+		"calendar_api_tokens":    {"users", "calendars"},
+		"participants":           {"calendars"},
+		"recurrences":            {"participants"},
+		"recurrence_exceptions":  {"recurrences"},
+		"availabilities":         {"participants", "recurrences"},
+		"notification_log":       {"calendars"},
+		"calendar_changes":       {"calendars", "users"},
+	}
+
+	position := make(map[string]int)
+	for i, table := range Tables() {
+		position[table] = i
+	}
+
+	for child, refs := range parents {
+		for _, parent := range refs {
+			if position[parent] >= position[child] {
+				t.Errorf("table %s must be imported before %s", parent, child)
+			}
+		}
+	}
+}
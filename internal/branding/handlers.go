@@ -0,0 +1,49 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package branding
+
+import (
+	"net/http"
+
+	"github.com/whento/pkg/httputil"
+
+	"github.com/whento/whento/internal/config"
+)
+
+// BrandingResponse represents the instance branding exposed to the frontend
+type BrandingResponse struct {
+	ProductName  string `json:"product_name" example:"WhenTo"`
+	LogoURL      string `json:"logo_url,omitempty" example:"https://example.com/logo.svg"`
+	PrimaryColor string `json:"primary_color" example:"#4F46E5"`
+	FooterText   string `json:"footer_text,omitempty" example:"Planned with care by the Riverside Club"`
+}
+
+type Handler struct {
+	branding config.BrandingConfig
+}
+
+func NewHandler(branding config.BrandingConfig) *Handler {
+	return &Handler{
+		branding: branding,
+	}
+}
+
+// GetBranding returns the instance branding
+//
+//	@Summary		Get instance branding
+//	@Description	Returns the white-label branding configured for this instance (product name, logo, primary color, footer text). Public endpoint read by the frontend at startup.
+//	@Tags			Branding
+//	@Produce		json
+//	@Success		200	{object}	BrandingResponse
+//	@Router			/api/v1/branding [get]
+func (h *Handler) GetBranding(w http.ResponseWriter, r *http.Request) {
+	httputil.JSON(w, http.StatusOK, BrandingResponse{
+		ProductName:  h.branding.ProductName,
+		LogoURL:      h.branding.LogoURL,
+		PrimaryColor: h.branding.PrimaryColor,
+		FooterText:   h.branding.FooterText,
+	})
+}
@@ -0,0 +1,202 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/caldav/models"
+	"github.com/whento/whento/internal/caldav/service"
+)
+
+// CalDAVHandler handles CalDAV account HTTP requests
+type CalDAVHandler struct {
+	service *service.CalDAVService
+	logger  *slog.Logger
+}
+
+// NewCalDAVHandler creates a new CalDAV handler
+func NewCalDAVHandler(service *service.CalDAVService, logger *slog.Logger) *CalDAVHandler {
+	return &CalDAVHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		Get the CalDAV account
+// @Description	Returns the connected CalDAV account of the current user and its sync status
+// @Tags			CalDAV
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	models.AccountResponse	"CalDAV account"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"No CalDAV account connected"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/caldav/account [get]
+func (h *CalDAVHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	account, err := h.service.GetAccount(r.Context(), userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to get CalDAV account")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, account.ToResponse())
+}
+
+// @Summary		Connect a CalDAV account
+// @Description	Connects (or replaces) the CalDAV account of the current user, e.g. Nextcloud or Fastmail. Event calendars are discovered from the server URL, then busy time is synced periodically so threshold events in the iCalendar feeds avoid the organizer's existing commitments. Use an app password.
+// @Tags			CalDAV
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			request	body		models.ConnectRequest	true	"CalDAV account"
+// @Success		200		{object}	models.AccountResponse	"Account connected"
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid request, rejected credentials or unreachable server"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		500		{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/caldav/account [put]
+func (h *CalDAVHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ConnectRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	account, err := h.service.Connect(r.Context(), userUUID, &req)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to connect CalDAV account")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, account.ToResponse())
+}
+
+// @Summary		Disconnect the CalDAV account
+// @Description	Deletes the CalDAV account of the current user and the synced busy time
+// @Tags			CalDAV
+// @Security		BearerAuth
+// @Success		204	"Account disconnected"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"No CalDAV account connected"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/caldav/account [delete]
+func (h *CalDAVHandler) Disconnect(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Disconnect(r.Context(), userUUID); err != nil {
+		h.handleError(w, err, userUUID, "Failed to disconnect CalDAV account")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Sync the CalDAV account
+// @Description	Pulls the busy time of the current user immediately instead of waiting for the periodic sync
+// @Tags			CalDAV
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	models.AccountResponse	"Account synced"
+// @Failure		400	{object}	httputil.ErrorResponse	"Rejected credentials or unreachable server"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"No CalDAV account connected"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/caldav/account/sync [post]
+func (h *CalDAVHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	account, err := h.service.Sync(r.Context(), userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to sync CalDAV account")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, account.ToResponse())
+}
+
+// @Summary		Get busy time
+// @Description	Returns the synced busy blocks of the current user overlapping a date range (at most one year), for the busy overlay of the calendar views
+// @Tags			CalDAV
+// @Produce		json
+// @Security		BearerAuth
+// @Param			start	query		string					true	"Start date (YYYY-MM-DD)"
+// @Param			end		query		string					true	"End date (YYYY-MM-DD, inclusive)"
+// @Param			tz		query		string					false	"IANA timezone of the dates (defaults to UTC)"
+// @Success		200		{array}		models.BusyBlock		"Busy blocks"
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid date range or timezone"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		500		{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/caldav/busy [get]
+func (h *CalDAVHandler) GetBusy(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	blocks, err := h.service.GetBusyBlocks(r.Context(), userUUID, query.Get("start"), query.Get("end"), query.Get("tz"))
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to get busy time")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, blocks)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *CalDAVHandler) handleError(w http.ResponseWriter, err error, userID uuid.UUID, defaultMsg string) {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "No CalDAV account connected")
+	case errors.Is(err, service.ErrInvalidServer), errors.Is(err, service.ErrUnauthorized),
+		errors.Is(err, service.ErrNoCalendar), errors.Is(err, service.ErrServer):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+	case errors.Is(err, service.ErrInvalidRange):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid date range, expected start and end dates (YYYY-MM-DD) at most one year apart")
+	case errors.Is(err, service.ErrInvalidTimezone):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid timezone, expected an IANA timezone name")
+	default:
+		h.logger.Error(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
+	}
+}
+
+// userID returns the authenticated user ID, writing an error response if missing
+func (h *CalDAVHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
@@ -0,0 +1,69 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Account is the CalDAV account of a user, whose busy time is synced periodically
+type Account struct {
+	UserID        uuid.UUID
+	ServerURL     string
+	Username      string
+	Password      string
+	CalendarURLs  []string // Event calendars discovered when connecting
+	LastSyncAt    *time.Time
+	LastSyncError *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// BusyBlock is a time range during which the user is busy
+type BusyBlock struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// BusyFeed is an external ICS feed attached by a participant, synced along with the CalDAV accounts
+// to flag the availabilities conflicting with their existing commitments
+type BusyFeed struct {
+	ID            uuid.UUID
+	ParticipantID uuid.UUID
+	URL           string
+	Timezone      string // Timezone of the participant's calendar, for all-day and floating events
+}
+
+// ConnectRequest represents a request to connect a CalDAV account
+type ConnectRequest struct {
+	ServerURL string `json:"server_url" validate:"required,url,max=2048"` // e.g. https://cloud.example.com/remote.php/dav
+	Username  string `json:"username" validate:"required,max=255"`
+	Password  string `json:"password" validate:"required,max=1024"` // App password recommended
+}
+
+// AccountResponse is the API response for a CalDAV account (the password is never returned)
+type AccountResponse struct {
+	ServerURL     string     `json:"server_url"`
+	Username      string     `json:"username"`
+	Calendars     int        `json:"calendars"`
+	LastSyncAt    *time.Time `json:"last_sync_at,omitempty"`
+	LastSyncError *string    `json:"last_sync_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ToResponse converts an Account to AccountResponse
+func (a *Account) ToResponse() *AccountResponse {
+	return &AccountResponse{
+		ServerURL:     a.ServerURL,
+		Username:      a.Username,
+		Calendars:     len(a.CalendarURLs),
+		LastSyncAt:    a.LastSyncAt,
+		LastSyncError: a.LastSyncError,
+		CreatedAt:     a.CreatedAt,
+	}
+}
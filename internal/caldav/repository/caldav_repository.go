@@ -0,0 +1,271 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/caldav/models"
+)
+
+var (
+	ErrAccountNotFound  = errors.New("caldav account not found")
+	ErrBusyFeedNotFound = errors.New("busy feed not found")
+)
+
+// CalDAVRepository handles CalDAV accounts and their synced busy blocks
+type CalDAVRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCalDAVRepository creates a new CalDAV repository
+func NewCalDAVRepository(pool *pgxpool.Pool) *CalDAVRepository {
+	return &CalDAVRepository{pool: pool}
+}
+
+const accountColumns = `user_id, server_url, username, password, calendar_urls, last_sync_at, last_sync_error, created_at, updated_at`
+
+// Upsert creates or replaces the account of a user
+func (r *CalDAVRepository) Upsert(ctx context.Context, account *models.Account) error {
+	query := `
+		INSERT INTO caldav_accounts (user_id, server_url, username, password, calendar_urls, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			server_url = EXCLUDED.server_url,
+			username = EXCLUDED.username,
+			password = EXCLUDED.password,
+			calendar_urls = EXCLUDED.calendar_urls,
+			last_sync_at = NULL,
+			last_sync_error = NULL,
+			updated_at = NOW()
+		RETURNING created_at, updated_at`
+
+	err := r.pool.QueryRow(ctx, query, account.UserID, account.ServerURL, account.Username, account.Password, account.CalendarURLs).
+		Scan(&account.CreatedAt, &account.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save caldav account: %w", err)
+	}
+	return nil
+}
+
+// GetByUserID returns the account of a user
+func (r *CalDAVRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Account, error) {
+	query := `SELECT ` + accountColumns + ` FROM caldav_accounts WHERE user_id = $1`
+
+	account, err := scanAccount(r.pool.QueryRow(ctx, query, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get caldav account: %w", err)
+	}
+	return account, nil
+}
+
+// ListAll returns all accounts, for the periodic sync
+func (r *CalDAVRepository) ListAll(ctx context.Context) ([]*models.Account, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+accountColumns+` FROM caldav_accounts ORDER BY last_sync_at NULLS FIRST`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list caldav accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*models.Account
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan caldav account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
+
+// Delete deletes the account of a user (busy blocks are deleted by cascade)
+func (r *CalDAVRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM caldav_accounts WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete caldav account: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAccountNotFound
+	}
+	return nil
+}
+
+// ReplaceBusyBlocks replaces the busy blocks of a user and records a successful sync
+func (r *CalDAVRepository) ReplaceBusyBlocks(ctx context.Context, userID uuid.UUID, blocks []models.BusyBlock) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM caldav_busy_blocks WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete busy blocks: %w", err)
+	}
+
+	if len(blocks) > 0 {
+		rows := make([][]any, len(blocks))
+		for i, block := range blocks {
+			rows[i] = []any{userID, block.Start, block.End}
+		}
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{"caldav_busy_blocks"}, []string{"user_id", "start_at", "end_at"}, pgx.CopyFromRows(rows))
+		if err != nil {
+			return fmt.Errorf("failed to insert busy blocks: %w", err)
+		}
+	}
+
+	result, err := tx.Exec(ctx, `UPDATE caldav_accounts SET last_sync_at = NOW(), last_sync_error = NULL WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update sync status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		// Account deleted during the sync
+		return ErrAccountNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetSyncError records a failed sync, keeping the previously synced busy blocks
+func (r *CalDAVRepository) SetSyncError(ctx context.Context, userID uuid.UUID, syncErr string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE caldav_accounts SET last_sync_error = $2 WHERE user_id = $1`, userID, syncErr)
+	return err
+}
+
+// ListBusyBlocks returns the busy blocks of a user overlapping [from, to), ordered by start
+func (r *CalDAVRepository) ListBusyBlocks(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]models.BusyBlock, error) {
+	query := `
+		SELECT start_at, end_at
+		FROM caldav_busy_blocks
+		WHERE user_id = $1 AND start_at < $3 AND end_at > $2
+		ORDER BY start_at`
+
+	rows, err := r.pool.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list busy blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []models.BusyBlock
+	for rows.Next() {
+		var block models.BusyBlock
+		if err := rows.Scan(&block.Start, &block.End); err != nil {
+			return nil, fmt.Errorf("failed to scan busy block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, rows.Err()
+}
+
+const busyFeedQuery = `
+	SELECT f.id, f.participant_id, f.url, c.timezone
+	FROM participant_busy_feeds f
+	JOIN participants p ON p.id = f.participant_id
+	JOIN calendars c ON c.id = p.calendar_id`
+
+// GetBusyFeed returns a participant busy feed
+func (r *CalDAVRepository) GetBusyFeed(ctx context.Context, feedID uuid.UUID) (*models.BusyFeed, error) {
+	var feed models.BusyFeed
+	err := r.pool.QueryRow(ctx, busyFeedQuery+` WHERE f.id = $1`, feedID).
+		Scan(&feed.ID, &feed.ParticipantID, &feed.URL, &feed.Timezone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBusyFeedNotFound
+		}
+		return nil, fmt.Errorf("failed to get busy feed: %w", err)
+	}
+	return &feed, nil
+}
+
+// ListBusyFeeds returns every participant busy feed
+func (r *CalDAVRepository) ListBusyFeeds(ctx context.Context) ([]*models.BusyFeed, error) {
+	rows, err := r.pool.Query(ctx, busyFeedQuery+` ORDER BY f.created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list busy feeds: %w", err)
+	}
+	defer rows.Close()
+
+	var feeds []*models.BusyFeed
+	for rows.Next() {
+		var feed models.BusyFeed
+		if err := rows.Scan(&feed.ID, &feed.ParticipantID, &feed.URL, &feed.Timezone); err != nil {
+			return nil, fmt.Errorf("failed to scan busy feed: %w", err)
+		}
+		feeds = append(feeds, &feed)
+	}
+	return feeds, rows.Err()
+}
+
+// ReplaceFeedBlocks replaces the busy blocks of a participant feed and records a successful sync
+func (r *CalDAVRepository) ReplaceFeedBlocks(ctx context.Context, feed *models.BusyFeed, blocks []models.BusyBlock) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM participant_busy_blocks WHERE feed_id = $1`, feed.ID); err != nil {
+		return fmt.Errorf("failed to delete busy blocks: %w", err)
+	}
+
+	if len(blocks) > 0 {
+		rows := make([][]any, len(blocks))
+		for i, block := range blocks {
+			rows[i] = []any{feed.ID, feed.ParticipantID, block.Start, block.End}
+		}
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{"participant_busy_blocks"}, []string{"feed_id", "participant_id", "start_at", "end_at"}, pgx.CopyFromRows(rows))
+		if err != nil {
+			return fmt.Errorf("failed to insert busy blocks: %w", err)
+		}
+	}
+
+	result, err := tx.Exec(ctx, `UPDATE participant_busy_feeds SET last_sync_at = NOW(), last_sync_error = NULL WHERE id = $1`, feed.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update sync status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		// Feed detached during the sync
+		return ErrBusyFeedNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetFeedSyncError records a failed sync of a participant feed, keeping its previously synced busy blocks
+func (r *CalDAVRepository) SetFeedSyncError(ctx context.Context, feedID uuid.UUID, syncErr string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE participant_busy_feeds SET last_sync_error = $2 WHERE id = $1`, feedID, syncErr)
+	return err
+}
+
+func scanAccount(row pgx.Row) (*models.Account, error) {
+	var account models.Account
+	err := row.Scan(
+		&account.UserID,
+		&account.ServerURL,
+		&account.Username,
+		&account.Password,
+		&account.CalendarURLs,
+		&account.LastSyncAt,
+		&account.LastSyncError,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
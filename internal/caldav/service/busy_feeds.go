@@ -0,0 +1,115 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/caldav/models"
+)
+
+// SyncFeed pulls the busy time of a participant ICS feed immediately
+// On failure, the previous blocks are kept and the error is recorded on the feed
+func (s *CalDAVService) SyncFeed(ctx context.Context, feedID uuid.UUID) error {
+	feed, err := s.repo.GetBusyFeed(ctx, feedID)
+	if err != nil {
+		return err
+	}
+	return s.syncFeed(ctx, feed)
+}
+
+// syncFeeds pulls the busy time of every participant ICS feed
+func (s *CalDAVService) syncFeeds(ctx context.Context) {
+	feeds, err := s.repo.ListBusyFeeds(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list busy feeds", "error", err)
+		return
+	}
+
+	failed := 0
+	for _, feed := range feeds {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.syncFeed(ctx, feed); err != nil {
+			failed++
+			s.logger.Warn("Busy feed sync failed", "feed_id", feed.ID, "error", err)
+		}
+	}
+
+	s.logger.Info("Busy feed sync completed", "feeds", len(feeds), "failed", failed)
+}
+
+// syncFeed replaces the busy blocks of a feed with those of the next syncDays days
+func (s *CalDAVService) syncFeed(ctx context.Context, feed *models.BusyFeed) error {
+	loc := time.UTC
+	if l, err := time.LoadLocation(feed.Timezone); err == nil {
+		loc = l
+	}
+
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, s.syncDays)
+
+	blocks, err := s.fetchFeed(ctx, feed.URL, from, to, loc)
+	if err != nil {
+		if recordErr := s.repo.SetFeedSyncError(ctx, feed.ID, err.Error()); recordErr != nil {
+			s.logger.Error("Failed to record busy feed sync error", "feed_id", feed.ID, "error", recordErr)
+		}
+		return err
+	}
+
+	return s.repo.ReplaceFeedBlocks(ctx, feed, mergeBusyBlocks(blocks))
+}
+
+// fetchFeed downloads an ICS feed and returns the time ranges of its events overlapping [from, to)
+// Recurring events are only counted for their first occurrence, as in most busy feeds they are already expanded
+func (s *CalDAVService) fetchFeed(ctx context.Context, feedURL string, from, to time.Time, loc *time.Location) ([]models.BusyBlock, error) {
+	if err := s.validateServer(feedURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+	req.Header.Set("User-Agent", "WhenTo-CalDAV/1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrServer, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %w", ErrServer, &statusError{method: http.MethodGet, code: resp.StatusCode})
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrServer, err)
+	}
+
+	parsed, err := parseBusyBlocks(string(data), loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ICS feed: %w", err)
+	}
+
+	var blocks []models.BusyBlock
+	for _, block := range parsed {
+		if block.End.After(from) && block.Start.Before(to) {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks, nil
+}
@@ -0,0 +1,304 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	authRepo "github.com/whento/whento/internal/auth/repository"
+	"github.com/whento/whento/internal/caldav/models"
+	"github.com/whento/whento/internal/caldav/repository"
+	"github.com/whento/whento/internal/config"
+)
+
+// maxBusyRange limits the range of a busy blocks query
+const maxBusyRange = 366 * 24 * time.Hour
+
+var (
+	ErrAccountNotFound = repository.ErrAccountNotFound
+	ErrInvalidServer   = errors.New("invalid CalDAV server URL")
+	ErrInvalidRange    = errors.New("invalid date range")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+)
+
+// CalDAVService connects the CalDAV account of an organizer and keeps a copy of their busy time,
+// so proposed dates can avoid their existing commitments
+// It also syncs the ICS feeds attached by participants, to flag their conflicting availabilities
+type CalDAVService struct {
+	repo                *repository.CalDAVRepository
+	userRepo            *authRepo.UserRepository
+	httpClient          *http.Client
+	requireHTTPS        bool
+	allowPrivateServers bool
+	syncInterval        time.Duration
+	syncDays            int
+	logger              *slog.Logger
+}
+
+// NewCalDAVService creates a new CalDAV service
+func NewCalDAVService(
+	repo *repository.CalDAVRepository,
+	userRepo *authRepo.UserRepository,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *CalDAVService {
+	syncDays := cfg.CalDAVSyncDays
+	if syncDays <= 0 {
+		syncDays = 180
+	}
+
+	return &CalDAVService{
+		repo:                repo,
+		userRepo:            userRepo,
+		httpClient:          httputil.NewOutboundClient(30*time.Second, cfg.CalDAVAllowPrivateServers),
+		requireHTTPS:        cfg.AppEnv == "production",
+		allowPrivateServers: cfg.CalDAVAllowPrivateServers,
+		syncInterval:        cfg.CalDAVSyncInterval,
+		syncDays:            syncDays,
+		logger:              logger,
+	}
+}
+
+// Connect discovers the event calendars of a CalDAV account, saves it and runs a first sync
+// The account is saved even if the first sync fails; the error is reported in its sync status
+func (s *CalDAVService) Connect(ctx context.Context, userID uuid.UUID, req *models.ConnectRequest) (*models.Account, error) {
+	serverURL := strings.TrimRight(req.ServerURL, "/")
+	if err := s.validateServer(serverURL); err != nil {
+		return nil, err
+	}
+
+	client := NewClient(s.httpClient, req.Username, req.Password)
+	calendars, err := client.DiscoverCalendars(ctx, serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &models.Account{
+		UserID:       userID,
+		ServerURL:    serverURL,
+		Username:     req.Username,
+		Password:     req.Password,
+		CalendarURLs: calendars,
+	}
+	if err := s.repo.Upsert(ctx, account); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("CalDAV account connected", "user_id", userID, "calendars", len(calendars))
+
+	if err := s.sync(ctx, account); err != nil {
+		s.logger.Warn("First CalDAV sync failed", "user_id", userID, "error", err)
+	}
+
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+// GetAccount returns the CalDAV account of a user
+func (s *CalDAVService) GetAccount(ctx context.Context, userID uuid.UUID) (*models.Account, error) {
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+// Disconnect deletes the CalDAV account of a user and their synced busy time
+func (s *CalDAVService) Disconnect(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("CalDAV account disconnected", "user_id", userID)
+	return nil
+}
+
+// Sync pulls the busy time of a user immediately
+func (s *CalDAVService) Sync(ctx context.Context, userID uuid.UUID) (*models.Account, error) {
+	account, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sync(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+// GetBusyBlocks returns the synced busy blocks of a user overlapping the dates from startDate to endDate
+// (YYYY-MM-DD, inclusive) in the given timezone (UTC if empty)
+func (s *CalDAVService) GetBusyBlocks(ctx context.Context, userID uuid.UUID, startDate, endDate, timezone string) ([]models.BusyBlock, error) {
+	loc := time.UTC
+	if timezone != "" {
+		l, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, ErrInvalidTimezone
+		}
+		loc = l
+	}
+
+	from, err := time.ParseInLocation("2006-01-02", startDate, loc)
+	if err != nil {
+		return nil, ErrInvalidRange
+	}
+	end, err := time.ParseInLocation("2006-01-02", endDate, loc)
+	if err != nil {
+		return nil, ErrInvalidRange
+	}
+	to := end.AddDate(0, 0, 1)
+	if !to.After(from) || to.Sub(from) > maxBusyRange {
+		return nil, ErrInvalidRange
+	}
+
+	blocks, err := s.repo.ListBusyBlocks(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if blocks == nil {
+		blocks = []models.BusyBlock{}
+	}
+	return blocks, nil
+}
+
+// SyncAll pulls the busy time of every connected account and participant feed
+func (s *CalDAVService) SyncAll(ctx context.Context) {
+	accounts, err := s.repo.ListAll(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list CalDAV accounts", "error", err)
+		return
+	}
+
+	failed := 0
+	for _, account := range accounts {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.sync(ctx, account); err != nil {
+			failed++
+			s.logger.Warn("CalDAV sync failed", "user_id", account.UserID, "error", err)
+		}
+	}
+
+	s.logger.Info("CalDAV sync completed", "accounts", len(accounts), "failed", failed)
+
+	s.syncFeeds(ctx)
+}
+
+// StartSyncTask syncs all accounts periodically until ctx is cancelled (disabled if the interval is 0)
+func (s *CalDAVService) StartSyncTask(ctx context.Context) {
+	if s.syncInterval <= 0 {
+		s.logger.Info("CalDAV periodic sync disabled")
+		return
+	}
+
+	s.logger.Info("Starting CalDAV sync background task", "interval", s.syncInterval)
+
+	go func() {
+		ticker := time.NewTicker(s.syncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("CalDAV sync task stopped (context cancelled)")
+				return
+			case <-ticker.C:
+				syncCtx, cancel := context.WithTimeout(ctx, s.syncInterval)
+				s.SyncAll(syncCtx)
+				cancel()
+			}
+		}
+	}()
+}
+
+// sync replaces the busy blocks of an account with those of the next syncDays days
+// On failure, the previous blocks are kept and the error is recorded
+func (s *CalDAVService) sync(ctx context.Context, account *models.Account) error {
+	loc := time.UTC
+	if user, err := s.userRepo.GetByID(ctx, account.UserID); err == nil && user.Timezone != "" {
+		if l, err := time.LoadLocation(user.Timezone); err == nil {
+			loc = l
+		}
+	}
+
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, s.syncDays)
+
+	client := NewClient(s.httpClient, account.Username, account.Password)
+
+	var blocks []models.BusyBlock
+	for _, calendarURL := range account.CalendarURLs {
+		calendarBlocks, err := client.FetchBusyBlocks(ctx, calendarURL, from, to, loc)
+		if err != nil {
+			if recordErr := s.repo.SetSyncError(ctx, account.UserID, err.Error()); recordErr != nil {
+				s.logger.Error("Failed to record CalDAV sync error", "user_id", account.UserID, "error", recordErr)
+			}
+			return fmt.Errorf("failed to sync %s: %w", calendarURL, err)
+		}
+		blocks = append(blocks, calendarBlocks...)
+	}
+
+	return s.repo.ReplaceBusyBlocks(ctx, account.UserID, mergeBusyBlocks(blocks))
+}
+
+// validateServer checks the scheme of a server URL and rejects literal private addresses
+// Host names are checked when connecting, since they may resolve differently later
+func (s *CalDAVService) validateServer(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return ErrInvalidServer
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if s.requireHTTPS {
+			return fmt.Errorf("%w: HTTPS is required", ErrInvalidServer)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported scheme %q", ErrInvalidServer, u.Scheme)
+	}
+
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !s.allowPrivateServers && !httputil.IsPublicIP(ip) {
+		return fmt.Errorf("%w: %v", ErrInvalidServer, httputil.ErrPrivateAddress)
+	}
+
+	return nil
+}
+
+// mergeBusyBlocks sorts busy blocks and merges overlapping or adjacent ones
+func mergeBusyBlocks(blocks []models.BusyBlock) []models.BusyBlock {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Start.Before(blocks[j].Start)
+	})
+
+	merged := []models.BusyBlock{blocks[0]}
+	for _, block := range blocks[1:] {
+		last := &merged[len(merged)-1]
+		if block.Start.After(last.End) {
+			merged = append(merged, block)
+			continue
+		}
+		if block.End.After(last.End) {
+			last.End = block.End
+		}
+	}
+	return merged
+}
@@ -0,0 +1,159 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/caldav/models"
+)
+
+func TestParseBusyBlocks(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("Europe/Paris timezone not available")
+	}
+
+	data := `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+BEGIN:VEVENT
+UID:tzid
+DTSTAMP:20250101T000000Z
+DTSTART;TZID=Europe/Paris:20250610T140000
+DTEND;TZID=Europe/Paris:20250610T150000
+END:VEVENT
+BEGIN:VEVENT
+UID:all-day
+DTSTAMP:20250101T000000Z
+DTSTART;VALUE=DATE:20250612
+DTEND;VALUE=DATE:20250613
+END:VEVENT
+BEGIN:VEVENT
+UID:duration
+DTSTAMP:20250101T000000Z
+DTSTART:20250614T090000Z
+DURATION:PT1H30M
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled
+DTSTAMP:20250101T000000Z
+DTSTART:20250615T090000Z
+DTEND:20250615T100000Z
+STATUS:CANCELLED
+END:VEVENT
+BEGIN:VEVENT
+UID:reminder
+DTSTAMP:20250101T000000Z
+DTSTART:20250616T090000Z
+END:VEVENT
+END:VCALENDAR
+`
+
+	blocks, err := parseBusyBlocks(data, paris)
+	if err != nil {
+		t.Fatalf("parseBusyBlocks() error = %v", err)
+	}
+
+	want := []models.BusyBlock{
+		{Start: time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 10, 13, 0, 0, 0, time.UTC)},
+		// All-day events cover the whole day in the user timezone
+		{Start: time.Date(2025, 6, 11, 22, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 12, 22, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2025, 6, 14, 9, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 14, 10, 30, 0, 0, time.UTC)},
+	}
+
+	if len(blocks) != len(want) {
+		t.Fatalf("parseBusyBlocks() returned %d blocks, want %d: %v", len(blocks), len(want), blocks)
+	}
+	for i := range want {
+		if !blocks[i].Start.Equal(want[i].Start) || !blocks[i].End.Equal(want[i].End) {
+			t.Errorf("block %d = %v - %v, want %v - %v", i, blocks[i].Start, blocks[i].End, want[i].Start, want[i].End)
+		}
+	}
+}
+
+func TestParseICalDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H":      time.Hour,
+		"PT1H30M":   90 * time.Minute,
+		"P1D":       24 * time.Hour,
+		"P1W":       7 * 24 * time.Hour,
+		"P1DT2H":    26 * time.Hour,
+		"PT45S":     45 * time.Second,
+		"-PT15M":    -15 * time.Minute,
+		"+P0DT1H0M": time.Hour,
+	}
+
+	for value, want := range tests {
+		got, err := parseICalDuration(value)
+		if err != nil || got != want {
+			t.Errorf("parseICalDuration(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "1H", "PT1X"} {
+		if _, err := parseICalDuration(value); err == nil {
+			t.Errorf("parseICalDuration(%q) should fail", value)
+		}
+	}
+}
+
+func TestMergeBusyBlocks(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2025, 6, 10, hour, 0, 0, 0, time.UTC)
+	}
+
+	blocks := []models.BusyBlock{
+		{Start: at(14), End: at(15)},
+		{Start: at(9), End: at(10)},
+		{Start: at(10), End: at(11)}, // Adjacent
+		{Start: at(9), End: at(10)},  // Duplicate from another calendar
+		{Start: at(14), End: at(14).Add(30 * time.Minute)},
+	}
+
+	merged := mergeBusyBlocks(blocks)
+
+	want := []models.BusyBlock{{Start: at(9), End: at(11)}, {Start: at(14), End: at(15)}}
+	if len(merged) != len(want) {
+		t.Fatalf("mergeBusyBlocks() = %v, want %v", merged, want)
+	}
+	for i := range want {
+		if !merged[i].Start.Equal(want[i].Start) || !merged[i].End.Equal(want[i].End) {
+			t.Errorf("block %d = %v - %v, want %v - %v", i, merged[i].Start, merged[i].End, want[i].Start, want[i].End)
+		}
+	}
+}
+
+func TestValidateServer(t *testing.T) {
+	tests := []struct {
+		name         string
+		server       string
+		requireHTTPS bool
+		allowPrivate bool
+		wantErr      bool
+	}{
+		{name: "https", server: "https://caldav.fastmail.com/dav", wantErr: false},
+		{name: "http in production", server: "http://cloud.example.com/remote.php/dav", requireHTTPS: true, wantErr: true},
+		{name: "unsupported scheme", server: "webcal://cloud.example.com", wantErr: true},
+		{name: "loopback", server: "https://127.0.0.1/remote.php/dav", wantErr: true},
+		{name: "private allowed", server: "http://192.168.1.20/remote.php/dav", allowPrivate: true, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &CalDAVService{requireHTTPS: tt.requireHTTPS, allowPrivateServers: tt.allowPrivate}
+			err := s.validateServer(tt.server)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateServer(%q) error = %v, wantErr %v", tt.server, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidServer) {
+				t.Errorf("validateServer(%q) error = %v, want ErrInvalidServer", tt.server, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/whento/whento/internal/caldav/models"
+)
+
+const (
+	maxResponseBodySize = 20 << 20
+	maxRedirects        = 5
+)
+
+var (
+	ErrUnauthorized = errors.New("the CalDAV server rejected the credentials")
+	ErrNoCalendar   = errors.New("no event calendar found on the CalDAV server")
+	ErrServer       = errors.New("CalDAV request failed") // Unreachable server or unexpected response
+	errNoPrincipal  = errors.New("current-user-principal not found")
+)
+
+// Client is a minimal CalDAV client (RFC 4791): it discovers the event calendars of an account
+// and reads the time ranges of their events
+type Client struct {
+	httpClient *http.Client
+	username   string
+	password   string
+}
+
+// NewClient creates a CalDAV client authenticating with HTTP Basic auth
+// Redirects are followed by the client itself, since net/http turns PROPFIND into GET on 301 and 302
+func NewClient(httpClient *http.Client, username, password string) *Client {
+	noRedirect := *httpClient
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Client{httpClient: &noRedirect, username: username, password: password}
+}
+
+// multistatus is a WebDAV 207 Multi-Status response
+type multistatus struct {
+	Responses []response `xml:"DAV: response"`
+}
+
+type response struct {
+	Href      string     `xml:"DAV: href"`
+	Propstats []propstat `xml:"DAV: propstat"`
+}
+
+type propstat struct {
+	Status string `xml:"DAV: status"`
+	Prop   prop   `xml:"DAV: prop"`
+}
+
+// prop holds the properties requested by this client
+type prop struct {
+	CurrentUserPrincipal *href         `xml:"DAV: current-user-principal"`
+	CalendarHomeSet      *href         `xml:"urn:ietf:params:xml:ns:caldav calendar-home-set"`
+	ResourceType         *resourceType `xml:"DAV: resourcetype"`
+	ComponentSet         *componentSet `xml:"urn:ietf:params:xml:ns:caldav supported-calendar-component-set"`
+	CalendarData         string        `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+}
+
+type href struct {
+	Href string `xml:"DAV: href"`
+}
+
+type resourceType struct {
+	Calendar *struct{} `xml:"urn:ietf:params:xml:ns:caldav calendar"`
+}
+
+type componentSet struct {
+	Comps []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"urn:ietf:params:xml:ns:caldav comp"`
+}
+
+// supportsEvents reports whether a calendar accepts VEVENT components
+func (cs *componentSet) supportsEvents() bool {
+	// A missing component set means the calendar accepts all components
+	if cs == nil {
+		return true
+	}
+	for _, comp := range cs.Comps {
+		if strings.EqualFold(comp.Name, "VEVENT") {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	propfindPrincipal = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:current-user-principal/></d:prop></d:propfind>`
+
+	propfindHomeSet = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><d:prop><c:calendar-home-set/></d:prop></d:propfind>`
+
+	propfindCalendars = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:resourcetype/><c:supported-calendar-component-set/></d:prop>
+</d:propfind>`
+
+	// Recurring events are expanded by the server, so each returned VEVENT is a single occurrence
+	calendarQuery = `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <c:calendar-data><c:expand start="%[1]s" end="%[2]s"/></c:calendar-data>
+  </d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VEVENT"><c:time-range start="%[1]s" end="%[2]s"/></c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`
+)
+
+// DiscoverCalendars returns the URLs of the event calendars of the account
+// The server URL may be the DAV root (e.g. https://cloud.example.com/remote.php/dav), the principal URL,
+// or the host itself when the server supports /.well-known/caldav
+func (c *Client) DiscoverCalendars(ctx context.Context, serverURL string) ([]string, error) {
+	principal, err := c.findPrincipal(ctx, serverURL)
+	if errors.Is(err, errNoPrincipal) {
+		wellKnown, parseErr := resolve(serverURL, "/.well-known/caldav")
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		principal, err = c.findPrincipal(ctx, wellKnown)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ms, base, err := c.do(ctx, "PROPFIND", principal, "0", propfindHomeSet)
+	if err != nil {
+		return nil, err
+	}
+	var home string
+	for _, prop := range ms.okProps() {
+		if prop.CalendarHomeSet != nil && prop.CalendarHomeSet.Href != "" {
+			home = prop.CalendarHomeSet.Href
+		}
+	}
+	if home == "" {
+		return nil, ErrNoCalendar
+	}
+	if home, err = resolve(base, home); err != nil {
+		return nil, err
+	}
+
+	ms, base, err = c.do(ctx, "PROPFIND", home, "1", propfindCalendars)
+	if err != nil {
+		return nil, err
+	}
+
+	var calendars []string
+	for _, response := range ms.Responses {
+		for _, propstat := range response.Propstats {
+			prop := propstat.Prop
+			if !statusOK(propstat.Status) || prop.ResourceType == nil || prop.ResourceType.Calendar == nil {
+				continue
+			}
+			if !prop.ComponentSet.supportsEvents() {
+				continue
+			}
+
+			calendarURL, err := resolve(base, response.Href)
+			if err != nil {
+				continue
+			}
+			if !slices.Contains(calendars, calendarURL) {
+				calendars = append(calendars, calendarURL)
+			}
+		}
+	}
+
+	if len(calendars) == 0 {
+		return nil, ErrNoCalendar
+	}
+	return calendars, nil
+}
+
+// FetchBusyBlocks returns the time ranges of the events of a calendar overlapping [from, to)
+// All-day and floating events are interpreted in loc
+func (c *Client) FetchBusyBlocks(ctx context.Context, calendarURL string, from, to time.Time, loc *time.Location) ([]models.BusyBlock, error) {
+	const layout = "20060102T150405Z"
+	body := fmt.Sprintf(calendarQuery, from.UTC().Format(layout), to.UTC().Format(layout))
+
+	ms, _, err := c.do(ctx, "REPORT", calendarURL, "1", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []models.BusyBlock
+	for _, prop := range ms.okProps() {
+		if prop.CalendarData == "" {
+			continue
+		}
+		parsed, err := parseBusyBlocks(prop.CalendarData, loc)
+		if err != nil {
+			// Skip unparseable objects instead of failing the whole calendar
+			continue
+		}
+		for _, block := range parsed {
+			if block.End.After(from) && block.Start.Before(to) {
+				blocks = append(blocks, block)
+			}
+		}
+	}
+
+	return blocks, nil
+}
+
+// findPrincipal returns the principal URL of the authenticated user
+func (c *Client) findPrincipal(ctx context.Context, target string) (string, error) {
+	ms, base, err := c.do(ctx, "PROPFIND", target, "0", propfindPrincipal)
+	if err != nil {
+		var statusErr *statusError
+		if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+			return "", errNoPrincipal
+		}
+		return "", err
+	}
+
+	for _, prop := range ms.okProps() {
+		if prop.CurrentUserPrincipal != nil && prop.CurrentUserPrincipal.Href != "" {
+			return resolve(base, prop.CurrentUserPrincipal.Href)
+		}
+	}
+	return "", errNoPrincipal
+}
+
+type statusError struct {
+	method string
+	code   int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.method, e.code)
+}
+
+// do sends a WebDAV request and decodes the multistatus response
+// It also returns the final URL (after redirects), against which relative hrefs are resolved
+func (c *Client) do(ctx context.Context, method, target, depth, body string) (*multistatus, string, error) {
+	for range maxRedirects {
+		req, err := http.NewRequestWithContext(ctx, method, target, strings.NewReader(body))
+		if err != nil {
+			return nil, "", err
+		}
+		req.SetBasicAuth(c.username, c.password)
+		req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+		req.Header.Set("Depth", depth)
+		req.Header.Set("User-Agent", "WhenTo-CalDAV/1.0")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %w", ErrServer, err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+			http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+			resp.Body.Close()
+			if target, err = resolve(target, resp.Header.Get("Location")); err != nil {
+				return nil, "", err
+			}
+			continue
+		case http.StatusUnauthorized, http.StatusForbidden:
+			resp.Body.Close()
+			return nil, "", ErrUnauthorized
+		case http.StatusMultiStatus:
+		default:
+			resp.Body.Close()
+			return nil, "", fmt.Errorf("%w: %w", ErrServer, &statusError{method: method, code: resp.StatusCode})
+		}
+
+		var ms multistatus
+		err = xml.NewDecoder(io.LimitReader(resp.Body, maxResponseBodySize)).Decode(&ms)
+		resp.Body.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid %s response: %w", ErrServer, method, err)
+		}
+		return &ms, target, nil
+	}
+
+	return nil, "", fmt.Errorf("%w: %s: too many redirects", ErrServer, method)
+}
+
+// okProps returns the properties found by the server (propstats with a 2xx status)
+func (ms *multistatus) okProps() []prop {
+	var props []prop
+	for _, response := range ms.Responses {
+		for _, propstat := range response.Propstats {
+			if statusOK(propstat.Status) {
+				props = append(props, propstat.Prop)
+			}
+		}
+	}
+	return props
+}
+
+// statusOK reports whether a propstat status line ("HTTP/1.1 200 OK") is a success
+func statusOK(status string) bool {
+	fields := strings.Fields(status)
+	return len(fields) >= 2 && strings.HasPrefix(fields[1], "2")
+}
+
+// resolve resolves an href against the URL of the request that returned it
+func resolve(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
@@ -0,0 +1,179 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeCalDAVServer mimics the discovery flow of Nextcloud: well-known redirect, principal,
+// calendar home with an event calendar and a tasks-only calendar
+func fakeCalDAVServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	multistatus := func(w http.ResponseWriter, body string) {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:" xmlns:cal="urn:ietf:params:xml:ns:caldav">`+body+`</d:multistatus>`)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "app-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/.well-known/caldav":
+			http.Redirect(w, r, "/remote.php/dav/", http.StatusMovedPermanently)
+		case r.Method == "PROPFIND" && r.URL.Path == "/":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "PROPFIND" && r.URL.Path == "/remote.php/dav/":
+			multistatus(w, `<d:response><d:href>/remote.php/dav/</d:href><d:propstat><d:prop>
+				<d:current-user-principal><d:href>/remote.php/dav/principals/users/alice/</d:href></d:current-user-principal>
+				</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`)
+		case r.Method == "PROPFIND" && r.URL.Path == "/remote.php/dav/principals/users/alice/":
+			multistatus(w, `<d:response><d:href>/remote.php/dav/principals/users/alice/</d:href><d:propstat><d:prop>
+				<cal:calendar-home-set><d:href>/remote.php/dav/calendars/alice/</d:href></cal:calendar-home-set>
+				</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`)
+		case r.Method == "PROPFIND" && r.URL.Path == "/remote.php/dav/calendars/alice/":
+			if r.Header.Get("Depth") != "1" {
+				t.Errorf("calendar listing Depth = %q, want 1", r.Header.Get("Depth"))
+			}
+			multistatus(w, `
+				<d:response><d:href>/remote.php/dav/calendars/alice/</d:href><d:propstat><d:prop>
+					<d:resourcetype><d:collection/></d:resourcetype>
+				</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+				<d:response><d:href>/remote.php/dav/calendars/alice/personal/</d:href><d:propstat><d:prop>
+					<d:resourcetype><d:collection/><cal:calendar/></d:resourcetype>
+					<cal:supported-calendar-component-set><cal:comp name="VEVENT"/></cal:supported-calendar-component-set>
+				</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+				<d:response><d:href>/remote.php/dav/calendars/alice/tasks/</d:href><d:propstat><d:prop>
+					<d:resourcetype><d:collection/><cal:calendar/></d:resourcetype>
+					<cal:supported-calendar-component-set><cal:comp name="VTODO"/></cal:supported-calendar-component-set>
+				</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`)
+		case r.Method == "REPORT" && r.URL.Path == "/remote.php/dav/calendars/alice/personal/":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "<c:expand") {
+				t.Error("calendar-query should ask the server to expand recurrences")
+			}
+			multistatus(w, `<d:response><d:href>/remote.php/dav/calendars/alice/personal/meeting.ics</d:href><d:propstat><d:prop>
+				<cal:calendar-data>BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+BEGIN:VEVENT
+UID:meeting
+DTSTAMP:20250101T000000Z
+DTSTART:20250610T080000Z
+DTEND:20250610T093000Z
+SUMMARY:Meeting
+END:VEVENT
+BEGIN:VEVENT
+UID:lunch
+DTSTAMP:20250101T000000Z
+DTSTART:20250610T120000Z
+DTEND:20250610T130000Z
+TRANSP:TRANSPARENT
+SUMMARY:Optional lunch
+END:VEVENT
+END:VCALENDAR
+</cal:calendar-data>
+				</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_DiscoverCalendars(t *testing.T) {
+	server := fakeCalDAVServer(t)
+	defer server.Close()
+
+	client := NewClient(server.Client(), "alice", "app-password")
+
+	// The host alone is enough: discovery falls back to /.well-known/caldav and follows its redirect
+	calendars, err := client.DiscoverCalendars(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("DiscoverCalendars() error = %v", err)
+	}
+
+	want := server.URL + "/remote.php/dav/calendars/alice/personal/"
+	if len(calendars) != 1 || calendars[0] != want {
+		t.Errorf("DiscoverCalendars() = %v, want [%s]", calendars, want)
+	}
+}
+
+func TestClient_DiscoverCalendars_Unauthorized(t *testing.T) {
+	server := fakeCalDAVServer(t)
+	defer server.Close()
+
+	client := NewClient(server.Client(), "alice", "wrong")
+
+	_, err := client.DiscoverCalendars(context.Background(), server.URL+"/remote.php/dav/")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("DiscoverCalendars() error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestClient_FetchBusyBlocks(t *testing.T) {
+	server := fakeCalDAVServer(t)
+	defer server.Close()
+
+	client := NewClient(server.Client(), "alice", "app-password")
+
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	blocks, err := client.FetchBusyBlocks(context.Background(), server.URL+"/remote.php/dav/calendars/alice/personal/", from, from.AddDate(0, 1, 0), time.UTC)
+	if err != nil {
+		t.Fatalf("FetchBusyBlocks() error = %v", err)
+	}
+
+	// The transparent lunch doesn't make the user busy
+	if len(blocks) != 1 {
+		t.Fatalf("FetchBusyBlocks() returned %d blocks, want 1: %v", len(blocks), blocks)
+	}
+	if !blocks[0].Start.Equal(time.Date(2025, 6, 10, 8, 0, 0, 0, time.UTC)) || !blocks[0].End.Equal(time.Date(2025, 6, 10, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("block = %v - %v, want 08:00 - 09:30 UTC", blocks[0].Start, blocks[0].End)
+	}
+}
+
+func TestFetchFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/busy.ics" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar")
+		_, _ = io.WriteString(w, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\n"+
+			"BEGIN:VEVENT\r\nUID:1\r\nDTSTART:20250610T080000Z\r\nDTEND:20250610T093000Z\r\nEND:VEVENT\r\n"+
+			"BEGIN:VEVENT\r\nUID:2\r\nDTSTART:20250801T080000Z\r\nDTEND:20250801T093000Z\r\nEND:VEVENT\r\n"+
+			"END:VCALENDAR\r\n")
+	}))
+	defer server.Close()
+
+	s := &CalDAVService{httpClient: server.Client(), allowPrivateServers: true}
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	blocks, err := s.fetchFeed(context.Background(), server.URL+"/busy.ics", from, from.AddDate(0, 1, 0), time.UTC)
+	if err != nil {
+		t.Fatalf("fetchFeed() error = %v", err)
+	}
+	// The August event is outside the synced range
+	if len(blocks) != 1 || !blocks[0].Start.Equal(time.Date(2025, 6, 10, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("fetchFeed() = %v, want the June 10 event only", blocks)
+	}
+
+	if _, err := s.fetchFeed(context.Background(), server.URL+"/missing.ics", from, from.AddDate(0, 1, 0), time.UTC); !errors.Is(err, ErrServer) {
+		t.Errorf("fetchFeed() error = %v, want ErrServer", err)
+	}
+}
@@ -0,0 +1,121 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+
+	"github.com/whento/whento/internal/caldav/models"
+)
+
+var icalDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseBusyBlocks extracts the time ranges of the events of an iCalendar object
+// Transparent (free) and cancelled events don't make the user busy
+func parseBusyBlocks(data string, loc *time.Location) ([]models.BusyBlock, error) {
+	cal, err := ics.ParseCalendar(strings.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []models.BusyBlock
+	for _, event := range cal.Events() {
+		if prop := event.GetProperty(ics.ComponentPropertyTransp); prop != nil && strings.EqualFold(prop.Value, string(ics.TransparencyTransparent)) {
+			continue
+		}
+		if prop := event.GetProperty(ics.ComponentPropertyStatus); prop != nil && strings.EqualFold(prop.Value, string(ics.ObjectStatusCancelled)) {
+			continue
+		}
+
+		start, allDay, err := parseICalTime(event.GetProperty(ics.ComponentPropertyDtStart), loc)
+		if err != nil {
+			continue
+		}
+
+		var end time.Time
+		switch {
+		case event.GetProperty(ics.ComponentPropertyDtEnd) != nil:
+			end, _, err = parseICalTime(event.GetProperty(ics.ComponentPropertyDtEnd), loc)
+		case event.GetProperty(ics.ComponentPropertyDuration) != nil:
+			var duration time.Duration
+			duration, err = parseICalDuration(event.GetProperty(ics.ComponentPropertyDuration).Value)
+			end = start.Add(duration)
+		case allDay:
+			end = start.AddDate(0, 0, 1)
+		default:
+			// An instant doesn't block any time
+			continue
+		}
+		if err != nil || !end.After(start) {
+			continue
+		}
+
+		blocks = append(blocks, models.BusyBlock{Start: start.UTC(), End: end.UTC()})
+	}
+
+	return blocks, nil
+}
+
+// parseICalTime parses a DATE or DATE-TIME property, reporting whether it's a date (all-day)
+// Dates and floating times are interpreted in loc, as are unknown TZIDs (e.g. Windows zone names)
+func parseICalTime(prop *ics.IANAProperty, loc *time.Location) (time.Time, bool, error) {
+	if prop == nil {
+		return time.Time{}, false, errors.New("missing time property")
+	}
+
+	value := strings.TrimSpace(prop.Value)
+	if values := prop.ICalParameters[string(ics.ParameterValue)]; (len(values) == 1 && values[0] == string(ics.ValueDataTypeDate)) || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	propLoc := loc
+	if tzid := prop.ICalParameters[string(ics.ParameterTzid)]; len(tzid) == 1 {
+		if l, err := time.LoadLocation(strings.Trim(tzid[0], `"`)); err == nil {
+			propLoc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, propLoc)
+	return t, false, err
+}
+
+// parseICalDuration parses an iCalendar duration (RFC 5545 3.3.6), e.g. PT1H30M or P1D
+func parseICalDuration(value string) (time.Duration, error) {
+	matches := icalDurationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration
+	for i, unit := range units {
+		if matches[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		duration += time.Duration(n) * unit
+	}
+
+	if matches[1] == "-" {
+		duration = -duration
+	}
+	return duration, nil
+}
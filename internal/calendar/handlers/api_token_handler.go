@@ -0,0 +1,210 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/calendar/service"
+)
+
+// APITokenHandler handles the API tokens of calendars
+type APITokenHandler struct {
+	tokenService *service.APITokenService
+	logger       *slog.Logger
+}
+
+// NewAPITokenHandler creates a new calendar API token handler
+func NewAPITokenHandler(tokenService *service.APITokenService, logger *slog.Logger) *APITokenHandler {
+	return &APITokenHandler{
+		tokenService: tokenService,
+		logger:       logger,
+	}
+}
+
+// Create creates an API token on a calendar
+//
+//	@Summary		Create a calendar API token
+//	@Description	Creates a token letting an external tool act on this calendar only, on behalf of the current user. Scopes: summaries:read (date and range summaries), availabilities:write (availabilities of the participants) and participants:manage (add, rename and remove participants). Every token can read the calendar. The token is returned once and can't be retrieved later. Owner or organization admin only.
+//	@Tags			Calendars
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"Calendar ID"
+//	@Param			request	body		models.CreateAPITokenRequest	true	"Token"
+//	@Success		201		{object}	models.CreatedAPITokenResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Failure		409		{object}	httputil.ErrorResponse	"Too many tokens"
+//	@Router			/api/v1/calendars/{id}/tokens [post]
+func (h *APITokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAPITokenRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	token, value, err := h.tokenService.Create(r.Context(), userID, calendarID, &req)
+	if err != nil {
+		h.handleError(w, err, "Failed to create API token")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, models.CreatedAPITokenResponse{
+		APITokenResponse: token.ToResponse(),
+		Token:            value,
+	})
+}
+
+// List lists the API tokens of a calendar
+//
+//	@Summary		List calendar API tokens
+//	@Description	Lists the API tokens of a calendar, without their values. Owner or organization admin only.
+//	@Tags			Calendars
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Calendar ID"
+//	@Success		200	{array}		models.APITokenResponse
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/api/v1/calendars/{id}/tokens [get]
+func (h *APITokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.tokenService.List(r.Context(), userID, middleware.GetUserRole(r.Context()), calendarID)
+	if err != nil {
+		h.handleError(w, err, "Failed to list API tokens")
+		return
+	}
+
+	responses := make([]models.APITokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		responses = append(responses, token.ToResponse())
+	}
+
+	httputil.JSON(w, http.StatusOK, responses)
+}
+
+// Revoke revokes an API token of a calendar
+//
+//	@Summary		Revoke a calendar API token
+//	@Description	Deletes an API token of a calendar; requests using it are rejected immediately. Owner or organization admin only.
+//	@Tags			Calendars
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Calendar ID"
+//	@Param			tid	path	string	true	"Token ID"
+//	@Success		204	"Token revoked"
+//	@Failure		400	{object}	httputil.ErrorResponse	"Invalid token ID"
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	httputil.ErrorResponse	"Calendar or token not found"
+//	@Router			/api/v1/calendars/{id}/tokens/{tid} [delete]
+func (h *APITokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	tokenID, err := uuid.Parse(chi.URLParam(r, "tid"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid token ID")
+		return
+	}
+
+	if err := h.tokenService.Revoke(r.Context(), userID, middleware.GetUserRole(r.Context()), calendarID, tokenID); err != nil {
+		h.handleError(w, err, "Failed to revoke API token")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AuthenticateToken implements middleware.TokenAuthenticator, so the Auth middleware accepts calendar API tokens
+// on the routes of their calendar allowed by their scopes, as their creator without admin privileges
+func (h *APITokenHandler) AuthenticateToken(r *http.Request, value string) (*middleware.TokenIdentity, error) {
+	owner, err := h.tokenService.Authenticate(r.Context(), value)
+	if err != nil {
+		if !errors.Is(err, service.ErrInvalidAPIToken) {
+			h.logger.Error("Failed to authenticate calendar API token", "error", err)
+		}
+		return nil, err
+	}
+
+	if !owner.Allows(r.Method, r.URL.Path) {
+		return nil, middleware.ErrTokenScope
+	}
+
+	return &middleware.TokenIdentity{
+		UserID: owner.UserID.String(),
+		Email:  owner.Email,
+		Role:   "user",
+	}, nil
+}
+
+// IsToken implements middleware.TokenAuthenticator
+func (h *APITokenHandler) IsToken(value string) bool {
+	return service.IsAPIToken(value)
+}
+
+// params returns the authenticated user ID and the calendar ID, writing an error response if invalid
+func (h *APITokenHandler) params(w http.ResponseWriter, r *http.Request) (string, uuid.UUID, bool) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return "", uuid.Nil, false
+	}
+
+	calendarID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid calendar ID")
+		return "", uuid.Nil, false
+	}
+	return userID, calendarID, true
+}
+
+// handleError writes the response of a calendar API token error
+func (h *APITokenHandler) handleError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrCalendarNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+	case errors.Is(err, service.ErrAPITokenNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Token not found")
+	case errors.Is(err, service.ErrUnauthorized):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't have permission to modify this calendar")
+	case errors.Is(err, service.ErrTooManyAPITokens):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, err.Error())
+	default:
+		h.logger.Error(message, "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, message)
+	}
+}
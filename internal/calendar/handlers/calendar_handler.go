@@ -7,6 +7,7 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -14,11 +15,13 @@ import (
 	"github.com/whento/pkg/httputil"
 	"github.com/whento/pkg/logger"
 	"github.com/whento/pkg/middleware"
+	pkgModels "github.com/whento/pkg/models"
 	"github.com/whento/pkg/validator"
 	authRepo "github.com/whento/whento/internal/auth/repository"
 	"github.com/whento/whento/internal/calendar/models"
 	"github.com/whento/whento/internal/calendar/service"
 	"github.com/whento/whento/internal/config"
+	orgModels "github.com/whento/whento/internal/organization/models"
 	"github.com/whento/whento/internal/quota"
 )
 
@@ -48,12 +51,13 @@ func NewCalendarHandler(
 // CreateCalendar handles calendar creation
 //
 //	@Summary		Create a calendar
-//	@Description	Creates a new calendar for the authenticated user. Enforces quota limits.
+//	@Description	Creates a new calendar for the authenticated user, or for the organization selected by the X-Organization-ID header (owners and admins). Enforces quota limits.
 //	@Tags			Calendars
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			request	body		models.CreateCalendarRequest	true	"Calendar details"
+//	@Param			X-Organization-ID	header		string							false	"Organization ID"
+//	@Param			request				body		models.CreateCalendarRequest	true	"Calendar details"
 //	@Success		201		{object}	models.CalendarResponse
 //	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request body or validation error"
 //	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
@@ -109,11 +113,33 @@ func (h *CalendarHandler) CreateCalendar(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	organizationID, ok := h.checkCanCreate(w, r, userID)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.calendarService.CreateCalendar(r.Context(), userID, organizationID, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBlackout) || errors.Is(err, service.ErrInvalidHoliday) || errors.Is(err, service.ErrRegionWithoutCountry) || errors.Is(err, service.ErrInvalidHolidayCountry) || errors.Is(err, service.ErrInvalidCapacity) || errors.Is(err, service.ErrInvalidThreshold) || errors.Is(err, service.ErrInvalidSoftThreshold) {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+			return
+		}
+		logger.FromContext(r.Context()).Error("Failed to create calendar", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to create calendar")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, calendar)
+}
+
+// checkCanCreate checks that the user may create a calendar (email verified, organization role, quota)
+// and returns the organization owning it, writing the error response otherwise
+func (h *CalendarHandler) checkCanCreate(w http.ResponseWriter, r *http.Request, userID string) (*uuid.UUID, bool) {
 	// Parse user ID
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid user ID")
-		return
+		return nil, false
 	}
 
 	// Check email verification if enabled
@@ -122,26 +148,38 @@ func (h *CalendarHandler) CreateCalendar(w http.ResponseWriter, r *http.Request)
 		if err != nil {
 			logger.FromContext(r.Context()).Error("Failed to get user", "error", err, "user_id", userID)
 			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to verify user status")
-			return
+			return nil, false
 		}
 
 		if !user.EmailVerified {
 			httputil.Error(w, http.StatusForbidden, "email_not_verified", "Please verify your email address before creating calendars")
-			return
+			return nil, false
+		}
+	}
+
+	// In an organization, owners and admins create calendars counting against the quota of the owner
+	var organizationID *uuid.UUID
+	quotaUserID := userUUID
+	if membership := orgModels.MembershipFromContext(r.Context()); membership != nil {
+		if !membership.CanManage() {
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "Only owners and admins of the organization can create calendars")
+			return nil, false
 		}
+		organizationID = &membership.OrganizationID
+		quotaUserID = membership.OwnerID
 	}
 
 	// Check quota limits before creating calendar
-	canCreate, err := h.quotaService.CanCreateCalendar(r.Context(), userUUID)
+	canCreate, err := h.quotaService.CanCreateCalendar(r.Context(), quotaUserID)
 	if err != nil {
 		logger.FromContext(r.Context()).Error("Failed to check quota", "error", err, "user_id", userID)
 		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to check calendar quota")
-		return
+		return nil, false
 	}
 
 	if !canCreate {
 		// Get limit info for better error message
-		userLimit, _ := h.quotaService.GetUserLimit(r.Context(), userUUID)
+		userLimit, _ := h.quotaService.GetUserLimit(r.Context(), quotaUserID)
 		serverLimit, _ := h.quotaService.GetServerLimit(r.Context())
 
 		var errorMsg string
@@ -156,17 +194,10 @@ func (h *CalendarHandler) CreateCalendar(w http.ResponseWriter, r *http.Request)
 		}
 
 		httputil.Error(w, http.StatusForbidden, "quota_exceeded", errorMsg)
-		return
-	}
-
-	calendar, err := h.calendarService.CreateCalendar(r.Context(), userID, &req)
-	if err != nil {
-		logger.FromContext(r.Context()).Error("Failed to create calendar", "error", err, "user_id", userID)
-		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to create calendar")
-		return
+		return nil, false
 	}
 
-	httputil.JSON(w, http.StatusCreated, calendar)
+	return organizationID, true
 }
 
 // GetCalendar retrieves a calendar by ID
@@ -212,12 +243,19 @@ func (h *CalendarHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
 // ListMyCalendars lists all calendars owned by the user
 //
 //	@Summary		List my calendars
-//	@Description	Returns all calendars owned by the authenticated user
+//	@Description	Returns the personal calendars of the authenticated user, or the calendars of the organization selected by the X-Organization-ID header, newest first by default. With page or per_page, the response is a page envelope (items, total, page, page_size, total_pages) instead of an array. With participants=false, the participant lists are left out and only participant_count is set.
 //	@Tags			Calendars
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200	{array}		models.CalendarResponse
-//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Param			X-Organization-ID	header		string	false	"Organization ID"
+//	@Param			q					query		string	false	"Search in name and description"
+//	@Param			sort				query		string	false	"name, created_at or updated_at, prefixed with - for descending order (default -created_at)"
+//	@Param			page				query		int		false	"Page number, from 1"
+//	@Param			per_page			query		int		false	"Page size (default 20, max 100)"
+//	@Param			participants		query		bool	false	"Include the participant lists (default true)"
+//	@Success		200					{array}		models.CalendarResponse
+//	@Failure		400					{object}	httputil.ErrorResponse	"Invalid parameter"
+//	@Failure		401					{object}	httputil.ErrorResponse	"Unauthorized"
 //	@Router			/api/v1/calendars [get]
 func (h *CalendarHandler) ListMyCalendars(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r.Context())
@@ -226,12 +264,26 @@ func (h *CalendarHandler) ListMyCalendars(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	calendars, err := h.calendarService.ListMyCalendars(r.Context(), userID)
+	query, err := parseCalendarListQuery(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	if membership := orgModels.MembershipFromContext(r.Context()); membership != nil {
+		query.Filter.OrganizationID = &membership.OrganizationID
+	}
+
+	calendars, total, err := h.calendarService.ListCalendars(r.Context(), userID, query.Filter, query.WithParticipants)
 	if err != nil {
 		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list calendars")
 		return
 	}
 
+	if query.Paginated {
+		httputil.JSON(w, http.StatusOK, pkgModels.NewPagedListResponse(calendars, total, query.Page, query.PerPage))
+		return
+	}
 	httputil.JSON(w, http.StatusOK, calendars)
 }
 
@@ -286,6 +338,10 @@ func (h *CalendarHandler) UpdateCalendar(w http.ResponseWriter, r *http.Request)
 			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't have permission to modify this calendar")
 			return
 		}
+		if errors.Is(err, service.ErrInvalidBlackout) || errors.Is(err, service.ErrInvalidHoliday) || errors.Is(err, service.ErrRegionWithoutCountry) || errors.Is(err, service.ErrInvalidHolidayCountry) || errors.Is(err, service.ErrInvalidCapacity) || errors.Is(err, service.ErrInvalidThreshold) || errors.Is(err, service.ErrInvalidSoftThreshold) {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+			return
+		}
 		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to update calendar")
 		return
 	}
@@ -333,6 +389,55 @@ func (h *CalendarHandler) DeleteCalendar(w http.ResponseWriter, r *http.Request)
 	httputil.JSON(w, http.StatusOK, map[string]string{"message": "Calendar deleted successfully"})
 }
 
+// ListChanges lists the changes of the settings of a calendar
+//
+//	@Summary		List calendar changes
+//	@Description	Returns the change log of the calendar settings (threshold, dates, allowed hours, notification config...), most recent first, with who changed what and when. Secret values are redacted.
+//	@Tags			Calendars
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string	true	"Calendar ID"
+//	@Param			limit	query		int		false	"Maximum number of changes (default 50, max 200)"
+//	@Param			offset	query		int		false	"Number of changes to skip"
+//	@Success		200		{object}	models.CalendarChangeList
+//	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/api/v1/calendars/{id}/changes [get]
+func (h *CalendarHandler) ListChanges(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	userRole := middleware.GetUserRole(r.Context())
+	if userID == "" {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit, offset := 50, 0
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = min(l, 200)
+	}
+	if o, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && o >= 0 {
+		offset = o
+	}
+
+	changes, err := h.calendarService.ListChanges(r.Context(), userID, userRole, chi.URLParam(r, "id"), limit, offset)
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't have permission to access this calendar")
+			return
+		}
+		logger.FromContext(r.Context()).Error("Failed to list calendar changes", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list calendar changes")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, changes)
+}
+
 // RegenerateToken regenerates a calendar token
 //
 //	@Summary		Regenerate calendar token
@@ -402,7 +507,7 @@ func (h *CalendarHandler) RegenerateToken(w http.ResponseWriter, r *http.Request
 //	@Tags			Calendars
 //	@Produce		json
 //	@Param			token			path		string	true	"Public calendar token"
-//	@Param			participant_id	query		string	false	"Filter by participant ID"
+//	@Param			participant_id	query		string	false	"Participant access token"
 //	@Success		200				{object}	models.PublicCalendarResponse
 //	@Failure		404				{object}	httputil.ErrorResponse	"Calendar not found"
 //	@Router			/api/v1/calendars/public/{token} [get]
@@ -423,6 +528,69 @@ func (h *CalendarHandler) GetPublicCalendar(w http.ResponseWriter, r *http.Reque
 	httputil.JSON(w, http.StatusOK, calendar)
 }
 
+// ClaimParticipantLink returns the personal link of a participant of an open calendar (no auth)
+//
+//	@Summary		Claim a participant link
+//	@Description	Returns the access token of a participant whose link was never used nor handed out by the owner, on calendars not locking their participants. The link can only be claimed once.
+//	@Tags			Calendars
+//	@Produce		json
+//	@Param			token	path		string	true	"Public calendar token"
+//	@Param			pid		path		string	true	"Participant ID"
+//	@Success		200		{object}	models.ParticipantLinkResponse
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar or participant not found"
+//	@Failure		409		{object}	httputil.ErrorResponse	"Link already claimed or participants locked"
+//	@Router			/api/v1/calendars/public/{token}/participants/{pid}/claim [post]
+func (h *CalendarHandler) ClaimParticipantLink(w http.ResponseWriter, r *http.Request) {
+	accessToken, err := h.calendarService.ClaimParticipantLink(r.Context(), chi.URLParam(r, "token"), chi.URLParam(r, "pid"))
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+			return
+		}
+		if errors.Is(err, service.ErrParticipantNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Participant not found")
+			return
+		}
+		if errors.Is(err, service.ErrLinkNotClaimable) {
+			httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "This participant link was already claimed, ask the organizer for it")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to claim participant link")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, models.ParticipantLinkResponse{AccessToken: accessToken})
+}
+
+// WithPublicToken serves routes of the public link by calendar ID, for authenticated clients such as calendar API tokens
+// It checks that the user may view the calendar, then sets the "token" URL parameter read by the public handlers
+func (h *CalendarHandler) WithPublicToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := middleware.GetUserID(r.Context())
+		if userID == "" {
+			httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+			return
+		}
+
+		token, err := h.calendarService.PublicToken(r.Context(), userID, middleware.GetUserRole(r.Context()), chi.URLParam(r, "id"))
+		if err != nil {
+			if errors.Is(err, service.ErrCalendarNotFound) {
+				httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+				return
+			}
+			if errors.Is(err, service.ErrUnauthorized) {
+				httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't have permission to access this calendar")
+				return
+			}
+			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to get calendar")
+			return
+		}
+
+		chi.RouteContext(r.Context()).URLParams.Add("token", token)
+		next.ServeHTTP(w, r)
+	})
+}
+
 // ListUserCalendars retrieves all calendars for a specific user (admin only)
 //
 //	@Summary		List user's calendars (Admin)
@@ -447,3 +615,90 @@ func (h *CalendarHandler) ListUserCalendars(w http.ResponseWriter, r *http.Reque
 
 	httputil.JSON(w, http.StatusOK, calendars)
 }
+
+// TransferCalendar transfers a calendar to another user (admin only)
+//
+//	@Summary		Transfer calendar ownership (Admin)
+//	@Description	Makes a calendar a personal calendar of another user, e.g. when an employee leaves. Tokens, participants and availabilities are kept and the calendar counts against the quota of the new owner. Admin only.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"Calendar ID"
+//	@Param			request	body		models.TransferCalendarRequest	true	"New owner"
+//	@Success		200		{object}	models.CalendarResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request or new owner not found"
+//	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Forbidden (requires admin role) or quota of the new owner exceeded"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Failure		409		{object}	httputil.ErrorResponse	"The user already owns the calendar"
+//	@Router			/api/v1/calendars/admin/{id}/transfer [post]
+func (h *CalendarHandler) TransferCalendar(w http.ResponseWriter, r *http.Request) {
+	adminID := middleware.GetUserID(r.Context())
+	calendarID := chi.URLParam(r, "id")
+
+	var req models.TransferCalendarRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if !h.checkCanReceive(w, r, req.UserID) {
+		return
+	}
+
+	calendar, err := h.calendarService.TransferCalendar(r.Context(), adminID, calendarID, req.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCalendarNotFound):
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+		case errors.Is(err, service.ErrNewOwnerNotFound):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "New owner not found")
+		case errors.Is(err, service.ErrAlreadyOwner):
+			httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "The user already owns this calendar")
+		default:
+			logger.FromContext(r.Context()).Error("Failed to transfer calendar", "error", err, "calendar_id", calendarID, "new_owner_id", req.UserID)
+			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to transfer calendar")
+		}
+		return
+	}
+
+	logger.FromContext(r.Context()).Info("Calendar transferred", "calendar_id", calendarID, "new_owner_id", req.UserID, "admin_id", adminID)
+	httputil.JSON(w, http.StatusOK, calendar)
+}
+
+// checkCanReceive checks that the new owner of a transferred calendar has room for it in their quota
+// Self-hosted limits are server-wide, which a transfer does not change
+func (h *CalendarHandler) checkCanReceive(w http.ResponseWriter, r *http.Request, userID string) bool {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "New owner not found")
+		return false
+	}
+
+	serverLimit, err := h.quotaService.GetServerLimit(r.Context())
+	if err != nil || serverLimit != -1 {
+		return true
+	}
+
+	canCreate, err := h.quotaService.CanCreateCalendar(r.Context(), userUUID)
+	if err != nil {
+		logger.FromContext(r.Context()).Error("Failed to check quota", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to check calendar quota")
+		return false
+	}
+	if !canCreate {
+		httputil.Error(w, http.StatusForbidden, "quota_exceeded", "Calendar limit reached for the plan of the new owner")
+		return false
+	}
+	return true
+}
@@ -7,12 +7,15 @@ package handlers_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	pkgModels "github.com/whento/pkg/models"
@@ -22,20 +25,26 @@ import (
 	"github.com/whento/whento/internal/calendar/repository"
 	"github.com/whento/whento/internal/calendar/service"
 	"github.com/whento/whento/internal/config"
+	orgModels "github.com/whento/whento/internal/organization/models"
+	"github.com/whento/whento/internal/quota"
 	"github.com/whento/whento/internal/testutil"
 )
 
 // Mock CalendarService implementing service.CalendarRepository and service.ParticipantRepository
 type mockCalendarRepository struct {
 	calendar                     *models.Calendar
+	created                      *models.Calendar
 	calendars                    []*models.Calendar
 	participants                 []models.Participant
 	err                          error
 	createWithParticipantsCalled bool
+	transferredTo                *uuid.UUID
+	updated                      *models.Calendar
 }
 
 func (m *mockCalendarRepository) CreateWithParticipants(ctx context.Context, calendar *models.Calendar, participantInputs []repository.ParticipantInput) ([]models.Participant, error) {
 	m.createWithParticipantsCalled = true
+	m.created = calendar
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -59,6 +68,20 @@ func (m *mockCalendarRepository) GetByOwnerID(ctx context.Context, ownerID uuid.
 	return m.calendars, nil
 }
 
+func (m *mockCalendarRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.Calendar, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.calendars, nil
+}
+
+func (m *mockCalendarRepository) List(ctx context.Context, filter models.CalendarListFilter) ([]*models.Calendar, int, error) {
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	return m.calendars, len(m.calendars), nil
+}
+
 func (m *mockCalendarRepository) GetByPublicToken(ctx context.Context, token string) (*models.Calendar, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -70,6 +93,7 @@ func (m *mockCalendarRepository) GetByPublicToken(ctx context.Context, token str
 }
 
 func (m *mockCalendarRepository) Update(ctx context.Context, calendar *models.Calendar) error {
+	m.updated = calendar
 	return m.err
 }
 
@@ -81,12 +105,34 @@ func (m *mockCalendarRepository) RegenerateToken(ctx context.Context, id uuid.UU
 	return m.err
 }
 
+func (m *mockCalendarRepository) TransferOwnership(ctx context.Context, id, newOwnerID uuid.UUID) error {
+	m.transferredTo = &newOwnerID
+	return m.err
+}
+
 type mockParticipantRepository struct {
 	participant  *models.Participant
 	participants []models.Participant
 	err          error
 }
 
+func (m *mockParticipantRepository) ClaimAccessToken(ctx context.Context, calendarID, id uuid.UUID) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	for i, p := range m.participants {
+		if p.ID != id || p.CalendarID != calendarID {
+			continue
+		}
+		if p.AccessTokenClaimed {
+			return "", repository.ErrParticipantAlreadyClaimed
+		}
+		m.participants[i].AccessTokenClaimed = true
+		return p.AccessToken, nil
+	}
+	return "", repository.ErrParticipantNotFound
+}
+
 func (m *mockParticipantRepository) Create(ctx context.Context, participant *models.Participant) error {
 	return m.err
 }
@@ -108,7 +154,29 @@ func (m *mockParticipantRepository) GetByCalendarID(ctx context.Context, calenda
 	return m.participants, nil
 }
 
-func (m *mockParticipantRepository) Update(ctx context.Context, id uuid.UUID, name string) error {
+func (m *mockParticipantRepository) GetByCalendarIDs(ctx context.Context, calendarIDs []uuid.UUID) (map[uuid.UUID][]models.Participant, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	participants := make(map[uuid.UUID][]models.Participant)
+	for _, p := range m.participants {
+		participants[p.CalendarID] = append(participants[p.CalendarID], p)
+	}
+	return participants, nil
+}
+
+func (m *mockParticipantRepository) CountByCalendarIDs(ctx context.Context, calendarIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	counts := make(map[uuid.UUID]int)
+	for _, p := range m.participants {
+		counts[p.CalendarID]++
+	}
+	return counts, nil
+}
+
+func (m *mockParticipantRepository) Update(ctx context.Context, id uuid.UUID, name string, required bool) error {
 	return m.err
 }
 
@@ -120,6 +188,13 @@ func (m *mockParticipantRepository) SetEmailAsVerified(ctx context.Context, part
 	return m.err
 }
 
+func (m *mockParticipantRepository) RegenerateAccessToken(ctx context.Context, id uuid.UUID) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	return "regenerated", nil
+}
+
 type mockCache struct{}
 
 func (m *mockCache) Get(ctx context.Context, key string, dest interface{}) error {
@@ -193,6 +268,27 @@ func (m *mockQuotaService) IsOverQuota(ctx context.Context, userID uuid.UUID) (b
 	return m.isOverQuota, nil
 }
 
+func (m *mockQuotaService) GetCapabilities(ctx context.Context, userID uuid.UUID) (quota.Capabilities, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return quota.Capabilities{}, nil
+}
+
+func (m *mockQuotaService) HasCapability(ctx context.Context, userID uuid.UUID, capability quota.Capability) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return false, nil
+}
+
+func (m *mockQuotaService) GetRateLimitFactor(ctx context.Context, userID uuid.UUID) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return 1, nil
+}
+
 type mockUserRepository struct {
 	user *authModels.User
 	err  error
@@ -297,8 +393,8 @@ func TestCalendarHandler_CreateCalendar_Success(t *testing.T) {
 	mockCache := &mockCache{}
 	mockQuota := &mockQuotaService{canCreate: true}
 
-	calendarSvc := service.NewCalendarService(mockCalRepo, mockPartRepo, nil, mockCache)
 	cfg := &config.Config{Email: config.EmailConfig{VerificationEnabled: false}}
+	calendarSvc := service.NewCalendarService(mockCalRepo, mockPartRepo, nil, nil, nil, nil, nil, mockCache, cfg)
 	handler := handlers.NewCalendarHandler(calendarSvc, mockQuota, nil, cfg)
 
 	reqBody := map[string]interface{}{
@@ -331,8 +427,8 @@ func TestCalendarHandler_CreateCalendar_QuotaExceeded(t *testing.T) {
 	mockCache := &mockCache{}
 	mockQuota := &mockQuotaService{canCreate: false} // Quota exceeded
 
-	calendarSvc := service.NewCalendarService(mockCalRepo, mockPartRepo, nil, mockCache)
 	cfg := &config.Config{Email: config.EmailConfig{VerificationEnabled: false}}
+	calendarSvc := service.NewCalendarService(mockCalRepo, mockPartRepo, nil, nil, nil, nil, nil, mockCache, cfg)
 	handler := handlers.NewCalendarHandler(calendarSvc, mockQuota, nil, cfg)
 
 	reqBody := map[string]interface{}{
@@ -356,8 +452,8 @@ func TestCalendarHandler_CreateCalendar_Unauthorized(t *testing.T) {
 	mockCache := &mockCache{}
 	mockQuota := &mockQuotaService{canCreate: true}
 
-	calendarSvc := service.NewCalendarService(mockCalRepo, mockPartRepo, nil, mockCache)
 	cfg := &config.Config{Email: config.EmailConfig{VerificationEnabled: false}}
+	calendarSvc := service.NewCalendarService(mockCalRepo, mockPartRepo, nil, nil, nil, nil, nil, mockCache, cfg)
 	handler := handlers.NewCalendarHandler(calendarSvc, mockQuota, nil, cfg)
 
 	reqBody := map[string]interface{}{
@@ -375,4 +471,406 @@ func TestCalendarHandler_CreateCalendar_Unauthorized(t *testing.T) {
 	}
 }
 
-// More tests to be added: GetCalendar, ListMyCalendars, UpdateCalendar, DeleteCalendar, RegenerateToken, GetPublicCalendar
+func TestCalendarHandler_CreateCalendar_Organization(t *testing.T) {
+	cfg := &config.Config{Email: config.EmailConfig{VerificationEnabled: false}}
+	membership := &orgModels.Membership{OrganizationID: uuid.New(), OwnerID: uuid.New()}
+
+	tests := []struct {
+		role       string
+		wantStatus int
+	}{
+		{orgModels.RoleOwner, http.StatusCreated},
+		{orgModels.RoleAdmin, http.StatusCreated},
+		{orgModels.RoleMember, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			mockCalRepo := &mockCalendarRepository{}
+			calendarSvc := service.NewCalendarService(mockCalRepo, &mockParticipantRepository{}, nil, nil, nil, nil, nil, &mockCache{}, cfg)
+			handler := handlers.NewCalendarHandler(calendarSvc, &mockQuotaService{canCreate: true}, nil, cfg)
+
+			membership.Role = tt.role
+			req := testutil.MakeJSONRequest(http.MethodPost, "/api/v1/calendars", map[string]interface{}{"name": "Club Calendar"})
+			req = testutil.WithAuth(req, uuid.New().String(), "user")
+			req = req.WithContext(orgModels.WithMembership(req.Context(), membership))
+			w := httptest.NewRecorder()
+
+			handler.CreateCalendar(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			if mockCalRepo.created.OrganizationID == nil || *mockCalRepo.created.OrganizationID != membership.OrganizationID {
+				t.Errorf("Expected calendar owned by organization %s, got %v", membership.OrganizationID, mockCalRepo.created.OrganizationID)
+			}
+		})
+	}
+}
+
+func TestCalendarHandler_WithPublicToken(t *testing.T) {
+	cfg := &config.Config{Email: config.EmailConfig{VerificationEnabled: false}}
+	ownerID := uuid.New()
+	calendar := &models.Calendar{OwnerID: ownerID, PublicToken: "public-token"}
+	calendar.ID = uuid.New()
+
+	tests := []struct {
+		name       string
+		userID     string
+		calendar   *models.Calendar
+		wantStatus int
+	}{
+		{"owner", ownerID.String(), calendar, http.StatusOK},
+		{"other user", uuid.New().String(), calendar, http.StatusForbidden},
+		{"unknown calendar", ownerID.String(), nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendarSvc := service.NewCalendarService(&mockCalendarRepository{calendar: tt.calendar}, &mockParticipantRepository{}, nil, nil, nil, nil, nil, &mockCache{}, cfg)
+			handler := handlers.NewCalendarHandler(calendarSvc, &mockQuotaService{}, nil, cfg)
+
+			var token string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				token = chi.URLParam(r, "token")
+			})
+
+			req := testutil.MakeRequest(http.MethodGet, "/api/v1/calendars/"+calendar.ID.String()+"/range")
+			req = testutil.WithURLParams(req, map[string]string{"id": calendar.ID.String()})
+			req = testutil.WithAuth(req, tt.userID, "user")
+			w := httptest.NewRecorder()
+
+			handler.WithPublicToken(next).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && token != calendar.PublicToken {
+				t.Errorf("Expected token URL parameter %q, got %q", calendar.PublicToken, token)
+			}
+		})
+	}
+}
+
+func TestCalendarHandler_UpdateCalendar_DisplaySettings(t *testing.T) {
+	cfg := &config.Config{}
+	ownerID := uuid.New()
+	calendarID := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       map[string]string
+		wantStatus int
+	}{
+		{"empty strings reset to the instance default", map[string]string{"week_start": "", "time_format": "", "date_format": ""}, http.StatusOK},
+		{"values are stored", map[string]string{"week_start": "sunday", "time_format": "24h", "date_format": "iso"}, http.StatusOK},
+		{"unknown week start", map[string]string{"week_start": "tuesday"}, http.StatusBadRequest},
+		{"unknown time format", map[string]string{"time_format": "36h"}, http.StatusBadRequest},
+		{"unknown date format", map[string]string{"date_format": "short"}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weekStart, timeFormat, dateFormat := "monday", "12h", "long"
+			mockCalRepo := &mockCalendarRepository{calendar: &models.Calendar{
+				OwnerID:    ownerID,
+				WeekStart:  &weekStart,
+				TimeFormat: &timeFormat,
+				DateFormat: &dateFormat,
+			}}
+			calendarSvc := service.NewCalendarService(mockCalRepo, &mockParticipantRepository{}, nil, nil, nil, nil, nil, &mockCache{}, cfg)
+			handler := handlers.NewCalendarHandler(calendarSvc, &mockQuotaService{}, nil, cfg)
+
+			req := testutil.MakeJSONRequest(http.MethodPatch, "/api/v1/calendars/"+calendarID.String(), tt.body)
+			req = testutil.WithAuth(req, ownerID.String(), "user")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", calendarID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.UpdateCalendar(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if mockCalRepo.updated != nil {
+					t.Error("Expected the calendar not to be updated")
+				}
+				return
+			}
+			if mockCalRepo.updated == nil {
+				t.Fatal("Expected the calendar to be updated")
+			}
+			got := map[string]*string{
+				"week_start":  mockCalRepo.updated.WeekStart,
+				"time_format": mockCalRepo.updated.TimeFormat,
+				"date_format": mockCalRepo.updated.DateFormat,
+			}
+			for field, value := range tt.body {
+				switch {
+				case value == "" && got[field] != nil:
+					t.Errorf("Expected %s to be cleared, got %q", field, *got[field])
+				case value != "" && (got[field] == nil || *got[field] != value):
+					t.Errorf("Expected %s %q, got %v", field, value, got[field])
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarHandler_GetPublicCalendar_ParticipantTokens(t *testing.T) {
+	cfg := &config.Config{}
+	calendar := &models.Calendar{PublicToken: "public-token", Threshold: 2}
+	calendar.ID = uuid.New()
+	participants := make([]models.Participant, 3)
+	for i, name := range []string{"Alice", "Bob", "Carol"} {
+		participants[i] = models.Participant{CalendarID: calendar.ID, Name: name, AccessToken: strings.Repeat(string(rune('a'+i)), 64)}
+		participants[i].ID = uuid.New()
+	}
+	participants[2].AccessTokenClaimed = true
+
+	tests := []struct {
+		name          string
+		locked        bool
+		participantID string
+		wantToken     map[string]string
+		wantClaimable map[string]bool
+	}{
+		{"open calendar without link", false, "", map[string]string{}, map[string]bool{"Alice": true, "Bob": true}},
+		{"open calendar from a participant link", false, participants[0].AccessToken, map[string]string{"Alice": participants[0].AccessToken}, map[string]bool{"Bob": true}},
+		{"locked calendar from a participant link", true, participants[1].AccessToken, map[string]string{"Bob": participants[1].AccessToken}, map[string]bool{}},
+		{"locked calendar without link", true, "", map[string]string{}, map[string]bool{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := *calendar
+			cal.LockParticipants = tt.locked
+			calendarSvc := service.NewCalendarService(&mockCalendarRepository{calendar: &cal}, &mockParticipantRepository{participants: participants}, nil, nil, nil, nil, nil, &mockCache{}, cfg)
+			handler := handlers.NewCalendarHandler(calendarSvc, &mockQuotaService{}, nil, cfg)
+
+			req := testutil.MakeRequest(http.MethodGet, "/api/v1/calendars/public/public-token?participant_id="+tt.participantID)
+			req = testutil.WithURLParams(req, map[string]string{"token": "public-token"})
+			w := httptest.NewRecorder()
+
+			handler.GetPublicCalendar(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			var response struct {
+				Data struct {
+					Participants []map[string]any `json:"participants"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(response.Data.Participants) != len(participants) {
+				t.Fatalf("Expected %d participants, got %d", len(participants), len(response.Data.Participants))
+			}
+			for _, p := range response.Data.Participants {
+				name := p["name"].(string)
+				token, _ := p["access_token"].(string)
+				if token != tt.wantToken[name] {
+					t.Errorf("Expected access_token %q for %s, got %q", tt.wantToken[name], name, token)
+				}
+				if claimable, _ := p["claimable"].(bool); claimable != tt.wantClaimable[name] {
+					t.Errorf("Expected claimable %v for %s, got %v", tt.wantClaimable[name], name, claimable)
+				}
+			}
+			for _, p := range participants {
+				if strings.Contains(w.Body.String(), p.AccessToken) && p.AccessToken != tt.participantID {
+					t.Errorf("Response contains the link of %s", p.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarHandler_ClaimParticipantLink(t *testing.T) {
+	cfg := &config.Config{}
+	calendar := &models.Calendar{PublicToken: "public-token"}
+	calendar.ID = uuid.New()
+	unclaimed := models.Participant{CalendarID: calendar.ID, Name: "Alice", AccessToken: strings.Repeat("a", 64)}
+	unclaimed.ID = uuid.New()
+	claimed := models.Participant{CalendarID: calendar.ID, Name: "Bob", AccessToken: strings.Repeat("b", 64), AccessTokenClaimed: true}
+	claimed.ID = uuid.New()
+
+	tests := []struct {
+		name          string
+		locked        bool
+		participantID string
+		wantStatus    int
+		wantToken     string
+	}{
+		{"unclaimed link", false, unclaimed.ID.String(), http.StatusOK, unclaimed.AccessToken},
+		{"claimed link", false, claimed.ID.String(), http.StatusConflict, ""},
+		{"locked calendar", true, unclaimed.ID.String(), http.StatusConflict, ""},
+		{"unknown participant", false, uuid.NewString(), http.StatusNotFound, ""},
+		{"invalid participant", false, "alice", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := *calendar
+			cal.LockParticipants = tt.locked
+			participantRepo := &mockParticipantRepository{participants: []models.Participant{unclaimed, claimed}}
+			calendarSvc := service.NewCalendarService(&mockCalendarRepository{calendar: &cal}, participantRepo, nil, nil, nil, nil, nil, &mockCache{}, cfg)
+			handler := handlers.NewCalendarHandler(calendarSvc, &mockQuotaService{}, nil, cfg)
+
+			claim := func() *httptest.ResponseRecorder {
+				req := testutil.MakeRequest(http.MethodPost, "/api/v1/calendars/public/public-token/participants/"+tt.participantID+"/claim")
+				req = testutil.WithURLParams(req, map[string]string{"token": "public-token", "pid": tt.participantID})
+				w := httptest.NewRecorder()
+				handler.ClaimParticipantLink(w, req)
+				return w
+			}
+
+			w := claim()
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response struct {
+				Data models.ParticipantLinkResponse `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Data.AccessToken != tt.wantToken {
+				t.Errorf("Expected access token %q, got %q", tt.wantToken, response.Data.AccessToken)
+			}
+
+			// A link can only be claimed once
+			if w := claim(); w.Code != http.StatusConflict {
+				t.Errorf("Expected status %d on the second claim, got %d", http.StatusConflict, w.Code)
+			}
+		})
+	}
+}
+
+// More tests to be added: GetCalendar, ListMyCalendars, DeleteCalendar, RegenerateToken
+
+func TestCalendarHandler_UpdateCalendar_HolidayLocation(t *testing.T) {
+	cfg := &config.Config{}
+	ownerID := uuid.New()
+	calendarID := uuid.New()
+
+	tests := []struct {
+		name        string
+		body        map[string]string
+		wantStatus  int
+		wantCountry string
+		wantRegion  string
+	}{
+		{"empty strings clear the country and region", map[string]string{"holiday_country": "", "holiday_region": ""}, http.StatusOK, "", ""},
+		{"empty region keeps the country", map[string]string{"holiday_region": ""}, http.StatusOK, "DE", ""},
+		{"new country clears the region", map[string]string{"holiday_country": "fr"}, http.StatusOK, "FR", ""},
+		{"new region", map[string]string{"holiday_region": "be"}, http.StatusOK, "DE", "BE"},
+		{"invalid country", map[string]string{"holiday_country": "D1"}, http.StatusBadRequest, "", ""},
+		{"country too long", map[string]string{"holiday_country": "DEU"}, http.StatusBadRequest, "", ""},
+		{"invalid region", map[string]string{"holiday_region": "B-Y"}, http.StatusBadRequest, "", ""},
+		{"region without country", map[string]string{"holiday_country": "", "holiday_region": "BY"}, http.StatusBadRequest, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			country, region := "DE", "BY"
+			mockCalRepo := &mockCalendarRepository{calendar: &models.Calendar{
+				TimestampedEntity: pkgModels.TimestampedEntity{Entity: pkgModels.Entity{ID: calendarID}},
+				OwnerID:           ownerID,
+				HolidayCountry:    &country,
+				HolidayRegion:     &region,
+			}}
+			calendarSvc := service.NewCalendarService(mockCalRepo, &mockParticipantRepository{}, nil, nil, nil, nil, nil, &mockCache{}, cfg)
+			handler := handlers.NewCalendarHandler(calendarSvc, &mockQuotaService{}, nil, cfg)
+
+			req := testutil.MakeJSONRequest(http.MethodPatch, "/api/v1/calendars/"+calendarID.String(), tt.body)
+			req = testutil.WithAuth(req, ownerID.String(), "user")
+			req = testutil.WithURLParams(req, map[string]string{"id": calendarID.String()})
+			w := httptest.NewRecorder()
+
+			handler.UpdateCalendar(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if mockCalRepo.updated != nil {
+					t.Error("Expected the calendar not to be updated")
+				}
+				return
+			}
+			if mockCalRepo.updated == nil {
+				t.Fatal("Expected the calendar to be updated")
+			}
+			if got := deref(mockCalRepo.updated.HolidayCountry); got != tt.wantCountry {
+				t.Errorf("Expected holiday_country %q, got %q", tt.wantCountry, got)
+			}
+			if got := deref(mockCalRepo.updated.HolidayRegion); got != tt.wantRegion {
+				t.Errorf("Expected holiday_region %q, got %q", tt.wantRegion, got)
+			}
+		})
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestCalendarHandler_TransferCalendar(t *testing.T) {
+	cfg := &config.Config{}
+	calendarID := uuid.New()
+	newOwnerID := uuid.New()
+
+	tests := []struct {
+		name       string
+		calendar   *models.Calendar
+		quota      *mockQuotaService
+		userID     string
+		wantStatus int
+	}{
+		{"transferred", &models.Calendar{OwnerID: uuid.New()}, &mockQuotaService{serverLimit: -1, canCreate: true}, newOwnerID.String(), http.StatusOK},
+		{"organization calendar", &models.Calendar{OwnerID: newOwnerID, OrganizationID: &calendarID}, &mockQuotaService{serverLimit: -1, canCreate: true}, newOwnerID.String(), http.StatusOK},
+		{"cloud quota of new owner exceeded", &models.Calendar{OwnerID: uuid.New()}, &mockQuotaService{serverLimit: -1}, newOwnerID.String(), http.StatusForbidden},
+		{"self-hosted server limit reached", &models.Calendar{OwnerID: uuid.New()}, &mockQuotaService{serverLimit: 10}, newOwnerID.String(), http.StatusOK},
+		{"already owner", &models.Calendar{OwnerID: newOwnerID}, &mockQuotaService{serverLimit: 0}, newOwnerID.String(), http.StatusConflict},
+		{"calendar not found", nil, &mockQuotaService{serverLimit: 0}, newOwnerID.String(), http.StatusNotFound},
+		{"invalid user id", &models.Calendar{}, &mockQuotaService{serverLimit: 0}, "not-a-uuid", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCalRepo := &mockCalendarRepository{calendar: tt.calendar}
+			calendarSvc := service.NewCalendarService(mockCalRepo, &mockParticipantRepository{}, nil, nil, nil, nil, nil, &mockCache{}, cfg)
+			handler := handlers.NewCalendarHandler(calendarSvc, tt.quota, nil, cfg)
+
+			req := testutil.MakeJSONRequest(http.MethodPost, "/api/v1/calendars/admin/"+calendarID.String()+"/transfer", map[string]string{"user_id": tt.userID})
+			req = testutil.WithAuth(req, uuid.New().String(), "admin")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", calendarID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.TransferCalendar(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			transferred := mockCalRepo.transferredTo != nil && *mockCalRepo.transferredTo == newOwnerID
+			if transferred != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("Expected transfer to happen only on success, transferred = %v", transferred)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/calendar/service"
+)
+
+// ConfirmationHandler handles the owner's confirmation of the dates reaching the threshold
+type ConfirmationHandler struct {
+	confirmationService *service.ConfirmationService
+	logger              *slog.Logger
+}
+
+// NewConfirmationHandler creates a new event confirmation handler
+func NewConfirmationHandler(confirmationService *service.ConfirmationService, logger *slog.Logger) *ConfirmationHandler {
+	return &ConfirmationHandler{
+		confirmationService: confirmationService,
+		logger:              logger,
+	}
+}
+
+// List lists the event confirmations of a calendar
+//
+//	@Summary		List event confirmations
+//	@Description	Lists the dates proposed to the owner of a calendar requiring confirmation, and the decided ones. Owner or organization admin only.
+//	@Tags			Calendars
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string	true	"Calendar ID"
+//	@Param			status	query		string	false	"pending, confirmed or declined"
+//	@Success		200		{array}		models.EventConfirmationResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid status"
+//	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/api/v1/calendars/{id}/confirmations [get]
+func (h *ConfirmationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	confirmations, err := h.confirmationService.List(r.Context(), userID, middleware.GetUserRole(r.Context()), calendarID, r.URL.Query().Get("status"))
+	if err != nil {
+		h.handleError(w, err, "Failed to list event confirmations")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, confirmationResponses(confirmations))
+}
+
+// Decide confirms or declines a date of a calendar
+//
+//	@Summary		Confirm or decline an event
+//	@Description	Confirms a date, which then appears in the ICS feed while it reaches the threshold, or declines it, so it is never proposed again. Dates can be decided before being proposed. Owner or organization admin only.
+//	@Tags			Calendars
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"Calendar ID"
+//	@Param			date	path		string								true	"Date (YYYY-MM-DD)"
+//	@Param			request	body		models.DecideConfirmationRequest	true	"Decision"
+//	@Success		200		{object}	models.EventConfirmationResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/api/v1/calendars/{id}/confirmations/{date} [put]
+func (h *ConfirmationHandler) Decide(w http.ResponseWriter, r *http.Request) {
+	userID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", chi.URLParam(r, "date"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	req, ok := decodeDecision(w, r)
+	if !ok {
+		return
+	}
+
+	confirmation, err := h.confirmationService.Decide(r.Context(), userID, middleware.GetUserRole(r.Context()), calendarID, date, req.Status)
+	if err != nil {
+		h.handleError(w, err, "Failed to decide on event")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, confirmation.ToResponse())
+}
+
+// GetByToken returns the proposed event of an email link
+//
+//	@Summary		Get a proposed event
+//	@Description	Returns the calendar, date and status of the event of a confirmation link sent to the owner by email
+//	@Tags			Calendars
+//	@Produce		json
+//	@Param			token	path		string	true	"Confirmation token"
+//	@Success		200		{object}	models.EventConfirmationResponse
+//	@Failure		404		{object}	httputil.ErrorResponse	"Unknown token"
+//	@Router			/api/v1/calendars/confirmations/{token} [get]
+func (h *ConfirmationHandler) GetByToken(w http.ResponseWriter, r *http.Request) {
+	confirmation, err := h.confirmationService.GetByToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.handleError(w, err, "Failed to get event confirmation")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, confirmation.ToResponse())
+}
+
+// DecideByToken confirms or declines the proposed event of an email link
+//
+//	@Summary		Confirm or decline a proposed event
+//	@Description	Confirms or declines the event of a confirmation link sent to the owner by email. The link stays valid to change the decision
+//	@Tags			Calendars
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string								true	"Confirmation token"
+//	@Param			request	body		models.DecideConfirmationRequest	true	"Decision"
+//	@Success		200		{object}	models.EventConfirmationResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Unknown token"
+//	@Router			/api/v1/calendars/confirmations/{token} [post]
+func (h *ConfirmationHandler) DecideByToken(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeDecision(w, r)
+	if !ok {
+		return
+	}
+
+	confirmation, err := h.confirmationService.DecideByToken(r.Context(), chi.URLParam(r, "token"), req.Status)
+	if err != nil {
+		h.handleError(w, err, "Failed to decide on event")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, confirmation.ToResponse())
+}
+
+// decodeDecision decodes and validates a decision, writing an error response if invalid
+func decodeDecision(w http.ResponseWriter, r *http.Request) (*models.DecideConfirmationRequest, bool) {
+	var req models.DecideConfirmationRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return nil, false
+	}
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return nil, false
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return nil, false
+	}
+	return &req, true
+}
+
+// confirmationResponses converts event confirmations to their API responses
+func confirmationResponses(confirmations []*models.EventConfirmation) []models.EventConfirmationResponse {
+	responses := make([]models.EventConfirmationResponse, 0, len(confirmations))
+	for _, confirmation := range confirmations {
+		responses = append(responses, confirmation.ToResponse())
+	}
+	return responses
+}
+
+// params returns the authenticated user ID and the calendar ID, writing an error response if invalid
+func (h *ConfirmationHandler) params(w http.ResponseWriter, r *http.Request) (string, uuid.UUID, bool) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return "", uuid.Nil, false
+	}
+
+	calendarID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid calendar ID")
+		return "", uuid.Nil, false
+	}
+	return userID, calendarID, true
+}
+
+// handleError writes the response of an event confirmation error
+func (h *ConfirmationHandler) handleError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrCalendarNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+	case errors.Is(err, service.ErrConfirmationNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Confirmation link not found")
+	case errors.Is(err, service.ErrInvalidStatus):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+	case errors.Is(err, service.ErrUnauthorized):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't have permission to modify this calendar")
+	default:
+		h.logger.Error(message, "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, message)
+	}
+}
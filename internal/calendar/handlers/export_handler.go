@@ -0,0 +1,123 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/logger"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/calendar/service"
+)
+
+// ExportCalendar downloads a calendar as a JSON bundle
+//
+//	@Summary		Export a calendar
+//	@Description	Downloads a JSON bundle with the calendar settings, participants, availabilities, recurrences and their exceptions. Owner or admin only. The bundle can be restored with the import endpoint.
+//	@Tags			Calendars
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Calendar ID"
+//	@Success		200	{object}	models.CalendarExport
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/api/v1/calendars/{id}/export [get]
+func (h *CalendarHandler) ExportCalendar(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	userRole := middleware.GetUserRole(r.Context())
+	if userID == "" {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	calendarID := chi.URLParam(r, "id")
+
+	export, err := h.calendarService.ExportCalendar(r.Context(), userID, userRole, calendarID, time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't have permission to export this calendar")
+			return
+		}
+		logger.FromContext(r.Context()).Error("Failed to export calendar", "error", err, "calendar_id", calendarID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to export calendar")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"whento-%s.json\"", calendarID))
+	httputil.JSON(w, http.StatusOK, export)
+}
+
+// ImportCalendar restores a calendar from a JSON bundle
+//
+//	@Summary		Import a calendar
+//	@Description	Creates a calendar from a bundle produced by the export endpoint, for the authenticated user or the organization selected by the X-Organization-ID header. New tokens are generated and participant emails must be verified again. Enforces quota limits.
+//	@Tags			Calendars
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Organization-ID	header		string					false	"Organization ID"
+//	@Param			request				body		models.CalendarExport	true	"Calendar export"
+//	@Success		201		{object}	models.CalendarResponse
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid bundle"
+//	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Quota exceeded"
+//	@Router			/api/v1/calendars/import [post]
+func (h *CalendarHandler) ImportCalendar(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var export models.CalendarExport
+	if err := httputil.DecodeJSON(r, &export); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&export.Calendar); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+	if len(export.Participants) > 0 && export.Calendar.Threshold > len(export.Participants) {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, "Threshold cannot exceed the number of participants")
+		return
+	}
+
+	organizationID, ok := h.checkCanCreate(w, r, userID)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.calendarService.ImportCalendar(r.Context(), userID, organizationID, &export)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidExport) || errors.Is(err, service.ErrInvalidBlackout) || errors.Is(err, service.ErrInvalidHoliday) || errors.Is(err, service.ErrRegionWithoutCountry) || errors.Is(err, service.ErrInvalidHolidayCountry) || errors.Is(err, service.ErrInvalidCapacity) || errors.Is(err, service.ErrInvalidThreshold) || errors.Is(err, service.ErrInvalidSoftThreshold) {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+			return
+		}
+		logger.FromContext(r.Context()).Error("Failed to import calendar", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to import calendar")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, calendar)
+}
@@ -0,0 +1,77 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/whento/whento/internal/calendar/models"
+)
+
+const (
+	defaultCalendarPageSize = 20
+	maxCalendarPageSize     = 100
+)
+
+// calendarListQuery holds the parameters of the calendar list
+type calendarListQuery struct {
+	Filter           models.CalendarListFilter
+	Page             int
+	PerPage          int
+	Paginated        bool // page or per_page set: the response is a page envelope instead of an array
+	WithParticipants bool
+}
+
+// parseCalendarListQuery reads the search, sort, pagination and participants parameters of the calendar list
+func parseCalendarListQuery(r *http.Request) (calendarListQuery, error) {
+	q := r.URL.Query()
+	query := calendarListQuery{
+		Filter: models.CalendarListFilter{
+			Search: strings.TrimSpace(q.Get("q")),
+			Sort:   q.Get("sort"),
+		},
+		Page:             1,
+		PerPage:          defaultCalendarPageSize,
+		WithParticipants: true,
+	}
+
+	if _, err := models.SortClause(query.Filter.Sort); err != nil {
+		return query, err
+	}
+
+	if p := q.Get("page"); p != "" {
+		page, err := strconv.Atoi(p)
+		if err != nil || page < 1 {
+			return query, fmt.Errorf("invalid page %q", p)
+		}
+		query.Page = page
+		query.Paginated = true
+	}
+	if pp := q.Get("per_page"); pp != "" {
+		perPage, err := strconv.Atoi(pp)
+		if err != nil || perPage < 1 {
+			return query, fmt.Errorf("invalid per_page %q", pp)
+		}
+		query.PerPage = min(perPage, maxCalendarPageSize)
+		query.Paginated = true
+	}
+	if query.Paginated {
+		query.Filter.Limit = query.PerPage
+		query.Filter.Offset = (query.Page - 1) * query.PerPage
+	}
+
+	if p := q.Get("participants"); p != "" {
+		withParticipants, err := strconv.ParseBool(p)
+		if err != nil {
+			return query, fmt.Errorf("invalid participants %q", p)
+		}
+		query.WithParticipants = withParticipants
+	}
+
+	return query, nil
+}
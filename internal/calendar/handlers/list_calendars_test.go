@@ -0,0 +1,55 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseCalendarListQuery(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/v1/calendars?q=+club+&sort=-name&page=3&per_page=500&participants=false", nil)
+
+	query, err := parseCalendarListQuery(r)
+	if err != nil {
+		t.Fatalf("parseCalendarListQuery: %v", err)
+	}
+	if query.Filter.Search != "club" || query.Filter.Sort != "-name" {
+		t.Errorf("search/sort = %q/%q", query.Filter.Search, query.Filter.Sort)
+	}
+	if !query.Paginated || query.PerPage != maxCalendarPageSize || query.Filter.Limit != maxCalendarPageSize || query.Filter.Offset != 2*maxCalendarPageSize {
+		t.Errorf("pagination = %+v", query)
+	}
+	if query.WithParticipants {
+		t.Error("participants not parsed")
+	}
+}
+
+func TestParseCalendarListQuery_Defaults(t *testing.T) {
+	query, err := parseCalendarListQuery(httptest.NewRequest("GET", "/api/v1/calendars", nil))
+	if err != nil {
+		t.Fatalf("parseCalendarListQuery: %v", err)
+	}
+	// Without page nor per_page, all the calendars are returned as an array
+	if query.Paginated || query.Filter.Limit != 0 || !query.WithParticipants {
+		t.Errorf("unexpected defaults: %+v", query)
+	}
+
+	query, err = parseCalendarListQuery(httptest.NewRequest("GET", "/api/v1/calendars?page=2", nil))
+	if err != nil {
+		t.Fatalf("parseCalendarListQuery: %v", err)
+	}
+	if query.Filter.Limit != defaultCalendarPageSize || query.Filter.Offset != defaultCalendarPageSize {
+		t.Errorf("page 2 = limit %d, offset %d", query.Filter.Limit, query.Filter.Offset)
+	}
+}
+
+func TestParseCalendarListQuery_Invalid(t *testing.T) {
+	for _, query := range []string{"sort=owner", "sort=--name", "page=0", "per_page=x", "participants=some"} {
+		if _, err := parseCalendarListQuery(httptest.NewRequest("GET", "/api/v1/calendars?"+query, nil)); err == nil {
+			t.Errorf("%s: expected an error", query)
+		}
+	}
+}
@@ -7,10 +7,12 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/logger"
 	"github.com/whento/pkg/middleware"
 	"github.com/whento/pkg/validator"
 	"github.com/whento/whento/internal/calendar/models"
@@ -93,7 +95,7 @@ func (h *ParticipantHandler) AddParticipant(w http.ResponseWriter, r *http.Reque
 // UpdateParticipant updates a participant's name
 //
 //	@Summary		Update participant
-//	@Description	Updates a participant's details. Owner or admin only.
+//	@Description	Updates a participant's name, or whether they are required: dates only reach the threshold when every required participant is available. Omitted fields are kept. Owner or admin only.
 //	@Tags			Participants
 //	@Accept			json
 //	@Produce		json
@@ -158,6 +160,52 @@ func (h *ParticipantHandler) UpdateParticipant(w http.ResponseWriter, r *http.Re
 	httputil.JSON(w, http.StatusOK, participant)
 }
 
+// RegenerateParticipantToken issues a new secret link for a participant
+//
+//	@Summary		Regenerate participant token
+//	@Description	Issues a new access token for a participant. Their previous link stops working. Owner or admin only.
+//	@Tags			Participants
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Calendar ID"
+//	@Param			pid	path		string	true	"Participant ID"
+//	@Success		200	{object}	models.Participant
+//	@Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	httputil.ErrorResponse	"Calendar or participant not found"
+//	@Router			/api/v1/calendars/{id}/participants/{pid}/regenerate-token [post]
+func (h *ParticipantHandler) RegenerateParticipantToken(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	userRole := middleware.GetUserRole(r.Context())
+	if userID == "" {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	calendarID := chi.URLParam(r, "id")
+	participantID := chi.URLParam(r, "pid")
+
+	participant, err := h.calendarService.RegenerateParticipantToken(r.Context(), userID, userRole, calendarID, participantID)
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+			return
+		}
+		if errors.Is(err, service.ErrParticipantNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Participant not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't have permission to modify this calendar")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to regenerate participant token")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, participant)
+}
+
 // RemoveParticipant removes a participant from a calendar
 //
 //	@Summary		Remove participant
@@ -203,3 +251,64 @@ func (h *ParticipantHandler) RemoveParticipant(w http.ResponseWriter, r *http.Re
 
 	httputil.JSON(w, http.StatusOK, map[string]string{"message": "Participant removed successfully"})
 }
+
+// maxCSVImportSize caps the size of a CSV import of participants
+const maxCSVImportSize = 1 << 20
+
+// ImportParticipantsCSV adds the participants of a CSV file to a calendar
+//
+//	@Summary		Import participants from CSV
+//	@Description	Adds participants from a CSV body with name, email and locale columns (in this order, or in any order after a header line naming them). Emails and locales are optional; commas and semicolons are accepted as separators. Rows whose name or email is already in the calendar, or earlier in the file, are reported as duplicates and skipped, as are invalid rows. With send_invites, participants with an email receive a verification email. At most 500 rows. Owner or admin only.
+//	@Tags			Participants
+//	@Accept			text/csv
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		string	true	"Calendar ID"
+//	@Param			send_invites	query		bool	false	"Send a verification email to the participants with an email"
+//	@Param			request			body		string	true	"CSV file"
+//	@Success		200				{object}	models.CSVImportResponse
+//	@Failure		400				{object}	httputil.ErrorResponse	"Invalid CSV or too many rows"
+//	@Failure		401				{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	httputil.ErrorResponse	"Forbidden"
+//	@Failure		404				{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/api/v1/calendars/{id}/participants/import/csv [post]
+func (h *ParticipantHandler) ImportParticipantsCSV(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	userRole := middleware.GetUserRole(r.Context())
+	if userID == "" {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	sendInvites := false
+	if value := r.URL.Query().Get("send_invites"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "send_invites must be true or false")
+			return
+		}
+		sendInvites = parsed
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCSVImportSize)
+	response, err := h.calendarService.ImportParticipantsCSV(r.Context(), userID, userRole, chi.URLParam(r, "id"), r.Body, sendInvites)
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't have permission to modify this calendar")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidCSV) || errors.Is(err, service.ErrCSVTooManyRows) {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+			return
+		}
+		logger.FromContext(r.Context()).Error("Failed to import participants", "error", err, "calendar_id", chi.URLParam(r, "id"))
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to import participants")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response)
+}
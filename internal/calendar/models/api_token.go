@@ -0,0 +1,107 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scopes of calendar API tokens
+const (
+	APITokenScopeSummariesRead       = "summaries:read"       // Date and range summaries
+	APITokenScopeAvailabilitiesWrite = "availabilities:write" // Availabilities of the participants
+	APITokenScopeParticipantsManage  = "participants:manage"  // Add, rename and remove participants
+)
+
+// APIToken is a token acting on a single calendar, on behalf of the user who created it (only its hash is stored)
+type APIToken struct {
+	ID         uuid.UUID
+	CalendarID uuid.UUID
+	Name       string
+	Scopes     []string
+	CreatedBy  uuid.UUID
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// APITokenOwner is the user and calendar authenticated by a calendar API token
+type APITokenOwner struct {
+	TokenID    uuid.UUID
+	CalendarID uuid.UUID
+	Scopes     []string
+	UserID     uuid.UUID
+	Email      string
+}
+
+// Allows reports whether the scopes of the token allow a request
+// Every scope can read the calendar itself; other routes of the calendar require the scope they belong to
+func (o *APITokenOwner) Allows(method, path string) bool {
+	rest, ok := strings.CutPrefix(path, "/api/v1/calendars/"+o.CalendarID.String())
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return false
+	}
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+	read := method == http.MethodGet || method == http.MethodHead
+
+	switch {
+	case rest == "" || rest == "/":
+		return read
+	case segments[0] == "range" && len(segments) == 1, segments[0] == "dates" && len(segments) == 2:
+		return read && slices.Contains(o.Scopes, APITokenScopeSummariesRead)
+	case segments[0] != "participants":
+		return false
+	case len(segments) >= 3 && segments[2] == "availabilities" && len(segments) <= 4:
+		return slices.Contains(o.Scopes, APITokenScopeAvailabilitiesWrite)
+	case len(segments) == 1:
+		return method == http.MethodPost && slices.Contains(o.Scopes, APITokenScopeParticipantsManage)
+	case len(segments) == 2:
+		return (method == http.MethodPatch || method == http.MethodDelete) && slices.Contains(o.Scopes, APITokenScopeParticipantsManage)
+	case len(segments) == 3 && segments[2] == "regenerate-token":
+		return method == http.MethodPost && slices.Contains(o.Scopes, APITokenScopeParticipantsManage)
+	default:
+		return false
+	}
+}
+
+// CreateAPITokenRequest represents a request to create a calendar API token
+type CreateAPITokenRequest struct {
+	Name   string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=summaries:read availabilities:write participants:manage"`
+}
+
+// APITokenResponse is the API response for a calendar API token (the token itself is never returned)
+type APITokenResponse struct {
+	ID         string     `json:"id"`
+	CalendarID string     `json:"calendar_id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPITokenResponse is returned once, when a calendar API token is created
+type CreatedAPITokenResponse struct {
+	APITokenResponse
+	Token string `json:"token"` // Shown only once
+}
+
+// ToResponse converts an APIToken to APITokenResponse
+func (t *APIToken) ToResponse() APITokenResponse {
+	return APITokenResponse{
+		ID:         t.ID.String(),
+		CalendarID: t.CalendarID.String(),
+		Name:       t.Name,
+		Scopes:     t.Scopes,
+		CreatedBy:  t.CreatedBy.String(),
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
@@ -0,0 +1,54 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAPITokenOwner_Allows(t *testing.T) {
+	calendarID := uuid.New()
+	base := "/api/v1/calendars/" + calendarID.String()
+
+	tests := []struct {
+		name   string
+		scopes []string
+		method string
+		path   string
+		want   bool
+	}{
+		{"read calendar", nil, "GET", base, true},
+		{"update calendar", []string{APITokenScopeSummariesRead, APITokenScopeAvailabilitiesWrite, APITokenScopeParticipantsManage}, "PATCH", base, false},
+		{"other calendar", []string{APITokenScopeSummariesRead}, "GET", "/api/v1/calendars/" + uuid.NewString() + "/range", false},
+		{"calendar list", []string{APITokenScopeSummariesRead}, "GET", "/api/v1/calendars", false},
+		{"other API", []string{APITokenScopeSummariesRead}, "GET", "/api/v1/auth/me", false},
+		{"range summary", []string{APITokenScopeSummariesRead}, "GET", base + "/range", true},
+		{"date summary", []string{APITokenScopeSummariesRead}, "GET", base + "/dates/2025-06-01", true},
+		{"summary without scope", []string{APITokenScopeAvailabilitiesWrite}, "GET", base + "/range", false},
+		{"create availability", []string{APITokenScopeAvailabilitiesWrite}, "POST", base + "/participants/p1/availabilities", true},
+		{"delete availability", []string{APITokenScopeAvailabilitiesWrite}, "DELETE", base + "/participants/p1/availabilities/2025-06-01", true},
+		{"bulk availabilities", []string{APITokenScopeAvailabilitiesWrite}, "POST", base + "/participants/p1/availabilities/bulk", true},
+		{"availability without scope", []string{APITokenScopeParticipantsManage}, "POST", base + "/participants/p1/availabilities", false},
+		{"add participant", []string{APITokenScopeParticipantsManage}, "POST", base + "/participants", true},
+		{"remove participant", []string{APITokenScopeParticipantsManage}, "DELETE", base + "/participants/p1", true},
+		{"import participants", []string{APITokenScopeParticipantsManage}, "POST", base + "/participants/import", false},
+		{"regenerate participant token", []string{APITokenScopeParticipantsManage}, "POST", base + "/participants/p1/regenerate-token", true},
+		{"regenerate participant token without scope", []string{APITokenScopeAvailabilitiesWrite}, "POST", base + "/participants/p1/regenerate-token", false},
+		{"participant without scope", []string{APITokenScopeAvailabilitiesWrite}, "PATCH", base + "/participants/p1", false},
+		{"manage tokens", []string{APITokenScopeSummariesRead, APITokenScopeAvailabilitiesWrite, APITokenScopeParticipantsManage}, "GET", base + "/tokens", false},
+		{"webhooks", []string{APITokenScopeSummariesRead, APITokenScopeAvailabilitiesWrite, APITokenScopeParticipantsManage}, "GET", base + "/webhooks", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := &APITokenOwner{CalendarID: calendarID, Scopes: tt.scopes}
+			if got := owner.Allows(tt.method, tt.path); got != tt.want {
+				t.Errorf("Allows(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+			}
+		})
+	}
+}
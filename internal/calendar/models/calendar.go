@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/whento/pkg/datevalidation"
 	"github.com/whento/pkg/models"
 )
 
@@ -21,23 +22,50 @@ type TimeRange struct {
 // Calendar represents a calendar with availability tracking
 type Calendar struct {
 	models.TimestampedEntity
-	OwnerID           uuid.UUID  `json:"owner_id"`
-	Name              string     `json:"name"`
-	Description       string     `json:"description,omitempty"`
-	PublicToken       string     `json:"public_token"`
-	ICSToken          string     `json:"ics_token"`
-	Threshold         int        `json:"threshold"`
-	AllowedWeekdays   []int      `json:"allowed_weekdays"`
-	MinDurationHours  int        `json:"min_duration_hours"`
-	Timezone          string     `json:"timezone"`
-	HolidaysPolicy    string     `json:"holidays_policy"`
-	AllowHolidayEves  bool       `json:"allow_holiday_eves"`
-	AllowedHours      *string    `json:"allowed_hours,omitempty"` // JSONB stored as nullable string
-	NotifyOnThreshold bool       `json:"notify_on_threshold"`
-	NotifyConfig      *string    `json:"notify_config,omitempty"` // JSONB stored as nullable string
-	LockParticipants  bool       `json:"lock_participants"`
-	StartDate         *time.Time `json:"start_date,omitempty"`
-	EndDate           *time.Time `json:"end_date,omitempty"`
+	OwnerID           uuid.UUID                       `json:"owner_id"`
+	OrganizationID    *uuid.UUID                      `json:"organization_id,omitempty"` // Nil for personal calendars
+	Name              string                          `json:"name"`
+	Description       string                          `json:"description,omitempty"`
+	PublicToken       string                          `json:"public_token"`
+	ICSToken          string                          `json:"ics_token"`
+	Threshold         int                             `json:"threshold"`                   // Effective threshold, derived from ThresholdPercent when set
+	ThresholdPercent  *int                            `json:"threshold_percent,omitempty"` // Nullable, threshold as a percentage of the participants
+	SoftThreshold     *int                            `json:"soft_threshold,omitempty"`    // Nullable, lower level only notified ("looking good")
+	RequireConfirm    bool                            `json:"require_confirmation"`        // Dates reaching the threshold need the owner's confirmation to become events
+	AllowedWeekdays   []int                           `json:"allowed_weekdays"`
+	MinDurationHours  int                             `json:"min_duration_hours"`
+	Timezone          string                          `json:"timezone"`
+	HolidaysPolicy    string                          `json:"holidays_policy"`
+	AllowHolidayEves  bool                            `json:"allow_holiday_eves"`
+	HolidaySets       []string                        `json:"holiday_sets"`              // Additional holiday sets (orthodox, islamic, jewish)
+	BlackoutDates     []datevalidation.BlackoutPeriod `json:"blackout_dates"`            // Dates blocked whatever the weekday and holiday rules
+	CustomHolidays    []datevalidation.CustomHoliday  `json:"custom_holidays"`           // Holidays added by the owner, on top of country holidays
+	HolidayCountry    *string                         `json:"holiday_country,omitempty"` // Nullable, derived from the timezone when unset
+	HolidayRegion     *string                         `json:"holiday_region,omitempty"`  // Nullable, nationwide holidays only when unset
+	HolidayCountries  []string                        `json:"holiday_countries"`         // Other countries whose public holidays also count (BE, DE-BY)
+	AllowedHours      *string                         `json:"allowed_hours,omitempty"`   // JSONB stored as nullable string
+	NotifyOnThreshold bool                            `json:"notify_on_threshold"`
+	NotifyConfig      *string                         `json:"notify_config,omitempty"` // JSONB stored as nullable string
+	LockParticipants  bool                            `json:"lock_participants"`
+	StartDate         *time.Time                      `json:"start_date,omitempty"`
+	EndDate           *time.Time                      `json:"end_date,omitempty"`
+	WeekStart         *string                         `json:"week_start,omitempty"`               // Nullable, inherits the instance default when unset
+	TimeFormat        *string                         `json:"time_format,omitempty"`              // Nullable, inherits the instance default when unset
+	DateFormat        *string                         `json:"date_format,omitempty"`              // Nullable, inherits the instance default when unset
+	ReminderMinutes   []int                           `json:"ics_reminder_minutes"`               // VALARM reminders of the ICS feed events, in minutes before the start
+	EventTitle        *string                         `json:"ics_title_template,omitempty"`       // Nullable, built-in title when unset
+	EventDescription  *string                         `json:"ics_description_template,omitempty"` // Nullable, participant list when unset
+	FeedPastDays      *int                            `json:"ics_past_days,omitempty"`            // Nullable, days of past events in the ICS feed (unset = all)
+	FeedFutureDays    *int                            `json:"ics_future_days,omitempty"`          // Nullable, days of upcoming events in the ICS feed (unset = all)
+	CountMaybe        bool                            `json:"count_maybe"`                        // "maybe" availabilities count toward the threshold
+	MaxParticipants   *int                            `json:"max_participants,omitempty"`         // Nullable, participants counted per date (unset = no limit)
+	CapacityPolicy    string                          `json:"capacity_policy"`                    // Availabilities on a full date: "reject" or "waitlist"
+}
+
+// PercentThreshold returns the participants needed for a percentage of a calendar, rounded up and at least 1
+// Must match the percent_threshold SQL function
+func PercentThreshold(percent, participants int) int {
+	return max(1, (percent*participants+99)/100)
 }
 
 // Participant represents a participant in a calendar
@@ -47,9 +75,12 @@ type Participant struct {
 	Name                            string     `json:"name"`
 	Email                           *string    `json:"email,omitempty"` // Nullable
 	EmailVerified                   bool       `json:"email_verified"`
-	EmailVerificationToken          *string    `json:"-"`      // Not exposed in API responses
-	EmailVerificationTokenExpiresAt *time.Time `json:"-"`      // Not exposed in API responses
-	Locale                          string     `json:"locale"` // Preferred language for notifications (e.g., 'en', 'fr')
+	EmailVerificationToken          *string    `json:"-"`                      // Not exposed in API responses
+	EmailVerificationTokenExpiresAt *time.Time `json:"-"`                      // Not exposed in API responses
+	Locale                          string     `json:"locale"`                 // Preferred language for notifications (e.g., 'en', 'fr')
+	AccessToken                     string     `json:"access_token,omitempty"` // Secret of the participant link, only shown to the owner
+	AccessTokenClaimed              bool       `json:"-"`                      // Link used or handed out, so it can't be claimed from the public page
+	Required                        bool       `json:"required"`               // Dates only reach the threshold when all required participants are available
 	CreatedAt                       time.Time  `json:"created_at"`
 }
 
@@ -62,63 +93,109 @@ type PublicParticipant struct {
 	Email         *string    `json:"email,omitempty"` // Nullable
 	EmailVerified bool       `json:"email_verified"`
 	Locale        string     `json:"locale"`
+	AccessToken   string     `json:"access_token,omitempty"` // Secret of the participant link, only returned to the participant of that link
+	Claimable     bool       `json:"claimable,omitempty"`    // Open calendar and link never claimed: a visitor can claim it
+	Current       bool       `json:"current,omitempty"`      // Participant of the link the calendar is viewed from
+	Required      bool       `json:"required,omitempty"`
 	CreatedAt     time.Time  `json:"created_at"`
 }
 
 // CreateCalendarRequest represents a request to create a calendar
 type CreateCalendarRequest struct {
-	Name              string               `json:"name" validate:"required,min=2,max=200"`
-	Description       string               `json:"description,omitempty" validate:"max=1000"`
-	Threshold         int                  `json:"threshold,omitempty" validate:"omitempty,min=1"`
-	AllowedWeekdays   []int                `json:"allowed_weekdays,omitempty" validate:"omitempty,dive,min=0,max=6"`
-	MinDurationHours  int                  `json:"min_duration_hours,omitempty" validate:"omitempty,min=0"`
-	Timezone          string               `json:"timezone,omitempty" validate:"omitempty"`
-	HolidaysPolicy    string               `json:"holidays_policy,omitempty" validate:"omitempty,oneof=ignore allow block" enums:"ignore,allow,block"`
-	AllowHolidayEves  bool                 `json:"allow_holiday_eves,omitempty"`
-	WeekdayTimes      map[string]TimeRange `json:"weekday_times,omitempty"`
-	HolidayMinTime    string               `json:"holiday_min_time,omitempty"`
-	HolidayMaxTime    string               `json:"holiday_max_time,omitempty"`
-	HolidayEveMinTime string               `json:"holiday_eve_min_time,omitempty"`
-	HolidayEveMaxTime string               `json:"holiday_eve_max_time,omitempty"`
-	NotifyOnThreshold bool                 `json:"notify_on_threshold,omitempty"`
-	NotifyConfig      *string              `json:"notify_config,omitempty"` // JSONB stored as nullable string
-	LockParticipants  bool                 `json:"lock_participants,omitempty"`
-	StartDate         string               `json:"start_date,omitempty"`
-	EndDate           string               `json:"end_date,omitempty"`
-	ParticipantLocale string               `json:"participant_locale,omitempty" validate:"omitempty,oneof=en fr"`
-	Participants      []string             `json:"participants,omitempty" validate:"omitempty,dive,min=1,max=100"`
+	Name              string                          `json:"name" validate:"required,min=2,max=200"`
+	Description       string                          `json:"description,omitempty" validate:"max=1000"`
+	Threshold         int                             `json:"threshold,omitempty" validate:"omitempty,min=1"`
+	ThresholdPercent  *int                            `json:"threshold_percent,omitempty" validate:"omitempty,min=1,max=100"` // Replaces threshold, adapting to the participant count
+	SoftThreshold     *int                            `json:"soft_threshold,omitempty" validate:"omitempty,min=1"`            // Lower than threshold, notified without creating events
+	RequireConfirm    bool                            `json:"require_confirmation,omitempty"`
+	AllowedWeekdays   []int                           `json:"allowed_weekdays,omitempty" validate:"omitempty,dive,min=0,max=6"`
+	MinDurationHours  int                             `json:"min_duration_hours,omitempty" validate:"omitempty,min=0"`
+	Timezone          string                          `json:"timezone,omitempty" validate:"omitempty"`
+	HolidaysPolicy    string                          `json:"holidays_policy,omitempty" validate:"omitempty,oneof=ignore allow block" enums:"ignore,allow,block"`
+	AllowHolidayEves  bool                            `json:"allow_holiday_eves,omitempty"`
+	HolidaySets       []string                        `json:"holiday_sets,omitempty" validate:"omitempty,dive,oneof=orthodox islamic jewish"`
+	BlackoutDates     []datevalidation.BlackoutPeriod `json:"blackout_dates,omitempty" validate:"omitempty,max=100,dive"`
+	CustomHolidays    []datevalidation.CustomHoliday  `json:"custom_holidays,omitempty" validate:"omitempty,max=100,dive"`
+	HolidayCountry    string                          `json:"holiday_country,omitempty" validate:"omitempty,len=2,alpha"`               // ISO 3166-1 code, derived from the timezone when unset
+	HolidayRegion     string                          `json:"holiday_region,omitempty" validate:"omitempty,max=3,alphanum"`             // ISO 3166-2 subdivision of the country, e.g. "BY"
+	HolidayCountries  []string                        `json:"holiday_countries,omitempty" validate:"omitempty,max=10,dive,min=2,max=6"` // Other countries, optionally with a region ("DE-BY")
+	WeekdayTimes      map[string]TimeRange            `json:"weekday_times,omitempty"`
+	HolidayMinTime    string                          `json:"holiday_min_time,omitempty"`
+	HolidayMaxTime    string                          `json:"holiday_max_time,omitempty"`
+	HolidayEveMinTime string                          `json:"holiday_eve_min_time,omitempty"`
+	HolidayEveMaxTime string                          `json:"holiday_eve_max_time,omitempty"`
+	NotifyOnThreshold bool                            `json:"notify_on_threshold,omitempty"`
+	NotifyConfig      *string                         `json:"notify_config,omitempty"` // JSONB stored as nullable string
+	LockParticipants  bool                            `json:"lock_participants,omitempty"`
+	StartDate         string                          `json:"start_date,omitempty"`
+	EndDate           string                          `json:"end_date,omitempty"`
+	WeekStart         string                          `json:"week_start,omitempty" validate:"omitempty,oneof=sunday monday" enums:"sunday,monday"`
+	TimeFormat        string                          `json:"time_format,omitempty" validate:"omitempty,oneof=24h 12h" enums:"24h,12h"`
+	DateFormat        string                          `json:"date_format,omitempty" validate:"omitempty,oneof=iso long" enums:"iso,long"`
+	ReminderMinutes   []int                           `json:"ics_reminder_minutes,omitempty" validate:"omitempty,max=5,dive,min=0,max=40320"` // Minutes before the start, at most 4 weeks
+	EventTitle        string                          `json:"ics_title_template,omitempty" validate:"max=200"`                                // Placeholders such as {{calendar}}, {{date}} or {{count}}, see the README
+	EventDescription  string                          `json:"ics_description_template,omitempty" validate:"max=2000"`
+	FeedPastDays      *int                            `json:"ics_past_days,omitempty" validate:"omitempty,min=0,max=3650"`   // Unset = all past events
+	FeedFutureDays    *int                            `json:"ics_future_days,omitempty" validate:"omitempty,min=0,max=3650"` // Unset = all upcoming events
+	CountMaybe        bool                            `json:"count_maybe,omitempty"`
+	MaxParticipants   *int                            `json:"max_participants,omitempty" validate:"omitempty,min=1,max=10000"`                              // Unset = no limit
+	CapacityPolicy    string                          `json:"capacity_policy,omitempty" validate:"omitempty,oneof=reject waitlist" enums:"reject,waitlist"` // Default: reject
+	ParticipantLocale string                          `json:"participant_locale,omitempty" validate:"omitempty,locale"`
+	Participants      []string                        `json:"participants,omitempty" validate:"omitempty,dive,min=1,max=100"`
 }
 
 // UpdateCalendarRequest represents a request to update a calendar
 type UpdateCalendarRequest struct {
-	Name              *string              `json:"name,omitempty" validate:"omitempty,min=2,max=200"`
-	Description       *string              `json:"description,omitempty" validate:"omitempty,max=1000"`
-	Threshold         *int                 `json:"threshold,omitempty" validate:"omitempty,min=1"`
-	AllowedWeekdays   []int                `json:"allowed_weekdays,omitempty" validate:"omitempty,dive,min=0,max=6"`
-	MinDurationHours  *int                 `json:"min_duration_hours,omitempty" validate:"omitempty,min=0"`
-	Timezone          *string              `json:"timezone,omitempty" validate:"omitempty"`
-	HolidaysPolicy    *string              `json:"holidays_policy,omitempty" validate:"omitempty,oneof=ignore allow block" enums:"ignore,allow,block"`
-	AllowHolidayEves  *bool                `json:"allow_holiday_eves,omitempty"`
-	WeekdayTimes      map[string]TimeRange `json:"weekday_times,omitempty"`
-	HolidayMinTime    *string              `json:"holiday_min_time,omitempty"`
-	HolidayMaxTime    *string              `json:"holiday_max_time,omitempty"`
-	HolidayEveMinTime *string              `json:"holiday_eve_min_time,omitempty"`
-	HolidayEveMaxTime *string              `json:"holiday_eve_max_time,omitempty"`
-	NotifyOnThreshold *bool                `json:"notify_on_threshold,omitempty"`
-	NotifyConfig      *string              `json:"notify_config,omitempty"` // JSONB stored as nullable string
-	LockParticipants  *bool                `json:"lock_participants,omitempty"`
-	StartDate         *string              `json:"start_date,omitempty"`
-	EndDate           *string              `json:"end_date,omitempty"`
+	Name              *string                         `json:"name,omitempty" validate:"omitempty,min=2,max=200"`
+	Description       *string                         `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Threshold         *int                            `json:"threshold,omitempty" validate:"omitempty,min=1"`                 // Switches back to an absolute threshold
+	ThresholdPercent  *int                            `json:"threshold_percent,omitempty" validate:"omitempty,min=0,max=100"` // 0 switches back to the absolute threshold
+	SoftThreshold     *int                            `json:"soft_threshold,omitempty" validate:"omitempty,min=0"`            // 0 removes the soft threshold
+	RequireConfirm    *bool                           `json:"require_confirmation,omitempty"`
+	AllowedWeekdays   []int                           `json:"allowed_weekdays,omitempty" validate:"omitempty,dive,min=0,max=6"`
+	MinDurationHours  *int                            `json:"min_duration_hours,omitempty" validate:"omitempty,min=0"`
+	Timezone          *string                         `json:"timezone,omitempty" validate:"omitempty"`
+	HolidaysPolicy    *string                         `json:"holidays_policy,omitempty" validate:"omitempty,oneof=ignore allow block" enums:"ignore,allow,block"`
+	AllowHolidayEves  *bool                           `json:"allow_holiday_eves,omitempty"`
+	HolidaySets       []string                        `json:"holiday_sets,omitempty" validate:"omitempty,dive,oneof=orthodox islamic jewish"` // Empty array clears all sets
+	BlackoutDates     []datevalidation.BlackoutPeriod `json:"blackout_dates,omitempty" validate:"omitempty,max=100,dive"`                     // Replaces all periods, empty array clears them
+	CustomHolidays    []datevalidation.CustomHoliday  `json:"custom_holidays,omitempty" validate:"omitempty,max=100,dive"`                    // Replaces all custom holidays, empty array clears them
+	HolidayCountry    *string                         `json:"holiday_country,omitempty" validate:"omitempty,len=0|len=2,len=0|alpha"`         // Empty string derives it from the timezone again, a new country clears the region
+	HolidayRegion     *string                         `json:"holiday_region,omitempty" validate:"omitempty,max=3,len=0|alphanum"`             // Empty string keeps nationwide holidays only
+	HolidayCountries  []string                        `json:"holiday_countries,omitempty" validate:"omitempty,max=10,dive,min=2,max=6"`       // Replaces all other countries, empty array clears them
+	WeekdayTimes      map[string]TimeRange            `json:"weekday_times,omitempty"`
+	HolidayMinTime    *string                         `json:"holiday_min_time,omitempty"`
+	HolidayMaxTime    *string                         `json:"holiday_max_time,omitempty"`
+	HolidayEveMinTime *string                         `json:"holiday_eve_min_time,omitempty"`
+	HolidayEveMaxTime *string                         `json:"holiday_eve_max_time,omitempty"`
+	NotifyOnThreshold *bool                           `json:"notify_on_threshold,omitempty"`
+	NotifyConfig      *string                         `json:"notify_config,omitempty"` // JSONB stored as nullable string
+	LockParticipants  *bool                           `json:"lock_participants,omitempty"`
+	StartDate         *string                         `json:"start_date,omitempty"`
+	EndDate           *string                         `json:"end_date,omitempty"`
+	WeekStart         *string                         `json:"week_start,omitempty" validate:"omitempty,oneof='' sunday monday" enums:"sunday,monday"` // Empty string resets to the instance default
+	TimeFormat        *string                         `json:"time_format,omitempty" validate:"omitempty,oneof='' 24h 12h" enums:"24h,12h"`            // Empty string resets to the instance default
+	DateFormat        *string                         `json:"date_format,omitempty" validate:"omitempty,oneof='' iso long" enums:"iso,long"`          // Empty string resets to the instance default
+	ReminderMinutes   []int                           `json:"ics_reminder_minutes,omitempty" validate:"omitempty,max=5,dive,min=0,max=40320"`         // Empty array removes all reminders
+	EventTitle        *string                         `json:"ics_title_template,omitempty" validate:"omitempty,max=200"`                              // Empty string restores the built-in title
+	EventDescription  *string                         `json:"ics_description_template,omitempty" validate:"omitempty,max=2000"`                       // Empty string restores the participant list
+	FeedPastDays      *int                            `json:"ics_past_days,omitempty" validate:"omitempty,min=-1,max=3650"`                           // -1 removes the limit
+	FeedFutureDays    *int                            `json:"ics_future_days,omitempty" validate:"omitempty,min=-1,max=3650"`                         // -1 removes the limit
+	CountMaybe        *bool                           `json:"count_maybe,omitempty"`
+	MaxParticipants   *int                            `json:"max_participants,omitempty" validate:"omitempty,min=-1,max=10000"` // -1 removes the limit
+	CapacityPolicy    *string                         `json:"capacity_policy,omitempty" validate:"omitempty,oneof=reject waitlist" enums:"reject,waitlist"`
 }
 
 // AddParticipantRequest represents a request to add a participant
 type AddParticipantRequest struct {
-	Name string `json:"name" validate:"required,min=1,max=100"`
+	Name     string `json:"name" validate:"required,min=1,max=100"`
+	Required bool   `json:"required,omitempty"`
 }
 
 // UpdateParticipantRequest represents a request to update a participant
 type UpdateParticipantRequest struct {
-	Name string `json:"name" validate:"required,min=1,max=100"`
+	Name     string `json:"name,omitempty" validate:"required_without=Required,omitempty,min=1,max=100"`
+	Required *bool  `json:"required,omitempty"`
 }
 
 // AddParticipantEmailRequest represents a request to add email to a participant
@@ -134,60 +211,106 @@ type ParticipantEmailResponse struct {
 	Message       string    `json:"message"`
 }
 
+// ParticipantLinkResponse represents the response after claiming a participant link
+type ParticipantLinkResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
 // RegenerateTokenRequest represents a request to regenerate a token
 type RegenerateTokenRequest struct {
 	TokenType string `json:"token_type" validate:"required,oneof=public ics"`
 }
 
+// TransferCalendarRequest represents a request to transfer a calendar to another user (admin only)
+type TransferCalendarRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
 // CalendarResponse represents the response when returning a calendar
 type CalendarResponse struct {
-	ID                uuid.UUID            `json:"id"`
-	OwnerID           uuid.UUID            `json:"owner_id"`
-	Name              string               `json:"name"`
-	Description       string               `json:"description,omitempty"`
-	PublicToken       string               `json:"public_token"`
-	ICSToken          string               `json:"ics_token"`
-	Threshold         int                  `json:"threshold"`
-	AllowedWeekdays   []int                `json:"allowed_weekdays"`
-	MinDurationHours  int                  `json:"min_duration_hours"`
-	Timezone          string               `json:"timezone"`
-	HolidaysPolicy    string               `json:"holidays_policy" enums:"ignore,allow,block"`
-	AllowHolidayEves  bool                 `json:"allow_holiday_eves"`
-	WeekdayTimes      map[string]TimeRange `json:"weekday_times,omitempty"`
-	HolidayMinTime    string               `json:"holiday_min_time,omitempty"`
-	HolidayMaxTime    string               `json:"holiday_max_time,omitempty"`
-	HolidayEveMinTime string               `json:"holiday_eve_min_time,omitempty"`
-	HolidayEveMaxTime string               `json:"holiday_eve_max_time,omitempty"`
-	NotifyOnThreshold bool                 `json:"notify_on_threshold"`
-	LockParticipants  bool                 `json:"lock_participants"`
-	StartDate         *time.Time           `json:"start_date,omitempty"`
-	EndDate           *time.Time           `json:"end_date,omitempty"`
-	Participants      []Participant        `json:"participants,omitempty"`
-	CreatedAt         time.Time            `json:"created_at"`
-	UpdatedAt         time.Time            `json:"updated_at"`
+	ID                uuid.UUID                       `json:"id"`
+	OwnerID           uuid.UUID                       `json:"owner_id"`
+	OrganizationID    *uuid.UUID                      `json:"organization_id,omitempty"`
+	Name              string                          `json:"name"`
+	Description       string                          `json:"description,omitempty"`
+	PublicToken       string                          `json:"public_token"`
+	ICSToken          string                          `json:"ics_token"`
+	Threshold         int                             `json:"threshold"`
+	ThresholdPercent  *int                            `json:"threshold_percent,omitempty"`
+	SoftThreshold     *int                            `json:"soft_threshold,omitempty"`
+	RequireConfirm    bool                            `json:"require_confirmation"`
+	AllowedWeekdays   []int                           `json:"allowed_weekdays"`
+	MinDurationHours  int                             `json:"min_duration_hours"`
+	Timezone          string                          `json:"timezone"`
+	HolidaysPolicy    string                          `json:"holidays_policy" enums:"ignore,allow,block"`
+	AllowHolidayEves  bool                            `json:"allow_holiday_eves"`
+	HolidaySets       []string                        `json:"holiday_sets"`
+	BlackoutDates     []datevalidation.BlackoutPeriod `json:"blackout_dates"`
+	CustomHolidays    []datevalidation.CustomHoliday  `json:"custom_holidays"`
+	HolidayCountry    string                          `json:"holiday_country,omitempty"`
+	HolidayRegion     string                          `json:"holiday_region,omitempty"`
+	HolidayCountries  []string                        `json:"holiday_countries"`
+	WeekdayTimes      map[string]TimeRange            `json:"weekday_times,omitempty"`
+	HolidayMinTime    string                          `json:"holiday_min_time,omitempty"`
+	HolidayMaxTime    string                          `json:"holiday_max_time,omitempty"`
+	HolidayEveMinTime string                          `json:"holiday_eve_min_time,omitempty"`
+	HolidayEveMaxTime string                          `json:"holiday_eve_max_time,omitempty"`
+	NotifyOnThreshold bool                            `json:"notify_on_threshold"`
+	LockParticipants  bool                            `json:"lock_participants"`
+	StartDate         *time.Time                      `json:"start_date,omitempty"`
+	EndDate           *time.Time                      `json:"end_date,omitempty"`
+	WeekStart         string                          `json:"week_start" enums:"sunday,monday"`
+	TimeFormat        string                          `json:"time_format" enums:"24h,12h"`
+	DateFormat        string                          `json:"date_format" enums:"iso,long"`
+	ReminderMinutes   []int                           `json:"ics_reminder_minutes"`
+	EventTitle        string                          `json:"ics_title_template,omitempty"`
+	EventDescription  string                          `json:"ics_description_template,omitempty"`
+	FeedPastDays      *int                            `json:"ics_past_days,omitempty"`
+	FeedFutureDays    *int                            `json:"ics_future_days,omitempty"`
+	CountMaybe        bool                            `json:"count_maybe"`
+	MaxParticipants   *int                            `json:"max_participants,omitempty"`
+	CapacityPolicy    string                          `json:"capacity_policy" enums:"reject,waitlist"`
+	Participants      []Participant                   `json:"participants,omitempty"`
+	ParticipantCount  int                             `json:"participant_count"`
+	CreatedAt         time.Time                       `json:"created_at"`
+	UpdatedAt         time.Time                       `json:"updated_at"`
 }
 
 // PublicCalendarResponse represents the public view of a calendar
 type PublicCalendarResponse struct {
-	ID                 uuid.UUID            `json:"id"`
-	Name               string               `json:"name"`
-	Description        string               `json:"description,omitempty"`
-	Threshold          int                  `json:"threshold"`
-	AllowedWeekdays    []int                `json:"allowed_weekdays"`
-	MinDurationHours   int                  `json:"min_duration_hours"`
-	Timezone           string               `json:"timezone"`
-	HolidaysPolicy     string               `json:"holidays_policy" enums:"ignore,allow,block"`
-	AllowHolidayEves   bool                 `json:"allow_holiday_eves"`
-	WeekdayTimes       map[string]TimeRange `json:"weekday_times,omitempty"`
-	HolidayMinTime     string               `json:"holiday_min_time,omitempty"`
-	HolidayMaxTime     string               `json:"holiday_max_time,omitempty"`
-	HolidayEveMinTime  string               `json:"holiday_eve_min_time,omitempty"`
-	HolidayEveMaxTime  string               `json:"holiday_eve_max_time,omitempty"`
-	LockParticipants   bool                 `json:"lock_participants"`
-	NotifyParticipants bool                 `json:"notify_participants"`
-	ICSToken           string               `json:"ics_token"`
-	StartDate          *time.Time           `json:"start_date,omitempty"`
-	EndDate            *time.Time           `json:"end_date,omitempty"`
-	Participants       []PublicParticipant  `json:"participants"`
-	CreatedAt          time.Time            `json:"created_at"`
+	ID                 uuid.UUID                       `json:"id"`
+	Name               string                          `json:"name"`
+	Description        string                          `json:"description,omitempty"`
+	Threshold          int                             `json:"threshold"`
+	ThresholdPercent   *int                            `json:"threshold_percent,omitempty"`
+	SoftThreshold      *int                            `json:"soft_threshold,omitempty"`
+	AllowedWeekdays    []int                           `json:"allowed_weekdays"`
+	MinDurationHours   int                             `json:"min_duration_hours"`
+	Timezone           string                          `json:"timezone"`
+	HolidaysPolicy     string                          `json:"holidays_policy" enums:"ignore,allow,block"`
+	AllowHolidayEves   bool                            `json:"allow_holiday_eves"`
+	HolidaySets        []string                        `json:"holiday_sets"`
+	BlackoutDates      []datevalidation.BlackoutPeriod `json:"blackout_dates"`
+	CustomHolidays     []datevalidation.CustomHoliday  `json:"custom_holidays"`
+	HolidayCountry     string                          `json:"holiday_country,omitempty"`
+	HolidayRegion      string                          `json:"holiday_region,omitempty"`
+	HolidayCountries   []string                        `json:"holiday_countries"`
+	WeekdayTimes       map[string]TimeRange            `json:"weekday_times,omitempty"`
+	HolidayMinTime     string                          `json:"holiday_min_time,omitempty"`
+	HolidayMaxTime     string                          `json:"holiday_max_time,omitempty"`
+	HolidayEveMinTime  string                          `json:"holiday_eve_min_time,omitempty"`
+	HolidayEveMaxTime  string                          `json:"holiday_eve_max_time,omitempty"`
+	LockParticipants   bool                            `json:"lock_participants"`
+	NotifyParticipants bool                            `json:"notify_participants"`
+	CountMaybe         bool                            `json:"count_maybe"`
+	MaxParticipants    *int                            `json:"max_participants,omitempty"`
+	CapacityPolicy     string                          `json:"capacity_policy" enums:"reject,waitlist"`
+	ICSToken           string                          `json:"ics_token"`
+	StartDate          *time.Time                      `json:"start_date,omitempty"`
+	EndDate            *time.Time                      `json:"end_date,omitempty"`
+	WeekStart          string                          `json:"week_start" enums:"sunday,monday"`
+	TimeFormat         string                          `json:"time_format" enums:"24h,12h"`
+	DateFormat         string                          `json:"date_format" enums:"iso,long"`
+	Participants       []PublicParticipant             `json:"participants"`
+	CreatedAt          time.Time                       `json:"created_at"`
 }
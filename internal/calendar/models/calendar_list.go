@@ -0,0 +1,55 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCalendarSort lists the newest calendars first
+const DefaultCalendarSort = "-created_at"
+
+// calendarSortColumns are the columns calendars can be sorted on
+var calendarSortColumns = map[string]string{
+	"name":       "LOWER(name)",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// CalendarListFilter selects a page of the personal calendars of an owner, or of the calendars of an organization
+type CalendarListFilter struct {
+	OwnerID        uuid.UUID
+	OrganizationID *uuid.UUID // Organization calendars instead of the personal ones
+	Search         string     // Case-insensitive match on the name or description
+	Sort           string     // Column, prefixed with "-" for descending order
+	Limit          int        // 0 = all
+	Offset         int
+}
+
+// SortClause returns the ORDER BY expression of a sort parameter (name, created_at or updated_at,
+// prefixed with "-" for descending order), the creation date breaking ties
+func SortClause(sort string) (string, error) {
+	if sort == "" {
+		sort = DefaultCalendarSort
+	}
+
+	direction := "ASC"
+	column, descending := strings.CutPrefix(sort, "-")
+	if descending {
+		direction = "DESC"
+	}
+
+	expr, ok := calendarSortColumns[column]
+	if !ok {
+		return "", fmt.Errorf("invalid sort %q (expected name, created_at or updated_at, optionally prefixed with -)", sort)
+	}
+	if column == "created_at" {
+		return fmt.Sprintf("%s %s, id", expr, direction), nil
+	}
+	return fmt.Sprintf("%s %s, created_at DESC, id", expr, direction), nil
+}
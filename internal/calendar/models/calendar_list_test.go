@@ -0,0 +1,29 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "testing"
+
+func TestSortClause(t *testing.T) {
+	tests := map[string]string{
+		"":            "created_at DESC, id",
+		"created_at":  "created_at ASC, id",
+		"-name":       "LOWER(name) DESC, created_at DESC, id",
+		"updated_at":  "updated_at ASC, created_at DESC, id",
+		"-updated_at": "updated_at DESC, created_at DESC, id",
+	}
+	for sort, want := range tests {
+		got, err := SortClause(sort)
+		if err != nil || got != want {
+			t.Errorf("SortClause(%q) = %q, %v, want %q", sort, got, err, want)
+		}
+	}
+
+	for _, sort := range []string{"owner_id", "name;DROP TABLE calendars", "+name"} {
+		if _, err := SortClause(sort); err == nil {
+			t.Errorf("SortClause(%q) should fail", sort)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "testing"
+
+func TestPercentThreshold(t *testing.T) {
+	tests := []struct {
+		percent, participants, want int
+	}{
+		{60, 5, 3},
+		{60, 10, 6},
+		{50, 3, 2},
+		{100, 4, 4},
+		{1, 4, 1},
+		{50, 0, 1},
+	}
+	for _, tt := range tests {
+		if got := PercentThreshold(tt.percent, tt.participants); got != tt.want {
+			t.Errorf("PercentThreshold(%d, %d) = %d, want %d", tt.percent, tt.participants, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FieldChange is the change of a single setting
+// Nested settings (allowed hours, notification config) are named by their path, e.g. "notify_config.channels.discord.enabled"
+type FieldChange struct {
+	Field    string          `json:"field" example:"threshold"`
+	Old      json.RawMessage `json:"old,omitempty" swaggertype:"object"` // Omitted when redacted
+	New      json.RawMessage `json:"new,omitempty" swaggertype:"object"` // Omitted when redacted
+	Redacted bool            `json:"redacted,omitempty"`                 // Secret value (webhook URL, token, password) that changed
+}
+
+// CalendarChange is an entry of the change log of a calendar
+type CalendarChange struct {
+	ID         uuid.UUID     `json:"id"`
+	CalendarID uuid.UUID     `json:"calendar_id"`
+	UserID     *uuid.UUID    `json:"user_id,omitempty"` // Nil once the author is deleted
+	UserName   string        `json:"user_name,omitempty"`
+	Changes    []FieldChange `json:"changes"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// CalendarChangeList is a page of the change log of a calendar
+type CalendarChangeList struct {
+	Items []CalendarChange `json:"items"`
+	Total int              `json:"total"`
+}
+
+// secretFields are settings whose values are never written to the change log
+var secretFields = []string{"webhook_url", "bot_token", "password", "ca_cert"}
+
+// DiffCalendars returns the settings changed between two versions of a calendar
+func DiffCalendars(before, after *Calendar) []FieldChange {
+	var changes []FieldChange
+	diffValues("", normalize(calendarSettings(before)), normalize(calendarSettings(after)), &changes)
+	return changes
+}
+
+// calendarSettings returns the settings of a calendar tracked by the change log
+func calendarSettings(c *Calendar) map[string]any {
+	return map[string]any{
+		"name":                     c.Name,
+		"description":              c.Description,
+		"threshold":                c.Threshold,
+		"allowed_weekdays":         c.AllowedWeekdays,
+		"min_duration_hours":       c.MinDurationHours,
+		"timezone":                 c.Timezone,
+		"holidays_policy":          c.HolidaysPolicy,
+		"allow_holiday_eves":       c.AllowHolidayEves,
+		"holiday_sets":             c.HolidaySets,
+		"blackout_dates":           c.BlackoutDates,
+		"custom_holidays":          c.CustomHolidays,
+		"holiday_country":          c.HolidayCountry,
+		"holiday_region":           c.HolidayRegion,
+		"holiday_countries":        c.HolidayCountries,
+		"allowed_hours":            rawJSON(c.AllowedHours),
+		"notify_on_threshold":      c.NotifyOnThreshold,
+		"notify_config":            rawJSON(c.NotifyConfig),
+		"lock_participants":        c.LockParticipants,
+		"start_date":               formatDate(c.StartDate),
+		"end_date":                 formatDate(c.EndDate),
+		"week_start":               c.WeekStart,
+		"time_format":              c.TimeFormat,
+		"date_format":              c.DateFormat,
+		"ics_reminder_minutes":     c.ReminderMinutes,
+		"ics_title_template":       c.EventTitle,
+		"ics_description_template": c.EventDescription,
+		"ics_past_days":            c.FeedPastDays,
+		"ics_future_days":          c.FeedFutureDays,
+		"count_maybe":              c.CountMaybe,
+		"threshold_percent":        c.ThresholdPercent,
+		"soft_threshold":           c.SoftThreshold,
+		"require_confirmation":     c.RequireConfirm,
+		"max_participants":         c.MaxParticipants,
+		"capacity_policy":          c.CapacityPolicy,
+	}
+}
+
+// diffValues compares two JSON values, descending into objects to name the changed leaves
+func diffValues(path string, before, after any, changes *[]FieldChange) {
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if (beforeIsMap || before == nil) && (afterIsMap || after == nil) && (beforeIsMap || afterIsMap) {
+		keys := maps.Clone(beforeMap)
+		if keys == nil {
+			keys = make(map[string]any)
+		}
+		maps.Copy(keys, afterMap)
+		for _, key := range slices.Sorted(maps.Keys(keys)) {
+			diffValues(joinPath(path, key), beforeMap[key], afterMap[key], changes)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	change := FieldChange{Field: path}
+	if slices.Contains(secretFields, path[strings.LastIndex(path, ".")+1:]) {
+		change.Redacted = true
+	} else {
+		change.Old, _ = json.Marshal(before)
+		change.New, _ = json.Marshal(after)
+	}
+	*changes = append(*changes, change)
+}
+
+// normalize converts a value to its generic JSON form (maps, slices, float64, string, bool, nil)
+func normalize(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil
+	}
+	return normalized
+}
+
+func rawJSON(value *string) json.RawMessage {
+	if value == nil || *value == "" || !json.Valid([]byte(*value)) {
+		return nil
+	}
+	return json.RawMessage(*value)
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format("2006-01-02")
+	return &formatted
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,77 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffCalendars(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := &Calendar{
+		Name:            "Five-a-side",
+		Threshold:       8,
+		AllowedWeekdays: []int{1, 3},
+		NotifyConfig:    ptr(`{"enabled":false,"channels":{"discord":{"enabled":false,"webhook_url":"https://discord.com/api/webhooks/old"}}}`),
+	}
+	after := *before
+	after.Threshold = 10
+	after.StartDate = &start
+	after.NotifyConfig = ptr(`{"enabled":true,"channels":{"discord":{"enabled":false,"webhook_url":"https://discord.com/api/webhooks/new"}}}`)
+
+	changes := DiffCalendars(before, &after)
+
+	want := map[string][2]string{
+		"notify_config.channels.discord.webhook_url": {"", ""},
+		"notify_config.enabled":                      {"false", "true"},
+		"start_date":                                 {"null", `"2025-06-01"`},
+		"threshold":                                  {"8", "10"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("DiffCalendars() = %+v, want %d changes", changes, len(want))
+	}
+	for _, change := range changes {
+		values, ok := want[change.Field]
+		if !ok {
+			t.Errorf("unexpected change of %s", change.Field)
+			continue
+		}
+		if string(change.Old) != values[0] || string(change.New) != values[1] {
+			t.Errorf("change of %s = %s -> %s, want %s -> %s", change.Field, change.Old, change.New, values[0], values[1])
+		}
+	}
+	if changes[0].Field != "notify_config.channels.discord.webhook_url" || !changes[0].Redacted {
+		t.Errorf("webhook URL change should be redacted, got %+v", changes[0])
+	}
+}
+
+func TestDiffCalendars_NoChange(t *testing.T) {
+	calendar := &Calendar{Name: "Board games", Threshold: 3, AllowedHours: ptr(`{"monday":{"min_time":"18:00"}}`)}
+	copied := *calendar
+	if changes := DiffCalendars(calendar, &copied); len(changes) != 0 {
+		t.Errorf("DiffCalendars() = %+v, want no change", changes)
+	}
+}
+
+func TestDiffCalendars_NotifyConfigSet(t *testing.T) {
+	before := &Calendar{}
+	after := &Calendar{NotifyConfig: ptr(`{"channels":{"telegram":{"bot_token":"123:abc","chat_id":"42"}}}`)}
+
+	changes := DiffCalendars(before, after)
+	if len(changes) != 2 {
+		t.Fatalf("DiffCalendars() = %+v, want 2 changes", changes)
+	}
+	if changes[0].Field != "notify_config.channels.telegram.bot_token" || !changes[0].Redacted || changes[0].New != nil {
+		t.Errorf("bot token should be redacted, got %+v", changes[0])
+	}
+	if changes[1].Field != "notify_config.channels.telegram.chat_id" || string(changes[1].New) != `"42"` {
+		t.Errorf("chat id change = %+v", changes[1])
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,55 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of event confirmations
+const (
+	ConfirmationPending   = "pending"   // Proposed to the owner, not in the ICS feed yet
+	ConfirmationConfirmed = "confirmed" // In the ICS feed while the date reaches the threshold
+	ConfirmationDeclined  = "declined"  // Never in the ICS feed, and not proposed again
+)
+
+// EventConfirmation is the owner's decision on a date of a calendar requiring confirmation
+type EventConfirmation struct {
+	CalendarID   uuid.UUID
+	CalendarName string
+	Date         time.Time
+	Status       string
+	CreatedAt    time.Time
+	DecidedAt    *time.Time
+}
+
+// DecideConfirmationRequest represents the owner's decision on a proposed event
+type DecideConfirmationRequest struct {
+	Status string `json:"status" validate:"required,oneof=confirmed declined"`
+}
+
+// EventConfirmationResponse is the API response for an event confirmation
+type EventConfirmationResponse struct {
+	CalendarID   string     `json:"calendar_id"`
+	CalendarName string     `json:"calendar_name"`
+	Date         string     `json:"date" example:"2025-06-14"` // YYYY-MM-DD
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DecidedAt    *time.Time `json:"decided_at,omitempty"`
+}
+
+// ToResponse converts an EventConfirmation to EventConfirmationResponse
+func (c *EventConfirmation) ToResponse() EventConfirmationResponse {
+	return EventConfirmationResponse{
+		CalendarID:   c.CalendarID.String(),
+		CalendarName: c.CalendarName,
+		Date:         c.Date.Format("2006-01-02"),
+		Status:       c.Status,
+		CreatedAt:    c.CreatedAt,
+		DecidedAt:    c.DecidedAt,
+	}
+}
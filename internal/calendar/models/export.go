@@ -0,0 +1,71 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "time"
+
+// ExportFormat is the version of the calendar export bundle
+const ExportFormat = 1
+
+// CalendarExport is a portable copy of a calendar, to back it up or move it to another instance
+// IDs, tokens and the owner are not exported: an import creates a new calendar owned by the importing user
+type CalendarExport struct {
+	Format       int                   `json:"format" example:"1"`
+	ExportedAt   time.Time             `json:"exported_at"`
+	Calendar     CreateCalendarRequest `json:"calendar"` // Settings, participants are listed below
+	Participants []ExportParticipant   `json:"participants"`
+	Summary      ExportSummary         `json:"summary"` // Readable overview, ignored on import
+}
+
+// ExportSummary is an overview of the availabilities of an exported calendar
+type ExportSummary struct {
+	WeekStart  string       `json:"week_start" enums:"sunday,monday"` // Week start of the calendar, or the instance default
+	TimeFormat string       `json:"time_format" enums:"24h,12h"`      // Time format of the calendar, or the instance default
+	Weeks      []ExportWeek `json:"weeks"`                            // Weeks with single-day availabilities, oldest first
+}
+
+// ExportWeek counts the single-day availabilities of a week
+type ExportWeek struct {
+	Start          string `json:"start" example:"2025-06-30"` // First day of the week
+	Availabilities int    `json:"availabilities"`
+	Participants   int    `json:"participants"` // Participants with at least one availability in the week
+}
+
+// ExportParticipant is a participant of an exported calendar with its availabilities
+type ExportParticipant struct {
+	Name           string               `json:"name"`
+	Email          *string              `json:"email,omitempty"` // Imported unverified
+	Locale         string               `json:"locale"`
+	Required       bool                 `json:"required,omitempty"`
+	Availabilities []ExportAvailability `json:"availabilities"`
+	Recurrences    []ExportRecurrence   `json:"recurrences"`
+}
+
+// ExportAvailability is a single-day availability of an exported participant
+type ExportAvailability struct {
+	Date      string  `json:"date" example:"2025-07-04"` // Format: "YYYY-MM-DD"
+	StartTime *string `json:"start_time,omitempty"`      // Format: "HH:MM"
+	EndTime   *string `json:"end_time,omitempty"`        // Format: "HH:MM"
+	Time      string  `json:"time,omitempty"`            // Readable slot in the summary time format, ignored on import
+	Note      string  `json:"note,omitempty"`
+	Status    string  `json:"status,omitempty" validate:"omitempty,oneof=yes maybe" enums:"yes,maybe"` // Unset = yes
+	Preferred bool    `json:"preferred,omitempty"`
+}
+
+// ExportRecurrence is a recurring availability of an exported participant, with its excluded dates
+type ExportRecurrence struct {
+	Frequency     string   `json:"frequency,omitempty"`      // weekly (default), monthly_day or monthly_weekday
+	IntervalWeeks int      `json:"interval_weeks,omitempty"` // Weekly only, default: 1
+	DayOfWeek     *int     `json:"day_of_week,omitempty"`    // 0=Sunday, 1=Monday, ..., 6=Saturday
+	DayOfMonth    *int     `json:"day_of_month,omitempty"`   // 1-31, monthly_day only
+	WeekOfMonth   *int     `json:"week_of_month,omitempty"`  // 1-5 or -1 for the last one, monthly_weekday only
+	StartTime     *string  `json:"start_time,omitempty"`     // Format: "HH:MM"
+	EndTime       *string  `json:"end_time,omitempty"`       // Format: "HH:MM"
+	Time          string   `json:"time,omitempty"`           // Readable slot in the summary time format, ignored on import
+	Note          string   `json:"note,omitempty"`
+	StartDate     string   `json:"start_date"`         // Format: "YYYY-MM-DD"
+	EndDate       *string  `json:"end_date,omitempty"` // Format: "YYYY-MM-DD"
+	Exceptions    []string `json:"exceptions"`         // Excluded dates, format: "YYYY-MM-DD"
+}
@@ -0,0 +1,44 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "github.com/google/uuid"
+
+// MaxCSVImportRows caps the participants of a CSV import
+const MaxCSVImportRows = 500
+
+// CSV import row statuses
+const (
+	CSVImportStatusAdded     = "added"
+	CSVImportStatusDuplicate = "duplicate" // Name or email already in the calendar, or earlier in the file
+	CSVImportStatusInvalid   = "invalid"
+)
+
+// CSVParticipantRow is a participant read from a CSV import
+type CSVParticipantRow struct {
+	Line   int
+	Name   string
+	Email  string
+	Locale string
+}
+
+// CSVImportResult is the outcome of the import of one CSV row
+type CSVImportResult struct {
+	Line          int        `json:"line"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email,omitempty"`
+	Status        string     `json:"status" example:"added"`
+	Error         string     `json:"error,omitempty"` // Why an invalid row was skipped
+	ParticipantID *uuid.UUID `json:"participant_id,omitempty"`
+	InviteSent    bool       `json:"invite_sent,omitempty"`
+}
+
+// CSVImportResponse represents the response of a CSV import of participants
+type CSVImportResponse struct {
+	Added      int               `json:"added"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    int               `json:"invalid"`
+	Results    []CSVImportResult `json:"results"`
+}
@@ -0,0 +1,107 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/calendar/models"
+)
+
+var ErrAPITokenNotFound = errors.New("calendar API token not found")
+
+// APITokenRepository handles calendar API token database operations
+type APITokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAPITokenRepository creates a new calendar API token repository
+func NewAPITokenRepository(pool *pgxpool.Pool) *APITokenRepository {
+	return &APITokenRepository{pool: pool}
+}
+
+// Create creates a calendar API token from the hash of its value
+func (r *APITokenRepository) Create(ctx context.Context, token *models.APIToken, tokenHash string) error {
+	query := `
+		INSERT INTO calendar_api_tokens (id, calendar_id, name, scopes, token_hash, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.pool.Exec(ctx, query, token.ID, token.CalendarID, token.Name, token.Scopes, tokenHash, token.CreatedBy, token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create calendar API token: %w", err)
+	}
+	return nil
+}
+
+// ListByCalendar returns the API tokens of a calendar, newest first
+func (r *APITokenRepository) ListByCalendar(ctx context.Context, calendarID uuid.UUID) ([]*models.APIToken, error) {
+	query := `
+		SELECT id, calendar_id, name, scopes, created_by, last_used_at, created_at
+		FROM calendar_api_tokens
+		WHERE calendar_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calendar API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.APIToken
+	for rows.Next() {
+		var token models.APIToken
+		if err := rows.Scan(&token.ID, &token.CalendarID, &token.Name, &token.Scopes, &token.CreatedBy, &token.LastUsedAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan calendar API token: %w", err)
+		}
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// CountByCalendar returns the number of API tokens of a calendar
+func (r *APITokenRepository) CountByCalendar(ctx context.Context, calendarID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM calendar_api_tokens WHERE calendar_id = $1`, calendarID).Scan(&count)
+	return count, err
+}
+
+// Delete deletes an API token of a calendar
+func (r *APITokenRepository) Delete(ctx context.Context, id, calendarID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM calendar_api_tokens WHERE id = $1 AND calendar_id = $2`, id, calendarID)
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar API token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// Authenticate returns the calendar and creator of an API token and records its use
+func (r *APITokenRepository) Authenticate(ctx context.Context, tokenHash string) (*models.APITokenOwner, error) {
+	query := `
+		UPDATE calendar_api_tokens t
+		SET last_used_at = NOW()
+		FROM users u
+		WHERE t.token_hash = $1 AND u.id = t.created_by AND u.deleted_at IS NULL
+		RETURNING t.id, t.calendar_id, t.scopes, u.id, u.email`
+
+	var owner models.APITokenOwner
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&owner.TokenID, &owner.CalendarID, &owner.Scopes, &owner.UserID, &owner.Email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAPITokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate calendar API token: %w", err)
+	}
+	return &owner, nil
+}
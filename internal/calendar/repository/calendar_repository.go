@@ -8,11 +8,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/whento/pkg/datevalidation"
 	"github.com/whento/whento/internal/calendar/models"
 )
 
@@ -30,14 +32,15 @@ func NewCalendarRepository(pool *pgxpool.Pool) *CalendarRepository {
 	return &CalendarRepository{Pool: pool}
 }
 
-// Create creates a new calendar
-func (r *CalendarRepository) Create(ctx context.Context, calendar *models.Calendar) error {
-	query := `
-		INSERT INTO calendars (id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+// insertCalendarQuery inserts a calendar, with the arguments of calendarArgs
+const insertCalendarQuery = `
+		INSERT INTO calendars (id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, week_start, time_format, holiday_sets, date_format, organization_id, ics_reminder_minutes, ics_title_template, ics_description_template, ics_past_days, ics_future_days, count_maybe, blackout_dates, custom_holidays, holiday_country, holiday_region, holiday_countries, max_participants, capacity_policy, threshold_percent, soft_threshold, require_confirmation)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39)
 		RETURNING created_at, updated_at`
 
-	err := r.Pool.QueryRow(ctx, query,
+// calendarArgs returns the arguments of insertCalendarQuery
+func calendarArgs(calendar *models.Calendar) []any {
+	return []any{
 		calendar.ID,
 		calendar.OwnerID,
 		calendar.Name,
@@ -56,7 +59,57 @@ func (r *CalendarRepository) Create(ctx context.Context, calendar *models.Calend
 		calendar.LockParticipants,
 		calendar.StartDate,
 		calendar.EndDate,
-	).Scan(&calendar.CreatedAt, &calendar.UpdatedAt)
+		calendar.WeekStart,
+		calendar.TimeFormat,
+		calendar.HolidaySets,
+		calendar.DateFormat,
+		calendar.OrganizationID,
+		calendar.ReminderMinutes,
+		calendar.EventTitle,
+		calendar.EventDescription,
+		calendar.FeedPastDays,
+		calendar.FeedFutureDays,
+		calendar.CountMaybe,
+		blackoutDates(calendar.BlackoutDates),
+		customHolidays(calendar.CustomHolidays),
+		calendar.HolidayCountry,
+		calendar.HolidayRegion,
+		holidayCountries(calendar.HolidayCountries),
+		calendar.MaxParticipants,
+		calendar.CapacityPolicy,
+		calendar.ThresholdPercent,
+		calendar.SoftThreshold,
+		calendar.RequireConfirm,
+	}
+}
+
+// blackoutDates returns the periods to store, as an empty array rather than null
+func blackoutDates(periods []datevalidation.BlackoutPeriod) []datevalidation.BlackoutPeriod {
+	if periods == nil {
+		return []datevalidation.BlackoutPeriod{}
+	}
+	return periods
+}
+
+// customHolidays returns the custom holidays to store, as an empty array rather than null
+func customHolidays(holidays []datevalidation.CustomHoliday) []datevalidation.CustomHoliday {
+	if holidays == nil {
+		return []datevalidation.CustomHoliday{}
+	}
+	return holidays
+}
+
+// holidayCountries returns the holiday countries to store, as an empty array rather than null
+func holidayCountries(codes []string) []string {
+	if codes == nil {
+		return []string{}
+	}
+	return codes
+}
+
+// Create creates a new calendar
+func (r *CalendarRepository) Create(ctx context.Context, calendar *models.Calendar) error {
+	err := r.Pool.QueryRow(ctx, insertCalendarQuery, calendarArgs(calendar)...).Scan(&calendar.CreatedAt, &calendar.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create calendar: %w", err)
@@ -82,31 +135,7 @@ func (r *CalendarRepository) CreateWithParticipants(ctx context.Context, calenda
 	defer tx.Rollback(ctx)
 
 	// Create calendar
-	calendarQuery := `
-		INSERT INTO calendars (id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
-		RETURNING created_at, updated_at`
-
-	err = tx.QueryRow(ctx, calendarQuery,
-		calendar.ID,
-		calendar.OwnerID,
-		calendar.Name,
-		calendar.Description,
-		calendar.PublicToken,
-		calendar.ICSToken,
-		calendar.Threshold,
-		calendar.AllowedWeekdays,
-		calendar.MinDurationHours,
-		calendar.Timezone,
-		calendar.HolidaysPolicy,
-		calendar.AllowHolidayEves,
-		calendar.AllowedHours,
-		calendar.NotifyOnThreshold,
-		calendar.NotifyConfig,
-		calendar.LockParticipants,
-		calendar.StartDate,
-		calendar.EndDate,
-	).Scan(&calendar.CreatedAt, &calendar.UpdatedAt)
+	err = tx.QueryRow(ctx, insertCalendarQuery, calendarArgs(calendar)...).Scan(&calendar.CreatedAt, &calendar.UpdatedAt)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create calendar: %w", err)
@@ -117,7 +146,7 @@ func (r *CalendarRepository) CreateWithParticipants(ctx context.Context, calenda
 	participantQuery := `
 		INSERT INTO participants (id, calendar_id, name, email, email_verified, locale)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING created_at`
+		RETURNING access_token, created_at`
 
 	for _, input := range participants {
 		// Skip empty names
@@ -141,7 +170,7 @@ func (r *CalendarRepository) CreateWithParticipants(ctx context.Context, calenda
 			participant.Email,
 			participant.EmailVerified,
 			participant.Locale,
-		).Scan(&participant.CreatedAt)
+		).Scan(&participant.AccessToken, &participant.CreatedAt)
 
 		if err != nil {
 			// Check for duplicate participant name
@@ -165,7 +194,7 @@ func (r *CalendarRepository) CreateWithParticipants(ctx context.Context, calenda
 // GetByID retrieves a calendar by ID
 func (r *CalendarRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Calendar, error) {
 	query := `
-		SELECT id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, created_at, updated_at
+		SELECT id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, week_start, time_format, holiday_sets, date_format, organization_id, ics_reminder_minutes, ics_title_template, ics_description_template, ics_past_days, ics_future_days, count_maybe, blackout_dates, custom_holidays, holiday_country, holiday_region, holiday_countries, max_participants, capacity_policy, threshold_percent, soft_threshold, require_confirmation, created_at, updated_at
 		FROM calendars
 		WHERE id = $1`
 
@@ -189,6 +218,27 @@ func (r *CalendarRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 		&calendar.LockParticipants,
 		&calendar.StartDate,
 		&calendar.EndDate,
+		&calendar.WeekStart,
+		&calendar.TimeFormat,
+		&calendar.HolidaySets,
+		&calendar.DateFormat,
+		&calendar.OrganizationID,
+		&calendar.ReminderMinutes,
+		&calendar.EventTitle,
+		&calendar.EventDescription,
+		&calendar.FeedPastDays,
+		&calendar.FeedFutureDays,
+		&calendar.CountMaybe,
+		&calendar.BlackoutDates,
+		&calendar.CustomHolidays,
+		&calendar.HolidayCountry,
+		&calendar.HolidayRegion,
+		&calendar.HolidayCountries,
+		&calendar.MaxParticipants,
+		&calendar.CapacityPolicy,
+		&calendar.ThresholdPercent,
+		&calendar.SoftThreshold,
+		&calendar.RequireConfirm,
 		&calendar.CreatedAt,
 		&calendar.UpdatedAt,
 	)
@@ -206,15 +256,85 @@ func (r *CalendarRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 // GetByOwnerID retrieves all calendars owned by a user
 func (r *CalendarRepository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Calendar, error) {
 	query := `
-		SELECT id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, created_at, updated_at
+		SELECT id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, week_start, time_format, holiday_sets, date_format, organization_id, ics_reminder_minutes, ics_title_template, ics_description_template, ics_past_days, ics_future_days, count_maybe, blackout_dates, custom_holidays, holiday_country, holiday_region, holiday_countries, max_participants, capacity_policy, threshold_percent, soft_threshold, require_confirmation, created_at, updated_at
 		FROM calendars
 		WHERE owner_id = $1
 		ORDER BY created_at DESC`
 
-	rows, err := r.Pool.Query(ctx, query, ownerID)
+	calendars, err := r.queryCalendars(ctx, query, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get calendars by owner: %w", err)
 	}
+	return calendars, nil
+}
+
+// GetByOrganizationID retrieves all calendars of an organization
+func (r *CalendarRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.Calendar, error) {
+	query := `
+		SELECT id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, week_start, time_format, holiday_sets, date_format, organization_id, ics_reminder_minutes, ics_title_template, ics_description_template, ics_past_days, ics_future_days, count_maybe, blackout_dates, custom_holidays, holiday_country, holiday_region, holiday_countries, max_participants, capacity_policy, threshold_percent, soft_threshold, require_confirmation, created_at, updated_at
+		FROM calendars
+		WHERE organization_id = $1
+		ORDER BY created_at DESC`
+
+	calendars, err := r.queryCalendars(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendars by organization: %w", err)
+	}
+	return calendars, nil
+}
+
+// List returns a page of the calendars matching a filter, along with the number of matching calendars
+func (r *CalendarRepository) List(ctx context.Context, filter models.CalendarListFilter) ([]*models.Calendar, int, error) {
+	orderBy, err := models.SortClause(filter.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Organization calendars are listed in the organization context only
+	where := " WHERE owner_id = $1 AND organization_id IS NULL"
+	args := []any{filter.OwnerID}
+	if filter.OrganizationID != nil {
+		where = " WHERE organization_id = $1"
+		args = []any{*filter.OrganizationID}
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		where += fmt.Sprintf(" AND (name ILIKE $%[1]d OR description ILIKE $%[1]d)", len(args))
+	}
+
+	var total int
+	if err := r.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM calendars`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count calendars: %w", err)
+	}
+
+	page := ""
+	if filter.Limit > 0 {
+		page = fmt.Sprintf(" LIMIT %d OFFSET %d", filter.Limit, filter.Offset)
+	}
+
+	query := `
+		SELECT id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, week_start, time_format, holiday_sets, date_format, organization_id, ics_reminder_minutes, ics_title_template, ics_description_template, ics_past_days, ics_future_days, count_maybe, blackout_dates, custom_holidays, holiday_country, holiday_region, holiday_countries, max_participants, capacity_policy, threshold_percent, soft_threshold, require_confirmation, created_at, updated_at
+		FROM calendars` + where + `
+		ORDER BY ` + orderBy + page
+
+	calendars, err := r.queryCalendars(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list calendars: %w", err)
+	}
+	return calendars, total, nil
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// queryCalendars runs a query selecting full calendar rows
+func (r *CalendarRepository) queryCalendars(ctx context.Context, query string, args ...any) ([]*models.Calendar, error) {
+	rows, err := r.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var calendars []*models.Calendar
@@ -239,6 +359,27 @@ func (r *CalendarRepository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID
 			&calendar.LockParticipants,
 			&calendar.StartDate,
 			&calendar.EndDate,
+			&calendar.WeekStart,
+			&calendar.TimeFormat,
+			&calendar.HolidaySets,
+			&calendar.DateFormat,
+			&calendar.OrganizationID,
+			&calendar.ReminderMinutes,
+			&calendar.EventTitle,
+			&calendar.EventDescription,
+			&calendar.FeedPastDays,
+			&calendar.FeedFutureDays,
+			&calendar.CountMaybe,
+			&calendar.BlackoutDates,
+			&calendar.CustomHolidays,
+			&calendar.HolidayCountry,
+			&calendar.HolidayRegion,
+			&calendar.HolidayCountries,
+			&calendar.MaxParticipants,
+			&calendar.CapacityPolicy,
+			&calendar.ThresholdPercent,
+			&calendar.SoftThreshold,
+			&calendar.RequireConfirm,
 			&calendar.CreatedAt,
 			&calendar.UpdatedAt,
 		)
@@ -248,13 +389,13 @@ func (r *CalendarRepository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID
 		calendars = append(calendars, calendar)
 	}
 
-	return calendars, nil
+	return calendars, rows.Err()
 }
 
 // GetByPublicToken retrieves a calendar by public token
 func (r *CalendarRepository) GetByPublicToken(ctx context.Context, token string) (*models.Calendar, error) {
 	query := `
-		SELECT id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, created_at, updated_at
+		SELECT id, owner_id, name, description, public_token, ics_token, threshold, allowed_weekdays, min_duration_hours, timezone, holidays_policy, allow_holiday_eves, allowed_hours, notify_on_threshold, notify_config, lock_participants, start_date, end_date, week_start, time_format, holiday_sets, date_format, organization_id, ics_reminder_minutes, ics_title_template, ics_description_template, ics_past_days, ics_future_days, count_maybe, blackout_dates, custom_holidays, holiday_country, holiday_region, holiday_countries, max_participants, capacity_policy, threshold_percent, soft_threshold, require_confirmation, created_at, updated_at
 		FROM calendars
 		WHERE public_token = $1`
 
@@ -278,6 +419,27 @@ func (r *CalendarRepository) GetByPublicToken(ctx context.Context, token string)
 		&calendar.LockParticipants,
 		&calendar.StartDate,
 		&calendar.EndDate,
+		&calendar.WeekStart,
+		&calendar.TimeFormat,
+		&calendar.HolidaySets,
+		&calendar.DateFormat,
+		&calendar.OrganizationID,
+		&calendar.ReminderMinutes,
+		&calendar.EventTitle,
+		&calendar.EventDescription,
+		&calendar.FeedPastDays,
+		&calendar.FeedFutureDays,
+		&calendar.CountMaybe,
+		&calendar.BlackoutDates,
+		&calendar.CustomHolidays,
+		&calendar.HolidayCountry,
+		&calendar.HolidayRegion,
+		&calendar.HolidayCountries,
+		&calendar.MaxParticipants,
+		&calendar.CapacityPolicy,
+		&calendar.ThresholdPercent,
+		&calendar.SoftThreshold,
+		&calendar.RequireConfirm,
 		&calendar.CreatedAt,
 		&calendar.UpdatedAt,
 	)
@@ -293,12 +455,13 @@ func (r *CalendarRepository) GetByPublicToken(ctx context.Context, token string)
 }
 
 // Update updates a calendar
+// A percentage threshold is applied to the current participant count, and the effective threshold read back
 func (r *CalendarRepository) Update(ctx context.Context, calendar *models.Calendar) error {
 	query := `
 		UPDATE calendars
-		SET name = $2, description = $3, threshold = $4, allowed_weekdays = $5, min_duration_hours = $6, timezone = $7, holidays_policy = $8, allow_holiday_eves = $9, allowed_hours = $10, notify_on_threshold = $11, notify_config = $12, lock_participants = $13, start_date = $14, end_date = $15, updated_at = NOW()
+		SET name = $2, description = $3, threshold = CASE WHEN $33::INTEGER IS NULL THEN $4 ELSE percent_threshold($33, (SELECT COUNT(*) FROM participants WHERE calendar_id = $1)) END, allowed_weekdays = $5, min_duration_hours = $6, timezone = $7, holidays_policy = $8, allow_holiday_eves = $9, allowed_hours = $10, notify_on_threshold = $11, notify_config = $12, lock_participants = $13, start_date = $14, end_date = $15, week_start = $16, time_format = $17, holiday_sets = $18, date_format = $19, ics_reminder_minutes = $20, ics_title_template = $21, ics_description_template = $22, ics_past_days = $23, ics_future_days = $24, count_maybe = $25, blackout_dates = $26, custom_holidays = $27, holiday_country = $28, holiday_region = $29, holiday_countries = $30, max_participants = $31, capacity_policy = $32, threshold_percent = $33, soft_threshold = $34, require_confirmation = $35, updated_at = NOW()
 		WHERE id = $1
-		RETURNING updated_at`
+		RETURNING threshold, updated_at`
 
 	err := r.Pool.QueryRow(ctx, query,
 		calendar.ID,
@@ -316,7 +479,27 @@ func (r *CalendarRepository) Update(ctx context.Context, calendar *models.Calend
 		calendar.LockParticipants,
 		calendar.StartDate,
 		calendar.EndDate,
-	).Scan(&calendar.UpdatedAt)
+		calendar.WeekStart,
+		calendar.TimeFormat,
+		calendar.HolidaySets,
+		calendar.DateFormat,
+		calendar.ReminderMinutes,
+		calendar.EventTitle,
+		calendar.EventDescription,
+		calendar.FeedPastDays,
+		calendar.FeedFutureDays,
+		calendar.CountMaybe,
+		blackoutDates(calendar.BlackoutDates),
+		customHolidays(calendar.CustomHolidays),
+		calendar.HolidayCountry,
+		calendar.HolidayRegion,
+		holidayCountries(calendar.HolidayCountries),
+		calendar.MaxParticipants,
+		calendar.CapacityPolicy,
+		calendar.ThresholdPercent,
+		calendar.SoftThreshold,
+		calendar.RequireConfirm,
+	).Scan(&calendar.Threshold, &calendar.UpdatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -367,9 +550,53 @@ func (r *CalendarRepository) RegenerateToken(ctx context.Context, id uuid.UUID,
 	return nil
 }
 
-// CountByUser returns the number of calendars owned by a user
+// TransferOwnership makes a calendar a personal calendar of another user
+// Tokens, participants and availabilities are kept; the REST hooks the previous owner
+// subscribed to this calendar are removed, as they are only delivered to the owner
+func (r *CalendarRepository) TransferOwnership(ctx context.Context, id, newOwnerID uuid.UUID) error {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var previousOwnerID uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT owner_id FROM calendars WHERE id = $1 FOR UPDATE`, id).Scan(&previousOwnerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCalendarNotFound
+		}
+		return fmt.Errorf("failed to get calendar owner: %w", err)
+	}
+
+	query := `
+		UPDATE calendars
+		SET owner_id = $2, organization_id = NULL, updated_at = NOW()
+		WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, id, newOwnerID); err != nil {
+		return fmt.Errorf("failed to transfer calendar: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM rest_hooks WHERE calendar_id = $1 AND user_id = $2`, id, previousOwnerID); err != nil {
+		return fmt.Errorf("failed to remove hooks of previous owner: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CountByUser returns the number of calendars counted against the quota of a user:
+// their personal calendars and the calendars of the organizations they own
 func (r *CalendarRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
-	query := `SELECT COUNT(*) FROM calendars WHERE owner_id = $1`
+	query := `
+		SELECT COUNT(*) FROM calendars
+		WHERE (owner_id = $1 AND organization_id IS NULL)
+		   OR organization_id IN (
+			SELECT organization_id FROM organization_members
+			WHERE user_id = $1 AND role = 'owner'
+		   )`
 
 	var count int
 	err := r.Pool.QueryRow(ctx, query, userID).Scan(&count)
@@ -393,6 +620,26 @@ func (r *CalendarRepository) CountAll(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// ListAllIDs returns the IDs of all calendars across all users
+func (r *CalendarRepository) ListAllIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.Pool.Query(ctx, `SELECT id FROM calendars ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calendars: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan calendar id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
 // UpdateNotifyConfig updates the notify_config field and notify_on_threshold flag for a calendar
 func (r *CalendarRepository) UpdateNotifyConfig(ctx context.Context, id uuid.UUID, notifyConfig string, enabled bool) error {
 	query := `
@@ -0,0 +1,84 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/calendar/models"
+)
+
+// ChangeRepository handles the change log of calendar settings
+type ChangeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewChangeRepository creates a new change repository
+func NewChangeRepository(pool *pgxpool.Pool) *ChangeRepository {
+	return &ChangeRepository{pool: pool}
+}
+
+// Record logs the settings changed by a user, if any
+func (r *ChangeRepository) Record(ctx context.Context, calendarID, userID uuid.UUID, changes []models.FieldChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal changes: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO calendar_changes (calendar_id, user_id, changes)
+		VALUES ($1, $2, $3)`,
+		calendarID, userID, data)
+	if err != nil {
+		return fmt.Errorf("failed to record calendar changes: %w", err)
+	}
+	return nil
+}
+
+// ListByCalendar returns the changes of a calendar, most recent first, with the total count
+func (r *ChangeRepository) ListByCalendar(ctx context.Context, calendarID uuid.UUID, limit, offset int) ([]models.CalendarChange, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM calendar_changes WHERE calendar_id = $1`, calendarID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count calendar changes: %w", err)
+	}
+
+	query := `
+		SELECT c.id, c.calendar_id, c.user_id, COALESCE(u.display_name, ''), c.changes, c.created_at
+		FROM calendar_changes c
+		LEFT JOIN users u ON u.id = c.user_id
+		WHERE c.calendar_id = $1
+		ORDER BY c.created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.pool.Query(ctx, query, calendarID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list calendar changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []models.CalendarChange
+	for rows.Next() {
+		var change models.CalendarChange
+		var data []byte
+		if err := rows.Scan(&change.ID, &change.CalendarID, &change.UserID, &change.UserName, &data, &change.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan calendar change: %w", err)
+		}
+		if err := json.Unmarshal(data, &change.Changes); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse calendar change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, total, rows.Err()
+}
@@ -0,0 +1,121 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/calendar/models"
+)
+
+var ErrConfirmationNotFound = errors.New("event confirmation not found")
+
+// confirmationColumns are the columns scanned by scanConfirmation, with the calendars table joined as c
+const confirmationColumns = `e.calendar_id, c.name, e.date, e.status, e.created_at, e.decided_at`
+
+// ConfirmationRepository handles event confirmation database operations
+type ConfirmationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewConfirmationRepository creates a new event confirmation repository
+func NewConfirmationRepository(pool *pgxpool.Pool) *ConfirmationRepository {
+	return &ConfirmationRepository{pool: pool}
+}
+
+// Propose proposes a date to the owner, with the hash of the secret of the email link
+// It returns false for a date already proposed or decided on, whose owner must not be asked again
+func (r *ConfirmationRepository) Propose(ctx context.Context, calendarID uuid.UUID, date time.Time, tokenHash string) (bool, error) {
+	query := `
+		INSERT INTO event_confirmations (calendar_id, date, token_hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (calendar_id, date) DO NOTHING
+		RETURNING true`
+
+	var proposed bool
+	err := r.pool.QueryRow(ctx, query, calendarID, date, tokenHash).Scan(&proposed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to propose event: %w", err)
+	}
+	return proposed, nil
+}
+
+// Decide records the owner's decision on a date, proposed or not
+func (r *ConfirmationRepository) Decide(ctx context.Context, calendarID uuid.UUID, date time.Time, status string) (*models.EventConfirmation, error) {
+	query := `
+		WITH e AS (
+			INSERT INTO event_confirmations (calendar_id, date, status, decided_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (calendar_id, date) DO UPDATE
+			SET status = EXCLUDED.status, decided_at = NOW()
+			RETURNING *
+		)
+		SELECT ` + confirmationColumns + `
+		FROM e JOIN calendars c ON c.id = e.calendar_id`
+
+	confirmation, err := scanConfirmation(r.pool.QueryRow(ctx, query, calendarID, date, status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide on event: %w", err)
+	}
+	return confirmation, nil
+}
+
+// GetByToken retrieves the confirmation of an email link from the hash of its secret
+func (r *ConfirmationRepository) GetByToken(ctx context.Context, tokenHash string) (*models.EventConfirmation, error) {
+	query := `
+		SELECT ` + confirmationColumns + `
+		FROM event_confirmations e JOIN calendars c ON c.id = e.calendar_id
+		WHERE e.token_hash = $1`
+
+	confirmation, err := scanConfirmation(r.pool.QueryRow(ctx, query, tokenHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrConfirmationNotFound
+	}
+	return confirmation, err
+}
+
+// ListByCalendar returns the confirmations of a calendar by date, optionally with a status
+func (r *ConfirmationRepository) ListByCalendar(ctx context.Context, calendarID uuid.UUID, status string) ([]*models.EventConfirmation, error) {
+	query := `
+		SELECT ` + confirmationColumns + `
+		FROM event_confirmations e JOIN calendars c ON c.id = e.calendar_id
+		WHERE e.calendar_id = $1 AND ($2 = '' OR e.status = $2)
+		ORDER BY e.date`
+
+	rows, err := r.pool.Query(ctx, query, calendarID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event confirmations: %w", err)
+	}
+	defer rows.Close()
+
+	var confirmations []*models.EventConfirmation
+	for rows.Next() {
+		confirmation, err := scanConfirmation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event confirmation: %w", err)
+		}
+		confirmations = append(confirmations, confirmation)
+	}
+	return confirmations, rows.Err()
+}
+
+// scanConfirmation scans the confirmationColumns of a row
+func scanConfirmation(row pgx.Row) (*models.EventConfirmation, error) {
+	var c models.EventConfirmation
+	if err := row.Scan(&c.CalendarID, &c.CalendarName, &c.Date, &c.Status, &c.CreatedAt, &c.DecidedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,190 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/calendar/models"
+)
+
+// ExportRepository reads and writes the availabilities of whole calendars, for exports and imports
+type ExportRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewExportRepository creates a new export repository
+func NewExportRepository(pool *pgxpool.Pool) *ExportRepository {
+	return &ExportRepository{pool: pool}
+}
+
+// GetAvailabilities returns the availabilities of the participants of a calendar, by participant
+func (r *ExportRepository) GetAvailabilities(ctx context.Context, calendarID uuid.UUID) (map[uuid.UUID][]models.ExportAvailability, error) {
+	query := `
+		SELECT a.participant_id, TO_CHAR(a.date, 'YYYY-MM-DD'),
+		       TO_CHAR(a.start_time, 'HH24:MI'), TO_CHAR(a.end_time, 'HH24:MI'),
+		       COALESCE(a.note, ''), a.status, a.preferred
+		FROM availabilities a
+		JOIN participants p ON p.id = a.participant_id
+		WHERE p.calendar_id = $1
+		ORDER BY a.date`
+
+	rows, err := r.pool.Query(ctx, query, calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availabilities: %w", err)
+	}
+	defer rows.Close()
+
+	availabilities := make(map[uuid.UUID][]models.ExportAvailability)
+	for rows.Next() {
+		var participantID uuid.UUID
+		var a models.ExportAvailability
+		if err := rows.Scan(&participantID, &a.Date, &a.StartTime, &a.EndTime, &a.Note, &a.Status, &a.Preferred); err != nil {
+			return nil, fmt.Errorf("failed to scan availability: %w", err)
+		}
+		availabilities[participantID] = append(availabilities[participantID], a)
+	}
+	return availabilities, rows.Err()
+}
+
+// GetRecurrences returns the recurrences of the participants of a calendar with their exceptions, by participant
+func (r *ExportRepository) GetRecurrences(ctx context.Context, calendarID uuid.UUID) (map[uuid.UUID][]models.ExportRecurrence, error) {
+	query := `
+		SELECT r.participant_id, r.frequency, r.interval_weeks, r.day_of_week, r.day_of_month, r.week_of_month,
+		       TO_CHAR(r.start_time, 'HH24:MI'), TO_CHAR(r.end_time, 'HH24:MI'),
+		       COALESCE(r.note, ''),
+		       TO_CHAR(r.start_date, 'YYYY-MM-DD'), TO_CHAR(r.end_date, 'YYYY-MM-DD'),
+		       COALESCE(ARRAY(
+		           SELECT TO_CHAR(e.excluded_date, 'YYYY-MM-DD')
+		           FROM recurrence_exceptions e
+		           WHERE e.recurrence_id = r.id
+		           ORDER BY e.excluded_date
+		       ), '{}')
+		FROM recurrences r
+		JOIN participants p ON p.id = r.participant_id
+		WHERE p.calendar_id = $1
+		ORDER BY r.day_of_week, r.start_date`
+
+	rows, err := r.pool.Query(ctx, query, calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	recurrences := make(map[uuid.UUID][]models.ExportRecurrence)
+	for rows.Next() {
+		var participantID uuid.UUID
+		var rec models.ExportRecurrence
+		if err := rows.Scan(&participantID, &rec.Frequency, &rec.IntervalWeeks, &rec.DayOfWeek, &rec.DayOfMonth, &rec.WeekOfMonth, &rec.StartTime, &rec.EndTime, &rec.Note,
+			&rec.StartDate, &rec.EndDate, &rec.Exceptions); err != nil {
+			return nil, fmt.Errorf("failed to scan recurrence: %w", err)
+		}
+		recurrences[participantID] = append(recurrences[participantID], rec)
+	}
+	return recurrences, rows.Err()
+}
+
+// Import creates a calendar with its participants, availabilities and recurrences in a transaction
+// Participant emails are imported unverified: they are confirmed again on this instance
+func (r *ExportRepository) Import(ctx context.Context, calendar *models.Calendar, participants []models.ExportParticipant) ([]models.Participant, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, insertCalendarQuery, calendarArgs(calendar)...).Scan(&calendar.CreatedAt, &calendar.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to create calendar: %w", err)
+	}
+
+	created := make([]models.Participant, 0, len(participants))
+	for _, input := range participants {
+		participant := models.Participant{
+			CalendarID: calendar.ID,
+			Name:       input.Name,
+			Email:      input.Email,
+			Locale:     input.Locale,
+			Required:   input.Required,
+		}
+		participant.ID = uuid.New()
+
+		err := tx.QueryRow(ctx, `
+			INSERT INTO participants (id, calendar_id, name, email, email_verified, locale, required)
+			VALUES ($1, $2, $3, $4, false, $5, $6)
+			RETURNING access_token, created_at`,
+			participant.ID, participant.CalendarID, participant.Name, participant.Email, participant.Locale, participant.Required,
+		).Scan(&participant.AccessToken, &participant.CreatedAt)
+		if err != nil {
+			if isDuplicateKeyError(err) {
+				return nil, ErrParticipantAlreadyExists
+			}
+			return nil, fmt.Errorf("failed to create participant: %w", err)
+		}
+
+		if err := importAvailabilities(ctx, tx, participant.ID, input.Availabilities); err != nil {
+			return nil, err
+		}
+		if err := importRecurrences(ctx, tx, participant.ID, input.Recurrences); err != nil {
+			return nil, err
+		}
+
+		created = append(created, participant)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return created, nil
+}
+
+// importAvailabilities inserts the availabilities of an imported participant
+func importAvailabilities(ctx context.Context, tx pgx.Tx, participantID uuid.UUID, availabilities []models.ExportAvailability) error {
+	for _, a := range availabilities {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO availabilities (id, participant_id, date, start_time, end_time, note, source, status, preferred)
+			VALUES ($1, $2, $3, $4, $5, $6, 'manual', COALESCE(NULLIF($7, ''), 'yes'), $8)`,
+			uuid.New(), participantID, a.Date, a.StartTime, a.EndTime, a.Note, a.Status, a.Preferred,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create availability: %w", err)
+		}
+	}
+	return nil
+}
+
+// importRecurrences inserts the recurrences of an imported participant with their exceptions
+func importRecurrences(ctx context.Context, tx pgx.Tx, participantID uuid.UUID, recurrences []models.ExportRecurrence) error {
+	for _, rec := range recurrences {
+		recurrenceID := uuid.New()
+		_, err := tx.Exec(ctx, `
+			INSERT INTO recurrences (id, participant_id, frequency, interval_weeks, day_of_week, day_of_month, week_of_month,
+			                         start_time, end_time, note, start_date, end_date)
+			VALUES ($1, $2, COALESCE(NULLIF($3, ''), 'weekly'), GREATEST($4, 1), $5, $6, $7, $8, $9, $10, $11, $12)`,
+			recurrenceID, participantID, rec.Frequency, rec.IntervalWeeks, rec.DayOfWeek, rec.DayOfMonth, rec.WeekOfMonth,
+			rec.StartTime, rec.EndTime, rec.Note, rec.StartDate, rec.EndDate,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create recurrence: %w", err)
+		}
+
+		for _, date := range rec.Exceptions {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO recurrence_exceptions (id, recurrence_id, excluded_date)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (recurrence_id, excluded_date) DO NOTHING`,
+				uuid.New(), recurrenceID, date,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create recurrence exception: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -18,8 +18,9 @@ import (
 )
 
 var (
-	ErrParticipantNotFound      = errors.New("participant not found")
-	ErrParticipantAlreadyExists = errors.New("participant with this name already exists in this calendar")
+	ErrParticipantNotFound       = errors.New("participant not found")
+	ErrParticipantAlreadyExists  = errors.New("participant with this name already exists in this calendar")
+	ErrParticipantAlreadyClaimed = errors.New("participant link already claimed")
 )
 
 // ParticipantRepository handles participant database operations
@@ -32,19 +33,21 @@ func NewParticipantRepository(pool *pgxpool.Pool) *ParticipantRepository {
 	return &ParticipantRepository{pool: pool}
 }
 
-// Create creates a new participant
+// Create creates a new participant, with an unverified email if set
 func (r *ParticipantRepository) Create(ctx context.Context, participant *models.Participant) error {
 	query := `
-		INSERT INTO participants (id, calendar_id, name, locale)
-		VALUES ($1, $2, $3, $4)
-		RETURNING created_at`
+		INSERT INTO participants (id, calendar_id, name, email, locale, required)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING access_token, created_at`
 
 	err := r.pool.QueryRow(ctx, query,
 		participant.ID,
 		participant.CalendarID,
 		participant.Name,
+		participant.Email,
 		participant.Locale,
-	).Scan(&participant.CreatedAt)
+		participant.Required,
+	).Scan(&participant.AccessToken, &participant.CreatedAt)
 
 	if err != nil {
 		if isDuplicateKeyError(err) {
@@ -56,11 +59,37 @@ func (r *ParticipantRepository) Create(ctx context.Context, participant *models.
 	return nil
 }
 
+// CreateWithVerifiedEmail creates a participant whose email is already verified (e.g. imported from a directory)
+func (r *ParticipantRepository) CreateWithVerifiedEmail(ctx context.Context, participant *models.Participant) error {
+	query := `
+		INSERT INTO participants (id, calendar_id, name, email, email_verified, locale)
+		VALUES ($1, $2, $3, $4, true, $5)
+		RETURNING access_token, created_at`
+
+	err := r.pool.QueryRow(ctx, query,
+		participant.ID,
+		participant.CalendarID,
+		participant.Name,
+		participant.Email,
+		participant.Locale,
+	).Scan(&participant.AccessToken, &participant.CreatedAt)
+
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return ErrParticipantAlreadyExists
+		}
+		return fmt.Errorf("failed to create participant: %w", err)
+	}
+
+	participant.EmailVerified = true
+	return nil
+}
+
 // GetByID retrieves a participant by ID
 func (r *ParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
 	query := `
 		SELECT id, calendar_id, name, email, email_verified,
-		       email_verification_token, email_verification_token_expires_at, locale, created_at
+		       email_verification_token, email_verification_token_expires_at, locale, access_token, access_token_claimed_at IS NOT NULL, required, created_at
 		FROM participants
 		WHERE id = $1`
 
@@ -74,6 +103,9 @@ func (r *ParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 		&participant.EmailVerificationToken,
 		&participant.EmailVerificationTokenExpiresAt,
 		&participant.Locale,
+		&participant.AccessToken,
+		&participant.AccessTokenClaimed,
+		&participant.Required,
 		&participant.CreatedAt,
 	)
 
@@ -91,7 +123,7 @@ func (r *ParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 func (r *ParticipantRepository) GetByCalendarID(ctx context.Context, calendarID uuid.UUID) ([]models.Participant, error) {
 	query := `
 		SELECT id, calendar_id, name, email, email_verified,
-		       email_verification_token, email_verification_token_expires_at, locale, created_at
+		       email_verification_token, email_verification_token_expires_at, locale, access_token, access_token_claimed_at IS NOT NULL, required, created_at
 		FROM participants
 		WHERE calendar_id = $1
 		ORDER BY created_at ASC`
@@ -114,6 +146,9 @@ func (r *ParticipantRepository) GetByCalendarID(ctx context.Context, calendarID
 			&participant.EmailVerificationToken,
 			&participant.EmailVerificationTokenExpiresAt,
 			&participant.Locale,
+			&participant.AccessToken,
+			&participant.AccessTokenClaimed,
+			&participant.Required,
 			&participant.CreatedAt,
 		)
 		if err != nil {
@@ -125,11 +160,79 @@ func (r *ParticipantRepository) GetByCalendarID(ctx context.Context, calendarID
 	return participants, nil
 }
 
+// GetByCalendarIDs retrieves the participants of several calendars, ordered by creation date
+func (r *ParticipantRepository) GetByCalendarIDs(ctx context.Context, calendarIDs []uuid.UUID) (map[uuid.UUID][]models.Participant, error) {
+	query := `
+		SELECT id, calendar_id, name, email, email_verified,
+		       email_verification_token, email_verification_token_expires_at, locale, access_token, access_token_claimed_at IS NOT NULL, required, created_at
+		FROM participants
+		WHERE calendar_id = ANY($1)
+		ORDER BY created_at ASC`
+
+	rows, err := r.pool.Query(ctx, query, calendarIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants by calendars: %w", err)
+	}
+	defer rows.Close()
+
+	participants := make(map[uuid.UUID][]models.Participant, len(calendarIDs))
+	for rows.Next() {
+		participant := models.Participant{}
+		err := rows.Scan(
+			&participant.ID,
+			&participant.CalendarID,
+			&participant.Name,
+			&participant.Email,
+			&participant.EmailVerified,
+			&participant.EmailVerificationToken,
+			&participant.EmailVerificationTokenExpiresAt,
+			&participant.Locale,
+			&participant.AccessToken,
+			&participant.AccessTokenClaimed,
+			&participant.Required,
+			&participant.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan participant: %w", err)
+		}
+		participants[participant.CalendarID] = append(participants[participant.CalendarID], participant)
+	}
+
+	return participants, rows.Err()
+}
+
+// CountByCalendarIDs counts the participants of several calendars
+func (r *ParticipantRepository) CountByCalendarIDs(ctx context.Context, calendarIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT calendar_id, COUNT(*)
+		FROM participants
+		WHERE calendar_id = ANY($1)
+		GROUP BY calendar_id`
+
+	rows, err := r.pool.Query(ctx, query, calendarIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count participants by calendars: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int, len(calendarIDs))
+	for rows.Next() {
+		var calendarID uuid.UUID
+		var count int
+		if err := rows.Scan(&calendarID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan participant count: %w", err)
+		}
+		counts[calendarID] = count
+	}
+
+	return counts, rows.Err()
+}
+
 // GetByCalendarIDAndName retrieves a participant by calendar ID and name
 func (r *ParticipantRepository) GetByCalendarIDAndName(ctx context.Context, calendarID uuid.UUID, name string) (*models.Participant, error) {
 	query := `
 		SELECT id, calendar_id, name, email, email_verified,
-		       email_verification_token, email_verification_token_expires_at, locale, created_at
+		       email_verification_token, email_verification_token_expires_at, locale, access_token, access_token_claimed_at IS NOT NULL, required, created_at
 		FROM participants
 		WHERE calendar_id = $1 AND name = $2`
 
@@ -143,6 +246,9 @@ func (r *ParticipantRepository) GetByCalendarIDAndName(ctx context.Context, cale
 		&participant.EmailVerificationToken,
 		&participant.EmailVerificationTokenExpiresAt,
 		&participant.Locale,
+		&participant.AccessToken,
+		&participant.AccessTokenClaimed,
+		&participant.Required,
 		&participant.CreatedAt,
 	)
 
@@ -156,14 +262,14 @@ func (r *ParticipantRepository) GetByCalendarIDAndName(ctx context.Context, cale
 	return participant, nil
 }
 
-// Update updates a participant's name
-func (r *ParticipantRepository) Update(ctx context.Context, id uuid.UUID, name string) error {
+// Update updates a participant's name and whether they are required
+func (r *ParticipantRepository) Update(ctx context.Context, id uuid.UUID, name string, required bool) error {
 	query := `
 		UPDATE participants
-		SET name = $1
-		WHERE id = $2`
+		SET name = $1, required = $2
+		WHERE id = $3`
 
-	result, err := r.pool.Exec(ctx, query, name, id)
+	result, err := r.pool.Exec(ctx, query, name, required, id)
 	if err != nil {
 		if isDuplicateKeyError(err) {
 			return ErrParticipantAlreadyExists
@@ -178,6 +284,54 @@ func (r *ParticipantRepository) Update(ctx context.Context, id uuid.UUID, name s
 	return nil
 }
 
+// RegenerateAccessToken replaces the access token of a participant, invalidating their current link
+// The new link is handed out by the owner, so it can't be claimed by a visitor
+func (r *ParticipantRepository) RegenerateAccessToken(ctx context.Context, id uuid.UUID) (string, error) {
+	query := `
+		UPDATE participants
+		SET access_token = DEFAULT, access_token_claimed_at = NOW()
+		WHERE id = $1
+		RETURNING access_token`
+
+	var token string
+	if err := r.pool.QueryRow(ctx, query, id).Scan(&token); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrParticipantNotFound
+		}
+		return "", fmt.Errorf("failed to regenerate participant token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ClaimAccessToken marks the link of a participant as claimed and returns its token
+// Returns ErrParticipantAlreadyClaimed when the link was already used or handed out by the owner
+func (r *ParticipantRepository) ClaimAccessToken(ctx context.Context, calendarID, id uuid.UUID) (string, error) {
+	query := `
+		UPDATE participants
+		SET access_token_claimed_at = NOW()
+		WHERE id = $1 AND calendar_id = $2 AND access_token_claimed_at IS NULL
+		RETURNING access_token`
+
+	var token string
+	err := r.pool.QueryRow(ctx, query, id, calendarID).Scan(&token)
+	if err == nil {
+		return token, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("failed to claim participant token: %w", err)
+	}
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM participants WHERE id = $1 AND calendar_id = $2)`, id, calendarID).Scan(&exists); err != nil {
+		return "", fmt.Errorf("failed to check participant: %w", err)
+	}
+	if !exists {
+		return "", ErrParticipantNotFound
+	}
+	return "", ErrParticipantAlreadyClaimed
+}
+
 // Delete deletes a participant
 func (r *ParticipantRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM participants WHERE id = $1`
@@ -270,7 +424,7 @@ func (r *ParticipantRepository) GetByVerificationToken(
 ) (*models.Participant, error) {
 	query := `
 		SELECT id, calendar_id, name, email, email_verified,
-		       email_verification_token, email_verification_token_expires_at, locale, created_at
+		       email_verification_token, email_verification_token_expires_at, locale, access_token, access_token_claimed_at IS NOT NULL, required, created_at
 		FROM participants
 		WHERE email_verification_token = $1
 		  AND email_verification_token_expires_at > NOW()`
@@ -285,6 +439,9 @@ func (r *ParticipantRepository) GetByVerificationToken(
 		&participant.EmailVerificationToken,
 		&participant.EmailVerificationTokenExpiresAt,
 		&participant.Locale,
+		&participant.AccessToken,
+		&participant.AccessTokenClaimed,
+		&participant.Required,
 		&participant.CreatedAt,
 	)
 
@@ -378,7 +535,7 @@ func (r *ParticipantRepository) GetVerifiedParticipantsByCalendar(
 ) ([]models.Participant, error) {
 	query := `
 		SELECT id, calendar_id, name, email, email_verified,
-		       email_verification_token, email_verification_token_expires_at, locale, created_at
+		       email_verification_token, email_verification_token_expires_at, locale, access_token, access_token_claimed_at IS NOT NULL, required, created_at
 		FROM participants
 		WHERE calendar_id = $1
 		  AND email IS NOT NULL
@@ -403,6 +560,9 @@ func (r *ParticipantRepository) GetVerifiedParticipantsByCalendar(
 			&participant.EmailVerificationToken,
 			&participant.EmailVerificationTokenExpiresAt,
 			&participant.Locale,
+			&participant.AccessToken,
+			&participant.AccessTokenClaimed,
+			&participant.Required,
 			&participant.CreatedAt,
 		)
 		if err != nil {
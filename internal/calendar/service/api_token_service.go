@@ -0,0 +1,156 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	authRepo "github.com/whento/whento/internal/auth/repository"
+	"github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/calendar/repository"
+)
+
+const (
+	// APITokenPrefix identifies calendar API tokens in Authorization headers
+	APITokenPrefix = "wtct_"
+
+	// MaxAPITokensPerCalendar limits the number of API tokens of a calendar
+	MaxAPITokensPerCalendar = 10
+)
+
+var (
+	ErrAPITokenNotFound = repository.ErrAPITokenNotFound
+	ErrInvalidAPIToken  = errors.New("invalid calendar API token")
+	ErrTooManyAPITokens = fmt.Errorf("too many API tokens (maximum %d per calendar)", MaxAPITokensPerCalendar)
+)
+
+// APITokenRepository defines the interface for calendar API token operations
+type APITokenRepository interface {
+	Create(ctx context.Context, token *models.APIToken, tokenHash string) error
+	ListByCalendar(ctx context.Context, calendarID uuid.UUID) ([]*models.APIToken, error)
+	CountByCalendar(ctx context.Context, calendarID uuid.UUID) (int, error)
+	Delete(ctx context.Context, id, calendarID uuid.UUID) error
+	Authenticate(ctx context.Context, tokenHash string) (*models.APITokenOwner, error)
+}
+
+// APITokenService manages the API tokens of calendars, which let external tools act on a single calendar
+// Tokens act on behalf of the user who created them, and stop working if that user can no longer manage the calendar
+type APITokenService struct {
+	calendars *CalendarService
+	tokenRepo APITokenRepository
+	logger    *slog.Logger
+}
+
+// NewAPITokenService creates a new calendar API token service
+func NewAPITokenService(calendars *CalendarService, tokenRepo APITokenRepository, logger *slog.Logger) *APITokenService {
+	return &APITokenService{
+		calendars: calendars,
+		tokenRepo: tokenRepo,
+		logger:    logger,
+	}
+}
+
+// Create creates an API token on a calendar the user manages and returns its value, which is not stored
+// Server admins can only create tokens on calendars they manage themselves, since tokens never get admin privileges
+func (s *APITokenService) Create(ctx context.Context, userID string, calendarID uuid.UUID, req *models.CreateAPITokenRequest) (*models.APIToken, string, error) {
+	if _, err := s.calendars.accessibleCalendar(ctx, userID, "", calendarID, true); err != nil {
+		return nil, "", err
+	}
+	creator, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, "", ErrUnauthorized
+	}
+
+	count, err := s.tokenRepo.CountByCalendar(ctx, calendarID)
+	if err != nil {
+		return nil, "", err
+	}
+	if count >= MaxAPITokensPerCalendar {
+		return nil, "", ErrTooManyAPITokens
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+	value := APITokenPrefix + base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	token := &models.APIToken{
+		ID:         uuid.New(),
+		CalendarID: calendarID,
+		Name:       req.Name,
+		Scopes:     slices.Compact(scopes),
+		CreatedBy:  creator,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.tokenRepo.Create(ctx, token, authRepo.HashToken(value)); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.Info("Calendar API token created", "token_id", token.ID, "calendar_id", calendarID, "user_id", userID, "scopes", token.Scopes)
+	return token, value, nil
+}
+
+// List returns the API tokens of a calendar the user manages
+func (s *APITokenService) List(ctx context.Context, userID, userRole string, calendarID uuid.UUID) ([]*models.APIToken, error) {
+	if _, err := s.calendars.accessibleCalendar(ctx, userID, userRole, calendarID, true); err != nil {
+		return nil, err
+	}
+	return s.tokenRepo.ListByCalendar(ctx, calendarID)
+}
+
+// Revoke deletes an API token of a calendar the user manages
+func (s *APITokenService) Revoke(ctx context.Context, userID, userRole string, calendarID, tokenID uuid.UUID) error {
+	if _, err := s.calendars.accessibleCalendar(ctx, userID, userRole, calendarID, true); err != nil {
+		return err
+	}
+	if err := s.tokenRepo.Delete(ctx, tokenID, calendarID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Calendar API token revoked", "token_id", tokenID, "calendar_id", calendarID, "user_id", userID)
+	return nil
+}
+
+// Authenticate returns the calendar and creator of an API token
+// The creator must still be able to manage the calendar
+func (s *APITokenService) Authenticate(ctx context.Context, value string) (*models.APITokenOwner, error) {
+	if !IsAPIToken(value) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	owner, err := s.tokenRepo.Authenticate(ctx, authRepo.HashToken(value))
+	if errors.Is(err, ErrAPITokenNotFound) {
+		return nil, ErrInvalidAPIToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.calendars.accessibleCalendar(ctx, owner.UserID.String(), "", owner.CalendarID, true); err != nil {
+		if errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrCalendarNotFound) {
+			return nil, ErrInvalidAPIToken
+		}
+		return nil, err
+	}
+	return owner, nil
+}
+
+// IsAPIToken reports whether a Bearer token looks like a calendar API token
+func IsAPIToken(value string) bool {
+	return strings.HasPrefix(value, APITokenPrefix)
+}
@@ -11,22 +11,40 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/whento/pkg/cache"
+	"github.com/whento/pkg/datevalidation"
+	"github.com/whento/pkg/logger"
+	pkgModels "github.com/whento/pkg/models"
 	authRepo "github.com/whento/whento/internal/auth/repository"
 	"github.com/whento/whento/internal/calendar/models"
 	"github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	orgModels "github.com/whento/whento/internal/organization/models"
+	webhookModels "github.com/whento/whento/internal/webhooks/models"
 )
 
 var (
-	ErrCalendarNotFound    = errors.New("calendar not found")
-	ErrParticipantNotFound = errors.New("participant not found")
-	ErrUnauthorized        = errors.New("you don't have permission to access this calendar")
-	ErrParticipantExists   = errors.New("participant with this name already exists")
-	ErrInvalidTokenType    = errors.New("invalid token type, must be 'public' or 'ics'")
+	ErrCalendarNotFound      = errors.New("calendar not found")
+	ErrParticipantNotFound   = errors.New("participant not found")
+	ErrLinkNotClaimable      = errors.New("the participant link was already claimed or the calendar locks its participants")
+	ErrUnauthorized          = errors.New("you don't have permission to access this calendar")
+	ErrParticipantExists     = errors.New("participant with this name already exists")
+	ErrInvalidTokenType      = errors.New("invalid token type, must be 'public' or 'ics'")
+	ErrNewOwnerNotFound      = errors.New("new owner not found")
+	ErrAlreadyOwner          = errors.New("the user already owns this calendar")
+	ErrInvalidBlackout       = errors.New("blackout dates must be YYYY-MM-DD and end on or after their start")
+	ErrInvalidHoliday        = errors.New("custom holidays must have a YYYY-MM-DD date and a name")
+	ErrRegionWithoutCountry  = errors.New("holiday_region requires a holiday_country")
+	ErrInvalidHolidayCountry = errors.New("holiday countries must be ISO 3166-1 codes, optionally with a region (DE-BY)")
+	ErrInvalidCapacity       = errors.New("max_participants must be positive and capacity_policy reject or waitlist")
+	ErrInvalidThreshold      = errors.New("threshold_percent must be between 1 and 100")
+	ErrInvalidSoftThreshold  = errors.New("soft_threshold must be lower than threshold")
 )
 
 // CalendarRepository defines the interface for calendar repository operations
@@ -34,10 +52,13 @@ type CalendarRepository interface {
 	CreateWithParticipants(ctx context.Context, calendar *models.Calendar, participants []repository.ParticipantInput) ([]models.Participant, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Calendar, error)
 	GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Calendar, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.Calendar, error)
+	List(ctx context.Context, filter models.CalendarListFilter) ([]*models.Calendar, int, error)
 	GetByPublicToken(ctx context.Context, token string) (*models.Calendar, error)
 	Update(ctx context.Context, calendar *models.Calendar) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	RegenerateToken(ctx context.Context, id uuid.UUID, tokenType, newToken string) error
+	TransferOwnership(ctx context.Context, id, newOwnerID uuid.UUID) error
 }
 
 // ParticipantRepository defines the interface for participant repository operations
@@ -45,17 +66,43 @@ type ParticipantRepository interface {
 	Create(ctx context.Context, participant *models.Participant) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Participant, error)
 	GetByCalendarID(ctx context.Context, calendarID uuid.UUID) ([]models.Participant, error)
-	Update(ctx context.Context, id uuid.UUID, name string) error
+	GetByCalendarIDs(ctx context.Context, calendarIDs []uuid.UUID) (map[uuid.UUID][]models.Participant, error)
+	CountByCalendarIDs(ctx context.Context, calendarIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	Update(ctx context.Context, id uuid.UUID, name string, required bool) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	SetEmailAsVerified(ctx context.Context, participantID uuid.UUID, email string) error
+	RegenerateAccessToken(ctx context.Context, id uuid.UUID) (string, error)
+	ClaimAccessToken(ctx context.Context, calendarID, id uuid.UUID) (string, error)
+}
+
+// ChangeRepository defines the interface for the change log of calendar settings
+type ChangeRepository interface {
+	Record(ctx context.Context, calendarID, userID uuid.UUID, changes []models.FieldChange) error
+	ListByCalendar(ctx context.Context, calendarID uuid.UUID, limit, offset int) ([]models.CalendarChange, int, error)
+}
+
+// MembershipReader resolves the role of a user in the organization owning a calendar
+type MembershipReader interface {
+	Membership(ctx context.Context, organizationID, userID uuid.UUID) (*orgModels.Membership, error)
+}
+
+// WebhookDispatcher queues calendar events for the outbound webhooks of a calendar
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, calendarID uuid.UUID, event string, data any)
 }
 
 // CalendarService handles calendar business logic
 type CalendarService struct {
-	calendarRepo    CalendarRepository
-	participantRepo ParticipantRepository
-	userRepo        *authRepo.UserRepository
-	cache           cache.Cache
+	calendarRepo      CalendarRepository
+	participantRepo   ParticipantRepository
+	userRepo          *authRepo.UserRepository
+	memberships       MembershipReader
+	changeRepo        ChangeRepository
+	exports           ExportRepository
+	webhooks          WebhookDispatcher      // nil = no webhooks
+	participantEmails ParticipantEmailSender // nil = imported participants aren't invited
+	cache             cache.Cache
+	cfg               *config.Config
 }
 
 // NewCalendarService creates a new calendar service
@@ -63,23 +110,157 @@ func NewCalendarService(
 	calendarRepo CalendarRepository,
 	participantRepo ParticipantRepository,
 	userRepo *authRepo.UserRepository,
+	memberships MembershipReader,
+	changeRepo ChangeRepository,
+	exports ExportRepository,
+	webhooks WebhookDispatcher,
 	c cache.Cache,
+	cfg *config.Config,
 ) *CalendarService {
 	return &CalendarService{
 		calendarRepo:    calendarRepo,
 		participantRepo: participantRepo,
 		userRepo:        userRepo,
+		memberships:     memberships,
+		changeRepo:      changeRepo,
+		exports:         exports,
+		webhooks:        webhooks,
 		cache:           c,
+		cfg:             cfg,
 	}
 }
 
-// CreateCalendar creates a new calendar with optional participants
-func (s *CalendarService) CreateCalendar(ctx context.Context, userID string, req *models.CreateCalendarRequest) (*models.CalendarResponse, error) {
+// checkAccess checks that a user may view (or manage) a calendar
+// Personal calendars are restricted to their owner; organization calendars follow the role of the user
+// in the organization, members viewing them and owners and admins managing them. Admins can access all calendars.
+func (s *CalendarService) checkAccess(ctx context.Context, calendar *models.Calendar, userID, userRole string, manage bool) error {
+	if userRole == "admin" {
+		return nil
+	}
+	if calendar.OrganizationID == nil || s.memberships == nil {
+		if calendar.OwnerID.String() != userID {
+			return ErrUnauthorized
+		}
+		return nil
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	membership, err := s.memberships.Membership(ctx, *calendar.OrganizationID, userUUID)
+	if err != nil {
+		return err
+	}
+	if membership == nil || (manage && !membership.CanManage()) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// displaySettings holds the resolved localization settings exposed in calendar responses
+type displaySettings struct {
+	WeekStart  pkgModels.WeekStart
+	TimeFormat pkgModels.TimeFormat
+	DateFormat pkgModels.DateFormat
+}
+
+// resolveDisplaySettings applies calendar overrides on top of the instance defaults
+func (s *CalendarService) resolveDisplaySettings(calendar *models.Calendar) displaySettings {
+	return displaySettings{
+		WeekStart:  pkgModels.ResolveWeekStart(s.cfg.WeekStart, calendar.WeekStart),
+		TimeFormat: pkgModels.ResolveTimeFormat(s.cfg.TimeFormat, calendar.TimeFormat),
+		DateFormat: pkgModels.ResolveDateFormat(s.cfg.DateFormat, calendar.DateFormat),
+	}
+}
+
+// CreateCalendar creates a new calendar with optional participants, owned by an organization if organizationID is set
+func (s *CalendarService) CreateCalendar(ctx context.Context, userID string, organizationID *uuid.UUID, req *models.CreateCalendarRequest) (*models.CalendarResponse, error) {
 	ownerUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user id: %w", err)
 	}
 
+	calendar, err := newCalendar(ownerUUID, organizationID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine participant locale (use request locale or fall back to owner's locale)
+	participantLocale := req.ParticipantLocale
+	var ownerEmail string
+	if participantLocale == "" || s.userRepo != nil {
+		// Get owner information for participant email matching and locale
+		if s.userRepo != nil {
+			owner, err := s.userRepo.GetByID(ctx, ownerUUID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get owner information: %w", err)
+			}
+			if participantLocale == "" {
+				participantLocale = owner.Locale
+			}
+			if owner.EmailVerified {
+				ownerEmail = owner.Email
+			}
+		}
+	}
+	if participantLocale == "" {
+		participantLocale = "en" // Default fallback
+	}
+
+	// Build participant inputs with locale and owner email if applicable
+	participantInputs := make([]repository.ParticipantInput, 0, len(req.Participants))
+	for _, name := range req.Participants {
+		input := repository.ParticipantInput{
+			Name:   name,
+			Locale: participantLocale,
+		}
+
+		// If participant name matches owner's email is available, pre-populate email
+		if ownerEmail != "" {
+			input.Email = &ownerEmail
+			input.EmailVerified = true
+		}
+
+		participantInputs = append(participantInputs, input)
+	}
+
+	// Create calendar and participants in a transaction
+	participants, err := s.calendarRepo.CreateWithParticipants(ctx, calendar, participantInputs)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantAlreadyExists) {
+			return nil, fmt.Errorf("duplicate participant name in request")
+		}
+		return nil, fmt.Errorf("failed to create calendar: %w", err)
+	}
+	if calendar.ThresholdPercent != nil {
+		calendar.Threshold = models.PercentThreshold(*calendar.ThresholdPercent, len(participants))
+	}
+
+	// Auto-populate owner participant's email if user has verified email
+	if len(participants) > 0 && s.userRepo != nil {
+		// Get owner user information
+		owner, err := s.userRepo.GetByID(ctx, ownerUUID)
+		if err == nil && owner != nil && owner.EmailVerified && owner.Email != "" {
+			// Find participant matching owner's display name (frontend auto-adds owner as first participant)
+			for _, participant := range participants {
+				if participant.Name == owner.DisplayName {
+					// Set email as already verified for the owner participant
+					if err := s.participantRepo.SetEmailAsVerified(ctx, participant.ID, owner.Email); err != nil {
+						// Log error but don't fail calendar creation
+						// The owner can manually add their email later if this fails
+					}
+					break
+				}
+			}
+		}
+	}
+
+	return buildCalendarResponse(calendar, participants, s.resolveDisplaySettings(calendar))
+}
+
+// newCalendar builds a calendar from creation settings, applying defaults and generating its tokens
+func newCalendar(ownerUUID uuid.UUID, organizationID *uuid.UUID, req *models.CreateCalendarRequest) (*models.Calendar, error) {
 	// Generate tokens
 	publicToken, err := generateToken()
 	if err != nil {
@@ -96,6 +277,16 @@ func (s *CalendarService) CreateCalendar(ctx context.Context, userID string, req
 	if threshold == 0 {
 		threshold = 1
 	}
+	if req.ThresholdPercent != nil {
+		// Imports bypass the request validation
+		if *req.ThresholdPercent < 1 || *req.ThresholdPercent > 100 {
+			return nil, ErrInvalidThreshold
+		}
+		threshold = models.PercentThreshold(*req.ThresholdPercent, len(req.Participants))
+	}
+	if !validSoftThreshold(req.SoftThreshold, threshold, req.ThresholdPercent) {
+		return nil, ErrInvalidSoftThreshold
+	}
 
 	// Set default allowed weekdays (all days if not specified)
 	allowedWeekdays := req.AllowedWeekdays
@@ -158,99 +349,84 @@ func (s *CalendarService) CreateCalendar(ctx context.Context, userID string, req
 		return nil, fmt.Errorf("end_date must be after start_date")
 	}
 
+	blackoutDates, err := normalizeBlackoutDates(req.BlackoutDates)
+	if err != nil {
+		return nil, err
+	}
+
+	customHolidays, err := normalizeCustomHolidays(req.CustomHolidays)
+	if err != nil {
+		return nil, err
+	}
+
+	holidayCountry, holidayRegion, err := holidayLocation(req.HolidayCountry, req.HolidayRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	holidayCountries, err := normalizeHolidayCountries(req.HolidayCountries)
+	if err != nil {
+		return nil, err
+	}
+
+	// Imports bypass the request validation, so the capacity is checked here too
+	if (req.MaxParticipants != nil && *req.MaxParticipants < 1) || !validCapacityPolicy(req.CapacityPolicy) {
+		return nil, ErrInvalidCapacity
+	}
+
 	calendar := &models.Calendar{
 		OwnerID:           ownerUUID,
+		OrganizationID:    organizationID,
 		Name:              req.Name,
 		Description:       req.Description,
 		PublicToken:       publicToken,
 		ICSToken:          icsToken,
 		Threshold:         threshold,
+		ThresholdPercent:  req.ThresholdPercent,
+		SoftThreshold:     req.SoftThreshold,
+		RequireConfirm:    req.RequireConfirm,
 		AllowedWeekdays:   allowedWeekdays,
 		MinDurationHours:  req.MinDurationHours,
 		Timezone:          timezone,
 		HolidaysPolicy:    holidaysPolicy,
 		AllowHolidayEves:  req.AllowHolidayEves,
+		HolidaySets:       normalizeHolidaySets(req.HolidaySets),
+		BlackoutDates:     blackoutDates,
+		CustomHolidays:    customHolidays,
+		HolidayCountry:    holidayCountry,
+		HolidayRegion:     holidayRegion,
+		HolidayCountries:  holidayCountries,
+		ReminderMinutes:   normalizeReminderMinutes(req.ReminderMinutes),
 		AllowedHours:      allowedHoursJSON,
 		NotifyOnThreshold: req.NotifyOnThreshold,
 		NotifyConfig:      req.NotifyConfig,
 		LockParticipants:  req.LockParticipants,
+		CountMaybe:        req.CountMaybe,
+		MaxParticipants:   req.MaxParticipants,
+		CapacityPolicy:    capacityPolicy(req.CapacityPolicy),
 		StartDate:         startDate,
 		EndDate:           endDate,
 	}
-	calendar.ID = uuid.New()
-
-	// Determine participant locale (use request locale or fall back to owner's locale)
-	participantLocale := req.ParticipantLocale
-	var ownerEmail string
-	if participantLocale == "" || s.userRepo != nil {
-		// Get owner information for participant email matching and locale
-		if s.userRepo != nil {
-			owner, err := s.userRepo.GetByID(ctx, ownerUUID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get owner information: %w", err)
-			}
-			if participantLocale == "" {
-				participantLocale = owner.Locale
-			}
-			if owner.EmailVerified {
-				ownerEmail = owner.Email
-			}
-		}
+	if req.WeekStart != "" {
+		calendar.WeekStart = &req.WeekStart
 	}
-	if participantLocale == "" {
-		participantLocale = "en" // Default fallback
+	if req.TimeFormat != "" {
+		calendar.TimeFormat = &req.TimeFormat
 	}
-
-	// Build participant inputs with locale and owner email if applicable
-	participantInputs := make([]repository.ParticipantInput, 0, len(req.Participants))
-	for _, name := range req.Participants {
-		input := repository.ParticipantInput{
-			Name:   name,
-			Locale: participantLocale,
-		}
-
-		// If participant name matches owner's email is available, pre-populate email
-		if ownerEmail != "" {
-			input.Email = &ownerEmail
-			input.EmailVerified = true
-		}
-
-		participantInputs = append(participantInputs, input)
-	}
-
-	// Create calendar and participants in a transaction
-	participants, err := s.calendarRepo.CreateWithParticipants(ctx, calendar, participantInputs)
-	if err != nil {
-		if errors.Is(err, repository.ErrParticipantAlreadyExists) {
-			return nil, fmt.Errorf("duplicate participant name in request")
-		}
-		return nil, fmt.Errorf("failed to create calendar: %w", err)
-	}
-
-	// Auto-populate owner participant's email if user has verified email
-	if len(participants) > 0 && s.userRepo != nil {
-		// Get owner user information
-		owner, err := s.userRepo.GetByID(ctx, ownerUUID)
-		if err == nil && owner != nil && owner.EmailVerified && owner.Email != "" {
-			// Find participant matching owner's display name (frontend auto-adds owner as first participant)
-			for _, participant := range participants {
-				if participant.Name == owner.DisplayName {
-					// Set email as already verified for the owner participant
-					if err := s.participantRepo.SetEmailAsVerified(ctx, participant.ID, owner.Email); err != nil {
-						// Log error but don't fail calendar creation
-						// The owner can manually add their email later if this fails
-					}
-					break
-				}
-			}
-		}
+	if req.DateFormat != "" {
+		calendar.DateFormat = &req.DateFormat
 	}
+	calendar.EventTitle = eventTemplate(req.EventTitle)
+	calendar.EventDescription = eventTemplate(req.EventDescription)
+	calendar.FeedPastDays = req.FeedPastDays
+	calendar.FeedFutureDays = req.FeedFutureDays
+	calendar.ID = uuid.New()
 
-	return buildCalendarResponse(calendar, participants)
+	return calendar, nil
 }
 
 // buildCalendarResponse converts a Calendar model to CalendarResponse with parsed allowed_hours
-func buildCalendarResponse(calendar *models.Calendar, participants []models.Participant) (*models.CalendarResponse, error) {
+func buildCalendarResponse(calendar *models.Calendar, participants []models.Participant, display displaySettings) (*models.CalendarResponse, error) {
 	// Parse allowed_hours JSONB to extract separate fields
 	weekdayTimes, holidayMinTime, holidayMaxTime, holidayEveMinTime, holidayEveMaxTime, err := models.ParseAllowedHoursJSON(calendar.AllowedHours)
 	if err != nil {
@@ -260,16 +436,26 @@ func buildCalendarResponse(calendar *models.Calendar, participants []models.Part
 	return &models.CalendarResponse{
 		ID:                calendar.ID,
 		OwnerID:           calendar.OwnerID,
+		OrganizationID:    calendar.OrganizationID,
 		Name:              calendar.Name,
 		Description:       calendar.Description,
 		PublicToken:       calendar.PublicToken,
 		ICSToken:          calendar.ICSToken,
 		Threshold:         calendar.Threshold,
+		ThresholdPercent:  calendar.ThresholdPercent,
+		SoftThreshold:     calendar.SoftThreshold,
+		RequireConfirm:    calendar.RequireConfirm,
 		AllowedWeekdays:   calendar.AllowedWeekdays,
 		MinDurationHours:  calendar.MinDurationHours,
 		Timezone:          calendar.Timezone,
 		HolidaysPolicy:    calendar.HolidaysPolicy,
 		AllowHolidayEves:  calendar.AllowHolidayEves,
+		HolidaySets:       normalizeHolidaySets(calendar.HolidaySets),
+		BlackoutDates:     calendar.BlackoutDates,
+		CustomHolidays:    calendar.CustomHolidays,
+		HolidayCountry:    valueOrEmpty(calendar.HolidayCountry),
+		HolidayRegion:     valueOrEmpty(calendar.HolidayRegion),
+		HolidayCountries:  calendar.HolidayCountries,
 		WeekdayTimes:      weekdayTimes,
 		HolidayMinTime:    holidayMinTime,
 		HolidayMaxTime:    holidayMaxTime,
@@ -279,14 +465,26 @@ func buildCalendarResponse(calendar *models.Calendar, participants []models.Part
 		LockParticipants:  calendar.LockParticipants,
 		StartDate:         calendar.StartDate,
 		EndDate:           calendar.EndDate,
+		WeekStart:         display.WeekStart.String(),
+		TimeFormat:        display.TimeFormat.String(),
+		DateFormat:        display.DateFormat.String(),
+		ReminderMinutes:   normalizeReminderMinutes(calendar.ReminderMinutes),
+		EventTitle:        valueOrEmpty(calendar.EventTitle),
+		EventDescription:  valueOrEmpty(calendar.EventDescription),
+		FeedPastDays:      calendar.FeedPastDays,
+		FeedFutureDays:    calendar.FeedFutureDays,
+		CountMaybe:        calendar.CountMaybe,
+		MaxParticipants:   calendar.MaxParticipants,
+		CapacityPolicy:    calendar.CapacityPolicy,
 		Participants:      participants,
+		ParticipantCount:  len(participants),
 		CreatedAt:         calendar.CreatedAt,
 		UpdatedAt:         calendar.UpdatedAt,
 	}, nil
 }
 
 // buildPublicCalendarResponse converts a Calendar model to PublicCalendarResponse with parsed allowed_hours
-func buildPublicCalendarResponse(calendar *models.Calendar, participants []models.PublicParticipant) (*models.PublicCalendarResponse, error) {
+func buildPublicCalendarResponse(calendar *models.Calendar, participants []models.PublicParticipant, display displaySettings) (*models.PublicCalendarResponse, error) {
 	// Parse allowed_hours JSONB to extract separate fields
 	weekdayTimes, holidayMinTime, holidayMaxTime, holidayEveMinTime, holidayEveMaxTime, err := models.ParseAllowedHoursJSON(calendar.AllowedHours)
 	if err != nil {
@@ -312,11 +510,19 @@ func buildPublicCalendarResponse(calendar *models.Calendar, participants []model
 		Name:               calendar.Name,
 		Description:        calendar.Description,
 		Threshold:          calendar.Threshold,
+		ThresholdPercent:   calendar.ThresholdPercent,
+		SoftThreshold:      calendar.SoftThreshold,
 		AllowedWeekdays:    calendar.AllowedWeekdays,
 		MinDurationHours:   calendar.MinDurationHours,
 		Timezone:           calendar.Timezone,
 		HolidaysPolicy:     calendar.HolidaysPolicy,
 		AllowHolidayEves:   calendar.AllowHolidayEves,
+		HolidaySets:        normalizeHolidaySets(calendar.HolidaySets),
+		BlackoutDates:      calendar.BlackoutDates,
+		CustomHolidays:     calendar.CustomHolidays,
+		HolidayCountry:     valueOrEmpty(calendar.HolidayCountry),
+		HolidayRegion:      valueOrEmpty(calendar.HolidayRegion),
+		HolidayCountries:   calendar.HolidayCountries,
 		WeekdayTimes:       weekdayTimes,
 		HolidayMinTime:     holidayMinTime,
 		HolidayMaxTime:     holidayMaxTime,
@@ -324,9 +530,15 @@ func buildPublicCalendarResponse(calendar *models.Calendar, participants []model
 		HolidayEveMaxTime:  holidayEveMaxTime,
 		LockParticipants:   calendar.LockParticipants,
 		NotifyParticipants: notifyParticipants,
+		CountMaybe:         calendar.CountMaybe,
+		MaxParticipants:    calendar.MaxParticipants,
+		CapacityPolicy:     calendar.CapacityPolicy,
 		ICSToken:           calendar.ICSToken,
 		StartDate:          calendar.StartDate,
 		EndDate:            calendar.EndDate,
+		WeekStart:          display.WeekStart.String(),
+		TimeFormat:         display.TimeFormat.String(),
+		DateFormat:         display.DateFormat.String(),
 		Participants:       participants,
 		CreatedAt:          calendar.CreatedAt,
 	}, nil
@@ -352,30 +564,26 @@ func findSubstring(s, substr string) bool {
 }
 
 // filterParticipants masks participant IDs based on lock_participants setting and participant_id
+// participant_id is the access token of the participant link. Only the participant of that link gets
+// their token back; on open calendars, links never claimed can be claimed with ClaimParticipantLink.
 func filterParticipants(lockParticipants bool, participantID string, participants []models.Participant) []models.PublicParticipant {
-	// Parse participant ID if provided
-	var parsedID uuid.UUID
-	var err error
-	if participantID != "" {
-		parsedID, err = uuid.Parse(participantID)
-		if err != nil {
-			parsedID = uuid.Nil
-		}
-	}
-
 	publicParticipants := make([]models.PublicParticipant, len(participants))
 
 	if !lockParticipants {
 		// Return all participants with their IDs visible
-		// Email info is only included for the specified participant
+		// Email info and the link are only included for the specified participant
 		for i, p := range participants {
-			isCurrentParticipant := participantID != "" && p.ID == parsedID
+			isCurrentParticipant := participantID != "" && p.AccessToken == participantID
 			publicParticipants[i] = models.PublicParticipant{
 				ID:            &p.ID,
 				CalendarID:    p.CalendarID,
 				Name:          p.Name,
 				Email:         conditionalEmail(isCurrentParticipant, p.Email),
 				EmailVerified: isCurrentParticipant && p.EmailVerified,
+				AccessToken:   conditionalToken(isCurrentParticipant, p.AccessToken),
+				Claimable:     !isCurrentParticipant && !p.AccessTokenClaimed,
+				Current:       isCurrentParticipant,
+				Required:      p.Required,
 				CreatedAt:     p.CreatedAt,
 			}
 		}
@@ -385,7 +593,7 @@ func filterParticipants(lockParticipants bool, participantID string, participant
 	// If lock_participants is true, mask IDs except for the specified participant
 	// Email info is still only shown for the specified participant
 	for i, p := range participants {
-		isCurrentParticipant := participantID != "" && p.ID == parsedID
+		isCurrentParticipant := participantID != "" && p.AccessToken == participantID
 		if isCurrentParticipant {
 			// Keep the participant with their ID and email
 			publicParticipants[i] = models.PublicParticipant{
@@ -394,6 +602,9 @@ func filterParticipants(lockParticipants bool, participantID string, participant
 				Name:          p.Name,
 				Email:         p.Email,
 				EmailVerified: p.EmailVerified,
+				AccessToken:   p.AccessToken,
+				Current:       true,
+				Required:      p.Required,
 				CreatedAt:     p.CreatedAt,
 			}
 		} else {
@@ -404,6 +615,7 @@ func filterParticipants(lockParticipants bool, participantID string, participant
 				Name:          p.Name,
 				Email:         nil,
 				EmailVerified: false,
+				Required:      p.Required,
 				CreatedAt:     p.CreatedAt,
 			}
 		}
@@ -412,6 +624,170 @@ func filterParticipants(lockParticipants bool, participantID string, participant
 	return publicParticipants
 }
 
+// normalizeHolidaySets returns a non-nil, deduplicated list of holiday sets (the column is NOT NULL)
+func normalizeHolidaySets(holidaySets []string) []string {
+	normalized := make([]string, 0, len(holidaySets))
+	seen := make(map[string]bool, len(holidaySets))
+	for _, set := range holidaySets {
+		if !seen[set] {
+			seen[set] = true
+			normalized = append(normalized, set)
+		}
+	}
+	return normalized
+}
+
+// normalizeBlackoutDates checks the blackout periods of a calendar and returns them as a non-nil list,
+// earliest period first
+func normalizeBlackoutDates(periods []datevalidation.BlackoutPeriod) ([]datevalidation.BlackoutPeriod, error) {
+	normalized := make([]datevalidation.BlackoutPeriod, 0, len(periods))
+	for _, period := range periods {
+		if !period.Valid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBlackout, period.StartDate)
+		}
+		normalized = append(normalized, period)
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return normalized[i].StartDate < normalized[j].StartDate
+	})
+	return normalized, nil
+}
+
+// normalizeCustomHolidays checks the custom holidays of a calendar and returns them as a non-nil list,
+// earliest date first
+func normalizeCustomHolidays(holidays []datevalidation.CustomHoliday) ([]datevalidation.CustomHoliday, error) {
+	normalized := make([]datevalidation.CustomHoliday, 0, len(holidays))
+	for _, holiday := range holidays {
+		if !holiday.Valid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHoliday, holiday.Date)
+		}
+		holiday.Name = strings.TrimSpace(holiday.Name)
+		normalized = append(normalized, holiday)
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return normalized[i].Date < normalized[j].Date
+	})
+	return normalized, nil
+}
+
+// holidayLocation returns the holiday country and region to store, uppercased and nil when unset
+// A region is a subdivision of an explicit country, it cannot be combined with a country derived from the timezone
+func holidayLocation(country, region string) (*string, *string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if region != "" && country == "" {
+		return nil, nil, ErrRegionWithoutCountry
+	}
+
+	var countryValue, regionValue *string
+	if country != "" {
+		countryValue = &country
+	}
+	if region != "" {
+		regionValue = &region
+	}
+	return countryValue, regionValue, nil
+}
+
+// normalizeHolidayCountries checks the additional holiday countries of a calendar and returns them
+// uppercased and deduplicated as a non-nil list
+func normalizeHolidayCountries(codes []string) ([]string, error) {
+	normalized := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !datevalidation.IsValidHolidayCode(code) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHolidayCountry, code)
+		}
+		if !seen[code] {
+			seen[code] = true
+			normalized = append(normalized, code)
+		}
+	}
+	return normalized, nil
+}
+
+// normalizeReminderMinutes returns a non-nil, deduplicated list of reminders, earliest reminder first
+func normalizeReminderMinutes(minutes []int) []int {
+	normalized := make([]int, 0, len(minutes))
+	seen := make(map[int]bool, len(minutes))
+	for _, m := range minutes {
+		if !seen[m] {
+			seen[m] = true
+			normalized = append(normalized, m)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(normalized)))
+	return normalized
+}
+
+// eventTemplate returns the template to store for an ICS event title or description, nil for blank templates
+func eventTemplate(template string) *string {
+	if strings.TrimSpace(template) == "" {
+		return nil
+	}
+	return &template
+}
+
+// feedDays returns the ICS feed window bound to store, nil for no limit
+func feedDays(days int) *int {
+	if days < 0 {
+		return nil
+	}
+	return &days
+}
+
+// validSoftThreshold reports whether a soft threshold is positive and below the threshold
+// A percentage threshold follows the participant count, so only the absolute one is compared
+func validSoftThreshold(soft *int, threshold int, percent *int) bool {
+	return soft == nil || *soft >= 1 && (percent != nil || *soft < threshold)
+}
+
+// thresholdPercent returns the threshold percentage to store, nil for 0 (absolute threshold)
+func thresholdPercent(percent int) *int {
+	if percent < 1 {
+		return nil
+	}
+	return &percent
+}
+
+// maxParticipants returns the capacity per date to store, nil for -1 (no limit)
+func maxParticipants(max int) *int {
+	if max < 1 {
+		return nil
+	}
+	return &max
+}
+
+// capacityPolicy returns the capacity policy to store, rejecting availabilities on full dates by default
+func capacityPolicy(policy string) string {
+	if policy == "" {
+		return "reject"
+	}
+	return policy
+}
+
+// validCapacityPolicy reports whether a capacity policy is known, empty meaning the default
+func validCapacityPolicy(policy string) bool {
+	return policy == "" || policy == "reject" || policy == "waitlist"
+}
+
+// valueOrEmpty returns the value of an optional string, empty when unset
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// conditionalToken returns the access token if condition is true, otherwise an empty string
+func conditionalToken(condition bool, token string) string {
+	if condition {
+		return token
+	}
+	return ""
+}
+
 // conditionalEmail returns the email if condition is true, otherwise nil
 func conditionalEmail(condition bool, email *string) *string {
 	if condition {
@@ -436,8 +812,8 @@ func (s *CalendarService) GetCalendar(ctx context.Context, userID, userRole, cal
 	}
 
 	// Check ownership or admin role
-	if calendar.OwnerID.String() != userID && userRole != "admin" {
-		return nil, ErrUnauthorized
+	if err := s.checkAccess(ctx, calendar, userID, userRole, false); err != nil {
+		return nil, err
 	}
 
 	// Get participants
@@ -446,37 +822,88 @@ func (s *CalendarService) GetCalendar(ctx context.Context, userID, userRole, cal
 		return nil, err
 	}
 
-	return buildCalendarResponse(calendar, participants)
+	// Participant links are only shown to the users who can manage the calendar
+	if err := s.checkAccess(ctx, calendar, userID, userRole, true); err != nil {
+		hideAccessTokens(participants)
+	}
+
+	return buildCalendarResponse(calendar, participants, s.resolveDisplaySettings(calendar))
+}
+
+// hideAccessTokens removes the secret of the participant links
+func hideAccessTokens(participants []models.Participant) {
+	for i := range participants {
+		participants[i].AccessToken = ""
+	}
+}
+
+// ListMyCalendars lists the personal calendars of the user, or the calendars of an organization if organizationID is set
+func (s *CalendarService) ListMyCalendars(ctx context.Context, userID string, organizationID *uuid.UUID) ([]*models.CalendarResponse, error) {
+	responses, _, err := s.ListCalendars(ctx, userID, models.CalendarListFilter{OrganizationID: organizationID}, true)
+	return responses, err
 }
 
-// ListMyCalendars lists all calendars owned by the user
-func (s *CalendarService) ListMyCalendars(ctx context.Context, userID string) ([]*models.CalendarResponse, error) {
+// ListCalendars returns a page of the personal calendars of the user, or of the calendars of the organization
+// of the filter, along with the number of matching calendars
+// Without participants, the calendars only carry their participant count
+func (s *CalendarService) ListCalendars(ctx context.Context, userID string, filter models.CalendarListFilter, withParticipants bool) ([]*models.CalendarResponse, int, error) {
 	ownerUUID, err := uuid.Parse(userID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user id: %w", err)
+		return nil, 0, fmt.Errorf("invalid user id: %w", err)
 	}
+	filter.OwnerID = ownerUUID
 
-	calendars, err := s.calendarRepo.GetByOwnerID(ctx, ownerUUID)
+	calendars, total, err := s.calendarRepo.List(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	if len(calendars) == 0 {
+		return nil, total, nil
 	}
 
-	var responses []*models.CalendarResponse
-	for _, calendar := range calendars {
-		// Get participants for each calendar
-		participants, err := s.participantRepo.GetByCalendarID(ctx, calendar.ID)
+	ids := make([]uuid.UUID, len(calendars))
+	for i, calendar := range calendars {
+		ids[i] = calendar.ID
+	}
+
+	// Participants of all the calendars of the page at once
+	var participants map[uuid.UUID][]models.Participant
+	var counts map[uuid.UUID]int
+	if withParticipants {
+		participants, err = s.participantRepo.GetByCalendarIDs(ctx, ids)
+	} else {
+		counts, err = s.participantRepo.CountByCalendarIDs(ctx, ids)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Participant links are only shown to the members who can manage the calendars of the organization
+	if filter.OrganizationID != nil && s.memberships != nil {
+		membership, err := s.memberships.Membership(ctx, *filter.OrganizationID, ownerUUID)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+		if membership == nil || !membership.CanManage() {
+			for _, calendarParticipants := range participants {
+				hideAccessTokens(calendarParticipants)
+			}
 		}
+	}
 
-		response, err := buildCalendarResponse(calendar, participants)
+	responses := make([]*models.CalendarResponse, 0, len(calendars))
+	for _, calendar := range calendars {
+		response, err := buildCalendarResponse(calendar, participants[calendar.ID], s.resolveDisplaySettings(calendar))
 		if err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+		if !withParticipants {
+			response.ParticipantCount = counts[calendar.ID]
 		}
 		responses = append(responses, response)
 	}
 
-	return responses, nil
+	return responses, total, nil
 }
 
 // UpdateCalendar updates a calendar (requires ownership or admin role)
@@ -495,10 +922,13 @@ func (s *CalendarService) UpdateCalendar(ctx context.Context, userID, userRole,
 	}
 
 	// Check ownership or admin role
-	if calendar.OwnerID.String() != userID && userRole != "admin" {
-		return nil, ErrUnauthorized
+	if err := s.checkAccess(ctx, calendar, userID, userRole, true); err != nil {
+		return nil, err
 	}
 
+	// Keep the current settings for the change log
+	before := *calendar
+
 	// Update fields if provided
 	if req.Name != nil {
 		calendar.Name = *req.Name
@@ -508,6 +938,20 @@ func (s *CalendarService) UpdateCalendar(ctx context.Context, userID, userRole,
 	}
 	if req.Threshold != nil {
 		calendar.Threshold = *req.Threshold
+		calendar.ThresholdPercent = nil
+	}
+	if req.ThresholdPercent != nil {
+		// The repository applies the percentage to the participant count
+		calendar.ThresholdPercent = thresholdPercent(*req.ThresholdPercent)
+	}
+	if req.SoftThreshold != nil {
+		calendar.SoftThreshold = nil
+		if *req.SoftThreshold > 0 {
+			calendar.SoftThreshold = req.SoftThreshold
+		}
+	}
+	if !validSoftThreshold(calendar.SoftThreshold, calendar.Threshold, calendar.ThresholdPercent) {
+		return nil, ErrInvalidSoftThreshold
 	}
 	if len(req.AllowedWeekdays) > 0 {
 		calendar.AllowedWeekdays = req.AllowedWeekdays
@@ -524,6 +968,40 @@ func (s *CalendarService) UpdateCalendar(ctx context.Context, userID, userRole,
 	if req.AllowHolidayEves != nil {
 		calendar.AllowHolidayEves = *req.AllowHolidayEves
 	}
+	if req.HolidaySets != nil {
+		calendar.HolidaySets = normalizeHolidaySets(req.HolidaySets)
+	}
+	if req.BlackoutDates != nil {
+		if calendar.BlackoutDates, err = normalizeBlackoutDates(req.BlackoutDates); err != nil {
+			return nil, err
+		}
+	}
+	if req.CustomHolidays != nil {
+		if calendar.CustomHolidays, err = normalizeCustomHolidays(req.CustomHolidays); err != nil {
+			return nil, err
+		}
+	}
+	if req.HolidayCountry != nil || req.HolidayRegion != nil {
+		country, region := valueOrEmpty(calendar.HolidayCountry), valueOrEmpty(calendar.HolidayRegion)
+		if req.HolidayCountry != nil && !strings.EqualFold(*req.HolidayCountry, country) {
+			// Regions belong to a country, a new country starts with nationwide holidays
+			country, region = *req.HolidayCountry, ""
+		}
+		if req.HolidayRegion != nil {
+			region = *req.HolidayRegion
+		}
+		if calendar.HolidayCountry, calendar.HolidayRegion, err = holidayLocation(country, region); err != nil {
+			return nil, err
+		}
+	}
+	if req.HolidayCountries != nil {
+		if calendar.HolidayCountries, err = normalizeHolidayCountries(req.HolidayCountries); err != nil {
+			return nil, err
+		}
+	}
+	if req.ReminderMinutes != nil {
+		calendar.ReminderMinutes = normalizeReminderMinutes(req.ReminderMinutes)
+	}
 	if req.NotifyOnThreshold != nil {
 		calendar.NotifyOnThreshold = *req.NotifyOnThreshold
 	}
@@ -533,6 +1011,18 @@ func (s *CalendarService) UpdateCalendar(ctx context.Context, userID, userRole,
 	if req.LockParticipants != nil {
 		calendar.LockParticipants = *req.LockParticipants
 	}
+	if req.RequireConfirm != nil {
+		calendar.RequireConfirm = *req.RequireConfirm
+	}
+	if req.CountMaybe != nil {
+		calendar.CountMaybe = *req.CountMaybe
+	}
+	if req.MaxParticipants != nil {
+		calendar.MaxParticipants = maxParticipants(*req.MaxParticipants)
+	}
+	if req.CapacityPolicy != nil {
+		calendar.CapacityPolicy = capacityPolicy(*req.CapacityPolicy)
+	}
 
 	// Update start_date if provided
 	if req.StartDate != nil {
@@ -562,6 +1052,49 @@ func (s *CalendarService) UpdateCalendar(ctx context.Context, userID, userRole,
 		}
 	}
 
+	// Update week_start if provided (empty string resets to the instance default)
+	if req.WeekStart != nil {
+		if *req.WeekStart == "" {
+			calendar.WeekStart = nil
+		} else {
+			calendar.WeekStart = req.WeekStart
+		}
+	}
+
+	// Update time_format if provided (empty string resets to the instance default)
+	if req.TimeFormat != nil {
+		if *req.TimeFormat == "" {
+			calendar.TimeFormat = nil
+		} else {
+			calendar.TimeFormat = req.TimeFormat
+		}
+	}
+
+	// Update date_format if provided (empty string resets to the instance default)
+	if req.DateFormat != nil {
+		if *req.DateFormat == "" {
+			calendar.DateFormat = nil
+		} else {
+			calendar.DateFormat = req.DateFormat
+		}
+	}
+
+	// Update the ICS event templates if provided (empty string restores the built-in text)
+	if req.EventTitle != nil {
+		calendar.EventTitle = eventTemplate(*req.EventTitle)
+	}
+	if req.EventDescription != nil {
+		calendar.EventDescription = eventTemplate(*req.EventDescription)
+	}
+
+	// Update the ICS feed window if provided (-1 removes the limit)
+	if req.FeedPastDays != nil {
+		calendar.FeedPastDays = feedDays(*req.FeedPastDays)
+	}
+	if req.FeedFutureDays != nil {
+		calendar.FeedFutureDays = feedDays(*req.FeedFutureDays)
+	}
+
 	// Validate that end_date is after start_date if both are set
 	if calendar.StartDate != nil && calendar.EndDate != nil && calendar.EndDate.Before(*calendar.StartDate) {
 		return nil, fmt.Errorf("end_date must be after start_date")
@@ -629,6 +1162,19 @@ func (s *CalendarService) UpdateCalendar(ctx context.Context, userID, userRole,
 		return nil, err
 	}
 
+	// Record the changed settings (the update is kept if the change log fails)
+	changes := models.DiffCalendars(&before, calendar)
+	if s.changeRepo != nil {
+		if userUUID, err := uuid.Parse(userID); err == nil {
+			if err := s.changeRepo.Record(ctx, calendar.ID, userUUID, changes); err != nil {
+				logger.FromContext(ctx).Error("Failed to record calendar changes", "error", err, "calendar_id", calendar.ID)
+			}
+		}
+	}
+	if s.webhooks != nil && len(changes) > 0 {
+		s.webhooks.Dispatch(ctx, calendar.ID, webhookModels.EventCalendarUpdated, map[string]any{"changes": changes})
+	}
+
 	// Invalidate the public calendar cache
 	cacheKey := cache.CalendarByPublicTokenKey(calendar.PublicToken)
 	_ = s.cache.Delete(ctx, cacheKey)
@@ -639,7 +1185,37 @@ func (s *CalendarService) UpdateCalendar(ctx context.Context, userID, userRole,
 		return nil, err
 	}
 
-	return buildCalendarResponse(calendar, participants)
+	return buildCalendarResponse(calendar, participants, s.resolveDisplaySettings(calendar))
+}
+
+// ListChanges lists the changes of the settings of a calendar, most recent first (same access as GetCalendar)
+func (s *CalendarService) ListChanges(ctx context.Context, userID, userRole, calendarID string, limit, offset int) (*models.CalendarChangeList, error) {
+	id, err := uuid.Parse(calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calendar id: %w", err)
+	}
+
+	calendar, err := s.calendarRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.checkAccess(ctx, calendar, userID, userRole, false); err != nil {
+		return nil, err
+	}
+
+	changes, total, err := s.changeRepo.ListByCalendar(ctx, calendar.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes == nil {
+		changes = []models.CalendarChange{}
+	}
+	return &models.CalendarChangeList{Items: changes, Total: total}, nil
 }
 
 // DeleteCalendar deletes a calendar (requires ownership or admin role)
@@ -658,8 +1234,8 @@ func (s *CalendarService) DeleteCalendar(ctx context.Context, userID, userRole,
 	}
 
 	// Check ownership or admin role
-	if calendar.OwnerID.String() != userID && userRole != "admin" {
-		return ErrUnauthorized
+	if err := s.checkAccess(ctx, calendar, userID, userRole, true); err != nil {
+		return err
 	}
 
 	// Invalidate the public calendar cache before deletion
@@ -689,8 +1265,8 @@ func (s *CalendarService) RegenerateToken(ctx context.Context, userID, userRole,
 	}
 
 	// Check ownership or admin role
-	if calendar.OwnerID.String() != userID && userRole != "admin" {
-		return nil, ErrUnauthorized
+	if err := s.checkAccess(ctx, calendar, userID, userRole, true); err != nil {
+		return nil, err
 	}
 
 	// If regenerating public token, invalidate the old cache first
@@ -723,7 +1299,38 @@ func (s *CalendarService) RegenerateToken(ctx context.Context, userID, userRole,
 		return nil, err
 	}
 
-	return buildCalendarResponse(calendar, participants)
+	return buildCalendarResponse(calendar, participants, s.resolveDisplaySettings(calendar))
+}
+
+// PublicToken returns the public token of a calendar the user may view
+// Used to serve the availability routes of the public link by calendar ID to authenticated clients
+func (s *CalendarService) PublicToken(ctx context.Context, userID, userRole, calendarID string) (string, error) {
+	id, err := uuid.Parse(calendarID)
+	if err != nil {
+		return "", ErrCalendarNotFound
+	}
+
+	calendar, err := s.accessibleCalendar(ctx, userID, userRole, id, false)
+	if err != nil {
+		return "", err
+	}
+	return calendar.PublicToken, nil
+}
+
+// accessibleCalendar returns a calendar the user may view (or manage)
+func (s *CalendarService) accessibleCalendar(ctx context.Context, userID, userRole string, calendarID uuid.UUID, manage bool) (*models.Calendar, error) {
+	calendar, err := s.calendarRepo.GetByID(ctx, calendarID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.checkAccess(ctx, calendar, userID, userRole, manage); err != nil {
+		return nil, err
+	}
+	return calendar, nil
 }
 
 // AddParticipant adds a participant to a calendar
@@ -742,13 +1349,14 @@ func (s *CalendarService) AddParticipant(ctx context.Context, userID, userRole,
 	}
 
 	// Check ownership or admin role
-	if calendar.OwnerID.String() != userID && userRole != "admin" {
-		return nil, ErrUnauthorized
+	if err := s.checkAccess(ctx, calendar, userID, userRole, true); err != nil {
+		return nil, err
 	}
 
 	participant := &models.Participant{
 		CalendarID: id,
 		Name:       req.Name,
+		Required:   req.Required,
 	}
 	participant.ID = uuid.New()
 
@@ -763,6 +1371,13 @@ func (s *CalendarService) AddParticipant(ctx context.Context, userID, userRole,
 	cacheKey := cache.CalendarByPublicTokenKey(calendar.PublicToken)
 	_ = s.cache.Delete(ctx, cacheKey)
 
+	if s.webhooks != nil {
+		s.webhooks.Dispatch(ctx, calendar.ID, webhookModels.EventParticipantAdded, webhookModels.ParticipantEventData{
+			ParticipantID:   participant.ID,
+			ParticipantName: participant.Name,
+		})
+	}
+
 	return participant, nil
 }
 
@@ -782,8 +1397,8 @@ func (s *CalendarService) UpdateParticipant(ctx context.Context, userID, userRol
 	}
 
 	// Check ownership or admin role
-	if calendar.OwnerID.String() != userID && userRole != "admin" {
-		return nil, ErrUnauthorized
+	if err := s.checkAccess(ctx, calendar, userID, userRole, true); err != nil {
+		return nil, err
 	}
 
 	partID, err := uuid.Parse(participantID)
@@ -804,8 +1419,15 @@ func (s *CalendarService) UpdateParticipant(ctx context.Context, userID, userRol
 		return nil, ErrParticipantNotFound
 	}
 
-	// Update the participant's name
-	if err := s.participantRepo.Update(ctx, partID, req.Name); err != nil {
+	// Update the participant's name and whether they are required, keeping the fields left out
+	name, required := participant.Name, participant.Required
+	if req.Name != "" {
+		name = req.Name
+	}
+	if req.Required != nil {
+		required = *req.Required
+	}
+	if err := s.participantRepo.Update(ctx, partID, name, required); err != nil {
 		if errors.Is(err, repository.ErrParticipantAlreadyExists) {
 			return nil, ErrParticipantExists
 		}
@@ -825,6 +1447,54 @@ func (s *CalendarService) UpdateParticipant(ctx context.Context, userID, userRol
 	return participant, nil
 }
 
+// RegenerateParticipantToken issues a new access token for a participant, so their previous link stops working
+func (s *CalendarService) RegenerateParticipantToken(ctx context.Context, userID, userRole, calendarID, participantID string) (*models.Participant, error) {
+	calID, err := uuid.Parse(calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calendar id: %w", err)
+	}
+
+	calendar, err := s.calendarRepo.GetByID(ctx, calID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.checkAccess(ctx, calendar, userID, userRole, true); err != nil {
+		return nil, err
+	}
+
+	partID, err := uuid.Parse(participantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid participant id: %w", err)
+	}
+
+	participant, err := s.participantRepo.GetByID(ctx, partID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, ErrParticipantNotFound
+		}
+		return nil, err
+	}
+
+	if participant.CalendarID != calID {
+		return nil, ErrParticipantNotFound
+	}
+
+	token, err := s.participantRepo.RegenerateAccessToken(ctx, partID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, ErrParticipantNotFound
+		}
+		return nil, err
+	}
+	participant.AccessToken = token
+
+	return participant, nil
+}
+
 // RemoveParticipant removes a participant from a calendar
 func (s *CalendarService) RemoveParticipant(ctx context.Context, userID, userRole, calendarID, participantID string) error {
 	calID, err := uuid.Parse(calendarID)
@@ -841,8 +1511,8 @@ func (s *CalendarService) RemoveParticipant(ctx context.Context, userID, userRol
 	}
 
 	// Check ownership or admin role
-	if calendar.OwnerID.String() != userID && userRole != "admin" {
-		return ErrUnauthorized
+	if err := s.checkAccess(ctx, calendar, userID, userRole, true); err != nil {
+		return err
 	}
 
 	partID, err := uuid.Parse(participantID)
@@ -874,8 +1544,9 @@ func (s *CalendarService) RemoveParticipant(ctx context.Context, userID, userRol
 	}
 
 	// If threshold exceeds remaining participants, reduce it automatically
+	// A percentage threshold already followed the participant count
 	remainingCount := len(remainingParticipants)
-	if calendar.Threshold > remainingCount && remainingCount > 0 {
+	if calendar.ThresholdPercent == nil && calendar.Threshold > remainingCount && remainingCount > 0 {
 		calendar.Threshold = remainingCount
 		if err := s.calendarRepo.Update(ctx, calendar); err != nil {
 			return fmt.Errorf("failed to update calendar threshold: %w", err)
@@ -886,6 +1557,13 @@ func (s *CalendarService) RemoveParticipant(ctx context.Context, userID, userRol
 	cacheKey := cache.CalendarByPublicTokenKey(calendar.PublicToken)
 	_ = s.cache.Delete(ctx, cacheKey)
 
+	if s.webhooks != nil {
+		s.webhooks.Dispatch(ctx, calendar.ID, webhookModels.EventParticipantRemoved, webhookModels.ParticipantEventData{
+			ParticipantID:   participant.ID,
+			ParticipantName: participant.Name,
+		})
+	}
+
 	return nil
 }
 
@@ -911,7 +1589,7 @@ func (s *CalendarService) GetPublicCalendar(ctx context.Context, token, particip
 	filteredParticipants := filterParticipants(calendar.LockParticipants, participantID, participants)
 
 	// Build response with parsed allowed_hours
-	response, err := buildPublicCalendarResponse(calendar, filteredParticipants)
+	response, err := buildPublicCalendarResponse(calendar, filteredParticipants, s.resolveDisplaySettings(calendar))
 	if err != nil {
 		return nil, err
 	}
@@ -919,6 +1597,40 @@ func (s *CalendarService) GetPublicCalendar(ctx context.Context, token, particip
 	return response, nil
 }
 
+// ClaimParticipantLink returns the access token of a participant of an open calendar whose link was
+// never used nor handed out by the owner, and marks it as claimed so nobody else can get it
+func (s *CalendarService) ClaimParticipantLink(ctx context.Context, token, participantID string) (string, error) {
+	calendar, err := s.calendarRepo.GetByPublicToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return "", ErrCalendarNotFound
+		}
+		return "", err
+	}
+
+	if calendar.LockParticipants {
+		return "", ErrLinkNotClaimable
+	}
+
+	partID, err := uuid.Parse(participantID)
+	if err != nil {
+		return "", ErrParticipantNotFound
+	}
+
+	accessToken, err := s.participantRepo.ClaimAccessToken(ctx, calendar.ID, partID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return "", ErrParticipantNotFound
+		}
+		if errors.Is(err, repository.ErrParticipantAlreadyClaimed) {
+			return "", ErrLinkNotClaimable
+		}
+		return "", err
+	}
+
+	return accessToken, nil
+}
+
 // ListUserCalendars lists all calendars owned by a specific user (admin only)
 func (s *CalendarService) ListUserCalendars(ctx context.Context, targetUserID string) ([]*models.CalendarResponse, error) {
 	ownerUUID, err := uuid.Parse(targetUserID)
@@ -939,7 +1651,7 @@ func (s *CalendarService) ListUserCalendars(ctx context.Context, targetUserID st
 			return nil, err
 		}
 
-		response, err := buildCalendarResponse(calendar, participants)
+		response, err := buildCalendarResponse(calendar, participants, s.resolveDisplaySettings(calendar))
 		if err != nil {
 			return nil, err
 		}
@@ -949,6 +1661,71 @@ func (s *CalendarService) ListUserCalendars(ctx context.Context, targetUserID st
 	return responses, nil
 }
 
+// TransferCalendar makes a calendar a personal calendar of another user (admin only)
+// Tokens, participants and availabilities are kept, so shared links and ICS subscriptions keep working
+// and the calendar counts against the quota of the new owner from now on
+func (s *CalendarService) TransferCalendar(ctx context.Context, adminID, calendarID, newOwnerID string) (*models.CalendarResponse, error) {
+	id, err := uuid.Parse(calendarID)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+	ownerUUID, err := uuid.Parse(newOwnerID)
+	if err != nil {
+		return nil, ErrNewOwnerNotFound
+	}
+
+	calendar, err := s.calendarRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+	if calendar.OwnerID == ownerUUID && calendar.OrganizationID == nil {
+		return nil, ErrAlreadyOwner
+	}
+
+	if s.userRepo != nil {
+		if _, err := s.userRepo.GetByID(ctx, ownerUUID); err != nil {
+			if errors.Is(err, authRepo.ErrUserNotFound) {
+				return nil, ErrNewOwnerNotFound
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.calendarRepo.TransferOwnership(ctx, id, ownerUUID); err != nil {
+		if errors.Is(err, repository.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	change := models.FieldChange{Field: "owner_id"}
+	change.Old, _ = json.Marshal(calendar.OwnerID)
+	change.New, _ = json.Marshal(ownerUUID)
+	if s.changeRepo != nil {
+		if adminUUID, err := uuid.Parse(adminID); err == nil {
+			if err := s.changeRepo.Record(ctx, calendar.ID, adminUUID, []models.FieldChange{change}); err != nil {
+				logger.FromContext(ctx).Error("Failed to record calendar changes", "error", err, "calendar_id", calendar.ID)
+			}
+		}
+	}
+
+	// Drop the cached public calendar so that it reflects the new owner
+	_ = s.cache.Delete(ctx, cache.CalendarByPublicTokenKey(calendar.PublicToken))
+
+	calendar.OwnerID = ownerUUID
+	calendar.OrganizationID = nil
+
+	participants, err := s.participantRepo.GetByCalendarID(ctx, calendar.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCalendarResponse(calendar, participants, s.resolveDisplaySettings(calendar))
+}
+
 // generateToken generates a random 64-character hex token
 func generateToken() (string, error) {
 	b := make([]byte, 32)
@@ -0,0 +1,98 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	authRepo "github.com/whento/whento/internal/auth/repository"
+	"github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/calendar/repository"
+)
+
+var (
+	ErrConfirmationNotFound = repository.ErrConfirmationNotFound
+	ErrInvalidStatus        = errors.New("status must be pending, confirmed or declined")
+)
+
+// ConfirmationRepository defines the interface for event confirmation operations
+type ConfirmationRepository interface {
+	Decide(ctx context.Context, calendarID uuid.UUID, date time.Time, status string) (*models.EventConfirmation, error)
+	GetByToken(ctx context.Context, tokenHash string) (*models.EventConfirmation, error)
+	ListByCalendar(ctx context.Context, calendarID uuid.UUID, status string) ([]*models.EventConfirmation, error)
+}
+
+// ConfirmationService handles the owner's decisions on the dates of calendars requiring confirmation
+// Dates are proposed by the notification service when they reach the threshold
+type ConfirmationService struct {
+	calendars        *CalendarService
+	confirmationRepo ConfirmationRepository
+	logger           *slog.Logger
+}
+
+// NewConfirmationService creates a new event confirmation service
+func NewConfirmationService(calendars *CalendarService, confirmationRepo ConfirmationRepository, logger *slog.Logger) *ConfirmationService {
+	return &ConfirmationService{
+		calendars:        calendars,
+		confirmationRepo: confirmationRepo,
+		logger:           logger,
+	}
+}
+
+// List returns the confirmations of a calendar the user manages, optionally with a status
+func (s *ConfirmationService) List(ctx context.Context, userID, userRole string, calendarID uuid.UUID, status string) ([]*models.EventConfirmation, error) {
+	switch status {
+	case "", models.ConfirmationPending, models.ConfirmationConfirmed, models.ConfirmationDeclined:
+	default:
+		return nil, ErrInvalidStatus
+	}
+
+	if _, err := s.calendars.accessibleCalendar(ctx, userID, userRole, calendarID, true); err != nil {
+		return nil, err
+	}
+	return s.confirmationRepo.ListByCalendar(ctx, calendarID, status)
+}
+
+// Decide confirms or declines a date of a calendar the user manages, proposed or not yet
+func (s *ConfirmationService) Decide(ctx context.Context, userID, userRole string, calendarID uuid.UUID, date time.Time, status string) (*models.EventConfirmation, error) {
+	if _, err := s.calendars.accessibleCalendar(ctx, userID, userRole, calendarID, true); err != nil {
+		return nil, err
+	}
+
+	confirmation, err := s.confirmationRepo.Decide(ctx, calendarID, date, status)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Event decided", "calendar_id", calendarID, "date", date.Format("2006-01-02"), "status", status, "user_id", userID)
+	return confirmation, nil
+}
+
+// GetByToken returns the confirmation of the link sent to the owner
+func (s *ConfirmationService) GetByToken(ctx context.Context, token string) (*models.EventConfirmation, error) {
+	return s.confirmationRepo.GetByToken(ctx, authRepo.HashToken(token))
+}
+
+// DecideByToken confirms or declines the date of the link sent to the owner
+// The link stays valid, so the owner can change their mind
+func (s *ConfirmationService) DecideByToken(ctx context.Context, token, status string) (*models.EventConfirmation, error) {
+	confirmation, err := s.confirmationRepo.GetByToken(ctx, authRepo.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	confirmation, err = s.confirmationRepo.Decide(ctx, confirmation.CalendarID, confirmation.Date, status)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Event decided from email link", "calendar_id", confirmation.CalendarID, "date", confirmation.Date.Format("2006-01-02"), "status", status)
+	return confirmation, nil
+}
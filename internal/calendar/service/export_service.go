@@ -0,0 +1,313 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/i18n"
+	pkgModels "github.com/whento/pkg/models"
+	"github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/calendar/repository"
+)
+
+// MaxImportAvailabilities caps the availabilities and recurrences of an imported participant
+const MaxImportAvailabilities = 5000
+
+// ErrInvalidExport is returned when an import bundle can't be restored
+var ErrInvalidExport = errors.New("invalid calendar export")
+
+// ExportRepository reads and writes whole calendars, for exports and imports
+type ExportRepository interface {
+	GetAvailabilities(ctx context.Context, calendarID uuid.UUID) (map[uuid.UUID][]models.ExportAvailability, error)
+	GetRecurrences(ctx context.Context, calendarID uuid.UUID) (map[uuid.UUID][]models.ExportRecurrence, error)
+	Import(ctx context.Context, calendar *models.Calendar, participants []models.ExportParticipant) ([]models.Participant, error)
+}
+
+// ExportCalendar returns a portable copy of a calendar: settings, participants, availabilities and recurrences
+// Requires the right to manage the calendar, as the bundle includes participant emails
+func (s *CalendarService) ExportCalendar(ctx context.Context, userID, userRole, calendarID string, now time.Time) (*models.CalendarExport, error) {
+	id, err := uuid.Parse(calendarID)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+
+	calendar, err := s.accessibleCalendar(ctx, userID, userRole, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := exportSettings(calendar)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.participantRepo.GetByCalendarID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	availabilities, err := s.exports.GetAvailabilities(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recurrences, err := s.exports.GetRecurrences(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.CalendarExport{
+		Format:       models.ExportFormat,
+		ExportedAt:   now.UTC(),
+		Calendar:     *settings,
+		Participants: make([]models.ExportParticipant, 0, len(participants)),
+	}
+	for _, p := range participants {
+		participant := models.ExportParticipant{
+			Name:           p.Name,
+			Email:          p.Email,
+			Locale:         p.Locale,
+			Required:       p.Required,
+			Availabilities: availabilities[p.ID],
+			Recurrences:    recurrences[p.ID],
+		}
+		if participant.Availabilities == nil {
+			participant.Availabilities = []models.ExportAvailability{}
+		}
+		if participant.Recurrences == nil {
+			participant.Recurrences = []models.ExportRecurrence{}
+		}
+		export.Participants = append(export.Participants, participant)
+	}
+
+	display := s.resolveDisplaySettings(calendar)
+	formatExportTimes(export.Participants, display.TimeFormat)
+	export.Summary = models.ExportSummary{
+		WeekStart:  display.WeekStart.String(),
+		TimeFormat: display.TimeFormat.String(),
+		Weeks:      exportWeeks(export.Participants, display.WeekStart),
+	}
+
+	return export, nil
+}
+
+// formatExportTimes renders the time slots of the availabilities and recurrences in the time format
+func formatExportTimes(participants []models.ExportParticipant, timeFormat pkgModels.TimeFormat) {
+	for _, p := range participants {
+		for i := range p.Availabilities {
+			a := &p.Availabilities[i]
+			a.Time = timeFormat.FormatSlot(a.StartTime, a.EndTime)
+		}
+		for i := range p.Recurrences {
+			r := &p.Recurrences[i]
+			r.Time = timeFormat.FormatSlot(r.StartTime, r.EndTime)
+		}
+	}
+}
+
+// exportWeeks groups the single-day availabilities of the participants by week
+func exportWeeks(participants []models.ExportParticipant, weekStart pkgModels.WeekStart) []models.ExportWeek {
+	weeks := map[string]*models.ExportWeek{}
+	for _, p := range participants {
+		counted := map[string]bool{}
+		for _, a := range p.Availabilities {
+			date, err := time.Parse(time.DateOnly, a.Date)
+			if err != nil {
+				continue
+			}
+			start := weekStart.StartOfWeek(date).Format(time.DateOnly)
+			week, ok := weeks[start]
+			if !ok {
+				week = &models.ExportWeek{Start: start}
+				weeks[start] = week
+			}
+			week.Availabilities++
+			if !counted[start] {
+				counted[start] = true
+				week.Participants++
+			}
+		}
+	}
+
+	result := make([]models.ExportWeek, 0, len(weeks))
+	for _, week := range weeks {
+		result = append(result, *week)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Start < result[j].Start })
+	return result
+}
+
+// ImportCalendar creates a calendar from an export, owned by the user (or the organization)
+// Tokens are generated again, so the links of the exported calendar don't open the imported one
+func (s *CalendarService) ImportCalendar(ctx context.Context, userID string, organizationID *uuid.UUID, export *models.CalendarExport) (*models.CalendarResponse, error) {
+	ownerUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+	if err := validateExport(export); err != nil {
+		return nil, err
+	}
+
+	settings := export.Calendar
+	settings.Participants = nil
+	settings.ParticipantLocale = ""
+	calendar, err := newCalendar(ownerUUID, organizationID, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+
+	participants, err := s.exports.Import(ctx, calendar, export.Participants)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantAlreadyExists) {
+			return nil, fmt.Errorf("%w: duplicate participant name", ErrInvalidExport)
+		}
+		return nil, fmt.Errorf("failed to import calendar: %w", err)
+	}
+	if calendar.ThresholdPercent != nil {
+		calendar.Threshold = models.PercentThreshold(*calendar.ThresholdPercent, len(participants))
+	}
+
+	return buildCalendarResponse(calendar, participants, s.resolveDisplaySettings(calendar))
+}
+
+// exportSettings converts a calendar to the settings of a creation request
+func exportSettings(calendar *models.Calendar) (*models.CreateCalendarRequest, error) {
+	weekdayTimes, holidayMinTime, holidayMaxTime, holidayEveMinTime, holidayEveMaxTime, err := models.ParseAllowedHoursJSON(calendar.AllowedHours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse allowed_hours: %w", err)
+	}
+
+	settings := &models.CreateCalendarRequest{
+		Name:              calendar.Name,
+		Description:       calendar.Description,
+		Threshold:         calendar.Threshold,
+		ThresholdPercent:  calendar.ThresholdPercent,
+		SoftThreshold:     calendar.SoftThreshold,
+		RequireConfirm:    calendar.RequireConfirm,
+		AllowedWeekdays:   calendar.AllowedWeekdays,
+		MinDurationHours:  calendar.MinDurationHours,
+		Timezone:          calendar.Timezone,
+		HolidaysPolicy:    calendar.HolidaysPolicy,
+		AllowHolidayEves:  calendar.AllowHolidayEves,
+		HolidaySets:       calendar.HolidaySets,
+		BlackoutDates:     calendar.BlackoutDates,
+		CustomHolidays:    calendar.CustomHolidays,
+		HolidayCountry:    valueOrEmpty(calendar.HolidayCountry),
+		HolidayRegion:     valueOrEmpty(calendar.HolidayRegion),
+		HolidayCountries:  calendar.HolidayCountries,
+		WeekdayTimes:      weekdayTimes,
+		HolidayMinTime:    holidayMinTime,
+		HolidayMaxTime:    holidayMaxTime,
+		HolidayEveMinTime: holidayEveMinTime,
+		HolidayEveMaxTime: holidayEveMaxTime,
+		NotifyOnThreshold: calendar.NotifyOnThreshold,
+		NotifyConfig:      calendar.NotifyConfig,
+		LockParticipants:  calendar.LockParticipants,
+		WeekStart:         valueOrEmpty(calendar.WeekStart),
+		TimeFormat:        valueOrEmpty(calendar.TimeFormat),
+		DateFormat:        valueOrEmpty(calendar.DateFormat),
+		ReminderMinutes:   calendar.ReminderMinutes,
+		EventTitle:        valueOrEmpty(calendar.EventTitle),
+		EventDescription:  valueOrEmpty(calendar.EventDescription),
+		FeedPastDays:      calendar.FeedPastDays,
+		FeedFutureDays:    calendar.FeedFutureDays,
+		CountMaybe:        calendar.CountMaybe,
+		MaxParticipants:   calendar.MaxParticipants,
+		CapacityPolicy:    calendar.CapacityPolicy,
+	}
+	if calendar.StartDate != nil {
+		settings.StartDate = calendar.StartDate.Format("2006-01-02")
+	}
+	if calendar.EndDate != nil {
+		settings.EndDate = calendar.EndDate.Format("2006-01-02")
+	}
+	return settings, nil
+}
+
+// validateExport checks the participants and availabilities of an import bundle
+// The calendar settings are validated like a creation request by the handler
+func validateExport(export *models.CalendarExport) error {
+	if export.Format != models.ExportFormat {
+		return fmt.Errorf("%w: unsupported format %d, expected %d", ErrInvalidExport, export.Format, models.ExportFormat)
+	}
+
+	names := make(map[string]bool, len(export.Participants))
+	for i := range export.Participants {
+		p := &export.Participants[i]
+		if p.Name == "" || utf8.RuneCountInString(p.Name) > 100 {
+			return fmt.Errorf("%w: participant names must be 1 to 100 characters", ErrInvalidExport)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("%w: duplicate participant %q", ErrInvalidExport, p.Name)
+		}
+		names[p.Name] = true
+		if !i18n.IsSupported(p.Locale) {
+			p.Locale = i18n.DefaultLocale
+		}
+		if len(p.Availabilities)+len(p.Recurrences) > MaxImportAvailabilities {
+			return fmt.Errorf("%w: participant %q has more than %d availabilities", ErrInvalidExport, p.Name, MaxImportAvailabilities)
+		}
+
+		dates := make(map[string]bool, len(p.Availabilities))
+		for _, a := range p.Availabilities {
+			if !validDate(a.Date) || !validTime(a.StartTime) || !validTime(a.EndTime) {
+				return fmt.Errorf("%w: invalid availability of %q on %q", ErrInvalidExport, p.Name, a.Date)
+			}
+			if dates[a.Date] {
+				return fmt.Errorf("%w: duplicate availability of %q on %s", ErrInvalidExport, p.Name, a.Date)
+			}
+			dates[a.Date] = true
+		}
+
+		for _, r := range p.Recurrences {
+			valid := validRecurrencePattern(r) && validDate(r.StartDate) &&
+				(r.EndDate == nil || validDate(*r.EndDate)) && validTime(r.StartTime) && validTime(r.EndTime)
+			for _, date := range r.Exceptions {
+				valid = valid && validDate(date)
+			}
+			if !valid {
+				return fmt.Errorf("%w: invalid recurrence of %q", ErrInvalidExport, p.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validRecurrencePattern reports whether an exported recurrence sets the days of its frequency
+// Bundles exported before monthly recurrences have no frequency: they are weekly
+func validRecurrencePattern(r models.ExportRecurrence) bool {
+	validDayOfWeek := r.DayOfWeek != nil && *r.DayOfWeek >= 0 && *r.DayOfWeek <= 6
+	switch r.Frequency {
+	case "", "weekly":
+		return validDayOfWeek && r.IntervalWeeks >= 0 && r.IntervalWeeks <= 52
+	case "monthly_day":
+		return r.DayOfMonth != nil && *r.DayOfMonth >= 1 && *r.DayOfMonth <= 31
+	case "monthly_weekday":
+		return validDayOfWeek && r.WeekOfMonth != nil && *r.WeekOfMonth >= -1 && *r.WeekOfMonth != 0 && *r.WeekOfMonth <= 5
+	default:
+		return false
+	}
+}
+
+// validDate reports whether a date is formatted as YYYY-MM-DD
+func validDate(date string) bool {
+	_, err := time.Parse("2006-01-02", date)
+	return err == nil
+}
+
+// validTime reports whether an optional time is formatted as HH:MM
+func validTime(clock *string) bool {
+	if clock == nil {
+		return true
+	}
+	_, err := time.Parse("15:04", *clock)
+	return err == nil
+}
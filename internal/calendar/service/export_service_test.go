@@ -0,0 +1,188 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pkgModels "github.com/whento/pkg/models"
+	"github.com/whento/whento/internal/calendar/models"
+)
+
+func strPtr(s string) *string { return &s }
+
+func intPtr(i int) *int { return &i }
+
+func TestValidateExport(t *testing.T) {
+	valid := func() *models.CalendarExport {
+		return &models.CalendarExport{
+			Format: models.ExportFormat,
+			Participants: []models.ExportParticipant{
+				{
+					Name:   "Alice",
+					Locale: "pt",
+					Availabilities: []models.ExportAvailability{
+						{Date: "2025-07-04", StartTime: strPtr("18:00"), EndTime: strPtr("22:00")},
+					},
+					Recurrences: []models.ExportRecurrence{
+						{DayOfWeek: intPtr(5), StartDate: "2025-07-01", Exceptions: []string{"2025-07-11"}},
+						{Frequency: "monthly_weekday", DayOfWeek: intPtr(6), WeekOfMonth: intPtr(-1), StartDate: "2025-07-01"},
+					},
+				},
+				{Name: "Bob", Locale: "de"},
+			},
+		}
+	}
+
+	export := valid()
+	if err := validateExport(export); err != nil {
+		t.Fatalf("validateExport() error = %v", err)
+	}
+	if export.Participants[0].Locale != "en" || export.Participants[1].Locale != "de" {
+		t.Errorf("locales = %q, %q, want en, de", export.Participants[0].Locale, export.Participants[1].Locale)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(e *models.CalendarExport)
+	}{
+		{"unknown format", func(e *models.CalendarExport) { e.Format = 2 }},
+		{"empty name", func(e *models.CalendarExport) { e.Participants[1].Name = "" }},
+		{"duplicate name", func(e *models.CalendarExport) { e.Participants[1].Name = "Alice" }},
+		{"invalid date", func(e *models.CalendarExport) { e.Participants[0].Availabilities[0].Date = "04/07/2025" }},
+		{"invalid time", func(e *models.CalendarExport) { e.Participants[0].Availabilities[0].EndTime = strPtr("25:00") }},
+		{"duplicate date", func(e *models.CalendarExport) {
+			e.Participants[0].Availabilities = append(e.Participants[0].Availabilities, models.ExportAvailability{Date: "2025-07-04"})
+		}},
+		{"invalid weekday", func(e *models.CalendarExport) { e.Participants[0].Recurrences[0].DayOfWeek = intPtr(7) }},
+		{"unknown frequency", func(e *models.CalendarExport) { e.Participants[0].Recurrences[0].Frequency = "yearly" }},
+		{"monthly without day", func(e *models.CalendarExport) { e.Participants[0].Recurrences[0].Frequency = "monthly_day" }},
+		{"invalid week of month", func(e *models.CalendarExport) { e.Participants[0].Recurrences[1].WeekOfMonth = intPtr(0) }},
+		{"invalid exception", func(e *models.CalendarExport) { e.Participants[0].Recurrences[0].Exceptions = []string{"soon"} }},
+		{"too many availabilities", func(e *models.CalendarExport) {
+			e.Participants[1].Availabilities = make([]models.ExportAvailability, MaxImportAvailabilities+1)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export := valid()
+			tt.modify(export)
+			if err := validateExport(export); !errors.Is(err, ErrInvalidExport) {
+				t.Errorf("validateExport() error = %v, want ErrInvalidExport", err)
+			}
+		})
+	}
+}
+
+func TestExportSettings(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	calendar := &models.Calendar{
+		Name:         "Summer",
+		Threshold:    3,
+		Timezone:     "Europe/Brussels",
+		StartDate:    &start,
+		TimeFormat:   strPtr("24h"),
+		AllowedHours: strPtr(`{"weekdays":{},"holidays":{"start":"10:00","end":""},"holiday_eves":{"start":"","end":""}}`),
+	}
+
+	settings, err := exportSettings(calendar)
+	if err != nil {
+		t.Fatalf("exportSettings() error = %v", err)
+	}
+	if settings.Name != "Summer" || settings.Threshold != 3 || settings.Timezone != "Europe/Brussels" {
+		t.Errorf("settings = %+v", settings)
+	}
+	if settings.StartDate != "2025-07-01" || settings.EndDate != "" {
+		t.Errorf("dates = %q, %q, want 2025-07-01 and empty", settings.StartDate, settings.EndDate)
+	}
+	if settings.TimeFormat != "24h" || settings.DateFormat != "" {
+		t.Errorf("formats = %q, %q, want 24h and empty", settings.TimeFormat, settings.DateFormat)
+	}
+	if settings.HolidayMinTime != "10:00" {
+		t.Errorf("HolidayMinTime = %q, want 10:00", settings.HolidayMinTime)
+	}
+}
+
+func TestExportWeeks(t *testing.T) {
+	participants := []models.ExportParticipant{
+		{Name: "Alice", Availabilities: []models.ExportAvailability{
+			{Date: "2025-07-05"}, // Saturday
+			{Date: "2025-07-06"}, // Sunday
+			{Date: "2025-07-07"}, // Monday
+		}},
+		{Name: "Bob", Availabilities: []models.ExportAvailability{
+			{Date: "2025-07-06"},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		weekStart pkgModels.WeekStart
+		want      []models.ExportWeek
+	}{
+		{"monday", pkgModels.WeekStartMonday, []models.ExportWeek{
+			{Start: "2025-06-30", Availabilities: 3, Participants: 2},
+			{Start: "2025-07-07", Availabilities: 1, Participants: 1},
+		}},
+		{"sunday", pkgModels.WeekStartSunday, []models.ExportWeek{
+			{Start: "2025-06-29", Availabilities: 1, Participants: 1},
+			{Start: "2025-07-06", Availabilities: 3, Participants: 2},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weeks := exportWeeks(participants, tt.weekStart)
+			if len(weeks) != len(tt.want) {
+				t.Fatalf("weeks = %+v, want %+v", weeks, tt.want)
+			}
+			for i, week := range tt.want {
+				if weeks[i] != week {
+					t.Errorf("weeks[%d] = %+v, want %+v", i, weeks[i], week)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatExportTimes(t *testing.T) {
+	tests := []struct {
+		timeFormat       pkgModels.TimeFormat
+		wantAvailability string
+		wantRecurrence   string
+	}{
+		{pkgModels.TimeFormat24h, "18:00 – 22:30", "09:00 –"},
+		{pkgModels.TimeFormat12h, "6:00 PM – 10:30 PM", "9:00 AM –"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeFormat.String(), func(t *testing.T) {
+			participants := []models.ExportParticipant{{
+				Name: "Alice",
+				Availabilities: []models.ExportAvailability{
+					{Date: "2025-07-04", StartTime: strPtr("18:00"), EndTime: strPtr("22:30")},
+					{Date: "2025-07-05"},
+				},
+				Recurrences: []models.ExportRecurrence{{DayOfWeek: intPtr(5), StartTime: strPtr("09:00"), StartDate: "2025-07-01"}},
+			}}
+
+			formatExportTimes(participants, tt.timeFormat)
+
+			p := participants[0]
+			if p.Availabilities[0].Time != tt.wantAvailability || p.Availabilities[1].Time != "" {
+				t.Errorf("availability times = %q, %q, want %q and empty", p.Availabilities[0].Time, p.Availabilities[1].Time, tt.wantAvailability)
+			}
+			if p.Recurrences[0].Time != tt.wantRecurrence {
+				t.Errorf("recurrence time = %q, want %q", p.Recurrences[0].Time, tt.wantRecurrence)
+			}
+			// Machine-readable times stay in HH:MM for the import
+			if *p.Availabilities[0].StartTime != "18:00" {
+				t.Errorf("start time = %q, want 18:00", *p.Availabilities[0].StartTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/cache"
+	"github.com/whento/pkg/i18n"
+	"github.com/whento/pkg/logger"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/calendar/repository"
+	webhookModels "github.com/whento/whento/internal/webhooks/models"
+)
+
+var (
+	ErrInvalidCSV     = errors.New("invalid CSV file")
+	ErrCSVTooManyRows = fmt.Errorf("a CSV import is limited to %d participants", models.MaxCSVImportRows)
+)
+
+// ParticipantEmailSender records the email of a participant and sends them a verification email
+type ParticipantEmailSender interface {
+	AddEmail(ctx context.Context, participantID uuid.UUID, email, name, locale string) error
+}
+
+// SetParticipantEmails lets CSV imports invite the imported participants by email
+func (s *CalendarService) SetParticipantEmails(sender ParticipantEmailSender) {
+	s.participantEmails = sender
+}
+
+// ImportParticipantsCSV adds the participants of a CSV file (name, email, locale) to a calendar
+// Rows whose name or email is already in the calendar, or earlier in the file, are reported as duplicates and skipped.
+// With sendInvites, participants with an email receive a verification email, which doubles as their invitation.
+func (s *CalendarService) ImportParticipantsCSV(ctx context.Context, userID, userRole, calendarID string, data io.Reader, sendInvites bool) (*models.CSVImportResponse, error) {
+	id, err := uuid.Parse(calendarID)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+
+	calendar, err := s.accessibleCalendar(ctx, userID, userRole, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ParseParticipantsCSV(data)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.participantRepo.GetByCalendarID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(existing)+len(rows))
+	emails := make(map[string]bool, len(existing)+len(rows))
+	for _, participant := range existing {
+		names[participant.Name] = true
+		if participant.Email != nil {
+			emails[strings.ToLower(*participant.Email)] = true
+		}
+	}
+
+	defaultLocale := "en"
+	if s.userRepo != nil {
+		if owner, err := s.userRepo.GetByID(ctx, calendar.OwnerID); err == nil && owner.Locale != "" {
+			defaultLocale = owner.Locale
+		}
+	}
+
+	response := &models.CSVImportResponse{Results: make([]models.CSVImportResult, 0, len(rows))}
+	for _, row := range rows {
+		result := models.CSVImportResult{Line: row.Line, Name: row.Name, Email: row.Email}
+
+		if err := validateCSVRow(row); err != nil {
+			result.Status = models.CSVImportStatusInvalid
+			result.Error = err.Error()
+			response.Invalid++
+			response.Results = append(response.Results, result)
+			continue
+		}
+
+		email := strings.ToLower(row.Email)
+		if names[row.Name] || (email != "" && emails[email]) {
+			result.Status = models.CSVImportStatusDuplicate
+			response.Duplicates++
+			response.Results = append(response.Results, result)
+			continue
+		}
+
+		locale := row.Locale
+		if locale == "" {
+			locale = defaultLocale
+		}
+		participant := &models.Participant{
+			CalendarID: id,
+			Name:       row.Name,
+			Locale:     locale,
+		}
+		participant.ID = uuid.New()
+		if row.Email != "" {
+			participant.Email = &row.Email
+		}
+
+		if err := s.participantRepo.Create(ctx, participant); err != nil {
+			if !errors.Is(err, repository.ErrParticipantAlreadyExists) {
+				return nil, err
+			}
+			result.Status = models.CSVImportStatusDuplicate
+			response.Duplicates++
+			response.Results = append(response.Results, result)
+			continue
+		}
+
+		names[row.Name] = true
+		if email != "" {
+			emails[email] = true
+		}
+		result.Status = models.CSVImportStatusAdded
+		result.ParticipantID = &participant.ID
+		response.Added++
+
+		if sendInvites && row.Email != "" && s.participantEmails != nil {
+			if err := s.participantEmails.AddEmail(ctx, participant.ID, row.Email, participant.Name, locale); err != nil {
+				logger.FromContext(ctx).Error("Failed to invite imported participant", "error", err, "participant_id", participant.ID)
+			} else {
+				result.InviteSent = true
+			}
+		}
+
+		if s.webhooks != nil {
+			s.webhooks.Dispatch(ctx, calendar.ID, webhookModels.EventParticipantAdded, webhookModels.ParticipantEventData{
+				ParticipantID:   participant.ID,
+				ParticipantName: participant.Name,
+			})
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	if response.Added > 0 {
+		// Invalidate the public calendar cache since participants list changed
+		_ = s.cache.Delete(ctx, cache.CalendarByPublicTokenKey(calendar.PublicToken))
+	}
+
+	return response, nil
+}
+
+// ParseParticipantsCSV reads the participants of a CSV file
+// Columns are name, email and locale, in this order unless the first line is a header naming them (in any order).
+// Commas and semicolons (spreadsheet exports) are both accepted as separators; blank lines are ignored.
+func ParseParticipantsCSV(data io.Reader) ([]models.CSVParticipantRow, error) {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	columns := map[string]int{"name": 0, "email": 1, "locale": 2}
+	var rows []models.CSVParticipantRow
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first && isCSVHeader(record) {
+			columns = map[string]int{}
+			for i, column := range record {
+				columns[strings.ToLower(strings.TrimSpace(column))] = i
+			}
+			continue
+		}
+
+		if len(rows) == models.MaxCSVImportRows {
+			return nil, ErrCSVTooManyRows
+		}
+		rows = append(rows, models.CSVParticipantRow{
+			Line:   line,
+			Name:   csvField(record, columns, "name"),
+			Email:  csvField(record, columns, "email"),
+			Locale: strings.ToLower(csvField(record, columns, "locale")),
+		})
+	}
+
+	return rows, nil
+}
+
+// isCSVHeader reports whether a record names the columns rather than a participant
+func isCSVHeader(record []string) bool {
+	for _, field := range record {
+		if strings.EqualFold(strings.TrimSpace(field), "name") {
+			return true
+		}
+	}
+	return false
+}
+
+// csvField returns the trimmed value of a column of a CSV record, empty if the column is missing
+func csvField(record []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// validateCSVRow checks a participant of a CSV import like the participant requests
+func validateCSVRow(row models.CSVParticipantRow) error {
+	if row.Name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(row.Name) > 100 {
+		return errors.New("name exceeds 100 characters")
+	}
+	if row.Email != "" && validator.ValidateVar(row.Email, "email,max=255") != nil {
+		return errors.New("invalid email address")
+	}
+	if row.Locale != "" && !i18n.IsSupported(row.Locale) {
+		return errors.New("locale must be one of " + strings.Join(i18n.Locales, ", "))
+	}
+	return nil
+}
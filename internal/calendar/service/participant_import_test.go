@@ -0,0 +1,75 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/whento/whento/internal/calendar/models"
+)
+
+func TestParseParticipantsCSV(t *testing.T) {
+	t.Run("positional columns", func(t *testing.T) {
+		rows, err := ParseParticipantsCSV(strings.NewReader("Alice, alice@example.com, FR\n\nBob\n"))
+		if err != nil {
+			t.Fatalf("ParseParticipantsCSV: %v", err)
+		}
+		want := []models.CSVParticipantRow{
+			{Line: 1, Name: "Alice", Email: "alice@example.com", Locale: "fr"},
+			{Line: 3, Name: "Bob"},
+		}
+		if len(rows) != len(want) || rows[0] != want[0] || rows[1] != want[1] {
+			t.Errorf("rows = %+v, want %+v", rows, want)
+		}
+	})
+
+	t.Run("header with semicolons", func(t *testing.T) {
+		rows, err := ParseParticipantsCSV(strings.NewReader("\ufeffEmail;Name\ncarol@example.com;Carol\n"))
+		if err != nil {
+			t.Fatalf("ParseParticipantsCSV: %v", err)
+		}
+		if len(rows) != 1 || rows[0].Name != "Carol" || rows[0].Email != "carol@example.com" || rows[0].Line != 2 {
+			t.Errorf("rows = %+v, want Carol on line 2", rows)
+		}
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		data := strings.Repeat("someone\n", models.MaxCSVImportRows+1)
+		if _, err := ParseParticipantsCSV(strings.NewReader(data)); !errors.Is(err, ErrCSVTooManyRows) {
+			t.Errorf("err = %v, want ErrCSVTooManyRows", err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		if _, err := ParseParticipantsCSV(strings.NewReader("\"Alice,alice@example.com\n")); !errors.Is(err, ErrInvalidCSV) {
+			t.Errorf("err = %v, want ErrInvalidCSV", err)
+		}
+	})
+}
+
+func TestValidateCSVRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     models.CSVParticipantRow
+		wantErr bool
+	}{
+		{"valid", models.CSVParticipantRow{Name: "Alice", Email: "alice@example.com", Locale: "en"}, false},
+		{"name only", models.CSVParticipantRow{Name: "Alice"}, false},
+		{"missing name", models.CSVParticipantRow{Email: "alice@example.com"}, true},
+		{"long name", models.CSVParticipantRow{Name: strings.Repeat("a", 101)}, true},
+		{"invalid email", models.CSVParticipantRow{Name: "Alice", Email: "alice"}, true},
+		{"german", models.CSVParticipantRow{Name: "Alice", Locale: "de"}, false},
+		{"unknown locale", models.CSVParticipantRow{Name: "Alice", Locale: "pt"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateCSVRow(tt.row); (err != nil) != tt.wantErr {
+				t.Errorf("validateCSVRow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFile writes a configuration file in a temporary directory and points CONFIG_FILE to it
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(FileEnv, path)
+	return path
+}
+
+// problemsText joins the problems of a configuration, for substring checks
+func problemsText(cfg *Config) string {
+	var lines []string
+	for _, problem := range cfg.Problems() {
+		lines = append(lines, problem.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestLoad_File(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"yaml flat", "whento.yaml", "app_url: https://when.example.com\nsmtp_port: 465\ncors_allowed_origins: [https://a.example.com, https://b.example.com]\n"},
+		{"yaml nested", "whento.yml", "app:\n  url: https://when.example.com\nsmtp:\n  port: 465\ncors:\n  allowed-origins:\n    - https://a.example.com\n    - https://b.example.com\n"},
+		{"toml", "whento.toml", "APP_URL = \"https://when.example.com\"\n\n[smtp]\nport = 465\n\n[cors]\nallowed_origins = [\"https://a.example.com\", \"https://b.example.com\"]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"APP_URL", "SMTP_PORT", "CORS_ALLOWED_ORIGINS"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			writeFile(t, tt.file, tt.content)
+
+			cfg := Load()
+			if cfg.AppURL != "https://when.example.com" || cfg.Email.SMTPPort != 465 {
+				t.Errorf("AppURL = %q, SMTPPort = %d", cfg.AppURL, cfg.Email.SMTPPort)
+			}
+			if got := strings.Join(cfg.CORS.AllowedOrigins, ","); got != "https://a.example.com,https://b.example.com" {
+				t.Errorf("CORS origins = %q", got)
+			}
+			if problems := problemsText(cfg); problems != "" {
+				t.Errorf("unexpected problems:\n%s", problems)
+			}
+		})
+	}
+}
+
+func TestLoad_EnvironmentOverridesFile(t *testing.T) {
+	t.Setenv("APP_URL", "https://env.example.com")
+	writeFile(t, "whento.yaml", "app_url: https://file.example.com\n")
+
+	if cfg := Load(); cfg.AppURL != "https://env.example.com" {
+		t.Errorf("AppURL = %q, want the environment variable", cfg.AppURL)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown setting", "app_url: https://when.example.com\nsmtp_prot: 587\n", "unknown settings SMTP_PROT"},
+		{"invalid integer", "smtp_port: twenty\n", `SMTP_PORT: "twenty" is not an integer`},
+		{"invalid port", "smtp_host: mail.example.com\nsmtp_port: 70000\n", "SMTP_PORT: 70000 is not a port"},
+		{"unknown email provider", "email_provider: sparkpost\n", `EMAIL_PROVIDER: unknown email provider "sparkpost"`},
+		{"oauth2 without mailbox", "smtp_oauth2_token_url: https://oauth2.googleapis.com/token\nsmtp_oauth2_client_id: client\n", "SMTP_USERNAME: OAuth2 needs the SMTP username"},
+		{"email provider without key", "email_provider: sendgrid\n", "EMAIL_PROVIDER: sendgrid: API key not configured"},
+		{"malformed app url", "app_url: when.example.com\n", "APP_URL"},
+		{"invalid boolean", "rate_limit_enabled: maybe\n", "RATE_LIMIT_ENABLED"},
+		{"key path is a directory", "jwt_private_key_path: " + os.TempDir() + "\n", "is a directory"},
+		{"missing email template dir", "email_template_dir: /nonexistent/whento/templates\n", "EMAIL_TEMPLATE_DIR"},
+		{"invalid yaml", "app_url: [\n", "whento.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"APP_URL", "SMTP_HOST", "SMTP_PORT", "EMAIL_PROVIDER", "EMAIL_API_KEY", "SMTP_USERNAME", "SMTP_OAUTH2_TOKEN_URL", "SMTP_OAUTH2_CLIENT_ID", "RATE_LIMIT_ENABLED", "JWT_PRIVATE_KEY_PATH", "EMAIL_TEMPLATE_DIR"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			writeFile(t, "whento.yaml", tt.content)
+
+			cfg := Load()
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_UnsupportedExtension(t *testing.T) {
+	writeFile(t, "whento.json", "{}")
+
+	if err := Load().Validate(); err == nil || !strings.Contains(err.Error(), "unsupported extension") {
+		t.Errorf("Validate() = %v, want an unsupported extension error", err)
+	}
+}
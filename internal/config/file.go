@@ -0,0 +1,125 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+)
+
+// FileEnv is the environment variable holding the path of the configuration file
+const FileEnv = "CONFIG_FILE"
+
+// readFile reads a YAML (.yaml, .yml) or TOML (.toml) configuration file into environment variable names and values
+// Keys are the names of the environment variables, in any case, and may be nested:
+// "smtp: {port: 587}" is the same as "SMTP_PORT: 587". Lists are joined with commas.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var tree map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("configuration file %s: unsupported extension %q, expected .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("configuration file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flatten(values, "", tree); err != nil {
+		return nil, fmt.Errorf("configuration file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// flatten adds the values of a configuration tree under their environment variable names
+func flatten(values map[string]string, prefix string, tree map[string]any) error {
+	for key, value := range tree {
+		name := strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			if err := flatten(values, name, v); err != nil {
+				return err
+			}
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				if _, nested := item.(map[string]any); nested {
+					return fmt.Errorf("%s: lists can only hold values", name)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			values[name] = strings.Join(items, ",")
+		case nil:
+			values[name] = ""
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// applyFile sets the variables of the .env and configuration files that are not set by the process,
+// so that environment variables take precedence over them
+// Variables set by a previous load follow the files, so that a reload sees their changes
+func applyFile(values map[string]string) {
+	for name := range fileEnv {
+		if _, ok := values[name]; !ok {
+			if !setByProcess(name) {
+				_ = os.Unsetenv(name)
+			}
+			delete(fileEnv, name)
+		}
+	}
+	for name, value := range values {
+		if !setByProcess(name) {
+			_ = os.Setenv(name, value)
+			fileEnv[name] = value
+		}
+	}
+}
+
+// fileEnv holds the variables set from the .env and configuration files, with the value set
+var fileEnv = make(map[string]string)
+
+// setByProcess reports whether a variable comes from the process environment rather than a file
+func setByProcess(name string) bool {
+	value, set := os.LookupEnv(name)
+	if !set {
+		return false
+	}
+	fileValue, fromFile := fileEnv[name]
+	return !fromFile || fileValue != value
+}
+
+// unknownKeys returns the variables of the configuration file that are not read by Load, sorted
+// OTEL_* variables are read by the OpenTelemetry SDK
+func unknownKeys(values map[string]string, read map[string]bool) []string {
+	var unknown []string
+	for name := range values {
+		if !read[name] && !strings.HasPrefix(name, "OTEL_") {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
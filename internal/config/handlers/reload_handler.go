@@ -0,0 +1,60 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/whento/pkg/httputil"
+)
+
+// Reloader reloads the configuration
+type Reloader interface {
+	Reload() ([]string, error)
+}
+
+// ReloadResponse lists the reloadable settings that changed
+type ReloadResponse struct {
+	Changed []string `json:"changed"`
+}
+
+// ReloadHandler handles HTTP requests for configuration reloads
+type ReloadHandler struct {
+	reloader Reloader
+	logger   *slog.Logger
+}
+
+// NewReloadHandler creates a new reload handler
+func NewReloadHandler(reloader Reloader, logger *slog.Logger) *ReloadHandler {
+	return &ReloadHandler{
+		reloader: reloader,
+		logger:   logger,
+	}
+}
+
+// @Summary		Reload configuration
+// @Description	Reads the environment, .env and configuration files again, like SIGHUP, and applies the SMTP settings, rate limits (RATE_LIMIT_API_REQUESTS, RATE_LIMIT_PUBLIC_REQUESTS), allowed registration emails and default time and date formats. Requests in flight keep the previous settings. An invalid configuration is rejected and the current settings are kept. Admin only.
+// @Tags			Admin
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	ReloadResponse
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Failure		422	{object}	httputil.ErrorResponse	"Invalid configuration"
+// @Router			/api/v1/auth/admin/config/reload [post]
+func (h *ReloadHandler) Reload(w http.ResponseWriter, r *http.Request) {
+	changed, err := h.reloader.Reload()
+	if err != nil {
+		httputil.Error(w, http.StatusUnprocessableEntity, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if changed == nil {
+		changed = []string{}
+	}
+	httputil.JSON(w, http.StatusOK, ReloadResponse{Changed: changed})
+}
@@ -0,0 +1,91 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// reloadable lists the settings applied by Reloader without a restart
+// Other settings (ports, database, keys, routes enabled by RATE_LIMIT_ENABLED...) still need one
+var reloadable = []struct {
+	key   string
+	value func(c *Config) string
+}{
+	{"SMTP_HOST", func(c *Config) string { return c.Email.SMTPHost }},
+	{"SMTP_PORT", func(c *Config) string { return fmt.Sprint(c.Email.SMTPPort) }},
+	{"SMTP_USERNAME", func(c *Config) string { return c.Email.SMTPUsername }},
+	{"SMTP_PASSWORD", func(c *Config) string { return c.Email.SMTPPassword }},
+	{"EMAIL_FROM_ADDRESS", func(c *Config) string { return c.Email.FromAddress }},
+	{"EMAIL_FROM_NAME", func(c *Config) string { return c.Email.FromName }},
+	{"RATE_LIMIT_API_REQUESTS", func(c *Config) string { return fmt.Sprint(c.RateLimitAPIRequests) }},
+	{"RATE_LIMIT_PUBLIC_REQUESTS", func(c *Config) string { return fmt.Sprint(c.RateLimitPublicRequests) }},
+	{"ALLOWED_EMAILS", func(c *Config) string { return fmt.Sprint(c.AllowedEmails) }},
+	{"TIME_FORMAT", func(c *Config) string { return c.TimeFormat }},
+	{"DATE_FORMAT", func(c *Config) string { return c.DateFormat }},
+}
+
+// ChangedSettings returns the reloadable settings that differ between two configurations
+func ChangedSettings(previous, next *Config) []string {
+	var changed []string
+	for _, setting := range reloadable {
+		if setting.value(previous) != setting.value(next) {
+			changed = append(changed, setting.key)
+		}
+	}
+	return changed
+}
+
+// Reloader reloads the configuration (on SIGHUP or from the admin API) and hands the reloadable
+// settings to the services, which swap them for the next requests
+type Reloader struct {
+	mu       sync.RWMutex
+	current  *Config
+	appliers []func(cfg *Config)
+	logger   *slog.Logger
+}
+
+// NewReloader creates a reloader starting from the configuration loaded at startup
+func NewReloader(cfg *Config, logger *slog.Logger) *Reloader {
+	return &Reloader{current: cfg, logger: logger}
+}
+
+// OnReload registers a function applying the settings of a reloaded configuration
+func (r *Reloader) OnReload(apply func(cfg *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.appliers = append(r.appliers, apply)
+}
+
+// Current returns the configuration of the last successful load
+func (r *Reloader) Current() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Reload loads the configuration again and applies it, returning the reloadable settings that changed
+// An invalid configuration is rejected as a whole, the current settings are kept
+func (r *Reloader) Reload() ([]string, error) {
+	cfg := Load()
+	if err := cfg.Validate(); err != nil {
+		r.logger.Error("Configuration reload rejected", "error", err)
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := ChangedSettings(r.current, cfg)
+	for _, apply := range r.appliers {
+		apply(cfg)
+	}
+	r.current = cfg
+
+	r.logger.Info("Configuration reloaded", "changed", changed)
+	return changed, nil
+}
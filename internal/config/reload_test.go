@@ -0,0 +1,68 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package config
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestReloader(t *testing.T) {
+	for _, key := range []string{"SMTP_HOST", "SMTP_USERNAME", "RATE_LIMIT_API_REQUESTS", "TIME_FORMAT"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	t.Setenv("SMTP_USERNAME", "env")
+	path := writeFile(t, "whento.yaml", "smtp_host: a.example.com\nsmtp_username: file\n")
+	rewrite := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reloader := NewReloader(Load(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var applied *Config
+	reloader.OnReload(func(cfg *Config) { applied = cfg })
+
+	rewrite("smtp_host: b.example.com\nsmtp_username: file\nrate_limit_api_requests: 200\n")
+	changed, err := reloader.Reload()
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if want := []string{"SMTP_HOST", "RATE_LIMIT_API_REQUESTS"}; !slices.Equal(changed, want) {
+		t.Errorf("changed = %v, want %v", changed, want)
+	}
+	if applied == nil || applied != reloader.Current() {
+		t.Fatal("reloaded configuration not applied")
+	}
+	if applied.Email.SMTPHost != "b.example.com" || applied.RateLimitAPIRequests != 200 {
+		t.Errorf("SMTPHost = %q, RateLimitAPIRequests = %d", applied.Email.SMTPHost, applied.RateLimitAPIRequests)
+	}
+	if applied.Email.SMTPUsername != "env" {
+		t.Errorf("SMTPUsername = %q, want the environment variable", applied.Email.SMTPUsername)
+	}
+
+	// An invalid configuration keeps the current settings
+	rewrite("smtp_host: c.example.com\nrate_limit_api_requests: 0\n")
+	if _, err := reloader.Reload(); err == nil {
+		t.Error("Reload() accepted RATE_LIMIT_API_REQUESTS = 0")
+	}
+	if reloader.Current() != applied {
+		t.Error("invalid configuration replaced the current one")
+	}
+
+	// Settings removed from the file fall back to their defaults
+	rewrite("time_format: 12h\n")
+	if _, err := reloader.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if cfg := reloader.Current(); cfg.Email.SMTPHost != "" || cfg.RateLimitAPIRequests != 100 || cfg.TimeFormat != "12h" {
+		t.Errorf("SMTPHost = %q, RateLimitAPIRequests = %d, TimeFormat = %q", cfg.Email.SMTPHost, cfg.RateLimitAPIRequests, cfg.TimeFormat)
+	}
+}
@@ -0,0 +1,142 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/whento/pkg/middleware"
+)
+
+// Validate checks the configuration, so that the server refuses to start rather than failing at runtime
+// It returns every problem found, joined, or nil when the configuration is valid
+func (c *Config) Validate() error {
+	return errors.Join(c.Problems()...)
+}
+
+// Problems lists the errors of the configuration file, the values that couldn't be parsed,
+// and the settings that are malformed or inconsistent
+func (c *Config) Problems() []error {
+	problems := append([]error(nil), c.problems...)
+	add := func(err error) {
+		if err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	add(validatePort("PORT", c.Port))
+	if c.GRPCPort != "" {
+		add(validatePort("GRPC_PORT", c.GRPCPort))
+	}
+	add(validateURL("APP_URL", c.AppURL))
+	if err := c.Email.SMTP().Validate(); err != nil {
+		add(fmt.Errorf("EMAIL_PROVIDER: %w", err))
+	}
+	if c.Email.APIEndpoint != "" {
+		add(validateURL("EMAIL_API_ENDPOINT", c.Email.APIEndpoint))
+	}
+	if oauth := c.Email.SMTPOAuth2; oauth.Enabled() {
+		if err := oauth.Validate(c.Email.SMTPUsername); err != nil {
+			add(fmt.Errorf("SMTP_OAUTH2_TOKEN_URL, SMTP_OAUTH2_CLIENT_ID, SMTP_USERNAME: %w", err))
+		} else {
+			add(validateURL("SMTP_OAUTH2_TOKEN_URL", oauth.TokenURL))
+		}
+	}
+	if c.Email.SMTPHost != "" && (c.Email.SMTPPort < 1 || c.Email.SMTPPort > 65535) {
+		add(fmt.Errorf("SMTP_PORT: %d is not a port (1 to 65535, usually 587 or 465)", c.Email.SMTPPort))
+	}
+	if c.EmailTemplateDir != "" {
+		add(validateDir("EMAIL_TEMPLATE_DIR", c.EmailTemplateDir))
+	}
+	add(validateKeyPath("JWT_PRIVATE_KEY_PATH", c.JWTPrivateKeyPath))
+	add(validateKeyPath("JWT_PUBLIC_KEY_PATH", c.JWTPublicKeyPath))
+	if c.JWTAccessExpiry <= 0 || c.JWTRefreshExpiry <= 0 {
+		add(fmt.Errorf("JWT_ACCESS_EXPIRY and JWT_REFRESH_EXPIRY must be positive durations"))
+	}
+
+	if c.RateLimitAPIRequests < 1 || c.RateLimitPublicRequests < 1 {
+		add(fmt.Errorf("RATE_LIMIT_API_REQUESTS and RATE_LIMIT_PUBLIC_REQUESTS must be positive"))
+	}
+	if c.AnnouncementEmailsPerMinute < 1 {
+		add(fmt.Errorf("ANNOUNCEMENT_EMAILS_PER_MINUTE must be positive"))
+	}
+
+	cors := middleware.CORSConfig{
+		AllowedOrigins:   c.CORS.AllowedOrigins,
+		AllowedMethods:   c.CORS.AllowedMethods,
+		AllowCredentials: c.CORS.AllowCredentials,
+	}
+	if err := cors.Validate(); err != nil {
+		add(fmt.Errorf("CORS_ALLOWED_ORIGINS, CORS_ALLOWED_METHODS: %w", err))
+	}
+
+	return problems
+}
+
+// validatePort checks that a port is a number between 1 and 65535
+func validatePort(key, value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("%s: %q is not a port (1 to 65535)", key, value)
+	}
+	return nil
+}
+
+// validateURL checks that a URL is absolute, with an http(s) scheme and a host
+func validateURL(key, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s: %q is not an absolute http(s) URL such as https://whento.example.com", key, value)
+	}
+	return nil
+}
+
+// validateDir checks that a path is an existing directory
+func validateDir(key, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s: %s is not a directory", key, path)
+	}
+	return nil
+}
+
+// validateKeyPath checks that a key path is a file, or can be created (keys are generated on first run)
+func validateKeyPath(key, path string) error {
+	if path == "" {
+		return fmt.Errorf("%s: empty path", key)
+	}
+
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		return fmt.Errorf("%s: %s is a directory, expected a PEM file", key, path)
+	case err == nil:
+		return nil
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	// Missing keys are generated, as long as their directory exists or can be created
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s: %s is not a directory", key, dir)
+			}
+			return nil
+		}
+		if !errors.Is(err, os.ErrNotExist) || dir == filepath.Dir(dir) {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+}
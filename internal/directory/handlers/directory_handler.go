@@ -0,0 +1,232 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/directory/models"
+	"github.com/whento/whento/internal/directory/service"
+)
+
+// DirectoryHandler handles HTTP requests for organization directories
+type DirectoryHandler struct {
+	service *service.DirectoryService
+	logger  *slog.Logger
+}
+
+// NewDirectoryHandler creates a new directory handler
+func NewDirectoryHandler(service *service.DirectoryService, logger *slog.Logger) *DirectoryHandler {
+	return &DirectoryHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		List directory providers
+// @Description	Lists the organization directories (Google Workspace, Microsoft 365), whether they are configured on this server and connected by the current user
+// @Tags			Directory
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		models.ProviderResponse	"Directory providers"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/directory/providers [get]
+func (h *DirectoryHandler) ListProviders(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	providers, err := h.service.ListProviders(r.Context(), userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to list directory providers")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, providers)
+}
+
+// @Summary		Connect a directory
+// @Description	Returns the consent page of the provider; open it in the browser. After consent, the provider redirects to the callback, which redirects to /settings?directory={provider}&status=connected (or status=error)
+// @Tags			Directory
+// @Produce		json
+// @Security		BearerAuth
+// @Param			provider	path		string					true	"Provider (google, microsoft)"
+// @Success		200			{object}	models.ConnectResponse	"Consent page URL"
+// @Failure		401			{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404			{object}	httputil.ErrorResponse	"Unknown or unconfigured provider"
+// @Failure		500			{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/directory/{provider}/connect [post]
+func (h *DirectoryHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.Connect(userUUID, chi.URLParam(r, "provider"))
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to connect directory")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response)
+}
+
+// @Summary		Directory OAuth callback
+// @Description	Called by the provider after consent; stores the connection and redirects to the settings page
+// @Tags			Directory
+// @Param			provider	path	string	true	"Provider (google, microsoft)"
+// @Param			state		query	string	true	"State returned by the provider"
+// @Param			code		query	string	false	"Authorization code"
+// @Success		302			"Redirect to the settings page"
+// @Router			/api/v1/directory/{provider}/callback [get]
+func (h *DirectoryHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	target := h.service.HandleCallback(r.Context(), chi.URLParam(r, "provider"), query.Get("state"), query.Get("code"))
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// @Summary		Disconnect a directory
+// @Description	Forgets the directory connection of the current user. Access can also be revoked from the account settings of the provider.
+// @Tags			Directory
+// @Security		BearerAuth
+// @Param			provider	path	string	true	"Provider (google, microsoft)"
+// @Success		204			"Directory disconnected"
+// @Failure		401			{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404			{object}	httputil.ErrorResponse	"Directory not connected"
+// @Failure		500			{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/directory/{provider} [delete]
+func (h *DirectoryHandler) Disconnect(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Disconnect(r.Context(), userUUID, chi.URLParam(r, "provider")); err != nil {
+		h.handleError(w, err, userUUID, "Failed to disconnect directory")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Search a directory
+// @Description	Searches the connected directory by name or email (at most 25 people); lists its first members without a query
+// @Tags			Directory
+// @Produce		json
+// @Security		BearerAuth
+// @Param			provider	path		string					true	"Provider (google, microsoft)"
+// @Param			q			query		string					false	"Name or email"
+// @Success		200			{array}		models.Person			"Directory members"
+// @Failure		401			{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404			{object}	httputil.ErrorResponse	"Directory not connected"
+// @Failure		409			{object}	httputil.ErrorResponse	"Directory authorization expired, connect again"
+// @Failure		502			{object}	httputil.ErrorResponse	"Directory unavailable"
+// @Router			/api/v1/directory/{provider}/people [get]
+func (h *DirectoryHandler) SearchPeople(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	people, err := h.service.SearchPeople(r.Context(), userUUID, chi.URLParam(r, "provider"), r.URL.Query().Get("q"))
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to search directory")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, people)
+}
+
+// @Summary		Import participants from a directory
+// @Description	Adds members of the connected directory as participants, with their directory name and email. Emails are marked as verified, no verification email is sent. Emails not found in the directory or already in the calendar are reported and skipped.
+// @Tags			Directory
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string								true	"Calendar ID"
+// @Param			request	body		models.ImportParticipantsRequest	true	"Directory members"
+// @Success		200		{object}	models.ImportParticipantsResponse	"Import results"
+// @Failure		400		{object}	httputil.ErrorResponse				"Invalid request"
+// @Failure		401		{object}	httputil.ErrorResponse				"Unauthorized"
+// @Failure		403		{object}	httputil.ErrorResponse				"Not the calendar owner"
+// @Failure		404		{object}	httputil.ErrorResponse				"Calendar not found or directory not connected"
+// @Failure		409		{object}	httputil.ErrorResponse				"Directory authorization expired, connect again"
+// @Failure		502		{object}	httputil.ErrorResponse				"Directory unavailable"
+// @Router			/api/v1/calendars/{id}/participants/import [post]
+func (h *DirectoryHandler) ImportParticipants(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	calendarID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid calendar ID")
+		return
+	}
+
+	var req models.ImportParticipantsRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.service.ImportParticipants(r.Context(), userUUID, middleware.GetUserRole(r.Context()), calendarID, &req)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to import participants")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *DirectoryHandler) handleError(w http.ResponseWriter, err error, userID uuid.UUID, defaultMsg string) {
+	switch {
+	case errors.Is(err, service.ErrUnknownProvider):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Unknown directory provider")
+	case errors.Is(err, service.ErrProviderNotConfigured):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, err.Error())
+	case errors.Is(err, service.ErrNotConnected):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Directory not connected")
+	case errors.Is(err, service.ErrCalendarNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+	case errors.Is(err, service.ErrUnauthorized):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "Forbidden")
+	case errors.Is(err, service.ErrAuthorization):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "Directory authorization expired or revoked, connect it again")
+	case errors.Is(err, service.ErrDirectory):
+		h.logger.Warn(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusBadGateway, httputil.ErrCodeInternal, "Directory unavailable")
+	default:
+		h.logger.Error(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
+	}
+}
+
+// userID returns the authenticated user ID, writing an error response if missing
+func (h *DirectoryHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
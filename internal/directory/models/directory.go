@@ -0,0 +1,77 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Directory providers
+const (
+	ProviderGoogle    = "google"    // Google Workspace
+	ProviderMicrosoft = "microsoft" // Microsoft 365 (Entra ID)
+)
+
+// Connection is an organization directory connected by a user through OAuth
+type Connection struct {
+	UserID       uuid.UUID
+	Provider     string
+	AccountEmail string
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Person is a member of an organization directory
+type Person struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// ProviderResponse describes a directory provider for the current user
+type ProviderResponse struct {
+	Provider     string     `json:"provider" example:"google"`
+	Configured   bool       `json:"configured"` // OAuth client set up on this server
+	Connected    bool       `json:"connected"`
+	AccountEmail string     `json:"account_email,omitempty"`
+	ConnectedAt  *time.Time `json:"connected_at,omitempty"`
+}
+
+// ConnectResponse is returned when starting to connect a directory
+type ConnectResponse struct {
+	AuthURL string `json:"auth_url"` // Provider consent page to open in the browser
+}
+
+// ImportParticipantsRequest represents a request to add directory members as participants
+type ImportParticipantsRequest struct {
+	Provider string   `json:"provider" validate:"required,oneof=google microsoft"`
+	Emails   []string `json:"emails" validate:"required,min=1,max=100,dive,required,email"`
+}
+
+// Import result statuses
+const (
+	ImportStatusAdded    = "added"
+	ImportStatusExists   = "exists"    // A participant with this name or email is already in the calendar
+	ImportStatusNotFound = "not_found" // Not a member of the directory
+)
+
+// ImportResult is the outcome of the import of one directory member
+type ImportResult struct {
+	Email         string     `json:"email"`
+	Name          string     `json:"name,omitempty"`
+	Status        string     `json:"status" example:"added"`
+	ParticipantID *uuid.UUID `json:"participant_id,omitempty"`
+}
+
+// ImportParticipantsResponse represents the response of a directory import
+type ImportParticipantsResponse struct {
+	Results []ImportResult `json:"results"`
+}
@@ -0,0 +1,123 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/directory/models"
+)
+
+var ErrConnectionNotFound = errors.New("directory connection not found")
+
+// ConnectionRepository handles directory connections
+type ConnectionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewConnectionRepository creates a new directory connection repository
+func NewConnectionRepository(pool *pgxpool.Pool) *ConnectionRepository {
+	return &ConnectionRepository{pool: pool}
+}
+
+const connectionColumns = `user_id, provider, account_email, access_token, refresh_token, expires_at, created_at, updated_at`
+
+// Upsert creates or replaces the connection of a user to a provider
+func (r *ConnectionRepository) Upsert(ctx context.Context, conn *models.Connection) error {
+	query := `
+		INSERT INTO directory_connections (user_id, provider, account_email, access_token, refresh_token, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (user_id, provider) DO UPDATE SET
+			account_email = EXCLUDED.account_email,
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			expires_at = EXCLUDED.expires_at,
+			updated_at = NOW()
+		RETURNING created_at, updated_at`
+
+	err := r.pool.QueryRow(ctx, query, conn.UserID, conn.Provider, conn.AccountEmail, conn.AccessToken, conn.RefreshToken, conn.ExpiresAt).
+		Scan(&conn.CreatedAt, &conn.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save directory connection: %w", err)
+	}
+	return nil
+}
+
+// Get returns the connection of a user to a provider
+func (r *ConnectionRepository) Get(ctx context.Context, userID uuid.UUID, provider string) (*models.Connection, error) {
+	query := `SELECT ` + connectionColumns + ` FROM directory_connections WHERE user_id = $1 AND provider = $2`
+
+	conn, err := scanConnection(r.pool.QueryRow(ctx, query, userID, provider))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrConnectionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get directory connection: %w", err)
+	}
+	return conn, nil
+}
+
+// ListByUser returns the connections of a user
+func (r *ConnectionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Connection, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+connectionColumns+` FROM directory_connections WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory connections: %w", err)
+	}
+	defer rows.Close()
+
+	var conns []*models.Connection
+	for rows.Next() {
+		conn, err := scanConnection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan directory connection: %w", err)
+		}
+		conns = append(conns, conn)
+	}
+
+	return conns, rows.Err()
+}
+
+// UpdateTokens stores refreshed tokens
+func (r *ConnectionRepository) UpdateTokens(ctx context.Context, conn *models.Connection) error {
+	query := `
+		UPDATE directory_connections
+		SET access_token = $3, refresh_token = $4, expires_at = $5, updated_at = NOW()
+		WHERE user_id = $1 AND provider = $2`
+
+	_, err := r.pool.Exec(ctx, query, conn.UserID, conn.Provider, conn.AccessToken, conn.RefreshToken, conn.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to update directory tokens: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes the connection of a user to a provider
+func (r *ConnectionRepository) Delete(ctx context.Context, userID uuid.UUID, provider string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM directory_connections WHERE user_id = $1 AND provider = $2`, userID, provider)
+	if err != nil {
+		return fmt.Errorf("failed to delete directory connection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrConnectionNotFound
+	}
+	return nil
+}
+
+func scanConnection(row pgx.Row) (*models.Connection, error) {
+	var conn models.Connection
+	err := row.Scan(&conn.UserID, &conn.Provider, &conn.AccountEmail, &conn.AccessToken, &conn.RefreshToken,
+		&conn.ExpiresAt, &conn.CreatedAt, &conn.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &conn, nil
+}
@@ -0,0 +1,380 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/cache"
+	"github.com/whento/pkg/jwt"
+	authRepo "github.com/whento/whento/internal/auth/repository"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/directory/models"
+	"github.com/whento/whento/internal/directory/repository"
+)
+
+const (
+	// stateTTL is the time left to the user to consent on the provider's page
+	stateTTL = 10 * time.Minute
+
+	// stateProviderClaim identifies the provider of a connection in state tokens
+	stateProviderClaim = "directory_provider"
+
+	// tokenExpiryMargin refreshes access tokens slightly before they expire
+	tokenExpiryMargin = time.Minute
+)
+
+var (
+	ErrNotConnected          = repository.ErrConnectionNotFound
+	ErrUnknownProvider       = errors.New("unknown directory provider")
+	ErrProviderNotConfigured = errors.New("directory provider not configured on this server")
+	ErrInvalidState          = errors.New("invalid or expired authorization state")
+	ErrCalendarNotFound      = errors.New("calendar not found")
+	ErrUnauthorized          = errors.New("unauthorized")
+)
+
+// providerNames lists the supported providers, in display order
+var providerNames = []string{models.ProviderGoogle, models.ProviderMicrosoft}
+
+// DirectoryService connects organization directories (Google Workspace, Microsoft 365) through OAuth,
+// so owners can pick participants from them with names and verified emails pre-filled
+type DirectoryService struct {
+	repo            *repository.ConnectionRepository
+	calendarRepo    *calendarRepo.CalendarRepository
+	participantRepo *calendarRepo.ParticipantRepository
+	userRepo        *authRepo.UserRepository
+	jwtManager      *jwt.Manager
+	cache           cache.Cache
+	providers       map[string]Provider // Configured providers only
+	appURL          string
+	logger          *slog.Logger
+}
+
+// NewDirectoryService creates a new directory service with the providers configured in cfg
+func NewDirectoryService(
+	repo *repository.ConnectionRepository,
+	calendarRepo *calendarRepo.CalendarRepository,
+	participantRepo *calendarRepo.ParticipantRepository,
+	userRepo *authRepo.UserRepository,
+	jwtManager *jwt.Manager,
+	c cache.Cache,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *DirectoryService {
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+
+	providers := make(map[string]Provider)
+	if cfg.Directory.GoogleClientID != "" && cfg.Directory.GoogleClientSecret != "" {
+		providers[models.ProviderGoogle] = NewGoogleProvider(httpClient,
+			cfg.Directory.GoogleClientID, cfg.Directory.GoogleClientSecret)
+	}
+	if cfg.Directory.MicrosoftClientID != "" && cfg.Directory.MicrosoftClientSecret != "" {
+		providers[models.ProviderMicrosoft] = NewMicrosoftProvider(httpClient,
+			cfg.Directory.MicrosoftClientID, cfg.Directory.MicrosoftClientSecret, cfg.Directory.MicrosoftTenant)
+	}
+
+	return &DirectoryService{
+		repo:            repo,
+		calendarRepo:    calendarRepo,
+		participantRepo: participantRepo,
+		userRepo:        userRepo,
+		jwtManager:      jwtManager,
+		cache:           c,
+		providers:       providers,
+		appURL:          strings.TrimRight(cfg.AppURL, "/"),
+		logger:          logger,
+	}
+}
+
+// ListProviders returns the supported providers with the connection state of a user
+func (s *DirectoryService) ListProviders(ctx context.Context, userID uuid.UUID) ([]models.ProviderResponse, error) {
+	conns, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.ProviderResponse, 0, len(providerNames))
+	for _, name := range providerNames {
+		response := models.ProviderResponse{Provider: name}
+		_, response.Configured = s.providers[name]
+		for _, conn := range conns {
+			if conn.Provider == name {
+				response.Connected = true
+				response.AccountEmail = conn.AccountEmail
+				response.ConnectedAt = &conn.CreatedAt
+			}
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// Connect returns the consent page URL to connect the directory of a provider
+func (s *DirectoryService) Connect(userID uuid.UUID, providerName string) (*models.ConnectResponse, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The state ties the callback to the user, who isn't authenticated when the provider redirects
+	// "sub" rather than "user_id" keeps the state from being accepted as an access token
+	state, err := s.jwtManager.GenerateCustomToken(map[string]interface{}{
+		"sub":              userID.String(),
+		stateProviderClaim: providerName,
+		"exp":              time.Now().Add(stateTTL).Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	return &models.ConnectResponse{AuthURL: provider.AuthURL(state, s.redirectURI(providerName))}, nil
+}
+
+// HandleCallback completes the connection of a directory and returns the page to redirect the user to
+func (s *DirectoryService) HandleCallback(ctx context.Context, providerName, state, code string) string {
+	if err := s.completeConnection(ctx, providerName, state, code); err != nil {
+		s.logger.Warn("Failed to connect directory", "provider", providerName, "error", err)
+		return s.settingsURL(providerName, "error")
+	}
+	return s.settingsURL(providerName, "connected")
+}
+
+func (s *DirectoryService) completeConnection(ctx context.Context, providerName, state, code string) error {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return err
+	}
+
+	claims, err := s.jwtManager.ValidateCustomToken(state)
+	if err != nil {
+		return ErrInvalidState
+	}
+	sub, _ := claims["sub"].(string)
+	userID, err := uuid.Parse(sub)
+	if err != nil || claims[stateProviderClaim] != providerName {
+		return ErrInvalidState
+	}
+	if code == "" {
+		return errors.New("consent denied")
+	}
+
+	tokens, err := provider.Exchange(ctx, code, s.redirectURI(providerName))
+	if err != nil {
+		return err
+	}
+
+	conn := &models.Connection{
+		UserID:       userID,
+		Provider:     providerName,
+		AccountEmail: tokens.AccountEmail,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	}
+	if err := s.repo.Upsert(ctx, conn); err != nil {
+		return err
+	}
+
+	s.logger.Info("Directory connected", "provider", providerName, "user_id", userID)
+	return nil
+}
+
+// Disconnect removes the directory connection of a user
+func (s *DirectoryService) Disconnect(ctx context.Context, userID uuid.UUID, providerName string) error {
+	if !isKnownProvider(providerName) {
+		return ErrUnknownProvider
+	}
+	if err := s.repo.Delete(ctx, userID, providerName); err != nil {
+		return err
+	}
+
+	s.logger.Info("Directory disconnected", "provider", providerName, "user_id", userID)
+	return nil
+}
+
+// SearchPeople searches the connected directory of a user
+func (s *DirectoryService) SearchPeople(ctx context.Context, userID uuid.UUID, providerName, query string) ([]models.Person, error) {
+	provider, accessToken, err := s.connection(ctx, userID, providerName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Search(ctx, accessToken, strings.TrimSpace(query))
+}
+
+// ImportParticipants adds members of the connected directory of a user as participants of a calendar
+// Emails come from the directory, so they are marked as verified without sending verification emails
+func (s *DirectoryService) ImportParticipants(ctx context.Context, userID uuid.UUID, userRole string, calendarID uuid.UUID, req *models.ImportParticipantsRequest) (*models.ImportParticipantsResponse, error) {
+	calendar, err := s.calendarRepo.GetByID(ctx, calendarID)
+	if err != nil {
+		if errors.Is(err, calendarRepo.ErrCalendarNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, err
+	}
+	if calendar.OwnerID != userID && userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+
+	provider, accessToken, err := s.connection(ctx, userID, req.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.participantRepo.GetByCalendarID(ctx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	existingEmails := make(map[string]bool, len(existing))
+	for _, participant := range existing {
+		if participant.Email != nil {
+			existingEmails[strings.ToLower(*participant.Email)] = true
+		}
+	}
+
+	locale := "en"
+	if owner, err := s.userRepo.GetByID(ctx, calendar.OwnerID); err == nil && owner.Locale != "" {
+		locale = owner.Locale
+	}
+
+	response := &models.ImportParticipantsResponse{Results: make([]models.ImportResult, 0, len(req.Emails))}
+	added := 0
+	for _, email := range req.Emails {
+		result := models.ImportResult{Email: email}
+
+		person, err := provider.Lookup(ctx, accessToken, email)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case person == nil:
+			result.Status = models.ImportStatusNotFound
+		case existingEmails[strings.ToLower(person.Email)]:
+			result.Name = person.Name
+			result.Status = models.ImportStatusExists
+		default:
+			result.Name = person.Name
+			participantID, err := s.addParticipant(ctx, calendarID, person, locale)
+			if errors.Is(err, calendarRepo.ErrParticipantAlreadyExists) {
+				result.Status = models.ImportStatusExists
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			existingEmails[strings.ToLower(person.Email)] = true
+			result.Status = models.ImportStatusAdded
+			result.ParticipantID = &participantID
+			added++
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	if added > 0 {
+		// Invalidate the public calendar cache since participants list changed
+		_ = s.cache.Delete(ctx, cache.CalendarByPublicTokenKey(calendar.PublicToken))
+		s.logger.Info("Participants imported from directory", "calendar_id", calendarID, "provider", req.Provider, "count", added)
+	}
+
+	return response, nil
+}
+
+// addParticipant creates a participant from a directory member
+func (s *DirectoryService) addParticipant(ctx context.Context, calendarID uuid.UUID, person *models.Person, locale string) (uuid.UUID, error) {
+	email := person.Email
+	participant := &calendarModels.Participant{
+		CalendarID: calendarID,
+		Name:       person.Name,
+		Email:      &email,
+		Locale:     locale,
+	}
+	participant.ID = uuid.New()
+
+	if err := s.participantRepo.CreateWithVerifiedEmail(ctx, participant); err != nil {
+		return uuid.Nil, err
+	}
+	return participant.ID, nil
+}
+
+// connection returns the provider and a valid access token of the directory connection of a user
+func (s *DirectoryService) connection(ctx context.Context, userID uuid.UUID, providerName string) (Provider, string, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conn, err := s.repo.Get(ctx, userID, providerName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if time.Now().Add(tokenExpiryMargin).Before(conn.ExpiresAt) {
+		return provider, conn.AccessToken, nil
+	}
+
+	if conn.RefreshToken == "" {
+		return nil, "", ErrAuthorization
+	}
+	tokens, err := provider.Refresh(ctx, conn.RefreshToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conn.AccessToken = tokens.AccessToken
+	conn.ExpiresAt = tokens.ExpiresAt
+	if tokens.RefreshToken != "" {
+		conn.RefreshToken = tokens.RefreshToken
+	}
+	if err := s.repo.UpdateTokens(ctx, conn); err != nil {
+		return nil, "", err
+	}
+
+	return provider, conn.AccessToken, nil
+}
+
+// provider returns a configured provider
+func (s *DirectoryService) provider(name string) (Provider, error) {
+	if !isKnownProvider(name) {
+		return nil, ErrUnknownProvider
+	}
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, ErrProviderNotConfigured
+	}
+	return provider, nil
+}
+
+// redirectURI returns the OAuth callback URL of a provider, to register in its OAuth client
+func (s *DirectoryService) redirectURI(providerName string) string {
+	return s.appURL + "/api/v1/directory/" + providerName + "/callback"
+}
+
+// settingsURL returns the settings page showing the outcome of a connection
+func (s *DirectoryService) settingsURL(providerName, status string) string {
+	params := url.Values{"directory": {providerName}, "status": {status}}
+	return s.appURL + "/settings?" + params.Encode()
+}
+
+func isKnownProvider(name string) bool {
+	for _, known := range providerNames {
+		if name == known {
+			return true
+		}
+	}
+	return false
+}
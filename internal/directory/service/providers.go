@@ -0,0 +1,370 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/whento/whento/internal/directory/models"
+)
+
+const (
+	// maxResults limits the number of people returned by a directory search
+	maxResults = 25
+
+	maxResponseBodySize = 1 << 20
+)
+
+var (
+	ErrDirectory     = errors.New("directory request failed")                   // Unreachable provider or unexpected response
+	ErrAuthorization = errors.New("directory authorization expired or revoked") // The user must connect the directory again
+)
+
+// Provider is an organization directory reachable with OAuth 2.0
+type Provider interface {
+	// AuthURL returns the consent page URL, which redirects to redirectURI with a code
+	AuthURL(state, redirectURI string) string
+	// Exchange exchanges an authorization code for tokens
+	Exchange(ctx context.Context, code, redirectURI string) (*Tokens, error)
+	// Refresh obtains a new access token
+	Refresh(ctx context.Context, refreshToken string) (*Tokens, error)
+	// Search returns the directory members matching a name or email (all members if query is empty)
+	Search(ctx context.Context, accessToken, query string) ([]models.Person, error)
+	// Lookup returns the directory member with an email, or nil
+	Lookup(ctx context.Context, accessToken, email string) (*models.Person, error)
+}
+
+// Tokens are the OAuth tokens of a directory connection
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string // Empty when the provider keeps the previous one
+	ExpiresAt    time.Time
+	AccountEmail string // From the ID token, only set by Exchange
+}
+
+// oauthClient implements the authorization code flow shared by providers
+type oauthClient struct {
+	httpClient   *http.Client
+	clientID     string
+	clientSecret string
+	authURL      string
+	tokenURL     string
+	scope        string
+	authParams   url.Values // Provider-specific consent page parameters
+}
+
+func (c *oauthClient) AuthURL(state, redirectURI string) string {
+	params := url.Values{
+		"client_id":     {c.clientID},
+		"redirect_uri":  {redirectURI},
+		"response_type": {"code"},
+		"scope":         {c.scope},
+		"state":         {state},
+	}
+	for key, values := range c.authParams {
+		params[key] = values
+	}
+	return c.authURL + "?" + params.Encode()
+}
+
+func (c *oauthClient) Exchange(ctx context.Context, code, redirectURI string) (*Tokens, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+	})
+}
+
+func (c *oauthClient) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+// token calls the token endpoint
+func (c *oauthClient) token(ctx context.Context, form url.Values) (*Tokens, error) {
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDirectory, err)
+	}
+	defer resp.Body.Close()
+
+	// invalid_grant: the code was already used, or the refresh token was revoked
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrAuthorization
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: token endpoint returned %d", ErrDirectory, resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		IDToken      string `json:"id_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBodySize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: invalid token response: %v", ErrDirectory, err)
+	}
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("%w: no access token", ErrDirectory)
+	}
+
+	return &Tokens{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+		AccountEmail: idTokenEmail(body.IDToken),
+	}, nil
+}
+
+// idTokenEmail returns the email of an ID token
+// The signature isn't checked: the token comes straight from the token endpoint over TLS
+func idTokenEmail(idToken string) string {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		PreferredUsername string `json:"preferred_username"` // Microsoft accounts without a mailbox
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	if claims.Email != "" {
+		return claims.Email
+	}
+	return claims.PreferredUsername
+}
+
+// getJSON sends an authenticated GET request to a directory API and decodes its response
+func getJSON(ctx context.Context, httpClient *http.Client, rawURL, accessToken string, header http.Header, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDirectory, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrAuthorization
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: directory API returned %d", ErrDirectory, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBodySize)).Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid directory response: %v", ErrDirectory, err)
+	}
+	return nil
+}
+
+// GoogleProvider reads the domain profiles of a Google Workspace directory with the People API
+type GoogleProvider struct {
+	oauthClient
+	apiURL string
+}
+
+// NewGoogleProvider creates a Google Workspace directory provider
+func NewGoogleProvider(httpClient *http.Client, clientID, clientSecret string) *GoogleProvider {
+	return &GoogleProvider{
+		oauthClient: oauthClient{
+			httpClient:   httpClient,
+			clientID:     clientID,
+			clientSecret: clientSecret,
+			authURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+			tokenURL:     "https://oauth2.googleapis.com/token",
+			scope:        "openid email https://www.googleapis.com/auth/directory.readonly",
+			// Offline access with consent, so a refresh token is returned on every connection
+			authParams: url.Values{"access_type": {"offline"}, "prompt": {"consent"}},
+		},
+		apiURL: "https://people.googleapis.com/v1",
+	}
+}
+
+type googlePeople struct {
+	People []struct {
+		ResourceName string `json:"resourceName"`
+		Names        []struct {
+			DisplayName string `json:"displayName"`
+		} `json:"names"`
+		EmailAddresses []struct {
+			Value string `json:"value"`
+		} `json:"emailAddresses"`
+	} `json:"people"`
+}
+
+func (p *GoogleProvider) Search(ctx context.Context, accessToken, query string) ([]models.Person, error) {
+	params := url.Values{
+		"readMask": {"names,emailAddresses"},
+		"sources":  {"DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE"},
+		"pageSize": {fmt.Sprint(maxResults)},
+	}
+	endpoint := p.apiURL + "/people:listDirectoryPeople"
+	if query != "" {
+		endpoint = p.apiURL + "/people:searchDirectoryPeople"
+		params.Set("query", query)
+	}
+
+	var body googlePeople
+	if err := getJSON(ctx, p.httpClient, endpoint+"?"+params.Encode(), accessToken, nil, &body); err != nil {
+		return nil, err
+	}
+
+	people := make([]models.Person, 0, len(body.People))
+	for _, person := range body.People {
+		// People without a work email can't be notified, so they can't be imported
+		if len(person.EmailAddresses) == 0 {
+			continue
+		}
+		email := person.EmailAddresses[0].Value
+		name := email
+		if len(person.Names) > 0 && person.Names[0].DisplayName != "" {
+			name = person.Names[0].DisplayName
+		}
+		people = append(people, models.Person{ID: person.ResourceName, Name: name, Email: email})
+	}
+	return people, nil
+}
+
+func (p *GoogleProvider) Lookup(ctx context.Context, accessToken, email string) (*models.Person, error) {
+	people, err := p.Search(ctx, accessToken, email)
+	if err != nil {
+		return nil, err
+	}
+	return findByEmail(people, email), nil
+}
+
+// MicrosoftProvider reads the users of a Microsoft 365 (Entra ID) tenant with Microsoft Graph
+type MicrosoftProvider struct {
+	oauthClient
+	apiURL string
+}
+
+// NewMicrosoftProvider creates a Microsoft 365 directory provider
+// tenant is a tenant ID or domain, or "organizations" to accept any work account
+func NewMicrosoftProvider(httpClient *http.Client, clientID, clientSecret, tenant string) *MicrosoftProvider {
+	base := "https://login.microsoftonline.com/" + url.PathEscape(tenant) + "/oauth2/v2.0"
+	return &MicrosoftProvider{
+		oauthClient: oauthClient{
+			httpClient:   httpClient,
+			clientID:     clientID,
+			clientSecret: clientSecret,
+			authURL:      base + "/authorize",
+			tokenURL:     base + "/token",
+			scope:        "openid email offline_access https://graph.microsoft.com/User.ReadBasic.All",
+		},
+		apiURL: "https://graph.microsoft.com/v1.0",
+	}
+}
+
+type graphUsers struct {
+	Value []struct {
+		ID                string `json:"id"`
+		DisplayName       string `json:"displayName"`
+		Mail              string `json:"mail"`
+		UserPrincipalName string `json:"userPrincipalName"`
+	} `json:"value"`
+}
+
+func (p *MicrosoftProvider) Search(ctx context.Context, accessToken, query string) ([]models.Person, error) {
+	params := url.Values{
+		"$select": {"id,displayName,mail,userPrincipalName"},
+		"$top":    {fmt.Sprint(maxResults)},
+	}
+	if query != "" {
+		// Quotes delimit $search clauses and can't be escaped
+		q := strings.ReplaceAll(query, `"`, "")
+		params.Set("$search", fmt.Sprintf(`"displayName:%s" OR "mail:%s"`, q, q))
+	}
+	return p.users(ctx, accessToken, params)
+}
+
+func (p *MicrosoftProvider) Lookup(ctx context.Context, accessToken, email string) (*models.Person, error) {
+	quoted := "'" + strings.ReplaceAll(email, "'", "''") + "'"
+	people, err := p.users(ctx, accessToken, url.Values{
+		"$select": {"id,displayName,mail,userPrincipalName"},
+		"$filter": {fmt.Sprintf("mail eq %s or userPrincipalName eq %s", quoted, quoted)},
+	})
+	if err != nil || len(people) == 0 {
+		return nil, err
+	}
+	// The filter is exact: the user may have been found by their principal name, which isn't their mail
+	return &people[0], nil
+}
+
+// users lists the users of the tenant
+func (p *MicrosoftProvider) users(ctx context.Context, accessToken string, params url.Values) ([]models.Person, error) {
+	// $search requires eventual consistency
+	header := http.Header{"Consistencylevel": {"eventual"}}
+
+	var body graphUsers
+	if err := getJSON(ctx, p.httpClient, p.apiURL+"/users?"+params.Encode(), accessToken, header, &body); err != nil {
+		return nil, err
+	}
+
+	people := make([]models.Person, 0, len(body.Value))
+	for _, user := range body.Value {
+		email := user.Mail
+		if email == "" {
+			email = user.UserPrincipalName
+		}
+		if email == "" {
+			continue
+		}
+		name := user.DisplayName
+		if name == "" {
+			name = email
+		}
+		people = append(people, models.Person{ID: user.ID, Name: name, Email: email})
+	}
+	return people, nil
+}
+
+// findByEmail returns the person with an email, ignoring case
+func findByEmail(people []models.Person, email string) *models.Person {
+	for i := range people {
+		if strings.EqualFold(people[i].Email, email) {
+			return &people[i]
+		}
+	}
+	return nil
+}
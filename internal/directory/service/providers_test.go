@@ -0,0 +1,227 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func fakeIDToken(payload string) string {
+	return "e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+}
+
+// fakeTokenServer accepts the code "good-code" and the refresh token "good-refresh"
+func fakeTokenServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Form.Get("grant_type") == "authorization_code" && r.Form.Get("code") == "good-code":
+			io.WriteString(w, `{"access_token":"access-1","refresh_token":"refresh-1","expires_in":3600,"id_token":"`+
+				fakeIDToken(`{"email":"owner@example.com"}`)+`"}`)
+		case r.Form.Get("grant_type") == "refresh_token" && r.Form.Get("refresh_token") == "good-refresh":
+			io.WriteString(w, `{"access_token":"access-2","expires_in":3600}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error":"invalid_grant"}`)
+		}
+	}))
+}
+
+func TestOAuthClient_AuthURL(t *testing.T) {
+	p := NewGoogleProvider(http.DefaultClient, "client", "secret")
+
+	u, err := url.Parse(p.AuthURL("state-1", "https://whento.example.com/api/v1/directory/google/callback"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	for key, want := range map[string]string{
+		"client_id":     "client",
+		"state":         "state-1",
+		"response_type": "code",
+		"access_type":   "offline",
+		"redirect_uri":  "https://whento.example.com/api/v1/directory/google/callback",
+	} {
+		if got := q.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if !strings.Contains(q.Get("scope"), "directory.readonly") {
+		t.Errorf("scope = %q, want directory.readonly", q.Get("scope"))
+	}
+}
+
+func TestOAuthClient_ExchangeAndRefresh(t *testing.T) {
+	server := fakeTokenServer(t)
+	defer server.Close()
+
+	p := NewMicrosoftProvider(server.Client(), "client", "secret", "organizations")
+	p.tokenURL = server.URL
+
+	tokens, err := p.Exchange(context.Background(), "good-code", "https://whento.example.com/callback")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if tokens.AccessToken != "access-1" || tokens.RefreshToken != "refresh-1" || tokens.AccountEmail != "owner@example.com" {
+		t.Errorf("Exchange() = %+v", tokens)
+	}
+
+	tokens, err = p.Refresh(context.Background(), "good-refresh")
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if tokens.AccessToken != "access-2" || tokens.RefreshToken != "" {
+		t.Errorf("Refresh() = %+v", tokens)
+	}
+
+	if _, err := p.Refresh(context.Background(), "revoked"); !errors.Is(err, ErrAuthorization) {
+		t.Errorf("Refresh(revoked) error = %v, want ErrAuthorization", err)
+	}
+}
+
+func TestIDTokenEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		idToken string
+		want    string
+	}{
+		{"email", fakeIDToken(`{"email":"a@example.com","preferred_username":"b@example.com"}`), "a@example.com"},
+		{"preferred username", fakeIDToken(`{"preferred_username":"b@example.com"}`), "b@example.com"},
+		{"empty", "", ""},
+		{"malformed", "not-a-jwt", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idTokenEmail(tt.idToken); got != tt.want {
+				t.Errorf("idTokenEmail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleProvider_SearchAndLookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("sources") != "DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/people:searchDirectoryPeople":
+			// Google matches prefixes, so an exact email search may return other people
+			io.WriteString(w, `{"people":[
+				{"resourceName":"people/1","names":[{"displayName":"Alice Martin"}],"emailAddresses":[{"value":"alice@example.com"}]},
+				{"resourceName":"people/2","names":[{"displayName":"Alice Martinez"}],"emailAddresses":[{"value":"alice.martinez@example.com"}]},
+				{"resourceName":"people/3","names":[{"displayName":"Meeting Room"}]}
+			]}`)
+		case "/people:listDirectoryPeople":
+			io.WriteString(w, `{"people":[{"resourceName":"people/4","emailAddresses":[{"value":"bob@example.com"}]}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := NewGoogleProvider(server.Client(), "client", "secret")
+	p.apiURL = server.URL
+	ctx := context.Background()
+
+	people, err := p.Search(ctx, "token", "alice")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(people) != 2 {
+		t.Fatalf("Search() returned %d people, want 2 (people without email skipped)", len(people))
+	}
+
+	people, err = p.Search(ctx, "token", "")
+	if err != nil {
+		t.Fatalf("Search(\"\") error = %v", err)
+	}
+	if len(people) != 1 || people[0].Name != "bob@example.com" {
+		t.Errorf("Search(\"\") = %+v, want bob named after his email", people)
+	}
+
+	person, err := p.Lookup(ctx, "token", "ALICE@example.com")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if person == nil || person.Name != "Alice Martin" {
+		t.Errorf("Lookup() = %+v, want Alice Martin", person)
+	}
+
+	if _, err := p.Search(ctx, "expired", "alice"); !errors.Is(err, ErrAuthorization) {
+		t.Errorf("Search(expired) error = %v, want ErrAuthorization", err)
+	}
+}
+
+func TestMicrosoftProvider_SearchAndLookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users" || r.Header.Get("ConsistencyLevel") != "eventual" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case q.Get("$search") == `"displayName:bob" OR "mail:bob"`:
+			io.WriteString(w, `{"value":[{"id":"1","displayName":"Bob Durand","mail":null,"userPrincipalName":"bob@contoso.com"}]}`)
+		case q.Get("$filter") == "mail eq 'o''brien@contoso.com' or userPrincipalName eq 'o''brien@contoso.com'":
+			io.WriteString(w, `{"value":[{"id":"2","displayName":"Pat O'Brien","mail":"pat.obrien@contoso.com"}]}`)
+		case q.Get("$filter") != "":
+			io.WriteString(w, `{"value":[]}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	p := NewMicrosoftProvider(server.Client(), "client", "secret", "organizations")
+	p.apiURL = server.URL
+	ctx := context.Background()
+
+	people, err := p.Search(ctx, "token", `bob"`)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(people) != 1 || people[0].Email != "bob@contoso.com" {
+		t.Errorf("Search() = %+v, want bob with his principal name as email", people)
+	}
+
+	person, err := p.Lookup(ctx, "token", "o'brien@contoso.com")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if person == nil || person.Email != "pat.obrien@contoso.com" {
+		t.Errorf("Lookup() = %+v, want Pat with their mail", person)
+	}
+
+	person, err = p.Lookup(ctx, "token", "nobody@contoso.com")
+	if err != nil || person != nil {
+		t.Errorf("Lookup(unknown) = %+v, %v, want nil, nil", person, err)
+	}
+}
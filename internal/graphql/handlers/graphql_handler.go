@@ -0,0 +1,106 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/whento/internal/graphql/service"
+)
+
+const (
+	// MaxBatchSize limits the number of queries of a batch request
+	MaxBatchSize = 10
+
+	// maxRequestSize limits the size of request bodies
+	maxRequestSize = 1 << 20
+)
+
+// GraphQLHandler handles GraphQL requests
+type GraphQLHandler struct {
+	service *service.GraphQLService
+	logger  *slog.Logger
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler(service *service.GraphQLService, logger *slog.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Query executes a GraphQL query, or a batch of queries
+//
+//	@Summary		Execute a GraphQL query
+//	@Description	Executes a read-only GraphQL query over calendars, participants, availabilities and summaries, as the current user. The body is a request object ({"query", "variables", "operationName"}) or an array of up to 10 of them, answered with an array of responses in the same order. Field errors are reported in the errors of each response with a 200 status. Honors the X-Organization-ID header like the calendar list. The schema is available at /api/graphql/schema.
+//	@Tags			GraphQL
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		service.Request	true	"GraphQL request (or an array of requests)"
+//	@Success		200		{object}	service.Response
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid request body or batch too large"
+//	@Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+//	@Router			/api/graphql [post]
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var body json.RawMessage
+	if err := httputil.DecodeJSON(r, &body); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		var req service.Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+			return
+		}
+		httputil.JSON(w, http.StatusOK, h.service.Execute(r.Context(), &req))
+		return
+	}
+
+	var batch []*service.Request
+	if err := json.Unmarshal(body, &batch); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+	if len(batch) == 0 || len(batch) > MaxBatchSize {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, fmt.Sprintf("A batch must contain 1 to %d queries", MaxBatchSize))
+		return
+	}
+
+	responses := make([]*service.Response, 0, len(batch))
+	for _, req := range batch {
+		if req == nil {
+			req = &service.Request{}
+		}
+		responses = append(responses, h.service.Execute(r.Context(), req))
+	}
+	httputil.JSON(w, http.StatusOK, responses)
+}
+
+// Schema returns the GraphQL schema
+//
+//	@Summary		Get the GraphQL schema
+//	@Description	Returns the schema of the GraphQL API in the schema definition language, for code generators and editors.
+//	@Tags			GraphQL
+//	@Produce		plain
+//	@Success		200	{string}	string	"Schema (SDL)"
+//	@Router			/api/graphql/schema [get]
+func (h *GraphQLHandler) Schema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(h.service.SDL())); err != nil {
+		h.logger.Debug("Failed to write GraphQL schema", "error", err)
+	}
+}
@@ -0,0 +1,138 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	availabilityService "github.com/whento/whento/internal/availability/service"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	calendarService "github.com/whento/whento/internal/calendar/service"
+)
+
+var (
+	ErrInvalidCalendarID = errors.New("invalid calendar ID")
+	ErrInvalidRange      = errors.New("end date must be on or after start date")
+	ErrUnauthenticated   = errors.New("authentication required")
+	ErrInternal          = errors.New("internal error")
+)
+
+// Errors of the calendar and availability services shown as is to clients
+var publicErrors = []error{
+	calendarService.ErrCalendarNotFound,
+	calendarService.ErrUnauthorized,
+	availabilityService.ErrCalendarNotFound,
+	availabilityService.ErrParticipantNotFound,
+	availabilityService.ErrInvalidDate,
+	availabilityService.ErrInvalidTimezone,
+	ErrInvalidCalendarID,
+	ErrInvalidRange,
+	ErrUnauthenticated,
+}
+
+// maxDepth limits the nesting of selections in queries
+const maxDepth = 10
+
+// schemaSDL is the schema of the API in the GraphQL schema definition language
+//
+//go:embed schema.graphql
+var schemaSDL string
+
+// CalendarReader defines the calendar operations used by the GraphQL API
+type CalendarReader interface {
+	ListMyCalendars(ctx context.Context, userID string, organizationID *uuid.UUID) ([]*calendarModels.CalendarResponse, error)
+	GetCalendar(ctx context.Context, userID, userRole, calendarID string) (*calendarModels.CalendarResponse, error)
+}
+
+// AvailabilityReader defines the availability operations used by the GraphQL API
+type AvailabilityReader interface {
+	GetParticipantAvailabilities(ctx context.Context, token, participantID, startDateStr, endDateStr string) (*availabilityModels.ParticipantAvailabilitiesResponse, error)
+	GetDateSummary(ctx context.Context, token, dateStr, timezone string) (*availabilityModels.DateAvailabilitySummary, error)
+	GetRangeSummary(ctx context.Context, token, startDateStr, endDateStr, participantID, timezone string) ([]availabilityModels.PublicDateAvailabilitySummary, error)
+}
+
+// Request is a GraphQL request
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is a GraphQL response
+// Data is absent when the request failed before its execution, and null when a non-null root field failed
+type Response struct {
+	Data   json.RawMessage         `json:"data,omitempty" swaggertype:"object"`
+	Errors []*gqlerrors.QueryError `json:"errors,omitempty" swaggertype:"array,object"`
+}
+
+// GraphQLService serves the read-only GraphQL API over calendars, participants, availabilities and summaries
+// Queries run as the authenticated user, with the same access checks as the REST API
+type GraphQLService struct {
+	calendars      CalendarReader
+	availabilities AvailabilityReader
+	logger         *slog.Logger
+	schema         *graphql.Schema
+}
+
+// NewGraphQLService creates a new GraphQL service
+// The schema is static: an invalid schema or resolver panics, which the tests catch
+func NewGraphQLService(calendars CalendarReader, availabilities AvailabilityReader, logger *slog.Logger) *GraphQLService {
+	s := &GraphQLService{
+		calendars:      calendars,
+		availabilities: availabilities,
+		logger:         logger,
+	}
+	s.schema = graphql.MustParseSchema(schemaSDL, &queryResolver{s: s}, graphql.UseStringDescriptions(), graphql.MaxDepth(maxDepth))
+	return s
+}
+
+// Execute executes a query as the user authenticated in the context
+// Field errors are reported in the response, along with the data of the other fields
+func (s *GraphQLService) Execute(ctx context.Context, req *Request) *Response {
+	response := s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	return &Response{Data: response.Data, Errors: response.Errors}
+}
+
+// SDL returns the schema in the GraphQL schema definition language
+func (s *GraphQLService) SDL() string {
+	return schemaSDL
+}
+
+// publicError returns the error reported to clients, hiding and logging unexpected errors
+func (s *GraphQLService) publicError(err error, message string) error {
+	for _, public := range publicErrors {
+		if errors.Is(err, public) {
+			return public
+		}
+	}
+	s.logger.Error(message, "error", err)
+	return ErrInternal
+}
+
+// checkRange validates a range of dates (YYYY-MM-DD)
+func checkRange(start, end string) error {
+	startDate, err := time.Parse(time.DateOnly, start)
+	if err != nil {
+		return availabilityService.ErrInvalidDate
+	}
+	endDate, err := time.Parse(time.DateOnly, end)
+	if err != nil {
+		return availabilityService.ErrInvalidDate
+	}
+	if endDate.Before(startDate) {
+		return ErrInvalidRange
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/middleware"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	calendarService "github.com/whento/whento/internal/calendar/service"
+)
+
+var (
+	testCalendarID    = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	testParticipantID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+)
+
+type mockCalendarReader struct {
+	err error
+}
+
+func (m *mockCalendarReader) calendar() *calendarModels.CalendarResponse {
+	participant := calendarModels.Participant{Name: "Alice", Locale: "en", CreatedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+	participant.ID = testParticipantID
+	return &calendarModels.CalendarResponse{
+		ID:           testCalendarID,
+		Name:         "Board games",
+		PublicToken:  "public-token",
+		Threshold:    2,
+		Participants: []calendarModels.Participant{participant},
+	}
+}
+
+func (m *mockCalendarReader) ListMyCalendars(ctx context.Context, userID string, organizationID *uuid.UUID) ([]*calendarModels.CalendarResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*calendarModels.CalendarResponse{m.calendar()}, nil
+}
+
+func (m *mockCalendarReader) GetCalendar(ctx context.Context, userID, userRole, calendarID string) (*calendarModels.CalendarResponse, error) {
+	if calendarID != testCalendarID.String() {
+		return nil, calendarService.ErrCalendarNotFound
+	}
+	return m.calendar(), nil
+}
+
+type mockAvailabilityReader struct {
+	tokens []string
+}
+
+func (m *mockAvailabilityReader) GetParticipantAvailabilities(ctx context.Context, token, participantID, startDateStr, endDateStr string) (*availabilityModels.ParticipantAvailabilitiesResponse, error) {
+	m.tokens = append(m.tokens, token)
+	return &availabilityModels.ParticipantAvailabilitiesResponse{
+		Availabilities: []availabilityModels.AvailabilityItem{{Date: startDateStr, Note: participantID}},
+	}, nil
+}
+
+func (m *mockAvailabilityReader) GetDateSummary(ctx context.Context, token, dateStr, timezone string) (*availabilityModels.DateAvailabilitySummary, error) {
+	m.tokens = append(m.tokens, token)
+	return &availabilityModels.DateAvailabilitySummary{Date: dateStr, Timezone: timezone}, nil
+}
+
+func (m *mockAvailabilityReader) GetRangeSummary(ctx context.Context, token, startDateStr, endDateStr, participantID, timezone string) ([]availabilityModels.PublicDateAvailabilitySummary, error) {
+	m.tokens = append(m.tokens, token)
+	return []availabilityModels.PublicDateAvailabilitySummary{{
+		Date:         startDateStr,
+		TotalCount:   1,
+		Participants: []availabilityModels.PublicParticipantAvailabilitySummary{{ParticipantID: &testParticipantID, ParticipantName: "Alice"}},
+	}}, nil
+}
+
+func userContext() context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, uuid.NewString())
+}
+
+func execute(t *testing.T, svc *GraphQLService, ctx context.Context, query string, variables map[string]any) string {
+	t.Helper()
+	data, err := json.Marshal(svc.Execute(ctx, &Request{Query: query, Variables: variables}))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(data)
+}
+
+func TestGraphQLService_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		calendars *mockCalendarReader
+		query     string
+		variables map[string]any
+		want      string
+	}{
+		{
+			name:      "calendars with participants, availabilities and summaries",
+			ctx:       userContext(),
+			calendars: &mockCalendarReader{},
+			query: `{ calendars { name threshold participants { name availabilities(start: "2025-06-01", end: "2025-06-30") { date note } }
+				summaries(start: "2025-06-01", end: "2025-06-30") { date totalCount participants { participantId participantName } } } }`,
+			want: `{"data":{"calendars":[{"name":"Board games","threshold":2,` +
+				`"participants":[{"name":"Alice","availabilities":[{"date":"2025-06-01","note":"22222222-2222-2222-2222-222222222222"}]}],` +
+				`"summaries":[{"date":"2025-06-01","totalCount":1,"participants":[{"participantId":"22222222-2222-2222-2222-222222222222","participantName":"Alice"}]}]}]}}`,
+		},
+		{
+			name:      "calendar by ID with a date summary",
+			ctx:       userContext(),
+			calendars: &mockCalendarReader{},
+			query:     `{ calendar(id: "11111111-1111-1111-1111-111111111111") { id allowedWeekdays summary(date: "2025-06-02", timezone: "UTC") { date timezone participants { participantName } } } }`,
+			want:      `{"data":{"calendar":{"id":"11111111-1111-1111-1111-111111111111","allowedWeekdays":[],"summary":{"date":"2025-06-02","timezone":"UTC","participants":[]}}}}`,
+		},
+		{
+			name:      "variables",
+			ctx:       userContext(),
+			calendars: &mockCalendarReader{},
+			query:     `query Calendar($id: ID!) { calendar(id: $id) { name } }`,
+			variables: map[string]any{"id": testCalendarID.String()},
+			want:      `{"data":{"calendar":{"name":"Board games"}}}`,
+		},
+		{
+			name:      "unknown field",
+			ctx:       userContext(),
+			calendars: &mockCalendarReader{},
+			query:     `{ calendars { secret } }`,
+			want:      `{"errors":[{"message":"Cannot query field \"secret\" on type \"Calendar\".","locations":[{"line":1,"column":15}]}]}`,
+		},
+		{
+			name:      "calendar not found",
+			ctx:       userContext(),
+			calendars: &mockCalendarReader{},
+			query:     `{ calendar(id: "33333333-3333-3333-3333-333333333333") { name } }`,
+			want:      `{"data":{"calendar":null},"errors":[{"message":"calendar not found","path":["calendar"]}]}`,
+		},
+		{
+			name:      "invalid calendar ID",
+			ctx:       userContext(),
+			calendars: &mockCalendarReader{},
+			query:     `{ calendar(id: "nope") { name } }`,
+			want:      `{"data":{"calendar":null},"errors":[{"message":"invalid calendar ID","path":["calendar"]}]}`,
+		},
+		{
+			name:      "invalid range",
+			ctx:       userContext(),
+			calendars: &mockCalendarReader{},
+			query:     `{ calendars { name summaries(start: "2025-06-30", end: "2025-06-01") { date } } }`,
+			want:      `{"data":null,"errors":[{"message":"end date must be on or after start date","path":["calendars",0,"summaries"]}]}`,
+		},
+		{
+			name:      "internal errors are hidden",
+			ctx:       userContext(),
+			calendars: &mockCalendarReader{err: errors.New("connection refused")},
+			query:     `{ calendars { name } }`,
+			want:      `{"data":null,"errors":[{"message":"internal error","path":["calendars"]}]}`,
+		},
+		{
+			name:      "unauthenticated",
+			ctx:       context.Background(),
+			calendars: &mockCalendarReader{},
+			query:     `{ calendar(id: "11111111-1111-1111-1111-111111111111") { name } }`,
+			want:      `{"data":{"calendar":null},"errors":[{"message":"authentication required","path":["calendar"]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGraphQLService(tt.calendars, &mockAvailabilityReader{}, logger)
+			if got := execute(t, svc, tt.ctx, tt.query, tt.variables); got != tt.want {
+				t.Errorf("Execute() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQLService_UsesCalendarPublicToken(t *testing.T) {
+	availabilities := &mockAvailabilityReader{}
+	svc := NewGraphQLService(&mockCalendarReader{}, availabilities, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	execute(t, svc, userContext(), `{ calendars { participants { availabilities { id } } summary(date: "2025-06-01") { date } } }`, nil)
+
+	if len(availabilities.tokens) != 2 || availabilities.tokens[0] != "public-token" || availabilities.tokens[1] != "public-token" {
+		t.Errorf("tokens = %v, want the public token of the calendar twice", availabilities.tokens)
+	}
+}
+
+func TestGraphQLService_SDL(t *testing.T) {
+	svc := NewGraphQLService(&mockCalendarReader{}, &mockAvailabilityReader{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if sdl := svc.SDL(); len(sdl) == 0 {
+		t.Error("SDL() is empty")
+	}
+}
@@ -0,0 +1,344 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/whento/pkg/middleware"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	orgModels "github.com/whento/whento/internal/organization/models"
+)
+
+// queryResolver resolves the root fields of the schema
+type queryResolver struct {
+	s *GraphQLService
+}
+
+func (r *queryResolver) Calendars(ctx context.Context) ([]*calendarResolver, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	var organizationID *uuid.UUID
+	if membership := orgModels.MembershipFromContext(ctx); membership != nil {
+		organizationID = &membership.OrganizationID
+	}
+
+	calendars, err := r.s.calendars.ListMyCalendars(ctx, userID, organizationID)
+	if err != nil {
+		return nil, r.s.publicError(err, "Failed to list calendars")
+	}
+
+	result := make([]*calendarResolver, 0, len(calendars))
+	for _, calendar := range calendars {
+		result = append(result, &calendarResolver{s: r.s, calendar: calendar})
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Calendar(ctx context.Context, args struct{ ID graphql.ID }) (*calendarResolver, error) {
+	userID := middleware.GetUserID(ctx)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	calendarID := string(args.ID)
+	if _, err := uuid.Parse(calendarID); err != nil {
+		return nil, ErrInvalidCalendarID
+	}
+
+	calendar, err := r.s.calendars.GetCalendar(ctx, userID, middleware.GetUserRole(ctx), calendarID)
+	if err != nil {
+		return nil, r.s.publicError(err, "Failed to get calendar")
+	}
+	return &calendarResolver{s: r.s, calendar: calendar}, nil
+}
+
+// calendarResolver resolves a calendar the current user can view
+// Availabilities and summaries are read with the public token of the calendar
+type calendarResolver struct {
+	s        *GraphQLService
+	calendar *calendarModels.CalendarResponse
+}
+
+func (r *calendarResolver) ID() graphql.ID              { return graphql.ID(r.calendar.ID.String()) }
+func (r *calendarResolver) OwnerID() graphql.ID         { return graphql.ID(r.calendar.OwnerID.String()) }
+func (r *calendarResolver) OrganizationID() *graphql.ID { return optionalID(r.calendar.OrganizationID) }
+func (r *calendarResolver) Name() string                { return r.calendar.Name }
+func (r *calendarResolver) Description() *string        { return optional(r.calendar.Description) }
+func (r *calendarResolver) PublicToken() string         { return r.calendar.PublicToken }
+func (r *calendarResolver) ICSToken() string            { return r.calendar.ICSToken }
+func (r *calendarResolver) Threshold() int32            { return int32(r.calendar.Threshold) }
+func (r *calendarResolver) MinDurationHours() int32     { return int32(r.calendar.MinDurationHours) }
+func (r *calendarResolver) Timezone() string            { return r.calendar.Timezone }
+func (r *calendarResolver) HolidaysPolicy() string      { return r.calendar.HolidaysPolicy }
+func (r *calendarResolver) AllowHolidayEves() bool      { return r.calendar.AllowHolidayEves }
+func (r *calendarResolver) NotifyOnThreshold() bool     { return r.calendar.NotifyOnThreshold }
+func (r *calendarResolver) LockParticipants() bool      { return r.calendar.LockParticipants }
+func (r *calendarResolver) StartDate() *string          { return optionalTime(r.calendar.StartDate) }
+func (r *calendarResolver) EndDate() *string            { return optionalTime(r.calendar.EndDate) }
+func (r *calendarResolver) WeekStart() string           { return r.calendar.WeekStart }
+func (r *calendarResolver) TimeFormat() string          { return r.calendar.TimeFormat }
+func (r *calendarResolver) DateFormat() string          { return r.calendar.DateFormat }
+func (r *calendarResolver) CreatedAt() string           { return timestamp(r.calendar.CreatedAt) }
+func (r *calendarResolver) UpdatedAt() string           { return timestamp(r.calendar.UpdatedAt) }
+
+func (r *calendarResolver) AllowedWeekdays() []int32 {
+	weekdays := make([]int32, 0, len(r.calendar.AllowedWeekdays))
+	for _, weekday := range r.calendar.AllowedWeekdays {
+		weekdays = append(weekdays, int32(weekday))
+	}
+	return weekdays
+}
+
+func (r *calendarResolver) HolidaySets() []string {
+	if r.calendar.HolidaySets == nil {
+		return []string{}
+	}
+	return r.calendar.HolidaySets
+}
+
+func (r *calendarResolver) WeekdayTimes() *jsonScalar {
+	if len(r.calendar.WeekdayTimes) == 0 {
+		return nil
+	}
+	return &jsonScalar{value: r.calendar.WeekdayTimes}
+}
+
+func (r *calendarResolver) Participants() []*participantResolver {
+	result := make([]*participantResolver, 0, len(r.calendar.Participants))
+	for i := range r.calendar.Participants {
+		result = append(result, &participantResolver{s: r.s, participant: &r.calendar.Participants[i], token: r.calendar.PublicToken})
+	}
+	return result
+}
+
+func (r *calendarResolver) Summary(ctx context.Context, args struct {
+	Date     string
+	Timezone *string
+}) (*dateSummaryResolver, error) {
+	summary, err := r.s.availabilities.GetDateSummary(ctx, r.calendar.PublicToken, args.Date, value(args.Timezone))
+	if err != nil {
+		return nil, r.s.publicError(err, "Failed to get date summary")
+	}
+
+	result := &dateSummaryResolver{
+		date:             summary.Date,
+		timezone:         summary.Timezone,
+		totalCount:       summary.TotalCount,
+		requiredMissing:  summary.RequiredMissing,
+		thresholdReached: summary.ThresholdReached,
+		participants:     make([]*availableParticipantResolver, 0, len(summary.Participants)),
+	}
+	for _, p := range summary.Participants {
+		result.participants = append(result.participants, &availableParticipantResolver{
+			participantID: &p.ParticipantID,
+			name:          p.ParticipantName,
+			startTime:     p.StartTime,
+			endTime:       p.EndTime,
+			startAt:       p.StartAt,
+			endAt:         p.EndAt,
+			note:          p.Note,
+		})
+	}
+	return result, nil
+}
+
+func (r *calendarResolver) Summaries(ctx context.Context, args struct {
+	Start         string
+	End           string
+	ParticipantID *graphql.ID
+	Timezone      *string
+}) ([]*dateSummaryResolver, error) {
+	if err := checkRange(args.Start, args.End); err != nil {
+		return nil, err
+	}
+
+	var participantID string
+	if args.ParticipantID != nil {
+		participantID = string(*args.ParticipantID)
+	}
+
+	summaries, err := r.s.availabilities.GetRangeSummary(ctx, r.calendar.PublicToken, args.Start, args.End, participantID, value(args.Timezone))
+	if err != nil {
+		return nil, r.s.publicError(err, "Failed to get range summary")
+	}
+
+	result := make([]*dateSummaryResolver, 0, len(summaries))
+	for _, summary := range summaries {
+		resolver := &dateSummaryResolver{
+			date:             summary.Date,
+			week:             summary.Week,
+			timezone:         summary.Timezone,
+			totalCount:       summary.TotalCount,
+			requiredMissing:  summary.RequiredMissing,
+			thresholdReached: summary.ThresholdReached,
+			participants:     make([]*availableParticipantResolver, 0, len(summary.Participants)),
+		}
+		for _, p := range summary.Participants {
+			resolver.participants = append(resolver.participants, &availableParticipantResolver{
+				participantID: p.ParticipantID,
+				name:          p.ParticipantName,
+				startTime:     p.StartTime,
+				endTime:       p.EndTime,
+				startAt:       p.StartAt,
+				endAt:         p.EndAt,
+				note:          p.Note,
+			})
+		}
+		result = append(result, resolver)
+	}
+	return result, nil
+}
+
+// participantResolver resolves a participant of a calendar, with the public token of the calendar for its availabilities
+type participantResolver struct {
+	s           *GraphQLService
+	participant *calendarModels.Participant
+	token       string
+}
+
+func (r *participantResolver) ID() graphql.ID      { return graphql.ID(r.participant.ID.String()) }
+func (r *participantResolver) Name() string        { return r.participant.Name }
+func (r *participantResolver) Email() *string      { return r.participant.Email }
+func (r *participantResolver) EmailVerified() bool { return r.participant.EmailVerified }
+func (r *participantResolver) Locale() string      { return r.participant.Locale }
+func (r *participantResolver) Required() bool      { return r.participant.Required }
+func (r *participantResolver) CreatedAt() string   { return timestamp(r.participant.CreatedAt) }
+
+func (r *participantResolver) Availabilities(ctx context.Context, args struct {
+	Start *string
+	End   *string
+}) ([]*availabilityResolver, error) {
+	start, end := value(args.Start), value(args.End)
+	if start != "" && end != "" {
+		if err := checkRange(start, end); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := r.s.availabilities.GetParticipantAvailabilities(ctx, r.token, r.participant.ID.String(), start, end)
+	if err != nil {
+		return nil, r.s.publicError(err, "Failed to get participant availabilities")
+	}
+
+	result := make([]*availabilityResolver, 0, len(response.Availabilities))
+	for i := range response.Availabilities {
+		result = append(result, &availabilityResolver{availability: &response.Availabilities[i]})
+	}
+	return result, nil
+}
+
+// availabilityResolver resolves an availability of a participant
+type availabilityResolver struct {
+	availability *availabilityModels.AvailabilityItem
+}
+
+func (r *availabilityResolver) ID() graphql.ID     { return graphql.ID(r.availability.ID.String()) }
+func (r *availabilityResolver) Date() string       { return r.availability.Date }
+func (r *availabilityResolver) StartTime() *string { return r.availability.StartTime }
+func (r *availabilityResolver) EndTime() *string   { return r.availability.EndTime }
+func (r *availabilityResolver) Note() *string      { return optional(r.availability.Note) }
+func (r *availabilityResolver) CreatedAt() string  { return timestamp(r.availability.CreatedAt) }
+func (r *availabilityResolver) UpdatedAt() string  { return timestamp(r.availability.UpdatedAt) }
+
+// dateSummaryResolver resolves the summary of a date, from a date or a range summary
+type dateSummaryResolver struct {
+	date             string
+	week             string
+	timezone         string
+	totalCount       int
+	requiredMissing  int
+	thresholdReached bool
+	participants     []*availableParticipantResolver
+}
+
+func (r *dateSummaryResolver) Date() string                                  { return r.date }
+func (r *dateSummaryResolver) Week() *string                                 { return optional(r.week) }
+func (r *dateSummaryResolver) Timezone() *string                             { return optional(r.timezone) }
+func (r *dateSummaryResolver) TotalCount() int32                             { return int32(r.totalCount) }
+func (r *dateSummaryResolver) RequiredMissing() int32                        { return int32(r.requiredMissing) }
+func (r *dateSummaryResolver) ThresholdReached() bool                        { return r.thresholdReached }
+func (r *dateSummaryResolver) Participants() []*availableParticipantResolver { return r.participants }
+
+// availableParticipantResolver resolves a participant available on a date
+type availableParticipantResolver struct {
+	participantID      *uuid.UUID
+	name               string
+	startTime, endTime *string
+	startAt, endAt     *time.Time
+	note               string
+}
+
+func (r *availableParticipantResolver) ParticipantID() *graphql.ID {
+	return optionalID(r.participantID)
+}
+func (r *availableParticipantResolver) ParticipantName() string { return r.name }
+func (r *availableParticipantResolver) StartTime() *string      { return r.startTime }
+func (r *availableParticipantResolver) EndTime() *string        { return r.endTime }
+func (r *availableParticipantResolver) StartAt() *string        { return optionalTime(r.startAt) }
+func (r *availableParticipantResolver) EndAt() *string          { return optionalTime(r.endAt) }
+func (r *availableParticipantResolver) Note() *string           { return optional(r.note) }
+
+// jsonScalar is a value of the JSON scalar, serialized as is
+type jsonScalar struct {
+	value any
+}
+
+func (jsonScalar) ImplementsGraphQLType(name string) bool { return name == "JSON" }
+
+func (j *jsonScalar) UnmarshalGraphQL(input any) error {
+	j.value = input
+	return nil
+}
+
+func (j jsonScalar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.value)
+}
+
+// optional returns nil for empty strings
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// value returns the value of an optional argument, empty when absent
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func optionalID(id *uuid.UUID) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+	gid := graphql.ID(id.String())
+	return &gid
+}
+
+// timestamp formats a time like the REST API (RFC 3339)
+func timestamp(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func optionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := timestamp(*t)
+	return &s
+}
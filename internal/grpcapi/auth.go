@@ -0,0 +1,118 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/whento/pkg/jwt"
+	"github.com/whento/pkg/middleware"
+	whentov1 "github.com/whento/whento/internal/grpcapi/whento/v1"
+)
+
+// authInterceptor authenticates calls like the Auth middleware of the REST API, from the "authorization" metadata.
+// API tokens are checked against the REST route equivalent to each call, so their scopes apply to both APIs.
+func authInterceptor(jwtManager *jwt.Manager, authenticators []middleware.TokenAuthenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+		}
+
+		parts := strings.SplitN(values[0], " ", 2)
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+		}
+
+		method, path, err := restRoute(req)
+		if err != nil {
+			return nil, err
+		}
+		r, err := http.NewRequestWithContext(ctx, method, path, nil)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		identity, err := middleware.Authenticate(r, parts[1], jwtManager, authenticators...)
+		if errors.Is(err, middleware.ErrTokenScope) {
+			return nil, status.Error(codes.PermissionDenied, "token scope does not allow this call")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+
+		return handler(middleware.WithIdentity(ctx, identity), req)
+	}
+}
+
+// restRoute returns the method and path of the REST route equivalent to a request.
+// The IDs and dates of the path are validated, so that they can't change the route.
+func restRoute(req any) (string, string, error) {
+	switch req := req.(type) {
+	case *whentov1.ListCalendarsRequest:
+		return http.MethodGet, "/api/v1/calendars", nil
+	case *whentov1.CreateCalendarRequest:
+		return http.MethodPost, "/api/v1/calendars", nil
+	case *whentov1.GetCalendarRequest:
+		path, err := calendarPath(req.GetId())
+		return http.MethodGet, path, err
+	case *whentov1.UpdateCalendarRequest:
+		path, err := calendarPath(req.GetId())
+		return http.MethodPatch, path, err
+	case *whentov1.DeleteCalendarRequest:
+		path, err := calendarPath(req.GetId())
+		return http.MethodDelete, path, err
+	case *whentov1.ListAvailabilitiesRequest:
+		path, err := availabilitiesPath(req.GetCalendarId(), req.GetParticipantId())
+		return http.MethodGet, path, err
+	case *whentov1.SubmitAvailabilityRequest:
+		path, err := availabilitiesPath(req.GetCalendarId(), req.GetParticipantId())
+		return http.MethodPost, path, err
+	case *whentov1.DeleteAvailabilityRequest:
+		path, err := availabilitiesPath(req.GetCalendarId(), req.GetParticipantId())
+		if err != nil {
+			return "", "", err
+		}
+		if _, err := time.Parse(time.DateOnly, req.GetDate()); err != nil {
+			return "", "", errInvalidDate
+		}
+		return http.MethodDelete, path + "/" + req.GetDate(), nil
+	default:
+		return "", "", status.Error(codes.Unimplemented, "unknown method")
+	}
+}
+
+// calendarPath returns the REST path of a calendar
+func calendarPath(calendarID string) (string, error) {
+	id, err := uuid.Parse(calendarID)
+	if err != nil {
+		return "", errInvalidCalendarID
+	}
+	return "/api/v1/calendars/" + id.String(), nil
+}
+
+// availabilitiesPath returns the REST path of the availabilities of a participant
+func availabilitiesPath(calendarID, participantID string) (string, error) {
+	path, err := calendarPath(calendarID)
+	if err != nil {
+		return "", err
+	}
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return "", errInvalidParticipantID
+	}
+	return path + "/participants/" + id.String() + "/availabilities", nil
+}
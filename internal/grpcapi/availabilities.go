@@ -0,0 +1,87 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/whento/pkg/middleware"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	availabilityService "github.com/whento/whento/internal/availability/service"
+	whentov1 "github.com/whento/whento/internal/grpcapi/whento/v1"
+)
+
+// ListAvailabilities lists the availabilities of a participant, optionally within a range of dates
+func (s *Server) ListAvailabilities(ctx context.Context, req *whentov1.ListAvailabilitiesRequest) (*whentov1.ListAvailabilitiesResponse, error) {
+	if err := checkRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return nil, err
+	}
+	token, err := s.publicToken(ctx, req.GetCalendarId())
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.availabilities.GetParticipantAvailabilities(ctx, token, req.GetParticipantId(), req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, s.statusError(err, "Failed to get participant availabilities")
+	}
+
+	resp := &whentov1.ListAvailabilitiesResponse{Availabilities: make([]*whentov1.Availability, 0, len(participant.Availabilities))}
+	for i := range participant.Availabilities {
+		resp.Availabilities = append(resp.Availabilities, toAvailabilityItem(req.GetParticipantId(), &participant.Availabilities[i]))
+	}
+	return resp, nil
+}
+
+// SubmitAvailability creates the availability of a participant on a date, or replaces the existing one
+func (s *Server) SubmitAvailability(ctx context.Context, req *whentov1.SubmitAvailabilityRequest) (*whentov1.Availability, error) {
+	token, err := s.publicToken(ctx, req.GetCalendarId())
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := s.availabilities.CreateAvailability(ctx, token, req.GetParticipantId(), &availabilityModels.CreateAvailabilityRequest{
+		Date:      req.GetDate(),
+		StartTime: stringPtr(req.GetStartTime()),
+		EndTime:   stringPtr(req.GetEndTime()),
+		Note:      req.GetNote(),
+	})
+	if errors.Is(err, availabilityService.ErrAvailabilityExists) {
+		// Empty values clear the times and the note of the existing availability
+		startTime, endTime, note := req.GetStartTime(), req.GetEndTime(), req.GetNote()
+		availability, err = s.availabilities.UpdateAvailability(ctx, token, req.GetParticipantId(), req.GetDate(), &availabilityModels.UpdateAvailabilityRequest{
+			StartTime: &startTime,
+			EndTime:   &endTime,
+			Note:      &note,
+		})
+	}
+	if err != nil {
+		return nil, s.statusError(err, "Failed to submit availability")
+	}
+	return toAvailability(availability), nil
+}
+
+// DeleteAvailability deletes the availability of a participant on a date
+func (s *Server) DeleteAvailability(ctx context.Context, req *whentov1.DeleteAvailabilityRequest) (*whentov1.DeleteAvailabilityResponse, error) {
+	token, err := s.publicToken(ctx, req.GetCalendarId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.availabilities.DeleteAvailability(ctx, token, req.GetParticipantId(), req.GetDate()); err != nil {
+		return nil, s.statusError(err, "Failed to delete availability")
+	}
+	return &whentov1.DeleteAvailabilityResponse{}, nil
+}
+
+// publicToken returns the public token of a calendar the user can view, which identifies it to the availability service
+func (s *Server) publicToken(ctx context.Context, calendarID string) (string, error) {
+	token, err := s.calendars.PublicToken(ctx, middleware.GetUserID(ctx), middleware.GetUserRole(ctx), calendarID)
+	if err != nil {
+		return "", s.statusError(err, "Failed to get calendar")
+	}
+	return token, nil
+}
@@ -0,0 +1,191 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	whentov1 "github.com/whento/whento/internal/grpcapi/whento/v1"
+	orgModels "github.com/whento/whento/internal/organization/models"
+)
+
+// ListCalendars lists the personal calendars of the user, or the calendars of an organization
+func (s *Server) ListCalendars(ctx context.Context, req *whentov1.ListCalendarsRequest) (*whentov1.ListCalendarsResponse, error) {
+	membership, err := s.membership(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	var organizationID *uuid.UUID
+	if membership != nil {
+		organizationID = &membership.OrganizationID
+	}
+
+	calendars, err := s.calendars.ListMyCalendars(ctx, middleware.GetUserID(ctx), organizationID)
+	if err != nil {
+		return nil, s.statusError(err, "Failed to list calendars")
+	}
+
+	resp := &whentov1.ListCalendarsResponse{Calendars: make([]*whentov1.Calendar, 0, len(calendars))}
+	for _, calendar := range calendars {
+		resp.Calendars = append(resp.Calendars, toCalendar(calendar))
+	}
+	return resp, nil
+}
+
+// GetCalendar returns a calendar the user can view
+func (s *Server) GetCalendar(ctx context.Context, req *whentov1.GetCalendarRequest) (*whentov1.Calendar, error) {
+	calendar, err := s.calendars.GetCalendar(ctx, middleware.GetUserID(ctx), middleware.GetUserRole(ctx), req.GetId())
+	if err != nil {
+		return nil, s.statusError(err, "Failed to get calendar")
+	}
+	return toCalendar(calendar), nil
+}
+
+// CreateCalendar creates a calendar, with the same checks as the REST API
+func (s *Server) CreateCalendar(ctx context.Context, req *whentov1.CreateCalendarRequest) (*whentov1.Calendar, error) {
+	userID := middleware.GetUserID(ctx)
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user ID")
+	}
+
+	createReq := toCreateCalendarRequest(req)
+	if err := validator.Validate(createReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkRange(createReq.StartDate, createReq.EndDate); err != nil {
+		return nil, err
+	}
+	if err := checkTimezone(createReq.Timezone); err != nil {
+		return nil, err
+	}
+
+	participants := make(map[string]bool)
+	for _, name := range createReq.Participants {
+		if name == "" {
+			continue
+		}
+		if participants[name] {
+			return nil, status.Error(codes.InvalidArgument, "duplicate participant name: "+name)
+		}
+		participants[name] = true
+	}
+	if len(participants) > 0 && createReq.Threshold > len(participants) {
+		return nil, status.Error(codes.InvalidArgument, "threshold cannot exceed the number of participants")
+	}
+
+	if s.cfg.Email.VerificationEnabled {
+		user, err := s.users.GetByID(ctx, userUUID)
+		if err != nil {
+			s.logger.Error("Failed to get user", "error", err, "user_id", userID)
+			return nil, errInternal
+		}
+		if !user.EmailVerified {
+			return nil, errEmailNotVerified
+		}
+	}
+
+	// In an organization, owners and admins create calendars counting against the quota of the owner
+	membership, err := s.membership(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	var organizationID *uuid.UUID
+	quotaUserID := userUUID
+	if membership != nil {
+		if !membership.CanManage() {
+			return nil, errNotManager
+		}
+		organizationID = &membership.OrganizationID
+		quotaUserID = membership.OwnerID
+	}
+
+	canCreate, err := s.quotaService.CanCreateCalendar(ctx, quotaUserID)
+	if err != nil {
+		s.logger.Error("Failed to check quota", "error", err, "user_id", userID)
+		return nil, errInternal
+	}
+	if !canCreate {
+		return nil, s.quotaError(ctx, quotaUserID)
+	}
+
+	calendar, err := s.calendars.CreateCalendar(ctx, userID, organizationID, createReq)
+	if err != nil {
+		return nil, s.statusError(err, "Failed to create calendar")
+	}
+	return toCalendar(calendar), nil
+}
+
+// UpdateCalendar updates the fields set in the request
+func (s *Server) UpdateCalendar(ctx context.Context, req *whentov1.UpdateCalendarRequest) (*whentov1.Calendar, error) {
+	updateReq := toUpdateCalendarRequest(req)
+	if err := validator.Validate(updateReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return nil, err
+	}
+	if err := checkTimezone(req.GetTimezone()); err != nil {
+		return nil, err
+	}
+
+	calendar, err := s.calendars.UpdateCalendar(ctx, middleware.GetUserID(ctx), middleware.GetUserRole(ctx), req.GetId(), updateReq)
+	if err != nil {
+		return nil, s.statusError(err, "Failed to update calendar")
+	}
+	return toCalendar(calendar), nil
+}
+
+// DeleteCalendar deletes a calendar
+func (s *Server) DeleteCalendar(ctx context.Context, req *whentov1.DeleteCalendarRequest) (*whentov1.DeleteCalendarResponse, error) {
+	if err := s.calendars.DeleteCalendar(ctx, middleware.GetUserID(ctx), middleware.GetUserRole(ctx), req.GetId()); err != nil {
+		return nil, s.statusError(err, "Failed to delete calendar")
+	}
+	return &whentov1.DeleteCalendarResponse{}, nil
+}
+
+// quotaError returns the error of a calendar quota reached, with the messages of the REST API
+func (s *Server) quotaError(ctx context.Context, quotaUserID uuid.UUID) error {
+	userLimit, _ := s.quotaService.GetUserLimit(ctx, quotaUserID)
+	serverLimit, _ := s.quotaService.GetServerLimit(ctx)
+
+	message := "calendar limit reached"
+	if serverLimit > 0 {
+		message = "server calendar limit reached, please upgrade your license at https://whento.be/pricing"
+	} else if userLimit > 0 {
+		message = "calendar limit reached for your plan, please upgrade your subscription"
+	}
+	return status.Error(codes.ResourceExhausted, message)
+}
+
+// membership returns the membership of the user in an organization, nil without organization ID
+func (s *Server) membership(ctx context.Context, organizationID string) (*orgModels.Membership, error) {
+	if organizationID == "" {
+		return nil, nil
+	}
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, errInvalidOrganizationID
+	}
+	userUUID, err := uuid.Parse(middleware.GetUserID(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user ID")
+	}
+
+	membership, err := s.organizations.Membership(ctx, orgID, userUUID)
+	if err != nil {
+		return nil, s.statusError(err, "Failed to check organization membership")
+	}
+	if membership == nil {
+		return nil, errNotMember
+	}
+	return membership, nil
+}
@@ -0,0 +1,257 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package grpcapi
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	availabilityService "github.com/whento/whento/internal/availability/service"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	calendarService "github.com/whento/whento/internal/calendar/service"
+	whentov1 "github.com/whento/whento/internal/grpcapi/whento/v1"
+	orgService "github.com/whento/whento/internal/organization/service"
+)
+
+var (
+	errInvalidCalendarID     = status.Error(codes.InvalidArgument, "invalid calendar ID")
+	errInvalidParticipantID  = status.Error(codes.InvalidArgument, "invalid participant ID")
+	errInvalidOrganizationID = status.Error(codes.InvalidArgument, "invalid organization ID")
+	errInvalidDate           = status.Error(codes.InvalidArgument, "invalid date format, expected YYYY-MM-DD")
+	errInvalidRange          = status.Error(codes.InvalidArgument, "end date must be on or after start date")
+	errInvalidTimezone       = status.Error(codes.InvalidArgument, "invalid timezone, expected an IANA timezone name")
+	errNotMember             = status.Error(codes.PermissionDenied, "not a member of this organization")
+	errNotManager            = status.Error(codes.PermissionDenied, "only owners and admins of the organization can create calendars")
+	errEmailNotVerified      = status.Error(codes.FailedPrecondition, "please verify your email address before creating calendars")
+	errInternal              = status.Error(codes.Internal, "internal error")
+)
+
+// errorCodes are the codes of the service errors reported to clients, other errors are internal
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{calendarService.ErrCalendarNotFound, codes.NotFound},
+	{calendarService.ErrUnauthorized, codes.PermissionDenied},
+	{orgService.ErrOrganizationNotFound, codes.NotFound},
+	{availabilityService.ErrCalendarNotFound, codes.NotFound},
+	{availabilityService.ErrParticipantNotFound, codes.NotFound},
+	{availabilityService.ErrAvailabilityNotFound, codes.NotFound},
+	{availabilityService.ErrAvailabilityExists, codes.AlreadyExists},
+	{availabilityService.ErrInvalidDate, codes.InvalidArgument},
+	{availabilityService.ErrInvalidTime, codes.InvalidArgument},
+	{availabilityService.ErrInvalidTimeRange, codes.InvalidArgument},
+	{availabilityService.ErrTimeOutsideAllowedHours, codes.InvalidArgument},
+	{availabilityService.ErrDurationTooShort, codes.InvalidArgument},
+	{availabilityService.ErrWeekdayNotAllowed, codes.InvalidArgument},
+	{availabilityService.ErrDateInPast, codes.InvalidArgument},
+	{availabilityService.ErrInvalidTimezone, codes.InvalidArgument},
+}
+
+// statusError returns the status of a service error, hiding and logging unexpected errors
+func (s *Server) statusError(err error, message string) error {
+	for _, known := range errorCodes {
+		if errors.Is(err, known.err) {
+			return status.Error(known.code, known.err.Error())
+		}
+	}
+	s.logger.Error(message, "error", err)
+	return errInternal
+}
+
+// checkDate validates an optional date (YYYY-MM-DD)
+func checkDate(date string) error {
+	if date == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return errInvalidDate
+	}
+	return nil
+}
+
+// checkRange validates an optional range of dates (YYYY-MM-DD)
+func checkRange(start, end string) error {
+	if err := checkDate(start); err != nil {
+		return err
+	}
+	if err := checkDate(end); err != nil {
+		return err
+	}
+	if start != "" && end != "" && end < start {
+		return errInvalidRange
+	}
+	return nil
+}
+
+// checkTimezone validates an optional IANA timezone name
+func checkTimezone(timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errInvalidTimezone
+	}
+	return nil
+}
+
+// toCalendar converts a calendar response to its message
+func toCalendar(calendar *calendarModels.CalendarResponse) *whentov1.Calendar {
+	message := &whentov1.Calendar{
+		Id:                calendar.ID.String(),
+		OwnerId:           calendar.OwnerID.String(),
+		Name:              calendar.Name,
+		Description:       calendar.Description,
+		PublicToken:       calendar.PublicToken,
+		IcsToken:          calendar.ICSToken,
+		Threshold:         int32(calendar.Threshold),
+		AllowedWeekdays:   toInt32s(calendar.AllowedWeekdays),
+		MinDurationHours:  int32(calendar.MinDurationHours),
+		Timezone:          calendar.Timezone,
+		HolidaysPolicy:    calendar.HolidaysPolicy,
+		AllowHolidayEves:  calendar.AllowHolidayEves,
+		NotifyOnThreshold: calendar.NotifyOnThreshold,
+		LockParticipants:  calendar.LockParticipants,
+		Participants:      make([]*whentov1.Participant, 0, len(calendar.Participants)),
+		CreatedAt:         timestamppb.New(calendar.CreatedAt),
+		UpdatedAt:         timestamppb.New(calendar.UpdatedAt),
+	}
+	if calendar.OrganizationID != nil {
+		message.OrganizationId = calendar.OrganizationID.String()
+	}
+	if calendar.StartDate != nil {
+		message.StartDate = calendar.StartDate.Format(time.DateOnly)
+	}
+	if calendar.EndDate != nil {
+		message.EndDate = calendar.EndDate.Format(time.DateOnly)
+	}
+
+	for _, participant := range calendar.Participants {
+		converted := &whentov1.Participant{
+			Id:            participant.ID.String(),
+			Name:          participant.Name,
+			EmailVerified: participant.EmailVerified,
+			Locale:        participant.Locale,
+			CreatedAt:     timestamppb.New(participant.CreatedAt),
+		}
+		if participant.Email != nil {
+			converted.Email = *participant.Email
+		}
+		message.Participants = append(message.Participants, converted)
+	}
+	return message
+}
+
+// toCreateCalendarRequest converts a create calendar message to its request
+func toCreateCalendarRequest(message *whentov1.CreateCalendarRequest) *calendarModels.CreateCalendarRequest {
+	return &calendarModels.CreateCalendarRequest{
+		Name:              message.GetName(),
+		Description:       message.GetDescription(),
+		Threshold:         int(message.GetThreshold()),
+		AllowedWeekdays:   toInts(message.GetAllowedWeekdays()),
+		MinDurationHours:  int(message.GetMinDurationHours()),
+		Timezone:          message.GetTimezone(),
+		HolidaysPolicy:    message.GetHolidaysPolicy(),
+		AllowHolidayEves:  message.GetAllowHolidayEves(),
+		NotifyOnThreshold: message.GetNotifyOnThreshold(),
+		LockParticipants:  message.GetLockParticipants(),
+		StartDate:         message.GetStartDate(),
+		EndDate:           message.GetEndDate(),
+		Participants:      message.GetParticipants(),
+	}
+}
+
+// toUpdateCalendarRequest converts an update calendar message to its request, keeping unset fields unchanged
+func toUpdateCalendarRequest(message *whentov1.UpdateCalendarRequest) *calendarModels.UpdateCalendarRequest {
+	return &calendarModels.UpdateCalendarRequest{
+		Name:              message.Name,
+		Description:       message.Description,
+		Threshold:         intPtr(message.Threshold),
+		AllowedWeekdays:   toInts(message.GetAllowedWeekdays()),
+		MinDurationHours:  intPtr(message.MinDurationHours),
+		Timezone:          message.Timezone,
+		HolidaysPolicy:    message.HolidaysPolicy,
+		AllowHolidayEves:  message.AllowHolidayEves,
+		NotifyOnThreshold: message.NotifyOnThreshold,
+		LockParticipants:  message.LockParticipants,
+		StartDate:         message.StartDate,
+		EndDate:           message.EndDate,
+	}
+}
+
+// toAvailability converts an availability response to its message
+func toAvailability(availability *availabilityModels.AvailabilityResponse) *whentov1.Availability {
+	return &whentov1.Availability{
+		Id:            availability.ID.String(),
+		ParticipantId: availability.ParticipantID.String(),
+		Date:          availability.Date,
+		StartTime:     stringValue(availability.StartTime),
+		EndTime:       stringValue(availability.EndTime),
+		Note:          availability.Note,
+		CreatedAt:     timestamppb.New(availability.CreatedAt),
+		UpdatedAt:     timestamppb.New(availability.UpdatedAt),
+	}
+}
+
+// toAvailabilityItem converts an availability of a participant to its message
+func toAvailabilityItem(participantID string, item *availabilityModels.AvailabilityItem) *whentov1.Availability {
+	return &whentov1.Availability{
+		Id:            item.ID.String(),
+		ParticipantId: participantID,
+		Date:          item.Date,
+		StartTime:     stringValue(item.StartTime),
+		EndTime:       stringValue(item.EndTime),
+		Note:          item.Note,
+		CreatedAt:     timestamppb.New(item.CreatedAt),
+		UpdatedAt:     timestamppb.New(item.UpdatedAt),
+	}
+}
+
+func toInt32s(values []int) []int32 {
+	converted := make([]int32, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, int32(value))
+	}
+	return converted
+}
+
+func toInts(values []int32) []int {
+	if len(values) == 0 {
+		return nil
+	}
+	converted := make([]int, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, int(value))
+	}
+	return converted
+}
+
+func intPtr(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	converted := int(*value)
+	return &converted
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// stringPtr returns nil for an empty string (e.g. a whole day availability)
+func stringPtr(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
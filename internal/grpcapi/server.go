@@ -0,0 +1,117 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+// Package grpcapi serves the gRPC API of WhenTo (proto/whento/v1/whento.proto) for server-to-server integrations.
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/whento/pkg/jwt"
+	"github.com/whento/pkg/middleware"
+	authModels "github.com/whento/whento/internal/auth/models"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/config"
+	whentov1 "github.com/whento/whento/internal/grpcapi/whento/v1"
+	orgModels "github.com/whento/whento/internal/organization/models"
+	"github.com/whento/whento/internal/quota"
+)
+
+// CalendarManager manages the calendars of users
+type CalendarManager interface {
+	ListMyCalendars(ctx context.Context, userID string, organizationID *uuid.UUID) ([]*calendarModels.CalendarResponse, error)
+	GetCalendar(ctx context.Context, userID, userRole, calendarID string) (*calendarModels.CalendarResponse, error)
+	CreateCalendar(ctx context.Context, userID string, organizationID *uuid.UUID, req *calendarModels.CreateCalendarRequest) (*calendarModels.CalendarResponse, error)
+	UpdateCalendar(ctx context.Context, userID, userRole, calendarID string, req *calendarModels.UpdateCalendarRequest) (*calendarModels.CalendarResponse, error)
+	DeleteCalendar(ctx context.Context, userID, userRole, calendarID string) error
+	PublicToken(ctx context.Context, userID, userRole, calendarID string) (string, error)
+}
+
+// AvailabilityManager manages the availabilities of participants, by public token of their calendar
+type AvailabilityManager interface {
+	GetParticipantAvailabilities(ctx context.Context, token, participantID, startDateStr, endDateStr string) (*availabilityModels.ParticipantAvailabilitiesResponse, error)
+	CreateAvailability(ctx context.Context, token, participantID string, req *availabilityModels.CreateAvailabilityRequest) (*availabilityModels.AvailabilityResponse, error)
+	UpdateAvailability(ctx context.Context, token, participantID, dateStr string, req *availabilityModels.UpdateAvailabilityRequest) (*availabilityModels.AvailabilityResponse, error)
+	DeleteAvailability(ctx context.Context, token, participantID, dateStr string) error
+}
+
+// MembershipReader returns the membership of a user in an organization (nil if not a member)
+type MembershipReader interface {
+	Membership(ctx context.Context, organizationID, userID uuid.UUID) (*orgModels.Membership, error)
+}
+
+// UserReader returns users
+type UserReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*authModels.User, error)
+}
+
+// Server implements the gRPC services of WhenTo on top of the application services
+type Server struct {
+	whentov1.UnimplementedCalendarServiceServer
+	whentov1.UnimplementedAvailabilityServiceServer
+
+	calendars      CalendarManager
+	availabilities AvailabilityManager
+	organizations  MembershipReader
+	users          UserReader
+	quotaService   quota.QuotaService
+	cfg            *config.Config
+	logger         *slog.Logger
+}
+
+// NewServer creates a new gRPC API server
+func NewServer(
+	calendars CalendarManager,
+	availabilities AvailabilityManager,
+	organizations MembershipReader,
+	users UserReader,
+	quotaService quota.QuotaService,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *Server {
+	return &Server{
+		calendars:      calendars,
+		availabilities: availabilities,
+		organizations:  organizations,
+		users:          users,
+		quotaService:   quotaService,
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+// GRPCServer returns a gRPC server of the services, authenticating calls with JWTs and the API tokens of authenticators
+func (s *Server) GRPCServer(jwtManager *jwt.Manager, authenticators ...middleware.TokenAuthenticator) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		traceInterceptor,
+		s.recoverPanic,
+		authInterceptor(jwtManager, authenticators),
+	))
+	whentov1.RegisterCalendarServiceServer(server, s)
+	whentov1.RegisterAvailabilityServiceServer(server, s)
+
+	// Lets tools such as grpcurl discover the services
+	reflection.Register(server)
+	return server
+}
+
+// recoverPanic turns panics of handlers into internal errors, like the Recoverer middleware of the HTTP server
+func (s *Server) recoverPanic(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			s.logger.Error("Panic in gRPC handler", "method", info.FullMethod, "panic", p, "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
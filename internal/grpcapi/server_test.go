@@ -0,0 +1,424 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/whento/pkg/middleware"
+	authModels "github.com/whento/whento/internal/auth/models"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	availabilityService "github.com/whento/whento/internal/availability/service"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	calendarService "github.com/whento/whento/internal/calendar/service"
+	"github.com/whento/whento/internal/config"
+	whentov1 "github.com/whento/whento/internal/grpcapi/whento/v1"
+	orgModels "github.com/whento/whento/internal/organization/models"
+	"github.com/whento/whento/internal/quota"
+)
+
+var (
+	testUserID        = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	testCalendarID    = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	testParticipantID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+)
+
+type mockCalendars struct {
+	err     error
+	created *calendarModels.CreateCalendarRequest
+}
+
+func (m *mockCalendars) calendar() *calendarModels.CalendarResponse {
+	participant := calendarModels.Participant{Name: "Alice", Locale: "en"}
+	participant.ID = testParticipantID
+	return &calendarModels.CalendarResponse{
+		ID:              testCalendarID,
+		OwnerID:         testUserID,
+		Name:            "Board games",
+		PublicToken:     "public-token",
+		Threshold:       2,
+		AllowedWeekdays: []int{5, 6},
+		Participants:    []calendarModels.Participant{participant},
+	}
+}
+
+func (m *mockCalendars) ListMyCalendars(ctx context.Context, userID string, organizationID *uuid.UUID) ([]*calendarModels.CalendarResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*calendarModels.CalendarResponse{m.calendar()}, nil
+}
+
+func (m *mockCalendars) GetCalendar(ctx context.Context, userID, userRole, calendarID string) (*calendarModels.CalendarResponse, error) {
+	if calendarID != testCalendarID.String() {
+		return nil, calendarService.ErrCalendarNotFound
+	}
+	return m.calendar(), nil
+}
+
+func (m *mockCalendars) CreateCalendar(ctx context.Context, userID string, organizationID *uuid.UUID, req *calendarModels.CreateCalendarRequest) (*calendarModels.CalendarResponse, error) {
+	m.created = req
+	return m.calendar(), nil
+}
+
+func (m *mockCalendars) UpdateCalendar(ctx context.Context, userID, userRole, calendarID string, req *calendarModels.UpdateCalendarRequest) (*calendarModels.CalendarResponse, error) {
+	return m.calendar(), nil
+}
+
+func (m *mockCalendars) DeleteCalendar(ctx context.Context, userID, userRole, calendarID string) error {
+	return m.err
+}
+
+func (m *mockCalendars) PublicToken(ctx context.Context, userID, userRole, calendarID string) (string, error) {
+	if calendarID != testCalendarID.String() {
+		return "", calendarService.ErrCalendarNotFound
+	}
+	return "public-token", nil
+}
+
+type mockAvailabilities struct {
+	exists  bool
+	updated *availabilityModels.UpdateAvailabilityRequest
+}
+
+func (m *mockAvailabilities) GetParticipantAvailabilities(ctx context.Context, token, participantID, startDateStr, endDateStr string) (*availabilityModels.ParticipantAvailabilitiesResponse, error) {
+	return &availabilityModels.ParticipantAvailabilitiesResponse{
+		Availabilities: []availabilityModels.AvailabilityItem{{Date: "2025-06-01", Note: token}},
+	}, nil
+}
+
+func (m *mockAvailabilities) CreateAvailability(ctx context.Context, token, participantID string, req *availabilityModels.CreateAvailabilityRequest) (*availabilityModels.AvailabilityResponse, error) {
+	if m.exists {
+		return nil, availabilityService.ErrAvailabilityExists
+	}
+	return &availabilityModels.AvailabilityResponse{Date: req.Date, StartTime: req.StartTime, EndTime: req.EndTime, Note: req.Note}, nil
+}
+
+func (m *mockAvailabilities) UpdateAvailability(ctx context.Context, token, participantID, dateStr string, req *availabilityModels.UpdateAvailabilityRequest) (*availabilityModels.AvailabilityResponse, error) {
+	m.updated = req
+	return &availabilityModels.AvailabilityResponse{Date: dateStr, StartTime: req.StartTime, EndTime: req.EndTime, Note: *req.Note}, nil
+}
+
+func (m *mockAvailabilities) DeleteAvailability(ctx context.Context, token, participantID, dateStr string) error {
+	return availabilityService.ErrAvailabilityNotFound
+}
+
+type mockOrganizations struct{}
+
+func (m *mockOrganizations) Membership(ctx context.Context, organizationID, userID uuid.UUID) (*orgModels.Membership, error) {
+	return nil, nil
+}
+
+type mockUsers struct{}
+
+func (m *mockUsers) GetByID(ctx context.Context, id uuid.UUID) (*authModels.User, error) {
+	return &authModels.User{EmailVerified: true}, nil
+}
+
+type mockQuota struct {
+	quota.QuotaService
+	canCreate bool
+}
+
+func (m *mockQuota) CanCreateCalendar(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return m.canCreate, nil
+}
+
+func (m *mockQuota) GetUserLimit(ctx context.Context, userID uuid.UUID) (int, error) {
+	return 3, nil
+}
+
+func (m *mockQuota) GetServerLimit(ctx context.Context) (int, error) {
+	return -1, nil
+}
+
+// mockTokens authenticates "read_" tokens for GET routes and "write_" tokens for all routes
+type mockTokens struct{}
+
+func (m *mockTokens) IsToken(value string) bool {
+	return strings.HasPrefix(value, "read_") || strings.HasPrefix(value, "write_")
+}
+
+func (m *mockTokens) AuthenticateToken(r *http.Request, value string) (*middleware.TokenIdentity, error) {
+	if strings.HasPrefix(value, "read_") && r.Method != http.MethodGet {
+		return nil, middleware.ErrTokenScope
+	}
+	return &middleware.TokenIdentity{UserID: testUserID.String(), Role: "user"}, nil
+}
+
+type testClients struct {
+	calendars      whentov1.CalendarServiceClient
+	availabilities whentov1.AvailabilityServiceClient
+}
+
+func newTestClients(t *testing.T, calendars *mockCalendars, availabilities *mockAvailabilities, quotaService *mockQuota) testClients {
+	t.Helper()
+
+	server := NewServer(calendars, availabilities, &mockOrganizations{}, &mockUsers{}, quotaService, &config.Config{},
+		slog.New(slog.NewTextHandler(io.Discard, nil))).GRPCServer(nil, &mockTokens{})
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return testClients{
+		calendars:      whentov1.NewCalendarServiceClient(conn),
+		availabilities: whentov1.NewAvailabilityServiceClient(conn),
+	}
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestServer_Calls(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(c testClients) error
+		calendar *mockCalendars
+		quota    *mockQuota
+		want     codes.Code
+	}{
+		{
+			name: "missing token",
+			call: func(c testClients) error {
+				_, err := c.calendars.ListCalendars(context.Background(), &whentov1.ListCalendarsRequest{})
+				return err
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "list calendars",
+			call: func(c testClients) error {
+				resp, err := c.calendars.ListCalendars(withToken("read_token"), &whentov1.ListCalendarsRequest{})
+				if err == nil && (len(resp.GetCalendars()) != 1 || resp.GetCalendars()[0].GetParticipants()[0].GetId() != testParticipantID.String()) {
+					return errors.New("unexpected calendars")
+				}
+				return err
+			},
+			want: codes.OK,
+		},
+		{
+			name: "scope of the token",
+			call: func(c testClients) error {
+				_, err := c.calendars.DeleteCalendar(withToken("read_token"), &whentov1.DeleteCalendarRequest{Id: testCalendarID.String()})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "invalid calendar ID",
+			call: func(c testClients) error {
+				_, err := c.calendars.GetCalendar(withToken("read_token"), &whentov1.GetCalendarRequest{Id: "nope"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "calendar not found",
+			call: func(c testClients) error {
+				_, err := c.calendars.GetCalendar(withToken("read_token"), &whentov1.GetCalendarRequest{Id: uuid.NewString()})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "internal errors are hidden",
+			call: func(c testClients) error {
+				_, err := c.calendars.ListCalendars(withToken("read_token"), &whentov1.ListCalendarsRequest{})
+				if status.Convert(err).Message() != "internal error" {
+					return errors.New("internal error leaked")
+				}
+				return err
+			},
+			calendar: &mockCalendars{err: errors.New("connection refused")},
+			want:     codes.Internal,
+		},
+		{
+			name: "duplicate participants",
+			call: func(c testClients) error {
+				_, err := c.calendars.CreateCalendar(withToken("write_token"), &whentov1.CreateCalendarRequest{Name: "Board games", Participants: []string{"Alice", "Alice"}})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "quota reached",
+			call: func(c testClients) error {
+				_, err := c.calendars.CreateCalendar(withToken("write_token"), &whentov1.CreateCalendarRequest{Name: "Board games"})
+				return err
+			},
+			quota: &mockQuota{canCreate: false},
+			want:  codes.ResourceExhausted,
+		},
+		{
+			name: "not a member of the organization",
+			call: func(c testClients) error {
+				_, err := c.calendars.CreateCalendar(withToken("write_token"), &whentov1.CreateCalendarRequest{Name: "Board games", OrganizationId: uuid.NewString()})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "availability not found",
+			call: func(c testClients) error {
+				_, err := c.availabilities.DeleteAvailability(withToken("write_token"), &whentov1.DeleteAvailabilityRequest{
+					CalendarId: testCalendarID.String(), ParticipantId: testParticipantID.String(), Date: "2030-06-01",
+				})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "invalid range",
+			call: func(c testClients) error {
+				_, err := c.availabilities.ListAvailabilities(withToken("read_token"), &whentov1.ListAvailabilitiesRequest{
+					CalendarId: testCalendarID.String(), ParticipantId: testParticipantID.String(), StartDate: "2030-06-30", EndDate: "2030-06-01",
+				})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendars := tt.calendar
+			if calendars == nil {
+				calendars = &mockCalendars{}
+			}
+			quotaService := tt.quota
+			if quotaService == nil {
+				quotaService = &mockQuota{canCreate: true}
+			}
+			clients := newTestClients(t, calendars, &mockAvailabilities{}, quotaService)
+
+			if got := status.Code(tt.call(clients)); got != tt.want {
+				t.Errorf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_CreateCalendar(t *testing.T) {
+	calendars := &mockCalendars{}
+	clients := newTestClients(t, calendars, &mockAvailabilities{}, &mockQuota{canCreate: true})
+
+	calendar, err := clients.calendars.CreateCalendar(withToken("write_token"), &whentov1.CreateCalendarRequest{
+		Name:            "Board games",
+		Threshold:       2,
+		AllowedWeekdays: []int32{5, 6},
+		Participants:    []string{"Alice", "Bob"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCalendar() error = %v", err)
+	}
+	if calendar.GetId() != testCalendarID.String() || len(calendar.GetAllowedWeekdays()) != 2 {
+		t.Errorf("CreateCalendar() = %v", calendar)
+	}
+	if calendars.created.Name != "Board games" || calendars.created.Threshold != 2 || len(calendars.created.Participants) != 2 {
+		t.Errorf("created = %+v", calendars.created)
+	}
+}
+
+func TestServer_SubmitAvailability(t *testing.T) {
+	tests := []struct {
+		name        string
+		exists      bool
+		wantUpdated bool
+	}{
+		{"creates", false, false},
+		{"replaces an existing availability", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			availabilities := &mockAvailabilities{exists: tt.exists}
+			clients := newTestClients(t, &mockCalendars{}, availabilities, &mockQuota{canCreate: true})
+
+			availability, err := clients.availabilities.SubmitAvailability(withToken("write_token"), &whentov1.SubmitAvailabilityRequest{
+				CalendarId:    testCalendarID.String(),
+				ParticipantId: testParticipantID.String(),
+				Date:          "2030-06-01",
+				StartTime:     "18:00",
+				Note:          "After work",
+			})
+			if err != nil {
+				t.Fatalf("SubmitAvailability() error = %v", err)
+			}
+			if availability.GetDate() != "2030-06-01" || availability.GetStartTime() != "18:00" || availability.GetEndTime() != "" || availability.GetNote() != "After work" {
+				t.Errorf("SubmitAvailability() = %v", availability)
+			}
+			if (availabilities.updated != nil) != tt.wantUpdated {
+				t.Errorf("updated = %v, want %v", availabilities.updated != nil, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestRestRoute(t *testing.T) {
+	calendarPath := "/api/v1/calendars/" + testCalendarID.String()
+	availabilitiesPath := calendarPath + "/participants/" + testParticipantID.String() + "/availabilities"
+
+	tests := []struct {
+		name       string
+		req        any
+		wantMethod string
+		wantPath   string
+		wantCode   codes.Code
+	}{
+		{"list calendars", &whentov1.ListCalendarsRequest{}, http.MethodGet, "/api/v1/calendars", codes.OK},
+		{"create calendar", &whentov1.CreateCalendarRequest{}, http.MethodPost, "/api/v1/calendars", codes.OK},
+		{"update calendar", &whentov1.UpdateCalendarRequest{Id: testCalendarID.String()}, http.MethodPatch, calendarPath, codes.OK},
+		{"canonical calendar ID", &whentov1.GetCalendarRequest{Id: "urn:uuid:" + testCalendarID.String()}, http.MethodGet, calendarPath, codes.OK},
+		{"calendar ID with a path", &whentov1.GetCalendarRequest{Id: testCalendarID.String() + "/range"}, "", "", codes.InvalidArgument},
+		{"submit availability", &whentov1.SubmitAvailabilityRequest{CalendarId: testCalendarID.String(), ParticipantId: testParticipantID.String()}, http.MethodPost, availabilitiesPath, codes.OK},
+		{"invalid participant ID", &whentov1.ListAvailabilitiesRequest{CalendarId: testCalendarID.String(), ParticipantId: "../.."}, "", "", codes.InvalidArgument},
+		{
+			"delete availability",
+			&whentov1.DeleteAvailabilityRequest{CalendarId: testCalendarID.String(), ParticipantId: testParticipantID.String(), Date: "2025-06-01"},
+			http.MethodDelete, availabilitiesPath + "/2025-06-01", codes.OK,
+		},
+		{
+			"invalid date",
+			&whentov1.DeleteAvailabilityRequest{CalendarId: testCalendarID.String(), ParticipantId: testParticipantID.String(), Date: "2025-06-01/x"},
+			"", "", codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, path, err := restRoute(tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("restRoute() code = %v, want %v", code, tt.wantCode)
+			}
+			if err == nil && (method != tt.wantMethod || path != tt.wantPath) {
+				t.Errorf("restRoute() = %s %s, want %s %s", method, path, tt.wantMethod, tt.wantPath)
+			}
+		})
+	}
+}
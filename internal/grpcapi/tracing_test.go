@@ -0,0 +1,68 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTraceInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus otelCodes.Code
+	}{
+		{"success", nil, otelCodes.Unset},
+		{"client error", status.Error(codes.NotFound, "calendar not found"), otelCodes.Unset},
+		{"server error", status.Error(codes.Internal, "internal error"), otelCodes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Reset()
+			ctx := metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+			info := &grpc.UnaryServerInfo{FullMethod: "/whento.v1.CalendarService/GetCalendar"}
+
+			_, _ = traceInterceptor(ctx, nil, info, func(context.Context, any) (any, error) {
+				return nil, tt.err
+			})
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("ended spans = %d, want 1", len(spans))
+			}
+			if spans[0].Name() != "whento.v1.CalendarService/GetCalendar" {
+				t.Errorf("span name = %q", spans[0].Name())
+			}
+			if spans[0].SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				t.Errorf("span does not continue the trace of the traceparent metadata")
+			}
+			if spans[0].Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", spans[0].Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,1431 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+// gRPC API of WhenTo for server-to-server integrations.
+// Calls are authenticated like the REST API: send "authorization: Bearer <token>" metadata with a JWT,
+// a personal access token or a calendar API token. Dates are YYYY-MM-DD and times HH:MM.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: whento/v1/whento.proto
+
+package whentov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Calendar struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId           string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	OrganizationId    string                 `protobuf:"bytes,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"` // Empty for personal calendars
+	Name              string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	PublicToken       string                 `protobuf:"bytes,6,opt,name=public_token,json=publicToken,proto3" json:"public_token,omitempty"`
+	IcsToken          string                 `protobuf:"bytes,7,opt,name=ics_token,json=icsToken,proto3" json:"ics_token,omitempty"`
+	Threshold         int32                  `protobuf:"varint,8,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	AllowedWeekdays   []int32                `protobuf:"varint,9,rep,packed,name=allowed_weekdays,json=allowedWeekdays,proto3" json:"allowed_weekdays,omitempty"` // 0 (Sunday) to 6 (Saturday)
+	MinDurationHours  int32                  `protobuf:"varint,10,opt,name=min_duration_hours,json=minDurationHours,proto3" json:"min_duration_hours,omitempty"`
+	Timezone          string                 `protobuf:"bytes,11,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	HolidaysPolicy    string                 `protobuf:"bytes,12,opt,name=holidays_policy,json=holidaysPolicy,proto3" json:"holidays_policy,omitempty"` // ignore, allow or block
+	AllowHolidayEves  bool                   `protobuf:"varint,13,opt,name=allow_holiday_eves,json=allowHolidayEves,proto3" json:"allow_holiday_eves,omitempty"`
+	NotifyOnThreshold bool                   `protobuf:"varint,14,opt,name=notify_on_threshold,json=notifyOnThreshold,proto3" json:"notify_on_threshold,omitempty"`
+	LockParticipants  bool                   `protobuf:"varint,15,opt,name=lock_participants,json=lockParticipants,proto3" json:"lock_participants,omitempty"`
+	StartDate         string                 `protobuf:"bytes,16,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // Empty when unbounded
+	EndDate           string                 `protobuf:"bytes,17,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // Empty when unbounded
+	Participants      []*Participant         `protobuf:"bytes,18,rep,name=participants,proto3" json:"participants,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Calendar) Reset() {
+	*x = Calendar{}
+	mi := &file_whento_v1_whento_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Calendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calendar) ProtoMessage() {}
+
+func (x *Calendar) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calendar.ProtoReflect.Descriptor instead.
+func (*Calendar) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Calendar) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Calendar) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Calendar) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Calendar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Calendar) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Calendar) GetPublicToken() string {
+	if x != nil {
+		return x.PublicToken
+	}
+	return ""
+}
+
+func (x *Calendar) GetIcsToken() string {
+	if x != nil {
+		return x.IcsToken
+	}
+	return ""
+}
+
+func (x *Calendar) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Calendar) GetAllowedWeekdays() []int32 {
+	if x != nil {
+		return x.AllowedWeekdays
+	}
+	return nil
+}
+
+func (x *Calendar) GetMinDurationHours() int32 {
+	if x != nil {
+		return x.MinDurationHours
+	}
+	return 0
+}
+
+func (x *Calendar) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Calendar) GetHolidaysPolicy() string {
+	if x != nil {
+		return x.HolidaysPolicy
+	}
+	return ""
+}
+
+func (x *Calendar) GetAllowHolidayEves() bool {
+	if x != nil {
+		return x.AllowHolidayEves
+	}
+	return false
+}
+
+func (x *Calendar) GetNotifyOnThreshold() bool {
+	if x != nil {
+		return x.NotifyOnThreshold
+	}
+	return false
+}
+
+func (x *Calendar) GetLockParticipants() bool {
+	if x != nil {
+		return x.LockParticipants
+	}
+	return false
+}
+
+func (x *Calendar) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Calendar) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *Calendar) GetParticipants() []*Participant {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *Calendar) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Calendar) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Participant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	EmailVerified bool                   `protobuf:"varint,4,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	Locale        string                 `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Participant) Reset() {
+	*x = Participant{}
+	mi := &file_whento_v1_whento_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Participant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Participant) ProtoMessage() {}
+
+func (x *Participant) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Participant.ProtoReflect.Descriptor instead.
+func (*Participant) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Participant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Participant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Participant) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Participant) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *Participant) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Participant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListCalendarsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"` // Empty for the personal calendars of the user
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListCalendarsRequest) Reset() {
+	*x = ListCalendarsRequest{}
+	mi := &file_whento_v1_whento_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalendarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalendarsRequest) ProtoMessage() {}
+
+func (x *ListCalendarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalendarsRequest.ProtoReflect.Descriptor instead.
+func (*ListCalendarsRequest) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCalendarsRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type ListCalendarsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendars     []*Calendar            `protobuf:"bytes,1,rep,name=calendars,proto3" json:"calendars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCalendarsResponse) Reset() {
+	*x = ListCalendarsResponse{}
+	mi := &file_whento_v1_whento_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalendarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalendarsResponse) ProtoMessage() {}
+
+func (x *ListCalendarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalendarsResponse.ProtoReflect.Descriptor instead.
+func (*ListCalendarsResponse) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCalendarsResponse) GetCalendars() []*Calendar {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+type GetCalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCalendarRequest) Reset() {
+	*x = GetCalendarRequest{}
+	mi := &file_whento_v1_whento_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalendarRequest) ProtoMessage() {}
+
+func (x *GetCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalendarRequest.ProtoReflect.Descriptor instead.
+func (*GetCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCalendarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateCalendarRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId    string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"` // Creates the calendar in this organization (owners and admins)
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Threshold         int32                  `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	AllowedWeekdays   []int32                `protobuf:"varint,5,rep,packed,name=allowed_weekdays,json=allowedWeekdays,proto3" json:"allowed_weekdays,omitempty"`
+	MinDurationHours  int32                  `protobuf:"varint,6,opt,name=min_duration_hours,json=minDurationHours,proto3" json:"min_duration_hours,omitempty"`
+	Timezone          string                 `protobuf:"bytes,7,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	HolidaysPolicy    string                 `protobuf:"bytes,8,opt,name=holidays_policy,json=holidaysPolicy,proto3" json:"holidays_policy,omitempty"`
+	AllowHolidayEves  bool                   `protobuf:"varint,9,opt,name=allow_holiday_eves,json=allowHolidayEves,proto3" json:"allow_holiday_eves,omitempty"`
+	NotifyOnThreshold bool                   `protobuf:"varint,10,opt,name=notify_on_threshold,json=notifyOnThreshold,proto3" json:"notify_on_threshold,omitempty"`
+	LockParticipants  bool                   `protobuf:"varint,11,opt,name=lock_participants,json=lockParticipants,proto3" json:"lock_participants,omitempty"`
+	StartDate         string                 `protobuf:"bytes,12,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate           string                 `protobuf:"bytes,13,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Participants      []string               `protobuf:"bytes,14,rep,name=participants,proto3" json:"participants,omitempty"` // Names of the initial participants
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateCalendarRequest) Reset() {
+	*x = CreateCalendarRequest{}
+	mi := &file_whento_v1_whento_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCalendarRequest) ProtoMessage() {}
+
+func (x *CreateCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCalendarRequest.ProtoReflect.Descriptor instead.
+func (*CreateCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateCalendarRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *CreateCalendarRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCalendarRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCalendarRequest) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *CreateCalendarRequest) GetAllowedWeekdays() []int32 {
+	if x != nil {
+		return x.AllowedWeekdays
+	}
+	return nil
+}
+
+func (x *CreateCalendarRequest) GetMinDurationHours() int32 {
+	if x != nil {
+		return x.MinDurationHours
+	}
+	return 0
+}
+
+func (x *CreateCalendarRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateCalendarRequest) GetHolidaysPolicy() string {
+	if x != nil {
+		return x.HolidaysPolicy
+	}
+	return ""
+}
+
+func (x *CreateCalendarRequest) GetAllowHolidayEves() bool {
+	if x != nil {
+		return x.AllowHolidayEves
+	}
+	return false
+}
+
+func (x *CreateCalendarRequest) GetNotifyOnThreshold() bool {
+	if x != nil {
+		return x.NotifyOnThreshold
+	}
+	return false
+}
+
+func (x *CreateCalendarRequest) GetLockParticipants() bool {
+	if x != nil {
+		return x.LockParticipants
+	}
+	return false
+}
+
+func (x *CreateCalendarRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *CreateCalendarRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *CreateCalendarRequest) GetParticipants() []string {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+type UpdateCalendarRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description       *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Threshold         *int32                 `protobuf:"varint,4,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+	AllowedWeekdays   []int32                `protobuf:"varint,5,rep,packed,name=allowed_weekdays,json=allowedWeekdays,proto3" json:"allowed_weekdays,omitempty"` // Replaced when not empty
+	MinDurationHours  *int32                 `protobuf:"varint,6,opt,name=min_duration_hours,json=minDurationHours,proto3,oneof" json:"min_duration_hours,omitempty"`
+	Timezone          *string                `protobuf:"bytes,7,opt,name=timezone,proto3,oneof" json:"timezone,omitempty"`
+	HolidaysPolicy    *string                `protobuf:"bytes,8,opt,name=holidays_policy,json=holidaysPolicy,proto3,oneof" json:"holidays_policy,omitempty"`
+	AllowHolidayEves  *bool                  `protobuf:"varint,9,opt,name=allow_holiday_eves,json=allowHolidayEves,proto3,oneof" json:"allow_holiday_eves,omitempty"`
+	NotifyOnThreshold *bool                  `protobuf:"varint,10,opt,name=notify_on_threshold,json=notifyOnThreshold,proto3,oneof" json:"notify_on_threshold,omitempty"`
+	LockParticipants  *bool                  `protobuf:"varint,11,opt,name=lock_participants,json=lockParticipants,proto3,oneof" json:"lock_participants,omitempty"`
+	StartDate         *string                `protobuf:"bytes,12,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"` // Empty string removes the start date
+	EndDate           *string                `protobuf:"bytes,13,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`       // Empty string removes the end date
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateCalendarRequest) Reset() {
+	*x = UpdateCalendarRequest{}
+	mi := &file_whento_v1_whento_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCalendarRequest) ProtoMessage() {}
+
+func (x *UpdateCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCalendarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateCalendarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCalendarRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCalendarRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateCalendarRequest) GetThreshold() int32 {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return 0
+}
+
+func (x *UpdateCalendarRequest) GetAllowedWeekdays() []int32 {
+	if x != nil {
+		return x.AllowedWeekdays
+	}
+	return nil
+}
+
+func (x *UpdateCalendarRequest) GetMinDurationHours() int32 {
+	if x != nil && x.MinDurationHours != nil {
+		return *x.MinDurationHours
+	}
+	return 0
+}
+
+func (x *UpdateCalendarRequest) GetTimezone() string {
+	if x != nil && x.Timezone != nil {
+		return *x.Timezone
+	}
+	return ""
+}
+
+func (x *UpdateCalendarRequest) GetHolidaysPolicy() string {
+	if x != nil && x.HolidaysPolicy != nil {
+		return *x.HolidaysPolicy
+	}
+	return ""
+}
+
+func (x *UpdateCalendarRequest) GetAllowHolidayEves() bool {
+	if x != nil && x.AllowHolidayEves != nil {
+		return *x.AllowHolidayEves
+	}
+	return false
+}
+
+func (x *UpdateCalendarRequest) GetNotifyOnThreshold() bool {
+	if x != nil && x.NotifyOnThreshold != nil {
+		return *x.NotifyOnThreshold
+	}
+	return false
+}
+
+func (x *UpdateCalendarRequest) GetLockParticipants() bool {
+	if x != nil && x.LockParticipants != nil {
+		return *x.LockParticipants
+	}
+	return false
+}
+
+func (x *UpdateCalendarRequest) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *UpdateCalendarRequest) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+type DeleteCalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCalendarRequest) Reset() {
+	*x = DeleteCalendarRequest{}
+	mi := &file_whento_v1_whento_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalendarRequest) ProtoMessage() {}
+
+func (x *DeleteCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalendarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteCalendarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteCalendarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCalendarResponse) Reset() {
+	*x = DeleteCalendarResponse{}
+	mi := &file_whento_v1_whento_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalendarResponse) ProtoMessage() {}
+
+func (x *DeleteCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalendarResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{8}
+}
+
+type Availability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParticipantId string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	StartTime     string                 `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // Empty for the whole day
+	EndTime       string                 `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // Empty for the whole day
+	Note          string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Availability) Reset() {
+	*x = Availability{}
+	mi := &file_whento_v1_whento_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Availability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Availability) ProtoMessage() {}
+
+func (x *Availability) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Availability.ProtoReflect.Descriptor instead.
+func (*Availability) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Availability) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Availability) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *Availability) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Availability) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *Availability) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *Availability) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Availability) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Availability) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListAvailabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CalendarId    string                 `protobuf:"bytes,1,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
+	ParticipantId string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	StartDate     string                 `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // Optional
+	EndDate       string                 `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // Optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAvailabilitiesRequest) Reset() {
+	*x = ListAvailabilitiesRequest{}
+	mi := &file_whento_v1_whento_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAvailabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAvailabilitiesRequest) ProtoMessage() {}
+
+func (x *ListAvailabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAvailabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListAvailabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListAvailabilitiesRequest) GetCalendarId() string {
+	if x != nil {
+		return x.CalendarId
+	}
+	return ""
+}
+
+func (x *ListAvailabilitiesRequest) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *ListAvailabilitiesRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ListAvailabilitiesRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+type ListAvailabilitiesResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Availabilities []*Availability        `protobuf:"bytes,1,rep,name=availabilities,proto3" json:"availabilities,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListAvailabilitiesResponse) Reset() {
+	*x = ListAvailabilitiesResponse{}
+	mi := &file_whento_v1_whento_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAvailabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAvailabilitiesResponse) ProtoMessage() {}
+
+func (x *ListAvailabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAvailabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListAvailabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListAvailabilitiesResponse) GetAvailabilities() []*Availability {
+	if x != nil {
+		return x.Availabilities
+	}
+	return nil
+}
+
+type SubmitAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CalendarId    string                 `protobuf:"bytes,1,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
+	ParticipantId string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	StartTime     string                 `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // Empty for the whole day
+	EndTime       string                 `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // Empty for the whole day
+	Note          string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitAvailabilityRequest) Reset() {
+	*x = SubmitAvailabilityRequest{}
+	mi := &file_whento_v1_whento_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAvailabilityRequest) ProtoMessage() {}
+
+func (x *SubmitAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*SubmitAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SubmitAvailabilityRequest) GetCalendarId() string {
+	if x != nil {
+		return x.CalendarId
+	}
+	return ""
+}
+
+func (x *SubmitAvailabilityRequest) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *SubmitAvailabilityRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *SubmitAvailabilityRequest) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *SubmitAvailabilityRequest) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *SubmitAvailabilityRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type DeleteAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CalendarId    string                 `protobuf:"bytes,1,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
+	ParticipantId string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAvailabilityRequest) Reset() {
+	*x = DeleteAvailabilityRequest{}
+	mi := &file_whento_v1_whento_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAvailabilityRequest) ProtoMessage() {}
+
+func (x *DeleteAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteAvailabilityRequest) GetCalendarId() string {
+	if x != nil {
+		return x.CalendarId
+	}
+	return ""
+}
+
+func (x *DeleteAvailabilityRequest) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *DeleteAvailabilityRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type DeleteAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAvailabilityResponse) Reset() {
+	*x = DeleteAvailabilityResponse{}
+	mi := &file_whento_v1_whento_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAvailabilityResponse) ProtoMessage() {}
+
+func (x *DeleteAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whento_v1_whento_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_whento_v1_whento_proto_rawDescGZIP(), []int{14}
+}
+
+var File_whento_v1_whento_proto protoreflect.FileDescriptor
+
+const file_whento_v1_whento_proto_rawDesc = "" +
+	"\n" +
+	"\x16whento/v1/whento.proto\x12\twhento.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\x06\n" +
+	"\bCalendar\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12'\n" +
+	"\x0forganization_id\x18\x03 \x01(\tR\x0eorganizationId\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12!\n" +
+	"\fpublic_token\x18\x06 \x01(\tR\vpublicToken\x12\x1b\n" +
+	"\tics_token\x18\a \x01(\tR\bicsToken\x12\x1c\n" +
+	"\tthreshold\x18\b \x01(\x05R\tthreshold\x12)\n" +
+	"\x10allowed_weekdays\x18\t \x03(\x05R\x0fallowedWeekdays\x12,\n" +
+	"\x12min_duration_hours\x18\n" +
+	" \x01(\x05R\x10minDurationHours\x12\x1a\n" +
+	"\btimezone\x18\v \x01(\tR\btimezone\x12'\n" +
+	"\x0fholidays_policy\x18\f \x01(\tR\x0eholidaysPolicy\x12,\n" +
+	"\x12allow_holiday_eves\x18\r \x01(\bR\x10allowHolidayEves\x12.\n" +
+	"\x13notify_on_threshold\x18\x0e \x01(\bR\x11notifyOnThreshold\x12+\n" +
+	"\x11lock_participants\x18\x0f \x01(\bR\x10lockParticipants\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x10 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x11 \x01(\tR\aendDate\x12:\n" +
+	"\fparticipants\x18\x12 \x03(\v2\x16.whento.v1.ParticipantR\fparticipants\x129\n" +
+	"\n" +
+	"created_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc1\x01\n" +
+	"\vParticipant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12%\n" +
+	"\x0eemail_verified\x18\x04 \x01(\bR\remailVerified\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"?\n" +
+	"\x14ListCalendarsRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"J\n" +
+	"\x15ListCalendarsResponse\x121\n" +
+	"\tcalendars\x18\x01 \x03(\v2\x13.whento.v1.CalendarR\tcalendars\"$\n" +
+	"\x12GetCalendarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9b\x04\n" +
+	"\x15CreateCalendarRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tthreshold\x18\x04 \x01(\x05R\tthreshold\x12)\n" +
+	"\x10allowed_weekdays\x18\x05 \x03(\x05R\x0fallowedWeekdays\x12,\n" +
+	"\x12min_duration_hours\x18\x06 \x01(\x05R\x10minDurationHours\x12\x1a\n" +
+	"\btimezone\x18\a \x01(\tR\btimezone\x12'\n" +
+	"\x0fholidays_policy\x18\b \x01(\tR\x0eholidaysPolicy\x12,\n" +
+	"\x12allow_holiday_eves\x18\t \x01(\bR\x10allowHolidayEves\x12.\n" +
+	"\x13notify_on_threshold\x18\n" +
+	" \x01(\bR\x11notifyOnThreshold\x12+\n" +
+	"\x11lock_participants\x18\v \x01(\bR\x10lockParticipants\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\f \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\r \x01(\tR\aendDate\x12\"\n" +
+	"\fparticipants\x18\x0e \x03(\tR\fparticipants\"\xd5\x05\n" +
+	"\x15UpdateCalendarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12!\n" +
+	"\tthreshold\x18\x04 \x01(\x05H\x02R\tthreshold\x88\x01\x01\x12)\n" +
+	"\x10allowed_weekdays\x18\x05 \x03(\x05R\x0fallowedWeekdays\x121\n" +
+	"\x12min_duration_hours\x18\x06 \x01(\x05H\x03R\x10minDurationHours\x88\x01\x01\x12\x1f\n" +
+	"\btimezone\x18\a \x01(\tH\x04R\btimezone\x88\x01\x01\x12,\n" +
+	"\x0fholidays_policy\x18\b \x01(\tH\x05R\x0eholidaysPolicy\x88\x01\x01\x121\n" +
+	"\x12allow_holiday_eves\x18\t \x01(\bH\x06R\x10allowHolidayEves\x88\x01\x01\x123\n" +
+	"\x13notify_on_threshold\x18\n" +
+	" \x01(\bH\aR\x11notifyOnThreshold\x88\x01\x01\x120\n" +
+	"\x11lock_participants\x18\v \x01(\bH\bR\x10lockParticipants\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"start_date\x18\f \x01(\tH\tR\tstartDate\x88\x01\x01\x12\x1e\n" +
+	"\bend_date\x18\r \x01(\tH\n" +
+	"R\aendDate\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_thresholdB\x15\n" +
+	"\x13_min_duration_hoursB\v\n" +
+	"\t_timezoneB\x12\n" +
+	"\x10_holidays_policyB\x15\n" +
+	"\x13_allow_holiday_evesB\x16\n" +
+	"\x14_notify_on_thresholdB\x14\n" +
+	"\x12_lock_participantsB\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_end_date\"'\n" +
+	"\x15DeleteCalendarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16DeleteCalendarResponse\"\x9d\x02\n" +
+	"\fAvailability\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tR\rparticipantId\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x05 \x01(\tR\aendTime\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9d\x01\n" +
+	"\x19ListAvailabilitiesRequest\x12\x1f\n" +
+	"\vcalendar_id\x18\x01 \x01(\tR\n" +
+	"calendarId\x12%\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tR\rparticipantId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x04 \x01(\tR\aendDate\"]\n" +
+	"\x1aListAvailabilitiesResponse\x12?\n" +
+	"\x0eavailabilities\x18\x01 \x03(\v2\x17.whento.v1.AvailabilityR\x0eavailabilities\"\xc5\x01\n" +
+	"\x19SubmitAvailabilityRequest\x12\x1f\n" +
+	"\vcalendar_id\x18\x01 \x01(\tR\n" +
+	"calendarId\x12%\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tR\rparticipantId\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x05 \x01(\tR\aendTime\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\"w\n" +
+	"\x19DeleteAvailabilityRequest\x12\x1f\n" +
+	"\vcalendar_id\x18\x01 \x01(\tR\n" +
+	"calendarId\x12%\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tR\rparticipantId\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\"\x1c\n" +
+	"\x1aDeleteAvailabilityResponse2\x91\x03\n" +
+	"\x0fCalendarService\x12R\n" +
+	"\rListCalendars\x12\x1f.whento.v1.ListCalendarsRequest\x1a .whento.v1.ListCalendarsResponse\x12A\n" +
+	"\vGetCalendar\x12\x1d.whento.v1.GetCalendarRequest\x1a\x13.whento.v1.Calendar\x12G\n" +
+	"\x0eCreateCalendar\x12 .whento.v1.CreateCalendarRequest\x1a\x13.whento.v1.Calendar\x12G\n" +
+	"\x0eUpdateCalendar\x12 .whento.v1.UpdateCalendarRequest\x1a\x13.whento.v1.Calendar\x12U\n" +
+	"\x0eDeleteCalendar\x12 .whento.v1.DeleteCalendarRequest\x1a!.whento.v1.DeleteCalendarResponse2\xb0\x02\n" +
+	"\x13AvailabilityService\x12a\n" +
+	"\x12ListAvailabilities\x12$.whento.v1.ListAvailabilitiesRequest\x1a%.whento.v1.ListAvailabilitiesResponse\x12S\n" +
+	"\x12SubmitAvailability\x12$.whento.v1.SubmitAvailabilityRequest\x1a\x17.whento.v1.Availability\x12a\n" +
+	"\x12DeleteAvailability\x12$.whento.v1.DeleteAvailabilityRequest\x1a%.whento.v1.DeleteAvailabilityResponseB>Z<github.com/whento/whento/internal/grpcapi/whento/v1;whentov1b\x06proto3"
+
+var (
+	file_whento_v1_whento_proto_rawDescOnce sync.Once
+	file_whento_v1_whento_proto_rawDescData []byte
+)
+
+func file_whento_v1_whento_proto_rawDescGZIP() []byte {
+	file_whento_v1_whento_proto_rawDescOnce.Do(func() {
+		file_whento_v1_whento_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_whento_v1_whento_proto_rawDesc), len(file_whento_v1_whento_proto_rawDesc)))
+	})
+	return file_whento_v1_whento_proto_rawDescData
+}
+
+var file_whento_v1_whento_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_whento_v1_whento_proto_goTypes = []any{
+	(*Calendar)(nil),                   // 0: whento.v1.Calendar
+	(*Participant)(nil),                // 1: whento.v1.Participant
+	(*ListCalendarsRequest)(nil),       // 2: whento.v1.ListCalendarsRequest
+	(*ListCalendarsResponse)(nil),      // 3: whento.v1.ListCalendarsResponse
+	(*GetCalendarRequest)(nil),         // 4: whento.v1.GetCalendarRequest
+	(*CreateCalendarRequest)(nil),      // 5: whento.v1.CreateCalendarRequest
+	(*UpdateCalendarRequest)(nil),      // 6: whento.v1.UpdateCalendarRequest
+	(*DeleteCalendarRequest)(nil),      // 7: whento.v1.DeleteCalendarRequest
+	(*DeleteCalendarResponse)(nil),     // 8: whento.v1.DeleteCalendarResponse
+	(*Availability)(nil),               // 9: whento.v1.Availability
+	(*ListAvailabilitiesRequest)(nil),  // 10: whento.v1.ListAvailabilitiesRequest
+	(*ListAvailabilitiesResponse)(nil), // 11: whento.v1.ListAvailabilitiesResponse
+	(*SubmitAvailabilityRequest)(nil),  // 12: whento.v1.SubmitAvailabilityRequest
+	(*DeleteAvailabilityRequest)(nil),  // 13: whento.v1.DeleteAvailabilityRequest
+	(*DeleteAvailabilityResponse)(nil), // 14: whento.v1.DeleteAvailabilityResponse
+	(*timestamppb.Timestamp)(nil),      // 15: google.protobuf.Timestamp
+}
+var file_whento_v1_whento_proto_depIdxs = []int32{
+	1,  // 0: whento.v1.Calendar.participants:type_name -> whento.v1.Participant
+	15, // 1: whento.v1.Calendar.created_at:type_name -> google.protobuf.Timestamp
+	15, // 2: whento.v1.Calendar.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 3: whento.v1.Participant.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: whento.v1.ListCalendarsResponse.calendars:type_name -> whento.v1.Calendar
+	15, // 5: whento.v1.Availability.created_at:type_name -> google.protobuf.Timestamp
+	15, // 6: whento.v1.Availability.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 7: whento.v1.ListAvailabilitiesResponse.availabilities:type_name -> whento.v1.Availability
+	2,  // 8: whento.v1.CalendarService.ListCalendars:input_type -> whento.v1.ListCalendarsRequest
+	4,  // 9: whento.v1.CalendarService.GetCalendar:input_type -> whento.v1.GetCalendarRequest
+	5,  // 10: whento.v1.CalendarService.CreateCalendar:input_type -> whento.v1.CreateCalendarRequest
+	6,  // 11: whento.v1.CalendarService.UpdateCalendar:input_type -> whento.v1.UpdateCalendarRequest
+	7,  // 12: whento.v1.CalendarService.DeleteCalendar:input_type -> whento.v1.DeleteCalendarRequest
+	10, // 13: whento.v1.AvailabilityService.ListAvailabilities:input_type -> whento.v1.ListAvailabilitiesRequest
+	12, // 14: whento.v1.AvailabilityService.SubmitAvailability:input_type -> whento.v1.SubmitAvailabilityRequest
+	13, // 15: whento.v1.AvailabilityService.DeleteAvailability:input_type -> whento.v1.DeleteAvailabilityRequest
+	3,  // 16: whento.v1.CalendarService.ListCalendars:output_type -> whento.v1.ListCalendarsResponse
+	0,  // 17: whento.v1.CalendarService.GetCalendar:output_type -> whento.v1.Calendar
+	0,  // 18: whento.v1.CalendarService.CreateCalendar:output_type -> whento.v1.Calendar
+	0,  // 19: whento.v1.CalendarService.UpdateCalendar:output_type -> whento.v1.Calendar
+	8,  // 20: whento.v1.CalendarService.DeleteCalendar:output_type -> whento.v1.DeleteCalendarResponse
+	11, // 21: whento.v1.AvailabilityService.ListAvailabilities:output_type -> whento.v1.ListAvailabilitiesResponse
+	9,  // 22: whento.v1.AvailabilityService.SubmitAvailability:output_type -> whento.v1.Availability
+	14, // 23: whento.v1.AvailabilityService.DeleteAvailability:output_type -> whento.v1.DeleteAvailabilityResponse
+	16, // [16:24] is the sub-list for method output_type
+	8,  // [8:16] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_whento_v1_whento_proto_init() }
+func file_whento_v1_whento_proto_init() {
+	if File_whento_v1_whento_proto != nil {
+		return
+	}
+	file_whento_v1_whento_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_whento_v1_whento_proto_rawDesc), len(file_whento_v1_whento_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_whento_v1_whento_proto_goTypes,
+		DependencyIndexes: file_whento_v1_whento_proto_depIdxs,
+		MessageInfos:      file_whento_v1_whento_proto_msgTypes,
+	}.Build()
+	File_whento_v1_whento_proto = out.File
+	file_whento_v1_whento_proto_goTypes = nil
+	file_whento_v1_whento_proto_depIdxs = nil
+}
@@ -0,0 +1,210 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	icsModels "github.com/whento/whento/internal/ics/models"
+)
+
+// MessagePublisher publishes messages to an MQTT broker
+type MessagePublisher interface {
+	Publish(topic string, payload []byte, retain bool) error
+}
+
+// SensorProvider computes the sensor of a calendar from its ICS token
+type SensorProvider interface {
+	GetSensor(ctx context.Context, icsToken string, now time.Time) (*icsModels.Sensor, error)
+}
+
+// MQTTPublisher publishes calendar sensors to MQTT, with Home Assistant discovery
+// Each calendar appears in Home Assistant as a device with two sensors: days until the next
+// confirmed event, and the start of this event (a timestamp usable as an automation trigger)
+type MQTTPublisher struct {
+	client          MessagePublisher
+	calendarRepo    *calendarRepo.CalendarRepository
+	sensors         SensorProvider
+	discoveryPrefix string
+	topicPrefix     string
+	refreshInterval time.Duration
+	productName     string
+	logger          *slog.Logger
+}
+
+// NewMQTTPublisher creates a new Home Assistant MQTT publisher
+func NewMQTTPublisher(
+	client MessagePublisher,
+	calendarRepo *calendarRepo.CalendarRepository,
+	sensors SensorProvider,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *MQTTPublisher {
+	return &MQTTPublisher{
+		client:          client,
+		calendarRepo:    calendarRepo,
+		sensors:         sensors,
+		discoveryPrefix: strings.Trim(cfg.HomeAssistant.DiscoveryPrefix, "/"),
+		topicPrefix:     strings.Trim(cfg.HomeAssistant.TopicPrefix, "/"),
+		refreshInterval: cfg.HomeAssistant.RefreshInterval,
+		productName:     cfg.Branding.ProductName,
+		logger:          logger,
+	}
+}
+
+// discoveryConfig is a Home Assistant MQTT discovery payload
+type discoveryConfig struct {
+	Name                string          `json:"name"`
+	UniqueID            string          `json:"unique_id"`
+	ObjectID            string          `json:"object_id"`
+	StateTopic          string          `json:"state_topic"`
+	ValueTemplate       string          `json:"value_template"`
+	JSONAttributesTopic string          `json:"json_attributes_topic,omitempty"`
+	UnitOfMeasurement   string          `json:"unit_of_measurement,omitempty"`
+	DeviceClass         string          `json:"device_class,omitempty"`
+	Icon                string          `json:"icon,omitempty"`
+	Device              discoveryDevice `json:"device"`
+}
+
+// discoveryDevice groups the sensors of a calendar in Home Assistant
+type discoveryDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model"`
+}
+
+// Publish republishes the sensor of a calendar after one of its events (e.g. a threshold transition)
+func (p *MQTTPublisher) Publish(ctx context.Context, ownerID, calendarID uuid.UUID, eventType string, data any) {
+	if err := p.PublishCalendar(ctx, calendarID); err != nil {
+		p.logger.Error("Failed to publish calendar sensor to MQTT", "calendar_id", calendarID, "event", eventType, "error", err)
+	}
+}
+
+// PublishCalendar publishes the discovery configuration and the state of the sensors of a calendar
+// Both are retained, so Home Assistant gets them as soon as it (re)connects to the broker
+func (p *MQTTPublisher) PublishCalendar(ctx context.Context, calendarID uuid.UUID) error {
+	calendar, err := p.calendarRepo.GetByID(ctx, calendarID)
+	if err != nil {
+		return err
+	}
+
+	sensor, err := p.sensors.GetSensor(ctx, calendar.ICSToken, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for topic, config := range p.discoveryConfigs(calendarID, calendar.Name) {
+		payload, err := json.Marshal(config)
+		if err != nil {
+			return err
+		}
+		if err := p.client.Publish(topic, payload, true); err != nil {
+			return err
+		}
+	}
+
+	payload, err := json.Marshal(sensor)
+	if err != nil {
+		return err
+	}
+	return p.client.Publish(p.stateTopic(calendarID), payload, true)
+}
+
+// PublishAll publishes the sensors of all calendars
+func (p *MQTTPublisher) PublishAll(ctx context.Context) {
+	ids, err := p.calendarRepo.ListAllIDs(ctx)
+	if err != nil {
+		p.logger.Error("Failed to list calendars for MQTT publishing", "error", err)
+		return
+	}
+
+	failed := 0
+	for _, id := range ids {
+		if err := p.PublishCalendar(ctx, id); err != nil {
+			p.logger.Warn("Failed to publish calendar sensor to MQTT", "calendar_id", id, "error", err)
+			failed++
+		}
+	}
+
+	p.logger.Info("Calendar sensors published to MQTT", "calendars", len(ids), "failed", failed)
+}
+
+// StartRefreshTask publishes all sensors now, then periodically so days until events stay current
+func (p *MQTTPublisher) StartRefreshTask(ctx context.Context) {
+	p.logger.Info("Starting Home Assistant MQTT publishing", "refresh_interval", p.refreshInterval)
+
+	go func() {
+		p.PublishAll(ctx)
+
+		if p.refreshInterval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(p.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				p.logger.Info("Home Assistant MQTT publishing stopped (context cancelled)")
+				return
+			case <-ticker.C:
+				p.PublishAll(ctx)
+			}
+		}
+	}()
+}
+
+// stateTopic returns the topic of the sensor state of a calendar
+func (p *MQTTPublisher) stateTopic(calendarID uuid.UUID) string {
+	return fmt.Sprintf("%s/calendar/%s/state", p.topicPrefix, calendarID)
+}
+
+// discoveryConfigs returns the discovery payloads of the sensors of a calendar, by topic
+func (p *MQTTPublisher) discoveryConfigs(calendarID uuid.UUID, calendarName string) map[string]discoveryConfig {
+	nodeID := "whento_" + strings.ReplaceAll(calendarID.String(), "-", "")
+	stateTopic := p.stateTopic(calendarID)
+	device := discoveryDevice{
+		Identifiers:  []string{nodeID},
+		Name:         calendarName,
+		Manufacturer: p.productName,
+		Model:        "Calendar",
+	}
+
+	return map[string]discoveryConfig{
+		fmt.Sprintf("%s/sensor/%s/days_until/config", p.discoveryPrefix, nodeID): {
+			Name:                "Days until next event",
+			UniqueID:            nodeID + "_days_until",
+			ObjectID:            nodeID + "_days_until",
+			StateTopic:          stateTopic,
+			ValueTemplate:       "{{ value_json.days_until }}",
+			JSONAttributesTopic: stateTopic,
+			UnitOfMeasurement:   "d",
+			Icon:                "mdi:calendar-clock",
+			Device:              device,
+		},
+		fmt.Sprintf("%s/sensor/%s/next_event/config", p.discoveryPrefix, nodeID): {
+			Name:          "Next event",
+			UniqueID:      nodeID + "_next_event",
+			ObjectID:      nodeID + "_next_event",
+			StateTopic:    stateTopic,
+			ValueTemplate: "{{ value_json.next_event.start if value_json.next_event else none }}",
+			DeviceClass:   "timestamp",
+			Icon:          "mdi:calendar-check",
+			Device:        device,
+		},
+	}
+}
@@ -0,0 +1,54 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDiscoveryConfigs(t *testing.T) {
+	p := &MQTTPublisher{discoveryPrefix: "homeassistant", topicPrefix: "whento", productName: "WhenTo"}
+	calendarID := uuid.MustParse("5f0c6d3e-8a7b-4c1d-9e2f-0a1b2c3d4e5f")
+
+	configs := p.discoveryConfigs(calendarID, "Five-a-side")
+
+	daysUntil, ok := configs["homeassistant/sensor/whento_5f0c6d3e8a7b4c1d9e2f0a1b2c3d4e5f/days_until/config"]
+	if !ok {
+		t.Fatalf("days_until discovery topic missing, got %v", configs)
+	}
+	nextEvent, ok := configs["homeassistant/sensor/whento_5f0c6d3e8a7b4c1d9e2f0a1b2c3d4e5f/next_event/config"]
+	if !ok {
+		t.Fatalf("next_event discovery topic missing, got %v", configs)
+	}
+
+	wantState := "whento/calendar/5f0c6d3e-8a7b-4c1d-9e2f-0a1b2c3d4e5f/state"
+	if daysUntil.StateTopic != wantState || nextEvent.StateTopic != wantState {
+		t.Errorf("state topics = %q, %q, want %q", daysUntil.StateTopic, nextEvent.StateTopic, wantState)
+	}
+	if daysUntil.UniqueID == nextEvent.UniqueID {
+		t.Error("sensors of a calendar must have distinct unique IDs")
+	}
+	if nextEvent.DeviceClass != "timestamp" {
+		t.Errorf("next_event device class = %q, want timestamp", nextEvent.DeviceClass)
+	}
+
+	// Both sensors belong to the same device, named after the calendar
+	payload, err := json.Marshal(daysUntil)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	device, _ := decoded["device"].(map[string]any)
+	if device["name"] != "Five-a-side" || daysUntil.Device.Identifiers[0] != nextEvent.Device.Identifiers[0] {
+		t.Errorf("device = %v, want the calendar shared by both sensors", device)
+	}
+}
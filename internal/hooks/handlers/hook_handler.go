@@ -0,0 +1,205 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/hooks/models"
+	"github.com/whento/whento/internal/hooks/service"
+)
+
+// HookHandler handles REST hook HTTP requests
+type HookHandler struct {
+	service *service.HookService
+	logger  *slog.Logger
+}
+
+// NewHookHandler creates a new hook handler
+func NewHookHandler(service *service.HookService, logger *slog.Logger) *HookHandler {
+	return &HookHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		Subscribe a REST hook
+// @Description	Registers a target URL receiving a POST with the event as JSON each time it happens (REST Hooks pattern, used by Zapier and Make). Omit calendar_id to receive the events of all owned calendars. Answering 410 Gone unsubscribes the hook. With format "ifttt", the target receives value1 (calendar name), value2 (date) and value3 (count/threshold) for IFTTT Webhooks applets.
+// @Tags			Hooks
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			request	body		models.SubscribeRequest	true	"Subscription"
+// @Success		201		{object}	models.HookResponse		"Hook subscribed"
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid request or target URL"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403		{object}	httputil.ErrorResponse	"Calendar belongs to another user"
+// @Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+// @Failure		409		{object}	httputil.ErrorResponse	"Too many hooks"
+// @Failure		500		{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/hooks [post]
+func (h *HookHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.SubscribeRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	hook, err := h.service.Subscribe(r.Context(), userUUID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTarget), errors.Is(err, service.ErrUnknownEvent):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		case errors.Is(err, service.ErrCalendarNotFound):
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+		case errors.Is(err, service.ErrNotOwner):
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't own this calendar")
+		case errors.Is(err, service.ErrTooManyHooks):
+			httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, err.Error())
+		default:
+			h.logger.Error("Failed to subscribe hook", "error", err, "user_id", userUUID)
+			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to subscribe hook")
+		}
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, hook.ToResponse())
+}
+
+// @Summary		List REST hooks
+// @Description	Lists the REST hook subscriptions of the current user
+// @Tags			Hooks
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		models.HookResponse		"Subscriptions"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/hooks [get]
+func (h *HookHandler) List(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	hooks, err := h.service.List(r.Context(), userUUID)
+	if err != nil {
+		h.logger.Error("Failed to list hooks", "error", err, "user_id", userUUID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list hooks")
+		return
+	}
+
+	responses := make([]*models.HookResponse, 0, len(hooks))
+	for _, hook := range hooks {
+		responses = append(responses, hook.ToResponse())
+	}
+
+	httputil.JSON(w, http.StatusOK, responses)
+}
+
+// @Summary		Unsubscribe a REST hook
+// @Description	Deletes a REST hook subscription of the current user
+// @Tags			Hooks
+// @Security		BearerAuth
+// @Param			id	path	string	true	"Hook ID"
+// @Success		204	"Hook unsubscribed"
+// @Failure		400	{object}	httputil.ErrorResponse	"Invalid hook ID"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"Hook not found"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/hooks/{id} [delete]
+func (h *HookHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	hookID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid hook ID")
+		return
+	}
+
+	if err := h.service.Unsubscribe(r.Context(), userUUID, hookID); err != nil {
+		if errors.Is(err, service.ErrHookNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Hook not found")
+			return
+		}
+		h.logger.Error("Failed to unsubscribe hook", "error", err, "hook_id", hookID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to unsubscribe hook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Poll recent events
+// @Description	Returns the latest events (up to 50, newest first) of the current user. Used as a polling fallback and as sample data when setting up an integration. Events are kept 30 days.
+// @Tags			Hooks
+// @Produce		json
+// @Security		BearerAuth
+// @Param			event		query		string					true	"Event type"	Enums(threshold_reached, threshold_lost)
+// @Param			calendar_id	query		string					false	"Only events of this calendar"
+// @Success		200			{array}		models.Event			"Recent events"
+// @Failure		400			{object}	httputil.ErrorResponse	"Unknown event or invalid calendar ID"
+// @Failure		401			{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		500			{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/hooks/poll [get]
+func (h *HookHandler) Poll(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var calendarID *uuid.UUID
+	if raw := r.URL.Query().Get("calendar_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid calendar ID")
+			return
+		}
+		calendarID = &id
+	}
+
+	events, err := h.service.Poll(r.Context(), userUUID, r.URL.Query().Get("event"), calendarID)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownEvent) {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Unknown event")
+			return
+		}
+		h.logger.Error("Failed to poll hook events", "error", err, "user_id", userUUID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to poll events")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, events)
+}
+
+// userID returns the authenticated user ID, writing an error response if missing
+func (h *HookHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
@@ -0,0 +1,103 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types available to REST hooks
+const (
+	EventThresholdReached     = "threshold_reached"
+	EventThresholdLost        = "threshold_lost"
+	EventSoftThresholdReached = "soft_threshold_reached"
+	EventSoftThresholdLost    = "soft_threshold_lost"
+)
+
+// Events lists the event types integrations can subscribe to
+var Events = []string{EventThresholdReached, EventThresholdLost, EventSoftThresholdReached, EventSoftThresholdLost}
+
+// Payload formats of REST hooks
+const (
+	FormatDefault = "default" // The Event as JSON
+	FormatIFTTT   = "ifttt"   // IFTTTPayload, for IFTTT Webhooks applets
+)
+
+// Hook is a REST hook subscription: events are POSTed to TargetURL as they happen
+type Hook struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	CalendarID *uuid.UUID // nil = all calendars owned by the user
+	Event      string
+	TargetURL  string
+	Format     string
+	CreatedAt  time.Time
+}
+
+// Event is an event delivered to hooks and returned by the polling endpoint
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Event      string          `json:"event"`
+	CalendarID uuid.UUID       `json:"calendar_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// ThresholdEventData is the data of threshold and soft threshold events
+type ThresholdEventData struct {
+	CalendarName string `json:"calendar_name"`
+	CalendarURL  string `json:"calendar_url"`
+	Date         string `json:"date"` // YYYY-MM-DD
+	Count        int    `json:"count"`
+	Threshold    int    `json:"threshold"`
+	Level        string `json:"level"` // "soft" or "hard"
+}
+
+// IFTTTPayload is the event payload of hooks in the ifttt format
+// IFTTT Webhooks only pass on three values to applets; the event type is the event name of the target URL
+// (https://maker.ifttt.com/trigger/{event}/with/key/{key}), as each hook receives a single event type
+type IFTTTPayload struct {
+	Value1 string `json:"value1"` // Calendar name
+	Value2 string `json:"value2"` // Date (YYYY-MM-DD)
+	Value3 string `json:"value3"` // Available participants and threshold, e.g. "5/5"
+}
+
+// SubscribeRequest represents a request to subscribe a REST hook
+type SubscribeRequest struct {
+	TargetURL  string  `json:"target_url" validate:"required,url,max=2048"`
+	Event      string  `json:"event" validate:"required,oneof=threshold_reached threshold_lost"`
+	CalendarID *string `json:"calendar_id,omitempty" validate:"omitempty,uuid"`                             // Omit to receive events of all owned calendars
+	Format     string  `json:"format,omitempty" validate:"omitempty,oneof=default ifttt" example:"default"` // Payload format (default if omitted)
+}
+
+// HookResponse is the API response for a REST hook subscription
+type HookResponse struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	TargetURL  string    `json:"target_url"`
+	Format     string    `json:"format"`
+	CalendarID *string   `json:"calendar_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ToResponse converts a Hook to HookResponse
+func (h *Hook) ToResponse() *HookResponse {
+	response := &HookResponse{
+		ID:        h.ID.String(),
+		Event:     h.Event,
+		TargetURL: h.TargetURL,
+		Format:    h.Format,
+		CreatedAt: h.CreatedAt,
+	}
+	if h.CalendarID != nil {
+		calendarID := h.CalendarID.String()
+		response.CalendarID = &calendarID
+	}
+	return response
+}
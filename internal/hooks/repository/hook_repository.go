@@ -0,0 +1,153 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/hooks/models"
+)
+
+var ErrHookNotFound = errors.New("hook not found")
+
+// HookRepository handles REST hook subscriptions and recent events
+type HookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewHookRepository creates a new hook repository
+func NewHookRepository(pool *pgxpool.Pool) *HookRepository {
+	return &HookRepository{pool: pool}
+}
+
+// Create creates a new subscription
+func (r *HookRepository) Create(ctx context.Context, hook *models.Hook) error {
+	query := `
+		INSERT INTO rest_hooks (id, user_id, calendar_id, event, target_url, format, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.pool.Exec(ctx, query, hook.ID, hook.UserID, hook.CalendarID, hook.Event, hook.TargetURL, hook.Format, hook.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create hook: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns the subscriptions of a user, newest first
+func (r *HookRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Hook, error) {
+	query := `
+		SELECT id, user_id, calendar_id, event, target_url, format, created_at
+		FROM rest_hooks
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	return r.query(ctx, query, userID)
+}
+
+// CountByUser returns the number of subscriptions of a user
+func (r *HookRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM rest_hooks WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+// ListForEvent returns the subscriptions receiving an event of a calendar owned by userID
+func (r *HookRepository) ListForEvent(ctx context.Context, userID, calendarID uuid.UUID, event string) ([]*models.Hook, error) {
+	query := `
+		SELECT id, user_id, calendar_id, event, target_url, format, created_at
+		FROM rest_hooks
+		WHERE user_id = $1 AND event = $2 AND (calendar_id IS NULL OR calendar_id = $3)`
+
+	return r.query(ctx, query, userID, event, calendarID)
+}
+
+// Delete deletes a subscription of a user
+func (r *HookRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM rest_hooks WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete hook: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrHookNotFound
+	}
+	return nil
+}
+
+// DeleteByID deletes a subscription regardless of its owner (target answered 410 Gone)
+func (r *HookRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM rest_hooks WHERE id = $1`, id)
+	return err
+}
+
+// LogEvent records an event for the polling endpoint
+func (r *HookRepository) LogEvent(ctx context.Context, userID uuid.UUID, event *models.Event) error {
+	query := `
+		INSERT INTO hook_events (id, user_id, calendar_id, event, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.pool.Exec(ctx, query, event.ID, userID, event.CalendarID, event.Event, event.Data, event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to log hook event: %w", err)
+	}
+	return nil
+}
+
+// ListEvents returns the latest events of a user, newest first (calendarID nil = all calendars)
+func (r *HookRepository) ListEvents(
+	ctx context.Context,
+	userID uuid.UUID,
+	event string,
+	calendarID *uuid.UUID,
+	limit int,
+) ([]*models.Event, error) {
+	query := `
+		SELECT id, event, calendar_id, created_at, payload
+		FROM hook_events
+		WHERE user_id = $1 AND event = $2 AND ($3::uuid IS NULL OR calendar_id = $3)
+		ORDER BY created_at DESC
+		LIMIT $4`
+
+	rows, err := r.pool.Query(ctx, query, userID, event, calendarID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hook events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		var event models.Event
+		if err := rows.Scan(&event.ID, &event.Event, &event.CalendarID, &event.CreatedAt, &event.Data); err != nil {
+			return nil, fmt.Errorf("failed to scan hook event: %w", err)
+		}
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *HookRepository) query(ctx context.Context, query string, args ...any) ([]*models.Hook, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []*models.Hook
+	for rows.Next() {
+		var hook models.Hook
+		if err := rows.Scan(&hook.ID, &hook.UserID, &hook.CalendarID, &hook.Event, &hook.TargetURL, &hook.Format, &hook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan hook: %w", err)
+		}
+		hooks = append(hooks, &hook)
+	}
+
+	return hooks, rows.Err()
+}
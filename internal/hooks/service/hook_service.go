@@ -0,0 +1,282 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/hooks/models"
+	"github.com/whento/whento/internal/hooks/repository"
+)
+
+const (
+	// MaxHooksPerUser limits the number of subscriptions of a user
+	MaxHooksPerUser = 50
+
+	// pollLimit is the number of events returned by the polling endpoint
+	pollLimit = 50
+)
+
+var (
+	ErrHookNotFound     = repository.ErrHookNotFound
+	ErrCalendarNotFound = errors.New("calendar not found")
+	ErrNotOwner         = errors.New("you don't own this calendar")
+	ErrUnknownEvent     = errors.New("unknown event")
+	ErrInvalidTarget    = errors.New("invalid target URL")
+	ErrTooManyHooks     = fmt.Errorf("too many hooks (maximum %d)", MaxHooksPerUser)
+)
+
+// HookService manages REST hook subscriptions and delivers events to them
+// It implements the REST Hooks pattern used by Zapier and Make: integrations subscribe a target URL,
+// events are POSTed to it, and a polling endpoint returns recent events as a fallback
+type HookService struct {
+	repo                *repository.HookRepository
+	calendarRepo        *calendarRepo.CalendarRepository
+	httpClient          *http.Client
+	requireHTTPS        bool
+	allowPrivateTargets bool
+	logger              *slog.Logger
+}
+
+// NewHookService creates a new hook service
+func NewHookService(
+	repo *repository.HookRepository,
+	calendarRepo *calendarRepo.CalendarRepository,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *HookService {
+	return &HookService{
+		repo:                repo,
+		calendarRepo:        calendarRepo,
+		httpClient:          newHTTPClient(cfg.HooksAllowPrivateTargets),
+		requireHTTPS:        cfg.AppEnv == "production",
+		allowPrivateTargets: cfg.HooksAllowPrivateTargets,
+		logger:              logger,
+	}
+}
+
+// Subscribe registers a target URL for an event of one calendar (or of all calendars of the user)
+func (s *HookService) Subscribe(ctx context.Context, userID uuid.UUID, req *models.SubscribeRequest) (*models.Hook, error) {
+	if !slices.Contains(models.Events, req.Event) {
+		return nil, ErrUnknownEvent
+	}
+	if err := s.validateTarget(req.TargetURL); err != nil {
+		return nil, err
+	}
+
+	hook := &models.Hook{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Event:     req.Event,
+		TargetURL: req.TargetURL,
+		Format:    req.Format,
+		CreatedAt: time.Now(),
+	}
+	if hook.Format == "" {
+		hook.Format = models.FormatDefault
+	}
+
+	if req.CalendarID != nil {
+		calendarID, err := uuid.Parse(*req.CalendarID)
+		if err != nil {
+			return nil, ErrCalendarNotFound
+		}
+		calendar, err := s.calendarRepo.GetByID(ctx, calendarID)
+		if err != nil {
+			return nil, ErrCalendarNotFound
+		}
+		if calendar.OwnerID != userID {
+			return nil, ErrNotOwner
+		}
+		hook.CalendarID = &calendarID
+	}
+
+	count, err := s.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxHooksPerUser {
+		return nil, ErrTooManyHooks
+	}
+
+	if err := s.repo.Create(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("REST hook subscribed", "hook_id", hook.ID, "user_id", userID, "event", hook.Event)
+	return hook, nil
+}
+
+// Unsubscribe deletes a subscription of the user
+func (s *HookService) Unsubscribe(ctx context.Context, userID, hookID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, hookID, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("REST hook unsubscribed", "hook_id", hookID, "user_id", userID)
+	return nil
+}
+
+// List returns the subscriptions of the user
+func (s *HookService) List(ctx context.Context, userID uuid.UUID) ([]*models.Hook, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Poll returns the latest events of the user, newest first (polling fallback and sample data)
+func (s *HookService) Poll(ctx context.Context, userID uuid.UUID, event string, calendarID *uuid.UUID) ([]*models.Event, error) {
+	if !slices.Contains(models.Events, event) {
+		return nil, ErrUnknownEvent
+	}
+
+	events, err := s.repo.ListEvents(ctx, userID, event, calendarID, pollLimit)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []*models.Event{}
+	}
+	return events, nil
+}
+
+// Publish records an event of a calendar and delivers it to the subscribed hooks of its owner
+// Delivery is best effort: failures are logged, and targets answering 410 Gone are unsubscribed
+func (s *HookService) Publish(ctx context.Context, ownerID, calendarID uuid.UUID, eventType string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		s.logger.Error("Failed to encode hook event", "event", eventType, "error", err)
+		return
+	}
+
+	event := &models.Event{
+		ID:         uuid.New(),
+		Event:      eventType,
+		CalendarID: calendarID,
+		CreatedAt:  time.Now().UTC(),
+		Data:       payload,
+	}
+
+	// Old events are purged by the retention janitor
+	if err := s.repo.LogEvent(ctx, ownerID, event); err != nil {
+		s.logger.Error("Failed to log hook event", "calendar_id", calendarID, "event", eventType, "error", err)
+	}
+
+	hooks, err := s.repo.ListForEvent(ctx, ownerID, calendarID, eventType)
+	if err != nil {
+		s.logger.Error("Failed to list hooks for event", "calendar_id", calendarID, "event", eventType, "error", err)
+		return
+	}
+
+	for _, hook := range hooks {
+		status, err := s.deliver(ctx, hook.TargetURL, hook.Format, event)
+		switch {
+		case status == http.StatusGone:
+			// REST Hooks convention: the target asks to be unsubscribed
+			s.logger.Info("REST hook target gone, unsubscribing", "hook_id", hook.ID)
+			if err := s.repo.DeleteByID(ctx, hook.ID); err != nil {
+				s.logger.Error("Failed to delete gone hook", "hook_id", hook.ID, "error", err)
+			}
+		case err != nil:
+			s.logger.Warn("Failed to deliver hook event", "hook_id", hook.ID, "event", eventType, "error", err)
+		default:
+			s.logger.Debug("Hook event delivered", "hook_id", hook.ID, "event", eventType)
+		}
+	}
+}
+
+// deliver POSTs an event to a target in the format of its hook and returns the response status
+func (s *HookService) deliver(ctx context.Context, targetURL, format string, event *models.Event) (int, error) {
+	body, err := payload(format, event)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "WhenTo-Hooks/1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("target returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// payload encodes an event in a hook format
+func payload(format string, event *models.Event) ([]byte, error) {
+	if format != models.FormatIFTTT {
+		return json.Marshal(event)
+	}
+
+	var data models.ThresholdEventData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode event data: %w", err)
+	}
+	return json.Marshal(models.IFTTTPayload{
+		Value1: data.CalendarName,
+		Value2: data.Date,
+		Value3: fmt.Sprintf("%d/%d", data.Count, data.Threshold),
+	})
+}
+
+// validateTarget checks the scheme of a target URL and rejects literal private addresses
+// Host names are checked when connecting, since they may resolve differently later
+func (s *HookService) validateTarget(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return ErrInvalidTarget
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if s.requireHTTPS {
+			return fmt.Errorf("%w: HTTPS is required", ErrInvalidTarget)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported scheme %q", ErrInvalidTarget, u.Scheme)
+	}
+
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !s.allowPrivateTargets && !httputil.IsPublicIP(ip) {
+		return fmt.Errorf("%w: %v", ErrInvalidTarget, httputil.ErrPrivateAddress)
+	}
+
+	return nil
+}
+
+// newHTTPClient creates the delivery client, which refuses private addresses unless allowed
+func newHTTPClient(allowPrivateTargets bool) *http.Client {
+	client := httputil.NewOutboundClient(10*time.Second, allowPrivateTargets)
+	// Redirects could point elsewhere, and targets shouldn't move anyway
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return client
+}
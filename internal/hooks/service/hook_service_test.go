@@ -0,0 +1,139 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/whento/internal/hooks/models"
+)
+
+func TestValidateTarget(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		requireHTTPS bool
+		allowPrivate bool
+		wantErr      bool
+	}{
+		{name: "https", target: "https://hooks.zapier.com/hooks/standard/123/abc", wantErr: false},
+		{name: "http in development", target: "http://example.com/hook", wantErr: false},
+		{name: "http in production", target: "http://example.com/hook", requireHTTPS: true, wantErr: true},
+		{name: "https in production", target: "https://example.com/hook", requireHTTPS: true, wantErr: false},
+		{name: "unsupported scheme", target: "ftp://example.com/hook", wantErr: true},
+		{name: "no host", target: "https:///hook", wantErr: true},
+		{name: "loopback", target: "https://127.0.0.1/hook", wantErr: true},
+		{name: "private", target: "https://192.168.1.10/hook", wantErr: true},
+		{name: "link-local metadata", target: "http://169.254.169.254/latest", wantErr: true},
+		{name: "IPv6 loopback", target: "https://[::1]/hook", wantErr: true},
+		{name: "private allowed", target: "http://192.168.1.10:8080/hook", allowPrivate: true, wantErr: false},
+		{name: "public IP", target: "https://93.184.216.34/hook", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &HookService{requireHTTPS: tt.requireHTTPS, allowPrivateTargets: tt.allowPrivate}
+			err := s.validateTarget(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTarget(%q) error = %v, wantErr %v", tt.target, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTarget) {
+				t.Errorf("validateTarget(%q) error = %v, want ErrInvalidTarget", tt.target, err)
+			}
+		})
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	var received models.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	event := &models.Event{
+		ID:         uuid.New(),
+		Event:      models.EventThresholdReached,
+		CalendarID: uuid.New(),
+		CreatedAt:  time.Now().UTC(),
+		Data:       json.RawMessage(`{"count":3,"threshold":3}`),
+	}
+
+	s := &HookService{httpClient: newHTTPClient(true)}
+
+	status, err := s.deliver(context.Background(), server.URL+"/hook", models.FormatDefault, event)
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("deliver() = %d, %v, want 200", status, err)
+	}
+	if received.ID != event.ID || received.Event != event.Event {
+		t.Errorf("received event %+v, want %+v", received, event)
+	}
+
+	status, err = s.deliver(context.Background(), server.URL+"/gone", models.FormatDefault, event)
+	if err == nil || status != http.StatusGone {
+		t.Errorf("deliver() = %d, %v, want 410 with error", status, err)
+	}
+}
+
+func TestDeliver_BlocksPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach a loopback target")
+	}))
+	defer server.Close()
+
+	s := &HookService{httpClient: newHTTPClient(false)}
+
+	_, err := s.deliver(context.Background(), server.URL, models.FormatDefault, &models.Event{ID: uuid.New()})
+	if !errors.Is(err, httputil.ErrPrivateAddress) {
+		t.Errorf("deliver() error = %v, want ErrPrivateAddress", err)
+	}
+}
+
+func TestPayload_IFTTT(t *testing.T) {
+	event := &models.Event{
+		ID:         uuid.New(),
+		Event:      models.EventThresholdReached,
+		CalendarID: uuid.New(),
+		Data:       json.RawMessage(`{"calendar_name":"Five-a-side","calendar_url":"https://whento.example.com/c/abc","date":"2025-06-14","count":10,"threshold":10}`),
+	}
+
+	body, err := payload(models.FormatIFTTT, event)
+	if err != nil {
+		t.Fatalf("payload() error = %v", err)
+	}
+	if want := `{"value1":"Five-a-side","value2":"2025-06-14","value3":"10/10"}`; string(body) != want {
+		t.Errorf("payload() = %s, want %s", body, want)
+	}
+
+	// The default format is the full event
+	body, err = payload(models.FormatDefault, event)
+	if err != nil {
+		t.Fatalf("payload() error = %v", err)
+	}
+	var decoded models.Event
+	if err := json.Unmarshal(body, &decoded); err != nil || decoded.ID != event.ID {
+		t.Errorf("payload() = %s, want the event", body)
+	}
+}
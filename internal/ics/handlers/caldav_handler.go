@@ -0,0 +1,433 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/whento/pkg/logger"
+	"github.com/whento/whento/internal/ics/models"
+	"github.com/whento/whento/internal/ics/service"
+)
+
+// XML namespaces of WebDAV, CalDAV and the calendarserver.org extensions (getctag)
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsCS     = "http://calendarserver.org/ns/"
+)
+
+// davPrefixes are the prefixes declared on every multistatus response
+var davPrefixes = map[string]string{nsDAV: "D", nsCalDAV: "C", nsCS: "CS"}
+
+// davAllow lists the methods of the read-only CalDAV collection
+const davAllow = "OPTIONS, GET, HEAD, PROPFIND, REPORT"
+
+// DAVHandler exposes calendars as read-only CalDAV collections, for clients subscribing natively
+// (Thunderbird, DAVx5, Apple Calendar) with ETag-based sync instead of polling the ICS feed.
+// Collections are addressed by the ICS token: /dav/calendars/{token}/
+type DAVHandler struct {
+	icsService *service.ICSService
+}
+
+// NewDAVHandler creates a new CalDAV handler
+func NewDAVHandler(icsService *service.ICSService) *DAVHandler {
+	return &DAVHandler{icsService: icsService}
+}
+
+// davProps maps the name of a property to its inner XML
+type davProps map[xml.Name]string
+
+// davResponse is a response element of a multistatus: a resource with its properties, or an error status
+type davResponse struct {
+	Href    string
+	Found   davProps
+	Missing []xml.Name
+	Status  int // Set instead of properties, e.g. 404 for unknown multiget hrefs
+}
+
+// propRequest lists the properties requested by a PROPFIND or REPORT body
+type propRequest struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type propfindRequest struct {
+	XMLName xml.Name     `xml:"DAV: propfind"`
+	AllProp *struct{}    `xml:"DAV: allprop"`
+	Prop    *propRequest `xml:"DAV: prop"`
+}
+
+type reportRequest struct {
+	XMLName xml.Name
+	Prop    *propRequest `xml:"DAV: prop"`
+	Hrefs   []string     `xml:"DAV: href"`
+	Filter  *struct {
+		CompFilter compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+type compFilter struct {
+	Name        string       `xml:"name,attr"`
+	TimeRange   *timeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	CompFilters []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type timeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+// ServeHTTP handles /dav/calendars/{token}/ and the events it contains, /dav/calendars/{token}/{object}
+// Supports OPTIONS, PROPFIND (Depth 0/1), REPORT (calendar-query with time-range, calendar-multiget) and GET.
+// Not in the Swagger docs, which can't describe WebDAV methods.
+func (h *DAVHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", "1, calendar-access")
+	w.Header().Set("Allow", davAllow)
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodGet, http.MethodHead, "PROPFIND", "REPORT":
+	default:
+		http.Error(w, "Read-only calendar", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := chi.URLParam(r, "token")
+	calendar, err := h.icsService.GetDAVCalendar(r.Context(), token, requestHost(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCalendarNotFound):
+			http.Error(w, "Calendar not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrQuotaExceeded):
+			http.Error(w, "Calendar owner has exceeded their quota. Please delete calendars or upgrade to access this calendar.", http.StatusForbidden)
+		default:
+			logger.FromContext(r.Context()).Error("Failed to get CalDAV calendar", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	collectionHref := "/dav/calendars/" + token + "/"
+	objectName := chi.URLParam(r, "object")
+
+	var object *models.DAVObject
+	if objectName != "" {
+		if object = findDAVObject(calendar, objectName); object == nil {
+			http.Error(w, "Event not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.get(w, r, calendar, object)
+	case "PROPFIND":
+		h.propfind(w, r, calendar, object, collectionHref)
+	case "REPORT":
+		if object != nil {
+			http.Error(w, "Reports are only supported on the calendar collection", http.StatusMethodNotAllowed)
+			return
+		}
+		h.report(w, r, calendar, collectionHref)
+	}
+}
+
+// get serves an event, or all of them as a single iCalendar for the collection
+func (h *DAVHandler) get(w http.ResponseWriter, r *http.Request, calendar *models.DAVCalendar, object *models.DAVObject) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if object == nil {
+		content, err := h.icsService.GenerateFeed(r.Context(), chi.URLParam(r, "token"), requestHost(r), models.FeedWindow{})
+		if err != nil {
+			logger.FromContext(r.Context()).Error("Failed to generate ICS feed", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", `"`+calendar.CTag+`"`)
+		writeBody(w, r, content)
+		return
+	}
+
+	w.Header().Set("ETag", object.ETag)
+	if r.Header.Get("If-None-Match") == object.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeBody(w, r, object.Data)
+}
+
+// propfind lists the properties of the collection (and its events with Depth: 1) or of an event
+func (h *DAVHandler) propfind(w http.ResponseWriter, r *http.Request, calendar *models.DAVCalendar, object *models.DAVObject, collectionHref string) {
+	var req propfindRequest
+	if err := decodeDAVBody(r, &req); err != nil {
+		http.Error(w, "Invalid PROPFIND body", http.StatusBadRequest)
+		return
+	}
+
+	// No body or allprop returns every property
+	var names []xml.Name
+	if req.AllProp == nil && req.Prop != nil {
+		names = req.Prop.names()
+	}
+
+	if object != nil {
+		writeMultistatus(w, []davResponse{selectProps(collectionHref+object.Name, objectProps(object, false), names)})
+		return
+	}
+
+	responses := []davResponse{selectProps(collectionHref, collectionProps(calendar), names)}
+	if r.Header.Get("Depth") != "0" {
+		for i := range calendar.Objects {
+			object := &calendar.Objects[i]
+			responses = append(responses, selectProps(collectionHref+object.Name, objectProps(object, false), names))
+		}
+	}
+	writeMultistatus(w, responses)
+}
+
+// report answers calendar-query (optionally filtered by time range) and calendar-multiget reports
+func (h *DAVHandler) report(w http.ResponseWriter, r *http.Request, calendar *models.DAVCalendar, collectionHref string) {
+	var req reportRequest
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid REPORT body", http.StatusBadRequest)
+		return
+	}
+
+	var names []xml.Name
+	if req.Prop != nil {
+		names = req.Prop.names()
+	}
+
+	var responses []davResponse
+	switch req.XMLName {
+	case xml.Name{Space: nsCalDAV, Local: "calendar-query"}:
+		var start, end time.Time
+		if req.Filter != nil {
+			if tr := req.Filter.CompFilter.eventTimeRange(); tr != nil {
+				start, end = parseDAVTime(tr.Start), parseDAVTime(tr.End)
+			}
+		}
+		for i := range calendar.Objects {
+			object := &calendar.Objects[i]
+			if (!end.IsZero() && !object.Start.Before(end)) || (!start.IsZero() && !object.End.After(start)) {
+				continue
+			}
+			responses = append(responses, selectProps(collectionHref+object.Name, objectProps(object, true), names))
+		}
+
+	case xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}:
+		for _, href := range req.Hrefs {
+			href = strings.TrimSpace(href)
+			if object := findDAVObject(calendar, path.Base(href)); object != nil {
+				responses = append(responses, selectProps(href, objectProps(object, true), names))
+			} else {
+				responses = append(responses, davResponse{Href: href, Status: http.StatusNotFound})
+			}
+		}
+
+	default:
+		http.Error(w, "Unsupported report", http.StatusForbidden)
+		return
+	}
+
+	writeMultistatus(w, responses)
+}
+
+// collectionProps returns the properties of a calendar collection
+func collectionProps(calendar *models.DAVCalendar) davProps {
+	return davProps{
+		{Space: nsDAV, Local: "resourcetype"}:                        "<D:collection/><C:calendar/>",
+		{Space: nsDAV, Local: "displayname"}:                         escapeXML(calendar.Name),
+		{Space: nsDAV, Local: "current-user-principal"}:              "<D:unauthenticated/>",
+		{Space: nsDAV, Local: "current-user-privilege-set"}:          "<D:privilege><D:read/></D:privilege>",
+		{Space: nsDAV, Local: "supported-report-set"}:                "<D:supported-report><D:report><C:calendar-query/></D:report></D:supported-report><D:supported-report><D:report><C:calendar-multiget/></D:report></D:supported-report>",
+		{Space: nsCalDAV, Local: "calendar-description"}:             escapeXML(calendar.Description),
+		{Space: nsCalDAV, Local: "supported-calendar-component-set"}: `<C:comp name="VEVENT"/>`,
+		{Space: nsCS, Local: "getctag"}:                              calendar.CTag,
+	}
+}
+
+// objectProps returns the properties of an event, with its iCalendar data for reports
+func objectProps(object *models.DAVObject, withData bool) davProps {
+	props := davProps{
+		{Space: nsDAV, Local: "resourcetype"}:     "",
+		{Space: nsDAV, Local: "getetag"}:          escapeXML(object.ETag),
+		{Space: nsDAV, Local: "getcontenttype"}:   "text/calendar; charset=utf-8; component=vevent",
+		{Space: nsDAV, Local: "getcontentlength"}: fmt.Sprint(len(object.Data)),
+	}
+	if withData {
+		props[xml.Name{Space: nsCalDAV, Local: "calendar-data"}] = escapeXML(object.Data)
+	}
+	return props
+}
+
+// selectProps splits the requested properties of a resource into found and missing ones (all when none requested)
+func selectProps(href string, props davProps, names []xml.Name) davResponse {
+	response := davResponse{Href: href, Found: props}
+	if len(names) == 0 {
+		return response
+	}
+
+	response.Found = davProps{}
+	for _, name := range names {
+		if value, ok := props[name]; ok {
+			response.Found[name] = value
+		} else {
+			response.Missing = append(response.Missing, name)
+		}
+	}
+	return response
+}
+
+// writeMultistatus writes a 207 Multi-Status response
+func writeMultistatus(w http.ResponseWriter, responses []davResponse) {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav" xmlns:CS="http://calendarserver.org/ns/">`)
+
+	for _, response := range responses {
+		b.WriteString("<D:response><D:href>" + escapeXML(response.Href) + "</D:href>")
+		if response.Status != 0 {
+			b.WriteString(statusLine(response.Status))
+		}
+		if len(response.Found) > 0 {
+			b.WriteString("<D:propstat><D:prop>")
+			names := make([]xml.Name, 0, len(response.Found))
+			for name := range response.Found {
+				names = append(names, name)
+			}
+			sort.Slice(names, func(i, j int) bool {
+				return names[i].Space+names[i].Local < names[j].Space+names[j].Local
+			})
+			for _, name := range names {
+				writeProp(&b, name, response.Found[name])
+			}
+			b.WriteString("</D:prop>" + statusLine(http.StatusOK) + "</D:propstat>")
+		}
+		if len(response.Missing) > 0 {
+			b.WriteString("<D:propstat><D:prop>")
+			for _, name := range response.Missing {
+				writeProp(&b, name, "")
+			}
+			b.WriteString("</D:prop>" + statusLine(http.StatusNotFound) + "</D:propstat>")
+		}
+		b.WriteString("</D:response>")
+	}
+	b.WriteString("</D:multistatus>")
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	_, _ = io.WriteString(w, b.String())
+}
+
+// writeProp writes a property element, declaring its namespace when it has no known prefix
+func writeProp(b *strings.Builder, name xml.Name, value string) {
+	tag := davPrefixes[name.Space] + ":" + name.Local
+	open := tag
+	if _, ok := davPrefixes[name.Space]; !ok {
+		tag = "X:" + name.Local
+		open = tag + ` xmlns:X="` + escapeXML(name.Space) + `"`
+	}
+
+	if value == "" {
+		b.WriteString("<" + open + "/>")
+		return
+	}
+	b.WriteString("<" + open + ">" + value + "</" + tag + ">")
+}
+
+func statusLine(status int) string {
+	return fmt.Sprintf("<D:status>HTTP/1.1 %d %s</D:status>", status, http.StatusText(status))
+}
+
+// names returns the requested property names
+func (p *propRequest) names() []xml.Name {
+	names := make([]xml.Name, 0, len(p.Names))
+	for _, n := range p.Names {
+		names = append(names, n.XMLName)
+	}
+	return names
+}
+
+// eventTimeRange returns the time range filtering VEVENT components, if any
+func (f *compFilter) eventTimeRange() *timeRange {
+	if f.Name == "VEVENT" {
+		return f.TimeRange
+	}
+	for i := range f.CompFilters {
+		if tr := f.CompFilters[i].eventTimeRange(); tr != nil {
+			return tr
+		}
+	}
+	return nil
+}
+
+// parseDAVTime parses a CalDAV UTC date-time (e.g. 20250614T000000Z), zero if absent or invalid
+func parseDAVTime(value string) time.Time {
+	t, err := time.Parse("20060102T150405Z", value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// decodeDAVBody decodes an optional XML body, leaving the request empty when there is none
+func decodeDAVBody(r *http.Request, v any) error {
+	err := xml.NewDecoder(r.Body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+func findDAVObject(calendar *models.DAVCalendar, name string) *models.DAVObject {
+	for i := range calendar.Objects {
+		if calendar.Objects[i].Name == name {
+			return &calendar.Objects[i]
+		}
+	}
+	return nil
+}
+
+func writeBody(w http.ResponseWriter, r *http.Request, content string) {
+	w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		_, _ = io.WriteString(w, content)
+	}
+}
+
+func escapeXML(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// requestHost returns the host of the request, used in event UIDs
+// Priority order: X-Forwarded-Host (behind a proxy), X-Real-Host (alternative proxy header), then r.Host
+func requestHost(r *http.Request) string {
+	if host := r.Header.Get("X-Forwarded-Host"); host != "" {
+		return host
+	}
+	if host := r.Header.Get("X-Real-Host"); host != "" {
+		return host
+	}
+	return r.Host
+}
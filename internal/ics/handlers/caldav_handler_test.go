@@ -0,0 +1,157 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/ics/handlers"
+	"github.com/whento/whento/internal/ics/repository"
+	"github.com/whento/whento/internal/ics/service"
+)
+
+// newDAVHandler returns a CalDAV handler over a calendar with two confirmed evenings, on June 1 and June 8 2025
+func newDAVHandler() *handlers.DAVHandler {
+	calendar := &repository.Calendar{
+		ID:                uuid.New(),
+		Name:              "Board games & co",
+		Threshold:         2,
+		AllowedWeekdays:   []int{0, 1, 2, 3, 4, 5, 6},
+		Timezone:          "Europe/Paris",
+		HolidaysPolicy:    "ignore",
+		OwnerID:           uuid.New(),
+		TotalParticipants: 2,
+	}
+
+	start, end := "19:00", "23:00"
+	events := map[time.Time][]repository.DateAvailability{}
+	for _, date := range []time.Time{time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)} {
+		for _, name := range []string{"Alice", "Bob"} {
+			events[date] = append(events[date], repository.DateAvailability{
+				Date: date, ParticipantName: name, StartTime: &start, EndTime: &end, AvailableCount: 2, TotalParticipants: 2,
+			})
+		}
+	}
+
+	icsSvc := service.NewICSService(&mockCalendarRepository{calendar: calendar}, &mockAvailabilityRepository{events: events}, nil, &mockQuotaChecker{}, "localhost:8080")
+	return handlers.NewDAVHandler(icsSvc)
+}
+
+func serveDAV(handler *handlers.DAVHandler, method, object, depth, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/dav/calendars/test-token/"+object, strings.NewReader(body))
+	if depth != "" {
+		req.Header.Set("Depth", depth)
+	}
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("token", "test-token")
+	rctx.URLParams.Add("object", object)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestDAV_Options(t *testing.T) {
+	w := serveDAV(newDAVHandler(), http.MethodOptions, "", "", "")
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Header().Get("DAV"), "calendar-access") {
+		t.Errorf("OPTIONS = %d, DAV %q, want 200 with calendar-access", w.Code, w.Header().Get("DAV"))
+	}
+}
+
+func TestDAV_PropfindCollection(t *testing.T) {
+	handler := newDAVHandler()
+	body := `<?xml version="1.0"?><D:propfind xmlns:D="DAV:" xmlns:CS="http://calendarserver.org/ns/"><D:prop><D:resourcetype/><D:displayname/><CS:getctag/><D:getetag/><D:owner/></D:prop></D:propfind>`
+
+	w := serveDAV(handler, "PROPFIND", "", "1", body)
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("PROPFIND = %d, want 207", w.Code)
+	}
+
+	response := w.Body.String()
+	for _, want := range []string{
+		"<D:href>/dav/calendars/test-token/</D:href>",
+		"<D:resourcetype><D:collection/><C:calendar/></D:resourcetype>",
+		"<D:displayname>Board games &amp; co</D:displayname>",
+		"<CS:getctag>",
+		"<D:href>/dav/calendars/test-token/20250601.ics</D:href>",
+		"<D:href>/dav/calendars/test-token/20250608.ics</D:href>",
+		"<D:owner/></D:prop><D:status>HTTP/1.1 404 Not Found</D:status>",
+	} {
+		if !strings.Contains(response, want) {
+			t.Errorf("response doesn't contain %q:\n%s", want, response)
+		}
+	}
+
+	// Depth 0 only describes the collection
+	w = serveDAV(handler, "PROPFIND", "", "0", body)
+	if strings.Contains(w.Body.String(), "20250601.ics") {
+		t.Error("Depth: 0 lists the events")
+	}
+}
+
+func TestDAV_ETagsAreStable(t *testing.T) {
+	handler := newDAVHandler()
+
+	first := serveDAV(handler, http.MethodGet, "20250601.ics", "", "")
+	time.Sleep(1100 * time.Millisecond) // DTSTAMP changes every second
+	second := serveDAV(handler, http.MethodGet, "20250601.ics", "", "")
+
+	etag := first.Header().Get("ETag")
+	if etag == "" || etag != second.Header().Get("ETag") {
+		t.Errorf("ETags %q and %q differ", etag, second.Header().Get("ETag"))
+	}
+	if !strings.Contains(first.Body.String(), "DTSTART:20250601T190000") {
+		t.Errorf("unexpected event:\n%s", first.Body.String())
+	}
+}
+
+func TestDAV_Reports(t *testing.T) {
+	handler := newDAVHandler()
+
+	t.Run("calendar-query with time range", func(t *testing.T) {
+		body := `<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav"><D:prop><D:getetag/><C:calendar-data/></D:prop>
+			<C:filter><C:comp-filter name="VCALENDAR"><C:comp-filter name="VEVENT"><C:time-range start="20250605T000000Z" end="20250701T000000Z"/></C:comp-filter></C:comp-filter></C:filter></C:calendar-query>`
+
+		w := serveDAV(handler, "REPORT", "", "1", body)
+		response := w.Body.String()
+		if w.Code != http.StatusMultiStatus || !strings.Contains(response, "20250608.ics") || strings.Contains(response, "20250601.ics") {
+			t.Errorf("REPORT = %d, want only the event of June 8:\n%s", w.Code, response)
+		}
+		if !strings.Contains(response, "<C:calendar-data>BEGIN:VCALENDAR") {
+			t.Errorf("calendar data missing:\n%s", response)
+		}
+	})
+
+	t.Run("calendar-multiget", func(t *testing.T) {
+		body := `<C:calendar-multiget xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav"><D:prop><D:getetag/></D:prop>
+			<D:href>/dav/calendars/test-token/20250601.ics</D:href><D:href>/dav/calendars/test-token/20990101.ics</D:href></C:calendar-multiget>`
+
+		response := serveDAV(handler, "REPORT", "", "", body).Body.String()
+		if !strings.Contains(response, "20250601.ics</D:href><D:propstat>") {
+			t.Errorf("known event missing:\n%s", response)
+		}
+		if !strings.Contains(response, "20990101.ics</D:href><D:status>HTTP/1.1 404 Not Found</D:status>") {
+			t.Errorf("unknown event not reported as 404:\n%s", response)
+		}
+	})
+}
+
+func TestDAV_ReadOnly(t *testing.T) {
+	w := serveDAV(newDAVHandler(), http.MethodPut, "20250601.ics", "", "BEGIN:VCALENDAR")
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT = %d, want 405", w.Code)
+	}
+}
@@ -6,15 +6,23 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/whento/pkg/httputil"
 	"github.com/whento/pkg/logger"
+	"github.com/whento/whento/internal/ics/models"
 	"github.com/whento/whento/internal/ics/service"
 )
 
+// maxFeedWindowDays is the largest past_days or future_days of a feed request
+const maxFeedWindowDays = 3650
+
 type ICSHandler struct {
 	icsService *service.ICSService
 }
@@ -33,8 +41,13 @@ func NewICSHandler(icsService *service.ICSService) *ICSHandler {
 //	@Tags			ICS
 //	@Produce		text/calendar
 //	@Param			token	path		string	true	"ICS token (with or without .ics extension)"
+//	@Param			past_days			query		int		false	"Days of past events to include (defaults to the calendar setting, all if unset)"
+//	@Param			future_days			query		int		false	"Days of upcoming events to include (defaults to the calendar setting, all if unset)"
+//	@Param			If-None-Match		header		string	false	"ETag of a previously fetched feed"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified date of a previously fetched feed"
 //	@Success		200		{string}	string	"iCalendar feed content"
-//	@Failure		400		{string}	string	"Token required"
+//	@Success		304		{string}	string	"Feed not modified"
+//	@Failure		400		{string}	string	"Token required, or invalid window"
 //	@Failure		403		{string}	string	"Quota exceeded (over limit)"
 //	@Failure		404		{string}	string	"Calendar not found"
 //	@Router			/api/v1/ics/feed/{token} [get]
@@ -52,41 +65,46 @@ func (h *ICSHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Extract host from request
-	// Priority order:
-	// 1. X-Forwarded-Host header (when behind a proxy)
-	// 2. X-Real-Host header (alternative proxy header)
-	// 3. r.Host (direct request)
-	host := r.Header.Get("X-Forwarded-Host")
-	if host == "" {
-		host = r.Header.Get("X-Real-Host")
+	// Optional date window, overriding the calendar defaults
+	pastDays, err := windowDays(r, "past_days")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	if host == "" {
-		host = r.Host
+	futureDays, err := windowDays(r, "future_days")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	window := models.FeedWindow{PastDays: pastDays, FutureDays: futureDays}
+
+	// Answer unchanged feeds without generating them
+	version, err := h.icsService.GetFeedVersion(r.Context(), token, requestHost(r), time.Now())
+	if err != nil {
+		h.feedError(w, r, err)
+		return
+	}
+
+	// Clients may cache the feed but must revalidate it on each poll
+	w.Header().Set("ETag", version.ETag)
+	w.Header().Set("Last-Modified", version.LastModified.Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "no-cache, must-revalidate")
+
+	if feedNotModified(r, version) {
+		w.WriteHeader(http.StatusNotModified)
+		return
 	}
 
 	// Generate ICS feed with the actual host from the request
-	icsContent, err := h.icsService.GenerateFeed(r.Context(), token, host)
+	icsContent, err := h.icsService.GenerateFeed(r.Context(), token, requestHost(r), window)
 	if err != nil {
-		if errors.Is(err, service.ErrCalendarNotFound) {
-			http.Error(w, "Calendar not found", http.StatusNotFound)
-			return
-		}
-		if errors.Is(err, service.ErrQuotaExceeded) {
-			http.Error(w, "Calendar owner has exceeded their quota. Please delete calendars or upgrade to access this feed.", http.StatusForbidden)
-			return
-		}
-		log.Error("Failed to generate ICS feed", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		h.feedError(w, r, err)
 		return
 	}
 
 	// Set headers for iCalendar response
 	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
 	w.Header().Set("Content-Disposition", "inline; filename=\"calendar.ics\"")
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Header().Set("Pragma", "no-cache")
-	w.Header().Set("Expires", "0")
 
 	// Write response
 	w.WriteHeader(http.StatusOK)
@@ -94,3 +112,163 @@ func (h *ICSHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 		log.Error("Failed to write ICS response", "error", err)
 	}
 }
+
+// windowDays parses a feed window query parameter, nil when absent
+func windowDays(r *http.Request, param string) (*int, error) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return nil, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 || days > maxFeedWindowDays {
+		return nil, fmt.Errorf("invalid %s: expected a number of days between 0 and %d", param, maxFeedWindowDays)
+	}
+	return &days, nil
+}
+
+// feedError writes the response of a failed feed request
+func (h *ICSHandler) feedError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrCalendarNotFound) {
+		http.Error(w, "Calendar not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, service.ErrQuotaExceeded) {
+		http.Error(w, "Calendar owner has exceeded their quota. Please delete calendars or upgrade to access this feed.", http.StatusForbidden)
+		return
+	}
+	logger.FromContext(r.Context()).Error("Failed to generate ICS feed", "error", err)
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
+
+// feedNotModified evaluates the conditional headers of a feed request (RFC 9110 section 13.2.2)
+// If-None-Match takes precedence over If-Modified-Since, entity tags are compared weakly
+func feedNotModified(r *http.Request, version *models.FeedVersion) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(version.ETag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !version.LastModified.After(since)
+}
+
+// GetSensor handles GET /api/v1/ics/sensor/{token}
+// Summarizes the next confirmed event of a calendar for home automation using its ICS token
+//
+//	@Summary		Get calendar sensor
+//	@Description	Returns the next confirmed event of a calendar (a date reaching the threshold, as in the ICS feed) and the days until it, for a Home Assistant REST sensor. Uses the calendar's ICS token.
+//	@Tags			ICS
+//	@Produce		json
+//	@Param			token	path		string					true	"ICS token"
+//	@Success		200		{object}	models.Sensor			"Calendar sensor"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Quota exceeded (over limit)"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/api/v1/ics/sensor/{token} [get]
+func (h *ICSHandler) GetSensor(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	sensor, err := h.icsService.GetSensor(r.Context(), chi.URLParam(r, "token"), time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+			return
+		}
+		if errors.Is(err, service.ErrQuotaExceeded) {
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, err.Error())
+			return
+		}
+		log.Error("Failed to get calendar sensor", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	httputil.JSON(w, http.StatusOK, sensor)
+}
+
+// GetFreeBusy handles GET /api/v1/calendars/public/{token}/freebusy
+// Returns the busy and free time of a calendar, in JSON or as an iCalendar VFREEBUSY
+//
+//	@Summary		Get calendar free/busy time
+//	@Description	Returns the merged busy time of a calendar (its confirmed events, as in the ICS feed) between start and end, and the free time between them, for external schedulers. Uses the public token of the calendar. Responds with an RFC 5545 VFREEBUSY when format=ics or the Accept header asks for text/calendar.
+//	@Tags			ICS
+//	@Produce		json
+//	@Produce		text/calendar
+//	@Param			token	path		string					true	"Public token"
+//	@Param			start	query		string					false	"Start of the range, RFC 3339 or YYYY-MM-DD (UTC), defaults to now"
+//	@Param			end		query		string					false	"End of the range, RFC 3339 or YYYY-MM-DD (UTC), defaults to 30 days after start, at most 366"
+//	@Param			format	query		string					false	"json (default) or ics"
+//	@Success		200		{object}	models.FreeBusy			"Free/busy time"
+//	@Failure		400		{object}	httputil.ErrorResponse	"Invalid range"
+//	@Failure		403		{object}	httputil.ErrorResponse	"Quota exceeded (over limit)"
+//	@Failure		404		{object}	httputil.ErrorResponse	"Calendar not found"
+//	@Router			/api/v1/calendars/public/{token}/freebusy [get]
+func (h *ICSHandler) GetFreeBusy(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	token := chi.URLParam(r, "token")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	start, err := freeBusyTime(r, "start", now)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	end, err := freeBusyTime(r, "end", start.AddDate(0, 0, 30))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	freeBusy, err := h.icsService.GetFreeBusy(r.Context(), token, start, end)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRange):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		case errors.Is(err, service.ErrCalendarNotFound):
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+		case errors.Is(err, service.ErrQuotaExceeded):
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, err.Error())
+		default:
+			log.Error("Failed to get free/busy time", "error", err)
+			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Internal server error")
+		}
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if wantsICS(r) {
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(service.GenerateFreeBusyICS(freeBusy, token, requestHost(r), now)))
+		return
+	}
+	httputil.JSON(w, http.StatusOK, freeBusy)
+}
+
+// freeBusyTime parses a free/busy range query parameter, RFC 3339 or a date at midnight UTC
+func freeBusyTime(r *http.Request, param string, fallback time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return fallback, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s: expected an RFC 3339 time or a YYYY-MM-DD date", param)
+}
+
+// wantsICS reports whether a free/busy request asks for iCalendar rather than JSON
+func wantsICS(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "ics"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/calendar")
+}
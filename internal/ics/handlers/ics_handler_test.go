@@ -38,6 +38,17 @@ func (m *mockCalendarRepository) GetByICSToken(ctx context.Context, icsToken str
 	return m.calendar, nil
 }
 
+func (m *mockCalendarRepository) GetByPublicToken(ctx context.Context, publicToken string) (*repository.Calendar, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.calendar, nil
+}
+
+func (m *mockCalendarRepository) MarkFetched(ctx context.Context, calendarID uuid.UUID) error {
+	return nil
+}
+
 type mockAvailabilityRepository struct {
 	events map[time.Time][]repository.DateAvailability
 	err    error
@@ -46,13 +57,17 @@ type mockAvailabilityRepository struct {
 // Ensure mockAvailabilityRepository implements service.AvailabilityRepository
 var _ service.AvailabilityRepository = (*mockAvailabilityRepository)(nil)
 
-func (m *mockAvailabilityRepository) GetEventsAboveThreshold(ctx context.Context, calendarID uuid.UUID, threshold int) (map[time.Time][]repository.DateAvailability, error) {
+func (m *mockAvailabilityRepository) GetEventsAboveThreshold(ctx context.Context, calendarID uuid.UUID, threshold int, countMaybe bool) (map[time.Time][]repository.DateAvailability, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.events, nil
 }
 
+func (m *mockAvailabilityRepository) GetConfirmedDates(ctx context.Context, calendarID uuid.UUID) (map[time.Time]bool, error) {
+	return nil, nil
+}
+
 type mockQuotaChecker struct {
 	isOverQuota bool
 	err         error
@@ -116,7 +131,7 @@ func TestGetFeed_Success(t *testing.T) {
 
 	// Create service and handler
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "localhost:8080")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "localhost:8080")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	// Create request
@@ -144,7 +159,7 @@ func TestGetFeed_Success(t *testing.T) {
 
 	// Check cache headers
 	cacheControl := w.Header().Get("Cache-Control")
-	if cacheControl != "no-cache, no-store, must-revalidate" {
+	if cacheControl != "no-cache, must-revalidate" {
 		t.Errorf("Expected Cache-Control header with no-cache, got '%s'", cacheControl)
 	}
 
@@ -175,7 +190,7 @@ func TestGetFeed_CalendarNotFound(t *testing.T) {
 
 	// Create service and handler
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "localhost:8080")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "localhost:8080")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	// Create request
@@ -203,7 +218,7 @@ func TestGetFeed_MissingToken(t *testing.T) {
 
 	// Create service and handler
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "localhost:8080")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "localhost:8080")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	// Create request with empty token
@@ -224,6 +239,193 @@ func TestGetFeed_MissingToken(t *testing.T) {
 	}
 }
 
+func TestGetFeed_ConditionalRequests(t *testing.T) {
+	calendar := &repository.Calendar{
+		ID:                uuid.New(),
+		Name:              "Test Calendar",
+		Threshold:         1,
+		AllowedWeekdays:   []int{0, 1, 2, 3, 4, 5, 6},
+		Timezone:          "Europe/Paris",
+		HolidaysPolicy:    "ignore",
+		OwnerID:           uuid.New(),
+		TotalParticipants: 1,
+		FeedUpdatedAt:     time.Now().Add(time.Hour), // Later than today, whatever the time of day
+	}
+	icsSvc := service.NewICSService(&mockCalendarRepository{calendar: calendar}, &mockAvailabilityRepository{}, nil, &mockQuotaChecker{}, "localhost:8080")
+	handler := handlers.NewICSHandler(icsSvc)
+
+	getFeed := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ics/feed/test-token.ics", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("token", "test-token.ics")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+		handler.GetFeed(w, req)
+		return w
+	}
+
+	first := getFeed(nil)
+	etag := first.Header().Get("ETag")
+	lastModified := first.Header().Get("Last-Modified")
+	if first.Code != http.StatusOK || etag == "" || lastModified == "" {
+		t.Fatalf("first request = %d, ETag %q, Last-Modified %q", first.Code, etag, lastModified)
+	}
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{"matching ETag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"matching ETag among others", map[string]string{"If-None-Match": `"other", ` + etag}, http.StatusNotModified},
+		{"other ETag", map[string]string{"If-None-Match": `W/"other"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": lastModified}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": time.Now().Add(-24 * time.Hour).UTC().Format(http.TimeFormat)}, http.StatusOK},
+		{"If-None-Match takes precedence", map[string]string{"If-None-Match": `W/"other"`, "If-Modified-Since": lastModified}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getFeed(tt.headers)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Error("304 response has a body")
+			}
+		})
+	}
+
+	// Any change to the calendar data changes the validators
+	calendar.FeedUpdatedAt = calendar.FeedUpdatedAt.Add(time.Minute)
+	if w := getFeed(map[string]string{"If-None-Match": etag}); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("after a change: status = %d, ETag %q, want 200 with a new ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func TestGetFeed_Reminders(t *testing.T) {
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	startTime, endTime := "19:00", "23:00"
+
+	newFeed := func(reminders []int) string {
+		calendar := &repository.Calendar{
+			ID:                uuid.New(),
+			Name:              "Test Calendar",
+			Threshold:         1,
+			AllowedWeekdays:   []int{0, 1, 2, 3, 4, 5, 6},
+			Timezone:          "Europe/Paris",
+			HolidaysPolicy:    "ignore",
+			OwnerID:           uuid.New(),
+			TotalParticipants: 1,
+			ReminderMinutes:   reminders,
+		}
+		availabilities := &mockAvailabilityRepository{
+			events: map[time.Time][]repository.DateAvailability{
+				date: {{Date: date, ParticipantName: "Alice", StartTime: &startTime, EndTime: &endTime, AvailableCount: 1, TotalParticipants: 1}},
+			},
+		}
+		icsSvc := service.NewICSService(&mockCalendarRepository{calendar: calendar}, availabilities, nil, &mockQuotaChecker{}, "localhost:8080")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ics/feed/test-token.ics", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("token", "test-token.ics")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+		handlers.NewICSHandler(icsSvc).GetFeed(w, req)
+		return w.Body.String()
+	}
+
+	if body := newFeed(nil); strings.Contains(body, "BEGIN:VALARM") {
+		t.Errorf("Expected no VALARM without reminders, got:\n%s", body)
+	}
+
+	body := newFeed([]int{1440, 90, 60})
+	if count := strings.Count(body, "BEGIN:VALARM"); count != 3 {
+		t.Errorf("Expected 3 VALARM components, got %d:\n%s", count, body)
+	}
+	for _, want := range []string{"TRIGGER:-P1D", "TRIGGER:-PT90M", "TRIGGER:-PT1H", "ACTION:DISPLAY"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected feed to contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestGetFeed_DateWindow(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Paris")
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	startTime, endTime := "19:00", "23:00"
+
+	events := map[time.Time][]repository.DateAvailability{}
+	for _, offset := range []int{-60, 0, 200} {
+		date := today.AddDate(0, 0, offset)
+		events[date] = []repository.DateAvailability{{Date: date, ParticipantName: "Alice", StartTime: &startTime, EndTime: &endTime, AvailableCount: 1, TotalParticipants: 1}}
+	}
+
+	pastDays := 30
+	calendar := &repository.Calendar{
+		ID:                uuid.New(),
+		Name:              "Test Calendar",
+		Threshold:         1,
+		AllowedWeekdays:   []int{0, 1, 2, 3, 4, 5, 6},
+		Timezone:          "Europe/Paris",
+		HolidaysPolicy:    "ignore",
+		OwnerID:           uuid.New(),
+		TotalParticipants: 1,
+		FeedPastDays:      &pastDays,
+	}
+	icsSvc := service.NewICSService(&mockCalendarRepository{calendar: calendar}, &mockAvailabilityRepository{events: events}, nil, &mockQuotaChecker{}, "localhost:8080")
+	handler := handlers.NewICSHandler(icsSvc)
+
+	getFeed := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ics/feed/test-token.ics"+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("token", "test-token.ics")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+		handler.GetFeed(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string // Event titles, numbered over all events
+	}{
+		{"calendar default", "", []string{"#2", "#3"}},
+		{"future limit", "?future_days=100", []string{"#2"}},
+		{"past override", "?past_days=90&future_days=100", []string{"#1", "#2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getFeed(tt.query)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status code 200, got %d", w.Code)
+			}
+			body := w.Body.String()
+			if count := strings.Count(body, "BEGIN:VEVENT"); count != len(tt.want) {
+				t.Errorf("Expected %d events, got %d:\n%s", len(tt.want), count, body)
+			}
+			for _, number := range tt.want {
+				if !strings.Contains(body, "SUMMARY:Test Calendar "+number+" ") {
+					t.Errorf("Expected event %s in the feed:\n%s", number, body)
+				}
+			}
+		})
+	}
+
+	if w := getFeed("?past_days=-1"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 for a negative window, got %d", w.Code)
+	}
+}
+
 func TestGetFeed_UsesCorrectDomain(t *testing.T) {
 	// Setup mock data
 	calID := uuid.New()
@@ -262,7 +464,7 @@ func TestGetFeed_UsesCorrectDomain(t *testing.T) {
 
 	// Create service and handler
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "default.example.com")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "default.example.com")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	// Create request with specific host
@@ -334,7 +536,7 @@ func TestGetFeed_UsesXForwardedHost(t *testing.T) {
 
 	// Create service and handler
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "default.example.com")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "default.example.com")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	// Create request - the Host will be set to localhost:5173 (backend)
@@ -431,7 +633,7 @@ func TestGetFeed_MultipleTimeSlotsPerDay(t *testing.T) {
 	}
 
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "localhost:8080")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "localhost:8080")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ics/feed/test-token.ics", nil)
@@ -526,7 +728,7 @@ func TestGetFeed_ContinuousTimeSlot(t *testing.T) {
 	}
 
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "localhost:8080")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "localhost:8080")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ics/feed/test-token.ics", nil)
@@ -589,7 +791,7 @@ func TestGetFeed_UsesFloatingTimeWithTimezoneHint(t *testing.T) {
 	}
 
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "localhost:8080")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "localhost:8080")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ics/feed/test-token.ics", nil)
@@ -741,7 +943,7 @@ func TestGetFeed_HolidaysAndHolidayEves(t *testing.T) {
 
 	// Create service and handler
 	mockQuota := &mockQuotaChecker{isOverQuota: false}
-	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, mockQuota, "localhost:8080")
+	icsSvc := service.NewICSService(mockCalRepo, mockAvailRepo, nil, mockQuota, "localhost:8080")
 	handler := handlers.NewICSHandler(icsSvc)
 
 	// Create request
@@ -785,3 +987,73 @@ func TestGetFeed_HolidaysAndHolidayEves(t *testing.T) {
 		t.Error("Did not expect to find event #4 (Thursday is not allowed)")
 	}
 }
+
+func TestGetFreeBusy(t *testing.T) {
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	startTime, endTime := "19:00", "23:00"
+	availability := func(name string) repository.DateAvailability {
+		return repository.DateAvailability{Date: date, ParticipantName: name, StartTime: &startTime, EndTime: &endTime, AvailableCount: 2, TotalParticipants: 2}
+	}
+
+	icsSvc := service.NewICSService(
+		&mockCalendarRepository{calendar: &repository.Calendar{
+			ID:                uuid.New(),
+			Name:              "Band practice",
+			Threshold:         2,
+			AllowedWeekdays:   []int{0, 1, 2, 3, 4, 5, 6},
+			Timezone:          "Europe/Paris",
+			HolidaysPolicy:    "ignore",
+			TotalParticipants: 2,
+		}},
+		&mockAvailabilityRepository{events: map[time.Time][]repository.DateAvailability{
+			date: {availability("Alice"), availability("Bob")},
+		}},
+		nil, &mockQuotaChecker{}, "localhost:8080")
+	handler := handlers.NewICSHandler(icsSvc)
+
+	get := func(query string, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/calendars/public/public-token/freebusy?"+query, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("token", "public-token")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		handler.GetFreeBusy(w, req)
+		return w
+	}
+
+	w := get("start=2025-06-01&end=2025-06-02", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`"busy":[{"start":"2025-06-01T17:00:00Z","end":"2025-06-01T21:00:00Z"}]`,
+		`"free":[{"start":"2025-06-01T00:00:00Z","end":"2025-06-01T17:00:00Z"},{"start":"2025-06-01T21:00:00Z","end":"2025-06-02T00:00:00Z"}]`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected JSON to contain %s, got %s", want, body)
+		}
+	}
+
+	for _, tc := range []struct{ query, accept string }{
+		{"start=2025-06-01&end=2025-06-02&format=ics", ""},
+		{"start=2025-06-01&end=2025-06-02", "text/calendar"},
+	} {
+		w := get(tc.query, tc.accept)
+		if ct := w.Header().Get("Content-Type"); ct != "text/calendar; charset=utf-8" {
+			t.Errorf("%s: expected text/calendar, got %q", tc.query, ct)
+		}
+		if !strings.Contains(w.Body.String(), "FREEBUSY;FBTYPE=BUSY:20250601T170000Z/20250601T210000Z") {
+			t.Errorf("%s: expected a busy period, got %s", tc.query, w.Body.String())
+		}
+	}
+
+	for _, query := range []string{"start=2025-06-02&end=2025-06-01", "start=2025-01-01&end=2026-06-01", "start=tomorrow"} {
+		if w := get(query, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status code 400, got %d", query, w.Code)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "time"
+
+// DAVCalendar is a calendar exposed as a read-only CalDAV collection
+type DAVCalendar struct {
+	Name        string
+	Description string
+	CTag        string // Changes whenever an event is added, changed or removed
+	Objects     []DAVObject
+}
+
+// DAVObject is a calendar object resource: a confirmed event serialized as its own iCalendar
+type DAVObject struct {
+	Name   string    // Resource name in the collection, e.g. "20250614.ics"
+	ETag   string    // Quoted entity tag, stable while the event doesn't change
+	Start  time.Time // Used by time-range filters
+	End    time.Time
+	AllDay bool // Event without times, Start and End are midnights
+	Data   string
+}
@@ -17,11 +17,16 @@ type CalendarEvent struct {
 	CalendarName        string
 	CalendarDescription string
 	EventNumber         int
-	AvailableCount      int
+	AvailableCount      int  // Participants counting toward the threshold
+	MaybeCount          int  // Participants who answered maybe
+	Tentative           bool // The threshold is only reached with maybe answers
 	TotalParticipants   int
 	Threshold           int
 	Participants        []ParticipantAvailability
 	Timezone            string
+	ReminderMinutes     []int  // VALARM reminders, in minutes before the start
+	TitleTemplate       string // Empty for the built-in title
+	DescriptionTemplate string // Empty for the participant list
 	// SlotStartTime and SlotEndTime define the time slot for this event
 	// When set, these override the calculated EventTimes()
 	SlotStartTime *string // HH:MM format
@@ -36,6 +41,9 @@ type ParticipantAvailability struct {
 	StartTime *string
 	EndTime   *string
 	Note      string
+	Maybe     bool   // Tentative answer
+	Required  bool   // Required participant of the calendar
+	RSVP      string // Answer to the event (going, not_going), empty if none
 }
 
 // EventTimes calculates the event start and end times based on slot times or participants
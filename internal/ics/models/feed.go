@@ -0,0 +1,20 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "time"
+
+// FeedVersion identifies a version of the ICS feed of a calendar, for conditional requests
+type FeedVersion struct {
+	ETag         string // Weak entity tag, the feed bytes change on every request (DTSTAMP)
+	LastModified time.Time
+}
+
+// FeedWindow limits the events of a feed to a number of days around today
+// Nil bounds fall back to the defaults of the calendar
+type FeedWindow struct {
+	PastDays   *int
+	FutureDays *int
+}
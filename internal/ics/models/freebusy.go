@@ -0,0 +1,23 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "time"
+
+// FreeBusy lists the busy time of a calendar (its confirmed events) over a range, and the free time left
+type FreeBusy struct {
+	CalendarName string          `json:"calendar_name"`
+	Timezone     string          `json:"timezone"`
+	Start        time.Time       `json:"start"`
+	End          time.Time       `json:"end"`
+	Busy         []FreeBusyBlock `json:"busy"` // Merged, sorted and clipped to the range
+	Free         []FreeBusyBlock `json:"free"` // Gaps between the busy blocks
+}
+
+// FreeBusyBlock is a time range of a free/busy response
+type FreeBusyBlock struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
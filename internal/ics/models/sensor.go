@@ -0,0 +1,37 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "time"
+
+// Sensor summarizes the confirmed events of a calendar for home automation (e.g. a Home Assistant REST sensor)
+type Sensor struct {
+	CalendarName   string       `json:"calendar_name"`
+	Threshold      int          `json:"threshold"`
+	SoftThreshold  *int         `json:"soft_threshold,omitempty"`
+	Timezone       string       `json:"timezone"`
+	DaysUntil      *int         `json:"days_until"` // Days until the next confirmed event (0 = today), null if none
+	NextEvent      *SensorEvent `json:"next_event"` // Null if no upcoming date reaches the threshold
+	UpcomingEvents int          `json:"upcoming_events"`
+	LookingGood    int          `json:"looking_good_dates"` // Upcoming dates reaching the soft threshold only, not events
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// SensorEvent is an upcoming confirmed event
+type SensorEvent struct {
+	Date             string    `json:"date" example:"2025-06-14"` // YYYY-MM-DD
+	Start            time.Time `json:"start"`                     // Midnight for all-day events
+	End              time.Time `json:"end"`
+	AllDay           bool      `json:"all_day"`
+	ParticipantCount int       `json:"participant_count"`
+	Participants     []string  `json:"participants"`
+}
+
+// Preview summarizes a shared calendar for the link previews of its public URL
+type Preview struct {
+	CalendarName string
+	Description  string
+	NextEvent    *SensorEvent // Nil if no upcoming date reaches the threshold
+}
@@ -15,10 +15,14 @@ import (
 
 type DateAvailability struct {
 	Date              time.Time
+	ParticipantID     uuid.UUID
 	ParticipantName   string
 	StartTime         *string
 	EndTime           *string
 	Note              string
+	Status            string // yes or maybe
+	Required          bool   // Required participant of the calendar
+	RSVP              string // Answer of the participant to the event (going, not_going), empty if none
 	AvailableCount    int
 	TotalParticipants int
 }
@@ -33,7 +37,8 @@ func NewAvailabilityRepository(db *pgxpool.Pool) *AvailabilityRepository {
 
 // GetEventsAboveThreshold retrieves all dates with availability >= threshold for a calendar
 // This includes both manual availabilities and computed availabilities from recurrences
-func (r *AvailabilityRepository) GetEventsAboveThreshold(ctx context.Context, calendarID uuid.UUID, threshold int) (map[time.Time][]DateAvailability, error) {
+// "maybe" availabilities are returned on those dates, but only count toward the threshold with countMaybe
+func (r *AvailabilityRepository) GetEventsAboveThreshold(ctx context.Context, calendarID uuid.UUID, threshold int, countMaybe bool) (map[time.Time][]DateAvailability, error) {
 	query := `
 		WITH
 		-- Generate all dates in the calendar's recurrence range
@@ -61,28 +66,30 @@ func (r *AvailabilityRepository) GetEventsAboveThreshold(ctx context.Context, ca
 				p.name as participant_name,
 				a.start_time,
 				a.end_time,
-				COALESCE(a.note, '') as note
+				COALESCE(a.note, '') as note,
+				a.status,
+				p.required
 			FROM availabilities a
 			JOIN participants p ON p.id = a.participant_id
 			WHERE p.calendar_id = $1
 
 			UNION
 
-			-- Computed availabilities from recurrences
-			SELECT
+			-- Computed availabilities from recurrences, once per participant and date
+			(SELECT DISTINCT ON (d.date, r.participant_id)
 				d.date,
 				r.participant_id,
 				p.name as participant_name,
 				r.start_time,
 				r.end_time,
-				COALESCE(r.note, '') as note
+				COALESCE(r.note, '') as note,
+				'yes' as status,
+				p.required
 			FROM recurrences r
 			JOIN participants p ON p.id = r.participant_id
 			CROSS JOIN all_dates d
 			WHERE p.calendar_id = $1
-				AND d.date >= r.start_date
-				AND (r.end_date IS NULL OR d.date <= r.end_date)
-				AND EXTRACT(DOW FROM d.date)::int = r.day_of_week
+				AND recurrence_occurs_on(r, d.date)
 				-- Exclude dates with exceptions
 				AND NOT EXISTS (
 					SELECT 1 FROM recurrence_exceptions re
@@ -95,32 +102,40 @@ func (r *AvailabilityRepository) GetEventsAboveThreshold(ctx context.Context, ca
 					WHERE a.participant_id = r.participant_id
 					AND a.date = d.date
 				)
+			ORDER BY d.date, r.participant_id, r.start_time)
 		),
-		-- Count availabilities per date
+		-- Count availabilities per date, every required participant must be counted
 		date_counts AS (
 			SELECT
 				date,
-				COUNT(DISTINCT participant_id) as available_count,
+				COUNT(DISTINCT participant_id) FILTER (WHERE status = 'yes' OR $3) as available_count,
 				(SELECT COUNT(*) FROM participants WHERE calendar_id = $1) as total_participants
 			FROM all_availabilities
 			GROUP BY date
-			HAVING COUNT(DISTINCT participant_id) >= $2
+			HAVING COUNT(DISTINCT participant_id) FILTER (WHERE status = 'yes' OR $3) >= $2
+				AND COUNT(DISTINCT participant_id) FILTER (WHERE required AND (status = 'yes' OR $3))
+					= (SELECT COUNT(*) FROM participants WHERE calendar_id = $1 AND required)
 		)
 		-- Final result
 		SELECT
 			aa.date,
+			aa.participant_id,
 			aa.participant_name,
 			aa.start_time,
 			aa.end_time,
 			aa.note,
+			aa.status,
+			aa.required,
+			COALESCE(v.status, ''),
 			dc.available_count,
 			dc.total_participants
 		FROM all_availabilities aa
 		JOIN date_counts dc ON dc.date = aa.date
+		LEFT JOIN rsvps v ON v.participant_id = aa.participant_id AND v.date = aa.date
 		ORDER BY aa.date, aa.participant_name
 	`
 
-	rows, err := r.db.Query(ctx, query, calendarID, threshold)
+	rows, err := r.db.Query(ctx, query, calendarID, threshold, countMaybe)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events above threshold: %w", err)
 	}
@@ -135,10 +150,14 @@ func (r *AvailabilityRepository) GetEventsAboveThreshold(ctx context.Context, ca
 
 		err := rows.Scan(
 			&da.Date,
+			&da.ParticipantID,
 			&da.ParticipantName,
 			&startTime,
 			&endTime,
 			&da.Note,
+			&da.Status,
+			&da.Required,
+			&da.RSVP,
 			&da.AvailableCount,
 			&da.TotalParticipants,
 		)
@@ -165,3 +184,33 @@ func (r *AvailabilityRepository) GetEventsAboveThreshold(ctx context.Context, ca
 
 	return eventsByDate, nil
 }
+
+// GetConfirmedDates retrieves the dates confirmed by the owner of a calendar requiring confirmation
+func (r *AvailabilityRepository) GetConfirmedDates(ctx context.Context, calendarID uuid.UUID) (map[time.Time]bool, error) {
+	query := `
+		SELECT date
+		FROM event_confirmations
+		WHERE calendar_id = $1 AND status = 'confirmed'
+	`
+
+	rows, err := r.db.Query(ctx, query, calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query confirmed dates: %w", err)
+	}
+	defer rows.Close()
+
+	confirmed := make(map[time.Time]bool)
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, fmt.Errorf("failed to scan confirmed date: %w", err)
+		}
+		confirmed[date] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating confirmed dates: %w", err)
+	}
+
+	return confirmed, nil
+}
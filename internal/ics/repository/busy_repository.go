@@ -0,0 +1,55 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BusyBlock is a time range during which the calendar owner is busy (synced from CalDAV)
+type BusyBlock struct {
+	Start time.Time
+	End   time.Time
+}
+
+type BusyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBusyRepository(db *pgxpool.Pool) *BusyRepository {
+	return &BusyRepository{db: db}
+}
+
+// GetBusyBlocks returns the busy blocks of a user overlapping [from, to)
+func (r *BusyRepository) GetBusyBlocks(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]BusyBlock, error) {
+	query := `
+		SELECT start_at, end_at
+		FROM caldav_busy_blocks
+		WHERE user_id = $1 AND start_at < $3 AND end_at > $2
+		ORDER BY start_at
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query busy blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []BusyBlock
+	for rows.Next() {
+		var block BusyBlock
+		if err := rows.Scan(&block.Start, &block.End); err != nil {
+			return nil, fmt.Errorf("failed to scan busy block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, rows.Err()
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/pkg/datevalidation"
 )
 
 type Calendar struct {
@@ -18,15 +20,31 @@ type Calendar struct {
 	Name              string
 	Description       string
 	Threshold         int
+	SoftThreshold     *int // Lower level, notified only: never creates events
+	RequireConfirm    bool // Only the dates confirmed by the owner become events
 	AllowedWeekdays   []int
 	MinDurationHours  int
 	Timezone          string
 	HolidaysPolicy    string
 	AllowHolidayEves  bool
+	HolidaySets       []string
+	BlackoutDates     []datevalidation.BlackoutPeriod // Dates blocked by the owner, without events
+	CustomHolidays    datevalidation.CustomHolidays   // Holidays added by the owner
+	HolidayCountry    string                          // Empty to derive the country from the timezone
+	HolidayRegion     string
+	HolidayCountries  []string // Other countries whose public holidays also count
 	OwnerID           uuid.UUID
+	QuotaOwnerID      uuid.UUID // Owner of the organization of the calendar, or its owner for personal calendars
 	TotalParticipants int
 	StartDate         *time.Time
 	EndDate           *time.Time
+	ReminderMinutes   []int     // VALARM reminders of the events, in minutes before the start
+	EventTitle        *string   // Title of the events, nil for the built-in title
+	EventDescription  *string   // Description of the events, nil for the participant list
+	FeedPastDays      *int      // Days of past events in the feed, nil for all
+	FeedFutureDays    *int      // Days of upcoming events in the feed, nil for all
+	CountMaybe        bool      // "maybe" availabilities count toward the threshold
+	FeedUpdatedAt     time.Time // Last change of the settings, participants, availabilities or owner busy time
 }
 
 type CalendarRepository struct {
@@ -39,47 +57,116 @@ func NewCalendarRepository(db *pgxpool.Pool) *CalendarRepository {
 
 // GetByICSToken retrieves a calendar by its ICS token
 func (r *CalendarRepository) GetByICSToken(ctx context.Context, icsToken string) (*Calendar, error) {
+	cal, err := r.getByToken(ctx, "ics_token", icsToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar by ics token: %w", err)
+	}
+	return cal, nil
+}
+
+// GetByPublicToken retrieves a calendar by its public token (link shared with participants)
+func (r *CalendarRepository) GetByPublicToken(ctx context.Context, publicToken string) (*Calendar, error) {
+	cal, err := r.getByToken(ctx, "public_token", publicToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar by public token: %w", err)
+	}
+	return cal, nil
+}
+
+// getByToken retrieves a calendar by one of its token columns
+func (r *CalendarRepository) getByToken(ctx context.Context, column string, token string) (*Calendar, error) {
 	query := `
 		SELECT
 			c.id,
 			c.name,
 			COALESCE(c.description, ''),
 			c.threshold,
+			c.soft_threshold,
+			c.require_confirmation,
 			c.allowed_weekdays,
 			c.min_duration_hours,
 			c.timezone,
 			c.holidays_policy,
 			c.allow_holiday_eves,
+			c.holiday_sets,
+			c.blackout_dates,
+			c.custom_holidays,
+			COALESCE(c.holiday_country, ''),
+			COALESCE(c.holiday_region, ''),
+			c.holiday_countries,
+			c.ics_reminder_minutes,
+			c.ics_title_template,
+			c.ics_description_template,
+			c.ics_past_days,
+			c.ics_future_days,
+			c.count_maybe,
 			c.owner_id,
+			COALESCE((
+				SELECT m.user_id FROM organization_members m
+				WHERE m.organization_id = c.organization_id AND m.role = 'owner'
+			), c.owner_id),
 			c.start_date,
 			c.end_date,
+			GREATEST(
+				c.updated_at,
+				c.feed_updated_at,
+				(SELECT ca.last_sync_at FROM caldav_accounts ca WHERE ca.user_id = c.owner_id)
+			),
 			COUNT(p.id) as total_participants
 		FROM calendars c
 		LEFT JOIN participants p ON p.calendar_id = c.id
-		WHERE c.ics_token = $1
-		GROUP BY c.id, c.name, c.description, c.threshold, c.allowed_weekdays, c.min_duration_hours, c.timezone, c.holidays_policy, c.allow_holiday_eves, c.owner_id, c.start_date, c.end_date
+		WHERE c.` + column + ` = $1
+		GROUP BY c.id, c.name, c.description, c.threshold, c.soft_threshold, c.require_confirmation, c.allowed_weekdays, c.min_duration_hours, c.timezone, c.holidays_policy, c.allow_holiday_eves, c.holiday_sets, c.blackout_dates, c.custom_holidays, c.holiday_country, c.holiday_region, c.holiday_countries, c.ics_reminder_minutes, c.ics_title_template, c.ics_description_template, c.ics_past_days, c.ics_future_days, c.count_maybe, c.owner_id, c.start_date, c.end_date, c.updated_at, c.feed_updated_at
 	`
 
 	var cal Calendar
-	err := r.db.QueryRow(ctx, query, icsToken).Scan(
+	err := r.db.QueryRow(ctx, query, token).Scan(
 		&cal.ID,
 		&cal.Name,
 		&cal.Description,
 		&cal.Threshold,
+		&cal.SoftThreshold,
+		&cal.RequireConfirm,
 		&cal.AllowedWeekdays,
 		&cal.MinDurationHours,
 		&cal.Timezone,
 		&cal.HolidaysPolicy,
 		&cal.AllowHolidayEves,
+		&cal.HolidaySets,
+		&cal.BlackoutDates,
+		&cal.CustomHolidays,
+		&cal.HolidayCountry,
+		&cal.HolidayRegion,
+		&cal.HolidayCountries,
+		&cal.ReminderMinutes,
+		&cal.EventTitle,
+		&cal.EventDescription,
+		&cal.FeedPastDays,
+		&cal.FeedFutureDays,
+		&cal.CountMaybe,
 		&cal.OwnerID,
+		&cal.QuotaOwnerID,
 		&cal.StartDate,
 		&cal.EndDate,
+		&cal.FeedUpdatedAt,
 		&cal.TotalParticipants,
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get calendar by ics token: %w", err)
+		return nil, err
 	}
 
 	return &cal, nil
 }
+
+// MarkFetched records a fetch of the feed of a calendar, at most once an hour to avoid a write per poll
+func (r *CalendarRepository) MarkFetched(ctx context.Context, calendarID uuid.UUID) error {
+	query := `
+		UPDATE calendars SET ics_fetched_at = NOW()
+		WHERE id = $1 AND (ics_fetched_at IS NULL OR ics_fetched_at < NOW() - INTERVAL '1 hour')`
+
+	if _, err := r.db.Exec(ctx, query, calendarID); err != nil {
+		return fmt.Errorf("failed to mark feed as fetched: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/whento/pkg/tracing"
+	"github.com/whento/whento/internal/ics/models"
+	"github.com/whento/whento/internal/ics/repository"
+)
+
+// GetDAVCalendar returns the confirmed events of a calendar as CalDAV resources, using its ICS token
+// Events are the same as those of the iCalendar feed, one resource per event
+func (s *ICSService) GetDAVCalendar(ctx context.Context, icsToken string, host string) (_ *models.DAVCalendar, err error) {
+	ctx, span := tracing.Start(ctx, tracerName, "ICSService.GetDAVCalendar")
+	defer func() { tracing.End(span, err) }()
+
+	domain := host
+	if domain == "" {
+		domain = s.appDomain
+	}
+
+	calendar, events, err := s.confirmedEvents(ctx, icsToken)
+	if err != nil {
+		return nil, err
+	}
+	s.markFetched(ctx, calendar)
+	events = windowEvents(events, calendar, models.FeedWindow{}, time.Now())
+	span.SetAttributes(
+		attribute.String("whento.calendar_id", calendar.ID.String()),
+		attribute.Int("whento.ics.events", len(events)),
+	)
+
+	return s.buildDAVCalendar(calendar, events, domain), nil
+}
+
+// buildDAVCalendar serializes each event on its own and derives the entity tags
+func (s *ICSService) buildDAVCalendar(calendar *repository.Calendar, events []models.CalendarEvent, domain string) *models.DAVCalendar {
+	loc, err := time.LoadLocation(calendar.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	dav := &models.DAVCalendar{
+		Name:        calendar.Name,
+		Description: calendar.Description,
+		Objects:     make([]models.DAVObject, 0, len(events)),
+	}
+
+	ctag := sha256.New()
+	for i := range events {
+		event := &events[i]
+		data := s.generateICS(calendar, []models.CalendarEvent{*event}, domain)
+		start, end, allDay := sensorEventTimes(event, loc)
+
+		object := models.DAVObject{
+			Name:   davObjectName(event),
+			ETag:   davETag(data),
+			Start:  start,
+			End:    end,
+			AllDay: allDay,
+			Data:   data,
+		}
+		dav.Objects = append(dav.Objects, object)
+
+		ctag.Write([]byte(object.Name + object.ETag))
+	}
+	dav.CTag = hex.EncodeToString(ctag.Sum(nil))[:32]
+
+	return dav
+}
+
+// davObjectName names the resource of an event after its date and time slot, as its UID
+func davObjectName(event *models.CalendarEvent) string {
+	if event.SlotIndex > 0 {
+		return fmt.Sprintf("%s-%d.ics", event.Date.Format("20060102"), event.SlotIndex)
+	}
+	return event.Date.Format("20060102") + ".ics"
+}
+
+// davETag hashes an event, ignoring its DTSTAMP which changes on every generation
+func davETag(data string) string {
+	hash := sha256.New()
+	for _, line := range strings.Split(data, "\r\n") {
+		if strings.HasPrefix(line, "DTSTAMP") {
+			continue
+		}
+		hash.Write([]byte(line + "\n"))
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`
+}
@@ -0,0 +1,131 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+
+	"github.com/whento/whento/internal/ics/models"
+)
+
+// maxFreeBusyDays is the longest range of a free/busy query
+const maxFreeBusyDays = 366
+
+var ErrInvalidRange = errors.New("invalid range: end must be after start, at most 366 days later")
+
+// GetFreeBusy returns the busy and free time of a calendar between start and end using its public token
+// Busy time is the confirmed events, as in the ICS feed, so external schedulers avoid them
+func (s *ICSService) GetFreeBusy(ctx context.Context, publicToken string, start, end time.Time) (*models.FreeBusy, error) {
+	if !end.After(start) || end.Sub(start) > maxFreeBusyDays*24*time.Hour {
+		return nil, ErrInvalidRange
+	}
+
+	calendar, err := s.calendarRepo.GetByPublicToken(ctx, publicToken)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+	isOverQuota, _ := s.quotaChecker.IsOverQuota(ctx, calendar.QuotaOwnerID)
+	if isOverQuota {
+		return nil, ErrQuotaExceeded
+	}
+
+	eventsByDate, err := s.thresholdDates(ctx, calendar)
+	if err != nil {
+		return nil, err
+	}
+	events := s.buildCalendarEvents(calendar, eventsByDate, s.ownerBusyBlocks(ctx, calendar, eventsByDate))
+
+	loc := sensorLocation(calendar)
+	blocks := make([]models.FreeBusyBlock, 0, len(events))
+	for i := range events {
+		eventStart, eventEnd, _ := sensorEventTimes(&events[i], loc)
+		blocks = append(blocks, models.FreeBusyBlock{Start: eventStart, End: eventEnd})
+	}
+
+	busy := mergeFreeBusy(blocks, start.UTC(), end.UTC())
+	return &models.FreeBusy{
+		CalendarName: calendar.Name,
+		Timezone:     calendar.Timezone,
+		Start:        start.UTC(),
+		End:          end.UTC(),
+		Busy:         busy,
+		Free:         freeGaps(busy, start.UTC(), end.UTC()),
+	}, nil
+}
+
+// GenerateFreeBusyICS serializes free/busy time as an iCalendar VFREEBUSY (RFC 5545 section 3.6.4)
+func GenerateFreeBusyICS(freeBusy *models.FreeBusy, publicToken, domain string, now time.Time) string {
+	cal := ics.NewCalendar()
+	cal.SetMethod(ics.MethodPublish)
+	cal.SetProductId("-//WhenTo//WhenTo Calendar//EN")
+
+	vfreebusy := cal.AddBusy(publicToken + "-freebusy@" + domain)
+	vfreebusy.SetDtStampTime(now)
+	vfreebusy.SetStartAt(freeBusy.Start)
+	vfreebusy.SetEndAt(freeBusy.End)
+	vfreebusy.SetProperty(ics.ComponentPropertyComment, freeBusy.CalendarName)
+
+	const layout = "20060102T150405Z"
+	for _, block := range freeBusy.Busy {
+		vfreebusy.AddProperty(ics.ComponentPropertyFreebusy,
+			block.Start.UTC().Format(layout)+"/"+block.End.UTC().Format(layout),
+			&ics.KeyValues{Key: string(ics.ParameterFbtype), Value: []string{string(ics.FreeBusyTimeTypeBusy)}})
+	}
+
+	// CRLF line endings as required by RFC 5545 section 3.1
+	return strings.ReplaceAll(cal.Serialize(), "\n", "\r\n")
+}
+
+// mergeFreeBusy clips blocks to [start, end), in UTC, and merges the overlapping or adjacent ones
+func mergeFreeBusy(blocks []models.FreeBusyBlock, start, end time.Time) []models.FreeBusyBlock {
+	clipped := make([]models.FreeBusyBlock, 0, len(blocks))
+	for _, block := range blocks {
+		blockStart, blockEnd := block.Start.UTC(), block.End.UTC()
+		if blockStart.Before(start) {
+			blockStart = start
+		}
+		if blockEnd.After(end) {
+			blockEnd = end
+		}
+		if blockEnd.After(blockStart) {
+			clipped = append(clipped, models.FreeBusyBlock{Start: blockStart, End: blockEnd})
+		}
+	}
+	sort.Slice(clipped, func(i, j int) bool { return clipped[i].Start.Before(clipped[j].Start) })
+
+	merged := make([]models.FreeBusyBlock, 0, len(clipped))
+	for _, block := range clipped {
+		if last := len(merged) - 1; last >= 0 && !block.Start.After(merged[last].End) {
+			if block.End.After(merged[last].End) {
+				merged[last].End = block.End
+			}
+			continue
+		}
+		merged = append(merged, block)
+	}
+	return merged
+}
+
+// freeGaps returns the time of [start, end) outside the merged busy blocks
+func freeGaps(busy []models.FreeBusyBlock, start, end time.Time) []models.FreeBusyBlock {
+	free := make([]models.FreeBusyBlock, 0, len(busy)+1)
+	cursor := start
+	for _, block := range busy {
+		if block.Start.After(cursor) {
+			free = append(free, models.FreeBusyBlock{Start: cursor, End: block.Start})
+		}
+		cursor = block.End
+	}
+	if end.After(cursor) {
+		free = append(free, models.FreeBusyBlock{Start: cursor, End: end})
+	}
+	return free
+}
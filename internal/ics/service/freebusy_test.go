@@ -0,0 +1,79 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/ics/models"
+)
+
+func TestMergeFreeBusy(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2025, 6, 1, hour, 0, 0, 0, time.UTC) }
+	block := func(start, end int) models.FreeBusyBlock { return models.FreeBusyBlock{Start: at(start), End: at(end)} }
+
+	busy := mergeFreeBusy([]models.FreeBusyBlock{
+		block(14, 16),
+		block(8, 10),
+		block(9, 12),  // Overlaps the previous one
+		block(12, 13), // Adjacent
+		block(2, 7),   // Clipped to the range
+		block(20, 23), // Outside the range
+	}, at(6), at(18))
+
+	want := []models.FreeBusyBlock{block(6, 7), block(8, 13), block(14, 16)}
+	if len(busy) != len(want) {
+		t.Fatalf("busy = %v, want %v", busy, want)
+	}
+	for i := range want {
+		if !busy[i].Start.Equal(want[i].Start) || !busy[i].End.Equal(want[i].End) {
+			t.Errorf("busy[%d] = %v, want %v", i, busy[i], want[i])
+		}
+	}
+
+	free := freeGaps(busy, at(6), at(18))
+	wantFree := []models.FreeBusyBlock{block(7, 8), block(13, 14), block(16, 18)}
+	if len(free) != len(wantFree) {
+		t.Fatalf("free = %v, want %v", free, wantFree)
+	}
+	for i := range wantFree {
+		if !free[i].Start.Equal(wantFree[i].Start) || !free[i].End.Equal(wantFree[i].End) {
+			t.Errorf("free[%d] = %v, want %v", i, free[i], wantFree[i])
+		}
+	}
+
+	if free := freeGaps(nil, at(6), at(18)); len(free) != 1 || !free[0].Start.Equal(at(6)) || !free[0].End.Equal(at(18)) {
+		t.Errorf("free without busy time = %v, want the whole range", free)
+	}
+}
+
+func TestGenerateFreeBusyICS(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	freeBusy := &models.FreeBusy{
+		CalendarName: "Band practice",
+		Start:        time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+		Busy: []models.FreeBusyBlock{
+			{Start: time.Date(2025, 6, 1, 19, 0, 0, 0, paris), End: time.Date(2025, 6, 1, 23, 0, 0, 0, paris)},
+		},
+	}
+
+	body := GenerateFreeBusyICS(freeBusy, "public-token", "whento.example.com", time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	for _, want := range []string{
+		"BEGIN:VFREEBUSY\r\n",
+		"UID:public-token-freebusy@whento.example.com\r\n",
+		"DTSTART:20250601T000000Z\r\n",
+		"DTEND:20250602T000000Z\r\n",
+		"FREEBUSY;FBTYPE=BUSY:20250601T170000Z/20250601T210000Z\r\n",
+		"END:VFREEBUSY\r\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("VFREEBUSY missing %q:\n%s", want, body)
+		}
+	}
+}
@@ -6,6 +6,8 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
@@ -14,12 +16,17 @@ import (
 
 	ics "github.com/arran4/golang-ical"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/whento/pkg/datevalidation"
+	"github.com/whento/pkg/tracing"
 	"github.com/whento/whento/internal/ics/models"
 	"github.com/whento/whento/internal/ics/repository"
 )
 
+// tracerName is the tracer of the spans of the ICS module
+const tracerName = "github.com/whento/whento/internal/ics"
+
 var (
 	ErrCalendarNotFound = errors.New("calendar not found")
 	ErrQuotaExceeded    = errors.New("calendar owner has exceeded their quota - please delete calendars or upgrade")
@@ -28,11 +35,19 @@ var (
 // CalendarRepository defines the interface for calendar repository operations
 type CalendarRepository interface {
 	GetByICSToken(ctx context.Context, icsToken string) (*repository.Calendar, error)
+	GetByPublicToken(ctx context.Context, publicToken string) (*repository.Calendar, error)
+	MarkFetched(ctx context.Context, calendarID uuid.UUID) error
 }
 
 // AvailabilityRepository defines the interface for availability repository operations
 type AvailabilityRepository interface {
-	GetEventsAboveThreshold(ctx context.Context, calendarID uuid.UUID, threshold int) (map[time.Time][]repository.DateAvailability, error)
+	GetEventsAboveThreshold(ctx context.Context, calendarID uuid.UUID, threshold int, countMaybe bool) (map[time.Time][]repository.DateAvailability, error)
+	GetConfirmedDates(ctx context.Context, calendarID uuid.UUID) (map[time.Time]bool, error)
+}
+
+// BusyRepository defines the interface for reading the busy time of calendar owners (synced from CalDAV)
+type BusyRepository interface {
+	GetBusyBlocks(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]repository.BusyBlock, error)
 }
 
 // QuotaChecker defines the interface for checking if a user is over quota
@@ -43,6 +58,7 @@ type QuotaChecker interface {
 type ICSService struct {
 	calendarRepo     CalendarRepository
 	availabilityRepo AvailabilityRepository
+	busyRepo         BusyRepository // nil = owner busy time is ignored
 	quotaChecker     QuotaChecker
 	appDomain        string
 }
@@ -50,12 +66,14 @@ type ICSService struct {
 func NewICSService(
 	calendarRepo CalendarRepository,
 	availabilityRepo AvailabilityRepository,
+	busyRepo BusyRepository,
 	quotaChecker QuotaChecker,
 	appDomain string,
 ) *ICSService {
 	return &ICSService{
 		calendarRepo:     calendarRepo,
 		availabilityRepo: availabilityRepo,
+		busyRepo:         busyRepo,
 		quotaChecker:     quotaChecker,
 		appDomain:        appDomain,
 	}
@@ -63,47 +81,204 @@ func NewICSService(
 
 // GenerateFeed generates an iCalendar feed for a calendar using its ICS token
 // The host parameter should be the host from the HTTP request (e.g., "192.168.1.10:8080" or "example.com")
-func (s *ICSService) GenerateFeed(ctx context.Context, icsToken string, host string) (string, error) {
+// Events outside the window (or the calendar default window) are left out
+func (s *ICSService) GenerateFeed(ctx context.Context, icsToken string, host string, window models.FeedWindow) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, tracerName, "ICSService.GenerateFeed")
+	defer func() { tracing.End(span, err) }()
+
 	// Use provided host if available, otherwise fall back to configured appDomain
 	domain := host
 	if domain == "" {
 		domain = s.appDomain
 	}
 
+	calendar, events, err := s.confirmedEvents(ctx, icsToken)
+	if err != nil {
+		return "", err
+	}
+	events = windowEvents(events, calendar, window, time.Now())
+	span.SetAttributes(
+		attribute.String("whento.calendar_id", calendar.ID.String()),
+		attribute.Int("whento.ics.events", len(events)),
+	)
+
+	// Generate ICS
+	ics := s.generateICS(calendar, events, domain)
+
+	return ics, nil
+}
+
+// GetFeedVersion returns the validators of the ICS feed of a calendar, without generating it
+// Calendar clients poll feeds every few minutes: unchanged feeds are answered with 304 Not Modified
+func (s *ICSService) GetFeedVersion(ctx context.Context, icsToken string, host string, now time.Time) (*models.FeedVersion, error) {
+	domain := host
+	if domain == "" {
+		domain = s.appDomain
+	}
+
+	calendar, err := s.feedCalendar(ctx, icsToken)
+	if err != nil {
+		return nil, err
+	}
+	s.markFetched(ctx, calendar)
+
+	// Open-ended recurrences are expanded up to a year from today, so the feed also changes daily
+	lastModified := calendar.FeedUpdatedAt.UTC().Truncate(time.Second)
+	if today := now.UTC().Truncate(24 * time.Hour); today.After(lastModified) {
+		lastModified = today
+	}
+
+	// The UIDs of the events depend on the domain
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s", calendar.ID, lastModified.Unix(), domain)))
+
+	return &models.FeedVersion{
+		ETag:         `W/"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: lastModified,
+	}, nil
+}
+
+// feedCalendar returns the calendar of an ICS token, as long as its owner is within quota
+func (s *ICSService) feedCalendar(ctx context.Context, icsToken string) (*repository.Calendar, error) {
 	// Get calendar
 	calendar, err := s.calendarRepo.GetByICSToken(ctx, icsToken)
 	if err != nil {
-		return "", ErrCalendarNotFound
+		return nil, ErrCalendarNotFound
 	}
 
 	// Check if calendar owner is over quota (subscription/license expired with too many calendars)
 	// If over quota, block ICS feed generation until they delete calendars or upgrade
-	isOverQuota, _ := s.quotaChecker.IsOverQuota(ctx, calendar.OwnerID)
+	isOverQuota, _ := s.quotaChecker.IsOverQuota(ctx, calendar.QuotaOwnerID)
 	if isOverQuota {
-		return "", ErrQuotaExceeded
+		return nil, ErrQuotaExceeded
+	}
+
+	return calendar, nil
+}
+
+// markFetched records that the feed of a calendar is still subscribed to, for the admin statistics
+// Each poll answers the feed version first, and CalDAV clients read the collection, so both record it
+func (s *ICSService) markFetched(ctx context.Context, calendar *repository.Calendar) {
+	// Best effort: a failure must not break the feed
+	_ = s.calendarRepo.MarkFetched(ctx, calendar.ID)
+}
+
+// confirmedEvents returns a calendar and its events reaching the threshold, using its ICS token
+func (s *ICSService) confirmedEvents(ctx context.Context, icsToken string) (*repository.Calendar, []models.CalendarEvent, error) {
+	calendar, err := s.feedCalendar(ctx, icsToken)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Get events above threshold
-	eventsByDate, err := s.availabilityRepo.GetEventsAboveThreshold(ctx, calendar.ID, calendar.Threshold)
+	eventsByDate, err := s.thresholdDates(ctx, calendar)
 	if err != nil {
-		return "", fmt.Errorf("failed to get events: %w", err)
+		return nil, nil, err
 	}
 
-	// Convert to calendar events
-	events := s.buildCalendarEvents(calendar, eventsByDate)
+	// Convert to calendar events, avoiding the owner's busy time
+	return calendar, s.buildCalendarEvents(calendar, eventsByDate, s.ownerBusyBlocks(ctx, calendar, eventsByDate)), nil
+}
 
-	// Generate ICS
-	ics := s.generateICS(calendar, events, domain)
+// thresholdDates returns the availabilities of the dates reaching the threshold that become events
+// Calendars requiring confirmation only keep the dates confirmed by their owner
+func (s *ICSService) thresholdDates(ctx context.Context, calendar *repository.Calendar) (map[time.Time][]repository.DateAvailability, error) {
+	eventsByDate, err := s.availabilityRepo.GetEventsAboveThreshold(ctx, calendar.ID, calendar.Threshold, calendar.CountMaybe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	if !calendar.RequireConfirm {
+		return eventsByDate, nil
+	}
 
-	return ics, nil
+	confirmed, err := s.availabilityRepo.GetConfirmedDates(ctx, calendar.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmed dates: %w", err)
+	}
+	return keepConfirmed(eventsByDate, confirmed), nil
+}
+
+// keepConfirmed removes the dates not confirmed by the owner
+func keepConfirmed(eventsByDate map[time.Time][]repository.DateAvailability, confirmed map[time.Time]bool) map[time.Time][]repository.DateAvailability {
+	for date := range eventsByDate {
+		if !confirmed[date] {
+			delete(eventsByDate, date)
+		}
+	}
+	return eventsByDate
+}
+
+// windowEvents keeps the events within the feed window, in days around today in the calendar timezone
+// Events are filtered after numbering, so that an event keeps its number whatever the window
+func windowEvents(events []models.CalendarEvent, calendar *repository.Calendar, window models.FeedWindow, now time.Time) []models.CalendarEvent {
+	pastDays, futureDays := window.PastDays, window.FutureDays
+	if pastDays == nil {
+		pastDays = calendar.FeedPastDays
+	}
+	if futureDays == nil {
+		futureDays = calendar.FeedFutureDays
+	}
+	if pastDays == nil && futureDays == nil {
+		return events
+	}
+
+	// Event dates are calendar dates at midnight UTC
+	if loc, err := time.LoadLocation(calendar.Timezone); err == nil {
+		now = now.In(loc)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	windowed := make([]models.CalendarEvent, 0, len(events))
+	for _, event := range events {
+		if pastDays != nil && event.Date.Before(today.AddDate(0, 0, -*pastDays)) {
+			continue
+		}
+		if futureDays != nil && event.Date.After(today.AddDate(0, 0, *futureDays)) {
+			continue
+		}
+		windowed = append(windowed, event)
+	}
+	return windowed
+}
+
+// ownerBusyBlocks returns the busy time of the calendar owner over the dates of the events
+// Busy time is best effort: if it can't be read, events are generated without it
+func (s *ICSService) ownerBusyBlocks(ctx context.Context, calendar *repository.Calendar, eventsByDate map[time.Time][]repository.DateAvailability) []repository.BusyBlock {
+	if s.busyRepo == nil || len(eventsByDate) == 0 {
+		return nil
+	}
+
+	var first, last time.Time
+	for date := range eventsByDate {
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+
+	// Widen the range by a day on each side to cover any timezone offset
+	blocks, err := s.busyRepo.GetBusyBlocks(ctx, calendar.OwnerID, first.AddDate(0, 0, -1), last.AddDate(0, 0, 2))
+	if err != nil {
+		return nil
+	}
+	return blocks
 }
 
 // buildCalendarEvents converts repository data to calendar events
 // It uses time slot segmentation to correctly handle cases where different
 // participants are available at different times of the day
-func (s *ICSService) buildCalendarEvents(calendar *repository.Calendar, eventsByDate map[time.Time][]repository.DateAvailability) []models.CalendarEvent {
+// Time slots overlapping the owner's busy blocks are trimmed or split around them
+func (s *ICSService) buildCalendarEvents(calendar *repository.Calendar, eventsByDate map[time.Time][]repository.DateAvailability, busy []repository.BusyBlock) []models.CalendarEvent {
 	var events []models.CalendarEvent
 
+	loc, err := time.LoadLocation(calendar.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	countryCode := datevalidation.HolidayCode(calendar.Timezone, calendar.HolidayCountry, calendar.HolidayRegion)
+	otherCountries := datevalidation.CountryHolidays(calendar.HolidayCountries)
+
 	// Sort dates
 	dates := make([]time.Time, 0, len(eventsByDate))
 	for date := range eventsByDate {
@@ -113,6 +288,11 @@ func (s *ICSService) buildCalendarEvents(calendar *repository.Calendar, eventsBy
 		return dates[i].Before(dates[j])
 	})
 
+	// Fetch the regional holidays of the feed once, not per date (the day after the last date is checked for eves)
+	if len(dates) > 0 {
+		datevalidation.PrefetchHolidays(dates[0], dates[len(dates)-1].AddDate(0, 0, 1), append([]string{countryCode}, otherCountries...)...)
+	}
+
 	// Build events with sequential numbering
 	eventNumber := 0
 	for _, date := range dates {
@@ -132,18 +312,28 @@ func (s *ICSService) buildCalendarEvents(calendar *repository.Calendar, eventsBy
 		}
 
 		// Filter by allowed weekdays, holidays policy, and holiday eves
-		if !datevalidation.IsDateAllowed(date, calendar.Timezone, calendar.AllowedWeekdays, calendar.HolidaysPolicy, calendar.AllowHolidayEves) {
+		if !datevalidation.IsDateAllowed(date, countryCode, calendar.AllowedWeekdays, calendar.HolidaysPolicy, calendar.AllowHolidayEves, calendar.HolidaySets, calendar.CustomHolidays, otherCountries) {
 			// Skip this event if the date is not allowed
 			continue
 		}
+		if datevalidation.IsBlackedOut(date, calendar.BlackoutDates) {
+			// Skip dates blocked by the owner
+			continue
+		}
 
 		// Compute time slots where threshold is met
-		timeSlots := computeTimeSlots(availabilities, calendar.Threshold)
+		timeSlots := computeTimeSlots(availabilities, calendar.Threshold, calendar.CountMaybe)
+		timeSlots = subtractBusyTime(timeSlots, busyIntervals(date, loc, busy))
 
 		// Create an event for each time slot
 		for slotIdx, slot := range timeSlots {
 			startTime := slot.StartTime
 			endTime := slot.EndTime
+			yes, maybe := slotCounts(slot)
+			counted := yes
+			if calendar.CountMaybe {
+				counted += maybe
+			}
 
 			event := models.CalendarEvent{
 				Date:                date,
@@ -151,11 +341,16 @@ func (s *ICSService) buildCalendarEvents(calendar *repository.Calendar, eventsBy
 				CalendarName:        calendar.Name,
 				CalendarDescription: calendar.Description,
 				EventNumber:         eventNumber + 1, // Will be set properly after filter
-				AvailableCount:      len(slot.Participants),
+				AvailableCount:      counted,
+				MaybeCount:          maybe,
+				Tentative:           yes < calendar.Threshold,
 				TotalParticipants:   calendar.TotalParticipants,
 				Threshold:           calendar.Threshold,
 				Participants:        slot.Participants,
 				Timezone:            calendar.Timezone,
+				ReminderMinutes:     calendar.ReminderMinutes,
+				TitleTemplate:       stringValue(calendar.EventTitle),
+				DescriptionTemplate: stringValue(calendar.EventDescription),
 				SlotStartTime:       &startTime,
 				SlotEndTime:         &endTime,
 				SlotIndex:           slotIdx,
@@ -230,20 +425,22 @@ func (s *ICSService) addEvent(cal *ics.Calendar, event models.CalendarEvent, dom
 	// Set timestamp
 	vevent.SetDtStampTime(time.Now())
 
-	// Set status
-	vevent.SetStatus(ics.ObjectStatusConfirmed)
+	// Set status, tentative when the threshold is only reached with maybe answers
+	if event.Tentative {
+		vevent.SetStatus(ics.ObjectStatusTentative)
+	} else {
+		vevent.SetStatus(ics.ObjectStatusConfirmed)
+	}
 
-	// Set summary: "{CalendarName} #{EventNumber} ({available}/{total})"
-	summary := fmt.Sprintf("%s #%d (%d/%d)",
-		event.CalendarName,
-		event.EventNumber,
-		event.AvailableCount,
-		event.TotalParticipants,
-	)
+	// Set summary from the calendar template, "{CalendarName} #{EventNumber} ({available}/{total})" by default
+	summary := eventTitle(event)
 	vevent.SetSummary(summary)
 
-	// Set description with participant list
+	// Set description from the calendar template, or with participant list
 	description := s.buildDescription(event)
+	if event.DescriptionTemplate != "" {
+		description = renderEventTemplate(event.DescriptionTemplate, event)
+	}
 	vevent.SetDescription(description)
 
 	// Add participants as ATTENDEE fields
@@ -282,6 +479,29 @@ func (s *ICSService) addEvent(cal *ics.Calendar, event models.CalendarEvent, dom
 			vevent.SetAllDayEndAt(event.Date.AddDate(0, 0, 1))
 		}
 	}
+
+	// Reminders configured on the calendar
+	for _, minutes := range event.ReminderMinutes {
+		alarm := vevent.AddAlarm()
+		alarm.SetAction(ics.ActionDisplay)
+		alarm.SetTrigger(reminderTrigger(minutes))
+		alarm.AddProperty(ics.ComponentPropertyDescription, summary)
+	}
+}
+
+// reminderTrigger returns the TRIGGER duration of a reminder set some minutes before the start of an event
+// e.g. -P1D for 1440 minutes, -PT2H for 120 minutes, -PT45M for 45 minutes
+func reminderTrigger(minutes int) string {
+	switch {
+	case minutes == 0:
+		return "PT0M"
+	case minutes%1440 == 0:
+		return fmt.Sprintf("-P%dD", minutes/1440)
+	case minutes%60 == 0:
+		return fmt.Sprintf("-PT%dH", minutes/60)
+	default:
+		return fmt.Sprintf("-PT%dM", minutes)
+	}
 }
 
 // generateUID generates a stable UID for an event
@@ -300,6 +520,9 @@ func (s *ICSService) buildDescription(event models.CalendarEvent) string {
 
 	for _, p := range event.Participants {
 		line := fmt.Sprintf("- %s", p.Name)
+		if p.Maybe {
+			line += " (peut-être)"
+		}
 
 		// Only show time range if it's not a full day (00:00-23:59)
 		if p.StartTime != nil || p.EndTime != nil {
@@ -338,13 +561,29 @@ func (s *ICSService) addAttendees(vevent *ics.VEvent, event models.CalendarEvent
 	for _, p := range event.Participants {
 		// Add ATTENDEE property with parameters
 		// Format: ATTENDEE;CN="Name";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:MAILTO:noreply@whento.be
+		partstat := partStat(p)
 		vevent.AddProperty(
 			ics.ComponentProperty("ATTENDEE"),
 			"MAILTO:noreply@whento.be",
 			&ics.KeyValues{Key: "CN", Value: []string{p.Name}},
 			&ics.KeyValues{Key: "ROLE", Value: []string{"REQ-PARTICIPANT"}},
-			&ics.KeyValues{Key: "PARTSTAT", Value: []string{"ACCEPTED"}},
+			&ics.KeyValues{Key: "PARTSTAT", Value: []string{partstat}},
 			&ics.KeyValues{Key: "CUTYPE", Value: []string{"INDIVIDUAL"}},
 		)
 	}
 }
+
+// partStat returns the participation status of an attendee: their answer to the event,
+// or else ACCEPTED for available participants and TENTATIVE for maybe answers
+func partStat(p models.ParticipantAvailability) string {
+	switch {
+	case p.RSVP == "going":
+		return "ACCEPTED"
+	case p.RSVP == "not_going":
+		return "DECLINED"
+	case p.Maybe:
+		return "TENTATIVE"
+	default:
+		return "ACCEPTED"
+	}
+}
@@ -0,0 +1,32 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"testing"
+
+	"github.com/whento/whento/internal/ics/models"
+)
+
+func TestPartStat(t *testing.T) {
+	tests := []struct {
+		name        string
+		participant models.ParticipantAvailability
+		want        string
+	}{
+		{"available", models.ParticipantAvailability{}, "ACCEPTED"},
+		{"maybe", models.ParticipantAvailability{Maybe: true}, "TENTATIVE"},
+		{"going", models.ParticipantAvailability{Maybe: true, RSVP: "going"}, "ACCEPTED"},
+		{"not going", models.ParticipantAvailability{RSVP: "not_going"}, "DECLINED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := partStat(tt.participant); got != tt.want {
+				t.Errorf("partStat() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/whento/whento/internal/ics/models"
+	"github.com/whento/whento/internal/ics/repository"
+)
+
+// GetSensor returns the next confirmed event of a calendar using its ICS token
+// Confirmed events are the events of the iCalendar feed, so both always agree
+func (s *ICSService) GetSensor(ctx context.Context, icsToken string, now time.Time) (*models.Sensor, error) {
+	calendar, err := s.calendarRepo.GetByICSToken(ctx, icsToken)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+
+	return s.sensorFor(ctx, calendar, now)
+}
+
+// GetPreview returns the name, description and next confirmed event of a calendar using its public token
+// Used for the link previews of shared calendars, which only know the public token
+func (s *ICSService) GetPreview(ctx context.Context, publicToken string, now time.Time) (*models.Preview, error) {
+	calendar, err := s.calendarRepo.GetByPublicToken(ctx, publicToken)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+
+	sensor, err := s.sensorFor(ctx, calendar, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Preview{
+		CalendarName: calendar.Name,
+		Description:  calendar.Description,
+		NextEvent:    sensor.NextEvent,
+	}, nil
+}
+
+// sensorFor computes the confirmed events of a calendar and summarizes them
+func (s *ICSService) sensorFor(ctx context.Context, calendar *repository.Calendar, now time.Time) (*models.Sensor, error) {
+	isOverQuota, _ := s.quotaChecker.IsOverQuota(ctx, calendar.QuotaOwnerID)
+	if isOverQuota {
+		return nil, ErrQuotaExceeded
+	}
+
+	eventsByDate, err := s.thresholdDates(ctx, calendar)
+	if err != nil {
+		return nil, err
+	}
+
+	events := s.buildCalendarEvents(calendar, eventsByDate, s.ownerBusyBlocks(ctx, calendar, eventsByDate))
+	sensor := buildSensor(calendar, events, now)
+
+	// Dates at the soft level are no events, they are only counted
+	if calendar.SoftThreshold != nil && *calendar.SoftThreshold < calendar.Threshold {
+		softByDate, err := s.availabilityRepo.GetEventsAboveThreshold(ctx, calendar.ID, *calendar.SoftThreshold, calendar.CountMaybe)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get soft threshold dates: %w", err)
+		}
+		soft := *calendar
+		soft.Threshold = *calendar.SoftThreshold
+		sensor.LookingGood = lookingGoodDates(s.buildCalendarEvents(&soft, softByDate, nil), events, now.In(sensorLocation(calendar)))
+	}
+
+	return sensor, nil
+}
+
+// lookingGoodDates counts the upcoming dates of the soft threshold events without a confirmed event
+func lookingGoodDates(softEvents, events []models.CalendarEvent, now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	confirmed := make(map[time.Time]bool, len(events))
+	for _, event := range events {
+		confirmed[event.Date] = true
+	}
+
+	dates := make(map[time.Time]bool)
+	for _, event := range softEvents {
+		if !confirmed[event.Date] && !event.Date.Before(today) {
+			dates[event.Date] = true
+		}
+	}
+	return len(dates)
+}
+
+// sensorLocation returns the timezone of a calendar, UTC if unknown
+func sensorLocation(calendar *repository.Calendar) *time.Location {
+	loc, err := time.LoadLocation(calendar.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// buildSensor summarizes events sorted by date, ignoring those already over
+func buildSensor(calendar *repository.Calendar, events []models.CalendarEvent, now time.Time) *models.Sensor {
+	loc := sensorLocation(calendar)
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	sensor := &models.Sensor{
+		CalendarName:  calendar.Name,
+		Threshold:     calendar.Threshold,
+		SoftThreshold: calendar.SoftThreshold,
+		Timezone:      calendar.Timezone,
+		UpdatedAt:     now.UTC(),
+	}
+
+	for i := range events {
+		event := &events[i]
+		start, end, allDay := sensorEventTimes(event, loc)
+		if !end.After(now) {
+			continue
+		}
+
+		sensor.UpcomingEvents++
+		if sensor.NextEvent != nil {
+			continue
+		}
+
+		participants := make([]string, 0, len(event.Participants))
+		for _, p := range event.Participants {
+			participants = append(participants, p.Name)
+		}
+
+		date := time.Date(event.Date.Year(), event.Date.Month(), event.Date.Day(), 0, 0, 0, 0, time.UTC)
+		daysUntil := int(date.Sub(today).Hours() / 24)
+		if daysUntil < 0 {
+			daysUntil = 0 // Started yesterday in another timezone
+		}
+
+		sensor.DaysUntil = &daysUntil
+		sensor.NextEvent = &models.SensorEvent{
+			Date:             event.Date.Format("2006-01-02"),
+			Start:            start,
+			End:              end,
+			AllDay:           allDay,
+			ParticipantCount: event.AvailableCount,
+			Participants:     participants,
+		}
+	}
+
+	return sensor
+}
+
+// sensorEventTimes returns the start and end of an event, all-day events spanning the whole date
+func sensorEventTimes(event *models.CalendarEvent, loc *time.Location) (start, end time.Time, allDay bool) {
+	midnight := time.Date(event.Date.Year(), event.Date.Month(), event.Date.Day(), 0, 0, 0, 0, loc)
+	if event.IsAllDay() {
+		return midnight, midnight.AddDate(0, 0, 1), true
+	}
+
+	startTime, endTime := event.EventTimes()
+	if startTime == nil || endTime == nil {
+		return midnight, midnight.AddDate(0, 0, 1), true
+	}
+	return *startTime, *endTime, false
+}
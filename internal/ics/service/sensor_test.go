@@ -0,0 +1,82 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/ics/models"
+	"github.com/whento/whento/internal/ics/repository"
+)
+
+func TestBuildSensor(t *testing.T) {
+	calendar := &repository.Calendar{Name: "Five-a-side", Threshold: 2, Timezone: "UTC"}
+
+	slot := func(day int, start, end string) models.CalendarEvent {
+		return models.CalendarEvent{
+			Date:           time.Date(2025, 6, day, 0, 0, 0, 0, time.UTC),
+			AvailableCount: 2,
+			Participants:   []models.ParticipantAvailability{{Name: "Alice"}, {Name: "Bob"}},
+			Timezone:       "UTC",
+			SlotStartTime:  &start,
+			SlotEndTime:    &end,
+		}
+	}
+	events := []models.CalendarEvent{
+		slot(9, "00:00", "23:59"),
+		slot(10, "18:00", "20:00"),
+		slot(14, "00:00", "23:59"),
+	}
+
+	t.Run("event later today", func(t *testing.T) {
+		sensor := buildSensor(calendar, events, time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC))
+
+		if sensor.DaysUntil == nil || *sensor.DaysUntil != 0 {
+			t.Fatalf("DaysUntil = %v, want 0", sensor.DaysUntil)
+		}
+		if sensor.NextEvent.Date != "2025-06-10" || sensor.NextEvent.AllDay || sensor.NextEvent.Start.Hour() != 18 {
+			t.Errorf("NextEvent = %+v, want 2025-06-10 18:00", sensor.NextEvent)
+		}
+		if sensor.UpcomingEvents != 2 {
+			t.Errorf("UpcomingEvents = %d, want 2", sensor.UpcomingEvents)
+		}
+		if len(sensor.NextEvent.Participants) != 2 || sensor.NextEvent.Participants[0] != "Alice" {
+			t.Errorf("Participants = %v, want [Alice Bob]", sensor.NextEvent.Participants)
+		}
+	})
+
+	t.Run("event of today over", func(t *testing.T) {
+		sensor := buildSensor(calendar, events, time.Date(2025, 6, 10, 21, 0, 0, 0, time.UTC))
+
+		if sensor.DaysUntil == nil || *sensor.DaysUntil != 4 {
+			t.Fatalf("DaysUntil = %v, want 4", sensor.DaysUntil)
+		}
+		if !sensor.NextEvent.AllDay || !sensor.NextEvent.End.Equal(time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("NextEvent = %+v, want all-day 2025-06-14", sensor.NextEvent)
+		}
+	})
+
+	t.Run("no upcoming event", func(t *testing.T) {
+		sensor := buildSensor(calendar, events, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+
+		if sensor.DaysUntil != nil || sensor.NextEvent != nil || sensor.UpcomingEvents != 0 {
+			t.Errorf("sensor = %+v, want no upcoming event", sensor)
+		}
+	})
+}
+
+func TestLookingGoodDates(t *testing.T) {
+	date := func(day int) models.CalendarEvent {
+		return models.CalendarEvent{Date: time.Date(2025, 6, day, 0, 0, 0, 0, time.UTC)}
+	}
+	events := []models.CalendarEvent{date(12)}
+	softEvents := []models.CalendarEvent{date(8), date(10), date(12), date(14), date(14)}
+
+	// The 8th is over and the 12th is a confirmed event, the 14th has two time slots
+	if got := lookingGoodDates(softEvents, events, time.Date(2025, 6, 10, 21, 0, 0, 0, time.UTC)); got != 2 {
+		t.Errorf("lookingGoodDates() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,68 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/whento/whento/internal/ics/models"
+)
+
+// DefaultEventTitle is the title of the events of calendars without a title template
+const DefaultEventTitle = "{{calendar}} #{{number}} ({{count}}/{{total}})"
+
+// eventTitle returns the single-line title of an event
+func eventTitle(event models.CalendarEvent) string {
+	template := event.TitleTemplate
+	if template == "" {
+		template = DefaultEventTitle
+	}
+	return strings.Join(strings.Fields(renderEventTemplate(template, event)), " ")
+}
+
+// renderEventTemplate expands the placeholders of an event title or description template:
+// {{calendar}}, {{description}}, {{number}}, {{date}} (2006-01-02), {{weekday}}, {{time}} (19:00-23:00, empty all day),
+// {{count}}, {{maybe}} (maybe answers), {{total}}, {{threshold}} and {{participants}} (comma-separated names, "?" after maybe answers)
+// Unknown placeholders are left as is
+func renderEventTemplate(template string, event models.CalendarEvent) string {
+	names := make([]string, len(event.Participants))
+	for i, p := range event.Participants {
+		names[i] = p.Name
+		if p.Maybe {
+			names[i] += "?"
+		}
+	}
+
+	timeRange := ""
+	if !event.IsAllDay() {
+		if start, end := event.EventTimes(); start != nil && end != nil {
+			timeRange = start.Format("15:04") + "-" + end.Format("15:04")
+		}
+	}
+
+	// Values are inserted in a single pass: placeholders in names or notes are not expanded
+	return strings.NewReplacer(
+		"{{calendar}}", event.CalendarName,
+		"{{description}}", event.CalendarDescription,
+		"{{number}}", strconv.Itoa(event.EventNumber),
+		"{{date}}", event.Date.Format("2006-01-02"),
+		"{{weekday}}", event.Date.Weekday().String(),
+		"{{time}}", timeRange,
+		"{{count}}", strconv.Itoa(event.AvailableCount),
+		"{{maybe}}", strconv.Itoa(event.MaybeCount),
+		"{{total}}", strconv.Itoa(event.TotalParticipants),
+		"{{threshold}}", strconv.Itoa(event.Threshold),
+		"{{participants}}", strings.Join(names, ", "),
+	).Replace(template)
+}
+
+// stringValue returns the value of an optional string, empty when unset
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
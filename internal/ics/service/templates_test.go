@@ -0,0 +1,67 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/ics/models"
+)
+
+func TestRenderEventTemplate(t *testing.T) {
+	event := models.CalendarEvent{
+		Date:                time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC),
+		CalendarName:        "Five-a-side",
+		CalendarDescription: "Bring a ball",
+		EventNumber:         3,
+		AvailableCount:      2,
+		TotalParticipants:   4,
+		Threshold:           2,
+		Timezone:            "Europe/Paris",
+		Participants: []models.ParticipantAvailability{
+			{Name: "Alice {{count}}"},
+			{Name: "Bob"},
+		},
+		SlotStartTime: ptr("18:30"),
+		SlotEndTime:   ptr("20:00"),
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"default title", "", "Five-a-side #3 (2/4)"},
+		{"all placeholders", "{{calendar}} on {{weekday}} {{date}} {{time}}: {{count}}/{{total}} (min {{threshold}}) {{participants}}. {{description}}",
+			"Five-a-side on Saturday 2025-06-14 18:30-20:00: 2/4 (min 2) Alice {{count}}, Bob. Bring a ball"},
+		{"unknown placeholder", "{{location}} match", "{{location}} match"},
+		{"single line", "Match\n{{date}}", "Match 2025-06-14"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := event
+			event.TitleTemplate = tt.template
+			if got := eventTitle(event); got != tt.want {
+				t.Errorf("eventTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEventTemplate_AllDay(t *testing.T) {
+	event := models.CalendarEvent{
+		Date:          time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC),
+		CalendarName:  "Hike",
+		Timezone:      "Europe/Paris",
+		SlotStartTime: ptr("00:00"),
+		SlotEndTime:   ptr("23:59"),
+	}
+
+	if got := renderEventTemplate("{{calendar}} {{time}}", event); got != "Hike " {
+		t.Errorf("renderEventTemplate() = %q, want no time for all-day events", got)
+	}
+}
@@ -60,7 +60,8 @@ func isParticipantAvailableAt(p *models.ParticipantAvailability, slotStart, slot
 
 // computeTimeSlots analyzes participant availabilities and computes time slots
 // where the threshold is met, merging consecutive slots into continuous events
-func computeTimeSlots(availabilities []repository.DateAvailability, threshold int) []TimeSlot {
+// "maybe" participants are listed in the slots, but only count toward the threshold with countMaybe
+func computeTimeSlots(availabilities []repository.DateAvailability, threshold int, countMaybe bool) []TimeSlot {
 	if len(availabilities) == 0 {
 		return nil
 	}
@@ -73,6 +74,17 @@ func computeTimeSlots(availabilities []repository.DateAvailability, threshold in
 			StartTime: av.StartTime,
 			EndTime:   av.EndTime,
 			Note:      av.Note,
+			Maybe:     av.Status == "maybe",
+			Required:  av.Required,
+			RSVP:      av.RSVP,
+		}
+	}
+
+	// Every required participant counted on the date must be available in a slot
+	requiredTotal := 0
+	for _, p := range participants {
+		if p.Required && (countMaybe || !p.Maybe) {
+			requiredTotal++
 		}
 	}
 
@@ -99,6 +111,7 @@ func computeTimeSlots(availabilities []repository.DateAvailability, threshold in
 		start        int
 		end          int
 		count        int
+		required     int
 		participants []models.ParticipantAvailability
 	}
 
@@ -110,16 +123,24 @@ func computeTimeSlots(availabilities []repository.DateAvailability, threshold in
 
 		// Count participants available for this entire segment
 		var availableParticipants []models.ParticipantAvailability
+		count, required := 0, 0
 		for j := range participants {
 			if isParticipantAvailableAt(&participants[j], segStart, segEnd) {
 				availableParticipants = append(availableParticipants, participants[j])
+				if countMaybe || !participants[j].Maybe {
+					count++
+					if participants[j].Required {
+						required++
+					}
+				}
 			}
 		}
 
 		segments = append(segments, segment{
 			start:        segStart,
 			end:          segEnd,
-			count:        len(availableParticipants),
+			count:        count,
+			required:     required,
 			participants: availableParticipants,
 		})
 	}
@@ -129,7 +150,7 @@ func computeTimeSlots(availabilities []repository.DateAvailability, threshold in
 	var currentSlot *TimeSlot
 
 	for _, seg := range segments {
-		if seg.count >= threshold {
+		if seg.count >= threshold && seg.required == requiredTotal {
 			if currentSlot == nil {
 				// Start new slot
 				currentSlot = &TimeSlot{
@@ -171,7 +192,90 @@ func computeTimeSlots(availabilities []repository.DateAvailability, threshold in
 	return result
 }
 
+// slotCounts returns the number of participants of a slot who answered yes and maybe
+func slotCounts(slot TimeSlot) (yes, maybe int) {
+	for _, p := range slot.Participants {
+		if p.Maybe {
+			maybe++
+		} else {
+			yes++
+		}
+	}
+	return yes, maybe
+}
+
 // isAllDaySlot checks if a time slot covers the entire day
 func isAllDaySlot(slot *TimeSlot) bool {
 	return slot.StartTime == "00:00" && slot.EndTime == "23:59"
 }
+
+// busyIntervals converts the busy blocks overlapping a date into minute ranges of that day
+// (in the calendar timezone), clamped to the day boundaries used by time slots (00:00-23:59)
+func busyIntervals(date time.Time, loc *time.Location, blocks []repository.BusyBlock) [][2]int {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	var intervals [][2]int
+	for _, block := range blocks {
+		if !block.End.After(dayStart) || !block.Start.Before(dayEnd) {
+			continue
+		}
+
+		start, end := 0, 1439
+		if block.Start.After(dayStart) {
+			t := block.Start.In(loc)
+			start = t.Hour()*60 + t.Minute()
+		}
+		if block.End.Before(dayEnd) {
+			t := block.End.In(loc)
+			end = t.Hour()*60 + t.Minute()
+			if t.Second() > 0 {
+				end++
+			}
+		}
+		if end > start {
+			intervals = append(intervals, [2]int{start, end})
+		}
+	}
+	return intervals
+}
+
+// subtractBusyTime removes busy minute ranges from time slots, splitting slots around them
+// so proposed events don't overlap the organizer's existing commitments
+func subtractBusyTime(slots []TimeSlot, busy [][2]int) []TimeSlot {
+	if len(busy) == 0 {
+		return slots
+	}
+
+	var result []TimeSlot
+	for _, slot := range slots {
+		free := [][2]int{{parseTimeToMinutes(slot.StartTime), parseTimeToMinutes(slot.EndTime)}}
+
+		for _, interval := range busy {
+			var remaining [][2]int
+			for _, f := range free {
+				if interval[1] <= f[0] || interval[0] >= f[1] {
+					remaining = append(remaining, f)
+					continue
+				}
+				if interval[0] > f[0] {
+					remaining = append(remaining, [2]int{f[0], interval[0]})
+				}
+				if interval[1] < f[1] {
+					remaining = append(remaining, [2]int{interval[1], f[1]})
+				}
+			}
+			free = remaining
+		}
+
+		for _, f := range free {
+			result = append(result, TimeSlot{
+				StartTime:    minutesToTimeString(f[0]),
+				EndTime:      minutesToTimeString(f[1]),
+				Participants: slot.Participants,
+			})
+		}
+	}
+
+	return result
+}
@@ -6,6 +6,7 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/whento/whento/internal/ics/repository"
 )
@@ -21,7 +22,7 @@ func TestComputeTimeSlots_SimpleOverlap(t *testing.T) {
 		{ParticipantName: "Bob", StartTime: ptr("10:00"), EndTime: ptr("18:00")},
 	}
 
-	slots := computeTimeSlots(availabilities, 2)
+	slots := computeTimeSlots(availabilities, 2, false)
 
 	if len(slots) != 1 {
 		t.Fatalf("Expected 1 slot, got %d", len(slots))
@@ -45,7 +46,7 @@ func TestComputeTimeSlots_NonOverlapping(t *testing.T) {
 		{ParticipantName: "P3", StartTime: ptr("14:00"), EndTime: ptr("23:59")},
 	}
 
-	slots := computeTimeSlots(availabilities, 2)
+	slots := computeTimeSlots(availabilities, 2, false)
 
 	if len(slots) != 2 {
 		t.Fatalf("Expected 2 slots, got %d", len(slots))
@@ -71,7 +72,7 @@ func TestComputeTimeSlots_ContinuousCoverage(t *testing.T) {
 		{ParticipantName: "P3", StartTime: ptr("12:00"), EndTime: ptr("23:59")},
 	}
 
-	slots := computeTimeSlots(availabilities, 2)
+	slots := computeTimeSlots(availabilities, 2, false)
 
 	if len(slots) != 1 {
 		t.Fatalf("Expected 1 slot (continuous coverage), got %d", len(slots))
@@ -88,7 +89,7 @@ func TestComputeTimeSlots_ThresholdNotMet(t *testing.T) {
 		{ParticipantName: "Alice", StartTime: ptr("10:00"), EndTime: ptr("18:00")},
 	}
 
-	slots := computeTimeSlots(availabilities, 2)
+	slots := computeTimeSlots(availabilities, 2, false)
 
 	if len(slots) != 0 {
 		t.Fatalf("Expected 0 slots (threshold not met), got %d", len(slots))
@@ -102,7 +103,7 @@ func TestComputeTimeSlots_NilTimes(t *testing.T) {
 		{ParticipantName: "Bob", StartTime: nil, EndTime: nil},
 	}
 
-	slots := computeTimeSlots(availabilities, 2)
+	slots := computeTimeSlots(availabilities, 2, false)
 
 	if len(slots) != 1 {
 		t.Fatalf("Expected 1 slot, got %d", len(slots))
@@ -120,7 +121,7 @@ func TestComputeTimeSlots_MixedNilAndExplicit(t *testing.T) {
 		{ParticipantName: "P2", StartTime: ptr("00:00"), EndTime: ptr("12:00")},
 	}
 
-	slots := computeTimeSlots(availabilities, 2)
+	slots := computeTimeSlots(availabilities, 2, false)
 
 	if len(slots) != 1 {
 		t.Fatalf("Expected 1 slot, got %d", len(slots))
@@ -143,7 +144,7 @@ func TestComputeTimeSlots_ThreeParticipantsPartialOverlap(t *testing.T) {
 		{ParticipantName: "P3", StartTime: ptr("12:00"), EndTime: ptr("20:00")},
 	}
 
-	slots := computeTimeSlots(availabilities, 2)
+	slots := computeTimeSlots(availabilities, 2, false)
 
 	if len(slots) != 1 {
 		t.Fatalf("Expected 1 slot, got %d", len(slots))
@@ -171,7 +172,7 @@ func TestComputeTimeSlots_HigherThreshold(t *testing.T) {
 		{ParticipantName: "P3", StartTime: ptr("12:00"), EndTime: ptr("14:00")},
 	}
 
-	slots := computeTimeSlots(availabilities, 3)
+	slots := computeTimeSlots(availabilities, 3, false)
 
 	if len(slots) != 1 {
 		t.Fatalf("Expected 1 slot, got %d", len(slots))
@@ -187,13 +188,54 @@ func TestComputeTimeSlots_HigherThreshold(t *testing.T) {
 }
 
 func TestComputeTimeSlots_EmptyAvailabilities(t *testing.T) {
-	slots := computeTimeSlots(nil, 2)
+	slots := computeTimeSlots(nil, 2, false)
 
 	if len(slots) != 0 {
 		t.Errorf("Expected 0 slots for empty input, got %d", len(slots))
 	}
 }
 
+func TestComputeTimeSlots_Maybe(t *testing.T) {
+	// P1: 10:00-18:00 (yes)
+	// P2: 12:00-16:00 (maybe)
+	// P3: 14:00-18:00 (yes)
+	availabilities := []repository.DateAvailability{
+		{ParticipantName: "P1", StartTime: ptr("10:00"), EndTime: ptr("18:00"), Status: "yes"},
+		{ParticipantName: "P2", StartTime: ptr("12:00"), EndTime: ptr("16:00"), Status: "maybe"},
+		{ParticipantName: "P3", StartTime: ptr("14:00"), EndTime: ptr("18:00"), Status: "yes"},
+	}
+
+	// Maybes don't count: only 14:00-18:00 has 2 yes, and P2 is listed as maybe
+	slots := computeTimeSlots(availabilities, 2, false)
+	if len(slots) != 1 || slots[0].StartTime != "14:00" || slots[0].EndTime != "18:00" {
+		t.Fatalf("Expected a single 14:00-18:00 slot, got %+v", slots)
+	}
+	if yes, maybe := slotCounts(slots[0]); yes != 2 || maybe != 1 {
+		t.Errorf("Expected 2 yes and 1 maybe, got %d and %d", yes, maybe)
+	}
+
+	// Maybes count: 12:00-18:00 reaches 2
+	slots = computeTimeSlots(availabilities, 2, true)
+	if len(slots) != 1 || slots[0].StartTime != "12:00" || slots[0].EndTime != "18:00" {
+		t.Fatalf("Expected a single 12:00-18:00 slot, got %+v", slots)
+	}
+}
+
+func TestComputeTimeSlots_Required(t *testing.T) {
+	// P1: 10:00-18:00, P2: 10:00-14:00, P3: 12:00-18:00 (required)
+	availabilities := []repository.DateAvailability{
+		{ParticipantName: "P1", StartTime: ptr("10:00"), EndTime: ptr("18:00"), Status: "yes"},
+		{ParticipantName: "P2", StartTime: ptr("10:00"), EndTime: ptr("14:00"), Status: "yes"},
+		{ParticipantName: "P3", StartTime: ptr("12:00"), EndTime: ptr("18:00"), Status: "yes", Required: true},
+	}
+
+	// 10:00-12:00 has 2 participants but misses P3
+	slots := computeTimeSlots(availabilities, 2, false)
+	if len(slots) != 1 || slots[0].StartTime != "12:00" || slots[0].EndTime != "18:00" {
+		t.Fatalf("Expected a single 12:00-18:00 slot, got %+v", slots)
+	}
+}
+
 func TestIsAllDaySlot(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -276,3 +318,70 @@ func TestMinutesToTimeString(t *testing.T) {
 		})
 	}
 }
+
+func TestSubtractBusyTime(t *testing.T) {
+	slots := []TimeSlot{{StartTime: "10:00", EndTime: "18:00"}}
+
+	tests := []struct {
+		name string
+		busy [][2]int
+		want []string
+	}{
+		{name: "no busy time", busy: nil, want: []string{"10:00-18:00"}},
+		{name: "busy before the slot", busy: [][2]int{{8 * 60, 9 * 60}}, want: []string{"10:00-18:00"}},
+		{name: "busy in the middle", busy: [][2]int{{12 * 60, 13 * 60}}, want: []string{"10:00-12:00", "13:00-18:00"}},
+		{name: "busy at the start", busy: [][2]int{{9 * 60, 11 * 60}}, want: []string{"11:00-18:00"}},
+		{name: "two busy blocks", busy: [][2]int{{11 * 60, 12 * 60}, {16 * 60, 19 * 60}}, want: []string{"10:00-11:00", "12:00-16:00"}},
+		{name: "busy all day", busy: [][2]int{{0, 1439}}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := subtractBusyTime(slots, tt.busy)
+
+			var got []string
+			for _, slot := range result {
+				got = append(got, slot.StartTime+"-"+slot.EndTime)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("subtractBusyTime() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("subtractBusyTime() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBusyIntervals(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("Europe/Paris timezone not available")
+	}
+	date := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	blocks := []repository.BusyBlock{
+		// 14:00-15:00 in Paris
+		{Start: time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 10, 13, 0, 0, 0, time.UTC)},
+		// Starts the day before, ends at 08:30 in Paris
+		{Start: time.Date(2025, 6, 9, 20, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 10, 6, 30, 0, 0, time.UTC)},
+		// Starts at 23:00 in Paris, ends the next day
+		{Start: time.Date(2025, 6, 10, 21, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 11, 8, 0, 0, 0, time.UTC)},
+		// Another day
+		{Start: time.Date(2025, 6, 12, 8, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 12, 9, 0, 0, 0, time.UTC)},
+	}
+
+	got := busyIntervals(date, loc, blocks)
+	want := [][2]int{{14 * 60, 15 * 60}, {0, 8*60 + 30}, {23 * 60, 1439}}
+
+	if len(got) != len(want) {
+		t.Fatalf("busyIntervals() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("busyIntervals()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
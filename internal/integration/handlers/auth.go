@@ -0,0 +1,74 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/whento/pkg/jwt"
+	"github.com/whento/pkg/logger"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/whento/internal/integration/models"
+	"github.com/whento/whento/internal/integration/service"
+)
+
+// AppPasswordAuthenticator resolves the user owning an app password
+type AppPasswordAuthenticator interface {
+	Authenticate(ctx context.Context, value string) (*models.AppPasswordOwner, error)
+}
+
+// Auth authenticates with an app password, sent as a Bearer token or with Basic auth
+// (login name and app password, as Nextcloud does), and falls back to JWT access tokens
+func Auth(authenticator AppPasswordAuthenticator, jwtManager *jwt.Manager, log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		jwtAuth := middleware.Auth(jwtManager)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			password, ok := appPassword(r)
+			if !ok {
+				jwtAuth.ServeHTTP(w, r)
+				return
+			}
+
+			owner, err := authenticator.Authenticate(r.Context(), password)
+			if err != nil {
+				if !errors.Is(err, service.ErrInvalidAppPassword) {
+					log.Error("Failed to authenticate app password", "error", err)
+				}
+				http.Error(w, "Invalid app password", http.StatusUnauthorized)
+				return
+			}
+
+			// Add user info to context, like JWT authentication
+			userID := owner.UserID.String()
+			ctx := r.Context()
+			ctx = context.WithValue(ctx, middleware.UserIDKey, userID)
+			ctx = context.WithValue(ctx, middleware.UserEmailKey, owner.Email)
+			ctx = context.WithValue(ctx, middleware.UserRoleKey, owner.Role)
+			ctx = logger.WithUserID(ctx, userID)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// appPassword returns the app password of a request, if any
+func appPassword(r *http.Request) (string, bool) {
+	if _, password, ok := r.BasicAuth(); ok {
+		return password, true
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "bearer") && service.IsAppPassword(token) {
+		return token, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,93 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/whento/internal/integration/models"
+	"github.com/whento/whento/internal/integration/service"
+)
+
+type fakeAuthenticator struct {
+	password string
+	owner    *models.AppPasswordOwner
+}
+
+func (f *fakeAuthenticator) Authenticate(ctx context.Context, value string) (*models.AppPasswordOwner, error) {
+	if value != f.password {
+		return nil, service.ErrInvalidAppPassword
+	}
+	return f.owner, nil
+}
+
+func TestAuth(t *testing.T) {
+	owner := &models.AppPasswordOwner{UserID: uuid.New(), Email: "alice@example.com", Role: "user"}
+	authenticator := &fakeAuthenticator{password: "wtap_secret", owner: owner}
+
+	handler := Auth(authenticator, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if middleware.GetUserID(r.Context()) != owner.UserID.String() || middleware.GetUserEmail(r.Context()) != owner.Email {
+				t.Errorf("context user = %q %q, want %s %s", middleware.GetUserID(r.Context()), middleware.GetUserEmail(r.Context()), owner.UserID, owner.Email)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	)
+
+	tests := []struct {
+		name       string
+		setup      func(r *http.Request)
+		wantStatus int
+	}{
+		{
+			name:       "bearer app password",
+			setup:      func(r *http.Request) { r.Header.Set("Authorization", "Bearer wtap_secret") },
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "basic auth",
+			setup:      func(r *http.Request) { r.SetBasicAuth("alice@example.com", "wtap_secret") },
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "revoked app password",
+			setup:      func(r *http.Request) { r.Header.Set("Authorization", "Bearer wtap_revoked") },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "basic auth with account password",
+			setup:      func(r *http.Request) { r.SetBasicAuth("alice@example.com", "hunter2") },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no credentials",
+			setup:      func(r *http.Request) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/calendars", nil)
+			tt.setup(req)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/integration/models"
+	"github.com/whento/whento/internal/integration/service"
+)
+
+// IntegrationHandler handles HTTP requests of third-party integrations (e.g. the Nextcloud app)
+type IntegrationHandler struct {
+	service   *service.IntegrationService
+	version   string
+	buildType string
+	logger    *slog.Logger
+}
+
+// NewIntegrationHandler creates a new integration handler
+func NewIntegrationHandler(service *service.IntegrationService, version, buildType string, logger *slog.Logger) *IntegrationHandler {
+	return &IntegrationHandler{
+		service:   service,
+		version:   version,
+		buildType: buildType,
+		logger:    logger,
+	}
+}
+
+// @Summary		Get instance capabilities
+// @Description	Describes this instance to integrations: version, build, accepted authentication methods, features and their endpoints. Public, so an integration can check a server URL before asking the user to log in.
+// @Tags			Integrations
+// @Produce		json
+// @Success		200	{object}	models.CapabilitiesResponse	"Capabilities"
+// @Router			/api/v1/capabilities [get]
+func (h *IntegrationHandler) Capabilities(w http.ResponseWriter, r *http.Request) {
+	httputil.JSON(w, http.StatusOK, h.service.Capabilities(h.version, h.buildType))
+}
+
+// @Summary		Create an app password
+// @Description	Creates a long-lived password for an integration, accepted on the /api/v1/integrations routes as a Bearer token or with Basic auth (email and app password). The password is returned only once.
+// @Tags			Integrations
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			request	body		models.CreateAppPasswordRequest		true	"App password"
+// @Success		201		{object}	models.CreatedAppPasswordResponse	"App password created"
+// @Failure		400		{object}	httputil.ErrorResponse				"Invalid request"
+// @Failure		401		{object}	httputil.ErrorResponse				"Unauthorized"
+// @Failure		409		{object}	httputil.ErrorResponse				"Too many app passwords"
+// @Failure		500		{object}	httputil.ErrorResponse				"Internal server error"
+// @Router			/api/v1/integrations/app-passwords [post]
+func (h *IntegrationHandler) CreateAppPassword(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAppPasswordRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	password, value, err := h.service.CreateAppPassword(r.Context(), userUUID, req.Name)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to create app password")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, models.CreatedAppPasswordResponse{
+		AppPasswordResponse: password.ToResponse(),
+		Password:            value,
+	})
+}
+
+// @Summary		List app passwords
+// @Description	Lists the app passwords of the current user, without their values
+// @Tags			Integrations
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		models.AppPasswordResponse	"App passwords"
+// @Failure		401	{object}	httputil.ErrorResponse		"Unauthorized"
+// @Failure		500	{object}	httputil.ErrorResponse		"Internal server error"
+// @Router			/api/v1/integrations/app-passwords [get]
+func (h *IntegrationHandler) ListAppPasswords(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	passwords, err := h.service.ListAppPasswords(r.Context(), userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to list app passwords")
+		return
+	}
+
+	responses := make([]models.AppPasswordResponse, 0, len(passwords))
+	for _, password := range passwords {
+		responses = append(responses, password.ToResponse())
+	}
+
+	httputil.JSON(w, http.StatusOK, responses)
+}
+
+// @Summary		Revoke an app password
+// @Description	Deletes an app password of the current user; the integration using it loses access immediately
+// @Tags			Integrations
+// @Security		BearerAuth
+// @Param			id	path	string	true	"App password ID"
+// @Success		204	"App password revoked"
+// @Failure		400	{object}	httputil.ErrorResponse	"Invalid app password ID"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"App password not found"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/integrations/app-passwords/{id} [delete]
+func (h *IntegrationHandler) DeleteAppPassword(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid app password ID")
+		return
+	}
+
+	if err := h.service.DeleteAppPassword(r.Context(), userUUID, id); err != nil {
+		h.handleError(w, err, userUUID, "Failed to revoke app password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Start a login flow
+// @Description	Starts a browser login for an integration (same flow as Nextcloud Login Flow v2). Open login_url in a browser so the user logs in and grants access, then poll the poll endpoint with the poll token until it returns the credentials.
+// @Tags			Integrations
+// @Accept			json
+// @Produce		json
+// @Param			request	body		models.StartLoginFlowRequest	true	"Integration"
+// @Success		200		{object}	models.LoginFlowResponse		"Login flow started"
+// @Failure		400		{object}	httputil.ErrorResponse			"Invalid request"
+// @Failure		429		{object}	httputil.ErrorResponse			"Too many requests"
+// @Failure		500		{object}	httputil.ErrorResponse			"Internal server error"
+// @Router			/api/v1/integrations/login-flow [post]
+func (h *IntegrationHandler) StartLoginFlow(w http.ResponseWriter, r *http.Request) {
+	var req models.StartLoginFlowRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	flow, err := h.service.StartLoginFlow(r.Context(), req.ClientName)
+	if err != nil {
+		h.logger.Error("Failed to start login flow", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to start login flow")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, flow)
+}
+
+// @Summary		Poll a login flow
+// @Description	Returns the server URL, login name and app password once the user granted access, only once. Returns 404 while the flow is pending; stop polling when it expires.
+// @Tags			Integrations
+// @Accept			json
+// @Produce		json
+// @Param			request	body		models.PollLoginFlowRequest		true	"Poll token"
+// @Success		200		{object}	models.LoginFlowResultResponse	"Access granted"
+// @Failure		400		{object}	httputil.ErrorResponse			"Invalid request"
+// @Failure		404		{object}	httputil.ErrorResponse			"Pending, expired or already polled"
+// @Failure		429		{object}	httputil.ErrorResponse			"Too many requests"
+// @Failure		500		{object}	httputil.ErrorResponse			"Internal server error"
+// @Router			/api/v1/integrations/login-flow/poll [post]
+func (h *IntegrationHandler) PollLoginFlow(w http.ResponseWriter, r *http.Request) {
+	var req models.PollLoginFlowRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.PollLoginFlow(r.Context(), req.Token)
+	if err != nil {
+		if errors.Is(err, service.ErrLoginFlowNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Login flow not granted yet")
+			return
+		}
+		h.logger.Error("Failed to poll login flow", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to poll login flow")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, result)
+}
+
+// @Summary		Get a login flow
+// @Description	Returns the integration asking for access, to show on the consent page opened from login_url
+// @Tags			Integrations
+// @Produce		json
+// @Security		BearerAuth
+// @Param			token	path		string							true	"Login flow token (from login_url)"
+// @Success		200		{object}	models.LoginFlowInfoResponse	"Pending login flow"
+// @Failure		401		{object}	httputil.ErrorResponse			"Unauthorized"
+// @Failure		404		{object}	httputil.ErrorResponse			"Login flow not found or expired"
+// @Failure		500		{object}	httputil.ErrorResponse			"Internal server error"
+// @Router			/api/v1/integrations/login-flow/{token} [get]
+func (h *IntegrationHandler) GetLoginFlow(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	flow, err := h.service.GetLoginFlow(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to get login flow")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, models.LoginFlowInfoResponse{
+		ClientName: flow.ClientName,
+		ExpiresAt:  flow.ExpiresAt,
+	})
+}
+
+// @Summary		Grant a login flow
+// @Description	Grants the integration of a pending login flow access to the current user's account, by creating an app password named after it
+// @Tags			Integrations
+// @Security		BearerAuth
+// @Param			token	path	string	true	"Login flow token (from login_url)"
+// @Success		204		"Access granted"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404		{object}	httputil.ErrorResponse	"Login flow not found or expired"
+// @Failure		409		{object}	httputil.ErrorResponse	"Too many app passwords"
+// @Failure		500		{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/integrations/login-flow/{token}/grant [post]
+func (h *IntegrationHandler) GrantLoginFlow(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.GrantLoginFlow(r.Context(), userUUID, chi.URLParam(r, "token")); err != nil {
+		h.handleError(w, err, userUUID, "Failed to grant login flow")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		List calendars for embedding
+// @Description	Lists the calendars owned by the current user with their public page, iCalendar feed and webcal subscription URLs. Accepts an app password.
+// @Tags			Integrations
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		models.CalendarResponse	"Calendars"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/integrations/calendars [get]
+func (h *IntegrationHandler) ListCalendars(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	calendars, err := h.service.ListCalendars(r.Context(), userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to list calendars")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, calendars)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *IntegrationHandler) handleError(w http.ResponseWriter, err error, userID uuid.UUID, defaultMsg string) {
+	switch {
+	case errors.Is(err, service.ErrAppPasswordNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "App password not found")
+	case errors.Is(err, service.ErrLoginFlowNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Login flow not found or expired")
+	case errors.Is(err, service.ErrTooManyAppPasswords):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, err.Error())
+	default:
+		h.logger.Error(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
+	}
+}
+
+// userID returns the authenticated user ID, writing an error response if missing
+func (h *IntegrationHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
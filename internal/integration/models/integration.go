@@ -0,0 +1,128 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppPassword is a long-lived credential of an integration (only its hash is stored)
+type AppPassword struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// AppPasswordOwner is the user authenticated by an app password
+type AppPasswordOwner struct {
+	UserID uuid.UUID
+	Email  string
+	Role   string
+}
+
+// LoginFlow is a pending browser login of an integration
+type LoginFlow struct {
+	ID          uuid.UUID
+	ClientName  string
+	UserID      *uuid.UUID // Set when granted
+	AppPassword *string    // Set when granted, until polled
+	Email       *string    // Email of the granting user
+	ExpiresAt   time.Time
+}
+
+// CreateAppPasswordRequest represents a request to create an app password
+type CreateAppPasswordRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+// AppPasswordResponse is the API response for an app password (the password itself is never returned)
+type AppPasswordResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAppPasswordResponse is returned once, when an app password is created
+type CreatedAppPasswordResponse struct {
+	AppPasswordResponse
+	Password string `json:"password"` // Shown only once
+}
+
+// ToResponse converts an AppPassword to AppPasswordResponse
+func (p *AppPassword) ToResponse() AppPasswordResponse {
+	return AppPasswordResponse{
+		ID:         p.ID.String(),
+		Name:       p.Name,
+		LastUsedAt: p.LastUsedAt,
+		CreatedAt:  p.CreatedAt,
+	}
+}
+
+// StartLoginFlowRequest represents a request of an integration to start a login flow
+type StartLoginFlowRequest struct {
+	ClientName string `json:"client_name" validate:"required,min=1,max=100"` // Shown to the user, and name of the app password
+}
+
+// LoginFlowResponse is returned to the integration starting a login flow
+type LoginFlowResponse struct {
+	LoginURL  string           `json:"login_url"` // To open in a browser
+	Poll      LoginFlowPolling `json:"poll"`
+	ExpiresAt time.Time        `json:"expires_at"`
+}
+
+// LoginFlowPolling tells the integration how to poll for the result of a login flow
+type LoginFlowPolling struct {
+	Endpoint string `json:"endpoint"`
+	Token    string `json:"token"`
+}
+
+// PollLoginFlowRequest represents a poll of an integration
+type PollLoginFlowRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// LoginFlowResultResponse is returned to the integration once the user granted access
+type LoginFlowResultResponse struct {
+	Server      string `json:"server"`
+	LoginName   string `json:"login_name"`
+	AppPassword string `json:"app_password"`
+}
+
+// LoginFlowInfoResponse describes a pending login flow to the user asked to grant it
+type LoginFlowInfoResponse struct {
+	ClientName string    `json:"client_name"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// CapabilitiesResponse describes what this instance supports, for integrations
+type CapabilitiesResponse struct {
+	ProductName string            `json:"product_name" example:"WhenTo"`
+	Version     string            `json:"version" example:"1.4.0"`
+	Build       string            `json:"build" example:"selfhosted"`
+	APIVersion  string            `json:"api_version" example:"v1"`
+	Auth        []string          `json:"auth"` // Accepted authentication methods
+	Features    map[string]bool   `json:"features"`
+	Endpoints   map[string]string `json:"endpoints"`
+}
+
+// CalendarResponse is a calendar of the user, with the URLs needed to embed or subscribe to it
+type CalendarResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Threshold   int       `json:"threshold"`
+	Timezone    string    `json:"timezone"`
+	PublicURL   string    `json:"public_url"` // Page where participants fill in their availability
+	ICSURL      string    `json:"ics_url"`    // iCalendar feed of the dates reaching the threshold
+	WebcalURL   string    `json:"webcal_url"` // Same feed with the webcal scheme, to subscribe from calendar apps
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
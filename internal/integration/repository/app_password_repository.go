@@ -0,0 +1,108 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/integration/models"
+)
+
+var ErrAppPasswordNotFound = errors.New("app password not found")
+
+// AppPasswordRepository handles app passwords
+type AppPasswordRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAppPasswordRepository creates a new app password repository
+func NewAppPasswordRepository(pool *pgxpool.Pool) *AppPasswordRepository {
+	return &AppPasswordRepository{pool: pool}
+}
+
+// Create creates an app password from the hash of its value
+func (r *AppPasswordRepository) Create(ctx context.Context, password *models.AppPassword, passwordHash string) error {
+	query := `
+		INSERT INTO app_passwords (id, user_id, name, password_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.pool.Exec(ctx, query, password.ID, password.UserID, password.Name, passwordHash, password.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create app password: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns the app passwords of a user, newest first
+func (r *AppPasswordRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.AppPassword, error) {
+	query := `
+		SELECT id, user_id, name, last_used_at, created_at
+		FROM app_passwords
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list app passwords: %w", err)
+	}
+	defer rows.Close()
+
+	var passwords []*models.AppPassword
+	for rows.Next() {
+		var password models.AppPassword
+		if err := rows.Scan(&password.ID, &password.UserID, &password.Name, &password.LastUsedAt, &password.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan app password: %w", err)
+		}
+		passwords = append(passwords, &password)
+	}
+
+	return passwords, rows.Err()
+}
+
+// CountByUser returns the number of app passwords of a user
+func (r *AppPasswordRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM app_passwords WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+// Delete deletes an app password of a user
+func (r *AppPasswordRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM app_passwords WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete app password: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAppPasswordNotFound
+	}
+	return nil
+}
+
+// Authenticate returns the owner of an app password and records its use
+func (r *AppPasswordRepository) Authenticate(ctx context.Context, passwordHash string) (*models.AppPasswordOwner, error) {
+	query := `
+		UPDATE app_passwords ap
+		SET last_used_at = NOW()
+		FROM users u
+		WHERE ap.password_hash = $1 AND u.id = ap.user_id AND u.deleted_at IS NULL
+		RETURNING u.id, u.email, u.role`
+
+	var owner models.AppPasswordOwner
+	err := r.pool.QueryRow(ctx, query, passwordHash).Scan(&owner.UserID, &owner.Email, &owner.Role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAppPasswordNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate app password: %w", err)
+	}
+	return &owner, nil
+}
@@ -0,0 +1,106 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/integration/models"
+)
+
+var ErrLoginFlowNotFound = errors.New("login flow not found")
+
+// LoginFlowRepository handles pending login flows
+type LoginFlowRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLoginFlowRepository creates a new login flow repository
+func NewLoginFlowRepository(pool *pgxpool.Pool) *LoginFlowRepository {
+	return &LoginFlowRepository{pool: pool}
+}
+
+// Create creates a login flow from the hashes of its tokens
+func (r *LoginFlowRepository) Create(ctx context.Context, flow *models.LoginFlow, flowTokenHash, pollTokenHash string) error {
+	query := `
+		INSERT INTO login_flows (id, flow_token_hash, poll_token_hash, client_name, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.pool.Exec(ctx, query, flow.ID, flowTokenHash, pollTokenHash, flow.ClientName, flow.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create login flow: %w", err)
+	}
+	return nil
+}
+
+// GetPendingByFlowToken returns a login flow that is neither expired nor granted
+func (r *LoginFlowRepository) GetPendingByFlowToken(ctx context.Context, flowTokenHash string) (*models.LoginFlow, error) {
+	query := `
+		SELECT id, client_name, expires_at
+		FROM login_flows
+		WHERE flow_token_hash = $1 AND user_id IS NULL AND expires_at > NOW()`
+
+	var flow models.LoginFlow
+	err := r.pool.QueryRow(ctx, query, flowTokenHash).Scan(&flow.ID, &flow.ClientName, &flow.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLoginFlowNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login flow: %w", err)
+	}
+	return &flow, nil
+}
+
+// Grant attaches the app password created for the user to a pending login flow
+func (r *LoginFlowRepository) Grant(ctx context.Context, id, userID uuid.UUID, appPassword string) error {
+	query := `
+		UPDATE login_flows
+		SET user_id = $2, app_password = $3
+		WHERE id = $1 AND user_id IS NULL AND expires_at > NOW()`
+
+	result, err := r.pool.Exec(ctx, query, id, userID, appPassword)
+	if err != nil {
+		return fmt.Errorf("failed to grant login flow: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrLoginFlowNotFound
+	}
+	return nil
+}
+
+// ConsumeGranted returns a granted login flow and deletes it, so its app password is handed out once
+func (r *LoginFlowRepository) ConsumeGranted(ctx context.Context, pollTokenHash string) (*models.LoginFlow, error) {
+	query := `
+		DELETE FROM login_flows lf
+		USING users u
+		WHERE lf.poll_token_hash = $1 AND lf.user_id = u.id AND lf.expires_at > NOW()
+		RETURNING lf.id, lf.client_name, lf.user_id, lf.app_password, u.email, lf.expires_at`
+
+	var flow models.LoginFlow
+	err := r.pool.QueryRow(ctx, query, pollTokenHash).Scan(
+		&flow.ID, &flow.ClientName, &flow.UserID, &flow.AppPassword, &flow.Email, &flow.ExpiresAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLoginFlowNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume login flow: %w", err)
+	}
+	return &flow, nil
+}
+
+// DeleteExpired deletes expired login flows
+func (r *LoginFlowRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM login_flows WHERE expires_at <= $1`, before)
+	return err
+}
@@ -0,0 +1,290 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/integration/models"
+	"github.com/whento/whento/internal/integration/repository"
+)
+
+const (
+	// AppPasswordPrefix identifies app passwords in Authorization headers
+	AppPasswordPrefix = "wtap_"
+
+	// MaxAppPasswordsPerUser limits the number of app passwords of a user
+	MaxAppPasswordsPerUser = 25
+
+	// loginFlowTTL is the time left to the user to grant a login flow, and to the integration to poll it
+	loginFlowTTL = 20 * time.Minute
+)
+
+var (
+	ErrAppPasswordNotFound = repository.ErrAppPasswordNotFound
+	ErrLoginFlowNotFound   = repository.ErrLoginFlowNotFound
+	ErrTooManyAppPasswords = fmt.Errorf("too many app passwords (maximum %d)", MaxAppPasswordsPerUser)
+	ErrInvalidAppPassword  = errors.New("invalid app password")
+)
+
+// IntegrationService provides what third-party integrations (e.g. a Nextcloud app) need:
+// app passwords, a browser login flow to obtain one, and embeddable calendar listings
+type IntegrationService struct {
+	appPasswordRepo *repository.AppPasswordRepository
+	loginFlowRepo   *repository.LoginFlowRepository
+	calendarRepo    *calendarRepo.CalendarRepository
+	appURL          string
+	productName     string
+	logger          *slog.Logger
+}
+
+// NewIntegrationService creates a new integration service
+func NewIntegrationService(
+	appPasswordRepo *repository.AppPasswordRepository,
+	loginFlowRepo *repository.LoginFlowRepository,
+	calendarRepo *calendarRepo.CalendarRepository,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *IntegrationService {
+	return &IntegrationService{
+		appPasswordRepo: appPasswordRepo,
+		loginFlowRepo:   loginFlowRepo,
+		calendarRepo:    calendarRepo,
+		appURL:          strings.TrimRight(cfg.AppURL, "/"),
+		productName:     cfg.Branding.ProductName,
+		logger:          logger,
+	}
+}
+
+// CreateAppPassword creates an app password and returns its value, which is not stored
+func (s *IntegrationService) CreateAppPassword(ctx context.Context, userID uuid.UUID, name string) (*models.AppPassword, string, error) {
+	count, err := s.appPasswordRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if count >= MaxAppPasswordsPerUser {
+		return nil, "", ErrTooManyAppPasswords
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	value := AppPasswordPrefix + secret
+
+	password := &models.AppPassword{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if err := s.appPasswordRepo.Create(ctx, password, hashToken(value)); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.Info("App password created", "app_password_id", password.ID, "user_id", userID)
+	return password, value, nil
+}
+
+// ListAppPasswords returns the app passwords of a user
+func (s *IntegrationService) ListAppPasswords(ctx context.Context, userID uuid.UUID) ([]*models.AppPassword, error) {
+	return s.appPasswordRepo.ListByUser(ctx, userID)
+}
+
+// DeleteAppPassword revokes an app password of a user
+func (s *IntegrationService) DeleteAppPassword(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.appPasswordRepo.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("App password revoked", "app_password_id", id, "user_id", userID)
+	return nil
+}
+
+// Authenticate returns the user owning an app password
+func (s *IntegrationService) Authenticate(ctx context.Context, value string) (*models.AppPasswordOwner, error) {
+	if !IsAppPassword(value) {
+		return nil, ErrInvalidAppPassword
+	}
+
+	owner, err := s.appPasswordRepo.Authenticate(ctx, hashToken(value))
+	if errors.Is(err, ErrAppPasswordNotFound) {
+		return nil, ErrInvalidAppPassword
+	}
+	return owner, err
+}
+
+// IsAppPassword reports whether a credential looks like an app password
+func IsAppPassword(value string) bool {
+	return strings.HasPrefix(value, AppPasswordPrefix)
+}
+
+// StartLoginFlow starts a browser login flow for an integration
+// The integration opens the login URL for the user, then polls with the poll token until access is granted
+func (s *IntegrationService) StartLoginFlow(ctx context.Context, clientName string) (*models.LoginFlowResponse, error) {
+	if err := s.loginFlowRepo.DeleteExpired(ctx, time.Now()); err != nil {
+		s.logger.Warn("Failed to delete expired login flows", "error", err)
+	}
+
+	flowToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	pollToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	flow := &models.LoginFlow{
+		ID:         uuid.New(),
+		ClientName: clientName,
+		ExpiresAt:  time.Now().Add(loginFlowTTL),
+	}
+	if err := s.loginFlowRepo.Create(ctx, flow, hashToken(flowToken), hashToken(pollToken)); err != nil {
+		return nil, err
+	}
+
+	return &models.LoginFlowResponse{
+		LoginURL: s.appURL + "/login/flow/" + flowToken,
+		Poll: models.LoginFlowPolling{
+			Endpoint: s.appURL + "/api/v1/integrations/login-flow/poll",
+			Token:    pollToken,
+		},
+		ExpiresAt: flow.ExpiresAt,
+	}, nil
+}
+
+// GetLoginFlow returns a pending login flow, to show the user which integration asks for access
+func (s *IntegrationService) GetLoginFlow(ctx context.Context, flowToken string) (*models.LoginFlow, error) {
+	return s.loginFlowRepo.GetPendingByFlowToken(ctx, hashToken(flowToken))
+}
+
+// GrantLoginFlow creates an app password for the integration of a pending login flow
+func (s *IntegrationService) GrantLoginFlow(ctx context.Context, userID uuid.UUID, flowToken string) error {
+	flow, err := s.loginFlowRepo.GetPendingByFlowToken(ctx, hashToken(flowToken))
+	if err != nil {
+		return err
+	}
+
+	password, value, err := s.CreateAppPassword(ctx, userID, flow.ClientName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.loginFlowRepo.Grant(ctx, flow.ID, userID, value); err != nil {
+		// Granted concurrently or expired meanwhile: don't leave an unused password behind
+		_ = s.appPasswordRepo.Delete(ctx, password.ID, userID)
+		return err
+	}
+
+	s.logger.Info("Login flow granted", "client_name", flow.ClientName, "user_id", userID)
+	return nil
+}
+
+// PollLoginFlow returns the credentials of a granted login flow, once
+// ErrLoginFlowNotFound means the flow is still pending (or expired)
+func (s *IntegrationService) PollLoginFlow(ctx context.Context, pollToken string) (*models.LoginFlowResultResponse, error) {
+	flow, err := s.loginFlowRepo.ConsumeGranted(ctx, hashToken(pollToken))
+	if err != nil {
+		return nil, err
+	}
+	if flow.AppPassword == nil || flow.Email == nil {
+		return nil, ErrLoginFlowNotFound
+	}
+
+	return &models.LoginFlowResultResponse{
+		Server:      s.appURL,
+		LoginName:   *flow.Email,
+		AppPassword: *flow.AppPassword,
+	}, nil
+}
+
+// ListCalendars returns the calendars owned by a user with the URLs to embed or subscribe to them
+func (s *IntegrationService) ListCalendars(ctx context.Context, userID uuid.UUID) ([]models.CalendarResponse, error) {
+	calendars, err := s.calendarRepo.GetByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.CalendarResponse, 0, len(calendars))
+	for _, calendar := range calendars {
+		icsURL := fmt.Sprintf("%s/api/v1/ics/feed/%s.ics", s.appURL, calendar.ICSToken)
+		responses = append(responses, models.CalendarResponse{
+			ID:          calendar.ID.String(),
+			Name:        calendar.Name,
+			Description: calendar.Description,
+			Threshold:   calendar.Threshold,
+			Timezone:    calendar.Timezone,
+			PublicURL:   fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken),
+			ICSURL:      icsURL,
+			WebcalURL:   webcalURL(icsURL),
+			CreatedAt:   calendar.CreatedAt,
+			UpdatedAt:   calendar.UpdatedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+// Capabilities describes the instance to integrations
+func (s *IntegrationService) Capabilities(version, build string) *models.CapabilitiesResponse {
+	return &models.CapabilitiesResponse{
+		ProductName: s.productName,
+		Version:     version,
+		Build:       build,
+		APIVersion:  "v1",
+		Auth:        []string{"bearer", "app_password"},
+		Features: map[string]bool{
+			"app_passwords": true,
+			"login_flow":    true,
+			"ics_feeds":     true,
+			"rest_hooks":    true,
+			"caldav_sync":   true,
+		},
+		Endpoints: map[string]string{
+			"login_flow": "/api/v1/integrations/login-flow",
+			"calendars":  "/api/v1/integrations/calendars",
+			"hooks":      "/api/v1/hooks",
+			"ics_feed":   "/api/v1/ics/feed/{ics_token}.ics",
+		},
+	}
+}
+
+// webcalURL returns a feed URL with the webcal scheme, which calendar apps open as a subscription
+func webcalURL(feedURL string) string {
+	if _, rest, ok := strings.Cut(feedURL, "://"); ok {
+		return "webcal://" + rest
+	}
+	return feedURL
+}
+
+// randomToken returns a random URL-safe token of 256 bits
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the SHA-256 hash of a token, as stored in the database
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,53 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"testing"
+)
+
+func TestWebcalURL(t *testing.T) {
+	tests := map[string]string{
+		"https://whento.example.com/api/v1/ics/feed/abc.ics": "webcal://whento.example.com/api/v1/ics/feed/abc.ics",
+		"http://localhost:8080/api/v1/ics/feed/abc.ics":      "webcal://localhost:8080/api/v1/ics/feed/abc.ics",
+		"/api/v1/ics/feed/abc.ics":                           "/api/v1/ics/feed/abc.ics",
+	}
+
+	for feedURL, want := range tests {
+		if got := webcalURL(feedURL); got != want {
+			t.Errorf("webcalURL(%q) = %q, want %q", feedURL, got, want)
+		}
+	}
+}
+
+func TestRandomToken(t *testing.T) {
+	first, err := randomToken()
+	if err != nil {
+		t.Fatalf("randomToken() error = %v", err)
+	}
+	second, _ := randomToken()
+
+	if len(first) != 43 {
+		t.Errorf("randomToken() length = %d, want 43", len(first))
+	}
+	if first == second {
+		t.Error("randomToken() returned the same token twice")
+	}
+}
+
+func TestAppPasswordHash(t *testing.T) {
+	value := AppPasswordPrefix + "secret"
+
+	if !IsAppPassword(value) || IsAppPassword("eyJhbGciOiJSUzI1NiJ9.payload.signature") {
+		t.Error("IsAppPassword() should only accept prefixed values")
+	}
+	if hashToken(value) != hashToken(value) || hashToken(value) == hashToken(value+"x") {
+		t.Error("hashToken() should be deterministic and distinct per value")
+	}
+	if len(hashToken(value)) != 64 {
+		t.Errorf("hashToken() length = %d, want 64", len(hashToken(value)))
+	}
+}
@@ -13,9 +13,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"os"
 	"sync"
 	"time"
 
+	"github.com/whento/pkg/license"
+
 	"github.com/whento/whento/internal/licensing/models"
 	"github.com/whento/whento/internal/licensing/repository"
 )
@@ -24,9 +27,10 @@ import (
 // License is loaded from DB at startup and kept in RAM for performance
 // Signature is verified on every load - DB columns are only for indexing
 type Service struct {
-	repo      *repository.LicenseRepository
-	publicKey ed25519.PublicKey
-	log       *slog.Logger
+	repo        *repository.LicenseRepository
+	publicKey   ed25519.PublicKey
+	revocations *license.RevocationList // Signed list of revoked licenses (nil = not configured)
+	log         *slog.Logger
 
 	// In-memory license cache
 	activeLicense *models.LicensePayload
@@ -37,8 +41,9 @@ type Service struct {
 const LicensePublicKeyBase64 = "Qb7v1/Iy0BIehwam7ALcBHo0X6g8un7WpQke79IPz9I="
 
 // Config holds the configuration for the licensing service
+// The public key is hardcoded for security
 type Config struct {
-	// No configuration needed - public key is hardcoded for security
+	RevocationListPath string // Signed revocation list file generated by licensegen revoke (empty = disabled)
 }
 
 // New creates a new licensing service
@@ -56,11 +61,49 @@ func New(repo *repository.LicenseRepository, cfg Config, log *slog.Logger) (*Ser
 	publicKey := ed25519.PublicKey(publicKeyBytes)
 	log.Info("License service initialized with hardcoded public key")
 
-	return &Service{
+	s := &Service{
 		repo:      repo,
 		publicKey: publicKey,
 		log:       log,
-	}, nil
+	}
+
+	if cfg.RevocationListPath != "" {
+		revocations, err := loadRevocationList(cfg.RevocationListPath, publicKey)
+		if err != nil {
+			return nil, err
+		}
+		s.revocations = revocations
+		log.Info("License revocation list loaded", "version", revocations.Version, "entries", len(revocations.Entries))
+	}
+
+	return s, nil
+}
+
+// loadRevocationList reads a revocation list file and verifies its signature
+func loadRevocationList(path string, publicKey ed25519.PublicKey) (*license.RevocationList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read license revocation list: %w", err)
+	}
+
+	var revocations license.RevocationList
+	if err := json.Unmarshal(data, &revocations); err != nil {
+		return nil, fmt.Errorf("invalid license revocation list: %w", err)
+	}
+
+	if err := license.ValidateRevocationList(&revocations, publicKey); err != nil {
+		return nil, fmt.Errorf("license revocation list verification failed - possible tampering detected: %w", err)
+	}
+
+	return &revocations, nil
+}
+
+// revocation returns the revocation entry of a license, or nil if it isn't revoked
+func (s *Service) revocation(payload *models.LicensePayload) *license.RevokedLicense {
+	if s.revocations == nil {
+		return nil
+	}
+	return s.revocations.Find(payload.Signature)
 }
 
 // LoadLicenseFromDB loads the license from the database into RAM
@@ -79,6 +122,17 @@ func (s *Service) LoadLicenseFromDB(ctx context.Context) error {
 		return fmt.Errorf("license signature verification failed - possible tampering detected")
 	}
 
+	// A revoked license is ignored: the instance falls back to the community tier
+	if revoked := s.revocation(&license.LicenseData); revoked != nil {
+		s.log.Warn("License has been revoked, using Community tier",
+			"license_id", license.ID,
+			"issued_to", license.LicenseData.IssuedTo,
+			"reason", revoked.Reason,
+			"revoked_at", revoked.RevokedAt,
+		)
+		return nil
+	}
+
 	// Load into RAM (self-hosted licenses are perpetual, no expiration check)
 	s.mu.Lock()
 	s.activeLicense = &license.LicenseData
@@ -119,22 +173,36 @@ func (s *Service) GetServerCalendarLimit() int {
 	return license.CalendarLimit
 }
 
-// ActivateLicense validates and activates a new license
-func (s *Service) ActivateLicense(ctx context.Context, licenseKey string) error {
+// ValidateLicenseKey parses a license key and checks its signature and revocation, without activating it
+func (s *Service) ValidateLicenseKey(licenseKey string) (*models.LicensePayload, error) {
 	// Check if public key is configured
 	if s.publicKey == nil {
-		return fmt.Errorf("license activation unavailable: no public key configured (set LICENSE_PUBLIC_KEY environment variable)")
+		return nil, fmt.Errorf("license activation unavailable: no public key configured (set LICENSE_PUBLIC_KEY environment variable)")
 	}
 
 	// Parse the license payload
 	var payload models.LicensePayload
 	if err := json.Unmarshal([]byte(licenseKey), &payload); err != nil {
-		return fmt.Errorf("invalid license format: %w", err)
+		return nil, fmt.Errorf("invalid license format: %w", err)
 	}
 
 	// Verify signature
 	if !s.verifySignature(&payload) {
-		return fmt.Errorf("invalid license signature")
+		return nil, fmt.Errorf("invalid license signature")
+	}
+
+	if revoked := s.revocation(&payload); revoked != nil {
+		return nil, fmt.Errorf("license has been revoked (%s)", revoked.Reason)
+	}
+
+	return &payload, nil
+}
+
+// ActivateLicense validates and activates a new license
+func (s *Service) ActivateLicense(ctx context.Context, licenseKey string) error {
+	payload, err := s.ValidateLicenseKey(licenseKey)
+	if err != nil {
+		return err
 	}
 
 	// Self-hosted licenses are perpetual (no expiration check)
@@ -148,7 +216,7 @@ func (s *Service) ActivateLicense(ctx context.Context, licenseKey string) error
 
 	// Create new license record
 	license := &models.License{
-		LicenseData: payload,
+		LicenseData: *payload,
 		ActivatedAt: time.Now(),
 	}
 
@@ -158,7 +226,7 @@ func (s *Service) ActivateLicense(ctx context.Context, licenseKey string) error
 
 	// Load into RAM
 	s.mu.Lock()
-	s.activeLicense = &payload
+	s.activeLicense = payload
 	s.mu.Unlock()
 
 	s.log.Info("License activated successfully",
@@ -0,0 +1,91 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/whento/internal/mailqueue/models"
+	"github.com/whento/whento/internal/mailqueue/repository"
+	"github.com/whento/whento/internal/mailqueue/service"
+)
+
+// MailQueueHandler handles the admin view of the undelivered emails
+type MailQueueHandler struct {
+	service *service.MailQueueService
+	logger  *slog.Logger
+}
+
+// NewMailQueueHandler creates a new mail queue handler
+func NewMailQueueHandler(service *service.MailQueueService, logger *slog.Logger) *MailQueueHandler {
+	return &MailQueueHandler{service: service, logger: logger}
+}
+
+// @Summary		List undelivered emails
+// @Description	Returns the latest emails that failed after all their attempts or were bounced by the SMTP server or provider, newest first, with their last error. Admin only.
+// @Tags			Admin
+// @Produce		json
+// @Security		BearerAuth
+// @Param			status	query		string	false	"Only failed or bounced emails"	Enums(failed, bounced)
+// @Success		200		{array}		models.QueuedEmail
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid status"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403		{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Router			/api/v1/auth/admin/mail-queue [get]
+func (h *MailQueueHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status != "" && status != models.StatusFailed && status != models.StatusBounced {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, "Status must be failed or bounced")
+		return
+	}
+
+	emails, err := h.service.ListUndelivered(r.Context(), status)
+	if err != nil {
+		h.logger.Error("Failed to list undelivered emails", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list undelivered emails")
+		return
+	}
+	if emails == nil {
+		emails = []*models.QueuedEmail{}
+	}
+
+	httputil.JSON(w, http.StatusOK, emails)
+}
+
+// @Summary		Resend an email
+// @Description	Queues a failed or bounced email again, with a new series of attempts. Admin only.
+// @Tags			Admin
+// @Security		BearerAuth
+// @Param			id	path	string	true	"Email ID"
+// @Success		204
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Failure		404	{object}	httputil.ErrorResponse	"Undelivered email not found"
+// @Router			/api/v1/auth/admin/mail-queue/{id}/resend [post]
+func (h *MailQueueHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid email ID")
+		return
+	}
+
+	if err := h.service.Resend(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrEmailNotFound) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Undelivered email not found")
+			return
+		}
+		h.logger.Error("Failed to resend email", "email_id", id, "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to resend email")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,35 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a queued email
+const (
+	StatusPending = "pending" // Waiting for its next attempt
+	StatusSent    = "sent"    // Accepted by the SMTP server or provider
+	StatusFailed  = "failed"  // All attempts failed
+	StatusBounced = "bounced" // Refused by the SMTP server or provider, not retried
+)
+
+// QueuedEmail is an email of the mail queue, with the outcome of its last attempt
+// The body isn't returned by the API: it may hold sign-in links and codes
+type QueuedEmail struct {
+	ID            uuid.UUID  `json:"id"`
+	Recipients    []string   `json:"recipients"`
+	Subject       string     `json:"subject"`
+	Body          string     `json:"-"`
+	HTML          bool       `json:"html"`
+	Status        string     `json:"status" enums:"pending,sent,failed,bounced"`
+	Attempts      int        `json:"attempts"`
+	Error         *string    `json:"error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+}
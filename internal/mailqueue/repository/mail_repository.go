@@ -0,0 +1,141 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/mailqueue/models"
+)
+
+var ErrEmailNotFound = errors.New("undelivered email not found")
+
+const mailColumns = `id, recipients, subject, body, html, status, attempts, error, next_attempt_at, created_at, sent_at`
+
+// MailRepository stores the emails waiting to be sent, and the outcome of the sent ones
+type MailRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMailRepository creates a new mail queue repository
+func NewMailRepository(pool *pgxpool.Pool) *MailRepository {
+	return &MailRepository{pool: pool}
+}
+
+// Enqueue queues an email, due at its NextAttemptAt
+func (r *MailRepository) Enqueue(ctx context.Context, m *models.QueuedEmail) error {
+	query := `
+		INSERT INTO mail_queue (id, recipients, subject, body, html, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.pool.Exec(ctx, query,
+		m.ID, m.Recipients, m.Subject, m.Body, m.HTML, m.Status, m.Attempts, m.NextAttemptAt, m.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue claims up to limit pending emails due at now
+// Claimed emails are postponed by lease, so that other instances skip them while they are sent
+// and the worker retries them if the instance stops before recording the attempt
+func (r *MailRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedEmail, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM mail_queue
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE mail_queue m
+		SET next_attempt_at = $2
+		FROM due
+		WHERE m.id = due.id
+		RETURNING ` + qualified("m")
+
+	return r.query(ctx, query, now, now.Add(lease), limit)
+}
+
+// RecordAttempt records the outcome of a delivery attempt
+func (r *MailRepository) RecordAttempt(ctx context.Context, m *models.QueuedEmail) error {
+	query := `
+		UPDATE mail_queue
+		SET status = $2, attempts = $3, error = $4, next_attempt_at = $5, sent_at = $6
+		WHERE id = $1`
+
+	_, err := r.pool.Exec(ctx, query, m.ID, m.Status, m.Attempts, m.Error, m.NextAttemptAt, m.SentAt)
+	if err != nil {
+		return fmt.Errorf("failed to record email delivery: %w", err)
+	}
+	return nil
+}
+
+// ListUndelivered returns the latest failed and bounced emails, or those of a status, newest first
+func (r *MailRepository) ListUndelivered(ctx context.Context, status string, limit int) ([]*models.QueuedEmail, error) {
+	query := `
+		SELECT ` + mailColumns + `
+		FROM mail_queue
+		WHERE status IN ('failed', 'bounced') AND ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	return r.query(ctx, query, status, limit)
+}
+
+// Requeue queues a failed or bounced email again, due at now, with its attempts reset
+func (r *MailRepository) Requeue(ctx context.Context, id uuid.UUID, now time.Time) error {
+	query := `
+		UPDATE mail_queue
+		SET status = 'pending', attempts = 0, error = NULL, next_attempt_at = $2
+		WHERE id = $1 AND status IN ('failed', 'bounced')`
+
+	result, err := r.pool.Exec(ctx, query, id, now)
+	if err != nil {
+		return fmt.Errorf("failed to requeue email: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrEmailNotFound
+	}
+	return nil
+}
+
+// qualified returns mailColumns qualified by a table alias
+func qualified(alias string) string {
+	return fmt.Sprintf(
+		"%[1]s.id, %[1]s.recipients, %[1]s.subject, %[1]s.body, %[1]s.html, %[1]s.status, %[1]s.attempts, "+
+			"%[1]s.error, %[1]s.next_attempt_at, %[1]s.created_at, %[1]s.sent_at",
+		alias,
+	)
+}
+
+func (r *MailRepository) query(ctx context.Context, query string, args ...any) ([]*models.QueuedEmail, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued emails: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []*models.QueuedEmail
+	for rows.Next() {
+		var m models.QueuedEmail
+		if err := rows.Scan(
+			&m.ID, &m.Recipients, &m.Subject, &m.Body, &m.HTML, &m.Status, &m.Attempts,
+			&m.Error, &m.NextAttemptAt, &m.CreatedAt, &m.SentAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan queued email: %w", err)
+		}
+		emails = append(emails, &m)
+	}
+
+	return emails, rows.Err()
+}
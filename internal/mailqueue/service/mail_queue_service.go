@@ -0,0 +1,219 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/email"
+	"github.com/whento/whento/internal/mailqueue/models"
+)
+
+const (
+	// Worker settings
+	workerInterval  = 30 * time.Second
+	workerBatchSize = 20
+	workerLease     = 2 * time.Minute // Longer than the SMTP and provider timeouts
+
+	// undeliveredLimit is the number of emails returned by the admin list
+	undeliveredLimit = 100
+
+	// Retries double their delay from retryBase: an email is marked as failed
+	// after maxAttempts attempts, about 2 hours after it was queued
+	retryBase   = time.Minute
+	maxAttempts = 8
+
+	// maxErrorLength truncates the recorded errors
+	maxErrorLength = 500
+)
+
+// ErrNotConfigured is the error of the attempts made while email isn't configured, retried later
+var ErrNotConfigured = errors.New("email is not configured")
+
+// MailRepository defines the interface for mail queue repository operations
+type MailRepository interface {
+	Enqueue(ctx context.Context, m *models.QueuedEmail) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedEmail, error)
+	RecordAttempt(ctx context.Context, m *models.QueuedEmail) error
+	ListUndelivered(ctx context.Context, status string, limit int) ([]*models.QueuedEmail, error)
+	Requeue(ctx context.Context, id uuid.UUID, now time.Time) error
+}
+
+// MailQueueService queues the emails of the email service and sends them from a worker,
+// retrying transient failures with an exponential backoff
+type MailQueueService struct {
+	repo         MailRepository
+	emailService *email.Service
+	wake         chan struct{}
+	logger       *slog.Logger
+}
+
+// NewMailQueueService creates a new mail queue service
+// It queues the emails sent with emailService.Send once set as its queue with SetQueue
+func NewMailQueueService(repo MailRepository, emailService *email.Service, logger *slog.Logger) *MailQueueService {
+	return &MailQueueService{
+		repo:         repo,
+		emailService: emailService,
+		wake:         make(chan struct{}, 1),
+		logger:       logger,
+	}
+}
+
+// Enqueue queues an email, sent as soon as the worker wakes up
+func (s *MailQueueService) Enqueue(ctx context.Context, e email.Email) error {
+	now := time.Now()
+	m := &models.QueuedEmail{
+		ID:            uuid.New(),
+		Recipients:    e.To,
+		Subject:       e.Subject,
+		Body:          e.Body,
+		HTML:          e.HTML,
+		Status:        models.StatusPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+	}
+	if err := s.repo.Enqueue(ctx, m); err != nil {
+		return err
+	}
+
+	s.wakeUp()
+	return nil
+}
+
+func (s *MailQueueService) wakeUp() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// StartTask starts the worker, which sends queued emails as soon as they are due
+func (s *MailQueueService) StartTask(ctx context.Context) {
+	s.logger.Info("Starting mail queue worker", "interval", workerInterval)
+
+	go func() {
+		ticker := time.NewTicker(workerInterval)
+		defer ticker.Stop()
+
+		for {
+			s.DeliverDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Mail queue worker stopped (context cancelled)")
+				return
+			case <-ticker.C:
+			case <-s.wake:
+			}
+		}
+	}()
+}
+
+// DeliverDue sends the queued emails that are due, in batches
+// Emails are claimed in the database, so several instances can run the worker
+func (s *MailQueueService) DeliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		due, err := s.repo.ClaimDue(ctx, time.Now(), workerLease, workerBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to claim queued emails", "error", err)
+			return
+		}
+
+		// One at a time, to stay below the limits of SMTP relays
+		for _, m := range due {
+			s.attempt(ctx, m)
+		}
+
+		if len(due) < workerBatchSize {
+			return
+		}
+	}
+}
+
+// attempt sends a claimed email and records the outcome
+func (s *MailQueueService) attempt(ctx context.Context, m *models.QueuedEmail) {
+	var err error
+	if s.emailService.IsConfigured() {
+		err = s.emailService.SendNow(email.Email{To: m.Recipients, Subject: m.Subject, Body: m.Body, HTML: m.HTML})
+	} else {
+		err = ErrNotConfigured
+	}
+	recordAttempt(m, err, time.Now())
+
+	switch m.Status {
+	case models.StatusSent:
+		s.logger.Debug("Queued email sent", "email_id", m.ID)
+	case models.StatusPending:
+		s.logger.Warn("Email delivery failed, will retry", "email_id", m.ID, "attempt", m.Attempts, "error", err)
+	default:
+		s.logger.Error("Email delivery failed", "email_id", m.ID, "status", m.Status, "attempt", m.Attempts, "error", err)
+	}
+
+	if err := s.repo.RecordAttempt(ctx, m); err != nil {
+		s.logger.Error("Failed to record email delivery", "email_id", m.ID, "error", err)
+	}
+}
+
+// recordAttempt updates an email with the outcome of an attempt, scheduling a retry if any is left
+// Bounces aren't retried: the server or provider would refuse the email again
+func recordAttempt(m *models.QueuedEmail, err error, now time.Time) {
+	m.Attempts++
+
+	if err == nil {
+		m.Status = models.StatusSent
+		m.Error = nil
+		m.NextAttemptAt = nil
+		m.SentAt = &now
+		return
+	}
+
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	m.Error = &message
+	m.NextAttemptAt = nil
+
+	if email.IsPermanent(err) {
+		m.Status = models.StatusBounced
+		return
+	}
+	delay, ok := retryDelay(m.Attempts)
+	if !ok {
+		m.Status = models.StatusFailed
+		return
+	}
+	next := now.Add(delay)
+	m.Status = models.StatusPending
+	m.NextAttemptAt = &next
+}
+
+// retryDelay returns the delay before the retry following an attempt (1-based), if any is left
+func retryDelay(attempts int) (time.Duration, bool) {
+	if attempts < 1 || attempts >= maxAttempts {
+		return 0, false
+	}
+	return retryBase << (attempts - 1), true
+}
+
+// ListUndelivered returns the latest failed and bounced emails, or those of a status
+func (s *MailQueueService) ListUndelivered(ctx context.Context, status string) ([]*models.QueuedEmail, error) {
+	return s.repo.ListUndelivered(ctx, status, undeliveredLimit)
+}
+
+// Resend queues a failed or bounced email again, with a new series of attempts
+func (s *MailQueueService) Resend(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Requeue(ctx, id, time.Now()); err != nil {
+		return err
+	}
+
+	s.wakeUp()
+	return nil
+}
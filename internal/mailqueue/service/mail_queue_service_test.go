@@ -0,0 +1,72 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/mailqueue/models"
+)
+
+func TestRecordAttempt(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		m := &models.QueuedEmail{Status: models.StatusPending, NextAttemptAt: &now}
+		recordAttempt(m, nil, now)
+		if m.Status != models.StatusSent || m.Attempts != 1 || m.NextAttemptAt != nil || m.SentAt == nil {
+			t.Errorf("email = %+v, want sent after 1 attempt", m)
+		}
+	})
+
+	t.Run("retries with exponential backoff", func(t *testing.T) {
+		m := &models.QueuedEmail{Status: models.StatusPending}
+		delay := retryBase
+		for attempt := 1; attempt < maxAttempts; attempt++ {
+			recordAttempt(m, errors.New("connection refused"), now)
+			if m.Status != models.StatusPending || m.Attempts != attempt {
+				t.Fatalf("attempt %d: email = %+v, want pending", attempt, m)
+			}
+			if m.NextAttemptAt == nil || !m.NextAttemptAt.Equal(now.Add(delay)) {
+				t.Fatalf("attempt %d: next attempt = %v, want %v", attempt, m.NextAttemptAt, now.Add(delay))
+			}
+			delay *= 2
+		}
+
+		recordAttempt(m, errors.New("connection refused"), now)
+		if m.Status != models.StatusFailed || m.NextAttemptAt != nil || m.Attempts != maxAttempts {
+			t.Errorf("email = %+v, want failed after %d attempts", m, maxAttempts)
+		}
+	})
+
+	t.Run("bounces are not retried", func(t *testing.T) {
+		m := &models.QueuedEmail{Status: models.StatusPending}
+		recordAttempt(m, fmt.Errorf("failed to send email: %w", &textproto.Error{Code: 550, Msg: "5.1.1 User unknown"}), now)
+		if m.Status != models.StatusBounced || m.NextAttemptAt != nil || m.Error == nil {
+			t.Errorf("email = %+v, want bounced", m)
+		}
+	})
+
+	t.Run("unconfigured email is retried", func(t *testing.T) {
+		m := &models.QueuedEmail{Status: models.StatusPending}
+		recordAttempt(m, ErrNotConfigured, now)
+		if m.Status != models.StatusPending || m.NextAttemptAt == nil {
+			t.Errorf("email = %+v, want pending", m)
+		}
+	})
+
+	t.Run("long errors are truncated", func(t *testing.T) {
+		m := &models.QueuedEmail{}
+		recordAttempt(m, errors.New(strings.Repeat("x", 2000)), now)
+		if m.Error == nil || len(*m.Error) != maxErrorLength {
+			t.Errorf("error length = %v, want %d", m.Error, maxErrorLength)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import (
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/jwt"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/maintenance/models"
+	"github.com/whento/whento/internal/maintenance/service"
+)
+
+// DefaultMessage is shown during maintenance when the admin didn't write one
+const DefaultMessage = "We are performing scheduled maintenance. Please try again in a few minutes."
+
+// retryAfter is the delay suggested to clients during maintenance, in seconds
+const retryAfter = "120"
+
+// openPaths are the routes served during maintenance: health checks, the maintenance status,
+// the branding of the SPA and the sign-in routes, so that admins can sign in and turn the maintenance off
+// Paths ending with a slash match all the routes below them
+var openPaths = []string{
+	"/api/health",
+	"/api/ready",
+	"/api/v1/maintenance",
+	"/api/v1/branding",
+	"/api/v1/auth/login",
+	"/api/v1/auth/refresh",
+	"/api/v1/auth/logout",
+	"/api/v1/auth/me",
+	"/api/v1/auth/sso",
+	"/api/v1/auth/sso/",
+	"/api/v1/auth/oidc/",
+	"/api/v1/auth/saml/",
+	"/api/v1/auth/passkey/login/",
+	"/api/v1/auth/mfa/",
+}
+
+// closedPrefixes are the routes answering 503 during maintenance; other paths are the SPA and its assets,
+// which keep being served to show the maintenance banner
+var closedPrefixes = []string{"/api/", "/dav/", "/embed/"}
+
+var pageTemplate = template.Must(template.New("maintenance").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.ProductName}} - Maintenance</title>
+<style>
+body{margin:0;min-height:100vh;display:flex;align-items:center;justify-content:center;font-family:system-ui,sans-serif;background:#f9fafb;color:#111827}
+main{max-width:32rem;padding:2rem;text-align:center}
+h1{font-size:1.5rem;margin-bottom:.5rem}
+p{color:#4b5563;line-height:1.5}
+</style>
+</head>
+<body>
+<main>
+<h1>{{.ProductName}} is under maintenance</h1>
+<p>{{.Message}}</p>
+</main>
+</body>
+</html>
+`))
+
+// MaintenanceHandler handles the maintenance mode: its status, its toggle and the middleware enforcing it
+type MaintenanceHandler struct {
+	service     *service.MaintenanceService
+	jwtManager  *jwt.Manager
+	productName string
+	logger      *slog.Logger
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(service *service.MaintenanceService, jwtManager *jwt.Manager, productName string, logger *slog.Logger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		service:     service,
+		jwtManager:  jwtManager,
+		productName: productName,
+		logger:      logger,
+	}
+}
+
+// Middleware answers 503 to the requests of everyone but admins while the maintenance mode is on
+// Browsers get an HTML page, API clients a JSON error with the MAINTENANCE code
+func (h *MaintenanceHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := h.service.Status()
+		if !status.Enabled || !isClosed(r.URL.Path) || h.isAdmin(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		message := status.Message
+		if message == "" {
+			message = DefaultMessage
+		}
+
+		w.Header().Set("Retry-After", retryAfter)
+		if strings.Contains(r.Header.Get("Accept"), "text/html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = pageTemplate.Execute(w, map[string]string{"ProductName": h.productName, "Message": message})
+			return
+		}
+		httputil.Error(w, http.StatusServiceUnavailable, httputil.ErrCodeMaintenance, message)
+	})
+}
+
+// isClosed reports whether a path answers 503 during maintenance
+func isClosed(path string) bool {
+	for _, open := range openPaths {
+		if path == open || (strings.HasSuffix(open, "/") && strings.HasPrefix(path, open)) {
+			return false
+		}
+	}
+	for _, prefix := range closedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdmin reports whether a request is sent by an admin signed in with a JWT
+func (h *MaintenanceHandler) isAdmin(r *http.Request) bool {
+	value, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	claims, err := h.jwtManager.ValidateAccessToken(value)
+	return err == nil && claims.Role == "admin"
+}
+
+// @Summary		Get maintenance status
+// @Description	Reports whether the instance is under maintenance, with the message to show. Public, polled by the SPA to show a banner.
+// @Tags			Maintenance
+// @Produce		json
+// @Success		200	{object}	models.PublicStatus
+// @Router			/api/v1/maintenance [get]
+func (h *MaintenanceHandler) GetPublicStatus(w http.ResponseWriter, r *http.Request) {
+	status := h.service.Status()
+	httputil.JSON(w, http.StatusOK, models.PublicStatus{Enabled: status.Enabled, Message: status.Message})
+}
+
+// @Summary		Get maintenance mode
+// @Description	Returns the maintenance mode with the admin who last changed it. Admin only.
+// @Tags			Admin
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	models.Status
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Router			/api/v1/auth/admin/maintenance [get]
+func (h *MaintenanceHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	httputil.JSON(w, http.StatusOK, h.service.Status())
+}
+
+// @Summary		Turn maintenance mode on or off
+// @Description	While the maintenance mode is on, every route but the sign-in routes, health checks and the SPA answers 503 to everyone but admins, with the message (or a default one). Other instances pick the change up within 10 seconds. Admin only.
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			request	body		models.UpdateRequest	true	"Maintenance mode"
+// @Success		200		{object}	models.Status
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403		{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Router			/api/v1/auth/admin/maintenance [put]
+func (h *MaintenanceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	var updatedBy *uuid.UUID
+	if userID, err := uuid.Parse(middleware.GetUserID(r.Context())); err == nil {
+		updatedBy = &userID
+	}
+
+	status, err := h.service.Set(r.Context(), *req.Enabled, strings.TrimSpace(req.Message), updatedBy)
+	if err != nil {
+		h.logger.Error("Failed to set maintenance mode", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to set maintenance mode")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, status)
+}
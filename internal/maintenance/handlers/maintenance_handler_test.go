@@ -0,0 +1,36 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package handlers
+
+import "testing"
+
+func TestIsClosed(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/api/v1/calendars", true},
+		{"/api/v1/ics/feed/abc", true},
+		{"/dav/calendars/abc/", true},
+		{"/embed/abc", true},
+		{"/api/v1/auth/register", true},
+		{"/api/v1/auth/me/export", true},
+		{"/api/v1/auth/login", false},
+		{"/api/v1/auth/mfa/verify", false},
+		{"/api/v1/auth/passkey/login/begin", false},
+		{"/api/v1/auth/me", false},
+		{"/api/v1/maintenance", false},
+		{"/api/health", false},
+		{"/", false},
+		{"/c/abc", false},
+		{"/assets/index.js", false},
+	}
+
+	for _, tt := range tests {
+		if got := isClosed(tt.path); got != tt.want {
+			t.Errorf("isClosed(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
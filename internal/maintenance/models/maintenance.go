@@ -0,0 +1,31 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the maintenance mode of the instance
+type Status struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message,omitempty"` // Shown to users instead of the default message
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// PublicStatus is the maintenance mode shown to everyone (banner of the SPA)
+type PublicStatus struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+}
+
+// UpdateRequest turns the maintenance mode on or off
+type UpdateRequest struct {
+	Enabled *bool  `json:"enabled" validate:"required"`
+	Message string `json:"message" validate:"max=500"`
+}
@@ -0,0 +1,52 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/maintenance/models"
+)
+
+// MaintenanceRepository stores the maintenance mode of the instance
+type MaintenanceRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMaintenanceRepository creates a new maintenance repository
+func NewMaintenanceRepository(pool *pgxpool.Pool) *MaintenanceRepository {
+	return &MaintenanceRepository{pool: pool}
+}
+
+// Get returns the maintenance mode
+func (r *MaintenanceRepository) Get(ctx context.Context) (*models.Status, error) {
+	status := &models.Status{}
+	err := r.pool.QueryRow(ctx, `SELECT enabled, message, updated_by, updated_at FROM maintenance_mode`).
+		Scan(&status.Enabled, &status.Message, &status.UpdatedBy, &status.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+	return status, nil
+}
+
+// Set turns the maintenance mode on or off (updatedBy is nil from the command line)
+func (r *MaintenanceRepository) Set(ctx context.Context, enabled bool, message string, updatedBy *uuid.UUID) (*models.Status, error) {
+	query := `
+		UPDATE maintenance_mode
+		SET enabled = $1, message = $2, updated_by = $3, updated_at = NOW()
+		RETURNING enabled, message, updated_by, updated_at`
+
+	status := &models.Status{}
+	err := r.pool.QueryRow(ctx, query, enabled, message, updatedBy).
+		Scan(&status.Enabled, &status.Message, &status.UpdatedBy, &status.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set maintenance mode: %w", err)
+	}
+	return status, nil
+}
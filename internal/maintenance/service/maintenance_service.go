@@ -0,0 +1,93 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/maintenance/models"
+)
+
+// RefreshInterval is how often the maintenance mode is read again from the database,
+// so that all the instances behind a load balancer (or a change from the command line) pick it up
+const RefreshInterval = 10 * time.Second
+
+// MaintenanceRepository defines the interface for maintenance repository operations
+type MaintenanceRepository interface {
+	Get(ctx context.Context) (*models.Status, error)
+	Set(ctx context.Context, enabled bool, message string, updatedBy *uuid.UUID) (*models.Status, error)
+}
+
+// MaintenanceService keeps the maintenance mode of the instance in memory, as it is checked on every request
+type MaintenanceService struct {
+	repo   MaintenanceRepository
+	status atomic.Pointer[models.Status]
+	logger *slog.Logger
+}
+
+// NewMaintenanceService creates a new maintenance service, with the maintenance mode off until loaded
+func NewMaintenanceService(repo MaintenanceRepository, logger *slog.Logger) *MaintenanceService {
+	s := &MaintenanceService{repo: repo, logger: logger}
+	s.status.Store(&models.Status{})
+	return s
+}
+
+// Load reads the maintenance mode from the database
+func (s *MaintenanceService) Load(ctx context.Context) error {
+	status, err := s.repo.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if previous := s.status.Swap(status); previous.Enabled != status.Enabled {
+		s.logger.Info("Maintenance mode changed", "enabled", status.Enabled)
+	}
+	return nil
+}
+
+// StartTask loads the maintenance mode, then reloads it every RefreshInterval until the context is cancelled
+func (s *MaintenanceService) StartTask(ctx context.Context) {
+	if err := s.Load(ctx); err != nil {
+		s.logger.Error("Failed to load maintenance mode", "error", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Load(ctx); err != nil {
+					s.logger.Error("Failed to reload maintenance mode", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Status returns the current maintenance mode
+func (s *MaintenanceService) Status() models.Status {
+	return *s.status.Load()
+}
+
+// Set turns the maintenance mode on or off (updatedBy is the admin, nil from the command line)
+func (s *MaintenanceService) Set(ctx context.Context, enabled bool, message string, updatedBy *uuid.UUID) (*models.Status, error) {
+	status, err := s.repo.Set(ctx, enabled, message, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.status.Store(status)
+	s.logger.Info("Maintenance mode set", "enabled", enabled, "user_id", updatedBy)
+	return status, nil
+}
@@ -7,6 +7,7 @@ package handlers
 
 import (
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -19,34 +20,39 @@ import (
 	authService "github.com/whento/whento/internal/auth/service"
 	"github.com/whento/whento/internal/mfa/models"
 	"github.com/whento/whento/internal/mfa/service"
+	passkeyService "github.com/whento/whento/internal/passkey/service"
 )
 
 // MFAHandler handles MFA HTTP requests
 type MFAHandler struct {
-	service     *service.MFAService
-	authService *authService.AuthService
-	jwtManager  *jwt.Manager
-	logger      *slog.Logger
+	service        *service.MFAService
+	authService    *authService.AuthService
+	passkeyService *passkeyService.PasskeyService
+	jwtManager     *jwt.Manager
+	logger         *slog.Logger
 }
 
 // NewMFAHandler creates a new MFA handler
-func NewMFAHandler(service *service.MFAService, authSvc *authService.AuthService, jwtManager *jwt.Manager, logger *slog.Logger) *MFAHandler {
+func NewMFAHandler(service *service.MFAService, authSvc *authService.AuthService, passkeySvc *passkeyService.PasskeyService, jwtManager *jwt.Manager, logger *slog.Logger) *MFAHandler {
 	return &MFAHandler{
-		service:     service,
-		authService: authSvc,
-		jwtManager:  jwtManager,
-		logger:      logger,
+		service:        service,
+		authService:    authSvc,
+		passkeyService: passkeySvc,
+		jwtManager:     jwtManager,
+		logger:         logger,
 	}
 }
 
 // @Summary		Begin MFA setup
-// @Description	Initiates MFA setup by generating a TOTP secret and backup codes. Returns QR code URL and secret for authenticator app configuration.
+// @Description	Initiates MFA setup with the TOTP method (default) or the email method, and generates backup codes. TOTP returns a QR code URL and secret for authenticator app configuration; email sends a one-time code to the user's address.
 // @Tags			MFA
 // @Accept			json
 // @Produce		json
 // @Security		BearerAuth
-// @Success		200	{object}	models.TOTPSetupResponse	"TOTP secret, QR code URL, and backup codes"
-// @Failure		401	{object}	httputil.ErrorResponse		"Unauthorized"
+// @Param			request	body		models.BeginSetupRequest	false	"MFA method (totp or email)"
+// @Success		200		{object}	models.TOTPSetupResponse	"Method, TOTP secret and QR code URL, and backup codes"
+// @Failure		400		{object}	httputil.ErrorResponse		"Invalid request or email not configured"
+// @Failure		401		{object}	httputil.ErrorResponse		"Unauthorized"
 // @Failure		404	{object}	httputil.ErrorResponse		"User not found"
 // @Failure		409	{object}	httputil.ErrorResponse		"MFA is already enabled"
 // @Failure		500	{object}	httputil.ErrorResponse		"Internal server error"
@@ -64,8 +70,28 @@ func (h *MFAHandler) BeginSetup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := h.service.BeginSetup(r.Context(), userUUID)
+	// The body is optional: without one, TOTP is set up
+	var req models.BeginSetupRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	response, err := h.service.BeginSetup(r.Context(), userUUID, req.Method)
 	if err != nil {
+		if errors.Is(err, service.ErrEmailUnavailable) {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Email is not configured on this server")
+			return
+		}
 		if errors.Is(err, service.ErrUserNotFound) {
 			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "User not found")
 			return
@@ -83,7 +109,7 @@ func (h *MFAHandler) BeginSetup(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary		Finish MFA setup
-// @Description	Completes MFA setup by verifying the TOTP code from the authenticator app. Enables MFA for the user.
+// @Description	Completes MFA setup by verifying the TOTP code from the authenticator app or the code sent by email. Enables MFA for the user.
 // @Tags			MFA
 // @Accept			json
 // @Produce		json
@@ -245,7 +271,7 @@ func (h *MFAHandler) RegenerateBackupCodes(w http.ResponseWriter, r *http.Reques
 }
 
 // @Summary		Verify MFA during login
-// @Description	Verifies TOTP or backup code during login for users with MFA enabled. Completes authentication and returns JWT tokens.
+// @Description	Verifies TOTP, email or backup code, or a WebAuthn assertion from a security key, during login for users with MFA enabled. Completes authentication and returns JWT tokens.
 // @Tags			Authentication
 // @Accept			json
 // @Produce		json
@@ -271,34 +297,18 @@ func (h *MFAHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate temp token and extract user ID
-	claims, err := h.jwtManager.ValidateCustomToken(req.TempToken)
-	if err != nil {
-		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid or expired temp token")
-		return
-	}
-
-	// Check mfa_pending claim
-	mfaPending, ok := claims["mfa_pending"].(bool)
-	if !ok || !mfaPending {
-		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid temp token")
-		return
-	}
-
-	// Extract user ID
-	userIDStr, ok := claims["user_id"].(string)
+	userID, ok := h.parseTempToken(w, req.TempToken)
 	if !ok {
-		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid temp token")
 		return
 	}
 
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid user ID in token")
+	// A security key assertion replaces the code
+	if len(req.Credential) > 0 {
+		h.verifySecurityKey(w, r, userID, req.TempToken, req.Credential)
 		return
 	}
 
-	// Verify MFA code (TOTP or backup code)
+	// Verify MFA code (TOTP, email or backup code)
 	valid, err := h.service.VerifyCode(r.Context(), userID, req.Code)
 	if err != nil {
 		if errors.Is(err, service.ErrMFANotFound) || errors.Is(err, service.ErrMFANotEnabled) {
@@ -325,3 +335,161 @@ func (h *MFAHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
 
 	httputil.JSON(w, http.StatusOK, authResponse)
 }
+
+// @Summary		Send MFA email code
+// @Description	Sends a one-time verification code by email during login, for users whose MFA method is email. Each request replaces the previous code.
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			request	body		models.SendEmailCodeRequest	true	"Temporary token"
+// @Success		200		{object}	map[string]string			"Code sent"
+// @Failure		400		{object}	httputil.ErrorResponse		"Invalid request, MFA not enabled or method is not email"
+// @Failure		401		{object}	httputil.ErrorResponse		"Invalid temporary token"
+// @Failure		500		{object}	httputil.ErrorResponse		"Internal server error"
+// @Router			/api/v1/auth/mfa/email/send [post]
+func (h *MFAHandler) SendEmailCode(w http.ResponseWriter, r *http.Request) {
+	var req models.SendEmailCodeRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	userID, ok := h.parseTempToken(w, req.TempToken)
+	if !ok {
+		return
+	}
+
+	if err := h.service.SendLoginCode(r.Context(), userID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrMFANotEnabled):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "MFA is not enabled for this user")
+		case errors.Is(err, service.ErrNotEmailMethod):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "MFA method is not email")
+		case errors.Is(err, service.ErrEmailUnavailable):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Email is not configured on this server")
+		default:
+			h.logger.Error("Failed to send MFA email code", "error", err, "user_id", userID)
+			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to send email code")
+		}
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, map[string]string{"message": "Code sent"})
+}
+
+// @Summary		Begin security key verification
+// @Description	Returns WebAuthn assertion options for the security keys and passkeys of a user completing a password login. The signed assertion is then sent as `credential` to the MFA verification endpoint.
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			request	body		models.BeginSecurityKeyRequest	true	"Temporary token"
+// @Success		200		{object}	object							"WebAuthn assertion options"
+// @Failure		400		{object}	httputil.ErrorResponse			"Invalid request or no security key registered"
+// @Failure		401		{object}	httputil.ErrorResponse			"Invalid temporary token"
+// @Failure		500		{object}	httputil.ErrorResponse			"Internal server error"
+// @Router			/api/v1/auth/mfa/webauthn/begin [post]
+func (h *MFAHandler) BeginSecurityKey(w http.ResponseWriter, r *http.Request) {
+	var req models.BeginSecurityKeyRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return
+	}
+
+	userID, ok := h.parseTempToken(w, req.TempToken)
+	if !ok {
+		return
+	}
+
+	options, err := h.passkeyService.BeginSecondFactor(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, passkeyService.ErrNoCredentials) {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "No security key registered")
+			return
+		}
+		if errors.Is(err, passkeyService.ErrUserNotFound) {
+			httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid temp token")
+			return
+		}
+		h.logger.Error("Failed to begin security key verification", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to begin security key verification")
+		return
+	}
+
+	// Return options directly - it already marshals to {publicKey: {...}}
+	httputil.JSON(w, http.StatusOK, options)
+}
+
+// verifySecurityKey completes an MFA login with a WebAuthn assertion
+func (h *MFAHandler) verifySecurityKey(w http.ResponseWriter, r *http.Request, userID uuid.UUID, tempToken string, credential []byte) {
+	if err := h.passkeyService.FinishSecondFactor(r.Context(), userID, credential); err != nil {
+		switch {
+		case errors.Is(err, passkeyService.ErrInvalidChallenge):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid or expired challenge")
+		case errors.Is(err, passkeyService.ErrInvalidCredential), errors.Is(err, passkeyService.ErrUserNotFound):
+			httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid security key")
+		default:
+			h.logger.Error("Failed to verify security key", "error", err, "user_id", userID)
+			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to verify security key")
+		}
+		return
+	}
+
+	// Complete login by generating full auth tokens
+	authResponse, err := h.authService.VerifyMFAAndLogin(r.Context(), tempToken, "")
+	if err != nil {
+		h.logger.Error("Failed to complete MFA login", "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to complete login")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, authResponse)
+}
+
+// parseTempToken validates an MFA temp token and returns its user ID, writing the error response on failure
+func (h *MFAHandler) parseTempToken(w http.ResponseWriter, tempToken string) (uuid.UUID, bool) {
+	claims, err := h.jwtManager.ValidateCustomToken(tempToken)
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid or expired temp token")
+		return uuid.Nil, false
+	}
+
+	// Check mfa_pending claim
+	mfaPending, ok := claims["mfa_pending"].(bool)
+	if !ok || !mfaPending {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid temp token")
+		return uuid.Nil, false
+	}
+
+	// Extract user ID
+	userIDStr, ok := claims["user_id"].(string)
+	if !ok {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid temp token")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Invalid user ID in token")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
@@ -11,26 +11,39 @@ import (
 	"github.com/google/uuid"
 )
 
+// MFA methods, selected by each user at setup
+const (
+	MethodTOTP     = "totp"     // Authenticator app
+	MethodEmail    = "email"    // One-time code sent by email
+	MethodWebAuthn = "webauthn" // Security keys only (registered through the passkey routes)
+)
+
 // UserMFA represents a user's MFA configuration
 type UserMFA struct {
-	UserID          uuid.UUID
-	Enabled         bool
-	Secret          string
-	BackupCodes     []string
-	BackupCodesUsed []string
-	CreatedAt       time.Time
-	EnabledAt       *time.Time
+	UserID             uuid.UUID
+	Enabled            bool
+	Method             string
+	Secret             string // TOTP secret, empty for the email method
+	BackupCodes        []string
+	BackupCodesUsed    []string
+	EmailCodeHash      *string // SHA-256 of the last email code, cleared once used
+	EmailCodeExpiresAt *time.Time
+	CreatedAt          time.Time
+	EnabledAt          *time.Time
 }
 
 // MFAStatusResponse is the API response for MFA status
 type MFAStatusResponse struct {
-	Enabled bool `json:"enabled"`
+	Enabled bool   `json:"enabled"`
+	Method  string `json:"method,omitempty" example:"totp"` // totp, email or webauthn, when enabled
 }
 
-// TOTPSetupResponse contains the data needed to set up TOTP
+// TOTPSetupResponse contains the data needed to set up MFA
+// The secret and QR code are only set for TOTP; with the email method, a code is sent to the user instead
 type TOTPSetupResponse struct {
-	Secret      string   `json:"secret"`
-	QRCodeURL   string   `json:"qr_code_url"`
+	Method      string   `json:"method" example:"totp"`
+	Secret      string   `json:"secret,omitempty"`
+	QRCodeURL   string   `json:"qr_code_url,omitempty"`
 	BackupCodes []string `json:"backup_codes"`
 }
 
@@ -5,15 +5,33 @@
 
 package models
 
+import "encoding/json"
+
+// BeginSetupRequest selects the MFA method (TOTP when omitted)
+type BeginSetupRequest struct {
+	Method string `json:"method" validate:"omitempty,oneof=totp email"`
+}
+
 // FinishSetupRequest represents the request to enable MFA
 type FinishSetupRequest struct {
 	Code string `json:"code" validate:"required,len=6,numeric"`
 }
 
-// VerifyMFARequest represents the request to verify MFA code during login
+// VerifyMFARequest represents the request to verify MFA code or security key assertion during login
 type VerifyMFARequest struct {
+	TempToken  string          `json:"temp_token" validate:"required"`
+	Code       string          `json:"code" validate:"required_without=Credential,omitempty,min=6,max=8"` // 6 for TOTP and email codes, 8 for backup codes
+	Credential json.RawMessage `json:"credential,omitempty" swaggertype:"object"`                         // WebAuthn assertion from a security key or passkey
+}
+
+// SendEmailCodeRequest represents the request to email a login code to a user with the email method
+type SendEmailCodeRequest struct {
+	TempToken string `json:"temp_token" validate:"required"`
+}
+
+// BeginSecurityKeyRequest represents the request for a WebAuthn challenge during login
+type BeginSecurityKeyRequest struct {
 	TempToken string `json:"temp_token" validate:"required"`
-	Code      string `json:"code" validate:"required,min=6,max=8"` // 6 for TOTP, 8 for backup codes
 }
 
 // DisableMFARequest represents the request to disable MFA
@@ -34,16 +34,20 @@ func NewMFARepository(pool *pgxpool.Pool) *MFARepository {
 // Create creates a new MFA configuration
 func (r *MFARepository) Create(ctx context.Context, mfa *models.UserMFA) error {
 	query := `
-		INSERT INTO user_mfa (user_id, enabled, secret, backup_codes, backup_codes_used, created_at, enabled_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO user_mfa (user_id, enabled, method, secret, backup_codes, backup_codes_used,
+		                      email_code_hash, email_code_expires_at, created_at, enabled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
 		mfa.UserID,
 		mfa.Enabled,
+		mfa.Method,
 		mfa.Secret,
 		mfa.BackupCodes,
 		mfa.BackupCodesUsed,
+		mfa.EmailCodeHash,
+		mfa.EmailCodeExpiresAt,
 		mfa.CreatedAt,
 		mfa.EnabledAt,
 	)
@@ -58,7 +62,8 @@ func (r *MFARepository) Create(ctx context.Context, mfa *models.UserMFA) error {
 // GetByUserID retrieves MFA configuration by user ID
 func (r *MFARepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
 	query := `
-		SELECT user_id, enabled, secret, backup_codes, backup_codes_used, created_at, enabled_at
+		SELECT user_id, enabled, method, secret, backup_codes, backup_codes_used,
+		       email_code_hash, email_code_expires_at, created_at, enabled_at
 		FROM user_mfa
 		WHERE user_id = $1
 	`
@@ -67,9 +72,12 @@ func (r *MFARepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*mod
 	err := r.pool.QueryRow(ctx, query, userID).Scan(
 		&mfa.UserID,
 		&mfa.Enabled,
+		&mfa.Method,
 		&mfa.Secret,
 		&mfa.BackupCodes,
 		&mfa.BackupCodesUsed,
+		&mfa.EmailCodeHash,
+		&mfa.EmailCodeExpiresAt,
 		&mfa.CreatedAt,
 		&mfa.EnabledAt,
 	)
@@ -88,15 +96,19 @@ func (r *MFARepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*mod
 func (r *MFARepository) Update(ctx context.Context, mfa *models.UserMFA) error {
 	query := `
 		UPDATE user_mfa
-		SET enabled = $1, secret = $2, backup_codes = $3, backup_codes_used = $4, enabled_at = $5
-		WHERE user_id = $6
+		SET enabled = $1, method = $2, secret = $3, backup_codes = $4, backup_codes_used = $5,
+		    email_code_hash = $6, email_code_expires_at = $7, enabled_at = $8
+		WHERE user_id = $9
 	`
 
 	result, err := r.pool.Exec(ctx, query,
 		mfa.Enabled,
+		mfa.Method,
 		mfa.Secret,
 		mfa.BackupCodes,
 		mfa.BackupCodesUsed,
+		mfa.EmailCodeHash,
+		mfa.EmailCodeExpiresAt,
 		mfa.EnabledAt,
 		mfa.UserID,
 	)
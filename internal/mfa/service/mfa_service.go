@@ -6,13 +6,20 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/subtle"
+	_ "embed"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math/big"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,12 +28,20 @@ import (
 	qrcode "github.com/skip2/go-qrcode"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/whento/pkg/email"
+	"github.com/whento/pkg/i18n"
 	authRepo "github.com/whento/whento/internal/auth/repository"
 	"github.com/whento/whento/internal/config"
 	"github.com/whento/whento/internal/mfa/models"
 	"github.com/whento/whento/internal/mfa/repository"
 )
 
+//go:embed templates/email_code.html
+var emailCodeTemplate string
+
+//go:embed templates/locales/mfa_email_code.json
+var emailCodeTranslationsJSON string
+
 var (
 	ErrMFANotFound        = errors.New("MFA not configured")
 	ErrMFAAlreadyEnabled  = errors.New("MFA is already enabled")
@@ -34,39 +49,77 @@ var (
 	ErrInvalidCode        = errors.New("invalid verification code")
 	ErrUserNotFound       = errors.New("user not found")
 	ErrAllBackupCodesUsed = errors.New("all backup codes have been used")
+	ErrEmailUnavailable   = errors.New("email is not configured on this server")
+	ErrNotEmailMethod     = errors.New("MFA method is not email")
+)
+
+const (
+	emailCodeExpiry = 10 * time.Minute
+	emailCodeDigits = 6
 )
 
 // MFAService handles MFA business logic
 type MFAService struct {
-	repo       *repository.MFARepository
-	userRepo   *authRepo.UserRepository
-	issuer     string
-	period     uint
-	digits     otp.Digits
-	bcryptCost int
-	logger     *slog.Logger
+	repo                  *repository.MFARepository
+	userRepo              *authRepo.UserRepository
+	emailService          *email.Service
+	issuer                string
+	period                uint
+	digits                otp.Digits
+	bcryptCost            int
+	emailCodeTemplate     *template.Template
+	emailCodeTranslations i18n.Translations
+	brandingData          map[string]string
+	logger                *slog.Logger
 }
 
 // NewMFAService creates a new MFA service
 func NewMFAService(
 	repo *repository.MFARepository,
 	userRepo *authRepo.UserRepository,
+	emailService *email.Service,
 	cfg *config.Config,
 	logger *slog.Logger,
 ) *MFAService {
+	source, err := email.LoadTemplate(emailCodeTemplate, cfg.EmailTemplateDir, "email_code")
+	if err != nil {
+		logger.Error("Failed to load MFA email code template override", "error", err)
+	}
+	tmpl, err := template.New("email_code").Parse(source)
+	if err != nil {
+		logger.Error("Failed to parse MFA email code template", "error", err)
+	}
+
+	translations, err := i18n.Load(emailCodeTranslationsJSON, cfg.TranslationsDir, "mfa_email_code")
+	if err != nil {
+		logger.Error("Failed to load MFA email code translations", "error", err)
+	}
+	translations = translations.WithVar("ProductName", cfg.Branding.ProductName)
+
 	return &MFAService{
-		repo:       repo,
-		userRepo:   userRepo,
-		issuer:     cfg.TOTPIssuer,
-		period:     cfg.TOTPPeriod,
-		digits:     otp.Digits(cfg.TOTPDigits),
-		bcryptCost: cfg.BcryptCost,
-		logger:     logger,
+		repo:                  repo,
+		userRepo:              userRepo,
+		emailService:          emailService,
+		issuer:                cfg.TOTPIssuer,
+		period:                cfg.TOTPPeriod,
+		digits:                otp.Digits(cfg.TOTPDigits),
+		bcryptCost:            cfg.BcryptCost,
+		emailCodeTemplate:     tmpl,
+		emailCodeTranslations: translations,
+		brandingData:          cfg.Branding.TemplateData(),
+		logger:                logger,
 	}
 }
 
-// BeginSetup generates TOTP secret and QR code
-func (s *MFAService) BeginSetup(ctx context.Context, userID uuid.UUID) (*models.TOTPSetupResponse, error) {
+// BeginSetup starts MFA setup with a method: TOTP (secret and QR code) or email (a code is sent to the user)
+func (s *MFAService) BeginSetup(ctx context.Context, userID uuid.UUID, method string) (*models.TOTPSetupResponse, error) {
+	if method == "" {
+		method = models.MethodTOTP
+	}
+	if method == models.MethodEmail && !s.emailService.IsConfigured() {
+		return nil, ErrEmailUnavailable
+	}
+
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -83,41 +136,10 @@ func (s *MFAService) BeginSetup(ctx context.Context, userID uuid.UUID) (*models.
 		return nil, ErrMFAAlreadyEnabled
 	}
 
-	// Generate TOTP secret
-	key, err := totp.Generate(totp.GenerateOpts{
-		Issuer:      s.issuer,
-		AccountName: user.Email,
-		Period:      s.period,
-		Digits:      s.digits,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate TOTP key: %w", err)
-	}
-
-	// Generate QR code
-	// Encode QR code as PNG
-	png, err := qrcode.Encode(key.URL(), qrcode.Medium, 256)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode QR code: %w", err)
-	}
-
-	// Convert to base64 data URL
-	qrCodeDataURL := fmt.Sprintf("data:image/png;base64,%s", base64.StdEncoding.EncodeToString(png))
-
-	// Generate backup codes
-	backupCodes, err := s.generateBackupCodes(10)
+	// Generate backup codes, hashed before storing
+	backupCodes, hashedBackupCodes, err := s.newBackupCodes()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate backup codes: %w", err)
-	}
-
-	// Hash backup codes before storing
-	hashedBackupCodes := make([]string, len(backupCodes))
-	for i, code := range backupCodes {
-		hash, err := bcrypt.GenerateFromPassword([]byte(code), s.bcryptCost)
-		if err != nil {
-			return nil, fmt.Errorf("failed to hash backup code: %w", err)
-		}
-		hashedBackupCodes[i] = string(hash)
+		return nil, err
 	}
 
 	// Create or update MFA configuration (not enabled yet)
@@ -125,12 +147,46 @@ func (s *MFAService) BeginSetup(ctx context.Context, userID uuid.UUID) (*models.
 	userMFA := &models.UserMFA{
 		UserID:          userID,
 		Enabled:         false,
-		Secret:          key.Secret(),
+		Method:          method,
 		BackupCodes:     hashedBackupCodes,
 		BackupCodesUsed: []string{},
 		CreatedAt:       now,
 		EnabledAt:       nil,
 	}
+	response := &models.TOTPSetupResponse{
+		Method:      method,
+		BackupCodes: backupCodes,
+	}
+
+	var emailCode string
+	if method == models.MethodTOTP {
+		// Generate TOTP secret
+		key, err := totp.Generate(totp.GenerateOpts{
+			Issuer:      s.issuer,
+			AccountName: user.Email,
+			Period:      s.period,
+			Digits:      s.digits,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate TOTP key: %w", err)
+		}
+
+		// Encode QR code as PNG, converted to a base64 data URL
+		png, err := qrcode.Encode(key.URL(), qrcode.Medium, 256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode QR code: %w", err)
+		}
+
+		userMFA.Secret = key.Secret()
+		response.Secret = key.Secret()
+		response.QRCodeURL = fmt.Sprintf("data:image/png;base64,%s", base64.StdEncoding.EncodeToString(png))
+	} else {
+		// The first code confirms that the user receives the emails
+		emailCode, err = setEmailCode(userMFA, now)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if mfa == nil {
 		// Create new MFA config
@@ -144,11 +200,13 @@ func (s *MFAService) BeginSetup(ctx context.Context, userID uuid.UUID) (*models.
 		}
 	}
 
-	return &models.TOTPSetupResponse{
-		Secret:      key.Secret(),
-		QRCodeURL:   qrCodeDataURL,
-		BackupCodes: backupCodes,
-	}, nil
+	if emailCode != "" {
+		if err := s.sendEmailCode(user.Email, user.DisplayName, user.Locale, emailCode); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
 }
 
 // FinishSetup verifies TOTP code and enables MFA
@@ -162,16 +220,17 @@ func (s *MFAService) FinishSetup(ctx context.Context, userID uuid.UUID, code str
 		return fmt.Errorf("failed to get MFA config: %w", err)
 	}
 
-	// Verify TOTP code
-	valid := totp.Validate(code, mfa.Secret)
-	if !valid {
+	// Verify the code of the method
+	now := time.Now()
+	if !s.validCode(mfa, code, now) {
 		return ErrInvalidCode
 	}
 
 	// Enable MFA
-	now := time.Now()
 	mfa.Enabled = true
 	mfa.EnabledAt = &now
+	mfa.EmailCodeHash = nil
+	mfa.EmailCodeExpiresAt = nil
 
 	if err := s.repo.Update(ctx, mfa); err != nil {
 		return fmt.Errorf("failed to enable MFA: %w", err)
@@ -195,10 +254,20 @@ func (s *MFAService) VerifyCode(ctx context.Context, userID uuid.UUID, code stri
 		return false, ErrMFANotEnabled
 	}
 
-	// Try TOTP code first (6 digits)
+	// Try TOTP or email code first (6 digits)
 	if len(code) == 6 {
-		valid := totp.Validate(code, mfa.Secret)
-		return valid, nil
+		if !s.validCode(mfa, code, time.Now()) {
+			return false, nil
+		}
+		if mfa.Method == models.MethodEmail {
+			// Email codes are single use
+			mfa.EmailCodeHash = nil
+			mfa.EmailCodeExpiresAt = nil
+			if err := s.repo.Update(ctx, mfa); err != nil {
+				return false, fmt.Errorf("failed to clear email code: %w", err)
+			}
+		}
+		return true, nil
 	}
 
 	// Try backup code (8 characters)
@@ -283,19 +352,9 @@ func (s *MFAService) RegenerateBackupCodes(ctx context.Context, userID uuid.UUID
 	}
 
 	// Generate new backup codes
-	backupCodes, err := s.generateBackupCodes(10)
+	backupCodes, hashedBackupCodes, err := s.newBackupCodes()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate backup codes: %w", err)
-	}
-
-	// Hash backup codes
-	hashedBackupCodes := make([]string, len(backupCodes))
-	for i, code := range backupCodes {
-		hash, err := bcrypt.GenerateFromPassword([]byte(code), s.bcryptCost)
-		if err != nil {
-			return nil, fmt.Errorf("failed to hash backup code: %w", err)
-		}
-		hashedBackupCodes[i] = string(hash)
+		return nil, err
 	}
 
 	// Update MFA configuration
@@ -319,7 +378,211 @@ func (s *MFAService) GetStatus(ctx context.Context, userID uuid.UUID) (*models.M
 		return nil, fmt.Errorf("failed to get MFA status: %w", err)
 	}
 
-	return &models.MFAStatusResponse{Enabled: mfa.Enabled}, nil
+	status := &models.MFAStatusResponse{Enabled: mfa.Enabled}
+	if mfa.Enabled {
+		status.Method = mfa.Method
+	}
+	return status, nil
+}
+
+// EnableSecurityKey enables MFA with the security key method when a user registers their first security key.
+// It returns the backup codes generated for the user, or nil if MFA was already enabled.
+func (s *MFAService) EnableSecurityKey(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	mfa, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrMFANotFound) {
+		return nil, fmt.Errorf("failed to check MFA status: %w", err)
+	}
+
+	// Security keys are accepted alongside the current method
+	if mfa != nil && mfa.Enabled {
+		return nil, nil
+	}
+
+	backupCodes, hashedBackupCodes, err := s.newBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	userMFA := &models.UserMFA{
+		UserID:          userID,
+		Enabled:         true,
+		Method:          models.MethodWebAuthn,
+		BackupCodes:     hashedBackupCodes,
+		BackupCodesUsed: []string{},
+		CreatedAt:       now,
+		EnabledAt:       &now,
+	}
+
+	if mfa == nil {
+		err = s.repo.Create(ctx, userMFA)
+	} else {
+		err = s.repo.Update(ctx, userMFA)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable MFA: %w", err)
+	}
+
+	return backupCodes, nil
+}
+
+// DisableSecurityKey disables MFA when a user removes their last security key and had no other method
+func (s *MFAService) DisableSecurityKey(ctx context.Context, userID uuid.UUID) error {
+	mfa, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFANotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get MFA config: %w", err)
+	}
+
+	if mfa.Method != models.MethodWebAuthn {
+		return nil
+	}
+
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to disable MFA: %w", err)
+	}
+
+	return nil
+}
+
+// SendLoginCode sends a new email code to a user with the email MFA method
+func (s *MFAService) SendLoginCode(ctx context.Context, userID uuid.UUID) error {
+	if !s.emailService.IsConfigured() {
+		return ErrEmailUnavailable
+	}
+
+	mfa, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFANotFound) {
+			return ErrMFANotEnabled
+		}
+		return fmt.Errorf("failed to get MFA config: %w", err)
+	}
+	if !mfa.Enabled {
+		return ErrMFANotEnabled
+	}
+	if mfa.Method != models.MethodEmail {
+		return ErrNotEmailMethod
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	// A new code replaces the previous one
+	code, err := setEmailCode(mfa, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, mfa); err != nil {
+		return fmt.Errorf("failed to store email code: %w", err)
+	}
+
+	return s.sendEmailCode(user.Email, user.DisplayName, user.Locale, code)
+}
+
+// validCode checks a 6-digit code against the method of the MFA configuration
+func (s *MFAService) validCode(mfa *models.UserMFA, code string, now time.Time) bool {
+	switch mfa.Method {
+	case models.MethodWebAuthn:
+		// No secret: security keys are verified by the passkey service
+		return false
+	case models.MethodEmail:
+	default:
+		return totp.Validate(code, mfa.Secret)
+	}
+	if mfa.EmailCodeHash == nil || mfa.EmailCodeExpiresAt == nil || now.After(*mfa.EmailCodeExpiresAt) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(authRepo.HashToken(code)), []byte(*mfa.EmailCodeHash)) == 1
+}
+
+// setEmailCode generates an email code and stores its hash and expiry on the MFA configuration
+func setEmailCode(mfa *models.UserMFA, now time.Time) (string, error) {
+	code, err := generateNumericCode(emailCodeDigits)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate email code: %w", err)
+	}
+
+	hash := authRepo.HashToken(code)
+	expiresAt := now.Add(emailCodeExpiry)
+	mfa.EmailCodeHash = &hash
+	mfa.EmailCodeExpiresAt = &expiresAt
+
+	return code, nil
+}
+
+// generateNumericCode generates a random code of the given number of digits
+func generateNumericCode(digits int) (string, error) {
+	code := make([]byte, digits)
+	for i := range code {
+		num, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + num.Int64())
+	}
+	return string(code), nil
+}
+
+// sendEmailCode sends a verification code email
+func (s *MFAService) sendEmailCode(to, displayName, locale, code string) error {
+	if s.emailCodeTemplate == nil {
+		return errors.New("MFA email code template is not available")
+	}
+
+	trans := s.emailCodeTranslations.Lookup(locale)
+
+	// Prepare template data
+	data := map[string]string{
+		"Subject":        trans["subject"],
+		"Greeting":       strings.ReplaceAll(trans["greeting"], "{{.DisplayName}}", displayName),
+		"Intro":          trans["intro"],
+		"Code":           code,
+		"ExpiryNotice":   strings.ReplaceAll(trans["expiry_notice"], "{{.ExpiryMinutes}}", strconv.Itoa(int(emailCodeExpiry.Minutes()))),
+		"SecurityNotice": trans["security_notice"],
+		"Signature":      trans["signature"],
+	}
+	maps.Copy(data, s.brandingData)
+
+	var htmlBody bytes.Buffer
+	if err := s.emailCodeTemplate.Execute(&htmlBody, data); err != nil {
+		return fmt.Errorf("failed to execute MFA email code template: %w", err)
+	}
+
+	if err := s.emailService.Send(email.Email{
+		To:      []string{to},
+		Subject: trans["subject"],
+		Body:    htmlBody.String(),
+		HTML:    true,
+	}); err != nil {
+		s.logger.Error("Failed to send MFA email code", "error", err, "to", to)
+		return fmt.Errorf("failed to send email code: %w", err)
+	}
+
+	return nil
+}
+
+// newBackupCodes generates backup codes along with their bcrypt hashes
+func (s *MFAService) newBackupCodes() ([]string, []string, error) {
+	backupCodes, err := s.generateBackupCodes(10)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate backup codes: %w", err)
+	}
+
+	hashedBackupCodes := make([]string, len(backupCodes))
+	for i, code := range backupCodes {
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), s.bcryptCost)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hash backup code: %w", err)
+		}
+		hashedBackupCodes[i] = string(hash)
+	}
+
+	return backupCodes, hashedBackupCodes, nil
 }
 
 // generateBackupCodes generates random alphanumeric backup codes
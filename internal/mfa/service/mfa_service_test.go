@@ -0,0 +1,70 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+
+	"github.com/whento/whento/internal/mfa/models"
+)
+
+func TestEmailCodeValidation(t *testing.T) {
+	s := &MFAService{}
+	now := time.Now()
+	mfa := &models.UserMFA{Method: models.MethodEmail}
+
+	if s.validCode(mfa, "123456", now) {
+		t.Fatal("code accepted without a pending email code")
+	}
+
+	code, err := setEmailCode(mfa, now)
+	if err != nil {
+		t.Fatalf("setEmailCode: %v", err)
+	}
+	if len(code) != emailCodeDigits {
+		t.Fatalf("code length = %d, want %d", len(code), emailCodeDigits)
+	}
+	if *mfa.EmailCodeHash == code {
+		t.Fatal("email code stored in clear")
+	}
+
+	if !s.validCode(mfa, code, now.Add(time.Minute)) {
+		t.Error("valid code rejected")
+	}
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	if s.validCode(mfa, wrong, now.Add(time.Minute)) {
+		t.Error("wrong code accepted")
+	}
+	if s.validCode(mfa, code, now.Add(emailCodeExpiry+time.Second)) {
+		t.Error("expired code accepted")
+	}
+
+	// TOTP users can't verify with an email code
+	mfa.Method = models.MethodTOTP
+	if s.validCode(mfa, code, now) {
+		t.Error("email code accepted for the TOTP method")
+	}
+}
+
+func TestSecurityKeyMethodRejectsCodes(t *testing.T) {
+	s := &MFAService{}
+	mfa := &models.UserMFA{Method: models.MethodWebAuthn}
+
+	// Without a secret, a TOTP check would accept the code derived from an empty key
+	code, err := totp.GenerateCode("", time.Now())
+	if err != nil {
+		t.Fatalf("GenerateCode: %v", err)
+	}
+	if s.validCode(mfa, code, time.Now()) {
+		t.Error("code accepted for the security key method")
+	}
+}
@@ -0,0 +1,382 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+// Package migrate applies the embedded SQL migrations.
+//
+// It uses the same "schema_migrations" table as golang-migrate (a single row holding the
+// current version and a dirty flag), so databases migrated with the migrate CLI can be
+// managed with "whento migrate" and vice versa.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NilVersion is the version of a database where no migration has been applied
+const NilVersion = -1
+
+// lockID is the advisory lock key preventing concurrent migrations
+const lockID = 72_656_570
+
+var (
+	ErrDirty          = errors.New("database is dirty")
+	ErrUnknownVersion = errors.New("no migration found for version")
+	ErrNoChange       = errors.New("no change")
+)
+
+// Migration is a pair of up/down SQL scripts for a version
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Direction of a migration step
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+// Step is a migration to run, with the version recorded once it succeeds
+type Step struct {
+	Migration     Migration
+	Direction     Direction
+	TargetVersion int
+}
+
+// SQL returns the script run by the step
+func (s Step) SQL() string {
+	if s.Direction == DirectionUp {
+		return s.Migration.Up
+	}
+	return s.Migration.Down
+}
+
+// Status describes the migration state of a database
+type Status struct {
+	Version int  // Current version (NilVersion if none)
+	Dirty   bool // A migration failed at Version and must be fixed manually
+	Latest  int  // Latest version embedded in this build
+	Pending []Migration
+}
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Load reads and sorts the migrations from the given directories
+// Files must be named "<version>_<name>.up.sql" and "<version>_<name>.down.sql"
+func Load(sources ...fs.FS) ([]Migration, error) {
+	byVersion := make(map[int]*Migration)
+
+	for _, source := range sources {
+		entries, err := fs.ReadDir(source, ".")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migrations: %w", err)
+		}
+
+		for _, entry := range entries {
+			match := fileNamePattern.FindStringSubmatch(entry.Name())
+			if entry.IsDir() || match == nil {
+				continue
+			}
+
+			version, err := strconv.Atoi(match[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+			}
+
+			content, err := fs.ReadFile(source, entry.Name())
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+			}
+
+			migration, ok := byVersion[version]
+			if !ok {
+				migration = &Migration{Version: version, Name: match[2]}
+				byVersion[version] = migration
+			} else if migration.Name != match[2] {
+				return nil, fmt.Errorf("duplicate migration version %d (%s, %s)", version, migration.Name, match[2])
+			}
+
+			if match[3] == "up" {
+				migration.Up = string(content)
+			} else {
+				migration.Down = string(content)
+			}
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// PlanUp returns the steps applying up to n pending migrations (n <= 0 applies all of them)
+func PlanUp(migrations []Migration, current, n int) []Step {
+	var steps []Step
+	for _, migration := range migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if n > 0 && len(steps) == n {
+			break
+		}
+		steps = append(steps, Step{Migration: migration, Direction: DirectionUp, TargetVersion: migration.Version})
+	}
+	return steps
+}
+
+// PlanDown returns the steps rolling back n applied migrations (n <= 0 rolls back all of them)
+func PlanDown(migrations []Migration, current, n int) ([]Step, error) {
+	if current == NilVersion {
+		return nil, nil
+	}
+
+	index := -1
+	for i, migration := range migrations {
+		if migration.Version == current {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, fmt.Errorf("%w %d", ErrUnknownVersion, current)
+	}
+
+	var steps []Step
+	for i := index; i >= 0; i-- {
+		if n > 0 && len(steps) == n {
+			break
+		}
+		target := NilVersion
+		if i > 0 {
+			target = migrations[i-1].Version
+		}
+		steps = append(steps, Step{Migration: migrations[i], Direction: DirectionDown, TargetVersion: target})
+	}
+	return steps, nil
+}
+
+// Migrator applies migrations to a database
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+// New creates a new migrator for the given migrations
+func New(pool *pgxpool.Pool, migrations []Migration) *Migrator {
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+	}
+}
+
+// Status returns the current version and pending migrations
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	version, dirty, err := m.version(ctx, m.pool)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Version: version,
+		Dirty:   dirty,
+		Latest:  NilVersion,
+	}
+	if len(m.migrations) > 0 {
+		status.Latest = m.migrations[len(m.migrations)-1].Version
+	}
+	for _, step := range PlanUp(m.migrations, version, 0) {
+		status.Pending = append(status.Pending, step.Migration)
+	}
+
+	return status, nil
+}
+
+// Plan returns the steps that Up (or Down) with n steps would run, without applying them
+func (m *Migrator) Plan(ctx context.Context, direction Direction, n int) ([]Step, error) {
+	version, dirty, err := m.version(ctx, m.pool)
+	if err != nil {
+		return nil, err
+	}
+	return m.plan(direction, version, dirty, n)
+}
+
+// Force sets the version without running any migration and clears the dirty flag
+// Used to recover after a failed migration has been fixed manually
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version < NilVersion {
+		return fmt.Errorf("invalid version %d", version)
+	}
+
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer m.unlock(conn)
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return setVersion(ctx, conn, version, false)
+}
+
+func (m *Migrator) plan(direction Direction, version int, dirty bool, n int) ([]Step, error) {
+	if dirty {
+		return nil, fmt.Errorf("%w at version %d, fix it manually then run 'whento migrate force %d'", ErrDirty, version, version)
+	}
+
+	var steps []Step
+	if direction == DirectionUp {
+		steps = PlanUp(m.migrations, version, n)
+	} else {
+		var err error
+		if steps, err = PlanDown(m.migrations, version, n); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(steps) == 0 {
+		return nil, ErrNoChange
+	}
+	return steps, nil
+}
+
+// Run applies (up) or rolls back (down) n migrations, n <= 0 meaning all of them
+// onStep, if set, is called before each migration runs
+func (m *Migrator) Run(ctx context.Context, direction Direction, n int, onStep func(Step)) error {
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer m.unlock(conn)
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+
+	version, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	steps, err := m.plan(direction, version, dirty, n)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		if onStep != nil {
+			onStep(step)
+		}
+
+		// Same protocol as golang-migrate: mark the target version dirty while the script
+		// runs, so a failure leaves the database flagged for manual recovery
+		if err := setVersion(ctx, conn, step.TargetVersion, true); err != nil {
+			return err
+		}
+		if _, err := conn.Exec(ctx, step.SQL()); err != nil {
+			return fmt.Errorf("migration %d_%s (%s) failed: %w", step.Migration.Version, step.Migration.Name, step.Direction, err)
+		}
+		if err := setVersion(ctx, conn, step.TargetVersion, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// querier is implemented by both the pool and a single connection
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// version returns the current version, or NilVersion when the table is missing or empty
+func (m *Migrator) version(ctx context.Context, q querier) (int, bool, error) {
+	var exists bool
+	if err := q.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return 0, false, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+	if !exists {
+		return NilVersion, false, nil
+	}
+
+	var version int64
+	var dirty bool
+	err := q.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	return int(version), dirty, nil
+}
+
+// lock acquires a connection holding the migration advisory lock
+func (m *Migrator) lock(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (m *Migrator) unlock(conn *pgxpool.Conn) {
+	_, _ = conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID)
+	conn.Release()
+}
+
+func ensureTable(ctx context.Context, conn *pgxpool.Conn) error {
+	_, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return nil
+}
+
+// setVersion replaces the version row (removing it for NilVersion)
+func setVersion(ctx context.Context, conn *pgxpool.Conn, version int, dirty bool) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `TRUNCATE schema_migrations`); err != nil {
+		return fmt.Errorf("failed to update migration version: %w", err)
+	}
+	if version != NilVersion {
+		if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, version, dirty); err != nil {
+			return fmt.Errorf("failed to update migration version: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,171 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package migrate
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/whento/whento/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	common := fstest.MapFS{
+		"001_init.up.sql":    {Data: []byte("CREATE TABLE a ();")},
+		"001_init.down.sql":  {Data: []byte("DROP TABLE a;")},
+		"003_third.up.sql":   {Data: []byte("CREATE TABLE c ();")},
+		"003_third.down.sql": {Data: []byte("DROP TABLE c;")},
+		"README.md":          {Data: []byte("not a migration")},
+	}
+	edition := fstest.MapFS{
+		"002_edition.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+		"002_edition.down.sql": {Data: []byte("DROP TABLE b;")},
+	}
+
+	loaded, err := Load(common, edition)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(loaded) != 3 {
+		t.Fatalf("Load() returned %d migrations, want 3", len(loaded))
+	}
+	for i, want := range []struct {
+		version int
+		name    string
+	}{{1, "init"}, {2, "edition"}, {3, "third"}} {
+		if loaded[i].Version != want.version || loaded[i].Name != want.name {
+			t.Errorf("migration %d = %d_%s, want %d_%s", i, loaded[i].Version, loaded[i].Name, want.version, want.name)
+		}
+	}
+	if loaded[1].Up != "CREATE TABLE b ();" || loaded[1].Down != "DROP TABLE b;" {
+		t.Errorf("migration 2 scripts = %q / %q", loaded[1].Up, loaded[1].Down)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []fs.FS
+	}{
+		{
+			name: "duplicate version across directories",
+			sources: []fs.FS{
+				fstest.MapFS{"005_ecommerce.up.sql": {Data: []byte("SELECT 1;")}},
+				fstest.MapFS{"005_licenses.up.sql": {Data: []byte("SELECT 1;")}},
+			},
+		},
+		{
+			name: "missing up script",
+			sources: []fs.FS{
+				fstest.MapFS{"001_init.down.sql": {Data: []byte("SELECT 1;")}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.sources...); err == nil {
+				t.Error("Load() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	loaded, err := Load(migrations.Sources()...)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded) == 0 || loaded[0].Version != 1 {
+		t.Fatalf("embedded migrations should start at version 1, got %d migrations", len(loaded))
+	}
+	for _, migration := range loaded {
+		if migration.Down == "" {
+			t.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+		}
+	}
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "init", Up: "up1", Down: "down1"},
+		{Version: 2, Name: "second", Up: "up2", Down: "down2"},
+		{Version: 4, Name: "fourth", Up: "up4", Down: "down4"},
+	}
+}
+
+func TestPlanUp(t *testing.T) {
+	tests := []struct {
+		name    string
+		current int
+		n       int
+		want    []int
+	}{
+		{"fresh database", NilVersion, 0, []int{1, 2, 4}},
+		{"partially migrated", 1, 0, []int{2, 4}},
+		{"limited steps", NilVersion, 2, []int{1, 2}},
+		{"up to date", 4, 0, nil},
+		{"database newer than binary", 5, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := PlanUp(testMigrations(), tt.current, tt.n)
+			if len(steps) != len(tt.want) {
+				t.Fatalf("PlanUp() returned %d steps, want %d", len(steps), len(tt.want))
+			}
+			for i, step := range steps {
+				if step.Migration.Version != tt.want[i] || step.TargetVersion != tt.want[i] {
+					t.Errorf("step %d = version %d (target %d), want %d", i, step.Migration.Version, step.TargetVersion, tt.want[i])
+				}
+				if step.SQL() != step.Migration.Up {
+					t.Errorf("step %d SQL() = %q, want the up script", i, step.SQL())
+				}
+			}
+		})
+	}
+}
+
+func TestPlanDown(t *testing.T) {
+	steps, err := PlanDown(testMigrations(), 4, 2)
+	if err != nil {
+		t.Fatalf("PlanDown() error = %v", err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("PlanDown() returned %d steps, want 2", len(steps))
+	}
+	if steps[0].Migration.Version != 4 || steps[0].TargetVersion != 2 {
+		t.Errorf("step 0 = version %d (target %d), want 4 (target 2)", steps[0].Migration.Version, steps[0].TargetVersion)
+	}
+	if steps[1].Migration.Version != 2 || steps[1].TargetVersion != 1 {
+		t.Errorf("step 1 = version %d (target %d), want 2 (target 1)", steps[1].Migration.Version, steps[1].TargetVersion)
+	}
+	if steps[0].SQL() != "down4" {
+		t.Errorf("step 0 SQL() = %q, want down4", steps[0].SQL())
+	}
+
+	// Rolling back everything ends with no version
+	steps, err = PlanDown(testMigrations(), 2, 0)
+	if err != nil {
+		t.Fatalf("PlanDown() error = %v", err)
+	}
+	if len(steps) != 2 || steps[1].TargetVersion != NilVersion {
+		t.Errorf("PlanDown(all) = %+v, want 2 steps ending at NilVersion", steps)
+	}
+
+	// Nothing to roll back on a fresh database
+	steps, err = PlanDown(testMigrations(), NilVersion, 1)
+	if err != nil || len(steps) != 0 {
+		t.Errorf("PlanDown(NilVersion) = %v, %v, want no steps", steps, err)
+	}
+
+	// Unknown current version
+	if _, err := PlanDown(testMigrations(), 3, 1); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("PlanDown(unknown) error = %v, want ErrUnknownVersion", err)
+	}
+}
@@ -7,8 +7,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -16,23 +18,31 @@ import (
 	"github.com/whento/pkg/httputil"
 	"github.com/whento/pkg/middleware"
 	"github.com/whento/pkg/validator"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
 	calendarRepo "github.com/whento/whento/internal/calendar/repository"
 	"github.com/whento/whento/internal/notify/models"
+	"github.com/whento/whento/internal/notify/service"
 )
 
 // NotifyConfigHandler handles notification configuration HTTP requests
 type NotifyConfigHandler struct {
 	calendarRepo *calendarRepo.CalendarRepository
+	changeRepo   *calendarRepo.ChangeRepository
+	notifySvc    *service.NotifyService
 	logger       *slog.Logger
 }
 
 // NewNotifyConfigHandler creates a new notification config handler
 func NewNotifyConfigHandler(
 	calendarRepo *calendarRepo.CalendarRepository,
+	changeRepo *calendarRepo.ChangeRepository,
+	notifySvc *service.NotifyService,
 	logger *slog.Logger,
 ) *NotifyConfigHandler {
 	return &NotifyConfigHandler{
 		calendarRepo: calendarRepo,
+		changeRepo:   changeRepo,
+		notifySvc:    notifySvc,
 		logger:       logger,
 	}
 }
@@ -92,15 +102,27 @@ func (h *NotifyConfigHandler) GetConfig(w http.ResponseWriter, r *http.Request)
 			NotifyOwner:        true,
 			NotifyParticipants: false,
 			Channels: models.ChannelConfig{
-				Email:    models.EmailChannelConfig{Enabled: true},
-				Discord:  models.DiscordChannelConfig{Enabled: false},
-				Slack:    models.SlackChannelConfig{Enabled: false},
-				Telegram: models.TelegramChannelConfig{Enabled: false},
+				Email:      models.EmailChannelConfig{Enabled: true},
+				Discord:    models.DiscordChannelConfig{Enabled: false},
+				Slack:      models.SlackChannelConfig{Enabled: false},
+				RocketChat: models.RocketChatChannelConfig{Enabled: false},
+				Mattermost: models.MattermostChannelConfig{Enabled: false},
+				Telegram:   models.TelegramChannelConfig{Enabled: false},
+				MQTT:       models.MQTTChannelConfig{Enabled: false},
+				Webhook:    models.WebhookChannelConfig{Enabled: false},
+				Push:       models.PushChannelConfig{Enabled: false},
+				Apprise:    models.AppriseChannelConfig{Enabled: false},
 			},
 			Reminders: models.ReminderConfig{
 				Enabled:     false,
 				HoursBefore: 24,
 			},
+			Digest: models.DigestConfig{
+				Mode:    models.DigestImmediate,
+				Hour:    18,
+				Weekday: int(time.Monday),
+			},
+			Activity: models.ActivityConfig{Enabled: false},
 		}
 	}
 
@@ -149,6 +171,18 @@ func (h *NotifyConfigHandler) UpdateConfig(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Apprise URLs must name a supported service, so mistakes show up now rather than as silent failures
+	if err := h.notifySvc.ValidateAppriseURLs(req.Config.Channels.Apprise.URLs); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	// Message templates are parsed now, so syntax errors don't silently fall back to the built-in messages
+	if err := service.ValidateMessageTemplates(req.Config.Templates); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
 	// Get calendar
 	calendar, err := h.calendarRepo.GetByID(ctx, cid)
 	if err != nil {
@@ -179,7 +213,126 @@ func (h *NotifyConfigHandler) UpdateConfig(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Record the changed settings in the calendar change log
+	updated := *calendar
+	updated.NotifyConfig = &configStr
+	updated.NotifyOnThreshold = req.Config.Enabled
+	if err := h.changeRepo.Record(ctx, cid, userID, calendarModels.DiffCalendars(calendar, &updated)); err != nil {
+		h.logger.Error("Failed to record calendar changes", "calendar_id", cid, "error", err)
+	}
+
 	h.logger.Info("Notification config updated", "calendar_id", cid, "enabled", req.Config.Enabled, "notify_on_threshold", req.Config.Enabled)
 
 	httputil.JSON(w, http.StatusOK, models.NotifyConfigResponse{Config: req.Config})
 }
+
+// TestConfig sends a test notification
+//
+//	@Summary		Send a test notification
+//	@Description	Sends a synthetic "threshold reached" notification for today through every enabled channel of the saved configuration, to the owner only (owner only)
+//	@Tags			Notifications
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Calendar ID"
+//	@Success		200	{object}	models.TestNotificationResponse
+//	@Failure		400	{object}	httputil.ErrorResponse
+//	@Failure		401	{object}	httputil.ErrorResponse
+//	@Failure		403	{object}	httputil.ErrorResponse
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/api/v1/calendars/{id}/notify-config/test [post]
+func (h *NotifyConfigHandler) TestConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	calendarID := chi.URLParam(r, "id")
+	userIDStr := middleware.GetUserID(ctx)
+
+	// Parse calendar ID
+	cid, err := uuid.Parse(calendarID)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid calendar ID")
+		return
+	}
+
+	// Get calendar
+	calendar, err := h.calendarRepo.GetByID(ctx, cid)
+	if err != nil {
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+		return
+	}
+
+	// Check ownership
+	userID, _ := uuid.Parse(userIDStr)
+	if calendar.OwnerID != userID {
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't own this calendar")
+		return
+	}
+
+	results, err := h.notifySvc.SendTestNotification(ctx, calendar)
+	if err != nil {
+		if errors.Is(err, service.ErrNoChannelConfigured) {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "No notification channel is enabled for this calendar")
+			return
+		}
+		h.logger.Error("Failed to send test notification", "calendar_id", cid, "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to send test notification")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, models.TestNotificationResponse{Results: results})
+}
+
+// ListDeliveries returns the delivery status of the latest notifications
+//
+//	@Summary		List notification deliveries
+//	@Description	Returns the latest threshold and activity notifications of a calendar (up to 50, newest first) with the outcome of their last attempt: failed deliveries are retried with an increasing delay (owner only)
+//	@Tags			Notifications
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Calendar ID"
+//	@Success		200	{array}		models.DeliveryResponse
+//	@Failure		400	{object}	httputil.ErrorResponse
+//	@Failure		401	{object}	httputil.ErrorResponse
+//	@Failure		403	{object}	httputil.ErrorResponse
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/api/v1/calendars/{id}/notify-config/deliveries [get]
+func (h *NotifyConfigHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	calendarID := chi.URLParam(r, "id")
+	userIDStr := middleware.GetUserID(ctx)
+
+	// Parse calendar ID
+	cid, err := uuid.Parse(calendarID)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid calendar ID")
+		return
+	}
+
+	// Get calendar
+	calendar, err := h.calendarRepo.GetByID(ctx, cid)
+	if err != nil {
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+		return
+	}
+
+	// Check ownership
+	userID, _ := uuid.Parse(userIDStr)
+	if calendar.OwnerID != userID {
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't own this calendar")
+		return
+	}
+
+	deliveries, err := h.notifySvc.ListDeliveries(ctx, cid)
+	if err != nil {
+		h.logger.Error("Failed to list notification deliveries", "calendar_id", cid, "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to list notification deliveries")
+		return
+	}
+
+	responses := make([]*models.DeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, delivery.ToResponse())
+	}
+
+	httputil.JSON(w, http.StatusOK, responses)
+}
@@ -50,7 +50,7 @@ func NewParticipantEmailHandler(
 //	@Accept			json
 //	@Produce		json
 //	@Param			token	path		string							true	"Calendar public token"
-//	@Param			pid		path		string							true	"Participant ID"
+//	@Param			pid		path		string							true	"Participant access token"
 //	@Param			request	body		object{email=string}			true	"Email address"
 //	@Success		200		{object}	object{participant_id=string,email=string,verified=bool,message=string}
 //	@Failure		400		{object}	httputil.ErrorResponse
@@ -158,7 +158,7 @@ func (h *ParticipantEmailHandler) VerifyEmail(w http.ResponseWriter, r *http.Req
 //	@Tags			Notifications
 //	@Produce		json
 //	@Param			token	path		string	true	"Calendar public token"
-//	@Param			pid		path		string	true	"Participant ID"
+//	@Param			pid		path		string	true	"Participant access token"
 //	@Success		200		{object}	map[string]string
 //	@Failure		400		{object}	httputil.ErrorResponse
 //	@Failure		404		{object}	httputil.ErrorResponse
@@ -5,21 +5,33 @@
 
 package models
 
+import "time"
+
 // NotifyConfig represents the notification configuration for a calendar
 type NotifyConfig struct {
-	Enabled            bool           `json:"enabled"`
-	NotifyOwner        bool           `json:"notify_owner"`
-	NotifyParticipants bool           `json:"notify_participants"`
-	Channels           ChannelConfig  `json:"channels"`
-	Reminders          ReminderConfig `json:"reminders"`
+	Enabled            bool             `json:"enabled"`
+	NotifyOwner        bool             `json:"notify_owner"`
+	NotifyParticipants bool             `json:"notify_participants"`
+	Channels           ChannelConfig    `json:"channels"`
+	Reminders          ReminderConfig   `json:"reminders"`
+	IncludeComments    bool             `json:"include_comments"` // Add the comments of the date to threshold emails
+	Digest             DigestConfig     `json:"digest"`
+	Activity           ActivityConfig   `json:"activity"`
+	Templates          MessageTemplates `json:"templates"`
 }
 
 // ChannelConfig represents the configuration for notification channels
 type ChannelConfig struct {
-	Email    EmailChannelConfig    `json:"email"`
-	Discord  DiscordChannelConfig  `json:"discord"`
-	Slack    SlackChannelConfig    `json:"slack"`
-	Telegram TelegramChannelConfig `json:"telegram"`
+	Email      EmailChannelConfig      `json:"email"`
+	Discord    DiscordChannelConfig    `json:"discord"`
+	Slack      SlackChannelConfig      `json:"slack"`
+	RocketChat RocketChatChannelConfig `json:"rocketchat"`
+	Mattermost MattermostChannelConfig `json:"mattermost"`
+	Telegram   TelegramChannelConfig   `json:"telegram"`
+	MQTT       MQTTChannelConfig       `json:"mqtt"`
+	Webhook    WebhookChannelConfig    `json:"webhook"`
+	Push       PushChannelConfig       `json:"push"`
+	Apprise    AppriseChannelConfig    `json:"apprise"`
 }
 
 // EmailChannelConfig represents the configuration for email notifications
@@ -27,6 +39,12 @@ type EmailChannelConfig struct {
 	Enabled bool `json:"enabled"`
 }
 
+// PushChannelConfig represents the configuration for Web Push notifications
+// They reach the browsers the owner and participants subscribed, like email they go to participants too
+type PushChannelConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
 // DiscordChannelConfig represents the configuration for Discord notifications
 type DiscordChannelConfig struct {
 	Enabled    bool   `json:"enabled"`
@@ -39,6 +57,52 @@ type SlackChannelConfig struct {
 	WebhookURL string `json:"webhook_url,omitempty" validate:"omitempty,url"`
 }
 
+// RocketChatChannelConfig represents the configuration for Rocket.Chat notifications
+type RocketChatChannelConfig struct {
+	Enabled    bool   `json:"enabled"`
+	WebhookURL string `json:"webhook_url,omitempty" validate:"omitempty,url"` // Incoming webhook integration URL
+}
+
+// RocketChatAttachment is the card shown under a Rocket.Chat notification
+type RocketChatAttachment struct {
+	Title     string            `json:"title"`
+	TitleLink string            `json:"title_link,omitempty"`
+	Color     string            `json:"color,omitempty"`
+	Fields    []RocketChatField `json:"fields,omitempty"`
+	Timestamp time.Time         `json:"ts"`
+}
+
+// RocketChatField is a labelled value of a Rocket.Chat attachment
+type RocketChatField struct {
+	Short bool   `json:"short"` // Displayed side by side with the next short field
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// MattermostChannelConfig represents the configuration for Mattermost notifications
+type MattermostChannelConfig struct {
+	Enabled    bool   `json:"enabled"`
+	WebhookURL string `json:"webhook_url,omitempty" validate:"omitempty,url"` // Incoming webhook URL
+	Channel    string `json:"channel,omitempty" validate:"max=64"`            // Channel name overriding the webhook default (if the webhook allows it)
+}
+
+// MattermostAttachment is the card shown under a Mattermost notification
+// Unlike Rocket.Chat, Mattermost needs a plain-text fallback for push notifications and has no timestamp
+type MattermostAttachment struct {
+	Fallback  string            `json:"fallback"`
+	Color     string            `json:"color,omitempty"`
+	Title     string            `json:"title"`
+	TitleLink string            `json:"title_link,omitempty"`
+	Fields    []MattermostField `json:"fields,omitempty"`
+}
+
+// MattermostField is a labelled value of a Mattermost attachment
+type MattermostField struct {
+	Short bool   `json:"short"` // Displayed side by side with the next short field
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
 // TelegramChannelConfig represents the configuration for Telegram notifications
 type TelegramChannelConfig struct {
 	Enabled  bool   `json:"enabled"`
@@ -46,6 +110,104 @@ type TelegramChannelConfig struct {
 	ChatID   string `json:"chat_id,omitempty"`
 }
 
+// MQTTChannelConfig represents the configuration for MQTT notifications
+// Threshold transitions are published as JSON (see MQTTPayload), for IoT devices and dashboards
+type MQTTChannelConfig struct {
+	Enabled       bool          `json:"enabled"`
+	BrokerURL     string        `json:"broker_url,omitempty" validate:"omitempty,url,max=2048"` // tcp:// or ssl:// (mqtts://) URL
+	Username      string        `json:"username,omitempty" validate:"max=255"`
+	Password      string        `json:"password,omitempty" validate:"max=255"`
+	TopicTemplate string        `json:"topic_template,omitempty" validate:"max=255"` // Placeholders: {calendar_id}, {event}, {date}
+	Retain        bool          `json:"retain"`
+	TLS           MQTTTLSConfig `json:"tls"`
+}
+
+// MQTTTLSConfig represents the TLS options of an ssl:// MQTT broker
+type MQTTTLSConfig struct {
+	CACert             string `json:"ca_cert,omitempty" validate:"max=16384"` // PEM certificate of a private CA (empty = system roots)
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`                   // Accept any certificate (self-signed brokers)
+}
+
+// MQTTPayload is the JSON message published on threshold transitions
+type MQTTPayload struct {
+	Event        string    `json:"event"` // "threshold_reached", "threshold_lost", "soft_threshold_reached" or "soft_threshold_lost"
+	CalendarID   string    `json:"calendar_id"`
+	CalendarName string    `json:"calendar_name"`
+	CalendarURL  string    `json:"calendar_url"`
+	Date         string    `json:"date"` // YYYY-MM-DD
+	Count        int       `json:"count"`
+	Threshold    int       `json:"threshold"`
+	Level        string    `json:"level"` // "soft" or "hard"
+	Test         bool      `json:"test,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// WebhookChannelConfig represents the configuration for generic webhook notifications
+// Threshold transitions are POSTed as JSON (see WebhookPayload), for Home Assistant, n8n and similar tools
+type WebhookChannelConfig struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Secret  string `json:"secret,omitempty" validate:"max=255"` // Signs payloads in X-WhenTo-Signature when set
+}
+
+// WebhookPayload is the JSON body POSTed to generic webhooks on threshold transitions
+type WebhookPayload struct {
+	Event         string               `json:"event"` // "threshold_reached", "threshold_lost", "soft_threshold_reached" or "soft_threshold_lost"
+	CalendarID    string               `json:"calendar_id"`
+	CalendarName  string               `json:"calendar_name"`
+	CalendarURL   string               `json:"calendar_url"`
+	Date          string               `json:"date"` // YYYY-MM-DD
+	PreviousCount int                  `json:"previous_count"`
+	Count         int                  `json:"count"`
+	Threshold     int                  `json:"threshold"`
+	Level         string               `json:"level"` // "soft" or "hard"
+	Participants  []WebhookParticipant `json:"participants"`
+	Test          bool                 `json:"test,omitempty"`
+	Timestamp     time.Time            `json:"timestamp"`
+}
+
+// WebhookParticipant is a participant available on the date of a webhook notification
+type WebhookParticipant struct {
+	Name      string  `json:"name"`
+	StartTime *string `json:"start_time,omitempty"` // HH:MM, absent for the whole day
+	EndTime   *string `json:"end_time,omitempty"`
+	Maybe     bool    `json:"maybe"`
+}
+
+// AppriseChannelConfig represents the configuration for notifications sent to Apprise URLs
+// Each URL names a service and its credentials, e.g. ntfy://host/topic or pover://user@token,
+// and apprise://host/key reaches any service through an Apprise API server
+type AppriseChannelConfig struct {
+	Enabled bool     `json:"enabled"`
+	URLs    []string `json:"urls,omitempty" validate:"max=10,dive,required,max=2048"`
+}
+
+// DigestConfig represents the grouping of threshold emails into a daily or weekly digest
+// Other channels are still notified on each transition
+type DigestConfig struct {
+	Mode    string `json:"mode,omitempty" validate:"omitempty,oneof=immediate daily weekly"`
+	Hour    int    `json:"hour" validate:"min=0,max=23"`   // Sending hour, in the timezone of the calendar
+	Weekday int    `json:"weekday" validate:"min=0,max=6"` // Sending day of weekly digests, 0 = Sunday
+}
+
+// Enabled reports whether threshold emails are grouped into digests
+func (c DigestConfig) Enabled() bool {
+	return c.Mode == DigestDaily || c.Mode == DigestWeekly
+}
+
+// ActivityConfig represents the notifications of the owner on every availability change of the participants
+// They are sent at most once an hour per calendar, on the channels of the owner
+type ActivityConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// MessageTemplates overrides the threshold and activity messages of a calendar with Go templates ({{.Date}}...)
+// Each map goes from a locale ("en", "fr") or "default" to a template, the built-in message being used without one
+type MessageTemplates struct {
+	Message map[string]string `json:"message,omitempty" validate:"max=10,dive,keys,min=2,max=10,endkeys,max=2000"` // Emails and browser notifications
+	Text    map[string]string `json:"text,omitempty" validate:"max=10,dive,keys,min=2,max=10,endkeys,max=2000"`    // Chat channels
+}
+
 // ReminderConfig represents the configuration for reminder notifications
 type ReminderConfig struct {
 	Enabled     bool `json:"enabled"`
@@ -61,3 +223,15 @@ type UpdateNotifyConfigRequest struct {
 type NotifyConfigResponse struct {
 	Config NotifyConfig `json:"config"`
 }
+
+// ChannelTestResult is the outcome of a test notification on one channel
+type ChannelTestResult struct {
+	Channel string `json:"channel"` // "email", "discord", "slack", "rocketchat", "mattermost", "telegram", "mqtt", "webhook", "push", "apprise"
+	Sent    bool   `json:"sent"`
+	Error   string `json:"error,omitempty"`
+}
+
+// TestNotificationResponse represents the response when sending a test notification
+type TestNotificationResponse struct {
+	Results []ChannelTestResult `json:"results"`
+}
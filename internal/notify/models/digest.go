@@ -0,0 +1,45 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Digest modes of threshold emails
+const (
+	DigestImmediate = "immediate" // One email per transition (default)
+	DigestDaily     = "daily"
+	DigestWeekly    = "weekly"
+)
+
+// DigestEntry is a threshold transition waiting for the digest email of a recipient
+type DigestEntry struct {
+	ID             uuid.UUID
+	CalendarID     uuid.UUID
+	UserID         *uuid.UUID // Set for the owner
+	ParticipantID  *uuid.UUID // Set for participants
+	Email          string
+	Name           string
+	Locale         string
+	CalendarURL    string
+	Date           time.Time
+	TransitionType string
+	Level          string
+	Count          int
+	Threshold      int
+	CreatedAt      time.Time
+}
+
+// RecipientID returns the user ID of the owner or the participant ID of a participant
+func (e *DigestEntry) RecipientID() uuid.UUID {
+	if e.UserID != nil {
+		return *e.UserID
+	}
+	return *e.ParticipantID
+}
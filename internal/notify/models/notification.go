@@ -18,7 +18,22 @@ type ThresholdTransition struct {
 	PreviousCount  int
 	NewCount       int
 	Threshold      int
-	TransitionType string // "reached", "lost", "none"
+	TransitionType string    // "threshold_reached", "threshold_lost", "soft_threshold_reached", "soft_threshold_lost", "activity", "none"
+	Level          string    // Threshold level of the transition: "soft" or "hard", "none" for "activity", empty for "none"
+	Activity       *Activity // Change behind an "activity" transition, nil otherwise
+}
+
+// Actions of a participant on their availability, for activity notifications
+const (
+	ActivityAdded   = "added"
+	ActivityChanged = "changed"
+	ActivityRemoved = "removed"
+)
+
+// Activity describes a participant adding, changing or removing an availability
+type Activity struct {
+	ParticipantName string
+	Action          string // ActivityAdded, ActivityChanged or ActivityRemoved
 }
 
 // NotificationEvent represents a notification event to be sent
@@ -26,7 +41,7 @@ type NotificationEvent struct {
 	CalendarID   uuid.UUID
 	CalendarName string
 	Date         time.Time
-	EventType    string // "threshold_reached", "threshold_lost", "reminder"
+	EventType    string // "threshold_reached", "threshold_lost", "soft_threshold_reached", "soft_threshold_lost", "activity", "reminder"
 	Message      string
 	Participants []string
 	TimeSlotInfo string
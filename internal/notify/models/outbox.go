@@ -0,0 +1,126 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery statuses of the notification outbox
+const (
+	DeliveryPending = "pending" // Waiting for its next attempt
+	DeliverySent    = "sent"    // Accepted by the channel
+	DeliveryFailed  = "failed"  // All attempts failed
+)
+
+// Delivery is a notification queued in the outbox, with the outcome of its last attempt
+type Delivery struct {
+	ID            uuid.UUID
+	CalendarID    uuid.UUID
+	Channel       string // Same values as the notification log
+	EventType     string
+	Date          time.Time
+	Level         string
+	RecipientType string // "owner" or "participant"
+	RecipientID   uuid.UUID
+	Payload       OutboxPayload
+	Status        string
+	Attempts      int
+	Error         *string
+	NextAttemptAt *time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// OutboxPayload is what a queued notification needs to be sent: the rendered message and a copy of
+// the channel settings, so later changes of the configuration don't affect queued notifications
+// Exactly one channel is set
+type OutboxPayload struct {
+	Text       string                 `json:"text,omitempty"` // Message of the chat channels
+	Email      *OutboxEmail           `json:"email,omitempty"`
+	Discord    *DiscordChannelConfig  `json:"discord,omitempty"`
+	Slack      *SlackChannelConfig    `json:"slack,omitempty"`
+	RocketChat *OutboxRocketChat      `json:"rocketchat,omitempty"`
+	Mattermost *OutboxMattermost      `json:"mattermost,omitempty"`
+	Telegram   *TelegramChannelConfig `json:"telegram,omitempty"`
+	MQTT       *OutboxMQTT            `json:"mqtt,omitempty"`
+	Webhook    *OutboxWebhook         `json:"webhook,omitempty"`
+	Apprise    *OutboxApprise         `json:"apprise,omitempty"`
+}
+
+// OutboxEmail is a queued email
+type OutboxEmail struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	HTML    bool   `json:"html"`
+}
+
+// OutboxRocketChat is a queued Rocket.Chat message
+type OutboxRocketChat struct {
+	Config     RocketChatChannelConfig `json:"config"`
+	Attachment RocketChatAttachment    `json:"attachment"`
+}
+
+// OutboxMattermost is a queued Mattermost message
+type OutboxMattermost struct {
+	Config     MattermostChannelConfig `json:"config"`
+	Attachment MattermostAttachment    `json:"attachment"`
+}
+
+// OutboxMQTT is a queued MQTT message
+type OutboxMQTT struct {
+	Config  MQTTChannelConfig `json:"config"`
+	Topic   string            `json:"topic"`
+	Payload MQTTPayload       `json:"payload"`
+}
+
+// OutboxWebhook is a queued generic webhook call
+type OutboxWebhook struct {
+	Config  WebhookChannelConfig `json:"config"`
+	Payload WebhookPayload       `json:"payload"`
+}
+
+// OutboxApprise is a queued Apprise notification, one per URL so that a retry doesn't notify the others twice
+type OutboxApprise struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// DeliveryResponse is the API response for a notification of the outbox
+// The payload isn't returned, as it holds the secrets of the channels
+type DeliveryResponse struct {
+	ID            string     `json:"id"`
+	Channel       string     `json:"channel"`
+	EventType     string     `json:"event_type"`
+	Date          string     `json:"date"` // YYYY-MM-DD
+	RecipientType string     `json:"recipient_type" enums:"owner,participant"`
+	Status        string     `json:"status" enums:"pending,sent,failed"`
+	Attempts      int        `json:"attempts"`
+	Error         *string    `json:"error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+}
+
+// ToResponse converts a Delivery to DeliveryResponse
+func (d *Delivery) ToResponse() *DeliveryResponse {
+	return &DeliveryResponse{
+		ID:            d.ID.String(),
+		Channel:       d.Channel,
+		EventType:     d.EventType,
+		Date:          d.Date.Format("2006-01-02"),
+		RecipientType: d.RecipientType,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		Error:         d.Error,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		SentAt:        d.SentAt,
+	}
+}
@@ -0,0 +1,44 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SummarySubscriber is an owner who opted in to the weekly summary email
+type SummarySubscriber struct {
+	UserID      uuid.UUID
+	Email       string
+	DisplayName string
+	Locale      string
+	Timezone    string
+	LastSentAt  *time.Time // Nil until the first summary is sent
+}
+
+// CalendarSummary summarizes the activity of a calendar over the last week
+type CalendarSummary struct {
+	CalendarID        uuid.UUID
+	Name              string
+	PublicToken       string
+	DateFormat        *string
+	FirstSummary      bool        // No summary was sent with the calendar yet
+	PreviousConfirmed []time.Time // Dates meeting the threshold at the last summary
+	Confirmed         []time.Time // Upcoming dates meeting the threshold, saved for the next summary
+	NewResponses      int         // Availabilities added or changed during the week
+	ReachedDates      []time.Time // Upcoming dates that reached the threshold since the last summary
+	LostDates         []time.Time // Upcoming dates that lost the threshold since the last summary
+	NonResponders     []string    // Participants without any upcoming availability or recurrence
+	UpcomingEvents    []time.Time // Confirmed dates in the coming weeks
+}
+
+// HasActivity reports whether the calendar has anything worth reporting
+func (c *CalendarSummary) HasActivity() bool {
+	return c.NewResponses > 0 || len(c.ReachedDates) > 0 || len(c.LostDates) > 0 ||
+		len(c.NonResponders) > 0 || len(c.UpcomingEvents) > 0
+}
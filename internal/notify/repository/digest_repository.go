@@ -0,0 +1,125 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/notify/models"
+)
+
+// DigestRepository stores the threshold transitions waiting for digest emails
+type DigestRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDigestRepository creates a new digest repository
+func NewDigestRepository(pool *pgxpool.Pool) *DigestRepository {
+	return &DigestRepository{pool: pool}
+}
+
+// Enqueue adds a transition to the next digest of a recipient
+func (r *DigestRepository) Enqueue(ctx context.Context, entry *models.DigestEntry) error {
+	query := `
+		INSERT INTO notification_digest_entries (
+			calendar_id, user_id, participant_id, email, name, locale, calendar_url,
+			date, transition_type, level, count, threshold
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := r.pool.Exec(ctx, query,
+		entry.CalendarID, entry.UserID, entry.ParticipantID, entry.Email, entry.Name, entry.Locale, entry.CalendarURL,
+		entry.Date, entry.TransitionType, entry.Level, entry.Count, entry.Threshold,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue digest entry: %w", err)
+	}
+	return nil
+}
+
+// ListPendingCalendars returns the calendars with transitions waiting for a digest
+func (r *DigestRepository) ListPendingCalendars(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, `SELECT DISTINCT calendar_id FROM notification_digest_entries`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending digests: %w", err)
+	}
+	defer rows.Close()
+
+	var calendarIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan pending digest: %w", err)
+		}
+		calendarIDs = append(calendarIDs, id)
+	}
+
+	return calendarIDs, rows.Err()
+}
+
+// ClaimDue removes and returns the entries of a calendar queued before the given time, oldest first
+// Entries are deleted as they are read, so several instances never send them twice
+func (r *DigestRepository) ClaimDue(ctx context.Context, calendarID uuid.UUID, before time.Time) ([]*models.DigestEntry, error) {
+	query := `
+		DELETE FROM notification_digest_entries
+		WHERE calendar_id = $1 AND created_at < $2
+		RETURNING id, calendar_id, user_id, participant_id, email, name, locale, calendar_url,
+		          date, transition_type, level, count, threshold, created_at`
+
+	rows, err := r.pool.Query(ctx, query, calendarID, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim digest entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.DigestEntry
+	for rows.Next() {
+		e := &models.DigestEntry{}
+		if err := rows.Scan(
+			&e.ID, &e.CalendarID, &e.UserID, &e.ParticipantID, &e.Email, &e.Name, &e.Locale, &e.CalendarURL,
+			&e.Date, &e.TransitionType, &e.Level, &e.Count, &e.Threshold, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan digest entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING doesn't follow ORDER BY
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt.Before(entries[j].CreatedAt) })
+	return entries, nil
+}
+
+// Requeue restores claimed entries whose digest could not be sent, so it is retried
+func (r *DigestRepository) Requeue(ctx context.Context, entries []*models.DigestEntry) error {
+	query := `
+		INSERT INTO notification_digest_entries (
+			id, calendar_id, user_id, participant_id, email, name, locale, calendar_url,
+			date, transition_type, level, count, threshold, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (id) DO NOTHING`
+
+	batch := &pgx.Batch{}
+	for _, e := range entries {
+		batch.Queue(query,
+			e.ID, e.CalendarID, e.UserID, e.ParticipantID, e.Email, e.Name, e.Locale, e.CalendarURL,
+			e.Date, e.TransitionType, e.Level, e.Count, e.Threshold, e.CreatedAt,
+		)
+	}
+
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to requeue digest entries: %w", err)
+	}
+	return nil
+}
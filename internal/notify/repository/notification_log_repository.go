@@ -25,6 +25,54 @@ func NewNotificationLogRepository(pool *pgxpool.Pool) *NotificationLogRepository
 
 // WasNotificationSentRecently checks if a similar notification was sent in the last hour
 func (r *NotificationLogRepository) WasNotificationSentRecently(
+	ctx context.Context,
+	calendarID uuid.UUID,
+	date time.Time,
+	eventType string,
+	level string,
+	recipientID uuid.UUID,
+	channel string,
+) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM notification_log
+			WHERE calendar_id = $1
+			  AND date = $2
+			  AND event_type = $3
+			  AND level = $4
+			  AND recipient_id = $5
+			  AND channel = $6
+			  AND sent_at > NOW() - INTERVAL '1 hour'
+		)`
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, query, calendarID, date, eventType, level, recipientID, channel).Scan(&exists)
+	return exists, err
+}
+
+// WasEventSentRecently checks if a notification of a type was sent to a recipient in the last hour, whatever its date and channel
+func (r *NotificationLogRepository) WasEventSentRecently(
+	ctx context.Context,
+	calendarID uuid.UUID,
+	eventType string,
+	recipientID uuid.UUID,
+) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM notification_log
+			WHERE calendar_id = $1
+			  AND event_type = $2
+			  AND recipient_id = $3
+			  AND sent_at > NOW() - INTERVAL '1 hour'
+		)`
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, query, calendarID, eventType, recipientID).Scan(&exists)
+	return exists, err
+}
+
+// WasNotificationSent checks if a notification was ever sent (within the 30 days of logs), for one-off notifications like reminders
+func (r *NotificationLogRepository) WasNotificationSent(
 	ctx context.Context,
 	calendarID uuid.UUID,
 	date time.Time,
@@ -40,7 +88,6 @@ func (r *NotificationLogRepository) WasNotificationSentRecently(
 			  AND event_type = $3
 			  AND recipient_id = $4
 			  AND channel = $5
-			  AND sent_at > NOW() - INTERVAL '1 hour'
 		)`
 
 	var exists bool
@@ -54,16 +101,17 @@ func (r *NotificationLogRepository) LogNotification(
 	calendarID uuid.UUID,
 	date time.Time,
 	eventType string,
+	level string,
 	recipientType string,
 	recipientID uuid.UUID,
 	channel string,
 ) error {
 	query := `
 		INSERT INTO notification_log
-			(calendar_id, date, event_type, recipient_type, recipient_id, channel)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+			(calendar_id, date, event_type, level, recipient_type, recipient_id, channel)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
-	_, err := r.pool.Exec(ctx, query, calendarID, date, eventType, recipientType, recipientID, channel)
+	_, err := r.pool.Exec(ctx, query, calendarID, date, eventType, level, recipientType, recipientID, channel)
 	return err
 }
 
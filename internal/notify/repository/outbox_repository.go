@@ -0,0 +1,133 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/notify/models"
+)
+
+// OutboxRepository stores the notifications waiting to be sent, and the outcome of the sent ones
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pool: pool}
+}
+
+// Enqueue queues a notification, due at its NextAttemptAt
+func (r *OutboxRepository) Enqueue(ctx context.Context, d *models.Delivery) error {
+	payload, err := json.Marshal(d.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification payload: %w", err)
+	}
+
+	query := `
+		INSERT INTO notification_outbox (
+			id, calendar_id, channel, event_type, date, level, recipient_type, recipient_id,
+			payload, status, attempts, next_attempt_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err = r.pool.Exec(ctx, query,
+		d.ID, d.CalendarID, d.Channel, d.EventType, d.Date, d.Level, d.RecipientType, d.RecipientID,
+		payload, d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue claims up to limit pending notifications due at now
+// Claimed notifications are postponed by lease, so that other instances skip them while they are sent
+// and the worker retries them if the instance stops before recording the attempt
+func (r *OutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Delivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM notification_outbox
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE notification_outbox o
+		SET next_attempt_at = $2
+		FROM due
+		WHERE o.id = due.id
+		RETURNING ` + deliveryColumns("o")
+
+	return r.query(ctx, query, now, now.Add(lease), limit)
+}
+
+// RecordAttempt records the outcome of a delivery attempt
+func (r *OutboxRepository) RecordAttempt(ctx context.Context, d *models.Delivery) error {
+	query := `
+		UPDATE notification_outbox
+		SET status = $2, attempts = $3, error = $4, next_attempt_at = $5, sent_at = $6
+		WHERE id = $1`
+
+	_, err := r.pool.Exec(ctx, query, d.ID, d.Status, d.Attempts, d.Error, d.NextAttemptAt, d.SentAt)
+	if err != nil {
+		return fmt.Errorf("failed to record notification delivery: %w", err)
+	}
+	return nil
+}
+
+// ListByCalendar returns the latest notifications of a calendar, newest first
+func (r *OutboxRepository) ListByCalendar(ctx context.Context, calendarID uuid.UUID, limit int) ([]*models.Delivery, error) {
+	query := `
+		SELECT ` + deliveryColumns("notification_outbox") + `
+		FROM notification_outbox
+		WHERE calendar_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	return r.query(ctx, query, calendarID, limit)
+}
+
+// deliveryColumns lists the columns scanned by query, qualified by a table name or alias
+func deliveryColumns(table string) string {
+	return fmt.Sprintf(
+		"%[1]s.id, %[1]s.calendar_id, %[1]s.channel, %[1]s.event_type, %[1]s.date, %[1]s.level, %[1]s.recipient_type, %[1]s.recipient_id, "+
+			"%[1]s.payload, %[1]s.status, %[1]s.attempts, %[1]s.error, %[1]s.next_attempt_at, %[1]s.created_at, %[1]s.sent_at",
+		table,
+	)
+}
+
+func (r *OutboxRepository) query(ctx context.Context, query string, args ...any) ([]*models.Delivery, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*models.Delivery
+	for rows.Next() {
+		var d models.Delivery
+		var payload []byte
+		if err := rows.Scan(
+			&d.ID, &d.CalendarID, &d.Channel, &d.EventType, &d.Date, &d.Level, &d.RecipientType, &d.RecipientID,
+			&payload, &d.Status, &d.Attempts, &d.Error, &d.NextAttemptAt, &d.CreatedAt, &d.SentAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification delivery: %w", err)
+		}
+		if err := json.Unmarshal(payload, &d.Payload); err != nil {
+			return nil, fmt.Errorf("failed to parse notification payload: %w", err)
+		}
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
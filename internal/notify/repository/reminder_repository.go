@@ -0,0 +1,51 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReminderRepository finds the calendars sending reminders before their events
+type ReminderRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReminderRepository creates a new reminder repository
+func NewReminderRepository(pool *pgxpool.Pool) *ReminderRepository {
+	return &ReminderRepository{pool: pool}
+}
+
+// ListReminderCalendars returns the IDs of the calendars with notifications and reminders enabled
+func (r *ReminderRepository) ListReminderCalendars(ctx context.Context) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM calendars
+		WHERE notify_on_threshold
+		  AND (notify_config->>'enabled')::boolean
+		  AND (notify_config->'reminders'->>'enabled')::boolean`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminder calendars: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan reminder calendar: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
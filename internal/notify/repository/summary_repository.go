@@ -0,0 +1,184 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/notify/models"
+)
+
+// SummaryRepository reads the data of the weekly summary emails sent to owners
+type SummaryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSummaryRepository creates a new summary repository
+func NewSummaryRepository(pool *pgxpool.Pool) *SummaryRepository {
+	return &SummaryRepository{pool: pool}
+}
+
+// ListSubscribers returns the owners who opted in to the weekly summary
+func (r *SummaryRepository) ListSubscribers(ctx context.Context) ([]*models.SummarySubscriber, error) {
+	query := `
+		SELECT id, email, display_name, locale, timezone, weekly_summary_sent_at
+		FROM users
+		WHERE weekly_summary AND email_verified AND deleted_at IS NULL`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list summary subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	var subscribers []*models.SummarySubscriber
+	for rows.Next() {
+		s := &models.SummarySubscriber{}
+		if err := rows.Scan(&s.UserID, &s.Email, &s.DisplayName, &s.Locale, &s.Timezone, &s.LastSentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan summary subscriber: %w", err)
+		}
+		subscribers = append(subscribers, s)
+	}
+
+	return subscribers, rows.Err()
+}
+
+// ClaimSummary marks the summary of an owner as sent, unless it was already sent since the given time
+// It returns false when another instance claimed it first
+func (r *SummaryRepository) ClaimSummary(ctx context.Context, userID uuid.UUID, since time.Time) (bool, error) {
+	query := `
+		UPDATE users
+		SET weekly_summary_sent_at = NOW()
+		WHERE id = $1
+		  AND (weekly_summary_sent_at IS NULL OR weekly_summary_sent_at < $2)`
+
+	result, err := r.pool.Exec(ctx, query, userID, since)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim weekly summary: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// ReleaseSummary restores the previous sent time of a summary that could not be sent, so it is retried
+func (r *SummaryRepository) ReleaseSummary(ctx context.Context, userID uuid.UUID, previous *time.Time) error {
+	query := `UPDATE users SET weekly_summary_sent_at = $2 WHERE id = $1`
+
+	if _, err := r.pool.Exec(ctx, query, userID, previous); err != nil {
+		return fmt.Errorf("failed to release weekly summary: %w", err)
+	}
+	return nil
+}
+
+// ListCalendarSummaries returns the calendars of an owner with the number of responses since the given time
+func (r *SummaryRepository) ListCalendarSummaries(ctx context.Context, ownerID uuid.UUID, since time.Time) ([]*models.CalendarSummary, error) {
+	query := `
+		SELECT c.id, c.name, c.public_token, c.date_format, c.summary_confirmed_dates,
+		       (SELECT COUNT(*)
+		        FROM availabilities a
+		        JOIN participants p ON p.id = a.participant_id
+		        WHERE p.calendar_id = c.id AND a.updated_at >= $2) AS new_responses
+		FROM calendars c
+		WHERE c.owner_id = $1
+		ORDER BY c.name`
+
+	rows, err := r.pool.Query(ctx, query, ownerID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calendar summaries: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []*models.CalendarSummary
+	for rows.Next() {
+		c := &models.CalendarSummary{}
+		if err := rows.Scan(&c.CalendarID, &c.Name, &c.PublicToken, &c.DateFormat, &c.PreviousConfirmed, &c.NewResponses); err != nil {
+			return nil, fmt.Errorf("failed to scan calendar summary: %w", err)
+		}
+		// NULL before the first summary, empty when no date met the threshold
+		c.FirstSummary = c.PreviousConfirmed == nil
+		summaries = append(summaries, c)
+	}
+
+	return summaries, rows.Err()
+}
+
+// SaveConfirmedDates records the dates of a calendar meeting its threshold, compared with at the next summary
+func (r *SummaryRepository) SaveConfirmedDates(ctx context.Context, calendarID uuid.UUID, dates []time.Time) error {
+	if dates == nil {
+		dates = []time.Time{}
+	}
+	query := `UPDATE calendars SET summary_confirmed_dates = $2 WHERE id = $1`
+
+	if _, err := r.pool.Exec(ctx, query, calendarID, dates); err != nil {
+		return fmt.Errorf("failed to save summary confirmed dates: %w", err)
+	}
+	return nil
+}
+
+// ListRespondedDates returns the dates from the given day whose availabilities changed since the given time
+func (r *SummaryRepository) ListRespondedDates(ctx context.Context, calendarID uuid.UUID, from time.Time, since time.Time) ([]time.Time, error) {
+	query := `
+		SELECT DISTINCT a.date
+		FROM availabilities a
+		JOIN participants p ON p.id = a.participant_id
+		WHERE p.calendar_id = $1 AND a.date >= $2 AND a.updated_at >= $3
+		ORDER BY a.date`
+
+	rows, err := r.pool.Query(ctx, query, calendarID, from, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list responded dates: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, fmt.Errorf("failed to scan responded date: %w", err)
+		}
+		dates = append(dates, date)
+	}
+
+	return dates, rows.Err()
+}
+
+// ListNonResponders returns the names of the participants without any availability or recurrence from the given day
+func (r *SummaryRepository) ListNonResponders(ctx context.Context, calendarID uuid.UUID, from time.Time) ([]string, error) {
+	query := `
+		SELECT p.name
+		FROM participants p
+		WHERE p.calendar_id = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM availabilities a
+			WHERE a.participant_id = p.id AND a.date >= $2
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM recurrences r
+			WHERE r.participant_id = p.id AND (r.end_date IS NULL OR r.end_date >= $2)
+		  )
+		ORDER BY p.name`
+
+	rows, err := r.pool.Query(ctx, query, calendarID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list non-responders: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan non-responder: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
@@ -0,0 +1,443 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAppriseURL is returned for Apprise URLs of an unknown service or missing a part the service needs
+var ErrInvalidAppriseURL = errors.New("invalid Apprise URL")
+
+// AppriseMessage is the content of a notification sent to Apprise URLs
+type AppriseMessage struct {
+	Title string
+	Body  string
+}
+
+// AppriseURL is an Apprise service URL split into its parts: scheme://[user[:password]@]host/path...?query
+// Tokens are often in the host position (tgram://123456:ABC/chat), so it is parsed leniently, unlike net/url
+type AppriseURL struct {
+	Scheme   string
+	User     string
+	Password string
+	Host     string   // First element after the credentials, including the port if any
+	Path     []string // Following elements, unescaped
+	Query    url.Values
+}
+
+// AppriseService builds the HTTP requests delivering a message to the service of an Apprise URL
+// It returns an error wrapping ErrInvalidAppriseURL when the URL lacks a part the service needs,
+// so URLs are checked by building a request for an empty message when the configuration is saved
+type AppriseService func(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error)
+
+// defaultAppriseServices returns the services supported out of the box, by URL scheme
+// apprise:// forwards to an Apprise API server, reaching every service Apprise supports
+func defaultAppriseServices() map[string]AppriseService {
+	return map[string]AppriseService{
+		"apprise":  appriseAPI,
+		"apprises": appriseAPI,
+		"discord":  appriseDiscord,
+		"gchat":    appriseGoogleChat,
+		"gotify":   appriseGotify,
+		"gotifys":  appriseGotify,
+		"json":     appriseJSON,
+		"jsons":    appriseJSON,
+		"mmost":    appriseMattermost,
+		"mmosts":   appriseMattermost,
+		"ntfy":     appriseNtfy,
+		"ntfys":    appriseNtfy,
+		"pbul":     apprisePushbullet,
+		"pover":    apprisePushover,
+		"slack":    appriseSlack,
+		"tgram":    appriseTelegram,
+	}
+}
+
+// RegisterAppriseService adds or replaces the service of URL schemes
+// It must be called at startup, before notifications are sent
+func (e *ExternalNotifier) RegisterAppriseService(service AppriseService, schemes ...string) {
+	for _, scheme := range schemes {
+		e.apprise[strings.ToLower(scheme)] = service
+	}
+}
+
+// ValidateAppriseURL checks that a URL belongs to a registered service and has the parts it needs
+func (e *ExternalNotifier) ValidateAppriseURL(raw string) error {
+	_, err := e.appriseRequests(raw, AppriseMessage{})
+	return err
+}
+
+// SendApprise sends a message to each Apprise URL and returns how many received it
+// The error joins the failures of the other URLs, whose tokens are kept out of it
+func (e *ExternalNotifier) SendApprise(ctx context.Context, urls []string, msg AppriseMessage) (int, error) {
+	if len(urls) == 0 {
+		return 0, fmt.Errorf("no Apprise URL configured")
+	}
+
+	sent := 0
+	var errs []error
+	for i, raw := range urls {
+		if err := e.sendApprise(ctx, raw, msg); err != nil {
+			errs = append(errs, fmt.Errorf("URL %d: %w", i+1, err))
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		e.logger.Info("Apprise notification sent successfully", "urls", sent)
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (e *ExternalNotifier) sendApprise(ctx context.Context, raw string, msg AppriseMessage) error {
+	requests, err := e.appriseRequests(raw, msg)
+	if err != nil {
+		return err
+	}
+
+	for _, req := range requests {
+		resp, err := e.webhookClient.Do(req.WithContext(ctx))
+		if err != nil {
+			// *url.Error repeats the request URL, which holds the token of most services
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			return fmt.Errorf("failed to reach %s: %w", req.URL.Hostname(), err)
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("%s returned status %d", req.URL.Hostname(), resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+// appriseRequests builds the requests of a URL with the service registered for its scheme
+func (e *ExternalNotifier) appriseRequests(raw string, msg AppriseMessage) ([]*http.Request, error) {
+	target, err := ParseAppriseURL(raw)
+	if err != nil {
+		return nil, err
+	}
+	service, ok := e.apprise[target.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported service %s://", ErrInvalidAppriseURL, target.Scheme)
+	}
+	return service(target, msg)
+}
+
+// ParseAppriseURL splits an Apprise URL into its parts
+func ParseAppriseURL(raw string) (*AppriseURL, error) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(raw), "://")
+	if !ok || scheme == "" {
+		return nil, fmt.Errorf("%w: expected service://...", ErrInvalidAppriseURL)
+	}
+
+	target := &AppriseURL{Scheme: strings.ToLower(scheme)}
+	rest, query, _ := strings.Cut(rest, "?")
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid query", ErrInvalidAppriseURL)
+	}
+	target.Query = values
+
+	elements := strings.Split(rest, "/")
+	host := elements[0]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		user, password, _ := strings.Cut(host[:at], ":")
+		if target.User, err = url.PathUnescape(user); err != nil {
+			return nil, fmt.Errorf("%w: invalid user", ErrInvalidAppriseURL)
+		}
+		if target.Password, err = url.PathUnescape(password); err != nil {
+			return nil, fmt.Errorf("%w: invalid password", ErrInvalidAppriseURL)
+		}
+		host = host[at+1:]
+	}
+	target.Host = host
+
+	for _, element := range elements[1:] {
+		if element == "" {
+			continue
+		}
+		unescaped, err := url.PathUnescape(element)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid path", ErrInvalidAppriseURL)
+		}
+		target.Path = append(target.Path, unescaped)
+	}
+
+	if target.Host == "" {
+		return nil, fmt.Errorf("%w: missing host", ErrInvalidAppriseURL)
+	}
+	return target, nil
+}
+
+// serverURL returns the URL of a self-hosted service: http, or https for the scheme ending with "s",
+// with the given path elements escaped after those of the Apprise URL
+func (t *AppriseURL) serverURL(defaultPort string, pathPrefix []string, elements ...string) string {
+	scheme, host := "http", t.Host
+	if strings.HasSuffix(t.Scheme, "s") {
+		scheme = "https"
+	} else if defaultPort != "" && !strings.Contains(host, ":") {
+		host += ":" + defaultPort
+	}
+
+	u := scheme + "://" + host
+	for _, element := range append(append([]string{}, pathPrefix...), elements...) {
+		u += "/" + url.PathEscape(element)
+	}
+	return u
+}
+
+// invalid describes the expected form of a URL in an ErrInvalidAppriseURL error
+func (t *AppriseURL) invalid(form string) error {
+	return fmt.Errorf("%w: expected %s://%s", ErrInvalidAppriseURL, t.Scheme, form)
+}
+
+// appriseText joins the title and the body of a message for services without a title field
+func appriseText(msg AppriseMessage, bold string) string {
+	if msg.Title == "" {
+		return msg.Body
+	}
+	return bold + msg.Title + bold + "\n" + msg.Body
+}
+
+// jsonRequest builds a POST request with a JSON body
+func jsonRequest(endpoint string, payload any) (*http.Request, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Apprise payload: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAppriseURL, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "WhenTo-Notify/1.0")
+	return req, nil
+}
+
+// withBasicAuth sets the credentials of the Apprise URL on a request, if any
+func withBasicAuth(req *http.Request, target *AppriseURL) *http.Request {
+	if target.User != "" {
+		req.SetBasicAuth(target.User, target.Password)
+	}
+	return req
+}
+
+// appriseAPI posts to an Apprise API server: apprise://host[:port][/path]/{key}
+func appriseAPI(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	if len(target.Path) == 0 {
+		return nil, target.invalid("host[:port]/{key}")
+	}
+	prefix, key := target.Path[:len(target.Path)-1], target.Path[len(target.Path)-1]
+
+	payload := map[string]string{"title": msg.Title, "body": msg.Body, "type": "info"}
+	if tags := target.Query.Get("tags"); tags != "" {
+		payload["tag"] = tags
+	}
+	req, err := jsonRequest(target.serverURL("", prefix, "notify", key), payload)
+	if err != nil {
+		return nil, err
+	}
+	return []*http.Request{withBasicAuth(req, target)}, nil
+}
+
+// appriseDiscord posts to a Discord webhook: discord://[botname@]{webhook_id}/{webhook_token}
+func appriseDiscord(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	if len(target.Path) != 1 {
+		return nil, target.invalid("{webhook_id}/{webhook_token}")
+	}
+
+	payload := map[string]string{"content": appriseText(msg, "**")}
+	if target.User != "" {
+		payload["username"] = target.User
+	}
+	req, err := jsonRequest("https://discord.com/api/webhooks/"+url.PathEscape(target.Host)+"/"+url.PathEscape(target.Path[0]), payload)
+	if err != nil {
+		return nil, err
+	}
+	return []*http.Request{req}, nil
+}
+
+// appriseGoogleChat posts to a Google Chat webhook: gchat://{workspace}/{webhook_key}/{webhook_token}
+func appriseGoogleChat(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	if len(target.Path) != 2 {
+		return nil, target.invalid("{workspace}/{webhook_key}/{webhook_token}")
+	}
+
+	query := url.Values{"key": {target.Path[0]}, "token": {target.Path[1]}}
+	endpoint := "https://chat.googleapis.com/v1/spaces/" + url.PathEscape(target.Host) + "/messages?" + query.Encode()
+	req, err := jsonRequest(endpoint, map[string]string{"text": appriseText(msg, "*")})
+	if err != nil {
+		return nil, err
+	}
+	return []*http.Request{req}, nil
+}
+
+// appriseGotify posts to a Gotify server: gotify://host[:port][/path]/{token}[?priority=5]
+func appriseGotify(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	if len(target.Path) == 0 {
+		return nil, target.invalid("host[:port]/{token}")
+	}
+	prefix, token := target.Path[:len(target.Path)-1], target.Path[len(target.Path)-1]
+
+	priority := 5
+	if value := target.Query.Get("priority"); value != "" {
+		p, err := strconv.Atoi(value)
+		if err != nil || p < 0 || p > 10 {
+			return nil, fmt.Errorf("%w: priority must be between 0 and 10", ErrInvalidAppriseURL)
+		}
+		priority = p
+	}
+
+	req, err := jsonRequest(target.serverURL("", prefix, "message"), map[string]any{
+		"title": msg.Title, "message": msg.Body, "priority": priority,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Gotify-Key", token)
+	return []*http.Request{req}, nil
+}
+
+// appriseJSON posts the message as JSON to any endpoint: json://[user:password@]host[:port][/path]
+func appriseJSON(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	req, err := jsonRequest(target.serverURL("", target.Path), map[string]string{
+		"version": "1.0", "title": msg.Title, "message": msg.Body, "type": "info",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []*http.Request{withBasicAuth(req, target)}, nil
+}
+
+// appriseMattermost posts to a Mattermost incoming webhook: mmost://[botname@]host[:port][/path]/{token}[?channel=name]
+func appriseMattermost(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	if len(target.Path) == 0 {
+		return nil, target.invalid("host[:port]/{token}")
+	}
+	prefix, token := target.Path[:len(target.Path)-1], target.Path[len(target.Path)-1]
+
+	payload := map[string]string{"text": appriseText(msg, "**")}
+	if target.User != "" {
+		payload["username"] = target.User
+	}
+	if channel := target.Query.Get("channel"); channel != "" {
+		payload["channel"] = channel
+	}
+	req, err := jsonRequest(target.serverURL("8065", prefix, "hooks", token), payload)
+	if err != nil {
+		return nil, err
+	}
+	return []*http.Request{req}, nil
+}
+
+// appriseNtfy publishes to ntfy topics: ntfy://{topic} on ntfy.sh,
+// or ntfy://[user:password@]host[:port]/{topic}[/{topic}...][?token=tk_...] on a self-hosted server
+func appriseNtfy(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	endpoint, topics := "https://ntfy.sh", []string{target.Host}
+	if len(target.Path) > 0 {
+		endpoint, topics = target.serverURL("", nil), target.Path
+	}
+
+	requests := make([]*http.Request, 0, len(topics))
+	for _, topic := range topics {
+		req, err := jsonRequest(endpoint, map[string]string{"topic": topic, "title": msg.Title, "message": msg.Body})
+		if err != nil {
+			return nil, err
+		}
+		if token := target.Query.Get("token"); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else {
+			withBasicAuth(req, target)
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+// apprisePushbullet creates a note on Pushbullet: pbul://{access_token}
+func apprisePushbullet(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	req, err := jsonRequest("https://api.pushbullet.com/v2/pushes", map[string]string{
+		"type": "note", "title": msg.Title, "body": msg.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Access-Token", target.Host)
+	return []*http.Request{req}, nil
+}
+
+// apprisePushover sends a Pushover message: pover://{user_key}@{app_token}[/{device}...]
+func apprisePushover(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	if target.User == "" {
+		return nil, target.invalid("{user_key}@{app_token}")
+	}
+
+	form := url.Values{"token": {target.Host}, "user": {target.User}, "title": {msg.Title}, "message": {msg.Body}}
+	if len(target.Path) > 0 {
+		form.Set("device", strings.Join(target.Path, ","))
+	}
+	req, err := http.NewRequest(http.MethodPost, "https://api.pushover.net/1/messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAppriseURL, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return []*http.Request{req}, nil
+}
+
+// appriseSlack posts to a Slack incoming webhook: slack://{token_a}/{token_b}/{token_c}[/#channel]
+func appriseSlack(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	if len(target.Path) != 2 && len(target.Path) != 3 {
+		return nil, target.invalid("{token_a}/{token_b}/{token_c}")
+	}
+
+	payload := map[string]string{"text": appriseText(msg, "*")}
+	if len(target.Path) == 3 {
+		payload["channel"] = target.Path[2]
+	}
+	endpoint := "https://hooks.slack.com/services/" + url.PathEscape(target.Host) + "/" +
+		url.PathEscape(target.Path[0]) + "/" + url.PathEscape(target.Path[1])
+	req, err := jsonRequest(endpoint, payload)
+	if err != nil {
+		return nil, err
+	}
+	return []*http.Request{req}, nil
+}
+
+// appriseTelegram sends a Telegram message to each chat: tgram://{bot_token}/{chat_id}[/{chat_id}...]
+func appriseTelegram(target *AppriseURL, msg AppriseMessage) ([]*http.Request, error) {
+	if len(target.Path) == 0 {
+		return nil, target.invalid("{bot_token}/{chat_id}")
+	}
+
+	// Plain text: calendar names would break Markdown parsing
+	requests := make([]*http.Request, 0, len(target.Path))
+	for _, chatID := range target.Path {
+		req, err := jsonRequest("https://api.telegram.org/bot"+url.PathEscape(target.Host)+"/sendMessage", map[string]string{
+			"chat_id": chatID, "text": appriseText(msg, ""),
+		})
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
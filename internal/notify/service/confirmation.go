@@ -0,0 +1,109 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/whento/pkg/email"
+	authRepo "github.com/whento/whento/internal/auth/repository"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+)
+
+//go:embed templates/event_confirmation.html
+var eventConfirmationTemplate string
+
+// confirmationEmail is the data of the event confirmation email template
+type confirmationEmail struct {
+	Locale       string
+	Subject      string
+	Greeting     string
+	Intro        string
+	Date         string
+	Button       string
+	Note         string
+	ConfirmURL   string
+	ProductName  string
+	LogoURL      string
+	PrimaryColor template.CSS
+	FooterText   string
+}
+
+// proposeEvent proposes a date that reached the threshold to the owner of a calendar requiring confirmation,
+// emailing a link to confirm or decline it. Dates already proposed or decided on are skipped
+func (s *NotifyService) proposeEvent(ctx context.Context, calendar *calendarModels.Calendar, date time.Time) error {
+	if s.confirmations == nil {
+		return nil
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	proposed, err := s.confirmations.Propose(ctx, calendar.ID, date, authRepo.HashToken(token))
+	if err != nil || !proposed {
+		return err
+	}
+
+	s.logger.Info("Event proposed to owner", "calendar_id", calendar.ID, "date", date.Format("2006-01-02"))
+
+	// Owners without email can still decide from the calendar settings
+	if !s.emailService.IsConfigured() {
+		return nil
+	}
+	owner, err := s.userRepo.GetByID(ctx, calendar.OwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to get owner: %w", err)
+	}
+
+	body, err := s.renderConfirmation(calendar, date, owner.DisplayName, owner.Locale, token)
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.Send(email.Email{
+		To:      []string{owner.Email},
+		Subject: s.translate(owner.Locale, "confirm_subject", map[string]string{"CalendarName": calendar.Name}),
+		Body:    body,
+		HTML:    true,
+	})
+}
+
+// renderConfirmation renders the email asking the owner to confirm a proposed event
+func (s *NotifyService) renderConfirmation(calendar *calendarModels.Calendar, date time.Time, name, locale, token string) (string, error) {
+	if s.confirmTemplate == nil {
+		return "", fmt.Errorf("event confirmation template not loaded")
+	}
+
+	data := confirmationEmail{
+		Locale:       locale,
+		Subject:      s.translate(locale, "confirm_subject", map[string]string{"CalendarName": calendar.Name}),
+		Greeting:     s.translate(locale, "summary_greeting", map[string]string{"Name": name}),
+		Intro:        s.translate(locale, "confirm_intro", map[string]string{"CalendarName": calendar.Name}),
+		Date:         s.formatDate(date, locale, calendar),
+		Button:       s.translate(locale, "confirm_button", nil),
+		Note:         s.translate(locale, "confirm_note", nil),
+		ConfirmURL:   fmt.Sprintf("%s/confirm-event/%s", s.appURL, token),
+		ProductName:  s.branding.ProductName,
+		LogoURL:      s.branding.LogoURL,
+		PrimaryColor: template.CSS(s.branding.PrimaryColor), // Validated as a hex color by the config
+		FooterText:   s.branding.Footer(),
+	}
+
+	var body bytes.Buffer
+	if err := s.confirmTemplate.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to render event confirmation: %w", err)
+	}
+	return body.String(), nil
+}
@@ -0,0 +1,43 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package service
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/config"
+)
+
+func TestRenderConfirmation(t *testing.T) {
+	cfg := &config.Config{
+		AppURL:   "https://whento.example.com",
+		Branding: config.BrandingConfig{ProductName: "WhenTo", PrimaryColor: "#4F46E5"},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	notify := NewNotifyService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, logger)
+
+	calendar := &calendarModels.Calendar{Name: "Board <games>"}
+	body, err := notify.renderConfirmation(calendar, time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC), "Alice", "en", "secret")
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Hello Alice,",
+		"Board &lt;games&gt; calendar reached the threshold",
+		"2025-06-20",
+		"https://whento.example.com/confirm-event/secret",
+		"Confirm or decline",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("confirmation email should contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/email"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/notify/models"
+	notifyRepo "github.com/whento/whento/internal/notify/repository"
+)
+
+//go:embed templates/digest.html
+var digestTemplate string
+
+// digestCheckInterval is how often the scheduler looks for digests to send
+const digestCheckInterval = 15 * time.Minute
+
+// DigestScheduler sends the daily or weekly digest emails of the calendars grouping their threshold emails
+type DigestScheduler struct {
+	notify     *NotifyService
+	digestRepo *notifyRepo.DigestRepository
+	template   *template.Template
+	logger     *slog.Logger
+}
+
+// NewDigestScheduler creates a new digest scheduler
+func NewDigestScheduler(notify *NotifyService, digestRepo *notifyRepo.DigestRepository, logger *slog.Logger) *DigestScheduler {
+	source, err := email.LoadTemplate(digestTemplate, notify.emailTemplateDir, "digest")
+	if err != nil {
+		logger.Error("Failed to load digest template override", "error", err)
+	}
+	tmpl, err := template.New("digest").Parse(source)
+	if err != nil {
+		logger.Error("Failed to parse digest template", "error", err)
+	}
+
+	return &DigestScheduler{
+		notify:     notify,
+		digestRepo: digestRepo,
+		template:   tmpl,
+		logger:     logger,
+	}
+}
+
+// StartTask checks periodically for digests whose time has come
+func (s *DigestScheduler) StartTask(ctx context.Context) {
+	s.logger.Info("Starting notification digest scheduler", "interval", digestCheckInterval)
+
+	go func() {
+		ticker := time.NewTicker(digestCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Notification digest scheduler stopped (context cancelled)")
+				return
+			case <-ticker.C:
+				s.SendDueDigests(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// lastDigest returns the latest digest time at or before now, in the location of now
+func lastDigest(now time.Time, digest models.DigestConfig) time.Time {
+	if digest.Mode == models.DigestWeekly {
+		return lastScheduled(now, time.Weekday(digest.Weekday), digest.Hour)
+	}
+
+	scheduled := time.Date(now.Year(), now.Month(), now.Day(), digest.Hour, 0, 0, 0, now.Location())
+	if scheduled.After(now) {
+		scheduled = scheduled.AddDate(0, 0, -1)
+	}
+	return scheduled
+}
+
+// SendDueDigests sends the digests of the transitions queued before the last digest time of their calendar
+func (s *DigestScheduler) SendDueDigests(ctx context.Context, now time.Time) {
+	if !s.notify.emailService.IsConfigured() {
+		return
+	}
+
+	calendarIDs, err := s.digestRepo.ListPendingCalendars(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list pending digests", "error", err)
+		return
+	}
+
+	for _, calendarID := range calendarIDs {
+		if err := s.sendCalendarDigests(ctx, calendarID, now); err != nil {
+			s.logger.Error("Failed to send notification digests", "calendar_id", calendarID, "error", err)
+		}
+	}
+}
+
+// sendCalendarDigests sends one digest to each recipient with due transitions on a calendar
+// When the owner went back to immediate emails, the queued transitions are sent right away;
+// when they disabled email notifications, they are dropped
+func (s *DigestScheduler) sendCalendarDigests(ctx context.Context, calendarID uuid.UUID, now time.Time) error {
+	calendar, err := s.notify.calendarRepo.GetByID(ctx, calendarID)
+	if err != nil {
+		return fmt.Errorf("failed to get calendar: %w", err)
+	}
+
+	var config models.NotifyConfig
+	if calendar.NotifyConfig != nil {
+		if err := json.Unmarshal([]byte(*calendar.NotifyConfig), &config); err != nil {
+			return fmt.Errorf("failed to parse notify config: %w", err)
+		}
+	}
+
+	due := now
+	if config.Digest.Enabled() {
+		loc, err := time.LoadLocation(calendar.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		due = lastDigest(now.In(loc), config.Digest)
+	}
+
+	entries, err := s.digestRepo.ClaimDue(ctx, calendar.ID, due)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 || !config.Enabled || !config.Channels.Email.Enabled {
+		return nil
+	}
+
+	// Group the transitions by recipient, in the order they were queued
+	var recipients []uuid.UUID
+	byRecipient := make(map[uuid.UUID][]*models.DigestEntry)
+	for _, entry := range entries {
+		id := entry.RecipientID()
+		if _, ok := byRecipient[id]; !ok {
+			recipients = append(recipients, id)
+		}
+		byRecipient[id] = append(byRecipient[id], entry)
+	}
+
+	for _, id := range recipients {
+		recipientEntries := byRecipient[id]
+		if err := s.sendDigest(calendar, recipientEntries); err != nil {
+			s.logger.Error("Failed to send notification digest", "calendar_id", calendar.ID, "recipient_id", id, "error", err)
+			if err := s.digestRepo.Requeue(ctx, recipientEntries); err != nil {
+				s.logger.Error("Failed to requeue notification digest", "calendar_id", calendar.ID, "recipient_id", id, "error", err)
+			}
+			continue
+		}
+		s.logger.Info("Notification digest sent", "calendar_id", calendar.ID, "recipient_id", id, "transitions", len(recipientEntries))
+	}
+
+	return nil
+}
+
+// sendDigest renders and sends the digest of a recipient
+func (s *DigestScheduler) sendDigest(calendar *calendarModels.Calendar, entries []*models.DigestEntry) error {
+	recipient := entries[0]
+	body, err := s.render(calendar, entries)
+	if err != nil {
+		return err
+	}
+
+	return s.notify.emailService.Send(email.Email{
+		To:      []string{recipient.Email},
+		Subject: s.notify.translate(recipient.Locale, "digest_subject", map[string]string{"CalendarName": calendar.Name}),
+		Body:    body,
+		HTML:    true,
+	})
+}
+
+// digestEmail is the data of the digest email template
+type digestEmail struct {
+	Locale       string
+	Subject      string
+	Greeting     string
+	Intro        string
+	Transitions  []string
+	ViewButton   string
+	CalendarURL  string
+	Note         string
+	ProductName  string
+	LogoURL      string
+	PrimaryColor template.CSS
+	FooterText   string
+}
+
+// render renders the digest email of a recipient, in their locale
+func (s *DigestScheduler) render(calendar *calendarModels.Calendar, entries []*models.DigestEntry) (string, error) {
+	if s.template == nil {
+		return "", fmt.Errorf("digest template not loaded")
+	}
+
+	recipient := entries[0]
+	locale := recipient.Locale
+	data := digestEmail{
+		Locale:       locale,
+		Subject:      s.notify.translate(locale, "digest_subject", map[string]string{"CalendarName": calendar.Name}),
+		Greeting:     s.notify.translate(locale, "summary_greeting", map[string]string{"Name": recipient.Name}),
+		Intro:        s.notify.translate(locale, "digest_intro", nil),
+		ViewButton:   s.notify.translate(locale, "view_button", nil),
+		CalendarURL:  recipient.CalendarURL,
+		Note:         s.notify.translate(locale, "digest_note", nil),
+		ProductName:  s.notify.branding.ProductName,
+		LogoURL:      s.notify.branding.LogoURL,
+		PrimaryColor: template.CSS(s.notify.branding.PrimaryColor), // Validated as a hex color by the config
+		FooterText:   s.notify.branding.Footer(),
+	}
+
+	for _, entry := range entries {
+		data.Transitions = append(data.Transitions, s.notify.translate(locale, "message_"+transitionMessageKey(entry.TransitionType), map[string]string{
+			"Date":      s.notify.formatDate(entry.Date, locale, calendar),
+			"Count":     strconv.Itoa(entry.Count),
+			"Threshold": strconv.Itoa(entry.Threshold),
+		}))
+	}
+
+	var body bytes.Buffer
+	if err := s.template.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return body.String(), nil
+}
+
+// enqueueDigest queues a threshold transition for the next digest of a recipient
+// It is logged like a sent email, so repeated transitions within the anti-spam window aren't queued twice
+func (s *NotifyService) enqueueDigest(
+	ctx context.Context,
+	calendar *calendarModels.Calendar,
+	transition *models.ThresholdTransition,
+	recipient *emailRecipient,
+	calendarURL string,
+) {
+	entry := &models.DigestEntry{
+		CalendarID:     calendar.ID,
+		Email:          recipient.Email,
+		Name:           recipient.Name,
+		Locale:         recipient.Locale,
+		CalendarURL:    calendarURL,
+		Date:           transition.Date,
+		TransitionType: transition.TransitionType,
+		Level:          transition.Level,
+		Count:          transition.NewCount,
+		Threshold:      transition.Threshold,
+	}
+	recipientType := "participant"
+	if recipient.IsOwner {
+		recipientType = "owner"
+		entry.UserID = &recipient.RecipientID
+	} else {
+		entry.ParticipantID = &recipient.RecipientID
+	}
+
+	if err := s.digests.Enqueue(ctx, entry); err != nil {
+		s.logger.Error("Failed to queue digest entry", "calendar_id", calendar.ID, "recipient_id", recipient.RecipientID, "error", err)
+		return
+	}
+
+	_ = s.notificationLog.LogNotification(
+		ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, recipientType, recipient.RecipientID, "email",
+	)
+}
@@ -0,0 +1,104 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/notify/models"
+)
+
+func TestLastDigest(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+
+	daily := models.DigestConfig{Mode: models.DigestDaily, Hour: 18}
+	weekly := models.DigestConfig{Mode: models.DigestWeekly, Hour: 9, Weekday: int(time.Friday)}
+
+	tests := []struct {
+		name   string
+		digest models.DigestConfig
+		now    time.Time
+		want   time.Time
+	}{
+		{"daily, later the same day", daily, time.Date(2025, 6, 16, 20, 0, 0, 0, paris), time.Date(2025, 6, 16, 18, 0, 0, 0, paris)},
+		{"daily, earlier the same day", daily, time.Date(2025, 6, 16, 17, 59, 0, 0, paris), time.Date(2025, 6, 15, 18, 0, 0, 0, paris)},
+		{"weekly, on the day", weekly, time.Date(2025, 6, 20, 9, 15, 0, 0, paris), time.Date(2025, 6, 20, 9, 0, 0, 0, paris)},
+		{"weekly, later in the week", weekly, time.Date(2025, 6, 23, 8, 0, 0, 0, paris), time.Date(2025, 6, 20, 9, 0, 0, 0, paris)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastDigest(tt.now, tt.digest); !got.Equal(tt.want) {
+				t.Errorf("lastDigest(%s) = %s, want %s", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderDigest(t *testing.T) {
+	cfg := &config.Config{
+		AppURL:   "https://whento.example.com",
+		Branding: config.BrandingConfig{ProductName: "WhenTo", PrimaryColor: "#4F46E5"},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	notify := NewNotifyService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, logger)
+	scheduler := NewDigestScheduler(notify, nil, logger)
+
+	participantID := uuid.New()
+	entry := func(date time.Time, transitionType string, count int) *models.DigestEntry {
+		return &models.DigestEntry{
+			ParticipantID:  &participantID,
+			Name:           "Bob",
+			Locale:         "fr",
+			CalendarURL:    "https://whento.example.com/c/abc/p/token",
+			Date:           date,
+			TransitionType: transitionType,
+			Count:          count,
+			Threshold:      4,
+		}
+	}
+	calendar := &calendarModels.Calendar{Name: "Board <games>"}
+	entries := []*models.DigestEntry{
+		entry(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC), "threshold_reached", 4),
+		entry(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC), "threshold_lost", 3),
+		entry(time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC), "threshold_reached", 5),
+	}
+
+	body, err := scheduler.render(calendar, entries)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Bonjour Bob,",
+		"Résumé des notifications de Board &lt;games&gt;",
+		"Seuil atteint pour 2025-06-20 ! (4/4 participants disponibles)",
+		"Seuil perdu pour 2025-06-20 (3/4 participants)",
+		"(5/4 participants disponibles)",
+		"https://whento.example.com/c/abc/p/token",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("digest should contain %q", want)
+		}
+	}
+	if strings.Contains(body, "<games>") {
+		t.Error("calendar names should be escaped")
+	}
+	if entries[0].RecipientID() != participantID {
+		t.Error("RecipientID() should return the participant ID")
+	}
+}
@@ -8,26 +8,47 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/mqtt"
+	"github.com/whento/whento/internal/notify/models"
+	webhookService "github.com/whento/whento/internal/webhooks/service"
 )
 
-// ExternalNotifier handles external notification channels (Discord, Slack, Telegram)
+// DefaultMQTTTopicTemplate is the topic of MQTT notifications when the owner doesn't set one
+const DefaultMQTTTopicTemplate = "whento/{calendar_id}/{event}"
+
+// ExternalNotifier handles external notification channels (Discord, Slack, Rocket.Chat, Mattermost, Telegram, MQTT, webhooks, Apprise URLs)
 type ExternalNotifier struct {
-	logger     *slog.Logger
-	httpClient *http.Client
+	productName             string
+	mqttAllowPrivateBrokers bool
+	logger                  *slog.Logger
+	httpClient              *http.Client
+	webhookClient           *http.Client              // Refuses private addresses unless allowed, as calendar webhooks
+	apprise                 map[string]AppriseService // By URL scheme, see RegisterAppriseService
 }
 
 // NewExternalNotifier creates a new external notifier
-func NewExternalNotifier(logger *slog.Logger) *ExternalNotifier {
+func NewExternalNotifier(productName string, mqttAllowPrivateBrokers, webhookAllowPrivateTargets bool, logger *slog.Logger) *ExternalNotifier {
 	return &ExternalNotifier{
-		logger: logger,
+		productName:             productName,
+		mqttAllowPrivateBrokers: mqttAllowPrivateBrokers,
+		logger:                  logger,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		webhookClient: httputil.NewOutboundClient(10*time.Second, webhookAllowPrivateTargets),
+		apprise:       defaultAppriseServices(),
 	}
 }
 
@@ -46,7 +67,7 @@ func (e *ExternalNotifier) SendDiscord(
 		"content": message,
 		"embeds": []map[string]interface{}{
 			{
-				"title":       "WhenTo Calendar Notification",
+				"title":       e.productName + " Calendar Notification",
 				"description": message,
 				"color":       5814783, // Purple color
 				"timestamp":   time.Now().Format(time.RFC3339),
@@ -128,6 +149,98 @@ func (e *ExternalNotifier) SendSlack(
 	return nil
 }
 
+// SendRocketChat sends notification via Rocket.Chat incoming webhook, with an attachment
+// linking to the calendar and showing the date and participant count
+func (e *ExternalNotifier) SendRocketChat(
+	ctx context.Context,
+	webhookURL string,
+	message string,
+	attachment models.RocketChatAttachment,
+) error {
+	if webhookURL == "" {
+		return fmt.Errorf("rocket.chat webhook URL not configured")
+	}
+
+	// Rocket.Chat webhook payload format (alias overrides the name of the integration bot)
+	payload := map[string]interface{}{
+		"alias":       e.productName,
+		"text":        message,
+		"attachments": []models.RocketChatAttachment{attachment},
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Rocket.Chat payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create Rocket.Chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Rocket.Chat notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("rocket.chat webhook returned status %d", resp.StatusCode)
+	}
+
+	e.logger.Info("Rocket.Chat notification sent successfully")
+	return nil
+}
+
+// SendMattermost sends notification via Mattermost incoming webhook, with an attachment
+// linking to the calendar and showing the date and participant count
+// Mattermost renders standard Markdown, so Slack's mrkdwn (*bold*, <url|label>) can't be reused
+func (e *ExternalNotifier) SendMattermost(
+	ctx context.Context,
+	config models.MattermostChannelConfig,
+	message string,
+	attachment models.MattermostAttachment,
+) error {
+	if config.WebhookURL == "" {
+		return fmt.Errorf("mattermost webhook URL not configured")
+	}
+
+	// Mattermost webhook payload format (username overrides the name of the webhook, if allowed by the server)
+	payload := map[string]interface{}{
+		"username":    e.productName,
+		"text":        message,
+		"attachments": []models.MattermostAttachment{attachment},
+	}
+	if config.Channel != "" {
+		payload["channel"] = config.Channel
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Mattermost payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", config.WebhookURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create Mattermost request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Mattermost notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("mattermost webhook returned status %d", resp.StatusCode)
+	}
+
+	e.logger.Info("Mattermost notification sent successfully")
+	return nil
+}
+
 // SendTelegram sends notification via Telegram bot
 func (e *ExternalNotifier) SendTelegram(
 	ctx context.Context,
@@ -173,3 +286,116 @@ func (e *ExternalNotifier) SendTelegram(
 	e.logger.Info("Telegram notification sent successfully", "chat_id", chatID)
 	return nil
 }
+
+// SendMQTT publishes a JSON payload to an MQTT broker
+func (e *ExternalNotifier) SendMQTT(
+	ctx context.Context,
+	config models.MQTTChannelConfig,
+	topic string,
+	payload models.MQTTPayload,
+) error {
+	if config.BrokerURL == "" {
+		return fmt.Errorf("MQTT broker URL not configured")
+	}
+
+	tlsConfig, err := mqttTLSConfig(config.TLS)
+	if err != nil {
+		return err
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal MQTT payload: %w", err)
+	}
+
+	err = mqtt.Publish(mqtt.Options{
+		BrokerURL:  config.BrokerURL,
+		ClientID:   "whento-" + uuid.NewString(),
+		Username:   config.Username,
+		Password:   config.Password,
+		TLSConfig:  tlsConfig,
+		PublicOnly: !e.mqttAllowPrivateBrokers,
+	}, topic, jsonPayload, config.Retain)
+	if err != nil {
+		return fmt.Errorf("failed to send MQTT notification: %w", err)
+	}
+
+	e.logger.Info("MQTT notification sent successfully", "topic", topic)
+	return nil
+}
+
+// SendWebhook POSTs a JSON payload to a generic webhook
+// With a secret, the payload is signed like calendar webhooks: X-WhenTo-Signature: t=<timestamp>,v1=<HMAC-SHA256>
+func (e *ExternalNotifier) SendWebhook(
+	ctx context.Context,
+	config models.WebhookChannelConfig,
+	payload models.WebhookPayload,
+) error {
+	if config.URL == "" {
+		return fmt.Errorf("webhook URL not configured")
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", config.URL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "WhenTo-Notify/1.0")
+	req.Header.Set(webhookService.HeaderEvent, payload.Event)
+	if config.Secret != "" {
+		req.Header.Set(webhookService.HeaderSignature, webhookService.Sign(config.Secret, time.Now().Unix(), jsonPayload))
+	}
+
+	resp, err := e.webhookClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	e.logger.Info("Webhook notification sent successfully")
+	return nil
+}
+
+// mqttTLSConfig returns the TLS configuration of a broker (nil = defaults)
+func mqttTLSConfig(config models.MQTTTLSConfig) (*tls.Config, error) {
+	if config.CACert == "" && !config.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify, // Explicitly enabled by the owner for self-signed brokers
+	}
+	if config.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(config.CACert)) {
+			return nil, fmt.Errorf("invalid MQTT CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// mqttTopic expands the placeholders of a topic template
+// Values are stripped of MQTT wildcards and level separators so they can't change the topic structure
+func mqttTopic(template string, payload models.MQTTPayload) string {
+	if template == "" {
+		template = DefaultMQTTTopicTemplate
+	}
+
+	clean := strings.NewReplacer("+", "", "#", "", "/", "-")
+	return strings.NewReplacer(
+		"{calendar_id}", clean.Replace(payload.CalendarID),
+		"{event}", clean.Replace(payload.Event),
+		"{date}", clean.Replace(payload.Date),
+	).Replace(template)
+}
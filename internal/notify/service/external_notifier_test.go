@@ -0,0 +1,222 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/whento/whento/internal/notify/models"
+	webhookService "github.com/whento/whento/internal/webhooks/service"
+)
+
+func TestMQTTTopic(t *testing.T) {
+	payload := models.MQTTPayload{
+		Event:      "threshold_reached",
+		CalendarID: "5f0c6d3e-8a7b-4c1d-9e2f-0a1b2c3d4e5f",
+		Date:       "2025-06-14",
+	}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"", "whento/5f0c6d3e-8a7b-4c1d-9e2f-0a1b2c3d4e5f/threshold_reached"},
+		{"home/events/{event}/{date}", "home/events/threshold_reached/2025-06-14"},
+		{"dashboard/whento", "dashboard/whento"},
+	}
+
+	for _, tt := range tests {
+		if got := mqttTopic(tt.template, payload); got != tt.want {
+			t.Errorf("mqttTopic(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+
+	// Placeholder values can't inject wildcards or topic levels
+	payload.Event = "a/+/#"
+	if got := mqttTopic("x/{event}", payload); got != "x/a--" {
+		t.Errorf("mqttTopic() = %q, want x/a--", got)
+	}
+}
+
+func TestMQTTTLSConfig(t *testing.T) {
+	config, err := mqttTLSConfig(models.MQTTTLSConfig{})
+	if err != nil || config != nil {
+		t.Errorf("mqttTLSConfig(default) = %v, %v, want nil (system roots)", config, err)
+	}
+
+	config, err = mqttTLSConfig(models.MQTTTLSConfig{InsecureSkipVerify: true})
+	if err != nil || config == nil || !config.InsecureSkipVerify {
+		t.Errorf("mqttTLSConfig(insecure) = %v, %v, want InsecureSkipVerify", config, err)
+	}
+
+	if _, err := mqttTLSConfig(models.MQTTTLSConfig{CACert: "not a certificate"}); err == nil {
+		t.Error("mqttTLSConfig() should reject an invalid CA certificate")
+	}
+}
+
+func TestSendRocketChat(t *testing.T) {
+	var received map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, `{"success":true}`)
+	}))
+	defer server.Close()
+
+	notifier := NewExternalNotifier("WhenTo", false, false, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	attachment := models.RocketChatAttachment{
+		Title:     "Five-a-side",
+		TitleLink: "https://whento.example.com/c/abc",
+		Color:     "#2de0a5",
+		Fields:    []models.RocketChatField{{Short: true, Title: "Date", Value: "Saturday 14 June 2025"}},
+	}
+
+	if err := notifier.SendRocketChat(context.Background(), server.URL, "Threshold reached", attachment); err != nil {
+		t.Fatalf("SendRocketChat() error = %v", err)
+	}
+
+	if string(received["alias"]) != `"WhenTo"` || string(received["text"]) != `"Threshold reached"` {
+		t.Errorf("alias = %s, text = %s", received["alias"], received["text"])
+	}
+	var attachments []models.RocketChatAttachment
+	if err := json.Unmarshal(received["attachments"], &attachments); err != nil || len(attachments) != 1 {
+		t.Fatalf("attachments = %s", received["attachments"])
+	}
+	if attachments[0].TitleLink != attachment.TitleLink || len(attachments[0].Fields) != 1 {
+		t.Errorf("attachment = %+v, want %+v", attachments[0], attachment)
+	}
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound) // Deleted integration
+	}))
+	defer rejecting.Close()
+
+	if err := notifier.SendRocketChat(context.Background(), rejecting.URL, "x", attachment); err == nil {
+		t.Error("SendRocketChat() to a rejecting webhook succeeded")
+	}
+}
+
+func TestSendMattermost(t *testing.T) {
+	var received map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	notifier := NewExternalNotifier("WhenTo", false, false, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	attachment := models.MattermostAttachment{
+		Fallback:  "Threshold reached",
+		Title:     "Five-a-side",
+		TitleLink: "https://whento.example.com/c/abc",
+		Color:     "#2de0a5",
+		Fields:    []models.MattermostField{{Short: true, Title: "Date", Value: "Saturday 14 June 2025"}},
+	}
+
+	config := models.MattermostChannelConfig{Enabled: true, WebhookURL: server.URL}
+	if err := notifier.SendMattermost(context.Background(), config, "Threshold reached", attachment); err != nil {
+		t.Fatalf("SendMattermost() error = %v", err)
+	}
+
+	if string(received["username"]) != `"WhenTo"` || string(received["text"]) != `"Threshold reached"` {
+		t.Errorf("username = %s, text = %s", received["username"], received["text"])
+	}
+	if _, ok := received["channel"]; ok {
+		t.Errorf("channel = %s, want the webhook default", received["channel"])
+	}
+	var attachments []models.MattermostAttachment
+	if err := json.Unmarshal(received["attachments"], &attachments); err != nil || len(attachments) != 1 {
+		t.Fatalf("attachments = %s", received["attachments"])
+	}
+	if attachments[0].Fallback != "Threshold reached" || attachments[0].TitleLink != attachment.TitleLink || len(attachments[0].Fields) != 1 {
+		t.Errorf("attachment = %+v, want %+v", attachments[0], attachment)
+	}
+
+	config.Channel = "town-square"
+	if err := notifier.SendMattermost(context.Background(), config, "Threshold reached", attachment); err != nil {
+		t.Fatalf("SendMattermost() error = %v", err)
+	}
+	if string(received["channel"]) != `"town-square"` {
+		t.Errorf("channel = %s, want town-square", received["channel"])
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	var body []byte
+	var signature, event string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get("X-WhenTo-Signature")
+		event = r.Header.Get("X-WhenTo-Event")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, as a Home Assistant instance on the LAN would
+	notifier := NewExternalNotifier("WhenTo", false, true, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	start := "19:00"
+	payload := models.WebhookPayload{
+		Event:        "threshold_reached",
+		CalendarID:   "5f0c6d3e-8a7b-4c1d-9e2f-0a1b2c3d4e5f",
+		Date:         "2025-06-14",
+		Count:        4,
+		Threshold:    4,
+		Level:        "hard",
+		Participants: []models.WebhookParticipant{{Name: "Alice", StartTime: &start}, {Name: "Bob", Maybe: true}},
+	}
+
+	config := models.WebhookChannelConfig{Enabled: true, URL: server.URL, Secret: "s3cret"}
+	if err := notifier.SendWebhook(context.Background(), config, payload); err != nil {
+		t.Fatalf("SendWebhook() error = %v", err)
+	}
+
+	var received models.WebhookPayload
+	if err := json.Unmarshal(body, &received); err != nil {
+		t.Fatalf("invalid payload %s: %v", body, err)
+	}
+	if received.Event != "threshold_reached" || len(received.Participants) != 2 || !received.Participants[1].Maybe {
+		t.Errorf("payload = %+v", received)
+	}
+	if event != "threshold_reached" {
+		t.Errorf("X-WhenTo-Event = %q", event)
+	}
+
+	// Receivers verify the signature as for calendar webhooks
+	var timestamp int64
+	if _, err := fmt.Sscanf(signature, "t=%d,", &timestamp); err != nil {
+		t.Fatalf("X-WhenTo-Signature = %q", signature)
+	}
+	if want := webhookService.Sign("s3cret", timestamp, body); signature != want {
+		t.Errorf("X-WhenTo-Signature = %q, want %q", signature, want)
+	}
+
+	// Without a secret, payloads aren't signed
+	config.Secret = ""
+	if err := notifier.SendWebhook(context.Background(), config, payload); err != nil {
+		t.Fatalf("SendWebhook() error = %v", err)
+	}
+	if signature != "" {
+		t.Errorf("X-WhenTo-Signature = %q without a secret", signature)
+	}
+
+	// Private addresses are refused unless allowed
+	guarded := NewExternalNotifier("WhenTo", false, false, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := guarded.SendWebhook(context.Background(), config, payload); err == nil {
+		t.Error("SendWebhook() to a loopback address succeeded with private targets refused")
+	}
+}
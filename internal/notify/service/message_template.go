@@ -0,0 +1,74 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/notify/models"
+)
+
+// defaultTemplateLocale is the key of the template used for the locales without their own
+const defaultTemplateLocale = "default"
+
+// ErrInvalidTemplate is returned for message templates that don't parse
+var ErrInvalidTemplate = errors.New("invalid message template")
+
+// ValidateMessageTemplates checks that the message templates of a calendar parse
+func ValidateMessageTemplates(templates models.MessageTemplates) error {
+	for kind, byLocale := range map[string]map[string]string{"message": templates.Message, "text": templates.Text} {
+		for locale, text := range byLocale {
+			if _, err := parseMessageTemplate(text); err != nil {
+				return fmt.Errorf("%w (%s, %s): %v", ErrInvalidTemplate, kind, locale, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseMessageTemplate parses a message template; unknown variables render as empty strings
+func parseMessageTemplate(text string) (*template.Template, error) {
+	return template.New("message").Option("missingkey=zero").Parse(text)
+}
+
+// messageTemplates returns the message templates of a calendar, if any
+func messageTemplates(calendar *calendarModels.Calendar) models.MessageTemplates {
+	var config models.NotifyConfig
+	if calendar.NotifyConfig != nil {
+		_ = json.Unmarshal([]byte(*calendar.NotifyConfig), &config)
+	}
+	return config.Templates
+}
+
+// customMessage renders the template of a locale, or the default one, with the variables of a message
+// It reports false without template, or when it fails, so that the built-in message is used instead
+func (s *NotifyService) customMessage(templates map[string]string, locale string, vars map[string]string) (string, bool) {
+	text, ok := templates[locale]
+	if !ok {
+		text = templates[defaultTemplateLocale]
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", false
+	}
+
+	tmpl, err := parseMessageTemplate(text)
+	if err != nil {
+		s.logger.Warn("Invalid message template, using the built-in message", "locale", locale, "error", err)
+		return "", false
+	}
+
+	var message strings.Builder
+	if err := tmpl.Execute(&message, vars); err != nil {
+		s.logger.Warn("Failed to render message template, using the built-in message", "locale", locale, "error", err)
+		return "", false
+	}
+	return strings.TrimSpace(message.String()), true
+}
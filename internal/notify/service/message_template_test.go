@@ -0,0 +1,94 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	pkgModels "github.com/whento/pkg/models"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/notify/models"
+)
+
+func TestValidateMessageTemplates(t *testing.T) {
+	valid := models.MessageTemplates{
+		Message: map[string]string{"default": "{{.Count}}/{{.Threshold}} for {{.Date}}"},
+		Text:    map[string]string{"fr": `{{if eq .Event "threshold_reached"}}On y va !{{else}}{{.CalendarName}}{{end}}`},
+	}
+	if err := ValidateMessageTemplates(valid); err != nil {
+		t.Errorf("ValidateMessageTemplates() = %v, want nil", err)
+	}
+
+	invalid := models.MessageTemplates{Text: map[string]string{"en": "{{if .Count}}unclosed"}}
+	if err := ValidateMessageTemplates(invalid); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("ValidateMessageTemplates() = %v, want ErrInvalidTemplate", err)
+	}
+}
+
+func TestCustomMessages(t *testing.T) {
+	cfg := &config.Config{AppURL: "https://whento.example.com"}
+	notify := NewNotifyService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	notifyConfig := `{"templates": {
+		"message": {"default": "{{.Count}} of {{.Threshold}} on {{.Date}}\n<b>Go!</b>", "fr": "{{.Count}} sur {{.Threshold}} le {{.Date}}"},
+		"text": {"default": "[{{.CalendarName}}] {{.Event}} {{.Level}} {{.Unknown}}{{.CalendarURL}}"}
+	}}`
+	calendar := &calendarModels.Calendar{Name: "Board games", PublicToken: "abc", NotifyConfig: &notifyConfig}
+	transition := &models.ThresholdTransition{
+		CalendarID:     uuid.New(),
+		Date:           time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC),
+		NewCount:       4,
+		Threshold:      4,
+		TransitionType: "threshold_reached",
+		Level:          "hard",
+	}
+
+	text := notify.buildNotificationMessage(calendar, transition, nil, "en", pkgModels.TimeFormat24h)
+	if want := "[Board games] threshold_reached hard https://whento.example.com/c/abc"; text != want {
+		t.Errorf("text message = %q, want %q", text, want)
+	}
+
+	if message := notify.transitionMessage(calendar, "fr", "message_", transition, map[string]string{"Date": "2025-06-20"}); message != "4 sur 4 le 2025-06-20" {
+		t.Errorf("French message = %q, want the French template", message)
+	}
+
+	body, err := notify.buildHTMLNotificationMessage(calendar, transition, "https://whento.example.com/c/abc", false, "de", nil, nil, pkgModels.TimeFormat24h)
+	if err != nil {
+		t.Fatalf("buildHTMLNotificationMessage() error = %v", err)
+	}
+	if !strings.Contains(body, "4 of 4 on 2025-06-20<br>&lt;b&gt;Go!&lt;/b&gt;") {
+		t.Error("email should contain the escaped default template, with its line breaks")
+	}
+
+	start := "18:00"
+	participants := []participantSlot{{Name: "<Ada>", StartTime: &start}, {Name: "Bob", Maybe: true}}
+	comments := []availabilityModels.DateComment{{ParticipantName: "Bob", Body: "Bringing <snacks>"}}
+	body, err = notify.buildHTMLNotificationMessage(calendar, transition, "https://whento.example.com/c/abc/p/xyz", true, "en", participants, comments, pkgModels.TimeFormat24h)
+	if err != nil {
+		t.Fatalf("buildHTMLNotificationMessage() error = %v", err)
+	}
+	for _, want := range []string{"&lt;Ada&gt; <span class=\"time-slot\">18:00 –</span>", `<li class="maybe">Bob`, "Bringing &lt;snacks&gt;", "?cancel=2025-06-20"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("email should contain %q", want)
+		}
+	}
+
+	// Templates that fail at runtime fall back to the built-in message
+	broken := `{"templates": {"text": {"default": "{{index .Date 99}}"}}}`
+	calendar.NotifyConfig = &broken
+	if text := notify.buildNotificationMessage(calendar, transition, nil, "en", pkgModels.TimeFormat24h); !strings.Contains(text, "Threshold reached") {
+		t.Errorf("text message = %q, want the built-in message", text)
+	}
+}
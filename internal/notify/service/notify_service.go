@@ -6,36 +6,95 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"html/template"
 	"log/slog"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/whento/pkg/email"
+	"github.com/whento/pkg/i18n"
+	pkgModels "github.com/whento/pkg/models"
 	authRepo "github.com/whento/whento/internal/auth/repository"
 	availabilityModels "github.com/whento/whento/internal/availability/models"
 	availabilityRepo "github.com/whento/whento/internal/availability/repository"
 	calendarModels "github.com/whento/whento/internal/calendar/models"
 	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	hookModels "github.com/whento/whento/internal/hooks/models"
 	"github.com/whento/whento/internal/notify/models"
 	notifyRepo "github.com/whento/whento/internal/notify/repository"
+	pushModels "github.com/whento/whento/internal/push/models"
 )
 
+//go:embed templates/locales/notification_message.json
+var notificationMessageTranslations string
+
+//go:embed templates/threshold_notification.html
+var thresholdNotificationTemplate string
+
+// ErrNoChannelConfigured is returned when a test notification has no enabled channel to go through
+var ErrNoChannelConfigured = errors.New("no notification channel configured")
+
+// EventPublisher forwards calendar events to integrations (REST hooks, Home Assistant)
+type EventPublisher interface {
+	Publish(ctx context.Context, ownerID, calendarID uuid.UUID, eventType string, data any)
+}
+
+// EventPublishers forwards calendar events to several integrations
+type EventPublishers []EventPublisher
+
+// Publish forwards an event to each integration
+func (p EventPublishers) Publish(ctx context.Context, ownerID, calendarID uuid.UUID, eventType string, data any) {
+	for _, publisher := range p {
+		publisher.Publish(ctx, ownerID, calendarID, eventType, data)
+	}
+}
+
+// PushNotifier delivers Web Push notifications to the browsers of users and participants
+type PushNotifier interface {
+	Enabled() bool
+	NotifyUser(ctx context.Context, userID uuid.UUID, msg pushModels.Message) (int, error)
+	NotifyParticipant(ctx context.Context, participantID uuid.UUID, msg pushModels.Message) (int, error)
+}
+
 // NotifyService orchestrates notification sending
 type NotifyService struct {
-	calendarRepo     *calendarRepo.CalendarRepository
-	participantRepo  *calendarRepo.ParticipantRepository
-	availabilityRepo *availabilityRepo.AvailabilityRepository
-	userRepo         *authRepo.UserRepository
-	notificationLog  *notifyRepo.NotificationLogRepository
-	emailService     *email.Service
-	externalNotifier *ExternalNotifier
-	detector         *ThresholdDetector
-	appURL           string
-	logger           *slog.Logger
+	calendarRepo      *calendarRepo.CalendarRepository
+	participantRepo   *calendarRepo.ParticipantRepository
+	availabilityRepo  *availabilityRepo.AvailabilityRepository
+	userRepo          *authRepo.UserRepository
+	notificationLog   *notifyRepo.NotificationLogRepository
+	confirmations     *calendarRepo.ConfirmationRepository
+	digests           *notifyRepo.DigestRepository
+	outbox            *notifyRepo.OutboxRepository // nil = notifications are sent right away, without retries
+	outboxWake        chan struct{}
+	emailService      *email.Service
+	externalNotifier  *ExternalNotifier
+	detector          *ThresholdDetector
+	events            EventPublisher // nil = no integrations
+	push              PushNotifier   // nil = no Web Push
+	appURL            string
+	formatsMu         sync.RWMutex
+	timeFormat        string // Instance default, overridden by calendar and user preferences
+	dateFormat        string // Instance default, overridden by calendar preferences
+	branding          config.BrandingConfig
+	translations      i18n.Translations
+	confirmTemplate   *template.Template
+	thresholdTemplate *template.Template
+	emailTemplateDir  string
+	logger            *slog.Logger
 }
 
 // NewNotifyService creates a new notification service
@@ -45,34 +104,110 @@ func NewNotifyService(
 	availabilityRepo *availabilityRepo.AvailabilityRepository,
 	userRepo *authRepo.UserRepository,
 	notificationLog *notifyRepo.NotificationLogRepository,
+	confirmations *calendarRepo.ConfirmationRepository,
+	digests *notifyRepo.DigestRepository,
+	outbox *notifyRepo.OutboxRepository,
 	emailService *email.Service,
 	externalNotifier *ExternalNotifier,
 	detector *ThresholdDetector,
-	appURL string,
+	events EventPublisher,
+	push PushNotifier,
+	cfg *config.Config,
 	logger *slog.Logger,
 ) *NotifyService {
+	// Load notification translations (with optional self-hosted overrides)
+	translations, err := i18n.Load(notificationMessageTranslations, cfg.TranslationsDir, "notification_message")
+	if err != nil {
+		logger.Error("Failed to load notification translations", "error", err)
+	}
+	translations = translations.WithVar("ProductName", cfg.Branding.ProductName)
+
+	// Parse email templates (with optional self-hosted overrides)
+	source, err := email.LoadTemplate(eventConfirmationTemplate, cfg.EmailTemplateDir, "event_confirmation")
+	if err != nil {
+		logger.Error("Failed to load event confirmation template override", "error", err)
+	}
+	confirmTemplate, err := template.New("event_confirmation").Parse(source)
+	if err != nil {
+		logger.Error("Failed to parse event confirmation template", "error", err)
+	}
+
+	source, err = email.LoadTemplate(thresholdNotificationTemplate, cfg.EmailTemplateDir, "threshold_notification")
+	if err != nil {
+		logger.Error("Failed to load threshold notification template override", "error", err)
+	}
+	thresholdTemplate, err := template.New("threshold_notification").Parse(source)
+	if err != nil {
+		logger.Error("Failed to parse threshold notification template", "error", err)
+	}
+
 	return &NotifyService{
-		calendarRepo:     calendarRepo,
-		participantRepo:  participantRepo,
-		availabilityRepo: availabilityRepo,
-		userRepo:         userRepo,
-		notificationLog:  notificationLog,
-		emailService:     emailService,
-		externalNotifier: externalNotifier,
-		detector:         detector,
-		appURL:           appURL,
-		logger:           logger,
+		calendarRepo:      calendarRepo,
+		participantRepo:   participantRepo,
+		availabilityRepo:  availabilityRepo,
+		userRepo:          userRepo,
+		notificationLog:   notificationLog,
+		confirmations:     confirmations,
+		digests:           digests,
+		outbox:            outbox,
+		outboxWake:        make(chan struct{}, 1),
+		emailService:      emailService,
+		externalNotifier:  externalNotifier,
+		detector:          detector,
+		events:            events,
+		push:              push,
+		appURL:            cfg.AppURL,
+		timeFormat:        cfg.TimeFormat,
+		dateFormat:        cfg.DateFormat,
+		branding:          cfg.Branding,
+		translations:      translations,
+		confirmTemplate:   confirmTemplate,
+		thresholdTemplate: thresholdTemplate,
+		emailTemplateDir:  cfg.EmailTemplateDir,
+		logger:            logger,
 	}
 }
 
+// SetDefaultFormats replaces the instance default time and date formats (TIME_FORMAT, DATE_FORMAT)
+func (s *NotifyService) SetDefaultFormats(timeFormat, dateFormat string) {
+	s.formatsMu.Lock()
+	defer s.formatsMu.Unlock()
+	s.timeFormat = timeFormat
+	s.dateFormat = dateFormat
+}
+
+// defaultTimeFormat returns the instance default time format
+func (s *NotifyService) defaultTimeFormat() string {
+	s.formatsMu.RLock()
+	defer s.formatsMu.RUnlock()
+	return s.timeFormat
+}
+
+// defaultDateFormat returns the instance default date format
+func (s *NotifyService) defaultDateFormat() string {
+	s.formatsMu.RLock()
+	defer s.formatsMu.RUnlock()
+	return s.dateFormat
+}
+
 // emailRecipient holds information about an email notification recipient
 type emailRecipient struct {
 	Email         string
 	Name          string
 	Locale        string
 	ParticipantID *uuid.UUID // nil for owner-only, set for participants
+	AccessToken   string     // secret of the participant link, set with ParticipantID
 	RecipientID   uuid.UUID  // user ID for owner, participant ID for participants
 	IsOwner       bool
+	TimeFormat    pkgModels.TimeFormat
+}
+
+// participantSlot holds a participant name and their time slot for display in notifications
+type participantSlot struct {
+	Name      string
+	StartTime *string
+	EndTime   *string
+	Maybe     bool // Tentative answer
 }
 
 // CheckThresholdAndNotify is the main entry point called from availability service
@@ -80,12 +215,13 @@ func (s *NotifyService) CheckThresholdAndNotify(
 	ctx context.Context,
 	calendarID uuid.UUID,
 	date time.Time,
-	previousCount int, // Pass -1 if unknown
+	previousCount availabilityModels.DateCount, // Pass a Count of -1 if unknown
 ) error {
 	s.logger.Debug("CheckThresholdAndNotify called",
 		"calendar_id", calendarID,
 		"date", date.Format("2006-01-02"),
-		"previous_count", previousCount)
+		"previous_count", previousCount.Count,
+		"previous_required_missing", previousCount.RequiredMissing)
 
 	// Get calendar with notify config
 	calendar, err := s.calendarRepo.GetByID(ctx, calendarID)
@@ -99,8 +235,25 @@ func (s *NotifyService) CheckThresholdAndNotify(
 		"notify_on_threshold", calendar.NotifyOnThreshold,
 		"has_notify_config", calendar.NotifyConfig != nil)
 
-	// Check if notifications enabled
-	if !calendar.NotifyOnThreshold || calendar.NotifyConfig == nil {
+	// Check if notifications enabled (transitions are still detected for integrations)
+	var config models.NotifyConfig
+	notificationsEnabled := calendar.NotifyOnThreshold && calendar.NotifyConfig != nil
+	if notificationsEnabled {
+		if err := json.Unmarshal([]byte(*calendar.NotifyConfig), &config); err != nil {
+			s.logger.Error("Failed to parse notify config", "calendar_id", calendarID, "error", err)
+			return fmt.Errorf("failed to parse notify config: %w", err)
+		}
+
+		s.logger.Debug("Notify config parsed",
+			"calendar_id", calendarID,
+			"enabled", config.Enabled,
+			"notify_owner", config.NotifyOwner,
+			"notify_participants", config.NotifyParticipants)
+
+		notificationsEnabled = config.Enabled
+	}
+
+	if !notificationsEnabled && s.events == nil && !calendar.RequireConfirm {
 		s.logger.Debug("Notifications disabled for calendar",
 			"calendar_id", calendarID,
 			"notify_on_threshold", calendar.NotifyOnThreshold,
@@ -108,26 +261,8 @@ func (s *NotifyService) CheckThresholdAndNotify(
 		return nil // Notifications disabled
 	}
 
-	// Parse notify config
-	var config models.NotifyConfig
-	if err := json.Unmarshal([]byte(*calendar.NotifyConfig), &config); err != nil {
-		s.logger.Error("Failed to parse notify config", "calendar_id", calendarID, "error", err)
-		return fmt.Errorf("failed to parse notify config: %w", err)
-	}
-
-	s.logger.Debug("Notify config parsed",
-		"calendar_id", calendarID,
-		"enabled", config.Enabled,
-		"notify_owner", config.NotifyOwner,
-		"notify_participants", config.NotifyParticipants)
-
-	if !config.Enabled {
-		s.logger.Debug("Notifications disabled in config", "calendar_id", calendarID)
-		return nil
-	}
-
 	// Detect threshold transition
-	transition, err := s.detector.DetectTransition(ctx, calendarID, date, calendar.Threshold, previousCount)
+	transition, err := s.detector.DetectTransition(ctx, calendarID, date, calendar.Threshold, calendar.SoftThreshold, previousCount)
 	if err != nil {
 		s.logger.Error("Failed to detect threshold transition", "calendar_id", calendarID, "error", err)
 		return err
@@ -146,6 +281,31 @@ func (s *NotifyService) CheckThresholdAndNotify(
 		return nil
 	}
 
+	// Dates of calendars requiring confirmation are proposed to the owner, once
+	if calendar.RequireConfirm && transition.TransitionType == "threshold_reached" {
+		if err := s.proposeEvent(ctx, calendar, transition.Date); err != nil {
+			s.logger.Error("Failed to propose event", "calendar_id", calendarID, "date", transition.Date.Format("2006-01-02"), "error", err)
+		}
+	}
+
+	// Integrations only receive real transitions: without a previous count, "reached" only means "met"
+	// and would be sent again on every change. Notifications are deduplicated by the notification log
+	if s.events != nil && transition.PreviousCount >= 0 {
+		s.events.Publish(ctx, calendar.OwnerID, calendar.ID, transition.TransitionType, hookModels.ThresholdEventData{
+			CalendarName: calendar.Name,
+			CalendarURL:  fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken),
+			Date:         transition.Date.Format("2006-01-02"),
+			Count:        transition.NewCount,
+			Threshold:    transition.Threshold,
+			Level:        transition.Level,
+		})
+	}
+
+	if !notificationsEnabled {
+		s.logger.Debug("Notifications disabled for calendar", "calendar_id", calendarID)
+		return nil
+	}
+
 	s.logger.Info("Threshold transition detected - SENDING NOTIFICATIONS",
 		"calendar_id", calendarID,
 		"date", date.Format("2006-01-02"),
@@ -154,7 +314,7 @@ func (s *NotifyService) CheckThresholdAndNotify(
 		"threshold", calendar.Threshold)
 
 	// Send notifications to recipients
-	// First handle external notifications (Discord, Slack, Telegram) - owner only
+	// First handle external notifications (Discord, Slack, Rocket.Chat, Mattermost, Telegram, MQTT, webhook, Apprise) - owner only
 	if config.NotifyOwner {
 		s.logger.Debug("Sending external notifications to owner", "calendar_id", calendarID)
 		if err := s.notifyOwnerExternalChannels(ctx, calendar, transition, config); err != nil {
@@ -168,12 +328,75 @@ func (s *NotifyService) CheckThresholdAndNotify(
 		if err := s.sendDeduplicatedEmailNotifications(ctx, calendar, transition, config); err != nil {
 			s.logger.Error("Failed to send deduplicated email notifications", "calendar_id", calendarID, "error", err)
 		}
+
+		// Web Push to the subscribed browsers, deduplicated per recipient like emails
+		s.sendPushNotifications(ctx, calendar, transition, config)
+	}
+
+	return nil
+}
+
+// NotifyActivity notifies the owner of a participant adding, changing or removing an availability, when
+// activity notifications are enabled. Unlike threshold transitions, they are sent at most once an hour per
+// calendar: the first change is reported, the following ones are left to the calendar view
+func (s *NotifyService) NotifyActivity(
+	ctx context.Context,
+	calendarID uuid.UUID,
+	date time.Time,
+	activity models.Activity,
+) error {
+	calendar, err := s.calendarRepo.GetByID(ctx, calendarID)
+	if err != nil {
+		s.logger.Error("Failed to get calendar for activity notification", "calendar_id", calendarID, "error", err)
+		return err
+	}
+
+	if !calendar.NotifyOnThreshold || calendar.NotifyConfig == nil {
+		return nil
+	}
+	var config models.NotifyConfig
+	if err := json.Unmarshal([]byte(*calendar.NotifyConfig), &config); err != nil {
+		s.logger.Error("Failed to parse notify config", "calendar_id", calendarID, "error", err)
+		return fmt.Errorf("failed to parse notify config: %w", err)
+	}
+	if !config.Enabled || !config.NotifyOwner || !config.Activity.Enabled {
+		return nil
+	}
+
+	sent, err := s.notificationLog.WasEventSentRecently(ctx, calendar.ID, "activity", calendar.OwnerID)
+	if err != nil {
+		s.logger.Error("Failed to check notification log", "calendar_id", calendarID, "error", err)
+	}
+	if sent {
+		s.logger.Debug("Activity notification already sent recently", "calendar_id", calendarID)
+		return nil
+	}
+
+	transition, err := s.detector.DetectActivity(ctx, calendarID, date, calendar.Threshold, activity)
+	if err != nil {
+		s.logger.Error("Failed to detect availability activity", "calendar_id", calendarID, "error", err)
+		return err
 	}
 
+	s.logger.Info("Availability activity - SENDING NOTIFICATIONS",
+		"calendar_id", calendarID,
+		"date", date.Format("2006-01-02"),
+		"action", activity.Action)
+
+	// Activity only concerns the owner, on all their channels
+	config.NotifyParticipants = false
+	if err := s.notifyOwnerExternalChannels(ctx, calendar, transition, config); err != nil {
+		s.logger.Error("Failed to send external notifications to owner", "calendar_id", calendarID, "error", err)
+	}
+	if err := s.sendDeduplicatedEmailNotifications(ctx, calendar, transition, config); err != nil {
+		s.logger.Error("Failed to send deduplicated email notifications", "calendar_id", calendarID, "error", err)
+	}
+	s.sendPushNotifications(ctx, calendar, transition, config)
+
 	return nil
 }
 
-// notifyOwnerExternalChannels sends external notifications (Discord, Slack, Telegram) to calendar owner
+// notifyOwnerExternalChannels sends external notifications (Discord, Slack, Rocket.Chat, Mattermost, Telegram, MQTT, webhook, Apprise) to calendar owner
 // Email notifications are handled separately via sendDeduplicatedEmailNotifications
 func (s *NotifyService) notifyOwnerExternalChannels(
 	ctx context.Context,
@@ -192,8 +415,16 @@ func (s *NotifyService) notifyOwnerExternalChannels(
 
 	s.logger.Debug("Owner retrieved for external notifications", "owner_id", owner.ID)
 
+	// Get availabilities to show the common time slot (optional, message is sent without it on error)
+	availabilities, err := s.availabilityRepo.GetByDate(ctx, calendar.ID, transition.Date)
+	if err != nil {
+		s.logger.Error("Failed to get availabilities for date", "calendar_id", calendar.ID, "date", transition.Date, "error", err)
+		availabilities = []*availabilityModels.Availability{}
+	}
+
 	// Build notification message for external channels (text-only)
-	textMessage := s.buildNotificationMessage(calendar, transition)
+	timeFormat := pkgModels.ResolveTimeFormat(s.defaultTimeFormat(), owner.TimeFormat, calendar.TimeFormat)
+	textMessage := s.buildNotificationMessage(calendar, transition, availabilities, owner.Locale, timeFormat)
 
 	s.logger.Debug("Checking Discord channel",
 		"enabled", config.Channels.Discord.Enabled,
@@ -201,18 +432,14 @@ func (s *NotifyService) notifyOwnerExternalChannels(
 
 	if config.Channels.Discord.Enabled && config.Channels.Discord.WebhookURL != "" {
 		sent, _ := s.notificationLog.WasNotificationSentRecently(
-			ctx, calendar.ID, transition.Date, transition.TransitionType, owner.ID, "discord",
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, owner.ID, "discord",
 		)
 		if !sent {
-			s.logger.Info("Sending Discord notification", "webhook_url", config.Channels.Discord.WebhookURL[:20]+"...")
-			if err := s.externalNotifier.SendDiscord(ctx, config.Channels.Discord.WebhookURL, textMessage); err != nil {
-				s.logger.Error("Failed to send Discord notification", "error", err)
-			} else {
-				s.logger.Info("Discord notification sent successfully")
-				_ = s.notificationLog.LogNotification(
-					ctx, calendar.ID, transition.Date, transition.TransitionType, "owner", owner.ID, "discord",
-				)
-			}
+			s.logger.Info("Queuing Discord notification", "webhook_url", config.Channels.Discord.WebhookURL[:20]+"...")
+			s.queue(ctx, calendar, transition, "owner", owner.ID, "discord", models.OutboxPayload{
+				Text:    textMessage,
+				Discord: &config.Channels.Discord,
+			})
 		} else {
 			s.logger.Debug("Discord notification already sent recently")
 		}
@@ -226,18 +453,14 @@ func (s *NotifyService) notifyOwnerExternalChannels(
 
 	if config.Channels.Slack.Enabled && config.Channels.Slack.WebhookURL != "" {
 		sent, _ := s.notificationLog.WasNotificationSentRecently(
-			ctx, calendar.ID, transition.Date, transition.TransitionType, owner.ID, "slack",
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, owner.ID, "slack",
 		)
 		if !sent {
-			s.logger.Info("Sending Slack notification", "webhook_url", config.Channels.Slack.WebhookURL[:20]+"...")
-			if err := s.externalNotifier.SendSlack(ctx, config.Channels.Slack.WebhookURL, textMessage); err != nil {
-				s.logger.Error("Failed to send Slack notification", "error", err)
-			} else {
-				s.logger.Info("Slack notification sent successfully")
-				_ = s.notificationLog.LogNotification(
-					ctx, calendar.ID, transition.Date, transition.TransitionType, "owner", owner.ID, "slack",
-				)
-			}
+			s.logger.Info("Queuing Slack notification", "webhook_url", config.Channels.Slack.WebhookURL[:20]+"...")
+			s.queue(ctx, calendar, transition, "owner", owner.ID, "slack", models.OutboxPayload{
+				Text:  textMessage,
+				Slack: &config.Channels.Slack,
+			})
 		} else {
 			s.logger.Debug("Slack notification already sent recently")
 		}
@@ -245,6 +468,54 @@ func (s *NotifyService) notifyOwnerExternalChannels(
 		s.logger.Debug("Slack channel disabled or webhook not configured")
 	}
 
+	s.logger.Debug("Checking Rocket.Chat channel",
+		"enabled", config.Channels.RocketChat.Enabled,
+		"has_webhook", config.Channels.RocketChat.WebhookURL != "")
+
+	if config.Channels.RocketChat.Enabled && config.Channels.RocketChat.WebhookURL != "" {
+		sent, _ := s.notificationLog.WasNotificationSentRecently(
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, owner.ID, "rocketchat",
+		)
+		if !sent {
+			s.logger.Info("Queuing Rocket.Chat notification")
+			s.queue(ctx, calendar, transition, "owner", owner.ID, "rocketchat", models.OutboxPayload{
+				Text: textMessage,
+				RocketChat: &models.OutboxRocketChat{
+					Config:     config.Channels.RocketChat,
+					Attachment: s.rocketChatAttachment(calendar, transition, owner.Locale),
+				},
+			})
+		} else {
+			s.logger.Debug("Rocket.Chat notification already sent recently")
+		}
+	} else {
+		s.logger.Debug("Rocket.Chat channel disabled or webhook not configured")
+	}
+
+	s.logger.Debug("Checking Mattermost channel",
+		"enabled", config.Channels.Mattermost.Enabled,
+		"has_webhook", config.Channels.Mattermost.WebhookURL != "")
+
+	if config.Channels.Mattermost.Enabled && config.Channels.Mattermost.WebhookURL != "" {
+		sent, _ := s.notificationLog.WasNotificationSentRecently(
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, owner.ID, "mattermost",
+		)
+		if !sent {
+			s.logger.Info("Queuing Mattermost notification")
+			s.queue(ctx, calendar, transition, "owner", owner.ID, "mattermost", models.OutboxPayload{
+				Text: textMessage,
+				Mattermost: &models.OutboxMattermost{
+					Config:     config.Channels.Mattermost,
+					Attachment: s.mattermostAttachment(calendar, transition, textMessage, owner.Locale),
+				},
+			})
+		} else {
+			s.logger.Debug("Mattermost notification already sent recently")
+		}
+	} else {
+		s.logger.Debug("Mattermost channel disabled or webhook not configured")
+	}
+
 	s.logger.Debug("Checking Telegram channel",
 		"enabled", config.Channels.Telegram.Enabled,
 		"has_token", config.Channels.Telegram.BotToken != "",
@@ -252,20 +523,14 @@ func (s *NotifyService) notifyOwnerExternalChannels(
 
 	if config.Channels.Telegram.Enabled && config.Channels.Telegram.BotToken != "" && config.Channels.Telegram.ChatID != "" {
 		sent, _ := s.notificationLog.WasNotificationSentRecently(
-			ctx, calendar.ID, transition.Date, transition.TransitionType, owner.ID, "telegram",
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, owner.ID, "telegram",
 		)
 		if !sent {
-			s.logger.Info("Sending Telegram notification", "chat_id", config.Channels.Telegram.ChatID)
-			if err := s.externalNotifier.SendTelegram(
-				ctx, config.Channels.Telegram.BotToken, config.Channels.Telegram.ChatID, textMessage,
-			); err != nil {
-				s.logger.Error("Failed to send Telegram notification", "error", err)
-			} else {
-				s.logger.Info("Telegram notification sent successfully")
-				_ = s.notificationLog.LogNotification(
-					ctx, calendar.ID, transition.Date, transition.TransitionType, "owner", owner.ID, "telegram",
-				)
-			}
+			s.logger.Info("Queuing Telegram notification", "chat_id", config.Channels.Telegram.ChatID)
+			s.queue(ctx, calendar, transition, "owner", owner.ID, "telegram", models.OutboxPayload{
+				Text:     textMessage,
+				Telegram: &config.Channels.Telegram,
+			})
 		} else {
 			s.logger.Debug("Telegram notification already sent recently")
 		}
@@ -273,10 +538,331 @@ func (s *NotifyService) notifyOwnerExternalChannels(
 		s.logger.Debug("Telegram channel disabled or credentials not configured")
 	}
 
+	s.logger.Debug("Checking MQTT channel",
+		"enabled", config.Channels.MQTT.Enabled,
+		"has_broker", config.Channels.MQTT.BrokerURL != "")
+
+	if config.Channels.MQTT.Enabled && config.Channels.MQTT.BrokerURL != "" {
+		sent, _ := s.notificationLog.WasNotificationSentRecently(
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, owner.ID, "mqtt",
+		)
+		if !sent {
+			payload := s.mqttPayload(calendar, transition)
+			topic := mqttTopic(config.Channels.MQTT.TopicTemplate, payload)
+			s.logger.Info("Queuing MQTT notification", "topic", topic)
+			s.queue(ctx, calendar, transition, "owner", owner.ID, "mqtt", models.OutboxPayload{
+				MQTT: &models.OutboxMQTT{Config: config.Channels.MQTT, Topic: topic, Payload: payload},
+			})
+		} else {
+			s.logger.Debug("MQTT notification already sent recently")
+		}
+	} else {
+		s.logger.Debug("MQTT channel disabled or broker not configured")
+	}
+
+	s.logger.Debug("Checking webhook channel",
+		"enabled", config.Channels.Webhook.Enabled,
+		"has_url", config.Channels.Webhook.URL != "")
+
+	if config.Channels.Webhook.Enabled && config.Channels.Webhook.URL != "" {
+		sent, _ := s.notificationLog.WasNotificationSentRecently(
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, owner.ID, "webhook",
+		)
+		if !sent {
+			s.logger.Info("Queuing webhook notification")
+			s.queue(ctx, calendar, transition, "owner", owner.ID, "webhook", models.OutboxPayload{
+				Webhook: &models.OutboxWebhook{
+					Config:  config.Channels.Webhook,
+					Payload: s.webhookPayload(calendar, transition, s.webhookParticipants(ctx, calendar, availabilities)),
+				},
+			})
+		} else {
+			s.logger.Debug("Webhook notification already sent recently")
+		}
+	} else {
+		s.logger.Debug("Webhook channel disabled or URL not configured")
+	}
+
+	s.logger.Debug("Checking Apprise channel",
+		"enabled", config.Channels.Apprise.Enabled,
+		"urls", len(config.Channels.Apprise.URLs))
+
+	if config.Channels.Apprise.Enabled && len(config.Channels.Apprise.URLs) > 0 {
+		sent, _ := s.notificationLog.WasNotificationSentRecently(
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, owner.ID, "apprise",
+		)
+		if !sent {
+			s.logger.Info("Queuing Apprise notification", "urls", len(config.Channels.Apprise.URLs))
+			// One notification per URL, so that retrying a failing URL doesn't notify the others twice
+			for _, appriseURL := range config.Channels.Apprise.URLs {
+				s.queue(ctx, calendar, transition, "owner", owner.ID, "apprise", models.OutboxPayload{
+					Text:    textMessage,
+					Apprise: &models.OutboxApprise{URL: appriseURL, Title: calendar.Name},
+				})
+			}
+		} else {
+			s.logger.Debug("Apprise notification already sent recently")
+		}
+	} else {
+		s.logger.Debug("Apprise channel disabled or no URL configured")
+	}
+
 	s.logger.Debug("notifyOwnerExternalChannels completed")
 	return nil
 }
 
+// ValidateAppriseURLs checks that each Apprise URL names a supported service with the parts it needs
+func (s *NotifyService) ValidateAppriseURLs(urls []string) error {
+	for i, raw := range urls {
+		if err := s.externalNotifier.ValidateAppriseURL(raw); err != nil {
+			return fmt.Errorf("apprise URL %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// SendTestNotification sends a synthetic threshold_reached notification for today through every
+// configured channel, so owners can check their setup without touching real availabilities
+// Only the owner receives it, and it isn't written to the notification log so real notifications
+// aren't deduplicated against it
+func (s *NotifyService) SendTestNotification(
+	ctx context.Context,
+	calendar *calendarModels.Calendar,
+) ([]models.ChannelTestResult, error) {
+	if calendar.NotifyConfig == nil || *calendar.NotifyConfig == "" {
+		return nil, ErrNoChannelConfigured
+	}
+
+	var config models.NotifyConfig
+	if err := json.Unmarshal([]byte(*calendar.NotifyConfig), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse notify config: %w", err)
+	}
+
+	owner, err := s.userRepo.GetByID(ctx, calendar.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner: %w", err)
+	}
+
+	transition := &models.ThresholdTransition{
+		CalendarID:     calendar.ID,
+		Date:           today(calendar.Timezone),
+		PreviousCount:  calendar.Threshold - 1,
+		NewCount:       calendar.Threshold,
+		Threshold:      calendar.Threshold,
+		TransitionType: "threshold_reached",
+		Level:          availabilityModels.LevelHard,
+	}
+
+	timeFormat := pkgModels.ResolveTimeFormat(s.defaultTimeFormat(), owner.TimeFormat, calendar.TimeFormat)
+	textMessage := s.translate(owner.Locale, "test_text", nil) + "\n\n" +
+		s.buildNotificationMessage(calendar, transition, nil, owner.Locale, timeFormat)
+
+	var results []models.ChannelTestResult
+	record := func(channel string, err error) {
+		result := models.ChannelTestResult{Channel: channel, Sent: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+			s.logger.Warn("Test notification failed", "calendar_id", calendar.ID, "channel", channel, "error", err)
+		}
+		results = append(results, result)
+	}
+
+	channels := config.Channels
+	if channels.Email.Enabled {
+		if !s.emailService.IsConfigured() {
+			record("email", fmt.Errorf("SMTP is not configured on this server"))
+		} else if body, err := s.buildHTMLNotificationMessage(calendar, transition, fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken), false, owner.Locale, nil, nil, timeFormat); err != nil {
+			record("email", err)
+		} else {
+			record("email", s.emailService.SendNow(email.Email{
+				To:      []string{owner.Email},
+				Subject: s.translate(owner.Locale, "test_subject", nil),
+				Body:    body,
+				HTML:    true,
+			}))
+		}
+	}
+	if channels.Discord.Enabled && channels.Discord.WebhookURL != "" {
+		record("discord", s.externalNotifier.SendDiscord(ctx, channels.Discord.WebhookURL, textMessage))
+	}
+	if channels.Slack.Enabled && channels.Slack.WebhookURL != "" {
+		record("slack", s.externalNotifier.SendSlack(ctx, channels.Slack.WebhookURL, textMessage))
+	}
+	if channels.RocketChat.Enabled && channels.RocketChat.WebhookURL != "" {
+		attachment := s.rocketChatAttachment(calendar, transition, owner.Locale)
+		record("rocketchat", s.externalNotifier.SendRocketChat(ctx, channels.RocketChat.WebhookURL, textMessage, attachment))
+	}
+	if channels.Mattermost.Enabled && channels.Mattermost.WebhookURL != "" {
+		attachment := s.mattermostAttachment(calendar, transition, textMessage, owner.Locale)
+		record("mattermost", s.externalNotifier.SendMattermost(ctx, channels.Mattermost, textMessage, attachment))
+	}
+	if channels.Telegram.Enabled && channels.Telegram.BotToken != "" && channels.Telegram.ChatID != "" {
+		record("telegram", s.externalNotifier.SendTelegram(ctx, channels.Telegram.BotToken, channels.Telegram.ChatID, textMessage))
+	}
+	if channels.MQTT.Enabled && channels.MQTT.BrokerURL != "" {
+		payload := s.mqttPayload(calendar, transition)
+		payload.Test = true
+		record("mqtt", s.externalNotifier.SendMQTT(ctx, channels.MQTT, mqttTopic(channels.MQTT.TopicTemplate, payload), payload))
+	}
+	if channels.Webhook.Enabled && channels.Webhook.URL != "" {
+		payload := s.webhookPayload(calendar, transition, []models.WebhookParticipant{})
+		payload.Test = true
+		record("webhook", s.externalNotifier.SendWebhook(ctx, channels.Webhook, payload))
+	}
+	if channels.Apprise.Enabled && len(channels.Apprise.URLs) > 0 {
+		_, err := s.externalNotifier.SendApprise(ctx, channels.Apprise.URLs, AppriseMessage{Title: calendar.Name, Body: textMessage})
+		record("apprise", err)
+	}
+
+	if channels.Push.Enabled {
+		record("push", s.sendTestPush(ctx, calendar, transition, owner.ID, owner.Locale, timeFormat))
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoChannelConfigured
+	}
+
+	s.logger.Info("Test notification sent", "calendar_id", calendar.ID, "channels", len(results))
+	return results, nil
+}
+
+// mqttPayload builds the MQTT message of a threshold transition
+func (s *NotifyService) mqttPayload(calendar *calendarModels.Calendar, transition *models.ThresholdTransition) models.MQTTPayload {
+	return models.MQTTPayload{
+		Event:        transition.TransitionType,
+		CalendarID:   calendar.ID.String(),
+		CalendarName: calendar.Name,
+		CalendarURL:  fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken),
+		Date:         transition.Date.Format("2006-01-02"),
+		Count:        transition.NewCount,
+		Threshold:    transition.Threshold,
+		Level:        transition.Level,
+		Timestamp:    time.Now().UTC(),
+	}
+}
+
+// webhookPayload builds the generic webhook body of a threshold transition
+func (s *NotifyService) webhookPayload(
+	calendar *calendarModels.Calendar,
+	transition *models.ThresholdTransition,
+	participants []models.WebhookParticipant,
+) models.WebhookPayload {
+	return models.WebhookPayload{
+		Event:         transition.TransitionType,
+		CalendarID:    calendar.ID.String(),
+		CalendarName:  calendar.Name,
+		CalendarURL:   fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken),
+		Date:          transition.Date.Format("2006-01-02"),
+		PreviousCount: transition.PreviousCount,
+		Count:         transition.NewCount,
+		Threshold:     transition.Threshold,
+		Level:         transition.Level,
+		Participants:  participants,
+		Timestamp:     time.Now().UTC(),
+	}
+}
+
+// webhookParticipants returns the participants available on the date of a transition, in calendar order
+// The payload is sent without participants if they can't be loaded
+func (s *NotifyService) webhookParticipants(
+	ctx context.Context,
+	calendar *calendarModels.Calendar,
+	availabilities []*availabilityModels.Availability,
+) []models.WebhookParticipant {
+	participants := []models.WebhookParticipant{}
+	if len(availabilities) == 0 {
+		return participants
+	}
+
+	availabilityByParticipant := make(map[uuid.UUID]*availabilityModels.Availability, len(availabilities))
+	for _, availability := range availabilities {
+		availabilityByParticipant[availability.ParticipantID] = availability
+	}
+
+	all, err := s.participantRepo.GetByCalendarID(ctx, calendar.ID)
+	if err != nil {
+		s.logger.Error("Failed to get participants for webhook", "calendar_id", calendar.ID, "error", err)
+		return participants
+	}
+	for _, p := range all {
+		if availability, ok := availabilityByParticipant[p.ID]; ok {
+			participants = append(participants, models.WebhookParticipant{
+				Name:      p.Name,
+				StartTime: availability.StartTime,
+				EndTime:   availability.EndTime,
+				Maybe:     availability.Status == availabilityModels.StatusMaybe,
+			})
+		}
+	}
+	return participants
+}
+
+// rocketChatAttachment builds the card of a Rocket.Chat notification
+func (s *NotifyService) rocketChatAttachment(
+	calendar *calendarModels.Calendar,
+	transition *models.ThresholdTransition,
+	locale string,
+) models.RocketChatAttachment {
+	color := "#2de0a5" // Green
+	switch transition.TransitionType {
+	case "threshold_lost", "soft_threshold_lost":
+		color = "#f5455c" // Red
+	case "soft_threshold_reached":
+		color = "#ffd21f" // Yellow
+	case "activity":
+		color = "#4f9ef8" // Blue
+	}
+
+	// Labels end with a colon (" :" in French), which Rocket.Chat field titles don't need
+	label := func(key string) string {
+		return strings.TrimRight(s.translate(locale, key, nil), " :")
+	}
+
+	return models.RocketChatAttachment{
+		Title:     calendar.Name,
+		TitleLink: fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken),
+		Color:     color,
+		Fields: []models.RocketChatField{
+			{Short: true, Title: label("date_label"), Value: s.formatDate(transition.Date, locale, calendar)},
+			{Short: true, Title: label("participants_label"), Value: fmt.Sprintf("%d/%d", transition.NewCount, transition.Threshold)},
+		},
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// mattermostAttachment builds the card of a Mattermost notification, with the same content as on Rocket.Chat
+func (s *NotifyService) mattermostAttachment(
+	calendar *calendarModels.Calendar,
+	transition *models.ThresholdTransition,
+	textMessage string,
+	locale string,
+) models.MattermostAttachment {
+	card := s.rocketChatAttachment(calendar, transition, locale)
+
+	fields := make([]models.MattermostField, 0, len(card.Fields))
+	for _, field := range card.Fields {
+		fields = append(fields, models.MattermostField{Short: field.Short, Title: field.Title, Value: field.Value})
+	}
+
+	return models.MattermostAttachment{
+		Fallback:  textMessage,
+		Color:     card.Color,
+		Title:     card.Title,
+		TitleLink: card.TitleLink,
+		Fields:    fields,
+	}
+}
+
+// today returns the current date in the calendar timezone (UTC if unknown), at midnight UTC like stored dates
+func today(timezone string) time.Time {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	now := time.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
 // sendDeduplicatedEmailNotifications collects all email recipients (owner + participants)
 // and sends one email per unique email address to prevent duplicates
 func (s *NotifyService) sendDeduplicatedEmailNotifications(
@@ -308,9 +894,11 @@ func (s *NotifyService) sendDeduplicatedEmailNotifications(
 				s.logger.Error("Failed to get participants for owner", "calendar_id", calendar.ID, "error", err)
 			} else {
 				var ownerParticipantID *uuid.UUID
+				var ownerAccessToken string
 				for _, p := range participants {
 					if p.Name == owner.DisplayName {
 						ownerParticipantID = &p.ID
+						ownerAccessToken = p.AccessToken
 						break
 					}
 				}
@@ -320,8 +908,10 @@ func (s *NotifyService) sendDeduplicatedEmailNotifications(
 					Name:          owner.DisplayName,
 					Locale:        owner.Locale,
 					ParticipantID: ownerParticipantID,
+					AccessToken:   ownerAccessToken,
 					RecipientID:   owner.ID,
 					IsOwner:       true,
+					TimeFormat:    pkgModels.ResolveTimeFormat(s.defaultTimeFormat(), owner.TimeFormat, calendar.TimeFormat),
 				}
 
 				s.logger.Debug("Owner added to email recipients",
@@ -341,14 +931,16 @@ func (s *NotifyService) sendDeduplicatedEmailNotifications(
 
 	// Build a map of participant IDs who have availability on this date
 	participantIDsWithAvailability := make(map[uuid.UUID]bool)
+	availabilityByParticipant := make(map[uuid.UUID]*availabilityModels.Availability)
 	for _, avail := range availabilities {
 		participantIDsWithAvailability[avail.ParticipantID] = true
+		availabilityByParticipant[avail.ParticipantID] = avail
 	}
 
 	s.logger.Debug("Participants with availability on date", "count", len(participantIDsWithAvailability))
 
-	// Build list of participant names for display in email
-	participantNames := make([]string, 0, len(participantIDsWithAvailability))
+	// Build list of participant names and time slots for display in email
+	participantSlots := make([]participantSlot, 0, len(participantIDsWithAvailability))
 	if len(participantIDsWithAvailability) > 0 {
 		// Get all participants to build the name list
 		allParticipants, err := s.participantRepo.GetByCalendarID(ctx, calendar.ID)
@@ -356,14 +948,28 @@ func (s *NotifyService) sendDeduplicatedEmailNotifications(
 			s.logger.Error("Failed to get all participants for name list", "calendar_id", calendar.ID, "error", err)
 		} else {
 			for _, p := range allParticipants {
-				if participantIDsWithAvailability[p.ID] {
-					participantNames = append(participantNames, p.Name)
+				if avail, ok := availabilityByParticipant[p.ID]; ok {
+					participantSlots = append(participantSlots, participantSlot{
+						Name:      p.Name,
+						StartTime: avail.StartTime,
+						EndTime:   avail.EndTime,
+						Maybe:     avail.Status == availabilityModels.StatusMaybe,
+					})
 				}
 			}
 		}
 	}
 
-	s.logger.Debug("Participant names collected for email", "count", len(participantNames), "names", participantNames)
+	s.logger.Debug("Participant names collected for email", "count", len(participantSlots))
+
+	// Comments posted on the date, when the calendar includes them in emails
+	var comments []availabilityModels.DateComment
+	if config.IncludeComments {
+		comments, err = s.availabilityRepo.GetCommentsByDate(ctx, calendar.ID, transition.Date)
+		if err != nil {
+			s.logger.Error("Failed to get comments for date", "calendar_id", calendar.ID, "date", transition.Date, "error", err)
+		}
+	}
 
 	// 2. Collect participant recipients if NotifyParticipants is enabled
 	if config.NotifyParticipants {
@@ -406,8 +1012,10 @@ func (s *NotifyService) sendDeduplicatedEmailNotifications(
 							Name:          p.Name,
 							Locale:        p.Locale,
 							ParticipantID: &pid,
+							AccessToken:   p.AccessToken,
 							RecipientID:   p.ID,
 							IsOwner:       false,
+							TimeFormat:    pkgModels.ResolveTimeFormat(s.defaultTimeFormat(), calendar.TimeFormat),
 						}
 
 						s.logger.Debug("Participant added to email recipients",
@@ -427,7 +1035,7 @@ func (s *NotifyService) sendDeduplicatedEmailNotifications(
 	for email, recipient := range recipients {
 		// Check if not sent recently (anti-spam)
 		sent, err := s.notificationLog.WasNotificationSentRecently(
-			ctx, calendar.ID, transition.Date, transition.TransitionType, recipient.RecipientID, "email",
+			ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, recipient.RecipientID, "email",
 		)
 		if err != nil {
 			s.logger.Error("Failed to check notification log", "email", email, "error", err)
@@ -444,77 +1052,196 @@ func (s *NotifyService) sendDeduplicatedEmailNotifications(
 		var calendarURL string
 		if recipient.ParticipantID != nil {
 			// Recipient has a participant - link to their participant view
-			calendarURL = fmt.Sprintf("%s/c/%s/p/%s", s.appURL, calendar.PublicToken, recipient.ParticipantID.String())
+			calendarURL = fmt.Sprintf("%s/c/%s/p/%s", s.appURL, calendar.PublicToken, recipient.AccessToken)
 		} else {
 			// Fallback to public calendar view
 			calendarURL = fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken)
 		}
 
-		htmlMessage := s.buildHTMLNotificationMessage(calendar, transition, calendarURL, recipient.ParticipantID != nil, recipient.Locale, participantNames)
+		// In digest mode, the transition waits for the next digest email of the recipient
+		if config.Digest.Enabled() && s.digests != nil {
+			s.enqueueDigest(ctx, calendar, transition, recipient, calendarURL)
+			continue
+		}
 
-		s.logger.Info("Sending email notification",
+		htmlMessage, err := s.buildHTMLNotificationMessage(calendar, transition, calendarURL, recipient.ParticipantID != nil, recipient.Locale, participantSlots, comments, recipient.TimeFormat)
+		if err != nil {
+			s.logger.Error("Failed to build email notification", "email", email, "error", err)
+			continue
+		}
+
+		s.logger.Info("Queuing email notification",
 			"email", email,
 			"name", recipient.Name,
 			"is_owner", recipient.IsOwner,
 			"url", calendarURL)
 
-		if err := s.sendEmailNotification(ctx, recipient.Email, recipient.Name, htmlMessage, recipient.Locale, true); err != nil {
-			s.logger.Error("Failed to send email",
-				"email", email,
-				"recipient_id", recipient.RecipientID,
-				"error", err)
-		} else {
-			s.logger.Info("Email notification sent successfully",
-				"email", email,
-				"recipient_id", recipient.RecipientID)
-
-			// Log notification
-			recipientType := "participant"
-			if recipient.IsOwner {
-				recipientType = "owner"
-			}
-			_ = s.notificationLog.LogNotification(
-				ctx, calendar.ID, transition.Date, transition.TransitionType, recipientType, recipient.RecipientID, "email",
-			)
+		recipientType := "participant"
+		if recipient.IsOwner {
+			recipientType = "owner"
 		}
+		s.queue(ctx, calendar, transition, recipientType, recipient.RecipientID, "email", models.OutboxPayload{
+			Email: &models.OutboxEmail{
+				To:      recipient.Email,
+				Subject: s.translate(recipient.Locale, "subject", nil),
+				Body:    htmlMessage,
+				HTML:    true,
+			},
+		})
 	}
 
 	s.logger.Debug("sendDeduplicatedEmailNotifications completed")
 	return nil
 }
 
+// translate returns the translation for a key in the given locale (falling back to English)
+// with {{.Var}} placeholders replaced by vars
+func (s *NotifyService) translate(locale, key string, vars map[string]string) string {
+	text, ok := s.translations.Lookup(locale)[key]
+	if !ok {
+		text = s.translations[i18n.DefaultLocale][key]
+	}
+
+	for name, value := range vars {
+		text = replaceVar(text, name, value)
+	}
+	return text
+}
+
+// formatDate renders a date for display according to the locale and the calendar date format
+func (s *NotifyService) formatDate(date time.Time, locale string, calendar *calendarModels.Calendar) string {
+	if pkgModels.ResolveDateFormat(s.defaultDateFormat(), calendar.DateFormat) != pkgModels.DateFormatLong {
+		return date.Format("2006-01-02")
+	}
+
+	return s.translate(locale, "date_long", map[string]string{
+		"Weekday": s.translate(locale, fmt.Sprintf("weekday_%d", date.Weekday()), nil),
+		"Day":     strconv.Itoa(date.Day()),
+		"Month":   s.translate(locale, fmt.Sprintf("month_%d", date.Month()), nil),
+		"Year":    strconv.Itoa(date.Year()),
+	})
+}
+
+// transitionMessageKey returns the translation key suffix for a transition type
+func transitionMessageKey(transitionType string) string {
+	switch transitionType {
+	case "threshold_reached":
+		return "reached"
+	case "threshold_lost":
+		return "lost"
+	case "soft_threshold_reached":
+		return "soft_reached"
+	case "soft_threshold_lost":
+		return "soft_lost"
+	default:
+		return "changed"
+	}
+}
+
+// transitionMessage returns the message of a transition, given the key prefix ("text_" or "message_")
+// and the date variables: the template of the calendar if it has one, the translation otherwise
+// Activity transitions have a message per action, naming the participant
+func (s *NotifyService) transitionMessage(
+	calendar *calendarModels.Calendar,
+	locale, prefix string,
+	transition *models.ThresholdTransition,
+	vars map[string]string,
+) string {
+	key := prefix + transitionMessageKey(transition.TransitionType)
+	vars["Count"] = strconv.Itoa(transition.NewCount)
+	vars["Threshold"] = strconv.Itoa(transition.Threshold)
+	if transition.Activity != nil {
+		key = prefix + "activity_" + transition.Activity.Action
+		vars["Name"] = transition.Activity.ParticipantName
+		vars["Action"] = transition.Activity.Action
+	}
+
+	templates := messageTemplates(calendar)
+	custom := templates.Message
+	if prefix == "text_" {
+		custom = templates.Text
+	}
+	if len(custom) > 0 {
+		templateVars := map[string]string{
+			"Event":        transition.TransitionType,
+			"Level":        transition.Level,
+			"CalendarName": calendar.Name,
+			"CalendarURL":  fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken),
+		}
+		maps.Copy(templateVars, vars)
+		if message, ok := s.customMessage(custom, locale, templateVars); ok {
+			return message
+		}
+	}
+
+	return s.translate(locale, key, vars)
+}
+
 // buildNotificationMessage creates the notification content (text for non-email channels)
 func (s *NotifyService) buildNotificationMessage(
 	calendar *calendarModels.Calendar,
 	transition *models.ThresholdTransition,
+	availabilities []*availabilityModels.Availability,
+	locale string,
+	timeFormat pkgModels.TimeFormat,
 ) string {
-	dateStr := transition.Date.Format("2006-01-02")
-
-	if transition.TransitionType == "threshold_reached" {
-		return fmt.Sprintf(
-			"🎉 Calendar '%s': Threshold reached for %s! (%d/%d participants available)",
-			calendar.Name,
-			dateStr,
-			transition.NewCount,
-			transition.Threshold,
-		)
-	} else if transition.TransitionType == "threshold_lost" {
-		return fmt.Sprintf(
-			"⚠️ Calendar '%s': Threshold lost for %s (%d/%d participants)",
-			calendar.Name,
-			dateStr,
-			transition.NewCount,
-			transition.Threshold,
-		)
+	dateStr := s.formatDate(transition.Date, locale, calendar)
+
+	// Show the time slot shared by all available participants, if any
+	if start, end := commonTimeSlot(availabilities); start != nil || end != nil {
+		dateStr += " (" + timeFormat.FormatSlot(start, end) + ")"
+	}
+
+	message := s.transitionMessage(calendar, locale, "text_", transition, map[string]string{
+		"CalendarName": calendar.Name,
+		"Date":         dateStr,
+	})
+
+	// Maybe answers are mentioned apart, whether or not they count toward the threshold
+	maybes := 0
+	for _, availability := range availabilities {
+		if availability.Status == availabilityModels.StatusMaybe {
+			maybes++
+		}
+	}
+	if maybes > 0 {
+		message += " " + s.translate(locale, "text_maybe", map[string]string{"Count": strconv.Itoa(maybes)})
 	}
+	return message
+}
+
+// thresholdEmail is the data of the threshold notification email template
+type thresholdEmail struct {
+	Locale               string
+	Emoji                string
+	Message              template.HTML // Escaped, with the line breaks of calendar templates
+	CalendarLabel        string
+	CalendarName         string
+	DateLabel            string
+	Date                 string
+	ParticipantsLabel    string
+	Count                int
+	Threshold            int
+	ParticipantListLabel string
+	MaybeLabel           string
+	Participants         []thresholdParticipant
+	CommentsLabel        string
+	Comments             []availabilityModels.DateComment
+	CalendarURL          string
+	ViewButton           string
+	CancelURL            string // Empty when the recipient isn't a participant
+	CancelButton         string
+	ProductName          string
+	LogoURL              string
+	PrimaryColor         template.CSS
+	FooterText           string
+}
 
-	return fmt.Sprintf(
-		"Calendar '%s': Availability changed for %s (%d/%d participants)",
-		calendar.Name,
-		dateStr,
-		transition.NewCount,
-		transition.Threshold,
-	)
+// thresholdParticipant is a participant of the threshold notification email
+type thresholdParticipant struct {
+	Name     string
+	Maybe    bool
+	TimeSlot string
 }
 
 // buildHTMLNotificationMessage creates HTML notification with calendar link
@@ -524,207 +1251,88 @@ func (s *NotifyService) buildHTMLNotificationMessage(
 	calendarURL string,
 	hasParticipantID bool,
 	locale string,
-	participantNames []string,
-) string {
-	dateStr := transition.Date.Format("2006-01-02")
-
-	// Translations
-	var emoji, messageText, calendarLabel, dateLabel, participantsLabel, participantListLabel, viewButton, cancelButtonText string
-
-	if locale == "fr" {
-		calendarLabel = "Calendrier :"
-		dateLabel = "Date :"
-		participantsLabel = "Participants disponibles :"
-		participantListLabel = "Liste des participants :"
-		viewButton = "Voir le calendrier"
-		cancelButtonText = "Annuler ma participation"
-
-		if transition.TransitionType == "threshold_reached" {
-			emoji = "🎉"
-			messageText = fmt.Sprintf(
-				"Seuil atteint pour %s ! (%d/%d participants disponibles)",
-				dateStr,
-				transition.NewCount,
-				transition.Threshold,
-			)
-		} else if transition.TransitionType == "threshold_lost" {
-			emoji = "⚠️"
-			messageText = fmt.Sprintf(
-				"Seuil perdu pour %s (%d/%d participants)",
-				dateStr,
-				transition.NewCount,
-				transition.Threshold,
-			)
-		} else {
-			messageText = fmt.Sprintf(
-				"Disponibilité modifiée pour %s (%d/%d participants)",
-				dateStr,
-				transition.NewCount,
-				transition.Threshold,
-			)
-		}
-	} else {
-		// Default to English
-		calendarLabel = "Calendar:"
-		dateLabel = "Date:"
-		participantsLabel = "Participants available:"
-		participantListLabel = "Participant list:"
-		viewButton = "View Calendar"
-		cancelButtonText = "Cancel my participation"
-
-		if transition.TransitionType == "threshold_reached" {
-			emoji = "🎉"
-			messageText = fmt.Sprintf(
-				"Threshold reached for %s! (%d/%d participants available)",
-				dateStr,
-				transition.NewCount,
-				transition.Threshold,
-			)
-		} else if transition.TransitionType == "threshold_lost" {
-			emoji = "⚠️"
-			messageText = fmt.Sprintf(
-				"Threshold lost for %s (%d/%d participants)",
-				dateStr,
-				transition.NewCount,
-				transition.Threshold,
-			)
-		} else {
-			messageText = fmt.Sprintf(
-				"Availability changed for %s (%d/%d participants)",
-				dateStr,
-				transition.NewCount,
-				transition.Threshold,
-			)
-		}
+	participants []participantSlot,
+	comments []availabilityModels.DateComment,
+	timeFormat pkgModels.TimeFormat,
+) (string, error) {
+	if s.thresholdTemplate == nil {
+		return "", fmt.Errorf("threshold notification template not loaded")
 	}
 
-	// Build participant list HTML
-	var participantListHTML string
-	if len(participantNames) > 0 {
-		participantListHTML = fmt.Sprintf(`<div class="participant-list">
-			<div class="participant-list-header">%s</div>
-			<ul class="participant-names">`, participantListLabel)
-		for _, name := range participantNames {
-			participantListHTML += fmt.Sprintf(`<li>%s</li>`, name)
-		}
-		participantListHTML += `</ul></div>`
+	dateStr := transition.Date.Format("2006-01-02") // ISO date for URLs
+	displayDate := s.formatDate(transition.Date, locale, calendar)
+
+	// Calendar templates are escaped, their line breaks are kept
+	messageText := s.transitionMessage(calendar, locale, "message_", transition, map[string]string{"Date": displayDate})
+	messageText = strings.ReplaceAll(html.EscapeString(messageText), "\n", "<br>")
+
+	var emoji string
+	switch transition.TransitionType {
+	case "threshold_reached":
+		emoji = "🎉"
+	case "threshold_lost":
+		emoji = "⚠️"
+	case "soft_threshold_reached":
+		emoji = "👀"
+	case "soft_threshold_lost":
+		emoji = "📉"
+	case "activity":
+		emoji = "✏️"
 	}
 
-	// Build cancel URL with date parameter (only if recipient has participant ID)
-	var cancelButton string
+	data := thresholdEmail{
+		Locale:               locale,
+		Emoji:                emoji,
+		Message:              template.HTML(messageText),
+		CalendarLabel:        s.translate(locale, "calendar_label", nil),
+		CalendarName:         calendar.Name,
+		DateLabel:            s.translate(locale, "date_label", nil),
+		Date:                 displayDate,
+		ParticipantsLabel:    s.translate(locale, "participants_label", nil),
+		Count:                transition.NewCount,
+		Threshold:            transition.Threshold,
+		ParticipantListLabel: s.translate(locale, "participant_list_label", nil),
+		MaybeLabel:           s.translate(locale, "maybe_label", nil),
+		CommentsLabel:        s.translate(locale, "comments_label", nil),
+		Comments:             comments,
+		CalendarURL:          calendarURL,
+		ViewButton:           s.translate(locale, "view_button", nil),
+		CancelButton:         s.translate(locale, "cancel_button", nil),
+		ProductName:          s.branding.ProductName,
+		LogoURL:              s.branding.LogoURL,
+		PrimaryColor:         template.CSS(s.branding.PrimaryColor), // Validated as a hex color by the config
+		FooterText:           s.branding.Footer(),
+	}
+	for _, p := range participants {
+		data.Participants = append(data.Participants, thresholdParticipant{
+			Name:     p.Name,
+			Maybe:    p.Maybe,
+			TimeSlot: timeFormat.FormatSlot(p.StartTime, p.EndTime),
+		})
+	}
+	// Cancel button with the date (only if recipient has participant ID)
 	if hasParticipantID {
-		cancelURL := fmt.Sprintf("%s?cancel=%s", calendarURL, dateStr)
-		cancelButton = fmt.Sprintf(`<a href="%s" class="btn btn-danger">%s</a>`, cancelURL, cancelButtonText)
-	}
-
-	// Build HTML with clickable calendar link and conditional cancel button
-	html := fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-	<meta charset="UTF-8">
-	<style>
-		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; background-color: #f4f4f4; }
-		.container { max-width: 600px; margin: 20px auto; padding: 30px; background-color: white; border-radius: 8px; box-shadow: 0 2px 4px rgba(0,0,0,0.1); }
-		.header { font-size: 24px; margin-bottom: 20px; color: #333; }
-		.calendar-name { color: #007bff; font-weight: bold; }
-		.message { font-size: 16px; margin-bottom: 10px; line-height: 1.8; }
-		.date-info { font-size: 18px; font-weight: bold; color: #555; margin: 15px 0; }
-		.buttons { margin-top: 30px; text-align: center; }
-		.btn {
-			display: inline-block;
-			padding: 14px 28px;
-			margin: 5px;
-			text-decoration: none;
-			border-radius: 5px;
-			font-weight: bold;
-			font-size: 16px;
-			transition: background-color 0.3s;
-		}
-		.btn-primary {
-			background-color: #007bff;
-			color: white !important;
-		}
-		.btn-primary:hover {
-			background-color: #0056b3;
-		}
-		.btn-danger {
-			background-color: #dc3545;
-			color: white !important;
-		}
-		.btn-danger:hover {
-			background-color: #c82333;
-		}
-		.participant-list {
-			margin: 20px 0;
-			padding: 15px;
-			background-color: #f8f9fa;
-			border-radius: 5px;
-			border-left: 4px solid #007bff;
-		}
-		.participant-list-header {
-			font-weight: bold;
-			margin-bottom: 10px;
-			color: #333;
-		}
-		.participant-names {
-			list-style: none;
-			padding: 0;
-			margin: 0;
-		}
-		.participant-names li {
-			padding: 5px 0;
-			color: #555;
-		}
-		.participant-names li:before {
-			content: "✓ ";
-			color: #28a745;
-			font-weight: bold;
-			margin-right: 8px;
-		}
-	</style>
-</head>
-<body>
-	<div class="container">
-		<div class="header">%s %s</div>
-		<div class="message">
-			%s <span class="calendar-name">%s</span>
-		</div>
-		<div class="date-info">%s %s</div>
-		<div class="message">
-			%s <strong>%d/%d</strong>
-		</div>
-		%s
-		<div class="buttons">
-			<a href="%s" class="btn btn-primary">%s</a>
-			%s
-		</div>
-	</div>
-</body>
-</html>
-	`, emoji, messageText, calendarLabel, calendar.Name, dateLabel, dateStr, participantsLabel, transition.NewCount, transition.Threshold, participantListHTML, calendarURL, viewButton, cancelButton)
-
-	return html
-}
+		data.CancelURL = fmt.Sprintf("%s?cancel=%s", calendarURL, dateStr)
+	}
 
-// sendEmailNotification sends email notification
-func (s *NotifyService) sendEmailNotification(
-	ctx context.Context,
-	to string,
-	name string,
-	message string,
-	locale string,
-	isHTML bool,
-) error {
-	subject := "WhenTo Calendar Notification"
-	if locale == "fr" {
-		subject = "Notification de Calendrier WhenTo"
+	var body bytes.Buffer
+	if err := s.thresholdTemplate.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to render threshold notification: %w", err)
 	}
+	return body.String(), nil
+}
 
-	return s.emailService.Send(email.Email{
-		To:      []string{to},
-		Subject: subject,
-		Body:    message,
-		HTML:    isHTML,
-	})
+// commonTimeSlot returns the latest start time and earliest end time across availabilities
+// Times are zero-padded "HH:MM" strings, so lexical comparison matches chronological order
+func commonTimeSlot(availabilities []*availabilityModels.Availability) (*string, *string) {
+	var maxStart, minEnd *string
+	for _, avail := range availabilities {
+		if avail.StartTime != nil && *avail.StartTime != "" && (maxStart == nil || *avail.StartTime > *maxStart) {
+			maxStart = avail.StartTime
+		}
+		if avail.EndTime != nil && *avail.EndTime != "" && (minEnd == nil || *avail.EndTime < *minEnd) {
+			minEnd = avail.EndTime
+		}
+	}
+	return maxStart, minEnd
 }
@@ -6,7 +6,17 @@
 package service
 
 import (
+	"io"
+	"log/slog"
+	"strings"
 	"testing"
+	"time"
+
+	pkgModels "github.com/whento/pkg/models"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/notify/models"
 )
 
 func TestEmailDeduplication(t *testing.T) {
@@ -161,3 +171,127 @@ func indexOf(s, substr string) int {
 	}
 	return -1
 }
+
+func TestToday(t *testing.T) {
+	for _, timezone := range []string{"Europe/Brussels", "Pacific/Kiritimati", "", "Not/AZone"} {
+		got := today(timezone)
+		if got.Location() != time.UTC || got.Hour() != 0 || got.Minute() != 0 {
+			t.Errorf("today(%q) = %v, want midnight UTC", timezone, got)
+		}
+
+		// Within a day of the current UTC date, whatever the timezone offset
+		if diff := time.Since(got); diff < -24*time.Hour || diff > 48*time.Hour {
+			t.Errorf("today(%q) = %v, too far from now", timezone, got)
+		}
+	}
+}
+
+func TestCommonTimeSlot(t *testing.T) {
+	clock := func(hhmm string) *string { return &hhmm }
+	slot := func(start, end *string) *availabilityModels.Availability {
+		return &availabilityModels.Availability{StartTime: start, EndTime: end}
+	}
+
+	tests := []struct {
+		name           string
+		availabilities []*availabilityModels.Availability
+		wantStart      string
+		wantEnd        string
+	}{
+		{"latest start and earliest end", []*availabilityModels.Availability{slot(clock("09:00"), clock("17:00")), slot(clock("10:30"), clock("20:00"))}, "10:30", "17:00"},
+		{"all-day availabilities are ignored", []*availabilityModels.Availability{slot(nil, nil), slot(clock("14:00"), clock("16:00"))}, "14:00", "16:00"},
+		{"empty times are ignored", []*availabilityModels.Availability{slot(clock(""), clock("")), slot(clock("08:00"), nil)}, "08:00", ""},
+		{"all day", []*availabilityModels.Availability{slot(nil, nil)}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := commonTimeSlot(tt.availabilities)
+			if deref(start) != tt.wantStart || deref(end) != tt.wantEnd {
+				t.Errorf("commonTimeSlot() = %q, %q, want %q, %q", deref(start), deref(end), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+// The same slot is rendered for each recipient in their own time format
+func TestTimeSlotForMixedRecipients(t *testing.T) {
+	twelve, twentyFour := "12h", "24h"
+	start, end := "19:00", "21:30"
+
+	tests := []struct {
+		name     string
+		user     *string // Account preference of the owner, nil for participants
+		calendar *string
+		want     string
+	}{
+		{"owner preferring 12h on a 24h calendar", &twelve, &twentyFour, "7:00 PM – 9:30 PM"},
+		{"participant of a 24h calendar", nil, &twentyFour, "19:00 – 21:30"},
+		{"owner preferring 24h on a 12h calendar", &twentyFour, &twelve, "19:00 – 21:30"},
+		{"participant of a 12h calendar", nil, &twelve, "7:00 PM – 9:30 PM"},
+		{"no preference, instance default", nil, nil, "19:00 – 21:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeFormat := pkgModels.ResolveTimeFormat("24h", tt.user, tt.calendar)
+			if got := timeFormat.FormatSlot(&start, &end); got != tt.want {
+				t.Errorf("FormatSlot() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestFormatDate(t *testing.T) {
+	value := func(s string) *string { return &s }
+	date := time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		defaultFormat string
+		dateFormat    *string
+		locale        string
+		want          string
+	}{
+		{"ISO by default", "", nil, "en", "2025-07-04"},
+		{"ISO instance default", "iso", nil, "fr", "2025-07-04"},
+		{"long instance default", "long", nil, "en", "Friday 4 July 2025"},
+		{"long instance default in French", "long", nil, "fr", "vendredi 4 juillet 2025"},
+		{"long instance default in German", "long", nil, "de", "Freitag, 4. Juli 2025"},
+		{"calendar long overrides ISO default", "iso", value("long"), "en", "Friday 4 July 2025"},
+		{"calendar ISO overrides long default", "long", value("iso"), "en", "2025-07-04"},
+		{"empty calendar value falls back to default", "long", value(""), "en", "Friday 4 July 2025"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{DateFormat: tt.defaultFormat}
+			notify := NewNotifyService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			calendar := &calendarModels.Calendar{DateFormat: tt.dateFormat}
+
+			if got := notify.formatDate(date, tt.locale, calendar); got != tt.want {
+				t.Errorf("formatDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Changing the instance default at runtime applies to later notifications
+	notify := NewNotifyService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &config.Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	notify.SetDefaultFormats("24h", "long")
+	if got := notify.formatDate(date, "en", &calendarModels.Calendar{}); got != "Friday 4 July 2025" {
+		t.Errorf("formatDate() after SetDefaultFormats = %q", got)
+	}
+
+	// The calendar date format is used in the notification text
+	transition := &models.ThresholdTransition{Date: date, NewCount: 3, Threshold: 3, TransitionType: "threshold_reached"}
+	text := notify.buildNotificationMessage(&calendarModels.Calendar{Name: "Board games", DateFormat: value("long")}, transition, nil, "fr", pkgModels.TimeFormat24h)
+	if !strings.Contains(text, "vendredi 4 juillet 2025") {
+		t.Errorf("notification text = %q, want the long French date", text)
+	}
+}
@@ -0,0 +1,240 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/email"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/notify/models"
+)
+
+const (
+	// Outbox worker settings
+	outboxInterval  = 30 * time.Second
+	outboxBatchSize = 20
+	outboxLease     = 2 * time.Minute // Longer than the timeouts of the channels
+
+	// outboxLogLimit is the number of notifications returned by the delivery log endpoint
+	outboxLogLimit = 50
+
+	// Retries double their delay from outboxRetryBase: a notification is marked as failed
+	// after outboxMaxAttempts attempts, about 4 hours after the transition
+	outboxRetryBase   = time.Minute
+	outboxMaxAttempts = 9
+
+	// maxDeliveryErrorLength truncates the errors recorded in the delivery log
+	maxDeliveryErrorLength = 500
+)
+
+// queue queues a threshold notification in the outbox, from which the worker sends it, retrying on failure
+// The notification log records it as soon as it is queued, so that it isn't queued twice within the anti-spam window
+// Without outbox, or if it can't be queued, the notification is sent right away
+func (s *NotifyService) queue(
+	ctx context.Context,
+	calendar *calendarModels.Calendar,
+	transition *models.ThresholdTransition,
+	recipientType string,
+	recipientID uuid.UUID,
+	channel string,
+	payload models.OutboxPayload,
+) {
+	now := time.Now()
+	delivery := &models.Delivery{
+		ID:            uuid.New(),
+		CalendarID:    calendar.ID,
+		Channel:       channel,
+		EventType:     transition.TransitionType,
+		Date:          transition.Date,
+		Level:         transition.Level,
+		RecipientType: recipientType,
+		RecipientID:   recipientID,
+		Payload:       payload,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+	}
+
+	queued := false
+	if s.outbox != nil {
+		if err := s.outbox.Enqueue(ctx, delivery); err != nil {
+			s.logger.Error("Failed to queue notification, sending it right away", "calendar_id", calendar.ID, "channel", channel, "error", err)
+		} else {
+			queued = true
+		}
+	}
+
+	if !queued {
+		if err := s.sendDelivery(ctx, channel, &payload); err != nil {
+			s.logger.Error("Failed to send notification", "calendar_id", calendar.ID, "channel", channel, "error", err)
+			return
+		}
+	}
+
+	_ = s.notificationLog.LogNotification(
+		ctx, calendar.ID, transition.Date, transition.TransitionType, transition.Level, recipientType, recipientID, channel,
+	)
+
+	if queued {
+		select {
+		case s.outboxWake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// StartOutboxTask starts the outbox worker, which sends queued notifications as soon as they are due
+func (s *NotifyService) StartOutboxTask(ctx context.Context) {
+	if s.outbox == nil {
+		return
+	}
+	s.logger.Info("Starting notification outbox worker", "interval", outboxInterval)
+
+	go func() {
+		ticker := time.NewTicker(outboxInterval)
+		defer ticker.Stop()
+
+		for {
+			s.DeliverDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Notification outbox worker stopped (context cancelled)")
+				return
+			case <-ticker.C:
+			case <-s.outboxWake:
+			}
+		}
+	}()
+}
+
+// DeliverDue sends the queued notifications that are due, in batches
+// Notifications are claimed in the database, so several instances can run the worker
+func (s *NotifyService) DeliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		due, err := s.outbox.ClaimDue(ctx, time.Now(), outboxLease, outboxBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to claim queued notifications", "error", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range due {
+			wg.Go(func() { s.attemptDelivery(ctx, delivery) })
+		}
+		wg.Wait()
+
+		if len(due) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+// attemptDelivery sends a claimed notification and records the outcome
+func (s *NotifyService) attemptDelivery(ctx context.Context, delivery *models.Delivery) {
+	err := s.sendDelivery(ctx, delivery.Channel, &delivery.Payload)
+	recordDelivery(delivery, err, time.Now())
+
+	switch delivery.Status {
+	case models.DeliverySent:
+		s.logger.Debug("Notification delivered", "delivery_id", delivery.ID, "channel", delivery.Channel)
+	case models.DeliveryPending:
+		s.logger.Warn("Notification delivery failed, will retry", "delivery_id", delivery.ID, "channel", delivery.Channel, "attempt", delivery.Attempts, "error", err)
+	default:
+		s.logger.Error("Notification delivery failed", "delivery_id", delivery.ID, "channel", delivery.Channel, "attempt", delivery.Attempts, "error", err)
+	}
+
+	if err := s.outbox.RecordAttempt(ctx, delivery); err != nil {
+		s.logger.Error("Failed to record notification delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// recordDelivery updates a notification with the outcome of an attempt, scheduling a retry if any is left
+func recordDelivery(delivery *models.Delivery, err error, now time.Time) {
+	delivery.Attempts++
+
+	if err == nil {
+		delivery.Status = models.DeliverySent
+		delivery.Error = nil
+		delivery.NextAttemptAt = nil
+		delivery.SentAt = &now
+		return
+	}
+
+	message := err.Error()
+	if len(message) > maxDeliveryErrorLength {
+		message = message[:maxDeliveryErrorLength]
+	}
+	delivery.Error = &message
+
+	delay, ok := outboxRetryDelay(delivery.Attempts)
+	if !ok {
+		delivery.Status = models.DeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(delay)
+	delivery.Status = models.DeliveryPending
+	delivery.NextAttemptAt = &next
+}
+
+// outboxRetryDelay returns the delay before the retry following an attempt (1-based), if any is left
+func outboxRetryDelay(attempts int) (time.Duration, bool) {
+	if attempts < 1 || attempts >= outboxMaxAttempts {
+		return 0, false
+	}
+	return outboxRetryBase << (attempts - 1), true
+}
+
+// sendDelivery sends a queued notification through its channel
+func (s *NotifyService) sendDelivery(ctx context.Context, channel string, payload *models.OutboxPayload) error {
+	switch {
+	case payload.Email != nil:
+		if !s.emailService.IsConfigured() {
+			return errors.New("SMTP is not configured")
+		}
+		return s.emailService.SendNow(email.Email{ // Retried by the outbox
+			To:      []string{payload.Email.To},
+			Subject: payload.Email.Subject,
+			Body:    payload.Email.Body,
+			HTML:    payload.Email.HTML,
+		})
+	case payload.Discord != nil:
+		return s.externalNotifier.SendDiscord(ctx, payload.Discord.WebhookURL, payload.Text)
+	case payload.Slack != nil:
+		return s.externalNotifier.SendSlack(ctx, payload.Slack.WebhookURL, payload.Text)
+	case payload.RocketChat != nil:
+		return s.externalNotifier.SendRocketChat(ctx, payload.RocketChat.Config.WebhookURL, payload.Text, payload.RocketChat.Attachment)
+	case payload.Mattermost != nil:
+		return s.externalNotifier.SendMattermost(ctx, payload.Mattermost.Config, payload.Text, payload.Mattermost.Attachment)
+	case payload.Telegram != nil:
+		return s.externalNotifier.SendTelegram(ctx, payload.Telegram.BotToken, payload.Telegram.ChatID, payload.Text)
+	case payload.MQTT != nil:
+		return s.externalNotifier.SendMQTT(ctx, payload.MQTT.Config, payload.MQTT.Topic, payload.MQTT.Payload)
+	case payload.Webhook != nil:
+		return s.externalNotifier.SendWebhook(ctx, payload.Webhook.Config, payload.Webhook.Payload)
+	case payload.Apprise != nil:
+		_, err := s.externalNotifier.SendApprise(ctx, []string{payload.Apprise.URL}, AppriseMessage{Title: payload.Apprise.Title, Body: payload.Text})
+		return err
+	default:
+		return fmt.Errorf("unsupported notification channel %q", channel)
+	}
+}
+
+// ListDeliveries returns the latest notifications of a calendar, with their delivery status
+func (s *NotifyService) ListDeliveries(ctx context.Context, calendarID uuid.UUID) ([]*models.Delivery, error) {
+	if s.outbox == nil {
+		return nil, nil
+	}
+	return s.outbox.ListByCalendar(ctx, calendarID, outboxLogLimit)
+}
@@ -0,0 +1,94 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/notify/models"
+)
+
+func TestRecordDelivery(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		d := &models.Delivery{Status: models.DeliveryPending}
+		recordDelivery(d, nil, now)
+		if d.Status != models.DeliverySent || d.Attempts != 1 || d.NextAttemptAt != nil || d.SentAt == nil {
+			t.Errorf("delivery = %+v, want sent after 1 attempt", d)
+		}
+	})
+
+	t.Run("retries with exponential backoff", func(t *testing.T) {
+		d := &models.Delivery{Status: models.DeliveryPending}
+		delay := time.Minute
+		for attempt := 1; attempt < outboxMaxAttempts; attempt++ {
+			recordDelivery(d, errors.New("connection refused"), now)
+			if d.Status != models.DeliveryPending || d.Attempts != attempt {
+				t.Fatalf("attempt %d: delivery = %+v, want pending", attempt, d)
+			}
+			if d.NextAttemptAt == nil || !d.NextAttemptAt.Equal(now.Add(delay)) {
+				t.Fatalf("attempt %d: next attempt = %v, want %v", attempt, d.NextAttemptAt, now.Add(delay))
+			}
+			delay *= 2
+		}
+
+		recordDelivery(d, errors.New("connection refused"), now)
+		if d.Status != models.DeliveryFailed || d.NextAttemptAt != nil || d.Attempts != outboxMaxAttempts {
+			t.Errorf("delivery = %+v, want failed after %d attempts", d, outboxMaxAttempts)
+		}
+	})
+
+	t.Run("long errors are truncated", func(t *testing.T) {
+		d := &models.Delivery{}
+		recordDelivery(d, errors.New(strings.Repeat("x", 2000)), now)
+		if d.Error == nil || len(*d.Error) != maxDeliveryErrorLength {
+			t.Errorf("error length = %v, want %d", d.Error, maxDeliveryErrorLength)
+		}
+	})
+}
+
+func TestSendDelivery(t *testing.T) {
+	var body string
+	status := http.StatusInternalServerError
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	notify := &NotifyService{externalNotifier: NewExternalNotifier("WhenTo", false, true, logger), logger: logger}
+	payload := &models.OutboxPayload{
+		Text:  "Threshold reached",
+		Slack: &models.SlackChannelConfig{Enabled: true, WebhookURL: server.URL},
+	}
+
+	if err := notify.sendDelivery(context.Background(), "slack", payload); err == nil {
+		t.Error("sendDelivery() should fail when the channel answers 500")
+	}
+
+	status = http.StatusOK
+	if err := notify.sendDelivery(context.Background(), "slack", payload); err != nil {
+		t.Fatalf("sendDelivery() failed: %v", err)
+	}
+	if !strings.Contains(body, "Threshold reached") {
+		t.Errorf("body = %q, want the queued message", body)
+	}
+
+	if err := notify.sendDelivery(context.Background(), "discord", &models.OutboxPayload{}); err == nil {
+		t.Error("sendDelivery() should fail without channel")
+	}
+}
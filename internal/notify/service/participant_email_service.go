@@ -11,9 +11,9 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"strings"
 	"text/template"
 	"time"
@@ -21,6 +21,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/whento/pkg/email"
+	"github.com/whento/pkg/i18n"
 	"github.com/whento/whento/internal/calendar/repository"
 	"github.com/whento/whento/internal/config"
 )
@@ -38,7 +39,7 @@ type ParticipantEmailService struct {
 	cfg             *config.Config
 	logger          *slog.Logger
 	template        *template.Template
-	translations    map[string]map[string]string
+	translations    i18n.Translations
 }
 
 // NewParticipantEmailService creates a new participant email service
@@ -48,17 +49,22 @@ func NewParticipantEmailService(
 	cfg *config.Config,
 	logger *slog.Logger,
 ) *ParticipantEmailService {
-	// Parse email verification template
-	tmpl, err := template.New("participant_email_verification").Parse(participantEmailVerificationTemplate)
+	// Parse email verification template (with an optional self-hosted override)
+	source, err := email.LoadTemplate(participantEmailVerificationTemplate, cfg.EmailTemplateDir, "participant_email_verification")
+	if err != nil {
+		logger.Error("Failed to load participant email verification template override", "error", err)
+	}
+	tmpl, err := template.New("participant_email_verification").Parse(source)
 	if err != nil {
 		logger.Error("Failed to parse participant email verification template", "error", err)
 	}
 
 	// Load email verification translations
-	var trans map[string]map[string]string
-	if err := json.Unmarshal([]byte(participantEmailVerificationTranslations), &trans); err != nil {
+	trans, err := i18n.Load(participantEmailVerificationTranslations, cfg.TranslationsDir, "participant_email_verification")
+	if err != nil {
 		logger.Error("Failed to load participant email verification translations", "error", err)
 	}
+	trans = trans.WithVar("ProductName", cfg.Branding.ProductName)
 
 	return &ParticipantEmailService{
 		participantRepo: participantRepo,
@@ -206,11 +212,7 @@ func (s *ParticipantEmailService) sendVerificationEmail(
 
 	verificationURL := fmt.Sprintf("%s/c/verify-email/%s", s.cfg.AppURL, token)
 
-	// Get translations for locale (fallback to english)
-	trans, ok := s.translations[locale]
-	if !ok {
-		trans = s.translations["en"]
-	}
+	trans := s.translations.Lookup(locale)
 
 	// Prepare template data
 	expiryDuration := s.cfg.Email.VerificationExpiry.String()
@@ -226,6 +228,7 @@ func (s *ParticipantEmailService) sendVerificationEmail(
 		"Signature":       trans["signature"],
 		"VerificationURL": verificationURL,
 	}
+	maps.Copy(data, s.cfg.Branding.TemplateData())
 
 	// Execute template
 	var htmlBody bytes.Buffer
@@ -0,0 +1,165 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	pkgModels "github.com/whento/pkg/models"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/notify/models"
+	pushModels "github.com/whento/whento/internal/push/models"
+)
+
+// pushEnabled reports whether Web Push notifications can be sent
+func (s *NotifyService) pushEnabled() bool {
+	return s.push != nil && s.push.Enabled()
+}
+
+// sendPushNotifications pushes a threshold transition to the browsers of the owner
+// and of the participants available on the date, like email notifications
+func (s *NotifyService) sendPushNotifications(
+	ctx context.Context,
+	calendar *calendarModels.Calendar,
+	transition *models.ThresholdTransition,
+	config models.NotifyConfig,
+) {
+	if !config.Channels.Push.Enabled || !s.pushEnabled() {
+		return
+	}
+
+	availabilities, err := s.availabilityRepo.GetByDate(ctx, calendar.ID, transition.Date)
+	if err != nil {
+		s.logger.Error("Failed to get availabilities for date", "calendar_id", calendar.ID, "date", transition.Date, "error", err)
+		availabilities = []*availabilityModels.Availability{}
+	}
+
+	if config.NotifyOwner {
+		owner, err := s.userRepo.GetByID(ctx, calendar.OwnerID)
+		if err != nil {
+			s.logger.Error("Failed to get owner user", "owner_id", calendar.OwnerID, "error", err)
+		} else {
+			timeFormat := pkgModels.ResolveTimeFormat(s.defaultTimeFormat(), owner.TimeFormat, calendar.TimeFormat)
+			msg := s.transitionPushMessage(calendar, transition, availabilities, owner.Locale, timeFormat, fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken))
+			s.pushOnce(ctx, calendar.ID, transition, "owner", owner.ID, func() (int, error) {
+				return s.push.NotifyUser(ctx, owner.ID, msg)
+			})
+		}
+	}
+
+	if config.NotifyParticipants && len(availabilities) > 0 {
+		available := make(map[uuid.UUID]bool, len(availabilities))
+		for _, availability := range availabilities {
+			available[availability.ParticipantID] = true
+		}
+
+		participants, err := s.participantRepo.GetByCalendarID(ctx, calendar.ID)
+		if err != nil {
+			s.logger.Error("Failed to get participants for push notifications", "calendar_id", calendar.ID, "error", err)
+			return
+		}
+
+		timeFormat := pkgModels.ResolveTimeFormat(s.defaultTimeFormat(), calendar.TimeFormat)
+		for _, p := range participants {
+			if !available[p.ID] {
+				continue
+			}
+			participantID := p.ID
+			url := fmt.Sprintf("%s/c/%s/p/%s", s.appURL, calendar.PublicToken, p.AccessToken)
+			msg := s.transitionPushMessage(calendar, transition, availabilities, p.Locale, timeFormat, url)
+			s.pushOnce(ctx, calendar.ID, transition, "participant", participantID, func() (int, error) {
+				return s.push.NotifyParticipant(ctx, participantID, msg)
+			})
+		}
+	}
+}
+
+// sendTestPush pushes a sample threshold notification to the browsers of the owner
+func (s *NotifyService) sendTestPush(
+	ctx context.Context,
+	calendar *calendarModels.Calendar,
+	transition *models.ThresholdTransition,
+	ownerID uuid.UUID,
+	locale string,
+	timeFormat pkgModels.TimeFormat,
+) error {
+	if !s.pushEnabled() {
+		return errors.New("Web Push is not configured on this server")
+	}
+
+	msg := s.transitionPushMessage(calendar, transition, nil, locale, timeFormat, fmt.Sprintf("%s/c/%s", s.appURL, calendar.PublicToken))
+	msg.Title = "[Test] " + msg.Title
+	delivered, err := s.push.NotifyUser(ctx, ownerID, msg)
+	if err != nil {
+		return err
+	}
+	if delivered == 0 {
+		return errors.New("no browser subscribed to notifications, enable them in your settings")
+	}
+	return nil
+}
+
+// pushOnce sends a push notification unless it was sent recently, and logs it when a browser received it
+func (s *NotifyService) pushOnce(
+	ctx context.Context,
+	calendarID uuid.UUID,
+	transition *models.ThresholdTransition,
+	recipientType string,
+	recipientID uuid.UUID,
+	send func() (int, error),
+) {
+	sent, _ := s.notificationLog.WasNotificationSentRecently(
+		ctx, calendarID, transition.Date, transition.TransitionType, transition.Level, recipientID, "push",
+	)
+	if sent {
+		return
+	}
+
+	delivered, err := send()
+	if err != nil {
+		s.logger.Error("Failed to send push notification", "calendar_id", calendarID, "recipient_id", recipientID, "error", err)
+		return
+	}
+	if delivered > 0 {
+		_ = s.notificationLog.LogNotification(
+			ctx, calendarID, transition.Date, transition.TransitionType, transition.Level, recipientType, recipientID, "push",
+		)
+	}
+}
+
+// transitionPushMessage builds the push notification of a threshold transition
+// The calendar name is the title, so the message is the short form without it
+func (s *NotifyService) transitionPushMessage(
+	calendar *calendarModels.Calendar,
+	transition *models.ThresholdTransition,
+	availabilities []*availabilityModels.Availability,
+	locale string,
+	timeFormat pkgModels.TimeFormat,
+	url string,
+) pushModels.Message {
+	dateStr := s.formatDate(transition.Date, locale, calendar)
+	if start, end := commonTimeSlot(availabilities); start != nil || end != nil {
+		dateStr += " (" + timeFormat.FormatSlot(start, end) + ")"
+	}
+
+	return pushModels.Message{
+		Title: calendar.Name,
+		Body:  s.transitionMessage(calendar, locale, "message_", transition, map[string]string{"Date": dateStr}),
+		URL:   url,
+		Tag:   pushTag(calendar.ID, transition.Date),
+	}
+}
+
+// pushTag groups the notifications of a date, so a later one replaces the previous in the browser
+func pushTag(calendarID uuid.UUID, date time.Time) string {
+	return calendarID.String() + "/" + date.Format("2006-01-02")
+}
@@ -0,0 +1,290 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/datevalidation"
+	pkgModels "github.com/whento/pkg/models"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	icsRepo "github.com/whento/whento/internal/ics/repository"
+	"github.com/whento/whento/internal/notify/models"
+	notifyRepo "github.com/whento/whento/internal/notify/repository"
+	pushModels "github.com/whento/whento/internal/push/models"
+)
+
+// reminderCheckInterval is how often the scheduler looks for events to remind
+const reminderCheckInterval = 15 * time.Minute
+
+// ConfirmedEventRepository reads the dates of a calendar reaching its threshold (the events of the ICS feed)
+type ConfirmedEventRepository interface {
+	GetEventsAboveThreshold(ctx context.Context, calendarID uuid.UUID, threshold int, countMaybe bool) (map[time.Time][]icsRepo.DateAvailability, error)
+}
+
+// ReminderEventRepository reads the events of a calendar and, for calendars requiring confirmation, the confirmed dates
+type ReminderEventRepository interface {
+	ConfirmedEventRepository
+	GetConfirmedDates(ctx context.Context, calendarID uuid.UUID) (map[time.Time]bool, error)
+}
+
+// ReminderScheduler pushes a reminder before each event to the owner and the participants of the event
+// Reminders go through Web Push, to the browsers subscribed by their recipients
+type ReminderScheduler struct {
+	notify       *NotifyService
+	reminderRepo *notifyRepo.ReminderRepository
+	events       ReminderEventRepository
+	logger       *slog.Logger
+}
+
+// NewReminderScheduler creates a new reminder scheduler
+func NewReminderScheduler(
+	notify *NotifyService,
+	reminderRepo *notifyRepo.ReminderRepository,
+	events ReminderEventRepository,
+	logger *slog.Logger,
+) *ReminderScheduler {
+	return &ReminderScheduler{
+		notify:       notify,
+		reminderRepo: reminderRepo,
+		events:       events,
+		logger:       logger,
+	}
+}
+
+// StartTask checks periodically for events whose reminder is due
+func (s *ReminderScheduler) StartTask(ctx context.Context) {
+	if !s.notify.pushEnabled() {
+		s.logger.Info("Event reminders disabled (Web Push not configured)")
+		return
+	}
+
+	s.logger.Info("Starting event reminder scheduler", "interval", reminderCheckInterval)
+
+	go func() {
+		ticker := time.NewTicker(reminderCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Event reminder scheduler stopped (context cancelled)")
+				return
+			case <-ticker.C:
+				s.SendDueReminders(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// SendDueReminders sends the reminders of the events starting within the delay configured on their calendar
+func (s *ReminderScheduler) SendDueReminders(ctx context.Context, now time.Time) {
+	calendarIDs, err := s.reminderRepo.ListReminderCalendars(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list calendars with reminders", "error", err)
+		return
+	}
+
+	for _, calendarID := range calendarIDs {
+		if err := s.remindCalendar(ctx, calendarID, now); err != nil {
+			s.logger.Error("Failed to send event reminders", "calendar_id", calendarID, "error", err)
+		}
+	}
+}
+
+// remindCalendar sends the due reminders of the events of a calendar
+func (s *ReminderScheduler) remindCalendar(ctx context.Context, calendarID uuid.UUID, now time.Time) error {
+	calendar, err := s.notify.calendarRepo.GetByID(ctx, calendarID)
+	if err != nil {
+		return fmt.Errorf("failed to get calendar: %w", err)
+	}
+	if calendar.NotifyConfig == nil {
+		return nil
+	}
+
+	var config models.NotifyConfig
+	if err := json.Unmarshal([]byte(*calendar.NotifyConfig), &config); err != nil {
+		return fmt.Errorf("failed to parse notify config: %w", err)
+	}
+	if !config.Enabled || !config.Reminders.Enabled || !config.Channels.Push.Enabled || config.Reminders.HoursBefore <= 0 {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(calendar.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	eventsByDate, err := s.events.GetEventsAboveThreshold(ctx, calendar.ID, calendar.Threshold, calendar.CountMaybe)
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+
+	var confirmed map[time.Time]bool
+	if calendar.RequireConfirm {
+		if confirmed, err = s.events.GetConfirmedDates(ctx, calendar.ID); err != nil {
+			return fmt.Errorf("failed to get confirmed dates: %w", err)
+		}
+	}
+
+	delay := time.Duration(config.Reminders.HoursBefore) * time.Hour
+	for date, availabilities := range eventsByDate {
+		if calendar.RequireConfirm && !confirmed[date] {
+			continue
+		}
+		if datevalidation.IsBlackedOut(date, calendar.BlackoutDates) {
+			continue
+		}
+
+		start := eventStart(date, availabilities, loc)
+		if now.Before(start.Add(-delay)) || !now.Before(start) {
+			continue
+		}
+
+		s.sendReminders(ctx, calendar, config, date, availabilities)
+	}
+
+	return nil
+}
+
+// sendReminders pushes the reminder of an event to the owner and to the participants of the event
+// Each recipient is reminded once per event, whatever the number of checks within the delay
+func (s *ReminderScheduler) sendReminders(
+	ctx context.Context,
+	calendar *calendarModels.Calendar,
+	config models.NotifyConfig,
+	date time.Time,
+	availabilities []icsRepo.DateAvailability,
+) {
+	if config.NotifyOwner {
+		owner, err := s.notify.userRepo.GetByID(ctx, calendar.OwnerID)
+		if err != nil {
+			s.logger.Error("Failed to get owner user", "owner_id", calendar.OwnerID, "error", err)
+		} else {
+			timeFormat := pkgModels.ResolveTimeFormat(s.notify.defaultTimeFormat(), owner.TimeFormat, calendar.TimeFormat)
+			msg := s.reminderMessage(calendar, date, availabilities, owner.Locale, timeFormat, fmt.Sprintf("%s/c/%s", s.notify.appURL, calendar.PublicToken))
+			s.remindOnce(ctx, calendar.ID, date, "owner", owner.ID, func() (int, error) {
+				return s.notify.push.NotifyUser(ctx, owner.ID, msg)
+			})
+		}
+	}
+
+	if !config.NotifyParticipants {
+		return
+	}
+
+	attending := make(map[uuid.UUID]bool, len(availabilities))
+	for _, availability := range availabilities {
+		if availability.RSVP != availabilityModels.RSVPNotGoing {
+			attending[availability.ParticipantID] = true
+		}
+	}
+
+	participants, err := s.notify.participantRepo.GetByCalendarID(ctx, calendar.ID)
+	if err != nil {
+		s.logger.Error("Failed to get participants for reminders", "calendar_id", calendar.ID, "error", err)
+		return
+	}
+
+	timeFormat := pkgModels.ResolveTimeFormat(s.notify.defaultTimeFormat(), calendar.TimeFormat)
+	for _, p := range participants {
+		if !attending[p.ID] {
+			continue
+		}
+		participantID := p.ID
+		url := fmt.Sprintf("%s/c/%s/p/%s", s.notify.appURL, calendar.PublicToken, p.AccessToken)
+		msg := s.reminderMessage(calendar, date, availabilities, p.Locale, timeFormat, url)
+		s.remindOnce(ctx, calendar.ID, date, "participant", participantID, func() (int, error) {
+			return s.notify.push.NotifyParticipant(ctx, participantID, msg)
+		})
+	}
+}
+
+// remindOnce sends a reminder unless it was already sent, and logs it when a browser received it
+func (s *ReminderScheduler) remindOnce(
+	ctx context.Context,
+	calendarID uuid.UUID,
+	date time.Time,
+	recipientType string,
+	recipientID uuid.UUID,
+	send func() (int, error),
+) {
+	sent, err := s.notify.notificationLog.WasNotificationSent(ctx, calendarID, date, "reminder", recipientID, "push")
+	if err != nil {
+		s.logger.Error("Failed to check notification log", "calendar_id", calendarID, "error", err)
+		return
+	}
+	if sent {
+		return
+	}
+
+	delivered, err := send()
+	if err != nil {
+		s.logger.Error("Failed to send reminder", "calendar_id", calendarID, "recipient_id", recipientID, "error", err)
+		return
+	}
+	if delivered > 0 {
+		_ = s.notify.notificationLog.LogNotification(
+			ctx, calendarID, date, "reminder", availabilityModels.LevelHard, recipientType, recipientID, "push",
+		)
+	}
+}
+
+// reminderMessage builds the push notification reminding an event
+func (s *ReminderScheduler) reminderMessage(
+	calendar *calendarModels.Calendar,
+	date time.Time,
+	availabilities []icsRepo.DateAvailability,
+	locale string,
+	timeFormat pkgModels.TimeFormat,
+	url string,
+) pushModels.Message {
+	dateStr := s.notify.formatDate(date, locale, calendar)
+	if start := earliestStart(availabilities); start != nil {
+		dateStr += " (" + timeFormat.FormatClock(*start) + ")"
+	}
+
+	return pushModels.Message{
+		Title: calendar.Name,
+		Body:  s.notify.translate(locale, "reminder_message", map[string]string{"Date": dateStr}),
+		URL:   url,
+		Tag:   pushTag(calendar.ID, date),
+	}
+}
+
+// eventStart returns when an event starts in the timezone of its calendar:
+// at the earliest start time of its participants, or at midnight when one of them is available all day
+func eventStart(date time.Time, availabilities []icsRepo.DateAvailability, loc *time.Location) time.Time {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	if earliest := earliestStart(availabilities); earliest != nil {
+		if t, err := time.Parse("15:04", *earliest); err == nil {
+			start = start.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+		}
+	}
+	return start
+}
+
+// earliestStart returns the earliest start time (HH:MM) of the participants of an event,
+// or nil when one of them is available all day
+func earliestStart(availabilities []icsRepo.DateAvailability) *string {
+	var earliest *string
+	for _, availability := range availabilities {
+		if availability.StartTime == nil || *availability.StartTime == "" {
+			return nil
+		}
+		if earliest == nil || *availability.StartTime < *earliest {
+			earliest = availability.StartTime
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,44 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	icsRepo "github.com/whento/whento/internal/ics/repository"
+)
+
+func TestEventStart(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	at := func(s string) *string { return &s }
+
+	tests := []struct {
+		name           string
+		availabilities []icsRepo.DateAvailability
+		want           time.Time
+	}{
+		{
+			name:           "earliest start time",
+			availabilities: []icsRepo.DateAvailability{{StartTime: at("19:30")}, {StartTime: at("18:00")}},
+			want:           time.Date(2025, 3, 10, 18, 0, 0, 0, paris),
+		},
+		{
+			name:           "participant available all day",
+			availabilities: []icsRepo.DateAvailability{{StartTime: at("18:00")}, {}},
+			want:           time.Date(2025, 3, 10, 0, 0, 0, 0, paris),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventStart(date, tt.availabilities, paris); !got.Equal(tt.want) {
+				t.Errorf("eventStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
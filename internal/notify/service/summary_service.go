@@ -0,0 +1,404 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/email"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/notify/models"
+	notifyRepo "github.com/whento/whento/internal/notify/repository"
+)
+
+//go:embed templates/weekly_summary.html
+var weeklySummaryTemplate string
+
+const (
+	// summaryCheckInterval is how often the scheduler looks for summaries to send
+	summaryCheckInterval = 15 * time.Minute
+	// summaryUpcomingDays is how far ahead upcoming events are listed
+	summaryUpcomingDays = 28
+	// summaryHorizonDays is how far ahead the dates reaching or losing the threshold are tracked
+	summaryHorizonDays = 90
+)
+
+// RangeSummarizer computes the availability summary of a calendar over a date range, as shown on its grid
+type RangeSummarizer interface {
+	GetRangeSummary(ctx context.Context, token, startDateStr, endDateStr, participantID, timezone string) ([]availabilityModels.PublicDateAvailabilitySummary, error)
+}
+
+// SummaryScheduler sends the opt-in weekly summary email of their calendars to owners
+type SummaryScheduler struct {
+	notify      *NotifyService
+	summaryRepo *notifyRepo.SummaryRepository
+	summarizer  RangeSummarizer
+	day         time.Weekday
+	hour        int
+	enabled     bool
+	template    *template.Template
+	logger      *slog.Logger
+}
+
+// NewSummaryScheduler creates a new weekly summary scheduler
+func NewSummaryScheduler(
+	notify *NotifyService,
+	summaryRepo *notifyRepo.SummaryRepository,
+	summarizer RangeSummarizer,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *SummaryScheduler {
+	source, err := email.LoadTemplate(weeklySummaryTemplate, cfg.EmailTemplateDir, "weekly_summary")
+	if err != nil {
+		logger.Error("Failed to load weekly summary template override", "error", err)
+	}
+	tmpl, err := template.New("weekly_summary").Parse(source)
+	if err != nil {
+		logger.Error("Failed to parse weekly summary template", "error", err)
+	}
+
+	day, ok := ParseWeekday(cfg.WeeklySummary.Day)
+
+	return &SummaryScheduler{
+		notify:      notify,
+		summaryRepo: summaryRepo,
+		summarizer:  summarizer,
+		day:         day,
+		hour:        cfg.WeeklySummary.Hour,
+		enabled:     ok && cfg.WeeklySummary.Hour >= 0 && cfg.WeeklySummary.Hour < 24,
+		template:    tmpl,
+		logger:      logger,
+	}
+}
+
+// ParseWeekday parses an English weekday name, case-insensitively
+func ParseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// lastScheduled returns the latest time at or before now falling on the given weekday and hour,
+// in the location of now
+func lastScheduled(now time.Time, day time.Weekday, hour int) time.Time {
+	scheduled := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	scheduled = scheduled.AddDate(0, 0, -((int(now.Weekday()) - int(day) + 7) % 7))
+	if scheduled.After(now) {
+		scheduled = scheduled.AddDate(0, 0, -7)
+	}
+	return scheduled
+}
+
+// StartTask checks periodically for owners whose weekly summary is due
+func (s *SummaryScheduler) StartTask(ctx context.Context) {
+	if !s.enabled {
+		s.logger.Info("Weekly summary emails disabled")
+		return
+	}
+
+	s.logger.Info("Starting weekly summary scheduler", "day", s.day, "hour", s.hour)
+
+	go func() {
+		ticker := time.NewTicker(summaryCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Weekly summary scheduler stopped (context cancelled)")
+				return
+			case <-ticker.C:
+				s.SendDueSummaries(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// SendDueSummaries sends the summaries whose scheduled time, in the timezone of their owner, has passed
+// Each summary is claimed before it is sent, so several instances never send it twice
+func (s *SummaryScheduler) SendDueSummaries(ctx context.Context, now time.Time) {
+	if !s.notify.emailService.IsConfigured() {
+		return
+	}
+
+	subscribers, err := s.summaryRepo.ListSubscribers(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list weekly summary subscribers", "error", err)
+		return
+	}
+
+	for _, subscriber := range subscribers {
+		loc, err := time.LoadLocation(subscriber.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		localNow := now.In(loc)
+
+		scheduled := lastScheduled(localNow, s.day, s.hour)
+		if subscriber.LastSentAt != nil && !subscriber.LastSentAt.Before(scheduled) {
+			continue
+		}
+
+		claimed, err := s.summaryRepo.ClaimSummary(ctx, subscriber.UserID, scheduled)
+		if err != nil {
+			s.logger.Error("Failed to claim weekly summary", "user_id", subscriber.UserID, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.sendSummary(ctx, subscriber, localNow); err != nil {
+			s.logger.Error("Failed to send weekly summary", "user_id", subscriber.UserID, "error", err)
+			if err := s.summaryRepo.ReleaseSummary(ctx, subscriber.UserID, subscriber.LastSentAt); err != nil {
+				s.logger.Error("Failed to release weekly summary", "user_id", subscriber.UserID, "error", err)
+			}
+			continue
+		}
+
+		s.logger.Info("Weekly summary sent", "user_id", subscriber.UserID)
+	}
+}
+
+// sendSummary builds and sends the summary of an owner, skipping owners without calendars
+func (s *SummaryScheduler) sendSummary(ctx context.Context, subscriber *models.SummarySubscriber, now time.Time) error {
+	calendars, err := s.buildSummaries(ctx, subscriber.UserID, now)
+	if err != nil {
+		return err
+	}
+	if len(calendars) == 0 {
+		return nil
+	}
+
+	body, err := s.render(subscriber, calendars)
+	if err != nil {
+		return err
+	}
+
+	err = s.notify.emailService.Send(email.Email{
+		To:      []string{subscriber.Email},
+		Subject: s.notify.translate(subscriber.Locale, "summary_subject", nil),
+		Body:    body,
+		HTML:    true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The next summary reports the changes from the dates of this one
+	for _, calendar := range calendars {
+		if err := s.summaryRepo.SaveConfirmedDates(ctx, calendar.CalendarID, calendar.Confirmed); err != nil {
+			s.logger.Error("Failed to save weekly summary dates", "calendar_id", calendar.CalendarID, "error", err)
+		}
+	}
+	return nil
+}
+
+// buildSummaries collects the activity of the last week of each calendar of an owner
+func (s *SummaryScheduler) buildSummaries(ctx context.Context, ownerID uuid.UUID, now time.Time) ([]*models.CalendarSummary, error) {
+	since := now.AddDate(0, 0, -7)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	upcomingUntil := today.AddDate(0, 0, summaryUpcomingDays)
+
+	calendars, err := s.summaryRepo.ListCalendarSummaries(ctx, ownerID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, calendar := range calendars {
+		// Same counts as the calendar grid: recurrences, required participants and blackout dates included
+		days, err := s.summarizer.GetRangeSummary(ctx, calendar.PublicToken,
+			today.Format(time.DateOnly), today.AddDate(0, 0, summaryHorizonDays).Format(time.DateOnly), "", "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get range summary: %w", err)
+		}
+		for _, day := range days {
+			date, err := time.Parse(time.DateOnly, day.Date)
+			if err != nil || !day.ThresholdReached {
+				continue
+			}
+			calendar.Confirmed = append(calendar.Confirmed, date)
+			if date.Before(upcomingUntil) {
+				calendar.UpcomingEvents = append(calendar.UpcomingEvents, date)
+			}
+		}
+
+		if calendar.FirstSummary {
+			// Without previous dates, the confirmed dates that got responses during the week are reported as reached
+			responded, err := s.summaryRepo.ListRespondedDates(ctx, calendar.CalendarID, today, since)
+			if err != nil {
+				return nil, err
+			}
+			calendar.ReachedDates = intersectDates(calendar.Confirmed, responded)
+		} else {
+			calendar.ReachedDates, calendar.LostDates = thresholdChanges(calendar.PreviousConfirmed, calendar.Confirmed, today)
+		}
+
+		calendar.NonResponders, err = s.summaryRepo.ListNonResponders(ctx, calendar.CalendarID, today)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return calendars, nil
+}
+
+// thresholdChanges compares the dates meeting the threshold with those of the previous summary,
+// returning the dates that reached it and the dates from today that lost it, in order
+func thresholdChanges(previous, current []time.Time, today time.Time) (reached, lost []time.Time) {
+	before := make(map[time.Time]bool, len(previous))
+	for _, date := range previous {
+		before[dateKey(date)] = true
+	}
+	now := make(map[time.Time]bool, len(current))
+	for _, date := range current {
+		now[dateKey(date)] = true
+		if !before[dateKey(date)] {
+			reached = append(reached, date)
+		}
+	}
+	for _, date := range previous {
+		if !now[dateKey(date)] && !dateKey(date).Before(today) {
+			lost = append(lost, date)
+		}
+	}
+
+	sortDates(reached)
+	sortDates(lost)
+	return reached, lost
+}
+
+// intersectDates returns the dates of a that are also in b, in order
+func intersectDates(a, b []time.Time) []time.Time {
+	in := make(map[time.Time]bool, len(b))
+	for _, date := range b {
+		in[dateKey(date)] = true
+	}
+	var dates []time.Time
+	for _, date := range a {
+		if in[dateKey(date)] {
+			dates = append(dates, date)
+		}
+	}
+	sortDates(dates)
+	return dates
+}
+
+// dateKey returns a date at midnight UTC, the dates of the database and of the range summary being comparable
+func dateKey(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func sortDates(dates []time.Time) {
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+}
+
+// summaryCalendar is a calendar section of the weekly summary email
+type summaryCalendar struct {
+	Name           string
+	URL            string
+	NewResponses   string
+	ReachedDates   []string
+	LostDates      []string
+	UpcomingEvents []string
+	NonResponders  []string
+	Quiet          bool
+}
+
+// summaryEmail is the data of the weekly summary email template
+type summaryEmail struct {
+	Locale             string
+	Subject            string
+	Greeting           string
+	Intro              string
+	ReachedLabel       string
+	LostLabel          string
+	UpcomingLabel      string
+	NonRespondersLabel string
+	NoActivity         string
+	OptOut             string
+	SettingsLink       string
+	SettingsURL        string
+	Calendars          []summaryCalendar
+	ProductName        string
+	LogoURL            string
+	PrimaryColor       template.CSS
+	FooterText         string
+}
+
+// render renders the weekly summary email of an owner
+func (s *SummaryScheduler) render(subscriber *models.SummarySubscriber, calendars []*models.CalendarSummary) (string, error) {
+	if s.template == nil {
+		return "", fmt.Errorf("weekly summary template not loaded")
+	}
+
+	locale := subscriber.Locale
+	data := summaryEmail{
+		Locale:             locale,
+		Subject:            s.notify.translate(locale, "summary_subject", nil),
+		Greeting:           s.notify.translate(locale, "summary_greeting", map[string]string{"Name": subscriber.DisplayName}),
+		Intro:              s.notify.translate(locale, "summary_intro", nil),
+		ReachedLabel:       s.notify.translate(locale, "summary_reached_label", nil),
+		LostLabel:          s.notify.translate(locale, "summary_lost_label", nil),
+		UpcomingLabel:      s.notify.translate(locale, "summary_upcoming_label", nil),
+		NonRespondersLabel: s.notify.translate(locale, "summary_non_responders_label", nil),
+		NoActivity:         s.notify.translate(locale, "summary_no_activity", nil),
+		OptOut:             s.notify.translate(locale, "summary_opt_out", nil),
+		SettingsLink:       s.notify.translate(locale, "summary_settings_link", nil),
+		SettingsURL:        s.notify.appURL + "/settings",
+		ProductName:        s.notify.branding.ProductName,
+		LogoURL:            s.notify.branding.LogoURL,
+		PrimaryColor:       template.CSS(s.notify.branding.PrimaryColor), // Validated as a hex color by the config
+		FooterText:         s.notify.branding.Footer(),
+	}
+
+	for _, c := range calendars {
+		calendar := &calendarModels.Calendar{DateFormat: c.DateFormat}
+		section := summaryCalendar{
+			Name:          c.Name,
+			URL:           fmt.Sprintf("%s/c/%s", s.notify.appURL, c.PublicToken),
+			NonResponders: c.NonResponders,
+			Quiet:         !c.HasActivity(),
+		}
+		if c.NewResponses > 0 {
+			section.NewResponses = s.notify.translate(locale, "summary_new_responses", map[string]string{
+				"Count": strconv.Itoa(c.NewResponses),
+			})
+		}
+		for _, date := range c.ReachedDates {
+			section.ReachedDates = append(section.ReachedDates, s.notify.formatDate(date, locale, calendar))
+		}
+		for _, date := range c.LostDates {
+			section.LostDates = append(section.LostDates, s.notify.formatDate(date, locale, calendar))
+		}
+		for _, date := range c.UpcomingEvents {
+			section.UpcomingEvents = append(section.UpcomingEvents, s.notify.formatDate(date, locale, calendar))
+		}
+		data.Calendars = append(data.Calendars, section)
+	}
+
+	var body bytes.Buffer
+	if err := s.template.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to render weekly summary: %w", err)
+	}
+	return body.String(), nil
+}
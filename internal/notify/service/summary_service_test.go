@@ -0,0 +1,127 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/notify/models"
+)
+
+func TestParseWeekday(t *testing.T) {
+	if day, ok := ParseWeekday("Monday"); !ok || day != time.Monday {
+		t.Errorf("ParseWeekday(Monday) = %v, %v", day, ok)
+	}
+	if day, ok := ParseWeekday("sunday"); !ok || day != time.Sunday {
+		t.Errorf("ParseWeekday(sunday) = %v, %v", day, ok)
+	}
+	if _, ok := ParseWeekday("mon"); ok {
+		t.Error("ParseWeekday(mon) should fail")
+	}
+}
+
+func TestLastScheduled(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"later the same day", time.Date(2025, 6, 16, 10, 30, 0, 0, paris), time.Date(2025, 6, 16, 8, 0, 0, 0, paris)},
+		{"exactly on time", time.Date(2025, 6, 16, 8, 0, 0, 0, paris), time.Date(2025, 6, 16, 8, 0, 0, 0, paris)},
+		{"earlier the same day", time.Date(2025, 6, 16, 7, 59, 0, 0, paris), time.Date(2025, 6, 9, 8, 0, 0, 0, paris)},
+		{"later in the week", time.Date(2025, 6, 19, 1, 0, 0, 0, paris), time.Date(2025, 6, 16, 8, 0, 0, 0, paris)},
+		{"day before", time.Date(2025, 6, 15, 23, 0, 0, 0, paris), time.Date(2025, 6, 9, 8, 0, 0, 0, paris)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastScheduled(tt.now, time.Monday, 8); !got.Equal(tt.want) {
+				t.Errorf("lastScheduled(%s) = %s, want %s", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThresholdChanges(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC) }
+	today := day(16)
+
+	previous := []time.Time{day(10), day(20), day(27)}
+	current := []time.Time{day(27), day(18), day(20)}
+	reached, lost := thresholdChanges(previous, current, today)
+	if len(reached) != 1 || !reached[0].Equal(day(18)) {
+		t.Errorf("reached = %v, want [2025-06-18]", reached)
+	}
+	// June 10 is past: it didn't lose the threshold, the event took place
+	if len(lost) != 0 {
+		t.Errorf("lost = %v, want none", lost)
+	}
+
+	reached, lost = thresholdChanges(previous, []time.Time{day(27)}, today)
+	if len(reached) != 0 || len(lost) != 1 || !lost[0].Equal(day(20)) {
+		t.Errorf("thresholdChanges() = %v, %v, want no reached date and 2025-06-20 lost", reached, lost)
+	}
+}
+
+func TestRenderWeeklySummary(t *testing.T) {
+	cfg := &config.Config{
+		AppURL:        "https://whento.example.com",
+		WeeklySummary: config.WeeklySummaryConfig{Day: "monday", Hour: 8},
+		Branding:      config.BrandingConfig{ProductName: "WhenTo", PrimaryColor: "#4F46E5"},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	notify := NewNotifyService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, logger)
+	scheduler := NewSummaryScheduler(notify, nil, nil, cfg, logger)
+
+	subscriber := &models.SummarySubscriber{DisplayName: "Alice", Locale: "en"}
+	calendars := []*models.CalendarSummary{
+		{
+			Name:           "Board <games>",
+			PublicToken:    "abc",
+			NewResponses:   3,
+			ReachedDates:   []time.Time{time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)},
+			LostDates:      []time.Time{time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC)},
+			UpcomingEvents: []time.Time{time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)},
+			NonResponders:  []string{"Bob"},
+		},
+		{Name: "Quiet", PublicToken: "def"},
+	}
+
+	body, err := scheduler.render(subscriber, calendars)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Hello Alice,",
+		"Board &lt;games&gt;",
+		"https://whento.example.com/c/abc",
+		"3 new or updated responses this week",
+		"2025-06-20",
+		"Dates that dropped below the threshold:",
+		"2025-06-27",
+		"Bob",
+		"Nothing new this week.",
+		"https://whento.example.com/settings",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("summary should contain %q", want)
+		}
+	}
+	if strings.Contains(body, "<games>") {
+		t.Error("calendar names should be escaped")
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/uuid"
 
+	availabilityModels "github.com/whento/whento/internal/availability/models"
 	"github.com/whento/whento/internal/availability/repository"
 	"github.com/whento/whento/internal/notify/models"
 )
@@ -30,30 +31,36 @@ func NewThresholdDetector(availabilityRepo *repository.AvailabilityRepository, l
 	}
 }
 
-// DetectTransition compares participant count vs threshold to detect transitions
+// DetectTransition compares participant count vs threshold levels to detect transitions
+// A level is only met when every required participant is available
 func (d *ThresholdDetector) DetectTransition(
 	ctx context.Context,
 	calendarID uuid.UUID,
 	date time.Time,
 	threshold int,
-	previousCount int, // Pass -1 if unknown (will only check current state)
+	softThreshold *int, // nil without soft threshold
+	previous availabilityModels.DateCount, // Pass a Count of -1 if unknown (will only check current state)
 ) (*models.ThresholdTransition, error) {
+	previousCount := previous.Count
 	d.logger.Debug("DetectTransition called",
 		"calendar_id", calendarID,
 		"date", date.Format("2006-01-02"),
 		"threshold", threshold,
+		"soft_threshold", softThreshold,
 		"previous_count", previousCount)
 
 	// Get current participant count for this date
-	newCount, err := d.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
+	current, err := d.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
 	if err != nil {
 		d.logger.Error("Failed to get participant count", "calendar_id", calendarID, "date", date, "error", err)
 		return nil, err
 	}
+	newCount := current.Count
 
 	d.logger.Debug("Current participant count retrieved",
 		"calendar_id", calendarID,
-		"new_count", newCount)
+		"new_count", newCount,
+		"required_missing", current.RequiredMissing)
 
 	transition := &models.ThresholdTransition{
 		CalendarID:    calendarID,
@@ -63,65 +70,90 @@ func (d *ThresholdDetector) DetectTransition(
 		Threshold:     threshold,
 	}
 
-	// Determine transition type
+	// Previous count unknown: only check the current state, as if no level was met before
+	previousLevel := availabilityModels.LevelNone
 	if previousCount >= 0 {
-		// We know the previous count, can detect transitions
-		wasMet := previousCount >= threshold
-		nowMet := newCount >= threshold
-
-		d.logger.Debug("Transition detection with previous count",
-			"was_met", wasMet,
-			"now_met", nowMet,
+		previousLevel = previous.Level(threshold, softThreshold)
+	}
+	currentLevel := current.Level(threshold, softThreshold)
+
+	d.logger.Debug("Transition detection",
+		"previous_level", previousLevel,
+		"current_level", currentLevel,
+		"previous", previousCount,
+		"new", newCount,
+		"threshold", threshold)
+
+	transition.TransitionType, transition.Level = levelTransition(previousLevel, currentLevel)
+	if transition.TransitionType != "none" {
+		d.logger.Info("THRESHOLD TRANSITION DETECTED",
+			"calendar_id", calendarID,
+			"date", date.Format("2006-01-02"),
+			"type", transition.TransitionType,
 			"previous", previousCount,
 			"new", newCount,
 			"threshold", threshold)
-
-		if !wasMet && nowMet {
-			transition.TransitionType = "threshold_reached"
-			d.logger.Info("THRESHOLD REACHED TRANSITION DETECTED",
-				"calendar_id", calendarID,
-				"date", date.Format("2006-01-02"),
-				"previous", previousCount,
-				"new", newCount,
-				"threshold", threshold)
-		} else if wasMet && !nowMet {
-			transition.TransitionType = "threshold_lost"
-			d.logger.Info("THRESHOLD LOST TRANSITION DETECTED",
-				"calendar_id", calendarID,
-				"date", date.Format("2006-01-02"),
-				"previous", previousCount,
-				"new", newCount,
-				"threshold", threshold)
-		} else {
-			transition.TransitionType = "none"
-			d.logger.Debug("No transition detected (threshold state unchanged)")
-		}
-	} else {
-		// Previous count unknown, just check current state
-		d.logger.Debug("Transition detection without previous count", "new", newCount, "threshold", threshold)
-
-		if newCount >= threshold {
-			transition.TransitionType = "threshold_reached"
-			d.logger.Info("THRESHOLD REACHED (no previous count)",
-				"calendar_id", calendarID,
-				"date", date.Format("2006-01-02"),
-				"new", newCount,
-				"threshold", threshold)
-		} else {
-			transition.TransitionType = "none"
-			d.logger.Debug("Threshold not met (no previous count)", "new", newCount, "threshold", threshold)
-		}
 	}
 
-	d.logger.Debug("Transition detection result", "type", transition.TransitionType)
+	d.logger.Debug("Transition detection result", "type", transition.TransitionType, "level", transition.Level)
 	return transition, nil
 }
 
+// levelTransition returns the transition type between two threshold levels and the level it concerns
+// Reaching or losing the threshold takes precedence: a date dropping from the threshold to the soft
+// threshold loses the threshold, and a date jumping over the soft threshold reaches the threshold
+func levelTransition(previous, current string) (transitionType, level string) {
+	switch {
+	case previous == current:
+		return "none", ""
+	case current == availabilityModels.LevelHard:
+		return "threshold_reached", availabilityModels.LevelHard
+	case previous == availabilityModels.LevelHard:
+		return "threshold_lost", availabilityModels.LevelHard
+	case current == availabilityModels.LevelSoft:
+		return "soft_threshold_reached", availabilityModels.LevelSoft
+	default:
+		return "soft_threshold_lost", availabilityModels.LevelSoft
+	}
+}
+
+// DetectActivity returns the "activity" transition of a participant changing their availability on a date
+// Unlike threshold transitions, it doesn't depend on the levels: any change is reported, with the current count
+func (d *ThresholdDetector) DetectActivity(
+	ctx context.Context,
+	calendarID uuid.UUID,
+	date time.Time,
+	threshold int,
+	activity models.Activity,
+) (*models.ThresholdTransition, error) {
+	current, err := d.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
+	if err != nil {
+		d.logger.Error("Failed to get participant count", "calendar_id", calendarID, "date", date, "error", err)
+		return nil, err
+	}
+
+	return activityTransition(calendarID, date, threshold, current.Count, activity), nil
+}
+
+// activityTransition builds the "activity" transition of a change, the previous count being unknown
+func activityTransition(calendarID uuid.UUID, date time.Time, threshold, count int, activity models.Activity) *models.ThresholdTransition {
+	return &models.ThresholdTransition{
+		CalendarID:     calendarID,
+		Date:           date,
+		PreviousCount:  -1,
+		NewCount:       count,
+		Threshold:      threshold,
+		TransitionType: "activity",
+		Level:          availabilityModels.LevelNone,
+		Activity:       &activity,
+	}
+}
+
 // GetCurrentCount gets the current participant count for a date
 func (d *ThresholdDetector) GetCurrentCount(
 	ctx context.Context,
 	calendarID uuid.UUID,
 	date time.Time,
-) (int, error) {
+) (availabilityModels.DateCount, error) {
 	return d.availabilityRepo.GetParticipantCountForDate(ctx, calendarID, date)
 }
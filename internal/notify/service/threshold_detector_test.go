@@ -6,7 +6,19 @@
 package service
 
 import (
+	"io"
+	"log/slog"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	pkgModels "github.com/whento/pkg/models"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/notify/models"
 )
 
 func TestThresholdTransitionLogic(t *testing.T) {
@@ -101,3 +113,58 @@ func TestThresholdTransitionLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestLevelTransition(t *testing.T) {
+	soft := 5
+	tests := []struct {
+		name               string
+		previous, current  availabilityModels.DateCount
+		expectedTransition string
+		expectedLevel      string
+	}{
+		{"soft threshold reached", availabilityModels.DateCount{Count: 4}, availabilityModels.DateCount{Count: 5}, "soft_threshold_reached", "soft"},
+		{"soft threshold lost", availabilityModels.DateCount{Count: 5}, availabilityModels.DateCount{Count: 4}, "soft_threshold_lost", "soft"},
+		{"staying at the soft level", availabilityModels.DateCount{Count: 5}, availabilityModels.DateCount{Count: 7}, "none", ""},
+		{"threshold reached from the soft level", availabilityModels.DateCount{Count: 7}, availabilityModels.DateCount{Count: 8}, "threshold_reached", "hard"},
+		{"threshold reached over the soft level", availabilityModels.DateCount{Count: 2}, availabilityModels.DateCount{Count: 8}, "threshold_reached", "hard"},
+		{"threshold lost to the soft level", availabilityModels.DateCount{Count: 8}, availabilityModels.DateCount{Count: 6}, "threshold_lost", "hard"},
+		{"required participant missing", availabilityModels.DateCount{Count: 4}, availabilityModels.DateCount{Count: 6, RequiredMissing: 1}, "none", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transitionType, level := levelTransition(tt.previous.Level(8, &soft), tt.current.Level(8, &soft))
+			if transitionType != tt.expectedTransition || level != tt.expectedLevel {
+				t.Errorf("levelTransition() = %s, %s, want %s, %s", transitionType, level, tt.expectedTransition, tt.expectedLevel)
+			}
+		})
+	}
+
+	// Without soft threshold, only the threshold is detected
+	if level := (availabilityModels.DateCount{Count: 5}).Level(8, nil); level != availabilityModels.LevelNone {
+		t.Errorf("Level() without soft threshold = %s, want none", level)
+	}
+}
+
+func TestActivityTransition(t *testing.T) {
+	cfg := &config.Config{AppURL: "https://whento.example.com"}
+	notify := NewNotifyService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	calendar := &calendarModels.Calendar{Name: "Board games"}
+	date := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+
+	transition := activityTransition(uuid.New(), date, 4, 3, models.Activity{ParticipantName: "Bob", Action: models.ActivityRemoved})
+	if transition.TransitionType != "activity" || transition.Level != availabilityModels.LevelNone || transition.PreviousCount != -1 {
+		t.Fatalf("activityTransition() = %s, %s, previous %d", transition.TransitionType, transition.Level, transition.PreviousCount)
+	}
+
+	text := notify.buildNotificationMessage(calendar, transition, nil, "en", pkgModels.TimeFormat24h)
+	if want := "Calendar 'Board games': Bob removed their availability for 2025-06-20 (3/4 participants)"; !strings.Contains(text, want) {
+		t.Errorf("text message = %q, want it to contain %q", text, want)
+	}
+
+	transition.Activity.Action = models.ActivityAdded
+	message := notify.transitionMessage(calendar, "fr", "message_", transition, map[string]string{"Date": "2025-06-20"})
+	if want := "Bob a ajouté une disponibilité pour 2025-06-20 (3/4 participants)"; message != want {
+		t.Errorf("message = %q, want %q", message, want)
+	}
+}
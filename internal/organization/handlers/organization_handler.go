@@ -0,0 +1,366 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/organization/models"
+	"github.com/whento/whento/internal/organization/service"
+)
+
+// OrganizationHandler handles HTTP requests for organizations
+type OrganizationHandler struct {
+	service *service.OrganizationService
+	logger  *slog.Logger
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(service *service.OrganizationService, logger *slog.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		List my organizations
+// @Description	Lists the organizations of the current user with their role. Send the ID of one of them in the X-Organization-ID header to list and create the calendars of this organization.
+// @Tags			Organizations
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		models.OrganizationResponse	"Organizations"
+// @Failure		401	{object}	httputil.ErrorResponse		"Unauthorized"
+// @Router			/api/v1/organizations [get]
+func (h *OrganizationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	orgs, err := h.service.List(r.Context(), userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to list organizations")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, orgs)
+}
+
+// @Summary		Create an organization
+// @Description	Creates an organization owned by the current user. Calendars of the organization count against the quota of its owner.
+// @Tags			Organizations
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			request	body		models.CreateOrganizationRequest	true	"Organization"
+// @Success		201		{object}	models.OrganizationResponse		"Organization created"
+// @Failure		400		{object}	httputil.ErrorResponse				"Invalid request"
+// @Failure		401		{object}	httputil.ErrorResponse				"Unauthorized"
+// @Router			/api/v1/organizations [post]
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateOrganizationRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	org, err := h.service.Create(r.Context(), userUUID, &req)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to create organization")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, org)
+}
+
+// @Summary		Get an organization
+// @Description	Returns an organization with its members (members only)
+// @Tags			Organizations
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string						true	"Organization ID"
+// @Success		200	{object}	models.OrganizationResponse	"Organization"
+// @Failure		401	{object}	httputil.ErrorResponse		"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse		"Organization not found"
+// @Router			/api/v1/organizations/{id} [get]
+func (h *OrganizationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userUUID, orgID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+
+	org, err := h.service.Get(r.Context(), userUUID, orgID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to get organization")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, org)
+}
+
+// @Summary		Rename an organization
+// @Description	Renames an organization (owner or admin)
+// @Tags			Organizations
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string								true	"Organization ID"
+// @Param			request	body		models.UpdateOrganizationRequest	true	"New name"
+// @Success		200		{object}	models.OrganizationResponse		"Organization updated"
+// @Failure		400		{object}	httputil.ErrorResponse				"Invalid request"
+// @Failure		403		{object}	httputil.ErrorResponse				"Not an owner or admin"
+// @Failure		404		{object}	httputil.ErrorResponse				"Organization not found"
+// @Router			/api/v1/organizations/{id} [patch]
+func (h *OrganizationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userUUID, orgID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateOrganizationRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	org, err := h.service.Update(r.Context(), userUUID, orgID, &req)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to update organization")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, org)
+}
+
+// @Summary		Delete an organization
+// @Description	Deletes an organization (owner only). Its calendars become personal calendars of their creators.
+// @Tags			Organizations
+// @Security		BearerAuth
+// @Param			id	path	string	true	"Organization ID"
+// @Success		204	"Organization deleted"
+// @Failure		403	{object}	httputil.ErrorResponse	"Not the owner"
+// @Failure		404	{object}	httputil.ErrorResponse	"Organization not found"
+// @Router			/api/v1/organizations/{id} [delete]
+func (h *OrganizationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userUUID, orgID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), userUUID, orgID); err != nil {
+		h.handleError(w, err, userUUID, "Failed to delete organization")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Add a member
+// @Description	Adds a user with an account on this server to an organization (owner or admin)
+// @Tags			Organizations
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string						true	"Organization ID"
+// @Param			request	body		models.AddMemberRequest		true	"Member"
+// @Success		201		{object}	models.OrganizationResponse	"Member added"
+// @Failure		400		{object}	httputil.ErrorResponse		"Invalid request"
+// @Failure		403		{object}	httputil.ErrorResponse		"Not an owner or admin"
+// @Failure		404		{object}	httputil.ErrorResponse		"Organization or user not found"
+// @Failure		409		{object}	httputil.ErrorResponse		"Already a member"
+// @Router			/api/v1/organizations/{id}/members [post]
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	userUUID, orgID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AddMemberRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	org, err := h.service.AddMember(r.Context(), userUUID, orgID, &req)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to add member")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, org)
+}
+
+// @Summary		Change the role of a member
+// @Description	Changes the role of a member to admin or member (owner or admin). The owner can't be changed.
+// @Tags			Organizations
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string						true	"Organization ID"
+// @Param			userId	path		string						true	"User ID of the member"
+// @Param			request	body		models.UpdateMemberRequest	true	"Role"
+// @Success		200		{object}	models.OrganizationResponse	"Member updated"
+// @Failure		400		{object}	httputil.ErrorResponse		"Invalid request"
+// @Failure		403		{object}	httputil.ErrorResponse		"Not an owner or admin, or member is the owner"
+// @Failure		404		{object}	httputil.ErrorResponse		"Organization or member not found"
+// @Router			/api/v1/organizations/{id}/members/{userId} [patch]
+func (h *OrganizationHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	userUUID, orgID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req models.UpdateMemberRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	org, err := h.service.UpdateMember(r.Context(), userUUID, orgID, memberID, &req)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to update member")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, org)
+}
+
+// @Summary		Remove a member
+// @Description	Removes a member (owner or admin); members can remove themselves to leave the organization. The owner can't be removed.
+// @Tags			Organizations
+// @Security		BearerAuth
+// @Param			id		path	string	true	"Organization ID"
+// @Param			userId	path	string	true	"User ID of the member"
+// @Success		204		"Member removed"
+// @Failure		403		{object}	httputil.ErrorResponse	"Not an owner or admin, or member is the owner"
+// @Failure		404		{object}	httputil.ErrorResponse	"Organization or member not found"
+// @Router			/api/v1/organizations/{id}/members/{userId} [delete]
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userUUID, orgID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), userUUID, orgID, memberID); err != nil {
+		h.handleError(w, err, userUUID, "Failed to remove member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Switch selects the organization named by the X-Organization-ID header for the calendar API
+// Requests without the header act on the personal calendars of the user
+func (h *OrganizationHandler) Switch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(models.Header)
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userUUID, ok := h.userID(w, r)
+		if !ok {
+			return
+		}
+		orgID, err := uuid.Parse(header)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid "+models.Header+" header")
+			return
+		}
+
+		membership, err := h.service.Membership(r.Context(), orgID, userUUID)
+		if err != nil {
+			h.handleError(w, err, userUUID, "Failed to check organization membership")
+			return
+		}
+		if membership == nil {
+			httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "Not a member of this organization")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(models.WithMembership(r.Context(), membership)))
+	})
+}
+
+// handleError maps service errors to HTTP responses
+func (h *OrganizationHandler) handleError(w http.ResponseWriter, err error, userID uuid.UUID, defaultMsg string) {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Organization not found")
+	case errors.Is(err, service.ErrMemberNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Member not found")
+	case errors.Is(err, service.ErrUserNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, err.Error())
+	case errors.Is(err, service.ErrForbidden), errors.Is(err, service.ErrOwnerRole):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, err.Error())
+	case errors.Is(err, service.ErrAlreadyMember):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, err.Error())
+	default:
+		h.logger.Error(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
+	}
+}
+
+// userID returns the authenticated user ID, writing an error response if missing
+func (h *OrganizationHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+// ids returns the authenticated user ID and the organization ID of the URL
+func (h *OrganizationHandler) ids(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid organization ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userUUID, orgID, true
+}
+
+// decode decodes and validates a JSON request body, writing an error response if invalid
+func decode(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := httputil.DecodeJSON(r, req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid request body")
+		return false
+	}
+	if err := validator.Validate(req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			httputil.ValidationError(w, validationErrs)
+			return false
+		}
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeValidation, err.Error())
+		return false
+	}
+	return true
+}
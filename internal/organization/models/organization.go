@@ -0,0 +1,97 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/models"
+)
+
+// Member roles, from most to least privileged
+const (
+	RoleOwner  = "owner"  // Single per organization, its plan or license covers the calendars
+	RoleAdmin  = "admin"  // Manages members and all calendars
+	RoleMember = "member" // Views the calendars
+)
+
+// Header selects the organization the calendar API acts on (org switcher)
+// Without it, the API acts on the personal calendars of the user
+const Header = "X-Organization-ID"
+
+// Organization owns calendars collectively
+type Organization struct {
+	models.TimestampedEntity
+	Name string `json:"name"`
+}
+
+// Member is a user belonging to an organization
+type Member struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Email       string    `json:"email"`
+	DisplayName string    `json:"display_name"`
+	Role        string    `json:"role" enums:"owner,admin,member"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Membership is the role of a user in an organization
+type Membership struct {
+	OrganizationID uuid.UUID
+	OwnerID        uuid.UUID // Owner of the organization, whose quota covers its calendars
+	Role           string
+}
+
+// CanManage reports whether the role manages members and calendars
+func (m *Membership) CanManage() bool {
+	return m.Role == RoleOwner || m.Role == RoleAdmin
+}
+
+// CreateOrganizationRequest creates an organization owned by the current user
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+// UpdateOrganizationRequest renames an organization
+type UpdateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+// AddMemberRequest adds an existing user to an organization
+type AddMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=admin member"`
+}
+
+// UpdateMemberRequest changes the role of a member
+type UpdateMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin member"`
+}
+
+// OrganizationResponse is an organization with the role of the current user
+type OrganizationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role" enums:"owner,admin,member"`
+	Members   []Member  `json:"members,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type membershipKey struct{}
+
+// WithMembership stores the organization selected by the request in the context
+func WithMembership(ctx context.Context, membership *Membership) context.Context {
+	return context.WithValue(ctx, membershipKey{}, membership)
+}
+
+// MembershipFromContext returns the organization selected by the request, nil for personal calendars
+func MembershipFromContext(ctx context.Context) *Membership {
+	membership, _ := ctx.Value(membershipKey{}).(*Membership)
+	return membership
+}
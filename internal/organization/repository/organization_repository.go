@@ -0,0 +1,225 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/organization/models"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrNotMember            = errors.New("user is not a member of the organization")
+	ErrAlreadyMember        = errors.New("user is already a member of the organization")
+)
+
+// OrganizationRepository handles organizations and their members
+type OrganizationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(pool *pgxpool.Pool) *OrganizationRepository {
+	return &OrganizationRepository{pool: pool}
+}
+
+// Create creates an organization owned by a user
+func (r *OrganizationRepository) Create(ctx context.Context, org *models.Organization, ownerID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO organizations (id, name)
+		VALUES ($1, $2)
+		RETURNING created_at, updated_at`,
+		org.ID, org.Name,
+	).Scan(&org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)`,
+		org.ID, ownerID, models.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("failed to add organization owner: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetByID returns an organization
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	org := &models.Organization{}
+	err := r.pool.QueryRow(ctx, `SELECT id, name, created_at, updated_at FROM organizations WHERE id = $1`, id).
+		Scan(&org.ID, &org.Name, &org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return org, nil
+}
+
+// ListByUser returns the organizations of a user with their role
+func (r *OrganizationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.OrganizationResponse, error) {
+	query := `
+		SELECT o.id, o.name, m.role, o.created_at, o.updated_at
+		FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	orgs := []*models.OrganizationResponse{}
+	for rows.Next() {
+		org := &models.OrganizationResponse{}
+		if err := rows.Scan(&org.ID, &org.Name, &org.Role, &org.CreatedAt, &org.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, org)
+	}
+
+	return orgs, rows.Err()
+}
+
+// Update renames an organization
+func (r *OrganizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	err := r.pool.QueryRow(ctx, `
+		UPDATE organizations SET name = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		org.ID, org.Name,
+	).Scan(&org.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrganizationNotFound
+		}
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes an organization, its calendars going back to their creators
+func (r *OrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM organizations WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrOrganizationNotFound
+	}
+	return nil
+}
+
+// GetMembership returns the role of a user in an organization, with the owner of the organization
+func (r *OrganizationRepository) GetMembership(ctx context.Context, organizationID, userID uuid.UUID) (*models.Membership, error) {
+	query := `
+		SELECT m.role, o.user_id
+		FROM organization_members m
+		JOIN organization_members o ON o.organization_id = m.organization_id AND o.role = 'owner'
+		WHERE m.organization_id = $1 AND m.user_id = $2`
+
+	membership := &models.Membership{OrganizationID: organizationID}
+	err := r.pool.QueryRow(ctx, query, organizationID, userID).Scan(&membership.Role, &membership.OwnerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotMember
+		}
+		return nil, fmt.Errorf("failed to get membership: %w", err)
+	}
+	return membership, nil
+}
+
+// ListMembers returns the members of an organization
+func (r *OrganizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]models.Member, error) {
+	query := `
+		SELECT u.id, u.email, u.display_name, m.role, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, u.display_name`
+
+	rows, err := r.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.Member
+	for rows.Next() {
+		var m models.Member
+		if err := rows.Scan(&m.UserID, &m.Email, &m.DisplayName, &m.Role, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+// AddMember adds a user to an organization
+func (r *OrganizationRepository) AddMember(ctx context.Context, organizationID, userID uuid.UUID, role string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)`,
+		organizationID, userID, role)
+	if err != nil {
+		if strings.Contains(err.Error(), "23505") {
+			return ErrAlreadyMember
+		}
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+	return nil
+}
+
+// UpdateMemberRole changes the role of a member other than the owner
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID uuid.UUID, role string) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE organization_members SET role = $3
+		WHERE organization_id = $1 AND user_id = $2 AND role <> 'owner'`,
+		organizationID, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to update member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotMember
+	}
+	return nil
+}
+
+// RemoveMember removes a member other than the owner
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM organization_members
+		WHERE organization_id = $1 AND user_id = $2 AND role <> 'owner'`,
+		organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotMember
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	authRepo "github.com/whento/whento/internal/auth/repository"
+	"github.com/whento/whento/internal/organization/models"
+	"github.com/whento/whento/internal/organization/repository"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrForbidden            = errors.New("your role in the organization doesn't allow this")
+	ErrUserNotFound         = errors.New("no account with this email")
+	ErrAlreadyMember        = errors.New("user is already a member of the organization")
+	ErrMemberNotFound       = errors.New("member not found")
+	ErrOwnerRole            = errors.New("the owner of an organization can't be changed or removed")
+)
+
+// OrganizationService handles organizations and their members
+type OrganizationService struct {
+	repo     *repository.OrganizationRepository
+	userRepo *authRepo.UserRepository
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(repo *repository.OrganizationRepository, userRepo *authRepo.UserRepository) *OrganizationService {
+	return &OrganizationService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// Membership returns the role of a user in an organization, nil if the user is not a member
+func (s *OrganizationService) Membership(ctx context.Context, organizationID, userID uuid.UUID) (*models.Membership, error) {
+	membership, err := s.repo.GetMembership(ctx, organizationID, userID)
+	if errors.Is(err, repository.ErrNotMember) {
+		return nil, nil
+	}
+	return membership, err
+}
+
+// List lists the organizations of a user
+func (s *OrganizationService) List(ctx context.Context, userID uuid.UUID) ([]*models.OrganizationResponse, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Create creates an organization owned by the user
+func (s *OrganizationService) Create(ctx context.Context, userID uuid.UUID, req *models.CreateOrganizationRequest) (*models.OrganizationResponse, error) {
+	org := &models.Organization{Name: req.Name}
+	org.ID = uuid.New()
+
+	if err := s.repo.Create(ctx, org, userID); err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, userID, org.ID)
+}
+
+// Get returns an organization with its members, for its members only
+func (s *OrganizationService) Get(ctx context.Context, userID, organizationID uuid.UUID) (*models.OrganizationResponse, error) {
+	membership, err := s.member(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.repo.GetByID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	members, err := s.repo.ListMembers(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OrganizationResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		Role:      membership.Role,
+		Members:   members,
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}, nil
+}
+
+// Update renames an organization (owner or admin)
+func (s *OrganizationService) Update(ctx context.Context, userID, organizationID uuid.UUID, req *models.UpdateOrganizationRequest) (*models.OrganizationResponse, error) {
+	if _, err := s.manager(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	org := &models.Organization{Name: req.Name}
+	org.ID = organizationID
+	if err := s.repo.Update(ctx, org); err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return s.Get(ctx, userID, organizationID)
+}
+
+// Delete deletes an organization (owner only), its calendars going back to their creators
+func (s *OrganizationService) Delete(ctx context.Context, userID, organizationID uuid.UUID) error {
+	membership, err := s.member(ctx, organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if membership.Role != models.RoleOwner {
+		return ErrForbidden
+	}
+
+	if err := s.repo.Delete(ctx, organizationID); err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return ErrOrganizationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// AddMember adds an existing user to an organization (owner or admin)
+func (s *OrganizationService) AddMember(ctx context.Context, userID, organizationID uuid.UUID, req *models.AddMemberRequest) (*models.OrganizationResponse, error) {
+	if _, err := s.manager(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, authRepo.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.repo.AddMember(ctx, organizationID, user.ID, req.Role); err != nil {
+		if errors.Is(err, repository.ErrAlreadyMember) {
+			return nil, ErrAlreadyMember
+		}
+		return nil, err
+	}
+
+	return s.Get(ctx, userID, organizationID)
+}
+
+// UpdateMember changes the role of a member (owner or admin)
+func (s *OrganizationService) UpdateMember(ctx context.Context, userID, organizationID, memberID uuid.UUID, req *models.UpdateMemberRequest) (*models.OrganizationResponse, error) {
+	if _, err := s.manager(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotOwner(ctx, organizationID, memberID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateMemberRole(ctx, organizationID, memberID, req.Role); err != nil {
+		if errors.Is(err, repository.ErrNotMember) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	return s.Get(ctx, userID, organizationID)
+}
+
+// RemoveMember removes a member (owner or admin), or lets a member leave the organization
+func (s *OrganizationService) RemoveMember(ctx context.Context, userID, organizationID, memberID uuid.UUID) error {
+	if memberID == userID {
+		if _, err := s.member(ctx, organizationID, userID); err != nil {
+			return err
+		}
+	} else if _, err := s.manager(ctx, organizationID, userID); err != nil {
+		return err
+	}
+	if err := s.checkNotOwner(ctx, organizationID, memberID); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveMember(ctx, organizationID, memberID); err != nil {
+		if errors.Is(err, repository.ErrNotMember) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// member returns the membership of a user, hiding organizations the user doesn't belong to
+func (s *OrganizationService) member(ctx context.Context, organizationID, userID uuid.UUID) (*models.Membership, error) {
+	membership, err := s.Membership(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return membership, nil
+}
+
+// manager returns the membership of a user allowed to manage the organization
+func (s *OrganizationService) manager(ctx context.Context, organizationID, userID uuid.UUID) (*models.Membership, error) {
+	membership, err := s.member(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !membership.CanManage() {
+		return nil, ErrForbidden
+	}
+	return membership, nil
+}
+
+// checkNotOwner refuses changes to the owner of the organization
+func (s *OrganizationService) checkNotOwner(ctx context.Context, organizationID, memberID uuid.UUID) error {
+	target, err := s.Membership(ctx, organizationID, memberID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return ErrMemberNotFound
+	}
+	if target.Role == models.RoleOwner {
+		return ErrOwnerRole
+	}
+	return nil
+}
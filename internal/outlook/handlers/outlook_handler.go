@@ -0,0 +1,171 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/whento/internal/outlook/service"
+)
+
+// OutlookHandler handles HTTP requests for the Outlook calendar push
+type OutlookHandler struct {
+	service *service.OutlookService
+	logger  *slog.Logger
+}
+
+// NewOutlookHandler creates a new Outlook handler
+func NewOutlookHandler(service *service.OutlookService, logger *slog.Logger) *OutlookHandler {
+	return &OutlookHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		Get the Outlook connection
+// @Description	Returns whether Outlook is configured on this server, whether the current user connected an account, and the outcome of the last push
+// @Tags			Outlook
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	models.StatusResponse	"Outlook connection"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/outlook [get]
+func (h *OutlookHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.GetStatus(r.Context(), userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to get Outlook connection")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, status)
+}
+
+// @Summary		Connect Outlook
+// @Description	Returns the Microsoft consent page; open it in the browser. After consent, Microsoft redirects to the callback, which redirects to /settings?outlook=connected (or outlook=error). The confirmed events of the calendars owned by the user are then pushed to the default calendar of the account.
+// @Tags			Outlook
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	models.ConnectResponse	"Consent page URL"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"Outlook not configured on this server"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/outlook/connect [post]
+func (h *OutlookHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.Connect(userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to connect Outlook")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response)
+}
+
+// @Summary		Outlook OAuth callback
+// @Description	Called by Microsoft after consent; stores the connection, starts a first push and redirects to the settings page
+// @Tags			Outlook
+// @Param			state	query	string	true	"State returned by Microsoft"
+// @Param			code	query	string	false	"Authorization code"
+// @Success		302		"Redirect to the settings page"
+// @Router			/api/v1/outlook/callback [get]
+func (h *OutlookHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	target := h.service.HandleCallback(r.Context(), query.Get("state"), query.Get("code"))
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// @Summary		Push events to Outlook now
+// @Description	Creates, updates and deletes the Outlook events of the current user without waiting for the periodic push
+// @Tags			Outlook
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	models.StatusResponse	"Outlook connection after the push"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"Outlook not connected"
+// @Failure		409	{object}	httputil.ErrorResponse	"Outlook authorization expired, connect again"
+// @Failure		502	{object}	httputil.ErrorResponse	"Outlook unavailable"
+// @Router			/api/v1/outlook/sync [post]
+func (h *OutlookHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.Sync(r.Context(), userUUID)
+	if err != nil {
+		h.handleError(w, err, userUUID, "Failed to push events to Outlook")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, status)
+}
+
+// @Summary		Disconnect Outlook
+// @Description	Deletes the pushed events from the Outlook calendar when the account is still reachable, then forgets the connection
+// @Tags			Outlook
+// @Security		BearerAuth
+// @Success		204	"Outlook disconnected"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse	"Outlook not connected"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/outlook [delete]
+func (h *OutlookHandler) Disconnect(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Disconnect(r.Context(), userUUID); err != nil {
+		h.handleError(w, err, userUUID, "Failed to disconnect Outlook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *OutlookHandler) handleError(w http.ResponseWriter, err error, userID uuid.UUID, defaultMsg string) {
+	switch {
+	case errors.Is(err, service.ErrNotConfigured):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, err.Error())
+	case errors.Is(err, service.ErrNotConnected):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Outlook not connected")
+	case errors.Is(err, service.ErrAuthorization):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, "Outlook authorization expired or revoked, connect it again")
+	case errors.Is(err, service.ErrGraph):
+		h.logger.Warn(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusBadGateway, httputil.ErrCodeInternal, "Outlook unavailable")
+	default:
+		h.logger.Error(defaultMsg, "error", err, "user_id", userID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
+	}
+}
+
+// userID returns the authenticated user ID, writing an error response if missing
+func (h *OutlookHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
@@ -0,0 +1,48 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Connection is the Outlook account of a user, connected through OAuth
+type Connection struct {
+	UserID        uuid.UUID
+	AccountEmail  string
+	AccessToken   string
+	RefreshToken  string
+	ExpiresAt     time.Time
+	LastSyncAt    *time.Time
+	LastSyncError *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// PushedEvent is an Outlook event created for a confirmed event of a calendar
+type PushedEvent struct {
+	CalendarID uuid.UUID
+	Name       string // Resource name of the event in the calendar, stable across syncs
+	EventID    string // Microsoft Graph event ID
+	ETag       string // Entity tag of the pushed version, the event is updated when it changes
+}
+
+// StatusResponse describes the Outlook integration for the current user
+type StatusResponse struct {
+	Configured    bool       `json:"configured"` // OAuth client set up on this server
+	Connected     bool       `json:"connected"`
+	AccountEmail  string     `json:"account_email,omitempty"`
+	ConnectedAt   *time.Time `json:"connected_at,omitempty"`
+	LastSyncAt    *time.Time `json:"last_sync_at,omitempty"`
+	LastSyncError *string    `json:"last_sync_error,omitempty"`
+}
+
+// ConnectResponse is returned when starting to connect an Outlook account
+type ConnectResponse struct {
+	AuthURL string `json:"auth_url"` // Microsoft consent page to open in the browser
+}
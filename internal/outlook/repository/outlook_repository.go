@@ -0,0 +1,183 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/outlook/models"
+)
+
+var ErrConnectionNotFound = errors.New("outlook connection not found")
+
+// OutlookRepository handles Outlook connections and the events pushed to them
+type OutlookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOutlookRepository creates a new Outlook repository
+func NewOutlookRepository(pool *pgxpool.Pool) *OutlookRepository {
+	return &OutlookRepository{pool: pool}
+}
+
+const connectionColumns = `user_id, account_email, access_token, refresh_token, expires_at, last_sync_at, last_sync_error, created_at, updated_at`
+
+// Upsert creates or replaces the connection of a user
+// Reconnecting keeps the pushed events, so they are updated rather than duplicated
+func (r *OutlookRepository) Upsert(ctx context.Context, conn *models.Connection) error {
+	query := `
+		INSERT INTO outlook_connections (user_id, account_email, access_token, refresh_token, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			account_email = EXCLUDED.account_email,
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			expires_at = EXCLUDED.expires_at,
+			last_sync_error = NULL,
+			updated_at = NOW()
+		RETURNING created_at, updated_at`
+
+	err := r.pool.QueryRow(ctx, query, conn.UserID, conn.AccountEmail, conn.AccessToken, conn.RefreshToken, conn.ExpiresAt).
+		Scan(&conn.CreatedAt, &conn.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save outlook connection: %w", err)
+	}
+	return nil
+}
+
+// Get returns the connection of a user
+func (r *OutlookRepository) Get(ctx context.Context, userID uuid.UUID) (*models.Connection, error) {
+	query := `SELECT ` + connectionColumns + ` FROM outlook_connections WHERE user_id = $1`
+
+	conn, err := scanConnection(r.pool.QueryRow(ctx, query, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrConnectionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outlook connection: %w", err)
+	}
+	return conn, nil
+}
+
+// ListAll returns every connection
+func (r *OutlookRepository) ListAll(ctx context.Context) ([]*models.Connection, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+connectionColumns+` FROM outlook_connections ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outlook connections: %w", err)
+	}
+	defer rows.Close()
+
+	var conns []*models.Connection
+	for rows.Next() {
+		conn, err := scanConnection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outlook connection: %w", err)
+		}
+		conns = append(conns, conn)
+	}
+
+	return conns, rows.Err()
+}
+
+// UpdateTokens stores refreshed tokens
+func (r *OutlookRepository) UpdateTokens(ctx context.Context, conn *models.Connection) error {
+	query := `
+		UPDATE outlook_connections
+		SET access_token = $2, refresh_token = $3, expires_at = $4, updated_at = NOW()
+		WHERE user_id = $1`
+
+	_, err := r.pool.Exec(ctx, query, conn.UserID, conn.AccessToken, conn.RefreshToken, conn.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to update outlook tokens: %w", err)
+	}
+	return nil
+}
+
+// SetSyncResult records the outcome of a sync, syncErr being nil on success
+func (r *OutlookRepository) SetSyncResult(ctx context.Context, userID uuid.UUID, syncErr *string) error {
+	query := `UPDATE outlook_connections SET last_sync_at = NOW(), last_sync_error = $2 WHERE user_id = $1`
+	if syncErr != nil {
+		// A failed sync keeps the time of the last successful one
+		query = `UPDATE outlook_connections SET last_sync_error = $2 WHERE user_id = $1`
+	}
+
+	if _, err := r.pool.Exec(ctx, query, userID, syncErr); err != nil {
+		return fmt.Errorf("failed to record outlook sync: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes the connection of a user and forgets their pushed events
+func (r *OutlookRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM outlook_connections WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete outlook connection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrConnectionNotFound
+	}
+	return nil
+}
+
+// ListEvents returns the events pushed to the Outlook account of a user
+func (r *OutlookRepository) ListEvents(ctx context.Context, userID uuid.UUID) ([]models.PushedEvent, error) {
+	rows, err := r.pool.Query(ctx, `SELECT calendar_id, name, event_id, etag FROM outlook_events WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outlook events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.PushedEvent
+	for rows.Next() {
+		var event models.PushedEvent
+		if err := rows.Scan(&event.CalendarID, &event.Name, &event.EventID, &event.ETag); err != nil {
+			return nil, fmt.Errorf("failed to scan outlook event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// SaveEvent records a created or updated Outlook event
+func (r *OutlookRepository) SaveEvent(ctx context.Context, userID uuid.UUID, event *models.PushedEvent) error {
+	query := `
+		INSERT INTO outlook_events (user_id, calendar_id, name, event_id, etag)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, calendar_id, name) DO UPDATE SET
+			event_id = EXCLUDED.event_id,
+			etag = EXCLUDED.etag`
+
+	if _, err := r.pool.Exec(ctx, query, userID, event.CalendarID, event.Name, event.EventID, event.ETag); err != nil {
+		return fmt.Errorf("failed to save outlook event: %w", err)
+	}
+	return nil
+}
+
+// DeleteEvent forgets a deleted Outlook event
+func (r *OutlookRepository) DeleteEvent(ctx context.Context, userID uuid.UUID, calendarID uuid.UUID, name string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM outlook_events WHERE user_id = $1 AND calendar_id = $2 AND name = $3`, userID, calendarID, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete outlook event: %w", err)
+	}
+	return nil
+}
+
+func scanConnection(row pgx.Row) (*models.Connection, error) {
+	var conn models.Connection
+	err := row.Scan(&conn.UserID, &conn.AccountEmail, &conn.AccessToken, &conn.RefreshToken, &conn.ExpiresAt,
+		&conn.LastSyncAt, &conn.LastSyncError, &conn.CreatedAt, &conn.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &conn, nil
+}
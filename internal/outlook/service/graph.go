@@ -0,0 +1,260 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const maxResponseBodySize = 1 << 20
+
+var (
+	ErrGraph         = errors.New("microsoft graph request failed")                 // Unreachable API or unexpected response
+	ErrAuthorization = errors.New("outlook authorization expired or revoked")       // The user must connect their account again
+	errEventNotFound = errors.New("outlook event not found (deleted by the user?)") // Recreated on the next push
+)
+
+// Tokens are the OAuth tokens of an Outlook connection
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string // Empty when Microsoft keeps the previous one
+	ExpiresAt    time.Time
+	AccountEmail string // From the ID token, only set by Exchange
+}
+
+// GraphEvent is an event of the Microsoft Graph calendar API
+type GraphEvent struct {
+	Subject      string         `json:"subject"`
+	Body         graphBody      `json:"body"`
+	Start        graphDateTime  `json:"start"`
+	End          graphDateTime  `json:"end"`
+	IsAllDay     bool           `json:"isAllDay"`
+	ShowAs       string         `json:"showAs"`
+	Location     *graphLocation `json:"location,omitempty"`
+	IsReminderOn bool           `json:"isReminderOn"`
+}
+
+type graphBody struct {
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+type graphDateTime struct {
+	DateTime string `json:"dateTime"` // Without offset, in TimeZone
+	TimeZone string `json:"timeZone"`
+}
+
+type graphLocation struct {
+	DisplayName string `json:"displayName"`
+}
+
+// GraphClient connects Outlook accounts with OAuth 2.0 and manages their events with Microsoft Graph
+type GraphClient struct {
+	httpClient   *http.Client
+	clientID     string
+	clientSecret string
+	authURL      string
+	tokenURL     string
+	apiURL       string
+}
+
+// NewGraphClient creates a Microsoft Graph client
+// tenant is a tenant ID or domain, "organizations" for any work account, or "common" to also accept personal accounts
+func NewGraphClient(httpClient *http.Client, clientID, clientSecret, tenant string) *GraphClient {
+	base := "https://login.microsoftonline.com/" + url.PathEscape(tenant) + "/oauth2/v2.0"
+	return &GraphClient{
+		httpClient:   httpClient,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		authURL:      base + "/authorize",
+		tokenURL:     base + "/token",
+		apiURL:       "https://graph.microsoft.com/v1.0",
+	}
+}
+
+// AuthURL returns the consent page URL, which redirects to redirectURI with a code
+func (c *GraphClient) AuthURL(state, redirectURI string) string {
+	params := url.Values{
+		"client_id":     {c.clientID},
+		"redirect_uri":  {redirectURI},
+		"response_type": {"code"},
+		"scope":         {"openid email offline_access https://graph.microsoft.com/Calendars.ReadWrite"},
+		"state":         {state},
+	}
+	return c.authURL + "?" + params.Encode()
+}
+
+// Exchange exchanges an authorization code for tokens
+func (c *GraphClient) Exchange(ctx context.Context, code, redirectURI string) (*Tokens, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+	})
+}
+
+// Refresh obtains a new access token
+func (c *GraphClient) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+// token calls the token endpoint
+func (c *GraphClient) token(ctx context.Context, form url.Values) (*Tokens, error) {
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGraph, err)
+	}
+	defer resp.Body.Close()
+
+	// invalid_grant: the code was already used, or the refresh token was revoked
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrAuthorization
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: token endpoint returned %d", ErrGraph, resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		IDToken      string `json:"id_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBodySize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: invalid token response: %v", ErrGraph, err)
+	}
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("%w: no access token", ErrGraph)
+	}
+
+	return &Tokens{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+		AccountEmail: idTokenEmail(body.IDToken),
+	}, nil
+}
+
+// CreateEvent creates an event in the default calendar of the account and returns its ID
+func (c *GraphClient) CreateEvent(ctx context.Context, accessToken string, event *GraphEvent) (string, error) {
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/me/events", accessToken, event, &created); err != nil {
+		return "", err
+	}
+	if created.ID == "" {
+		return "", fmt.Errorf("%w: no event ID", ErrGraph)
+	}
+	return created.ID, nil
+}
+
+// UpdateEvent replaces the fields of an event, returning errEventNotFound if it was deleted
+func (c *GraphClient) UpdateEvent(ctx context.Context, accessToken, eventID string, event *GraphEvent) error {
+	return c.do(ctx, http.MethodPatch, "/me/events/"+url.PathEscape(eventID), accessToken, event, nil)
+}
+
+// DeleteEvent deletes an event, succeeding if it was already deleted
+func (c *GraphClient) DeleteEvent(ctx context.Context, accessToken, eventID string) error {
+	err := c.do(ctx, http.MethodDelete, "/me/events/"+url.PathEscape(eventID), accessToken, nil, nil)
+	if errors.Is(err, errEventNotFound) {
+		return nil
+	}
+	return err
+}
+
+// do sends an authenticated request to Microsoft Graph, encoding body and decoding the response into v
+func (c *GraphClient) do(ctx context.Context, method, path, accessToken string, body, v any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGraph, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return ErrAuthorization
+	case resp.StatusCode == http.StatusNotFound:
+		return errEventNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("%w: %s %s returned %d", ErrGraph, method, path, resp.StatusCode)
+	}
+
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBodySize)).Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid response: %v", ErrGraph, err)
+	}
+	return nil
+}
+
+// idTokenEmail returns the email of an ID token
+// The signature isn't checked: the token comes straight from the token endpoint over TLS
+func idTokenEmail(idToken string) string {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		PreferredUsername string `json:"preferred_username"` // Accounts without a mailbox
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	if claims.Email != "" {
+		return claims.Email
+	}
+	return claims.PreferredUsername
+}
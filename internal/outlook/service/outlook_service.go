@@ -0,0 +1,481 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/jwt"
+	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	icsModels "github.com/whento/whento/internal/ics/models"
+	"github.com/whento/whento/internal/outlook/models"
+	"github.com/whento/whento/internal/outlook/repository"
+)
+
+const (
+	// stateTTL is the time left to the user to consent on the Microsoft page
+	stateTTL = 10 * time.Minute
+
+	// statePurposeClaim identifies Outlook connections in state tokens
+	statePurposeClaim = "outlook_connect"
+
+	// tokenExpiryMargin refreshes access tokens slightly before they expire
+	tokenExpiryMargin = time.Minute
+)
+
+var (
+	ErrNotConnected  = repository.ErrConnectionNotFound
+	ErrNotConfigured = errors.New("outlook integration not configured on this server")
+	ErrInvalidState  = errors.New("invalid or expired authorization state")
+)
+
+// EventProvider returns the confirmed events of a calendar from its ICS token
+type EventProvider interface {
+	GetDAVCalendar(ctx context.Context, icsToken string, host string) (*icsModels.DAVCalendar, error)
+}
+
+// OutlookService connects the Outlook account of an owner and pushes the confirmed events of their calendars
+// to its default calendar, updating and deleting them as the events change
+type OutlookService struct {
+	repo         *repository.OutlookRepository
+	calendarRepo *calendarRepo.CalendarRepository
+	events       EventProvider
+	graph        *GraphClient // nil when not configured
+	jwtManager   *jwt.Manager
+	syncInterval time.Duration
+	appURL       string
+	logger       *slog.Logger
+}
+
+// NewOutlookService creates a new Outlook service, available when its OAuth client is configured in cfg
+func NewOutlookService(
+	repo *repository.OutlookRepository,
+	calendarRepo *calendarRepo.CalendarRepository,
+	events EventProvider,
+	jwtManager *jwt.Manager,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *OutlookService {
+	var graph *GraphClient
+	if cfg.Outlook.ClientID != "" && cfg.Outlook.ClientSecret != "" {
+		graph = NewGraphClient(&http.Client{Timeout: 15 * time.Second},
+			cfg.Outlook.ClientID, cfg.Outlook.ClientSecret, cfg.Outlook.Tenant)
+	}
+
+	return &OutlookService{
+		repo:         repo,
+		calendarRepo: calendarRepo,
+		events:       events,
+		graph:        graph,
+		jwtManager:   jwtManager,
+		syncInterval: cfg.Outlook.SyncInterval,
+		appURL:       strings.TrimRight(cfg.AppURL, "/"),
+		logger:       logger,
+	}
+}
+
+// GetStatus returns the Outlook connection of a user
+func (s *OutlookService) GetStatus(ctx context.Context, userID uuid.UUID) (*models.StatusResponse, error) {
+	status := &models.StatusResponse{Configured: s.graph != nil}
+
+	conn, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, ErrNotConnected) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status.Connected = true
+	status.AccountEmail = conn.AccountEmail
+	status.ConnectedAt = &conn.CreatedAt
+	status.LastSyncAt = conn.LastSyncAt
+	status.LastSyncError = conn.LastSyncError
+	return status, nil
+}
+
+// Connect returns the Microsoft consent page URL to connect an Outlook account
+func (s *OutlookService) Connect(userID uuid.UUID) (*models.ConnectResponse, error) {
+	if s.graph == nil {
+		return nil, ErrNotConfigured
+	}
+
+	// The state ties the callback to the user, who isn't authenticated when Microsoft redirects
+	// "sub" rather than "user_id" keeps the state from being accepted as an access token
+	state, err := s.jwtManager.GenerateCustomToken(map[string]interface{}{
+		"sub":             userID.String(),
+		statePurposeClaim: true,
+		"exp":             time.Now().Add(stateTTL).Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	return &models.ConnectResponse{AuthURL: s.graph.AuthURL(state, s.redirectURI())}, nil
+}
+
+// HandleCallback completes the connection of an Outlook account and returns the page to redirect the user to
+// The events are pushed right away, in the background
+func (s *OutlookService) HandleCallback(ctx context.Context, state, code string) string {
+	userID, err := s.completeConnection(ctx, state, code)
+	if err != nil {
+		s.logger.Warn("Failed to connect Outlook", "error", err)
+		return s.settingsURL("error")
+	}
+
+	go func() {
+		syncCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		if _, err := s.Sync(syncCtx, userID); err != nil {
+			s.logger.Warn("First Outlook sync failed", "user_id", userID, "error", err)
+		}
+	}()
+
+	return s.settingsURL("connected")
+}
+
+func (s *OutlookService) completeConnection(ctx context.Context, state, code string) (uuid.UUID, error) {
+	if s.graph == nil {
+		return uuid.Nil, ErrNotConfigured
+	}
+
+	claims, err := s.jwtManager.ValidateCustomToken(state)
+	if err != nil {
+		return uuid.Nil, ErrInvalidState
+	}
+	sub, _ := claims["sub"].(string)
+	userID, err := uuid.Parse(sub)
+	if err != nil || claims[statePurposeClaim] != true {
+		return uuid.Nil, ErrInvalidState
+	}
+	if code == "" {
+		return uuid.Nil, errors.New("consent denied")
+	}
+
+	tokens, err := s.graph.Exchange(ctx, code, s.redirectURI())
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	conn := &models.Connection{
+		UserID:       userID,
+		AccountEmail: tokens.AccountEmail,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	}
+	if err := s.repo.Upsert(ctx, conn); err != nil {
+		return uuid.Nil, err
+	}
+
+	s.logger.Info("Outlook connected", "user_id", userID)
+	return userID, nil
+}
+
+// Disconnect removes the pushed events from Outlook when possible and forgets the connection of a user
+func (s *OutlookService) Disconnect(ctx context.Context, userID uuid.UUID) error {
+	conn, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Best effort: the account may already be revoked
+	if accessToken, err := s.accessToken(ctx, conn); err == nil {
+		pushed, err := s.repo.ListEvents(ctx, userID)
+		if err == nil {
+			for _, event := range pushed {
+				if err := s.graph.DeleteEvent(ctx, accessToken, event.EventID); err != nil {
+					s.logger.Warn("Failed to delete Outlook event", "user_id", userID, "error", err)
+					break
+				}
+			}
+		}
+	}
+
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Outlook disconnected", "user_id", userID)
+	return nil
+}
+
+// Sync pushes the confirmed events of a user immediately
+func (s *OutlookService) Sync(ctx context.Context, userID uuid.UUID) (*models.StatusResponse, error) {
+	conn, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sync(ctx, conn); err != nil {
+		return nil, err
+	}
+	return s.GetStatus(ctx, userID)
+}
+
+// SyncAll pushes the confirmed events of every connected user
+func (s *OutlookService) SyncAll(ctx context.Context) {
+	conns, err := s.repo.ListAll(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list Outlook connections", "error", err)
+		return
+	}
+
+	failed := 0
+	for _, conn := range conns {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.sync(ctx, conn); err != nil {
+			failed++
+			s.logger.Warn("Outlook sync failed", "user_id", conn.UserID, "error", err)
+		}
+	}
+
+	s.logger.Info("Outlook sync completed", "connections", len(conns), "failed", failed)
+}
+
+// StartSyncTask syncs all connections periodically until ctx is cancelled
+// (disabled if the interval is 0 or the integration isn't configured)
+func (s *OutlookService) StartSyncTask(ctx context.Context) {
+	if s.graph == nil || s.syncInterval <= 0 {
+		s.logger.Info("Outlook periodic sync disabled")
+		return
+	}
+
+	s.logger.Info("Starting Outlook sync background task", "interval", s.syncInterval)
+
+	go func() {
+		ticker := time.NewTicker(s.syncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Outlook sync task stopped (context cancelled)")
+				return
+			case <-ticker.C:
+				syncCtx, cancel := context.WithTimeout(ctx, s.syncInterval)
+				s.SyncAll(syncCtx)
+				cancel()
+			}
+		}
+	}()
+}
+
+// sync pushes the confirmed events of a connection and records the outcome
+func (s *OutlookService) sync(ctx context.Context, conn *models.Connection) error {
+	err := s.push(ctx, conn)
+
+	var syncErr *string
+	if err != nil {
+		msg := err.Error()
+		syncErr = &msg
+	}
+	if recordErr := s.repo.SetSyncResult(ctx, conn.UserID, syncErr); recordErr != nil {
+		s.logger.Error("Failed to record Outlook sync", "user_id", conn.UserID, "error", recordErr)
+	}
+	return err
+}
+
+// push creates the new events of the calendars of a user, updates the changed ones and deletes the
+// ones that are no longer confirmed (or whose calendar was deleted)
+// The events of a calendar that fails to load are left untouched
+func (s *OutlookService) push(ctx context.Context, conn *models.Connection) error {
+	accessToken, err := s.accessToken(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	calendars, err := s.calendarRepo.GetByOwnerID(ctx, conn.UserID)
+	if err != nil {
+		return err
+	}
+
+	pushedList, err := s.repo.ListEvents(ctx, conn.UserID)
+	if err != nil {
+		return err
+	}
+	pushed := make(map[string]models.PushedEvent, len(pushedList))
+	for _, event := range pushedList {
+		pushed[pushedKey(event.CalendarID, event.Name)] = event
+	}
+
+	var firstErr error
+	current := make(map[string]bool)
+	kept := make(map[uuid.UUID]bool)
+	for _, calendar := range calendars {
+		dav, err := s.events.GetDAVCalendar(ctx, calendar.ICSToken, "")
+		if err != nil {
+			kept[calendar.ID] = true
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to load the events of %s: %w", calendar.Name, err)
+			}
+			continue
+		}
+
+		for _, object := range dav.Objects {
+			key := pushedKey(calendar.ID, object.Name)
+			current[key] = true
+
+			previous, exists := pushed[key]
+			if exists && previous.ETag == object.ETag {
+				continue
+			}
+			if err := s.pushEvent(ctx, conn.UserID, accessToken, calendar.ID, &object, previous, exists); err != nil {
+				if errors.Is(err, ErrAuthorization) {
+					return err
+				}
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+	}
+
+	for key, event := range pushed {
+		if current[key] || kept[event.CalendarID] {
+			continue
+		}
+		if err := s.graph.DeleteEvent(ctx, accessToken, event.EventID); err != nil {
+			if errors.Is(err, ErrAuthorization) {
+				return err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if err := s.repo.DeleteEvent(ctx, conn.UserID, event.CalendarID, event.Name); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// pushEvent creates an event in Outlook, or updates the previously pushed one
+// An event deleted from Outlook by the user is created again
+func (s *OutlookService) pushEvent(ctx context.Context, userID uuid.UUID, accessToken string, calendarID uuid.UUID, object *icsModels.DAVObject, previous models.PushedEvent, exists bool) error {
+	event, err := graphEvent(object)
+	if err != nil {
+		return err
+	}
+
+	eventID := previous.EventID
+	if exists {
+		err = s.graph.UpdateEvent(ctx, accessToken, eventID, event)
+	}
+	if !exists || errors.Is(err, errEventNotFound) {
+		eventID, err = s.graph.CreateEvent(ctx, accessToken, event)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SaveEvent(ctx, userID, &models.PushedEvent{
+		CalendarID: calendarID,
+		Name:       object.Name,
+		EventID:    eventID,
+		ETag:       object.ETag,
+	})
+}
+
+// accessToken returns a valid access token of a connection, refreshing it if needed
+func (s *OutlookService) accessToken(ctx context.Context, conn *models.Connection) (string, error) {
+	if s.graph == nil {
+		return "", ErrNotConfigured
+	}
+	if time.Now().Add(tokenExpiryMargin).Before(conn.ExpiresAt) {
+		return conn.AccessToken, nil
+	}
+
+	if conn.RefreshToken == "" {
+		return "", ErrAuthorization
+	}
+	tokens, err := s.graph.Refresh(ctx, conn.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	conn.AccessToken = tokens.AccessToken
+	conn.ExpiresAt = tokens.ExpiresAt
+	if tokens.RefreshToken != "" {
+		conn.RefreshToken = tokens.RefreshToken
+	}
+	if err := s.repo.UpdateTokens(ctx, conn); err != nil {
+		return "", err
+	}
+
+	return conn.AccessToken, nil
+}
+
+// redirectURI returns the OAuth callback URL, to register in the Microsoft Entra app
+func (s *OutlookService) redirectURI() string {
+	return s.appURL + "/api/v1/outlook/callback"
+}
+
+// settingsURL returns the settings page showing the outcome of a connection
+func (s *OutlookService) settingsURL(status string) string {
+	params := url.Values{"outlook": {status}}
+	return s.appURL + "/settings?" + params.Encode()
+}
+
+func pushedKey(calendarID uuid.UUID, name string) string {
+	return calendarID.String() + "/" + name
+}
+
+// graphEvent converts a confirmed event to a Microsoft Graph event
+// Timed events are sent in UTC; all-day events keep their date
+func graphEvent(object *icsModels.DAVObject) (*GraphEvent, error) {
+	cal, err := ics.ParseCalendar(strings.NewReader(object.Data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid event %s: %w", object.Name, err)
+	}
+	events := cal.Events()
+	if len(events) == 0 {
+		return nil, fmt.Errorf("invalid event %s: no VEVENT", object.Name)
+	}
+
+	text := func(property ics.ComponentProperty) string {
+		if prop := events[0].GetProperty(property); prop != nil {
+			return prop.Value
+		}
+		return ""
+	}
+
+	event := &GraphEvent{
+		Subject:  text(ics.ComponentPropertySummary),
+		Body:     graphBody{ContentType: "text", Content: text(ics.ComponentPropertyDescription)},
+		IsAllDay: object.AllDay,
+		ShowAs:   "busy",
+	}
+	if location := text(ics.ComponentPropertyLocation); location != "" {
+		event.Location = &graphLocation{DisplayName: location}
+	}
+	event.IsReminderOn = len(events[0].Alarms()) > 0
+
+	const layout = "2006-01-02T15:04:05"
+	if object.AllDay {
+		event.Start = graphDateTime{DateTime: object.Start.Format("2006-01-02") + "T00:00:00", TimeZone: "UTC"}
+		event.End = graphDateTime{DateTime: object.End.Format("2006-01-02") + "T00:00:00", TimeZone: "UTC"}
+	} else {
+		event.Start = graphDateTime{DateTime: object.Start.UTC().Format(layout), TimeZone: "UTC"}
+		event.End = graphDateTime{DateTime: object.End.UTC().Format(layout), TimeZone: "UTC"}
+	}
+	return event, nil
+}
@@ -0,0 +1,140 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	icsModels "github.com/whento/whento/internal/ics/models"
+)
+
+const testEvent = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//WhenTo//EN\r\n" +
+	"BEGIN:VEVENT\r\nUID:event-1\r\nDTSTAMP:20250101T000000Z\r\n" +
+	"DTSTART:20250310T180000Z\r\nDTEND:20250310T200000Z\r\n" +
+	"SUMMARY:Rehearsal\\, band\r\nDESCRIPTION:4 participants\\nBring music\r\nLOCATION:Studio\r\n" +
+	"BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER:-PT1H\r\nEND:VALARM\r\n" +
+	"END:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func TestGraphEvent(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	object := &icsModels.DAVObject{
+		Name:  "event-1.ics",
+		Start: time.Date(2025, 3, 10, 19, 0, 0, 0, paris),
+		End:   time.Date(2025, 3, 10, 21, 0, 0, 0, paris),
+		Data:  testEvent,
+	}
+
+	event, err := graphEvent(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Subject != "Rehearsal, band" {
+		t.Errorf("Subject = %q", event.Subject)
+	}
+	if event.Body.Content != "4 participants\nBring music" {
+		t.Errorf("Body = %q", event.Body.Content)
+	}
+	if event.Location == nil || event.Location.DisplayName != "Studio" {
+		t.Errorf("Location = %+v", event.Location)
+	}
+	if !event.IsReminderOn || event.IsAllDay || event.ShowAs != "busy" {
+		t.Errorf("IsReminderOn = %v, IsAllDay = %v, ShowAs = %q", event.IsReminderOn, event.IsAllDay, event.ShowAs)
+	}
+	if event.Start != (graphDateTime{DateTime: "2025-03-10T18:00:00", TimeZone: "UTC"}) {
+		t.Errorf("Start = %+v", event.Start)
+	}
+	if event.End != (graphDateTime{DateTime: "2025-03-10T20:00:00", TimeZone: "UTC"}) {
+		t.Errorf("End = %+v", event.End)
+	}
+}
+
+func TestGraphEvent_AllDay(t *testing.T) {
+	// All-day events keep their date whatever the offset of the calendar
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	object := &icsModels.DAVObject{
+		Name:   "event-1.ics",
+		Start:  time.Date(2025, 3, 10, 0, 0, 0, 0, tokyo),
+		End:    time.Date(2025, 3, 11, 0, 0, 0, 0, tokyo),
+		AllDay: true,
+		Data:   testEvent,
+	}
+
+	event, err := graphEvent(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !event.IsAllDay {
+		t.Error("IsAllDay = false")
+	}
+	if event.Start.DateTime != "2025-03-10T00:00:00" || event.End.DateTime != "2025-03-11T00:00:00" {
+		t.Errorf("Start = %+v, End = %+v", event.Start, event.End)
+	}
+}
+
+func TestGraphEvent_Invalid(t *testing.T) {
+	if _, err := graphEvent(&icsModels.DAVObject{Name: "broken.ics", Data: "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"}); err == nil {
+		t.Error("expected an error for a calendar without event")
+	}
+}
+
+func TestGraphClient_Events(t *testing.T) {
+	var created GraphEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/me/events":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id":"AAMk-1"}`)
+		case r.URL.Path == "/me/events/AAMk-1":
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewGraphClient(server.Client(), "client", "secret", "common")
+	c.apiURL = server.URL
+	ctx := context.Background()
+	event := &GraphEvent{Subject: "Rehearsal", ShowAs: "busy"}
+
+	id, err := c.CreateEvent(ctx, "access-1", event)
+	if err != nil || id != "AAMk-1" {
+		t.Fatalf("CreateEvent = %q, %v", id, err)
+	}
+	if created.Subject != "Rehearsal" {
+		t.Errorf("created subject = %q", created.Subject)
+	}
+
+	if err := c.UpdateEvent(ctx, "access-1", "AAMk-1", event); err != nil {
+		t.Errorf("UpdateEvent: %v", err)
+	}
+	if err := c.UpdateEvent(ctx, "access-1", "deleted", event); !errors.Is(err, errEventNotFound) {
+		t.Errorf("UpdateEvent of a deleted event = %v, want errEventNotFound", err)
+	}
+	if err := c.DeleteEvent(ctx, "access-1", "deleted"); err != nil {
+		t.Errorf("DeleteEvent of a deleted event = %v, want nil", err)
+	}
+	if _, err := c.CreateEvent(ctx, "revoked", event); !errors.Is(err, ErrAuthorization) {
+		t.Errorf("CreateEvent with a revoked token = %v, want ErrAuthorization", err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/whento/pkg/middleware"
 	"github.com/whento/pkg/validator"
 	authService "github.com/whento/whento/internal/auth/service"
+	mfaService "github.com/whento/whento/internal/mfa/service"
 	"github.com/whento/whento/internal/passkey/models"
 	"github.com/whento/whento/internal/passkey/service"
 )
@@ -25,14 +26,16 @@ import (
 type PasskeyHandler struct {
 	service     *service.PasskeyService
 	authService *authService.AuthService
+	mfaService  *mfaService.MFAService
 	logger      *slog.Logger
 }
 
 // NewPasskeyHandler creates a new passkey handler
-func NewPasskeyHandler(service *service.PasskeyService, authSvc *authService.AuthService, logger *slog.Logger) *PasskeyHandler {
+func NewPasskeyHandler(service *service.PasskeyService, authSvc *authService.AuthService, mfaSvc *mfaService.MFAService, logger *slog.Logger) *PasskeyHandler {
 	return &PasskeyHandler{
 		service:     service,
 		authService: authSvc,
+		mfaService:  mfaSvc,
 		logger:      logger,
 	}
 }
@@ -49,6 +52,26 @@ func NewPasskeyHandler(service *service.PasskeyService, authSvc *authService.Aut
 // @Failure		500	{object}	httputil.ErrorResponse				"Internal server error"
 // @Router			/api/v1/passkey/register/begin [post]
 func (h *PasskeyHandler) BeginRegistration(w http.ResponseWriter, r *http.Request) {
+	h.beginRegistration(w, r, false)
+}
+
+// @Summary		Begin security key registration
+// @Description	Initiates the registration of a FIDO2 security key as a second factor for password logins. Security keys can't be used for passwordless login.
+// @Tags			Passkey
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	models.RegistrationOptionsResponse	"WebAuthn credential creation options"
+// @Failure		401	{object}	httputil.ErrorResponse				"Unauthorized"
+// @Failure		404	{object}	httputil.ErrorResponse				"User not found"
+// @Failure		500	{object}	httputil.ErrorResponse				"Internal server error"
+// @Router			/api/v1/passkey/security-key/begin [post]
+func (h *PasskeyHandler) BeginSecurityKeyRegistration(w http.ResponseWriter, r *http.Request) {
+	h.beginRegistration(w, r, true)
+}
+
+// beginRegistration starts the registration of a passkey or security key
+func (h *PasskeyHandler) beginRegistration(w http.ResponseWriter, r *http.Request, secondFactor bool) {
 	userID := middleware.GetUserID(r.Context())
 	if userID == "" {
 		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
@@ -61,7 +84,7 @@ func (h *PasskeyHandler) BeginRegistration(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	options, err := h.service.BeginRegistration(r.Context(), userUUID)
+	options, err := h.service.BeginRegistration(r.Context(), userUUID, secondFactor)
 	if err != nil {
 		if errors.Is(err, service.ErrUserNotFound) {
 			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "User not found")
@@ -90,38 +113,80 @@ func (h *PasskeyHandler) BeginRegistration(w http.ResponseWriter, r *http.Reques
 // @Failure		500			{object}	httputil.ErrorResponse		"Internal server error"
 // @Router			/api/v1/passkey/register/finish [post]
 func (h *PasskeyHandler) FinishRegistration(w http.ResponseWriter, r *http.Request) {
+	passkey, ok := h.finishRegistration(w, r, false)
+	if !ok {
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, passkey.ToResponse())
+}
+
+// @Summary		Finish security key registration
+// @Description	Completes the registration of a security key. Registering the first key enables 2FA if it wasn't already, and returns backup codes; otherwise the key is accepted alongside the current 2FA method.
+// @Tags			Passkey
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			credential	body		object						true	"WebAuthn credential response from authenticator"
+// @Success		201			{object}	models.SecurityKeyResponse	"Security key registered successfully"
+// @Failure		400			{object}	httputil.ErrorResponse		"Invalid credential or challenge"
+// @Failure		401			{object}	httputil.ErrorResponse		"Unauthorized"
+// @Failure		404			{object}	httputil.ErrorResponse		"User not found"
+// @Failure		500			{object}	httputil.ErrorResponse		"Internal server error"
+// @Router			/api/v1/passkey/security-key/finish [post]
+func (h *PasskeyHandler) FinishSecurityKeyRegistration(w http.ResponseWriter, r *http.Request) {
+	passkey, ok := h.finishRegistration(w, r, true)
+	if !ok {
+		return
+	}
+
+	backupCodes, err := h.mfaService.EnableSecurityKey(r.Context(), passkey.UserID)
+	if err != nil {
+		h.logger.Error("Failed to enable 2FA with security key", "error", err, "user_id", passkey.UserID)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to enable 2FA")
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, &models.SecurityKeyResponse{
+		PasskeyResponse: passkey.ToResponse(),
+		BackupCodes:     backupCodes,
+	})
+}
+
+// finishRegistration completes the registration of a passkey or security key, writing the error response on failure
+func (h *PasskeyHandler) finishRegistration(w http.ResponseWriter, r *http.Request, secondFactor bool) (*models.Passkey, bool) {
 	userID := middleware.GetUserID(r.Context())
 	if userID == "" {
 		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
-		return
+		return nil, false
 	}
 
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid user ID")
-		return
+		return nil, false
 	}
 
-	passkey, err := h.service.FinishRegistration(r.Context(), userUUID, r)
+	passkey, err := h.service.FinishRegistration(r.Context(), userUUID, secondFactor, r)
 	if err != nil {
 		if errors.Is(err, service.ErrUserNotFound) {
 			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "User not found")
-			return
+			return nil, false
 		}
 		if errors.Is(err, service.ErrInvalidCredential) {
 			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid credential")
-			return
+			return nil, false
 		}
 		if errors.Is(err, service.ErrInvalidChallenge) {
 			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid or expired challenge")
-			return
+			return nil, false
 		}
 		h.logger.Error("Failed to finish registration", "error", err, "user_id", userID)
 		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to complete registration")
-		return
+		return nil, false
 	}
 
-	httputil.JSON(w, http.StatusCreated, passkey.ToResponse())
+	return passkey, true
 }
 
 // @Summary		List passkeys
@@ -229,7 +294,7 @@ func (h *PasskeyHandler) Rename(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary		Delete passkey
-// @Description	Deletes a passkey from the user's account. The passkey can no longer be used for authentication.
+// @Description	Deletes a passkey or security key from the user's account. The key can no longer be used for authentication. Removing the last security key disables 2FA when security keys were the only method.
 // @Tags			Passkey
 // @Produce		json
 // @Security		BearerAuth
@@ -275,6 +340,15 @@ func (h *PasskeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 2FA with security keys only ends with the last key
+	hasSecondFactor, err := h.service.HasSecondFactor(r.Context(), userUUID)
+	if err == nil && !hasSecondFactor {
+		err = h.mfaService.DisableSecurityKey(r.Context(), userUUID)
+	}
+	if err != nil {
+		h.logger.Error("Failed to update 2FA after passkey deletion", "error", err, "user_id", userID)
+	}
+
 	httputil.JSON(w, http.StatusOK, map[string]string{"message": "Passkey deleted successfully"})
 }
 
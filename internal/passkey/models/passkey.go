@@ -11,7 +11,7 @@ import (
 	"github.com/google/uuid"
 )
 
-// Passkey represents a WebAuthn credential for passwordless authentication
+// Passkey represents a WebAuthn credential for passwordless authentication, or a security key used as a second factor
 type Passkey struct {
 	ID             uuid.UUID
 	UserID         uuid.UUID
@@ -23,24 +23,27 @@ type Passkey struct {
 	Transports     []string
 	BackupEligible bool // Indicates if credential can be backed up (e.g., cloud passkey)
 	BackupState    bool // Indicates if credential is currently backed up
+	SecondFactor   bool // Security key verifying password logins, not usable for passwordless login
 	CreatedAt      time.Time
 	LastUsedAt     *time.Time
 }
 
 // PasskeyResponse is the API response for a passkey
 type PasskeyResponse struct {
-	ID         string     `json:"id"`
-	Name       string     `json:"name"`
-	CreatedAt  time.Time  `json:"created_at"`
-	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	SecondFactor bool       `json:"second_factor"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
 }
 
 // ToResponse converts a Passkey to PasskeyResponse
 func (p *Passkey) ToResponse() *PasskeyResponse {
 	return &PasskeyResponse{
-		ID:         p.ID.String(),
-		Name:       p.Name,
-		CreatedAt:  p.CreatedAt,
-		LastUsedAt: p.LastUsedAt,
+		ID:           p.ID.String(),
+		Name:         p.Name,
+		SecondFactor: p.SecondFactor,
+		CreatedAt:    p.CreatedAt,
+		LastUsedAt:   p.LastUsedAt,
 	}
 }
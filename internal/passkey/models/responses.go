@@ -15,6 +15,12 @@ type AuthenticationOptionsResponse struct {
 	PublicKey interface{} `json:"publicKey"`
 }
 
+// SecurityKeyResponse is returned when a security key is registered as a second factor
+type SecurityKeyResponse struct {
+	*PasskeyResponse
+	BackupCodes []string `json:"backup_codes,omitempty"` // Generated when the key enables 2FA
+}
+
 // DiscoverableAuthenticationOptionsResponse wraps WebAuthn authentication options for discoverable credentials
 type DiscoverableAuthenticationOptionsResponse struct {
 	PublicKey   interface{} `json:"publicKey"`
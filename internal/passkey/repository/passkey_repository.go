@@ -35,8 +35,8 @@ func NewPasskeyRepository(pool *pgxpool.Pool) *PasskeyRepository {
 // Create creates a new passkey
 func (r *PasskeyRepository) Create(ctx context.Context, passkey *models.Passkey) error {
 	query := `
-		INSERT INTO passkeys (id, user_id, name, credential_id, public_key, aaguid, sign_count, transports, backup_eligible, backup_state, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO passkeys (id, user_id, name, credential_id, public_key, aaguid, sign_count, transports, backup_eligible, backup_state, second_factor, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -50,6 +50,7 @@ func (r *PasskeyRepository) Create(ctx context.Context, passkey *models.Passkey)
 		passkey.Transports,
 		passkey.BackupEligible,
 		passkey.BackupState,
+		passkey.SecondFactor,
 		passkey.CreatedAt,
 	)
 
@@ -67,7 +68,7 @@ func (r *PasskeyRepository) Create(ctx context.Context, passkey *models.Passkey)
 // GetByID retrieves a passkey by ID
 func (r *PasskeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Passkey, error) {
 	query := `
-		SELECT id, user_id, name, credential_id, public_key, aaguid, sign_count, transports, backup_eligible, backup_state, created_at, last_used_at
+		SELECT id, user_id, name, credential_id, public_key, aaguid, sign_count, transports, backup_eligible, backup_state, second_factor, created_at, last_used_at
 		FROM passkeys
 		WHERE id = $1
 	`
@@ -84,6 +85,7 @@ func (r *PasskeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 		&passkey.Transports,
 		&passkey.BackupEligible,
 		&passkey.BackupState,
+		&passkey.SecondFactor,
 		&passkey.CreatedAt,
 		&passkey.LastUsedAt,
 	)
@@ -101,7 +103,7 @@ func (r *PasskeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 // GetByCredentialID retrieves a passkey by credential ID
 func (r *PasskeyRepository) GetByCredentialID(ctx context.Context, credentialID []byte) (*models.Passkey, error) {
 	query := `
-		SELECT id, user_id, name, credential_id, public_key, aaguid, sign_count, transports, backup_eligible, backup_state, created_at, last_used_at
+		SELECT id, user_id, name, credential_id, public_key, aaguid, sign_count, transports, backup_eligible, backup_state, second_factor, created_at, last_used_at
 		FROM passkeys
 		WHERE credential_id = $1
 	`
@@ -118,6 +120,7 @@ func (r *PasskeyRepository) GetByCredentialID(ctx context.Context, credentialID
 		&passkey.Transports,
 		&passkey.BackupEligible,
 		&passkey.BackupState,
+		&passkey.SecondFactor,
 		&passkey.CreatedAt,
 		&passkey.LastUsedAt,
 	)
@@ -135,7 +138,7 @@ func (r *PasskeyRepository) GetByCredentialID(ctx context.Context, credentialID
 // ListByUserID retrieves all passkeys for a user
 func (r *PasskeyRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Passkey, error) {
 	query := `
-		SELECT id, user_id, name, credential_id, public_key, aaguid, sign_count, transports, backup_eligible, backup_state, created_at, last_used_at
+		SELECT id, user_id, name, credential_id, public_key, aaguid, sign_count, transports, backup_eligible, backup_state, second_factor, created_at, last_used_at
 		FROM passkeys
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -161,6 +164,7 @@ func (r *PasskeyRepository) ListByUserID(ctx context.Context, userID uuid.UUID)
 			&passkey.Transports,
 			&passkey.BackupEligible,
 			&passkey.BackupState,
+			&passkey.SecondFactor,
 			&passkey.CreatedAt,
 			&passkey.LastUsedAt,
 		)
@@ -31,6 +31,7 @@ var (
 	ErrInvalidCredential = errors.New("invalid credential")
 	ErrUserNotFound      = errors.New("user not found")
 	ErrInvalidChallenge  = errors.New("invalid or expired challenge")
+	ErrNoCredentials     = errors.New("no security key or passkey registered")
 )
 
 // WebAuthnUser implements webauthn.User interface for existing users
@@ -58,8 +59,8 @@ func (u *WebAuthnUser) WebAuthnCredentials() []webauthn.Credential {
 			ID:        pk.CredentialID,
 			PublicKey: pk.PublicKey,
 			Flags: webauthn.CredentialFlags{
-				UserPresent:    true, // Passkeys always require user presence
-				UserVerified:   true, // Passkeys always require user verification
+				UserPresent:    true,             // Passkeys always require user presence
+				UserVerified:   !pk.SecondFactor, // Passkeys require user verification, security keys only a touch
 				BackupEligible: pk.BackupEligible,
 				BackupState:    pk.BackupState,
 			},
@@ -113,8 +114,8 @@ func NewPasskeyService(
 	}, nil
 }
 
-// BeginRegistration starts the passkey registration process
-func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID) (*protocol.CredentialCreation, error) {
+// BeginRegistration starts the passkey registration process, or the security key registration if secondFactor is set
+func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID, secondFactor bool) (*protocol.CredentialCreation, error) {
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -142,14 +143,28 @@ func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID
 		}
 	}
 
-	// Generate registration options using high-level API for passkeys
-	options, sessionData, err := s.webAuthn.BeginMediatedRegistration(
-		webAuthnUser,
-		protocol.MediationDefault,
-		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
-		webauthn.WithExclusions(credentialDescriptors),
-		webauthn.WithExtensions(map[string]any{"credProps": true}),
-	)
+	var options *protocol.CredentialCreation
+	var sessionData *webauthn.SessionData
+	if secondFactor {
+		// Security keys only prove possession: no resident key, and no PIN or biometrics required
+		options, sessionData, err = s.webAuthn.BeginRegistration(
+			webAuthnUser,
+			webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+				ResidentKey:      protocol.ResidentKeyRequirementDiscouraged,
+				UserVerification: protocol.VerificationDiscouraged,
+			}),
+			webauthn.WithExclusions(credentialDescriptors),
+		)
+	} else {
+		// Generate registration options using high-level API for passkeys
+		options, sessionData, err = s.webAuthn.BeginMediatedRegistration(
+			webAuthnUser,
+			protocol.MediationDefault,
+			webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+			webauthn.WithExclusions(credentialDescriptors),
+			webauthn.WithExtensions(map[string]any{"credProps": true}),
+		)
+	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin registration: %w", err)
@@ -157,7 +172,7 @@ func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID
 
 	// Store session data in cache (5-minute TTL)
 	// Cache will handle JSON marshalling internally
-	cacheKey := fmt.Sprintf("passkey:registration:%s", userID.String())
+	cacheKey := registrationCacheKey(userID, secondFactor)
 	if err := s.cache.Set(ctx, cacheKey, sessionData, 5*time.Minute); err != nil {
 		s.logger.Error("Failed to store registration session in cache", "error", err)
 		// Continue anyway - cache is optional
@@ -166,8 +181,8 @@ func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID
 	return options, nil
 }
 
-// FinishRegistration completes the passkey registration
-func (s *PasskeyService) FinishRegistration(ctx context.Context, userID uuid.UUID, r *http.Request) (*models.Passkey, error) {
+// FinishRegistration completes the passkey or security key registration
+func (s *PasskeyService) FinishRegistration(ctx context.Context, userID uuid.UUID, secondFactor bool, r *http.Request) (*models.Passkey, error) {
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -176,7 +191,7 @@ func (s *PasskeyService) FinishRegistration(ctx context.Context, userID uuid.UUI
 
 	// Get stored session data
 	// Cache will handle JSON unmarshalling internally
-	cacheKey := fmt.Sprintf("passkey:registration:%s", userID.String())
+	cacheKey := registrationCacheKey(userID, secondFactor)
 	var sessionData webauthn.SessionData
 	if err := s.cache.Get(ctx, cacheKey, &sessionData); err != nil {
 		return nil, ErrInvalidChallenge
@@ -203,8 +218,16 @@ func (s *PasskeyService) FinishRegistration(ctx context.Context, userID uuid.UUI
 	s.cache.Delete(ctx, cacheKey)
 
 	// Generate default name
-	count := len(passkeys)
+	count := 0
+	for _, pk := range passkeys {
+		if pk.SecondFactor == secondFactor {
+			count++
+		}
+	}
 	defaultName := fmt.Sprintf("Passkey #%d", count+1)
+	if secondFactor {
+		defaultName = fmt.Sprintf("Security key #%d", count+1)
+	}
 
 	// Create passkey record
 	passkey := &models.Passkey{
@@ -222,6 +245,7 @@ func (s *PasskeyService) FinishRegistration(ctx context.Context, userID uuid.UUI
 		SignCount:      int64(credential.Authenticator.SignCount),
 		BackupEligible: credential.Flags.BackupEligible,
 		BackupState:    credential.Flags.BackupState,
+		SecondFactor:   secondFactor,
 		Transports:     []string{}, // Transports not always available
 		CreatedAt:      time.Now(),
 	}
@@ -276,6 +300,11 @@ func (s *PasskeyService) FinishAuthentication(ctx context.Context, challengeID s
 			return nil, ErrPasskeyNotFound
 		}
 
+		// Security keys only verify password logins
+		if passkey.SecondFactor {
+			return nil, ErrPasskeyNotFound
+		}
+
 		// Get user
 		user, err := s.userRepo.GetByID(ctx, passkey.UserID)
 		if err != nil {
@@ -310,7 +339,101 @@ func (s *PasskeyService) FinishAuthentication(ctx context.Context, challengeID s
 		return nil, fmt.Errorf("unexpected user type")
 	}
 
-	// Update sign count, backup state, and last used time for the validated credential
+	s.markUsed(ctx, webAuthnUser, validatedCredential)
+
+	return webAuthnUser.user, nil
+}
+
+// BeginSecondFactor starts the verification of a password login with one of the user's security keys or passkeys
+func (s *PasskeyService) BeginSecondFactor(ctx context.Context, userID uuid.UUID) (*protocol.CredentialAssertion, error) {
+	webAuthnUser, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(webAuthnUser.passkeys) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	options, sessionData, err := s.webAuthn.BeginLogin(webAuthnUser, webauthn.WithUserVerification(protocol.VerificationDiscouraged))
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin second factor authentication: %w", err)
+	}
+
+	// Store session data in cache (5-minute TTL, the lifetime of the MFA temp token)
+	if err := s.cache.Set(ctx, secondFactorCacheKey(userID), sessionData, 5*time.Minute); err != nil {
+		s.logger.Error("Failed to store second factor session in cache", "error", err)
+		// Continue anyway - cache is optional
+	}
+
+	return options, nil
+}
+
+// FinishSecondFactor verifies a WebAuthn assertion for a password login
+func (s *PasskeyService) FinishSecondFactor(ctx context.Context, userID uuid.UUID, credential []byte) error {
+	cacheKey := secondFactorCacheKey(userID)
+	var sessionData webauthn.SessionData
+	if err := s.cache.Get(ctx, cacheKey, &sessionData); err != nil {
+		return ErrInvalidChallenge
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(credential)
+	if err != nil {
+		return ErrInvalidCredential
+	}
+
+	webAuthnUser, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	validatedCredential, err := s.webAuthn.ValidateLogin(webAuthnUser, sessionData, parsed)
+	if err != nil {
+		s.logger.Warn("Failed to verify second factor", "error", err, "user_id", userID)
+		return ErrInvalidCredential
+	}
+
+	// A challenge can only be answered once
+	s.cache.Delete(ctx, cacheKey)
+
+	s.markUsed(ctx, webAuthnUser, validatedCredential)
+
+	return nil
+}
+
+// HasSecondFactor reports whether the user has a security key registered
+func (s *PasskeyService) HasSecondFactor(ctx context.Context, userID uuid.UUID) (bool, error) {
+	passkeys, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, pk := range passkeys {
+		if pk.SecondFactor {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// loadUser loads a user and their credentials
+func (s *PasskeyService) loadUser(ctx context.Context, userID uuid.UUID) (*WebAuthnUser, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	passkeys, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	return &WebAuthnUser{
+		user:     user,
+		passkeys: passkeys,
+	}, nil
+}
+
+// markUsed updates sign count, backup state, and last used time for the validated credential
+func (s *PasskeyService) markUsed(ctx context.Context, webAuthnUser *WebAuthnUser, validatedCredential *webauthn.Credential) {
 	for _, passkey := range webAuthnUser.passkeys {
 		if string(passkey.CredentialID) == string(validatedCredential.ID) {
 			now := time.Now()
@@ -325,8 +448,19 @@ func (s *PasskeyService) FinishAuthentication(ctx context.Context, challengeID s
 			break
 		}
 	}
+}
+
+// registrationCacheKey returns the cache key of a registration session
+func registrationCacheKey(userID uuid.UUID, secondFactor bool) string {
+	if secondFactor {
+		return fmt.Sprintf("passkey:registration:security-key:%s", userID.String())
+	}
+	return fmt.Sprintf("passkey:registration:%s", userID.String())
+}
 
-	return webAuthnUser.user, nil
+// secondFactorCacheKey returns the cache key of a second factor verification session
+func secondFactorCacheKey(userID uuid.UUID) string {
+	return fmt.Sprintf("passkey:mfa:%s", userID.String())
 }
 
 // List retrieves all passkeys for a user
@@ -0,0 +1,210 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/push/models"
+	"github.com/whento/whento/internal/push/service"
+)
+
+// PushHandler handles HTTP requests for Web Push subscriptions
+type PushHandler struct {
+	service *service.PushService
+	logger  *slog.Logger
+}
+
+// NewPushHandler creates a new push handler
+func NewPushHandler(service *service.PushService, logger *slog.Logger) *PushHandler {
+	return &PushHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		Get the Web Push public key
+// @Description	Returns the VAPID public key to pass as applicationServerKey to PushManager.subscribe(), or enabled=false when Web Push is not configured on this server
+// @Tags			Push
+// @Produce		json
+// @Success		200	{object}	models.PublicKeyResponse	"VAPID public key"
+// @Router			/api/v1/push/public-key [get]
+func (h *PushHandler) GetPublicKey(w http.ResponseWriter, r *http.Request) {
+	httputil.JSON(w, http.StatusOK, h.service.GetPublicKey())
+}
+
+// @Summary		Subscribe a browser
+// @Description	Registers the push subscription of a browser (PushSubscription.toJSON()) for the notifications of the current user
+// @Tags			Push
+// @Accept			json
+// @Security		BearerAuth
+// @Param			request	body	models.SubscribeRequest	true	"Push subscription"
+// @Success		204		"Browser subscribed"
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid subscription"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		404		{object}	httputil.ErrorResponse	"Web Push not configured on this server"
+// @Router			/api/v1/push/subscriptions [post]
+func (h *PushHandler) SubscribeUser(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.SubscribeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if err := h.service.SubscribeUser(r.Context(), userUUID, &req); err != nil {
+		h.handleError(w, err, "Failed to subscribe to push notifications")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Unsubscribe a browser
+// @Description	Removes the push subscription of a browser from the notifications of the current user
+// @Tags			Push
+// @Accept			json
+// @Security		BearerAuth
+// @Param			request	body	models.UnsubscribeRequest	true	"Endpoint of the subscription"
+// @Success		204		"Browser unsubscribed"
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Router			/api/v1/push/subscriptions [delete]
+func (h *PushHandler) UnsubscribeUser(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UnsubscribeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if err := h.service.UnsubscribeUser(r.Context(), userUUID, req.Endpoint); err != nil {
+		h.handleError(w, err, "Failed to unsubscribe from push notifications")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Subscribe a browser for a participant
+// @Description	Registers the push subscription of a browser for the notifications of a participant, through their link
+// @Tags			Push
+// @Accept			json
+// @Param			token	path	string					true	"Calendar public token"
+// @Param			pid		path	string					true	"Participant access token"
+// @Param			request	body	models.SubscribeRequest	true	"Push subscription"
+// @Success		204		"Browser subscribed"
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid subscription"
+// @Failure		404		{object}	httputil.ErrorResponse	"Participant not found, or Web Push not configured on this server"
+// @Router			/api/v1/calendars/{token}/participants/{pid}/push [post]
+func (h *PushHandler) SubscribeParticipant(w http.ResponseWriter, r *http.Request) {
+	participantID, ok := participantID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.SubscribeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if err := h.service.SubscribeParticipant(r.Context(), participantID, &req); err != nil {
+		h.handleError(w, err, "Failed to subscribe to push notifications")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Unsubscribe a browser for a participant
+// @Description	Removes the push subscription of a browser from the notifications of a participant
+// @Tags			Push
+// @Accept			json
+// @Param			token	path	string						true	"Calendar public token"
+// @Param			pid		path	string						true	"Participant access token"
+// @Param			request	body	models.UnsubscribeRequest	true	"Endpoint of the subscription"
+// @Success		204		"Browser unsubscribed"
+// @Failure		400		{object}	httputil.ErrorResponse	"Invalid request"
+// @Failure		404		{object}	httputil.ErrorResponse	"Participant not found"
+// @Router			/api/v1/calendars/{token}/participants/{pid}/push [delete]
+func (h *PushHandler) UnsubscribeParticipant(w http.ResponseWriter, r *http.Request) {
+	participantID, ok := participantID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UnsubscribeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if err := h.service.UnsubscribeParticipant(r.Context(), participantID, req.Endpoint); err != nil {
+		h.handleError(w, err, "Failed to unsubscribe from push notifications")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *PushHandler) handleError(w http.ResponseWriter, err error, defaultMsg string) {
+	switch {
+	case errors.Is(err, service.ErrNotConfigured):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidSubscription):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+	default:
+		h.logger.Error(defaultMsg, "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, defaultMsg)
+	}
+}
+
+// userID returns the authenticated user ID, writing an error response if missing
+func (h *PushHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+// participantID returns the participant ID resolved from their link by the participant token middleware
+func participantID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pid, err := uuid.Parse(chi.URLParam(r, "pid"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid participant ID")
+		return uuid.Nil, false
+	}
+	return pid, true
+}
+
+// decode reads and validates a JSON request body, writing an error response if invalid
+func decode(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := httputil.DecodeJSON(r, req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return false
+	}
+	if err := validator.Validate(req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscription is a browser subscribed to Web Push, either for a user account or for a participant
+type Subscription struct {
+	ID            uuid.UUID
+	UserID        *uuid.UUID // Set for the subscriptions of users (owners)
+	ParticipantID *uuid.UUID // Set for the subscriptions of participants, through their link
+	Endpoint      string     // Push service URL of the browser
+	P256dh        string     // Public key of the browser, base64url
+	Auth          string     // Authentication secret of the browser, base64url
+	CreatedAt     time.Time
+}
+
+// SubscriptionKeys are the encryption keys of a browser subscription, as in PushSubscription.toJSON()
+type SubscriptionKeys struct {
+	P256dh string `json:"p256dh" validate:"required,max=128"`
+	Auth   string `json:"auth" validate:"required,max=64"`
+}
+
+// SubscribeRequest registers the push subscription of a browser
+type SubscribeRequest struct {
+	Endpoint string           `json:"endpoint" validate:"required,url,max=2048"`
+	Keys     SubscriptionKeys `json:"keys" validate:"required"`
+}
+
+// UnsubscribeRequest removes the push subscription of a browser
+type UnsubscribeRequest struct {
+	Endpoint string `json:"endpoint" validate:"required,url,max=2048"`
+}
+
+// PublicKeyResponse gives the browser the key to subscribe with
+type PublicKeyResponse struct {
+	Enabled   bool   `json:"enabled"`              // VAPID keys configured on this server
+	PublicKey string `json:"public_key,omitempty"` // applicationServerKey of PushManager.subscribe(), base64url
+}
+
+// Message is the JSON payload shown by the service worker of the SPA
+type Message struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url,omitempty"` // Page opened when the notification is clicked
+	Tag   string `json:"tag,omitempty"` // Notifications with the same tag replace each other
+}
@@ -0,0 +1,104 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/push/models"
+)
+
+// PushRepository handles the Web Push subscriptions of users and participants
+type PushRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPushRepository creates a new push repository
+func NewPushRepository(pool *pgxpool.Pool) *PushRepository {
+	return &PushRepository{pool: pool}
+}
+
+const subscriptionColumns = `id, user_id, participant_id, endpoint, p256dh, auth, created_at`
+
+// Save stores a subscription, replacing the one with the same endpoint
+// A browser re-subscribing (or switching from a participant link to an account) keeps a single subscription
+func (r *PushRepository) Save(ctx context.Context, sub *models.Subscription) error {
+	query := `
+		INSERT INTO push_subscriptions (user_id, participant_id, endpoint, p256dh, auth)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (endpoint) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			participant_id = EXCLUDED.participant_id,
+			p256dh = EXCLUDED.p256dh,
+			auth = EXCLUDED.auth
+		RETURNING id, created_at`
+
+	err := r.pool.QueryRow(ctx, query, sub.UserID, sub.ParticipantID, sub.Endpoint, sub.P256dh, sub.Auth).
+		Scan(&sub.ID, &sub.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save push subscription: %w", err)
+	}
+	return nil
+}
+
+// DeleteForUser removes a subscription of a user
+func (r *PushRepository) DeleteForUser(ctx context.Context, userID uuid.UUID, endpoint string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM push_subscriptions WHERE user_id = $1 AND endpoint = $2`, userID, endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+	return nil
+}
+
+// DeleteForParticipant removes a subscription of a participant
+func (r *PushRepository) DeleteForParticipant(ctx context.Context, participantID uuid.UUID, endpoint string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM push_subscriptions WHERE participant_id = $1 AND endpoint = $2`, participantID, endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a subscription the push service reported as expired
+func (r *PushRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM push_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns the subscriptions of a user
+func (r *PushRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error) {
+	return r.list(ctx, `SELECT `+subscriptionColumns+` FROM push_subscriptions WHERE user_id = $1`, userID)
+}
+
+// ListByParticipant returns the subscriptions of a participant
+func (r *PushRepository) ListByParticipant(ctx context.Context, participantID uuid.UUID) ([]*models.Subscription, error) {
+	return r.list(ctx, `SELECT `+subscriptionColumns+` FROM push_subscriptions WHERE participant_id = $1`, participantID)
+}
+
+func (r *PushRepository) list(ctx context.Context, query string, id uuid.UUID) ([]*models.Subscription, error) {
+	rows, err := r.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*models.Subscription
+	for rows.Next() {
+		sub := &models.Subscription{}
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ParticipantID, &sub.Endpoint, &sub.P256dh, &sub.Auth, &sub.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan push subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
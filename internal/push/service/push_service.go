@@ -0,0 +1,213 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/push/models"
+	"github.com/whento/whento/internal/push/repository"
+)
+
+// messageTTL is how long push services keep a message for an offline browser
+const messageTTL = 24 * time.Hour
+
+var (
+	ErrNotConfigured       = errors.New("web push is not configured on this server")
+	ErrInvalidSubscription = errors.New("invalid push subscription")
+	// errSubscriptionGone is returned by the push service for unsubscribed or expired browsers
+	errSubscriptionGone = errors.New("push subscription expired")
+)
+
+// PushService stores the Web Push subscriptions and sends them notifications
+type PushService struct {
+	repo       *repository.PushRepository
+	keys       *vapidKeys // Nil when Web Push is disabled
+	subject    string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewPushService creates a new push service, disabled unless a valid VAPID key pair is configured
+func NewPushService(repo *repository.PushRepository, cfg *config.Config, logger *slog.Logger) *PushService {
+	s := &PushService{
+		repo:       repo,
+		subject:    cfg.WebPush.Subject,
+		httpClient: httputil.NewOutboundClient(10*time.Second, false),
+		logger:     logger,
+	}
+
+	if cfg.WebPush.Enabled() {
+		keys, err := parseVAPIDKeys(cfg.WebPush.PublicKey, cfg.WebPush.PrivateKey)
+		if err != nil {
+			logger.Error("Web Push disabled", "error", err)
+		} else {
+			s.keys = keys
+		}
+	}
+
+	return s
+}
+
+// Enabled reports whether notifications can be pushed
+func (s *PushService) Enabled() bool {
+	return s.keys != nil
+}
+
+// GetPublicKey returns the key browsers subscribe with
+func (s *PushService) GetPublicKey() *models.PublicKeyResponse {
+	if s.keys == nil {
+		return &models.PublicKeyResponse{}
+	}
+	return &models.PublicKeyResponse{Enabled: true, PublicKey: s.keys.publicKey}
+}
+
+// SubscribeUser registers a browser for the notifications of a user
+func (s *PushService) SubscribeUser(ctx context.Context, userID uuid.UUID, req *models.SubscribeRequest) error {
+	return s.subscribe(ctx, &models.Subscription{UserID: &userID}, req)
+}
+
+// SubscribeParticipant registers a browser for the notifications of a participant
+func (s *PushService) SubscribeParticipant(ctx context.Context, participantID uuid.UUID, req *models.SubscribeRequest) error {
+	return s.subscribe(ctx, &models.Subscription{ParticipantID: &participantID}, req)
+}
+
+func (s *PushService) subscribe(ctx context.Context, sub *models.Subscription, req *models.SubscribeRequest) error {
+	if s.keys == nil {
+		return ErrNotConfigured
+	}
+
+	// Push services are public HTTPS endpoints; the keys must encrypt messages the browser can read
+	if u, err := url.Parse(req.Endpoint); err != nil || u.Scheme != "https" {
+		return fmt.Errorf("%w: the endpoint must be an https URL", ErrInvalidSubscription)
+	}
+	if _, err := encrypt(nil, req.Keys.P256dh, req.Keys.Auth, rand.Reader); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSubscription, err)
+	}
+
+	sub.Endpoint = req.Endpoint
+	sub.P256dh = req.Keys.P256dh
+	sub.Auth = req.Keys.Auth
+	return s.repo.Save(ctx, sub)
+}
+
+// UnsubscribeUser removes a browser from the notifications of a user
+func (s *PushService) UnsubscribeUser(ctx context.Context, userID uuid.UUID, endpoint string) error {
+	return s.repo.DeleteForUser(ctx, userID, endpoint)
+}
+
+// UnsubscribeParticipant removes a browser from the notifications of a participant
+func (s *PushService) UnsubscribeParticipant(ctx context.Context, participantID uuid.UUID, endpoint string) error {
+	return s.repo.DeleteForParticipant(ctx, participantID, endpoint)
+}
+
+// NotifyUser pushes a message to the browsers of a user and returns how many received it
+func (s *PushService) NotifyUser(ctx context.Context, userID uuid.UUID, msg models.Message) (int, error) {
+	if s.keys == nil {
+		return 0, ErrNotConfigured
+	}
+	subs, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return s.notify(ctx, subs, msg)
+}
+
+// NotifyParticipant pushes a message to the browsers of a participant and returns how many received it
+func (s *PushService) NotifyParticipant(ctx context.Context, participantID uuid.UUID, msg models.Message) (int, error) {
+	if s.keys == nil {
+		return 0, ErrNotConfigured
+	}
+	subs, err := s.repo.ListByParticipant(ctx, participantID)
+	if err != nil {
+		return 0, err
+	}
+	return s.notify(ctx, subs, msg)
+}
+
+// notify sends a message to each subscription, forgetting those the push service reports as expired
+// An error is returned only when no browser received the message
+func (s *PushService) notify(ctx context.Context, subs []*models.Subscription, msg models.Message) (int, error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var lastErr error
+	for _, sub := range subs {
+		err := s.send(ctx, sub, payload)
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, errSubscriptionGone):
+			s.logger.Info("Removing expired push subscription", "subscription_id", sub.ID)
+			if err := s.repo.Delete(ctx, sub.ID); err != nil {
+				s.logger.Error("Failed to remove expired push subscription", "subscription_id", sub.ID, "error", err)
+			}
+		default:
+			s.logger.Warn("Failed to send push notification", "subscription_id", sub.ID, "error", err)
+			lastErr = err
+		}
+	}
+
+	if sent == 0 && lastErr != nil {
+		return 0, lastErr
+	}
+	return sent, nil
+}
+
+// send encrypts a payload for a subscription and posts it to its push service
+func (s *PushService) send(ctx context.Context, sub *models.Subscription, payload []byte) error {
+	body, err := encrypt(payload, sub.P256dh, sub.Auth, rand.Reader)
+	if err != nil {
+		return err
+	}
+	authorization, err := s.keys.authorization(sub.Endpoint, s.subject, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create push request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(messageTTL.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach push service: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return errSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("push service returned status %d", resp.StatusCode)
+	}
+	return nil
+}
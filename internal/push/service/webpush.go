@@ -0,0 +1,203 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+const (
+	// recordSize is the record size announced in the aes128gcm header; messages fit in a single record
+	recordSize = 4096
+	// maxPayloadSize leaves room for the header, the delimiter and the tag within what push services accept
+	maxPayloadSize = 3000
+	// vapidTokenLifetime is the validity of the VAPID JWT, push services refuse more than 24 hours
+	vapidTokenLifetime = 12 * time.Hour
+)
+
+var errPayloadTooLarge = errors.New("push payload too large")
+
+// GenerateVAPIDKeys generates a VAPID key pair, both keys base64url-encoded without padding
+// The public key is the uncompressed P-256 point the browser subscribes with, the private key the raw scalar
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate VAPID key: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()), base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// vapidKeys is the parsed VAPID key pair of the server
+type vapidKeys struct {
+	private   *ecdsa.PrivateKey
+	publicKey string // base64url, sent in the k= parameter of the Authorization header
+}
+
+// parseVAPIDKeys parses the base64url key pair of the configuration and checks that both keys match
+func parseVAPIDKeys(publicKey, privateKey string) (*vapidKeys, error) {
+	rawPrivate, err := decodeBase64URL(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	private, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), rawPrivate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	rawPublic, err := decodeBase64URL(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID public key: %w", err)
+	}
+	public, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), rawPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID public key: %w", err)
+	}
+	if !private.PublicKey.Equal(public) {
+		return nil, errors.New("the VAPID public key does not match the private key")
+	}
+
+	return &vapidKeys{private: private, publicKey: base64.RawURLEncoding.EncodeToString(rawPublic)}, nil
+}
+
+// authorization returns the VAPID Authorization header for a push endpoint (RFC 8292)
+func (k *vapidKeys) authorization(endpoint, subject string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid push endpoint: %w", err)
+	}
+
+	claims := map[string]any{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenLifetime).Unix(),
+	}
+	if subject != "" {
+		claims["sub"] = subject
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	// JWS ES256 signatures are the 32-byte big-endian r and s, concatenated
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return fmt.Sprintf("vapid t=%s.%s, k=%s", signingInput, base64.RawURLEncoding.EncodeToString(signature), k.publicKey), nil
+}
+
+// encrypt encrypts a push message for a subscription with the aes128gcm content encoding (RFC 8291)
+// p256dh and auth are the base64url keys of the subscription
+func encrypt(payload []byte, p256dh, auth string, random io.Reader) ([]byte, error) {
+	if len(payload) > maxPayloadSize {
+		return nil, errPayloadTooLarge
+	}
+
+	rawUA, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(rawUA)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, errors.New("invalid subscription auth secret")
+	}
+
+	// Ephemeral key of the application server, sent in the header
+	asPrivate, err := ecdh.P256().GenerateKey(random)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(random, salt); err != nil {
+		return nil, err
+	}
+
+	return encryptWithKey(payload, uaPublic, authSecret, asPrivate, salt)
+}
+
+// encryptWithKey encrypts a push message with a given server key and salt
+func encryptWithKey(payload []byte, uaPublic *ecdh.PublicKey, authSecret []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public)
+	keyInfo := "WebPush: info\x00" + string(uaPublic.Bytes()) + string(asPublic)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Single record: the payload followed by the last record delimiter
+	plaintext := append(append(make([]byte, 0, len(payload)+1), payload...), 0x02)
+
+	// Header: salt (16) || record size (4) || key ID length (1) || key ID (the server public key)
+	body := make([]byte, 0, 21+len(asPublic)+len(plaintext)+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublic)))
+	body = append(body, asPublic...)
+	return gcm.Seal(body, nonce, plaintext, nil), nil
+}
+
+// decodeBase64URL decodes base64url with or without padding, depending on the tool that encoded the key
+func decodeBase64URL(s string) ([]byte, error) {
+	if data, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return data, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
@@ -0,0 +1,253 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/push/models"
+)
+
+// browser is the receiving side of a subscription, decrypting messages as a user agent does (RFC 8291)
+type browser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newBrowser(t *testing.T) *browser {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return &browser{key: key, auth: auth}
+}
+
+func (b *browser) subscription(endpoint string) *models.Subscription {
+	return &models.Subscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(b.auth),
+	}
+}
+
+func (b *browser) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	if len(body) < 21 {
+		t.Fatalf("body too short: %d bytes", len(body))
+	}
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != recordSize {
+		t.Errorf("record size = %d", rs)
+	}
+	idLen := int(body[20])
+	asPublic, err := ecdh.P256().NewPublicKey(body[21 : 21+idLen])
+	if err != nil {
+		t.Fatalf("invalid server key: %v", err)
+	}
+
+	secret, _ := b.key.ECDH(asPublic)
+	keyInfo := "WebPush: info\x00" + string(b.key.PublicKey().Bytes()) + string(asPublic.Bytes())
+	ikm, _ := hkdf.Key(sha256.New, secret, b.auth, keyInfo, 32)
+	prk, _ := hkdf.Extract(sha256.New, ikm, salt)
+	cek, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if len(plaintext) == 0 || plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatal("missing last record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestEncrypt_RoundTrip(t *testing.T) {
+	b := newBrowser(t)
+	sub := b.subscription("https://push.example.com/abc")
+	payload := []byte(`{"title":"Rehearsal","body":"Threshold reached"}`)
+
+	body, err := encrypt(payload, sub.P256dh, sub.Auth, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.decrypt(t, body); !bytes.Equal(got, payload) {
+		t.Errorf("decrypted %q, want %q", got, payload)
+	}
+}
+
+func TestEncrypt_RFC8291Example(t *testing.T) {
+	// Example of RFC 8291 section 5
+	decode := func(s string) []byte {
+		data, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	asPrivate, err := ecdh.P256().NewPrivateKey(decode("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(decode("BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := encryptWithKey([]byte("When I grow up, I want to be a watermelon"), uaPublic, decode("BTBZMqHH6r4Tts7J_aSIgg"), asPrivate, decode("DGv6ra1nlYgDCS1FRnbzlw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	if got := base64.RawURLEncoding.EncodeToString(body); got != want {
+		t.Errorf("encrypted message = %s\nwant %s", got, want)
+	}
+}
+
+func TestEncrypt_Invalid(t *testing.T) {
+	b := newBrowser(t)
+	sub := b.subscription("https://push.example.com/abc")
+
+	if _, err := encrypt([]byte("x"), "not-a-key", sub.Auth, rand.Reader); err == nil {
+		t.Error("expected an error for an invalid p256dh key")
+	}
+	if _, err := encrypt([]byte("x"), sub.P256dh, "c2hvcnQ", rand.Reader); err == nil {
+		t.Error("expected an error for a short auth secret")
+	}
+	if _, err := encrypt(make([]byte, maxPayloadSize+1), sub.P256dh, sub.Auth, rand.Reader); !errors.Is(err, errPayloadTooLarge) {
+		t.Errorf("large payload error = %v, want errPayloadTooLarge", err)
+	}
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := parseVAPIDKeys(publicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	header, err := keys.authorization("https://fcm.googleapis.com/fcm/send/abc", "mailto:admin@example.com", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var token, k string
+	for _, part := range strings.Split(strings.TrimPrefix(header, "vapid "), ", ") {
+		switch {
+		case strings.HasPrefix(part, "t="):
+			token = strings.TrimPrefix(part, "t=")
+		case strings.HasPrefix(part, "k="):
+			k = strings.TrimPrefix(part, "k=")
+		}
+	}
+	if k != publicKey {
+		t.Errorf("k = %q, want the public key", k)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token %q is not a JWT", token)
+	}
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	raw, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Aud != "https://fcm.googleapis.com" || claims.Sub != "mailto:admin@example.com" || claims.Exp != now.Add(vapidTokenLifetime).Unix() {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	rawPublic, _ := base64.RawURLEncoding.DecodeString(publicKey)
+	public, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), rawPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if len(signature) != 64 || !ecdsa.Verify(public, digest[:], r, s) {
+		t.Error("invalid ES256 signature")
+	}
+}
+
+func TestParseVAPIDKeys_Mismatch(t *testing.T) {
+	publicKey, _, _ := GenerateVAPIDKeys()
+	_, privateKey, _ := GenerateVAPIDKeys()
+	if _, err := parseVAPIDKeys(publicKey, privateKey); err == nil {
+		t.Error("expected an error for keys of different pairs")
+	}
+}
+
+func TestSend(t *testing.T) {
+	publicKey, privateKey, _ := GenerateVAPIDKeys()
+	keys, _ := parseVAPIDKeys(publicKey, privateKey)
+	b := newBrowser(t)
+
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		if r.Header.Get("Content-Encoding") != "aes128gcm" || r.Header.Get("TTL") == "" ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "vapid t=") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	s := &PushService{keys: keys, httpClient: server.Client(), logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	payload := []byte(`{"title":"Rehearsal"}`)
+
+	if err := s.send(context.Background(), b.subscription(server.URL+"/ok"), payload); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if got := b.decrypt(t, received); !bytes.Equal(got, payload) {
+		t.Errorf("browser received %q, want %q", got, payload)
+	}
+
+	if err := s.send(context.Background(), b.subscription(server.URL+"/gone"), payload); !errors.Is(err, errSubscriptionGone) {
+		t.Errorf("send to an expired subscription = %v, want errSubscriptionGone", err)
+	}
+}
@@ -0,0 +1,75 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package quota
+
+import (
+	"github.com/whento/pkg/models"
+)
+
+// Capability is a feature gated by the subscription plan (cloud) or license tier (self-hosted)
+type Capability string
+
+const (
+	CapabilityWebhooks       Capability = "webhooks"        // REST hook subscriptions
+	CapabilityCalDAV         Capability = "caldav"          // CalDAV busy time sync
+	CapabilityCustomBranding Capability = "custom_branding" // White-label branding of the instance (self-hosted)
+	CapabilityAPIKeys        Capability = "api_keys"        // App passwords for integrations
+	CapabilitySAML           Capability = "saml"            // SAML single sign-on (self-hosted)
+)
+
+// AllCapabilities lists the gated features
+var AllCapabilities = []Capability{
+	CapabilityWebhooks,
+	CapabilityCalDAV,
+	CapabilityCustomBranding,
+	CapabilityAPIKeys,
+	CapabilitySAML,
+}
+
+// Capabilities maps each gated feature to whether it is available
+type Capabilities map[Capability]bool
+
+// planCapabilities lists the features of each cloud plan
+// Branding is set by the operator of the cloud instance, not by its users
+var planCapabilities = map[models.SubscriptionPlan][]Capability{
+	models.PlanFree:  {},
+	models.PlanPro:   {CapabilityWebhooks, CapabilityCalDAV, CapabilityAPIKeys},
+	models.PlanPower: {CapabilityWebhooks, CapabilityCalDAV, CapabilityAPIKeys},
+}
+
+// tierCapabilities lists the features of each self-hosted license tier
+var tierCapabilities = map[models.LicenseTier][]Capability{
+	models.TierCommunity:  {CapabilityWebhooks, CapabilityCalDAV, CapabilityAPIKeys},
+	models.TierPro:        {CapabilityWebhooks, CapabilityCalDAV, CapabilityAPIKeys, CapabilityCustomBranding},
+	models.TierEnterprise: {CapabilityWebhooks, CapabilityCalDAV, CapabilityAPIKeys, CapabilityCustomBranding, CapabilitySAML},
+}
+
+// PlanCapabilities returns the features of a cloud plan, those of the free plan if unknown
+func PlanCapabilities(plan models.SubscriptionPlan) Capabilities {
+	if _, ok := planCapabilities[plan]; !ok {
+		plan = models.PlanFree
+	}
+	return newCapabilities(planCapabilities[plan])
+}
+
+// TierCapabilities returns the features of a license tier, those of the community tier if unknown
+func TierCapabilities(tier models.LicenseTier) Capabilities {
+	if _, ok := tierCapabilities[tier]; !ok {
+		tier = models.TierCommunity
+	}
+	return newCapabilities(tierCapabilities[tier])
+}
+
+// newCapabilities lists every gated feature, enabling the given ones
+func newCapabilities(enabled []Capability) Capabilities {
+	capabilities := make(Capabilities, len(AllCapabilities))
+	for _, capability := range AllCapabilities {
+		capabilities[capability] = false
+	}
+	for _, capability := range enabled {
+		capabilities[capability] = true
+	}
+	return capabilities
+}
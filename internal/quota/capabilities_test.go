@@ -0,0 +1,57 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/whento/pkg/models"
+)
+
+func TestPlanCapabilities(t *testing.T) {
+	free := PlanCapabilities(models.PlanFree)
+	if len(free) != len(AllCapabilities) {
+		t.Fatalf("expected every capability to be listed, got %v", free)
+	}
+	for capability, enabled := range free {
+		if enabled {
+			t.Errorf("free plan should not include %s", capability)
+		}
+	}
+
+	pro := PlanCapabilities(models.PlanPro)
+	if !pro[CapabilityWebhooks] || !pro[CapabilityCalDAV] || !pro[CapabilityAPIKeys] {
+		t.Errorf("pro plan should include webhooks, caldav and api keys, got %v", pro)
+	}
+
+	if unknown := PlanCapabilities("gold"); unknown[CapabilityWebhooks] {
+		t.Error("unknown plans should fall back to the free plan")
+	}
+}
+
+func TestTierCapabilities(t *testing.T) {
+	community := TierCapabilities(models.TierCommunity)
+	if community[CapabilityCustomBranding] {
+		t.Error("community tier should not include custom branding")
+	}
+	if !community[CapabilityWebhooks] {
+		t.Error("community tier should include webhooks")
+	}
+
+	if !TierCapabilities(models.TierPro)[CapabilityCustomBranding] {
+		t.Error("pro tier should include custom branding")
+	}
+
+	if TierCapabilities(models.TierPro)[CapabilitySAML] || !TierCapabilities(models.TierEnterprise)[CapabilitySAML] {
+		t.Error("SAML should only be included in the enterprise tier")
+	}
+	if PlanCapabilities(models.PlanPower)[CapabilitySAML] {
+		t.Error("SAML should not be included in cloud plans")
+	}
+
+	if TierCapabilities("platinum")[CapabilityCustomBranding] {
+		t.Error("unknown tiers should fall back to the community tier")
+	}
+}
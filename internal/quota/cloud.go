@@ -9,16 +9,22 @@ package quota
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/whento/whento/internal/subscription/models"
 	"github.com/whento/whento/internal/subscription/service"
 )
 
+// rateLimitFactorTTL is the window of the API rate limits: a factor is looked up at most once per window
+const rateLimitFactorTTL = time.Minute
+
 // CloudQuotaService implements QuotaService for the cloud version
 type CloudQuotaService struct {
 	subscriptionService *service.Service
 	calendarRepo        CalendarCounter
+	rateLimitFactors    *factorCache
 }
 
 // CalendarCounter is an interface for counting calendars
@@ -29,10 +35,14 @@ type CalendarCounter interface {
 
 // NewCloudService creates a new cloud quota service
 func NewCloudService(subscriptionService *service.Service, calendarRepo CalendarCounter) *CloudQuotaService {
-	return &CloudQuotaService{
+	s := &CloudQuotaService{
 		subscriptionService: subscriptionService,
 		calendarRepo:        calendarRepo,
+		rateLimitFactors:    newFactorCache(rateLimitFactorTTL),
 	}
+	// Upgrades and downgrades apply to the rate limits right away
+	subscriptionService.OnChange(s.rateLimitFactors.invalidate)
+	return s
 }
 
 // CanCreateCalendar checks if a user can create a new calendar based on their subscription
@@ -112,6 +122,54 @@ func (s *CloudQuotaService) IsOverQuota(ctx context.Context, userID uuid.UUID) (
 	return current > limit, nil
 }
 
+// GetCapabilities returns the features of the user's plan
+// Subscriptions that are not active fall back to the free plan, as for calendar limits
+func (s *CloudQuotaService) GetCapabilities(ctx context.Context, userID uuid.UUID) (Capabilities, error) {
+	sub, err := s.subscriptionService.GetUserSubscription(ctx, userID)
+	if err != nil {
+		return PlanCapabilities(models.PlanFree), fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	plan := sub.Plan
+	if sub.Status != models.StatusActive && sub.Status != models.StatusTrialing {
+		plan = models.PlanFree
+	}
+
+	return PlanCapabilities(plan), nil
+}
+
+// HasCapability checks if the user's plan includes a feature
+func (s *CloudQuotaService) HasCapability(ctx context.Context, userID uuid.UUID, capability Capability) (bool, error) {
+	capabilities, err := s.GetCapabilities(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return capabilities[capability], nil
+}
+
+// GetRateLimitFactor returns the rate limit factor of the user's plan
+// Subscriptions that are not active fall back to the free plan, as for capabilities.
+// Factors are cached for the rate limit window, and dropped when the subscription changes
+func (s *CloudQuotaService) GetRateLimitFactor(ctx context.Context, userID uuid.UUID) (int, error) {
+	if factor, ok := s.rateLimitFactors.get(userID); ok {
+		return factor, nil
+	}
+
+	sub, err := s.subscriptionService.GetUserSubscription(ctx, userID)
+	if err != nil {
+		return PlanRateLimitFactor(models.PlanFree), fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	plan := sub.Plan
+	if sub.Status != models.StatusActive && sub.Status != models.StatusTrialing {
+		plan = models.PlanFree
+	}
+
+	factor := PlanRateLimitFactor(plan)
+	s.rateLimitFactors.set(userID, factor)
+	return factor, nil
+}
+
 // GetLimitInfo returns detailed information about limits and usage
 func (s *CloudQuotaService) GetLimitInfo(ctx context.Context, userID uuid.UUID) (*LimitInfo, error) {
 	userLimit, err := s.GetUserLimit(ctx, userID)
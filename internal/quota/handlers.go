@@ -101,3 +101,30 @@ func (h *Handler) HandleGetLimits(w http.ResponseWriter, r *http.Request) {
 
 	httputil.JSON(w, http.StatusOK, response)
 }
+
+// RequireCapability restricts routes to users whose plan or license includes a feature
+// Must be used after the authentication middleware
+func RequireCapability(service QuotaService, capability Capability, log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+			if err != nil {
+				httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "User not authenticated")
+				return
+			}
+
+			allowed, err := service.HasCapability(r.Context(), userID, capability)
+			if err != nil {
+				log.Error("Failed to check capability", "error", err, "user_id", userID, "capability", capability)
+				httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to check plan features")
+				return
+			}
+			if !allowed {
+				httputil.Error(w, http.StatusForbidden, "feature_unavailable", "This feature is not included in your plan. Please upgrade to use it.")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
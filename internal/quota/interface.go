@@ -10,7 +10,7 @@ import (
 	"github.com/google/uuid"
 )
 
-// QuotaService defines the interface for calendar quota management
+// QuotaService defines the interface for calendar quota management and feature gating
 // Implementations differ based on build type (cloud vs selfhosted)
 type QuotaService interface {
 	// CanCreateCalendar checks if a user can create a new calendar
@@ -34,6 +34,17 @@ type QuotaService interface {
 	// This happens when subscription/license expires but user still has more calendars than allowed
 	// When over quota, users should be blocked from creating calendars and accessing ICS feeds
 	IsOverQuota(ctx context.Context, userID uuid.UUID) (bool, error)
+
+	// GetCapabilities returns the features available to a user
+	// with their plan (cloud) or the server license (self-hosted)
+	GetCapabilities(ctx context.Context, userID uuid.UUID) (Capabilities, error)
+
+	// HasCapability checks if a feature is available to a user
+	HasCapability(ctx context.Context, userID uuid.UUID, capability Capability) (bool, error)
+
+	// GetRateLimitFactor returns the multiplier of the API rate limits of a user
+	// with their plan (cloud) or the server license (self-hosted), 1 for free users
+	GetRateLimitFactor(ctx context.Context, userID uuid.UUID) (int, error)
 }
 
 // LimitInfo contains detailed information about limits and usage
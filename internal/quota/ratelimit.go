@@ -0,0 +1,127 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package quota
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/models"
+)
+
+// planRateLimitFactors multiply the API rate limits of the users of each cloud plan
+var planRateLimitFactors = map[models.SubscriptionPlan]int{
+	models.PlanFree:  1,
+	models.PlanPro:   3,
+	models.PlanPower: 10,
+}
+
+// tierRateLimitFactors multiply the API rate limits of the users of each self-hosted license tier
+var tierRateLimitFactors = map[models.LicenseTier]int{
+	models.TierCommunity:  1,
+	models.TierPro:        3,
+	models.TierEnterprise: 10,
+}
+
+// PlanRateLimitFactor returns the rate limit factor of a cloud plan, that of the free plan if unknown
+func PlanRateLimitFactor(plan models.SubscriptionPlan) int {
+	if factor, ok := planRateLimitFactors[plan]; ok {
+		return factor
+	}
+	return planRateLimitFactors[models.PlanFree]
+}
+
+// TierRateLimitFactor returns the rate limit factor of a license tier, that of the community tier if unknown
+func TierRateLimitFactor(tier models.LicenseTier) int {
+	if factor, ok := tierRateLimitFactors[tier]; ok {
+		return factor
+	}
+	return tierRateLimitFactors[models.TierCommunity]
+}
+
+// RateLimit returns a rate limit resolver (middleware.RateLimitConfig.LimitFunc) scaling the base limit
+// of free users with the plan or license of the current user
+// The base limit is read on each request, so that it follows configuration reloads
+// Must be used after the authentication middleware
+func RateLimit(service QuotaService, base func() int, log *slog.Logger) func(r *http.Request) int {
+	return func(r *http.Request) int {
+		requests := base()
+		userID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+		if err != nil {
+			return requests
+		}
+
+		factor, err := service.GetRateLimitFactor(r.Context(), userID)
+		if err != nil {
+			// Fall back to the free limit rather than rejecting the request
+			log.Warn("Failed to get rate limit factor", "error", err, "user_id", userID)
+			return requests
+		}
+		return requests * factor
+	}
+}
+
+// factorCache keeps the rate limit factors of users for a while, so that rate limited requests
+// don't look up the subscription of their user each time
+type factorCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[uuid.UUID]cachedFactor
+	nextSweep time.Time
+}
+
+type cachedFactor struct {
+	factor  int
+	expires time.Time
+}
+
+func newFactorCache(ttl time.Duration) *factorCache {
+	return &factorCache{ttl: ttl, now: time.Now, entries: make(map[uuid.UUID]cachedFactor)}
+}
+
+// get returns the cached factor of a user, if not expired
+func (c *factorCache) get(userID uuid.UUID) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[userID]
+	if !ok || !c.now().Before(entry.expires) {
+		return 0, false
+	}
+	return entry.factor, true
+}
+
+// set caches the factor of a user
+// Expired entries are swept at most once per ttl, which bounds the cache to the recently active users
+func (c *factorCache) set(userID uuid.UUID, factor int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !now.Before(c.nextSweep) {
+		for id, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, id)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+	c.entries[userID] = cachedFactor{factor: factor, expires: now.Add(c.ttl)}
+}
+
+// invalidate drops the cached factor of a user
+func (c *factorCache) invalidate(userID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, userID)
+}
@@ -0,0 +1,130 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/models"
+)
+
+func TestRateLimitFactors(t *testing.T) {
+	if PlanRateLimitFactor(models.PlanFree) != 1 || TierRateLimitFactor(models.TierCommunity) != 1 {
+		t.Error("free plan and community tier should keep the base limits")
+	}
+	if PlanRateLimitFactor(models.PlanPro) <= 1 || PlanRateLimitFactor(models.PlanPower) <= PlanRateLimitFactor(models.PlanPro) {
+		t.Error("power plan should have higher limits than the pro plan, itself higher than the free plan")
+	}
+	if TierRateLimitFactor(models.TierPro) <= 1 || TierRateLimitFactor(models.TierEnterprise) <= TierRateLimitFactor(models.TierPro) {
+		t.Error("enterprise tier should have higher limits than the pro tier, itself higher than the community tier")
+	}
+	if PlanRateLimitFactor("gold") != 1 || TierRateLimitFactor("platinum") != 1 {
+		t.Error("unknown plans and tiers should fall back to the base limits")
+	}
+}
+
+// factorQuotaService only implements the rate limit factor of QuotaService
+type factorQuotaService struct {
+	QuotaService
+	factor int
+	err    error
+}
+
+func (s *factorQuotaService) GetRateLimitFactor(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.factor, s.err
+}
+
+func TestRateLimit(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name    string
+		userID  string
+		service *factorQuotaService
+		want    int
+	}{
+		{"paid user", uuid.NewString(), &factorQuotaService{factor: 3}, 300},
+		{"free user", uuid.NewString(), &factorQuotaService{factor: 1}, 100},
+		{"lookup failure", uuid.NewString(), &factorQuotaService{err: errors.New("database down")}, 100},
+		{"anonymous", "", &factorQuotaService{factor: 3}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/calendars", nil)
+			r = r.WithContext(context.WithValue(r.Context(), middleware.UserIDKey, tt.userID))
+
+			if got := RateLimit(tt.service, func() int { return 100 }, log)(r); got != tt.want {
+				t.Errorf("limit = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFactorCache(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	userID, otherID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		drop    bool
+		wantOK  bool
+	}{
+		{"fresh", 30 * time.Second, false, true},
+		{"expired", time.Minute, false, false},
+		{"invalidated", 0, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newFactorCache(time.Minute)
+			cache.now = func() time.Time { return now }
+			cache.set(userID, 3)
+			cache.set(otherID, 10)
+
+			cache.now = func() time.Time { return now.Add(tt.elapsed) }
+			if tt.drop {
+				cache.invalidate(userID)
+			}
+
+			factor, ok := cache.get(userID)
+			if ok != tt.wantOK || (ok && factor != 3) {
+				t.Errorf("get = %d, %v, want 3, %v", factor, ok, tt.wantOK)
+			}
+			if factor, ok := cache.get(otherID); tt.drop && (!ok || factor != 10) {
+				t.Errorf("other user = %d, %v, want 10, true", factor, ok)
+			}
+		})
+	}
+}
+
+func TestFactorCache_Sweep(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cache := newFactorCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	cache.set(uuid.New(), 3)
+
+	// The next set after the window drops the expired entries
+	cache.now = func() time.Time { return now.Add(2 * time.Minute) }
+	userID := uuid.New()
+	cache.set(userID, 10)
+
+	if len(cache.entries) != 1 {
+		t.Errorf("entries = %d, want 1", len(cache.entries))
+	}
+	if factor, ok := cache.get(userID); !ok || factor != 10 {
+		t.Errorf("get = %d, %v, want 10, true", factor, ok)
+	}
+}
@@ -112,6 +112,26 @@ func (s *SelfHostedQuotaService) IsOverQuota(ctx context.Context, userID uuid.UU
 	return serverUsage > serverLimit, nil
 }
 
+// GetCapabilities returns the features of the server license (the same for all users)
+func (s *SelfHostedQuotaService) GetCapabilities(ctx context.Context, userID uuid.UUID) (Capabilities, error) {
+	// License is loaded in RAM, no need for context
+	return TierCapabilities(s.licensingService.GetActiveLicense().GetTier()), nil
+}
+
+// HasCapability checks if the server license includes a feature
+func (s *SelfHostedQuotaService) HasCapability(ctx context.Context, userID uuid.UUID, capability Capability) (bool, error) {
+	capabilities, err := s.GetCapabilities(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return capabilities[capability], nil
+}
+
+// GetRateLimitFactor returns the rate limit factor of the server license (the same for all users)
+func (s *SelfHostedQuotaService) GetRateLimitFactor(ctx context.Context, userID uuid.UUID) (int, error) {
+	return TierRateLimitFactor(s.licensingService.GetActiveLicense().GetTier()), nil
+}
+
 // GetLimitInfo returns detailed information about limits and usage
 func (s *SelfHostedQuotaService) GetLimitInfo(ctx context.Context, userID uuid.UUID) (*LimitInfo, error) {
 	serverLimit, err := s.GetServerLimit(ctx)
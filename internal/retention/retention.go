@@ -0,0 +1,217 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+// Package retention purges data older than the configured retention periods (the janitor job)
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/config"
+)
+
+// Categories group the tables sharing a retention setting
+const (
+	CategoryAvailability = "availability"  // Availability history
+	CategoryLogs         = "logs"          // Notification and event logs
+	CategoryAudit        = "audit"         // Audit logs
+	CategoryTokens       = "tokens"        // Expired tokens
+	CategoryDeletedUsers = "deleted_users" // Users deleted by an admin
+)
+
+// rule selects the rows of a table older than a cutoff
+type rule struct {
+	Table     string
+	Category  string
+	Condition string // SQL condition on the cutoff ($1)
+}
+
+// rules lists the purged tables
+// The notification log is also used to deduplicate notifications sent within an hour,
+// so a retention of at least one day doesn't change which notifications are sent
+var rules = []rule{
+	{Table: "availabilities", Category: CategoryAvailability, Condition: "date < $1::date"},
+	{Table: "notification_log", Category: CategoryLogs, Condition: "sent_at < $1"},
+	{Table: "hook_events", Category: CategoryLogs, Condition: "created_at < $1"},
+	{Table: "webhook_deliveries", Category: CategoryLogs, Condition: "created_at < $1 AND status <> 'pending'"},
+	{Table: "notification_outbox", Category: CategoryLogs, Condition: "created_at < $1 AND status <> 'pending'"},
+	{Table: "mail_queue", Category: CategoryLogs, Condition: "created_at < $1 AND status <> 'pending'"},
+	{Table: "calendar_changes", Category: CategoryAudit, Condition: "created_at < $1"},
+	{Table: "refresh_tokens", Category: CategoryTokens, Condition: "expires_at < $1"},
+	{Table: "login_flows", Category: CategoryTokens, Condition: "expires_at < $1"},
+	{Table: "data_exports", Category: CategoryTokens, Condition: "expires_at < $1"},
+	{Table: "users", Category: CategoryDeletedUsers, Condition: "deleted_at < $1"},
+}
+
+// Tables returns the names of the tables purged by the janitor
+func Tables() []string {
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.Table)
+	}
+	return names
+}
+
+// TableReport is the outcome of the retention policy of a table
+type TableReport struct {
+	Table         string     `json:"table"`
+	Category      string     `json:"category"`
+	RetentionDays int        `json:"retention_days"` // 0 = kept forever
+	Cutoff        *time.Time `json:"cutoff,omitempty"`
+	Rows          int64      `json:"rows"` // Rows older than the cutoff: purged, or that would be in a dry run
+}
+
+// Janitor periodically purges data older than its retention period
+type Janitor struct {
+	pool         *pgxpool.Pool
+	categoryDays map[string]int
+	overrides    map[string]int
+	interval     time.Duration
+	dryRun       bool
+	logger       *slog.Logger
+}
+
+// NewJanitor creates a janitor applying the retention configuration
+func NewJanitor(pool *pgxpool.Pool, cfg *config.Config, logger *slog.Logger) *Janitor {
+	j := &Janitor{
+		pool: pool,
+		categoryDays: map[string]int{
+			CategoryAvailability: cfg.Retention.AvailabilityDays,
+			CategoryLogs:         cfg.Retention.LogDays,
+			CategoryAudit:        cfg.Retention.AuditDays,
+			CategoryTokens:       cfg.Retention.TokenDays,
+			CategoryDeletedUsers: cfg.Retention.DeletedUserDays,
+		},
+		overrides: cfg.Retention.Overrides,
+		interval:  cfg.Retention.Interval,
+		dryRun:    cfg.Retention.DryRun,
+		logger:    logger,
+	}
+
+	for _, table := range UnknownOverrides(cfg.Retention.Overrides) {
+		logger.Warn("Ignoring retention override of a table without retention policy", "table", table)
+	}
+	return j
+}
+
+// UnknownOverrides returns the overridden tables that aren't purged by the janitor
+func UnknownOverrides(overrides map[string]int) []string {
+	var unknown []string
+	for table := range overrides {
+		if !isPurged(table) {
+			unknown = append(unknown, table)
+		}
+	}
+	return unknown
+}
+
+func isPurged(table string) bool {
+	for _, r := range rules {
+		if r.Table == table {
+			return true
+		}
+	}
+	return false
+}
+
+// retentionDays returns the retention period of a table, 0 if kept forever
+func (j *Janitor) retentionDays(r rule) int {
+	days, ok := j.overrides[r.Table]
+	if !ok {
+		days = j.categoryDays[r.Category]
+	}
+	return max(days, 0)
+}
+
+// Report returns what would be purged now, without deleting anything
+func (j *Janitor) Report(ctx context.Context, now time.Time) ([]TableReport, error) {
+	return j.apply(ctx, now, false)
+}
+
+// Purge deletes the data older than its retention period
+func (j *Janitor) Purge(ctx context.Context, now time.Time) ([]TableReport, error) {
+	return j.apply(ctx, now, true)
+}
+
+// apply counts, or deletes, the rows of each table older than its cutoff
+func (j *Janitor) apply(ctx context.Context, now time.Time, purge bool) ([]TableReport, error) {
+	reports := make([]TableReport, 0, len(rules))
+	for _, r := range rules {
+		report := TableReport{Table: r.Table, Category: r.Category, RetentionDays: j.retentionDays(r)}
+		if report.RetentionDays == 0 {
+			reports = append(reports, report)
+			continue
+		}
+
+		cutoff := now.UTC().AddDate(0, 0, -report.RetentionDays)
+		report.Cutoff = &cutoff
+
+		if purge {
+			result, err := j.pool.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s`, r.Table, r.Condition), cutoff)
+			if err != nil {
+				return reports, fmt.Errorf("failed to purge %s: %w", r.Table, err)
+			}
+			report.Rows = result.RowsAffected()
+		} else {
+			err := j.pool.QueryRow(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, r.Table, r.Condition), cutoff).Scan(&report.Rows)
+			if err != nil {
+				return reports, fmt.Errorf("failed to count %s: %w", r.Table, err)
+			}
+		}
+
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Run purges expired data once (or reports it in dry-run mode) and logs the outcome
+func (j *Janitor) Run(ctx context.Context) {
+	apply, action := j.Purge, "purged"
+	if j.dryRun {
+		apply, action = j.Report, "would be purged (dry run)"
+	}
+
+	reports, err := apply(ctx, time.Now())
+	for _, report := range reports {
+		if report.Rows > 0 {
+			j.logger.Info("Expired data "+action, "table", report.Table, "rows", report.Rows, "retention_days", report.RetentionDays)
+		}
+	}
+	if err != nil {
+		j.logger.Error("Retention janitor failed", "error", err)
+	}
+}
+
+// StartTask runs the janitor now, then at the configured interval
+func (j *Janitor) StartTask(ctx context.Context) {
+	if j.interval <= 0 {
+		j.logger.Info("Retention janitor disabled")
+		return
+	}
+
+	j.logger.Info("Starting retention janitor", "interval", j.interval, "dry_run", j.dryRun)
+
+	go func() {
+		j.Run(ctx)
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				j.logger.Info("Retention janitor stopped (context cancelled)")
+				return
+			case <-ticker.C:
+				j.Run(ctx)
+			}
+		}
+	}()
+}
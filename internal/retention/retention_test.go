@@ -0,0 +1,90 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package retention
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/config"
+)
+
+func newTestJanitor(retention config.RetentionConfig) *Janitor {
+	return NewJanitor(nil, &config.Config{Retention: retention}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestRetentionDays(t *testing.T) {
+	j := newTestJanitor(config.RetentionConfig{
+		AvailabilityDays: 0,
+		LogDays:          30,
+		AuditDays:        365,
+		TokenDays:        7,
+		DeletedUserDays:  30,
+		Overrides:        map[string]int{"hook_events": 90, "login_flows": -1, "availabilities": 730},
+	})
+
+	want := map[string]int{
+		"availabilities":      730, // Override of a category kept forever
+		"notification_log":    30,
+		"hook_events":         90,
+		"webhook_deliveries":  30,
+		"notification_outbox": 30,
+		"mail_queue":          30,
+		"calendar_changes":    365,
+		"refresh_tokens":      7,
+		"login_flows":         0, // Negative means forever
+		"data_exports":        7,
+		"users":               30,
+	}
+	for _, r := range rules {
+		if got := j.retentionDays(r); got != want[r.Table] {
+			t.Errorf("retentionDays(%s) = %d, want %d", r.Table, got, want[r.Table])
+		}
+	}
+}
+
+func TestUnknownOverrides(t *testing.T) {
+	got := UnknownOverrides(map[string]int{"hook_events": 7, "calendars": 30})
+	if !slices.Equal(got, []string{"calendars"}) {
+		t.Errorf("UnknownOverrides() = %v, want [calendars]", got)
+	}
+}
+
+func TestRules(t *testing.T) {
+	categories := []string{CategoryAvailability, CategoryLogs, CategoryAudit, CategoryTokens, CategoryDeletedUsers}
+	seen := make(map[string]bool)
+	for _, r := range rules {
+		if seen[r.Table] {
+			t.Errorf("table %s has several rules", r.Table)
+		}
+		seen[r.Table] = true
+		if !slices.Contains(categories, r.Category) {
+			t.Errorf("table %s has unknown category %q", r.Table, r.Category)
+		}
+	}
+}
+
+func TestReport_KeptForever(t *testing.T) {
+	// Without retention, no query is needed: the janitor works without a database
+	j := newTestJanitor(config.RetentionConfig{})
+
+	reports, err := j.Report(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if len(reports) != len(rules) {
+		t.Fatalf("Report() returned %d tables, want %d", len(reports), len(rules))
+	}
+	for _, report := range reports {
+		if report.Cutoff != nil || report.Rows != 0 {
+			t.Errorf("report of %s = %+v, want kept forever", report.Table, report)
+		}
+	}
+}
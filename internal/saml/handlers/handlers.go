@@ -0,0 +1,170 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build selfhosted
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/whento/pkg/httputil"
+	authService "github.com/whento/whento/internal/auth/service"
+	"github.com/whento/whento/internal/saml/service"
+)
+
+const (
+	// requestCookie ties a response to the browser that started the sign-in
+	requestCookie = "whento_saml_request"
+	cookiePath    = "/api/v1/auth/saml"
+
+	maxResponseSize = 1 << 20 // Signed responses with certificates stay well under 1MB
+)
+
+// Handler handles SAML single sign-on (self-hosted Enterprise licenses)
+type Handler struct {
+	service     *service.Service
+	callbackURL string // Web app page completing the sign-in
+	secure      bool   // APP_URL uses https
+	log         *slog.Logger
+}
+
+// New creates a new SAML handler
+func New(service *service.Service, appURL string, log *slog.Logger) *Handler {
+	return &Handler{
+		service:     service,
+		callbackURL: strings.TrimRight(appURL, "/") + "/auth/saml/callback",
+		secure:      strings.HasPrefix(appURL, "https://"),
+		log:         log,
+	}
+}
+
+// HandleMetadata returns the service provider metadata
+// @Summary Get SAML service provider metadata (Self-hosted Enterprise only)
+// @Description Returns the SAML 2.0 metadata to register WhenTo at the identity provider. Its URL is also the entity ID of the service provider.
+// @Tags Authentication
+// @Produce xml
+// @Success 200 {string} string "SAML metadata"
+// @Failure 404 {object} httputil.ErrorResponse "SAML not configured or not included in the license"
+// @Router /api/v1/auth/saml/metadata [get]
+func (h *Handler) HandleMetadata(w http.ResponseWriter, r *http.Request) {
+	metadata, err := h.service.Metadata(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrSAMLDisabled) {
+			httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "SAML single sign-on is not available")
+			return
+		}
+		h.log.Error("Failed to build SAML metadata", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to build metadata")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(metadata)
+}
+
+// HandleLogin redirects the browser to the identity provider
+// @Summary Start SAML single sign-on (Self-hosted Enterprise only)
+// @Description Redirects the browser to the identity provider login page. The identity provider posts its response to /api/v1/auth/saml/acs.
+// @Tags Authentication
+// @Success 302 "Redirect to the identity provider, or to APP_URL/auth/saml/callback?error=... on failure"
+// @Router /api/v1/auth/saml/login [get]
+func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	requestState, loginURL, err := h.service.LoginURL(r.Context())
+	if err != nil {
+		h.redirectError(w, r, err)
+		return
+	}
+
+	h.setRequestCookie(w, requestState, 10*60) // Same lifetime as the request state
+	http.Redirect(w, r, loginURL, http.StatusFound)
+}
+
+// HandleACS consumes the response of the identity provider (assertion consumer service)
+// @Summary SAML assertion consumer service (Self-hosted Enterprise only)
+// @Description Verifies the SAML response posted by the identity provider (HTTP-POST binding) and redirects to APP_URL/auth/saml/callback with a single-use login ticket, to redeem with POST /api/v1/auth/sso/ticket. Unknown users are linked by email or provisioned when SAML_AUTO_PROVISION is enabled.
+// @Tags Authentication
+// @Accept x-www-form-urlencoded
+// @Param SAMLResponse formData string true "Base64-encoded SAML response"
+// @Success 303 "Redirect to APP_URL/auth/saml/callback#ticket=..., or ?error=... on failure"
+// @Router /api/v1/auth/saml/acs [post]
+func (h *Handler) HandleACS(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxResponseSize)
+	if err := r.ParseForm(); err != nil {
+		h.redirectError(w, r, service.ErrInvalidResponse)
+		return
+	}
+
+	cookie, err := r.Cookie(requestCookie)
+	if err != nil {
+		h.redirectError(w, r, service.ErrInvalidRequestState)
+		return
+	}
+	// The request is single-use
+	h.setRequestCookie(w, "", -1)
+
+	ticket, err := h.service.ACS(r.Context(), r.PostForm.Get("SAMLResponse"), cookie.Value)
+	if err != nil {
+		h.redirectError(w, r, err)
+		return
+	}
+
+	// The ticket is in the fragment: never sent to servers nor in Referer headers
+	http.Redirect(w, r, h.callbackURL+"#"+url.Values{"ticket": {ticket}}.Encode(), http.StatusSeeOther)
+}
+
+// setRequestCookie sets the cookie of the pending request, a negative maxAge deletes it
+// The identity provider posts the response cross-site: SameSite=None, which browsers only accept on https
+func (h *Handler) setRequestCookie(w http.ResponseWriter, value string, maxAge int) {
+	sameSite := http.SameSiteLaxMode
+	if h.secure {
+		sameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     requestCookie,
+		Value:    value,
+		Path:     cookiePath,
+		HttpOnly: true,
+		Secure:   h.secure,
+		SameSite: sameSite,
+		MaxAge:   maxAge,
+	})
+}
+
+// redirectError redirects the browser to the web app with the message of a sign-in error
+func (h *Handler) redirectError(w http.ResponseWriter, r *http.Request, err error) {
+	var message string
+	switch {
+	case errors.Is(err, service.ErrSAMLDisabled):
+		message = "SAML single sign-on is not available"
+	case errors.Is(err, service.ErrInvalidRequestState):
+		message = "Sign-in expired, please try again"
+	case errors.Is(err, service.ErrAuthnFailed):
+		h.log.Warn("SAML sign-in failed at the identity provider", "error", err)
+		message = "Sign-in failed at the identity provider"
+	case errors.Is(err, service.ErrInvalidResponse):
+		h.log.Warn("Rejected SAML response", "error", err)
+		message = "Invalid identity provider response"
+	case errors.Is(err, service.ErrIdPUnavailable):
+		h.log.Error("SAML identity provider unavailable", "error", err)
+		message = "Identity provider unavailable"
+	case errors.Is(err, authService.ErrSSOEmailMissing):
+		message = "The identity provider did not share your email address"
+	case errors.Is(err, authService.ErrSSOAccountNotFound):
+		message = "No account is linked to this identity, ask an administrator"
+	case errors.Is(err, authService.ErrEmailNotAllowed):
+		message = "This email address is not allowed to register"
+	case errors.Is(err, authService.ErrUserAlreadyExists):
+		message = "An account with this email already exists, sign in with your password"
+	default:
+		h.log.Error("SAML single sign-on failed", "error", err)
+		message = "Failed to sign in"
+	}
+
+	http.Redirect(w, r, h.callbackURL+"?"+url.Values{"error": {message}}.Encode(), http.StatusSeeOther)
+}
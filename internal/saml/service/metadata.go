@@ -0,0 +1,140 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build selfhosted
+
+package service
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/crewjam/saml"
+)
+
+// IdPMetadata is what the service provider needs to know about the identity provider
+type IdPMetadata struct {
+	EntityID     string
+	SSOURL       string // SingleSignOnService with the HTTP-Redirect binding
+	Certificates []*x509.Certificate
+
+	descriptor *saml.EntityDescriptor // Only holds the identity provider used
+}
+
+// parseIdPMetadata reads the identity provider of a metadata document
+// The document may list several entities (federation metadata), the first identity provider is used
+func parseIdPMetadata(data []byte) (*IdPMetadata, error) {
+	var descriptors []saml.EntityDescriptor
+	var entities saml.EntitiesDescriptor
+	if err := xml.Unmarshal(data, &entities); err == nil {
+		descriptors = flattenEntities(&entities)
+	} else {
+		var descriptor saml.EntityDescriptor
+		if err := xml.Unmarshal(data, &descriptor); err != nil {
+			return nil, fmt.Errorf("invalid metadata: %w", err)
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+
+	for _, descriptor := range descriptors {
+		for _, idp := range descriptor.IDPSSODescriptors {
+			metadata := &IdPMetadata{EntityID: descriptor.EntityID}
+
+			for _, service := range idp.SingleSignOnServices {
+				if service.Binding == saml.HTTPRedirectBinding {
+					metadata.SSOURL = service.Location
+					break
+				}
+			}
+
+			for _, key := range idp.KeyDescriptors {
+				if key.Use != "" && key.Use != "signing" {
+					continue
+				}
+				for _, encoded := range key.KeyInfo.X509Data.X509Certificates {
+					der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded.Data), ""))
+					if err != nil {
+						return nil, fmt.Errorf("invalid certificate in metadata: %w", err)
+					}
+					cert, err := x509.ParseCertificate(der)
+					if err != nil {
+						return nil, fmt.Errorf("invalid certificate in metadata: %w", err)
+					}
+					metadata.Certificates = append(metadata.Certificates, cert)
+				}
+			}
+
+			switch {
+			case metadata.EntityID == "":
+				return nil, errors.New("metadata has no entityID")
+			case metadata.SSOURL == "":
+				return nil, errors.New("metadata has no SingleSignOnService with the HTTP-Redirect binding")
+			case len(metadata.Certificates) == 0:
+				return nil, errors.New("metadata has no signing certificate")
+			}
+
+			descriptor.IDPSSODescriptors = []saml.IDPSSODescriptor{idp}
+			metadata.descriptor = &descriptor
+			return metadata, nil
+		}
+	}
+	return nil, errors.New("metadata has no IDPSSODescriptor")
+}
+
+// flattenEntities lists the entities of federation metadata, groups included
+func flattenEntities(entities *saml.EntitiesDescriptor) []saml.EntityDescriptor {
+	descriptors := entities.EntityDescriptors
+	for i := range entities.EntitiesDescriptors {
+		descriptors = append(descriptors, flattenEntities(&entities.EntitiesDescriptors[i])...)
+	}
+	return descriptors
+}
+
+// serviceProvider returns the service provider for an identity provider
+// AuthnRequests are not signed and assertions are not encrypted: the service provider has no key pair
+func (s *Service) serviceProvider(idp *IdPMetadata) (*saml.ServiceProvider, error) {
+	acsURL, err := url.Parse(s.acsURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACS URL: %w", err)
+	}
+	sp := &saml.ServiceProvider{
+		EntityID:          s.entityID,
+		AcsURL:            *acsURL,
+		AuthnNameIDFormat: saml.UnspecifiedNameIDFormat,
+	}
+	if idp != nil {
+		sp.IDPMetadata = idp.descriptor
+	}
+	return sp, nil
+}
+
+// buildSPMetadata returns the metadata document of the service provider
+func (s *Service) buildSPMetadata() ([]byte, error) {
+	sp, err := s.serviceProvider(nil)
+	if err != nil {
+		return nil, err
+	}
+	metadata := sp.Metadata()
+
+	// Responses are only accepted with the HTTP-POST binding
+	descriptor := &metadata.SPSSODescriptors[0]
+	var services []saml.IndexedEndpoint
+	for _, service := range descriptor.AssertionConsumerServices {
+		if service.Binding == saml.HTTPPostBinding {
+			services = append(services, service)
+		}
+	}
+	descriptor.AssertionConsumerServices = services
+
+	out, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(xml.Header + strings.TrimSpace(string(out)) + "\n"), nil
+}
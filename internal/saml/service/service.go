@@ -0,0 +1,370 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build selfhosted
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/jwt"
+	authModels "github.com/whento/whento/internal/auth/models"
+	authService "github.com/whento/whento/internal/auth/service"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/quota"
+)
+
+const (
+	samlRequestClaim = "saml_request"   // JWT claim holding the ID of the pending AuthnRequest
+	requestTTL       = 10 * time.Minute // Time left to sign in at the identity provider
+
+	metadataRefreshInterval = 24 * time.Hour // Certificates of the identity provider may rotate
+	metadataRetryInterval   = time.Hour      // After a failed refresh, the previous metadata is kept
+	maxMetadataSize         = 5 << 20        // Federation metadata can be large
+)
+
+var (
+	ErrSAMLDisabled        = errors.New("SAML single sign-on is not available")
+	ErrIdPUnavailable      = errors.New("SAML identity provider metadata unavailable")
+	ErrInvalidRequestState = errors.New("invalid or expired SAML request")
+	ErrInvalidResponse     = errors.New("invalid SAML response")
+	ErrAuthnFailed         = errors.New("authentication failed at the identity provider")
+)
+
+// Attributes tried when no attribute is configured (LDAP names, ADFS/Entra ID claims and OIDs)
+var (
+	emailAttributes = []string{
+		"email", "mail", "emailAddress",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+	}
+	nameAttributes = []string{
+		"displayName", "name",
+		"http://schemas.microsoft.com/identity/claims/displayname",
+		"urn:oid:2.16.840.1.113730.3.1.241",
+		"cn", "urn:oid:2.5.4.3",
+	}
+	givenNameAttributes = []string{
+		"givenName", "firstName",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname",
+		"urn:oid:2.5.4.42",
+	}
+	surnameAttributes = []string{
+		"sn", "surname", "lastName",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname",
+		"urn:oid:2.5.4.4",
+	}
+	localeAttributes = []string{"preferredLanguage", "locale", "urn:oid:2.16.840.1.113730.3.1.39"}
+)
+
+// LicenseChecker checks the features included in the license
+type LicenseChecker interface {
+	HasCapability(ctx context.Context, userID uuid.UUID, capability quota.Capability) (bool, error)
+}
+
+// Service is the SAML 2.0 service provider (Web Browser SSO profile)
+// AuthnRequests use the HTTP-Redirect binding, responses the HTTP-POST binding
+type Service struct {
+	cfg          config.SAMLConfig
+	entityID     string
+	acsURL       string
+	ssoService   *authService.SSOService
+	licenses     LicenseChecker
+	jwtManager   *jwt.Manager
+	requireHTTPS bool
+	httpClient   *http.Client
+	logger       *slog.Logger
+
+	mu        sync.Mutex
+	idp       *IdPMetadata // Cached identity provider metadata
+	fetchedAt time.Time
+}
+
+// New creates a new SAML service provider
+func New(ssoService *authService.SSOService, licenses LicenseChecker, jwtManager *jwt.Manager, cfg *config.Config, logger *slog.Logger) *Service {
+	baseURL := strings.TrimRight(cfg.AppURL, "/") + "/api/v1/auth/saml"
+	return &Service{
+		cfg:          cfg.SAML,
+		entityID:     baseURL + "/metadata",
+		acsURL:       baseURL + "/acs",
+		ssoService:   ssoService,
+		licenses:     licenses,
+		jwtManager:   jwtManager,
+		requireHTTPS: cfg.AppEnv == "production",
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		logger:       logger,
+	}
+}
+
+// Available reports whether SAML is configured and included in the license (Enterprise tier)
+func (s *Service) Available(ctx context.Context) bool {
+	if !s.cfg.Enabled() {
+		return false
+	}
+	ok, err := s.licenses.HasCapability(ctx, uuid.Nil, quota.CapabilitySAML)
+	return err == nil && ok
+}
+
+// ProviderName returns the label of the login button
+func (s *Service) ProviderName() string {
+	return s.cfg.ProviderName
+}
+
+// Metadata returns the metadata document to register the service provider at the identity provider
+func (s *Service) Metadata(ctx context.Context) ([]byte, error) {
+	if !s.Available(ctx) {
+		return nil, ErrSAMLDisabled
+	}
+	return s.buildSPMetadata()
+}
+
+// LoginURL starts a sign-in and returns its signed request state with the identity provider login page URL
+// The state must be sent back with the response of the identity provider
+func (s *Service) LoginURL(ctx context.Context) (requestState, loginURL string, err error) {
+	if !s.Available(ctx) {
+		return "", "", ErrSAMLDisabled
+	}
+
+	idp, err := s.getIdP(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	sp, err := s.serviceProvider(idp)
+	if err != nil {
+		return "", "", err
+	}
+
+	request, err := sp.MakeAuthenticationRequest(idp.SSOURL, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create AuthnRequest: %w", err)
+	}
+	redirect, err := request.Redirect("", sp)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode AuthnRequest: %w", err)
+	}
+
+	// The request ID is signed so that no server-side storage is needed until the response
+	requestState, err = s.jwtManager.GenerateCustomToken(map[string]interface{}{
+		samlRequestClaim: request.ID,
+		"exp":            time.Now().Add(requestTTL).Unix(),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate request state: %w", err)
+	}
+
+	return requestState, redirect.String(), nil
+}
+
+// ACS consumes the response posted by the identity provider and returns a login ticket for the web app
+// The user is found by identity, then linked by email, then provisioned if allowed
+func (s *Service) ACS(ctx context.Context, encodedResponse, requestState string) (string, error) {
+	if !s.Available(ctx) {
+		return "", ErrSAMLDisabled
+	}
+
+	claims, err := s.jwtManager.ValidateCustomToken(requestState)
+	if err != nil {
+		return "", ErrInvalidRequestState
+	}
+	requestID, ok := claims[samlRequestClaim].(string)
+	if !ok || requestID == "" {
+		return "", ErrInvalidRequestState
+	}
+
+	idp, err := s.getIdP(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Encoded responses may be wrapped over several lines
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encodedResponse), ""))
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid encoding", ErrInvalidResponse)
+	}
+
+	assertion, err := s.validateResponse(data, idp, requestID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.ssoService.IssueTicket(ctx, s.externalIdentity(idp, assertion), s.cfg.AutoProvision)
+}
+
+// assertionData holds the verified content of an assertion
+type assertionData struct {
+	NameID       string
+	NameIDFormat string
+	Attributes   []assertionAttribute
+}
+
+type assertionAttribute struct {
+	Name         string
+	FriendlyName string
+	Values       []string
+}
+
+// attribute returns the first value of the configured attribute, or of the first candidate with a value
+// Candidates also match friendly names, case-insensitively
+func (a *assertionData) attribute(configured string, candidates ...string) string {
+	if configured != "" {
+		candidates = []string{configured}
+	}
+	for _, candidate := range candidates {
+		for _, attr := range a.Attributes {
+			if attr.Name != candidate && (attr.FriendlyName == "" || !strings.EqualFold(attr.FriendlyName, candidate)) {
+				continue
+			}
+			for _, value := range attr.Values {
+				if value != "" {
+					return value
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// validateResponse checks the signature and conditions of a response to the pending request and returns its assertion
+// Either the response or the assertion must be signed by the identity provider
+func (s *Service) validateResponse(data []byte, idp *IdPMetadata, requestID string) (*assertionData, error) {
+	sp, err := s.serviceProvider(idp)
+	if err != nil {
+		return nil, err
+	}
+
+	// IdP-initiated sign-ins are not supported: they can't be tied to the browser
+	assertion, err := sp.ParseXMLResponse(data, []string{requestID}, sp.AcsURL)
+	if err != nil {
+		// The library hides the reason from the error message
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) && invalid.PrivateErr != nil {
+			err = invalid.PrivateErr
+		}
+		var status saml.ErrBadStatus
+		if errors.As(err, &status) {
+			return nil, fmt.Errorf("%w: %s", ErrAuthnFailed, status.Status[strings.LastIndex(status.Status, ":")+1:])
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		return nil, fmt.Errorf("%w: missing NameID", ErrInvalidResponse)
+	}
+
+	result := &assertionData{NameID: assertion.Subject.NameID.Value, NameIDFormat: assertion.Subject.NameID.Format}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			attribute := assertionAttribute{Name: attr.Name, FriendlyName: attr.FriendlyName}
+			for _, value := range attr.Values {
+				attribute.Values = append(attribute.Values, value.Value)
+			}
+			result.Attributes = append(result.Attributes, attribute)
+		}
+	}
+	return result, nil
+}
+
+// externalIdentity maps the attributes of an assertion to the identity of the user
+// Emails are trusted: the identity provider is configured by the administrator
+func (s *Service) externalIdentity(idp *IdPMetadata, assertion *assertionData) *authService.ExternalIdentity {
+	email := assertion.attribute(s.cfg.EmailAttribute, emailAttributes...)
+	if email == "" && strings.Contains(assertion.NameID, "@") {
+		email = assertion.NameID
+	}
+	email = strings.TrimSpace(email)
+
+	name := assertion.attribute(s.cfg.NameAttribute, nameAttributes...)
+	if name == "" && s.cfg.NameAttribute == "" {
+		name = strings.TrimSpace(assertion.attribute("", givenNameAttributes...) + " " + assertion.attribute("", surnameAttributes...))
+	}
+
+	// Transient NameIDs change on every sign-in, the email identifies the user instead
+	subject := assertion.NameID
+	if assertion.NameIDFormat == string(saml.TransientNameIDFormat) {
+		subject = strings.ToLower(email)
+	}
+
+	return &authService.ExternalIdentity{
+		Provider:      authModels.IdentityProviderSAML,
+		Issuer:        idp.EntityID,
+		Subject:       subject,
+		Email:         email,
+		EmailVerified: true,
+		DisplayName:   name,
+		Locale:        assertion.attribute("", localeAttributes...),
+	}
+}
+
+// getIdP returns the identity provider metadata, refreshed daily
+// If a refresh fails, the previous metadata is kept and the refresh retried later
+func (s *Service) getIdP(ctx context.Context) (*IdPMetadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.idp != nil && time.Since(s.fetchedAt) < metadataRefreshInterval {
+		return s.idp, nil
+	}
+
+	data, err := s.readMetadata(ctx)
+	var idp *IdPMetadata
+	if err == nil {
+		idp, err = parseIdPMetadata(data)
+	}
+	if err != nil {
+		if s.idp != nil {
+			s.logger.Warn("Failed to refresh SAML identity provider metadata, keeping the previous one", "error", err)
+			s.fetchedAt = time.Now().Add(metadataRetryInterval - metadataRefreshInterval)
+			return s.idp, nil
+		}
+		return nil, fmt.Errorf("%w: %v", ErrIdPUnavailable, err)
+	}
+
+	if s.idp == nil || s.idp.EntityID != idp.EntityID {
+		s.logger.Info("Loaded SAML identity provider metadata", "entity_id", idp.EntityID, "certificates", len(idp.Certificates))
+	}
+	s.idp = idp
+	s.fetchedAt = time.Now()
+	return idp, nil
+}
+
+// readMetadata reads the identity provider metadata from its URL or file
+func (s *Service) readMetadata(ctx context.Context) ([]byte, error) {
+	source := s.cfg.IDPMetadata
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		return os.ReadFile(source)
+	}
+	// Metadata holds the trusted certificates, it must not be tampered with
+	if s.requireHTTPS && !strings.HasPrefix(source, "https://") {
+		return nil, errors.New("the metadata URL must use https in production")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %d", source, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+}
@@ -0,0 +1,302 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build selfhosted
+
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/crewjam/saml"
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/whento/whento/internal/config"
+)
+
+const (
+	testEntityID  = "https://whento.example.com/api/v1/auth/saml/metadata"
+	testACSURL    = "https://whento.example.com/api/v1/auth/saml/acs"
+	testIdPEntity = "https://idp.example.com/saml"
+	testRequestID = "_4fee3b046395c4e751011e97f8900b5273d56685"
+)
+
+func TestValidateResponse(t *testing.T) {
+	key, cert := newTestCertificate(t)
+	otherKey, otherCert := newTestCertificate(t)
+	idp, err := parseIdPMetadata([]byte(testIdPMetadata(cert)))
+	if err != nil {
+		t.Fatalf("parseIdPMetadata() error = %v", err)
+	}
+	s := &Service{entityID: testEntityID, acsURL: testACSURL}
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name    string
+		build   func() string
+		wantErr error
+	}{
+		{name: "signed assertion", build: func() string {
+			return sign(t, key, cert, testResponse(now, nil), "_assertion")
+		}},
+		{name: "signed response", build: func() string {
+			return sign(t, key, cert, testResponse(now, nil), "_response")
+		}},
+		{name: "unsigned", build: func() string {
+			return testResponse(now, nil)
+		}, wantErr: ErrInvalidResponse},
+		{name: "other key", build: func() string {
+			return sign(t, otherKey, otherCert, testResponse(now, nil), "_assertion")
+		}, wantErr: ErrInvalidResponse},
+		{name: "tampered after signing", build: func() string {
+			return strings.Replace(sign(t, key, cert, testResponse(now, nil), "_assertion"), "jane@example.com", "admin@example.com", 1)
+		}, wantErr: ErrInvalidResponse},
+		{name: "unsigned assertion placed before a signed one", build: func() string {
+			// Only the signed assertion is read
+			signed := sign(t, key, cert, testResponse(now, nil), "_assertion")
+			forged := testAssertion(now, map[string]string{`ID="_assertion"`: `ID="_forged"`, "jane@example.com": "admin@example.com"})
+			return strings.Replace(signed, "</samlp:Status>", "</samlp:Status>"+forged, 1)
+		}},
+		{name: "encrypted assertion", build: func() string {
+			encrypted := `<saml:EncryptedAssertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion"><xenc:EncryptedData xmlns:xenc="http://www.w3.org/2001/04/xmlenc#"/></saml:EncryptedAssertion>`
+			return sign(t, key, cert, strings.Replace(testResponse(now, nil), testAssertion(now, nil), encrypted, 1), "_response")
+		}, wantErr: ErrInvalidResponse},
+		{name: "other request", build: func() string {
+			return sign(t, key, cert, testResponse(now, map[string]string{testRequestID: "_other"}), "_response")
+		}, wantErr: ErrInvalidResponse},
+		{name: "other audience", build: func() string {
+			return sign(t, key, cert, testResponse(now, map[string]string{"<saml:Audience>" + testEntityID: "<saml:Audience>https://other.example.com"}), "_assertion")
+		}, wantErr: ErrInvalidResponse},
+		{name: "other issuer", build: func() string {
+			return sign(t, key, cert, testResponse(now, map[string]string{testIdPEntity: "https://evil.example.com"}), "_assertion")
+		}, wantErr: ErrInvalidResponse},
+		{name: "expired", build: func() string {
+			return sign(t, key, cert, testResponse(now.Add(-time.Hour), nil), "_assertion")
+		}, wantErr: ErrInvalidResponse},
+		{name: "failed status", build: func() string {
+			return sign(t, key, cert, testResponse(now, map[string]string{
+				`<samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/>`: `<samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Responder"><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:AuthnFailed"/></samlp:StatusCode>`,
+			}), "_response")
+		}, wantErr: ErrAuthnFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertion, err := s.validateResponse([]byte(tt.build()), idp, testRequestID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("validateResponse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateResponse() error = %v", err)
+			}
+			if assertion.NameID != "jdoe" || assertion.attribute("", emailAttributes...) != "jane@example.com" {
+				t.Errorf("validateResponse() = %+v", assertion)
+			}
+		})
+	}
+}
+
+func TestExternalIdentity(t *testing.T) {
+	idp := &IdPMetadata{EntityID: testIdPEntity}
+	assertion := &assertionData{
+		NameID: "jdoe",
+		Attributes: []assertionAttribute{
+			{Name: "urn:oid:0.9.2342.19200300.100.1.3", FriendlyName: "mail", Values: []string{"jane@example.com"}},
+			{Name: "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname", Values: []string{"Jane"}},
+			{Name: "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname", Values: []string{"Doe"}},
+			{Name: "department", Values: []string{"", "Sales"}},
+		},
+	}
+
+	s := &Service{}
+	ext := s.externalIdentity(idp, assertion)
+	if ext.Subject != "jdoe" || ext.Issuer != testIdPEntity || ext.Email != "jane@example.com" || !ext.EmailVerified {
+		t.Errorf("externalIdentity() = %+v", ext)
+	}
+	if ext.DisplayName != "Jane Doe" {
+		t.Errorf("display name = %q, want Jane Doe", ext.DisplayName)
+	}
+
+	s = &Service{cfg: config.SAMLConfig{NameAttribute: "department"}}
+	if ext := s.externalIdentity(idp, assertion); ext.DisplayName != "Sales" {
+		t.Errorf("display name with configured attribute = %q, want Sales", ext.DisplayName)
+	}
+
+	// Transient NameIDs are replaced by the email
+	transient := &assertionData{NameID: "Jane@Example.com", NameIDFormat: string(saml.TransientNameIDFormat)}
+	if ext := s.externalIdentity(idp, transient); ext.Subject != "jane@example.com" || ext.Email != "Jane@Example.com" {
+		t.Errorf("externalIdentity() with transient NameID = %+v", ext)
+	}
+}
+
+func TestParseIdPMetadata(t *testing.T) {
+	_, cert := newTestCertificate(t)
+	metadata := testIdPMetadata(cert)
+
+	idp, err := parseIdPMetadata([]byte(metadata))
+	if err != nil {
+		t.Fatalf("parseIdPMetadata() error = %v", err)
+	}
+	if idp.EntityID != testIdPEntity || idp.SSOURL != "https://idp.example.com/sso/redirect" || len(idp.Certificates) != 1 {
+		t.Errorf("parseIdPMetadata() = %+v", idp)
+	}
+
+	withoutRedirect := strings.Replace(metadata, "HTTP-Redirect", "SOAP", 1)
+	if _, err := parseIdPMetadata([]byte(withoutRedirect)); err == nil {
+		t.Error("parseIdPMetadata() without HTTP-Redirect binding should fail")
+	}
+}
+
+func TestBuildSPMetadata(t *testing.T) {
+	s := &Service{entityID: testEntityID, acsURL: testACSURL}
+	metadata, err := s.buildSPMetadata()
+	if err != nil {
+		t.Fatalf("buildSPMetadata() error = %v", err)
+	}
+
+	var descriptor saml.EntityDescriptor
+	if err := xml.Unmarshal(metadata, &descriptor); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	if descriptor.EntityID != testEntityID || len(descriptor.SPSSODescriptors) != 1 {
+		t.Fatalf("metadata = %s", metadata)
+	}
+	sp := descriptor.SPSSODescriptors[0]
+	if len(sp.AssertionConsumerServices) != 1 || sp.AssertionConsumerServices[0].Location != testACSURL || sp.AssertionConsumerServices[0].Binding != saml.HTTPPostBinding {
+		t.Errorf("metadata = %s", metadata)
+	}
+	if sp.WantAssertionsSigned == nil || !*sp.WantAssertionsSigned {
+		t.Errorf("WantAssertionsSigned = %v, want true", sp.WantAssertionsSigned)
+	}
+}
+
+// newTestCertificate generates a self-signed identity provider certificate
+func newTestCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, cert
+}
+
+// testAssertion returns an assertion for the test request, with replacements applied
+func testAssertion(now time.Time, replace map[string]string) string {
+	assertion := fmt.Sprintf(`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_assertion" Version="2.0" IssueInstant="%[1]s">
+    <saml:Issuer>%[4]s</saml:Issuer>
+    <saml:Subject>
+      <saml:NameID Format="urn:oasis:names:tc:SAML:2.0:nameid-format:persistent">jdoe</saml:NameID>
+      <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+        <saml:SubjectConfirmationData InResponseTo="%[5]s" Recipient="%[6]s" NotOnOrAfter="%[3]s"/>
+      </saml:SubjectConfirmation>
+    </saml:Subject>
+    <saml:Conditions NotBefore="%[2]s" NotOnOrAfter="%[3]s">
+      <saml:AudienceRestriction><saml:Audience>%[7]s</saml:Audience></saml:AudienceRestriction>
+    </saml:Conditions>
+    <saml:AttributeStatement>
+      <saml:Attribute Name="email"><saml:AttributeValue>jane@example.com</saml:AttributeValue></saml:Attribute>
+    </saml:AttributeStatement>
+  </saml:Assertion>`,
+		now.Format(time.RFC3339), now.Add(-time.Minute).Format(time.RFC3339), now.Add(5*time.Minute).Format("2006-01-02T15:04:05.000Z"),
+		testIdPEntity, testRequestID, testACSURL, testEntityID)
+	for old, replacement := range replace {
+		assertion = strings.ReplaceAll(assertion, old, replacement)
+	}
+	return assertion
+}
+
+// testResponse returns a response with one assertion for the test request, with replacements applied
+func testResponse(now time.Time, replace map[string]string) string {
+	response := fmt.Sprintf(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_response" Version="2.0" IssueInstant="%s" Destination="%s" InResponseTo="%s">
+  <saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">%s</saml:Issuer>
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>
+  %s
+</samlp:Response>`, now.Format(time.RFC3339), testACSURL, testRequestID, testIdPEntity, testAssertion(now, nil))
+	for old, replacement := range replace {
+		response = strings.ReplaceAll(response, old, replacement)
+	}
+	return response
+}
+
+// testIdPMetadata returns federation metadata with the test identity provider trusting cert
+func testIdPMetadata(cert *x509.Certificate) string {
+	encoded := base64.StdEncoding.EncodeToString(cert.Raw)
+	return `<?xml version="1.0"?>
+<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+  <md:EntityDescriptor entityID="https://sp.example.com"><md:SPSSODescriptor/></md:EntityDescriptor>
+  <md:EntityDescriptor entityID="` + testIdPEntity + `">
+    <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+      <md:KeyDescriptor use="encryption"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>invalid</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+      <md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+        ` + encoded[:40] + `
+        ` + encoded[40:] + `
+      </ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+      <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+      <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+    </md:IDPSSODescriptor>
+  </md:EntityDescriptor>
+</md:EntitiesDescriptor>`
+}
+
+// sign adds the enveloped signature of the element with this ID to a document, with the certificate in KeyInfo
+func sign(t *testing.T, key *rsa.PrivateKey, cert *x509.Certificate, doc, id string) string {
+	t.Helper()
+	document := etree.NewDocument()
+	if err := document.ReadFromString(doc); err != nil {
+		t.Fatal(err)
+	}
+	target := document.FindElement("//*[@ID='" + id + "']")
+	if target == nil {
+		t.Fatalf("no element with ID %s", id)
+	}
+
+	ctx := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key}))
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signed, err := ctx.SignEnveloped(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parent := target.Parent(); parent != nil && parent != &document.Element {
+		parent.InsertChildAt(target.Index(), signed)
+		parent.RemoveChild(target)
+	} else {
+		document.SetRoot(signed)
+	}
+	out, err := document.WriteToString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/whento/internal/search/models"
+	"github.com/whento/whento/internal/search/service"
+)
+
+// SearchHandler handles HTTP requests for search
+type SearchHandler struct {
+	service *service.SearchService
+	logger  *slog.Logger
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(service *service.SearchService, logger *slog.Logger) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		Search my calendars
+// @Description	Searches calendar names and descriptions, participant names and availability notes (case-insensitive, substrings included) in the personal calendars of the current user and those of their organizations. Results are ranked by similarity with the query.
+// @Tags			Search
+// @Produce		json
+// @Security		BearerAuth
+// @Param			q		query		string	true	"Search query (2 to 100 characters)"
+// @Param			limit	query		int		false	"Maximum number of results (default 20, max 50)"
+// @Success		200		{object}	models.SearchResponse	"Matches, best first"
+// @Failure		400		{object}	httputil.ErrorResponse	"Query too short or too long"
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Router			/api/v1/search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	query := service.NormalizeQuery(r.URL.Query().Get("q"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	results, err := h.service.Search(r.Context(), userUUID, query, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrQueryTooShort), errors.Is(err, service.ErrQueryTooLong):
+			httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		default:
+			h.logger.Error("Failed to search", "error", err, "user_id", userUUID)
+			httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to search")
+		}
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, models.SearchResponse{Query: query, Results: results})
+}
@@ -0,0 +1,35 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// Kinds of search results
+const (
+	KindCalendar    = "calendar"    // Name or description of a calendar
+	KindParticipant = "participant" // Name of a participant
+	KindNote        = "note"        // Note of an availability or a recurring availability
+)
+
+// Result is a match of a search, always linked to the calendar it belongs to
+type Result struct {
+	Kind            string     `json:"kind" enums:"calendar,participant,note"`
+	CalendarID      uuid.UUID  `json:"calendar_id"`
+	CalendarName    string     `json:"calendar_name"`
+	ParticipantID   *uuid.UUID `json:"participant_id,omitempty"`            // Participant and note results
+	ParticipantName string     `json:"participant_name,omitempty"`          // Participant and note results
+	Date            *string    `json:"date,omitempty" example:"2025-06-14"` // Notes of single-day availabilities
+	Text            string     `json:"text"`                                // Matched text
+	Score           float64    `json:"score"`                               // Trigram similarity with the query, from 0 to 1
+}
+
+// SearchResponse lists the matches of a search, best first
+type SearchResponse struct {
+	Query   string   `json:"query"`
+	Results []Result `json:"results"`
+}
@@ -0,0 +1,107 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/search/models"
+)
+
+// SearchRepository searches the calendars accessible to a user
+type SearchRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(pool *pgxpool.Pool) *SearchRepository {
+	return &SearchRepository{pool: pool}
+}
+
+// Search matches a query against calendar names and descriptions, participant names and availability notes
+// Only the personal calendars of the user and those of their organizations are searched.
+// Matching is case-insensitive on substrings (backed by trigram indexes), ranked by similarity.
+func (r *SearchRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]models.Result, error) {
+	sql := `
+		WITH accessible AS (
+			SELECT c.id, c.name, COALESCE(c.description, '') AS description
+			FROM calendars c
+			WHERE (c.organization_id IS NULL AND c.owner_id = $1)
+			   OR c.organization_id IN (SELECT m.organization_id FROM organization_members m WHERE m.user_id = $1)
+		)
+		SELECT kind, calendar_id, calendar_name, participant_id, participant_name, date, text, score
+		FROM (
+			SELECT 'calendar' AS kind, a.id AS calendar_id, a.name AS calendar_name,
+				NULL::uuid AS participant_id, '' AS participant_name, NULL::date AS date,
+				a.name AS text, similarity(a.name, $2) AS score
+			FROM accessible a
+			WHERE a.name ILIKE $3
+
+			UNION ALL
+			SELECT 'calendar', a.id, a.name, NULL, '', NULL, a.description, similarity(a.description, $2)
+			FROM accessible a
+			WHERE a.description ILIKE $3 AND a.name NOT ILIKE $3
+
+			UNION ALL
+			SELECT 'participant', a.id, a.name, p.id, p.name, NULL, p.name, similarity(p.name, $2)
+			FROM participants p
+			JOIN accessible a ON a.id = p.calendar_id
+			WHERE p.name ILIKE $3
+
+			UNION ALL
+			SELECT 'note', a.id, a.name, p.id, p.name, av.date, av.note, similarity(av.note, $2)
+			FROM availabilities av
+			JOIN participants p ON p.id = av.participant_id
+			JOIN accessible a ON a.id = p.calendar_id
+			WHERE av.note IS NOT NULL AND av.note <> '' AND av.note ILIKE $3
+
+			UNION ALL
+			SELECT 'note', a.id, a.name, p.id, p.name, NULL, rec.note, similarity(rec.note, $2)
+			FROM recurrences rec
+			JOIN participants p ON p.id = rec.participant_id
+			JOIN accessible a ON a.id = p.calendar_id
+			WHERE rec.note IS NOT NULL AND rec.note <> '' AND rec.note ILIKE $3
+		) results
+		ORDER BY score DESC, calendar_name, text
+		LIMIT $4`
+
+	rows, err := r.pool.Query(ctx, sql, userID, query, likePattern(query), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.Result{}
+	for rows.Next() {
+		var result models.Result
+		var date *time.Time
+		var score float32
+		if err := rows.Scan(&result.Kind, &result.CalendarID, &result.CalendarName, &result.ParticipantID,
+			&result.ParticipantName, &date, &result.Text, &score); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if date != nil {
+			formatted := date.Format("2006-01-02")
+			result.Date = &formatted
+		}
+		result.Score = float64(score)
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// likePattern matches a query anywhere in a text, escaping the LIKE wildcards it contains
+func likePattern(query string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query)
+	return "%" + escaped + "%"
+}
@@ -0,0 +1,69 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/search/models"
+)
+
+// Query length and result limits
+const (
+	MinQueryLength = 2
+	MaxQueryLength = 100
+	DefaultLimit   = 20
+	MaxLimit       = 50
+)
+
+var (
+	ErrQueryTooShort = errors.New("search query must be at least 2 characters")
+	ErrQueryTooLong  = errors.New("search query must be at most 100 characters")
+)
+
+// SearchRepository defines the interface for search repository operations
+type SearchRepository interface {
+	Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]models.Result, error)
+}
+
+// SearchService searches the calendars accessible to a user
+type SearchService struct {
+	repo SearchRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(repo SearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// NormalizeQuery collapses the whitespace of a search query
+func NormalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// Search returns the best matches of a normalized query in the calendars of a user, at most limit (0 = default)
+func (s *SearchService) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]models.Result, error) {
+	switch length := utf8.RuneCountInString(query); {
+	case length < MinQueryLength:
+		return nil, ErrQueryTooShort
+	case length > MaxQueryLength:
+		return nil, ErrQueryTooLong
+	}
+
+	switch {
+	case limit <= 0:
+		limit = DefaultLimit
+	case limit > MaxLimit:
+		limit = MaxLimit
+	}
+
+	return s.repo.Search(ctx, userID, query, limit)
+}
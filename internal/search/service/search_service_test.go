@@ -0,0 +1,61 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/search/models"
+)
+
+type mockSearchRepository struct {
+	limit int
+}
+
+func (m *mockSearchRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]models.Result, error) {
+	m.limit = limit
+	return []models.Result{}, nil
+}
+
+func TestSearchService_Search(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		err   error
+		want  int
+	}{
+		{"default limit", "board games", 0, nil, DefaultLimit},
+		{"custom limit", "board games", 5, nil, 5},
+		{"clamped limit", "board games", 500, nil, MaxLimit},
+		{"too short", "a", 0, ErrQueryTooShort, 0},
+		{"too long", strings.Repeat("a", MaxQueryLength+1), 0, ErrQueryTooLong, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockSearchRepository{}
+			_, err := NewSearchService(repo).Search(context.Background(), uuid.New(), tt.query, tt.limit)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if repo.limit != tt.want {
+				t.Errorf("limit = %d, want %d", repo.limit, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	if got := NormalizeQuery("  board \t games \n"); got != "board games" {
+		t.Errorf("NormalizeQuery() = %q, want %q", got, "board games")
+	}
+}
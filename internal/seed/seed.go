@@ -0,0 +1,344 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+// Package seed generates realistic demo data (calendars, participants, availabilities
+// and recurrences) for evaluation installs, screenshots and load testing.
+package seed
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+
+	authModels "github.com/whento/whento/internal/auth/models"
+	authRepo "github.com/whento/whento/internal/auth/repository"
+	availabilityModels "github.com/whento/whento/internal/availability/models"
+	availabilityRepo "github.com/whento/whento/internal/availability/repository"
+	calendarModels "github.com/whento/whento/internal/calendar/models"
+	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+)
+
+// DefaultOwnerEmail is the demo account created when no owner is given
+const DefaultOwnerEmail = "demo@whento.local"
+
+// Options controls the amount and shape of the generated data
+type Options struct {
+	Calendars    int    // Number of calendars to create
+	Participants int    // Participants per calendar
+	Weeks        int    // Number of weeks of availabilities, starting today
+	OwnerEmail   string // Owner of the calendars, created if missing
+	Seed         uint64 // Random seed (0 = random), for reproducible data
+}
+
+// Result summarizes the generated data
+type Result struct {
+	OwnerEmail     string
+	OwnerPassword  string // Only set when the owner account was created
+	Calendars      []*calendarModels.Calendar
+	Participants   int
+	Availabilities int
+	Recurrences    int
+}
+
+// Seeder writes demo data through the regular repositories
+type Seeder struct {
+	users          *authRepo.UserRepository
+	calendars      *calendarRepo.CalendarRepository
+	availabilities *availabilityRepo.AvailabilityRepository
+	recurrences    *availabilityRepo.RecurrenceRepository
+	bcryptCost     int
+}
+
+// New creates a new seeder
+func New(pool *pgxpool.Pool, bcryptCost int) *Seeder {
+	return &Seeder{
+		bcryptCost:     bcryptCost,
+		users:          authRepo.NewUserRepository(pool),
+		calendars:      calendarRepo.NewCalendarRepository(pool),
+		availabilities: availabilityRepo.NewAvailabilityRepository(pool),
+		recurrences:    availabilityRepo.NewRecurrenceRepository(pool),
+	}
+}
+
+// calendarTemplate describes a kind of recurring group event
+type calendarTemplate struct {
+	Name        string
+	Description string
+	Weekdays    []int  // Allowed weekdays (0=Sunday)
+	EarliestMin int    // Earliest start, in minutes since midnight
+	LatestMin   int    // Latest end, in minutes since midnight
+	Note        string // Typical availability note
+}
+
+var calendarTemplates = []calendarTemplate{
+	{"Board game night", "Weekly board game evening, bring snacks!", []int{1, 2, 3, 4, 5}, 18 * 60, 24*60 - 1, "Can bring a game"},
+	{"Five-a-side football", "Friendly match at the community pitch", []int{2, 4, 6}, 17 * 60, 22 * 60, "Might be late"},
+	{"Band rehearsal", "Rehearsal at the studio", []int{1, 3, 6, 0}, 14 * 60, 23 * 60, "Need a lift"},
+	{"D&D campaign", "The Lost Mines campaign, session every week", []int{5, 6, 0}, 13 * 60, 24*60 - 1, "Will prepare my character"},
+	{"Book club", "Monthly book discussion", []int{2, 3, 4}, 18 * 60, 22 * 60, "Halfway through the book"},
+	{"Team retrospective", "Sprint retrospective for the product team", []int{1, 2, 3, 4, 5}, 9 * 60, 18 * 60, "Remote only"},
+	{"Climbing sessions", "Bouldering at the gym", []int{1, 3, 5, 6}, 12 * 60, 22 * 60, "Bringing chalk"},
+	{"Choir practice", "Practice for the spring concert", []int{2, 4, 0}, 10 * 60, 21 * 60, ""},
+}
+
+var participantNames = []string{
+	"Alice", "Bastien", "Camille", "David", "Emma", "Farid", "Gabrielle", "Hugo",
+	"Inès", "Jules", "Karim", "Léa", "Manon", "Nathan", "Océane", "Paul",
+	"Quentin", "Rose", "Sofia", "Théo", "Ulysse", "Victor", "Wendy", "Yanis", "Zoé",
+}
+
+// Run generates the demo data
+func (s *Seeder) Run(ctx context.Context, opts Options) (*Result, error) {
+	if opts.Calendars < 1 || opts.Participants < 1 || opts.Weeks < 1 {
+		return nil, errors.New("calendars, participants and weeks must be at least 1")
+	}
+	if opts.OwnerEmail == "" {
+		opts.OwnerEmail = DefaultOwnerEmail
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = mathrand.Uint64()
+	}
+	rng := mathrand.New(mathrand.NewPCG(seed, seed))
+
+	owner, password, err := s.ensureOwner(ctx, opts.OwnerEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		OwnerEmail:    owner.Email,
+		OwnerPassword: password,
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for i := 0; i < opts.Calendars; i++ {
+		tmpl := calendarTemplates[i%len(calendarTemplates)]
+		if err := s.seedCalendar(ctx, rng, owner, tmpl, i, opts, today, result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// ensureOwner returns the owner account, creating it with a random password if missing
+func (s *Seeder) ensureOwner(ctx context.Context, email string) (*authModels.User, string, error) {
+	user, err := s.users.GetByEmail(ctx, email)
+	if err == nil {
+		return user, "", nil
+	}
+	if !errors.Is(err, authRepo.ErrUserNotFound) {
+		return nil, "", err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, "", err
+	}
+	password := "Demo-" + token[:12]
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.bcryptCost)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user = &authModels.User{
+		Email:         email,
+		PasswordHash:  string(hash),
+		DisplayName:   "Demo",
+		Role:          authModels.RoleUser,
+		Locale:        authModels.LocaleEN,
+		Timezone:      "Europe/Paris",
+		EmailVerified: true,
+	}
+	user.ID = uuid.New()
+
+	if err := s.users.Create(ctx, user); err != nil {
+		return nil, "", err
+	}
+
+	return user, password, nil
+}
+
+// seedCalendar creates one calendar with its participants, availabilities and recurrences
+func (s *Seeder) seedCalendar(
+	ctx context.Context,
+	rng *mathrand.Rand,
+	owner *authModels.User,
+	tmpl calendarTemplate,
+	index int,
+	opts Options,
+	today time.Time,
+	result *Result,
+) error {
+	publicToken, err := generateToken()
+	if err != nil {
+		return err
+	}
+	icsToken, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	name := tmpl.Name
+	if index >= len(calendarTemplates) {
+		name = fmt.Sprintf("%s #%d", tmpl.Name, index/len(calendarTemplates)+1)
+	}
+
+	threshold := opts.Participants/2 + 1
+	if threshold > opts.Participants {
+		threshold = opts.Participants
+	}
+
+	calendar := &calendarModels.Calendar{
+		OwnerID:          owner.ID,
+		Name:             name,
+		Description:      tmpl.Description,
+		PublicToken:      publicToken,
+		ICSToken:         icsToken,
+		Threshold:        threshold,
+		AllowedWeekdays:  tmpl.Weekdays,
+		MinDurationHours: 0,
+		Timezone:         owner.Timezone,
+		HolidaysPolicy:   "ignore",
+		HolidaySets:      []string{},
+		ReminderMinutes:  []int{},
+	}
+	calendar.ID = uuid.New()
+
+	participants, err := s.calendars.CreateWithParticipants(ctx, calendar, participantInputs(rng, opts.Participants, owner.Locale))
+	if err != nil {
+		return fmt.Errorf("failed to create calendar %q: %w", name, err)
+	}
+	result.Calendars = append(result.Calendars, calendar)
+	result.Participants += len(participants)
+
+	for _, participant := range participants {
+		// Each participant has their own level of commitment
+		eagerness := 0.2 + rng.Float64()*0.5
+
+		// About a third of participants use a weekly recurrence instead of picking dates
+		recurringDay := -1
+		if rng.Float64() < 0.33 {
+			recurringDay = tmpl.Weekdays[rng.IntN(len(tmpl.Weekdays))]
+			start, end := randomSlot(rng, tmpl)
+			recurrence := &availabilityModels.Recurrence{
+				ParticipantID: participant.ID,
+				Frequency:     availabilityModels.FrequencyWeekly,
+				IntervalWeeks: 1,
+				DayOfWeek:     &recurringDay,
+				StartTime:     start,
+				EndTime:       end,
+				StartDate:     today.Format("2006-01-02"),
+				CreatedAt:     time.Now(),
+			}
+			recurrence.ID = uuid.New()
+			if _, err := s.recurrences.CreateRecurrence(ctx, calendar.ID, recurrence); err != nil {
+				return err
+			}
+			result.Recurrences++
+		}
+
+		for day := 0; day < opts.Weeks*7; day++ {
+			date := today.AddDate(0, 0, day)
+			weekday := int(date.Weekday())
+			if weekday == recurringDay || !containsInt(tmpl.Weekdays, weekday) || rng.Float64() > eagerness {
+				continue
+			}
+
+			start, end := randomSlot(rng, tmpl)
+			availability := &availabilityModels.Availability{
+				ParticipantID: participant.ID,
+				Date:          date,
+				StartTime:     start,
+				EndTime:       end,
+				Source:        "manual",
+			}
+			if tmpl.Note != "" && rng.Float64() < 0.1 {
+				availability.Note = tmpl.Note
+			}
+			availability.ID = uuid.New()
+
+			if err := s.availabilities.Create(ctx, availability); err != nil {
+				return err
+			}
+			result.Availabilities++
+		}
+	}
+
+	return nil
+}
+
+// participantInputs picks distinct participant names, numbering them when the list runs out
+func participantInputs(rng *mathrand.Rand, count int, locale string) []calendarRepo.ParticipantInput {
+	names := make([]string, len(participantNames))
+	copy(names, participantNames)
+	rng.Shuffle(len(names), func(i, j int) { names[i], names[j] = names[j], names[i] })
+
+	inputs := make([]calendarRepo.ParticipantInput, count)
+	for i := range inputs {
+		name := names[i%len(names)]
+		if i >= len(names) {
+			name = fmt.Sprintf("%s %d", name, i/len(names)+1)
+		}
+		inputs[i] = calendarRepo.ParticipantInput{Name: name, Locale: locale}
+	}
+	return inputs
+}
+
+// randomSlot returns a time slot within the template hours, or nil times (all day) 30% of the time
+// Slots start on the half hour and last between 1 and 4 hours
+func randomSlot(rng *mathrand.Rand, tmpl calendarTemplate) (*string, *string) {
+	if rng.Float64() < 0.3 {
+		return nil, nil
+	}
+
+	duration := 60 + rng.IntN(7)*30
+	latestStart := tmpl.LatestMin - duration
+	if latestStart < tmpl.EarliestMin {
+		latestStart = tmpl.EarliestMin
+	}
+	start := tmpl.EarliestMin + rng.IntN((latestStart-tmpl.EarliestMin)/30+1)*30
+	end := start + duration
+	if end > tmpl.LatestMin {
+		end = tmpl.LatestMin
+	}
+
+	startTime := formatMinutes(start)
+	endTime := formatMinutes(end)
+	return &startTime, &endTime
+}
+
+// formatMinutes formats minutes since midnight as "HH:MM"
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// generateToken generates a random 64-character hex token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,53 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package seed
+
+import (
+	mathrand "math/rand/v2"
+	"testing"
+)
+
+func TestRandomSlot(t *testing.T) {
+	rng := mathrand.New(mathrand.NewPCG(1, 1))
+
+	for _, tmpl := range calendarTemplates {
+		earliest := formatMinutes(tmpl.EarliestMin)
+		latest := formatMinutes(tmpl.LatestMin)
+
+		for i := 0; i < 200; i++ {
+			start, end := randomSlot(rng, tmpl)
+			if (start == nil) != (end == nil) {
+				t.Fatalf("%s: expected both times or none, got %v-%v", tmpl.Name, start, end)
+			}
+			if start == nil {
+				continue
+			}
+			if *start < earliest || *end > latest || *start >= *end {
+				t.Errorf("%s: slot %s-%s outside %s-%s", tmpl.Name, *start, *end, earliest, latest)
+			}
+		}
+	}
+}
+
+func TestParticipantInputs(t *testing.T) {
+	rng := mathrand.New(mathrand.NewPCG(1, 1))
+
+	count := len(participantNames)*2 + 3
+	inputs := participantInputs(rng, count, "en")
+	if len(inputs) != count {
+		t.Fatalf("Expected %d participants, got %d", count, len(inputs))
+	}
+
+	seen := make(map[string]bool, count)
+	for _, input := range inputs {
+		if seen[input.Name] {
+			t.Errorf("Duplicate participant name %q", input.Name)
+		}
+		seen[input.Name] = true
+		if input.Locale != "en" {
+			t.Errorf("Expected locale en, got %q", input.Locale)
+		}
+	}
+}
@@ -15,13 +15,15 @@ type Handler struct {
 	appURL        string
 	disableRobots bool
 	buildType     string // "cloud" or "selfhosted"
+	productName   string // Branded product name (defaults to "WhenTo")
 }
 
-func NewHandler(appURL string, disableRobots bool, buildType string) *Handler {
+func NewHandler(appURL string, disableRobots bool, buildType string, productName string) *Handler {
 	return &Handler{
 		appURL:        appURL,
 		disableRobots: disableRobots,
 		buildType:     buildType,
+		productName:   productName,
 	}
 }
 
@@ -36,7 +38,7 @@ func NewHandler(appURL string, disableRobots bool, buildType string) *Handler {
 func (h *Handler) HandleRobotsTxt(w http.ResponseWriter, r *http.Request) {
 	var content strings.Builder
 
-	content.WriteString("# WhenTo - Robots.txt\n")
+	content.WriteString("# " + h.productName + " - Robots.txt\n")
 	content.WriteString("# Generated dynamically based on deployment configuration\n\n")
 
 	// SEO only enabled for cloud builds, unless explicitly disabled
@@ -0,0 +1,63 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package seo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/whento/whento/internal/ics/models"
+)
+
+// CalendarPreview is the public summary of a shared calendar, shown when its link unfurls in chat apps
+type CalendarPreview struct {
+	Name        string
+	Description string
+	NextEvent   string // Date of the next confirmed event, e.g. "Saturday, June 14, 2025 at 18:00", empty if none
+}
+
+// PreviewReader reads the public summary of a calendar from its public token
+type PreviewReader interface {
+	GetPreview(ctx context.Context, publicToken string, now time.Time) (*models.Preview, error)
+}
+
+// PreviewService builds the link previews of shared calendars
+type PreviewService struct {
+	previews PreviewReader
+	now      func() time.Time
+}
+
+// NewPreviewService creates a new preview service
+func NewPreviewService(previews PreviewReader) *PreviewService {
+	return &PreviewService{previews: previews, now: time.Now}
+}
+
+// CalendarPreview returns the preview of the calendar shared with the given public token
+// Calendars not found or whose owner is over quota return an error, callers fall back to generic meta tags
+func (s *PreviewService) CalendarPreview(ctx context.Context, publicToken string) (*CalendarPreview, error) {
+	calendar, err := s.previews.GetPreview(ctx, publicToken, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &CalendarPreview{
+		Name:        calendar.CalendarName,
+		Description: calendar.Description,
+	}
+	if calendar.NextEvent != nil {
+		preview.NextEvent = formatEvent(calendar.NextEvent)
+	}
+	return preview, nil
+}
+
+// formatEvent formats the date (and start time unless all-day) of an event in the calendar timezone
+func formatEvent(event *models.SensorEvent) string {
+	if event.AllDay {
+		return event.Start.Format("Monday, January 2, 2006")
+	}
+	return fmt.Sprintf("%s at %s", event.Start.Format("Monday, January 2, 2006"), event.Start.Format("15:04"))
+}
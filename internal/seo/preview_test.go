@@ -0,0 +1,66 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package seo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/whento/whento/internal/ics/models"
+)
+
+type fakePreviewReader struct {
+	preview *models.Preview
+	err     error
+}
+
+func (f *fakePreviewReader) GetPreview(ctx context.Context, publicToken string, now time.Time) (*models.Preview, error) {
+	return f.preview, f.err
+}
+
+func TestPreviewService_CalendarPreview(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+
+	tests := []struct {
+		name      string
+		event     *models.SensorEvent
+		nextEvent string
+	}{
+		{"no event", nil, ""},
+		{"all-day event", &models.SensorEvent{Start: time.Date(2025, 6, 14, 0, 0, 0, 0, paris), AllDay: true}, "Saturday, June 14, 2025"},
+		{"timed event", &models.SensorEvent{Start: time.Date(2025, 6, 14, 18, 30, 0, 0, paris)}, "Saturday, June 14, 2025 at 18:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewPreviewService(&fakePreviewReader{preview: &models.Preview{
+				CalendarName: "Board games",
+				Description:  "Every other Saturday",
+				NextEvent:    tt.event,
+			}})
+
+			preview, err := service.CalendarPreview(context.Background(), "token")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if preview.Name != "Board games" || preview.Description != "Every other Saturday" {
+				t.Errorf("preview = %+v, want calendar name and description", preview)
+			}
+			if preview.NextEvent != tt.nextEvent {
+				t.Errorf("NextEvent = %q, want %q", preview.NextEvent, tt.nextEvent)
+			}
+		})
+	}
+
+	t.Run("calendar not found", func(t *testing.T) {
+		service := NewPreviewService(&fakePreviewReader{err: errors.New("calendar not found")})
+		if _, err := service.CalendarPreview(context.Background(), "token"); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
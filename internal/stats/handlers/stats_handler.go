@@ -0,0 +1,55 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/whento/internal/stats/models"
+	"github.com/whento/whento/internal/stats/service"
+)
+
+// StatsHandler handles HTTP requests for the instance statistics
+type StatsHandler struct {
+	service *service.StatsService
+	logger  *slog.Logger
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(service *service.StatsService, logger *slog.Logger) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		Get instance statistics
+// @Description	Counts users, calendars, participants, availabilities and active ICS feeds (fetched within the last 30 days), with the accounts and calendars created and the notifications sent per channel, day and week (starting on WEEK_START) over the period. Admin only.
+// @Tags			Admin
+// @Produce		json
+// @Security		BearerAuth
+// @Param			days	query		int		false	"Period in days, today included (default 30, max 365)"
+// @Success		200		{object}	models.InstanceStats
+// @Failure		401		{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403		{object}	httputil.ErrorResponse	"Forbidden (requires admin role)"
+// @Router			/api/v1/auth/admin/stats [get]
+func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+
+	var stats *models.InstanceStats
+	stats, err := h.service.GetStats(r.Context(), days, time.Now())
+	if err != nil {
+		h.logger.Error("Failed to get instance statistics", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, "Failed to get statistics")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, stats)
+}
@@ -0,0 +1,88 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"time"
+)
+
+// InstanceStats summarizes the usage of the instance, for administrators monitoring its growth
+type InstanceStats struct {
+	Days           int               `json:"days" example:"30"` // Period of the "new" counts and of the notification volume
+	Users          UserStats         `json:"users"`
+	Calendars      CalendarStats     `json:"calendars"`
+	Participants   int64             `json:"participants"`
+	Availabilities AvailabilityStats `json:"availabilities"`
+	ICSFeeds       ICSFeedStats      `json:"ics_feeds"`
+	Notifications  NotificationStats `json:"notifications"`
+	GeneratedAt    time.Time         `json:"generated_at"`
+}
+
+// UserStats counts the accounts
+type UserStats struct {
+	Total    int64 `json:"total"`
+	Verified int64 `json:"verified"` // Email address verified
+	Admins   int64 `json:"admins"`
+	New      int64 `json:"new"` // Created within the period
+}
+
+// CalendarStats counts the calendars
+type CalendarStats struct {
+	Total        int64 `json:"total"`
+	Organization int64 `json:"organization"` // Owned by an organization
+	New          int64 `json:"new"`          // Created within the period
+}
+
+// AvailabilityStats counts the single-day availabilities
+type AvailabilityStats struct {
+	Total    int64 `json:"total"`
+	Upcoming int64 `json:"upcoming"` // Today or later
+}
+
+// ICSFeedStats counts the feeds calendar clients are subscribed to
+type ICSFeedStats struct {
+	Active     int64 `json:"active"` // Fetched (ICS or CalDAV) within ActiveDays
+	ActiveDays int   `json:"active_days" example:"30"`
+}
+
+// NotificationStats is the volume of notifications sent within the period
+// Older notifications may have been purged by the retention janitor (RETENTION_LOG_DAYS)
+type NotificationStats struct {
+	Total     int64            `json:"total"`
+	ByChannel map[string]int64 `json:"by_channel"`                       // email, discord, slack, telegram, mqtt, rocketchat, mattermost, webhook, push, apprise
+	Daily     []DailyCount     `json:"daily"`                            // One entry per day (UTC) of the period, oldest first
+	WeekStart string           `json:"week_start" enums:"sunday,monday"` // First day of the weekly buckets (WEEK_START)
+	Weekly    []WeeklyCount    `json:"weekly"`                           // One entry per week overlapping the period, oldest first
+}
+
+// WeeklyCount is a number of notifications sent in a week
+// The first and last weeks only count the days within the period
+type WeeklyCount struct {
+	WeekStart string `json:"week_start" example:"2025-06-09"` // First day of the week
+	Count     int64  `json:"count"`
+}
+
+// DailyCount is a number of notifications sent on a day
+type DailyCount struct {
+	Date  string `json:"date" example:"2025-06-14"`
+	Count int64  `json:"count"`
+}
+
+// Counts are the totals read from the database
+type Counts struct {
+	Users          UserStats
+	Calendars      CalendarStats
+	Participants   int64
+	Availabilities AvailabilityStats
+	ActiveFeeds    int64
+}
+
+// ChannelDayCount is a number of notifications sent on a channel on a day
+type ChannelDayCount struct {
+	Date    time.Time
+	Channel string
+	Count   int64
+}
@@ -0,0 +1,89 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/stats/models"
+)
+
+// StatsRepository reads the usage statistics of the instance
+type StatsRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewStatsRepository creates a new stats repository
+func NewStatsRepository(pool *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{pool: pool}
+}
+
+// GetCounts counts the users, calendars, participants and availabilities, those created since a date,
+// and the ICS feeds fetched since another date
+func (r *StatsRepository) GetCounts(ctx context.Context, since, feedsSince time.Time) (*models.Counts, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM users WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM users WHERE email_verified AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM users WHERE role = 'admin' AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM users WHERE created_at >= $1 AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM calendars),
+			(SELECT COUNT(*) FROM calendars WHERE organization_id IS NOT NULL),
+			(SELECT COUNT(*) FROM calendars WHERE created_at >= $1),
+			(SELECT COUNT(*) FROM participants),
+			(SELECT COUNT(*) FROM availabilities),
+			(SELECT COUNT(*) FROM availabilities WHERE date >= CURRENT_DATE),
+			(SELECT COUNT(*) FROM calendars WHERE ics_fetched_at >= $2)`
+
+	var c models.Counts
+	err := r.pool.QueryRow(ctx, query, since, feedsSince).Scan(
+		&c.Users.Total,
+		&c.Users.Verified,
+		&c.Users.Admins,
+		&c.Users.New,
+		&c.Calendars.Total,
+		&c.Calendars.Organization,
+		&c.Calendars.New,
+		&c.Participants,
+		&c.Availabilities.Total,
+		&c.Availabilities.Upcoming,
+		&c.ActiveFeeds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count: %w", err)
+	}
+	return &c, nil
+}
+
+// GetNotificationsPerDay counts the notifications sent since a date, per day (UTC) and channel
+func (r *StatsRepository) GetNotificationsPerDay(ctx context.Context, since time.Time) ([]models.ChannelDayCount, error) {
+	query := `
+		SELECT (sent_at AT TIME ZONE 'UTC')::date AS day, channel, COUNT(*)
+		FROM notification_log
+		WHERE sent_at >= $1
+		GROUP BY day, channel
+		ORDER BY day, channel`
+
+	rows, err := r.pool.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []models.ChannelDayCount
+	for rows.Next() {
+		var c models.ChannelDayCount
+		if err := rows.Scan(&c.Date, &c.Channel, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan notification count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,106 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"time"
+
+	pkgModels "github.com/whento/pkg/models"
+	"github.com/whento/whento/internal/stats/models"
+)
+
+// Period limits, in days
+const (
+	DefaultDays = 30
+	MaxDays     = 365
+
+	// ActiveFeedDays is how recently a feed must have been fetched to count as active
+	ActiveFeedDays = 30
+)
+
+// StatsRepository defines the interface for stats repository operations
+type StatsRepository interface {
+	GetCounts(ctx context.Context, since, feedsSince time.Time) (*models.Counts, error)
+	GetNotificationsPerDay(ctx context.Context, since time.Time) ([]models.ChannelDayCount, error)
+}
+
+// StatsService computes the usage statistics of the instance
+type StatsService struct {
+	repo      StatsRepository
+	weekStart pkgModels.WeekStart
+}
+
+// NewStatsService creates a new stats service, grouping weeks from the instance week start
+func NewStatsService(repo StatsRepository, weekStart string) *StatsService {
+	return &StatsService{repo: repo, weekStart: pkgModels.ResolveWeekStart(weekStart)}
+}
+
+// GetStats returns the statistics of the instance over the last days (0 = default), today included
+func (s *StatsService) GetStats(ctx context.Context, days int, now time.Time) (*models.InstanceStats, error) {
+	switch {
+	case days <= 0:
+		days = DefaultDays
+	case days > MaxDays:
+		days = MaxDays
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	counts, err := s.repo.GetCounts(ctx, since, now.AddDate(0, 0, -ActiveFeedDays))
+	if err != nil {
+		return nil, err
+	}
+	perDay, err := s.repo.GetNotificationsPerDay(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.InstanceStats{
+		Days:           days,
+		Users:          counts.Users,
+		Calendars:      counts.Calendars,
+		Participants:   counts.Participants,
+		Availabilities: counts.Availabilities,
+		ICSFeeds:       models.ICSFeedStats{Active: counts.ActiveFeeds, ActiveDays: ActiveFeedDays},
+		Notifications:  notificationStats(perDay, since, days, s.weekStart),
+		GeneratedAt:    now.UTC(),
+	}, nil
+}
+
+// notificationStats sums the notification counts per channel, per day and per week, days without notifications included
+func notificationStats(perDay []models.ChannelDayCount, since time.Time, days int, weekStart pkgModels.WeekStart) models.NotificationStats {
+	stats := models.NotificationStats{
+		ByChannel: map[string]int64{},
+		Daily:     make([]models.DailyCount, days),
+		WeekStart: weekStart.String(),
+	}
+	for i := range stats.Daily {
+		stats.Daily[i].Date = since.AddDate(0, 0, i).Format(time.DateOnly)
+	}
+
+	for _, c := range perDay {
+		stats.Total += c.Count
+		stats.ByChannel[c.Channel] += c.Count
+
+		i := int(c.Date.Sub(since).Hours() / 24)
+		if i >= 0 && i < days {
+			stats.Daily[i].Count += c.Count
+		}
+	}
+
+	// Days are grouped by the week they belong to, the first one possibly starting before the period
+	firstWeek := weekStart.StartOfWeek(since)
+	for i, day := range stats.Daily {
+		week := (i + int(since.Sub(firstWeek).Hours()/24)) / 7
+		if week == len(stats.Weekly) {
+			stats.Weekly = append(stats.Weekly, models.WeeklyCount{WeekStart: firstWeek.AddDate(0, 0, 7*week).Format(time.DateOnly)})
+		}
+		stats.Weekly[week].Count += day.Count
+	}
+	return stats
+}
@@ -0,0 +1,135 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pkgModels "github.com/whento/pkg/models"
+	"github.com/whento/whento/internal/stats/models"
+)
+
+type mockStatsRepository struct {
+	since      time.Time
+	feedsSince time.Time
+	perDay     []models.ChannelDayCount
+}
+
+func (m *mockStatsRepository) GetCounts(ctx context.Context, since, feedsSince time.Time) (*models.Counts, error) {
+	m.since, m.feedsSince = since, feedsSince
+	return &models.Counts{ActiveFeeds: 4}, nil
+}
+
+func (m *mockStatsRepository) GetNotificationsPerDay(ctx context.Context, since time.Time) ([]models.ChannelDayCount, error) {
+	return m.perDay, nil
+}
+
+func TestStatsService_GetStats(t *testing.T) {
+	now := time.Date(2025, 6, 14, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		days      int
+		wantDays  int
+		wantSince string
+	}{
+		{"default period", 0, DefaultDays, "2025-05-16"},
+		{"custom period", 7, 7, "2025-06-08"},
+		{"clamped period", 1000, MaxDays, "2024-06-15"},
+		{"today only", 1, 1, "2025-06-14"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockStatsRepository{}
+			stats, err := NewStatsService(repo, "monday").GetStats(context.Background(), tt.days, now)
+			if err != nil {
+				t.Fatalf("GetStats() error = %v", err)
+			}
+			if stats.Days != tt.wantDays || len(stats.Notifications.Daily) != tt.wantDays {
+				t.Errorf("days = %d (%d daily counts), want %d", stats.Days, len(stats.Notifications.Daily), tt.wantDays)
+			}
+			if got := repo.since.Format(time.DateOnly); got != tt.wantSince {
+				t.Errorf("since = %s, want %s", got, tt.wantSince)
+			}
+			if got := stats.Notifications.Daily[tt.wantDays-1].Date; got != "2025-06-14" {
+				t.Errorf("last day = %s, want today", got)
+			}
+			if !repo.feedsSince.Equal(now.AddDate(0, 0, -ActiveFeedDays)) || stats.ICSFeeds.Active != 4 {
+				t.Errorf("active feeds = %d since %v", stats.ICSFeeds.Active, repo.feedsSince)
+			}
+		})
+	}
+}
+
+func TestNotificationStats(t *testing.T) {
+	since := time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+	perDay := []models.ChannelDayCount{
+		{Date: since, Channel: "email", Count: 3},
+		{Date: since, Channel: "discord", Count: 1},
+		{Date: since.AddDate(0, 0, 2), Channel: "email", Count: 2},
+	}
+
+	stats := notificationStats(perDay, since, 3, pkgModels.WeekStartMonday)
+
+	if stats.Total != 6 {
+		t.Errorf("total = %d, want 6", stats.Total)
+	}
+	if stats.ByChannel["email"] != 5 || stats.ByChannel["discord"] != 1 {
+		t.Errorf("by channel = %v", stats.ByChannel)
+	}
+	want := []models.DailyCount{{Date: "2025-06-12", Count: 4}, {Date: "2025-06-13", Count: 0}, {Date: "2025-06-14", Count: 2}}
+	for i, day := range want {
+		if stats.Daily[i] != day {
+			t.Errorf("daily[%d] = %+v, want %+v", i, stats.Daily[i], day)
+		}
+	}
+}
+
+func TestNotificationStats_Weekly(t *testing.T) {
+	// Thursday 2025-06-12 to Thursday 2025-06-19
+	since := time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+	perDay := []models.ChannelDayCount{
+		{Date: since, Channel: "email", Count: 1},                  // Thursday
+		{Date: since.AddDate(0, 0, 3), Channel: "email", Count: 2}, // Sunday
+		{Date: since.AddDate(0, 0, 4), Channel: "slack", Count: 4}, // Monday
+		{Date: since.AddDate(0, 0, 7), Channel: "email", Count: 8}, // Thursday
+	}
+
+	tests := []struct {
+		name      string
+		weekStart pkgModels.WeekStart
+		want      []models.WeeklyCount
+	}{
+		{"monday", pkgModels.WeekStartMonday, []models.WeeklyCount{
+			{WeekStart: "2025-06-09", Count: 3},
+			{WeekStart: "2025-06-16", Count: 12},
+		}},
+		{"sunday", pkgModels.WeekStartSunday, []models.WeeklyCount{
+			{WeekStart: "2025-06-08", Count: 1},
+			{WeekStart: "2025-06-15", Count: 14},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := notificationStats(perDay, since, 8, tt.weekStart)
+			if stats.WeekStart != tt.weekStart.String() {
+				t.Errorf("week start = %s, want %s", stats.WeekStart, tt.weekStart)
+			}
+			if len(stats.Weekly) != len(tt.want) {
+				t.Fatalf("weekly = %+v, want %+v", stats.Weekly, tt.want)
+			}
+			for i, week := range tt.want {
+				if stats.Weekly[i] != week {
+					t.Errorf("weekly[%d] = %+v, want %+v", i, stats.Weekly[i], week)
+				}
+			}
+		})
+	}
+}
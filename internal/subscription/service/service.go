@@ -42,6 +42,9 @@ type Service struct {
 	// Cached plan configs fetched from Stripe
 	planConfigsMu sync.RWMutex
 	planConfigs   map[models.SubscriptionPlan]models.PlanConfig
+
+	// Called with the user of every subscription created or updated, see OnChange
+	changeListeners []func(userID uuid.UUID)
 }
 
 // Config holds the configuration for the subscription service
@@ -76,6 +79,20 @@ func New(repo *repository.SubscriptionRepository, vatService *vatservice.Service
 	return s
 }
 
+// OnChange registers a function called with the user of every subscription created or updated,
+// for the caches derived from their plan
+// Listeners must be registered at startup, before the webhooks are served
+func (s *Service) OnChange(listener func(userID uuid.UUID)) {
+	s.changeListeners = append(s.changeListeners, listener)
+}
+
+// notifyChange calls the change listeners for the user of a subscription
+func (s *Service) notifyChange(userID uuid.UUID) {
+	for _, listener := range s.changeListeners {
+		listener(userID)
+	}
+}
+
 // GetPlanConfig returns the configuration for a plan (fetched from Stripe)
 func (s *Service) GetPlanConfig(plan models.SubscriptionPlan) models.PlanConfig {
 	s.planConfigsMu.RLock()
@@ -577,6 +594,7 @@ func (s *Service) updateExistingSubscription(ctx context.Context, userID uuid.UU
 	if err != nil {
 		return nil, fmt.Errorf("failed to update subscription in database: %w", err)
 	}
+	s.notifyChange(userID)
 
 	s.log.Info("Updated subscription with proration",
 		"user_id", userID,
@@ -651,6 +669,7 @@ func (s *Service) HandleCheckoutComplete(ctx context.Context, session *stripe.Ch
 	if err != nil {
 		return fmt.Errorf("failed to create subscription: %w", err)
 	}
+	s.notifyChange(userID)
 
 	s.log.Info("Subscription created", "user_id", userID, "plan", plan, "subscription_id", sub.ID)
 
@@ -696,6 +715,7 @@ func (s *Service) HandleSubscriptionUpdated(ctx context.Context, stripeSubscript
 	if err != nil {
 		return fmt.Errorf("failed to update subscription: %w", err)
 	}
+	s.notifyChange(sub.UserID)
 
 	s.log.Info("Subscription updated", "subscription_id", sub.ID, "status", sub.Status, "plan", sub.Plan)
 
@@ -725,6 +745,7 @@ func (s *Service) HandleSubscriptionDeleted(ctx context.Context, stripeSubscript
 	if err != nil {
 		return fmt.Errorf("failed to update subscription: %w", err)
 	}
+	s.notifyChange(sub.UserID)
 
 	s.log.Info("Subscription canceled", "subscription_id", sub.ID)
 
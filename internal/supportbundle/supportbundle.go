@@ -0,0 +1,277 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+// Package supportbundle builds a zip archive describing an instance, to attach to bug reports.
+//
+// The bundle holds the version, the configuration with secrets redacted, the configuration check
+// report, database statistics (row counts only, no data) and the tail of a log file with emails,
+// IP addresses and tokens masked. Anonymization is best effort: users should review it before sharing.
+package supportbundle
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const redacted = "[redacted]"
+
+// Bundle is the content of a support bundle
+type Bundle struct {
+	Version  VersionInfo
+	Config   map[string]any
+	Checks   string         // Configuration check report (whento --validate-config output)
+	Database *DatabaseStats // nil if the database couldn't be reached
+	Logs     []string       // Anonymized log lines
+	Errors   []string       // Parts of the bundle that couldn't be collected
+}
+
+// VersionInfo describes the binary and the host
+type VersionInfo struct {
+	Version     string    `json:"version"`
+	BuildType   string    `json:"build_type"`
+	Revision    string    `json:"revision,omitempty"`
+	GoVersion   string    `json:"go_version"`
+	OS          string    `json:"os"`
+	Arch        string    `json:"arch"`
+	CPUs        int       `json:"cpus"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// DatabaseStats describes the database without exposing its content
+type DatabaseStats struct {
+	ServerVersion string       `json:"server_version"`
+	SchemaVersion int          `json:"schema_version"`
+	Dirty         bool         `json:"dirty"`
+	SizeBytes     int64        `json:"size_bytes"`
+	Tables        []TableStats `json:"tables"`
+}
+
+// TableStats is the row count of a table (-1 if the table doesn't exist)
+type TableStats struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// NewVersionInfo describes the running binary
+func NewVersionInfo(version, buildType string) VersionInfo {
+	info := VersionInfo{
+		Version:     version,
+		BuildType:   buildType,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		CPUs:        runtime.NumCPU(),
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			if setting.Key == "vcs.revision" {
+				info.Revision = setting.Value
+			}
+		}
+	}
+
+	return info
+}
+
+// RedactConfig flattens a configuration struct into a map keyed by field path (e.g. "Email.SMTPHost")
+// Secrets are replaced with "[redacted]" when set and credentials are removed from URLs
+func RedactConfig(cfg any) map[string]any {
+	out := make(map[string]any)
+	redactValue(reflect.Indirect(reflect.ValueOf(cfg)), "", out)
+	return out
+}
+
+func redactValue(v reflect.Value, prefix string, out map[string]any) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := v.Field(i)
+		name := prefix + field.Name
+
+		switch {
+		case value.Kind() == reflect.Struct && value.Type() != reflect.TypeOf(time.Time{}):
+			redactValue(value, name+".", out)
+		case isSecret(field.Name):
+			if value.IsZero() {
+				out[name] = ""
+			} else {
+				out[name] = redacted
+			}
+		case value.Type() == reflect.TypeOf(time.Duration(0)):
+			out[name] = value.Interface().(time.Duration).String()
+		case value.Kind() == reflect.String && strings.HasSuffix(field.Name, "URL"):
+			out[name] = redactURL(value.String())
+		default:
+			out[name] = value.Interface()
+		}
+	}
+}
+
+// isSecret reports whether a configuration field holds a secret or personal data
+func isSecret(name string) bool {
+	for _, part := range []string{"Password", "Secret", "Username", "Base64", "AllowedEmails"} {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return name == "Key" // License key
+}
+
+// redactURL removes the password of a URL (the whole URL if it can't be parsed)
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
+
+var anonymizers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`), "<jwt>"},
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "<email>"},
+	{regexp.MustCompile(`\b[0-9a-fA-F]{64}\b`), "<token>"},             // Calendar, ICS and verification tokens
+	{regexp.MustCompile(`\d{6,12}:[A-Za-z0-9_-]{30,}`), "<bot-token>"}, // Telegram bot tokens
+	{regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`), "<ip>"},
+	{regexp.MustCompile(`\b(?:[0-9a-fA-F]{1,4}:){7}[0-9a-fA-F]{1,4}\b`), "<ip>"},
+}
+
+// AnonymizeLine masks emails, IP addresses and tokens in a log line
+func AnonymizeLine(line string) string {
+	for _, a := range anonymizers {
+		line = a.pattern.ReplaceAllString(line, a.replacement)
+	}
+	return line
+}
+
+// ReadLogTail returns the last n lines of a log file, anonymized
+func ReadLogTail(path string, n int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return tail(f, n)
+}
+
+func tail(r io.Reader, n int) ([]string, error) {
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, AnonymizeLine(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// CollectDatabaseStats reads the server version, database size and row counts of the given tables
+func CollectDatabaseStats(ctx context.Context, pool *pgxpool.Pool, tables []string) (*DatabaseStats, error) {
+	stats := &DatabaseStats{}
+
+	if err := pool.QueryRow(ctx, "SHOW server_version").Scan(&stats.ServerVersion); err != nil {
+		return nil, fmt.Errorf("failed to read server version: %w", err)
+	}
+	if err := pool.QueryRow(ctx, "SELECT pg_database_size(current_database())").Scan(&stats.SizeBytes); err != nil {
+		return nil, fmt.Errorf("failed to read database size: %w", err)
+	}
+
+	for _, table := range tables {
+		var exists bool
+		if err := pool.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to check table %s: %w", table, err)
+		}
+
+		rows := int64(-1)
+		if exists {
+			query := "SELECT count(*) FROM " + pgx.Identifier{table}.Sanitize()
+			if err := pool.QueryRow(ctx, query).Scan(&rows); err != nil {
+				return nil, fmt.Errorf("failed to count rows of %s: %w", table, err)
+			}
+		}
+		stats.Tables = append(stats.Tables, TableStats{Name: table, Rows: rows})
+	}
+
+	return stats, nil
+}
+
+// Write writes the bundle to w as a zip archive
+// Empty parts (no database, no logs, no errors) are left out
+func (b *Bundle) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	if err := writeJSON(zw, "version.json", b.Version); err != nil {
+		return err
+	}
+	if err := writeJSON(zw, "config.json", b.Config); err != nil {
+		return err
+	}
+	if b.Database != nil {
+		if err := writeJSON(zw, "database.json", b.Database); err != nil {
+			return err
+		}
+	}
+	if err := writeText(zw, "checks.txt", b.Checks); err != nil {
+		return err
+	}
+	if err := writeText(zw, "logs.txt", strings.Join(b.Logs, "\n")); err != nil {
+		return err
+	}
+	if err := writeText(zw, "errors.txt", strings.Join(b.Errors, "\n")); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return writeText(zw, name, string(data))
+}
+
+func writeText(zw *zip.Writer, name, content string) error {
+	if content == "" {
+		return nil
+	}
+
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, strings.TrimRight(content, "\n")+"\n")
+	return err
+}
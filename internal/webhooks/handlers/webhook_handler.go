@@ -0,0 +1,334 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	"github.com/whento/pkg/middleware"
+	"github.com/whento/pkg/validator"
+	"github.com/whento/whento/internal/webhooks/models"
+	"github.com/whento/whento/internal/webhooks/service"
+)
+
+// WebhookHandler handles outbound webhook HTTP requests
+type WebhookHandler struct {
+	service *service.WebhookService
+	logger  *slog.Logger
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(service *service.WebhookService, logger *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary		Create a webhook
+// @Description	Registers a URL receiving a signed POST for each event of the calendar (owner only). Omit events to receive all of them: threshold.reached, threshold.lost, availability.created, availability.updated, availability.deleted, participant.added, participant.removed and calendar.updated. The X-WhenTo-Signature header holds "t=<timestamp>,v1=<signature>", the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret, which is only returned here and on rotation. Failed deliveries are retried after 1 minute, 5 minutes, 30 minutes, 2 hours and 6 hours; answering 410 Gone disables the webhook.
+// @Tags			Webhooks
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string						true	"Calendar ID"
+// @Param			request	body		models.CreateWebhookRequest	true	"Webhook"
+// @Success		201		{object}	models.WebhookResponse		"Webhook created, with its secret"
+// @Failure		400		{object}	httputil.ErrorResponse		"Invalid request, URL or event"
+// @Failure		401		{object}	httputil.ErrorResponse		"Unauthorized"
+// @Failure		403		{object}	httputil.ErrorResponse		"Calendar belongs to another user"
+// @Failure		404		{object}	httputil.ErrorResponse		"Calendar not found"
+// @Failure		409		{object}	httputil.ErrorResponse		"Too many webhooks"
+// @Failure		500		{object}	httputil.ErrorResponse		"Internal server error"
+// @Router			/api/v1/calendars/{id}/webhooks [post]
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userUUID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateWebhookRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.service.Create(r.Context(), userUUID, calendarID, &req)
+	if err != nil {
+		h.error(w, err, "Failed to create webhook")
+		return
+	}
+
+	response := webhook.ToResponse()
+	response.Secret = webhook.Secret
+	httputil.JSON(w, http.StatusCreated, response)
+}
+
+// @Summary		List webhooks
+// @Description	Lists the webhooks of a calendar (owner only). Secrets are not returned.
+// @Tags			Webhooks
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"Calendar ID"
+// @Success		200	{array}		models.WebhookResponse	"Webhooks"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Calendar belongs to another user"
+// @Failure		404	{object}	httputil.ErrorResponse	"Calendar not found"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/calendars/{id}/webhooks [get]
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	userUUID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.service.List(r.Context(), userUUID, calendarID)
+	if err != nil {
+		h.error(w, err, "Failed to list webhooks")
+		return
+	}
+
+	responses := make([]*models.WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		responses = append(responses, webhook.ToResponse())
+	}
+
+	httputil.JSON(w, http.StatusOK, responses)
+}
+
+// @Summary		Update a webhook
+// @Description	Updates the URL, subscribed events or active flag of a webhook (owner only)
+// @Tags			Webhooks
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string						true	"Calendar ID"
+// @Param			wid		path		string						true	"Webhook ID"
+// @Param			request	body		models.UpdateWebhookRequest	true	"Changes"
+// @Success		200		{object}	models.WebhookResponse		"Webhook updated"
+// @Failure		400		{object}	httputil.ErrorResponse		"Invalid request, URL or event"
+// @Failure		401		{object}	httputil.ErrorResponse		"Unauthorized"
+// @Failure		403		{object}	httputil.ErrorResponse		"Calendar belongs to another user"
+// @Failure		404		{object}	httputil.ErrorResponse		"Calendar or webhook not found"
+// @Failure		500		{object}	httputil.ErrorResponse		"Internal server error"
+// @Router			/api/v1/calendars/{id}/webhooks/{wid} [patch]
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userUUID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWebhookRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.service.Update(r.Context(), userUUID, calendarID, webhookID, &req)
+	if err != nil {
+		h.error(w, err, "Failed to update webhook")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, webhook.ToResponse())
+}
+
+// @Summary		Delete a webhook
+// @Description	Deletes a webhook and its delivery log (owner only)
+// @Tags			Webhooks
+// @Security		BearerAuth
+// @Param			id	path	string	true	"Calendar ID"
+// @Param			wid	path	string	true	"Webhook ID"
+// @Success		204	"Webhook deleted"
+// @Failure		400	{object}	httputil.ErrorResponse	"Invalid ID"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Calendar belongs to another user"
+// @Failure		404	{object}	httputil.ErrorResponse	"Calendar or webhook not found"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/calendars/{id}/webhooks/{wid} [delete]
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userUUID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), userUUID, calendarID, webhookID); err != nil {
+		h.error(w, err, "Failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Rotate a webhook secret
+// @Description	Replaces the signing secret of a webhook and returns it (owner only). Deliveries are signed with the new secret right away.
+// @Tags			Webhooks
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"Calendar ID"
+// @Param			wid	path		string	true	"Webhook ID"
+// @Success		200	{object}	models.WebhookResponse	"Webhook, with its new secret"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Calendar belongs to another user"
+// @Failure		404	{object}	httputil.ErrorResponse	"Calendar or webhook not found"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/calendars/{id}/webhooks/{wid}/rotate-secret [post]
+func (h *WebhookHandler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	userUUID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.service.RotateSecret(r.Context(), userUUID, calendarID, webhookID)
+	if err != nil {
+		h.error(w, err, "Failed to rotate webhook secret")
+		return
+	}
+
+	response := webhook.ToResponse()
+	response.Secret = webhook.Secret
+	httputil.JSON(w, http.StatusOK, response)
+}
+
+// @Summary		Send a test delivery
+// @Description	Queues a "ping" event to a webhook, even if it is disabled (owner only). Its outcome shows up in the delivery log.
+// @Tags			Webhooks
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"Calendar ID"
+// @Param			wid	path		string	true	"Webhook ID"
+// @Success		202	{object}	models.DeliveryResponse	"Delivery queued"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Calendar belongs to another user"
+// @Failure		404	{object}	httputil.ErrorResponse	"Calendar or webhook not found"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/calendars/{id}/webhooks/{wid}/ping [post]
+func (h *WebhookHandler) Ping(w http.ResponseWriter, r *http.Request) {
+	userUUID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.Ping(r.Context(), userUUID, calendarID, webhookID)
+	if err != nil {
+		h.error(w, err, "Failed to ping webhook")
+		return
+	}
+
+	httputil.JSON(w, http.StatusAccepted, delivery.ToResponse())
+}
+
+// @Summary		List webhook deliveries
+// @Description	Returns the latest deliveries of a webhook (up to 50, newest first) with the outcome of their last attempt (owner only)
+// @Tags			Webhooks
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"Calendar ID"
+// @Param			wid	path		string	true	"Webhook ID"
+// @Success		200	{array}		models.DeliveryResponse	"Deliveries"
+// @Failure		401	{object}	httputil.ErrorResponse	"Unauthorized"
+// @Failure		403	{object}	httputil.ErrorResponse	"Calendar belongs to another user"
+// @Failure		404	{object}	httputil.ErrorResponse	"Calendar or webhook not found"
+// @Failure		500	{object}	httputil.ErrorResponse	"Internal server error"
+// @Router			/api/v1/calendars/{id}/webhooks/{wid}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	userUUID, calendarID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), userUUID, calendarID, webhookID)
+	if err != nil {
+		h.error(w, err, "Failed to list webhook deliveries")
+		return
+	}
+
+	responses := make([]*models.DeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, delivery.ToResponse())
+	}
+
+	httputil.JSON(w, http.StatusOK, responses)
+}
+
+// error writes the error response of a service error
+func (h *WebhookHandler) error(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidURL), errors.Is(err, service.ErrUnknownEvent):
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, err.Error())
+	case errors.Is(err, service.ErrCalendarNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Calendar not found")
+	case errors.Is(err, service.ErrWebhookNotFound):
+		httputil.Error(w, http.StatusNotFound, httputil.ErrCodeNotFound, "Webhook not found")
+	case errors.Is(err, service.ErrNotOwner):
+		httputil.Error(w, http.StatusForbidden, httputil.ErrCodeForbidden, "You don't own this calendar")
+	case errors.Is(err, service.ErrTooManyWebhooks):
+		httputil.Error(w, http.StatusConflict, httputil.ErrCodeConflict, err.Error())
+	default:
+		h.logger.Error(message, "error", err)
+		httputil.Error(w, http.StatusInternalServerError, httputil.ErrCodeInternal, message)
+	}
+}
+
+// params returns the authenticated user ID and the calendar ID, writing an error response if invalid
+func (h *WebhookHandler) params(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userUUID, err := uuid.Parse(middleware.GetUserID(r.Context()))
+	if err != nil {
+		httputil.Error(w, http.StatusUnauthorized, httputil.ErrCodeUnauthorized, "Unauthorized")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	calendarID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid calendar ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userUUID, calendarID, true
+}
+
+// webhookID returns the webhook ID of the path, writing an error response if invalid
+func (h *WebhookHandler) webhookID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	webhookID, err := uuid.Parse(chi.URLParam(r, "wid"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, httputil.ErrCodeBadRequest, "Invalid webhook ID")
+		return uuid.Nil, false
+	}
+	return webhookID, true
+}
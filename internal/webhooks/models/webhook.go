@@ -0,0 +1,190 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types delivered to webhooks
+const (
+	EventThresholdReached     = "threshold.reached"
+	EventThresholdLost        = "threshold.lost"
+	EventSoftThresholdReached = "soft_threshold.reached"
+	EventSoftThresholdLost    = "soft_threshold.lost"
+	EventAvailabilityCreated  = "availability.created"
+	EventAvailabilityUpdated  = "availability.updated"
+	EventAvailabilityDeleted  = "availability.deleted"
+	EventParticipantAdded     = "participant.added"
+	EventParticipantRemoved   = "participant.removed"
+	EventCalendarUpdated      = "calendar.updated"
+	EventPing                 = "ping" // Test delivery, sent to every webhook
+)
+
+// Events lists the event types webhooks can subscribe to
+var Events = []string{
+	EventThresholdReached,
+	EventThresholdLost,
+	EventSoftThresholdReached,
+	EventSoftThresholdLost,
+	EventAvailabilityCreated,
+	EventAvailabilityUpdated,
+	EventAvailabilityDeleted,
+	EventParticipantAdded,
+	EventParticipantRemoved,
+	EventCalendarUpdated,
+}
+
+// Delivery statuses
+const (
+	StatusPending   = "pending"   // Waiting for its next attempt
+	StatusSucceeded = "succeeded" // Target answered with a 2xx status
+	StatusFailed    = "failed"    // All attempts failed
+)
+
+// Webhook is an outbound webhook of a calendar: events are POSTed to URL, signed with Secret
+type Webhook struct {
+	ID         uuid.UUID
+	CalendarID uuid.UUID
+	URL        string
+	Secret     string
+	Events     []string // Empty = all events
+	Active     bool
+	CreatedBy  *uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Subscribes reports whether the webhook receives an event type
+func (w *Webhook) Subscribes(event string) bool {
+	if event == EventPing || len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is the JSON body POSTed to webhooks
+type Payload struct {
+	ID         uuid.UUID       `json:"id"` // Unique per event, shared by the deliveries of the event
+	Event      string          `json:"event"`
+	CalendarID uuid.UUID       `json:"calendar_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// Delivery is a delivery of an event to a webhook, with the outcome of its last attempt
+type Delivery struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	Event          string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	ResponseStatus *int
+	Error          *string
+	NextAttemptAt  *time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// AvailabilityEventData is the data of availability.* events
+type AvailabilityEventData struct {
+	ParticipantID   uuid.UUID `json:"participant_id"`
+	ParticipantName string    `json:"participant_name"`
+	Date            string    `json:"date"` // YYYY-MM-DD
+	StartTime       *string   `json:"start_time,omitempty"`
+	EndTime         *string   `json:"end_time,omitempty"`
+	Note            string    `json:"note,omitempty"`
+	Status          string    `json:"status,omitempty"` // yes or maybe, unset for deletions
+	Preferred       bool      `json:"preferred,omitempty"`
+}
+
+// ParticipantEventData is the data of participant.* events
+type ParticipantEventData struct {
+	ParticipantID   uuid.UUID `json:"participant_id"`
+	ParticipantName string    `json:"participant_name"`
+}
+
+// CreateWebhookRequest represents a request to register a webhook
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2048"`
+	Events []string `json:"events,omitempty" validate:"omitempty,max=20"` // Omit to receive all events
+	Active *bool    `json:"active,omitempty"`                             // Defaults to true
+}
+
+// UpdateWebhookRequest represents a request to update a webhook (omitted fields are unchanged)
+type UpdateWebhookRequest struct {
+	URL    *string   `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Events *[]string `json:"events,omitempty" validate:"omitempty,max=20"` // Empty = all events
+	Active *bool     `json:"active,omitempty"`
+}
+
+// WebhookResponse is the API response for a webhook
+type WebhookResponse struct {
+	ID         string    `json:"id"`
+	CalendarID string    `json:"calendar_id"`
+	URL        string    `json:"url"`
+	Events     []string  `json:"events"`
+	Active     bool      `json:"active"`
+	Secret     string    `json:"secret,omitempty"` // Only returned on creation and rotation
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ToResponse converts a Webhook to WebhookResponse, without its secret
+func (w *Webhook) ToResponse() *WebhookResponse {
+	events := w.Events
+	if events == nil {
+		events = []string{}
+	}
+	return &WebhookResponse{
+		ID:         w.ID.String(),
+		CalendarID: w.CalendarID.String(),
+		URL:        w.URL,
+		Events:     events,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+// DeliveryResponse is the API response for a delivery of the log
+type DeliveryResponse struct {
+	ID             string          `json:"id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status" enums:"pending,succeeded,failed"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	Error          *string         `json:"error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+}
+
+// ToResponse converts a Delivery to DeliveryResponse
+func (d *Delivery) ToResponse() *DeliveryResponse {
+	return &DeliveryResponse{
+		ID:             d.ID.String(),
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.Error,
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+		Payload:        d.Payload,
+	}
+}
@@ -0,0 +1,251 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/whento/whento/internal/webhooks/models"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// DueDelivery is a pending delivery claimed by the worker, with the target of its webhook
+type DueDelivery struct {
+	models.Delivery
+	URL    string
+	Secret string
+	Active bool
+}
+
+// WebhookRepository handles outbound webhooks and their deliveries
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+const webhookColumns = `id, calendar_id, url, secret, events, active, created_by, created_at, updated_at`
+
+// Create creates a new webhook
+func (r *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	query := `
+		INSERT INTO webhooks (` + webhookColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.pool.Exec(ctx, query,
+		webhook.ID, webhook.CalendarID, webhook.URL, webhook.Secret, webhook.Events,
+		webhook.Active, webhook.CreatedBy, webhook.CreatedAt, webhook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns a webhook
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error) {
+	webhooks, err := r.query(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, ErrWebhookNotFound
+	}
+	return webhooks[0], nil
+}
+
+// ListByCalendar returns the webhooks of a calendar, oldest first
+func (r *WebhookRepository) ListByCalendar(ctx context.Context, calendarID uuid.UUID) ([]*models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE calendar_id = $1 ORDER BY created_at`
+	return r.query(ctx, query, calendarID)
+}
+
+// ListActiveByCalendar returns the active webhooks of a calendar
+func (r *WebhookRepository) ListActiveByCalendar(ctx context.Context, calendarID uuid.UUID) ([]*models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE calendar_id = $1 AND active`
+	return r.query(ctx, query, calendarID)
+}
+
+// CountByCalendar returns the number of webhooks of a calendar
+func (r *WebhookRepository) CountByCalendar(ctx context.Context, calendarID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM webhooks WHERE calendar_id = $1`, calendarID).Scan(&count)
+	return count, err
+}
+
+// Update updates the URL, events, active flag and secret of a webhook
+func (r *WebhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $2, events = $3, active = $4, secret = $5, updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, webhook.ID, webhook.URL, webhook.Events, webhook.Active, webhook.Secret, webhook.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Delete deletes a webhook and its deliveries
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Deactivate disables a webhook (target answered 410 Gone)
+func (r *WebhookRepository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `UPDATE webhooks SET active = FALSE, updated_at = NOW() WHERE id = $1`, id)
+	return err
+}
+
+// CreateDeliveries queues deliveries
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*models.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, webhook_id, event, payload, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	batch := &pgx.Batch{}
+	for _, d := range deliveries {
+		batch.Queue(query, d.ID, d.WebhookID, d.Event, d.Payload, d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt)
+	}
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue claims up to limit pending deliveries due at now
+// Claimed deliveries are postponed by lease, so that other instances skip them while they are sent
+// and the worker retries them if the instance stops before recording the attempt
+func (r *WebhookRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*DueDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries d
+			SET next_attempt_at = $2
+			FROM due
+			WHERE d.id = due.id
+			RETURNING d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.created_at
+		)
+		SELECT c.id, c.webhook_id, c.event, c.payload, c.status, c.attempts, c.created_at, w.url, w.secret, w.active
+		FROM claimed c
+		JOIN webhooks w ON w.id = c.webhook_id`
+
+	rows, err := r.pool.Query(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*DueDelivery
+	for rows.Next() {
+		var d DueDelivery
+		if err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.CreatedAt,
+			&d.URL, &d.Secret, &d.Active,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// RecordAttempt records the outcome of a delivery attempt
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, delivery *models.Delivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_status = $4, error = $5, next_attempt_at = $6, delivered_at = $7
+		WHERE id = $1`
+
+	_, err := r.pool.Exec(ctx, query,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.ResponseStatus,
+		delivery.Error, delivery.NextAttemptAt, delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries of a webhook, newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*models.Delivery, error) {
+	query := `
+		SELECT id, webhook_id, event, payload, status, attempts, response_status, error, next_attempt_at, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*models.Delivery
+	for rows.Next() {
+		var d models.Delivery
+		if err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Status, &d.Attempts,
+			&d.ResponseStatus, &d.Error, &d.NextAttemptAt, &d.CreatedAt, &d.DeliveredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) query(ctx context.Context, query string, args ...any) ([]*models.Webhook, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		var w models.Webhook
+		if err := rows.Scan(
+			&w.ID, &w.CalendarID, &w.URL, &w.Secret, &w.Events,
+			&w.Active, &w.CreatedBy, &w.CreatedAt, &w.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, &w)
+	}
+
+	return webhooks, rows.Err()
+}
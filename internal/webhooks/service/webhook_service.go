@@ -0,0 +1,585 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/pkg/httputil"
+	calendarRepo "github.com/whento/whento/internal/calendar/repository"
+	"github.com/whento/whento/internal/config"
+	"github.com/whento/whento/internal/webhooks/models"
+	"github.com/whento/whento/internal/webhooks/repository"
+)
+
+const (
+	// MaxWebhooksPerCalendar limits the number of webhooks of a calendar
+	MaxWebhooksPerCalendar = 10
+
+	// Headers of webhook deliveries
+	HeaderEvent     = "X-WhenTo-Event"
+	HeaderDelivery  = "X-WhenTo-Delivery"
+	HeaderSignature = "X-WhenTo-Signature"
+
+	// deliveryLogLimit is the number of deliveries returned by the delivery log endpoint
+	deliveryLogLimit = 50
+
+	// Delivery worker settings
+	workerInterval = 30 * time.Second
+	claimBatchSize = 20
+	deliveryLease  = 2 * time.Minute // Longer than the delivery timeout
+
+	// maxErrorLength truncates the errors recorded in the delivery log
+	maxErrorLength = 500
+)
+
+// retryDelays are the delays before each retry of a failed delivery
+// A delivery is marked as failed after the last retry, about 8.5 hours after the event
+var retryDelays = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	6 * time.Hour,
+}
+
+// publishedEvents maps the event types published by the notification service to webhook events
+var publishedEvents = map[string]string{
+	"threshold_reached":      models.EventThresholdReached,
+	"threshold_lost":         models.EventThresholdLost,
+	"soft_threshold_reached": models.EventSoftThresholdReached,
+	"soft_threshold_lost":    models.EventSoftThresholdLost,
+}
+
+var (
+	ErrWebhookNotFound  = repository.ErrWebhookNotFound
+	ErrCalendarNotFound = errors.New("calendar not found")
+	ErrNotOwner         = errors.New("you don't own this calendar")
+	ErrUnknownEvent     = errors.New("unknown event")
+	ErrInvalidURL       = errors.New("invalid webhook URL")
+	ErrTooManyWebhooks  = fmt.Errorf("too many webhooks (maximum %d per calendar)", MaxWebhooksPerCalendar)
+)
+
+// WebhookService manages the outbound webhooks of calendars and delivers events to them
+// Events are queued in the delivery log and sent by a background worker, which retries failed
+// deliveries with an increasing delay. Payloads are signed with the secret of the webhook (HMAC-SHA256)
+type WebhookService struct {
+	repo                *repository.WebhookRepository
+	calendarRepo        *calendarRepo.CalendarRepository
+	httpClient          *http.Client
+	requireHTTPS        bool
+	allowPrivateTargets bool
+	wake                chan struct{}
+	logger              *slog.Logger
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	repo *repository.WebhookRepository,
+	calendarRepo *calendarRepo.CalendarRepository,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *WebhookService {
+	return &WebhookService{
+		repo:                repo,
+		calendarRepo:        calendarRepo,
+		httpClient:          newHTTPClient(cfg.HooksAllowPrivateTargets),
+		requireHTTPS:        cfg.AppEnv == "production",
+		allowPrivateTargets: cfg.HooksAllowPrivateTargets,
+		wake:                make(chan struct{}, 1),
+		logger:              logger,
+	}
+}
+
+// Create registers a webhook on a calendar owned by the user
+// The returned webhook holds its secret, which is only shown at creation and rotation
+func (s *WebhookService) Create(ctx context.Context, userID, calendarID uuid.UUID, req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	if err := s.checkOwner(ctx, userID, calendarID); err != nil {
+		return nil, err
+	}
+	if err := s.validateURL(req.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByCalendar(ctx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxWebhooksPerCalendar {
+		return nil, ErrTooManyWebhooks
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	webhook := &models.Webhook{
+		ID:         uuid.New(),
+		CalendarID: calendarID,
+		URL:        req.URL,
+		Secret:     secret,
+		Events:     events,
+		Active:     req.Active == nil || *req.Active,
+		CreatedBy:  &userID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.repo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook created", "webhook_id", webhook.ID, "calendar_id", calendarID, "user_id", userID)
+	return webhook, nil
+}
+
+// List returns the webhooks of a calendar owned by the user
+func (s *WebhookService) List(ctx context.Context, userID, calendarID uuid.UUID) ([]*models.Webhook, error) {
+	if err := s.checkOwner(ctx, userID, calendarID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByCalendar(ctx, calendarID)
+}
+
+// Update updates the URL, events or active flag of a webhook
+func (s *WebhookService) Update(ctx context.Context, userID, calendarID, webhookID uuid.UUID, req *models.UpdateWebhookRequest) (*models.Webhook, error) {
+	webhook, err := s.get(ctx, userID, calendarID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := s.validateURL(*req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *req.URL
+	}
+	if req.Events != nil {
+		events, err := normalizeEvents(*req.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// RotateSecret replaces the signing secret of a webhook
+func (s *WebhookService) RotateSecret(ctx context.Context, userID, calendarID, webhookID uuid.UUID) (*models.Webhook, error) {
+	webhook, err := s.get(ctx, userID, calendarID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+	webhook.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook secret rotated", "webhook_id", webhookID, "user_id", userID)
+	return webhook, nil
+}
+
+// Delete deletes a webhook and its delivery log
+func (s *WebhookService) Delete(ctx context.Context, userID, calendarID, webhookID uuid.UUID) error {
+	if _, err := s.get(ctx, userID, calendarID, webhookID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, webhookID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Webhook deleted", "webhook_id", webhookID, "user_id", userID)
+	return nil
+}
+
+// Ping queues a test delivery to a webhook, active or not
+func (s *WebhookService) Ping(ctx context.Context, userID, calendarID, webhookID uuid.UUID) (*models.Delivery, error) {
+	webhook, err := s.get(ctx, userID, calendarID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.queue(ctx, []*models.Webhook{webhook}, calendarID, models.EventPing, map[string]string{"webhook_id": webhookID.String()})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries[0], nil
+}
+
+// ListDeliveries returns the latest deliveries of a webhook, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, userID, calendarID, webhookID uuid.UUID) ([]*models.Delivery, error) {
+	if _, err := s.get(ctx, userID, calendarID, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, webhookID, deliveryLogLimit)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []*models.Delivery{}
+	}
+	return deliveries, nil
+}
+
+// Dispatch queues an event of a calendar for its active webhooks subscribed to it
+// Failures are logged: events are best effort and never fail the operation that triggered them
+func (s *WebhookService) Dispatch(ctx context.Context, calendarID uuid.UUID, event string, data any) {
+	webhooks, err := s.repo.ListActiveByCalendar(ctx, calendarID)
+	if err != nil {
+		s.logger.Error("Failed to list webhooks for event", "calendar_id", calendarID, "event", event, "error", err)
+		return
+	}
+
+	var targets []*models.Webhook
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event) {
+			targets = append(targets, webhook)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	if _, err := s.queue(ctx, targets, calendarID, event, data); err != nil {
+		s.logger.Error("Failed to queue webhook deliveries", "calendar_id", calendarID, "event", event, "error", err)
+	}
+}
+
+// Publish forwards the events of the notification service (threshold transitions) to webhooks
+func (s *WebhookService) Publish(ctx context.Context, _, calendarID uuid.UUID, eventType string, data any) {
+	if event, ok := publishedEvents[eventType]; ok {
+		s.Dispatch(ctx, calendarID, event, data)
+	}
+}
+
+// queue records a delivery of an event for each webhook and wakes the worker up
+func (s *WebhookService) queue(ctx context.Context, webhooks []*models.Webhook, calendarID uuid.UUID, event string, data any) ([]*models.Delivery, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	now := time.Now().UTC()
+	payload, err := json.Marshal(models.Payload{
+		ID:         uuid.New(),
+		Event:      event,
+		CalendarID: calendarID,
+		CreatedAt:  now,
+		Data:       encoded,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	deliveries := make([]*models.Delivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, &models.Delivery{
+			ID:            uuid.New(),
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       payload,
+			Status:        models.StatusPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		})
+	}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return deliveries, nil
+}
+
+// StartTask starts the delivery worker, which sends queued deliveries as soon as they are due
+func (s *WebhookService) StartTask(ctx context.Context) {
+	s.logger.Info("Starting webhook delivery worker", "interval", workerInterval)
+
+	go func() {
+		ticker := time.NewTicker(workerInterval)
+		defer ticker.Stop()
+
+		for {
+			s.DeliverDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Webhook delivery worker stopped (context cancelled)")
+				return
+			case <-ticker.C:
+			case <-s.wake:
+			}
+		}
+	}()
+}
+
+// DeliverDue sends the pending deliveries that are due, in batches
+// Deliveries are claimed in the database, so several instances can run the worker
+func (s *WebhookService) DeliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		due, err := s.repo.ClaimDue(ctx, time.Now(), deliveryLease, claimBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to claim webhook deliveries", "error", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range due {
+			wg.Go(func() { s.attempt(ctx, delivery) })
+		}
+		wg.Wait()
+
+		if len(due) < claimBatchSize {
+			return
+		}
+	}
+}
+
+// attempt sends a claimed delivery and records the outcome
+func (s *WebhookService) attempt(ctx context.Context, due *repository.DueDelivery) {
+	delivery := &due.Delivery
+	now := time.Now()
+
+	if !due.Active && delivery.Event != models.EventPing {
+		message := "webhook disabled"
+		delivery.Status = models.StatusFailed
+		delivery.Error = &message
+		delivery.NextAttemptAt = nil
+	} else {
+		status, err := s.deliver(ctx, due.URL, due.Secret, delivery, now)
+		recordOutcome(delivery, status, err, now)
+
+		if status == http.StatusGone {
+			// The target asks to stop receiving events
+			s.logger.Info("Webhook target gone, disabling webhook", "webhook_id", delivery.WebhookID)
+			if err := s.repo.Deactivate(ctx, delivery.WebhookID); err != nil {
+				s.logger.Error("Failed to disable gone webhook", "webhook_id", delivery.WebhookID, "error", err)
+			}
+		}
+
+		switch delivery.Status {
+		case models.StatusSucceeded:
+			s.logger.Debug("Webhook delivered", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "event", delivery.Event)
+		case models.StatusPending:
+			s.logger.Warn("Webhook delivery failed, will retry", "delivery_id", delivery.ID, "attempt", delivery.Attempts, "error", err)
+		default:
+			s.logger.Warn("Webhook delivery failed", "delivery_id", delivery.ID, "attempt", delivery.Attempts, "error", err)
+		}
+	}
+
+	if err := s.repo.RecordAttempt(ctx, delivery); err != nil {
+		s.logger.Error("Failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// recordOutcome updates a delivery with the outcome of an attempt, scheduling a retry if any is left
+func recordOutcome(delivery *models.Delivery, status int, err error, now time.Time) {
+	delivery.Attempts++
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	if err == nil {
+		delivery.Status = models.StatusSucceeded
+		delivery.Error = nil
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	delivery.Error = &message
+
+	delay, ok := retryDelay(delivery.Attempts)
+	if !ok || status == http.StatusGone {
+		delivery.Status = models.StatusFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(delay)
+	delivery.Status = models.StatusPending
+	delivery.NextAttemptAt = &next
+}
+
+// retryDelay returns the delay before the retry following an attempt (1-based), if any is left
+func retryDelay(attempts int) (time.Duration, bool) {
+	if attempts < 1 || attempts > len(retryDelays) {
+		return 0, false
+	}
+	return retryDelays[attempts-1], true
+}
+
+// deliver POSTs a delivery to a target and returns the response status
+func (s *WebhookService) deliver(ctx context.Context, targetURL, secret string, delivery *models.Delivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "WhenTo-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderSignature, Sign(secret, now.Unix(), delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("target returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header of a payload: "t=<unix timestamp>,v1=<hex HMAC-SHA256>"
+// The HMAC covers "<timestamp>.<body>" so that receivers can reject replayed deliveries
+func Sign(secret string, timestamp int64, body []byte) string {
+	t := strconv.FormatInt(timestamp, 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkOwner checks that a calendar exists and is owned by the user
+func (s *WebhookService) checkOwner(ctx context.Context, userID, calendarID uuid.UUID) error {
+	calendar, err := s.calendarRepo.GetByID(ctx, calendarID)
+	if err != nil {
+		if errors.Is(err, calendarRepo.ErrCalendarNotFound) {
+			return ErrCalendarNotFound
+		}
+		return err
+	}
+	if calendar.OwnerID != userID {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+// get returns a webhook of a calendar owned by the user
+func (s *WebhookService) get(ctx context.Context, userID, calendarID, webhookID uuid.UUID) (*models.Webhook, error) {
+	if err := s.checkOwner(ctx, userID, calendarID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.repo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.CalendarID != calendarID {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// normalizeEvents checks the subscribed events and removes duplicates (empty = all events)
+func normalizeEvents(events []string) ([]string, error) {
+	normalized := []string{}
+	for _, event := range events {
+		if !slices.Contains(models.Events, event) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownEvent, event)
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+// validateURL checks the scheme of a webhook URL and rejects literal private addresses
+// Host names are checked when connecting, since they may resolve differently later
+func (s *WebhookService) validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return ErrInvalidURL
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if s.requireHTTPS {
+			return fmt.Errorf("%w: HTTPS is required", ErrInvalidURL)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported scheme %q", ErrInvalidURL, u.Scheme)
+	}
+
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !s.allowPrivateTargets && !httputil.IsPublicIP(ip) {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, httputil.ErrPrivateAddress)
+	}
+
+	return nil
+}
+
+// generateSecret generates a signing secret
+func generateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// newHTTPClient creates the delivery client, which refuses private addresses unless allowed
+func newHTTPClient(allowPrivateTargets bool) *http.Client {
+	client := httputil.NewOutboundClient(10*time.Second, allowPrivateTargets)
+	// Redirects could point elsewhere, and targets shouldn't move anyway
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return client
+}
@@ -0,0 +1,200 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// Licensed under the Business Source License 1.1
+// See LICENSE file for details
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whento/whento/internal/webhooks/models"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"threshold.reached"}`)
+
+	got := Sign("whsec_test", 1700000000, body)
+
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte(`1700000000.{"event":"threshold.reached"}`))
+	want := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+	if got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+
+	if Sign("other", 1700000000, body) == got {
+		t.Error("Sign() should depend on the secret")
+	}
+	if Sign("whsec_test", 1700000001, body) == got {
+		t.Error("Sign() should depend on the timestamp")
+	}
+}
+
+func TestRecordOutcome(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		d := &models.Delivery{Status: models.StatusPending}
+		recordOutcome(d, http.StatusOK, nil, now)
+		if d.Status != models.StatusSucceeded || d.Attempts != 1 || d.NextAttemptAt != nil || d.DeliveredAt == nil {
+			t.Errorf("delivery = %+v, want succeeded after 1 attempt", d)
+		}
+		if d.ResponseStatus == nil || *d.ResponseStatus != http.StatusOK {
+			t.Errorf("response status = %v, want 200", d.ResponseStatus)
+		}
+	})
+
+	t.Run("retries with backoff", func(t *testing.T) {
+		d := &models.Delivery{Status: models.StatusPending}
+		for i, delay := range retryDelays {
+			recordOutcome(d, http.StatusInternalServerError, errors.New("target returned status 500"), now)
+			if d.Status != models.StatusPending || d.Attempts != i+1 {
+				t.Fatalf("attempt %d: delivery = %+v, want pending", i+1, d)
+			}
+			if d.NextAttemptAt == nil || !d.NextAttemptAt.Equal(now.Add(delay)) {
+				t.Fatalf("attempt %d: next attempt = %v, want %v", i+1, d.NextAttemptAt, now.Add(delay))
+			}
+		}
+
+		recordOutcome(d, 0, errors.New("connection refused"), now)
+		if d.Status != models.StatusFailed || d.NextAttemptAt != nil || d.ResponseStatus != nil {
+			t.Errorf("delivery = %+v, want failed after the last retry", d)
+		}
+		if d.Attempts != len(retryDelays)+1 {
+			t.Errorf("attempts = %d, want %d", d.Attempts, len(retryDelays)+1)
+		}
+	})
+
+	t.Run("gone is not retried", func(t *testing.T) {
+		d := &models.Delivery{Status: models.StatusPending}
+		recordOutcome(d, http.StatusGone, errors.New("target returned status 410"), now)
+		if d.Status != models.StatusFailed || d.NextAttemptAt != nil {
+			t.Errorf("delivery = %+v, want failed", d)
+		}
+	})
+
+	t.Run("long errors are truncated", func(t *testing.T) {
+		d := &models.Delivery{}
+		recordOutcome(d, 0, errors.New(strings.Repeat("x", 2000)), now)
+		if d.Error == nil || len(*d.Error) != maxErrorLength {
+			t.Errorf("error length = %d, want %d", len(*d.Error), maxErrorLength)
+		}
+	})
+}
+
+func TestDeliver(t *testing.T) {
+	payload := []byte(`{"id":"1","event":"participant.added"}`)
+	delivery := &models.Delivery{ID: uuid.New(), Event: models.EventParticipantAdded, Payload: payload}
+	now := time.Unix(1700000000, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != string(payload) {
+			t.Errorf("body = %s, want %s", body, payload)
+		}
+		if got := r.Header.Get(HeaderEvent); got != models.EventParticipantAdded {
+			t.Errorf("%s = %q", HeaderEvent, got)
+		}
+		if got := r.Header.Get(HeaderDelivery); got != delivery.ID.String() {
+			t.Errorf("%s = %q, want %s", HeaderDelivery, got, delivery.ID)
+		}
+		if got, want := r.Header.Get(HeaderSignature), Sign("secret", now.Unix(), payload); got != want {
+			t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	s := &WebhookService{httpClient: newHTTPClient(true)}
+
+	status, err := s.deliver(context.Background(), server.URL+"/ok", "secret", delivery, now)
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("deliver() = %d, %v, want 204", status, err)
+	}
+
+	status, err = s.deliver(context.Background(), server.URL+"/fail", "secret", delivery, now)
+	if err == nil || status != http.StatusServiceUnavailable {
+		t.Errorf("deliver() = %d, %v, want 503 with error", status, err)
+	}
+}
+
+func TestNormalizeEvents(t *testing.T) {
+	events, err := normalizeEvents([]string{models.EventCalendarUpdated, models.EventThresholdReached, models.EventCalendarUpdated})
+	if err != nil {
+		t.Fatalf("normalizeEvents() error = %v", err)
+	}
+	if len(events) != 2 || events[0] != models.EventCalendarUpdated || events[1] != models.EventThresholdReached {
+		t.Errorf("normalizeEvents() = %v", events)
+	}
+
+	if _, err := normalizeEvents([]string{"threshold_reached"}); !errors.Is(err, ErrUnknownEvent) {
+		t.Errorf("normalizeEvents() error = %v, want ErrUnknownEvent", err)
+	}
+
+	// The ping event is only sent on request
+	if _, err := normalizeEvents([]string{models.EventPing}); !errors.Is(err, ErrUnknownEvent) {
+		t.Errorf("normalizeEvents(ping) error = %v, want ErrUnknownEvent", err)
+	}
+}
+
+func TestWebhookSubscribes(t *testing.T) {
+	all := &models.Webhook{}
+	some := &models.Webhook{Events: []string{models.EventThresholdReached}}
+
+	tests := []struct {
+		webhook *models.Webhook
+		event   string
+		want    bool
+	}{
+		{all, models.EventAvailabilityCreated, true},
+		{some, models.EventThresholdReached, true},
+		{some, models.EventAvailabilityCreated, false},
+		{some, models.EventPing, true},
+	}
+	for _, tt := range tests {
+		if got := tt.webhook.Subscribes(tt.event); got != tt.want {
+			t.Errorf("Subscribes(%s) with events %v = %v, want %v", tt.event, tt.webhook.Events, got, tt.want)
+		}
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		requireHTTPS bool
+		wantErr      bool
+	}{
+		{name: "https", url: "https://example.com/webhook"},
+		{name: "http in development", url: "http://example.com/webhook"},
+		{name: "http in production", url: "http://example.com/webhook", requireHTTPS: true, wantErr: true},
+		{name: "unsupported scheme", url: "ftp://example.com/webhook", wantErr: true},
+		{name: "loopback", url: "https://127.0.0.1/webhook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &WebhookService{requireHTTPS: tt.requireHTTPS}
+			if err := s.validateURL(tt.url); (err != nil) != tt.wantErr {
+				t.Errorf("validateURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
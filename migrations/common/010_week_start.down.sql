@@ -0,0 +1,2 @@
+-- Remove week_start column from calendars table
+ALTER TABLE calendars DROP COLUMN IF EXISTS week_start;
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Per-calendar first day of the week (NULL = use the instance WEEK_START default)
+ALTER TABLE calendars
+  ADD COLUMN week_start VARCHAR(6) CHECK (week_start IN ('sunday', 'monday'));
+
+COMMENT ON COLUMN calendars.week_start IS 'First day of the week for grouping (sunday or monday), NULL inherits the instance default';
@@ -0,0 +1,3 @@
+-- Remove time_format columns from users and calendars tables
+ALTER TABLE calendars DROP COLUMN IF EXISTS time_format;
+ALTER TABLE users DROP COLUMN IF EXISTS time_format;
@@ -0,0 +1,13 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Clock format preference (NULL = use the instance TIME_FORMAT default)
+ALTER TABLE users
+  ADD COLUMN time_format VARCHAR(3) CHECK (time_format IN ('24h', '12h'));
+
+ALTER TABLE calendars
+  ADD COLUMN time_format VARCHAR(3) CHECK (time_format IN ('24h', '12h'));
+
+COMMENT ON COLUMN users.time_format IS 'Preferred clock format for notifications (24h or 12h), NULL inherits the calendar or instance default';
+COMMENT ON COLUMN calendars.time_format IS 'Clock format for notifications sent from this calendar (24h or 12h), NULL inherits the instance default';
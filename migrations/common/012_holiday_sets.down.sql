@@ -0,0 +1,2 @@
+-- Remove holiday_sets column from calendars table
+ALTER TABLE calendars DROP COLUMN IF EXISTS holiday_sets;
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Additional holiday rule sets checked on top of the country holidays (e.g. orthodox, islamic, jewish)
+ALTER TABLE calendars
+  ADD COLUMN holiday_sets TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN calendars.holiday_sets IS 'Additional holiday sets (orthodox, islamic, jewish) applied with holidays_policy and allow_holiday_eves';
@@ -0,0 +1,2 @@
+-- Remove date_format column from calendars table
+ALTER TABLE calendars DROP COLUMN IF EXISTS date_format;
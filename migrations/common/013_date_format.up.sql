@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Per-calendar date format for notifications (NULL = use the instance DATE_FORMAT default)
+ALTER TABLE calendars
+  ADD COLUMN date_format VARCHAR(4) CHECK (date_format IN ('iso', 'long'));
+
+COMMENT ON COLUMN calendars.date_format IS 'Date format for notifications (iso or long), NULL inherits the instance default';
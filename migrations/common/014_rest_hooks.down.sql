@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove REST hooks tables
+DROP TABLE IF EXISTS hook_events;
+DROP TABLE IF EXISTS rest_hooks;
@@ -0,0 +1,30 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- REST Hooks subscriptions (Zapier, Make and other automation platforms)
+CREATE TABLE rest_hooks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  calendar_id UUID REFERENCES calendars(id) ON DELETE CASCADE, -- NULL = all calendars owned by the user
+  event VARCHAR(50) NOT NULL,
+  target_url TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rest_hooks_user_event ON rest_hooks(user_id, event);
+
+-- Recent events, served by the polling endpoint (fallback and sample data for integrations)
+CREATE TABLE hook_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  calendar_id UUID NOT NULL REFERENCES calendars(id) ON DELETE CASCADE,
+  event VARCHAR(50) NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_hook_events_poll ON hook_events(user_id, event, created_at DESC);
+
+-- Index for cleanup (events are kept 30 days)
+CREATE INDEX idx_hook_events_cleanup ON hook_events(created_at);
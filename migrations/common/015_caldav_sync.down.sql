@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove CalDAV sync tables
+DROP TABLE IF EXISTS caldav_busy_blocks;
+DROP TABLE IF EXISTS caldav_accounts;
@@ -0,0 +1,26 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- CalDAV account of a user (Nextcloud, Fastmail...), synced periodically to avoid their busy time
+CREATE TABLE caldav_accounts (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  server_url TEXT NOT NULL,
+  username VARCHAR(255) NOT NULL,
+  password TEXT NOT NULL, -- App password
+  calendar_urls TEXT[] NOT NULL DEFAULT '{}', -- Discovered event calendars
+  last_sync_at TIMESTAMPTZ,
+  last_sync_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Busy blocks pulled from the CalDAV account, replaced on each sync
+CREATE TABLE caldav_busy_blocks (
+  user_id UUID NOT NULL REFERENCES caldav_accounts(user_id) ON DELETE CASCADE,
+  start_at TIMESTAMPTZ NOT NULL,
+  end_at TIMESTAMPTZ NOT NULL,
+  CHECK (end_at > start_at)
+);
+
+CREATE INDEX idx_caldav_busy_blocks_user_range ON caldav_busy_blocks(user_id, start_at, end_at);
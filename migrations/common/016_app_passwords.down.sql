@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove app passwords and login flows
+DROP TABLE IF EXISTS login_flows;
+DROP TABLE IF EXISTS app_passwords;
@@ -0,0 +1,32 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- App passwords: long-lived credentials for integrations (Nextcloud, desktop clients...)
+-- Only a SHA-256 hash of the password is stored; it is shown once when created
+CREATE TABLE app_passwords (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  password_hash VARCHAR(64) NOT NULL UNIQUE,
+  last_used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_app_passwords_user ON app_passwords(user_id);
+
+-- Login flows: an integration opens the login URL in a browser, the user grants access,
+-- and the integration polls for the app password created for it (like Nextcloud Login Flow v2)
+CREATE TABLE login_flows (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  flow_token_hash VARCHAR(64) NOT NULL UNIQUE, -- Token of the login URL opened by the user
+  poll_token_hash VARCHAR(64) NOT NULL UNIQUE, -- Token kept by the integration to poll
+  client_name VARCHAR(100) NOT NULL,
+  user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- Set when granted
+  app_password TEXT, -- Set when granted, deleted once polled
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Index for cleanup of expired flows
+CREATE INDEX idx_login_flows_expires ON login_flows(expires_at);
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove the MQTT channel from the notification log
+DELETE FROM notification_log WHERE channel = 'mqtt';
+ALTER TABLE notification_log DROP CONSTRAINT notification_log_channel_check;
+ALTER TABLE notification_log ADD CONSTRAINT notification_log_channel_check
+  CHECK (channel IN ('email', 'discord', 'slack', 'telegram'));
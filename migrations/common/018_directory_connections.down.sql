@@ -0,0 +1,6 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove organization directory connections
+DROP TABLE IF EXISTS directory_connections;
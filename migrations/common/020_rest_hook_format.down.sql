@@ -0,0 +1,6 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove the payload format of REST hooks
+ALTER TABLE rest_hooks DROP COLUMN IF EXISTS format;
@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Payload format of REST hooks: the full event, or value1/value2/value3 for IFTTT Webhooks
+ALTER TABLE rest_hooks
+  ADD COLUMN format VARCHAR(16) NOT NULL DEFAULT 'default' CHECK (format IN ('default', 'ifttt'));
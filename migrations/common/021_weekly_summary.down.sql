@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove the weekly summary email preference
+ALTER TABLE users DROP COLUMN IF EXISTS weekly_summary_sent_at;
+ALTER TABLE users DROP COLUMN IF EXISTS weekly_summary;
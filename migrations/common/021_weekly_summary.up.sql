@@ -0,0 +1,11 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Opt-in weekly summary email sent to calendar owners
+ALTER TABLE users
+  ADD COLUMN weekly_summary BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN weekly_summary_sent_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN users.weekly_summary IS 'Whether the owner receives a weekly summary of their calendars by email';
+COMMENT ON COLUMN users.weekly_summary_sent_at IS 'When the last weekly summary was sent, so each week is sent once';
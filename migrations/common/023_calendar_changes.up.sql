@@ -0,0 +1,17 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Change log of calendar settings (who changed what, and when)
+CREATE TABLE calendar_changes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  calendar_id UUID NOT NULL REFERENCES calendars(id) ON DELETE CASCADE,
+  user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL once the author is deleted
+  changes JSONB NOT NULL, -- [{"field": "threshold", "old": 3, "new": 4}, ...]
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_calendar_changes_calendar ON calendar_changes(calendar_id, created_at DESC);
+
+-- Index for cleanup (audit retention)
+CREATE INDEX idx_calendar_changes_cleanup ON calendar_changes(created_at);
@@ -0,0 +1,10 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove search indexes (pg_trgm is kept, other objects may depend on it)
+DROP INDEX IF EXISTS idx_recurrences_note_trgm;
+DROP INDEX IF EXISTS idx_availabilities_note_trgm;
+DROP INDEX IF EXISTS idx_participants_name_trgm;
+DROP INDEX IF EXISTS idx_calendars_description_trgm;
+DROP INDEX IF EXISTS idx_calendars_name_trgm;
@@ -0,0 +1,13 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Trigram indexes for the search of calendars, participants and availability notes (GET /api/v1/search)
+-- pg_trgm is a trusted extension: the database owner can create it without superuser rights
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_calendars_name_trgm ON calendars USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_calendars_description_trgm ON calendars USING GIN (description gin_trgm_ops);
+CREATE INDEX idx_participants_name_trgm ON participants USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_availabilities_note_trgm ON availabilities USING GIN (note gin_trgm_ops) WHERE note IS NOT NULL AND note <> '';
+CREATE INDEX idx_recurrences_note_trgm ON recurrences USING GIN (note gin_trgm_ops) WHERE note IS NOT NULL AND note <> '';
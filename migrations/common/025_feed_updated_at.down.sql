@@ -0,0 +1,12 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+DROP TRIGGER IF EXISTS recurrence_exceptions_touch_calendar_feed ON recurrence_exceptions;
+DROP TRIGGER IF EXISTS recurrences_touch_calendar_feed ON recurrences;
+DROP TRIGGER IF EXISTS availabilities_touch_calendar_feed ON availabilities;
+DROP TRIGGER IF EXISTS participants_touch_calendar_feed ON participants;
+
+DROP FUNCTION IF EXISTS touch_calendar_feed();
+
+ALTER TABLE calendars DROP COLUMN IF EXISTS feed_updated_at;
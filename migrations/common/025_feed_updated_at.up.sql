@@ -0,0 +1,57 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Last change of the data behind the ICS feed of a calendar, used for its ETag and Last-Modified headers
+-- Bumped by triggers, so that deletions, renames and recurrence edits are tracked too
+ALTER TABLE calendars ADD COLUMN feed_updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE OR REPLACE FUNCTION touch_calendar_feed()
+RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    IF TG_TABLE_NAME = 'participants' THEN
+        UPDATE calendars SET feed_updated_at = NOW() WHERE id = changed.calendar_id;
+    ELSIF TG_TABLE_NAME = 'recurrence_exceptions' THEN
+        UPDATE calendars SET feed_updated_at = NOW()
+        WHERE id = (
+            SELECT p.calendar_id FROM recurrences r
+            JOIN participants p ON p.id = r.participant_id
+            WHERE r.id = changed.recurrence_id
+        );
+    ELSE
+        -- availabilities and recurrences
+        UPDATE calendars SET feed_updated_at = NOW()
+        WHERE id = (SELECT calendar_id FROM participants WHERE id = changed.participant_id);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER participants_touch_calendar_feed
+    AFTER INSERT OR UPDATE OR DELETE ON participants
+    FOR EACH ROW
+    EXECUTE FUNCTION touch_calendar_feed();
+
+CREATE TRIGGER availabilities_touch_calendar_feed
+    AFTER INSERT OR UPDATE OR DELETE ON availabilities
+    FOR EACH ROW
+    EXECUTE FUNCTION touch_calendar_feed();
+
+CREATE TRIGGER recurrences_touch_calendar_feed
+    AFTER INSERT OR UPDATE OR DELETE ON recurrences
+    FOR EACH ROW
+    EXECUTE FUNCTION touch_calendar_feed();
+
+CREATE TRIGGER recurrence_exceptions_touch_calendar_feed
+    AFTER INSERT OR UPDATE OR DELETE ON recurrence_exceptions
+    FOR EACH ROW
+    EXECUTE FUNCTION touch_calendar_feed();
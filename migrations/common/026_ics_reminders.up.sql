@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Reminders added to the events of the ICS feed, in minutes before the start (e.g. {1440,60})
+ALTER TABLE calendars
+  ADD COLUMN ics_reminder_minutes INTEGER[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN calendars.ics_reminder_minutes IS 'VALARM reminders of the ICS feed events, in minutes before the start, empty = no reminders';
@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+ALTER TABLE calendars
+  DROP COLUMN IF EXISTS ics_description_template,
+  DROP COLUMN IF EXISTS ics_title_template;
@@ -0,0 +1,11 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Owner-editable templates of the title and description of the ICS feed events (NULL = built-in text)
+ALTER TABLE calendars
+  ADD COLUMN ics_title_template VARCHAR(200),
+  ADD COLUMN ics_description_template TEXT;
+
+COMMENT ON COLUMN calendars.ics_title_template IS 'Title of the ICS feed events, with placeholders such as {{calendar}}, {{date}}, {{count}}; NULL = "{{calendar}} #{{number}} ({{count}}/{{total}})"';
+COMMENT ON COLUMN calendars.ics_description_template IS 'Description of the ICS feed events, with the same placeholders; NULL = participant list';
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Default date window of the ICS feed, in days before and after today (NULL = no limit)
+-- Feed URLs can override them with ?past_days= and ?future_days=
+ALTER TABLE calendars
+  ADD COLUMN ics_past_days INTEGER CHECK (ics_past_days >= 0),
+  ADD COLUMN ics_future_days INTEGER CHECK (ics_future_days >= 0);
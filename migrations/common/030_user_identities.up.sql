@@ -0,0 +1,19 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Accounts of single sign-on providers linked to users
+-- Users created on their first single sign-on login have an empty password hash, which never matches
+CREATE TABLE user_identities (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  provider VARCHAR(20) NOT NULL, -- 'oidc'
+  issuer TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  email VARCHAR(255),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_login_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (provider, issuer, subject)
+);
+
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
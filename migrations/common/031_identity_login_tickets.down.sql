@@ -0,0 +1,12 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove single sign-on login tickets
+DROP INDEX IF EXISTS idx_user_identities_login_ticket;
+
+ALTER TABLE user_identities
+  DROP COLUMN IF EXISTS login_ticket_hash,
+  DROP COLUMN IF EXISTS login_ticket_expires_at;
+
+COMMENT ON COLUMN user_identities.provider IS NULL;
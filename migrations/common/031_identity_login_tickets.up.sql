@@ -0,0 +1,13 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Single-use login tickets handing a SAML sign-in over to the web app
+-- The identity provider posts to the API, which redirects to the web app with the ticket
+ALTER TABLE user_identities
+  ADD COLUMN login_ticket_hash VARCHAR(64),
+  ADD COLUMN login_ticket_expires_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX idx_user_identities_login_ticket ON user_identities(login_ticket_hash) WHERE login_ticket_hash IS NOT NULL;
+
+COMMENT ON COLUMN user_identities.provider IS 'oidc or saml';
@@ -0,0 +1,17 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- API tokens of a single calendar, acting on behalf of the user who created them (only their hash is stored)
+CREATE TABLE calendar_api_tokens (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  calendar_id UUID NOT NULL REFERENCES calendars(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  scopes TEXT[] NOT NULL, -- 'summaries:read', 'availabilities:write', 'participants:manage'
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  last_used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_calendar_api_tokens_calendar ON calendar_api_tokens(calendar_id);
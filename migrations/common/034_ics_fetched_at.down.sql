@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove the last fetch of ICS feeds
+DROP INDEX IF EXISTS idx_calendars_ics_fetched_at;
+ALTER TABLE calendars DROP COLUMN IF EXISTS ics_fetched_at;
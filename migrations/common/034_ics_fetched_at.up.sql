@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Last fetch of the ICS feed (or CalDAV collection) of a calendar, recorded at most once an hour
+-- Used by the admin statistics to count the feeds still subscribed to
+ALTER TABLE calendars ADD COLUMN ics_fetched_at TIMESTAMPTZ;
+
+CREATE INDEX idx_calendars_ics_fetched_at ON calendars(ics_fetched_at) WHERE ics_fetched_at IS NOT NULL;
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove the device of sessions
+ALTER TABLE refresh_tokens
+  DROP COLUMN IF EXISTS user_agent,
+  DROP COLUMN IF EXISTS ip_address,
+  DROP COLUMN IF EXISTS last_used_at;
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Sessions: the device of each refresh token, listed and revoked by users
+ALTER TABLE refresh_tokens
+  ADD COLUMN user_agent TEXT,
+  ADD COLUMN ip_address VARCHAR(45),
+  ADD COLUMN last_used_at TIMESTAMPTZ;
@@ -0,0 +1,10 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove email one-time codes (users with this method lose their second factor)
+DELETE FROM user_mfa WHERE method = 'email';
+ALTER TABLE user_mfa
+  DROP COLUMN IF EXISTS method,
+  DROP COLUMN IF EXISTS email_code_hash,
+  DROP COLUMN IF EXISTS email_code_expires_at;
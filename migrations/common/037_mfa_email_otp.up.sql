@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Email one-time codes as a second factor, for users who can't use an authenticator app
+ALTER TABLE user_mfa
+  ADD COLUMN method VARCHAR(10) NOT NULL DEFAULT 'totp' CHECK (method IN ('totp', 'email')),
+  ADD COLUMN email_code_hash VARCHAR(64), -- SHA-256 of the last code sent
+  ADD COLUMN email_code_expires_at TIMESTAMPTZ;
@@ -0,0 +1,10 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove security keys (users with only security keys lose their second factor)
+DELETE FROM user_mfa WHERE method = 'webauthn';
+ALTER TABLE user_mfa DROP CONSTRAINT IF EXISTS user_mfa_method_check;
+ALTER TABLE user_mfa ADD CONSTRAINT user_mfa_method_check CHECK (method IN ('totp', 'email'));
+DELETE FROM passkeys WHERE second_factor;
+ALTER TABLE passkeys DROP COLUMN IF EXISTS second_factor;
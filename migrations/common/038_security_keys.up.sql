@@ -0,0 +1,10 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- FIDO2 security keys as a second factor for password logins (not usable for passwordless login)
+ALTER TABLE passkeys ADD COLUMN second_factor BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Users whose only second factor is a security key
+ALTER TABLE user_mfa DROP CONSTRAINT IF EXISTS user_mfa_method_check;
+ALTER TABLE user_mfa ADD CONSTRAINT user_mfa_method_check CHECK (method IN ('totp', 'email', 'webauthn'));
@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove announcements
+DROP TABLE IF EXISTS announcement_dismissals;
+DROP TABLE IF EXISTS announcements;
@@ -0,0 +1,33 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Announcements of the admins, shown as an in-app banner and/or emailed to all users or the users of a plan
+CREATE TABLE announcements (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  title VARCHAR(200) NOT NULL,
+  body TEXT NOT NULL,
+  translations JSONB NOT NULL DEFAULT '{}', -- Locale => {"title", "body"}, falling back to title and body
+  level VARCHAR(20) NOT NULL DEFAULT 'info' CHECK (level IN ('info', 'warning')),
+  audience VARCHAR(20) NOT NULL DEFAULT 'all', -- 'all' or a subscription plan (cloud)
+  banner BOOLEAN NOT NULL DEFAULT TRUE,
+  ends_at TIMESTAMPTZ, -- Banner hidden afterwards (NULL = until deleted)
+  -- Email delivery: 'none', 'pending', 'sending' or 'sent'
+  email_status VARCHAR(20) NOT NULL DEFAULT 'none',
+  email_cursor UUID, -- Last user emailed, so that an interrupted delivery resumes where it stopped
+  email_claimed_at TIMESTAMPTZ,
+  emails_sent INT NOT NULL DEFAULT 0,
+  emails_failed INT NOT NULL DEFAULT 0,
+  created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_announcements_email ON announcements(email_status) WHERE email_status IN ('pending', 'sending');
+
+-- Banners dismissed by each user
+CREATE TABLE announcement_dismissals (
+  announcement_id UUID NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  dismissed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (announcement_id, user_id)
+);
@@ -0,0 +1,10 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove soft deletion of users
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+ALTER TABLE users
+  DROP COLUMN IF EXISTS deleted_by,
+  DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+ALTER TABLE calendars
+  DROP COLUMN IF EXISTS count_maybe;
+
+ALTER TABLE availabilities
+  DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Tentative availabilities: 'maybe' answers are shown separately and only count toward
+-- the threshold when the calendar enables count_maybe
+ALTER TABLE availabilities
+  ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'yes' CHECK (status IN ('yes', 'maybe'));
+
+ALTER TABLE calendars
+  ADD COLUMN count_maybe BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Preferred availabilities weigh more in the score of date summaries
+ALTER TABLE availabilities
+  ADD COLUMN preferred BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+DROP INDEX IF EXISTS idx_participants_access_token;
+
+ALTER TABLE participants
+  DROP COLUMN IF EXISTS access_token;
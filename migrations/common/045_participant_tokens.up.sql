@@ -0,0 +1,11 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Secret token of each participant, used in place of the participant ID in the links of the public
+-- availability routes. Calendars locking their participants only accept this token.
+ALTER TABLE participants
+  ADD COLUMN access_token VARCHAR(64) NOT NULL
+    DEFAULT (replace(gen_random_uuid()::text, '-', '') || replace(gen_random_uuid()::text, '-', ''));
+
+CREATE UNIQUE INDEX idx_participants_access_token ON participants(access_token);
@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- A date only reaches the threshold when all the required participants of the calendar are available
+ALTER TABLE participants
+  ADD COLUMN required BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,16 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+DROP FUNCTION IF EXISTS recurrence_occurs_on(recurrences, DATE);
+
+-- Monthly recurrences can't be expressed with a day of week only
+DELETE FROM recurrences WHERE frequency <> 'weekly';
+
+ALTER TABLE recurrences
+  DROP CONSTRAINT IF EXISTS recurrences_pattern_check,
+  ALTER COLUMN day_of_week SET NOT NULL,
+  DROP COLUMN IF EXISTS week_of_month,
+  DROP COLUMN IF EXISTS day_of_month,
+  DROP COLUMN IF EXISTS interval_weeks,
+  DROP COLUMN IF EXISTS frequency;
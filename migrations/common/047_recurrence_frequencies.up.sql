@@ -0,0 +1,40 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Recurrences repeat weekly (every interval_weeks weeks), on a day of the month ("the 15th"),
+-- or on the nth weekday of the month ("the first Friday", week_of_month -1 meaning the last one)
+ALTER TABLE recurrences
+  ADD COLUMN frequency TEXT NOT NULL DEFAULT 'weekly'
+    CHECK (frequency IN ('weekly', 'monthly_day', 'monthly_weekday')),
+  ADD COLUMN interval_weeks INTEGER NOT NULL DEFAULT 1 CHECK (interval_weeks BETWEEN 1 AND 52),
+  ADD COLUMN day_of_month INTEGER CHECK (day_of_month BETWEEN 1 AND 31),
+  ADD COLUMN week_of_month INTEGER CHECK (week_of_month IN (-1, 1, 2, 3, 4, 5)),
+  ALTER COLUMN day_of_week DROP NOT NULL,
+  ADD CONSTRAINT recurrences_pattern_check CHECK (
+    (frequency = 'weekly' AND day_of_week IS NOT NULL)
+    OR (frequency = 'monthly_day' AND day_of_month IS NOT NULL)
+    OR (frequency = 'monthly_weekday' AND day_of_week IS NOT NULL AND week_of_month IS NOT NULL)
+  );
+
+-- Whether a recurrence applies to a date, exceptions aside
+-- Must match Recurrence.OccursOn in internal/availability/models
+CREATE OR REPLACE FUNCTION recurrence_occurs_on(r recurrences, d DATE)
+RETURNS BOOLEAN AS $$
+  SELECT d >= r.start_date
+    AND (r.end_date IS NULL OR d <= r.end_date)
+    AND CASE r.frequency
+      WHEN 'weekly' THEN
+        EXTRACT(DOW FROM d)::int = r.day_of_week
+        AND ((d - r.start_date) / 7) % r.interval_weeks = 0
+      WHEN 'monthly_day' THEN
+        EXTRACT(DAY FROM d)::int = r.day_of_month
+      WHEN 'monthly_weekday' THEN
+        EXTRACT(DOW FROM d)::int = r.day_of_week
+        AND CASE
+          WHEN r.week_of_month = -1 THEN EXTRACT(MONTH FROM d + 7) <> EXTRACT(MONTH FROM d)
+          ELSE (EXTRACT(DAY FROM d)::int - 1) / 7 + 1 = r.week_of_month
+        END
+      ELSE FALSE
+    END
+$$ LANGUAGE sql IMMUTABLE;
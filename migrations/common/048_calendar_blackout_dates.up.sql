@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Dates blocked by the owner (venue closed, exam period): [{"start_date": "...", "end_date": "...", "reason": "..."}]
+ALTER TABLE calendars
+  ADD COLUMN blackout_dates JSONB NOT NULL DEFAULT '[]'::jsonb;
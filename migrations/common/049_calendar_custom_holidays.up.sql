@@ -0,0 +1,7 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Holidays added by the owner (club anniversary, local festival): [{"date": "...", "name": "...", "yearly": true}]
+ALTER TABLE calendars
+  ADD COLUMN custom_holidays JSONB NOT NULL DEFAULT '[]'::jsonb;
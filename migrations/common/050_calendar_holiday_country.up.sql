@@ -0,0 +1,11 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Explicit holiday country and region, independent of the display timezone
+ALTER TABLE calendars
+  ADD COLUMN holiday_country VARCHAR(2),
+  ADD COLUMN holiday_region VARCHAR(3);
+
+COMMENT ON COLUMN calendars.holiday_country IS 'ISO 3166-1 country of the public holidays, NULL derives it from the timezone';
+COMMENT ON COLUMN calendars.holiday_region IS 'ISO 3166-2 subdivision of holiday_country (e.g. BY for DE-BY), NULL for nationwide holidays only';
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Other countries whose public holidays also count, for cross-border groups (e.g. BE, NL or DE-BY)
+ALTER TABLE calendars
+  ADD COLUMN holiday_countries TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN calendars.holiday_countries IS 'Additional holiday countries (ISO 3166-1, optionally with an ISO 3166-2 region such as DE-BY) applied with holidays_policy and allow_holiday_eves';
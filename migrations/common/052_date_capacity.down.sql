@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+DROP TABLE IF EXISTS availability_waitlist;
+
+ALTER TABLE calendars
+  DROP COLUMN IF EXISTS max_participants,
+  DROP COLUMN IF EXISTS capacity_policy;
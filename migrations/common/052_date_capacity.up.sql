@@ -0,0 +1,27 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Maximum number of participants per date, for venues or carpools with hard limits
+ALTER TABLE calendars
+  ADD COLUMN max_participants INTEGER CHECK (max_participants > 0),
+  ADD COLUMN capacity_policy VARCHAR(10) NOT NULL DEFAULT 'reject' CHECK (capacity_policy IN ('reject', 'waitlist'));
+
+COMMENT ON COLUMN calendars.max_participants IS 'Maximum participants counted per date, NULL for no limit';
+COMMENT ON COLUMN calendars.capacity_policy IS 'What happens to new availabilities on a full date: reject them or queue them on the waitlist';
+
+-- Availabilities queued on full dates, promoted in arrival order when a spot frees up
+CREATE TABLE availability_waitlist (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  participant_id UUID NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+  date DATE NOT NULL,
+  start_time TIME,
+  end_time TIME,
+  note TEXT NOT NULL DEFAULT '',
+  status VARCHAR(10) NOT NULL DEFAULT 'yes' CHECK (status IN ('yes', 'maybe')),
+  preferred BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (participant_id, date)
+);
+
+CREATE INDEX idx_availability_waitlist_date ON availability_waitlist(date, created_at);
@@ -0,0 +1,10 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+DROP TRIGGER IF EXISTS participants_apply_threshold_percent ON participants;
+
+DROP FUNCTION IF EXISTS apply_threshold_percent();
+DROP FUNCTION IF EXISTS percent_threshold(INTEGER, BIGINT);
+
+ALTER TABLE calendars DROP COLUMN IF EXISTS threshold_percent;
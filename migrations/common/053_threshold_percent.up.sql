@@ -0,0 +1,41 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Thresholds expressed as a percentage of the participants (e.g. 60%)
+-- The threshold column stays the effective value read everywhere, kept in sync by the triggers below
+ALTER TABLE calendars
+  ADD COLUMN threshold_percent INTEGER CHECK (threshold_percent BETWEEN 1 AND 100);
+
+COMMENT ON COLUMN calendars.threshold_percent IS 'Threshold as a percentage of the participants, NULL for an absolute threshold';
+
+-- Participants needed for a percentage of a calendar, rounded up and at least 1
+-- Must match PercentThreshold in internal/calendar/models
+CREATE OR REPLACE FUNCTION percent_threshold(percent INTEGER, participants BIGINT)
+RETURNS INTEGER AS $$
+    SELECT GREATEST(1, CEIL(percent * participants / 100.0))::INTEGER;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION apply_threshold_percent()
+RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    UPDATE calendars
+    SET threshold = percent_threshold(threshold_percent, (SELECT COUNT(*) FROM participants WHERE calendar_id = changed.calendar_id))
+    WHERE id = changed.calendar_id AND threshold_percent IS NOT NULL;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER participants_apply_threshold_percent
+    AFTER INSERT OR DELETE ON participants
+    FOR EACH ROW
+    EXECUTE FUNCTION apply_threshold_percent();
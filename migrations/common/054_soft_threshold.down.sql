@@ -0,0 +1,14 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+DELETE FROM notification_log WHERE level = 'soft';
+DROP INDEX idx_notification_log_lookup;
+CREATE INDEX idx_notification_log_lookup
+  ON notification_log(calendar_id, date, event_type, recipient_id, channel, sent_at DESC);
+ALTER TABLE notification_log DROP CONSTRAINT notification_log_event_type_check;
+ALTER TABLE notification_log ADD CONSTRAINT notification_log_event_type_check
+  CHECK (event_type IN ('threshold_reached', 'threshold_lost', 'reminder'));
+ALTER TABLE notification_log DROP COLUMN level;
+
+ALTER TABLE calendars DROP COLUMN soft_threshold;
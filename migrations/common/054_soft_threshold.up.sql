@@ -0,0 +1,16 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Optional lower threshold, only notified ("looking good"): events of the ICS feed still need the threshold
+ALTER TABLE calendars ADD COLUMN soft_threshold INTEGER CHECK (soft_threshold > 0);
+
+-- Log the threshold level of each notification, so soft and hard transitions are deduplicated apart
+ALTER TABLE notification_log ADD COLUMN level VARCHAR(10) NOT NULL DEFAULT 'hard' CHECK (level IN ('soft', 'hard'));
+ALTER TABLE notification_log DROP CONSTRAINT notification_log_event_type_check;
+ALTER TABLE notification_log ADD CONSTRAINT notification_log_event_type_check
+  CHECK (event_type IN ('threshold_reached', 'threshold_lost', 'soft_threshold_reached', 'soft_threshold_lost', 'reminder'));
+
+DROP INDEX idx_notification_log_lookup;
+CREATE INDEX idx_notification_log_lookup
+  ON notification_log(calendar_id, date, event_type, level, recipient_id, channel, sent_at DESC);
@@ -0,0 +1,39 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+DROP TRIGGER IF EXISTS event_confirmations_touch_calendar_feed ON event_confirmations;
+
+CREATE OR REPLACE FUNCTION touch_calendar_feed()
+RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    IF TG_TABLE_NAME = 'participants' THEN
+        UPDATE calendars SET feed_updated_at = NOW() WHERE id = changed.calendar_id;
+    ELSIF TG_TABLE_NAME = 'recurrence_exceptions' THEN
+        UPDATE calendars SET feed_updated_at = NOW()
+        WHERE id = (
+            SELECT p.calendar_id FROM recurrences r
+            JOIN participants p ON p.id = r.participant_id
+            WHERE r.id = changed.recurrence_id
+        );
+    ELSE
+        -- availabilities and recurrences
+        UPDATE calendars SET feed_updated_at = NOW()
+        WHERE id = (SELECT calendar_id FROM participants WHERE id = changed.participant_id);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS event_confirmations;
+
+ALTER TABLE calendars DROP COLUMN IF EXISTS require_confirmation;
@@ -0,0 +1,53 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Dates reaching the threshold of a calendar requiring confirmation are proposed to the owner,
+-- and only become events of the ICS feed once confirmed
+ALTER TABLE calendars ADD COLUMN require_confirmation BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE event_confirmations (
+  calendar_id UUID NOT NULL REFERENCES calendars(id) ON DELETE CASCADE,
+  date DATE NOT NULL,
+  status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'declined')),
+  token_hash VARCHAR(64) UNIQUE, -- Secret of the email link (SHA-256), NULL for dates decided in the app
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  decided_at TIMESTAMPTZ,
+  PRIMARY KEY (calendar_id, date)
+);
+
+-- Decisions change the events of the feed
+CREATE OR REPLACE FUNCTION touch_calendar_feed()
+RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    IF TG_TABLE_NAME IN ('participants', 'event_confirmations') THEN
+        UPDATE calendars SET feed_updated_at = NOW() WHERE id = changed.calendar_id;
+    ELSIF TG_TABLE_NAME = 'recurrence_exceptions' THEN
+        UPDATE calendars SET feed_updated_at = NOW()
+        WHERE id = (
+            SELECT p.calendar_id FROM recurrences r
+            JOIN participants p ON p.id = r.participant_id
+            WHERE r.id = changed.recurrence_id
+        );
+    ELSE
+        -- availabilities and recurrences
+        UPDATE calendars SET feed_updated_at = NOW()
+        WHERE id = (SELECT calendar_id FROM participants WHERE id = changed.participant_id);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER event_confirmations_touch_calendar_feed
+    AFTER INSERT OR UPDATE OR DELETE ON event_confirmations
+    FOR EACH ROW
+    EXECUTE FUNCTION touch_calendar_feed();
@@ -0,0 +1,21 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Attendance answers of participants on the events of their calendar, apart from their availability
+CREATE TABLE rsvps (
+  participant_id UUID NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+  calendar_id UUID NOT NULL REFERENCES calendars(id) ON DELETE CASCADE,
+  date DATE NOT NULL,
+  status VARCHAR(10) NOT NULL CHECK (status IN ('going', 'not_going')),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (participant_id, date)
+);
+
+CREATE INDEX idx_rsvps_calendar_date ON rsvps(calendar_id, date);
+
+-- Answers are the PARTSTAT of the attendees of the feed
+CREATE TRIGGER rsvps_touch_calendar_feed
+    AFTER INSERT OR UPDATE OR DELETE ON rsvps
+    FOR EACH ROW
+    EXECUTE FUNCTION touch_calendar_feed();
@@ -0,0 +1,27 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- External ICS feeds of a participant (e.g. their Google or Outlook busy feed), synced periodically
+-- to warn them when they mark availability while already booked
+CREATE TABLE participant_busy_feeds (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  participant_id UUID NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+  url TEXT NOT NULL,
+  last_sync_at TIMESTAMPTZ,
+  last_sync_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (participant_id, url)
+);
+
+-- Busy blocks pulled from a feed, replaced on each sync
+CREATE TABLE participant_busy_blocks (
+  feed_id UUID NOT NULL REFERENCES participant_busy_feeds(id) ON DELETE CASCADE,
+  participant_id UUID NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+  start_at TIMESTAMPTZ NOT NULL,
+  end_at TIMESTAMPTZ NOT NULL,
+  CHECK (end_at > start_at)
+);
+
+CREATE INDEX idx_participant_busy_blocks_range ON participant_busy_blocks(participant_id, start_at, end_at);
+CREATE INDEX idx_participant_busy_blocks_feed ON participant_busy_blocks(feed_id);
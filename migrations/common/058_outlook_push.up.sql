@@ -0,0 +1,26 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Outlook account of a user, into whose default calendar the confirmed events of their calendars are pushed
+CREATE TABLE outlook_connections (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  account_email VARCHAR(255) NOT NULL DEFAULT '',
+  access_token TEXT NOT NULL,
+  refresh_token TEXT NOT NULL DEFAULT '',
+  expires_at TIMESTAMPTZ NOT NULL,
+  last_sync_at TIMESTAMPTZ,
+  last_sync_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Outlook events created for the confirmed events, to update or delete them when the events change
+CREATE TABLE outlook_events (
+  user_id UUID NOT NULL REFERENCES outlook_connections(user_id) ON DELETE CASCADE,
+  calendar_id UUID NOT NULL, -- No foreign key: the events of a deleted calendar are removed on the next sync
+  name VARCHAR(64) NOT NULL, -- Resource name of the event, e.g. 20250614.ics
+  event_id TEXT NOT NULL, -- Microsoft Graph event ID
+  etag VARCHAR(64) NOT NULL, -- Entity tag of the pushed version
+  PRIMARY KEY (user_id, calendar_id, name)
+);
@@ -0,0 +1,23 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Browsers subscribed to Web Push, for a user account or for a participant through their link
+CREATE TABLE push_subscriptions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+  participant_id UUID REFERENCES participants(id) ON DELETE CASCADE,
+  endpoint TEXT NOT NULL UNIQUE, -- Push service URL of the browser
+  p256dh VARCHAR(128) NOT NULL,
+  auth VARCHAR(64) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK ((user_id IS NULL) <> (participant_id IS NULL))
+);
+
+CREATE INDEX idx_push_subscriptions_user ON push_subscriptions(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_push_subscriptions_participant ON push_subscriptions(participant_id) WHERE participant_id IS NOT NULL;
+
+-- Allow logging push notifications
+ALTER TABLE notification_log DROP CONSTRAINT notification_log_channel_check;
+ALTER TABLE notification_log ADD CONSTRAINT notification_log_channel_check
+  CHECK (channel IN ('email', 'discord', 'slack', 'telegram', 'mqtt', 'rocketchat', 'webhook', 'mattermost', 'push'));
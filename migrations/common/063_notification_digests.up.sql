@@ -0,0 +1,24 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Threshold transitions waiting for the daily or weekly digest email of their recipient
+CREATE TABLE notification_digest_entries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  calendar_id UUID NOT NULL REFERENCES calendars(id) ON DELETE CASCADE,
+  user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- Owner recipient
+  participant_id UUID REFERENCES participants(id) ON DELETE CASCADE, -- Participant recipient
+  email VARCHAR(255) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  locale VARCHAR(10) NOT NULL,
+  calendar_url TEXT NOT NULL, -- Link of the recipient, to their participant view when they have one
+  date DATE NOT NULL,
+  transition_type VARCHAR(50) NOT NULL,
+  level VARCHAR(10) NOT NULL,
+  count INTEGER NOT NULL,
+  threshold INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK ((user_id IS NULL) <> (participant_id IS NULL))
+);
+
+CREATE INDEX idx_notification_digest_entries_calendar ON notification_digest_entries(calendar_id, created_at);
@@ -0,0 +1,13 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove the activity notifications from the notification log
+DELETE FROM notification_log WHERE event_type = 'activity';
+DELETE FROM notification_digest_entries WHERE transition_type = 'activity';
+ALTER TABLE notification_log DROP CONSTRAINT notification_log_level_check;
+ALTER TABLE notification_log ADD CONSTRAINT notification_log_level_check
+  CHECK (level IN ('soft', 'hard'));
+ALTER TABLE notification_log DROP CONSTRAINT notification_log_event_type_check;
+ALTER TABLE notification_log ADD CONSTRAINT notification_log_event_type_check
+  CHECK (event_type IN ('threshold_reached', 'threshold_lost', 'soft_threshold_reached', 'soft_threshold_lost', 'reminder'));
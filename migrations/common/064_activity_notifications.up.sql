@@ -0,0 +1,11 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Allow logging the activity notifications of the owner, which don't concern a threshold level
+ALTER TABLE notification_log DROP CONSTRAINT notification_log_event_type_check;
+ALTER TABLE notification_log ADD CONSTRAINT notification_log_event_type_check
+  CHECK (event_type IN ('threshold_reached', 'threshold_lost', 'soft_threshold_reached', 'soft_threshold_lost', 'reminder', 'activity'));
+ALTER TABLE notification_log DROP CONSTRAINT notification_log_level_check;
+ALTER TABLE notification_log ADD CONSTRAINT notification_log_level_check
+  CHECK (level IN ('soft', 'hard', 'none'));
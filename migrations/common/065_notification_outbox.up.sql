@@ -0,0 +1,28 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Outbox of threshold and activity notifications, sent by a worker retrying failed deliveries
+CREATE TABLE notification_outbox (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  calendar_id UUID NOT NULL REFERENCES calendars(id) ON DELETE CASCADE,
+  channel VARCHAR(20) NOT NULL,
+  event_type VARCHAR(50) NOT NULL,
+  date DATE NOT NULL,
+  level VARCHAR(10) NOT NULL,
+  recipient_type VARCHAR(20) NOT NULL CHECK (recipient_type IN ('owner', 'participant')),
+  recipient_id UUID NOT NULL,
+  payload JSONB NOT NULL, -- Rendered message and channel settings, secrets included
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  error TEXT,
+  next_attempt_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_notification_outbox_calendar ON notification_outbox(calendar_id, created_at DESC);
+CREATE INDEX idx_notification_outbox_pending ON notification_outbox(next_attempt_at) WHERE status = 'pending';
+
+-- Index for cleanup (the retention janitor purges old deliveries with the other logs)
+CREATE INDEX idx_notification_outbox_cleanup ON notification_outbox(created_at);
@@ -0,0 +1,25 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Queue of the emails, sent by a worker retrying transient failures
+-- Bounced emails were refused by the server or provider and aren't retried
+CREATE TABLE mail_queue (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  recipients TEXT[] NOT NULL,
+  subject TEXT NOT NULL,
+  body TEXT NOT NULL, -- Rendered email, links and codes included
+  html BOOLEAN NOT NULL DEFAULT FALSE,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'bounced')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  error TEXT,
+  next_attempt_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_mail_queue_pending ON mail_queue(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_mail_queue_undelivered ON mail_queue(created_at DESC) WHERE status IN ('failed', 'bounced');
+
+-- Index for cleanup (the retention janitor purges old emails with the other logs)
+CREATE INDEX idx_mail_queue_cleanup ON mail_queue(created_at);
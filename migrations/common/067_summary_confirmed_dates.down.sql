@@ -0,0 +1,6 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Remove the confirmed dates of the weekly summaries
+ALTER TABLE calendars DROP COLUMN IF EXISTS summary_confirmed_dates;
@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Dates of a calendar meeting its threshold when its owner's last weekly summary was sent,
+-- compared with the current ones to report the dates that reached or lost the threshold since
+ALTER TABLE calendars ADD COLUMN summary_confirmed_dates DATE[];
+
+COMMENT ON COLUMN calendars.summary_confirmed_dates IS 'Dates meeting the threshold at the last weekly summary, NULL before the first one';
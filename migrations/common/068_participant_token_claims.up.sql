@@ -0,0 +1,9 @@
+-- WhenTo - Collaborative event calendar for self-hosted environments
+-- Copyright (C) 2025 WhenTo Contributors
+-- SPDX-License-Identifier: BSL-1.1
+
+-- Time at which the link of a participant was first used or handed out by the owner.
+-- On calendars not locking their participants, a visitor can claim the link of a participant
+-- whose link was never claimed; claimed links are only shown to the owner.
+ALTER TABLE participants
+  ADD COLUMN access_token_claimed_at TIMESTAMPTZ;
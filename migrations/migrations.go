@@ -0,0 +1,31 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+// Package migrations embeds the SQL migrations of the current build:
+// common migrations plus the cloud or selfhosted ones, selected by build tag.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed common/*.sql
+var commonFS embed.FS
+
+// sources lists the migration directories included in this build
+var sources = []fs.FS{mustSub(commonFS, "common")}
+
+// Sources returns the embedded migration directories of this build
+func Sources() []fs.FS {
+	return sources
+}
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,16 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build cloud
+
+package migrations
+
+import "embed"
+
+//go:embed cloud/*.sql
+var cloudFS embed.FS
+
+func init() {
+	sources = append(sources, mustSub(cloudFS, "cloud"))
+}
@@ -0,0 +1,16 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+//go:build selfhosted
+
+package migrations
+
+import "embed"
+
+//go:embed selfhosted/*.sql
+var selfhostedFS embed.FS
+
+func init() {
+	sources = append(sources, mustSub(selfhostedFS, "selfhosted"))
+}
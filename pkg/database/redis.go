@@ -9,6 +9,8 @@ import (
 	"fmt"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/whento/pkg/tracing"
 )
 
 // RedisConfig holds Redis configuration
@@ -24,6 +26,7 @@ func NewRedisClient(ctx context.Context, cfg *RedisConfig) (*redis.Client, error
 	}
 
 	client := redis.NewClient(opts)
+	client.AddHook(tracing.RedisHook{})
 
 	// Verify connection
 	if err := client.Ping(ctx).Err(); err != nil {
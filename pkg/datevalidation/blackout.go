@@ -0,0 +1,51 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package datevalidation
+
+import "time"
+
+// BlackoutPeriod is a range of dates blocked by the calendar owner (venue closed, exam period)
+// No availability can be given on these dates, whatever the weekday and holiday rules
+type BlackoutPeriod struct {
+	StartDate string `json:"start_date" validate:"required,datetime=2006-01-02"`          // Format: "YYYY-MM-DD"
+	EndDate   string `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02"` // Inclusive, a single date when unset
+	Reason    string `json:"reason,omitempty" validate:"max=200"`
+}
+
+// lastDate returns the last blocked date of the period
+func (p BlackoutPeriod) lastDate() string {
+	if p.EndDate == "" {
+		return p.StartDate
+	}
+	return p.EndDate
+}
+
+// Valid reports whether the period has well-formed dates and does not end before it starts
+func (p BlackoutPeriod) Valid() bool {
+	if _, err := time.Parse("2006-01-02", p.StartDate); err != nil {
+		return false
+	}
+	if _, err := time.Parse("2006-01-02", p.lastDate()); err != nil {
+		return false
+	}
+	return p.lastDate() >= p.StartDate
+}
+
+// Contains reports whether a date falls within the period
+func (p BlackoutPeriod) Contains(date time.Time) bool {
+	// Compare dates as strings to avoid timezone issues
+	day := date.Format("2006-01-02")
+	return day >= p.StartDate && day <= p.lastDate()
+}
+
+// IsBlackedOut reports whether a date falls within one of the blackout periods
+func IsBlackedOut(date time.Time, periods []BlackoutPeriod) bool {
+	for _, period := range periods {
+		if period.Contains(date) {
+			return true
+		}
+	}
+	return false
+}
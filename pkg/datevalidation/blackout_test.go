@@ -0,0 +1,62 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package datevalidation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsBlackedOut(t *testing.T) {
+	periods := []BlackoutPeriod{
+		{StartDate: "2025-06-10", EndDate: "2025-06-20", Reason: "Exam period"},
+		{StartDate: "2025-07-14"},
+	}
+
+	tests := []struct {
+		name string
+		date string
+		want bool
+	}{
+		{name: "first day of a range", date: "2025-06-10", want: true},
+		{name: "last day of a range", date: "2025-06-20", want: true},
+		{name: "day after a range", date: "2025-06-21", want: false},
+		{name: "single date", date: "2025-07-14", want: true},
+		{name: "day after a single date", date: "2025-07-15", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", tt.date)
+			if err != nil {
+				t.Fatalf("invalid test date: %v", err)
+			}
+			if got := IsBlackedOut(date, periods); got != tt.want {
+				t.Errorf("IsBlackedOut(%s) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlackoutPeriod_Valid(t *testing.T) {
+	tests := []struct {
+		name   string
+		period BlackoutPeriod
+		want   bool
+	}{
+		{name: "range", period: BlackoutPeriod{StartDate: "2025-06-10", EndDate: "2025-06-20"}, want: true},
+		{name: "single date", period: BlackoutPeriod{StartDate: "2025-06-10"}, want: true},
+		{name: "ends before start", period: BlackoutPeriod{StartDate: "2025-06-10", EndDate: "2025-06-09"}, want: false},
+		{name: "invalid date", period: BlackoutPeriod{StartDate: "10/06/2025"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Valid(); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package datevalidation
+
+import (
+	"strings"
+	"time"
+)
+
+// CustomHoliday is a holiday added by a calendar owner (club anniversary, local festival)
+// It follows the holidays policy, holiday hours and holiday eves like a country holiday
+type CustomHoliday struct {
+	Date   string `json:"date" validate:"required,datetime=2006-01-02"` // Format: "YYYY-MM-DD"
+	Name   string `json:"name" validate:"required,max=100"`
+	Yearly bool   `json:"yearly,omitempty"` // Same day every year from Date on
+}
+
+// Valid reports whether the holiday has a well-formed date and a name
+func (h CustomHoliday) Valid() bool {
+	if _, err := time.Parse("2006-01-02", h.Date); err != nil {
+		return false
+	}
+	return strings.TrimSpace(h.Name) != ""
+}
+
+// Matches reports whether the holiday falls on a date
+func (h CustomHoliday) Matches(date time.Time) bool {
+	// Compare dates as strings to avoid timezone issues
+	day := date.Format("2006-01-02")
+	if !h.Yearly {
+		return day == h.Date
+	}
+	return len(h.Date) == len(day) && day >= h.Date && day[4:] == h.Date[4:]
+}
+
+// CustomHolidays is the list of custom holidays of a calendar, usable as a HolidayProvider
+type CustomHolidays []CustomHoliday
+
+// IsHoliday reports whether one of the custom holidays falls on the date
+func (h CustomHolidays) IsHoliday(date time.Time) bool {
+	return h.Name(date) != ""
+}
+
+// Name returns the name of the custom holiday falling on the date, or "" if there is none
+func (h CustomHolidays) Name(date time.Time) string {
+	for _, holiday := range h {
+		if holiday.Matches(date) {
+			return holiday.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package datevalidation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCustomHolidays(t *testing.T) {
+	holidays := CustomHolidays{
+		{Date: "2025-03-14", Name: "Club anniversary", Yearly: true},
+		{Date: "2025-07-05", Name: "Village festival"},
+	}
+
+	tests := []struct {
+		date string
+		want string
+	}{
+		{date: "2025-03-14", want: "Club anniversary"},
+		{date: "2027-03-14", want: "Club anniversary"},
+		{date: "2024-03-14", want: ""}, // Before the club existed
+		{date: "2025-07-05", want: "Village festival"},
+		{date: "2026-07-05", want: ""}, // One-off holiday
+		{date: "2025-07-06", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", tt.date)
+			if err != nil {
+				t.Fatalf("invalid test date: %v", err)
+			}
+
+			if got := holidays.Name(date); got != tt.want {
+				t.Errorf("Name(%s) = %q, want %q", tt.date, got, tt.want)
+			}
+			if got := holidays.IsHoliday(date); got != (tt.want != "") {
+				t.Errorf("IsHoliday(%s) = %v, want %v", tt.date, got, tt.want != "")
+			}
+		})
+	}
+}
+
+func TestCustomHolidayValid(t *testing.T) {
+	if !(CustomHoliday{Date: "2025-03-14", Name: "Club anniversary"}).Valid() {
+		t.Error("Expected a dated, named holiday to be valid")
+	}
+	if (CustomHoliday{Date: "14/03/2025", Name: "Club anniversary"}).Valid() {
+		t.Error("Expected a malformed date to be invalid")
+	}
+	if (CustomHoliday{Date: "2025-03-14", Name: " "}).Valid() {
+		t.Error("Expected a blank name to be invalid")
+	}
+}
+
+func TestIsDateAllowed_CustomHolidays(t *testing.T) {
+	holidays := CustomHolidays{{Date: "2025-03-14", Name: "Club anniversary"}}
+	friday := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	thursday := friday.AddDate(0, 0, -1)
+	weekend := []int{0, 6}
+
+	if IsDateAllowed(friday, "", []int{5}, "block", false, nil, holidays) {
+		t.Error("Expected a custom holiday to be blocked by the block policy")
+	}
+	if !IsDateAllowed(friday, "", weekend, "allow", false, nil, holidays) {
+		t.Error("Expected a custom holiday to be allowed by the allow policy")
+	}
+	if !IsDateAllowed(thursday, "", weekend, "ignore", true, nil, holidays) {
+		t.Error("Expected the day before a custom holiday to be allowed as a holiday eve")
+	}
+}
@@ -5,6 +5,7 @@
 package datevalidation
 
 import (
+	"regexp"
 	"strings"
 	"time"
 
@@ -21,15 +22,12 @@ import (
 //   - "block": Holidays are explicitly blocked (return false)
 //
 // 3. Holiday eves (if allow_holiday_eves is true)
-func IsDateAllowed(date time.Time, timezone string, allowedWeekdays []int, holidaysPolicy string, allowHolidayEves bool) bool {
-	// Get country code from timezone for holiday checking
-	countryCode := getCountryFromTimezone(timezone)
-
-	// Check if it's a holiday (if we have country information)
-	isHolidayDate := false
-	if countryCode != "" {
-		isHolidayDate = isHoliday(date, countryCode)
-	}
+//
+// Holidays are those of the holiday country (see HolidayCode) plus any additional holiday sets (see HolidaySets)
+// and extra providers, such as the custom holidays of the calendar
+func IsDateAllowed(date time.Time, countryCode string, allowedWeekdays []int, holidaysPolicy string, allowHolidayEves bool, holidaySets []string, extra ...HolidayProvider) bool {
+	// Check if it's a holiday (if we have country information, additional holiday sets or custom holidays)
+	isHolidayDate := IsHolidayInSets(date, countryCode, holidaySets, extra...)
 
 	// Apply holidays_policy
 	switch holidaysPolicy {
@@ -54,7 +52,7 @@ func IsDateAllowed(date time.Time, timezone string, allowedWeekdays []int, holid
 	}
 
 	// If weekday is not allowed, check holiday eve exception
-	if countryCode != "" && allowHolidayEves && isHolidayEve(date, countryCode) {
+	if allowHolidayEves && IsHolidayEveInSets(date, countryCode, holidaySets, extra...) {
 		return true
 	}
 
@@ -93,13 +91,52 @@ func GetCountryFromTimezone(timezone string) string {
 	return ""
 }
 
+// HolidayCode returns the code used to look up public holidays: the explicit holiday country when set,
+// with its region as an ISO 3166-2 subdivision (e.g. "DE-BY"), otherwise the country of the timezone
+func HolidayCode(timezone, country, region string) string {
+	if country == "" {
+		return GetCountryFromTimezone(timezone)
+	}
+	if region == "" {
+		return strings.ToUpper(country)
+	}
+	return strings.ToUpper(country + "-" + region)
+}
+
+// holidayCodePattern matches an ISO 3166-1 country code, optionally with an ISO 3166-2 subdivision ("DE-BY")
+var holidayCodePattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+
+// IsValidHolidayCode reports whether a code is an uppercase country code, optionally with its region (see HolidayCode)
+func IsValidHolidayCode(code string) bool {
+	return holidayCodePattern.MatchString(code)
+}
+
+// CountryHolidays is a list of holiday codes ("BE", "DE-BY") usable as a HolidayProvider,
+// for cross-border groups: a date is a holiday when it is one in any of the countries
+type CountryHolidays []string
+
+// IsHoliday reports whether the date is a public holiday in one of the countries
+func (c CountryHolidays) IsHoliday(date time.Time) bool {
+	for _, code := range c {
+		if IsHoliday(date, code) {
+			return true
+		}
+	}
+	return false
+}
+
 // getCountryFromTimezone is a private alias for backward compatibility
 func getCountryFromTimezone(timezone string) string {
 	return GetCountryFromTimezone(timezone)
 }
 
 // IsHoliday checks if a given date is a public holiday in the specified country
+// With a subdivision code such as "DE-BY", only the holidays observed in that region count
 func IsHoliday(date time.Time, countryCode string) bool {
+	if country, _, ok := strings.Cut(countryCode, "-"); ok {
+		return isRegionalHoliday(date, country, countryCode)
+	}
+
 	// Check if the date is a holiday using go-holidays library
 	isHoliday := holidays.IsHoliday(countryCode, date)
 	return isHoliday
@@ -0,0 +1,227 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package datevalidation
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday set identifiers that can be enabled per calendar on top of the country holidays
+const (
+	HolidaySetOrthodox = "orthodox"
+	HolidaySetIslamic  = "islamic"
+	HolidaySetJewish   = "jewish"
+)
+
+// HolidayProvider reports holidays from an additional rule set (movable feasts, non-Gregorian calendars)
+type HolidayProvider interface {
+	// IsHoliday reports whether the date is a holiday in this rule set
+	IsHoliday(date time.Time) bool
+}
+
+// HolidayProviderFunc adapts a plain function to the HolidayProvider interface
+type HolidayProviderFunc func(date time.Time) bool
+
+// IsHoliday calls f(date)
+func (f HolidayProviderFunc) IsHoliday(date time.Time) bool {
+	return f(date)
+}
+
+// holidayProviders maps holiday set identifiers to their providers
+var holidayProviders = map[string]HolidayProvider{
+	HolidaySetOrthodox: HolidayProviderFunc(isOrthodoxHoliday),
+	HolidaySetIslamic:  HolidayProviderFunc(isIslamicHoliday),
+	HolidaySetJewish:   HolidayProviderFunc(isJewishHoliday),
+}
+
+// HolidaySets returns the identifiers of all available additional holiday sets, sorted
+func HolidaySets() []string {
+	sets := make([]string, 0, len(holidayProviders))
+	for name := range holidayProviders {
+		sets = append(sets, name)
+	}
+	sort.Strings(sets)
+	return sets
+}
+
+// IsValidHolidaySet checks if a holiday set identifier is known
+func IsValidHolidaySet(name string) bool {
+	_, ok := holidayProviders[name]
+	return ok
+}
+
+// IsHolidayInSets checks if a date is a holiday in the country (when known), in any of the given holiday sets
+// or for any of the extra providers (such as the custom holidays of a calendar)
+// Unknown holiday set identifiers are ignored
+func IsHolidayInSets(date time.Time, countryCode string, holidaySets []string, extra ...HolidayProvider) bool {
+	if countryCode != "" && IsHoliday(date, countryCode) {
+		return true
+	}
+
+	for _, name := range holidaySets {
+		if provider, ok := holidayProviders[name]; ok && provider.IsHoliday(date) {
+			return true
+		}
+	}
+
+	for _, provider := range extra {
+		if provider != nil && provider.IsHoliday(date) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsHolidayEveInSets checks if a date is the day before a holiday (see IsHolidayInSets)
+func IsHolidayEveInSets(date time.Time, countryCode string, holidaySets []string, extra ...HolidayProvider) bool {
+	return IsHolidayInSets(date.AddDate(0, 0, 1), countryCode, holidaySets, extra...)
+}
+
+// sameDay compares the calendar dates of two times, ignoring the time of day
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+// julianDayNumber returns the Julian Day Number of a Gregorian calendar date
+func julianDayNumber(date time.Time) int {
+	a := (14 - int(date.Month())) / 12
+	y := date.Year() + 4800 - a
+	m := int(date.Month()) + 12*a - 3
+	return date.Day() + (153*m+2)/5 + 365*y + y/4 - y/100 + y/400 - 32045
+}
+
+// ========== ORTHODOX (JULIAN COMPUTUS) ==========
+
+// orthodoxEaster returns the Gregorian date of Orthodox Easter Sunday for a year (Meeus' Julian algorithm)
+func orthodoxEaster(year int) time.Time {
+	a := year % 4
+	b := year % 7
+	c := year % 19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+
+	// Convert from the Julian to the Gregorian calendar
+	julianOffset := year/100 - year/400 - 2
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, julianOffset)
+}
+
+// isOrthodoxHoliday checks Christmas (Julian), Good Friday, Easter, Easter Monday and Pentecost
+func isOrthodoxHoliday(date time.Time) bool {
+	year := date.Year()
+	julianOffset := year/100 - year/400 - 2
+
+	// Julian December 25th of the previous year falls in early January
+	christmas := time.Date(year-1, time.December, 25, 0, 0, 0, 0, time.UTC).AddDate(0, 0, julianOffset)
+	if sameDay(date, christmas) {
+		return true
+	}
+
+	easter := orthodoxEaster(year)
+	for _, offset := range []int{-2, 0, 1, 49} {
+		if sameDay(date, easter.AddDate(0, 0, offset)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ========== ISLAMIC (TABULAR CALENDAR) ==========
+
+// Actual observance depends on moon sighting and may differ by a day from the tabular calendar
+
+// islamicEpoch is the Julian Day Number of 1 Muharram 1 AH (civil epoch, July 16th 622 Julian)
+const islamicEpoch = 1948440
+
+// islamicHolidays lists holidays as (month, day) pairs in the Islamic calendar
+var islamicHolidays = [][2]int{
+	{1, 1},   // Islamic New Year
+	{3, 12},  // Mawlid
+	{10, 1},  // Eid al-Fitr
+	{12, 10}, // Eid al-Adha
+}
+
+// islamicToJDN returns the Julian Day Number of a date in the tabular Islamic calendar
+func islamicToJDN(year, month, day int) int {
+	return day + (59*(month-1)+1)/2 + (year-1)*354 + (3+11*year)/30 + islamicEpoch - 1
+}
+
+// isIslamicHoliday checks Islamic New Year, Mawlid, Eid al-Fitr and Eid al-Adha
+func isIslamicHoliday(date time.Time) bool {
+	jdn := julianDayNumber(date)
+
+	// Approximate Islamic year, then check its neighbours to cover year boundaries
+	year := (30*(jdn-islamicEpoch) + 10646) / 10631
+	for y := year - 1; y <= year+1; y++ {
+		for _, holiday := range islamicHolidays {
+			if islamicToJDN(y, holiday[0], holiday[1]) == jdn {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ========== JEWISH (HEBREW CALENDAR) ==========
+
+// hebrewEpoch is the Julian Day Number of 1 Tishrei AM 1
+const hebrewEpoch = 347998
+
+// hebrewElapsedDays returns the number of days from the epoch to Rosh Hashanah of a Hebrew year,
+// applying the molad and "lo ADU Rosh" postponements
+func hebrewElapsedDays(year int) int {
+	monthsElapsed := (235*year - 234) / 19
+	partsElapsed := 12084 + 13753*monthsElapsed
+	days := 29*monthsElapsed + partsElapsed/25920
+	if (3*(days+1))%7 < 3 {
+		days++
+	}
+	return days
+}
+
+// roshHashanah returns the Julian Day Number of 1 Tishrei of a Hebrew year,
+// applying the remaining postponements that keep year lengths valid
+func roshHashanah(year int) int {
+	previous := hebrewElapsedDays(year - 1)
+	current := hebrewElapsedDays(year)
+	next := hebrewElapsedDays(year + 1)
+
+	correction := 0
+	if next-current == 356 {
+		correction = 2
+	} else if current-previous == 382 {
+		correction = 1
+	}
+
+	return hebrewEpoch + current + correction
+}
+
+// isJewishHoliday checks Rosh Hashanah, Yom Kippur, Sukkot, Shemini Atzeret, Passover and Shavuot
+func isJewishHoliday(date time.Time) bool {
+	jdn := julianDayNumber(date)
+
+	// Tishrei holidays in a Gregorian year belong to Hebrew year (year + 3761)
+	tishrei := roshHashanah(date.Year() + 3761)
+	for _, offset := range []int{0, 1, 9, 14, 21} {
+		if jdn == tishrei+offset {
+			return true
+		}
+	}
+
+	// 15 Nisan always falls 163 days before the following Rosh Hashanah
+	passover := tishrei - 163
+	for _, offset := range []int{0, 6, 50} {
+		if jdn == passover+offset {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package datevalidation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsHolidayInSets(t *testing.T) {
+	tests := []struct {
+		name string
+		date string
+		set  string
+		want bool
+	}{
+		{name: "orthodox christmas", date: "2025-01-07", set: HolidaySetOrthodox, want: true},
+		{name: "orthodox easter 2024", date: "2024-05-05", set: HolidaySetOrthodox, want: true},
+		{name: "orthodox good friday 2025", date: "2025-04-18", set: HolidaySetOrthodox, want: true},
+		{name: "orthodox pentecost 2025", date: "2025-06-08", set: HolidaySetOrthodox, want: true},
+		{name: "western easter is not orthodox easter", date: "2024-03-31", set: HolidaySetOrthodox, want: false},
+		{name: "eid al-fitr 1445", date: "2024-04-10", set: HolidaySetIslamic, want: true},
+		{name: "islamic new year 1447", date: "2025-06-27", set: HolidaySetIslamic, want: true},
+		{name: "regular day islamic", date: "2024-04-11", set: HolidaySetIslamic, want: false},
+		{name: "rosh hashanah 5785", date: "2024-10-03", set: HolidaySetJewish, want: true},
+		{name: "yom kippur 5785", date: "2024-10-12", set: HolidaySetJewish, want: true},
+		{name: "passover 5785", date: "2025-04-13", set: HolidaySetJewish, want: true},
+		{name: "shavuot 5784", date: "2024-06-12", set: HolidaySetJewish, want: true},
+		{name: "regular day jewish", date: "2024-10-05", set: HolidaySetJewish, want: false},
+		{name: "unknown set is ignored", date: "2024-10-03", set: "unknown", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", tt.date)
+			if err != nil {
+				t.Fatalf("invalid test date: %v", err)
+			}
+
+			// Empty country code skips the country holiday lookup (network)
+			if got := IsHolidayInSets(date, "", []string{tt.set}); got != tt.want {
+				t.Errorf("IsHolidayInSets(%s, %s) = %v, want %v", tt.date, tt.set, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsHolidayEveInSets(t *testing.T) {
+	date := time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC)
+	if !IsHolidayEveInSets(date, "", []string{HolidaySetJewish}) {
+		t.Error("Expected the day before Rosh Hashanah to be a holiday eve")
+	}
+}
+
+func TestIsValidHolidaySet(t *testing.T) {
+	for _, name := range HolidaySets() {
+		if !IsValidHolidaySet(name) {
+			t.Errorf("Expected %q to be a valid holiday set", name)
+		}
+	}
+	if IsValidHolidaySet("gregorian") {
+		t.Error("Expected unknown holiday set to be invalid")
+	}
+}
@@ -0,0 +1,149 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package datevalidation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// nagerAPIURL is the public holidays endpoint of the Nager.Date API, also used by go-holidays
+var nagerAPIURL = "https://date.nager.at/api/v3/PublicHolidays"
+
+const (
+	// regionalHolidaysTTL is how long the holidays of a country and year are cached
+	regionalHolidaysTTL = 24 * time.Hour
+	// regionalHolidaysBackoff is how long a failed fetch is cached before the API is tried again,
+	// so that an unreachable API slows down one request rather than every date checked
+	regionalHolidaysBackoff = 5 * time.Minute
+	// regionalHolidaysTimeout bounds a fetch from the API
+	regionalHolidaysTimeout = 5 * time.Second
+)
+
+// nagerHoliday is a public holiday as returned by the Nager.Date API
+type nagerHoliday struct {
+	Date     string   `json:"date"`
+	Global   bool     `json:"global"`
+	Counties []string `json:"counties"` // ISO 3166-2 subdivisions observing the holiday, when not global
+}
+
+// observedIn reports whether the holiday is observed in a subdivision (e.g. "DE-BY")
+func (h nagerHoliday) observedIn(subdivision string) bool {
+	return h.Global || len(h.Counties) == 0 || slices.Contains(h.Counties, subdivision)
+}
+
+// regionalHolidaysEntry is the cached list of holidays of a country and year, or the error fetching it
+type regionalHolidaysEntry struct {
+	holidays []nagerHoliday
+	err      error
+	expires  time.Time
+}
+
+var (
+	regionalHolidaysMu     sync.RWMutex
+	regionalHolidaysCache  = make(map[string]regionalHolidaysEntry)
+	regionalHolidaysClient = &http.Client{Timeout: regionalHolidaysTimeout}
+)
+
+// PrefetchHolidays loads the regional holidays of the given codes ("DE-BY") for the years from..to in parallel,
+// before checking the dates of a range, so that they are fetched once per country and year rather than per date
+// Codes without a region are computed locally and need no prefetch
+func PrefetchHolidays(from, to time.Time, codes ...string) {
+	var wg sync.WaitGroup
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		country, _, ok := strings.Cut(code, "-")
+		if !ok {
+			continue
+		}
+		country = strings.ToUpper(country)
+		for year := from.Year(); year <= to.Year(); year++ {
+			key := country + "-" + strconv.Itoa(year)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			wg.Go(func() { _, _ = countryHolidays(country, year) })
+		}
+	}
+	wg.Wait()
+}
+
+// isRegionalHoliday checks if a date is a public holiday in a subdivision (e.g. "DE-BY") of a country:
+// a nationwide holiday or one of the holidays of that region
+func isRegionalHoliday(date time.Time, country, subdivision string) bool {
+	list, err := countryHolidays(strings.ToUpper(country), date.Year())
+	if err != nil {
+		return false
+	}
+
+	day := date.Format("2006-01-02")
+	for _, holiday := range list {
+		if holiday.Date == day && holiday.observedIn(strings.ToUpper(subdivision)) {
+			return true
+		}
+	}
+	return false
+}
+
+// countryHolidays returns the holidays of a country for a year, from the cache when fresh
+// Failures are cached too, for regionalHolidaysBackoff
+func countryHolidays(country string, year int) ([]nagerHoliday, error) {
+	key := country + "-" + strconv.Itoa(year)
+
+	regionalHolidaysMu.RLock()
+	entry, found := regionalHolidaysCache[key]
+	regionalHolidaysMu.RUnlock()
+	if found && time.Now().Before(entry.expires) {
+		return entry.holidays, entry.err
+	}
+
+	list, err := fetchCountryHolidays(country, year)
+	entry = regionalHolidaysEntry{holidays: list, err: err, expires: time.Now().Add(regionalHolidaysTTL)}
+	if err != nil {
+		entry.expires = time.Now().Add(regionalHolidaysBackoff)
+	}
+
+	regionalHolidaysMu.Lock()
+	regionalHolidaysCache[key] = entry
+	regionalHolidaysMu.Unlock()
+
+	return list, err
+}
+
+// fetchCountryHolidays fetches the holidays of a country for a year from the Nager.Date API
+func fetchCountryHolidays(country string, year int) ([]nagerHoliday, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), regionalHolidaysTimeout)
+	defer cancel()
+
+	url := nagerAPIURL + "/" + strconv.Itoa(year) + "/" + country
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := regionalHolidaysClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nager: unexpected status %d", resp.StatusCode)
+	}
+
+	var list []nagerHoliday
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,172 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package datevalidation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// withNagerServer points the holidays API at a test server for the duration of a test
+func withNagerServer(t *testing.T, handler http.HandlerFunc) *atomic.Int32 {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	previous := nagerAPIURL
+	nagerAPIURL = server.URL
+	t.Cleanup(func() { nagerAPIURL = previous })
+	return &requests
+}
+
+func TestIsRegionalHoliday(t *testing.T) {
+	// Seed the cache so the test does not hit the network
+	regionalHolidaysMu.Lock()
+	regionalHolidaysCache["DE-2025"] = regionalHolidaysEntry{
+		holidays: []nagerHoliday{
+			{Date: "2025-10-03", Global: true},
+			{Date: "2025-01-06", Counties: []string{"DE-BW", "DE-BY", "DE-ST"}},
+		},
+		expires: time.Now().Add(time.Hour),
+	}
+	regionalHolidaysMu.Unlock()
+
+	tests := []struct {
+		name string
+		date string
+		code string
+		want bool
+	}{
+		{name: "nationwide holiday", date: "2025-10-03", code: "DE-BE", want: true},
+		{name: "holiday of the region", date: "2025-01-06", code: "DE-BY", want: true},
+		{name: "holiday of other regions", date: "2025-01-06", code: "DE-BE", want: false},
+		{name: "lowercase code", date: "2025-01-06", code: "de-by", want: true},
+		{name: "regular day", date: "2025-01-07", code: "DE-BY", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", tt.date)
+			if err != nil {
+				t.Fatalf("invalid test date: %v", err)
+			}
+
+			if got := IsHoliday(date, tt.code); got != tt.want {
+				t.Errorf("IsHoliday(%s, %s) = %v, want %v", tt.date, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountryHolidays(t *testing.T) {
+	regionalHolidaysMu.Lock()
+	regionalHolidaysCache["DE-2025"] = regionalHolidaysEntry{
+		holidays: []nagerHoliday{{Date: "2025-01-06", Counties: []string{"DE-BY"}}},
+		expires:  time.Now().Add(time.Hour),
+	}
+	regionalHolidaysCache["AT-2025"] = regionalHolidaysEntry{
+		holidays: []nagerHoliday{{Date: "2025-10-26", Global: true}},
+		expires:  time.Now().Add(time.Hour),
+	}
+	regionalHolidaysMu.Unlock()
+
+	countries := CountryHolidays{"AT-9", "DE-BY"}
+	for _, day := range []string{"2025-01-06", "2025-10-26"} {
+		date, _ := time.Parse("2006-01-02", day)
+		if !countries.IsHoliday(date) {
+			t.Errorf("Expected %s to be a holiday in one of %v", day, countries)
+		}
+	}
+
+	date, _ := time.Parse("2006-01-02", "2025-01-07")
+	if countries.IsHoliday(date) {
+		t.Errorf("Expected 2025-01-07 not to be a holiday in %v", countries)
+	}
+}
+
+func TestIsRegionalHoliday_APIFailure(t *testing.T) {
+	requests := withNagerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	start := time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for day := range 60 {
+		if IsHoliday(start.AddDate(0, 0, day), "XA-BY") {
+			t.Fatal("Expected no holiday when the API fails")
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected the failure to be cached after 1 request, got %d", got)
+	}
+
+	// Once the backoff is over, the API is tried again
+	regionalHolidaysMu.Lock()
+	entry := regionalHolidaysCache["XA-2031"]
+	entry.expires = time.Now().Add(-time.Second)
+	regionalHolidaysCache["XA-2031"] = entry
+	regionalHolidaysMu.Unlock()
+
+	IsHoliday(start, "XA-BY")
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected a new request after the backoff, got %d requests", got)
+	}
+}
+
+func TestPrefetchHolidays(t *testing.T) {
+	requests := withNagerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"date": "2032-01-06", "global": false, "counties": ["XB-BY"]}]`))
+	})
+
+	from := time.Date(2032, time.December, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2033, time.January, 31, 0, 0, 0, 0, time.UTC)
+	PrefetchHolidays(from, to, "XB-BY", "xb-be", "XC-1", "FR")
+	if got := requests.Load(); got != 4 {
+		t.Fatalf("Expected 1 request per country and year (4), got %d", got)
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		IsHoliday(date, "XB-BY")
+		IsHoliday(date, "XC-1")
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("Expected the dates to be checked from the cache, got %d requests", got)
+	}
+	if !IsHoliday(time.Date(2032, time.January, 6, 0, 0, 0, 0, time.UTC), "XB-BY") {
+		t.Error("Expected the prefetched holiday to be found")
+	}
+}
+
+func TestIsValidHolidayCode(t *testing.T) {
+	for _, code := range []string{"FR", "DE-BY", "AU-NSW", "AT-9"} {
+		if !IsValidHolidayCode(code) {
+			t.Errorf("Expected %q to be valid", code)
+		}
+	}
+	for _, code := range []string{"", "fr", "FRA", "DE-", "DE-BAVA", "DE BY"} {
+		if IsValidHolidayCode(code) {
+			t.Errorf("Expected %q to be invalid", code)
+		}
+	}
+}
+
+func TestHolidayCode(t *testing.T) {
+	if got := HolidayCode("Europe/Berlin", "AT", ""); got != "AT" {
+		t.Errorf("Expected the explicit country to win over the timezone, got %q", got)
+	}
+	if got := HolidayCode("Europe/Berlin", "de", "by"); got != "DE-BY" {
+		t.Errorf("Expected DE-BY, got %q", got)
+	}
+	if got := HolidayCode("UTC", "", "BY"); got != "" {
+		t.Errorf("Expected no holiday country for UTC without an explicit country, got %q", got)
+	}
+}
@@ -0,0 +1,170 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package email
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// tokenExpiryMargin is how long before its expiry an access token is renewed
+const tokenExpiryMargin = time.Minute
+
+// OAuth2Config holds the credentials of the SMTP XOAUTH2 authentication (Google Workspace, Microsoft 365)
+// With a refresh token, access tokens are obtained with the refresh token grant, otherwise with client credentials
+type OAuth2Config struct {
+	TokenURL     string // e.g. https://oauth2.googleapis.com/token
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	Scopes       []string // e.g. https://mail.google.com/ or https://outlook.office365.com/.default
+}
+
+// Enabled returns true when XOAUTH2 replaces the password authentication
+func (c OAuth2Config) Enabled() bool {
+	return c.TokenURL != "" || c.ClientID != ""
+}
+
+// Validate checks that the settings of the token request and the mailbox username are present
+func (c OAuth2Config) Validate(username string) error {
+	if c.TokenURL == "" || c.ClientID == "" {
+		return errors.New("OAuth2 token URL and client ID are required")
+	}
+	if username == "" {
+		return errors.New("OAuth2 needs the SMTP username, the address of the mailbox")
+	}
+	return nil
+}
+
+// smtpAuth returns the authentication of the SMTP server: XOAUTH2 with a valid access token, PLAIN with a password
+func (s *Service) smtpAuth(cfg Config) (smtp.Auth, error) {
+	if cfg.OAuth2.Enabled() {
+		if err := cfg.OAuth2.Validate(cfg.Username); err != nil {
+			return nil, err
+		}
+		token, err := s.accessToken(cfg.OAuth2)
+		if err != nil {
+			return nil, err
+		}
+		return &xoauth2Auth{username: cfg.Username, token: token, host: cfg.Host}, nil
+	}
+	if cfg.Username != "" && cfg.Password != "" {
+		return smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host), nil
+	}
+	return nil, nil
+}
+
+// accessToken returns the cached access token, requesting a new one when it is about to expire
+func (s *Service) accessToken(cfg OAuth2Config) (string, error) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.tokenExpiry) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := requestToken(s.client, cfg)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.tokenExpiry = time.Now().Add(expiresIn - tokenExpiryMargin)
+	return token, nil
+}
+
+// resetToken drops the cached access token, after a configuration change or a rejected authentication
+func (s *Service) resetToken() {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+	s.token = ""
+}
+
+// requestToken calls the token endpoint and returns the access token and its lifetime
+func requestToken(client *http.Client, cfg OAuth2Config) (string, time.Duration, error) {
+	form := url.Values{
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+	}
+	if cfg.RefreshToken != "" {
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", cfg.RefreshToken)
+	} else {
+		form.Set("grant_type", "client_credentials")
+	}
+	if len(cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequest(http.MethodPost, cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("OAuth2 token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return "", 0, fmt.Errorf("invalid OAuth2 token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		// invalid_grant: the refresh token was revoked or expired, invalid_client: wrong client secret
+		return "", 0, fmt.Errorf("%w: OAuth2 token endpoint returned %d %s %s",
+			ErrUnauthorized, resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+
+	expiresIn := time.Duration(body.ExpiresIn) * time.Second
+	if expiresIn <= tokenExpiryMargin {
+		// No or short lifetime: use the token for this email only
+		expiresIn = tokenExpiryMargin
+	}
+	return body.AccessToken, expiresIn, nil
+}
+
+// xoauth2Auth implements the XOAUTH2 SMTP mechanism
+// See https://developers.google.com/gmail/imap/xoauth2-protocol
+type xoauth2Auth struct {
+	username, token, host string
+}
+
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// Like PLAIN, the token must not be sent in clear text, except to localhost
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "XOAUTH2", []byte("user=" + a.username + "\x01auth=Bearer " + a.token + "\x01\x01"), nil
+}
+
+// Next answers the error challenge of a rejected token with an empty response, so the server ends the exchange
+func (a *xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		return []byte{}, nil
+	}
+	return nil, nil
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
@@ -0,0 +1,109 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package email
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAccessToken(t *testing.T) {
+	var calls atomic.Int32
+	var form atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = r.ParseForm()
+		form.Store(r.PostForm)
+		if r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token-` + r.PostForm.Get("grant_type") + `","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		Host:     "smtp.example.com",
+		Username: "events@example.com",
+		OAuth2: OAuth2Config{
+			TokenURL:     server.URL,
+			ClientID:     "client",
+			ClientSecret: "secret",
+			RefreshToken: "refresh",
+			Scopes:       []string{"https://mail.google.com/"},
+		},
+	}
+	service := NewService(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for range 2 {
+		auth, err := service.smtpAuth(cfg)
+		if err != nil {
+			t.Fatalf("smtpAuth() error = %v", err)
+		}
+		if xoauth2, ok := auth.(*xoauth2Auth); !ok || xoauth2.token != "token-refresh_token" {
+			t.Fatalf("smtpAuth() = %#v, want an XOAUTH2 auth with the refreshed token", auth)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("token requests = %d, want 1 (cached token)", calls.Load())
+	}
+	if got := form.Load().(url.Values); got.Get("refresh_token") != "refresh" || got.Get("scope") != "https://mail.google.com/" {
+		t.Errorf("token request form = %v", got)
+	}
+
+	// Client credentials without a refresh token, after a reconfiguration drops the cached token
+	cfg.OAuth2.RefreshToken = ""
+	service.Reconfigure(cfg)
+	if token, err := service.accessToken(cfg.OAuth2); err != nil || token != "token-client_credentials" {
+		t.Errorf("accessToken() = %q, %v, want the client credentials token", token, err)
+	}
+
+	cfg.OAuth2.ClientSecret = "wrong"
+	service.Reconfigure(cfg)
+	if _, err := service.accessToken(cfg.OAuth2); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("accessToken() with a wrong secret error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestXOAUTH2Auth(t *testing.T) {
+	auth := &xoauth2Auth{username: "events@example.com", token: "ya29.token", host: "smtp.gmail.com"}
+
+	proto, resp, err := auth.Start(&smtp.ServerInfo{Name: "smtp.gmail.com", TLS: true})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if want := "user=events@example.com\x01auth=Bearer ya29.token\x01\x01"; proto != "XOAUTH2" || string(resp) != want {
+		t.Errorf("Start() = %q, %q, want XOAUTH2, %q", proto, resp, want)
+	}
+
+	if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.gmail.com"}); err == nil {
+		t.Error("Start() over an unencrypted connection should fail")
+	}
+	if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.other.com", TLS: true}); err == nil {
+		t.Error("Start() with another host should fail")
+	}
+}
+
+func TestOAuth2ConfigValidate(t *testing.T) {
+	oauth := OAuth2Config{TokenURL: "https://oauth2.googleapis.com/token", ClientID: "client"}
+	if err := oauth.Validate(""); err == nil {
+		t.Error("Validate() without the mailbox username should fail")
+	}
+	if err := oauth.Validate("events@example.com"); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (OAuth2Config{ClientID: "client"}).Validate("events@example.com"); err == nil {
+		t.Error("Validate() without the token URL should fail")
+	}
+}
@@ -0,0 +1,437 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Email providers, selected by Config.Provider
+const (
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+	ProviderMailgun  = "mailgun"
+	ProviderSES      = "ses"
+	ProviderPostmark = "postmark"
+)
+
+// Errors wrapped by ProviderError, so callers can react to a failure whatever the provider
+var (
+	ErrUnauthorized = errors.New("email provider rejected the credentials")
+	ErrRejected     = errors.New("email provider rejected the message")
+	ErrRateLimited  = errors.New("email provider rate limit exceeded")
+	ErrUnavailable  = errors.New("email provider unavailable")
+)
+
+const (
+	// apiTimeout bounds each request to a provider API
+	apiTimeout = 30 * time.Second
+	// apiMaxRetries is the number of retries of a rate limited email, after waiting for the Retry-After delay
+	// Longer delays are left to the callers, which retry failed notifications later
+	apiMaxRetries   = 2
+	apiMaxRetryWait = 10 * time.Second
+	apiDefaultWait  = time.Second
+)
+
+// ProviderError is a failed provider API request
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Message    string        // Error reported by the provider, if any
+	RetryAfter time.Duration // Delay before retrying a rate limited request
+	err        error
+}
+
+func (e *ProviderError) Error() string {
+	msg := fmt.Sprintf("%s: HTTP %d", e.Provider, e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Unwrap returns ErrUnauthorized, ErrRejected, ErrRateLimited or ErrUnavailable
+func (e *ProviderError) Unwrap() error {
+	return e.err
+}
+
+// provider sends emails through the HTTP API of a transactional email service
+type provider struct {
+	// validate checks that the settings the provider needs are present
+	validate func(cfg Config) error
+	// send builds the request sending an email
+	send func(cfg Config, email Email) (*http.Request, error)
+	// verify builds a read-only request checking the credentials
+	verify func(cfg Config) (*http.Request, error)
+	// errorMessage extracts the error message of a response body
+	errorMessage func(body []byte) string
+}
+
+var providers = map[string]provider{
+	ProviderSendGrid: {validate: requireAPIKey, send: sendGridSend, verify: sendGridVerify, errorMessage: sendGridError},
+	ProviderMailgun:  {validate: mailgunValidate, send: mailgunSend, verify: mailgunVerify, errorMessage: jsonMessage},
+	ProviderSES:      {validate: sesValidate, send: sesSend, verify: sesVerify, errorMessage: jsonMessage},
+	ProviderPostmark: {validate: requireAPIKey, send: postmarkSend, verify: postmarkVerify, errorMessage: jsonMessage},
+}
+
+// Providers returns the supported providers, SMTP first
+func Providers() []string {
+	names := []string{ProviderSMTP}
+	for name := range providers {
+		names = append(names, name)
+	}
+	slices.Sort(names[1:])
+	return names
+}
+
+// Validate checks that the provider is known and that its settings are present
+// An unconfigured SMTP server is valid: email features are then disabled
+func (cfg Config) Validate() error {
+	if !cfg.usesAPI() {
+		if cfg.Provider != "" && cfg.Provider != ProviderSMTP {
+			return fmt.Errorf("unknown email provider %q (expected one of %s)", cfg.Provider, strings.Join(Providers(), ", "))
+		}
+		return nil
+	}
+	return providers[cfg.Provider].validate(cfg)
+}
+
+// usesAPI reports whether emails are sent through a provider API rather than SMTP
+func (cfg Config) usesAPI() bool {
+	_, ok := providers[cfg.Provider]
+	return ok
+}
+
+// provider returns the name of the transport, for logs
+func (cfg Config) provider() string {
+	if cfg.Provider == "" {
+		return ProviderSMTP
+	}
+	return cfg.Provider
+}
+
+// baseURL returns the endpoint override, or the default API URL of the provider
+func (cfg Config) baseURL(defaultURL string) string {
+	if cfg.Endpoint != "" {
+		return strings.TrimSuffix(cfg.Endpoint, "/")
+	}
+	return defaultURL
+}
+
+// sendAPI sends an email through the provider, retrying rate limited requests after a short delay
+func (s *Service) sendAPI(cfg Config, email Email) error {
+	p := providers[cfg.Provider]
+	if err := p.validate(cfg); err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := p.send(cfg, email)
+		if err != nil {
+			return err
+		}
+		err = doAPI(s.client, cfg, p, req)
+
+		var providerErr *ProviderError
+		if !errors.As(err, &providerErr) || !errors.Is(err, ErrRateLimited) || attempt >= apiMaxRetries {
+			return err
+		}
+		wait := providerErr.RetryAfter
+		if wait > apiMaxRetryWait {
+			return err
+		}
+		s.logger.Warn("Email provider rate limit exceeded, retrying",
+			"provider", cfg.Provider, "retry_after", wait.String())
+		time.Sleep(wait)
+	}
+}
+
+// verifyAPI checks the provider credentials without sending anything
+func (s *Service) verifyAPI(cfg Config, timeout time.Duration) error {
+	p := providers[cfg.Provider]
+	if err := p.validate(cfg); err != nil {
+		return err
+	}
+	req, err := p.verify(cfg)
+	if err != nil {
+		return err
+	}
+	return doAPI(&http.Client{Timeout: timeout}, cfg, p, req)
+}
+
+// doAPI performs a provider request and maps its failure to a ProviderError
+func doAPI(client *http.Client, cfg Config, p provider, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cfg.Provider, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	providerErr := &ProviderError{
+		Provider:   cfg.Provider,
+		StatusCode: resp.StatusCode,
+		Message:    p.errorMessage(body),
+		err:        statusError(resp.StatusCode),
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		providerErr.RetryAfter = retryAfter(resp.Header.Get("Retry-After"))
+	}
+	return providerErr
+}
+
+// statusError maps the status code of a failed request to the error it wraps
+func statusError(status int) error {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrUnauthorized
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status >= 500:
+		return ErrUnavailable
+	default:
+		return ErrRejected
+	}
+}
+
+// retryAfter parses a Retry-After header, in seconds or as an HTTP date, defaulting to apiDefaultWait
+func retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return apiDefaultWait
+}
+
+// jsonMessage extracts the "message" or "Message" field of a JSON error body (Mailgun, SES, Postmark)
+func jsonMessage(body []byte) string {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return ""
+	}
+	return payload.Message
+}
+
+// newJSONRequest creates a request with a JSON body
+func newJSONRequest(method, url string, payload any) (*http.Request, []byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return req, body, nil
+}
+
+func requireAPIKey(cfg Config) error {
+	if cfg.APIKey == "" {
+		return fmt.Errorf("%s: API key not configured", cfg.Provider)
+	}
+	return nil
+}
+
+// SendGrid: https://www.twilio.com/docs/sendgrid/api-reference/mail-send/mail-send
+
+func sendGridSend(cfg Config, email Email) (*http.Request, error) {
+	type address struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+	to := make([]address, len(email.To))
+	for i, recipient := range email.To {
+		to[i] = address{Email: recipient}
+	}
+	contentType := "text/plain"
+	if email.HTML {
+		contentType = "text/html"
+	}
+
+	req, _, err := newJSONRequest(http.MethodPost, cfg.baseURL("https://api.sendgrid.com")+"/v3/mail/send", map[string]any{
+		"personalizations": []map[string]any{{"to": to}},
+		"from":             address{Email: cfg.FromAddress, Name: cfg.FromName},
+		"subject":          email.Subject,
+		"content":          []map[string]string{{"type": contentType, "value": email.Body}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+	return req, nil
+}
+
+func sendGridVerify(cfg Config) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, cfg.baseURL("https://api.sendgrid.com")+"/v3/scopes", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+	return req, nil
+}
+
+// sendGridError extracts the first error of a SendGrid response body
+func sendGridError(body []byte) string {
+	var payload struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) != nil || len(payload.Errors) == 0 {
+		return ""
+	}
+	return payload.Errors[0].Message
+}
+
+// Mailgun: https://documentation.mailgun.com/docs/mailgun/api-reference/send/mailgun/messages
+
+func mailgunValidate(cfg Config) error {
+	if cfg.APIKey == "" || cfg.Domain == "" {
+		return fmt.Errorf("%s: API key and sending domain are required", cfg.Provider)
+	}
+	return nil
+}
+
+// mailgunURL returns the API URL of the region of the account
+func mailgunURL(cfg Config) string {
+	if strings.EqualFold(cfg.Region, "eu") {
+		return cfg.baseURL("https://api.eu.mailgun.net")
+	}
+	return cfg.baseURL("https://api.mailgun.net")
+}
+
+func mailgunSend(cfg Config, email Email) (*http.Request, error) {
+	form := url.Values{}
+	form.Set("from", cfg.fromHeader())
+	for _, recipient := range email.To {
+		form.Add("to", recipient)
+	}
+	form.Set("subject", email.Subject)
+	if email.HTML {
+		form.Set("html", email.Body)
+	} else {
+		form.Set("text", email.Body)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, mailgunURL(cfg)+"/v3/"+url.PathEscape(cfg.Domain)+"/messages",
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("api", cfg.APIKey)
+	return req, nil
+}
+
+func mailgunVerify(cfg Config) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, mailgunURL(cfg)+"/v3/domains/"+url.PathEscape(cfg.Domain), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth("api", cfg.APIKey)
+	return req, nil
+}
+
+// Amazon SES (API v2): https://docs.aws.amazon.com/ses/latest/APIReference-V2/API_SendEmail.html
+
+func sesValidate(cfg Config) error {
+	if cfg.APIKey == "" || cfg.APISecret == "" || cfg.Region == "" {
+		return fmt.Errorf("%s: access key ID, secret access key and region are required", cfg.Provider)
+	}
+	return nil
+}
+
+func sesURL(cfg Config) string {
+	return cfg.baseURL("https://email." + cfg.Region + ".amazonaws.com")
+}
+
+func sesSend(cfg Config, email Email) (*http.Request, error) {
+	type content struct {
+		Data    string `json:"Data"`
+		Charset string `json:"Charset"`
+	}
+	body := map[string]content{"Text": {Data: email.Body, Charset: "UTF-8"}}
+	if email.HTML {
+		body = map[string]content{"Html": {Data: email.Body, Charset: "UTF-8"}}
+	}
+
+	req, payload, err := newJSONRequest(http.MethodPost, sesURL(cfg)+"/v2/email/outbound-emails", map[string]any{
+		"FromEmailAddress": cfg.fromHeader(),
+		"Destination":      map[string][]string{"ToAddresses": email.To},
+		"Content": map[string]any{
+			"Simple": map[string]any{
+				"Subject": content{Data: email.Subject, Charset: "UTF-8"},
+				"Body":    body,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	signAWSv4(req, payload, cfg.APIKey, cfg.APISecret, cfg.Region, "ses", time.Now())
+	return req, nil
+}
+
+func sesVerify(cfg Config) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, sesURL(cfg)+"/v2/email/account", nil)
+	if err != nil {
+		return nil, err
+	}
+	signAWSv4(req, nil, cfg.APIKey, cfg.APISecret, cfg.Region, "ses", time.Now())
+	return req, nil
+}
+
+// Postmark: https://postmarkapp.com/developer/api/email-api
+
+func postmarkSend(cfg Config, email Email) (*http.Request, error) {
+	payload := map[string]string{
+		"From":          cfg.fromHeader(),
+		"To":            strings.Join(email.To, ", "),
+		"Subject":       email.Subject,
+		"MessageStream": "outbound",
+	}
+	if email.HTML {
+		payload["HtmlBody"] = email.Body
+	} else {
+		payload["TextBody"] = email.Body
+	}
+
+	req, _, err := newJSONRequest(http.MethodPost, cfg.baseURL("https://api.postmarkapp.com")+"/email", payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Postmark-Server-Token", cfg.APIKey)
+	return req, nil
+}
+
+func postmarkVerify(cfg Config) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, cfg.baseURL("https://api.postmarkapp.com")+"/server", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Postmark-Server-Token", cfg.APIKey)
+	return req, nil
+}
@@ -0,0 +1,150 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package email
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSignAWSv4(t *testing.T) {
+	// "get-vanilla" case of the AWS Signature Version 4 test suite
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	signAWSv4(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service",
+		time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"smtp by default", Config{}, false},
+		{"smtp", Config{Provider: ProviderSMTP, Host: "smtp.example.com"}, false},
+		{"unknown provider", Config{Provider: "sparkpost"}, true},
+		{"sendgrid", Config{Provider: ProviderSendGrid, APIKey: "key"}, false},
+		{"sendgrid without key", Config{Provider: ProviderSendGrid}, true},
+		{"mailgun without domain", Config{Provider: ProviderMailgun, APIKey: "key"}, true},
+		{"ses", Config{Provider: ProviderSES, APIKey: "id", APISecret: "secret", Region: "eu-west-1"}, false},
+		{"ses without region", Config{Provider: ProviderSES, APIKey: "id", APISecret: "secret"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendAPI(t *testing.T) {
+	var request struct {
+		path, auth string
+		body       []byte
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request.path = r.URL.Path
+		request.auth = r.Header.Get("Authorization") + r.Header.Get("X-Postmark-Server-Token")
+		request.body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	msg := Email{To: []string{"alice@example.com"}, Subject: "Hello", Body: "<p>Hi</p>", HTML: true}
+	tests := []struct {
+		cfg      Config
+		path     string
+		auth     string
+		contains string
+	}{
+		{Config{Provider: ProviderSendGrid, APIKey: "sg-key"}, "/v3/mail/send", "Bearer sg-key", `"type":"text/html"`},
+		{Config{Provider: ProviderMailgun, APIKey: "mg-key", Domain: "mg.example.com"}, "/v3/mg.example.com/messages", "Basic ", "html=%3Cp%3EHi%3C%2Fp%3E"},
+		{Config{Provider: ProviderSES, APIKey: "AKID", APISecret: "secret", Region: "eu-west-1"}, "/v2/email/outbound-emails", "AWS4-HMAC-SHA256 Credential=AKID/", `"ToAddresses":["alice@example.com"]`},
+		{Config{Provider: ProviderPostmark, APIKey: "pm-token"}, "/email", "pm-token", `"HtmlBody":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cfg.Provider, func(t *testing.T) {
+			tt.cfg.Endpoint = server.URL
+			tt.cfg.FromAddress = "noreply@example.com"
+			service := NewService(tt.cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if !service.IsConfigured() {
+				t.Fatal("IsConfigured() = false, want true")
+			}
+
+			if err := service.Send(msg); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if request.path != tt.path {
+				t.Errorf("path = %q, want %q", request.path, tt.path)
+			}
+			if !strings.HasPrefix(request.auth, tt.auth) {
+				t.Errorf("credentials = %q, want prefix %q", request.auth, tt.auth)
+			}
+			if !strings.Contains(string(request.body), tt.contains) {
+				t.Errorf("body = %s, want it to contain %s", request.body, tt.contains)
+			}
+		})
+	}
+}
+
+func TestSendAPIErrors(t *testing.T) {
+	var calls, status atomic.Int32
+	status.Store(http.StatusTooManyRequests)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 || status.Load() != http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(int(status.Load()))
+			_ = json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]string{{"message": "nope"}}})
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	service := NewService(Config{Provider: ProviderSendGrid, APIKey: "key", Endpoint: server.URL},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	msg := Email{To: []string{"alice@example.com"}, Subject: "Hello", Body: "Hi"}
+
+	// A rate limited email is retried once the Retry-After delay is over
+	if err := service.Send(msg); err != nil {
+		t.Fatalf("Send() after rate limit error = %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2", calls.Load())
+	}
+
+	tests := map[int]error{
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusBadRequest:          ErrRejected,
+		http.StatusServiceUnavailable:  ErrUnavailable,
+		http.StatusUnprocessableEntity: ErrRejected,
+	}
+	for code, want := range tests {
+		status.Store(int32(code))
+		err := service.Send(msg)
+		if !errors.Is(err, want) {
+			t.Errorf("Send() with HTTP %d error = %v, want %v", code, err, want)
+		}
+		var providerErr *ProviderError
+		if !errors.As(err, &providerErr) || providerErr.Message != "nope" {
+			t.Errorf("Send() with HTTP %d error = %v, want the provider message", code, err)
+		}
+	}
+}
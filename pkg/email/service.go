@@ -5,47 +5,93 @@
 package email
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
 	"net/smtp"
+	"net/textproto"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
-// Service handles email sending via SMTP
+// Service handles email sending via SMTP, or the HTTP API of a transactional email provider
 type Service struct {
-	host        string
-	port        int
-	username    string
-	password    string
-	fromAddress string
-	fromName    string
-	logger      *slog.Logger
+	mu     sync.RWMutex
+	cfg    Config
+	client *http.Client
+	queue  Queue
+	logger *slog.Logger
+
+	// XOAUTH2 access token, reused until it expires
+	tokenMu     sync.Mutex
+	token       string
+	tokenExpiry time.Time
 }
 
 // Config holds email service configuration
 type Config struct {
+	Provider    string // ProviderSMTP (default), or one of the API providers
 	Host        string
 	Port        int
 	Username    string
 	Password    string
 	FromAddress string
 	FromName    string
+	OAuth2      OAuth2Config // XOAUTH2 instead of the password, when enabled
+
+	// API providers
+	APIKey    string // SendGrid and Mailgun API key, Postmark server token, SES access key ID
+	APISecret string // SES secret access key
+	Domain    string // Mailgun sending domain
+	Region    string // Mailgun region ("us" or "eu"), SES AWS region (e.g. eu-west-1)
+	Endpoint  string // Base URL replacing the provider's default one, e.g. for a proxy
+}
+
+// queueTimeout bounds the queueing of an email, after which it is sent right away
+const queueTimeout = 5 * time.Second
+
+// Queue stores the emails of Send, so that a worker delivers them with SendNow and retries the failed ones
+type Queue interface {
+	Enqueue(ctx context.Context, email Email) error
 }
 
 // NewService creates a new email service
 func NewService(cfg Config, logger *slog.Logger) *Service {
 	return &Service{
-		host:        cfg.Host,
-		port:        cfg.Port,
-		username:    cfg.Username,
-		password:    cfg.Password,
-		fromAddress: cfg.FromAddress,
-		fromName:    cfg.FromName,
-		logger:      logger,
+		cfg:    cfg,
+		client: &http.Client{Timeout: apiTimeout},
+		logger: logger,
 	}
 }
 
+// SetQueue makes Send queue the emails instead of sending them right away
+// It must be called at startup, before emails are sent
+func (s *Service) SetQueue(queue Queue) {
+	s.queue = queue
+}
+
+// Reconfigure replaces the SMTP or provider settings used by the next emails
+// Emails being sent keep the settings they started with
+func (s *Service) Reconfigure(cfg Config) {
+	s.mu.Lock()
+	s.cfg = cfg
+	s.mu.Unlock()
+	s.resetToken()
+}
+
+// config returns the current SMTP or provider settings
+func (s *Service) config() Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg
+}
+
 // Email represents an email message
 type Email struct {
 	To      []string
@@ -54,15 +100,59 @@ type Email struct {
 	HTML    bool
 }
 
-// Send sends an email via SMTP
+// Send queues an email when the service has a queue, or sends it right away
+// Without queue, or if the email can't be queued, the error is the outcome of the delivery
 func (s *Service) Send(email Email) error {
+	if s.queue != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), queueTimeout)
+		defer cancel()
+		err := s.queue.Enqueue(ctx, email)
+		if err == nil {
+			return nil
+		}
+		s.logger.Error("Failed to queue email, sending it right away", slog.String("error", err.Error()))
+	}
+	return s.SendNow(email)
+}
+
+// SendNow sends an email via SMTP or the configured provider, bypassing the queue
+// It is used by the queue worker, and by the senders reporting or retrying failures themselves
+func (s *Service) SendNow(email Email) error {
+	cfg := s.config()
+	to := strings.Join(email.To, ", ")
+
+	var err error
+	if cfg.usesAPI() {
+		err = s.sendAPI(cfg, email)
+	} else {
+		err = s.sendSMTP(cfg, email)
+	}
+	if err != nil {
+		s.logger.Error("Failed to send email",
+			slog.String("provider", cfg.provider()),
+			slog.String("error", err.Error()),
+			slog.String("to", to),
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("Email sent successfully",
+		slog.String("to", to),
+		slog.String("subject", email.Subject),
+	)
+
+	return nil
+}
+
+// sendSMTP sends an email to the SMTP server
+func (s *Service) sendSMTP(cfg Config, email Email) error {
 	// Validate configuration
-	if s.host == "" {
+	if cfg.Host == "" {
 		return fmt.Errorf("SMTP host not configured")
 	}
 
 	// Build message
-	from := s.buildFromHeader()
+	from := cfg.fromHeader()
 	to := strings.Join(email.To, ", ")
 
 	var contentType string
@@ -83,39 +173,30 @@ func (s *Service) Send(email Email) error {
 	)
 
 	// Connect to SMTP server
-	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 
 	// Setup authentication
-	var auth smtp.Auth
-	if s.username != "" && s.password != "" {
-		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	auth, err := s.smtpAuth(cfg)
+	if err != nil {
+		return err
 	}
 
 	// Try to send with TLS first (port 465 or explicit STARTTLS)
-	err := s.sendWithTLS(addr, auth, s.fromAddress, email.To, message)
-	if err != nil {
-		s.logger.Error("Failed to send email",
-			slog.String("error", err.Error()),
-			slog.String("to", to),
-		)
-		return fmt.Errorf("failed to send email: %w", err)
+	err = sendWithTLS(cfg, addr, auth, cfg.FromAddress, email.To, message)
+	if err != nil && cfg.OAuth2.Enabled() {
+		// The token may have been revoked before its expiry: the next email requests a new one
+		s.resetToken()
 	}
-
-	s.logger.Info("Email sent successfully",
-		slog.String("to", to),
-		slog.String("subject", email.Subject),
-	)
-
-	return nil
+	return err
 }
 
 // sendWithTLS attempts to send email with TLS/STARTTLS
-func (s *Service) sendWithTLS(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+func sendWithTLS(cfg Config, addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
 	// For port 465 (implicit TLS)
-	if s.port == 465 {
+	if cfg.Port == 465 {
 		// Create TLS config
 		tlsConfig := &tls.Config{
-			ServerName: s.host,
+			ServerName: cfg.Host,
 		}
 
 		// Connect with TLS
@@ -126,7 +207,7 @@ func (s *Service) sendWithTLS(addr string, auth smtp.Auth, from string, to []str
 		defer conn.Close()
 
 		// Create SMTP client
-		client, err := smtp.NewClient(conn, s.host)
+		client, err := smtp.NewClient(conn, cfg.Host)
 		if err != nil {
 			return err
 		}
@@ -172,15 +253,90 @@ func (s *Service) sendWithTLS(addr string, auth smtp.Auth, from string, to []str
 	return smtp.SendMail(addr, auth, from, to, msg)
 }
 
-// buildFromHeader builds the From header with optional name
-func (s *Service) buildFromHeader() string {
-	if s.fromName != "" {
-		return fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress)
+// fromHeader builds the From header with optional name
+func (cfg Config) fromHeader() string {
+	if cfg.FromName != "" {
+		return fmt.Sprintf("%s <%s>", cfg.FromName, cfg.FromAddress)
 	}
-	return s.fromAddress
+	return cfg.FromAddress
 }
 
-// IsConfigured returns true if SMTP is configured
+// Verify connects to the SMTP server and authenticates without sending anything
+// Uses the same transport as Send: implicit TLS on port 465, STARTTLS when offered otherwise
+// With an API provider, it checks the credentials with a read-only request instead
+func (s *Service) Verify(timeout time.Duration) error {
+	cfg := s.config()
+	if cfg.usesAPI() {
+		return s.verifyAPI(cfg, timeout)
+	}
+	if cfg.Host == "" {
+		return fmt.Errorf("SMTP host not configured")
+	}
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: timeout}
+
+	var conn net.Conn
+	var err error
+	if cfg.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: cfg.Host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if cfg.Port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+				return fmt.Errorf("STARTTLS failed: %w", err)
+			}
+		}
+	}
+
+	auth, err := s.smtpAuth(cfg)
+	if err != nil {
+		return err
+	}
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	return client.Quit()
+}
+
+// IsPermanent reports whether a failed email would fail again: the provider or the SMTP server
+// refused the message or its recipient (a bounce), unlike network, authentication or rate limit errors
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrRejected) {
+		return true
+	}
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) {
+		switch smtpErr.Code {
+		case 550, 551, 553, 554: // Mailbox unavailable, user not local, mailbox name not allowed, transaction failed
+			return true
+		}
+	}
+	return false
+}
+
+// IsConfigured returns true if SMTP or the provider is configured
 func (s *Service) IsConfigured() bool {
-	return s.host != ""
+	cfg := s.config()
+	if cfg.usesAPI() {
+		return cfg.Validate() == nil
+	}
+	return cfg.Host != ""
 }
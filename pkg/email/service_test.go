@@ -0,0 +1,64 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/textproto"
+	"testing"
+)
+
+type queueFunc func(ctx context.Context, email Email) error
+
+func (f queueFunc) Enqueue(ctx context.Context, email Email) error { return f(ctx, email) }
+
+func TestSend_Queue(t *testing.T) {
+	service := NewService(Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var queued []Email
+	service.SetQueue(queueFunc(func(_ context.Context, email Email) error {
+		queued = append(queued, email)
+		return nil
+	}))
+	if err := service.Send(Email{To: []string{"alice@example.com"}, Subject: "Hello"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(queued) != 1 || queued[0].Subject != "Hello" {
+		t.Errorf("queued = %v, want the email", queued)
+	}
+
+	// An email that can't be queued is sent right away, failing here without SMTP host
+	service.SetQueue(queueFunc(func(context.Context, Email) error { return errors.New("database down") }))
+	if err := service.Send(Email{To: []string{"alice@example.com"}}); err == nil {
+		t.Error("Send() should report the delivery error when the email can't be queued")
+	}
+}
+
+func TestIsPermanent(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unknown mailbox", fmt.Errorf("failed to send email: %w", &textproto.Error{Code: 550, Msg: "5.1.1 User unknown"}), true},
+		{"spam rejection", &textproto.Error{Code: 554, Msg: "5.7.1 Message rejected"}, true},
+		{"mailbox busy", &textproto.Error{Code: 450, Msg: "4.2.1 Try again later"}, false},
+		{"authentication", &textproto.Error{Code: 535, Msg: "5.7.8 Bad credentials"}, false},
+		{"provider rejection", &ProviderError{Provider: ProviderPostmark, StatusCode: 422, err: ErrRejected}, true},
+		{"provider rate limit", &ProviderError{Provider: ProviderSendGrid, StatusCode: 429, err: ErrRateLimited}, false},
+		{"network", errors.New("dial tcp: connection refused"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPermanent(tt.err); got != tt.want {
+				t.Errorf("IsPermanent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
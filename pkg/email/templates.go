@@ -0,0 +1,38 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package email
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// LoadTemplate returns the source of an email template: the override file "<overrideDir>/<name>.html" when it exists,
+// otherwise the embedded template
+// An override that can't be read or parsed is reported with the embedded template, so emails keep being sent
+// An empty overrideDir disables overrides
+func LoadTemplate(embedded, overrideDir, name string) (string, error) {
+	if overrideDir == "" {
+		return embedded, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(overrideDir, name+".html"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return embedded, nil
+		}
+		return embedded, fmt.Errorf("failed to read email template override %s: %w", name, err)
+	}
+
+	// html/template shares the syntax of text/template, the escaping is checked when the template is executed
+	if _, err := template.New(name).Parse(string(data)); err != nil {
+		return embedded, fmt.Errorf("failed to parse email template override %s: %w", name, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,44 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package email
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "magic_link.html"), []byte("<p>{{.Greeting}} from Acme</p>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "digest.html"), []byte("<p>{{.Greeting</p>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	embedded := "<p>{{.Greeting}}</p>"
+	tests := []struct {
+		name, dir, template string
+		want                string
+		wantErr             bool
+	}{
+		{"overrides disabled", "", "magic_link", embedded, false},
+		{"override", dir, "magic_link", "<p>{{.Greeting}} from Acme</p>", false},
+		{"no override file", dir, "password_reset", embedded, false},
+		{"invalid override", dir, "digest", embedded, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadTemplate(embedded, tt.dir, tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LoadTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package httputil
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned when an outbound connection targets a non-public address
+var ErrPrivateAddress = errors.New("target resolves to a private address")
+
+// NewOutboundClient creates a client for requests to user-provided URLs (webhooks, CalDAV servers...)
+// Unless private addresses are allowed, connections to loopback, private and link-local addresses are
+// refused after DNS resolution (including on redirects), so user URLs can't be used to reach internal services
+func NewOutboundClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = NewOutboundDialer(allowPrivate).DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// NewOutboundDialer creates a dialer for connections to user-provided hosts (e.g. MQTT brokers)
+// Unless private addresses are allowed, it refuses non-public addresses like NewOutboundClient
+func NewOutboundDialer(allowPrivate bool) *net.Dialer {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return ErrPrivateAddress
+			}
+			return nil
+		}
+	}
+	return dialer
+}
+
+// IsPublicIP reports whether an IP address is routable on the internet
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast()
+}
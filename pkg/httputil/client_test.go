@@ -0,0 +1,58 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package httputil
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":         true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.0.0.1":        false,
+		"172.16.5.4":      false,
+		"192.168.0.1":     false,
+		"169.254.169.254": false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"224.0.0.1":       false,
+	}
+
+	for ip, want := range tests {
+		if got := IsPublicIP(net.ParseIP(ip)); got != want {
+			t.Errorf("IsPublicIP(%s) = %v, want %v", ip, got, want)
+		}
+	}
+}
+
+func TestNewOutboundClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, err := NewOutboundClient(time.Second, false).Get(server.URL)
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("Get(loopback) error = %v, want ErrPrivateAddress", err)
+	}
+
+	resp, err := NewOutboundClient(time.Second, true).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get(loopback) with private addresses allowed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want 204", resp.StatusCode)
+	}
+}
@@ -92,4 +92,5 @@ const (
 	ErrCodeConflict     = "CONFLICT"
 	ErrCodeValidation   = "VALIDATION_ERROR"
 	ErrCodeRateLimited  = "RATE_LIMITED"
+	ErrCodeMaintenance  = "MAINTENANCE"
 )
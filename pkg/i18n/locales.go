@@ -0,0 +1,56 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package i18n
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultLocale is used for unsupported locales, and for the keys missing from a locale
+const DefaultLocale = "en"
+
+// Locales are the locales of the embedded translations, the emails and notifications being available in all of them
+// Override files may add other locales to a bundle, but users and participants can only choose these
+var Locales = []string{"en", "fr", "de", "es", "nl", "it"}
+
+// IsSupported reports whether a locale is one of Locales
+func IsSupported(locale string) bool {
+	return slices.Contains(Locales, locale)
+}
+
+// Match returns the supported locale of a language tag such as "de-AT" or "FR", or DefaultLocale
+func Match(tag string) string {
+	language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	language, _, _ = strings.Cut(language, "_")
+	if IsSupported(language) {
+		return language
+	}
+	return DefaultLocale
+}
+
+// Lookup returns the strings of a locale, or those of DefaultLocale when the bundle doesn't have it
+func (t Translations) Lookup(locale string) map[string]string {
+	if keys, ok := t[locale]; ok {
+		return keys
+	}
+	return t[DefaultLocale]
+}
+
+// fillDefaults completes every locale with the DefaultLocale strings it is missing,
+// so that a partial locale, e.g. added by an override file, falls back to English key by key
+func fillDefaults(t Translations) {
+	defaults := t[DefaultLocale]
+	for locale, keys := range t {
+		if locale == DefaultLocale {
+			continue
+		}
+		for key, value := range defaults {
+			if _, ok := keys[key]; !ok {
+				keys[key] = value
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Translations maps a locale to its translation keys and strings
+type Translations map[string]map[string]string
+
+// Load parses embedded translations and merges the override file "<overrideDir>/<name>.json" on top of them
+// The override file is optional and may be partial: only the locales and keys it defines are replaced
+// An empty overrideDir disables overrides
+// Keys missing from a locale are filled with their DefaultLocale strings
+func Load(embedded string, overrideDir, name string) (Translations, error) {
+	var translations Translations
+	if err := json.Unmarshal([]byte(embedded), &translations); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded translations %s: %w", name, err)
+	}
+	fillDefaults(translations)
+
+	if overrideDir == "" {
+		return translations, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(overrideDir, name+".json"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return translations, nil
+		}
+		return translations, fmt.Errorf("failed to read translation overrides %s: %w", name, err)
+	}
+
+	var overrides Translations
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return translations, fmt.Errorf("failed to parse translation overrides %s: %w", name, err)
+	}
+
+	merged := Merge(translations, overrides)
+	fillDefaults(merged)
+	return merged, nil
+}
+
+// Merge returns base with every locale and key from overrides applied on top
+func Merge(base, overrides Translations) Translations {
+	merged := make(Translations, len(base))
+	for locale, keys := range base {
+		merged[locale] = make(map[string]string, len(keys))
+		for key, value := range keys {
+			merged[locale][key] = value
+		}
+	}
+
+	for locale, keys := range overrides {
+		if merged[locale] == nil {
+			merged[locale] = make(map[string]string, len(keys))
+		}
+		for key, value := range keys {
+			merged[locale][key] = value
+		}
+	}
+
+	return merged
+}
+
+// WithVar returns a copy of the translations with every "{{.name}}" placeholder replaced by value
+// Used for instance-wide values such as the product name, resolved once at load time
+func (t Translations) WithVar(name, value string) Translations {
+	placeholder := "{{." + name + "}}"
+	replaced := make(Translations, len(t))
+	for locale, keys := range t {
+		replaced[locale] = make(map[string]string, len(keys))
+		for key, str := range keys {
+			replaced[locale][key] = strings.ReplaceAll(str, placeholder, value)
+		}
+	}
+	return replaced
+}
@@ -0,0 +1,116 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const embeddedTranslations = `{
+  "en": {"title": "Your calendar", "button": "View calendar"},
+  "fr": {"title": "Votre calendrier", "button": "Voir le calendrier"}
+}`
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	override := `{"en": {"title": "Your club"}, "de": {"title": "Dein Verein"}}`
+	if err := os.WriteFile(filepath.Join(dir, "notification.json"), []byte(override), 0o600); err != nil {
+		t.Fatalf("failed to write override file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		overrideDir string
+		file        string
+		locale      string
+		key         string
+		want        string
+	}{
+		{name: "overridden key", overrideDir: dir, file: "notification", locale: "en", key: "title", want: "Your club"},
+		{name: "key kept from embedded", overrideDir: dir, file: "notification", locale: "en", key: "button", want: "View calendar"},
+		{name: "locale untouched", overrideDir: dir, file: "notification", locale: "fr", key: "title", want: "Votre calendrier"},
+		{name: "new locale", overrideDir: dir, file: "notification", locale: "de", key: "title", want: "Dein Verein"},
+		{name: "new locale falls back by key", overrideDir: dir, file: "notification", locale: "de", key: "button", want: "View calendar"},
+		{name: "missing override file", overrideDir: dir, file: "other", locale: "en", key: "title", want: "Your calendar"},
+		{name: "overrides disabled", overrideDir: "", file: "notification", locale: "en", key: "title", want: "Your calendar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translations, err := Load(embeddedTranslations, tt.overrideDir, tt.file)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := translations[tt.locale][tt.key]; got != tt.want {
+				t.Errorf("translations[%s][%s] = %q, want %q", tt.locale, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_InvalidOverride(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notification.json"), []byte("{invalid"), 0o600); err != nil {
+		t.Fatalf("failed to write override file: %v", err)
+	}
+
+	translations, err := Load(embeddedTranslations, dir, "notification")
+	if err == nil {
+		t.Fatal("Expected an error for an invalid override file")
+	}
+	if translations["en"]["title"] != "Your calendar" {
+		t.Error("Expected embedded translations to be returned when overrides are invalid")
+	}
+}
+
+func TestWithVar(t *testing.T) {
+	translations := Translations{
+		"en": {"subject": "Your {{.ProductName}} login link", "greeting": "Hello {{.DisplayName}},"},
+	}
+
+	branded := translations.WithVar("ProductName", "Club Planner")
+
+	if got := branded["en"]["subject"]; got != "Your Club Planner login link" {
+		t.Errorf("subject = %q, want %q", got, "Your Club Planner login link")
+	}
+	if got := branded["en"]["greeting"]; got != "Hello {{.DisplayName}}," {
+		t.Errorf("Expected other placeholders to be kept, got %q", got)
+	}
+	if translations["en"]["subject"] != "Your {{.ProductName}} login link" {
+		t.Error("Expected WithVar to leave the original translations untouched")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	translations, err := Load(embeddedTranslations, "", "notification")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := translations.Lookup("fr")["title"]; got != "Votre calendrier" {
+		t.Errorf("Lookup(fr) title = %q, want %q", got, "Votre calendrier")
+	}
+	if got := translations.Lookup("pt")["title"]; got != "Your calendar" {
+		t.Errorf("Lookup(pt) title = %q, want the English one", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := map[string]string{
+		"de":    "de",
+		"de-AT": "de",
+		"NL_be": "nl",
+		" it ":  "it",
+		"pt-BR": DefaultLocale,
+		"":      DefaultLocale,
+	}
+	for tag, want := range tests {
+		if got := Match(tag); got != want {
+			t.Errorf("Match(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
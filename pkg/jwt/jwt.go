@@ -34,17 +34,19 @@ type Claims struct {
 
 // Config holds JWT configuration
 type Config struct {
-	PrivateKeyPath string
-	PublicKeyPath  string
-	AccessExpiry   time.Duration
-	RefreshExpiry  time.Duration
-	Issuer         string
+	PrivateKeyPath        string
+	PublicKeyPath         string
+	PreviousPublicKeyPath string // Previous public key still accepted after a rotation (optional, ignored if missing)
+	AccessExpiry          time.Duration
+	RefreshExpiry         time.Duration
+	Issuer                string
 }
 
 // Manager handles JWT operations
 type Manager struct {
 	privateKey    *rsa.PrivateKey
 	publicKey     *rsa.PublicKey
+	previousKey   *rsa.PublicKey // Accepted for verification only (nil = no rotation in progress)
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
 	issuer        string
@@ -68,9 +70,20 @@ func NewManager(cfg *Config) (*Manager, error) {
 		return nil, fmt.Errorf("failed to load public key: %w", err)
 	}
 
+	var previousKey *rsa.PublicKey
+	if cfg.PreviousPublicKeyPath != "" {
+		previousKey, err = loadPublicKey(cfg.PreviousPublicKeyPath)
+		if errors.Is(err, os.ErrNotExist) {
+			previousKey = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to load previous public key: %w", err)
+		}
+	}
+
 	return &Manager{
 		privateKey:    privateKey,
 		publicKey:     publicKey,
+		previousKey:   previousKey,
 		accessExpiry:  cfg.AccessExpiry,
 		refreshExpiry: cfg.RefreshExpiry,
 		issuer:        cfg.Issuer,
@@ -125,12 +138,7 @@ func (m *Manager) GenerateRefreshToken(userID string) (string, time.Time, error)
 
 // ValidateAccessToken validates an access token and returns claims
 func (m *Manager) ValidateAccessToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return m.publicKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -149,12 +157,7 @@ func (m *Manager) ValidateAccessToken(tokenString string) (*Claims, error) {
 
 // ValidateRefreshToken validates a refresh token and returns the user ID
 func (m *Manager) ValidateRefreshToken(tokenString string) (string, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return m.publicKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, m.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -171,6 +174,17 @@ func (m *Manager) ValidateRefreshToken(tokenString string) (string, error) {
 	return claims.Subject, nil
 }
 
+// keyFunc returns the verification keys: the current public key, and the previous one during a rotation
+func (m *Manager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	if m.previousKey == nil {
+		return m.publicKey, nil
+	}
+	return jwt.VerificationKeySet{Keys: []jwt.VerificationKey{m.publicKey, m.previousKey}}, nil
+}
+
 // GetPublicKey returns the public key for external verification
 func (m *Manager) GetPublicKey() *rsa.PublicKey {
 	return m.publicKey
@@ -205,12 +219,7 @@ func (m *Manager) GenerateCustomToken(customClaims map[string]interface{}) (stri
 
 // ValidateCustomToken validates a custom token and returns the claims
 func (m *Manager) ValidateCustomToken(tokenString string) (map[string]interface{}, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return m.publicKey, nil
-	})
+	token, err := jwt.Parse(tokenString, m.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
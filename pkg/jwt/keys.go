@@ -0,0 +1,96 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// MinKeyBits is the smallest RSA key size accepted by GenerateKeyPair
+const MinKeyBits = 2048
+
+// GenerateKeyPair generates a new RSA key pair for RS256 signing
+func GenerateKeyPair(bits int) (*rsa.PrivateKey, error) {
+	if bits < MinKeyBits {
+		return nil, fmt.Errorf("key size must be at least %d bits", MinKeyBits)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+
+	return key, nil
+}
+
+// WriteKeyPair writes the private key (PKCS8, mode 0600) and the public key (PKIX, mode 0644) as PEM files
+// Each file is written to a temporary file first, so a running server never reads a partial key
+func WriteKeyPair(privateKeyPath, publicKeyPath string, key *rsa.PrivateKey) error {
+	privateBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode private key: %w", err)
+	}
+	publicBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	if err := writePEM(privateKeyPath, "PRIVATE KEY", privateBytes, 0o600); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := writePEM(publicKeyPath, "PUBLIC KEY", publicBytes, 0o644); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	return nil
+}
+
+// CopyPublicKey copies a public key file after checking that it holds an RSA public key
+func CopyPublicKey(srcPath, dstPath string) error {
+	key, err := loadPublicKey(srcPath)
+	if err != nil {
+		return err
+	}
+
+	publicBytes, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return writePEM(dstPath, "PUBLIC KEY", publicBytes, 0o644)
+}
+
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := pem.Encode(tmp, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
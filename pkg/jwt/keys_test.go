@@ -0,0 +1,106 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package jwt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T, dir string) *Manager {
+	t.Helper()
+
+	manager, err := NewManager(&Config{
+		PrivateKeyPath:        filepath.Join(dir, "private.pem"),
+		PublicKeyPath:         filepath.Join(dir, "public.pem"),
+		PreviousPublicKeyPath: filepath.Join(dir, "public.previous.pem"),
+		AccessExpiry:          time.Minute,
+		RefreshExpiry:         time.Hour,
+		Issuer:                "whento",
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	return manager
+}
+
+func generateTestKeys(t *testing.T, dir string) {
+	t.Helper()
+
+	key, err := GenerateKeyPair(MinKeyBits)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair() error = %v", err)
+	}
+	if err := WriteKeyPair(filepath.Join(dir, "private.pem"), filepath.Join(dir, "public.pem"), key); err != nil {
+		t.Fatalf("WriteKeyPair() error = %v", err)
+	}
+}
+
+func TestWriteKeyPair(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	generateTestKeys(t, dir)
+
+	info, err := os.Stat(filepath.Join(dir, "private.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("private key mode = %o, want 600", perm)
+	}
+
+	manager := newTestManager(t, dir)
+	token, err := manager.GenerateAccessToken("user-id", "user@example.com", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.ValidateAccessToken(token); err != nil {
+		t.Errorf("ValidateAccessToken() error = %v", err)
+	}
+}
+
+func TestGenerateKeyPair_TooSmall(t *testing.T) {
+	if _, err := GenerateKeyPair(1024); err == nil {
+		t.Error("GenerateKeyPair(1024) should fail")
+	}
+}
+
+func TestRotation(t *testing.T) {
+	dir := t.TempDir()
+	generateTestKeys(t, dir)
+
+	oldToken, _, err := newTestManager(t, dir).GenerateRefreshToken("user-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotate: keep the current public key as the previous one, then generate a new pair
+	if err := CopyPublicKey(filepath.Join(dir, "public.pem"), filepath.Join(dir, "public.previous.pem")); err != nil {
+		t.Fatalf("CopyPublicKey() error = %v", err)
+	}
+	generateTestKeys(t, dir)
+
+	manager := newTestManager(t, dir)
+	if userID, err := manager.ValidateRefreshToken(oldToken); err != nil || userID != "user-id" {
+		t.Errorf("ValidateRefreshToken(old token) = %q, %v", userID, err)
+	}
+
+	newToken, _, err := manager.GenerateRefreshToken("user-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.ValidateRefreshToken(newToken); err != nil {
+		t.Errorf("ValidateRefreshToken(new token) error = %v", err)
+	}
+
+	// Once the previous key is removed, old tokens are rejected
+	if err := os.Remove(filepath.Join(dir, "public.previous.pem")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newTestManager(t, dir).ValidateRefreshToken(oldToken); err == nil {
+		t.Error("ValidateRefreshToken(old token) should fail without the previous key")
+	}
+}
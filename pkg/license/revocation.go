@@ -0,0 +1,120 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package license
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Revocation reasons
+const (
+	ReasonRefunded    = "refunded"
+	ReasonChargeback  = "chargeback"
+	ReasonCompromised = "compromised"
+	ReasonSuperseded  = "superseded"
+	ReasonOther       = "other"
+)
+
+// RevocationReasons lists the accepted revocation reasons
+var RevocationReasons = []string{ReasonRefunded, ReasonChargeback, ReasonCompromised, ReasonSuperseded, ReasonOther}
+
+// RevocationList is a signed list of revoked licenses, distributed to self-hosted instances
+// Instances verify it with the same public key as licenses, so it can be served from anywhere
+type RevocationList struct {
+	Version   int              `json:"version"` // Incremented on every change
+	UpdatedAt time.Time        `json:"updated_at"`
+	Entries   []RevokedLicense `json:"entries"`
+	Signature string           `json:"signature"`
+}
+
+// RevokedLicense is an entry of a revocation list
+type RevokedLicense struct {
+	Fingerprint string    `json:"fingerprint"` // SHA-256 of the license signature
+	SupportKey  string    `json:"support_key"` // For reference only, support keys change on renewal
+	IssuedTo    string    `json:"issued_to"`
+	Reason      string    `json:"reason"`
+	RevokedAt   time.Time `json:"revoked_at"`
+}
+
+// Fingerprint identifies a license by the SHA-256 of its signature
+// Every issued or renewed license has its own signature, hence its own fingerprint
+func Fingerprint(signature string) string {
+	sum := sha256.Sum256([]byte(signature))
+	return hex.EncodeToString(sum[:])
+}
+
+// Revoke adds a license to the list, bumps its version and signs it
+func (l *RevocationList) Revoke(license *License, reason string, privateKey ed25519.PrivateKey) (*RevokedLicense, error) {
+	if !slices.Contains(RevocationReasons, reason) {
+		return nil, fmt.Errorf("invalid reason: %s (must be one of %s)", reason, strings.Join(RevocationReasons, ", "))
+	}
+	if license.Signature == "" {
+		return nil, fmt.Errorf("license has no signature")
+	}
+	if entry := l.Find(license.Signature); entry != nil {
+		return nil, fmt.Errorf("license already revoked on %s (%s)", entry.RevokedAt.Format("2006-01-02"), entry.Reason)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	l.Entries = append(l.Entries, RevokedLicense{
+		Fingerprint: Fingerprint(license.Signature),
+		SupportKey:  license.SupportKey,
+		IssuedTo:    license.IssuedTo,
+		Reason:      reason,
+		RevokedAt:   now,
+	})
+	l.Version++
+	l.UpdatedAt = now
+	l.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(l.constructMessage())))
+
+	return &l.Entries[len(l.Entries)-1], nil
+}
+
+// Find returns the entry revoking the license with the given signature, or nil
+func (l *RevocationList) Find(signature string) *RevokedLicense {
+	fingerprint := Fingerprint(signature)
+	for i := range l.Entries {
+		if l.Entries[i].Fingerprint == fingerprint {
+			return &l.Entries[i]
+		}
+	}
+	return nil
+}
+
+// ValidateRevocationList verifies a revocation list signature using the public key
+func ValidateRevocationList(list *RevocationList, publicKey ed25519.PublicKey) error {
+	if list == nil {
+		return fmt.Errorf("revocation list is nil")
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(list.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if !ed25519.Verify(publicKey, []byte(list.constructMessage()), signatureBytes) {
+		return fmt.Errorf("invalid revocation list signature")
+	}
+
+	return nil
+}
+
+// constructMessage creates the canonical message string for signing
+// Format: crl|version|updated_at, then one fingerprint|reason|revoked_at line per entry
+func (l *RevocationList) constructMessage() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "crl|%d|%s", l.Version, l.UpdatedAt.Format(time.RFC3339))
+	for _, entry := range l.Entries {
+		fmt.Fprintf(&b, "\n%s|%s|%s", entry.Fingerprint, entry.Reason, entry.RevokedAt.Format(time.RFC3339))
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package license
+
+import (
+	"testing"
+)
+
+func TestRevocationList(t *testing.T) {
+	publicKey, privateKey, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lic, err := Generate(GenerateConfig{Tier: TierPro, IssuedTo: "ACME"}, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := Generate(GenerateConfig{Tier: TierPro, IssuedTo: "Other"}, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list RevocationList
+	entry, err := list.Revoke(lic, ReasonRefunded, privateKey)
+	if err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if entry.SupportKey != lic.SupportKey || entry.Reason != ReasonRefunded {
+		t.Errorf("Revoke() entry = %+v", entry)
+	}
+	if list.Version != 1 {
+		t.Errorf("Version = %d, want 1", list.Version)
+	}
+
+	if err := ValidateRevocationList(&list, publicKey); err != nil {
+		t.Errorf("ValidateRevocationList() error = %v", err)
+	}
+	if list.Find(lic.Signature) == nil {
+		t.Error("Find() = nil for a revoked license")
+	}
+	if list.Find(other.Signature) != nil {
+		t.Error("Find() returned an entry for a valid license")
+	}
+
+	if _, err := list.Revoke(lic, ReasonRefunded, privateKey); err == nil {
+		t.Error("Revoke() of an already revoked license should fail")
+	}
+	if _, err := list.Revoke(other, "bored", privateKey); err == nil {
+		t.Error("Revoke() with an unknown reason should fail")
+	}
+}
+
+func TestValidateRevocationList_Tampered(t *testing.T) {
+	publicKey, privateKey, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lic, err := Generate(GenerateConfig{Tier: TierEnterprise, IssuedTo: "ACME"}, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list RevocationList
+	if _, err := list.Revoke(lic, ReasonCompromised, privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Removing an entry invalidates the signature
+	list.Entries = nil
+	if err := ValidateRevocationList(&list, publicKey); err == nil {
+		t.Error("ValidateRevocationList() should fail for a tampered list")
+	}
+
+	// A list signed with another key is rejected
+	otherPublicKey, _, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signed RevocationList
+	if _, err := signed.Revoke(lic, ReasonCompromised, privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateRevocationList(&signed, otherPublicKey); err == nil {
+		t.Error("ValidateRevocationList() should fail with another public key")
+	}
+}
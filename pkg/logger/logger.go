@@ -8,6 +8,8 @@ import (
 	"context"
 	"log/slog"
 	"os"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ctxKey string
@@ -86,6 +88,11 @@ func FromContext(ctx context.Context) *slog.Logger {
 		l = l.With("user_id", userID)
 	}
 
+	// Correlates logs with the trace of the request when tracing is enabled
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		l = l.With("trace_id", spanContext.TraceID().String())
+	}
+
 	return l
 }
 
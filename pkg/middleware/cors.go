@@ -0,0 +1,98 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// DefaultCORSMethods are the methods allowed in cross-origin requests when none are configured
+var DefaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// corsMethods are the methods that can be allowed in cross-origin requests
+var corsMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// CORSConfig holds the Cross-Origin Resource Sharing policy
+type CORSConfig struct {
+	AllowedOrigins   []string // Origins such as "https://app.example.com", or "*" for any
+	AllowedMethods   []string // DefaultCORSMethods when empty
+	AllowCredentials bool     // Lets browsers send cookies, not allowed with the "*" origin
+}
+
+// Validate checks that origins are "*" or bare origins (scheme and host, no path) and that methods are known
+func (c CORSConfig) Validate() error {
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			if c.AllowCredentials {
+				return fmt.Errorf("the \"*\" origin can't be allowed with credentials, list the origins instead")
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return fmt.Errorf("origin %q is not \"*\" or a scheme and host such as https://app.example.com", origin)
+		}
+	}
+	for _, method := range c.AllowedMethods {
+		if !slices.Contains(corsMethods, method) {
+			return fmt.Errorf("method %q is not one of %s", method, strings.Join(corsMethods, ", "))
+		}
+	}
+	return nil
+}
+
+// CORS handles Cross-Origin Resource Sharing
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	methods := cfg.AllowedMethods
+	if len(methods) == 0 {
+		methods = DefaultCORSMethods
+	}
+	allowMethods := strings.Join(methods, ", ")
+	anyOrigin := slices.Contains(cfg.AllowedOrigins, "*")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+
+			// Responses depend on the origin, unless any origin is allowed
+			if !anyOrigin {
+				w.Header().Add("Vary", "Origin")
+			}
+
+			switch {
+			case origin == "":
+				// Not a cross-origin request
+			case anyOrigin:
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			case slices.Contains(cfg.AllowedOrigins, origin):
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				if cfg.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+			default:
+				origin = ""
+			}
+
+			if origin != "" {
+				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-Request-ID, X-Organization-ID")
+				w.Header().Set("Access-Control-Max-Age", "3600")
+			}
+
+			// Only answer preflights here, plain OPTIONS requests (e.g. CalDAV discovery) reach the handlers
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
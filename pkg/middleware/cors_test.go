@@ -0,0 +1,76 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     CORSConfig
+		wantErr bool
+	}{
+		{"any origin", CORSConfig{AllowedOrigins: []string{"*"}}, false},
+		{"listed origins with credentials", CORSConfig{AllowedOrigins: []string{"https://app.example.com", "http://localhost:5173"}, AllowCredentials: true}, false},
+		{"any origin with credentials", CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, true},
+		{"origin with path", CORSConfig{AllowedOrigins: []string{"https://app.example.com/"}}, true},
+		{"origin without scheme", CORSConfig{AllowedOrigins: []string{"app.example.com"}}, true},
+		{"unknown method", CORSConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET", "PROPFIND"}}, true},
+		{"lowercase method", CORSConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"get"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCORS(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             CORSConfig
+		origin          string
+		wantOrigin      string
+		wantCredentials string
+	}{
+		{"any origin", CORSConfig{AllowedOrigins: []string{"*"}}, "https://evil.example", "*", ""},
+		{"listed origin", CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}, "https://app.example.com", "https://app.example.com", "true"},
+		{"unlisted origin", CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}, "https://evil.example", "", ""},
+		{"same origin", CORSConfig{AllowedOrigins: []string{"https://app.example.com"}}, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CORS(tt.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			req := httptest.NewRequest(http.MethodOptions, "/api/v1/calendars", nil)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNoContent {
+				t.Errorf("preflight status = %d, want %d", rec.Code, http.StatusNoContent)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want %q", got, tt.wantCredentials)
+			}
+			if tt.wantOrigin != "" && rec.Header().Get("Access-Control-Allow-Methods") != "GET, POST, PUT, PATCH, DELETE, OPTIONS" {
+				t.Errorf("Access-Control-Allow-Methods = %q", rec.Header().Get("Access-Control-Allow-Methods"))
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -23,6 +25,8 @@ const (
 	UserIDKey    ctxKey = "user_id"
 	UserEmailKey ctxKey = "user_email"
 	UserRoleKey  ctxKey = "user_role"
+	ClientIPKey  ctxKey = "client_ip"
+	UserAgentKey ctxKey = "user_agent"
 )
 
 // RequestID adds a unique request ID to each request
@@ -40,6 +44,21 @@ func RequestID(next http.Handler) http.Handler {
 	})
 }
 
+// ClientInfo adds the IP address and user agent of the client to the context, to record the device of sessions
+// It must run after chi's RealIP middleware so that clients behind a reverse proxy get their own address
+func ClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+
+		ctx := context.WithValue(r.Context(), ClientIPKey, ip)
+		ctx = context.WithValue(ctx, UserAgentKey, r.UserAgent())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // Logger logs each request
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -76,41 +95,55 @@ func Recoverer(next http.Handler) http.Handler {
 	})
 }
 
-// CORS handles Cross-Origin Resource Sharing
-func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			origin := r.Header.Get("Origin")
+// ErrTokenScope is returned by token authenticators when the scope of a token doesn't allow the request
+var ErrTokenScope = errors.New("token scope does not allow this request")
 
-			// Check if origin is allowed
-			allowed := false
-			for _, o := range allowedOrigins {
-				if o == "*" || o == origin {
-					allowed = true
-					break
-				}
-			}
+// TokenIdentity is the user authenticated by a long-lived API token
+type TokenIdentity struct {
+	UserID string
+	Email  string
+	Role   string // Role granted to the token, which may be lower than the role of the user
+}
 
-			if allowed {
-				w.Header().Set("Access-Control-Allow-Origin", origin)
-				w.Header().Set("Access-Control-Allow-Credentials", "true")
-				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-Request-ID")
-				w.Header().Set("Access-Control-Max-Age", "3600")
-			}
+// TokenAuthenticator authenticates long-lived API tokens sent as Bearer tokens instead of JWTs
+type TokenAuthenticator interface {
+	// IsToken reports whether a Bearer value is one of its tokens (JWTs are validated otherwise)
+	IsToken(value string) bool
+	// AuthenticateToken returns the identity of a token, or ErrTokenScope if it can't send the request
+	AuthenticateToken(r *http.Request, value string) (*TokenIdentity, error)
+}
 
-			if r.Method == http.MethodOptions {
-				w.WriteHeader(http.StatusNoContent)
-				return
-			}
+// Authenticate returns the identity of a Bearer value sent with a request: an API token of the first
+// authenticator recognizing it, or a JWT. Scope errors are returned as ErrTokenScope.
+func Authenticate(r *http.Request, value string, jwtManager *jwt.Manager, authenticators ...TokenAuthenticator) (*TokenIdentity, error) {
+	for _, authenticator := range authenticators {
+		if authenticator.IsToken(value) {
+			return authenticator.AuthenticateToken(r, value)
+		}
+	}
 
-			next.ServeHTTP(w, r)
-		})
+	claims, err := jwtManager.ValidateAccessToken(value)
+	if err != nil {
+		return nil, err
 	}
+	return &TokenIdentity{UserID: claims.UserID, Email: claims.Email, Role: claims.Role}, nil
+}
+
+// WithIdentity adds the user info of an authenticated identity to a context
+func WithIdentity(ctx context.Context, identity *TokenIdentity) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, identity.UserID)
+	ctx = context.WithValue(ctx, UserEmailKey, identity.Email)
+	ctx = context.WithValue(ctx, UserRoleKey, identity.Role)
+	return logger.WithUserID(ctx, identity.UserID)
 }
 
 // Auth creates an authentication middleware
 func Auth(jwtManager *jwt.Manager) func(http.Handler) http.Handler {
+	return AuthWithTokens(jwtManager)
+}
+
+// AuthWithTokens creates an authentication middleware that also accepts the API tokens of authenticators
+func AuthWithTokens(jwtManager *jwt.Manager, authenticators ...TokenAuthenticator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -125,20 +158,17 @@ func Auth(jwtManager *jwt.Manager) func(http.Handler) http.Handler {
 				return
 			}
 
-			claims, err := jwtManager.ValidateAccessToken(parts[1])
+			identity, err := Authenticate(r, parts[1], jwtManager, authenticators...)
+			if errors.Is(err, ErrTokenScope) {
+				http.Error(w, "Token scope does not allow this request", http.StatusForbidden)
+				return
+			}
 			if err != nil {
 				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 				return
 			}
 
-			// Add user info to context
-			ctx := r.Context()
-			ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
-			ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
-			ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
-			ctx = logger.WithUserID(ctx, claims.UserID)
-
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
 		})
 	}
 }
@@ -195,6 +225,22 @@ func GetUserRole(ctx context.Context) string {
 	return ""
 }
 
+// GetClientIP extracts the client IP address from context (set by ClientInfo)
+func GetClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(ClientIPKey).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// GetUserAgent extracts the client user agent from context (set by ClientInfo)
+func GetUserAgent(ctx context.Context) string {
+	if userAgent, ok := ctx.Value(UserAgentKey).(string); ok {
+		return userAgent
+	}
+	return ""
+}
+
 // LimitRequestSize limits the maximum size of request bodies
 func LimitRequestSize(maxBytes int64) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -246,3 +292,29 @@ func SecurityHeaders(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// Embeddable lets pages be framed by the given origins ("*" = any website), overriding SecurityHeaders
+// Embedded pages are self-contained, so they get a stricter policy than the SPA: no scripts nor external resources
+func Embeddable(frameAncestors []string) func(http.Handler) http.Handler {
+	ancestors := strings.Join(frameAncestors, " ")
+	if ancestors == "" {
+		ancestors = "'none'"
+	}
+
+	csp := "default-src 'none'; " +
+		"style-src 'unsafe-inline'; " +
+		"img-src 'self' data:; " +
+		"frame-ancestors " + ancestors + "; " +
+		"base-uri 'none'; " +
+		"form-action 'none'"
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// frame-ancestors supersedes X-Frame-Options, which can't list several origins
+			w.Header().Del("X-Frame-Options")
+			w.Header().Set("Content-Security-Policy", csp)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -26,9 +26,20 @@ func NewRateLimiter(client *redis.Client) *RateLimiter {
 
 // RateLimitConfig holds rate limit configuration
 type RateLimitConfig struct {
-	Requests int                          // Number of requests allowed
-	Window   time.Duration                // Time window
-	KeyFunc  func(r *http.Request) string // Function to extract rate limit key
+	Requests  int                          // Number of requests allowed
+	Window    time.Duration                // Time window
+	KeyFunc   func(r *http.Request) string // Function to extract rate limit key
+	LimitFunc func(r *http.Request) int    // Optional: resolves the requests allowed per request (e.g. by plan), Requests when nil or 0
+}
+
+// limit returns the number of requests allowed for a request
+func (cfg RateLimitConfig) limit(r *http.Request) int {
+	if cfg.LimitFunc != nil {
+		if limit := cfg.LimitFunc(r); limit > 0 {
+			return limit
+		}
+	}
+	return cfg.Requests
 }
 
 // Limit creates a rate limiting middleware
@@ -48,14 +59,15 @@ func (rl *RateLimiter) Limit(cfg RateLimitConfig) func(http.Handler) http.Handle
 				return
 			}
 
-			allowed, remaining, resetAt, err := rl.check(r.Context(), key, cfg.Requests, cfg.Window)
+			limit := cfg.limit(r)
+			allowed, remaining, resetAt, err := rl.check(r.Context(), key, limit, cfg.Window)
 			if err != nil {
 				// On error, allow the request but log it
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", cfg.Requests))
+			w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", limit))
 			w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
 			w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", resetAt))
 
@@ -5,6 +5,12 @@
 
 package models
 
+import (
+	"time"
+
+	"github.com/whento/pkg/i18n"
+)
+
 // Role represents user roles in the system
 type Role string
 
@@ -33,7 +39,7 @@ const (
 
 // IsValid checks if the locale is valid
 func (l Locale) IsValid() bool {
-	return l == LocaleFR || l == LocaleEN
+	return i18n.IsSupported(string(l))
 }
 
 // String returns the string representation of the locale
@@ -148,3 +154,142 @@ func (h HolidaysPolicy) IsValid() bool {
 func (h HolidaysPolicy) String() string {
 	return string(h)
 }
+
+// WeekStart represents the first day of the week used when grouping dates
+type WeekStart string
+
+const (
+	WeekStartSunday WeekStart = "sunday"
+	WeekStartMonday WeekStart = "monday"
+)
+
+// IsValid checks if the week start is valid
+func (w WeekStart) IsValid() bool {
+	return w == WeekStartSunday || w == WeekStartMonday
+}
+
+// String returns the string representation of the week start
+func (w WeekStart) String() string {
+	return string(w)
+}
+
+// Weekday returns the time.Weekday the week starts on (Monday unless explicitly Sunday)
+func (w WeekStart) Weekday() time.Weekday {
+	if w == WeekStartSunday {
+		return time.Sunday
+	}
+	return time.Monday
+}
+
+// StartOfWeek returns the first day of the week containing date
+func (w WeekStart) StartOfWeek(date time.Time) time.Time {
+	offset := (int(date.Weekday()) - int(w.Weekday()) + 7) % 7
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return day.AddDate(0, 0, -offset)
+}
+
+// ResolveWeekStart returns the first valid override (e.g. calendar), otherwise the instance default
+func ResolveWeekStart(instanceDefault string, overrides ...*string) WeekStart {
+	for _, override := range overrides {
+		if override != nil && WeekStart(*override).IsValid() {
+			return WeekStart(*override)
+		}
+	}
+	if WeekStart(instanceDefault).IsValid() {
+		return WeekStart(instanceDefault)
+	}
+	return WeekStartMonday
+}
+
+// TimeFormat represents how clock times are rendered to users
+type TimeFormat string
+
+const (
+	TimeFormat24h TimeFormat = "24h"
+	TimeFormat12h TimeFormat = "12h"
+)
+
+// IsValid checks if the time format is valid
+func (t TimeFormat) IsValid() bool {
+	return t == TimeFormat24h || t == TimeFormat12h
+}
+
+// String returns the string representation of the time format
+func (t TimeFormat) String() string {
+	return string(t)
+}
+
+// FormatClock renders an "HH:MM" time string in this format ("18:30" or "6:30 PM")
+// Values that cannot be parsed are returned unchanged
+func (t TimeFormat) FormatClock(hhmm string) string {
+	parsed, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return hhmm
+	}
+	if t == TimeFormat12h {
+		return parsed.Format("3:04 PM")
+	}
+	return parsed.Format("15:04")
+}
+
+// FormatSlot renders an optional "HH:MM" start/end pair in this format ("18:30 – 23:00" or "6:30 PM – 11:00 PM")
+// Returns an empty string for all-day slots
+func (t TimeFormat) FormatSlot(startTime, endTime *string) string {
+	hasStart := startTime != nil && *startTime != ""
+	hasEnd := endTime != nil && *endTime != ""
+
+	switch {
+	case hasStart && hasEnd:
+		return t.FormatClock(*startTime) + " – " + t.FormatClock(*endTime)
+	case hasStart:
+		return t.FormatClock(*startTime) + " –"
+	case hasEnd:
+		return "– " + t.FormatClock(*endTime)
+	default:
+		return ""
+	}
+}
+
+// ResolveTimeFormat returns the first valid override (e.g. user, then calendar), otherwise the instance default
+func ResolveTimeFormat(instanceDefault string, overrides ...*string) TimeFormat {
+	for _, override := range overrides {
+		if override != nil && TimeFormat(*override).IsValid() {
+			return TimeFormat(*override)
+		}
+	}
+	if TimeFormat(instanceDefault).IsValid() {
+		return TimeFormat(instanceDefault)
+	}
+	return TimeFormat24h
+}
+
+// DateFormat represents how dates are rendered in notifications
+type DateFormat string
+
+const (
+	DateFormatISO  DateFormat = "iso"  // 2025-07-04
+	DateFormatLong DateFormat = "long" // Friday 4 July 2025 (locale-aware)
+)
+
+// IsValid checks if the date format is valid
+func (d DateFormat) IsValid() bool {
+	return d == DateFormatISO || d == DateFormatLong
+}
+
+// String returns the string representation of the date format
+func (d DateFormat) String() string {
+	return string(d)
+}
+
+// ResolveDateFormat returns the first valid override (e.g. calendar), otherwise the instance default
+func ResolveDateFormat(instanceDefault string, overrides ...*string) DateFormat {
+	for _, override := range overrides {
+		if override != nil && DateFormat(*override).IsValid() {
+			return DateFormat(*override)
+		}
+	}
+	if DateFormat(instanceDefault).IsValid() {
+		return DateFormat(instanceDefault)
+	}
+	return DateFormatISO
+}
@@ -0,0 +1,112 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package models
+
+import "testing"
+
+func TestFormatClock(t *testing.T) {
+	tests := []struct {
+		hhmm       string
+		timeFormat TimeFormat
+		want       string
+	}{
+		{"00:00", TimeFormat24h, "00:00"},
+		{"00:00", TimeFormat12h, "12:00 AM"},
+		{"09:05", TimeFormat24h, "09:05"},
+		{"09:05", TimeFormat12h, "9:05 AM"},
+		{"12:00", TimeFormat12h, "12:00 PM"},
+		{"18:30", TimeFormat24h, "18:30"},
+		{"18:30", TimeFormat12h, "6:30 PM"},
+		{"23:59", TimeFormat12h, "11:59 PM"},
+		{"9:05", TimeFormat12h, "9:05 AM"},
+		{"noon", TimeFormat12h, "noon"}, // Unparsable values are returned unchanged
+		{"", TimeFormat24h, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hhmm+" "+tt.timeFormat.String(), func(t *testing.T) {
+			if got := tt.timeFormat.FormatClock(tt.hhmm); got != tt.want {
+				t.Errorf("FormatClock(%q) = %q, want %q", tt.hhmm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSlot(t *testing.T) {
+	start, end, morning := "18:30", "23:00", "09:05"
+
+	tests := []struct {
+		name       string
+		start, end *string
+		timeFormat TimeFormat
+		want       string
+	}{
+		{"24h range", &start, &end, TimeFormat24h, "18:30 – 23:00"},
+		{"12h range", &start, &end, TimeFormat12h, "6:30 PM – 11:00 PM"},
+		{"12h morning", &morning, &start, TimeFormat12h, "9:05 AM – 6:30 PM"},
+		{"24h start only", &start, nil, TimeFormat24h, "18:30 –"},
+		{"12h end only", nil, &end, TimeFormat12h, "– 11:00 PM"},
+		{"all day", nil, nil, TimeFormat12h, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.timeFormat.FormatSlot(tt.start, tt.end); got != tt.want {
+				t.Errorf("FormatSlot() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDisplaySettings(t *testing.T) {
+	value := func(s string) *string { return &s }
+
+	tests := []struct {
+		name      string
+		def       string
+		overrides []*string
+		weekStart WeekStart
+		time      TimeFormat
+		date      DateFormat
+	}{
+		{"built-in defaults", "", nil, WeekStartMonday, TimeFormat24h, DateFormatISO},
+		{"invalid instance default", "bogus", nil, WeekStartMonday, TimeFormat24h, DateFormatISO},
+		{"unset override", "", []*string{nil}, WeekStartMonday, TimeFormat24h, DateFormatISO},
+		{"empty override", "", []*string{value("")}, WeekStartMonday, TimeFormat24h, DateFormatISO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveWeekStart(tt.def, tt.overrides...); got != tt.weekStart {
+				t.Errorf("ResolveWeekStart() = %q, want %q", got, tt.weekStart)
+			}
+			if got := ResolveTimeFormat(tt.def, tt.overrides...); got != tt.time {
+				t.Errorf("ResolveTimeFormat() = %q, want %q", got, tt.time)
+			}
+			if got := ResolveDateFormat(tt.def, tt.overrides...); got != tt.date {
+				t.Errorf("ResolveDateFormat() = %q, want %q", got, tt.date)
+			}
+		})
+	}
+
+	// The instance default applies when no override is set, the first valid override wins otherwise
+	if got := ResolveWeekStart("sunday"); got != WeekStartSunday {
+		t.Errorf("ResolveWeekStart(sunday) = %q", got)
+	}
+	if got := ResolveWeekStart("sunday", value("monday")); got != WeekStartMonday {
+		t.Errorf("ResolveWeekStart(sunday, monday) = %q", got)
+	}
+	if got := ResolveTimeFormat("24h", nil, value("12h")); got != TimeFormat12h {
+		t.Errorf("ResolveTimeFormat(24h, nil, 12h) = %q", got)
+	}
+	if got := ResolveTimeFormat("12h", value("24h"), value("12h")); got != TimeFormat24h {
+		t.Errorf("ResolveTimeFormat(12h, 24h, 12h) = %q", got)
+	}
+	if got := ResolveDateFormat("long"); got != DateFormatLong {
+		t.Errorf("ResolveDateFormat(long) = %q", got)
+	}
+	if got := ResolveDateFormat("long", value("iso")); got != DateFormatISO {
+		t.Errorf("ResolveDateFormat(long, iso) = %q", got)
+	}
+}
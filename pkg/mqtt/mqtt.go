@@ -0,0 +1,133 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package mqtt
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/whento/pkg/httputil"
+)
+
+// ErrInvalidBrokerURL is returned when a broker URL can't be used
+var ErrInvalidBrokerURL = errors.New("invalid MQTT broker URL")
+
+// timeout bounds connecting and publishing
+const timeout = 10 * time.Second
+
+// Options configures a connection to an MQTT broker
+type Options struct {
+	BrokerURL string // tcp://, ssl://, ws:// or wss:// URL, credentials may be set in the user info
+	ClientID  string
+	Username  string // Overrides the user info of the URL
+	Password  string
+	TLSConfig *tls.Config // Used by ssl:// and wss:// brokers (nil = system roots)
+	// PublicOnly refuses brokers on loopback and private networks, for user-provided broker URLs
+	// WebSocket brokers are not supported then, as their connections can't be checked
+	PublicOnly bool
+}
+
+// Client publishes messages to an MQTT broker, reconnecting automatically
+type Client struct {
+	client paho.Client
+}
+
+// NewClient creates a client, which must be connected before publishing
+func NewClient(opts Options) (*Client, error) {
+	clientOpts, err := clientOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	clientOpts.SetAutoReconnect(true)
+	clientOpts.SetConnectRetry(true)
+
+	return &Client{client: paho.NewClient(clientOpts)}, nil
+}
+
+// Connect connects to the broker
+// The client keeps retrying in the background if the broker is unreachable
+func (c *Client) Connect() error {
+	token := c.client.Connect()
+	if !token.WaitTimeout(timeout) {
+		return fmt.Errorf("timeout connecting to MQTT broker")
+	}
+	return token.Error()
+}
+
+// Publish publishes a message with QoS 1
+// Retained messages are delivered to subscribers as soon as they subscribe
+func (c *Client) Publish(topic string, payload []byte, retain bool) error {
+	token := c.client.Publish(topic, 1, retain, payload)
+	if !token.WaitTimeout(timeout) {
+		return fmt.Errorf("timeout publishing to MQTT topic %s", topic)
+	}
+	return token.Error()
+}
+
+// Disconnect disconnects from the broker after in-flight messages are sent
+func (c *Client) Disconnect() {
+	c.client.Disconnect(250)
+}
+
+// Publish connects to a broker, publishes a single message and disconnects
+func Publish(opts Options, topic string, payload []byte, retain bool) error {
+	clientOpts, err := clientOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	client := &Client{client: paho.NewClient(clientOpts)}
+	if err := client.Connect(); err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	defer client.Disconnect()
+
+	return client.Publish(topic, payload, retain)
+}
+
+// clientOptions converts options to paho client options
+func clientOptions(opts Options) (*paho.ClientOptions, error) {
+	u, err := url.Parse(opts.BrokerURL)
+	if err != nil || u.Host == "" {
+		return nil, ErrInvalidBrokerURL
+	}
+	switch u.Scheme {
+	case "tcp", "mqtt", "ssl", "tls", "mqtts":
+	case "ws", "wss":
+		if opts.PublicOnly {
+			return nil, fmt.Errorf("%w: WebSocket brokers are not allowed", ErrInvalidBrokerURL)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme %q", ErrInvalidBrokerURL, u.Scheme)
+	}
+
+	username, password := opts.Username, opts.Password
+	if u.User != nil && username == "" {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	u.User = nil
+
+	clientOpts := paho.NewClientOptions().
+		AddBroker(u.String()).
+		SetClientID(opts.ClientID).
+		SetUsername(username).
+		SetPassword(password).
+		SetConnectTimeout(timeout).
+		SetWriteTimeout(timeout)
+	if opts.TLSConfig != nil {
+		clientOpts.SetTLSConfig(opts.TLSConfig)
+	}
+	if opts.PublicOnly {
+		clientOpts.SetDialer(httputil.NewOutboundDialer(false))
+	}
+
+	return clientOpts, nil
+}
@@ -0,0 +1,73 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook implements redis.Hook, tracing each command and pipeline in a client span with the command names
+// Keys and values are never recorded, as they may contain tokens
+type RedisHook struct{}
+
+// DialHook doesn't trace connections
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook traces a command
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !trace.SpanFromContext(ctx).IsRecording() {
+			return next(ctx, cmd)
+		}
+
+		name := strings.ToUpper(cmd.Name())
+		ctx, span := startRedisSpan(ctx, "redis "+name, name)
+		err := next(ctx, cmd)
+		End(span, redisError(err))
+		return err
+	}
+}
+
+// ProcessPipelineHook traces a pipeline
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !trace.SpanFromContext(ctx).IsRecording() {
+			return next(ctx, cmds)
+		}
+
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, strings.ToUpper(cmd.Name()))
+		}
+		ctx, span := startRedisSpan(ctx, "redis pipeline", strings.Join(names, " "))
+		err := next(ctx, cmds)
+		End(span, redisError(err))
+		return err
+	}
+}
+
+func startRedisSpan(ctx context.Context, name, operation string) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNameRedis, semconv.DBOperationName(operation)),
+	)
+}
+
+// redisError returns the error of a command, a cache miss is not an error
+func redisError(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+// Package tracing sets up OpenTelemetry tracing and instruments HTTP handlers, PostgreSQL queries and Redis commands.
+// Spans are no-ops until Setup installs an OTLP exporter.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentation is the name of the tracer of the instrumentations of this package
+const instrumentation = "github.com/whento/pkg/tracing"
+
+// OTLP protocols of the exporter
+const (
+	ProtocolHTTP = "http/protobuf"
+	ProtocolGRPC = "grpc"
+)
+
+// Config holds the tracing configuration
+// The endpoint, headers and TLS settings of the exporter are read from the standard OTEL_EXPORTER_OTLP_* variables,
+// and the sampler from OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG
+type Config struct {
+	Enabled        bool
+	Protocol       string // ProtocolHTTP (default) or ProtocolGRPC
+	ServiceName    string // Overridden by OTEL_SERVICE_NAME
+	ServiceVersion string
+}
+
+// Setup installs the global tracer provider exporting spans over OTLP, and the W3C trace context propagator.
+// It returns a function flushing the pending spans and stopping the exporter, a no-op when tracing is disabled.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Protocol {
+	case "", ProtocolHTTP:
+		exporter, err = otlptracehttp.New(ctx)
+	case ProtocolGRPC:
+		exporter, err = otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q, expected %s or %s", cfg.Protocol, ProtocolHTTP, ProtocolGRPC)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName), semconv.ServiceVersion(cfg.ServiceVersion)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span of the tracer of a module, named after the operation (e.g. "AvailabilityService.GetRangeSummary")
+func Start(ctx context.Context, tracer, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracer).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error of an operation, if any, and ends its span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
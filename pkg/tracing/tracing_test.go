@@ -0,0 +1,143 @@
+// WhenTo - Collaborative event calendar for self-hosted environments
+// Copyright (C) 2025 WhenTo Contributors
+// SPDX-License-Identifier: BSL-1.1
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// record installs a tracer provider recording the ended spans for the duration of a test
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		status     int
+		wantName   string
+		wantStatus codes.Code
+	}{
+		{"route pattern", "/calendars/42/range", http.StatusOK, "GET /calendars/{id}/range", codes.Unset},
+		{"server error", "/calendars/42/range?fail=1", http.StatusInternalServerError, "GET /calendars/{id}/range", codes.Error},
+		{"not found", "/nowhere", http.StatusNotFound, "GET", codes.Unset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record(t)
+
+			r := chi.NewRouter()
+			r.Use(Middleware)
+			r.Get("/calendars/{id}/range", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("fail") != "" {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				_, _ = w.Write([]byte("ok"))
+			})
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("ended spans = %d, want 1", len(spans))
+			}
+			if spans[0].Name() != tt.wantName {
+				t.Errorf("span name = %q, want %q", spans[0].Name(), tt.wantName)
+			}
+			if got := attributeValue(spans[0], "http.response.status_code").AsInt64(); got != int64(tt.status) {
+				t.Errorf("status attribute = %d, want %d", got, tt.status)
+			}
+			if spans[0].Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", spans[0].Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestMiddleware_ContinuesTrace(t *testing.T) {
+	recorder := record(t)
+	if _, err := Setup(context.Background(), Config{}); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("span does not continue the trace of the traceparent header")
+	}
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := record(t)
+	tracer := QueryTracer{}
+
+	// Queries outside of a traced operation are not traced
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	if len(recorder.Ended()) != 0 {
+		t.Fatalf("query without parent span was traced")
+	}
+
+	parent, span := otel.Tracer("test").Start(context.Background(), "request")
+	ctx = tracer.TraceQueryStart(parent, nil, pgx.TraceQueryStartData{SQL: "\n\t  select id FROM calendars WHERE id = $1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: pgx.ErrNoRows})
+	ctx = tracer.TraceQueryStart(parent, nil, pgx.TraceQueryStartData{SQL: "DELETE FROM calendars"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("deadlock detected")})
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("ended spans = %d, want 3", len(spans))
+	}
+	if spans[0].Name() != "postgresql SELECT" || spans[0].Status().Code != codes.Unset {
+		t.Errorf("select span = %q (%v), want postgresql SELECT without error", spans[0].Name(), spans[0].Status().Code)
+	}
+	if spans[1].Name() != "postgresql DELETE" || spans[1].Status().Code != codes.Error {
+		t.Errorf("delete span = %q (%v), want postgresql DELETE with an error", spans[1].Name(), spans[1].Status().Code)
+	}
+	if spans[0].Parent().SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("query span is not a child of the request span")
+	}
+}
+
+func TestSetup_InvalidProtocol(t *testing.T) {
+	if _, err := Setup(context.Background(), Config{Enabled: true, Protocol: "http/json"}); err == nil {
+		t.Error("Setup() with an unsupported protocol should fail")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/whento/pkg/i18n"
 )
 
 var validate *validator.Validate
@@ -96,7 +98,7 @@ func getErrorMessage(e validator.FieldError) string {
 	case "timezone":
 		return "must be a valid IANA timezone"
 	case "locale":
-		return "must be one of: fr, en"
+		return "must be one of: " + strings.Join(i18n.Locales, ", ")
 	case "uuid":
 		return "must be a valid UUID"
 	case "strongpassword":
@@ -107,8 +109,7 @@ func getErrorMessage(e validator.FieldError) string {
 }
 
 func validateLocale(fl validator.FieldLevel) bool {
-	locale := fl.Field().String()
-	return locale == "fr" || locale == "en"
+	return i18n.IsSupported(fl.Field().String())
 }
 
 // validateStrongPassword validates password complexity
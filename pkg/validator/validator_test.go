@@ -206,8 +206,10 @@ func TestValidateLocale(t *testing.T) {
 	}{
 		{"valid fr", "fr", false},
 		{"valid en", "en", false},
-		{"invalid de", "de", true},
-		{"invalid es", "es", true},
+		{"valid de", "de", false},
+		{"valid es", "es", false},
+		{"invalid pt", "pt", true},
+		{"region not accepted", "fr-FR", true},
 		{"empty locale", "", true},
 	}
 
@@ -4,8 +4,9 @@ set -e
 # docker-entrypoint.sh - Initialize WhenTo on first run
 # This script handles:
 # 1. JWT key generation (if keys don't exist)
-# 2. Database migrations
-# 3. Starting the application
+# 2. Waiting for the database
+# 3. Starting the application, which applies the pending migrations (MIGRATE_ON_START defaults to true
+#    in the images; with MIGRATE_ON_START=false it refuses to start on upgrades until "/app/whento migrate up")
 
 KEYS_DIR="${JWT_KEYS_DIR:-/app/keys}"
 PRIVATE_KEY="$KEYS_DIR/private.pem"
@@ -43,6 +44,10 @@ build_database_url() {
 # Build DATABASE_URL first
 build_database_url
 
+# Containers upgrade their schema on start, as before explicit migrations were introduced
+export MIGRATE_ON_START="${MIGRATE_ON_START:-true}"
+echo "[DB] MIGRATE_ON_START=$MIGRATE_ON_START"
+
 # ============================================
 # Step 1: Generate JWT keys if they don't exist
 # ============================================
@@ -114,33 +119,6 @@ wait_for_db() {
     return 1
 }
 
-# ============================================
-# Step 3: Run database migrations
-# ============================================
-run_migrations() {
-    if [ -z "$DATABASE_URL" ]; then
-        echo "[Migrations] WARNING: DATABASE_URL not set, skipping migrations"
-        return 0
-    fi
-
-    if [ ! -d "/app/migrations" ]; then
-        echo "[Migrations] No migrations directory found, skipping"
-        return 0
-    fi
-
-    echo "[Migrations] Running database migrations..."
-
-    # Run migrations using golang-migrate
-    migrate -path /app/migrations -database "$DATABASE_URL" up
-
-    if [ $? -eq 0 ]; then
-        echo "[Migrations] Migrations completed successfully"
-    else
-        # Exit code 1 with "no change" is OK
-        echo "[Migrations] Migrations applied (or already up to date)"
-    fi
-}
-
 # ============================================
 # Main execution
 # ============================================
@@ -151,9 +129,6 @@ generate_keys
 # Step 2: Wait for database
 wait_for_db
 
-# Step 3: Run migrations
-run_migrations
-
 echo "=== Starting WhenTo Application ==="
 
 # Execute the main application (or any command passed as argument)
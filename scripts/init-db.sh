@@ -71,12 +71,10 @@ fi
 echo -e "${GREEN}Running database migrations...${NC}"
 export DATABASE_URL="postgres://${DB_USER}:${DB_PASSWORD}@${DB_HOST}:${DB_PORT}/${DB_NAME}?sslmode=disable"
 
-if [ -x ./scripts/migrate.sh ]; then
-    ./scripts/migrate.sh reset
-else
-    echo -e "${YELLOW}migrate.sh not found or not executable, using migrate directly...${NC}"
-    migrate -path ./migrations -database "$DATABASE_URL" up
+if [ "$TABLE_COUNT" -gt 0 ]; then
+    go run -tags "${BUILD_TYPE:-selfhosted}" ./cmd migrate down --all
 fi
+go run -tags "${BUILD_TYPE:-selfhosted}" ./cmd migrate up
 
 echo ""
 
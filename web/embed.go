@@ -5,11 +5,16 @@
 package web
 
 import (
+	"context"
 	"embed"
 	"fmt"
+	"html"
 	"io/fs"
 	"net/http"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/whento/whento/internal/seo"
 )
 
 //go:embed dist/*
@@ -28,17 +33,25 @@ type PageMeta struct {
 	JSONLD        string // JSON-LD structured data for rich snippets
 }
 
+// CalendarPreviewer resolves the public token of a shared calendar to its link preview
+type CalendarPreviewer interface {
+	CalendarPreview(ctx context.Context, publicToken string) (*seo.CalendarPreview, error)
+}
+
 // SPAHandler serves the embedded frontend with SPA fallback
 type SPAHandler struct {
-	staticFS   http.Handler
-	indexHTML  []byte
-	fileSystem fs.FS
-	appURL     string
-	buildType  string
+	staticFS    http.Handler
+	indexHTML   []byte
+	fileSystem  fs.FS
+	appURL      string
+	buildType   string
+	productName string            // Branded product name, replaces "WhenTo" in self-hosted page titles
+	logoURL     string            // Branded logo, used as social preview image on self-hosted instances
+	previews    CalendarPreviewer // nil = shared calendars get generic meta tags
 }
 
 // NewSPAHandler creates a new SPA handler from the embedded frontend
-func NewSPAHandler(appURL string, buildType string) (*SPAHandler, error) {
+func NewSPAHandler(appURL string, buildType string, productName string, logoURL string, previews CalendarPreviewer) (*SPAHandler, error) {
 	// Get the dist subdirectory
 	distFS, err := fs.Sub(frontendFS, "dist")
 	if err != nil {
@@ -52,11 +65,14 @@ func NewSPAHandler(appURL string, buildType string) (*SPAHandler, error) {
 	}
 
 	return &SPAHandler{
-		staticFS:   http.FileServer(http.FS(distFS)),
-		indexHTML:  indexHTML,
-		fileSystem: distFS,
-		appURL:     appURL,
-		buildType:  buildType,
+		staticFS:    http.FileServer(http.FS(distFS)),
+		indexHTML:   indexHTML,
+		fileSystem:  distFS,
+		appURL:      appURL,
+		buildType:   buildType,
+		productName: productName,
+		logoURL:     logoURL,
+		previews:    previews,
 	}, nil
 }
 
@@ -152,7 +168,7 @@ func (h *SPAHandler) getJSONLDForWhyPage() string {
 }
 
 // getMetaForRoute returns SEO meta tags for a specific route
-func (h *SPAHandler) getMetaForRoute(path string) PageMeta {
+func (h *SPAHandler) getMetaForRoute(ctx context.Context, path string) PageMeta {
 	// Default meta tags (home page) - optimized for SEO with bilingual keywords
 	defaultMeta := PageMeta{
 		Title:         "WhenTo - Recurring Date Poll & Collaborative Calendar | Free Doodle Alternative",
@@ -163,9 +179,16 @@ func (h *SPAHandler) getMetaForRoute(path string) PageMeta {
 		Canonical:     h.appURL + path,
 	}
 
-	// Only provide SEO for cloud builds
+	// Shared calendars unfurl with their own name and next event on every build
+	if token, ok := calendarToken(path); ok {
+		if meta, ok := h.getMetaForCalendar(ctx, path, token); ok {
+			return meta
+		}
+	}
+
+	// Only provide SEO for cloud builds, self-hosted instances only get their branding applied
 	if h.buildType != "cloud" {
-		return defaultMeta
+		return h.brandMeta(defaultMeta)
 	}
 
 	// Route-specific meta tags
@@ -235,9 +258,89 @@ func (h *SPAHandler) getMetaForRoute(path string) PageMeta {
 	}
 }
 
+// calendarToken extracts the public token of calendar routes (/c/{token} and /c/{token}/p/{participantId})
+func calendarToken(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, "/c/")
+	if !ok {
+		return "", false
+	}
+	token, _, _ := strings.Cut(rest, "/")
+	if token == "" || token == "verify-email" {
+		return "", false
+	}
+	return token, true
+}
+
+// getMetaForCalendar returns the social sharing meta tags of a shared calendar
+// Returns false when the calendar cannot be previewed, so the generic calendar meta tags are used
+func (h *SPAHandler) getMetaForCalendar(ctx context.Context, path string, token string) (PageMeta, bool) {
+	if h.previews == nil {
+		return PageMeta{}, false
+	}
+
+	preview, err := h.previews.CalendarPreview(ctx, token)
+	if err != nil {
+		return PageMeta{}, false
+	}
+
+	productName := h.productName
+	if productName == "" {
+		productName = "WhenTo"
+	}
+
+	var description strings.Builder
+	if preview.Description != "" {
+		description.WriteString(truncate(preview.Description, 200))
+		description.WriteString(" ")
+	}
+	if preview.NextEvent != "" {
+		description.WriteString("📅 Next event: " + preview.NextEvent + ".")
+	} else {
+		description.WriteString("📅 No event confirmed yet. Click to share your availability!")
+	}
+
+	image := h.appURL + "/og-calendar.png"
+	if h.buildType != "cloud" && h.logoURL != "" {
+		image = h.logoURL
+	}
+
+	return PageMeta{
+		Title:         preview.Name + " - " + productName,
+		Description:   description.String(),
+		OGTitle:       preview.Name,
+		OGDescription: description.String(),
+		OGImage:       image,
+		Canonical:     h.appURL + path,
+		NoIndex:       true, // User calendars should not be indexed
+	}, true
+}
+
+// truncate shortens a text to a maximum number of characters, adding an ellipsis
+func truncate(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	return strings.TrimSpace(string([]rune(text)[:max-1])) + "…"
+}
+
+// brandMeta applies the instance branding to page meta tags
+func (h *SPAHandler) brandMeta(meta PageMeta) PageMeta {
+	if h.productName != "" && h.productName != "WhenTo" {
+		meta.Title = h.productName
+		meta.OGTitle = h.productName
+		meta.Description = strings.ReplaceAll(meta.Description, "WhenTo", h.productName)
+		meta.OGDescription = strings.ReplaceAll(meta.OGDescription, "WhenTo", h.productName)
+	}
+	if h.logoURL != "" {
+		meta.OGImage = h.logoURL
+	}
+	return meta
+}
+
 // injectMetaTags replaces the default meta tags in index.html with route-specific ones
-func (h *SPAHandler) injectMetaTags(html []byte, meta PageMeta) []byte {
-	htmlStr := string(html)
+// Values are HTML-escaped, as calendar names and descriptions are user content
+func (h *SPAHandler) injectMetaTags(page []byte, meta PageMeta) []byte {
+	htmlStr := string(page)
 
 	// Replace title (match the existing title tag)
 	if meta.Title != "" {
@@ -246,7 +349,7 @@ func (h *SPAHandler) injectMetaTags(html []byte, meta PageMeta) []byte {
 		if titleStart != -1 {
 			titleEnd := strings.Index(htmlStr[titleStart:], "</title>") + titleStart + 8 // +8 for </title>
 			if titleEnd > titleStart {
-				htmlStr = htmlStr[:titleStart] + "<title>" + meta.Title + "</title>" + htmlStr[titleEnd:]
+				htmlStr = htmlStr[:titleStart] + "<title>" + html.EscapeString(meta.Title) + "</title>" + htmlStr[titleEnd:]
 			}
 		}
 	}
@@ -258,7 +361,7 @@ func (h *SPAHandler) injectMetaTags(html []byte, meta PageMeta) []byte {
 			// Find the end of the existing description tag
 			descEnd := strings.Index(htmlStr[descStart:], ">") + descStart + 1
 			if descEnd > descStart {
-				newDesc := fmt.Sprintf(`<meta name="description" content="%s">`, meta.Description)
+				newDesc := fmt.Sprintf(`<meta name="description" content="%s">`, html.EscapeString(meta.Description))
 				htmlStr = htmlStr[:descStart] + newDesc + htmlStr[descEnd:]
 			}
 		}
@@ -267,7 +370,7 @@ func (h *SPAHandler) injectMetaTags(html []byte, meta PageMeta) []byte {
 	// Find the position after <meta charset="UTF-8" /> to inject our meta tags
 	charsetPos := strings.Index(htmlStr, `<meta charset="UTF-8"`)
 	if charsetPos == -1 {
-		return html // If charset meta not found, return original
+		return page // If charset meta not found, return original
 	}
 
 	// Find the end of the charset meta tag (looking for />)
@@ -279,15 +382,15 @@ func (h *SPAHandler) injectMetaTags(html []byte, meta PageMeta) []byte {
 
 	// Open Graph tags
 	if meta.OGTitle != "" {
-		metaTags.WriteString(fmt.Sprintf(`<meta property="og:title" content="%s">`, meta.OGTitle))
+		metaTags.WriteString(fmt.Sprintf(`<meta property="og:title" content="%s">`, html.EscapeString(meta.OGTitle)))
 		metaTags.WriteString("\n    ")
 	}
 	if meta.OGDescription != "" {
-		metaTags.WriteString(fmt.Sprintf(`<meta property="og:description" content="%s">`, meta.OGDescription))
+		metaTags.WriteString(fmt.Sprintf(`<meta property="og:description" content="%s">`, html.EscapeString(meta.OGDescription)))
 		metaTags.WriteString("\n    ")
 	}
 	if meta.OGImage != "" {
-		metaTags.WriteString(fmt.Sprintf(`<meta property="og:image" content="%s">`, meta.OGImage))
+		metaTags.WriteString(fmt.Sprintf(`<meta property="og:image" content="%s">`, html.EscapeString(meta.OGImage)))
 		metaTags.WriteString("\n    ")
 	}
 	metaTags.WriteString(`<meta property="og:type" content="website">`)
@@ -297,21 +400,21 @@ func (h *SPAHandler) injectMetaTags(html []byte, meta PageMeta) []byte {
 	metaTags.WriteString(`<meta name="twitter:card" content="summary_large_image">`)
 	metaTags.WriteString("\n    ")
 	if meta.OGTitle != "" {
-		metaTags.WriteString(fmt.Sprintf(`<meta name="twitter:title" content="%s">`, meta.OGTitle))
+		metaTags.WriteString(fmt.Sprintf(`<meta name="twitter:title" content="%s">`, html.EscapeString(meta.OGTitle)))
 		metaTags.WriteString("\n    ")
 	}
 	if meta.OGDescription != "" {
-		metaTags.WriteString(fmt.Sprintf(`<meta name="twitter:description" content="%s">`, meta.OGDescription))
+		metaTags.WriteString(fmt.Sprintf(`<meta name="twitter:description" content="%s">`, html.EscapeString(meta.OGDescription)))
 		metaTags.WriteString("\n    ")
 	}
 	if meta.OGImage != "" {
-		metaTags.WriteString(fmt.Sprintf(`<meta name="twitter:image" content="%s">`, meta.OGImage))
+		metaTags.WriteString(fmt.Sprintf(`<meta name="twitter:image" content="%s">`, html.EscapeString(meta.OGImage)))
 		metaTags.WriteString("\n    ")
 	}
 
 	// Canonical URL
 	if meta.Canonical != "" {
-		metaTags.WriteString(fmt.Sprintf(`<link rel="canonical" href="%s">`, meta.Canonical))
+		metaTags.WriteString(fmt.Sprintf(`<link rel="canonical" href="%s">`, html.EscapeString(meta.Canonical)))
 		metaTags.WriteString("\n    ")
 	}
 
@@ -348,7 +451,7 @@ func (h *SPAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	_, err := fs.Stat(h.fileSystem, fsPath)
 	if err != nil || fsPath == "index.html" {
 		// File doesn't exist OR it's index.html - serve with injected meta tags for SEO
-		meta := h.getMetaForRoute(path)
+		meta := h.getMetaForRoute(r.Context(), path)
 		injectedHTML := h.injectMetaTags(h.indexHTML, meta)
 
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")